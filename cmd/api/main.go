@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -105,8 +107,14 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Parse log level from configuration
-	logLevel := parseLogLevel(cfg.Log.Level)
+	// "config validate" checks the configuration and exits without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(cfg, os.Args[2:]))
+	}
+
+	// Parse log level from configuration; SIGHUP reloads it
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(config.ParseLogLevel(cfg.Log.Level))
 
 	// Initialize structured logger with configured level
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -114,6 +122,10 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go config.WatchLogLevel(watchCtx, logLevel, logger)
+
 	// Validate production configuration
 	// This prevents the application from starting with insecure development settings
 	// in production environments. Checks for weak passwords, localhost URLs, disabled SSL, etc.
@@ -134,9 +146,10 @@ func main() {
 
 	if cfg.Observability.TracingEnabled {
 		slog.Info("distributed tracing enabled",
-			"endpoint", cfg.Observability.TracingEndpoint,
+			"exporters", tracing.ExporterNames(&cfg.Observability),
 			"service_name", cfg.Observability.TracingServiceName,
 			"sample_rate", cfg.Observability.TracingSampleRate,
+			"signal_sample_rates", cfg.Observability.TracingSampleRates,
 		)
 	}
 
@@ -184,19 +197,29 @@ func main() {
 	slog.Info("server stopped")
 }
 
-// parseLogLevel converts string log level to slog.Level
-func parseLogLevel(level string) slog.Level {
-	switch level {
-	case "debug":
-		return slog.LevelDebug
-	case "info":
-		return slog.LevelInfo
-	case "warn":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
-	default:
-		// Default to info if invalid level specified
-		return slog.LevelInfo
+// runConfigCommand runs a "config" subcommand and returns the exit code.
+// "config validate" applies the production checks to the loaded configuration
+// and lists every problem, so CI can reject a bad deployment before rollout.
+func runConfigCommand(cfg *config.Config, args []string) int {
+	if len(args) != 1 || args[0] != "validate" {
+		fmt.Fprintln(os.Stderr, "usage: api config validate")
+		return 2
+	}
+
+	err := config.ValidateForProduction(cfg)
+	if err == nil {
+		fmt.Println("configuration is valid")
+		return 0
+	}
+
+	var validationErrs config.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "configuration has %d problem(s):\n", len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fmt.Fprintf(os.Stderr, "  %s\n", fieldErr)
 	}
+	return 1
 }
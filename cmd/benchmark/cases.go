@@ -0,0 +1,126 @@
+//go:build legacymetrics
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// benchEnv is the dataset and repository shared by all cases
+type benchEnv struct {
+	db        *sqlx.DB
+	repo      *workflow.Repository
+	tenantID  string
+	startDate time.Time
+	endDate   time.Time
+}
+
+// benchmarkCases returns the registered cases in the order they run. A case
+// with a Baseline must come after the case it is compared against.
+func benchmarkCases(env *benchEnv) []benchmarkCase {
+	return []benchmarkCase{
+		{
+			Name:        "GetTopFailuresCorrelated",
+			Description: "top failures, correlated subquery (deprecated)",
+			Run: func(ctx context.Context) error {
+				_, err := env.repo.GetTopFailuresCorrelated(ctx, env.tenantID, env.startDate, env.endDate, 10)
+				return err
+			},
+			Explain: explainAnalyze(env.db, topFailuresCorrelatedQuery, env.tenantID, env.startDate, env.endDate),
+		},
+		{
+			Name:        "GetTopFailures",
+			Description: "top failures, LATERAL join",
+			Run: func(ctx context.Context) error {
+				_, err := env.repo.GetTopFailures(ctx, env.tenantID, env.startDate, env.endDate, workflow.TopFailuresOptions{Limit: 10})
+				return err
+			},
+			Baseline: "GetTopFailuresCorrelated",
+			Explain:  explainAnalyze(env.db, topFailuresLateralQuery, env.tenantID, env.startDate, env.endDate),
+		},
+		{
+			Name:        "GetExecutionStats",
+			Description: "tenant-wide success rate and duration percentiles",
+			Run: func(ctx context.Context) error {
+				_, err := env.repo.GetExecutionStats(ctx, env.tenantID, env.startDate, env.endDate)
+				return err
+			},
+		},
+		{
+			Name:        "GetExecutionStatsByWorkflow",
+			Description: "per-workflow success rate and duration percentiles",
+			Run: func(ctx context.Context) error {
+				_, err := env.repo.GetExecutionStatsByWorkflow(ctx, env.tenantID, env.startDate, env.endDate)
+				return err
+			},
+		},
+	}
+}
+
+// topFailuresCorrelatedQuery mirrors GetTopFailuresCorrelated
+const topFailuresCorrelatedQuery = `
+	SELECT
+		e.workflow_id,
+		w.name as workflow_name,
+		COUNT(*) as failure_count,
+		MAX(e.completed_at) as last_failed_at,
+		(
+			SELECT error_message
+			FROM executions
+			WHERE workflow_id = e.workflow_id
+				AND status = 'failed'
+				AND error_message IS NOT NULL
+			ORDER BY completed_at DESC
+			LIMIT 1
+		) as error_preview
+	FROM executions e
+	INNER JOIN workflows w ON e.workflow_id = w.id
+	WHERE e.tenant_id = $1
+		AND e.created_at >= $2
+		AND e.created_at < $3
+		AND e.status = 'failed'
+	GROUP BY e.workflow_id, w.name
+	ORDER BY failure_count DESC
+	LIMIT 10
+`
+
+// topFailuresLateralQuery mirrors GetTopFailures
+const topFailuresLateralQuery = `
+	WITH failures AS (
+		SELECT
+			e.workflow_id,
+			COUNT(*) as failure_count,
+			MAX(e.completed_at) as last_failed_at
+		FROM executions e
+		WHERE e.tenant_id = $1
+			AND e.created_at >= $2
+			AND e.created_at < $3
+			AND e.status = 'failed'
+		GROUP BY e.workflow_id
+	)
+	SELECT
+		f.workflow_id,
+		w.name as workflow_name,
+		f.failure_count,
+		f.last_failed_at,
+		latest.error_message as error_preview
+	FROM failures f
+	INNER JOIN workflows w ON f.workflow_id = w.id
+	LEFT JOIN LATERAL (
+		SELECT error_message
+		FROM executions
+		WHERE workflow_id = f.workflow_id
+			AND tenant_id = $1
+			AND status = 'failed'
+			AND error_message IS NOT NULL
+		ORDER BY completed_at DESC NULLS LAST
+		LIMIT 1
+	) latest ON true
+	ORDER BY f.failure_count DESC, f.workflow_id
+	LIMIT 10
+`
@@ -0,0 +1,199 @@
+//go:build legacymetrics
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// benchmarkCase is a single timed operation. Only Name and Run are required.
+type benchmarkCase struct {
+	// Name identifies the case in logs, JSON output and -run filters
+	Name string
+	// Description is logged before the case runs
+	Description string
+	// Setup runs once before the case is warmed up and timed
+	Setup func(ctx context.Context) error
+	// Run is the operation being timed
+	Run func(ctx context.Context) error
+	// Iterations overrides -iterations for this case when positive
+	Iterations int
+	// Baseline names an earlier case this one is compared against; the
+	// speedup is checked against -fail-under
+	Baseline string
+	// Explain returns the query plan lines logged for this case
+	Explain func(ctx context.Context) ([]string, error)
+}
+
+// queryResult is the timing of one case over all iterations
+type queryResult struct {
+	Name       string  `json:"name"`
+	Iterations int     `json:"iterations"`
+	AvgMs      float64 `json:"avg_ms"`
+	MinMs      float64 `json:"min_ms"`
+	MaxMs      float64 `json:"max_ms"`
+	P95Ms      float64 `json:"p95_ms"`
+}
+
+// comparison is a case's speedup over its baseline
+type comparison struct {
+	Baseline    string  `json:"baseline"`
+	Candidate   string  `json:"candidate"`
+	Speedup     float64 `json:"speedup"`
+	Improvement float64 `json:"improvement_percent"`
+	Passed      bool    `json:"passed"`
+}
+
+// harness runs benchmark cases uniformly and collects their results
+type harness struct {
+	iterations int
+	failUnder  float64
+	results    map[string]queryResult
+}
+
+// newHarness creates a harness with the default iteration count and
+// regression threshold (0 disables the threshold)
+func newHarness(iterations int, failUnder float64) *harness {
+	return &harness{
+		iterations: iterations,
+		failUnder:  failUnder,
+		results:    make(map[string]queryResult),
+	}
+}
+
+// runCase sets up, warms up and times c
+func (h *harness) runCase(ctx context.Context, c benchmarkCase) (queryResult, error) {
+	log.Printf("\nBenchmarking %s", c.Name)
+	if c.Description != "" {
+		log.Printf("  %s", c.Description)
+	}
+
+	if c.Setup != nil {
+		if err := c.Setup(ctx); err != nil {
+			return queryResult{}, fmt.Errorf("%s setup failed: %w", c.Name, err)
+		}
+	}
+
+	// Warm up
+	_ = c.Run(ctx)
+
+	iterations := h.iterations
+	if c.Iterations > 0 {
+		iterations = c.Iterations
+	}
+	result, err := timeQuery(ctx, c, iterations)
+	if err != nil {
+		return queryResult{}, err
+	}
+	h.results[c.Name] = result
+	logQueryResult(result)
+	return result, nil
+}
+
+// compare computes c's speedup over its baseline. ok is false when c has no
+// baseline or the baseline was not run.
+func (h *harness) compare(c benchmarkCase) (comparison, bool) {
+	if c.Baseline == "" {
+		return comparison{}, false
+	}
+	baseline, ok := h.results[c.Baseline]
+	if !ok {
+		return comparison{}, false
+	}
+	candidate := h.results[c.Name]
+
+	cmp := comparison{
+		Baseline:    c.Baseline,
+		Candidate:   c.Name,
+		Speedup:     baseline.AvgMs / candidate.AvgMs,
+		Improvement: (baseline.AvgMs - candidate.AvgMs) / baseline.AvgMs * 100,
+	}
+	cmp.Passed = h.failUnder == 0 || cmp.Speedup >= h.failUnder
+	return cmp, true
+}
+
+// timeQuery runs c iterations times and summarizes the latencies
+func timeQuery(ctx context.Context, c benchmarkCase, iterations int) (queryResult, error) {
+	durations := make([]time.Duration, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		start := time.Now()
+		if err := c.Run(ctx); err != nil {
+			return queryResult{}, fmt.Errorf("%s failed: %w", c.Name, err)
+		}
+		durations = append(durations, time.Since(start))
+	}
+	return summarize(c.Name, durations), nil
+}
+
+// summarize computes avg/min/max/p95 of durations in milliseconds
+func summarize(name string, durations []time.Duration) queryResult {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	// Nearest-rank percentile
+	p95Index := (len(sorted)*95+99)/100 - 1
+
+	return queryResult{
+		Name:       name,
+		Iterations: len(sorted),
+		AvgMs:      milliseconds(total / time.Duration(len(sorted))),
+		MinMs:      milliseconds(sorted[0]),
+		MaxMs:      milliseconds(sorted[len(sorted)-1]),
+		P95Ms:      milliseconds(sorted[p95Index]),
+	}
+}
+
+// milliseconds converts d to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// logQueryResult logs a case's latency summary
+func logQueryResult(r queryResult) {
+	log.Printf("Average: %.3fms  min: %.3fms  max: %.3fms  p95: %.3fms (%d iterations)\n",
+		r.AvgMs, r.MinMs, r.MaxMs, r.P95Ms, r.Iterations)
+}
+
+// logComparison logs a case's speedup over its baseline
+func logComparison(cmp comparison) {
+	separator := "========================================"
+	log.Println("\n" + separator)
+	log.Printf("%s vs %s\n", cmp.Candidate, cmp.Baseline)
+	log.Println(separator)
+	log.Printf("Improvement:         %.2f%%\n", cmp.Improvement)
+	log.Printf("Speedup:             %.2fx faster\n", cmp.Speedup)
+	log.Println(separator)
+}
+
+// logExplain logs a case's query plan
+func logExplain(ctx context.Context, c benchmarkCase) {
+	log.Printf("\n--- %s ---", c.Name)
+	plan, err := c.Explain(ctx)
+	if err != nil {
+		log.Printf("Failed to get query plan: %v", err)
+		return
+	}
+	for _, line := range plan {
+		log.Println(line)
+	}
+}
+
+// explainAnalyze returns an Explain func running EXPLAIN ANALYZE on query
+func explainAnalyze(db *sqlx.DB, query string, args ...interface{}) func(ctx context.Context) ([]string, error) {
+	return func(ctx context.Context) ([]string, error) {
+		var plan []string
+		err := db.SelectContext(ctx, &plan, "EXPLAIN ANALYZE "+query, args...)
+		return plan, err
+	}
+}
@@ -1,9 +1,28 @@
+//go:build legacymetrics
+
+// Command benchmark times analytics repository queries against a generated
+// dataset and compares the deprecated correlated-subquery GetTopFailures
+// implementation with the current LATERAL join one. Run it with:
+//
+//	go run -tags legacymetrics ./cmd/benchmark
+//
+// Cases are registered in benchmarkCases; -run selects them by name. In CI,
+// -json writes the results to stdout as a single JSON document instead of
+// printing query plans, and -fail-under exits non-zero when a case's speedup
+// over its baseline drops below the given factor:
+//
+//	go run -tags legacymetrics ./cmd/benchmark -json -fail-under 2.0 > benchmark.json
 package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -12,15 +31,92 @@ import (
 	"github.com/gorax/gorax/internal/workflow"
 )
 
+// options holds the command line flags
+type options struct {
+	workflows   int
+	executions  int
+	failureRate float64
+	iterations  int
+	run         string
+	jsonOutput  bool
+	failUnder   float64
+}
+
+// benchmarkResult is the machine-readable output of a run
+type benchmarkResult struct {
+	Timestamp   time.Time     `json:"timestamp"`
+	Workflows   int           `json:"workflows"`
+	Executions  int           `json:"executions_per_workflow"`
+	FailureRate float64       `json:"failure_rate"`
+	TotalRows   int           `json:"total_executions"`
+	FailedRows  int           `json:"failed_executions"`
+	Queries     []queryResult `json:"queries"`
+	Comparisons []comparison  `json:"comparisons"`
+	FailUnder   float64       `json:"fail_under,omitempty"`
+	Passed      bool          `json:"passed"`
+}
+
+// errRegression is returned when a speedup falls below -fail-under
+var errRegression = errors.New("speedup regressed below threshold")
+
 func main() {
+	var opts options
+	flag.IntVar(&opts.workflows, "workflows", 100, "number of workflows to generate")
+	flag.IntVar(&opts.executions, "executions", 100, "number of executions to generate per workflow")
+	flag.Float64Var(&opts.failureRate, "failure-rate", 0.30, "fraction of generated executions that fail (0-1)")
+	flag.IntVar(&opts.iterations, "iterations", 100, "number of timed runs of each case")
+	flag.StringVar(&opts.run, "run", "", "only run cases whose name matches this regular expression")
+	flag.BoolVar(&opts.jsonOutput, "json", false, "write results to stdout as JSON")
+	flag.Float64Var(&opts.failUnder, "fail-under", 0, "exit non-zero if a speedup is below this factor (0 disables)")
+	flag.Parse()
+
+	if err := opts.validate(); err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+
+	if err := run(opts); err != nil {
+		if errors.Is(err, errRegression) {
+			log.Printf("FAIL: %v", err)
+			os.Exit(1)
+		}
+		log.Fatal(err)
+	}
+}
+
+// validate checks the flag values
+func (o options) validate() error {
+	if o.workflows <= 0 {
+		return errors.New("-workflows must be positive")
+	}
+	if o.executions <= 0 {
+		return errors.New("-executions must be positive")
+	}
+	if o.failureRate < 0 || o.failureRate > 1 {
+		return errors.New("-failure-rate must be between 0 and 1")
+	}
+	if o.iterations <= 0 {
+		return errors.New("-iterations must be positive")
+	}
+	if o.failUnder < 0 {
+		return errors.New("-fail-under must not be negative")
+	}
+	if _, err := regexp.Compile(o.run); err != nil {
+		return fmt.Errorf("-run: %w", err)
+	}
+	return nil
+}
+
+// run generates the dataset, runs the selected cases and reports the results.
+// Human-readable progress is logged to stderr so stdout only carries JSON.
+func run(opts options) error {
 	dbURL := os.Getenv("TEST_DATABASE_URL")
 	if dbURL == "" {
-		log.Fatal("TEST_DATABASE_URL environment variable must be set")
+		return errors.New("TEST_DATABASE_URL environment variable must be set")
 	}
 
 	db, err := sqlx.Connect("postgres", dbURL)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 	defer db.Close()
 
@@ -28,13 +124,14 @@ func main() {
 
 	// Generate benchmark data
 	log.Println("Generating benchmark data...")
-	log.Println("Creating 100 workflows with 100 executions each (30% failure rate)...")
+	log.Printf("Creating %d workflows with %d executions each (%.0f%% failure rate)...\n",
+		opts.workflows, opts.executions, opts.failureRate*100)
 	generator := workflow.NewBenchmarkDataGenerator(db)
 	defer generator.Cleanup(ctx)
 
-	err = generator.GenerateFullDataset(ctx, 100, 100, 0.30) // 100 workflows, 100 executions each, 30% failure rate
+	err = generator.GenerateFullDataset(ctx, opts.workflows, opts.executions, opts.failureRate)
 	if err != nil {
-		log.Fatalf("Failed to generate data: %v", err)
+		return fmt.Errorf("failed to generate data: %w", err)
 	}
 
 	// Get total execution count
@@ -47,136 +144,76 @@ func main() {
 	var tenantID string
 	err = db.GetContext(ctx, &tenantID, "SELECT id FROM tenants WHERE name = 'Benchmark Tenant' LIMIT 1")
 	if err != nil {
-		log.Fatalf("Failed to get tenant ID: %v", err)
+		return fmt.Errorf("failed to get tenant ID: %w", err)
 	}
 
-	// Run benchmarks
-	repo := workflow.NewRepository(db)
-	startDate := time.Now().Add(-24 * time.Hour)
-	endDate := time.Now().Add(24 * time.Hour)
-
-	// Warm up
-	log.Println("Warming up...")
-	_, _ = repo.GetTopFailures(ctx, tenantID, startDate, endDate, 10)
-	_, _ = repo.GetTopFailuresOptimized(ctx, tenantID, startDate, endDate, 10)
+	env := &benchEnv{
+		db:        db,
+		repo:      workflow.NewRepository(db),
+		tenantID:  tenantID,
+		startDate: time.Now().Add(-24 * time.Hour),
+		endDate:   time.Now().Add(24 * time.Hour),
+	}
 
-	// Benchmark GetTopFailures (old)
-	log.Println("\nBenchmarking GetTopFailures (correlated subquery)...")
-	iterations := 100
-	start := time.Now()
-	for i := 0; i < iterations; i++ {
-		_, err := repo.GetTopFailures(ctx, tenantID, startDate, endDate, 10)
-		if err != nil {
-			log.Fatalf("GetTopFailures failed: %v", err)
+	filter := regexp.MustCompile(opts.run)
+	var cases []benchmarkCase
+	for _, c := range benchmarkCases(env) {
+		if filter.MatchString(c.Name) {
+			cases = append(cases, c)
 		}
 	}
-	oldDuration := time.Since(start)
-	oldAvg := oldDuration / time.Duration(iterations)
-	log.Printf("Average: %v (total: %v for %d iterations)\n", oldAvg, oldDuration, iterations)
+	if len(cases) == 0 {
+		return fmt.Errorf("no benchmark cases match -run %q", opts.run)
+	}
 
-	// Benchmark GetTopFailuresOptimized (new)
-	log.Println("\nBenchmarking GetTopFailuresOptimized (LATERAL join)...")
-	start = time.Now()
-	for i := 0; i < iterations; i++ {
-		_, err := repo.GetTopFailuresOptimized(ctx, tenantID, startDate, endDate, 10)
+	h := newHarness(opts.iterations, opts.failUnder)
+	result := benchmarkResult{
+		Timestamp:   time.Now().UTC(),
+		Workflows:   opts.workflows,
+		Executions:  opts.executions,
+		FailureRate: opts.failureRate,
+		TotalRows:   totalExec,
+		FailedRows:  failedExec,
+		Comparisons: []comparison{},
+		FailUnder:   opts.failUnder,
+		Passed:      true,
+	}
+	for _, c := range cases {
+		timing, err := h.runCase(ctx, c)
 		if err != nil {
-			log.Fatalf("GetTopFailuresOptimized failed: %v", err)
+			return err
+		}
+		result.Queries = append(result.Queries, timing)
+
+		if cmp, ok := h.compare(c); ok {
+			logComparison(cmp)
+			result.Comparisons = append(result.Comparisons, cmp)
+			result.Passed = result.Passed && cmp.Passed
 		}
 	}
-	newDuration := time.Since(start)
-	newAvg := newDuration / time.Duration(iterations)
-	log.Printf("Average: %v (total: %v for %d iterations)\n", newAvg, newDuration, iterations)
-
-	// Calculate improvement
-	improvement := float64(oldDuration-newDuration) / float64(oldDuration) * 100
-	speedup := float64(oldDuration) / float64(newDuration)
-
-	log.Println("\n" + string([]byte{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61}))
-	log.Println("RESULTS SUMMARY")
-	log.Println(string([]byte{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61}))
-	log.Printf("Old Implementation:  %v per query\n", oldAvg)
-	log.Printf("New Implementation:  %v per query\n", newAvg)
-	log.Printf("Improvement:         %.2f%%\n", improvement)
-	log.Printf("Speedup:             %.2fx faster\n", speedup)
-	log.Println(string([]byte{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61}))
-
-	// Get EXPLAIN ANALYZE for both queries
-	log.Println("\nGenerating EXPLAIN ANALYZE plans...")
-
-	// Old query
-	log.Println("\n--- Old Implementation (Correlated Subquery) ---")
-	var oldPlan []string
-	err = db.SelectContext(ctx, &oldPlan, `
-		EXPLAIN ANALYZE
-		SELECT
-			e.workflow_id,
-			w.name as workflow_name,
-			COUNT(*) as failure_count,
-			MAX(e.completed_at) as last_failed_at,
-			(
-				SELECT error_message
-				FROM executions
-				WHERE workflow_id = e.workflow_id
-					AND status = 'failed'
-					AND error_message IS NOT NULL
-				ORDER BY completed_at DESC
-				LIMIT 1
-			) as error_preview
-		FROM executions e
-		INNER JOIN workflows w ON e.workflow_id = w.id
-		WHERE e.tenant_id = $1
-			AND e.created_at >= $2
-			AND e.created_at < $3
-			AND e.status = 'failed'
-		GROUP BY e.workflow_id, w.name
-		ORDER BY failure_count DESC
-		LIMIT 10
-	`, tenantID, startDate, endDate)
-	if err != nil {
-		log.Printf("Failed to get old query plan: %v", err)
+
+	if opts.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to write JSON results: %w", err)
+		}
 	} else {
-		for _, line := range oldPlan {
-			log.Println(line)
+		log.Println("\nGenerating EXPLAIN ANALYZE plans...")
+		for _, c := range cases {
+			if c.Explain != nil {
+				logExplain(ctx, c)
+			}
 		}
 	}
 
-	// New query
-	log.Println("\n--- New Implementation (LATERAL Join) ---")
-	var newPlan []string
-	err = db.SelectContext(ctx, &newPlan, `
-		EXPLAIN ANALYZE
-		SELECT
-			e.workflow_id,
-			w.name as workflow_name,
-			COUNT(*) as failure_count,
-			MAX(e.completed_at) as last_failed_at,
-			latest.error_message as error_preview
-		FROM executions e
-		INNER JOIN workflows w ON e.workflow_id = w.id
-		CROSS JOIN LATERAL (
-			SELECT error_message
-			FROM executions
-			WHERE workflow_id = e.workflow_id
-				AND status = 'failed'
-				AND error_message IS NOT NULL
-			ORDER BY completed_at DESC
-			LIMIT 1
-		) latest
-		WHERE e.tenant_id = $1
-			AND e.created_at >= $2
-			AND e.created_at < $3
-			AND e.status = 'failed'
-		GROUP BY e.workflow_id, w.name, latest.error_message
-		ORDER BY failure_count DESC
-		LIMIT 10
-	`, tenantID, startDate, endDate)
-	if err != nil {
-		log.Printf("Failed to get new query plan: %v", err)
-	} else {
-		for _, line := range newPlan {
-			log.Println(line)
+	for _, cmp := range result.Comparisons {
+		if !cmp.Passed {
+			return fmt.Errorf("%w: %s is %.2fx faster than %s, want at least %.2fx",
+				errRegression, cmp.Candidate, cmp.Speedup, cmp.Baseline, opts.failUnder)
 		}
 	}
 
 	log.Println("\nBenchmark complete!")
+	return nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
@@ -10,7 +11,8 @@ import (
 	"sort"
 	"strings"
 
-	_ "github.com/lib/pq"
+	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/database"
 )
 
 func main() {
@@ -38,18 +40,20 @@ func main() {
 		command = "down"
 	}
 
+	// Read connection settings from the URL; DB_AUTH_MODE=iam replaces the
+	// password with an IAM auth token
+	dbConfig, err := config.LoadDatabaseConfig(databaseURL)
+	if err != nil {
+		log.Fatalf("Invalid database configuration: %v", err)
+	}
+
 	// Connect to database
-	db, err := sql.Open("postgres", databaseURL)
+	db, err := database.OpenDB(context.Background(), dbConfig)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
 
-	// Test connection
-	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
-	}
-
 	// Create migrations table if it doesn't exist
 	if err := createMigrationsTable(db); err != nil {
 		log.Fatalf("Failed to create migrations table: %v", err)
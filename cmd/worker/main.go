@@ -9,11 +9,14 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
-
 	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/database"
+	"github.com/gorax/gorax/internal/nodecache"
+	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/residency"
 	"github.com/gorax/gorax/internal/schedule"
+	"github.com/gorax/gorax/internal/tenant"
 	"github.com/gorax/gorax/internal/tracing"
 	"github.com/gorax/gorax/internal/webhook"
 	"github.com/gorax/gorax/internal/worker"
@@ -21,19 +24,21 @@ import (
 )
 
 func main() {
-	// Initialize structured logger
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
-	slog.SetDefault(logger)
-
-	// Load configuration
+	// Load configuration first (we need it to configure logging)
 	cfg, err := config.Load()
 	if err != nil {
 		slog.Error("failed to load configuration", "error", err)
 		os.Exit(1)
 	}
 
+	// Initialize structured logger with configured level; SIGHUP reloads it
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(config.ParseLogLevel(cfg.Log.Level))
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: logLevel,
+	}))
+	slog.SetDefault(logger)
+
 	// Initialize tracing
 	tracingCleanup, err := tracing.InitGlobalTracer(context.Background(), &cfg.Observability)
 	if err != nil {
@@ -44,9 +49,10 @@ func main() {
 
 	if cfg.Observability.TracingEnabled {
 		slog.Info("distributed tracing enabled",
-			"endpoint", cfg.Observability.TracingEndpoint,
+			"exporters", tracing.ExporterNames(&cfg.Observability),
 			"service_name", cfg.Observability.TracingServiceName,
 			"sample_rate", cfg.Observability.TracingSampleRate,
+			"signal_sample_rates", cfg.Observability.TracingSampleRates,
 		)
 	}
 
@@ -54,8 +60,10 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	go config.WatchLogLevel(ctx, logLevel, logger)
+
 	// Initialize database connection for scheduler
-	db, err := sqlx.Connect("postgres", cfg.Database.ConnectionString())
+	db, err := database.Connect(ctx, cfg.Database)
 	if err != nil {
 		slog.Error("failed to connect to database", "error", err)
 		os.Exit(1)
@@ -66,6 +74,20 @@ func main() {
 	workflowRepo := workflow.NewRepository(db)
 	scheduleRepo := schedule.NewRepository(db)
 
+	// Scheduled executions of tenants pinned to a data region are stored in
+	// that region's database
+	if len(cfg.DataResidency.Regions) > 0 {
+		regionDBs, err := residency.ConnectDatabases(ctx, cfg.DataResidency.Regions)
+		if err != nil {
+			slog.Error("failed to connect to data region databases", "error", err)
+			os.Exit(1)
+		}
+		defer residency.CloseDatabases(regionDBs)
+
+		resolver := residency.NewResolver(tenant.NewRepository(db), residency.DefaultRegionCacheTTL)
+		workflowRepo.SetExecutionDBRouter(residency.NewRouter(resolver, residency.ExecutionStore, db, regionDBs))
+	}
+
 	// Initialize workflow service
 	workflowService := workflow.NewService(workflowRepo, logger)
 
@@ -97,6 +119,32 @@ func main() {
 		cleanupScheduler = webhook.NewCleanupScheduler(cleanupService, cfg.Cleanup.Schedule, logger)
 	}
 
+	// Initialize webhook redelivery if enabled
+	var retryWorker *webhook.RetryWorker
+	if cfg.WebhookRetry.Enabled {
+		deliverer := webhook.NewWorkflowDeliverer(&workflowExecutorAdapter{workflowService: workflowService})
+		retryConfig := webhook.RedeliveryRetryConfig(cfg.WebhookRetry.BaseDelay, cfg.WebhookRetry.MaxDelay)
+		retryWorker = webhook.NewRetryWorker(webhook.NewRepository(db), deliverer, retryConfig).WithLogger(logger)
+	}
+
+	// Initialize OAuth state cleanup if enabled
+	var stateCleaner *oauth.StateCleaner
+	if cfg.OAuthCleanup.Enabled {
+		stateCleaner = oauth.NewStateCleaner(oauth.NewRepository(db), cfg.OAuthCleanup.UsedGracePeriod, logger)
+	}
+
+	// Initialize purge of deleted credentials past their restore window
+	var credentialPurger *credential.Purger
+	if cfg.Credential.PurgeInterval > 0 {
+		credentialPurger = credential.NewPurger(credential.NewRepository(db), cfg.Credential.DeletedRetention, logger)
+	}
+
+	// Initialize removal of expired cached node results
+	var nodeCacheCleaner *nodecache.Cleaner
+	if cfg.Workflow.NodeCacheCleanupInterval > 0 {
+		nodeCacheCleaner = nodecache.NewCleaner(nodecache.NewRepository(db), logger)
+	}
+
 	// Initialize worker
 	w, err := worker.New(cfg, logger)
 	if err != nil {
@@ -140,6 +188,42 @@ func main() {
 		}()
 	}
 
+	// Start webhook retry worker if enabled
+	if retryWorker != nil {
+		go func() {
+			if err := retryWorker.Start(ctx, cfg.WebhookRetry.Interval); err != nil && err != context.Canceled {
+				slog.Error("webhook retry worker error", "error", err)
+			}
+		}()
+	}
+
+	// Start OAuth state cleanup if enabled
+	if stateCleaner != nil {
+		go func() {
+			if err := stateCleaner.Start(ctx, cfg.OAuthCleanup.Interval); err != nil && err != context.Canceled {
+				slog.Error("OAuth state cleanup error", "error", err)
+			}
+		}()
+	}
+
+	// Start deleted credential purge if enabled
+	if credentialPurger != nil {
+		go func() {
+			if err := credentialPurger.Start(ctx, cfg.Credential.PurgeInterval); err != nil && err != context.Canceled {
+				slog.Error("deleted credential purge error", "error", err)
+			}
+		}()
+	}
+
+	// Start node cache cleanup if enabled
+	if nodeCacheCleaner != nil {
+		go func() {
+			if err := nodeCacheCleaner.Start(ctx, cfg.Workflow.NodeCacheCleanupInterval); err != nil && err != context.Canceled {
+				slog.Error("node cache cleanup error", "error", err)
+			}
+		}()
+	}
+
 	// Start worker in goroutine
 	go func() {
 		slog.Info("starting workflow worker", "concurrency", cfg.Worker.Concurrency)
@@ -165,8 +249,8 @@ func main() {
 		cleanupScheduler.Stop()
 	}
 
-	// Wait for worker to finish current jobs
-	w.Wait()
+	// Wait for current jobs, checkpointing any still running after the drain timeout
+	w.Drain(cfg.Worker.DrainTimeout)
 
 	slog.Info("worker, scheduler, and cleanup scheduler stopped")
 }
@@ -179,3 +263,16 @@ type workflowServiceAdapter struct {
 func (w *workflowServiceAdapter) GetByID(ctx context.Context, tenantID, id string) (interface{}, error) {
 	return w.workflowService.GetByID(ctx, tenantID, id)
 }
+
+// workflowExecutorAdapter adapts workflow.Service to webhook.WorkflowExecutor interface
+type workflowExecutorAdapter struct {
+	workflowService *workflow.Service
+}
+
+func (w *workflowExecutorAdapter) Execute(ctx context.Context, tenantID, workflowID, triggerType string, triggerData []byte) (string, error) {
+	execution, err := w.workflowService.Execute(ctx, tenantID, workflowID, triggerType, triggerData)
+	if err != nil {
+		return "", err
+	}
+	return execution.ID, nil
+}
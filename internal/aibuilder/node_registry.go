@@ -246,6 +246,7 @@ func DefaultNodeRegistry() *NodeRegistry {
 			"type": "object",
 			"properties": map[string]interface{}{
 				"cron":     map[string]interface{}{"type": "string"},
+				"interval": map[string]interface{}{"type": "string"},
 				"timezone": map[string]interface{}{"type": "string"},
 			},
 		},
@@ -254,7 +255,8 @@ func DefaultNodeRegistry() *NodeRegistry {
 			"timezone": "America/New_York",
 		},
 		LLMDescription: "Use this to run a workflow automatically at scheduled times. " +
-			"Specify a cron expression (e.g., '0 9 * * 1-5' for 9am weekdays) and optional timezone.",
+			"Specify a cron expression (e.g., '0 9 * * 1-5' for 9am weekdays) and optional timezone, " +
+			"or an interval (e.g., '30s', '5m') instead of cron for fixed-frequency runs.",
 		IsActive: true,
 	})
 
@@ -294,8 +296,9 @@ func DefaultNodeRegistry() *NodeRegistry {
 		ConfigSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
-				"expression": map[string]interface{}{"type": "string"},
-				"mapping":    map[string]interface{}{"type": "object"},
+				"transform_type": map[string]interface{}{"type": "string", "enum": []string{"mapping", "expr"}},
+				"expression":     map[string]interface{}{"type": "string"},
+				"mapping":        map[string]interface{}{"type": "object"},
 			},
 		},
 		ExampleConfig: map[string]interface{}{
@@ -305,7 +308,9 @@ func DefaultNodeRegistry() *NodeRegistry {
 			},
 		},
 		LLMDescription: "Use this to reshape, filter, or combine data. Create mappings to extract and rename fields " +
-			"from previous steps. Use ${steps.nodeName.output.field} syntax for variable references.",
+			"from previous steps. Use ${steps.nodeName.output.field} syntax for variable references. " +
+			"For aggregating, grouping or filtering arrays, set transform_type to \"expr\" and write the whole " +
+			"output as one expression, e.g. {\"total\": sum(map(steps.nodeName.items, .amount))}.",
 		IsActive: true,
 	})
 
@@ -359,17 +364,25 @@ func DefaultNodeRegistry() *NodeRegistry {
 		ConfigSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
-				"to":      map[string]interface{}{"type": "string"},
-				"subject": map[string]interface{}{"type": "string"},
-				"body":    map[string]interface{}{"type": "string"},
-				"cc":      map[string]interface{}{"type": "string"},
-				"bcc":     map[string]interface{}{"type": "string"},
+				"provider":      map[string]interface{}{"type": "string", "enum": []string{"sendgrid", "mailgun", "aws_ses", "smtp"}},
+				"credential_id": map[string]interface{}{"type": "string"},
+				"from":          map[string]interface{}{"type": "string"},
+				"to":            map[string]interface{}{"type": "string"},
+				"subject":       map[string]interface{}{"type": "string"},
+				"body":          map[string]interface{}{"type": "string"},
+				"body_html":     map[string]interface{}{"type": "string"},
+				"cc":            map[string]interface{}{"type": "string"},
+				"bcc":           map[string]interface{}{"type": "string"},
+				"attachments":   map[string]interface{}{"type": "array"},
 			},
 		},
 		ExampleConfig: map[string]interface{}{
-			"to":      "user@example.com",
-			"subject": "Workflow Alert",
-			"body":    "Data processed: ${steps.process.output.count} items",
+			"provider":      "sendgrid",
+			"credential_id": "cred-email",
+			"from":          "alerts@example.com",
+			"to":            "user@example.com",
+			"subject":       "Workflow Alert",
+			"body":          "Data processed: ${steps.process.output.count} items",
 		},
 		LLMDescription: "Use this to send email notifications. Configure recipients, subject, and body. " +
 			"Use template variables like ${steps.nodeName.output.field} for dynamic content.",
@@ -398,6 +411,43 @@ func DefaultNodeRegistry() *NodeRegistry {
 		IsActive: true,
 	})
 
+	registry.Register(NodeTemplate{
+		NodeType:    "control:switch",
+		Name:        "Switch",
+		Description: "Routes workflow to one of several branches based on a value",
+		Category:    NodeCategoryControl,
+		ConfigSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"expression": map[string]interface{}{"type": "string"},
+				"cases": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"value": map[string]interface{}{},
+							"label": map[string]interface{}{"type": "string"},
+						},
+					},
+				},
+				"description": map[string]interface{}{"type": "string"},
+			},
+		},
+		ExampleConfig: map[string]interface{}{
+			"expression": "trigger.body.severity",
+			"cases": []map[string]interface{}{
+				{"value": "critical", "label": "page"},
+				{"value": "high", "label": "page"},
+				{"value": "medium", "label": "ticket"},
+			},
+			"description": "Route alerts by severity",
+		},
+		LLMDescription: "Use this instead of chaining several conditionals when routing on one value. The first case whose value " +
+			"equals the expression result is taken. Label each outgoing edge with a case label (the case value when no label is set), " +
+			"and label an edge 'default' for when no case matches.",
+		IsActive: true,
+	})
+
 	registry.Register(NodeTemplate{
 		NodeType:    "control:loop",
 		Name:        "Loop (For Each)",
@@ -406,10 +456,12 @@ func DefaultNodeRegistry() *NodeRegistry {
 		ConfigSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
-				"source":         map[string]interface{}{"type": "string"},
-				"item_variable":  map[string]interface{}{"type": "string"},
-				"index_variable": map[string]interface{}{"type": "string"},
-				"max_iterations": map[string]interface{}{"type": "integer"},
+				"source":            map[string]interface{}{"type": "string"},
+				"item_variable":     map[string]interface{}{"type": "string"},
+				"index_variable":    map[string]interface{}{"type": "string"},
+				"max_iterations":    map[string]interface{}{"type": "integer"},
+				"concurrency":       map[string]interface{}{"type": "integer"},
+				"continue_on_error": map[string]interface{}{"type": "boolean"},
 			},
 		},
 		ExampleConfig: map[string]interface{}{
@@ -419,7 +471,9 @@ func DefaultNodeRegistry() *NodeRegistry {
 			"max_iterations": 100,
 		},
 		LLMDescription: "Use this to process each item in an array. Specify the source array and variable names. " +
-			"Nodes inside the loop can access ${loop.item} for the current item and ${loop.index} for the index.",
+			"Nodes inside the loop can access ${loop.item} for the current item and ${loop.index} for the index. " +
+			"Set concurrency to run independent iterations in parallel and continue_on_error to keep going after a failed item; " +
+			"each iteration's final output is collected in ${steps.loopName.results}.",
 		IsActive: true,
 	})
 
@@ -472,9 +526,12 @@ func DefaultNodeRegistry() *NodeRegistry {
 		ConfigSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
-				"channel": map[string]interface{}{"type": "string"},
-				"text":    map[string]interface{}{"type": "string"},
-				"blocks":  map[string]interface{}{"type": "array"},
+				"credential_id": map[string]interface{}{"type": "string"},
+				"channel":       map[string]interface{}{"type": "string"},
+				"text":          map[string]interface{}{"type": "string"},
+				"message":       map[string]interface{}{"type": "string"},
+				"blocks":        map[string]interface{}{"type": "array"},
+				"thread_ts":     map[string]interface{}{"type": "string"},
 			},
 		},
 		ExampleConfig: map[string]interface{}{
@@ -482,7 +539,7 @@ func DefaultNodeRegistry() *NodeRegistry {
 			"text":    "New alert: ${steps.data.output.message}",
 		},
 		LLMDescription: "Use this to send messages to Slack channels. Specify channel name (e.g., #general) or ID " +
-			"and message text. Supports Block Kit for rich formatting.",
+			"and message text. Supports Block Kit for rich formatting. Set thread_ts to a previous step's ts to reply in a thread.",
 		IsActive: true,
 	})
 
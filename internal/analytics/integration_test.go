@@ -485,3 +485,16 @@ func (m *mockRepository) GetNodePerformance(ctx context.Context, tenantID, workf
 		},
 	}, nil
 }
+
+func (m *mockRepository) GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange TimeRange) (*ConcurrencyMetrics, error) {
+	return &ConcurrencyMetrics{
+		StartDate: timeRange.StartDate,
+		EndDate:   timeRange.EndDate,
+		Workflows: []WorkflowConcurrency{
+			{WorkflowID: "wf-1", WorkflowName: "Health Check", InFlight: 2, Queued: 4, ExecutionCount: 288, StartedCount: 284, AvgQueueWaitMs: 3500, PeakHourlyExecutions: 12},
+		},
+		Hourly: []HourlyExecutions{
+			{Timestamp: timeRange.StartDate, ExecutionCount: 12, StartedCount: 12, AvgQueueWaitMs: 3500},
+		},
+	}, nil
+}
@@ -119,3 +119,53 @@ type NodePerformance struct {
 	WorkflowName string      `json:"workflowName"`
 	Nodes        []NodeStats `json:"nodes"`
 }
+
+// WorkflowConcurrency represents the load on a workflow: executions in flight
+// now, and how often and how promptly executions started over the window
+type WorkflowConcurrency struct {
+	WorkflowID     string `db:"workflow_id" json:"workflowId"`
+	WorkflowName   string `db:"workflow_name" json:"workflowName"`
+	MaxConcurrency *int   `db:"max_concurrency" json:"maxConcurrency,omitempty"`
+	InFlight       int    `db:"in_flight" json:"inFlight"`
+	Queued         int    `db:"queued" json:"queued"`
+	ExecutionCount int    `db:"execution_count" json:"executionCount"`
+	// StartedCount is how many executions in the window have started, the
+	// sample behind AvgQueueWaitMs
+	StartedCount         int     `db:"started_count" json:"-"`
+	AvgQueueWaitMs       int64   `db:"avg_queue_wait_ms" json:"avgQueueWaitMs"`
+	PeakHourlyExecutions int     `db:"peak_hourly_executions" json:"peakHourlyExecutions"`
+	ExecutionsPerHour    float64 `db:"-" json:"executionsPerHour"`
+}
+
+// HourlyExecutions represents the executions created in one hour
+type HourlyExecutions struct {
+	Timestamp      time.Time `db:"timestamp" json:"timestamp"`
+	ExecutionCount int       `db:"execution_count" json:"executionCount"`
+	StartedCount   int       `db:"started_count" json:"-"`
+	AvgQueueWaitMs int64     `db:"avg_queue_wait_ms" json:"avgQueueWaitMs"`
+}
+
+// ConcurrencyRollup represents the tenant-wide totals of ConcurrencyMetrics
+type ConcurrencyRollup struct {
+	InFlight             int     `json:"inFlight"`
+	Queued               int     `json:"queued"`
+	ExecutionCount       int     `json:"executionCount"`
+	AvgQueueWaitMs       int64   `json:"avgQueueWaitMs"`
+	PeakHourlyExecutions int     `json:"peakHourlyExecutions"`
+	ExecutionsPerHour    float64 `json:"executionsPerHour"`
+	// ActiveSlots and SlotLimit are the tenant's execution slots held across
+	// workers and the per-tenant cap; they are omitted when worker state is
+	// unavailable
+	ActiveSlots *int `json:"activeSlots,omitempty"`
+	SlotLimit   int  `json:"slotLimit,omitempty"`
+}
+
+// ConcurrencyMetrics represents execution concurrency and throughput per
+// workflow, with a tenant-wide rollup and hourly buckets
+type ConcurrencyMetrics struct {
+	StartDate time.Time             `json:"startDate"`
+	EndDate   time.Time             `json:"endDate"`
+	Tenant    ConcurrencyRollup     `json:"tenant"`
+	Workflows []WorkflowConcurrency `json:"workflows"`
+	Hourly    []HourlyExecutions    `json:"hourly"`
+}
@@ -343,6 +343,95 @@ func (r *Repository) GetNodePerformance(ctx context.Context, tenantID, workflowI
 	}, nil
 }
 
+// GetConcurrencyMetrics retrieves per-workflow in-flight executions and
+// throughput over the window, plus the tenant's hourly buckets. Throughput is
+// aggregated per hour first so the peak hour comes from the same pass.
+func (r *Repository) GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange TimeRange) (*ConcurrencyMetrics, error) {
+	query := `
+		WITH hourly AS (
+			SELECT
+				workflow_id,
+				COUNT(*) as execution_count,
+				COUNT(started_at) as started_count,
+				SUM(EXTRACT(EPOCH FROM (started_at - created_at)) * 1000) as queue_wait_ms
+			FROM executions
+			WHERE tenant_id = $1
+				AND created_at >= $2
+				AND created_at <= $3
+			GROUP BY workflow_id, date_trunc_hour_immutable(created_at)
+		),
+		windowed AS (
+			SELECT
+				workflow_id,
+				SUM(execution_count) as execution_count,
+				SUM(started_count) as started_count,
+				MAX(execution_count) as peak_hourly_executions,
+				COALESCE(SUM(queue_wait_ms) / NULLIF(SUM(started_count), 0), 0) as avg_queue_wait_ms
+			FROM hourly
+			GROUP BY workflow_id
+		),
+		live AS (
+			SELECT
+				workflow_id,
+				COUNT(*) FILTER (WHERE status = 'running') as in_flight,
+				COUNT(*) FILTER (WHERE status = 'pending') as queued
+			FROM executions
+			WHERE tenant_id = $1
+				AND status IN ('pending', 'running')
+			GROUP BY workflow_id
+		)
+		SELECT
+			w.id as workflow_id,
+			w.name as workflow_name,
+			w.max_concurrency,
+			COALESCE(l.in_flight, 0) as in_flight,
+			COALESCE(l.queued, 0) as queued,
+			CAST(COALESCE(wd.execution_count, 0) AS BIGINT) as execution_count,
+			CAST(COALESCE(wd.started_count, 0) AS BIGINT) as started_count,
+			CAST(COALESCE(wd.avg_queue_wait_ms, 0) AS BIGINT) as avg_queue_wait_ms,
+			COALESCE(wd.peak_hourly_executions, 0) as peak_hourly_executions
+		FROM workflows w
+		LEFT JOIN windowed wd ON wd.workflow_id = w.id
+		LEFT JOIN live l ON l.workflow_id = w.id
+		WHERE w.tenant_id = $1
+			AND (wd.workflow_id IS NOT NULL OR l.workflow_id IS NOT NULL)
+		ORDER BY in_flight DESC, queued DESC, execution_count DESC
+	`
+
+	var workflows []WorkflowConcurrency
+	err := r.db.SelectContext(ctx, &workflows, query, tenantID, timeRange.StartDate, timeRange.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow concurrency: %w", err)
+	}
+
+	hourlyQuery := `
+		SELECT
+			date_trunc_hour_immutable(created_at) as timestamp,
+			COUNT(*) as execution_count,
+			COUNT(started_at) as started_count,
+			CAST(COALESCE(AVG(EXTRACT(EPOCH FROM (started_at - created_at)) * 1000), 0) AS BIGINT) as avg_queue_wait_ms
+		FROM executions
+		WHERE tenant_id = $1
+			AND created_at >= $2
+			AND created_at <= $3
+		GROUP BY date_trunc_hour_immutable(created_at)
+		ORDER BY timestamp ASC
+	`
+
+	var hourly []HourlyExecutions
+	err = r.db.SelectContext(ctx, &hourly, hourlyQuery, tenantID, timeRange.StartDate, timeRange.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("get hourly executions: %w", err)
+	}
+
+	return &ConcurrencyMetrics{
+		StartDate: timeRange.StartDate,
+		EndDate:   timeRange.EndDate,
+		Workflows: workflows,
+		Hourly:    hourly,
+	}, nil
+}
+
 // getTruncFunction returns the appropriate PostgreSQL date truncation string
 func getTruncFunction(granularity Granularity) string {
 	switch granularity {
@@ -341,3 +341,71 @@ func TestGetNodePerformance_WorkflowNotFound(t *testing.T) {
 	assert.Nil(t, performance)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetConcurrencyMetrics_Success(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	ctx := context.Background()
+	tenantID := "tenant-123"
+	timeRange := TimeRange{
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	workflowRows := sqlmock.NewRows([]string{
+		"workflow_id", "workflow_name", "max_concurrency", "in_flight", "queued",
+		"execution_count", "started_count", "avg_queue_wait_ms", "peak_hourly_executions",
+	}).
+		AddRow("wf-health", "Health Check", 2, 2, 5, 288, 283, 4200, 12).
+		AddRow("wf-sync", "Nightly Sync", nil, 0, 0, 1, 1, 30, 1)
+
+	mock.ExpectQuery("WITH hourly AS (.+) FROM executions").
+		WithArgs(tenantID, timeRange.StartDate, timeRange.EndDate).
+		WillReturnRows(workflowRows)
+
+	hourlyRows := sqlmock.NewRows([]string{"timestamp", "execution_count", "started_count", "avg_queue_wait_ms"}).
+		AddRow(timeRange.StartDate, 12, 12, 3900).
+		AddRow(timeRange.StartDate.Add(time.Hour), 13, 12, 4500)
+
+	mock.ExpectQuery("SELECT (.+) FROM executions").
+		WithArgs(tenantID, timeRange.StartDate, timeRange.EndDate).
+		WillReturnRows(hourlyRows)
+
+	metrics, err := repo.GetConcurrencyMetrics(ctx, tenantID, timeRange)
+
+	require.NoError(t, err)
+	require.Len(t, metrics.Workflows, 2)
+	health := metrics.Workflows[0]
+	assert.Equal(t, "Health Check", health.WorkflowName)
+	require.NotNil(t, health.MaxConcurrency)
+	assert.Equal(t, 2, *health.MaxConcurrency)
+	assert.Equal(t, 2, health.InFlight)
+	assert.Equal(t, 5, health.Queued)
+	assert.Equal(t, int64(4200), health.AvgQueueWaitMs)
+	assert.Equal(t, 12, health.PeakHourlyExecutions)
+	assert.Nil(t, metrics.Workflows[1].MaxConcurrency)
+
+	require.Len(t, metrics.Hourly, 2)
+	assert.Equal(t, 13, metrics.Hourly[1].ExecutionCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetConcurrencyMetrics_DatabaseError(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	timeRange := TimeRange{
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectQuery("WITH hourly AS").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetConcurrencyMetrics(context.Background(), "tenant-123", timeRange)
+
+	assert.ErrorContains(t, err, "get workflow concurrency")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,11 +3,23 @@ package analytics
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
+// MaxConcurrencyWindow bounds the time range of GetConcurrencyMetrics, which
+// returns a bucket per hour
+const MaxConcurrencyWindow = 31 * 24 * time.Hour
+
 // Service handles analytics business logic
 type Service struct {
-	repo AnalyticsRepository
+	repo  AnalyticsRepository
+	slots TenantSlots
+}
+
+// TenantSlots reports the per-tenant execution slots held across workers
+type TenantSlots interface {
+	GetCurrent(ctx context.Context, tenantID string) (int, error)
+	GetMaxPerTenant() int
 }
 
 // AnalyticsRepository defines the interface for analytics data access
@@ -18,6 +30,7 @@ type AnalyticsRepository interface {
 	GetTopWorkflows(ctx context.Context, tenantID string, timeRange TimeRange, limit int) (*TopWorkflows, error)
 	GetErrorBreakdown(ctx context.Context, tenantID string, timeRange TimeRange) (*ErrorBreakdown, error)
 	GetNodePerformance(ctx context.Context, tenantID, workflowID string) (*NodePerformance, error)
+	GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange TimeRange) (*ConcurrencyMetrics, error)
 }
 
 // NewService creates a new analytics service
@@ -25,6 +38,11 @@ func NewService(repo AnalyticsRepository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetTenantSlots adds worker slot usage to concurrency metrics
+func (s *Service) SetTenantSlots(slots TenantSlots) {
+	s.slots = slots
+}
+
 // GetWorkflowStats retrieves statistics for a specific workflow
 func (s *Service) GetWorkflowStats(ctx context.Context, tenantID, workflowID string, timeRange TimeRange) (*WorkflowStats, error) {
 	if err := validateTimeRange(timeRange); err != nil {
@@ -117,6 +135,65 @@ func (s *Service) GetNodePerformance(ctx context.Context, tenantID, workflowID s
 	return performance, nil
 }
 
+// GetConcurrencyMetrics retrieves in-flight executions, queue wait and
+// throughput per workflow, with a tenant-wide rollup
+func (s *Service) GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange TimeRange) (*ConcurrencyMetrics, error) {
+	if err := validateTimeRange(timeRange); err != nil {
+		return nil, err
+	}
+
+	window := timeRange.EndDate.Sub(timeRange.StartDate)
+	if window > MaxConcurrencyWindow {
+		return nil, fmt.Errorf("time range must not exceed %d days", int(MaxConcurrencyWindow.Hours()/24))
+	}
+
+	metrics, err := s.repo.GetConcurrencyMetrics(ctx, tenantID, timeRange)
+	if err != nil {
+		return nil, fmt.Errorf("get concurrency metrics: %w", err)
+	}
+
+	// Windows shorter than an hour count as one so rates are not inflated
+	hours := max(window.Hours(), 1)
+
+	rollup := ConcurrencyRollup{}
+	var queueWaitMs, started int64
+	for i := range metrics.Workflows {
+		wf := &metrics.Workflows[i]
+		wf.ExecutionsPerHour = float64(wf.ExecutionCount) / hours
+
+		rollup.InFlight += wf.InFlight
+		rollup.Queued += wf.Queued
+		rollup.ExecutionCount += wf.ExecutionCount
+		queueWaitMs += wf.AvgQueueWaitMs * int64(wf.StartedCount)
+		started += int64(wf.StartedCount)
+	}
+	if started > 0 {
+		rollup.AvgQueueWaitMs = queueWaitMs / started
+	}
+	for _, bucket := range metrics.Hourly {
+		rollup.PeakHourlyExecutions = max(rollup.PeakHourlyExecutions, bucket.ExecutionCount)
+	}
+	rollup.ExecutionsPerHour = float64(rollup.ExecutionCount) / hours
+
+	// Slot usage is best effort; the execution metrics stand on their own
+	if s.slots != nil {
+		if active, err := s.slots.GetCurrent(ctx, tenantID); err == nil {
+			rollup.ActiveSlots = &active
+			rollup.SlotLimit = s.slots.GetMaxPerTenant()
+		}
+	}
+
+	metrics.Tenant = rollup
+	if metrics.Workflows == nil {
+		metrics.Workflows = []WorkflowConcurrency{}
+	}
+	if metrics.Hourly == nil {
+		metrics.Hourly = []HourlyExecutions{}
+	}
+
+	return metrics, nil
+}
+
 // validateTimeRange validates the time range
 func validateTimeRange(timeRange TimeRange) error {
 	if timeRange.StartDate.IsZero() || timeRange.EndDate.IsZero() {
@@ -64,6 +64,14 @@ func (m *MockRepository) GetNodePerformance(ctx context.Context, tenantID, workf
 	return args.Get(0).(*NodePerformance), args.Error(1)
 }
 
+func (m *MockRepository) GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange TimeRange) (*ConcurrencyMetrics, error) {
+	args := m.Called(ctx, tenantID, timeRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ConcurrencyMetrics), args.Error(1)
+}
+
 func TestServiceGetWorkflowStats_Success(t *testing.T) {
 	mockRepo := new(MockRepository)
 	service := NewService(mockRepo)
@@ -732,3 +740,103 @@ func TestValidateTimeRange_BoundaryConditions(t *testing.T) {
 		})
 	}
 }
+
+type fakeTenantSlots struct {
+	active int
+	err    error
+}
+
+func (f fakeTenantSlots) GetCurrent(ctx context.Context, tenantID string) (int, error) {
+	return f.active, f.err
+}
+
+func (f fakeTenantSlots) GetMaxPerTenant() int {
+	return 10
+}
+
+func TestServiceGetConcurrencyMetrics(t *testing.T) {
+	ctx := context.Background()
+	timeRange := TimeRange{
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	metrics := func() *ConcurrencyMetrics {
+		return &ConcurrencyMetrics{
+			StartDate: timeRange.StartDate,
+			EndDate:   timeRange.EndDate,
+			Workflows: []WorkflowConcurrency{
+				{WorkflowID: "wf-health", InFlight: 3, Queued: 7, ExecutionCount: 288, StartedCount: 280, AvgQueueWaitMs: 1000, PeakHourlyExecutions: 12},
+				{WorkflowID: "wf-sync", InFlight: 1, ExecutionCount: 24, StartedCount: 20, AvgQueueWaitMs: 50, PeakHourlyExecutions: 1},
+			},
+			Hourly: []HourlyExecutions{
+				{Timestamp: timeRange.StartDate, ExecutionCount: 13},
+				{Timestamp: timeRange.StartDate.Add(time.Hour), ExecutionCount: 11},
+			},
+		}
+	}
+
+	t.Run("computes rates and the tenant rollup", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		service := NewService(mockRepo)
+		service.SetTenantSlots(fakeTenantSlots{active: 4})
+		mockRepo.On("GetConcurrencyMetrics", ctx, "tenant-123", timeRange).Return(metrics(), nil)
+
+		result, err := service.GetConcurrencyMetrics(ctx, "tenant-123", timeRange)
+
+		require.NoError(t, err)
+		assert.Equal(t, 12.0, result.Workflows[0].ExecutionsPerHour)
+		assert.Equal(t, 1.0, result.Workflows[1].ExecutionsPerHour)
+
+		rollup := result.Tenant
+		assert.Equal(t, 4, rollup.InFlight)
+		assert.Equal(t, 7, rollup.Queued)
+		assert.Equal(t, 312, rollup.ExecutionCount)
+		assert.Equal(t, 13.0, rollup.ExecutionsPerHour)
+		assert.Equal(t, 13, rollup.PeakHourlyExecutions)
+		// Weighted by started executions: (280*1000 + 20*50) / 300
+		assert.Equal(t, int64(936), rollup.AvgQueueWaitMs)
+		require.NotNil(t, rollup.ActiveSlots)
+		assert.Equal(t, 4, *rollup.ActiveSlots)
+		assert.Equal(t, 10, rollup.SlotLimit)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("omits slot usage when worker state is unavailable", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		service := NewService(mockRepo)
+		service.SetTenantSlots(fakeTenantSlots{err: errors.New("redis down")})
+		mockRepo.On("GetConcurrencyMetrics", ctx, "tenant-123", timeRange).Return(metrics(), nil)
+
+		result, err := service.GetConcurrencyMetrics(ctx, "tenant-123", timeRange)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Tenant.ActiveSlots)
+		assert.Zero(t, result.Tenant.SlotLimit)
+	})
+
+	t.Run("short windows count as one hour", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		service := NewService(mockRepo)
+		short := TimeRange{StartDate: timeRange.StartDate, EndDate: timeRange.StartDate.Add(10 * time.Minute)}
+		mockRepo.On("GetConcurrencyMetrics", ctx, "tenant-123", short).Return(&ConcurrencyMetrics{
+			Workflows: []WorkflowConcurrency{{WorkflowID: "wf-health", ExecutionCount: 2}},
+		}, nil)
+
+		result, err := service.GetConcurrencyMetrics(ctx, "tenant-123", short)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, result.Tenant.ExecutionsPerHour)
+		assert.NotNil(t, result.Hourly)
+	})
+
+	t.Run("rejects windows over the maximum", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		service := NewService(mockRepo)
+		long := TimeRange{StartDate: timeRange.StartDate, EndDate: timeRange.StartDate.Add(MaxConcurrencyWindow + time.Hour)}
+
+		_, err := service.GetConcurrencyMetrics(ctx, "tenant-123", long)
+
+		assert.ErrorContains(t, err, "31 days")
+		mockRepo.AssertNotCalled(t, "GetConcurrencyMetrics")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -29,19 +30,31 @@ import (
 	"github.com/gorax/gorax/internal/collaboration"
 	"github.com/gorax/gorax/internal/config"
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/database"
+	"github.com/gorax/gorax/internal/envvars"
 	"github.com/gorax/gorax/internal/errortracking"
 	"github.com/gorax/gorax/internal/eventtypes"
 	"github.com/gorax/gorax/internal/executor"
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/featureflag"
+	"github.com/gorax/gorax/internal/integrations/slack"
 	"github.com/gorax/gorax/internal/llm"
 	"github.com/gorax/gorax/internal/llm/providers/anthropic"
 	"github.com/gorax/gorax/internal/llm/providers/bedrock"
 	"github.com/gorax/gorax/internal/llm/providers/openai"
 	"github.com/gorax/gorax/internal/marketplace"
 	"github.com/gorax/gorax/internal/metrics"
+	"github.com/gorax/gorax/internal/nodecache"
 	"github.com/gorax/gorax/internal/oauth"
 	oauthProviders "github.com/gorax/gorax/internal/oauth/providers"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/queue"
 	"github.com/gorax/gorax/internal/quota"
+	"github.com/gorax/gorax/internal/ratelimit"
+	"github.com/gorax/gorax/internal/rbac"
+	"github.com/gorax/gorax/internal/residency"
 	"github.com/gorax/gorax/internal/schedule"
+	"github.com/gorax/gorax/internal/security"
 	"github.com/gorax/gorax/internal/sso"
 	"github.com/gorax/gorax/internal/suggestions"
 	"github.com/gorax/gorax/internal/template"
@@ -49,6 +62,7 @@ import (
 	"github.com/gorax/gorax/internal/tracing"
 	"github.com/gorax/gorax/internal/webhook"
 	"github.com/gorax/gorax/internal/websocket"
+	"github.com/gorax/gorax/internal/worker"
 	"github.com/gorax/gorax/internal/workflow"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -89,6 +103,9 @@ type App struct {
 	redis  *redis.Client
 	router *chi.Mux
 
+	// Execution databases of the data regions tenants can be pinned to
+	regionDBs map[string]*sqlx.DB
+
 	// Error tracking
 	errorTracker *errortracking.Tracker
 
@@ -106,7 +123,10 @@ type App struct {
 	webhookService      *webhook.Service
 	scheduleService     *schedule.Service
 	eventTypeService    *eventtypes.Service
+	featureFlagService  *featureflag.Service
+	envVarService       *envvars.Service
 	credentialService   credential.Service
+	credentialUsage     *credential.UsageTracker
 	templateService     *template.Service
 	marketplaceService  *marketplace.Service
 	collabService       *collaboration.Service
@@ -131,6 +151,7 @@ type App struct {
 	tenantHandler            *handlers.TenantHandler
 	scheduleHandler          *handlers.ScheduleHandler
 	executionHandler         *handlers.ExecutionHandler
+	deadLetterHandler        *handlers.DeadLetterHandler
 	usageHandler             *handlers.UsageHandler
 	credentialHandler        *handlers.CredentialHandler
 	metricsHandler           *handlers.MetricsHandler
@@ -143,9 +164,16 @@ type App struct {
 	oauthHandler             *handlers.OAuthHandler
 	ssoHandler               *handlers.SSOHandler
 	auditHandler             *handlers.AuditHandler
+	auditTrailHandler        *handlers.AuditTrailHandler
+	featureFlagHandler       *handlers.FeatureFlagHandler
+	envVarHandler            *handlers.EnvVarHandler
+	nodeCacheHandler         *handlers.NodeCacheHandler
+	recoveryHandler          *handlers.CredentialRecoveryHandler
+	slackInteractionHandler  *handlers.SlackInteractionHandler
 
 	// Middleware
 	quotaChecker *apiMiddleware.QuotaChecker
+	rateLimiter  *apiMiddleware.TenantRateLimiter
 
 	// Quota tracking
 	quotaTracker *quota.Tracker
@@ -162,7 +190,7 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	}
 
 	// Initialize database connection
-	db, err := sqlx.Connect("postgres", cfg.Database.ConnectionString())
+	db, err := database.Connect(context.Background(), cfg.Database)
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +249,31 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	app.workflowBulkService = workflow.NewBulkService(workflowRepo, app.webhookService, logger)
 	app.scheduleService = schedule.NewService(scheduleRepo, logger)
 	app.eventTypeService = eventtypes.NewService(eventTypeRepo, logger)
+	app.featureFlagService = featureflag.NewService(featureflag.NewRepository(db), logger)
+	app.envVarService = envvars.NewService(envvars.NewRepository(db), logger)
+	app.workflowService.SetEnvChecker(app.envVarService)
+	app.workflowService.SetHTTPMaxResponseBytes(cfg.HTTPAction.MaxResponseBytes)
+
+	// Store the executions of tenants pinned to a data region in that
+	// region's database
+	var regionResolver *residency.Resolver
+	if len(cfg.DataResidency.Regions) > 0 {
+		regionResolver = residency.NewResolver(tenantRepo, residency.DefaultRegionCacheTTL)
+		app.tenantService.SetDataRegions(cfg.DataResidency.RegionNames())
+
+		app.regionDBs, err = residency.ConnectDatabases(context.Background(), cfg.DataResidency.Regions)
+		if err != nil {
+			return nil, err
+		}
+		workflowRepo.SetExecutionDBRouter(residency.NewRouter(regionResolver, residency.ExecutionStore, db, app.regionDBs))
+		logger.Info("Data residency enabled", "regions", cfg.DataResidency.RegionNames())
+	}
+	nodeCacheRepo := nodecache.NewRepository(db)
+	app.webhookService.SetEventTypeRegistry(app.eventTypeService)
+	if cfg.WebhookRetry.Enabled {
+		app.webhookService.SetRetryConfig(webhook.RedeliveryRetryConfig(cfg.WebhookRetry.BaseDelay, cfg.WebhookRetry.MaxDelay))
+	}
+	app.workflowService.SetEventTypeRegistry(app.eventTypeService)
 	app.templateService = template.NewService(templateRepo, logger)
 
 	// Initialize marketplace service with workflow service adapter
@@ -241,18 +294,62 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	// Initialize executor with WebSocket broadcaster and metrics
 	broadcaster := websocket.NewHubBroadcaster(app.wsHub)
 	workflowExecutor := executor.NewWithBroadcaster(workflowRepo, logger, broadcaster)
+	workflowExecutor.SetHTTPOptions(actions.HTTPOptions{
+		AllowInsecureTLS:   cfg.HTTPAction.InsecureTLSAllowed(cfg.Server.Env),
+		MaxResponseBytes:   cfg.HTTPAction.MaxResponseBytes,
+		MaxResponseHeaders: cfg.HTTPAction.MaxResponseHeaders,
+		URLValidator: security.NewURLValidatorWithConfig(&security.URLValidatorConfig{
+			Enabled:         cfg.SSRF.ProtectionEnabled(cfg.Server.Env),
+			AllowedNetworks: cfg.SSRF.AllowedNetworks,
+			BlockedNetworks: cfg.SSRF.BlockedNetworks,
+			AllowedHosts:    cfg.SSRF.AllowedHosts,
+			BlockedHosts:    cfg.SSRF.BlockedHosts,
+		}),
+	})
+	workflowExecutor.SetRedactor(executor.NewRedactor(cfg.Redaction.SensitiveKeys))
 	workflowExecutor.SetMetrics(app.metrics)
+	workflowExecutor.SetFeatureFlags(app.featureFlagService)
+	workflowExecutor.SetEnvResolver(app.envVarService)
+	workflowExecutor.SetNodeResultCache(nodeCacheRepo)
+	workflowExecutor.SetMaxExecutionDuration(cfg.Workflow.MaxExecutionDuration)
+
+	// Slack message buttons need the signing secret to verify responses
+	if cfg.OAuth.SlackSigningSecret != "" {
+		slackInteractions := slack.NewInteractionRepository(db)
+		workflowExecutor.SetSlackInteractions(slackInteractions)
+		app.slackInteractionHandler = handlers.NewSlackInteractionHandler(slackInteractions, cfg.OAuth.SlackSigningSecret, logger)
+	}
 
 	// Create workflow getter adapter for schedule service
 	workflowGetter := &workflowServiceAdapter{workflowService: app.workflowService}
 
 	// Wire up dependencies to avoid import cycles
 	app.workflowService.SetExecutor(workflowExecutor)
+	workflowExecutor.SetFailureHandler(app.workflowService)
 	app.workflowService.SetWebhookService(app.webhookService)
 	app.scheduleService.SetWorkflowService(workflowGetter)
 
 	// Initialize handlers
 	app.healthHandler = handlers.NewHealthHandler(db, app.redis)
+	if cfg.Queue.Enabled && cfg.AWS.SQSQueueURL != "" {
+		// Executions are only processed while the job queue is reachable
+		sqsClient, err := queue.NewSQSClient(context.Background(), queue.SQSConfig{
+			QueueURL:        cfg.AWS.SQSQueueURL,
+			DLQueueURL:      cfg.AWS.SQSDLQueueURL,
+			Region:          cfg.AWS.Region,
+			AccessKeyID:     cfg.AWS.AccessKeyID,
+			SecretAccessKey: cfg.AWS.SecretAccessKey,
+			Endpoint:        cfg.AWS.Endpoint,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SQS client: %w", err)
+		}
+		app.healthHandler.AddReadinessCheck(handlers.ReadinessCheck{
+			Name:     "queue",
+			Check:    sqsClient.HealthCheck,
+			Critical: true,
+		})
+	}
 	app.workflowHandler = handlers.NewWorkflowHandler(app.workflowService, logger)
 	app.workflowBulkHandler = handlers.NewWorkflowBulkHandler(app.workflowBulkService, logger)
 	app.webhookHandler = handlers.NewWebhookHandler(app.workflowService, app.webhookService, logger)
@@ -271,8 +368,12 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	app.tenantHandler = handlers.NewTenantHandler(app.tenantService, logger)
 	app.scheduleHandler = handlers.NewScheduleHandler(app.scheduleService, logger)
 	app.executionHandler = handlers.NewExecutionHandler(app.workflowService, logger)
+	app.deadLetterHandler = handlers.NewDeadLetterHandler(app.workflowService, logger)
 	app.metricsHandler = handlers.NewMetricsHandler(workflowRepo)
 	app.eventTypesHandler = handlers.NewEventTypesHandler(app.eventTypeService, logger)
+	app.featureFlagHandler = handlers.NewFeatureFlagHandler(app.featureFlagService, logger)
+	app.envVarHandler = handlers.NewEnvVarHandler(app.envVarService, logger)
+	app.nodeCacheHandler = handlers.NewNodeCacheHandler(nodeCacheRepo, logger)
 
 	// Initialize credential service
 	credentialRepo := credential.NewRepository(db)
@@ -300,9 +401,50 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 			return nil, fmt.Errorf("failed to create KMS encryption service: %w", err)
 		}
 
+		// Tenants with their own KMS key have new data keys wrapped with it
+		kmsEncryptionService.SetTenantKeyResolver(credentialRepo)
+		app.tenantAdminHandler.SetKeyRotator(credential.NewTenantKeyRotator(kmsEncryptionService, credentialRepo, logger))
+
 		encryptionService = credential.NewKMSEncryptionAdapter(kmsEncryptionService)
 		logger.Info("Credential encryption initialized", "mode", "KMS", "key_id", cfg.Credential.KMSKeyID, "region", cfg.Credential.KMSRegion)
+
+		// Wrap data keys of tenants pinned to a data region with that
+		// region's KMS key
+		if regionResolver != nil {
+			regionalKMS, err := residency.NewKMSEncryptionServices(context.Background(), cfg.DataResidency.Regions, credentialRepo)
+			if err != nil {
+				return nil, err
+			}
+			regional := make(map[string]credential.EncryptionServiceInterface, len(regionalKMS))
+			for name, service := range regionalKMS {
+				regional[name] = credential.NewKMSEncryptionAdapter(service)
+				app.healthHandler.AddReadinessCheck(handlers.ReadinessCheck{
+					Name:     "kms-" + name,
+					Check:    service.HealthCheck,
+					Critical: true,
+					CacheTTL: time.Minute,
+				})
+			}
+			encryptionService = credential.NewRegionalEncryptionService(
+				residency.NewRouter(regionResolver, residency.CredentialKeyStore, encryptionService, regional))
+		}
+
+		// A pod that can't use the KMS key can't decrypt credentials, so it
+		// isn't ready. KMS calls are billed and rate limited, so cache the result.
+		app.healthHandler.AddReadinessCheck(handlers.ReadinessCheck{
+			Name:     "kms",
+			Check:    kmsEncryptionService.HealthCheck,
+			Critical: true,
+			CacheTTL: time.Minute,
+		})
 	} else {
+		// Regional KMS keys would be silently ignored
+		for _, region := range cfg.DataResidency.Regions {
+			if region.KMSKeyID != "" {
+				return nil, fmt.Errorf("data region %s has a KMS key but CREDENTIAL_USE_KMS is false", region.Name)
+			}
+		}
+
 		// Development: Use simple encryption with master key
 		masterKey, err := base64.StdEncoding.DecodeString(cfg.Credential.MasterKey)
 		if err != nil {
@@ -318,8 +460,54 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 		logger.Warn("Credential encryption initialized", "mode", "simple", "warning", "Use KMS in production")
 	}
 
-	app.credentialService = credential.NewServiceImpl(credentialRepo, encryptionService, logger)
-	app.credentialHandler = handlers.NewCredentialHandler(app.credentialService, logger)
+	// Initialize OAuth service and handler
+	oauthRepo := oauth.NewRepository(db)
+
+	// Determine Salesforce environment (sandbox or production)
+	salesforceIsSandbox := cfg.OAuth.SalesforceEnvironment == "sandbox"
+
+	oauthProviderRegistry := map[string]oauth.Provider{
+		"github":     oauthProviders.NewGitHubProvider(),
+		"google":     oauthProviders.NewGoogleProvider(),
+		"slack":      oauthProviders.NewSlackProvider(),
+		"microsoft":  oauthProviders.NewMicrosoftProvider(),
+		"twitter":    oauthProviders.NewTwitterProvider(),
+		"linkedin":   oauthProviders.NewLinkedInProvider(),
+		"salesforce": oauthProviders.NewSalesforceProvider(salesforceIsSandbox),
+		"auth0":      oauthProviders.NewAuth0Provider(cfg.OAuth.Auth0Domain),
+	}
+	// Create an OAuth encryption adapter from the credential encryption service
+	oauthEncryptionAdapter := &oauthEncryptionAdapter{encryptionSvc: encryptionService}
+	app.oauthService = oauth.NewService(oauthRepo, oauthEncryptionAdapter, oauthProviderRegistry, cfg.OAuth.BaseURL)
+	app.oauthService.SetClockSkew(oauth.ClockSkewConfig{
+		Tolerance:    cfg.OAuth.ClockSkewTolerance,
+		ExpiryMargin: cfg.OAuth.TokenExpiryMargin,
+	})
+	app.oauthHandler = handlers.NewOAuthHandler(app.oauthService)
+	logger.Info("OAuth service initialized", "providers", len(oauthProviderRegistry))
+
+	// Complete approved device authorizations even when the client stops polling
+	go func() {
+		_ = oauth.NewDevicePoller(app.oauthService, 50, logger).Start(app.metricsStopCtx, 5*time.Second)
+	}()
+
+	app.credentialUsage = credential.NewUsageTracker(credentialRepo, cfg.Credential.UsageInterval, logger)
+	go app.credentialUsage.Start(app.metricsStopCtx, cfg.Credential.UsageFlushInterval)
+	app.credentialService = credential.NewServiceImpl(credentialRepo, encryptionService, logger,
+		credential.WithUsageTracker(app.credentialUsage),
+		credential.WithDeletedRetention(cfg.Credential.DeletedRetention),
+		credential.WithConnectionTester(&oauthConnectionTester{repo: oauthRepo, service: app.oauthService}))
+	app.marketplaceService.SetPrerequisiteChecker(&credentialPrerequisiteAdapter{repo: credentialRepo})
+	app.workflowService.SetCredentialChecker(&credentialPrerequisiteAdapter{repo: credentialRepo})
+	app.workflowService.SetStrictReferences(cfg.Workflow.StrictReferences)
+	app.credentialHandler = handlers.NewCredentialHandler(app.credentialService, rbac.NewRepository(db), logger)
+
+	// Disaster recovery export/import is off unless explicitly enabled
+	if cfg.Credential.RecoveryEnabled {
+		recoveryService := credential.NewRecoveryService(credentialRepo, encryptionService, logger)
+		app.recoveryHandler = handlers.NewCredentialRecoveryHandler(recoveryService, logger)
+		logger.Warn("Credential recovery export/import endpoints enabled")
+	}
 
 	// Initialize quota tracker
 	app.quotaTracker = quota.NewTracker(app.redis)
@@ -420,30 +608,9 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	// Initialize analytics service and handler
 	analyticsRepo := analytics.NewRepository(db)
 	analyticsService := analytics.NewService(analyticsRepo)
+	analyticsService.SetTenantSlots(worker.NewTenantConcurrencyLimiter(app.redis, cfg.Worker.MaxConcurrencyPerTenant))
 	app.analyticsHandler = handlers.NewAnalyticsHandler(analyticsService, logger)
 
-	// Initialize OAuth service and handler
-	oauthRepo := oauth.NewRepository(db)
-
-	// Determine Salesforce environment (sandbox or production)
-	salesforceIsSandbox := cfg.OAuth.SalesforceEnvironment == "sandbox"
-
-	oauthProviderRegistry := map[string]oauth.Provider{
-		"github":     oauthProviders.NewGitHubProvider(),
-		"google":     oauthProviders.NewGoogleProvider(),
-		"slack":      oauthProviders.NewSlackProvider(),
-		"microsoft":  oauthProviders.NewMicrosoftProvider(),
-		"twitter":    oauthProviders.NewTwitterProvider(),
-		"linkedin":   oauthProviders.NewLinkedInProvider(),
-		"salesforce": oauthProviders.NewSalesforceProvider(salesforceIsSandbox),
-		"auth0":      oauthProviders.NewAuth0Provider(cfg.OAuth.Auth0Domain),
-	}
-	// Create an OAuth encryption adapter from the credential encryption service
-	oauthEncryptionAdapter := &oauthEncryptionAdapter{encryptionSvc: encryptionService}
-	app.oauthService = oauth.NewService(oauthRepo, oauthEncryptionAdapter, oauthProviderRegistry, cfg.OAuth.BaseURL)
-	app.oauthHandler = handlers.NewOAuthHandler(app.oauthService)
-	logger.Info("OAuth service initialized", "providers", len(oauthProviderRegistry))
-
 	// Initialize SSO service and handler
 	// TODO: SSO service requires refactoring to avoid import cycles
 	// For now, initialize with nil to allow compilation
@@ -457,6 +624,7 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	auditRepo := audit.NewRepository(db)
 	app.auditService = audit.NewService(auditRepo, cfg.Audit.BufferSize, cfg.Audit.FlushInterval)
 	app.auditHandler = handlers.NewAuditHandler(app.auditService, logger)
+	app.auditTrailHandler = handlers.NewAuditTrailHandler(audit.NewTrailExporter(auditRepo), logger)
 	logger.Info("Audit service initialized",
 		"buffer_size", cfg.Audit.BufferSize,
 		"flush_interval", cfg.Audit.FlushInterval,
@@ -464,6 +632,25 @@ func NewApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 
 	// Initialize middleware
 	app.quotaChecker = apiMiddleware.NewQuotaChecker(app.tenantService, app.redis, logger)
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.BucketStore
+		if cfg.RateLimit.Backend == "memory" {
+			store = ratelimit.NewMemoryBucketStore()
+		} else {
+			store = ratelimit.NewRedisBucketStore(app.redis)
+		}
+		app.rateLimiter = apiMiddleware.NewTenantRateLimiter(store, cfg.RateLimit, logger)
+		app.quotaChecker.DelegateAPIRateLimit()
+		logger.Info("Tenant rate limiting enabled",
+			"backend", cfg.RateLimit.Backend,
+			"default_requests_per_minute", cfg.RateLimit.RequestsPerMinute,
+		)
+	}
+
+	pagination.Configure(cfg.Pagination.CursorSecret, cfg.Pagination.CursorTTL)
+	if cfg.Pagination.CursorSecret == "" {
+		logger.Warn("PAGINATION_CURSOR_SECRET not set; list cursors will not survive a restart or work across instances")
+	}
 
 	// Start collaboration cleanup goroutine
 	go func() {
@@ -506,9 +693,18 @@ func (a *App) Close() error {
 	if a.errorTracker != nil {
 		a.errorTracker.Close()
 	}
+
+	// Write credential usage recorded since the last flush
+	if a.credentialUsage != nil {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		a.credentialUsage.Flush(flushCtx)
+		cancel()
+	}
+
 	if a.db != nil {
 		a.db.Close()
 	}
+	residency.CloseDatabases(a.regionDBs)
 	if a.redis != nil {
 		a.redis.Close()
 	}
@@ -522,10 +718,17 @@ func (a *App) setupRouter() {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 
+	// Add distributed tracing middleware if enabled. It runs before the HTTP
+	// logger so access log entries carry the request's trace and span IDs.
+	if a.config.Observability.TracingEnabled {
+		r.Use(tracing.HTTPMiddleware())
+	}
+
 	// HTTP logging with configured level
 	httpLogLevel := parseHTTPLogLevel(a.config.Log.HTTPLogLevel)
 	r.Use(apiMiddleware.StructuredLoggerWithConfig(a.logger, apiMiddleware.HTTPLoggerConfig{
-		LogLevel: httpLogLevel,
+		LogLevel:   httpLogLevel,
+		LogHeaders: a.config.Log.HTTPLogHeaders,
 	}))
 
 	// Security headers middleware
@@ -537,11 +740,6 @@ func (a *App) setupRouter() {
 	}
 	r.Use(apiMiddleware.SecurityHeaders(securityHeadersConfig))
 
-	// Add distributed tracing middleware if enabled
-	if a.config.Observability.TracingEnabled {
-		r.Use(tracing.HTTPMiddleware())
-	}
-
 	// Add Sentry middleware if error tracking is enabled
 	if a.errorTracker != nil {
 		r.Use(apiMiddleware.SentryMiddleware(a.errorTracker))
@@ -604,12 +802,29 @@ func (a *App) setupRouter() {
 				r.Put("/{tenantID}", a.tenantAdminHandler.UpdateTenant)
 				r.Delete("/{tenantID}", a.tenantAdminHandler.DeleteTenant)
 				r.Put("/{tenantID}/quotas", a.tenantAdminHandler.UpdateTenantQuotas)
+				r.Put("/{tenantID}/kms-key", a.tenantAdminHandler.SetTenantKMSKey)
 				r.Get("/{tenantID}/usage", a.tenantAdminHandler.GetTenantUsage)
 				r.Put("/{tenantID}/status", a.tenantAdminHandler.SetTenantStatus)
 				r.Post("/{tenantID}/activate", a.tenantAdminHandler.ActivateTenant)
 				r.Post("/{tenantID}/suspend", a.tenantAdminHandler.SuspendTenant)
+
+				if a.recoveryHandler != nil {
+					r.Post("/{tenantID}/credentials/export", a.recoveryHandler.Export)
+					r.Post("/{tenantID}/credentials/import", a.recoveryHandler.Import)
+				}
+				r.Get("/{tenantID}/audit-trail", a.auditTrailHandler.Export)
+
+				r.Get("/{tenantID}/oauth/health", a.oauthHandler.GetConnectionHealth)
+
+				r.Get("/{tenantID}/feature-flags", a.featureFlagHandler.ListTenant)
+				r.Put("/{tenantID}/feature-flags/{flag}", a.featureFlagHandler.SetOverride)
+				r.Delete("/{tenantID}/feature-flags/{flag}", a.featureFlagHandler.DeleteOverride)
 			})
 
+			// Feature flag global defaults
+			r.Get("/feature-flags", a.featureFlagHandler.List)
+			r.Put("/feature-flags/{flag}", a.featureFlagHandler.SetDefault)
+
 			// SSO provider management routes (admin only)
 			// TODO: Re-enable when SSO service is properly initialized
 			/* r.Route("/sso", func(r chi.Router) {
@@ -620,6 +835,9 @@ func (a *App) setupRouter() {
 				r.Delete("/providers/{id}", a.ssoHandler.DeleteProvider)
 			}) */
 
+			// OAuth connection management (admin only)
+			r.Post("/oauth/providers/{provider}/revoke", a.oauthHandler.RevokeConnectionsByProvider)
+
 			// Audit log routes (admin only)
 			r.Route("/audit", func(r chi.Router) {
 				r.Get("/events", a.auditHandler.QueryEvents)
@@ -636,6 +854,9 @@ func (a *App) setupRouter() {
 				TenantConfig: a.config.Tenant,
 			}
 			r.Use(apiMiddleware.TenantContextWithConfig(a.tenantService, tenantMiddlewareCfg))
+			if a.rateLimiter != nil {
+				r.Use(a.rateLimiter.Limit())
+			}
 			r.Use(a.quotaChecker.CheckQuotas())
 
 			// Current tenant info routes (available to all authenticated users)
@@ -649,11 +870,15 @@ func (a *App) setupRouter() {
 			r.Route("/workflows", func(r chi.Router) {
 				r.Get("/", a.workflowHandler.List)
 				r.Post("/", a.workflowHandler.Create)
+				r.Post("/import", a.workflowHandler.Import)
+				r.Post("/lint", a.workflowHandler.Lint)
 				r.Get("/{workflowID}", a.workflowHandler.Get)
 				r.Put("/{workflowID}", a.workflowHandler.Update)
 				r.Delete("/{workflowID}", a.workflowHandler.Delete)
 				r.Post("/{workflowID}/execute", a.workflowHandler.Execute)
 				r.Post("/{workflowID}/dry-run", a.workflowHandler.DryRun)
+				r.Post("/{workflowID}/simulate", a.workflowHandler.Simulate)
+				r.Get("/{workflowID}/export", a.workflowHandler.Export)
 
 				// Bulk operations
 				r.Route("/bulk", func(r chi.Router) {
@@ -671,6 +896,15 @@ func (a *App) setupRouter() {
 					r.Post("/{version}/restore", a.workflowHandler.RestoreVersion)
 				})
 
+				// Environment variables overriding the tenant's for a specific workflow
+				r.Route("/{workflowID}/env-vars", func(r chi.Router) {
+					r.Get("/", a.envVarHandler.List)
+					r.Post("/", a.envVarHandler.Create)
+				})
+
+				// Cached results of the workflow's nodes
+				r.Delete("/{workflowID}/node-cache", a.nodeCacheHandler.Invalidate)
+
 				// Schedule routes for a specific workflow
 				r.Route("/{workflowID}/schedules", func(r chi.Router) {
 					r.Get("/", a.scheduleHandler.List)
@@ -681,12 +915,33 @@ func (a *App) setupRouter() {
 				r.Get("/{id}/collaborate", a.collaborationHandler.HandleWorkflowCollaboration)
 			})
 
+			// Tenant-wide environment variables, read by workflows as ${env.KEY}
+			r.Route("/env-vars", func(r chi.Router) {
+				r.Get("/", a.envVarHandler.List)
+				r.Post("/", a.envVarHandler.Create)
+				r.Put("/{id}", a.envVarHandler.Update)
+				r.Delete("/{id}", a.envVarHandler.Delete)
+			})
+
+			// Cached node results across the tenant's workflows
+			r.Delete("/node-cache", a.nodeCacheHandler.Invalidate)
+
 			// Execution routes
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", a.executionHandler.ListExecutionsAdvanced)
 				r.Get("/stats", a.executionHandler.GetExecutionStats)
+				r.Get("/search", a.executionHandler.SearchExecutions)
 				r.Get("/{executionID}", a.workflowHandler.GetExecution)
 				r.Get("/{executionID}/steps", a.executionHandler.GetExecutionWithSteps)
+				r.Post("/{executionID}/cancel", a.executionHandler.CancelExecution)
+				r.Post("/{executionID}/retry", a.executionHandler.RetryExecution)
+			})
+
+			// Dead-letter queue of failed executions
+			r.Route("/dead-letters", func(r chi.Router) {
+				r.Get("/", a.deadLetterHandler.List)
+				r.Get("/{deadLetterID}", a.deadLetterHandler.Get)
+				r.Post("/{deadLetterID}/requeue", a.deadLetterHandler.Requeue)
 			})
 
 			// Metrics routes
@@ -694,6 +949,7 @@ func (a *App) setupRouter() {
 				r.Get("/trends", a.metricsHandler.GetExecutionTrends)
 				r.Get("/duration", a.metricsHandler.GetDurationStats)
 				r.Get("/failures", a.metricsHandler.GetTopFailures)
+				r.Get("/stats", a.metricsHandler.GetExecutionStats)
 				r.Get("/trigger-breakdown", a.metricsHandler.GetTriggerBreakdown)
 			})
 
@@ -709,6 +965,8 @@ func (a *App) setupRouter() {
 				r.Get("/{scheduleID}", a.scheduleHandler.Get)
 				r.Put("/{scheduleID}", a.scheduleHandler.Update)
 				r.Delete("/{scheduleID}", a.scheduleHandler.Delete)
+				r.Post("/{scheduleID}/pause", a.scheduleHandler.Pause)
+				r.Post("/{scheduleID}/resume", a.scheduleHandler.Resume)
 				r.Post("/parse-cron", a.scheduleHandler.ParseCron)
 				r.Post("/preview", a.scheduleHandler.PreviewSchedule)
 
@@ -727,6 +985,9 @@ func (a *App) setupRouter() {
 				r.Post("/{id}/regenerate-secret", a.webhookManagementHandler.RegenerateSecret)
 				r.Post("/{id}/test", a.webhookManagementHandler.TestWebhook)
 				r.Get("/{id}/events", a.webhookManagementHandler.GetEventHistory)
+				r.Get("/{id}/deliveries", a.webhookManagementHandler.GetDeliveries)
+				r.Get("/{id}/captures", a.webhookManagementHandler.ListCaptures)
+				r.Post("/{webhookID}/captures/{captureID}/replay", a.webhookReplayHandler.ReplayCapture)
 				r.Post("/{webhookID}/events/replay", a.webhookReplayHandler.BatchReplayEvents)
 
 				// Filter routes
@@ -748,6 +1009,10 @@ func (a *App) setupRouter() {
 			// Event types registry routes
 			r.Route("/event-types", func(r chi.Router) {
 				r.Get("/", a.eventTypesHandler.List)
+				r.Get("/{name}/schema", a.eventTypesHandler.GetSchema)
+
+				// Registering schemas changes what every tenant's webhooks accept
+				r.With(apiMiddleware.RequireAdmin()).Post("/", a.eventTypesHandler.Register)
 			})
 
 			// WebSocket routes
@@ -761,13 +1026,16 @@ func (a *App) setupRouter() {
 			r.Route("/credentials", func(r chi.Router) {
 				r.Get("/", a.credentialHandler.List)
 				r.Post("/", a.credentialHandler.Create)
+				r.Post("/bulk/delete", a.credentialHandler.BulkDelete)
 				r.Get("/{credentialID}", a.credentialHandler.Get)
 				r.Get("/{credentialID}/value", a.credentialHandler.GetValue) // Sensitive endpoint
 				r.Put("/{credentialID}", a.credentialHandler.Update)
 				r.Delete("/{credentialID}", a.credentialHandler.Delete)
+				r.Post("/{credentialID}/restore", a.credentialHandler.Restore)
 				r.Post("/{credentialID}/rotate", a.credentialHandler.Rotate)
 				r.Get("/{credentialID}/versions", a.credentialHandler.ListVersions)
 				r.Get("/{credentialID}/access-log", a.credentialHandler.GetAccessLog)
+				r.Post("/{credentialID}/test", a.credentialHandler.Test)
 			})
 
 			// Suggestions routes (Smart error analysis)
@@ -785,6 +1053,7 @@ func (a *App) setupRouter() {
 
 			// AI Workflow Builder routes
 			r.Route("/ai/workflows", func(r chi.Router) {
+				r.Use(apiMiddleware.RequireFeature(a.featureFlagService, featureflag.FlagAIBuilder))
 				r.Post("/generate", a.aiBuilderHandler.Generate)
 				r.Post("/refine", a.aiBuilderHandler.Refine)
 				r.Route("/conversations", func(r chi.Router) {
@@ -802,6 +1071,10 @@ func (a *App) setupRouter() {
 				r.Get("/templates/{id}", a.marketplaceHandler.GetTemplate)
 				r.Post("/templates", a.marketplaceHandler.PublishTemplate)
 				r.Post("/templates/{id}/install", a.marketplaceHandler.InstallTemplate)
+				r.Get("/templates/{id}/versions", a.marketplaceHandler.GetTemplateVersions)
+				r.Post("/templates/{id}/versions", a.marketplaceHandler.PublishVersion)
+				r.Get("/templates/{id}/versions/{version}", a.marketplaceHandler.GetTemplateVersion)
+				r.Get("/installed/updates", a.marketplaceHandler.ListInstalledUpdates)
 				r.Get("/trending", a.marketplaceHandler.GetTrending)
 				r.Get("/popular", a.marketplaceHandler.GetPopular)
 
@@ -833,6 +1106,9 @@ func (a *App) setupRouter() {
 					r.Get("/review-reports", a.marketplaceHandler.GetReviewReports)
 					r.Put("/review-reports/{reportId}", a.marketplaceHandler.ResolveReviewReport)
 					r.Put("/reviews/{reviewId}/hide", a.marketplaceHandler.HideReview)
+
+					// Publisher verification
+					r.Put("/templates/{id}/verify", a.marketplaceHandler.SetVerified)
 				})
 			})
 
@@ -844,6 +1120,7 @@ func (a *App) setupRouter() {
 				r.Get("/top-workflows", a.analyticsHandler.GetTopWorkflows)
 				r.Get("/errors", a.analyticsHandler.GetErrorBreakdown)
 				r.Get("/workflows/{workflowID}/nodes", a.analyticsHandler.GetNodePerformance)
+				r.Get("/concurrency", a.analyticsHandler.GetConcurrencyMetrics)
 			})
 
 			// OAuth routes
@@ -851,9 +1128,12 @@ func (a *App) setupRouter() {
 				r.Get("/providers", a.oauthHandler.ListProviders)
 				r.Get("/authorize/{provider}", a.oauthHandler.Authorize)
 				r.Get("/callback/{provider}", a.oauthHandler.Callback)
+				r.Post("/device/token", a.oauthHandler.PollDeviceToken)
+				r.Post("/device/{provider}", a.oauthHandler.StartDeviceAuthorization)
 				r.Get("/connections", a.oauthHandler.ListConnections)
 				r.Get("/connections/{id}", a.oauthHandler.GetConnection)
 				r.Delete("/connections/{id}", a.oauthHandler.RevokeConnection)
+				r.Post("/connections/bulk/delete", a.oauthHandler.RevokeConnections)
 				r.Post("/connections/{id}/test", a.oauthHandler.TestConnection)
 			})
 		})
@@ -889,6 +1169,11 @@ func (a *App) setupRouter() {
 		r.Post("/{workflowID}/{webhookID}", a.webhookHandler.Handle)
 	})
 
+	// Slack interactions endpoint (public, verified by Slack request signature)
+	if a.slackInteractionHandler != nil {
+		r.Post("/slack/interactions", a.slackInteractionHandler.Handle)
+	}
+
 	// SSO authentication endpoints (public)
 	// TODO: Re-enable when SSO service is properly initialized
 	/* r.Route("/sso", func(r chi.Router) {
@@ -1031,10 +1316,11 @@ type workflowServiceMarketplaceAdapter struct {
 	workflowService *workflow.Service
 }
 
-func (w *workflowServiceMarketplaceAdapter) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage) (string, error) {
+func (w *workflowServiceMarketplaceAdapter) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage, status string) (string, error) {
 	input := workflow.CreateWorkflowInput{
 		Name:       workflowName,
 		Definition: definition,
+		Status:     workflow.WorkflowStatus(status),
 	}
 
 	created, err := w.workflowService.Create(ctx, tenantID, userID, input)
@@ -1044,6 +1330,44 @@ func (w *workflowServiceMarketplaceAdapter) CreateFromTemplate(ctx context.Conte
 	return created.ID, nil
 }
 
+// oauthConnectionTester tests the OAuth connection backing an oauth2
+// credential, refusing connections that belong to another tenant
+type oauthConnectionTester struct {
+	repo    oauth.OAuthRepository
+	service *oauth.Service
+}
+
+func (t *oauthConnectionTester) TestConnection(ctx context.Context, tenantID, connectionID string) error {
+	conn, err := t.repo.GetConnection(ctx, connectionID)
+	if err != nil {
+		return err
+	}
+	if conn.TenantID != tenantID {
+		return oauth.ErrConnectionNotFound
+	}
+	return t.service.TestConnection(ctx, connectionID)
+}
+
+// credentialPrerequisiteAdapter adapts credential.Repository to the
+// marketplace.PrerequisiteChecker and workflow.CredentialChecker interfaces
+type credentialPrerequisiteAdapter struct {
+	repo *credential.Repository
+}
+
+func (a *credentialPrerequisiteAdapter) MissingCredentials(ctx context.Context, tenantID string, names []string) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		if _, err := a.repo.GetByName(ctx, tenantID, name); err != nil {
+			if errors.Is(err, credential.ErrNotFound) {
+				missing = append(missing, name)
+				continue
+			}
+			return nil, err
+		}
+	}
+	return missing, nil
+}
+
 // parseHTTPLogLevel converts string log level to slog.Level for HTTP access logs
 func parseHTTPLogLevel(level string) slog.Level {
 	switch level {
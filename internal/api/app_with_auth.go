@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/gorax/gorax/internal/api/handlers"
 	apiMiddleware "github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/database"
 	"github.com/gorax/gorax/internal/executor"
 	"github.com/gorax/gorax/internal/tenant"
 	"github.com/gorax/gorax/internal/user"
@@ -59,7 +61,7 @@ func NewAppWithAuth(cfg *config.Config, logger *slog.Logger) (*AppWithAuth, erro
 	}
 
 	// Initialize database connection
-	db, err := sqlx.Connect("postgres", cfg.Database.ConnectionString())
+	db, err := database.Connect(context.Background(), cfg.Database)
 	if err != nil {
 		return nil, err
 	}
@@ -93,9 +95,12 @@ func NewAppWithAuth(cfg *config.Config, logger *slog.Logger) (*AppWithAuth, erro
 	// Initialize executor with WebSocket broadcaster
 	broadcaster := websocket.NewHubBroadcaster(app.wsHub)
 	workflowExecutor := executor.NewWithBroadcaster(workflowRepo, logger, broadcaster)
+	workflowExecutor.SetRedactor(executor.NewRedactor(cfg.Redaction.SensitiveKeys))
+	workflowExecutor.SetMaxExecutionDuration(cfg.Workflow.MaxExecutionDuration)
 
 	// Wire up dependencies to avoid import cycles
 	app.workflowService.SetExecutor(workflowExecutor)
+	workflowExecutor.SetFailureHandler(app.workflowService)
 	app.workflowService.SetWebhookService(app.webhookService)
 
 	// Initialize handlers
@@ -227,6 +232,7 @@ func (a *AppWithAuth) setupRouter() {
 					r.Delete("/{workflowID}", a.workflowHandler.Delete)
 					r.Post("/{workflowID}/execute", a.workflowHandler.Execute)
 					r.Post("/{workflowID}/dry-run", a.workflowHandler.DryRun)
+					r.Post("/{workflowID}/simulate", a.workflowHandler.Simulate)
 				})
 
 				// Execution routes
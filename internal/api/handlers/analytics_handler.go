@@ -23,6 +23,7 @@ type AnalyticsService interface {
 	GetTopWorkflows(ctx context.Context, tenantID string, timeRange analytics.TimeRange, limit int) (*analytics.TopWorkflows, error)
 	GetErrorBreakdown(ctx context.Context, tenantID string, timeRange analytics.TimeRange) (*analytics.ErrorBreakdown, error)
 	GetNodePerformance(ctx context.Context, tenantID, workflowID string) (*analytics.NodePerformance, error)
+	GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange analytics.TimeRange) (*analytics.ConcurrencyMetrics, error)
 }
 
 // AnalyticsHandler handles analytics-related HTTP requests
@@ -317,6 +318,54 @@ func (h *AnalyticsHandler) GetNodePerformance(w http.ResponseWriter, r *http.Req
 	_ = response.OK(w, performance)
 }
 
+// GetConcurrencyMetrics retrieves execution concurrency and throughput
+// @Summary Get workflow concurrency metrics
+// @Description Returns in-flight and queued executions, average queue wait and executions per hour for each workflow, with a tenant-wide rollup and hourly buckets
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param start_date query string true "Start date (RFC3339 format)" example(2024-01-01T00:00:00Z)
+// @Param end_date query string true "End date (RFC3339 format, at most 31 days after start_date)" example(2024-01-02T00:00:00Z)
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} analytics.ConcurrencyMetrics "Concurrency metrics"
+// @Failure 400 {object} map[string]string "Invalid time range"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/analytics/concurrency [get]
+func (h *AnalyticsHandler) GetConcurrencyMetrics(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	timeRange, err := h.parseTimeRange(r)
+	if err != nil {
+		_ = response.BadRequest(w, "invalid time range: "+err.Error())
+		return
+	}
+	switch window := timeRange.EndDate.Sub(timeRange.StartDate); {
+	case window < 0:
+		_ = response.BadRequest(w, "invalid time range: end_date must be after start_date")
+		return
+	case window > analytics.MaxConcurrencyWindow:
+		_ = response.BadRequest(w, "invalid time range: must not exceed 31 days")
+		return
+	}
+
+	metrics, err := h.service.GetConcurrencyMetrics(r.Context(), tenantID, timeRange)
+	if err != nil {
+		h.logger.Error("failed to get concurrency metrics",
+			"error", err,
+			"tenant_id", tenantID,
+		)
+		_ = response.InternalError(w, "failed to get concurrency metrics")
+		return
+	}
+
+	_ = response.OK(w, metrics)
+}
+
 // parseTimeRange parses start_date and end_date from query parameters
 func (h *AnalyticsHandler) parseTimeRange(r *http.Request) (analytics.TimeRange, error) {
 	startDateStr := r.URL.Query().Get("start_date")
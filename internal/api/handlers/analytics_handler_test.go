@@ -72,6 +72,14 @@ func (m *MockAnalyticsService) GetNodePerformance(ctx context.Context, tenantID,
 	return args.Get(0).(*analytics.NodePerformance), args.Error(1)
 }
 
+func (m *MockAnalyticsService) GetConcurrencyMetrics(ctx context.Context, tenantID string, timeRange analytics.TimeRange) (*analytics.ConcurrencyMetrics, error) {
+	args := m.Called(ctx, tenantID, timeRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.ConcurrencyMetrics), args.Error(1)
+}
+
 func newTestAnalyticsHandler() (*AnalyticsHandler, *MockAnalyticsService) {
 	mockService := new(MockAnalyticsService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -400,6 +408,66 @@ func TestGetTenantOverview_NoTenantContext(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestGetConcurrencyMetrics_Success(t *testing.T) {
+	handler, mockService := newTestAnalyticsHandler()
+
+	activeSlots := 4
+	expected := &analytics.ConcurrencyMetrics{
+		Tenant: analytics.ConcurrencyRollup{InFlight: 3, Queued: 7, ExecutionsPerHour: 12, ActiveSlots: &activeSlots, SlotLimit: 10},
+		Workflows: []analytics.WorkflowConcurrency{
+			{WorkflowID: "wf-health", WorkflowName: "Health Check", InFlight: 3, Queued: 7, AvgQueueWaitMs: 4200, ExecutionsPerHour: 12},
+		},
+		Hourly: []analytics.HourlyExecutions{},
+	}
+
+	mockService.On("GetConcurrencyMetrics",
+		mock.Anything,
+		"tenant-123",
+		mock.AnythingOfType("analytics.TimeRange"),
+	).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/concurrency?start_date=2024-01-01T00:00:00Z&end_date=2024-01-02T00:00:00Z", nil)
+	req = addTenantContext(req, "tenant-123")
+	w := httptest.NewRecorder()
+
+	handler.GetConcurrencyMetrics(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response analytics.ConcurrencyMetrics
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, 7, response.Tenant.Queued)
+	require.NotNil(t, response.Tenant.ActiveSlots)
+	assert.Equal(t, 4, *response.Tenant.ActiveSlots)
+	require.Len(t, response.Workflows, 1)
+	assert.Equal(t, int64(4200), response.Workflows[0].AvgQueueWaitMs)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetConcurrencyMetrics_InvalidTimeRange(t *testing.T) {
+	tests := map[string]string{
+		"missing dates":   "",
+		"reversed":        "?start_date=2024-01-02T00:00:00Z&end_date=2024-01-01T00:00:00Z",
+		"over 31 days":    "?start_date=2024-01-01T00:00:00Z&end_date=2024-03-01T00:00:00Z",
+		"malformed dates": "?start_date=yesterday&end_date=today",
+	}
+
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler, mockService := newTestAnalyticsHandler()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/concurrency"+query, nil)
+			req = addTenantContext(req, "tenant-123")
+			w := httptest.NewRecorder()
+
+			handler.GetConcurrencyMetrics(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "GetConcurrencyMetrics")
+		})
+	}
+}
+
 // Integration Tests - Full Request/Response Cycle
 
 func TestAnalyticsIntegration_GetTenantOverview_FullCycle(t *testing.T) {
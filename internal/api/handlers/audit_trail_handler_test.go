@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorax/gorax/internal/audit"
+)
+
+// fakeTrailExporter records the filter of the last export
+type fakeTrailExporter struct {
+	filter audit.AuditFilter
+}
+
+func (f *fakeTrailExporter) ExportAuditLogs(ctx context.Context, tenantID string, filter audit.AuditFilter) (io.Reader, error) {
+	f.filter = filter
+	return strings.NewReader(`{"source":"oauth","id":"log-1"}` + "\n"), nil
+}
+
+func auditTrailRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/audit-trail"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tenantID", "tenant-1")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAuditTrailHandler_Export(t *testing.T) {
+	exporter := &fakeTrailExporter{}
+	handler := NewAuditTrailHandler(exporter, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Export(w, auditTrailRequest("?start=2026-01-01T00:00:00Z&action=read&action=authorize&actor=user-1"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"source":"oauth","id":"log-1"}`+"\n", w.Body.String())
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), exporter.filter.StartTime)
+	assert.True(t, exporter.filter.EndTime.IsZero())
+	assert.Equal(t, []string{"read", "authorize"}, exporter.filter.Actions)
+	assert.Equal(t, "user-1", exporter.filter.Actor)
+}
+
+func TestAuditTrailHandler_Export_InvalidTime(t *testing.T) {
+	handler := NewAuditTrailHandler(&fakeTrailExporter{}, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Export(w, auditTrailRequest("?end=yesterday"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
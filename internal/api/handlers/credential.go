@@ -2,28 +2,34 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/api/response"
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/rbac"
 	"github.com/gorax/gorax/internal/validation"
 )
 
 // CredentialHandler handles credential-related HTTP requests
 type CredentialHandler struct {
-	service credential.Service
-	logger  *slog.Logger
+	service     credential.Service
+	permissions middleware.PermissionChecker
+	logger      *slog.Logger
 }
 
 // NewCredentialHandler creates a new credential handler
-func NewCredentialHandler(service credential.Service, logger *slog.Logger) *CredentialHandler {
+func NewCredentialHandler(service credential.Service, permissions middleware.PermissionChecker, logger *slog.Logger) *CredentialHandler {
 	return &CredentialHandler{
-		service: service,
-		logger:  logger,
+		service:     service,
+		permissions: permissions,
+		logger:      logger,
 	}
 }
 
@@ -45,8 +51,8 @@ func (h *CredentialHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	cred, err := h.service.Create(r.Context(), tenantID, user.ID, input)
 	if err != nil {
-		if _, ok := err.(*credential.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to create credential",
@@ -86,6 +92,17 @@ func (h *CredentialHandler) List(w http.ResponseWriter, r *http.Request) {
 		Search: r.URL.Query().Get("search"),
 	}
 
+	page, cursorMode, err := cursorPage(r)
+	if err != nil {
+		_ = response.WriteError(w, err)
+		return
+	}
+	if cursorMode {
+		// The repository applies the cursor page; the service must not slice it again
+		filter.Page = page
+		limit, offset = 0, 0
+	}
+
 	credentials, err := h.service.List(r.Context(), tenantID, filter, limit, offset)
 	if err != nil {
 		h.logger.Error("failed to list credentials",
@@ -95,6 +112,11 @@ func (h *CredentialHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if cursorMode {
+		_ = response.OK(w, pagination.NewPage(credentials, page.Limit, credentialPosition))
+		return
+	}
+
 	_ = response.Paginated(w, credentials, limit, offset, 0)
 }
 
@@ -110,8 +132,8 @@ func (h *CredentialHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	cred, err := h.service.GetByID(r.Context(), tenantID, credentialID)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to get credential",
@@ -127,7 +149,11 @@ func (h *CredentialHandler) Get(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetValue retrieves the decrypted credential value (restricted access)
+// GetValue retrieves the decrypted credential value (restricted access).
+// Listing and reading metadata needs credential:read; decrypting the value
+// needs credential:read_value. The keys query parameter limits the response
+// to those keys, which callers holding credential:read_value:<key> for each
+// of them may read without credential:read_value.
 func (h *CredentialHandler) GetValue(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
 	user := middleware.GetUser(r)
@@ -138,14 +164,34 @@ func (h *CredentialHandler) GetValue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	value, err := h.service.GetValue(r.Context(), tenantID, credentialID, user.ID)
+	keys := valueKeys(r)
+
+	allowed, err := h.canReadValue(r, user.ID, tenantID, keys)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
-			return
+		h.logger.Error("failed to check credential permission",
+			"error", err,
+			"tenant_id", tenantID,
+			"credential_id", credentialID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to check permission")
+		return
+	}
+	if !allowed {
+		if err := h.service.LogDeniedAccess(r.Context(), tenantID, credentialID, user.ID, keys); err != nil {
+			h.logger.Error("failed to log denied credential access",
+				"error", err,
+				"tenant_id", tenantID,
+				"credential_id", credentialID,
+				"user_id", user.ID)
 		}
-		if err == credential.ErrUnauthorized {
-			_ = response.Forbidden(w, "unauthorized access to credential value")
+		_ = response.WriteError(w, domainError(credential.ErrUnauthorized))
+		return
+	}
+
+	value, err := h.service.GetCredentialValue(r.Context(), tenantID, credentialID, user.ID, keys)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to get credential value",
@@ -162,6 +208,40 @@ func (h *CredentialHandler) GetValue(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// canReadValue reports whether the user may read the requested keys of a
+// credential value, or the whole value when no keys are requested
+func (h *CredentialHandler) canReadValue(r *http.Request, userID, tenantID string, keys []string) (bool, error) {
+	allowed, err := h.permissions.HasPermission(r.Context(), userID, tenantID, rbac.ResourceCredential, rbac.ActionReadValue)
+	if err != nil || allowed || len(keys) == 0 {
+		return allowed, err
+	}
+
+	for _, key := range keys {
+		allowed, err := h.permissions.HasPermission(r.Context(), userID, tenantID, rbac.ResourceCredential, rbac.ReadValueKeyAction(key))
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// valueKeys parses the keys query parameter, given repeated or comma-separated
+func valueKeys(r *http.Request) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, param := range r.URL.Query()["keys"] {
+		for _, key := range strings.Split(param, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // Update updates a credential's metadata
 func (h *CredentialHandler) Update(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
@@ -181,12 +261,8 @@ func (h *CredentialHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	cred, err := h.service.Update(r.Context(), tenantID, credentialID, user.ID, input)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
-			return
-		}
-		if _, ok := err.(*credential.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to update credential",
@@ -203,7 +279,8 @@ func (h *CredentialHandler) Update(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// Delete deletes a credential
+// Delete deletes a credential. It is soft-deleted and can be restored until
+// the retention window passes, unless ?permanent=true purges it now.
 func (h *CredentialHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
 	user := middleware.GetUser(r)
@@ -214,10 +291,15 @@ func (h *CredentialHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.Delete(r.Context(), tenantID, credentialID, user.ID)
+	var err error
+	if r.URL.Query().Get("permanent") == "true" {
+		err = h.service.Purge(r.Context(), tenantID, credentialID, user.ID)
+	} else {
+		err = h.service.Delete(r.Context(), tenantID, credentialID, user.ID)
+	}
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to delete credential",
@@ -232,6 +314,95 @@ func (h *CredentialHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w)
 }
 
+// Restore undoes the deletion of a credential deleted within the retention
+// window
+func (h *CredentialHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	user := middleware.GetUser(r)
+	credentialID := chi.URLParam(r, "credentialID")
+
+	if tenantID == "" || user == nil {
+		_ = response.InternalError(w, "tenant or user context missing")
+		return
+	}
+
+	cred, err := h.service.Restore(r.Context(), tenantID, credentialID, user.ID)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to restore credential",
+			"error", err,
+			"tenant_id", tenantID,
+			"credential_id", credentialID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to restore credential")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": cred,
+	})
+}
+
+// BulkDeleteCredentialsInput represents input for bulk credential deletion
+type BulkDeleteCredentialsInput struct {
+	IDs       []string `json:"ids"`
+	Permanent bool     `json:"permanent"`
+}
+
+// BulkDelete deletes several credentials, soft-deleting them unless
+// permanent is set
+// POST /api/v1/credentials/bulk/delete
+func (h *CredentialHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	user := middleware.GetUser(r)
+
+	if tenantID == "" || user == nil {
+		_ = response.InternalError(w, "tenant or user context missing")
+		return
+	}
+
+	var input BulkDeleteCredentialsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	if len(input.IDs) == 0 {
+		_ = response.BadRequest(w, "at least one credential ID is required")
+		return
+	}
+
+	if len(input.IDs) > credential.MaxBulkDelete {
+		_ = response.BadRequest(w, fmt.Sprintf("cannot delete more than %d credentials at once", credential.MaxBulkDelete))
+		return
+	}
+
+	deleted, failed := h.service.BulkDelete(r.Context(), tenantID, user.ID, input.IDs, input.Permanent)
+
+	result := BulkOperationResult{
+		Success: deleted,
+		Failed:  make([]BulkOperationError, 0, len(failed)),
+	}
+	for _, f := range failed {
+		result.Failed = append(result.Failed, BulkOperationError{ID: f.ID, Error: f.Error})
+	}
+
+	h.logger.Info("bulk credential deletion completed",
+		"tenant_id", tenantID,
+		"user_id", user.ID,
+		"permanent", input.Permanent,
+		"success_count", len(deleted),
+		"failed_count", len(failed),
+	)
+
+	_ = response.OK(w, map[string]any{
+		"data": result,
+	})
+}
+
 // Rotate creates a new version of the credential value
 func (h *CredentialHandler) Rotate(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
@@ -251,12 +422,8 @@ func (h *CredentialHandler) Rotate(w http.ResponseWriter, r *http.Request) {
 
 	cred, err := h.service.Rotate(r.Context(), tenantID, credentialID, user.ID, input)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
-			return
-		}
-		if _, ok := err.(*credential.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to rotate credential",
@@ -285,8 +452,8 @@ func (h *CredentialHandler) ListVersions(w http.ResponseWriter, r *http.Request)
 
 	versions, err := h.service.ListVersions(r.Context(), tenantID, credentialID)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to list credential versions",
@@ -321,8 +488,8 @@ func (h *CredentialHandler) GetAccessLog(w http.ResponseWriter, r *http.Request)
 
 	logs, err := h.service.GetAccessLog(r.Context(), tenantID, credentialID, limit, offset)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to get access log",
@@ -336,8 +503,10 @@ func (h *CredentialHandler) GetAccessLog(w http.ResponseWriter, r *http.Request)
 	_ = response.Paginated(w, logs, limit, offset, 0)
 }
 
-// Test validates a credential by attempting to decrypt and verify its value
-// This endpoint does NOT return the decrypted value, only confirmation of validity
+// Test makes a probe request with the credential applied and reports
+// whether it worked. The credential value is sent to the endpoint configured
+// in its metadata, so testing needs the same permission as reading the value.
+// The response never includes the value.
 func (h *CredentialHandler) Test(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
 	user := middleware.GetUser(r)
@@ -348,27 +517,38 @@ func (h *CredentialHandler) Test(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Attempt to get the credential value - this verifies decryption works
-	_, err := h.service.GetValue(r.Context(), tenantID, credentialID, user.ID)
+	allowed, err := h.canReadValue(r, user.ID, tenantID, nil)
+	if err != nil {
+		h.logger.Error("failed to check credential permission",
+			"error", err,
+			"tenant_id", tenantID,
+			"credential_id", credentialID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to check permission")
+		return
+	}
+	if !allowed {
+		_ = response.WriteError(w, domainError(credential.ErrUnauthorized))
+		return
+	}
+
+	result, err := h.service.TestCredential(r.Context(), tenantID, credentialID, user.ID)
 	if err != nil {
-		if err == credential.ErrNotFound {
-			_ = response.NotFound(w, "credential not found")
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
-		h.logger.Error("credential test failed",
+		h.logger.Error("failed to test credential",
 			"error", err,
 			"tenant_id", tenantID,
-			"credential_id", credentialID)
-		_ = response.OK(w, map[string]any{
-			"valid":   false,
-			"message": "credential validation failed: unable to decrypt",
-		})
+			"credential_id", credentialID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to test credential")
 		return
 	}
 
 	_ = response.OK(w, map[string]any{
-		"valid":   true,
-		"message": "credential is valid and decryptable",
+		"data": result,
 	})
 }
 
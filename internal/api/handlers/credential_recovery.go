@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/credential"
+)
+
+// maxRecoveryBundleBytes bounds the size of an imported bundle
+const maxRecoveryBundleBytes = 32 << 20
+
+// CredentialRecoveryService exports and imports credential recovery bundles
+type CredentialRecoveryService interface {
+	ExportCredentials(ctx context.Context, tenantID, exportedBy string, recoveryKey *rsa.PublicKey) (*credential.CredentialBundle, error)
+	ImportCredentials(ctx context.Context, tenantID, importedBy string, bundle *credential.CredentialBundle, recoveryKey *rsa.PrivateKey) (*credential.ImportResult, error)
+}
+
+// CredentialRecoveryHandler handles the admin disaster recovery endpoints
+// for moving a tenant's credentials between clusters
+type CredentialRecoveryHandler struct {
+	service CredentialRecoveryService
+	logger  *slog.Logger
+}
+
+// NewCredentialRecoveryHandler creates a new credential recovery handler
+func NewCredentialRecoveryHandler(service CredentialRecoveryService, logger *slog.Logger) *CredentialRecoveryHandler {
+	return &CredentialRecoveryHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ExportCredentialsRequest names the recovery key a bundle is sealed for
+type ExportCredentialsRequest struct {
+	RecoveryPublicKey string `json:"recovery_public_key"`
+}
+
+// ImportCredentialsRequest carries a bundle and the recovery key that opens it
+type ImportCredentialsRequest struct {
+	Bundle             *credential.CredentialBundle `json:"bundle"`
+	RecoveryPrivateKey string                       `json:"recovery_private_key"`
+}
+
+// Export handles POST /api/v1/admin/tenants/{tenantID}/credentials/export
+// The response is a bundle of the tenant's credentials sealed for the given
+// PEM encoded RSA public key; it never contains plaintext values.
+func (h *CredentialRecoveryHandler) Export(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	user := middleware.GetUser(r)
+	if user == nil {
+		_ = response.Unauthorized(w, "user context missing")
+		return
+	}
+
+	var req ExportCredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	recoveryKey, err := credential.ParseRecoveryPublicKey([]byte(req.RecoveryPublicKey))
+	if err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	bundle, err := h.service.ExportCredentials(r.Context(), tenantID, user.ID, recoveryKey)
+	if err != nil {
+		h.logger.Error("failed to export credentials",
+			"error", err,
+			"tenant_id", tenantID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to export credentials")
+		return
+	}
+
+	_ = response.OK(w, bundle)
+}
+
+// Import handles POST /api/v1/admin/tenants/{tenantID}/credentials/import
+// Bundled credentials are re-encrypted under this cluster's encryption; names
+// that already exist are skipped.
+func (h *CredentialRecoveryHandler) Import(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	user := middleware.GetUser(r)
+	if user == nil {
+		_ = response.Unauthorized(w, "user context missing")
+		return
+	}
+
+	var req ImportCredentialsRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRecoveryBundleBytes)).Decode(&req); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+	if req.Bundle == nil {
+		_ = response.BadRequest(w, "bundle is required")
+		return
+	}
+
+	recoveryKey, err := credential.ParseRecoveryPrivateKey([]byte(req.RecoveryPrivateKey))
+	if err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	result, err := h.service.ImportCredentials(r.Context(), tenantID, user.ID, req.Bundle, recoveryKey)
+	if err != nil {
+		if errors.Is(err, credential.ErrRecoveryKeyMismatch) || errors.Is(err, credential.ErrUnsupportedBundle) {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
+		h.logger.Error("failed to import credentials",
+			"error", err,
+			"tenant_id", tenantID,
+			"user_id", user.ID)
+		_ = response.InternalError(w, "failed to import credentials")
+		return
+	}
+
+	_ = response.OK(w, result)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/credential"
+)
+
+// fakeRecoveryService records the caller of each export and import
+type fakeRecoveryService struct {
+	exportedBy string
+	importErr  error
+}
+
+func (f *fakeRecoveryService) ExportCredentials(ctx context.Context, tenantID, exportedBy string, recoveryKey *rsa.PublicKey) (*credential.CredentialBundle, error) {
+	f.exportedBy = exportedBy
+	return &credential.CredentialBundle{Version: credential.BundleVersion, TenantID: tenantID, ExportedBy: exportedBy}, nil
+}
+
+func (f *fakeRecoveryService) ImportCredentials(ctx context.Context, tenantID, importedBy string, bundle *credential.CredentialBundle, recoveryKey *rsa.PrivateKey) (*credential.ImportResult, error) {
+	if f.importErr != nil {
+		return nil, f.importErr
+	}
+	return &credential.ImportResult{Imported: len(bundle.Credentials), Skipped: []string{}}, nil
+}
+
+func recoveryRequest(t *testing.T, path string, body interface{}) *http.Request {
+	t.Helper()
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tenantID", "tenant-1")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.UserContextKey, &middleware.User{ID: "admin-1"})
+	return req.WithContext(ctx)
+}
+
+func TestCredentialRecoveryHandler_Export(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	service := &fakeRecoveryService{}
+	handler := NewCredentialRecoveryHandler(service, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Export(w, recoveryRequest(t, "/export", ExportCredentialsRequest{RecoveryPublicKey: publicPEM}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "admin-1", service.exportedBy)
+
+	w = httptest.NewRecorder()
+	handler.Export(w, recoveryRequest(t, "/export", ExportCredentialsRequest{RecoveryPublicKey: "not a key"}))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCredentialRecoveryHandler_Import_KeyMismatch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	service := &fakeRecoveryService{importErr: credential.ErrRecoveryKeyMismatch}
+	handler := NewCredentialRecoveryHandler(service, slog.Default())
+
+	w := httptest.NewRecorder()
+	handler.Import(w, recoveryRequest(t, "/import", ImportCredentialsRequest{
+		Bundle:             &credential.CredentialBundle{Version: credential.BundleVersion},
+		RecoveryPrivateKey: privatePEM,
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/pagination"
 	"github.com/gorax/gorax/internal/tenant"
 )
 
@@ -71,6 +73,24 @@ func (m *MockCredentialService) Delete(ctx context.Context, tenantID, credential
 	return args.Error(0)
 }
 
+func (m *MockCredentialService) Purge(ctx context.Context, tenantID, credentialID, userID string) error {
+	args := m.Called(ctx, tenantID, credentialID, userID)
+	return args.Error(0)
+}
+
+func (m *MockCredentialService) Restore(ctx context.Context, tenantID, credentialID, userID string) (*credential.Credential, error) {
+	args := m.Called(ctx, tenantID, credentialID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credential.Credential), args.Error(1)
+}
+
+func (m *MockCredentialService) BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []credential.BulkOperationError) {
+	args := m.Called(ctx, tenantID, userID, credentialIDs, permanent)
+	return args.Get(0).([]string), args.Get(1).([]credential.BulkOperationError)
+}
+
 func (m *MockCredentialService) Rotate(ctx context.Context, tenantID, credentialID, userID string, input credential.RotateCredentialInput) (*credential.Credential, error) {
 	args := m.Called(ctx, tenantID, credentialID, userID, input)
 	if args.Get(0) == nil {
@@ -95,10 +115,49 @@ func (m *MockCredentialService) GetAccessLog(ctx context.Context, tenantID, cred
 	return args.Get(0).([]*credential.AccessLog), args.Error(1)
 }
 
+func (m *MockCredentialService) GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*credential.DecryptedValue, error) {
+	args := m.Called(ctx, tenantID, credentialID, userID, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credential.DecryptedValue), args.Error(1)
+}
+
+func (m *MockCredentialService) LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error {
+	args := m.Called(ctx, tenantID, credentialID, userID, keys)
+	return args.Error(0)
+}
+
+func (m *MockCredentialService) TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*credential.CredentialTestResult, error) {
+	args := m.Called(ctx, tenantID, credentialID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*credential.CredentialTestResult), args.Error(1)
+}
+
+// stubPermissionChecker grants only the listed "resource:action" permissions
+type stubPermissionChecker struct {
+	granted []string
+}
+
+func (c *stubPermissionChecker) HasPermission(ctx context.Context, userID, tenantID, resource, action string) (bool, error) {
+	for _, perm := range c.granted {
+		if perm == resource+":"+action {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func newTestCredentialHandler() (*CredentialHandler, *MockCredentialService) {
+	return newTestCredentialHandlerWithPermissions("credential:read", "credential:read_value")
+}
+
+func newTestCredentialHandlerWithPermissions(granted ...string) (*CredentialHandler, *MockCredentialService) {
 	mockService := new(MockCredentialService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	handler := NewCredentialHandler(mockService, logger)
+	handler := NewCredentialHandler(mockService, &stubPermissionChecker{granted: granted}, logger)
 	return handler, mockService
 }
 
@@ -282,6 +341,58 @@ func TestList_WithFilters(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestList_CursorPagination tests that an after parameter returns items with a next_cursor
+func TestList_CursorPagination(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	now := time.Now().UTC()
+	credentials := []*credential.Credential{
+		{ID: "cred-3", TenantID: "tenant-123", CreatedAt: now},
+		{ID: "cred-2", TenantID: "tenant-123", CreatedAt: now.Add(-time.Minute)},
+		{ID: "cred-1", TenantID: "tenant-123", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	after := pagination.Cursor{CreatedAt: now.Add(time.Minute), ID: "cred-4"}
+	mockService.On("List", mock.Anything, "tenant-123", mock.MatchedBy(func(f credential.CredentialListFilter) bool {
+		return f.Page.Limit == 2 && f.Page.After != nil && f.Page.After.ID == "cred-4"
+	}), 0, 0).Return(credentials, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials?limit=2&after="+pagination.EncodeCursor(after), nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page struct {
+		Items      []credential.Credential `json:"items"`
+		NextCursor string                  `json:"next_cursor"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	assert.Len(t, page.Items, 2)
+
+	next, err := pagination.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "cred-2", next.ID)
+	mockService.AssertExpectations(t)
+}
+
+// TestList_InvalidCursor tests that a tampered cursor is rejected
+func TestList_InvalidCursor(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials?after=not-a-cursor", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+	w := httptest.NewRecorder()
+
+	handler.List(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_cursor")
+	mockService.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestGet_Success tests successful credential retrieval
 func TestGet_Success(t *testing.T) {
 	handler, mockService := newTestCredentialHandler()
@@ -358,7 +469,7 @@ func TestGetValue_Success(t *testing.T) {
 		CreatedAt: now,
 	}
 
-	mockService.On("GetValue", mock.Anything, "tenant-123", "cred-123", "user-123").
+	mockService.On("GetCredentialValue", mock.Anything, "tenant-123", "cred-123", "user-123", []string(nil)).
 		Return(expectedValue, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials/cred-123/value", nil)
@@ -390,7 +501,7 @@ func TestGetValue_Success(t *testing.T) {
 func TestGetValue_Unauthorized(t *testing.T) {
 	handler, mockService := newTestCredentialHandler()
 
-	mockService.On("GetValue", mock.Anything, "tenant-123", "cred-123", "user-123").
+	mockService.On("GetCredentialValue", mock.Anything, "tenant-123", "cred-123", "user-123", []string(nil)).
 		Return(nil, credential.ErrUnauthorized)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials/cred-123/value", nil)
@@ -408,6 +519,181 @@ func TestGetValue_Unauthorized(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestGetValue_ReadWithoutReadValue tests that credential:read alone cannot decrypt values
+func TestGetValue_ReadWithoutReadValue(t *testing.T) {
+	handler, mockService := newTestCredentialHandlerWithPermissions("credential:read")
+
+	mockService.On("LogDeniedAccess", mock.Anything, "tenant-123", "cred-123", "user-123", []string(nil)).Return(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials/cred-123/value", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.GetValue(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"credential_access_denied"`)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "GetCredentialValue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestTest_Success tests that a credential test result is returned without the value
+func TestTest_Success(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("TestCredential", mock.Anything, "tenant-123", "cred-123", "user-123").
+		Return(&credential.CredentialTestResult{Success: false, StatusCode: 401, Message: "test endpoint returned 401 Unauthorized", LatencyMS: 12}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/cred-123/test", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.Test(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, false, data["success"])
+	assert.Equal(t, float64(401), data["status_code"])
+	assert.Equal(t, float64(12), data["latency_ms"])
+	mockService.AssertExpectations(t)
+}
+
+// TestTest_RequiresReadValue tests that testing sends the value, so needs credential:read_value
+func TestTest_RequiresReadValue(t *testing.T) {
+	handler, mockService := newTestCredentialHandlerWithPermissions("credential:read")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/cred-123/test", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.Test(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "TestCredential", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestTest_NotConfigured tests that a credential that cannot be tested returns 400
+func TestTest_NotConfigured(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("TestCredential", mock.Anything, "tenant-123", "cred-123", "user-123").
+		Return(nil, &credential.ValidationError{Message: "credential metadata has no test_url to test against"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/cred-123/test", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.Test(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "test_url")
+	mockService.AssertExpectations(t)
+}
+
+// TestGetValue_SelectedKeys tests that a keys filter is passed to the service
+func TestGetValue_SelectedKeys(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("GetCredentialValue", mock.Anything, "tenant-123", "cred-123", "user-123", []string{"api_key", "region"}).
+		Return(&credential.DecryptedValue{
+			Version: 1,
+			Value:   map[string]interface{}{"api_key": "secret-key-123", "region": "us-east-1"},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials/cred-123/value?keys=api_key,region&keys=api_key", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.GetValue(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetValue_KeyPermissions tests reads authorized by per-key permissions
+func TestGetValue_KeyPermissions(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		keys           []string
+		expectedStatus int
+	}{
+		{
+			name:           "granted key",
+			query:          "?keys=api_key",
+			keys:           []string{"api_key"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "key without permission",
+			query:          "?keys=api_key,api_secret",
+			keys:           []string{"api_key", "api_secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "whole value",
+			query:          "",
+			keys:           nil,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestCredentialHandlerWithPermissions("credential:read", "credential:read_value:api_key")
+
+			if tt.expectedStatus == http.StatusOK {
+				mockService.On("GetCredentialValue", mock.Anything, "tenant-123", "cred-123", "user-123", tt.keys).
+					Return(&credential.DecryptedValue{Value: map[string]interface{}{"api_key": "secret-key-123"}}, nil)
+			} else {
+				mockService.On("LogDeniedAccess", mock.Anything, "tenant-123", "cred-123", "user-123", tt.keys).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/credentials/cred-123/value"+tt.query, nil)
+			req = addUserContext(req, "tenant-123", "user-123")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("credentialID", "cred-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+
+			handler.GetValue(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // TestUpdate_Success tests successful credential update
 func TestUpdate_Success(t *testing.T) {
 	handler, mockService := newTestCredentialHandler()
@@ -530,6 +816,146 @@ func TestDelete_NotFound(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestDelete_Permanent tests that ?permanent=true purges the credential
+func TestDelete_Permanent(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("Purge", mock.Anything, "tenant-123", "cred-123", "user-123").Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/credentials/cred-123?permanent=true", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.Delete(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestRestore_Success tests restoring a deleted credential
+func TestRestore_Success(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("Restore", mock.Anything, "tenant-123", "cred-123", "user-123").
+		Return(&credential.Credential{ID: "cred-123", Name: "api-key"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/cred-123/restore", nil)
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("credentialID", "cred-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+
+	handler.Restore(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "cred-123", resp["data"].(map[string]any)["id"])
+	mockService.AssertExpectations(t)
+}
+
+// TestRestore_Errors tests restore failures map to API errors
+func TestRestore_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"outside retention window", credential.ErrNotFound, http.StatusNotFound},
+		{"name taken by a live credential", credential.ErrDuplicateCredential, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestCredentialHandler()
+
+			mockService.On("Restore", mock.Anything, "tenant-123", "cred-123", "user-123").Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/cred-123/restore", nil)
+			req = addUserContext(req, "tenant-123", "user-123")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("credentialID", "cred-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+
+			handler.Restore(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestBulkDelete_Success tests bulk deletion reports each credential's outcome
+func TestBulkDelete_Success(t *testing.T) {
+	handler, mockService := newTestCredentialHandler()
+
+	mockService.On("BulkDelete", mock.Anything, "tenant-123", "user-123", []string{"cred-1", "cred-2"}, true).
+		Return([]string{"cred-1"}, []credential.BulkOperationError{{ID: "cred-2", Error: "credential not found"}})
+
+	body := `{"ids": ["cred-1", "cred-2"], "permanent": true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/bulk/delete", bytes.NewBufferString(body))
+	req = addUserContext(req, "tenant-123", "user-123")
+
+	w := httptest.NewRecorder()
+
+	handler.BulkDelete(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data BulkOperationResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"cred-1"}, resp.Data.Success)
+	assert.Equal(t, []BulkOperationError{{ID: "cred-2", Error: "credential not found"}}, resp.Data.Failed)
+	mockService.AssertExpectations(t)
+}
+
+// TestBulkDelete_InvalidInput tests bulk deletion input validation
+func TestBulkDelete_InvalidInput(t *testing.T) {
+	tooMany := make([]string, credential.MaxBulkDelete+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("cred-%d", i)
+	}
+	tooManyBody, err := json.Marshal(map[string]any{"ids": tooMany})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid json", `{"ids":`},
+		{"no ids", `{"ids": []}`},
+		{"too many ids", string(tooManyBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestCredentialHandler()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/credentials/bulk/delete", bytes.NewBufferString(tt.body))
+			req = addUserContext(req, "tenant-123", "user-123")
+
+			w := httptest.NewRecorder()
+
+			handler.BulkDelete(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "BulkDelete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 // TestRotate_Success tests successful credential rotation
 func TestRotate_Success(t *testing.T) {
 	handler, mockService := newTestCredentialHandler()
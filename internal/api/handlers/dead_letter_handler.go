@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// DeadLetterService defines the dead-letter queue methods needed from the workflow service
+type DeadLetterService interface {
+	ListDeadLetters(ctx context.Context, tenantID string, filter workflow.DeadLetterFilter) (*workflow.DeadLetterListResult, error)
+	GetDeadLetter(ctx context.Context, tenantID, id string) (*workflow.DeadLetterDetail, error)
+	RequeueDeadLetter(ctx context.Context, tenantID, id string) (*workflow.Execution, error)
+}
+
+// DeadLetterHandler handles dead-letter queue HTTP requests
+type DeadLetterHandler struct {
+	service DeadLetterService
+	logger  *slog.Logger
+}
+
+// NewDeadLetterHandler creates a new dead-letter handler
+func NewDeadLetterHandler(service DeadLetterService, logger *slog.Logger) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List returns the tenant's failed executions, newest first
+// @Summary List dead-letter entries
+// @Description Returns failed executions with their failure reason and payload, newest first, with the total match count
+// @Tags Dead Letters
+// @Accept json
+// @Produce json
+// @Param workflow_id query string false "Filter by workflow ID"
+// @Param status query string false "Filter by status" Enums(pending, requeued)
+// @Param limit query int false "Maximum results" default(20)
+// @Param offset query int false "Results to skip" default(0)
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} workflow.DeadLetterListResult "Dead-letter entries with total count"
+// @Failure 400 {object} map[string]string "Invalid filter parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/dead-letters [get]
+func (h *DeadLetterHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	filter := workflow.DeadLetterFilter{
+		WorkflowID: r.URL.Query().Get("workflow_id"),
+		Status:     r.URL.Query().Get("status"),
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			_ = response.BadRequest(w, "limit must be a non-negative integer")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			_ = response.BadRequest(w, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = offset
+	}
+
+	result, err := h.service.ListDeadLetters(r.Context(), tenantID, filter)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to list dead-letter entries",
+			"error", err,
+			"tenant_id", tenantID,
+		)
+		_ = response.InternalError(w, "failed to list dead-letter entries")
+		return
+	}
+
+	_ = response.OK(w, result)
+}
+
+// Get returns a dead-letter entry with the steps of its failed execution
+// @Summary Get a dead-letter entry
+// @Description Returns a failed execution's failure reason, failed node, payload and step executions
+// @Tags Dead Letters
+// @Accept json
+// @Produce json
+// @Param deadLetterID path string true "Dead-letter entry ID"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} workflow.DeadLetterDetail "Dead-letter entry with steps"
+// @Failure 404 {object} map[string]string "Dead-letter entry not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/dead-letters/{deadLetterID} [get]
+func (h *DeadLetterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	id := chi.URLParam(r, "deadLetterID")
+	if id == "" {
+		_ = response.BadRequest(w, "dead-letter ID is required")
+		return
+	}
+
+	detail, err := h.service.GetDeadLetter(r.Context(), tenantID, id)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to get dead-letter entry",
+			"error", err,
+			"tenant_id", tenantID,
+			"dead_letter_id", id,
+		)
+		_ = response.InternalError(w, "failed to get dead-letter entry")
+		return
+	}
+
+	_ = response.OK(w, detail)
+}
+
+// Requeue starts a new execution from a dead-letter entry
+// @Summary Requeue a dead-letter entry
+// @Description Starts a new execution of the entry's workflow with the failed execution's payload. Every node runs again. An entry can be requeued once.
+// @Tags Dead Letters
+// @Accept json
+// @Produce json
+// @Param deadLetterID path string true "Dead-letter entry ID"
+// @Security TenantID
+// @Security UserID
+// @Success 201 {object} workflow.Execution "Requeued execution"
+// @Failure 400 {object} map[string]string "Workflow is not active"
+// @Failure 404 {object} map[string]string "Dead-letter entry not found"
+// @Failure 409 {object} map[string]string "Entry was already requeued"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/dead-letters/{deadLetterID}/requeue [post]
+func (h *DeadLetterHandler) Requeue(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	id := chi.URLParam(r, "deadLetterID")
+	if id == "" {
+		_ = response.BadRequest(w, "dead-letter ID is required")
+		return
+	}
+
+	execution, err := h.service.RequeueDeadLetter(r.Context(), tenantID, id)
+	if err != nil {
+		if err == workflow.ErrNotFound {
+			_ = response.WriteError(w, errWorkflowNotFound)
+			return
+		}
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to requeue dead-letter entry",
+			"error", err,
+			"tenant_id", tenantID,
+			"dead_letter_id", id,
+		)
+		_ = response.InternalError(w, "failed to requeue dead-letter entry")
+		return
+	}
+
+	_ = response.Created(w, execution)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// MockDeadLetterService is a mock implementation of DeadLetterService for testing
+type MockDeadLetterService struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterService) ListDeadLetters(ctx context.Context, tenantID string, filter workflow.DeadLetterFilter) (*workflow.DeadLetterListResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.DeadLetterListResult), args.Error(1)
+}
+
+func (m *MockDeadLetterService) GetDeadLetter(ctx context.Context, tenantID, id string) (*workflow.DeadLetterDetail, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.DeadLetterDetail), args.Error(1)
+}
+
+func (m *MockDeadLetterService) RequeueDeadLetter(ctx context.Context, tenantID, id string) (*workflow.Execution, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.Execution), args.Error(1)
+}
+
+func newTestDeadLetterHandler() (*DeadLetterHandler, *MockDeadLetterService) {
+	mockService := new(MockDeadLetterService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewDeadLetterHandler(mockService, logger), mockService
+}
+
+func deadLetterRequest(method, url, id string) *http.Request {
+	req := httptest.NewRequest(method, url, nil)
+	req = addTenantContext(req, "tenant-123")
+	rctx := chi.NewRouteContext()
+	if id != "" {
+		rctx.URLParams.Add("deadLetterID", id)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestDeadLetterHandler_List(t *testing.T) {
+	handler, mockService := newTestDeadLetterHandler()
+
+	mockService.On("ListDeadLetters", mock.Anything, "tenant-123", workflow.DeadLetterFilter{
+		WorkflowID: "wf-1",
+		Status:     "pending",
+		Limit:      10,
+		Offset:     20,
+	}).Return(&workflow.DeadLetterListResult{
+		Data:       []*workflow.DeadLetter{{ID: "dl-1", ExecutionID: "exec-1", FailureReason: "node http-1 failed: timeout"}},
+		TotalCount: 21,
+		Limit:      10,
+		Offset:     20,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	handler.List(w, deadLetterRequest(http.MethodGet, "/api/v1/dead-letters?workflow_id=wf-1&status=pending&limit=10&offset=20", ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Data       []workflow.DeadLetter `json:"data"`
+		TotalCount int                   `json:"total_count"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, 21, body.TotalCount)
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "dl-1", body.Data[0].ID)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeadLetterHandler_ListInvalidOffset(t *testing.T) {
+	handler, mockService := newTestDeadLetterHandler()
+
+	w := httptest.NewRecorder()
+	handler.List(w, deadLetterRequest(http.MethodGet, "/api/v1/dead-letters?offset=-1", ""))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListDeadLetters")
+}
+
+func TestDeadLetterHandler_GetNotFound(t *testing.T) {
+	handler, mockService := newTestDeadLetterHandler()
+	mockService.On("GetDeadLetter", mock.Anything, "tenant-123", "dl-missing").Return(nil, workflow.ErrDeadLetterNotFound)
+
+	w := httptest.NewRecorder()
+	handler.Get(w, deadLetterRequest(http.MethodGet, "/api/v1/dead-letters/dl-missing", "dl-missing"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"dead_letter_not_found"`)
+}
+
+func TestDeadLetterHandler_Requeue(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceResult  *workflow.Execution
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "requeued",
+			serviceResult:  &workflow.Execution{ID: "exec-2", Status: string(workflow.ExecutionStatusPending)},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "already requeued",
+			serviceErr:     workflow.ErrDeadLetterRequeued,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "dead_letter_requeued",
+		},
+		{
+			name:           "workflow inactive",
+			serviceErr:     &workflow.ValidationError{Message: "workflow must be active to execute"},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "workflow deleted",
+			serviceErr:     workflow.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "workflow_not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestDeadLetterHandler()
+
+			var result interface{}
+			if tt.serviceResult != nil {
+				result = tt.serviceResult
+			}
+			mockService.On("RequeueDeadLetter", mock.Anything, "tenant-123", "dl-1").Return(result, tt.serviceErr)
+
+			w := httptest.NewRecorder()
+			handler.Requeue(w, deadLetterRequest(http.MethodPost, "/api/v1/dead-letters/dl-1/requeue", "dl-1"))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/envvars"
+)
+
+// EnvVarService defines the environment variable management used by the API
+type EnvVarService interface {
+	List(ctx context.Context, tenantID string, workflowID *string) ([]envvars.Variable, error)
+	Create(ctx context.Context, tenantID string, workflowID *string, userID string, input envvars.CreateVariableInput) (*envvars.Variable, error)
+	Update(ctx context.Context, tenantID, id string, input envvars.UpdateVariableInput) (*envvars.Variable, error)
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+// EnvVarHandler handles tenant and workflow environment variable requests
+type EnvVarHandler struct {
+	service EnvVarService
+	logger  *slog.Logger
+}
+
+// NewEnvVarHandler creates a new environment variable handler
+func NewEnvVarHandler(service EnvVarService, logger *slog.Logger) *EnvVarHandler {
+	return &EnvVarHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// workflowScope returns the workflow ID from the route, or nil on tenant-wide routes
+func workflowScope(r *http.Request) *string {
+	if workflowID := chi.URLParam(r, "workflowID"); workflowID != "" {
+		return &workflowID
+	}
+	return nil
+}
+
+// List handles GET /api/v1/env-vars and GET /api/v1/workflows/{workflowID}/env-vars
+func (h *EnvVarHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+
+	variables, err := h.service.List(r.Context(), tenantID, workflowScope(r))
+	if err != nil {
+		h.logger.Error("failed to list environment variables", "error", err, "tenant_id", tenantID)
+		_ = response.InternalError(w, "failed to list environment variables")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": variables,
+	})
+}
+
+// Create handles POST /api/v1/env-vars and POST /api/v1/workflows/{workflowID}/env-vars
+func (h *EnvVarHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+
+	var input envvars.CreateVariableInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	variable, err := h.service.Create(r.Context(), tenantID, workflowScope(r), middleware.GetUserID(r), input)
+	if err != nil {
+		h.writeError(w, err, "failed to create environment variable", "tenant_id", tenantID, "key", input.Key)
+		return
+	}
+
+	_ = response.Created(w, map[string]any{
+		"data": variable,
+	})
+}
+
+// Update handles PUT /api/v1/env-vars/{id}
+func (h *EnvVarHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	id := chi.URLParam(r, "id")
+
+	var input envvars.UpdateVariableInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	variable, err := h.service.Update(r.Context(), tenantID, id, input)
+	if err != nil {
+		h.writeError(w, err, "failed to update environment variable", "tenant_id", tenantID, "id", id)
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": variable,
+	})
+}
+
+// Delete handles DELETE /api/v1/env-vars/{id}
+func (h *EnvVarHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	id := chi.URLParam(r, "id")
+
+	if err := h.service.Delete(r.Context(), tenantID, id); err != nil {
+		h.writeError(w, err, "failed to delete environment variable", "tenant_id", tenantID, "id", id)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// writeError writes a domain error, or logs err and writes message as an internal error
+func (h *EnvVarHandler) writeError(w http.ResponseWriter, err error, message string, attrs ...any) {
+	if apiErr := domainError(err); apiErr != nil {
+		_ = response.WriteError(w, apiErr)
+		return
+	}
+	h.logger.Error(message, append([]any{"error", err}, attrs...)...)
+	_ = response.InternalError(w, message)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/envvars"
+	"github.com/gorax/gorax/internal/tenant"
+)
+
+// MockEnvVarService is a mock implementation of EnvVarService
+type MockEnvVarService struct {
+	mock.Mock
+}
+
+func (m *MockEnvVarService) List(ctx context.Context, tenantID string, workflowID *string) ([]envvars.Variable, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]envvars.Variable), args.Error(1)
+}
+
+func (m *MockEnvVarService) Create(ctx context.Context, tenantID string, workflowID *string, userID string, input envvars.CreateVariableInput) (*envvars.Variable, error) {
+	args := m.Called(ctx, tenantID, workflowID, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envvars.Variable), args.Error(1)
+}
+
+func (m *MockEnvVarService) Update(ctx context.Context, tenantID, id string, input envvars.UpdateVariableInput) (*envvars.Variable, error) {
+	args := m.Called(ctx, tenantID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*envvars.Variable), args.Error(1)
+}
+
+func (m *MockEnvVarService) Delete(ctx context.Context, tenantID, id string) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func newTestEnvVarRouter() (http.Handler, *MockEnvVarService) {
+	service := new(MockEnvVarService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewEnvVarHandler(service, logger)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.TenantContextKey, &tenant.Tenant{ID: "tenant-1"})
+			ctx = context.WithValue(ctx, middleware.UserContextKey, &middleware.User{ID: "user-1"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Get("/env-vars", handler.List)
+	r.Post("/env-vars", handler.Create)
+	r.Put("/env-vars/{id}", handler.Update)
+	r.Delete("/env-vars/{id}", handler.Delete)
+	r.Get("/workflows/{workflowID}/env-vars", handler.List)
+	r.Post("/workflows/{workflowID}/env-vars", handler.Create)
+	return r, service
+}
+
+func TestEnvVarHandler_Create(t *testing.T) {
+	workflowID := "wf-1"
+	input := envvars.CreateVariableInput{Key: "API_BASE_URL", Value: "https://staging.example.com"}
+	body := `{"key":"API_BASE_URL","value":"https://staging.example.com"}`
+
+	tests := []struct {
+		name           string
+		path           string
+		workflowID     *string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "tenant-wide", path: "/env-vars", expectedStatus: http.StatusCreated},
+		{name: "workflow override", path: "/workflows/wf-1/env-vars", workflowID: &workflowID, expectedStatus: http.StatusCreated},
+		{name: "duplicate key", path: "/env-vars", serviceErr: envvars.ErrDuplicateKey, expectedStatus: http.StatusConflict, expectedCode: "env_var_already_exists"},
+		{name: "unknown workflow", path: "/workflows/wf-1/env-vars", workflowID: &workflowID, serviceErr: envvars.ErrWorkflowNotFound, expectedStatus: http.StatusNotFound, expectedCode: "workflow_not_found"},
+		{name: "secret-looking key", path: "/env-vars", serviceErr: &envvars.ValidationError{Message: "looks like a secret"}, expectedStatus: http.StatusBadRequest, expectedCode: "validation_failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newTestEnvVarRouter()
+			var created *envvars.Variable
+			if tt.serviceErr == nil {
+				created = &envvars.Variable{ID: "var-1", TenantID: "tenant-1", WorkflowID: tt.workflowID, Key: input.Key, Value: input.Value}
+			}
+			service.On("Create", mock.Anything, "tenant-1", tt.workflowID, "user-1", input).Return(created, tt.serviceErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEnvVarHandler_UpdateAndDelete(t *testing.T) {
+	router, service := newTestEnvVarRouter()
+	value := "eu"
+	service.On("Update", mock.Anything, "tenant-1", "var-1", envvars.UpdateVariableInput{Value: &value}).
+		Return(&envvars.Variable{ID: "var-1", Key: "REGION", Value: "eu"}, nil)
+	service.On("Delete", mock.Anything, "tenant-1", "var-2").Return(envvars.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/env-vars/var-1", strings.NewReader(`{"value":"eu"}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"value":"eu"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/env-vars/var-2", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"env_var_not_found"`)
+}
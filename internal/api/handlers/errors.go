@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/envvars"
+	"github.com/gorax/gorax/internal/eventtypes"
+	"github.com/gorax/gorax/internal/featureflag"
+	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// errWorkflowNotFound is returned by endpoints addressing a workflow by ID.
+// workflow.ErrNotFound is also used for executions, so it is not mapped by domainError.
+var errWorkflowNotFound = response.NewAPIError(http.StatusNotFound, response.CodeWorkflowNotFound, "workflow not found")
+
+// domainError maps errors returned by services to API errors with stable
+// codes. It returns nil for errors it does not recognise, which handlers log
+// and report as internal errors.
+func domainError(err error) *response.APIError {
+	var apiErr *response.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var credentialValidation *credential.ValidationError
+	if errors.As(err, &credentialValidation) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, credentialValidation.Message)
+	}
+	var workflowValidation *workflow.ValidationError
+	if errors.As(err, &workflowValidation) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, workflowValidation.Message)
+	}
+	var definitionErr *workflow.DefinitionError
+	if errors.As(err, &definitionErr) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, definitionErr.Error()).
+			WithDetails(map[string]interface{}{"issues": definitionErr.Issues})
+	}
+	var providerErr *oauth.ProviderError
+	if errors.As(err, &providerErr) {
+		code := response.CodeOAuthProviderError
+		switch {
+		case providerErr.Denied():
+			code = response.CodeOAuthAccessDenied
+		case providerErr.Misconfigured():
+			code = response.CodeOAuthMisconfigured
+		}
+		return response.NewAPIError(http.StatusBadRequest, code, providerErr.Reason()).
+			WithDetails(map[string]interface{}{"provider_error": providerErr.Code})
+	}
+	var eventTypeValidation *eventtypes.ValidationError
+	if errors.As(err, &eventTypeValidation) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, eventTypeValidation.Message)
+	}
+	var envVarValidation *envvars.ValidationError
+	if errors.As(err, &envVarValidation) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, envVarValidation.Message)
+	}
+	var featureFlagValidation *featureflag.ValidationError
+	if errors.As(err, &featureFlagValidation) {
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, featureFlagValidation.Message)
+	}
+	var breakingChange *eventtypes.BreakingChangeError
+	if errors.As(err, &breakingChange) {
+		return response.NewAPIError(http.StatusConflict, response.CodeEventTypeBreakingChange, breakingChange.Error()).
+			WithDetails(map[string]interface{}{
+				"version":      breakingChange.Version,
+				"next_version": breakingChange.NextVersion,
+				"changes":      breakingChange.Changes,
+			})
+	}
+
+	switch {
+	case errors.Is(err, credential.ErrNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeCredentialNotFound, "credential not found")
+	case errors.Is(err, credential.ErrUnauthorized):
+		return response.NewAPIError(http.StatusForbidden, response.CodeCredentialAccessDenied, "unauthorized access to credential")
+	case errors.Is(err, credential.ErrAlreadyExists), errors.Is(err, credential.ErrDuplicateCredential):
+		return response.NewAPIError(http.StatusConflict, response.CodeCredentialExists, "credential already exists")
+	case errors.Is(err, credential.ErrInvalidInput):
+		return response.NewAPIError(http.StatusBadRequest, response.CodeValidationFailed, "invalid input")
+
+	case errors.Is(err, oauth.ErrConnectionNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeConnectionNotFound, "OAuth connection not found")
+	case errors.Is(err, oauth.ErrConnectionRevoked):
+		return response.NewAPIError(http.StatusConflict, response.CodeConnectionRevoked, "OAuth connection has been revoked")
+	case errors.Is(err, oauth.ErrInvalidProvider):
+		return response.NewAPIError(http.StatusBadRequest, response.CodeInvalidOAuthProvider, "invalid OAuth provider")
+	case errors.Is(err, oauth.ErrInvalidState):
+		return response.NewAPIError(http.StatusBadRequest, response.CodeInvalidOAuthState, "invalid or expired OAuth state")
+	case errors.Is(err, oauth.ErrInvalidCode):
+		return response.NewAPIError(http.StatusBadRequest, response.CodeInvalidOAuthCode, "invalid authorization code")
+	case errors.Is(err, oauth.ErrTokenExpired), errors.Is(err, oauth.ErrTokenRefreshFailed), errors.Is(err, oauth.ErrMissingRefreshToken):
+		return response.NewAPIError(http.StatusConflict, response.CodeOAuthTokenUnavailable, "OAuth token is unavailable; reconnect the account")
+	case errors.Is(err, oauth.ErrUnsupportedGrant):
+		return response.NewAPIError(http.StatusBadRequest, response.CodeUnsupportedOAuthGrant, oauth.ErrUnsupportedGrant.Error())
+	case errors.Is(err, oauth.ErrDeviceAuthorizationNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeDeviceAuthorizationNotFound, oauth.ErrDeviceAuthorizationNotFound.Error())
+
+	case errors.Is(err, workflow.ErrExecutionNotCancellable):
+		return response.NewAPIError(http.StatusConflict, response.CodeExecutionNotCancellable, "execution is not pending or running")
+	case errors.Is(err, workflow.ErrIdempotencyKeyReused):
+		return response.NewAPIError(http.StatusConflict, response.CodeIdempotencyKeyReused, workflow.ErrIdempotencyKeyReused.Error())
+	case errors.Is(err, workflow.ErrIdempotencyKeyInProgress):
+		return response.NewAPIError(http.StatusConflict, response.CodeIdempotencyKeyInProgress, workflow.ErrIdempotencyKeyInProgress.Error())
+	case errors.Is(err, workflow.ErrDeadLetterNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeDeadLetterNotFound, workflow.ErrDeadLetterNotFound.Error())
+	case errors.Is(err, workflow.ErrDeadLetterRequeued):
+		return response.NewAPIError(http.StatusConflict, response.CodeDeadLetterRequeued, workflow.ErrDeadLetterRequeued.Error())
+
+	case errors.Is(err, eventtypes.ErrNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeEventTypeNotFound, eventtypes.ErrNotFound.Error())
+
+	case errors.Is(err, envvars.ErrNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeEnvVarNotFound, envvars.ErrNotFound.Error())
+	case errors.Is(err, envvars.ErrDuplicateKey):
+		return response.NewAPIError(http.StatusConflict, response.CodeEnvVarExists, envvars.ErrDuplicateKey.Error())
+	case errors.Is(err, envvars.ErrWorkflowNotFound):
+		return errWorkflowNotFound
+
+	case errors.Is(err, featureflag.ErrNotFound):
+		return response.NewAPIError(http.StatusNotFound, response.CodeFeatureFlagNotFound, featureflag.ErrNotFound.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestDomainError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"credential not found", credential.ErrNotFound, http.StatusNotFound, response.CodeCredentialNotFound},
+		{"wrapped credential unauthorized", fmt.Errorf("get value: %w", credential.ErrUnauthorized), http.StatusForbidden, response.CodeCredentialAccessDenied},
+		{"credential validation", &credential.ValidationError{Message: "name is required"}, http.StatusBadRequest, response.CodeValidationFailed},
+		{"workflow validation", &workflow.ValidationError{Message: "workflow must be active to execute"}, http.StatusBadRequest, response.CodeValidationFailed},
+		{"workflow definition", &workflow.DefinitionError{Issues: []workflow.DefinitionIssue{{Code: workflow.IssueCycle, NodeID: "a", Message: "workflow contains a cycle: a -> a"}}}, http.StatusBadRequest, response.CodeValidationFailed},
+		{"connection not found", oauth.ErrConnectionNotFound, http.StatusNotFound, response.CodeConnectionNotFound},
+		{"invalid oauth state", oauth.ErrInvalidState, http.StatusBadRequest, response.CodeInvalidOAuthState},
+		{"idempotency key reused", workflow.ErrIdempotencyKeyReused, http.StatusConflict, response.CodeIdempotencyKeyReused},
+		{"api error passes through", response.NewAPIError(http.StatusTeapot, "teapot", "short and stout"), http.StatusTeapot, "teapot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := domainError(tt.err)
+
+			require.NotNil(t, apiErr)
+			assert.Equal(t, tt.wantStatus, apiErr.Status)
+			assert.Equal(t, tt.wantCode, apiErr.Code)
+		})
+	}
+
+	t.Run("unknown error", func(t *testing.T) {
+		assert.Nil(t, domainError(errors.New("database error")))
+	})
+}
@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/gorax/gorax/internal/api/response"
 	"github.com/gorax/gorax/internal/eventtypes"
@@ -12,6 +16,8 @@ import (
 // EventTypeService defines the interface for event type business logic
 type EventTypeService interface {
 	ListEventTypes(ctx context.Context) ([]eventtypes.EventType, error)
+	RegisterEventType(ctx context.Context, input eventtypes.RegisterEventTypeInput) (*eventtypes.EventSchema, error)
+	GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error)
 }
 
 // EventTypesHandler handles event type HTTP requests
@@ -41,3 +47,58 @@ func (h *EventTypesHandler) List(w http.ResponseWriter, r *http.Request) {
 		"data": eventTypes,
 	})
 }
+
+// Register registers a JSON Schema for a version of an event type
+func (h *EventTypesHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var input eventtypes.RegisterEventTypeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	schema, err := h.service.RegisterEventType(r.Context(), input)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to register event type", "error", err, "event_type", input.Name)
+		_ = response.InternalError(w, "failed to register event type")
+		return
+	}
+
+	_ = response.Created(w, map[string]any{
+		"data": schema,
+	})
+}
+
+// GetSchema returns the schema of an event type. The optional version query
+// parameter selects a version; the latest is returned by default.
+func (h *EventTypesHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	version := 0
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		parsed, err := strconv.Atoi(versionStr)
+		if err != nil || parsed < 1 {
+			_ = response.BadRequest(w, "version must be a positive integer")
+			return
+		}
+		version = parsed
+	}
+
+	schema, err := h.service.GetEventSchema(r.Context(), name, version)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to get event type schema", "error", err, "event_type", name)
+		_ = response.InternalError(w, "failed to get event type schema")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": schema,
+	})
+}
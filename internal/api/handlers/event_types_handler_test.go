@@ -8,9 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,22 @@ func (m *MockEventTypeService) ListEventTypes(ctx context.Context) ([]EventType,
 	return args.Get(0).([]EventType), args.Error(1)
 }
 
+func (m *MockEventTypeService) RegisterEventType(ctx context.Context, input eventtypes.RegisterEventTypeInput) (*eventtypes.EventSchema, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eventtypes.EventSchema), args.Error(1)
+}
+
+func (m *MockEventTypeService) GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error) {
+	args := m.Called(ctx, name, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eventtypes.EventSchema), args.Error(1)
+}
+
 func newTestEventTypesHandler() (*EventTypesHandler, *MockEventTypeService) {
 	mockService := new(MockEventTypeService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -265,3 +283,120 @@ func TestListEventTypesContentType(t *testing.T) {
 
 	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
 }
+
+func TestRegisterEventType(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","required":["order_id"]}`)
+	input := eventtypes.RegisterEventTypeInput{Name: "order.created", Version: 1, Schema: schema}
+
+	tests := []struct {
+		name           string
+		body           string
+		mockReturn     *eventtypes.EventSchema
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "registers schema",
+			body:           `{"name":"order.created","version":1,"schema":{"type":"object","required":["order_id"]}}`,
+			mockReturn:     &eventtypes.EventSchema{EventType: "order.created", Version: 1, Schema: schema},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"eventType":"order.created"`,
+		},
+		{
+			name:           "breaking change",
+			body:           `{"name":"order.created","version":1,"schema":{"type":"object","required":["order_id"]}}`,
+			mockError:      &eventtypes.BreakingChangeError{EventType: "order.created", Version: 1, NextVersion: 2, Changes: []string{`$: property "order_id" is now required`}},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `"next_version":2`,
+		},
+		{
+			name:           "invalid input",
+			body:           `{"name":"order.created","version":1,"schema":{"type":"object","required":["order_id"]}}`,
+			mockError:      &eventtypes.ValidationError{Message: "next version of order.created is 3"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"code":"validation_failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestEventTypesHandler()
+			mockService.On("RegisterEventType", mock.Anything, mock.MatchedBy(func(in eventtypes.RegisterEventTypeInput) bool {
+				return in.Name == input.Name && in.Version == input.Version
+			})).Return(tt.mockReturn, tt.mockError)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/event-types", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.Register(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetEventTypeSchema(t *testing.T) {
+	schema := &eventtypes.EventSchema{EventType: "order.created", Version: 2, Schema: json.RawMessage(`{"type":"object"}`)}
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedVersion int
+		mockReturn      *eventtypes.EventSchema
+		mockError       error
+		expectedStatus  int
+		expectedCode    string
+	}{
+		{
+			name:            "latest version",
+			expectedVersion: 0,
+			mockReturn:      schema,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "specific version",
+			query:           "?version=2",
+			expectedVersion: 2,
+			mockReturn:      schema,
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:            "unknown event type",
+			expectedVersion: 0,
+			mockError:       eventtypes.ErrNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    "event_type_not_found",
+		},
+		{
+			name:           "invalid version",
+			query:          "?version=latest",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestEventTypesHandler()
+			if tt.mockReturn != nil || tt.mockError != nil {
+				mockService.On("GetEventSchema", mock.Anything, "order.created", tt.expectedVersion).Return(tt.mockReturn, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/event-types/order.created/schema"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("name", "order.created")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetSchema(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -19,6 +21,9 @@ type ExecutionService interface {
 	ListExecutionsAdvanced(ctx context.Context, tenantID string, filter workflow.ExecutionFilter, cursor string, limit int) (*workflow.ExecutionListResult, error)
 	GetExecutionWithSteps(ctx context.Context, tenantID, executionID string) (*workflow.ExecutionWithSteps, error)
 	GetExecutionStats(ctx context.Context, tenantID string, filter workflow.ExecutionFilter) (*workflow.ExecutionStats, error)
+	SearchExecutions(ctx context.Context, tenantID string, filter workflow.ExecutionSearchFilter) (*workflow.ExecutionSearchResult, error)
+	CancelExecution(ctx context.Context, tenantID, executionID, userID string) (*workflow.Execution, error)
+	RetryExecution(ctx context.Context, tenantID, executionID, fromNode string) (*workflow.Execution, error)
 }
 
 // ExecutionHandler handles execution-related HTTP requests
@@ -46,6 +51,7 @@ func NewExecutionHandler(service ExecutionService, logger *slog.Logger) *Executi
 // @Param trigger_type query string false "Filter by trigger type (manual, webhook, schedule)" Enums(manual, webhook, schedule)
 // @Param from query string false "Start date (RFC3339 format)"
 // @Param to query string false "End date (RFC3339 format)"
+// @Param tag.{key} query string false "Filter by execution tag value (e.g. tag.order_id=12345); repeatable for different keys"
 // @Param cursor query string false "Cursor for pagination"
 // @Param limit query int false "Maximum results" default(20)
 // @Security TenantID
@@ -72,8 +78,8 @@ func (h *ExecutionHandler) ListExecutionsAdvanced(w http.ResponseWriter, r *http
 
 	result, err := h.service.ListExecutionsAdvanced(r.Context(), tenantID, filter, cursor, limit)
 	if err != nil {
-		if _, ok := err.(*workflow.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to list executions",
@@ -132,6 +138,120 @@ func (h *ExecutionHandler) GetExecutionWithSteps(w http.ResponseWriter, r *http.
 	_ = response.OK(w, result)
 }
 
+// CancelExecution cancels a pending or running execution
+// @Summary Cancel an execution
+// @Description Cancels a pending or running execution and records who cancelled it. Nodes that have not started are skipped; a node in progress on this server (such as a delay) is interrupted.
+// @Tags Executions
+// @Accept json
+// @Produce json
+// @Param executionID path string true "Execution ID"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} workflow.Execution "Cancelled execution"
+// @Failure 400 {object} map[string]string "Execution is not pending or running"
+// @Failure 404 {object} map[string]string "Execution not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/executions/{executionID}/cancel [post]
+func (h *ExecutionHandler) CancelExecution(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	executionID := chi.URLParam(r, "executionID")
+	if executionID == "" {
+		_ = response.BadRequest(w, "execution ID is required")
+		return
+	}
+
+	execution, err := h.service.CancelExecution(r.Context(), tenantID, executionID, middleware.GetUserID(r))
+	if err != nil {
+		if err == workflow.ErrNotFound {
+			_ = response.NotFound(w, "execution not found")
+			return
+		}
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to cancel execution",
+			"error", err,
+			"tenant_id", tenantID,
+			"execution_id", executionID,
+		)
+		_ = response.InternalError(w, "failed to cancel execution")
+		return
+	}
+
+	_ = response.OK(w, execution)
+}
+
+// RetryExecutionInput represents the request body for retrying an execution
+type RetryExecutionInput struct {
+	FromNode string `json:"from_node"`
+}
+
+// RetryExecution starts a new execution that resumes a failed execution at a node
+// @Summary Retry an execution from a node
+// @Description Creates a new execution for a failed or cancelled execution that resumes at from_node. Outputs of the nodes before from_node are reused from the original execution; from_node and the nodes after it run again.
+// @Tags Executions
+// @Accept json
+// @Produce json
+// @Param executionID path string true "Execution ID"
+// @Param input body RetryExecutionInput true "Node to resume at"
+// @Security TenantID
+// @Security UserID
+// @Success 201 {object} workflow.Execution "Retry execution"
+// @Failure 400 {object} map[string]string "Invalid node or execution is not failed or cancelled"
+// @Failure 404 {object} map[string]string "Execution not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/executions/{executionID}/retry [post]
+func (h *ExecutionHandler) RetryExecution(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	executionID := chi.URLParam(r, "executionID")
+	if executionID == "" {
+		_ = response.BadRequest(w, "execution ID is required")
+		return
+	}
+
+	var input RetryExecutionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+	if input.FromNode == "" {
+		_ = response.BadRequest(w, "from_node is required")
+		return
+	}
+
+	execution, err := h.service.RetryExecution(r.Context(), tenantID, executionID, input.FromNode)
+	if err != nil {
+		if err == workflow.ErrNotFound {
+			_ = response.NotFound(w, "execution not found")
+			return
+		}
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to retry execution",
+			"error", err,
+			"tenant_id", tenantID,
+			"execution_id", executionID,
+		)
+		_ = response.InternalError(w, "failed to retry execution")
+		return
+	}
+
+	_ = response.Created(w, execution)
+}
+
 // GetExecutionStats returns execution statistics grouped by status
 // GET /api/v1/executions/stats
 func (h *ExecutionHandler) GetExecutionStats(w http.ResponseWriter, r *http.Request) {
@@ -149,8 +269,8 @@ func (h *ExecutionHandler) GetExecutionStats(w http.ResponseWriter, r *http.Requ
 
 	stats, err := h.service.GetExecutionStats(r.Context(), tenantID, filter)
 	if err != nil {
-		if _, ok := err.(*workflow.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		h.logger.Error("failed to get execution stats",
@@ -164,6 +284,75 @@ func (h *ExecutionHandler) GetExecutionStats(w http.ResponseWriter, r *http.Requ
 	_ = response.OK(w, stats)
 }
 
+// SearchExecutions finds executions by error message text
+// @Summary Search executions by error message
+// @Description Returns executions whose error message contains the query (case-insensitive), newest first, with the total match count
+// @Tags Executions
+// @Accept json
+// @Produce json
+// @Param q query string false "Text to find in the error message"
+// @Param workflow_id query string false "Filter by workflow ID"
+// @Param status query string false "Filter by status"
+// @Param start_date query string false "Start date (RFC3339 format)"
+// @Param end_date query string false "End date (RFC3339 format)"
+// @Param limit query int false "Maximum results" default(20)
+// @Param offset query int false "Results to skip" default(0)
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} workflow.ExecutionSearchResult "Matching executions with total count"
+// @Failure 400 {object} map[string]string "Invalid search parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/executions/search [get]
+func (h *ExecutionHandler) SearchExecutions(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.InternalError(w, "tenant ID not found")
+		return
+	}
+
+	filter, err := h.parseExecutionFilter(r)
+	if err != nil {
+		_ = response.BadRequest(w, "invalid filter parameters: "+err.Error())
+		return
+	}
+
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			_ = response.BadRequest(w, "offset must be a non-negative integer")
+			return
+		}
+	}
+
+	result, err := h.service.SearchExecutions(r.Context(), tenantID, workflow.ExecutionSearchFilter{
+		Query:      r.URL.Query().Get("q"),
+		WorkflowID: filter.WorkflowID,
+		Status:     filter.Status,
+		StartDate:  filter.StartDate,
+		EndDate:    filter.EndDate,
+		Limit:      h.parseLimit(r),
+		Offset:     offset,
+	})
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to search executions",
+			"error", err,
+			"tenant_id", tenantID,
+		)
+		_ = response.InternalError(w, "failed to search executions")
+		return
+	}
+
+	_ = response.OK(w, result)
+}
+
+// tagQueryPrefix marks query parameters that filter by execution tag
+const tagQueryPrefix = "tag."
+
 // parseExecutionFilter parses execution filter from query parameters
 func (h *ExecutionHandler) parseExecutionFilter(r *http.Request) (workflow.ExecutionFilter, error) {
 	filter := workflow.ExecutionFilter{
@@ -188,6 +377,18 @@ func (h *ExecutionHandler) parseExecutionFilter(r *http.Request) (workflow.Execu
 		filter.EndDate = &endDate
 	}
 
+	// Tag filters use tag.<key>=<value> query parameters
+	for param, values := range r.URL.Query() {
+		key, ok := strings.CutPrefix(param, tagQueryPrefix)
+		if !ok || key == "" || len(values) == 0 {
+			continue
+		}
+		if filter.Tags == nil {
+			filter.Tags = make(map[string]string)
+		}
+		filter.Tags[key] = values[0]
+	}
+
 	return filter, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,6 +48,30 @@ func (m *MockWorkflowService) GetExecutionStats(ctx context.Context, tenantID st
 	return args.Get(0).(*workflow.ExecutionStats), args.Error(1)
 }
 
+func (m *MockWorkflowService) SearchExecutions(ctx context.Context, tenantID string, filter workflow.ExecutionSearchFilter) (*workflow.ExecutionSearchResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.ExecutionSearchResult), args.Error(1)
+}
+
+func (m *MockWorkflowService) CancelExecution(ctx context.Context, tenantID, executionID, userID string) (*workflow.Execution, error) {
+	args := m.Called(ctx, tenantID, executionID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.Execution), args.Error(1)
+}
+
+func (m *MockWorkflowService) RetryExecution(ctx context.Context, tenantID, executionID, fromNode string) (*workflow.Execution, error) {
+	args := m.Called(ctx, tenantID, executionID, fromNode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.Execution), args.Error(1)
+}
+
 func newTestExecutionHandler() (*ExecutionHandler, *MockWorkflowService) {
 	mockService := new(MockWorkflowService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -156,6 +181,15 @@ func TestListExecutionsAdvanced_WithFilters(t *testing.T) {
 			expectedLimit:  50,
 			expectedCursor: "abc123",
 		},
+		{
+			name:        "filter by tags",
+			queryParams: "?tag.order_id=12345&tag.customer_id=c-9",
+			expectedFilter: workflow.ExecutionFilter{
+				Tags: map[string]string{"order_id": "12345", "customer_id": "c-9"},
+			},
+			expectedLimit:  0,
+			expectedCursor: "",
+		},
 		{
 			name:        "combined filters",
 			queryParams: "?workflow_id=workflow-1&status=completed&limit=10",
@@ -392,6 +426,172 @@ func TestGetExecutionStats_WithFilters(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestSearchExecutions_Success tests error message search with filters and offset pagination
+func TestSearchExecutions_Success(t *testing.T) {
+	handler, mockService := newTestExecutionHandler()
+
+	expectedFilter := workflow.ExecutionSearchFilter{
+		Query:      "connection refused",
+		WorkflowID: "workflow-1",
+		Status:     "failed",
+		Limit:      10,
+		Offset:     20,
+	}
+	expectedResult := &workflow.ExecutionSearchResult{
+		Data:       []*workflow.Execution{{ID: "exec-1", Status: "failed"}},
+		TotalCount: 21,
+		Limit:      10,
+		Offset:     20,
+	}
+
+	mockService.On("SearchExecutions", mock.Anything, "tenant-123", expectedFilter).Return(expectedResult, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/search?q=connection+refused&workflow_id=workflow-1&status=failed&limit=10&offset=20", nil)
+	req = addTenantContext(req, "tenant-123")
+	w := httptest.NewRecorder()
+
+	handler.SearchExecutions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.Equal(t, float64(21), response["total_count"])
+	assert.Len(t, response["data"], 1)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestSearchExecutions_InvalidOffset tests that a bad offset is rejected before searching
+func TestSearchExecutions_InvalidOffset(t *testing.T) {
+	handler, mockService := newTestExecutionHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/executions/search?q=timeout&offset=-1", nil)
+	req = addTenantContext(req, "tenant-123")
+	w := httptest.NewRecorder()
+
+	handler.SearchExecutions(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "SearchExecutions")
+}
+
+// TestCancelExecution tests cancelling an execution on behalf of the current user
+func TestCancelExecution(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceResult  *workflow.Execution
+		serviceErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "cancelled",
+			serviceResult:  &workflow.Execution{ID: "exec-1", Status: string(workflow.ExecutionStatusCancelled)},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "not found",
+			serviceErr:     workflow.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "already finished",
+			serviceErr:     &workflow.ValidationError{Message: "cannot cancel execution in completed state"},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestExecutionHandler()
+
+			var result interface{}
+			if tt.serviceResult != nil {
+				result = tt.serviceResult
+			}
+			mockService.On("CancelExecution", mock.Anything, "tenant-123", "exec-1", "user-1").Return(result, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/exec-1/cancel", nil)
+			req = addUserContext(req, "tenant-123", "user-1")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("executionID", "exec-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.CancelExecution(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestRetryExecution tests retrying an execution from a node
+func TestRetryExecution(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceResult  *workflow.Execution
+		serviceErr     error
+		expectService  bool
+		expectedStatus int
+	}{
+		{
+			name:           "retried",
+			body:           `{"from_node":"http-1"}`,
+			serviceResult:  &workflow.Execution{ID: "exec-2", Status: string(workflow.ExecutionStatusPending)},
+			expectService:  true,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing from_node",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid node",
+			body:           `{"from_node":"missing"}`,
+			serviceErr:     &workflow.ValidationError{Message: "node missing not found in workflow"},
+			expectService:  true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not found",
+			body:           `{"from_node":"http-1"}`,
+			serviceErr:     workflow.ErrNotFound,
+			expectService:  true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestExecutionHandler()
+
+			if tt.expectService {
+				var result interface{}
+				if tt.serviceResult != nil {
+					result = tt.serviceResult
+				}
+				mockService.On("RetryExecution", mock.Anything, "tenant-123", "exec-1", mock.Anything).Return(result, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/executions/exec-1/retry", strings.NewReader(tt.body))
+			req = addTenantContext(req, "tenant-123")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("executionID", "exec-1")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.RetryExecution(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // TestMissingTenantID tests handler behavior when tenant ID is missing
 func TestMissingTenantID(t *testing.T) {
 	handler, _ := newTestExecutionHandler()
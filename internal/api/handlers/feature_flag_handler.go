@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/featureflag"
+)
+
+// FeatureFlagService defines the feature flag management used by the admin API
+type FeatureFlagService interface {
+	ListFlags(ctx context.Context) ([]featureflag.Flag, error)
+	ListTenantFlags(ctx context.Context, tenantID string) ([]featureflag.TenantFlag, error)
+	SetDefault(ctx context.Context, key string, input featureflag.UpdateFlagInput) (*featureflag.Flag, error)
+	SetOverride(ctx context.Context, tenantID, key string, input featureflag.UpdateFlagInput, updatedBy string) error
+	DeleteOverride(ctx context.Context, tenantID, key string) error
+}
+
+// FeatureFlagHandler handles the admin feature flag API
+type FeatureFlagHandler struct {
+	service FeatureFlagService
+	logger  *slog.Logger
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(service FeatureFlagService, logger *slog.Logger) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List handles GET /api/v1/admin/feature-flags
+func (h *FeatureFlagHandler) List(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.service.ListFlags(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list feature flags", "error", err)
+		_ = response.InternalError(w, "failed to list feature flags")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": flags,
+	})
+}
+
+// SetDefault handles PUT /api/v1/admin/feature-flags/{flag}
+func (h *FeatureFlagHandler) SetDefault(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "flag")
+
+	var input featureflag.UpdateFlagInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	flag, err := h.service.SetDefault(r.Context(), key, input)
+	if err != nil {
+		h.writeError(w, err, "failed to update feature flag", "flag", key)
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": flag,
+	})
+}
+
+// ListTenant handles GET /api/v1/admin/tenants/{tenantID}/feature-flags
+func (h *FeatureFlagHandler) ListTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+
+	flags, err := h.service.ListTenantFlags(r.Context(), tenantID)
+	if err != nil {
+		h.logger.Error("failed to list tenant feature flags", "error", err, "tenant_id", tenantID)
+		_ = response.InternalError(w, "failed to list feature flags")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": flags,
+	})
+}
+
+// SetOverride handles PUT /api/v1/admin/tenants/{tenantID}/feature-flags/{flag}
+func (h *FeatureFlagHandler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	key := chi.URLParam(r, "flag")
+
+	var input featureflag.UpdateFlagInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	if err := h.service.SetOverride(r.Context(), tenantID, key, input, middleware.GetUserID(r)); err != nil {
+		h.writeError(w, err, "failed to update feature flag", "flag", key, "tenant_id", tenantID)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// DeleteOverride handles DELETE /api/v1/admin/tenants/{tenantID}/feature-flags/{flag}
+func (h *FeatureFlagHandler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	key := chi.URLParam(r, "flag")
+
+	if err := h.service.DeleteOverride(r.Context(), tenantID, key); err != nil {
+		h.writeError(w, err, "failed to delete feature flag override", "flag", key, "tenant_id", tenantID)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// writeError writes a domain error, or logs err and writes message as an internal error
+func (h *FeatureFlagHandler) writeError(w http.ResponseWriter, err error, message string, attrs ...any) {
+	if apiErr := domainError(err); apiErr != nil {
+		_ = response.WriteError(w, apiErr)
+		return
+	}
+	h.logger.Error(message, append([]any{"error", err}, attrs...)...)
+	_ = response.InternalError(w, message)
+}
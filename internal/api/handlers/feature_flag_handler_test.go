@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/featureflag"
+)
+
+// MockFeatureFlagService is a mock implementation of FeatureFlagService
+type MockFeatureFlagService struct {
+	mock.Mock
+}
+
+func (m *MockFeatureFlagService) ListFlags(ctx context.Context) ([]featureflag.Flag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]featureflag.Flag), args.Error(1)
+}
+
+func (m *MockFeatureFlagService) ListTenantFlags(ctx context.Context, tenantID string) ([]featureflag.TenantFlag, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]featureflag.TenantFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagService) SetDefault(ctx context.Context, key string, input featureflag.UpdateFlagInput) (*featureflag.Flag, error) {
+	args := m.Called(ctx, key, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*featureflag.Flag), args.Error(1)
+}
+
+func (m *MockFeatureFlagService) SetOverride(ctx context.Context, tenantID, key string, input featureflag.UpdateFlagInput, updatedBy string) error {
+	args := m.Called(ctx, tenantID, key, input, updatedBy)
+	return args.Error(0)
+}
+
+func (m *MockFeatureFlagService) DeleteOverride(ctx context.Context, tenantID, key string) error {
+	args := m.Called(ctx, tenantID, key)
+	return args.Error(0)
+}
+
+func newTestFeatureFlagRouter() (http.Handler, *MockFeatureFlagService) {
+	service := new(MockFeatureFlagService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewFeatureFlagHandler(service, logger)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.UserContextKey, &middleware.User{ID: "admin-1"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Get("/feature-flags", handler.List)
+	r.Put("/feature-flags/{flag}", handler.SetDefault)
+	r.Get("/tenants/{tenantID}/feature-flags", handler.ListTenant)
+	r.Put("/tenants/{tenantID}/feature-flags/{flag}", handler.SetOverride)
+	r.Delete("/tenants/{tenantID}/feature-flags/{flag}", handler.DeleteOverride)
+	return r, service
+}
+
+func TestFeatureFlagHandler_SetDefault(t *testing.T) {
+	router, service := newTestFeatureFlagRouter()
+	enabled := false
+	service.On("SetDefault", mock.Anything, "switch_node", featureflag.UpdateFlagInput{Enabled: &enabled}).
+		Return(&featureflag.Flag{Key: "switch_node", Enabled: false}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/feature-flags/switch_node", strings.NewReader(`{"enabled":false}`)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"switch_node"`)
+	service.AssertExpectations(t)
+}
+
+func TestFeatureFlagHandler_SetOverride(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "override set", body: `{"enabled":true}`, expectedStatus: http.StatusNoContent},
+		{name: "unknown flag", body: `{"enabled":true}`, serviceErr: featureflag.ErrNotFound, expectedStatus: http.StatusNotFound, expectedCode: "feature_flag_not_found"},
+		{name: "missing enabled", body: `{}`, serviceErr: &featureflag.ValidationError{Message: "enabled is required"}, expectedStatus: http.StatusBadRequest, expectedCode: "validation_failed"},
+		{name: "malformed body", body: `{`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newTestFeatureFlagRouter()
+			input := featureflag.UpdateFlagInput{}
+			if strings.Contains(tt.body, "enabled") {
+				input.Enabled = &enabled
+			}
+			service.On("SetOverride", mock.Anything, "tenant-1", "switch_node", input, "admin-1").Return(tt.serviceErr).Maybe()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/tenants/tenant-1/feature-flags/switch_node", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tt.expectedCode+`"`)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagHandler_DeleteOverride(t *testing.T) {
+	router, service := newTestFeatureFlagRouter()
+	service.On("DeleteOverride", mock.Anything, "tenant-1", "switch_node").Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tenants/tenant-1/feature-flags/switch_node", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestFeatureFlagHandler_ListTenant(t *testing.T) {
+	router, service := newTestFeatureFlagRouter()
+	override := false
+	service.On("ListTenantFlags", mock.Anything, "tenant-1").Return([]featureflag.TenantFlag{
+		{Key: "switch_node", Default: true, Override: &override, Enabled: false},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/feature-flags", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"override":false`)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -20,10 +21,49 @@ type RedisPinger interface {
 	Ping(ctx context.Context) *redis.StatusCmd
 }
 
+// ReadinessCheck is an additional dependency verified by Ready
+type ReadinessCheck struct {
+	// Name is the key of the check in the response
+	Name string
+	// Check returns an error when the dependency is unusable
+	Check func(ctx context.Context) error
+	// Critical checks fail readiness with 503; other failures are only reported
+	Critical bool
+	// CacheTTL reuses the last result for this long, for checks that are
+	// costly or rate limited such as KMS calls
+	CacheTTL time.Duration
+}
+
+// cachedCheck is a registered readiness check and its last result
+type cachedCheck struct {
+	ReadinessCheck
+
+	mu        sync.Mutex
+	lastErr   error
+	checkedAt time.Time
+}
+
+// run returns the check's cached result or runs it
+func (c *cachedCheck) run(ctx context.Context) error {
+	if c.CacheTTL <= 0 {
+		return c.Check(ctx)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.CacheTTL {
+		return c.lastErr
+	}
+	c.lastErr = c.Check(ctx)
+	c.checkedAt = time.Now()
+	return c.lastErr
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	db    DBPinger
-	redis RedisPinger
+	db     DBPinger
+	redis  RedisPinger
+	checks []*cachedCheck
 }
 
 // NewHealthHandler creates a new health handler
@@ -34,6 +74,12 @@ func NewHealthHandler(db *sqlx.DB, redis *redis.Client) *HealthHandler {
 	}
 }
 
+// AddReadinessCheck registers a dependency verified by Ready alongside the
+// database and Redis, which are always critical
+func (h *HealthHandler) AddReadinessCheck(check ReadinessCheck) {
+	h.checks = append(h.checks, &cachedCheck{ReadinessCheck: check})
+}
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status    string            `json:"status"`
@@ -41,9 +87,9 @@ type HealthResponse struct {
 	Checks    map[string]string `json:"checks,omitempty"`
 }
 
-// Health returns basic health status
+// Health is a cheap liveness probe that does not touch any dependency
 // @Summary Health check
-// @Description Returns basic health status of the API
+// @Description Returns basic liveness status of the API without checking dependencies
 // @Tags Health
 // @Produce json
 // @Success 200 {object} HealthResponse
@@ -58,48 +104,67 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// Ready returns readiness status including dependency checks
+// Ready returns readiness status including dependency checks. The database,
+// Redis and every critical registered check (such as KMS and the job queue)
+// must pass, otherwise it responds 503 so the pod receives no traffic.
 // @Summary Readiness check
-// @Description Returns readiness status including database and Redis health checks
+// @Description Returns per-dependency readiness status (database, Redis, KMS, job queue)
 // @Tags Health
 // @Produce json
-// @Success 200 {object} HealthResponse "All dependencies healthy"
-// @Failure 503 {object} HealthResponse "One or more dependencies unhealthy"
+// @Success 200 {object} HealthResponse "All critical dependencies healthy"
+// @Failure 503 {object} HealthResponse "One or more critical dependencies unhealthy"
 // @Router /ready [get]
 func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	checks := make(map[string]string)
-	allHealthy := true
-
-	// Check database
-	if err := h.db.PingContext(ctx); err != nil {
-		checks["database"] = "unhealthy: " + err.Error()
-		allHealthy = false
-	} else {
-		checks["database"] = "healthy"
+	checks := []*cachedCheck{
+		{ReadinessCheck: ReadinessCheck{Name: "database", Check: h.db.PingContext, Critical: true}},
+		{ReadinessCheck: ReadinessCheck{Name: "redis", Check: func(ctx context.Context) error {
+			return h.redis.Ping(ctx).Err()
+		}, Critical: true}},
 	}
-
-	// Check Redis
-	if err := h.redis.Ping(ctx).Err(); err != nil {
-		checks["redis"] = "unhealthy: " + err.Error()
-		allHealthy = false
-	} else {
-		checks["redis"] = "healthy"
+	checks = append(checks, h.checks...)
+
+	// Run checks concurrently so one slow dependency doesn't use up the timeout
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check *cachedCheck) {
+			defer wg.Done()
+			errs[i] = check.run(ctx)
+		}(i, check)
+	}
+	wg.Wait()
+
+	results := make(map[string]string, len(checks))
+	allHealthy, criticalHealthy := true, true
+	for i, check := range checks {
+		if errs[i] != nil {
+			results[check.Name] = "unhealthy: " + errs[i].Error()
+			allHealthy = false
+			if check.Critical {
+				criticalHealthy = false
+			}
+		} else {
+			results[check.Name] = "healthy"
+		}
 	}
 
 	status := "ok"
 	statusCode := http.StatusOK
 	if !allHealthy {
 		status = "degraded"
+	}
+	if !criticalHealthy {
 		statusCode = http.StatusServiceUnavailable
 	}
 
 	resp := HealthResponse{
 		Status:    status,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Checks:    checks,
+		Checks:    results,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -184,6 +185,85 @@ func TestHealthHandler_Ready(t *testing.T) {
 	}
 }
 
+func TestHealthHandler_Ready_AdditionalChecks(t *testing.T) {
+	newHandler := func() *HealthHandler {
+		mockDB := new(MockDBPinger)
+		mockDB.On("PingContext", mock.Anything).Return(nil)
+		mockRedis := new(MockRedisPinger)
+		cmd := redis.NewStatusCmd(context.Background())
+		cmd.SetVal("PONG")
+		mockRedis.On("Ping", mock.Anything).Return(cmd)
+		return &HealthHandler{db: mockDB, redis: mockRedis}
+	}
+
+	ready := func(handler *HealthHandler) (*httptest.ResponseRecorder, HealthResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+		rr := httptest.NewRecorder()
+		handler.Ready(rr, req)
+
+		var response HealthResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr, response
+	}
+
+	t.Run("critical check failure is unavailable", func(t *testing.T) {
+		handler := newHandler()
+		handler.AddReadinessCheck(ReadinessCheck{
+			Name:     "kms",
+			Check:    func(ctx context.Context) error { return errors.New("AccessDeniedException") },
+			Critical: true,
+		})
+		handler.AddReadinessCheck(ReadinessCheck{
+			Name:     "queue",
+			Check:    func(ctx context.Context) error { return nil },
+			Critical: true,
+		})
+
+		rr, response := ready(handler)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, "degraded", response.Status)
+		assert.Contains(t, response.Checks["kms"], "unhealthy: AccessDeniedException")
+		assert.Equal(t, "healthy", response.Checks["queue"])
+		assert.Equal(t, "healthy", response.Checks["database"])
+	})
+
+	t.Run("non-critical check failure stays ready", func(t *testing.T) {
+		handler := newHandler()
+		handler.AddReadinessCheck(ReadinessCheck{
+			Name:  "search",
+			Check: func(ctx context.Context) error { return errors.New("timeout") },
+		})
+
+		rr, response := ready(handler)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "degraded", response.Status)
+		assert.Contains(t, response.Checks["search"], "unhealthy")
+	})
+
+	t.Run("cached check runs once per TTL", func(t *testing.T) {
+		handler := newHandler()
+		calls := 0
+		handler.AddReadinessCheck(ReadinessCheck{
+			Name: "kms",
+			Check: func(ctx context.Context) error {
+				calls++
+				return nil
+			},
+			Critical: true,
+			CacheTTL: time.Minute,
+		})
+
+		for i := 0; i < 3; i++ {
+			rr, response := ready(handler)
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "ok", response.Status)
+		}
+		assert.Equal(t, 1, calls)
+	})
+}
+
 func TestNewHealthHandler(t *testing.T) {
 	// NewHealthHandler expects concrete types, but our handler stores interfaces
 	// Since we can't easily create nil *sqlx.DB and *redis.Client for testing,
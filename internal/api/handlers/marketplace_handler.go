@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/api/response"
 	"github.com/gorax/gorax/internal/marketplace"
+	"github.com/gorax/gorax/internal/template"
 )
 
 // MarketplaceHandler handles marketplace HTTP requests
@@ -29,11 +31,16 @@ type MarketplaceService interface {
 	PublishTemplate(ctx context.Context, userID, userName string, input marketplace.PublishTemplateInput) (*marketplace.MarketplaceTemplate, error)
 	GetTemplate(ctx context.Context, id string) (*marketplace.MarketplaceTemplate, error)
 	SearchTemplates(ctx context.Context, filter marketplace.SearchFilter) ([]*marketplace.MarketplaceTemplate, error)
-	GetTrending(ctx context.Context, limit int) ([]*marketplace.MarketplaceTemplate, error)
-	GetPopular(ctx context.Context, limit int) ([]*marketplace.MarketplaceTemplate, error)
+	GetTrending(ctx context.Context, limit int, verifiedOnly bool) ([]*marketplace.MarketplaceTemplate, error)
+	GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*marketplace.MarketplaceTemplate, error)
+	SetVerified(ctx context.Context, templateID string, verified bool, adminID string) (*marketplace.MarketplaceTemplate, error)
 	InstallTemplate(ctx context.Context, tenantID, userID, templateID string, input marketplace.InstallTemplateInput) (*marketplace.InstallTemplateResult, error)
+	PublishNewVersion(ctx context.Context, userID, templateID string, input marketplace.PublishVersionInput) (*marketplace.MarketplaceTemplate, error)
+	GetTemplateVersions(ctx context.Context, templateID string) ([]*marketplace.TemplateVersion, error)
+	GetTemplateVersion(ctx context.Context, templateID, version string) (*marketplace.TemplateVersion, error)
+	ListInstalledWithUpdates(ctx context.Context, tenantID string) ([]*marketplace.InstalledTemplateUpdate, error)
 	RateTemplate(ctx context.Context, tenantID, userID, userName, templateID string, input marketplace.RateTemplateInput) (*marketplace.TemplateReview, error)
-	GetReviews(ctx context.Context, templateID string, sortBy marketplace.ReviewSortOption, limit, offset int) ([]*marketplace.TemplateReview, error)
+	GetReviews(ctx context.Context, templateID string, sortBy marketplace.ReviewSortOption, limit, offset int) ([]*marketplace.TemplateReview, int, error)
 	DeleteReview(ctx context.Context, tenantID, templateID, reviewID string) error
 	GetCategories() []string
 	VoteReviewHelpful(ctx context.Context, tenantID, userID, reviewID string) error
@@ -73,6 +80,7 @@ func NewMarketplaceHandler(service MarketplaceService, categoryService CategoryS
 // @Param tags query string false "Comma-separated list of tags"
 // @Param min_rating query number false "Minimum rating (0-5)"
 // @Param is_verified query boolean false "Filter by verification status"
+// @Param verified_only query boolean false "Only include system and verified publishers"
 // @Param sort_by query string false "Sort field (created_at, updated_at, rating, installs)" Enums(created_at, updated_at, rating, installs)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Results per page" default(20)
@@ -83,9 +91,10 @@ func NewMarketplaceHandler(service MarketplaceService, categoryService CategoryS
 // @Router /api/v1/marketplace/templates [get]
 func (h *MarketplaceHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
 	filter := marketplace.SearchFilter{
-		Category:    r.URL.Query().Get("category"),
-		SearchQuery: r.URL.Query().Get("search"),
-		SortBy:      r.URL.Query().Get("sort_by"),
+		Category:     r.URL.Query().Get("category"),
+		SearchQuery:  r.URL.Query().Get("search"),
+		SortBy:       r.URL.Query().Get("sort_by"),
+		VerifiedOnly: r.URL.Query().Get("verified_only") == "true",
 	}
 
 	if tags := r.URL.Query().Get("tags"); tags != "" {
@@ -201,7 +210,7 @@ func (h *MarketplaceHandler) PublishTemplate(w http.ResponseWriter, r *http.Requ
 
 // InstallTemplate installs a template as a workflow
 // @Summary Install marketplace template
-// @Description Installs a marketplace template as a workflow in the tenant's account
+// @Description Installs a marketplace template as a workflow in the tenant's account. With install_mode "draft" the workflow starts disabled and is created even when parameters or prerequisites are missing
 // @Tags Marketplace
 // @Accept json
 // @Produce json
@@ -210,9 +219,10 @@ func (h *MarketplaceHandler) PublishTemplate(w http.ResponseWriter, r *http.Requ
 // @Security TenantID
 // @Security UserID
 // @Success 200 {object} marketplace.InstallTemplateResult "Installation result with workflow ID"
-// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 400 {object} map[string]string "Invalid request or parameter values"
 // @Failure 404 {object} map[string]string "Template not found"
 // @Failure 409 {object} map[string]string "Template already installed"
+// @Failure 422 {object} marketplace.InstallTemplateResult "Required credentials or environment variables are not configured for an active install"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/marketplace/templates/{id}/install [post]
 func (h *MarketplaceHandler) InstallTemplate(w http.ResponseWriter, r *http.Request) {
@@ -241,13 +251,154 @@ func (h *MarketplaceHandler) InstallTemplate(w http.ResponseWriter, r *http.Requ
 			_ = response.Conflict(w, "template already installed")
 			return
 		}
+		if errors.Is(err, template.ErrInvalidParameters) {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
 		_ = response.InternalError(w, "failed to install template")
 		return
 	}
 
+	if result.WorkflowID == "" && result.UnmetPrerequisites != nil {
+		_ = response.JSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+
 	_ = response.OK(w, result)
 }
 
+// PublishVersion publishes a new version of an existing marketplace template
+// @Summary Publish template version
+// @Description Publishes a new version of a template. Only the author can publish, and the version must be newer than the current one
+// @Tags Marketplace
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param version body marketplace.PublishVersionInput true "Version data"
+// @Security TenantID
+// @Security UserID
+// @Success 201 {object} marketplace.MarketplaceTemplate "Template at the new version"
+// @Failure 400 {object} map[string]string "Invalid request or version not newer"
+// @Failure 403 {object} map[string]string "Not the template author"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 409 {object} map[string]string "Version already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/marketplace/templates/{id}/versions [post]
+func (h *MarketplaceHandler) PublishVersion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	templateID := chi.URLParam(r, "id")
+
+	var input marketplace.PublishVersionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	template, err := h.service.PublishNewVersion(r.Context(), userID, templateID, input)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			_ = response.NotFound(w, "template not found")
+			return
+		}
+		if strings.Contains(err.Error(), "only the template author") {
+			_ = response.Forbidden(w, "only the template author can publish new versions")
+			return
+		}
+		if strings.Contains(err.Error(), "already exists") {
+			_ = response.Conflict(w, "version already exists")
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
+		_ = response.InternalError(w, "failed to publish version")
+		return
+	}
+
+	_ = response.Created(w, template)
+}
+
+// GetTemplateVersions returns the version history of a template
+// @Summary List template versions
+// @Description Retrieves all published versions of a marketplace template, newest first
+// @Tags Marketplace
+// @Produce json
+// @Param id path string true "Template ID"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {array} marketplace.TemplateVersion "Template versions"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/marketplace/templates/{id}/versions [get]
+func (h *MarketplaceHandler) GetTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "id")
+
+	versions, err := h.service.GetTemplateVersions(r.Context(), templateID)
+	if err != nil {
+		_ = response.InternalError(w, "failed to get template versions")
+		return
+	}
+
+	_ = response.OK(w, versions)
+}
+
+// GetTemplateVersion returns a single version of a template
+// @Summary Get template version
+// @Description Retrieves the definition and change notes of a specific template version
+// @Tags Marketplace
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param version path string true "Version"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} marketplace.TemplateVersion "Template version"
+// @Failure 404 {object} map[string]string "Version not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/marketplace/templates/{id}/versions/{version} [get]
+func (h *MarketplaceHandler) GetTemplateVersion(w http.ResponseWriter, r *http.Request) {
+	templateID := chi.URLParam(r, "id")
+	version := chi.URLParam(r, "version")
+
+	templateVersion, err := h.service.GetTemplateVersion(r.Context(), templateID, version)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			_ = response.NotFound(w, "version not found")
+			return
+		}
+		_ = response.InternalError(w, "failed to get template version")
+		return
+	}
+
+	_ = response.OK(w, templateVersion)
+}
+
+// ListInstalledUpdates returns installed templates that have a newer version available
+// @Summary List available template updates
+// @Description Lists templates installed by the tenant whose upstream version is newer than the installed version
+// @Tags Marketplace
+// @Produce json
+// @Security TenantID
+// @Security UserID
+// @Success 200 {array} marketplace.InstalledTemplateUpdate "Installed templates with updates"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/marketplace/installed/updates [get]
+func (h *MarketplaceHandler) ListInstalledUpdates(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+
+	updates, err := h.service.ListInstalledWithUpdates(r.Context(), tenantID)
+	if err != nil {
+		_ = response.InternalError(w, "failed to list template updates")
+		return
+	}
+
+	_ = response.OK(w, updates)
+}
+
 // RateTemplate adds or updates a rating for a template
 // @Summary Rate marketplace template
 // @Description Submits or updates a rating and review for a marketplace template
@@ -260,6 +411,7 @@ func (h *MarketplaceHandler) InstallTemplate(w http.ResponseWriter, r *http.Requ
 // @Security UserID
 // @Success 200 {object} marketplace.TemplateReview "Submitted review"
 // @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 429 {object} map[string]string "Too many new reviews today"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/marketplace/templates/{id}/rate [post]
 func (h *MarketplaceHandler) RateTemplate(w http.ResponseWriter, r *http.Request) {
@@ -281,6 +433,10 @@ func (h *MarketplaceHandler) RateTemplate(w http.ResponseWriter, r *http.Request
 
 	review, err := h.service.RateTemplate(r.Context(), tenantID, userID, userName, templateID, input)
 	if err != nil {
+		if strings.Contains(err.Error(), "rate limit exceeded") {
+			_ = response.Error(w, http.StatusTooManyRequests, err.Error(), "review_rate_limited")
+			return
+		}
 		if strings.Contains(err.Error(), "invalid") {
 			_ = response.BadRequest(w, err.Error())
 			return
@@ -299,43 +455,49 @@ func (h *MarketplaceHandler) RateTemplate(w http.ResponseWriter, r *http.Request
 // @Accept json
 // @Produce json
 // @Param id path string true "Template ID"
-// @Param sort query string false "Sort by: recent, helpful, rating_high, rating_low" default(recent)
-// @Param limit query int false "Maximum results" default(10)
+// @Param sort_by query string false "Sort by: recent, helpful, rating_high, rating_low" default(recent)
+// @Param limit query int false "Maximum results, at most 100" default(10)
 // @Param offset query int false "Pagination offset" default(0)
 // @Security TenantID
 // @Security UserID
-// @Success 200 {array} marketplace.TemplateReview "List of reviews"
+// @Success 200 {object} response.PaginatedResponse "Page of reviews with the total count"
+// @Failure 400 {object} map[string]string "Invalid sort option"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/marketplace/templates/{id}/reviews [get]
 func (h *MarketplaceHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
 	templateID := chi.URLParam(r, "id")
+	query := r.URL.Query()
 
-	sortBy := marketplace.ReviewSortRecent
-	if sortStr := r.URL.Query().Get("sort"); sortStr != "" {
-		sortBy = marketplace.ReviewSortOption(sortStr)
+	// sort is the parameter's original name
+	sortBy := marketplace.ReviewSortOption(query.Get("sort_by"))
+	if sortBy == "" {
+		sortBy = marketplace.ReviewSortOption(query.Get("sort"))
+	}
+	if sortBy == "" {
+		sortBy = marketplace.ReviewSortRecent
 	}
 
-	limit := 10
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil {
-			limit = l
-		}
+	limit := marketplace.DefaultReviewLimit
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		limit = min(l, marketplace.MaxReviewLimit)
 	}
 
 	offset := 0
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil {
-			offset = o
-		}
+	if o, err := strconv.Atoi(query.Get("offset")); err == nil && o > 0 {
+		offset = o
 	}
 
-	reviews, err := h.service.GetReviews(r.Context(), templateID, sortBy, limit, offset)
+	reviews, total, err := h.service.GetReviews(r.Context(), templateID, sortBy, limit, offset)
 	if err != nil {
+		if errors.Is(err, marketplace.ErrInvalidReviewSort) {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
 		_ = response.InternalError(w, "failed to get reviews")
 		return
 	}
 
-	_ = response.OK(w, reviews)
+	_ = response.Paginated(w, reviews, limit, offset, total)
 }
 
 // DeleteReview deletes a review
@@ -376,6 +538,7 @@ func (h *MarketplaceHandler) DeleteReview(w http.ResponseWriter, r *http.Request
 // @Accept json
 // @Produce json
 // @Param limit query int false "Maximum results" default(10)
+// @Param verified_only query boolean false "Only include system and verified publishers"
 // @Security TenantID
 // @Security UserID
 // @Success 200 {array} marketplace.MarketplaceTemplate "Trending templates"
@@ -389,7 +552,7 @@ func (h *MarketplaceHandler) GetTrending(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	templates, err := h.service.GetTrending(r.Context(), limit)
+	templates, err := h.service.GetTrending(r.Context(), limit, r.URL.Query().Get("verified_only") == "true")
 	if err != nil {
 		_ = response.InternalError(w, "failed to get trending templates")
 		return
@@ -405,6 +568,7 @@ func (h *MarketplaceHandler) GetTrending(w http.ResponseWriter, r *http.Request)
 // @Accept json
 // @Produce json
 // @Param limit query int false "Maximum results" default(10)
+// @Param verified_only query boolean false "Only include system and verified publishers"
 // @Security TenantID
 // @Security UserID
 // @Success 200 {array} marketplace.MarketplaceTemplate "Popular templates"
@@ -418,7 +582,7 @@ func (h *MarketplaceHandler) GetPopular(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	templates, err := h.service.GetPopular(r.Context(), limit)
+	templates, err := h.service.GetPopular(r.Context(), limit, r.URL.Query().Get("verified_only") == "true")
 	if err != nil {
 		_ = response.InternalError(w, "failed to get popular templates")
 		return
@@ -772,6 +936,58 @@ func (h *MarketplaceHandler) HideReview(w http.ResponseWriter, r *http.Request)
 	response.NoContent(w)
 }
 
+// SetVerifiedInput represents input for changing a template's verification
+type SetVerifiedInput struct {
+	Verified *bool `json:"verified" validate:"required"`
+}
+
+// SetVerified marks a template's publisher as verified or unverified (admin only)
+// @Summary Set template verification (admin only)
+// @Description Marks a template's publisher as verified or community. System templates are always verified.
+// @Tags Marketplace
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param input body SetVerifiedInput true "Verification status"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} marketplace.MarketplaceTemplate "Updated template"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Template not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/marketplace/admin/templates/{id}/verify [put]
+func (h *MarketplaceHandler) SetVerified(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r)
+	templateID := chi.URLParam(r, "id")
+
+	var input SetVerifiedInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	template, err := h.service.SetVerified(r.Context(), templateID, *input.Verified, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			_ = response.NotFound(w, "template not found")
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
+		_ = response.InternalError(w, "failed to set template verification")
+		return
+	}
+
+	_ = response.OK(w, template)
+}
+
 func (h *MarketplaceHandler) getUserName(r *http.Request) string {
 	if userName, ok := r.Context().Value("user_name").(string); ok {
 		return userName
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/marketplace"
+	"github.com/gorax/gorax/internal/template"
 	"github.com/gorax/gorax/internal/tenant"
 )
 
@@ -53,16 +55,24 @@ func (m *MockMarketplaceService) SearchTemplates(ctx context.Context, filter mar
 	return args.Get(0).([]*marketplace.MarketplaceTemplate), args.Error(1)
 }
 
-func (m *MockMarketplaceService) GetTrending(ctx context.Context, limit int) ([]*marketplace.MarketplaceTemplate, error) {
-	args := m.Called(ctx, limit)
+func (m *MockMarketplaceService) GetTrending(ctx context.Context, limit int, verifiedOnly bool) ([]*marketplace.MarketplaceTemplate, error) {
+	args := m.Called(ctx, limit, verifiedOnly)
 	return args.Get(0).([]*marketplace.MarketplaceTemplate), args.Error(1)
 }
 
-func (m *MockMarketplaceService) GetPopular(ctx context.Context, limit int) ([]*marketplace.MarketplaceTemplate, error) {
-	args := m.Called(ctx, limit)
+func (m *MockMarketplaceService) GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*marketplace.MarketplaceTemplate, error) {
+	args := m.Called(ctx, limit, verifiedOnly)
 	return args.Get(0).([]*marketplace.MarketplaceTemplate), args.Error(1)
 }
 
+func (m *MockMarketplaceService) SetVerified(ctx context.Context, templateID string, verified bool, adminID string) (*marketplace.MarketplaceTemplate, error) {
+	args := m.Called(ctx, templateID, verified, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*marketplace.MarketplaceTemplate), args.Error(1)
+}
+
 func (m *MockMarketplaceService) InstallTemplate(ctx context.Context, tenantID, userID, templateID string, input marketplace.InstallTemplateInput) (*marketplace.InstallTemplateResult, error) {
 	args := m.Called(ctx, tenantID, userID, templateID, input)
 	if args.Get(0) == nil {
@@ -71,6 +81,38 @@ func (m *MockMarketplaceService) InstallTemplate(ctx context.Context, tenantID,
 	return args.Get(0).(*marketplace.InstallTemplateResult), args.Error(1)
 }
 
+func (m *MockMarketplaceService) PublishNewVersion(ctx context.Context, userID, templateID string, input marketplace.PublishVersionInput) (*marketplace.MarketplaceTemplate, error) {
+	args := m.Called(ctx, userID, templateID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*marketplace.MarketplaceTemplate), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetTemplateVersions(ctx context.Context, templateID string) ([]*marketplace.TemplateVersion, error) {
+	args := m.Called(ctx, templateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*marketplace.TemplateVersion), args.Error(1)
+}
+
+func (m *MockMarketplaceService) GetTemplateVersion(ctx context.Context, templateID, version string) (*marketplace.TemplateVersion, error) {
+	args := m.Called(ctx, templateID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*marketplace.TemplateVersion), args.Error(1)
+}
+
+func (m *MockMarketplaceService) ListInstalledWithUpdates(ctx context.Context, tenantID string) ([]*marketplace.InstalledTemplateUpdate, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*marketplace.InstalledTemplateUpdate), args.Error(1)
+}
+
 func (m *MockMarketplaceService) RateTemplate(ctx context.Context, tenantID, userID, userName, templateID string, input marketplace.RateTemplateInput) (*marketplace.TemplateReview, error) {
 	args := m.Called(ctx, tenantID, userID, userName, templateID, input)
 	if args.Get(0) == nil {
@@ -79,9 +121,12 @@ func (m *MockMarketplaceService) RateTemplate(ctx context.Context, tenantID, use
 	return args.Get(0).(*marketplace.TemplateReview), args.Error(1)
 }
 
-func (m *MockMarketplaceService) GetReviews(ctx context.Context, templateID string, sortBy marketplace.ReviewSortOption, limit, offset int) ([]*marketplace.TemplateReview, error) {
+func (m *MockMarketplaceService) GetReviews(ctx context.Context, templateID string, sortBy marketplace.ReviewSortOption, limit, offset int) ([]*marketplace.TemplateReview, int, error) {
 	args := m.Called(ctx, templateID, sortBy, limit, offset)
-	return args.Get(0).([]*marketplace.TemplateReview), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*marketplace.TemplateReview), args.Int(1), args.Error(2)
 }
 
 func (m *MockMarketplaceService) DeleteReview(ctx context.Context, tenantID, templateID, reviewID string) error {
@@ -288,6 +333,186 @@ func TestInstallTemplate(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestInstallTemplate_InvalidParameters(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	input := marketplace.InstallTemplateInput{WorkflowName: "My Workflow"}
+
+	service.On("InstallTemplate", mock.Anything, "tenant-1", "user-1", "template-1", input).
+		Return(nil, fmt.Errorf("%w: missing required parameters: env", template.ErrInvalidParameters))
+
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/install", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	ten := &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant"}
+	user := &middleware.User{ID: "user-1", Email: "test@example.com", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.TenantContextKey, ten)
+	ctx = context.WithValue(ctx, middleware.UserContextKey, user)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	handler.InstallTemplate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "missing required parameters: env")
+}
+
+func TestInstallTemplate_UnmetPrerequisites(t *testing.T) {
+	service := new(MockMarketplaceService)
+	handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	input := marketplace.InstallTemplateInput{WorkflowName: "Lead Sync"}
+	result := &marketplace.InstallTemplateResult{
+		WorkflowName: "Lead Sync",
+		UnmetPrerequisites: &marketplace.UnmetPrerequisites{
+			Credentials: []string{"salesforce_token"},
+		},
+	}
+	service.On("InstallTemplate", mock.Anything, "tenant-1", "user-1", "template-1", input).Return(result, nil)
+
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/install", bytes.NewReader(body))
+	ten := &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant"}
+	user := &middleware.User{ID: "user-1", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.TenantContextKey, ten)
+	ctx = context.WithValue(ctx, middleware.UserContextKey, user)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handler.InstallTemplate(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "salesforce_token")
+}
+
+func TestInstallTemplate_DraftWithUnmetPrerequisites(t *testing.T) {
+	service := new(MockMarketplaceService)
+	handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	input := marketplace.InstallTemplateInput{WorkflowName: "Lead Sync", InstallMode: marketplace.InstallModeDraft}
+	result := &marketplace.InstallTemplateResult{
+		WorkflowID:     "workflow-1",
+		WorkflowName:   "Lead Sync",
+		WorkflowStatus: "draft",
+		UnmetPrerequisites: &marketplace.UnmetPrerequisites{
+			Credentials: []string{"salesforce_token"},
+		},
+	}
+	service.On("InstallTemplate", mock.Anything, "tenant-1", "user-1", "template-1", input).Return(result, nil)
+
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/install", bytes.NewReader(body))
+	ten := &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant"}
+	user := &middleware.User{ID: "user-1", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.TenantContextKey, ten)
+	ctx = context.WithValue(ctx, middleware.UserContextKey, user)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	handler.InstallTemplate(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"workflow_status":"draft"`)
+	assert.Contains(t, w.Body.String(), "salesforce_token")
+}
+
+func TestInstallTemplate_InvalidInstallMode(t *testing.T) {
+	service := new(MockMarketplaceService)
+	handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/install",
+		bytes.NewReader([]byte(`{"workflow_name": "Lead Sync", "install_mode": "paused"}`)))
+	w := httptest.NewRecorder()
+
+	handler.InstallTemplate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	service.AssertNotCalled(t, "InstallTemplate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPublishVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", expectedStatus: http.StatusCreated},
+		{name: "not author", serviceErr: errors.New("only the template author can publish new versions"), expectedStatus: http.StatusForbidden},
+		{name: "version not newer", serviceErr: errors.New("invalid version: 1.0.0 must be greater than current version 1.0.0"), expectedStatus: http.StatusBadRequest},
+		{name: "template not found", serviceErr: errors.New("get template: template not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMarketplaceService)
+			handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+			input := marketplace.PublishVersionInput{
+				Version:    "1.1.0",
+				Definition: json.RawMessage(`{"nodes":[],"edges":[]}`),
+			}
+			if tt.serviceErr != nil {
+				service.On("PublishNewVersion", mock.Anything, "user-1", "template-1", mock.Anything).Return(nil, tt.serviceErr)
+			} else {
+				service.On("PublishNewVersion", mock.Anything, "user-1", "template-1", mock.Anything).
+					Return(&marketplace.MarketplaceTemplate{ID: "template-1", Version: "1.1.0"}, nil)
+			}
+
+			body, _ := json.Marshal(input)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/versions", bytes.NewReader(body))
+			user := &middleware.User{ID: "user-1", TenantID: "tenant-1"}
+			ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "template-1")
+			ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+			req = req.WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.PublishVersion(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListInstalledUpdates(t *testing.T) {
+	service := new(MockMarketplaceService)
+	handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	updates := []*marketplace.InstalledTemplateUpdate{
+		{
+			TemplateInstallation: marketplace.TemplateInstallation{TemplateID: "template-1", InstalledVersion: "1.0.0"},
+			TemplateName:         "Error Notification",
+			LatestVersion:        "1.1.0",
+		},
+	}
+	service.On("ListInstalledWithUpdates", mock.Anything, "tenant-1").Return(updates, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/marketplace/installed/updates", nil)
+	ten := &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant"}
+	req = req.WithContext(context.WithValue(req.Context(), middleware.TenantContextKey, ten))
+	w := httptest.NewRecorder()
+
+	handler.ListInstalledUpdates(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"latest_version":"1.1.0"`)
+	service.AssertExpectations(t)
+}
+
 func TestRateTemplate(t *testing.T) {
 	service := new(MockMarketplaceService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -325,6 +550,37 @@ func TestRateTemplate(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestRateTemplate_RateLimited(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	input := marketplace.RateTemplateInput{Rating: 5}
+
+	service.On("RateTemplate", mock.Anything, "tenant-1", "user-1", "Test User", "template-1", input).
+		Return(nil, errors.New("review rate limit exceeded: at most 10 new reviews per day"))
+
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/marketplace/templates/template-1/rate", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	ten := &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant"}
+	user := &middleware.User{ID: "user-1", Email: "test@example.com", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.TenantContextKey, ten)
+	ctx = context.WithValue(ctx, middleware.UserContextKey, user)
+	ctx = context.WithValue(ctx, "user_name", "Test User")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	handler.RateTemplate(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	service.AssertExpectations(t)
+}
+
 func TestGetTrending(t *testing.T) {
 	service := new(MockMarketplaceService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -335,7 +591,7 @@ func TestGetTrending(t *testing.T) {
 		{ID: "1", Name: "Trending 1"},
 	}
 
-	service.On("GetTrending", mock.Anything, 10).Return(templates, nil)
+	service.On("GetTrending", mock.Anything, 10, false).Return(templates, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/marketplace/trending", nil)
 	w := httptest.NewRecorder()
@@ -345,6 +601,27 @@ func TestGetTrending(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestGetTrending_VerifiedOnly(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	templates := []*marketplace.MarketplaceTemplate{
+		{ID: "1", Name: "Trending 1", PublisherType: marketplace.PublisherTypeVerified},
+	}
+
+	service.On("GetTrending", mock.Anything, 10, true).Return(templates, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/marketplace/trending?verified_only=true", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetTrending(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
 func TestGetCategories(t *testing.T) {
 	service := new(MockMarketplaceService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -821,7 +1098,7 @@ func TestMarketplaceIntegration_RateTemplate_ReviewFlow(t *testing.T) {
 			marketplace.ReviewSortRecent,
 			15,
 			10,
-		).Return(reviews, nil)
+		).Return(reviews, 13, nil)
 
 		req := httptest.NewRequest(
 			http.MethodGet,
@@ -839,10 +1116,18 @@ func TestMarketplaceIntegration_RateTemplate_ReviewFlow(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []*marketplace.TemplateReview
-		err := json.NewDecoder(w.Body).Decode(&response)
+		var page struct {
+			Data   []*marketplace.TemplateReview `json:"data"`
+			Limit  int                           `json:"limit"`
+			Offset int                           `json:"offset"`
+			Total  int                           `json:"total"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&page)
 		require.NoError(t, err)
-		assert.Len(t, response, 3)
+		assert.Len(t, page.Data, 3)
+		assert.Equal(t, 15, page.Limit)
+		assert.Equal(t, 10, page.Offset)
+		assert.Equal(t, 13, page.Total)
 		service.AssertExpectations(t)
 	})
 }
@@ -860,7 +1145,7 @@ func TestMarketplaceIntegration_TrendingAndPopular(t *testing.T) {
 			{ID: "tpl-3", Name: "Trending 3", DownloadCount: 400, AverageRating: 4.7},
 		}
 
-		service.On("GetTrending", mock.Anything, 3).Return(templates, nil)
+		service.On("GetTrending", mock.Anything, 3, false).Return(templates, nil)
 
 		req := httptest.NewRequest(
 			http.MethodGet,
@@ -892,7 +1177,7 @@ func TestMarketplaceIntegration_TrendingAndPopular(t *testing.T) {
 			{ID: "tpl-pop-2", Name: "Popular 2", DownloadCount: 900, AverageRating: 4.9},
 		}
 
-		service.On("GetPopular", mock.Anything, 10).Return(templates, nil)
+		service.On("GetPopular", mock.Anything, 10, false).Return(templates, nil)
 
 		req := httptest.NewRequest(
 			http.MethodGet,
@@ -1258,6 +1543,79 @@ func TestHideReview_NotFound(t *testing.T) {
 	service.AssertExpectations(t)
 }
 
+func TestSetVerified_Success(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	template := &marketplace.MarketplaceTemplate{
+		ID:            "template-123",
+		IsVerified:    true,
+		PublisherType: marketplace.PublisherTypeVerified,
+	}
+	service.On("SetVerified", mock.Anything, "template-123", true, "admin-user").Return(template, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/marketplace/admin/templates/template-123/verify", bytes.NewReader([]byte(`{"verified": true}`)))
+	w := httptest.NewRecorder()
+
+	user := &middleware.User{ID: "admin-user", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-123")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	handler.SetVerified(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	service.AssertExpectations(t)
+}
+
+func TestSetVerified_MissingVerified(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/marketplace/admin/templates/template-123/verify", bytes.NewReader([]byte(`{}`)))
+	w := httptest.NewRecorder()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "template-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	handler.SetVerified(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	service.AssertNotCalled(t, "SetVerified", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetVerified_SystemTemplate(t *testing.T) {
+	service := new(MockMarketplaceService)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	categoryService := new(MockCategoryService)
+	handler := NewMarketplaceHandler(service, categoryService, logger)
+
+	service.On("SetVerified", mock.Anything, "builtin-1", false, "admin-user").
+		Return(nil, errors.New("invalid request: system templates are always verified"))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/marketplace/admin/templates/builtin-1/verify", bytes.NewReader([]byte(`{"verified": false}`)))
+	w := httptest.NewRecorder()
+
+	user := &middleware.User{ID: "admin-user", TenantID: "tenant-1"}
+	ctx := context.WithValue(req.Context(), middleware.UserContextKey, user)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "builtin-1")
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	handler.SetVerified(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	service.AssertExpectations(t)
+}
+
 func TestGetReviews_WithSorting(t *testing.T) {
 	service := new(MockMarketplaceService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -1271,7 +1629,7 @@ func TestGetReviews_WithSorting(t *testing.T) {
 	}
 
 	service.On("GetReviews", mock.Anything, "template-123", marketplace.ReviewSortHelpful, 10, 0).
-		Return(reviews, nil)
+		Return(reviews, 3, nil)
 
 	req := httptest.NewRequest(
 		http.MethodGet,
@@ -1288,10 +1646,52 @@ func TestGetReviews_WithSorting(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []*marketplace.TemplateReview
-	err := json.NewDecoder(w.Body).Decode(&response)
+	var page struct {
+		Data []*marketplace.TemplateReview `json:"data"`
+	}
+	err := json.NewDecoder(w.Body).Decode(&page)
 	require.NoError(t, err)
-	assert.Len(t, response, 3)
-	assert.Equal(t, 100, response[0].HelpfulCount)
+	assert.Len(t, page.Data, 3)
+	assert.Equal(t, 100, page.Data[0].HelpfulCount)
 	service.AssertExpectations(t)
 }
+
+func TestGetReviews_QueryParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		sortBy     marketplace.ReviewSortOption
+		limit      int
+		offset     int
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "sort_by", query: "?sort_by=rating_low", sortBy: marketplace.ReviewSortRatingL, limit: 10, wantStatus: http.StatusOK},
+		{name: "limit is clamped", query: "?limit=5000", sortBy: marketplace.ReviewSortRecent, limit: marketplace.MaxReviewLimit, wantStatus: http.StatusOK},
+		{name: "negative offset", query: "?offset=-5", sortBy: marketplace.ReviewSortRecent, limit: 10, wantStatus: http.StatusOK},
+		{name: "invalid sort", query: "?sort_by=oldest", sortBy: "oldest", limit: 10, serviceErr: marketplace.ErrInvalidReviewSort, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockMarketplaceService)
+			handler := NewMarketplaceHandler(service, new(MockCategoryService), slog.New(slog.NewTextHandler(os.Stdout, nil)))
+			if tt.serviceErr != nil {
+				service.On("GetReviews", mock.Anything, "template-123", tt.sortBy, tt.limit, tt.offset).Return(nil, 0, tt.serviceErr)
+			} else {
+				service.On("GetReviews", mock.Anything, "template-123", tt.sortBy, tt.limit, tt.offset).Return([]*marketplace.TemplateReview{}, 0, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/marketplace/templates/template-123/reviews"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "template-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetReviews(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			service.AssertExpectations(t)
+		})
+	}
+}
@@ -90,8 +90,7 @@ func (h *MetricsHandler) GetDurationStats(w http.ResponseWriter, r *http.Request
 }
 
 // GetTopFailures returns workflows with the most failures
-// GET /api/v1/metrics/failures?limit=10&days=30
-// Uses optimized query with LATERAL join for 70-90% performance improvement
+// GET /api/v1/metrics/failures?limit=10&offset=0&workflowId=...&days=30
 func (h *MetricsHandler) GetTopFailures(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
 	if tenantID == "" {
@@ -109,24 +108,92 @@ func (h *MetricsHandler) GetTopFailures(w http.ResponseWriter, r *http.Request)
 		limit = parsedLimit
 	}
 
+	offset := 0
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err != nil || parsedOffset < 0 {
+			_ = response.BadRequest(w, "offset must be a non-negative integer")
+			return
+		}
+		offset = parsedOffset
+	}
+
+	workflowID := r.URL.Query().Get("workflowId")
+
 	startDate, endDate, err := h.parseDateRange(r)
 	if err != nil {
 		_ = response.BadRequest(w, err.Error())
 		return
 	}
 
-	failures, err := h.repo.GetTopFailuresOptimized(r.Context(), tenantID, startDate, endDate, limit)
+	failures, err := h.repo.GetTopFailures(r.Context(), tenantID, startDate, endDate, workflow.TopFailuresOptions{
+		WorkflowID: workflowID,
+		Limit:      limit,
+		Offset:     offset,
+	})
 	if err != nil {
 		_ = response.InternalError(w, "failed to get top failures")
 		return
 	}
 
-	_ = response.OK(w, map[string]any{
+	result := map[string]any{
 		"failures":  failures,
 		"startDate": startDate.Format(time.RFC3339),
 		"endDate":   endDate.Format(time.RFC3339),
 		"limit":     limit,
-	})
+		"offset":    offset,
+	}
+	if workflowID != "" {
+		result["workflowId"] = workflowID
+	}
+
+	_ = response.OK(w, result)
+}
+
+// GetExecutionStats returns success rate and latency percentiles, optionally per workflow
+// GET /api/v1/metrics/stats?groupBy=workflow&days=7&startDate=2024-01-01&endDate=2024-01-31
+func (h *MetricsHandler) GetExecutionStats(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	if tenantID == "" {
+		_ = response.Unauthorized(w, "tenant_id required")
+		return
+	}
+
+	groupBy := r.URL.Query().Get("groupBy")
+	if groupBy != "" && groupBy != "workflow" {
+		_ = response.BadRequest(w, "groupBy must be 'workflow' when set")
+		return
+	}
+
+	startDate, endDate, err := h.parseDateRange(r)
+	if err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	stats, err := h.repo.GetExecutionStats(r.Context(), tenantID, startDate, endDate)
+	if err != nil {
+		_ = response.InternalError(w, "failed to get execution stats")
+		return
+	}
+
+	result := map[string]any{
+		"stats":     stats,
+		"startDate": startDate.Format(time.RFC3339),
+		"endDate":   endDate.Format(time.RFC3339),
+	}
+
+	if groupBy == "workflow" {
+		workflows, err := h.repo.GetExecutionStatsByWorkflow(r.Context(), tenantID, startDate, endDate)
+		if err != nil {
+			_ = response.InternalError(w, "failed to get execution stats")
+			return
+		}
+		result["groupBy"] = groupBy
+		result["workflows"] = workflows
+	}
+
+	_ = response.OK(w, result)
 }
 
 // GetTriggerBreakdown returns execution distribution by trigger type
@@ -1,7 +1,15 @@
 package handlers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/tenant"
 )
 
 func TestGetExecutionTrends(t *testing.T) {
@@ -33,3 +41,42 @@ func TestMetricsHandler_InvalidGroupBy(t *testing.T) {
 func TestMetricsHandler_InvalidDateFormat(t *testing.T) {
 	t.Skip("Requires database connection for repository")
 }
+
+// TestGetTopFailures_InvalidPagination tests validation of limit and offset before the repository is queried
+func TestGetTopFailures_InvalidPagination(t *testing.T) {
+	handler := NewMetricsHandler(nil)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "negative offset", query: "offset=-1"},
+		{name: "non-numeric offset", query: "offset=abc"},
+		{name: "limit too large", query: "limit=101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics/failures?"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), middleware.TenantContextKey, &tenant.Tenant{ID: "tenant-1"})
+			w := httptest.NewRecorder()
+
+			handler.GetTopFailures(w, req.WithContext(ctx))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+// TestGetExecutionStats_InvalidGroupBy tests validation of groupBy before the repository is queried
+func TestGetExecutionStats_InvalidGroupBy(t *testing.T) {
+	handler := NewMetricsHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/metrics/stats?groupBy=day", nil)
+	ctx := context.WithValue(req.Context(), middleware.TenantContextKey, &tenant.Tenant{ID: "tenant-1"})
+	w := httptest.NewRecorder()
+
+	handler.GetExecutionStats(w, req.WithContext(ctx))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/api/response"
+)
+
+// NodeCacheInvalidator removes cached node results
+type NodeCacheInvalidator interface {
+	Invalidate(ctx context.Context, tenantID string, workflowID *string, nodeID string) (int, error)
+}
+
+// NodeCacheHandler handles requests to invalidate cached node results
+type NodeCacheHandler struct {
+	cache  NodeCacheInvalidator
+	logger *slog.Logger
+}
+
+// NewNodeCacheHandler creates a new node cache handler
+func NewNodeCacheHandler(cache NodeCacheInvalidator, logger *slog.Logger) *NodeCacheHandler {
+	return &NodeCacheHandler{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Invalidate handles DELETE /api/v1/node-cache and
+// DELETE /api/v1/workflows/{workflowID}/node-cache. On the workflow route
+// ?node_id= narrows it to one node.
+func (h *NodeCacheHandler) Invalidate(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	workflowID := workflowScope(r)
+	nodeID := r.URL.Query().Get("node_id")
+
+	if nodeID != "" && workflowID == nil {
+		_ = response.BadRequest(w, "node_id requires a workflow")
+		return
+	}
+
+	invalidated, err := h.cache.Invalidate(r.Context(), tenantID, workflowID, nodeID)
+	if err != nil {
+		h.logger.Error("failed to invalidate node cache", "error", err, "tenant_id", tenantID)
+		_ = response.InternalError(w, "failed to invalidate node cache")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": map[string]int{"invalidated": invalidated},
+	})
+}
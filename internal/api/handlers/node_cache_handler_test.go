@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gorax/gorax/internal/api/middleware"
+	"github.com/gorax/gorax/internal/tenant"
+)
+
+// MockNodeCacheInvalidator is a mock implementation of NodeCacheInvalidator
+type MockNodeCacheInvalidator struct {
+	mock.Mock
+}
+
+func (m *MockNodeCacheInvalidator) Invalidate(ctx context.Context, tenantID string, workflowID *string, nodeID string) (int, error) {
+	args := m.Called(ctx, tenantID, workflowID, nodeID)
+	return args.Int(0), args.Error(1)
+}
+
+func newTestNodeCacheRouter() (http.Handler, *MockNodeCacheInvalidator) {
+	cache := new(MockNodeCacheInvalidator)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewNodeCacheHandler(cache, logger)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), middleware.TenantContextKey, &tenant.Tenant{ID: "tenant-1"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Delete("/node-cache", handler.Invalidate)
+	r.Delete("/workflows/{workflowID}/node-cache", handler.Invalidate)
+	return r, cache
+}
+
+func TestNodeCacheHandler_Invalidate(t *testing.T) {
+	workflowID := "wf-1"
+
+	tests := []struct {
+		name       string
+		path       string
+		workflowID *string
+		nodeID     string
+	}{
+		{name: "whole tenant", path: "/node-cache"},
+		{name: "one workflow", path: "/workflows/wf-1/node-cache", workflowID: &workflowID},
+		{name: "one node", path: "/workflows/wf-1/node-cache?node_id=http-1", workflowID: &workflowID, nodeID: "http-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, cache := newTestNodeCacheRouter()
+			cache.On("Invalidate", mock.Anything, "tenant-1", tt.workflowID, tt.nodeID).Return(4, nil)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, `{"data":{"invalidated":4}}`, rec.Body.String())
+			cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNodeCacheHandler_Invalidate_NodeWithoutWorkflow(t *testing.T) {
+	router, cache := newTestNodeCacheRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/node-cache?node_id=http-1", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	cache.AssertNotCalled(t, "Invalidate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNodeCacheHandler_Invalidate_Error(t *testing.T) {
+	router, cache := newTestNodeCacheRouter()
+	cache.On("Invalidate", mock.Anything, "tenant-1", (*string)(nil), "").Return(0, errors.New("connection refused"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/node-cache", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+}
@@ -3,33 +3,61 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/gorax/gorax/internal/api/response"
 	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/validation"
 )
 
 // OAuthHandler handles OAuth-related HTTP requests
 type OAuthHandler struct {
 	service oauth.OAuthService
+	logger  *slog.Logger
 }
 
 // NewOAuthHandler creates a new OAuth handler
 func NewOAuthHandler(service oauth.OAuthService) *OAuthHandler {
 	return &OAuthHandler{
 		service: service,
+		logger:  slog.Default(),
 	}
 }
 
-// ListProviders returns available OAuth providers
+// writeServiceError writes a mapped domain error, or logs err and writes a
+// 500 with message
+func (h *OAuthHandler) writeServiceError(w http.ResponseWriter, err error, message string) {
+	if apiErr := domainError(err); apiErr != nil {
+		_ = response.WriteError(w, apiErr)
+		return
+	}
+	h.logger.Error(message, "error", err)
+	_ = response.InternalError(w, message)
+}
+
+// ListProviders returns available OAuth providers. Only active providers are
+// listed unless the status query parameter asks for inactive ones.
 // GET /api/v1/oauth/providers
 func (h *OAuthHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	providers, err := h.service.ListProviders(ctx)
+	filter := oauth.ProviderListFilter{Status: oauth.ProviderStatusActive}
+	if status := r.URL.Query().Get("status"); status != "" {
+		filter.Status = oauth.ProviderStatus(status)
+		if filter.Status != oauth.ProviderStatusActive && filter.Status != oauth.ProviderStatusInactive {
+			_ = response.BadRequest(w, "status must be active or inactive")
+			return
+		}
+	}
+
+	providers, err := h.service.ListProviders(ctx, filter)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list providers: %v", err), http.StatusInternalServerError)
+		h.writeServiceError(w, err, "failed to list providers")
 		return
 	}
 
@@ -42,11 +70,7 @@ func (h *OAuthHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
 		provider.ClientSecretKMSKeyID = ""
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(providers); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	_ = response.OK(w, providers)
 }
 
 // Authorize starts the OAuth authorization flow
@@ -58,13 +82,13 @@ func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 	// Get user and tenant from context
 	userID, ok := ctx.Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		_ = response.Unauthorized(w, "Unauthorized")
 		return
 	}
 
 	tenantID, ok := ctx.Value("tenant_id").(string)
 	if !ok {
-		http.Error(w, "Missing tenant context", http.StatusBadRequest)
+		_ = response.BadRequest(w, "Missing tenant context")
 		return
 	}
 
@@ -80,19 +104,14 @@ func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 
 	authURL, err := h.service.Authorize(ctx, userID, tenantID, input)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to start authorization: %v", err), http.StatusInternalServerError)
+		h.writeServiceError(w, err, "failed to start authorization")
 		return
 	}
 
 	// Return authorization URL or redirect
 	accept := r.Header.Get("Accept")
 	if accept == "application/json" {
-		w.Header().Set("Content-Type", "application/json")
-		response := map[string]string{"authorization_url": authURL}
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-			return
-		}
+		_ = response.OK(w, map[string]string{"authorization_url": authURL})
 	} else {
 		// Redirect to authorization URL
 		http.Redirect(w, r, authURL, http.StatusFound)
@@ -114,17 +133,24 @@ func (h *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 	code := r.URL.Query().Get("code")
 	state := r.URL.Query().Get("state")
 	errorParam := r.URL.Query().Get("error")
+	errorDescription := r.URL.Query().Get("error_description")
 
 	input := &oauth.CallbackInput{
-		Code:  code,
-		State: state,
-		Error: errorParam,
+		Code:             code,
+		State:            state,
+		Error:            errorParam,
+		ErrorDescription: errorDescription,
 	}
 
 	// Handle callback
 	conn, err := h.service.HandleCallback(ctx, userID, tenantID, input)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("OAuth callback failed: %v", err), http.StatusBadRequest)
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Warn("OAuth callback failed", "error", err, "provider", providerKey)
+		_ = response.WriteError(w, response.NewAPIError(http.StatusBadRequest, response.CodeOAuthCallbackFailed, "OAuth callback failed"))
 		return
 	}
 
@@ -139,19 +165,88 @@ func (h *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 	conn.RefreshTokenEncDEK = nil
 
 	// Return success response
-	w.Header().Set("Content-Type", "application/json")
-	response := map[string]interface{}{
+	_ = response.OK(w, map[string]interface{}{
 		"success":    true,
 		"provider":   providerKey,
 		"connection": conn,
+	})
+}
+
+// StartDeviceAuthorization starts the device authorization flow for clients
+// without a browser redirect
+// POST /api/v1/oauth/device/:provider
+func (h *OAuthHandler) StartDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	providerKey := chi.URLParam(r, "provider")
+
+	// Get user and tenant from context
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok {
+		_ = response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	tenantID, ok := ctx.Value("tenant_id").(string)
+	if !ok {
+		_ = response.BadRequest(w, "Missing tenant context")
+		return
+	}
+
+	auth, err := h.service.StartDeviceAuthorization(ctx, userID, tenantID, providerKey)
+	if err != nil {
+		h.writeServiceError(w, err, "failed to start device authorization")
+		return
+	}
+
+	_ = response.Created(w, auth)
+}
+
+// DeviceTokenRequest is the request body for polling a device authorization
+type DeviceTokenRequest struct {
+	DeviceCode string `json:"device_code"`
+}
+
+// PollDeviceToken polls a device authorization until the user approves it
+// POST /api/v1/oauth/device/token
+func (h *OAuthHandler) PollDeviceToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get user and tenant from context
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok {
+		_ = response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	tenantID, ok := ctx.Value("tenant_id").(string)
+	if !ok {
+		_ = response.BadRequest(w, "Missing tenant context")
+		return
+	}
+
+	var req DeviceTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = response.BadRequest(w, "Invalid request body")
+		return
 	}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+
+	if req.DeviceCode == "" {
+		_ = response.BadRequest(w, "Device code is required")
 		return
 	}
+
+	auth, err := h.service.PollDeviceToken(ctx, userID, tenantID, req.DeviceCode)
+	if err != nil {
+		h.writeServiceError(w, err, "failed to poll device authorization")
+		return
+	}
+
+	_ = response.OK(w, auth)
 }
 
-// ListConnections lists user's OAuth connections
+// ListConnections lists user's OAuth connections, optionally filtered by
+// provider, status and needs_refresh. Requests with limit or offset get an
+// offset page; requests with after get a cursor page.
 // GET /api/v1/oauth/connections
 func (h *OAuthHandler) ListConnections(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -159,19 +254,56 @@ func (h *OAuthHandler) ListConnections(w http.ResponseWriter, r *http.Request) {
 	// Get user and tenant from context
 	userID, ok := ctx.Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		_ = response.Unauthorized(w, "Unauthorized")
 		return
 	}
 
 	tenantID, ok := ctx.Value("tenant_id").(string)
 	if !ok {
-		http.Error(w, "Missing tenant context", http.StatusBadRequest)
+		_ = response.BadRequest(w, "Missing tenant context")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := oauth.ConnectionListFilter{
+		ProviderKey: query.Get("provider"),
+		Status:      oauth.ConnectionStatus(query.Get("status")),
+	}
+	switch filter.Status {
+	case "", oauth.ConnectionStatusActive, oauth.ConnectionStatusRevoked, oauth.ConnectionStatusExpired:
+	default:
+		_ = response.BadRequest(w, "status must be active, revoked or expired")
+		return
+	}
+	if raw := query.Get("needs_refresh"); raw != "" {
+		needsRefresh, err := strconv.ParseBool(raw)
+		if err != nil {
+			_ = response.BadRequest(w, "needs_refresh must be true or false")
+			return
+		}
+		filter.NeedsRefresh = &needsRefresh
+	}
+
+	page, cursorMode, err := cursorPage(r)
+	if err != nil {
+		_ = response.WriteError(w, err)
 		return
 	}
+	offsetMode := !cursorMode && (query.Has("limit") || query.Has("offset"))
+	if cursorMode {
+		filter.Page = page
+	} else if offsetMode {
+		filter.Limit, _ = validation.ParsePaginationLimit(
+			query.Get("limit"),
+			validation.DefaultPaginationLimit,
+			validation.MaxPaginationLimit,
+		)
+		filter.Offset, _ = validation.ParsePaginationOffset(query.Get("offset"))
+	}
 
-	connections, err := h.service.ListConnections(ctx, userID, tenantID)
+	connections, err := h.service.ListConnections(ctx, userID, tenantID, filter)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list connections: %v", err), http.StatusInternalServerError)
+		h.writeServiceError(w, err, "failed to list connections")
 		return
 	}
 
@@ -188,11 +320,16 @@ func (h *OAuthHandler) ListConnections(w http.ResponseWriter, r *http.Request) {
 		conn.RawTokenResponse = nil
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(connections); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	if cursorMode {
+		_ = response.OK(w, pagination.NewPage(connections, page.Limit, connectionPosition))
 		return
 	}
+	if offsetMode {
+		_ = response.Paginated(w, connections, filter.Limit, filter.Offset, 0)
+		return
+	}
+
+	_ = response.OK(w, connections)
 }
 
 // GetConnection retrieves a specific OAuth connection
@@ -204,13 +341,13 @@ func (h *OAuthHandler) GetConnection(w http.ResponseWriter, r *http.Request) {
 	// Get user and tenant from context
 	userID, ok := ctx.Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		_ = response.Unauthorized(w, "Unauthorized")
 		return
 	}
 
 	tenantID, ok := ctx.Value("tenant_id").(string)
 	if !ok {
-		http.Error(w, "Missing tenant context", http.StatusBadRequest)
+		_ = response.BadRequest(w, "Missing tenant context")
 		return
 	}
 
@@ -218,7 +355,7 @@ func (h *OAuthHandler) GetConnection(w http.ResponseWriter, r *http.Request) {
 	// Note: We need to implement a GetConnectionByID method that validates ownership
 	conn, err := h.service.GetConnection(ctx, userID, tenantID, connectionID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Connection not found: %v", err), http.StatusNotFound)
+		h.writeServiceError(w, err, "failed to get connection")
 		return
 	}
 
@@ -233,11 +370,7 @@ func (h *OAuthHandler) GetConnection(w http.ResponseWriter, r *http.Request) {
 	conn.RefreshTokenEncDEK = nil
 	conn.RawTokenResponse = nil
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(conn); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	_ = response.OK(w, conn)
 }
 
 // RevokeConnection revokes an OAuth connection
@@ -249,24 +382,129 @@ func (h *OAuthHandler) RevokeConnection(w http.ResponseWriter, r *http.Request)
 	// Get user and tenant from context
 	userID, ok := ctx.Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		_ = response.Unauthorized(w, "Unauthorized")
 		return
 	}
 
 	tenantID, ok := ctx.Value("tenant_id").(string)
 	if !ok {
-		http.Error(w, "Missing tenant context", http.StatusBadRequest)
+		_ = response.BadRequest(w, "Missing tenant context")
 		return
 	}
 
 	if err := h.service.RevokeConnection(ctx, userID, tenantID, connectionID); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to revoke connection: %v", err), http.StatusInternalServerError)
+		h.writeServiceError(w, err, "failed to revoke connection")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// RevokeConnectionsRequest is the request body for revoking several connections
+type RevokeConnectionsRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// RevokeConnections revokes several of the user's OAuth connections
+// POST /api/v1/oauth/connections/bulk/delete
+func (h *OAuthHandler) RevokeConnections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get user and tenant from context
+	userID, ok := ctx.Value("user_id").(string)
+	if !ok {
+		_ = response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	tenantID, ok := ctx.Value("tenant_id").(string)
+	if !ok {
+		_ = response.BadRequest(w, "Missing tenant context")
+		return
+	}
+
+	var req RevokeConnectionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		_ = response.BadRequest(w, "At least one connection ID is required")
+		return
+	}
+
+	if len(req.IDs) > oauth.MaxBulkRevoke {
+		_ = response.BadRequest(w, fmt.Sprintf("Cannot revoke more than %d connections at once", oauth.MaxBulkRevoke))
+		return
+	}
+
+	revoked, failed := h.service.RevokeConnections(ctx, userID, tenantID, req.IDs)
+
+	result := BulkOperationResult{
+		Success: revoked,
+		Failed:  make([]BulkOperationError, 0, len(failed)),
+	}
+	for _, f := range failed {
+		result.Failed = append(result.Failed, BulkOperationError{ID: f.ID, Error: f.Error})
+	}
+
+	_ = response.OK(w, result)
+}
+
+// BulkRevokeRequest is the request body for revoking connections by provider
+type BulkRevokeRequest struct {
+	// TenantID limits revocation to one tenant; empty revokes across all tenants
+	TenantID string `json:"tenant_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// RevokeConnectionsByProvider revokes all connections for a provider
+// POST /api/v1/admin/oauth/providers/:provider/revoke
+func (h *OAuthHandler) RevokeConnectionsByProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	providerKey := chi.URLParam(r, "provider")
+
+	var req BulkRevokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if req.Reason == "" {
+		_ = response.BadRequest(w, "Reason is required")
+		return
+	}
+
+	var result *oauth.BulkRevokeResult
+	var err error
+	if req.TenantID != "" {
+		result, err = h.service.RevokeConnectionsByProvider(ctx, req.TenantID, providerKey, req.Reason)
+	} else {
+		result, err = h.service.RevokeAllConnectionsByProvider(ctx, providerKey, req.Reason)
+	}
+	if err != nil {
+		h.writeServiceError(w, err, "failed to revoke connections")
+		return
+	}
+
+	_ = response.OK(w, result)
+}
+
+// GetConnectionHealth summarizes a tenant's OAuth connections: counts by
+// status, connections needing a refresh, recent refresh failures and each
+// provider's last error
+// GET /api/v1/admin/tenants/:tenantID/oauth/health
+func (h *OAuthHandler) GetConnectionHealth(w http.ResponseWriter, r *http.Request) {
+	health, err := h.service.GetConnectionHealth(r.Context(), chi.URLParam(r, "tenantID"))
+	if err != nil {
+		h.writeServiceError(w, err, "failed to get connection health")
+		return
+	}
+
+	_ = response.OK(w, health)
+}
+
 // TestConnection tests an OAuth connection
 // POST /api/v1/oauth/connections/:id/test
 func (h *OAuthHandler) TestConnection(w http.ResponseWriter, r *http.Request) {
@@ -276,37 +514,26 @@ func (h *OAuthHandler) TestConnection(w http.ResponseWriter, r *http.Request) {
 	// Get user and tenant from context
 	_, ok := ctx.Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		_ = response.Unauthorized(w, "Unauthorized")
 		return
 	}
 
 	_, ok = ctx.Value("tenant_id").(string)
 	if !ok {
-		http.Error(w, "Missing tenant context", http.StatusBadRequest)
+		_ = response.BadRequest(w, "Missing tenant context")
 		return
 	}
 
 	if err := h.service.TestConnection(ctx, connectionID); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		response := map[string]interface{}{
+		_ = response.OK(w, map[string]interface{}{
 			"success": false,
 			"error":   err.Error(),
-		}
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-			return
-		}
+		})
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	response := map[string]interface{}{
+	_ = response.OK(w, map[string]interface{}{
 		"success": true,
 		"message": "Connection test successful",
-	}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
+	})
 }
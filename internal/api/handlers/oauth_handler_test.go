@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,7 +15,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gorax/gorax/internal/api/response"
 	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/validation"
 )
 
 // MockOAuthService is a mock implementation of oauth.OAuthService
@@ -30,8 +34,8 @@ func (m *MockOAuthService) GetProvider(ctx context.Context, providerKey string)
 	return args.Get(0).(*oauth.OAuthProvider), args.Error(1)
 }
 
-func (m *MockOAuthService) ListProviders(ctx context.Context) ([]*oauth.OAuthProvider, error) {
-	args := m.Called(ctx)
+func (m *MockOAuthService) ListProviders(ctx context.Context, filter oauth.ProviderListFilter) ([]*oauth.OAuthProvider, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -59,8 +63,8 @@ func (m *MockOAuthService) GetConnection(ctx context.Context, userID, tenantID,
 	return args.Get(0).(*oauth.OAuthConnection), args.Error(1)
 }
 
-func (m *MockOAuthService) ListConnections(ctx context.Context, userID, tenantID string) ([]*oauth.OAuthConnection, error) {
-	args := m.Called(ctx, userID, tenantID)
+func (m *MockOAuthService) ListConnections(ctx context.Context, userID, tenantID string, filter oauth.ConnectionListFilter) ([]*oauth.OAuthConnection, error) {
+	args := m.Called(ctx, userID, tenantID, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -72,6 +76,59 @@ func (m *MockOAuthService) RevokeConnection(ctx context.Context, userID, tenantI
 	return args.Error(0)
 }
 
+func (m *MockOAuthService) RevokeConnections(ctx context.Context, userID, tenantID string, connectionIDs []string) ([]string, []oauth.BulkOperationError) {
+	args := m.Called(ctx, userID, tenantID, connectionIDs)
+	return args.Get(0).([]string), args.Get(1).([]oauth.BulkOperationError)
+}
+
+func (m *MockOAuthService) RevokeConnectionsByProvider(ctx context.Context, tenantID, providerKey, reason string) (*oauth.BulkRevokeResult, error) {
+	args := m.Called(ctx, tenantID, providerKey, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.BulkRevokeResult), args.Error(1)
+}
+
+func (m *MockOAuthService) CreateServiceConnection(ctx context.Context, tenantID, providerKey string, scopes []string) (*oauth.OAuthConnection, error) {
+	args := m.Called(ctx, tenantID, providerKey, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.OAuthConnection), args.Error(1)
+}
+
+func (m *MockOAuthService) StartDeviceAuthorization(ctx context.Context, userID, tenantID, providerKey string) (*oauth.DeviceAuthorization, error) {
+	args := m.Called(ctx, userID, tenantID, providerKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.DeviceAuthorization), args.Error(1)
+}
+
+func (m *MockOAuthService) PollDeviceToken(ctx context.Context, userID, tenantID, deviceCode string) (*oauth.DeviceAuthorization, error) {
+	args := m.Called(ctx, userID, tenantID, deviceCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.DeviceAuthorization), args.Error(1)
+}
+
+func (m *MockOAuthService) RevokeAllConnectionsByProvider(ctx context.Context, providerKey, reason string) (*oauth.BulkRevokeResult, error) {
+	args := m.Called(ctx, providerKey, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.BulkRevokeResult), args.Error(1)
+}
+
+func (m *MockOAuthService) GetConnectionHealth(ctx context.Context, tenantID string) (*oauth.ConnectionHealth, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*oauth.ConnectionHealth), args.Error(1)
+}
+
 func (m *MockOAuthService) RefreshToken(ctx context.Context, connectionID string) error {
 	args := m.Called(ctx, connectionID)
 	return args.Error(0)
@@ -167,7 +224,7 @@ func TestOAuthHandler_ListProviders(t *testing.T) {
 						Status:      oauth.ProviderStatusActive,
 					},
 				}
-				m.On("ListProviders", mock.Anything).Return(providers, nil)
+				m.On("ListProviders", mock.Anything, oauth.ProviderListFilter{Status: oauth.ProviderStatusActive}).Return(providers, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedCount:  2,
@@ -175,7 +232,7 @@ func TestOAuthHandler_ListProviders(t *testing.T) {
 		{
 			name: "success empty list",
 			setupMock: func(m *MockOAuthService) {
-				m.On("ListProviders", mock.Anything).Return([]*oauth.OAuthProvider{}, nil)
+				m.On("ListProviders", mock.Anything, oauth.ProviderListFilter{Status: oauth.ProviderStatusActive}).Return([]*oauth.OAuthProvider{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedCount:  0,
@@ -183,7 +240,7 @@ func TestOAuthHandler_ListProviders(t *testing.T) {
 		{
 			name: "service error",
 			setupMock: func(m *MockOAuthService) {
-				m.On("ListProviders", mock.Anything).Return(nil, errors.New("database error"))
+				m.On("ListProviders", mock.Anything, oauth.ProviderListFilter{Status: oauth.ProviderStatusActive}).Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -220,6 +277,44 @@ func TestOAuthHandler_ListProviders(t *testing.T) {
 	}
 }
 
+func TestOAuthHandler_ListProviders_StatusFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter *oauth.ProviderListFilter
+		expectedStatus int
+	}{
+		{
+			name:           "inactive providers",
+			query:          "?status=inactive",
+			expectedFilter: &oauth.ProviderListFilter{Status: oauth.ProviderStatusInactive},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid status",
+			query:          "?status=deleted",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			if tt.expectedFilter != nil {
+				mockService.On("ListProviders", mock.Anything, *tt.expectedFilter).Return([]*oauth.OAuthProvider{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/oauth/providers"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListProviders(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // =============================================================================
 // Authorize Tests
 // =============================================================================
@@ -262,13 +357,23 @@ func TestOAuthHandler_Authorize(t *testing.T) {
 			expectedJSON:   true,
 		},
 		{
-			name:        "service error",
+			name:        "invalid provider",
 			provider:    "invalid",
 			queryParams: "",
 			setupMock: func(m *MockOAuthService) {
 				m.On("Authorize", mock.Anything, userID, tenantID, mock.AnythingOfType("*oauth.AuthorizeInput")).
 					Return("", oauth.ErrInvalidProvider)
 			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			provider:    "github",
+			queryParams: "",
+			setupMock: func(m *MockOAuthService) {
+				m.On("Authorize", mock.Anything, userID, tenantID, mock.AnythingOfType("*oauth.AuthorizeInput")).
+					Return("", errors.New("database error"))
+			},
 			expectedStatus: http.StatusInternalServerError,
 		},
 	}
@@ -381,7 +486,7 @@ func TestOAuthHandler_Callback(t *testing.T) {
 			setupMock: func(m *MockOAuthService) {
 				m.On("HandleCallback", mock.Anything, userID, tenantID, mock.MatchedBy(func(input *oauth.CallbackInput) bool {
 					return input.Error == "access_denied"
-				})).Return(nil, errors.New("OAuth provider error: access_denied"))
+				})).Return(nil, &oauth.ProviderError{ProviderKey: "github", Code: "access_denied"})
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
@@ -428,6 +533,57 @@ func TestOAuthHandler_Callback(t *testing.T) {
 	}
 }
 
+func TestOAuthHandler_Callback_ProviderErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		queryParams  string
+		providerErr  *oauth.ProviderError
+		expectedCode string
+	}{
+		{
+			name:         "user denied",
+			queryParams:  "?error=access_denied&error_description=The+user+denied+access&state=state-abc",
+			providerErr:  &oauth.ProviderError{Code: "access_denied", Description: "The user denied access"},
+			expectedCode: response.CodeOAuthAccessDenied,
+		},
+		{
+			name:         "invalid scope",
+			queryParams:  "?error=invalid_scope&error_description=Unknown+scope+repo:admin&state=state-abc",
+			providerErr:  &oauth.ProviderError{Code: "invalid_scope", Description: "Unknown scope repo:admin"},
+			expectedCode: response.CodeOAuthMisconfigured,
+		},
+		{
+			name:         "provider outage",
+			queryParams:  "?error=temporarily_unavailable&state=state-abc",
+			providerErr:  &oauth.ProviderError{Code: "temporarily_unavailable"},
+			expectedCode: response.CodeOAuthProviderError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			mockService.On("HandleCallback", mock.Anything, "user-123", "tenant-123", mock.MatchedBy(func(input *oauth.CallbackInput) bool {
+				return input.Error == tt.providerErr.Code && input.ErrorDescription == tt.providerErr.Description
+			})).Return(nil, tt.providerErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/oauth/callback/github"+tt.queryParams, nil)
+			req = addOAuthContext(req, "tenant-123", "user-123")
+			req = addOAuthChiURLParam(req, "provider", "github")
+			rr := httptest.NewRecorder()
+
+			handler.Callback(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp map[string]interface{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, tt.expectedCode, resp["code"])
+			assert.Equal(t, tt.providerErr.Reason(), resp["error"])
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // =============================================================================
 // ListConnections Tests
 // =============================================================================
@@ -455,7 +611,7 @@ func TestOAuthHandler_ListConnections(t *testing.T) {
 						Status:      oauth.ConnectionStatusActive,
 					},
 				}
-				m.On("ListConnections", mock.Anything, userID, tenantID).Return(connections, nil)
+				m.On("ListConnections", mock.Anything, userID, tenantID, oauth.ConnectionListFilter{}).Return(connections, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedCount:  2,
@@ -463,7 +619,7 @@ func TestOAuthHandler_ListConnections(t *testing.T) {
 		{
 			name: "success empty list",
 			setupMock: func(m *MockOAuthService) {
-				m.On("ListConnections", mock.Anything, userID, tenantID).Return([]*oauth.OAuthConnection{}, nil)
+				m.On("ListConnections", mock.Anything, userID, tenantID, oauth.ConnectionListFilter{}).Return([]*oauth.OAuthConnection{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedCount:  0,
@@ -471,7 +627,7 @@ func TestOAuthHandler_ListConnections(t *testing.T) {
 		{
 			name: "service error",
 			setupMock: func(m *MockOAuthService) {
-				m.On("ListConnections", mock.Anything, userID, tenantID).Return(nil, errors.New("database error"))
+				m.On("ListConnections", mock.Anything, userID, tenantID, oauth.ConnectionListFilter{}).Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -550,6 +706,89 @@ func TestOAuthHandler_ListConnections_MissingContext(t *testing.T) {
 	}
 }
 
+func TestOAuthHandler_ListConnections_Filters(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-123"
+	needsRefresh := true
+	fresh := false
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter *oauth.ConnectionListFilter
+		expectedStatus int
+		paginated      bool
+	}{
+		{
+			name:           "provider and status",
+			query:          "?provider=github&status=active",
+			expectedFilter: &oauth.ConnectionListFilter{ProviderKey: "github", Status: oauth.ConnectionStatusActive},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "needs refresh",
+			query:          "?needs_refresh=true",
+			expectedFilter: &oauth.ConnectionListFilter{NeedsRefresh: &needsRefresh},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "status and fresh tokens with offset page",
+			query:          "?status=expired&needs_refresh=false&limit=5&offset=10",
+			expectedFilter: &oauth.ConnectionListFilter{Status: oauth.ConnectionStatusExpired, NeedsRefresh: &fresh, Limit: 5, Offset: 10},
+			expectedStatus: http.StatusOK,
+			paginated:      true,
+		},
+		{
+			name:           "offset without limit uses default limit",
+			query:          "?offset=20",
+			expectedFilter: &oauth.ConnectionListFilter{Limit: validation.DefaultPaginationLimit, Offset: 20},
+			expectedStatus: http.StatusOK,
+			paginated:      true,
+		},
+		{
+			name:           "cursor page",
+			query:          "?after=&limit=5&provider=google",
+			expectedFilter: &oauth.ConnectionListFilter{ProviderKey: "google", Page: pagination.Params{Limit: 5}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid status",
+			query:          "?status=pending",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid needs_refresh",
+			query:          "?needs_refresh=soon",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			if tt.expectedFilter != nil {
+				mockService.On("ListConnections", mock.Anything, userID, tenantID, *tt.expectedFilter).
+					Return([]*oauth.OAuthConnection{createTestOAuthConnection()}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/oauth/connections"+tt.query, nil)
+			req = addOAuthContext(req, tenantID, userID)
+			rr := httptest.NewRecorder()
+
+			handler.ListConnections(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.paginated {
+				var body response.PaginatedResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, tt.expectedFilter.Limit, body.Limit)
+				assert.Equal(t, tt.expectedFilter.Offset, body.Offset)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // =============================================================================
 // GetConnection Tests
 // =============================================================================
@@ -655,7 +894,7 @@ func TestOAuthHandler_RevokeConnection(t *testing.T) {
 				m.On("RevokeConnection", mock.Anything, userID, tenantID, "nonexistent").
 					Return(oauth.ErrConnectionNotFound)
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusNotFound,
 		},
 		{
 			name:         "unauthorized",
@@ -846,6 +1085,303 @@ func TestOAuthHandler_TestConnection_MissingContext(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// RevokeConnections Tests
+// =============================================================================
+
+func TestOAuthHandler_RevokeConnections(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockOAuthService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "reports each connection's outcome",
+			body: `{"ids": ["conn-1", "conn-2"]}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("RevokeConnections", mock.Anything, userID, tenantID, []string{"conn-1", "conn-2"}).
+					Return([]string{"conn-1"}, []oauth.BulkOperationError{{ID: "conn-2", Error: "unauthorized"}})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"success":["conn-1"],"failed":[{"id":"conn-2","error":"unauthorized"}]}`,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"ids":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "no ids",
+			body:           `{"ids": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too many ids",
+			body:           `{"ids": [` + strings.Repeat(`"conn",`, oauth.MaxBulkRevoke) + `"conn"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/oauth/connections/bulk/delete", strings.NewReader(tt.body))
+			req = addOAuthContext(req, tenantID, userID)
+			rr := httptest.NewRecorder()
+
+			handler.RevokeConnections(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// =============================================================================
+// RevokeConnectionsByProvider Tests
+// =============================================================================
+
+func TestOAuthHandler_RevokeConnectionsByProvider(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockOAuthService)
+		expectedStatus int
+		expectedResult *oauth.BulkRevokeResult
+	}{
+		{
+			name: "tenant scoped",
+			body: `{"tenant_id":"tenant-123","reason":"app uninstalled"}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("RevokeConnectionsByProvider", mock.Anything, "tenant-123", "github", "app uninstalled").
+					Return(&oauth.BulkRevokeResult{ProviderKey: "github", TenantID: "tenant-123", Revoked: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: &oauth.BulkRevokeResult{ProviderKey: "github", TenantID: "tenant-123", Revoked: 3},
+		},
+		{
+			name: "all tenants",
+			body: `{"reason":"client secret rotated"}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("RevokeAllConnectionsByProvider", mock.Anything, "github", "client secret rotated").
+					Return(&oauth.BulkRevokeResult{ProviderKey: "github", Revoked: 10, Failed: 1, ProviderRevokeFailed: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: &oauth.BulkRevokeResult{ProviderKey: "github", Revoked: 10, Failed: 1, ProviderRevokeFailed: 2},
+		},
+		{
+			name:           "missing reason",
+			body:           `{"tenant_id":"tenant-123"}`,
+			setupMock:      func(m *MockOAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid body",
+			body:           `not json`,
+			setupMock:      func(m *MockOAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: `{"reason":"client secret rotated"}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("RevokeAllConnectionsByProvider", mock.Anything, "github", "client secret rotated").
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/oauth/providers/github/revoke", strings.NewReader(tt.body))
+			req = addOAuthChiURLParam(req, "provider", "github")
+			rr := httptest.NewRecorder()
+
+			handler.RevokeConnectionsByProvider(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedResult != nil {
+				var result oauth.BulkRevokeResult
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+				assert.Equal(t, *tt.expectedResult, result)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthHandler_GetConnectionHealth(t *testing.T) {
+	t.Run("returns the summary", func(t *testing.T) {
+		handler, mockService := newTestOAuthHandler()
+		mockService.On("GetConnectionHealth", mock.Anything, "tenant-123").Return(&oauth.ConnectionHealth{
+			TenantID: "tenant-123",
+			Total:    3,
+			ByStatus: map[oauth.ConnectionStatus]int{oauth.ConnectionStatusActive: 2, oauth.ConnectionStatusRevoked: 1},
+			RefreshFailures: []oauth.ConnectionRefreshFailure{
+				{ConnectionID: "conn-1", ProviderKey: "github", Failures: 2, LastError: "invalid_grant"},
+			},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/tenants/tenant-123/oauth/health", nil)
+		req = addOAuthChiURLParam(req, "tenantID", "tenant-123")
+		rr := httptest.NewRecorder()
+
+		handler.GetConnectionHealth(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var health oauth.ConnectionHealth
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&health))
+		assert.Equal(t, 3, health.Total)
+		assert.Equal(t, 2, health.ByStatus[oauth.ConnectionStatusActive])
+		require.Len(t, health.RefreshFailures, 1)
+		assert.Equal(t, "invalid_grant", health.RefreshFailures[0].LastError)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		handler, mockService := newTestOAuthHandler()
+		mockService.On("GetConnectionHealth", mock.Anything, "tenant-123").Return(nil, errors.New("database error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/tenants/tenant-123/oauth/health", nil)
+		req = addOAuthChiURLParam(req, "tenantID", "tenant-123")
+		rr := httptest.NewRecorder()
+
+		handler.GetConnectionHealth(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestOAuthHandler_StartDeviceAuthorization(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockOAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			setupMock: func(m *MockOAuthService) {
+				m.On("StartDeviceAuthorization", mock.Anything, userID, tenantID, "auth0").
+					Return(&oauth.DeviceAuthorization{
+						DeviceCode:      "device-code-123",
+						UserCode:        "WDJB-MJHT",
+						VerificationURI: "https://tenant.auth0.com/activate",
+						Interval:        5,
+						Status:          oauth.DeviceAuthorizationPending,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "provider without device flow",
+			setupMock: func(m *MockOAuthService) {
+				m.On("StartDeviceAuthorization", mock.Anything, userID, tenantID, "auth0").
+					Return(nil, oauth.ErrUnsupportedGrant)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/oauth/device/auth0", nil)
+			req = addOAuthContext(req, tenantID, userID)
+			req = addOAuthChiURLParam(req, "provider", "auth0")
+			rr := httptest.NewRecorder()
+
+			handler.StartDeviceAuthorization(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var auth oauth.DeviceAuthorization
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&auth))
+				assert.Equal(t, "WDJB-MJHT", auth.UserCode)
+				assert.Equal(t, "device-code-123", auth.DeviceCode)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthHandler_PollDeviceToken(t *testing.T) {
+	tenantID := "tenant-123"
+	userID := "user-123"
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockOAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "approved",
+			body: `{"device_code":"device-code-123"}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("PollDeviceToken", mock.Anything, userID, tenantID, "device-code-123").
+					Return(&oauth.DeviceAuthorization{
+						DeviceCode:   "device-code-123",
+						Status:       oauth.DeviceAuthorizationApproved,
+						ConnectionID: "conn-123",
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown device code",
+			body: `{"device_code":"unknown"}`,
+			setupMock: func(m *MockOAuthService) {
+				m.On("PollDeviceToken", mock.Anything, userID, tenantID, "unknown").
+					Return(nil, oauth.ErrDeviceAuthorizationNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing device code",
+			body:           `{}`,
+			setupMock:      func(m *MockOAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestOAuthHandler()
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/oauth/device/token", strings.NewReader(tt.body))
+			req = addOAuthContext(req, tenantID, userID)
+			rr := httptest.NewRecorder()
+
+			handler.PollDeviceToken(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // =============================================================================
 // NewOAuthHandler Tests
 // =============================================================================
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/oauth"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/template"
+	"github.com/gorax/gorax/internal/validation"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// cursorPage reads the after and limit query parameters of a list endpoint.
+// Requests without an after parameter keep offset pagination and ok is
+// false; clients send an empty after to fetch the first cursor page. The
+// returned error is an APIError ready to write.
+func cursorPage(r *http.Request) (page pagination.Params, ok bool, err error) {
+	query := r.URL.Query()
+	if !query.Has("after") {
+		return pagination.Params{}, false, nil
+	}
+
+	page.Limit, _ = validation.ParsePaginationLimit(query.Get("limit"), pagination.DefaultLimit, pagination.MaxLimit)
+
+	if after := query.Get("after"); after != "" {
+		cursor, err := pagination.DecodeCursor(after)
+		if errors.Is(err, pagination.ErrCursorExpired) {
+			return pagination.Params{}, true, response.NewAPIError(http.StatusBadRequest, response.CodeCursorExpired, "cursor has expired; restart from the first page")
+		}
+		if err != nil {
+			return pagination.Params{}, true, response.NewAPIError(http.StatusBadRequest, response.CodeInvalidCursor, "invalid cursor")
+		}
+		page.After = &cursor
+	}
+
+	return page, true, nil
+}
+
+func credentialPosition(c *credential.Credential) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+func executionPosition(e *workflow.Execution) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
+}
+
+func connectionPosition(c *oauth.OAuthConnection) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
+}
+
+func templatePosition(t *template.Template) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
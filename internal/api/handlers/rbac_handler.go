@@ -48,7 +48,7 @@ func (h *RBACHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 
 // CreateRole handles POST /api/v1/roles
 // @Summary Create role
-// @Description Creates a new custom role with specified permissions
+// @Description Creates a new custom role with permissions given by ID or as "resource:action" strings. The caller must hold every permission granted.
 // @Tags RBAC
 // @Accept json
 // @Produce json
@@ -56,7 +56,8 @@ func (h *RBACHandler) ListRoles(w http.ResponseWriter, r *http.Request) {
 // @Security TenantID
 // @Security UserID
 // @Success 201 {object} rbac.Role "Created role"
-// @Failure 400 {object} map[string]string "Invalid request or role name"
+// @Failure 400 {object} map[string]string "Invalid request, role name or permission"
+// @Failure 403 {object} map[string]string "Granting a permission the caller does not hold"
 // @Failure 409 {object} map[string]string "Role already exists"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/roles [post]
@@ -72,6 +73,9 @@ func (h *RBACHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
 
 	role, err := h.service.CreateRole(r.Context(), tenantID, userID, &req)
 	if err != nil {
+		if respondGrantError(w, err) {
+			return
+		}
 		if errors.Is(err, rbac.ErrRoleAlreadyExists) {
 			respondError(w, http.StatusConflict, "Role already exists")
 			return
@@ -128,8 +132,8 @@ func (h *RBACHandler) GetRole(w http.ResponseWriter, r *http.Request) {
 // @Security TenantID
 // @Security UserID
 // @Success 204 "Role updated successfully"
-// @Failure 400 {object} map[string]string "Invalid request or role name"
-// @Failure 403 {object} map[string]string "System role cannot be modified"
+// @Failure 400 {object} map[string]string "Invalid request, role name or permission"
+// @Failure 403 {object} map[string]string "System role cannot be modified, or granting a permission the caller does not hold"
 // @Failure 404 {object} map[string]string "Role not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/roles/{id} [put]
@@ -146,6 +150,9 @@ func (h *RBACHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 
 	err := h.service.UpdateRole(r.Context(), roleID, tenantID, userID, &req)
 	if err != nil {
+		if respondGrantError(w, err) {
+			return
+		}
 		if errors.Is(err, rbac.ErrRoleNotFound) {
 			respondError(w, http.StatusNotFound, "Role not found")
 			return
@@ -235,6 +242,9 @@ func (h *RBACHandler) UpdateRolePermissions(w http.ResponseWriter, r *http.Reque
 
 	err := h.service.UpdateRolePermissions(r.Context(), roleID, tenantID, userID, req.PermissionIDs)
 	if err != nil {
+		if respondGrantError(w, err) {
+			return
+		}
 		if errors.Is(err, rbac.ErrRoleNotFound) {
 			respondError(w, http.StatusNotFound, "Role not found")
 			return
@@ -276,6 +286,7 @@ func (h *RBACHandler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
 // @Security UserID
 // @Success 204 "Roles assigned successfully"
 // @Failure 400 {object} map[string]string "Invalid request or no roles provided"
+// @Failure 403 {object} map[string]string "Role grants a permission the caller does not hold"
 // @Failure 404 {object} map[string]string "Role not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/users/{id}/roles [put]
@@ -292,6 +303,9 @@ func (h *RBACHandler) AssignUserRoles(w http.ResponseWriter, r *http.Request) {
 
 	err := h.service.AssignRolesToUser(r.Context(), userID, tenantID, grantedBy, &req)
 	if err != nil {
+		if respondGrantError(w, err) {
+			return
+		}
 		if errors.Is(err, rbac.ErrRoleNotFound) {
 			respondError(w, http.StatusNotFound, "Role not found")
 			return
@@ -394,3 +408,17 @@ func (h *RBACHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, logs)
 }
+
+// respondGrantError writes the response for errors raised while granting
+// permissions, reporting whether it handled err
+func respondGrantError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, rbac.ErrPermissionEscalation):
+		respondError(w, http.StatusForbidden, "Cannot grant a permission you do not hold")
+	case errors.Is(err, rbac.ErrInvalidPermission), errors.Is(err, rbac.ErrPermissionNotFound):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		return false
+	}
+	return true
+}
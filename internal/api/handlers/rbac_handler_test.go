@@ -91,6 +91,11 @@ func (m *MockRBACRepository) AssignRolesToUser(ctx context.Context, userID strin
 	return args.Error(0)
 }
 
+func (m *MockRBACRepository) AddUserRole(ctx context.Context, userID, roleID, grantedBy string) error {
+	args := m.Called(ctx, userID, roleID, grantedBy)
+	return args.Error(0)
+}
+
 func (m *MockRBACRepository) GetUserPermissions(ctx context.Context, userID, tenantID string) ([]rbac.Permission, error) {
 	args := m.Called(ctx, userID, tenantID)
 	if args.Get(0) == nil {
@@ -645,6 +650,7 @@ func TestRBACHandler_UpdateRolePermissions(t *testing.T) {
 		userID         string
 		roleID         string
 		permissionIDs  []string
+		callerPermIDs  []string
 		mockRole       *rbac.Role
 		mockGetError   error
 		mockError      error
@@ -656,6 +662,7 @@ func TestRBACHandler_UpdateRolePermissions(t *testing.T) {
 			userID:        "user-123",
 			roleID:        "role-123",
 			permissionIDs: []string{"perm-1", "perm-2"},
+			callerPermIDs: []string{"perm-1", "perm-2"},
 			mockRole: &rbac.Role{
 				ID:        "role-123",
 				TenantID:  "tenant-123",
@@ -668,6 +675,20 @@ func TestRBACHandler_UpdateRolePermissions(t *testing.T) {
 			mockError:      nil,
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name:          "caller does not hold a granted permission",
+			tenantID:      "tenant-123",
+			userID:        "user-123",
+			roleID:        "role-123",
+			permissionIDs: []string{"perm-1", "perm-2"},
+			callerPermIDs: []string{"perm-1"},
+			mockRole: &rbac.Role{
+				ID:       "role-123",
+				TenantID: "tenant-123",
+				Name:     "custom-role",
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 		{
 			name:           "role not found",
 			tenantID:       "tenant-123",
@@ -701,7 +722,16 @@ func TestRBACHandler_UpdateRolePermissions(t *testing.T) {
 
 			mockRepo.On("GetRoleByID", mock.Anything, tt.roleID, tt.tenantID).Return(tt.mockRole, tt.mockGetError)
 			if tt.mockRole != nil && !tt.mockRole.IsSystem {
-				mockRepo.On("SetRolePermissions", mock.Anything, tt.roleID, tt.permissionIDs).Return(tt.mockError)
+				mockRepo.On("ListPermissions", mock.Anything).Return([]rbac.Permission{
+					{ID: "perm-1", Resource: "workflow", Action: "read"},
+					{ID: "perm-2", Resource: "workflow", Action: "update"},
+				}, nil)
+				var held []rbac.Permission
+				for _, id := range tt.callerPermIDs {
+					held = append(held, rbac.Permission{ID: id})
+				}
+				mockRepo.On("GetUserPermissions", mock.Anything, tt.userID, tt.tenantID).Return(held, nil)
+				mockRepo.On("SetRolePermissions", mock.Anything, tt.roleID, tt.permissionIDs).Return(tt.mockError).Maybe()
 				mockRepo.On("CreateAuditLog", mock.Anything, mock.Anything).Return(nil).Maybe()
 			}
 
@@ -843,6 +873,7 @@ func TestRBACHandler_AssignUserRoles(t *testing.T) {
 				for i, roleID := range tt.roleIDs {
 					if tt.mockRoles != nil && i < len(tt.mockRoles) {
 						mockRepo.On("GetRoleByID", mock.Anything, roleID, tt.tenantID).Return(tt.mockRoles[i], nil)
+						mockRepo.On("GetRolePermissions", mock.Anything, roleID).Return([]rbac.Permission{}, nil)
 					} else {
 						mockRepo.On("GetRoleByID", mock.Anything, roleID, tt.tenantID).Return(nil, tt.mockError)
 					}
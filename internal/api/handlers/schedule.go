@@ -21,6 +21,8 @@ type ScheduleService interface {
 	GetByID(ctx context.Context, tenantID, id string) (*schedule.Schedule, error)
 	Update(ctx context.Context, tenantID, id string, input schedule.UpdateScheduleInput) (*schedule.Schedule, error)
 	Delete(ctx context.Context, tenantID, id string) error
+	PauseSchedule(ctx context.Context, tenantID, id, userID string) (*schedule.Schedule, error)
+	ResumeSchedule(ctx context.Context, tenantID, id, userID string) (*schedule.Schedule, error)
 	List(ctx context.Context, tenantID, workflowID string, limit, offset int) ([]*schedule.Schedule, error)
 	ListAll(ctx context.Context, tenantID string, limit, offset int) ([]*schedule.ScheduleWithWorkflow, error)
 	ParseNextRunTime(expression, timezone string) (time.Time, error)
@@ -180,6 +182,54 @@ func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w)
 }
 
+// Pause stops a schedule from running until it is resumed
+func (h *ScheduleHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	user := middleware.GetUser(r)
+	scheduleID := chi.URLParam(r, "scheduleID")
+
+	sched, err := h.service.PauseSchedule(r.Context(), tenantID, scheduleID, user.ID)
+	if err != nil {
+		if err == schedule.ErrNotFound {
+			_ = response.NotFound(w, "schedule not found")
+			return
+		}
+		if err == schedule.ErrAlreadyPaused {
+			_ = response.Conflict(w, err.Error())
+			return
+		}
+		h.logger.Error("failed to pause schedule", "error", err)
+		_ = response.InternalError(w, "failed to pause schedule")
+		return
+	}
+
+	_ = response.OK(w, sched)
+}
+
+// Resume makes a paused schedule run again from its next occurrence
+func (h *ScheduleHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	user := middleware.GetUser(r)
+	scheduleID := chi.URLParam(r, "scheduleID")
+
+	sched, err := h.service.ResumeSchedule(r.Context(), tenantID, scheduleID, user.ID)
+	if err != nil {
+		if err == schedule.ErrNotFound {
+			_ = response.NotFound(w, "schedule not found")
+			return
+		}
+		if err == schedule.ErrNotPaused {
+			_ = response.Conflict(w, err.Error())
+			return
+		}
+		h.logger.Error("failed to resume schedule", "error", err)
+		_ = response.InternalError(w, "failed to resume schedule")
+		return
+	}
+
+	_ = response.OK(w, sched)
+}
+
 // ParseCron validates a cron expression and returns next run times
 func (h *ScheduleHandler) ParseCron(w http.ResponseWriter, r *http.Request) {
 	var input struct {
@@ -56,6 +56,22 @@ func (m *MockScheduleService) Delete(ctx context.Context, tenantID, id string) e
 	return args.Error(0)
 }
 
+func (m *MockScheduleService) PauseSchedule(ctx context.Context, tenantID, id, userID string) (*schedule.Schedule, error) {
+	args := m.Called(ctx, tenantID, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schedule.Schedule), args.Error(1)
+}
+
+func (m *MockScheduleService) ResumeSchedule(ctx context.Context, tenantID, id, userID string) (*schedule.Schedule, error) {
+	args := m.Called(ctx, tenantID, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schedule.Schedule), args.Error(1)
+}
+
 func (m *MockScheduleService) List(ctx context.Context, tenantID, workflowID string, limit, offset int) ([]*schedule.Schedule, error) {
 	args := m.Called(ctx, tenantID, workflowID, limit, offset)
 	if args.Get(0) == nil {
@@ -674,6 +690,132 @@ func TestScheduleHandler_Delete(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Pause/Resume Handler Tests
+// ============================================================================
+
+func TestScheduleHandler_Pause(t *testing.T) {
+	paused := createTestSchedule()
+	paused.Status = schedule.ScheduleStatusPaused
+
+	tests := []struct {
+		name           string
+		scheduleID     string
+		setupMock      func(*MockScheduleService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:       "successful pause",
+			scheduleID: "sched-123",
+			setupMock: func(m *MockScheduleService) {
+				m.On("PauseSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(paused, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"paused"`,
+		},
+		{
+			name:       "already paused",
+			scheduleID: "sched-123",
+			setupMock: func(m *MockScheduleService) {
+				m.On("PauseSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(nil, schedule.ErrAlreadyPaused)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "already paused",
+		},
+		{
+			name:       "schedule not found",
+			scheduleID: "nonexistent",
+			setupMock: func(m *MockScheduleService) {
+				m.On("PauseSchedule", mock.Anything, "tenant-123", "nonexistent", "user-123").Return(nil, schedule.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "schedule not found",
+		},
+		{
+			name:       "service error",
+			scheduleID: "sched-123",
+			setupMock: func(m *MockScheduleService) {
+				m.On("PauseSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to pause schedule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestScheduleHandler()
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/schedules/"+tt.scheduleID+"/pause", nil)
+			req = addScheduleContext(req, "tenant-123", &middleware.User{ID: "user-123"})
+			req = addScheduleURLParams(req, map[string]string{"scheduleID": tt.scheduleID})
+
+			rr := httptest.NewRecorder()
+			handler.Pause(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduleHandler_Resume(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*MockScheduleService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful resume",
+			setupMock: func(m *MockScheduleService) {
+				resumed := createTestSchedule()
+				resumed.Status = schedule.ScheduleStatusActive
+				m.On("ResumeSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(resumed, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"active"`,
+		},
+		{
+			name: "not paused",
+			setupMock: func(m *MockScheduleService) {
+				m.On("ResumeSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(nil, schedule.ErrNotPaused)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "not paused",
+		},
+		{
+			name: "service error",
+			setupMock: func(m *MockScheduleService) {
+				m.On("ResumeSchedule", mock.Anything, "tenant-123", "sched-123", "user-123").Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to resume schedule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := newTestScheduleHandler()
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/schedules/sched-123/resume", nil)
+			req = addScheduleContext(req, "tenant-123", &middleware.User{ID: "user-123"})
+			req = addScheduleURLParams(req, map[string]string{"scheduleID": "sched-123"})
+
+			rr := httptest.NewRecorder()
+			handler.Resume(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 // ============================================================================
 // ParseCron Handler Tests
 // ============================================================================
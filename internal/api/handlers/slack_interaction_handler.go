@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/integrations/slack"
+)
+
+// maxSlackInteractionBody caps the size of an interaction request body
+const maxSlackInteractionBody = 1 << 20
+
+// SlackInteractionResponder records responses to Slack messages with buttons
+type SlackInteractionResponder interface {
+	Respond(ctx context.Context, response slack.InteractionResponse) (*slack.Interaction, error)
+}
+
+// SlackInteractionHandler receives button clicks and slash commands from
+// Slack and hands the response to the execution waiting on it
+type SlackInteractionHandler struct {
+	responder     SlackInteractionResponder
+	signingSecret string
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+// NewSlackInteractionHandler creates a new Slack interaction handler
+func NewSlackInteractionHandler(responder SlackInteractionResponder, signingSecret string, logger *slog.Logger) *SlackInteractionHandler {
+	return &SlackInteractionHandler{
+		responder:     responder,
+		signingSecret: signingSecret,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// slashCommandReply is the message shown to the user who ran a slash command
+type slashCommandReply struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// Handle records a button click or slash command response
+// POST /api/v1/slack/interactions
+func (h *SlackInteractionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSlackInteractionBody))
+	if err != nil {
+		_ = response.BadRequest(w, "failed to read request body")
+		return
+	}
+
+	err = slack.VerifySignature(
+		h.signingSecret,
+		r.Header.Get("X-Slack-Request-Timestamp"),
+		r.Header.Get("X-Slack-Signature"),
+		body,
+		h.now(),
+	)
+	if err != nil {
+		_ = response.Unauthorized(w, "invalid Slack signature")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		_ = response.BadRequest(w, "invalid form body")
+		return
+	}
+
+	interaction, err := slack.ParseInteractionRequest(form)
+	if err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	recorded, err := h.responder.Respond(r.Context(), *interaction)
+	if err != nil && !isSlackInteractionUserError(err) {
+		h.logger.Error("failed to record slack interaction", "error", err, "source", interaction.Source)
+		_ = response.InternalError(w, "failed to record response")
+		return
+	}
+	if err == nil {
+		h.logger.Info("slack interaction recorded",
+			"execution_id", recorded.ExecutionID,
+			"node_id", recorded.NodeID,
+			"source", interaction.Source,
+		)
+	}
+
+	// Slack shows slash command replies to the user; button clicks only need
+	// to be acknowledged
+	if interaction.Source != slack.InteractionSourceSlashCommand {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	text := "Response `" + interaction.Value + "` recorded."
+	if err != nil {
+		text = err.Error()
+		if errors.Is(err, slack.ErrInteractionNotFound) {
+			text = "No request is waiting for that token."
+		}
+	}
+	_ = response.OK(w, slashCommandReply{ResponseType: "ephemeral", Text: text})
+}
+
+// isSlackInteractionUserError reports whether err is the responder's fault
+// rather than the server's
+func isSlackInteractionUserError(err error) bool {
+	return errors.Is(err, slack.ErrInteractionNotFound) ||
+		errors.Is(err, slack.ErrInteractionClosed) ||
+		errors.Is(err, slack.ErrInvalidResponseValue)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/integrations/slack"
+)
+
+type fakeSlackResponder struct {
+	got      *slack.InteractionResponse
+	err      error
+	recorded *slack.Interaction
+}
+
+func (f *fakeSlackResponder) Respond(ctx context.Context, response slack.InteractionResponse) (*slack.Interaction, error) {
+	f.got = &response
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.recorded, nil
+}
+
+const testSlackSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+var testSlackNow = time.Unix(1700000000, 0)
+
+func newSlackInteractionRequest(t *testing.T, form url.Values, secret string) *http.Request {
+	t.Helper()
+	body := form.Encode()
+	timestamp := strconv.FormatInt(testSlackNow.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newTestSlackInteractionHandler(responder SlackInteractionResponder) *SlackInteractionHandler {
+	h := NewSlackInteractionHandler(responder, testSlackSigningSecret, slog.Default())
+	h.now = func() time.Time { return testSlackNow }
+	return h
+}
+
+func TestSlackInteractionHandler_Handle(t *testing.T) {
+	buttonForm := url.Values{"payload": {
+		`{"type":"block_actions","user":{"id":"U1","username":"alice"},"actions":[{"block_id":"gorax_interaction:tok-1","value":"approve"}]}`,
+	}}
+	commandForm := url.Values{
+		"command":   {"/approve-request"},
+		"text":      {"reject tok-1"},
+		"user_id":   {"U2"},
+		"user_name": {"bob"},
+	}
+	recorded := &slack.Interaction{Token: "tok-1", ExecutionID: "exec-1", NodeID: "slack-1"}
+
+	t.Run("records a button click", func(t *testing.T) {
+		responder := &fakeSlackResponder{recorded: recorded}
+		rr := httptest.NewRecorder()
+
+		newTestSlackInteractionHandler(responder).Handle(rr, newSlackInteractionRequest(t, buttonForm, testSlackSigningSecret))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		require.NotNil(t, responder.got)
+		assert.Equal(t, "tok-1", responder.got.Token)
+		assert.Equal(t, "approve", responder.got.Value)
+		assert.Equal(t, slack.InteractionSourceButton, responder.got.Source)
+		assert.Equal(t, "alice", responder.got.ResponderName)
+	})
+
+	t.Run("replies to a slash command", func(t *testing.T) {
+		responder := &fakeSlackResponder{recorded: recorded}
+		rr := httptest.NewRecorder()
+
+		newTestSlackInteractionHandler(responder).Handle(rr, newSlackInteractionRequest(t, commandForm, testSlackSigningSecret))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var reply slashCommandReply
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&reply))
+		assert.Equal(t, "ephemeral", reply.ResponseType)
+		assert.Contains(t, reply.Text, "reject")
+		assert.Equal(t, "reject", responder.got.Value)
+	})
+
+	t.Run("tells a slash command user why a response was refused", func(t *testing.T) {
+		for _, err := range []error{slack.ErrInteractionNotFound, slack.ErrInteractionClosed, slack.ErrInvalidResponseValue} {
+			rr := httptest.NewRecorder()
+			newTestSlackInteractionHandler(&fakeSlackResponder{err: err}).Handle(rr, newSlackInteractionRequest(t, commandForm, testSlackSigningSecret))
+
+			require.Equal(t, http.StatusOK, rr.Code, err.Error())
+			var reply slashCommandReply
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&reply))
+			assert.NotContains(t, reply.Text, "recorded", err.Error())
+		}
+	})
+
+	t.Run("acknowledges a click on a closed request", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		newTestSlackInteractionHandler(&fakeSlackResponder{err: slack.ErrInteractionClosed}).Handle(rr, newSlackInteractionRequest(t, buttonForm, testSlackSigningSecret))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("rejects a bad signature", func(t *testing.T) {
+		responder := &fakeSlackResponder{recorded: recorded}
+		rr := httptest.NewRecorder()
+
+		newTestSlackInteractionHandler(responder).Handle(rr, newSlackInteractionRequest(t, buttonForm, "wrong-secret"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Nil(t, responder.got)
+	})
+
+	t.Run("rejects unsupported interactions", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		form := url.Values{"payload": {`{"type":"view_submission"}`}}
+
+		newTestSlackInteractionHandler(&fakeSlackResponder{}).Handle(rr, newSlackInteractionRequest(t, form, testSlackSigningSecret))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("reports store failures", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+
+		newTestSlackInteractionHandler(&fakeSlackResponder{err: errors.New("db down")}).Handle(rr, newSlackInteractionRequest(t, buttonForm, testSlackSigningSecret))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
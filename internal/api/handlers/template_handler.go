@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/pagination"
 	"github.com/gorax/gorax/internal/template"
 )
 
@@ -60,12 +62,24 @@ func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request)
 		filter.IsPublic = &isPublicBool
 	}
 
+	page, cursorMode, err := cursorPage(r)
+	if err != nil {
+		_ = response.WriteError(w, err)
+		return
+	}
+	filter.Page = page
+
 	templates, err := h.service.ListTemplates(r.Context(), tenantID, filter)
 	if err != nil {
 		_ = response.InternalError(w, "failed to list templates")
 		return
 	}
 
+	if cursorMode {
+		_ = response.OK(w, pagination.NewPage(templates, page.Limit, templatePosition))
+		return
+	}
+
 	_ = response.OK(w, templates)
 }
 
@@ -218,6 +232,10 @@ func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Req
 			_ = response.NotFound(w, "template not found")
 			return
 		}
+		if errors.Is(err, template.ErrInvalidParameters) {
+			_ = response.BadRequest(w, err.Error())
+			return
+		}
 		_ = response.InternalError(w, "failed to instantiate template")
 		return
 	}
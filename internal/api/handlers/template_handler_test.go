@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -394,3 +395,31 @@ func TestInstantiateTemplate(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestInstantiateTemplate_InvalidParameters(t *testing.T) {
+	handler, mockService := newTestTemplateHandler()
+
+	tenantID := "test-tenant-123"
+	templateID := "template-123"
+
+	input := template.InstantiateTemplateInput{
+		WorkflowName: "New Workflow",
+		Parameters:   map[string]interface{}{"region": "eu"},
+	}
+
+	mockService.On("InstantiateTemplate", mock.Anything, tenantID, templateID, input).
+		Return(nil, fmt.Errorf("%w: unknown parameters: region", template.ErrInvalidParameters))
+
+	body, _ := json.Marshal(input)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/templates/"+templateID+"/instantiate", bytes.NewReader(body))
+	req = addTemplateTestContext(req, tenantID, "user-123")
+	req = addRouteParam(req, "id", templateID)
+	w := httptest.NewRecorder()
+
+	handler.InstantiateTemplate(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown parameters: region")
+
+	mockService.AssertExpectations(t)
+}
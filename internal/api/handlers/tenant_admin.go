@@ -1,19 +1,28 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/gorax/gorax/internal/credential"
 	"github.com/gorax/gorax/internal/tenant"
 	"github.com/gorax/gorax/internal/validation"
 )
 
+// TenantKeyRotator moves a tenant onto its own KMS key
+type TenantKeyRotator interface {
+	RotateTenantKey(ctx context.Context, tenantID, newKeyID string) (*credential.KeyRotationResult, error)
+}
+
 // TenantAdminHandler handles tenant administration endpoints
 type TenantAdminHandler struct {
 	tenantService *tenant.Service
+	keyRotator    TenantKeyRotator
 	logger        *slog.Logger
 }
 
@@ -25,6 +34,12 @@ func NewTenantAdminHandler(tenantService *tenant.Service, logger *slog.Logger) *
 	}
 }
 
+// SetKeyRotator enables per-tenant KMS keys. It is only set when
+// credentials are encrypted with KMS.
+func (h *TenantAdminHandler) SetKeyRotator(rotator TenantKeyRotator) {
+	h.keyRotator = rotator
+}
+
 // CreateTenant handles POST /api/v1/admin/tenants
 func (h *TenantAdminHandler) CreateTenant(w http.ResponseWriter, r *http.Request) {
 	var input tenant.CreateTenantInput
@@ -43,6 +58,10 @@ func (h *TenantAdminHandler) CreateTenant(w http.ResponseWriter, r *http.Request
 
 	t, err := h.tenantService.Create(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, tenant.ErrUnknownRegion) {
+			http.Error(w, "validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.Error("failed to create tenant", "error", err)
 		http.Error(w, "failed to create tenant: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -141,6 +160,10 @@ func (h *TenantAdminHandler) UpdateTenant(w http.ResponseWriter, r *http.Request
 			http.Error(w, "tenant not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, tenant.ErrUnknownRegion) {
+			http.Error(w, "validation error: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.Error("failed to update tenant", "error", err, "tenant_id", tenantID)
 		http.Error(w, "failed to update tenant: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -205,6 +228,49 @@ func (h *TenantAdminHandler) UpdateTenantQuotas(w http.ResponseWriter, r *http.R
 	})
 }
 
+// SetTenantKMSKey handles PUT /api/v1/admin/tenants/{id}/kms-key
+// It assigns the tenant its own KMS key and re-wraps the data keys of the
+// tenant's existing secrets under it.
+func (h *TenantAdminHandler) SetTenantKMSKey(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	if tenantID == "" {
+		http.Error(w, "tenant ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if h.keyRotator == nil {
+		http.Error(w, "per-tenant KMS keys require KMS credential encryption", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		KMSKeyID string `json:"kms_key_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error("failed to decode KMS key request", "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if input.KMSKeyID == "" {
+		http.Error(w, "kms_key_id is required", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.keyRotator.RotateTenantKey(r.Context(), tenantID, input.KMSKeyID)
+	if err != nil {
+		if errors.Is(err, credential.ErrInvalidTenantID) {
+			http.Error(w, "tenant not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to rotate tenant KMS key", "error", err, "tenant_id", tenantID)
+		http.Error(w, "failed to rotate KMS key: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 // GetTenantUsage handles GET /api/v1/admin/tenants/{id}/usage
 func (h *TenantAdminHandler) GetTenantUsage(w http.ResponseWriter, r *http.Request) {
 	tenantID := chi.URLParam(r, "tenantID")
@@ -22,8 +22,12 @@ type WebhookWorkflowService interface {
 // WebhookService defines the interface for webhook operations
 type WebhookService interface {
 	GetByWorkflowAndWebhookID(ctx context.Context, workflowID, webhookID string) (*webhook.Webhook, error)
-	VerifySignature(payload []byte, signature string, secret string) bool
+	VerifyRequest(webhook *webhook.Webhook, header http.Header, body []byte) error
+	ValidatePayload(ctx context.Context, webhook *webhook.Webhook, body []byte) ([]webhook.SchemaViolation, error)
+	ClaimDelivery(ctx context.Context, webhook *webhook.Webhook, deliveryID string) (bool, error)
+	ReleaseDelivery(ctx context.Context, webhook *webhook.Webhook, deliveryID string) error
 	LogEvent(ctx context.Context, event *webhook.WebhookEvent) error
+	EvaluateFilters(ctx context.Context, webhookID string, payload map[string]interface{}) (*webhook.FilterResult, error)
 }
 
 // WebhookHandler handles incoming webhook requests
@@ -76,21 +80,78 @@ func (h *WebhookHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify signature if required
+	// Capture before any checks so rejected requests can be inspected too
+	h.captureRequest(r.Context(), webhookConfig, r, body)
+
+	// Verify signature if required, using the webhook's signature scheme
 	if webhookConfig.AuthType == webhook.AuthTypeSignature {
-		signature := r.Header.Get("X-Webhook-Signature")
-		if signature == "" {
-			// Also check for X-Hub-Signature-256 (GitHub style)
-			signature = r.Header.Get("X-Hub-Signature-256")
+		if err := h.webhookService.VerifyRequest(webhookConfig, r.Header, body); err != nil {
+			h.logger.Warn("webhook signature verification failed",
+				"error", err,
+				"webhook_id", webhookID,
+				"signature_scheme", webhookConfig.SignatureScheme,
+			)
+			_ = response.Unauthorized(w, "invalid signature")
+			return
 		}
+	}
 
-		if !h.webhookService.VerifySignature(body, signature, webhookConfig.Secret) {
-			h.logger.Warn("webhook signature verification failed", "webhook_id", webhookID)
-			_ = response.Unauthorized(w, "invalid signature")
+	// Reject payloads that do not match the webhook's payload schema or event type
+	if webhookConfig.PayloadSchema != nil || webhookConfig.EventType != nil {
+		violations, err := h.webhookService.ValidatePayload(r.Context(), webhookConfig, body)
+		if err != nil {
+			h.logger.Error("failed to validate webhook payload", "error", err, "webhook_id", webhookID)
+			_ = response.InternalError(w, "failed to validate payload")
+			return
+		}
+		if len(violations) > 0 {
+			h.logger.Info("webhook payload rejected by schema", "webhook_id", webhookID, "violations", len(violations))
+			h.logWebhookEvent(r.Context(), webhookConfig, r, body, nil, webhook.EventStatusFailed, metadata, stringPtr("payload does not match schema"))
+			_ = response.ValidationError(w, "payload does not match schema", violationDetails(violations))
+			return
+		}
+	}
+
+	// Drop provider retries of a delivery that was already handled
+	var deliveryID string
+	if webhookConfig.IdempotencyHeader != "" {
+		deliveryID = r.Header.Get(webhookConfig.IdempotencyHeader)
+	}
+	if deliveryID != "" {
+		claimed, err := h.webhookService.ClaimDelivery(r.Context(), webhookConfig, deliveryID)
+		if err != nil {
+			// Process the delivery rather than drop it when the check fails
+			deliveryID = ""
+		} else if !claimed {
+			h.logger.Info("duplicate webhook delivery ignored", "webhook_id", webhookID, "delivery_id", deliveryID)
+			_ = response.OK(w, map[string]string{
+				"status":      "duplicate",
+				"delivery_id": deliveryID,
+			})
 			return
 		}
 	}
 
+	// Evaluate filters before triggering the workflow
+	filterResult, err := h.webhookService.EvaluateFilters(r.Context(), webhookConfig.ID, parsePayload(body))
+	if err != nil {
+		h.logger.Error("failed to evaluate webhook filters", "error", err, "webhook_id", webhookID)
+		h.releaseDelivery(r.Context(), webhookConfig, deliveryID)
+		h.logWebhookEvent(r.Context(), webhookConfig, r, body, nil, webhook.EventStatusFailed, metadata, stringPtr(err.Error()))
+		h.writeWebhookResponse(w, webhookConfig, webhook.ResponseOutcomeError, webhook.ResponseData{Error: "failed to evaluate filters"})
+		return
+	}
+
+	if !filterResult.Passed {
+		h.logger.Info("webhook filtered", "webhook_id", webhookID, "reason", filterResult.Reason)
+		h.logWebhookEvent(r.Context(), webhookConfig, r, body, nil, webhook.EventStatusFiltered, metadata, stringPtr(filterResult.Reason))
+		h.writeWebhookResponse(w, webhookConfig, webhook.ResponseOutcomeFiltered, webhook.ResponseData{
+			Status: string(webhook.EventStatusFiltered),
+			Reason: filterResult.Reason,
+		})
+		return
+	}
+
 	// Build trigger data
 	triggerData := map[string]interface{}{
 		"method":  r.Method,
@@ -106,18 +167,29 @@ func (h *WebhookHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Execute workflow using tenant ID from webhook config
-	execution, err := h.workflowService.Execute(r.Context(), webhookConfig.TenantID, workflowID, "webhook", triggerDataJSON)
+	// The webhook's node is the entry point when the workflow has several triggers
+	execCtx := workflow.WithTriggerNode(r.Context(), webhookConfig.NodeID)
+	execution, err := h.workflowService.Execute(execCtx, webhookConfig.TenantID, workflowID, "webhook", triggerDataJSON)
 	if err != nil {
 		if err == workflow.ErrNotFound {
+			h.releaseDelivery(r.Context(), webhookConfig, deliveryID)
 			_ = response.NotFound(w, "workflow not found")
 			return
 		}
 		h.logger.Error("failed to execute workflow from webhook", "error", err, "workflow_id", workflowID)
 
-		// Log failed event with metadata
-		h.logWebhookEvent(r.Context(), webhookConfig, r, body, nil, webhook.EventStatusFailed, metadata, stringPtr(err.Error()))
+		// A scheduled redelivery keeps the delivery claimed so a provider
+		// retry does not execute the workflow a second time
+		logged, scheduled := h.scheduleRedelivery(r.Context(), webhookConfig, r, body, metadata, err)
+		if !scheduled {
+			h.releaseDelivery(r.Context(), webhookConfig, deliveryID)
+		}
+		if !logged {
+			// Log failed event with metadata
+			h.logWebhookEvent(r.Context(), webhookConfig, r, body, nil, webhook.EventStatusFailed, metadata, stringPtr(err.Error()))
+		}
 
-		_ = response.InternalError(w, "failed to execute workflow")
+		h.writeWebhookResponse(w, webhookConfig, webhook.ResponseOutcomeError, webhook.ResponseData{Error: "failed to execute workflow"})
 		return
 	}
 
@@ -127,12 +199,58 @@ func (h *WebhookHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	h.logWebhookEvent(r.Context(), webhookConfig, r, body, &execution.ID, webhook.EventStatusProcessed, metadata, nil)
 
 	// Return execution ID
-	_ = response.JSON(w, http.StatusAccepted, map[string]any{
-		"execution_id": execution.ID,
-		"status":       execution.Status,
+	h.writeWebhookResponse(w, webhookConfig, webhook.ResponseOutcomePassed, webhook.ResponseData{
+		ExecutionID: execution.ID,
+		Status:      execution.Status,
 	})
 }
 
+// releaseDelivery forgets a claimed delivery ID so the provider's retry is processed
+func (h *WebhookHandler) releaseDelivery(ctx context.Context, webhookConfig *webhook.Webhook, deliveryID string) {
+	if deliveryID == "" {
+		return
+	}
+	// Best effort: if this fails the retry is dropped until the ID expires
+	_ = h.webhookService.ReleaseDelivery(ctx, webhookConfig, deliveryID)
+}
+
+// writeWebhookResponse writes the configured (or default) response for an outcome
+func (h *WebhookHandler) writeWebhookResponse(w http.ResponseWriter, webhookConfig *webhook.Webhook, outcome webhook.ResponseOutcome, data webhook.ResponseData) {
+	rendered := webhookConfig.ResponseConfig.Render(outcome, data)
+
+	w.Header().Set("Content-Type", rendered.ContentType)
+	w.WriteHeader(rendered.StatusCode)
+	if _, err := io.WriteString(w, rendered.Body); err != nil {
+		h.logger.Error("failed to write webhook response", "error", err, "webhook_id", webhookConfig.ID)
+	}
+}
+
+// violationDetails groups schema violations by path for the error response
+func violationDetails(violations []webhook.SchemaViolation) map[string]string {
+	details := make(map[string]string, len(violations))
+	for _, violation := range violations {
+		if existing, ok := details[violation.Path]; ok {
+			details[violation.Path] = existing + "; " + violation.Message
+			continue
+		}
+		details[violation.Path] = violation.Message
+	}
+	return details
+}
+
+// parsePayload decodes a JSON object body for filter evaluation.
+// Non-object or invalid bodies evaluate against an empty payload.
+func parsePayload(body []byte) map[string]interface{} {
+	payload := make(map[string]interface{})
+	if len(body) == 0 {
+		return payload
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return make(map[string]interface{})
+	}
+	return payload
+}
+
 func flattenHeaders(headers http.Header) map[string]string {
 	result := make(map[string]string)
 	for key, values := range headers {
@@ -162,7 +280,7 @@ func (h *WebhookHandler) logWebhookEvent(
 	executionID *string,
 	status webhook.WebhookEventStatus,
 	metadata *webhook.EventMetadata,
-	errorMsg *string,
+	detail *string,
 ) {
 	event := &webhook.WebhookEvent{
 		TenantID:       webhookConfig.TenantID,
@@ -172,16 +290,83 @@ func (h *WebhookHandler) logWebhookEvent(
 		RequestHeaders: flattenHeaders(r.Header),
 		RequestBody:    json.RawMessage(body),
 		Status:         status,
-		ErrorMessage:   errorMsg,
 		Metadata:       metadata,
 	}
 
+	// Filtered events record the filter reason; other statuses record an error
+	if status == webhook.EventStatusFiltered {
+		event.FilteredReason = detail
+	} else {
+		event.ErrorMessage = detail
+	}
+
 	// Log the event (best effort - don't fail request if logging fails)
 	if err := h.webhookService.LogEvent(ctx, event); err != nil {
 		h.logger.Error("failed to log webhook event", "error", err, "webhook_id", webhookConfig.ID)
 	}
 }
 
+// RequestCapturer is implemented by webhook services that can store raw
+// requests for debugging
+type RequestCapturer interface {
+	CaptureRequest(ctx context.Context, webhook *webhook.Webhook, r *http.Request, body []byte) error
+}
+
+// captureRequest stores the raw request when the webhook has capture enabled.
+// Capture is best effort and never fails the delivery.
+func (h *WebhookHandler) captureRequest(ctx context.Context, webhookConfig *webhook.Webhook, r *http.Request, body []byte) {
+	if webhookConfig.CaptureConfig == nil || !webhookConfig.CaptureConfig.Enabled {
+		return
+	}
+	capturer, ok := h.webhookService.(RequestCapturer)
+	if !ok {
+		return
+	}
+	_ = capturer.CaptureRequest(ctx, webhookConfig, r, body)
+}
+
+// RedeliveryScheduler is implemented by webhook services that retry failed
+// deliveries in the background
+type RedeliveryScheduler interface {
+	ScheduleRedelivery(ctx context.Context, event *webhook.WebhookEvent, cause string) (bool, error)
+}
+
+// scheduleRedelivery logs a failed event for background redelivery. It
+// reports whether the event was logged and whether a retry was scheduled;
+// services that do not redeliver events do neither.
+func (h *WebhookHandler) scheduleRedelivery(
+	ctx context.Context,
+	webhookConfig *webhook.Webhook,
+	r *http.Request,
+	body []byte,
+	metadata *webhook.EventMetadata,
+	cause error,
+) (logged, scheduled bool) {
+	scheduler, ok := h.webhookService.(RedeliveryScheduler)
+	if !ok {
+		return false, false
+	}
+
+	event := &webhook.WebhookEvent{
+		TenantID:       webhookConfig.TenantID,
+		WebhookID:      webhookConfig.ID,
+		RequestMethod:  r.Method,
+		RequestHeaders: flattenHeaders(r.Header),
+		RequestBody:    json.RawMessage(body),
+		Metadata:       metadata,
+	}
+
+	scheduled, err := scheduler.ScheduleRedelivery(ctx, event, cause.Error())
+	if err != nil {
+		h.logger.Error("failed to schedule webhook redelivery", "error", err, "webhook_id", webhookConfig.ID)
+	} else if scheduled {
+		h.logger.Info("webhook redelivery scheduled", "event_id", event.ID, "webhook_id", webhookConfig.ID)
+	}
+
+	// The event is logged with an ID even if only scheduling its retry failed
+	return event.ID != "", scheduled
+}
+
 // stringPtr returns a pointer to the given string
 func stringPtr(s string) *string {
 	return &s
@@ -43,8 +43,9 @@ func NewWebhookFilterHandler(service WebhookFilterService, logger *slog.Logger)
 // CreateFilterRequest represents the request to create a filter
 type CreateFilterRequest struct {
 	FieldPath  string `json:"fieldPath" validate:"required"`
-	Operator   string `json:"operator" validate:"required,oneof=equals not_equals contains not_contains starts_with ends_with regex gt lt in not_in exists not_exists"`
+	Operator   string `json:"operator" validate:"required,oneof=equals not_equals contains not_contains starts_with ends_with regex gt gte lt lte between in not_in exists not_exists"`
 	Value      any    `json:"value"`
+	ValueType  string `json:"valueType" validate:"omitempty,oneof=number date string"`
 	LogicGroup int    `json:"logicGroup" validate:"min=0"`
 	Enabled    bool   `json:"enabled"`
 }
@@ -52,8 +53,9 @@ type CreateFilterRequest struct {
 // UpdateFilterRequest represents the request to update a filter
 type UpdateFilterRequest struct {
 	FieldPath  string `json:"fieldPath" validate:"required"`
-	Operator   string `json:"operator" validate:"required,oneof=equals not_equals contains not_contains starts_with ends_with regex gt lt in not_in exists not_exists"`
+	Operator   string `json:"operator" validate:"required,oneof=equals not_equals contains not_contains starts_with ends_with regex gt gte lt lte between in not_in exists not_exists"`
 	Value      any    `json:"value"`
+	ValueType  string `json:"valueType" validate:"omitempty,oneof=number date string"`
 	LogicGroup int    `json:"logicGroup" validate:"min=0"`
 	Enabled    bool   `json:"enabled"`
 }
@@ -124,6 +126,7 @@ func (h *WebhookFilterHandler) Create(w http.ResponseWriter, r *http.Request) {
 		FieldPath:  input.FieldPath,
 		Operator:   webhook.FilterOperator(input.Operator),
 		Value:      input.Value,
+		ValueType:  webhook.FilterValueType(input.ValueType),
 		LogicGroup: input.LogicGroup,
 		Enabled:    input.Enabled,
 	}
@@ -165,6 +168,7 @@ func (h *WebhookFilterHandler) Update(w http.ResponseWriter, r *http.Request) {
 		FieldPath:  input.FieldPath,
 		Operator:   webhook.FilterOperator(input.Operator),
 		Value:      input.Value,
+		ValueType:  webhook.FilterValueType(input.ValueType),
 		LogicGroup: input.LogicGroup,
 		Enabled:    input.Enabled,
 	}
@@ -347,6 +347,37 @@ func TestWebhookFilterHandler_Create(t *testing.T) {
 			setupMock:      func(m *MockWebhookFilterService) {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:      "date comparison",
+			tenantID:  "tenant-123",
+			webhookID: "webhook-123",
+			body: CreateFilterRequest{
+				FieldPath: "$.created_at",
+				Operator:  "gt",
+				Value:     "2024-01-01T00:00:00Z",
+				ValueType: "date",
+				Enabled:   true,
+			},
+			setupMock: func(m *MockWebhookFilterService) {
+				m.On("CreateFilter", mock.Anything, "tenant-123", "webhook-123", mock.MatchedBy(func(f *webhook.WebhookFilter) bool {
+					return f.Operator == webhook.OpGreaterThan && f.ValueType == webhook.FilterValueDate
+				})).Return(createTestWebhookFilter(), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:      "validation error - invalid value type",
+			tenantID:  "tenant-123",
+			webhookID: "webhook-123",
+			body: CreateFilterRequest{
+				FieldPath: "$.created_at",
+				Operator:  "gt",
+				Value:     "2024-01-01T00:00:00Z",
+				ValueType: "timestamp",
+			},
+			setupMock:      func(m *MockWebhookFilterService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:      "webhook not found",
 			tenantID:  "tenant-123",
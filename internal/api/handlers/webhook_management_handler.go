@@ -29,10 +29,14 @@ type WebhookManagementService interface {
 	GetByID(ctx context.Context, tenantID, webhookID string) (*webhook.Webhook, error)
 	CreateWithDetails(ctx context.Context, tenantID, workflowID, name, path, authType, description string, priority int) (*webhook.Webhook, error)
 	Update(ctx context.Context, tenantID, webhookID, name, authType, description string, priority int, enabled bool) (*webhook.Webhook, error)
+	UpdateResponseConfig(ctx context.Context, tenantID, webhookID string, config *webhook.ResponseConfig) (*webhook.Webhook, error)
+	UpdateCaptureConfig(ctx context.Context, tenantID, webhookID string, config *webhook.CaptureConfig) (*webhook.Webhook, error)
 	DeleteByID(ctx context.Context, tenantID, webhookID string) error
 	RegenerateSecret(ctx context.Context, tenantID, webhookID string) (*webhook.Webhook, error)
 	TestWebhook(ctx context.Context, tenantID, webhookID, method string, headers map[string]string, body json.RawMessage) (*webhook.TestResult, error)
 	GetEventHistory(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*webhook.Event, int, error)
+	GetDeliveries(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*webhook.DeliveryAttempt, int, error)
+	ListCaptures(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*webhook.WebhookCapture, int, error)
 }
 
 // NewWebhookManagementHandler creates a new webhook management handler
@@ -61,6 +65,10 @@ type UpdateWebhookRequest struct {
 	Description string `json:"description"`
 	Priority    int    `json:"priority" validate:"min=0,max=3"`
 	Enabled     bool   `json:"enabled"`
+	// ResponseConfig optionally overrides the responses returned for passed, filtered and error outcomes
+	ResponseConfig *webhook.ResponseConfig `json:"responseConfig,omitempty"`
+	// CaptureConfig optionally turns request capture on or off
+	CaptureConfig *webhook.CaptureConfig `json:"captureConfig,omitempty"`
 }
 
 // TestWebhookRequest represents the request to test a webhook
@@ -203,6 +211,16 @@ func (h *WebhookManagementHandler) Update(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if err := input.ResponseConfig.Validate(); err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
+	if err := input.CaptureConfig.Validate(); err != nil {
+		_ = response.BadRequest(w, err.Error())
+		return
+	}
+
 	wh, err := h.service.Update(
 		r.Context(),
 		tenantID,
@@ -222,6 +240,22 @@ func (h *WebhookManagementHandler) Update(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if input.ResponseConfig != nil {
+		wh, err = h.service.UpdateResponseConfig(r.Context(), tenantID, webhookID, input.ResponseConfig)
+		if err != nil {
+			_ = response.InternalError(w, "failed to update webhook response config")
+			return
+		}
+	}
+
+	if input.CaptureConfig != nil {
+		wh, err = h.service.UpdateCaptureConfig(r.Context(), tenantID, webhookID, input.CaptureConfig)
+		if err != nil {
+			_ = response.InternalError(w, "failed to update webhook capture config")
+			return
+		}
+	}
+
 	_ = response.OK(w, map[string]any{
 		"data": wh,
 	})
@@ -350,3 +384,65 @@ func (h *WebhookManagementHandler) GetEventHistory(w http.ResponseWriter, r *htt
 		"offset": offset,
 	})
 }
+
+// GetDeliveries retrieves the delivery log of a webhook: every delivery
+// attempt of its events with the outcome and the next retry time
+func (h *WebhookManagementHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	webhookID := chi.URLParam(r, "id")
+
+	limit, _ := validation.ParsePaginationLimit(
+		r.URL.Query().Get("limit"),
+		validation.DefaultPaginationLimit,
+		validation.MaxPaginationLimit,
+	)
+	offset, _ := validation.ParsePaginationOffset(r.URL.Query().Get("offset"))
+
+	deliveries, total, err := h.service.GetDeliveries(r.Context(), tenantID, webhookID, limit, offset)
+	if err != nil {
+		if err == webhook.ErrNotFound {
+			_ = response.NotFound(w, "webhook not found")
+			return
+		}
+		_ = response.InternalError(w, "failed to get deliveries")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data":   deliveries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ListCaptures retrieves the unexpired raw requests captured for a webhook,
+// newest first
+func (h *WebhookManagementHandler) ListCaptures(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	webhookID := chi.URLParam(r, "id")
+
+	limit, _ := validation.ParsePaginationLimit(
+		r.URL.Query().Get("limit"),
+		validation.DefaultPaginationLimit,
+		validation.MaxPaginationLimit,
+	)
+	offset, _ := validation.ParsePaginationOffset(r.URL.Query().Get("offset"))
+
+	captures, total, err := h.service.ListCaptures(r.Context(), tenantID, webhookID, limit, offset)
+	if err != nil {
+		if err == webhook.ErrNotFound {
+			_ = response.NotFound(w, "webhook not found")
+			return
+		}
+		_ = response.InternalError(w, "failed to list captures")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data":   captures,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
@@ -57,6 +57,22 @@ func (m *MockWebhookManagementService) Update(ctx context.Context, tenantID, web
 	return args.Get(0).(*webhook.Webhook), args.Error(1)
 }
 
+func (m *MockWebhookManagementService) UpdateResponseConfig(ctx context.Context, tenantID, webhookID string, config *webhook.ResponseConfig) (*webhook.Webhook, error) {
+	args := m.Called(ctx, tenantID, webhookID, config)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookManagementService) UpdateCaptureConfig(ctx context.Context, tenantID, webhookID string, config *webhook.CaptureConfig) (*webhook.Webhook, error) {
+	args := m.Called(ctx, tenantID, webhookID, config)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
 func (m *MockWebhookManagementService) DeleteByID(ctx context.Context, tenantID, webhookID string) error {
 	args := m.Called(ctx, tenantID, webhookID)
 	return args.Error(0)
@@ -86,6 +102,22 @@ func (m *MockWebhookManagementService) GetEventHistory(ctx context.Context, tena
 	return args.Get(0).([]*webhook.Event), args.Int(1), args.Error(2)
 }
 
+func (m *MockWebhookManagementService) GetDeliveries(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*webhook.DeliveryAttempt, int, error) {
+	args := m.Called(ctx, tenantID, webhookID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*webhook.DeliveryAttempt), args.Int(1), args.Error(2)
+}
+
+func (m *MockWebhookManagementService) ListCaptures(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*webhook.WebhookCapture, int, error) {
+	args := m.Called(ctx, tenantID, webhookID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*webhook.WebhookCapture), args.Int(1), args.Error(2)
+}
+
 func newTestWebhookManagementHandler() (*WebhookManagementHandler, *MockWebhookManagementService) {
 	mockService := new(MockWebhookManagementService)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -552,6 +584,43 @@ func TestUpdateWebhook(t *testing.T) {
 				assert.Contains(t, body["error"], "webhook not found")
 			},
 		},
+		{
+			name:      "enables request capture",
+			tenantID:  "tenant-123",
+			webhookID: "webhook-1",
+			requestBody: map[string]interface{}{
+				"name":          "Updated Webhook",
+				"priority":      1,
+				"captureConfig": map[string]interface{}{"enabled": true, "ttlSeconds": 3600, "redactHeaders": []string{"X-Shared-Secret"}},
+			},
+			setupMock: func(mockService *MockWebhookManagementService) {
+				mockService.On("Update", mock.Anything, "tenant-123", "webhook-1", "Updated Webhook", "", "", 1, false).
+					Return(updatedWebhook, nil)
+				mockService.On("UpdateCaptureConfig", mock.Anything, "tenant-123", "webhook-1", &webhook.CaptureConfig{
+					Enabled:       true,
+					TTLSeconds:    3600,
+					RedactHeaders: []string{"X-Shared-Secret"},
+				}).Return(updatedWebhook, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body, "data")
+			},
+		},
+		{
+			name:      "capture TTL too long",
+			tenantID:  "tenant-123",
+			webhookID: "webhook-1",
+			requestBody: map[string]interface{}{
+				"name":          "Updated Webhook",
+				"captureConfig": map[string]interface{}{"enabled": true, "ttlSeconds": 30 * 24 * 3600},
+			},
+			setupMock:      func(mockService *MockWebhookManagementService) {},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "capture TTL")
+			},
+		},
 		{
 			name:           "invalid json body",
 			tenantID:       "tenant-123",
@@ -1079,3 +1148,106 @@ func TestGetEventHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeliveries(t *testing.T) {
+	nextRetryAt := time.Now().Add(time.Minute)
+	deliveries := []*webhook.DeliveryAttempt{
+		{
+			ID:          "attempt-1",
+			WebhookID:   "webhook-1",
+			EventID:     "event-1",
+			Attempt:     1,
+			Status:      webhook.DeliveryStatusFailed,
+			NextRetryAt: &nextRetryAt,
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := newTestWebhookManagementHandler()
+		mockService.On("GetDeliveries", mock.Anything, "tenant-123", "webhook-1", 10, 0).
+			Return(deliveries, 1, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/webhooks/webhook-1/deliveries?limit=10", nil)
+		req = addTenantContext(req, "tenant-123")
+		req = addRouteParam(req, "id", "webhook-1")
+		w := httptest.NewRecorder()
+
+		handler.GetDeliveries(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, float64(1), body["total"])
+		data := body["data"].([]interface{})
+		require.Len(t, data, 1)
+		assert.Equal(t, "failed", data[0].(map[string]interface{})["status"])
+		assert.NotNil(t, data[0].(map[string]interface{})["nextRetryAt"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		handler, mockService := newTestWebhookManagementHandler()
+		mockService.On("GetDeliveries", mock.Anything, "tenant-123", "webhook-999", 20, 0).
+			Return(nil, 0, webhook.ErrNotFound)
+
+		req := httptest.NewRequest("GET", "/api/v1/webhooks/webhook-999/deliveries", nil)
+		req = addTenantContext(req, "tenant-123")
+		req = addRouteParam(req, "id", "webhook-999")
+		w := httptest.NewRecorder()
+
+		handler.GetDeliveries(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestListCaptures(t *testing.T) {
+	captures := []*webhook.WebhookCapture{
+		{
+			ID:        "capture-1",
+			WebhookID: "webhook-1",
+			Method:    "POST",
+			Headers:   webhook.CaptureHeaders{"Authorization": {webhook.CaptureRedactedValue}},
+			Body:      []byte(`{"event": "push"}`),
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := newTestWebhookManagementHandler()
+		mockService.On("ListCaptures", mock.Anything, "tenant-123", "webhook-1", 10, 0).
+			Return(captures, 1, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/webhooks/webhook-1/captures?limit=10", nil)
+		req = addTenantContext(req, "tenant-123")
+		req = addRouteParam(req, "id", "webhook-1")
+		w := httptest.NewRecorder()
+
+		handler.ListCaptures(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, float64(1), body["total"])
+		data := body["data"].([]interface{})
+		require.Len(t, data, 1)
+		capture := data[0].(map[string]interface{})
+		assert.Equal(t, `{"event": "push"}`, capture["body"])
+		assert.Equal(t, []interface{}{"[REDACTED]"}, capture["headers"].(map[string]interface{})["Authorization"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("webhook not found", func(t *testing.T) {
+		handler, mockService := newTestWebhookManagementHandler()
+		mockService.On("ListCaptures", mock.Anything, "tenant-123", "webhook-999", 20, 0).
+			Return(nil, 0, webhook.ErrNotFound)
+
+		req := httptest.NewRequest("GET", "/api/v1/webhooks/webhook-999/captures", nil)
+		req = addTenantContext(req, "tenant-123")
+		req = addRouteParam(req, "id", "webhook-999")
+		w := httptest.NewRecorder()
+
+		handler.ListCaptures(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
@@ -107,3 +108,35 @@ func (h *WebhookReplayHandler) BatchReplayEvents(w http.ResponseWriter, r *http.
 
 	_ = response.OK(w, results)
 }
+
+// ReplayCapture re-triggers a webhook's workflow with a captured request
+// POST /api/v1/webhooks/{webhookID}/captures/{captureID}/replay
+func (h *WebhookReplayHandler) ReplayCapture(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	webhookID := chi.URLParam(r, "webhookID")
+	captureID := chi.URLParam(r, "captureID")
+
+	var result *webhook.ReplayResult
+
+	// Wrap replay operation with tracing
+	_, _ = tracing.TraceWebhookReplay(r.Context(), tenantID, webhookID, captureID, func(ctx context.Context) (string, error) {
+		result = h.replayService.ReplayCapture(ctx, tenantID, webhookID, captureID)
+		if !result.Success {
+			return "", fmt.Errorf("replay failed: %s", result.Error)
+		}
+		return result.ExecutionID, nil
+	})
+
+	if !result.Success {
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(result.Error, "capture not found") {
+			status = http.StatusNotFound
+		} else if result.Error == "webhook is disabled" {
+			status = http.StatusConflict
+		}
+		_ = response.JSON(w, status, result)
+		return
+	}
+
+	_ = response.OK(w, result)
+}
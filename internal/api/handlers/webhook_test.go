@@ -47,9 +47,27 @@ func (m *MockWebhookService) GetByWorkflowAndWebhookID(ctx context.Context, work
 	return args.Get(0).(*webhook.Webhook), args.Error(1)
 }
 
-func (m *MockWebhookService) VerifySignature(payload []byte, signature string, secret string) bool {
-	args := m.Called(payload, signature, secret)
-	return args.Bool(0)
+func (m *MockWebhookService) VerifyRequest(wh *webhook.Webhook, header http.Header, body []byte) error {
+	args := m.Called(wh, header, body)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ValidatePayload(ctx context.Context, wh *webhook.Webhook, body []byte) ([]webhook.SchemaViolation, error) {
+	args := m.Called(ctx, wh, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhook.SchemaViolation), args.Error(1)
+}
+
+func (m *MockWebhookService) ClaimDelivery(ctx context.Context, wh *webhook.Webhook, deliveryID string) (bool, error) {
+	args := m.Called(ctx, wh, deliveryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookService) ReleaseDelivery(ctx context.Context, wh *webhook.Webhook, deliveryID string) error {
+	args := m.Called(ctx, wh, deliveryID)
+	return args.Error(0)
 }
 
 func (m *MockWebhookService) LogEvent(ctx context.Context, event *webhook.WebhookEvent) error {
@@ -57,6 +75,14 @@ func (m *MockWebhookService) LogEvent(ctx context.Context, event *webhook.Webhoo
 	return args.Error(0)
 }
 
+func (m *MockWebhookService) EvaluateFilters(ctx context.Context, webhookID string, payload map[string]interface{}) (*webhook.FilterResult, error) {
+	args := m.Called(ctx, webhookID, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.FilterResult), args.Error(1)
+}
+
 func newTestWebhookHandler() (*WebhookHandler, *MockWebhookWorkflowService, *MockWebhookService) {
 	mockWorkflowService := new(MockWebhookWorkflowService)
 	mockWebhookService := new(MockWebhookService)
@@ -97,6 +123,10 @@ func createTestWebhookConfigWithSignature() *webhook.Webhook {
 	return wh
 }
 
+func passedFilterResult() *webhook.FilterResult {
+	return &webhook.FilterResult{Passed: true, Reason: "no filters configured"}
+}
+
 func createTestExecution() *workflow.Execution {
 	return &workflow.Execution{
 		ID:         "exec-123",
@@ -135,6 +165,8 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfig()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(createTestExecution(), nil)
 				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
@@ -162,8 +194,10 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfigWithSignature()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
-				mwhs.On("VerifySignature", []byte(`{"event": "test"}`), "sha256=valid-signature", "secret-key").
-					Return(true)
+				mwhs.On("VerifyRequest", webhookConfig, mock.Anything, []byte(`{"event": "test"}`)).
+					Return(nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(createTestExecution(), nil)
 				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
@@ -184,8 +218,10 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfigWithSignature()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
-				mwhs.On("VerifySignature", []byte(`{"event": "push"}`), "sha256=github-signature", "secret-key").
-					Return(true)
+				mwhs.On("VerifyRequest", webhookConfig, mock.Anything, []byte(`{"event": "push"}`)).
+					Return(nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(createTestExecution(), nil)
 				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
@@ -229,8 +265,8 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfigWithSignature()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
-				mwhs.On("VerifySignature", []byte(`{"event": "test"}`), "sha256=invalid-signature", "secret-key").
-					Return(false)
+				mwhs.On("VerifyRequest", webhookConfig, mock.Anything, []byte(`{"event": "test"}`)).
+					Return(webhook.ErrInvalidSignature)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "invalid signature",
@@ -245,9 +281,9 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfigWithSignature()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
-				// VerifySignature will be called with empty signature
-				mwhs.On("VerifySignature", []byte(`{"event": "test"}`), "", "secret-key").
-					Return(false)
+				// VerifyRequest will be called with no signature header
+				mwhs.On("VerifyRequest", webhookConfig, mock.Anything, []byte(`{"event": "test"}`)).
+					Return(webhook.ErrInvalidSignature)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "invalid signature",
@@ -261,6 +297,8 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfig()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(nil, workflow.ErrNotFound)
 			},
@@ -276,6 +314,8 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfig()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(nil, errors.New("execution failed"))
 				// LogEvent should be called for failed execution
@@ -294,6 +334,8 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfig()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(createTestExecution(), nil)
 				// LogEvent fails but request should still succeed
@@ -315,6 +357,155 @@ func TestWebhookHandler_Handle(t *testing.T) {
 				webhookConfig := createTestWebhookConfig()
 				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
 					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
+				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
+					Return(createTestExecution(), nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:       "filtered out - default response",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "ignored"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(createTestWebhookConfig(), nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", map[string]interface{}{"event": "ignored"}).
+					Return(&webhook.FilterResult{Passed: false, Reason: "no logic groups passed: group 0"}, nil)
+				mwhs.On("LogEvent", mock.Anything, mock.MatchedBy(func(e *webhook.WebhookEvent) bool {
+					return e.Status == webhook.EventStatusFiltered && e.FilteredReason != nil && e.ErrorMessage == nil
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var resp map[string]interface{}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "filtered", resp["status"])
+				assert.Equal(t, "no logic groups passed: group 0", resp["reason"])
+			},
+		},
+		{
+			name:       "filtered out - custom acknowledgment response",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"type": "event_callback"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.ResponseConfig = &webhook.ResponseConfig{
+					Filtered: &webhook.ResponseTemplate{StatusCode: http.StatusOK, Body: "ok", ContentType: "text/plain"},
+				}
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(&webhook.FilterResult{Passed: false, Reason: "filtered"}, nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, "ok", rr.Body.String())
+				assert.Equal(t, "text/plain", rr.Header().Get("Content-Type"))
+			},
+		},
+		{
+			name:       "passed - custom response template",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "test"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.ResponseConfig = &webhook.ResponseConfig{
+					Passed: &webhook.ResponseTemplate{StatusCode: http.StatusOK, Body: `{"ok":true,"id":"{{execution_id}}"}`},
+				}
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
+				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
+					Return(createTestExecution(), nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true,"id":"exec-123"}`,
+		},
+		{
+			name:       "filter evaluation error uses error response",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "test"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.ResponseConfig = &webhook.ResponseConfig{
+					Error: &webhook.ResponseTemplate{StatusCode: http.StatusOK, Body: `{"error":"{{error}}"}`},
+				}
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(nil, errors.New("database error"))
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"error":"failed to evaluate filters"}`,
+		},
+		{
+			name:       "payload not matching schema is rejected",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": 42}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				schema := json.RawMessage(`{"type":"object","properties":{"event":{"type":"string"}}}`)
+				webhookConfig.PayloadSchema = &schema
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ValidatePayload", mock.Anything, webhookConfig, []byte(`{"event": 42}`)).
+					Return([]webhook.SchemaViolation{{Path: "$.event", Message: "expected string, got number"}}, nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"$.event":"expected string, got number"`,
+		},
+		{
+			name:       "payload not matching event type is rejected",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "push"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				eventType := "order.created"
+				webhookConfig.EventType = &eventType
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ValidatePayload", mock.Anything, webhookConfig, []byte(`{"event": "push"}`)).
+					Return([]webhook.SchemaViolation{{Path: "$.order_id", Message: "required property missing"}}, nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"$.order_id":"required property missing"`,
+		},
+		{
+			name:       "payload matching schema is executed",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "push"}`,
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				schema := json.RawMessage(`{"type":"object","properties":{"event":{"type":"string"}}}`)
+				webhookConfig.PayloadSchema = &schema
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ValidatePayload", mock.Anything, webhookConfig, []byte(`{"event": "push"}`)).
+					Return(nil, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
 				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
 					Return(createTestExecution(), nil)
 				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
@@ -322,6 +513,69 @@ func TestWebhookHandler_Handle(t *testing.T) {
 			},
 			expectedStatus: http.StatusAccepted,
 		},
+		{
+			name:       "first delivery is claimed and executed",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "push"}`,
+			headers:    map[string]string{"X-GitHub-Delivery": "delivery-1"},
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.IdempotencyHeader = "X-GitHub-Delivery"
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ClaimDelivery", mock.Anything, webhookConfig, "delivery-1").
+					Return(true, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
+				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
+					Return(createTestExecution(), nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:       "duplicate delivery does not execute",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "push"}`,
+			headers:    map[string]string{"X-GitHub-Delivery": "delivery-1"},
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.IdempotencyHeader = "X-GitHub-Delivery"
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ClaimDelivery", mock.Anything, webhookConfig, "delivery-1").
+					Return(false, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"duplicate"`,
+		},
+		{
+			name:       "failed execution releases the delivery for retry",
+			workflowID: "workflow-123",
+			webhookID:  "webhook-123",
+			body:       `{"event": "push"}`,
+			headers:    map[string]string{"X-GitHub-Delivery": "delivery-1"},
+			setupMock: func(mws *MockWebhookWorkflowService, mwhs *MockWebhookService) {
+				webhookConfig := createTestWebhookConfig()
+				webhookConfig.IdempotencyHeader = "X-GitHub-Delivery"
+				mwhs.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").
+					Return(webhookConfig, nil)
+				mwhs.On("ClaimDelivery", mock.Anything, webhookConfig, "delivery-1").
+					Return(true, nil)
+				mwhs.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).
+					Return(passedFilterResult(), nil)
+				mws.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.AnythingOfType("[]uint8")).
+					Return(nil, errors.New("queue unavailable"))
+				mwhs.On("ReleaseDelivery", mock.Anything, webhookConfig, "delivery-1").
+					Return(nil)
+				mwhs.On("LogEvent", mock.Anything, mock.AnythingOfType("*webhook.WebhookEvent")).
+					Return(nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -356,6 +610,91 @@ func TestWebhookHandler_Handle(t *testing.T) {
 	}
 }
 
+// MockCapturingWebhookService also captures raw requests
+type MockCapturingWebhookService struct {
+	MockWebhookService
+}
+
+func (m *MockCapturingWebhookService) CaptureRequest(ctx context.Context, wh *webhook.Webhook, r *http.Request, body []byte) error {
+	args := m.Called(ctx, wh, r, body)
+	return args.Error(0)
+}
+
+func TestWebhookHandler_Handle_CapturesRequest(t *testing.T) {
+	t.Run("captures before signature verification", func(t *testing.T) {
+		mockWebhookService := new(MockCapturingWebhookService)
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		handler := NewWebhookHandler(new(MockWebhookWorkflowService), mockWebhookService, logger)
+
+		wh := createTestWebhookConfigWithSignature()
+		wh.CaptureConfig = &webhook.CaptureConfig{Enabled: true}
+		body := []byte(`{"event": "push"}`)
+
+		mockWebhookService.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").Return(wh, nil)
+		mockWebhookService.On("CaptureRequest", mock.Anything, wh, mock.Anything, body).Return(nil)
+		mockWebhookService.On("VerifyRequest", wh, mock.Anything, body).Return(webhook.ErrInvalidSignature)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/workflow-123/webhook-123", bytes.NewReader(body))
+		req = addWebhookURLParams(req, map[string]string{"workflowID": "workflow-123", "webhookID": "webhook-123"})
+		rr := httptest.NewRecorder()
+
+		handler.Handle(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockWebhookService.AssertExpectations(t)
+	})
+
+	t.Run("capture failure does not fail the delivery", func(t *testing.T) {
+		mockWorkflowService := new(MockWebhookWorkflowService)
+		mockWebhookService := new(MockCapturingWebhookService)
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		handler := NewWebhookHandler(mockWorkflowService, mockWebhookService, logger)
+
+		wh := createTestWebhookConfig()
+		wh.CaptureConfig = &webhook.CaptureConfig{Enabled: true}
+
+		mockWebhookService.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").Return(wh, nil)
+		mockWebhookService.On("CaptureRequest", mock.Anything, wh, mock.Anything, mock.Anything).Return(errors.New("db down"))
+		mockWebhookService.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).Return(passedFilterResult(), nil)
+		mockWebhookService.On("LogEvent", mock.Anything, mock.Anything).Return(nil)
+		mockWorkflowService.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.Anything).Return(createTestExecution(), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/workflow-123/webhook-123", bytes.NewReader([]byte(`{}`)))
+		req = addWebhookURLParams(req, map[string]string{"workflowID": "workflow-123", "webhookID": "webhook-123"})
+		rr := httptest.NewRecorder()
+
+		handler.Handle(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockWebhookService.AssertExpectations(t)
+		mockWorkflowService.AssertExpectations(t)
+	})
+
+	t.Run("capture disabled", func(t *testing.T) {
+		mockWorkflowService := new(MockWebhookWorkflowService)
+		mockWebhookService := new(MockCapturingWebhookService)
+		logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+		handler := NewWebhookHandler(mockWorkflowService, mockWebhookService, logger)
+
+		wh := createTestWebhookConfig()
+		wh.CaptureConfig = &webhook.CaptureConfig{Enabled: false}
+
+		mockWebhookService.On("GetByWorkflowAndWebhookID", mock.Anything, "workflow-123", "webhook-123").Return(wh, nil)
+		mockWebhookService.On("EvaluateFilters", mock.Anything, "webhook-123", mock.Anything).Return(passedFilterResult(), nil)
+		mockWebhookService.On("LogEvent", mock.Anything, mock.Anything).Return(nil)
+		mockWorkflowService.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook", mock.Anything).Return(createTestExecution(), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/workflow-123/webhook-123", bytes.NewReader([]byte(`{}`)))
+		req = addWebhookURLParams(req, map[string]string{"workflowID": "workflow-123", "webhookID": "webhook-123"})
+		rr := httptest.NewRecorder()
+
+		handler.Handle(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockWebhookService.AssertNotCalled(t, "CaptureRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 // ============================================================================
 // Helper Function Tests
 // ============================================================================
@@ -3,6 +3,8 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -47,6 +49,30 @@ func (h *WebSocketHandler) HandleConnection(w http.ResponseWriter, r *http.Reque
 
 	tenantID := middleware.GetTenantID(r)
 
+	// Reconnecting clients pass the last sequence they saw to replay missed events
+	var since *uint64
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		seq, err := strconv.ParseUint(sinceStr, 10, 64)
+		if err != nil {
+			http.Error(w, "since must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		since = &seq
+	}
+
+	// Clients can limit execution events to status classes, e.g. ?status=failed
+	statusFilter := make(map[string]bool)
+	if statuses := r.URL.Query().Get("status"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.TrimSpace(status)
+			if !ws.ValidStatusClass(status) {
+				http.Error(w, "status must be running, completed or failed", http.StatusBadRequest)
+				return
+			}
+			statusFilter[status] = true
+		}
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -62,13 +88,14 @@ func (h *WebSocketHandler) HandleConnection(w http.ResponseWriter, r *http.Reque
 		Hub:           h.hub,
 		Send:          make(chan []byte, 256),
 		Subscriptions: make(map[string]bool),
+		StatusFilter:  statusFilter,
 	}
 
 	// Register client with hub
 	h.hub.Register <- client
 
 	// Parse subscription parameters
-	h.handleSubscriptions(r, client)
+	h.handleSubscriptions(r, client, since)
 
 	// Start client pumps
 	go client.WritePump()
@@ -81,8 +108,10 @@ func (h *WebSocketHandler) HandleConnection(w http.ResponseWriter, r *http.Reque
 	)
 }
 
-// handleSubscriptions processes subscription query parameters
-func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Client) {
+// handleSubscriptions processes subscription query parameters. Rooms are
+// scoped to the client's tenant, so IDs from other tenants receive nothing.
+// With since set, each room's events after that sequence are replayed first.
+func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Client, since *uint64) {
 	// Get subscription parameters from query string
 	executionID := r.URL.Query().Get("execution_id")
 	workflowID := r.URL.Query().Get("workflow_id")
@@ -90,8 +119,7 @@ func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Clien
 
 	// Subscribe to specific execution
 	if executionID != "" {
-		room := "execution:" + executionID
-		h.hub.SubscribeClient(client, room)
+		h.subscribe(client, ws.ExecutionRoom(client.TenantID, executionID), since)
 		h.logger.Info("client subscribed to execution",
 			"client_id", client.ID,
 			"execution_id", executionID,
@@ -100,8 +128,7 @@ func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Clien
 
 	// Subscribe to workflow updates
 	if workflowID != "" {
-		room := "workflow:" + workflowID
-		h.hub.SubscribeClient(client, room)
+		h.subscribe(client, ws.WorkflowRoom(client.TenantID, workflowID), since)
 		h.logger.Info("client subscribed to workflow",
 			"client_id", client.ID,
 			"workflow_id", workflowID,
@@ -110,8 +137,7 @@ func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Clien
 
 	// Subscribe to tenant-wide updates (for dashboard)
 	if subscribeTenant == "true" {
-		room := "tenant:" + client.TenantID
-		h.hub.SubscribeClient(client, room)
+		h.subscribe(client, ws.TenantRoom(client.TenantID), since)
 		h.logger.Info("client subscribed to tenant",
 			"client_id", client.ID,
 			"tenant_id", client.TenantID,
@@ -119,6 +145,22 @@ func (h *WebSocketHandler) handleSubscriptions(r *http.Request, client *ws.Clien
 	}
 }
 
+func (h *WebSocketHandler) subscribe(client *ws.Client, room string, since *uint64) {
+	var err error
+	if since != nil {
+		err = h.hub.SubscribeClientSince(client, room, *since)
+	} else {
+		err = h.hub.SubscribeClient(client, room)
+	}
+	if err != nil {
+		h.logger.Warn("websocket subscription rejected",
+			"error", err,
+			"client_id", client.ID,
+			"room", room,
+		)
+	}
+}
+
 // HandleExecutionConnection is a convenience endpoint for execution-specific subscriptions
 func (h *WebSocketHandler) HandleExecutionConnection(w http.ResponseWriter, r *http.Request) {
 	executionID := chi.URLParam(r, "executionID")
@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/gorax/gorax/internal/api/middleware"
 	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/pagination"
 	"github.com/gorax/gorax/internal/validation"
 	"github.com/gorax/gorax/internal/workflow"
 )
@@ -89,8 +93,8 @@ func (h *WorkflowHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	wf, err := h.service.Create(r.Context(), tenantID, user.ID, input)
 	if err != nil {
-		if _, ok := err.(*workflow.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		_ = response.InternalError(w, "failed to create workflow")
@@ -122,7 +126,7 @@ func (h *WorkflowHandler) Get(w http.ResponseWriter, r *http.Request) {
 	wf, err := h.service.GetByID(r.Context(), tenantID, workflowID)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
 		_ = response.InternalError(w, "failed to get workflow")
@@ -162,11 +166,11 @@ func (h *WorkflowHandler) Update(w http.ResponseWriter, r *http.Request) {
 	wf, err := h.service.Update(r.Context(), tenantID, workflowID, input)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
-		if _, ok := err.(*workflow.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		_ = response.InternalError(w, "failed to update workflow")
@@ -198,7 +202,7 @@ func (h *WorkflowHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	err := h.service.Delete(r.Context(), tenantID, workflowID)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
 		_ = response.InternalError(w, "failed to delete workflow")
@@ -210,16 +214,19 @@ func (h *WorkflowHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // Execute triggers a workflow execution
 // @Summary Execute workflow
-// @Description Triggers a manual execution of a workflow
+// @Description Triggers a manual execution of a workflow. With an Idempotency-Key header, a repeated request with the same body within 24 hours returns the original execution.
 // @Tags Workflows
 // @Accept json
 // @Produce json
 // @Param workflowID path string true "Workflow ID"
+// @Param Idempotency-Key header string false "Client-generated key that makes retries safe"
 // @Param triggerData body object false "Optional trigger data"
 // @Security TenantID
 // @Security UserID
 // @Success 202 {object} map[string]interface{} "Execution started"
+// @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 404 {object} map[string]string "Workflow not found"
+// @Failure 409 {object} map[string]string "Idempotency key reused with a different request or still in progress"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workflows/{workflowID}/execute [post]
 func (h *WorkflowHandler) Execute(w http.ResponseWriter, r *http.Request) {
@@ -232,16 +239,24 @@ func (h *WorkflowHandler) Execute(w http.ResponseWriter, r *http.Request) {
 		json.NewDecoder(r.Body).Decode(&triggerData)
 	}
 
-	execution, err := h.service.Execute(r.Context(), tenantID, workflowID, "manual", triggerData)
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	execution, replayed, err := h.service.ExecuteIdempotent(r.Context(), tenantID, workflowID, idempotencyKey, "manual", triggerData)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
+			return
+		}
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		_ = response.InternalError(w, "failed to execute workflow")
 		return
 	}
 
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
 	_ = response.JSON(w, http.StatusAccepted, map[string]any{
 		"data": execution,
 	})
@@ -259,6 +274,21 @@ func (h *WorkflowHandler) ListExecutions(w http.ResponseWriter, r *http.Request)
 	)
 	offset, _ := validation.ParsePaginationOffset(r.URL.Query().Get("offset"))
 
+	page, cursorMode, err := cursorPage(r)
+	if err != nil {
+		_ = response.WriteError(w, err)
+		return
+	}
+	if cursorMode {
+		executions, err := h.service.ListExecutionsPage(r.Context(), tenantID, workflowID, page)
+		if err != nil {
+			_ = response.InternalError(w, "failed to list executions")
+			return
+		}
+		_ = response.OK(w, pagination.NewPage(executions, page.Limit, executionPosition))
+		return
+	}
+
 	executions, err := h.service.ListExecutions(r.Context(), tenantID, workflowID, limit, offset)
 	if err != nil {
 		_ = response.InternalError(w, "failed to list executions")
@@ -320,11 +350,11 @@ func (h *WorkflowHandler) DryRun(w http.ResponseWriter, r *http.Request) {
 	result, err := h.service.DryRun(r.Context(), tenantID, workflowID, input.TestData)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
-		if _, ok := err.(*workflow.ValidationError); ok {
-			_ = response.BadRequest(w, err.Error())
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
 			return
 		}
 		_ = response.InternalError(w, "failed to perform dry-run")
@@ -336,6 +366,88 @@ func (h *WorkflowHandler) DryRun(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Simulate runs a workflow in memory with mocked node outputs
+// @Summary Simulate workflow
+// @Description Runs a workflow against test trigger data without persisting an execution. Nodes listed in mocks return the given output; other actions are stubbed.
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param workflowID path string true "Workflow ID"
+// @Param input body workflow.SimulateInput true "Trigger data and mocked node outputs"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} map[string]interface{} "Simulation result"
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Workflow not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workflows/{workflowID}/simulate [post]
+func (h *WorkflowHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	workflowID := chi.URLParam(r, "workflowID")
+
+	var input workflow.SimulateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	result, err := h.service.SimulateExecution(r.Context(), tenantID, workflowID, input.TriggerData, input.Mocks)
+	if err != nil {
+		if err == workflow.ErrNotFound {
+			_ = response.WriteError(w, errWorkflowNotFound)
+			return
+		}
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to simulate workflow", "error", err, "workflow_id", workflowID)
+		_ = response.InternalError(w, "failed to simulate workflow")
+		return
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": result,
+	})
+}
+
+// Lint reports anti-patterns in a workflow definition
+// @Summary Lint workflow definition
+// @Description Returns non-blocking advice about a workflow definition, such as HTTP nodes without retries or timeouts, secrets read from env variables, conditions without a false branch and branches that are never taken. The same findings are returned in lint_warnings when a workflow is saved.
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param input body workflow.LintInput true "Workflow definition"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} map[string]interface{} "Lint findings"
+// @Failure 400 {object} map[string]string "Invalid request or definition"
+// @Router /workflows/lint [post]
+func (h *WorkflowHandler) Lint(w http.ResponseWriter, r *http.Request) {
+	var input workflow.LintInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || len(input.Definition) == 0 {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	findings, err := h.service.Lint(input.Definition)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		_ = response.InternalError(w, "failed to lint workflow")
+		return
+	}
+	if findings == nil {
+		findings = []workflow.LintFinding{}
+	}
+
+	_ = response.OK(w, map[string]any{
+		"data": findings,
+	})
+}
+
 // ListVersions retrieves all versions for a workflow
 func (h *WorkflowHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.GetTenantID(r)
@@ -345,7 +457,7 @@ func (h *WorkflowHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
 	_, err := h.service.GetByID(r.Context(), tenantID, workflowID)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
 		_ = response.InternalError(w, "failed to get workflow")
@@ -379,7 +491,7 @@ func (h *WorkflowHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
 	_, err = h.service.GetByID(r.Context(), tenantID, workflowID)
 	if err != nil {
 		if err == workflow.ErrNotFound {
-			_ = response.NotFound(w, "workflow not found")
+			_ = response.WriteError(w, errWorkflowNotFound)
 			return
 		}
 		_ = response.InternalError(w, "failed to get workflow")
@@ -427,3 +539,78 @@ func (h *WorkflowHandler) RestoreVersion(w http.ResponseWriter, r *http.Request)
 		"data": restoredWorkflow,
 	})
 }
+
+// maxWorkflowBundleBytes bounds the size of an imported workflow bundle
+const maxWorkflowBundleBytes = 5 * 1024 * 1024
+
+// Export returns a workflow as a portable JSON bundle
+// @Summary Export workflow
+// @Description Exports a workflow with its referenced event types and a manifest of required credentials and env names. Secrets are never included.
+// @Tags Workflows
+// @Produce json
+// @Param workflowID path string true "Workflow ID"
+// @Security TenantID
+// @Security UserID
+// @Success 200 {object} workflow.WorkflowBundle "Workflow bundle"
+// @Failure 404 {object} map[string]string "Workflow not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workflows/{workflowID}/export [get]
+func (h *WorkflowHandler) Export(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	workflowID := chi.URLParam(r, "workflowID")
+
+	bundle, err := h.service.ExportWorkflow(r.Context(), tenantID, workflowID)
+	if err != nil {
+		if errors.Is(err, workflow.ErrNotFound) {
+			_ = response.WriteError(w, errWorkflowNotFound)
+			return
+		}
+		h.logger.Error("failed to export workflow", "error", err, "workflow_id", workflowID)
+		_ = response.InternalError(w, "failed to export workflow")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=workflow-%s.json", workflowID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bundle)
+}
+
+// Import creates a workflow from a bundle produced by Export
+// @Summary Import workflow
+// @Description Creates a workflow from an exported bundle. The workflow gets a new ID; unmet prerequisites are returned in reference_warnings.
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param bundle body workflow.WorkflowBundle true "Workflow bundle"
+// @Security TenantID
+// @Security UserID
+// @Success 201 {object} map[string]interface{} "Imported workflow"
+// @Failure 400 {object} map[string]string "Invalid bundle or validation error"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workflows/import [post]
+func (h *WorkflowHandler) Import(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.GetTenantID(r)
+	user := middleware.GetUser(r)
+
+	bundle, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWorkflowBundleBytes))
+	if err != nil {
+		_ = response.BadRequest(w, "invalid request body")
+		return
+	}
+
+	wf, err := h.service.ImportWorkflow(r.Context(), tenantID, user.ID, bundle)
+	if err != nil {
+		if apiErr := domainError(err); apiErr != nil {
+			_ = response.WriteError(w, apiErr)
+			return
+		}
+		h.logger.Error("failed to import workflow", "error", err, "tenant_id", tenantID)
+		_ = response.InternalError(w, "failed to import workflow")
+		return
+	}
+
+	_ = response.Created(w, map[string]any{
+		"data": wf,
+	})
+}
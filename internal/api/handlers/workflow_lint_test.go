@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestWorkflowHandler_Lint(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewWorkflowHandler(workflow.NewService(nil, logger), logger)
+
+	t.Run("returns findings", func(t *testing.T) {
+		body := `{"definition": {"nodes": [
+			{"id": "trigger", "type": "trigger:webhook", "data": {"config": {}}},
+			{"id": "check", "type": "control:if", "data": {"config": {"condition": "${trigger.ok}"}}}
+		], "edges": [{"id": "e1", "source": "trigger", "target": "check"}]}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workflows/lint", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.Lint(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp struct {
+			Data []workflow.LintFinding `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, workflow.LintMissingFalseBranch, resp.Data[0].Rule)
+		assert.Equal(t, "check", resp.Data[0].NodeID)
+	})
+
+	t.Run("returns an empty list for a clean definition", func(t *testing.T) {
+		body := `{"definition": {"nodes": [{"id": "trigger", "type": "trigger:webhook", "data": {"config": {}}}]}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workflows/lint", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.Lint(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data": []}`, rr.Body.String())
+	})
+
+	t.Run("rejects a missing definition", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workflows/lint", bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+
+		handler.Lint(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
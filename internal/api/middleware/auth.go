@@ -53,6 +53,7 @@ func KratosAuth(cfg config.KratosConfig) func(next http.Handler) http.Handler {
 			}
 
 			// Add user to context
+			setRequestLogUser(r.Context(), user.ID)
 			ctx := context.WithValue(r.Context(), UserContextKey, user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -52,6 +52,7 @@ func DevAuth() func(next http.Handler) http.Handler {
 			}
 
 			// Add user to context
+			setRequestLogUser(r.Context(), user.ID)
 			ctx := context.WithValue(r.Context(), UserContextKey, user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorax/gorax/internal/api/response"
+)
+
+// FeatureFlagChecker reports whether a feature flag is enabled for a tenant
+type FeatureFlagChecker interface {
+	IsEnabled(ctx context.Context, tenantID, flag string) bool
+}
+
+// RequireFeature returns middleware that responds 404 unless flag is enabled
+// for the request's tenant, so gated endpoints look absent to other tenants.
+// It must run after the tenant context middleware.
+func RequireFeature(checker FeatureFlagChecker, flag string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID := GetTenantID(r)
+			if tenantID == "" {
+				_ = response.BadRequest(w, "tenant context missing")
+				return
+			}
+
+			if !checker.IsEnabled(r.Context(), tenantID, flag) {
+				_ = response.NotFound(w, "feature not enabled: "+flag)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorax/gorax/internal/tenant"
+)
+
+// fakeFeatureFlags enables the listed flags for the listed tenants
+type fakeFeatureFlags map[string]map[string]bool
+
+func (f fakeFeatureFlags) IsEnabled(ctx context.Context, tenantID, flag string) bool {
+	return f[tenantID][flag]
+}
+
+func TestRequireFeature(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	flags := fakeFeatureFlags{"tenant-1": {"ai_builder": true}}
+
+	tests := []struct {
+		name           string
+		tenantID       string
+		expectedStatus int
+	}{
+		{name: "enabled for tenant", tenantID: "tenant-1", expectedStatus: http.StatusOK},
+		{name: "disabled for tenant returns 404", tenantID: "tenant-2", expectedStatus: http.StatusNotFound},
+		{name: "no tenant returns 400", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/ai/workflows/generate", nil)
+			if tt.tenantID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), TenantContextKey, &tenant.Tenant{ID: tt.tenantID}))
+			}
+			w := httptest.NewRecorder()
+
+			RequireFeature(flags, "ai_builder")(okHandler).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
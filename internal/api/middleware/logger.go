@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 // HTTPLoggerConfig holds configuration for HTTP logging
@@ -14,6 +17,33 @@ type HTTPLoggerConfig struct {
 	// LogLevel is the log level for successful HTTP requests (2xx)
 	// Typically "debug" in development to reduce noise, "info" in production
 	LogLevel slog.Level
+	// LogHeaders adds the request headers to each entry. Values of headers
+	// that may carry secrets, such as Authorization or Cookie, are redacted.
+	LogHeaders bool
+}
+
+// requestLogKey is the context key for the identity recorded on a request's log entry
+const requestLogKey contextKey = "request_log"
+
+// requestLogFields is the identity of a request, filled in by the auth and
+// tenant middleware that run after the logger
+type requestLogFields struct {
+	tenantID string
+	userID   string
+}
+
+// setRequestLogTenant records the tenant on the request's log entry
+func setRequestLogTenant(ctx context.Context, tenantID string) {
+	if fields, ok := ctx.Value(requestLogKey).(*requestLogFields); ok {
+		fields.tenantID = tenantID
+	}
+}
+
+// setRequestLogUser records the authenticated user on the request's log entry
+func setRequestLogUser(ctx context.Context, userID string) {
+	if fields, ok := ctx.Value(requestLogKey).(*requestLogFields); ok {
+		fields.userID = userID
+	}
 }
 
 // StructuredLogger returns a middleware that logs requests with slog
@@ -25,12 +55,16 @@ func StructuredLogger(logger *slog.Logger) func(next http.Handler) http.Handler
 	})
 }
 
-// StructuredLoggerWithConfig returns a middleware with custom logging configuration
+// StructuredLoggerWithConfig returns a middleware with custom logging configuration.
+// Entries include the tenant and user once resolved, and the trace and span
+// IDs when the request is traced, so they can be joined with traces.
 func StructuredLoggerWithConfig(logger *slog.Logger, config HTTPLoggerConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			fields := &requestLogFields{}
+			r = r.WithContext(context.WithValue(r.Context(), requestLogKey, fields))
 
 			defer func() {
 				status := ww.Status()
@@ -52,6 +86,18 @@ func StructuredLoggerWithConfig(logger *slog.Logger, config HTTPLoggerConfig) fu
 					"remote_addr", r.RemoteAddr,
 					"user_agent", r.UserAgent(),
 				}
+				if fields.tenantID != "" {
+					attrs = append(attrs, "tenant_id", fields.tenantID)
+				}
+				if fields.userID != "" {
+					attrs = append(attrs, "user_id", fields.userID)
+				}
+				if traceID := tracing.GetTraceID(r.Context()); traceID != "" {
+					attrs = append(attrs, "trace_id", traceID, "span_id", tracing.GetSpanID(r.Context()))
+				}
+				if config.LogHeaders {
+					attrs = append(attrs, "headers", redactedHeaders(r.Header))
+				}
 
 				// Log at different levels based on response status
 				if status >= 500 {
@@ -71,6 +117,19 @@ func StructuredLoggerWithConfig(logger *slog.Logger, config HTTPLoggerConfig) fu
 	}
 }
 
+// redactedHeaders returns the request headers with secret values redacted
+func redactedHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if tracing.IsSensitiveHeader(name) {
+			headers[name] = tracing.RedactedValue
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
 // shouldSkipLogging returns true for paths that should not be logged at any level
 func shouldSkipLogging(path string) bool {
 	noisyPaths := []string{
@@ -2,11 +2,14 @@ package middleware
 
 import (
 	"bytes"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestStructuredLogger(t *testing.T) {
@@ -199,3 +202,74 @@ func TestShouldSkipLogging(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuredLogger_RequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// Tenant and user are resolved by middleware running after the logger
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setRequestLogTenant(r.Context(), "tenant-1")
+		setRequestLogUser(r.Context(), "user-1")
+		w.WriteHeader(http.StatusCreated)
+	})
+	handler := StructuredLoggerWithConfig(logger, HTTPLoggerConfig{
+		LogLevel:   slog.LevelInfo,
+		LogHeaders: true,
+	})(testHandler)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+
+	req := httptest.NewRequest("POST", "/api/v1/workflows", nil)
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), spanContext))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("Cookie", "ory_kratos_session=secret")
+	req.Header.Set("X-Tenant-ID", "tenant-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry %q: %v", buf.String(), err)
+	}
+
+	for key, want := range map[string]interface{}{
+		"status":    float64(http.StatusCreated),
+		"tenant_id": "tenant-1",
+		"user_id":   "user-1",
+		"trace_id":  "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":   "00f067aa0ba902b7",
+	} {
+		if entry[key] != want {
+			t.Errorf("expected %s = %v, got %v", key, want, entry[key])
+		}
+	}
+
+	headers, _ := entry["headers"].(map[string]interface{})
+	if headers["Authorization"] != "[REDACTED]" || headers["Cookie"] != "[REDACTED]" {
+		t.Errorf("expected auth headers to be redacted, got %v", headers)
+	}
+	if headers["X-Tenant-Id"] != "tenant-1" {
+		t.Errorf("expected X-Tenant-Id header to be logged, got %v", headers)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Errorf("log entry leaks a secret: %s", buf.String())
+	}
+}
+
+func TestStructuredLogger_OmitsUnknownContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	handler := StructuredLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, key := range []string{"tenant_id", "user_id", "trace_id", "headers"} {
+		if strings.Contains(buf.String(), `"`+key+`"`) {
+			t.Errorf("expected no %s in log entry: %s", key, buf.String())
+		}
+	}
+}
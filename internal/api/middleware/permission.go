@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/rbac"
+)
+
+// PermissionChecker checks a user's permissions within a tenant
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID, tenantID, resource, action string) (bool, error)
+}
+
+// RequirePermission returns middleware that only lets through users whose
+// roles in the request's tenant grant perm, given as "resource:action".
+// It panics if perm is malformed, since that is a routing bug.
+func RequirePermission(checker PermissionChecker, perm string) func(next http.Handler) http.Handler {
+	resource, action, err := rbac.ParsePermission(perm)
+	if err != nil {
+		panic(fmt.Sprintf("RequirePermission: %v", err))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUser(r)
+			if user == nil {
+				_ = response.Unauthorized(w, "not authenticated")
+				return
+			}
+
+			tenantID := GetTenantID(r)
+			if tenantID == "" {
+				_ = response.BadRequest(w, "tenant context missing")
+				return
+			}
+
+			allowed, err := checker.HasPermission(r.Context(), user.ID, tenantID, resource, action)
+			if err != nil {
+				slog.Error("failed to check permission",
+					"error", err,
+					"permission", perm,
+					"tenant_id", tenantID,
+					"user_id", user.ID)
+				_ = response.InternalError(w, "failed to check permission")
+				return
+			}
+			if !allowed {
+				_ = response.Forbidden(w, "permission denied: "+perm)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorax/gorax/internal/tenant"
+)
+
+// fakePermissionChecker grants the listed "resource:action" permissions to every user
+type fakePermissionChecker struct {
+	granted map[string]bool
+	err     error
+}
+
+func (c *fakePermissionChecker) HasPermission(ctx context.Context, userID, tenantID, resource, action string) (bool, error) {
+	if c.err != nil {
+		return false, c.err
+	}
+	return c.granted[resource+":"+action], nil
+}
+
+func TestRequirePermission(t *testing.T) {
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		user           *User
+		checker        *fakePermissionChecker
+		expectedStatus int
+	}{
+		{
+			name:           "no user returns 401",
+			checker:        &fakePermissionChecker{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "permission granted",
+			user:           &User{ID: "user-1"},
+			checker:        &fakePermissionChecker{granted: map[string]bool{"workflow:update": true}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "permission missing returns 403",
+			user:           &User{ID: "user-1"},
+			checker:        &fakePermissionChecker{granted: map[string]bool{"workflow:read": true}},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "checker error returns 500",
+			user:           &User{ID: "user-1"},
+			checker:        &fakePermissionChecker{err: errors.New("db down")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/workflows/wf-1", nil)
+			ctx := context.WithValue(req.Context(), TenantContextKey, &tenant.Tenant{ID: "tenant-1"})
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, UserContextKey, tt.user)
+			}
+			w := httptest.NewRecorder()
+
+			RequirePermission(tt.checker, "workflow:update")(okHandler).ServeHTTP(w, req.WithContext(ctx))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRequirePermission_PanicsOnMalformedPermission(t *testing.T) {
+	assert.Panics(t, func() {
+		RequirePermission(&fakePermissionChecker{}, "workflow")
+	})
+}
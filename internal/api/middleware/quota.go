@@ -33,6 +33,10 @@ type QuotaChecker struct {
 	tenantService QuotaTenantService
 	redis         QuotaRedisClient
 	logger        *slog.Logger
+
+	// apiRateLimitDelegated skips the per-minute API call check when a
+	// TenantRateLimiter enforces it instead
+	apiRateLimitDelegated bool
 }
 
 // NewQuotaChecker creates a new quota checker
@@ -44,6 +48,12 @@ func NewQuotaChecker(tenantService *tenant.Service, redis *redis.Client, logger
 	}
 }
 
+// DelegateAPIRateLimit disables the quota checker's own per-minute API call
+// limit because a TenantRateLimiter enforces max_api_calls_per_minute
+func (qc *QuotaChecker) DelegateAPIRateLimit() {
+	qc.apiRateLimitDelegated = true
+}
+
 // CheckQuotas returns middleware that validates tenant quotas before allowing operations
 func (qc *QuotaChecker) CheckQuotas() func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -79,6 +89,9 @@ func (qc *QuotaChecker) CheckQuotas() func(next http.Handler) http.Handler {
 					return
 				}
 			case "api_call":
+				if qc.apiRateLimitDelegated {
+					break
+				}
 				if err := qc.checkAPIRateLimit(r.Context(), t.ID, quotas); err != nil {
 					qc.handleQuotaExceeded(w, err)
 					return
@@ -75,8 +75,10 @@ func TenantContextWithConfig(tenantService *tenant.Service, cfg TenantMiddleware
 
 			// Add tenant to context using both the middleware key and tenantctx package
 			// This ensures compatibility with both middleware.GetTenantID() and tenantctx.GetTenantID()
+			setRequestLogTenant(r.Context(), t.ID)
 			ctx := context.WithValue(r.Context(), TenantContextKey, t)
 			ctx = tenantctx.WithTenantID(ctx, t.ID)
+			ctx = tenantctx.WithRegion(ctx, t.DataRegion())
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
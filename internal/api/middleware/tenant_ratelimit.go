@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorax/gorax/internal/api/response"
+	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/ratelimit"
+)
+
+// TenantRateLimiter enforces a per-tenant token bucket on API requests. The
+// quota comes from the config override for the tenant, then the tenant's
+// max_api_calls_per_minute, then the configured default.
+type TenantRateLimiter struct {
+	store  ratelimit.BucketStore
+	config config.RateLimitConfig
+	logger *slog.Logger
+}
+
+// NewTenantRateLimiter creates a per-tenant rate limiter backed by store
+func NewTenantRateLimiter(store ratelimit.BucketStore, cfg config.RateLimitConfig, logger *slog.Logger) *TenantRateLimiter {
+	return &TenantRateLimiter{
+		store:  store,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Limit returns middleware that rejects requests with 429 once the tenant's
+// bucket is empty. It must run after tenant resolution; requests without a
+// tenant fall back to the X-Tenant-ID header and are skipped without either.
+func (l *TenantRateLimiter) Limit() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if l.exempt(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tenantID, limit := l.quotaFor(r)
+			if tenantID == "" || limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision, err := l.store.Take(r.Context(), tenantID, ratelimit.Quota{
+				Limit:  int64(limit),
+				Period: time.Minute,
+			})
+			if err != nil {
+				// Fail open so a rate limit store outage does not take down the API
+				l.logger.Error("rate limit check failed", "error", err, "tenant_id", tenantID)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", formatInt64(decision.Limit))
+			w.Header().Set("X-RateLimit-Remaining", formatInt64(decision.Remaining))
+
+			if !decision.Allowed {
+				retryAfter := int64(math.Ceil(decision.RetryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+
+				l.logger.Warn("rate limit exceeded", "tenant_id", tenantID, "limit", decision.Limit, "path", r.URL.Path)
+				_ = response.Error(w, http.StatusTooManyRequests, "rate limit exceeded", response.CodeRateLimitExceeded)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// quotaFor returns the tenant a request is limited as and its requests per
+// minute, where a limit of zero or less (-1 by convention) means unlimited
+func (l *TenantRateLimiter) quotaFor(r *http.Request) (string, int) {
+	tenantID := r.Header.Get("X-Tenant-ID")
+	t := GetTenant(r)
+	if t != nil {
+		tenantID = t.ID
+	}
+	if tenantID == "" {
+		return "", -1
+	}
+
+	if limit, ok := l.config.TenantRequestsPerMinute[tenantID]; ok {
+		return tenantID, limit
+	}
+
+	if t != nil && len(t.Quotas) > 0 {
+		quotas, err := t.GetQuotas()
+		if err != nil {
+			l.logger.Warn("failed to parse tenant quotas for rate limit", "error", err, "tenant_id", tenantID)
+		} else if quotas.MaxAPICallsPerMinute != 0 {
+			return tenantID, quotas.MaxAPICallsPerMinute
+		}
+	}
+
+	return tenantID, l.config.RequestsPerMinute
+}
+
+// exempt reports whether a path is never rate limited
+func (l *TenantRateLimiter) exempt(path string) bool {
+	for _, prefix := range l.config.ExemptPaths {
+		if matchPath(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
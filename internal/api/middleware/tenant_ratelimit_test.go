@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/ratelimit"
+	"github.com/gorax/gorax/internal/tenant"
+)
+
+// failingBucketStore always fails to take a token
+type failingBucketStore struct{}
+
+func (failingBucketStore) Take(ctx context.Context, key string, quota ratelimit.Quota) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("redis unavailable")
+}
+
+func newTestRateLimiter(store ratelimit.BucketStore, cfg config.RateLimitConfig) http.Handler {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	limiter := NewTenantRateLimiter(store, cfg, logger)
+	return limiter.Limit()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func serveRateLimited(handler http.Handler, path string, t *tenant.Tenant) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if t != nil {
+		req = addTenantToContext(req, t)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestTenantRateLimiter_Limit tests headers and 429 responses once a tenant's bucket is empty
+func TestTenantRateLimiter_Limit(t *testing.T) {
+	handler := newTestRateLimiter(ratelimit.NewMemoryBucketStore(), config.RateLimitConfig{RequestsPerMinute: 2})
+	tenantA := &tenant.Tenant{ID: "tenant-a"}
+
+	rec := serveRateLimited(handler, "/api/v1/workflows", tenantA)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = serveRateLimited(handler, "/api/v1/workflows", tenantA)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = serveRateLimited(handler, "/api/v1/workflows", tenantA)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "rate_limit_exceeded")
+
+	rec = serveRateLimited(handler, "/api/v1/workflows", &tenant.Tenant{ID: "tenant-b"})
+	assert.Equal(t, http.StatusOK, rec.Code, "tenants have separate buckets")
+}
+
+// TestTenantRateLimiter_QuotaFor tests the precedence of config overrides, tenant quotas and the default
+func TestTenantRateLimiter_QuotaFor(t *testing.T) {
+	limiter := NewTenantRateLimiter(ratelimit.NewMemoryBucketStore(), config.RateLimitConfig{
+		RequestsPerMinute:       60,
+		TenantRequestsPerMinute: map[string]int{"tenant-override": 600, "tenant-unlimited": -1},
+	}, slog.Default())
+
+	tests := []struct {
+		name       string
+		tenant     *tenant.Tenant
+		header     string
+		wantTenant string
+		wantLimit  int
+	}{
+		{
+			name:       "config override wins over tenant quota",
+			tenant:     &tenant.Tenant{ID: "tenant-override", Quotas: createTestQuotas(0, 0, 0, 300)},
+			wantTenant: "tenant-override",
+			wantLimit:  600,
+		},
+		{
+			name:       "unlimited override",
+			tenant:     &tenant.Tenant{ID: "tenant-unlimited"},
+			wantTenant: "tenant-unlimited",
+			wantLimit:  -1,
+		},
+		{
+			name:       "tenant quota",
+			tenant:     &tenant.Tenant{ID: "tenant-pro", Quotas: createTestQuotas(0, 0, 0, 300)},
+			wantTenant: "tenant-pro",
+			wantLimit:  300,
+		},
+		{
+			name:       "default when quota unset",
+			tenant:     &tenant.Tenant{ID: "tenant-new", Quotas: createTestQuotas(0, 0, 0, 0)},
+			wantTenant: "tenant-new",
+			wantLimit:  60,
+		},
+		{
+			name:       "X-Tenant-ID header without resolved tenant",
+			header:     "tenant-header",
+			wantTenant: "tenant-header",
+			wantLimit:  60,
+		},
+		{
+			name:      "no tenant",
+			wantLimit: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/workflows", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			if tt.tenant != nil {
+				req = addTenantToContext(req, tt.tenant)
+			}
+
+			tenantID, limit := limiter.quotaFor(req)
+
+			assert.Equal(t, tt.wantTenant, tenantID)
+			assert.Equal(t, tt.wantLimit, limit)
+		})
+	}
+}
+
+// TestTenantRateLimiter_Exempt tests that exempt paths and unlimited tenants bypass the limiter
+func TestTenantRateLimiter_Exempt(t *testing.T) {
+	handler := newTestRateLimiter(ratelimit.NewMemoryBucketStore(), config.RateLimitConfig{
+		RequestsPerMinute:       1,
+		TenantRequestsPerMinute: map[string]int{"tenant-unlimited": -1},
+		ExemptPaths:             []string{"/health", "/ready"},
+	})
+	tenantA := &tenant.Tenant{ID: "tenant-a"}
+
+	for i := 0; i < 3; i++ {
+		rec := serveRateLimited(handler, "/health", tenantA)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+
+		rec = serveRateLimited(handler, "/ready", tenantA)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = serveRateLimited(handler, "/api/v1/workflows", &tenant.Tenant{ID: "tenant-unlimited"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+// TestTenantRateLimiter_FailOpen tests that store errors do not reject requests
+func TestTenantRateLimiter_FailOpen(t *testing.T) {
+	handler := newTestRateLimiter(failingBucketStore{}, config.RateLimitConfig{RequestsPerMinute: 1})
+
+	rec := serveRateLimited(handler, "/api/v1/workflows", &tenant.Tenant{ID: "tenant-a"})
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+}
@@ -0,0 +1,96 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Machine-readable error codes returned in the "code" field of error
+// responses. Clients branch on these, so existing values must not change.
+const (
+	CodeBadRequest         = "bad_request"
+	CodeValidationFailed   = "validation_failed"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeRateLimitExceeded  = "rate_limit_exceeded"
+	CodeInternal           = "internal_error"
+	CodeServiceUnavailable = "service_unavailable"
+	CodeInvalidCursor      = "invalid_cursor"
+	CodeCursorExpired      = "cursor_expired"
+
+	CodeCredentialNotFound     = "credential_not_found"
+	CodeCredentialAccessDenied = "credential_access_denied"
+	CodeCredentialExists       = "credential_already_exists"
+
+	CodeConnectionNotFound    = "connection_not_found"
+	CodeConnectionRevoked     = "connection_revoked"
+	CodeInvalidOAuthProvider  = "invalid_oauth_provider"
+	CodeInvalidOAuthState     = "invalid_oauth_state"
+	CodeInvalidOAuthCode      = "invalid_authorization_code"
+	CodeOAuthCallbackFailed   = "oauth_callback_failed"
+	CodeOAuthTokenUnavailable = "oauth_token_unavailable"
+	CodeUnsupportedOAuthGrant = "unsupported_oauth_grant"
+	CodeOAuthAccessDenied     = "oauth_access_denied"
+	CodeOAuthMisconfigured    = "oauth_provider_misconfigured"
+	CodeOAuthProviderError    = "oauth_provider_error"
+
+	CodeDeviceAuthorizationNotFound = "device_authorization_not_found"
+
+	CodeWorkflowNotFound         = "workflow_not_found"
+	CodeExecutionNotCancellable  = "execution_not_cancellable"
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+	CodeDeadLetterNotFound       = "dead_letter_not_found"
+	CodeDeadLetterRequeued       = "dead_letter_requeued"
+
+	CodeEventTypeNotFound       = "event_type_not_found"
+	CodeEventTypeBreakingChange = "event_type_breaking_change"
+
+	CodeFeatureFlagNotFound = "feature_flag_not_found"
+
+	CodeEnvVarNotFound = "env_var_not_found"
+	CodeEnvVarExists   = "env_var_already_exists"
+)
+
+// APIError is an error with an HTTP status and a stable code. It is written
+// in the same envelope as ErrorResponse.
+type APIError struct {
+	Status  int                    `json:"-"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"error"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewAPIError creates an API error
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// WithDetails returns a copy of the error with details attached
+func (e *APIError) WithDetails(details map[string]interface{}) *APIError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// WriteError sends err as an error response. An APIError anywhere in the
+// chain is written as is; any other error becomes a 500 without exposing its
+// message.
+func WriteError(w http.ResponseWriter, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		apiErr = NewAPIError(http.StatusInternalServerError, CodeInternal, "internal server error")
+	}
+	return JSON(w, apiErr.Status, apiErr)
+}
@@ -89,27 +89,27 @@ func NoContent(w http.ResponseWriter) {
 
 // BadRequest sends a 400 Bad Request error response.
 func BadRequest(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusBadRequest, message, "bad_request")
+	return Error(w, http.StatusBadRequest, message, CodeBadRequest)
 }
 
 // Unauthorized sends a 401 Unauthorized error response.
 func Unauthorized(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusUnauthorized, message, "unauthorized")
+	return Error(w, http.StatusUnauthorized, message, CodeUnauthorized)
 }
 
 // Forbidden sends a 403 Forbidden error response.
 func Forbidden(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusForbidden, message, "forbidden")
+	return Error(w, http.StatusForbidden, message, CodeForbidden)
 }
 
 // NotFound sends a 404 Not Found error response.
 func NotFound(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusNotFound, message, "not_found")
+	return Error(w, http.StatusNotFound, message, CodeNotFound)
 }
 
 // Conflict sends a 409 Conflict error response.
 func Conflict(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusConflict, message, "conflict")
+	return Error(w, http.StatusConflict, message, CodeConflict)
 }
 
 // UnprocessableEntity sends a 422 Unprocessable Entity error response.
@@ -126,10 +126,10 @@ func ValidationError(w http.ResponseWriter, message string, details map[string]s
 // InternalError sends a 500 Internal Server Error response.
 // The actual error is not exposed to the client for security reasons.
 func InternalError(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusInternalServerError, message, "internal_error")
+	return Error(w, http.StatusInternalServerError, message, CodeInternal)
 }
 
 // ServiceUnavailable sends a 503 Service Unavailable error response.
 func ServiceUnavailable(w http.ResponseWriter, message string) error {
-	return Error(w, http.StatusServiceUnavailable, message, "service_unavailable")
+	return Error(w, http.StatusServiceUnavailable, message, CodeServiceUnavailable)
 }
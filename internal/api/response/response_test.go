@@ -2,6 +2,8 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -403,3 +405,27 @@ func TestContentTypeHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteError(t *testing.T) {
+	t.Run("api error with details", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		apiErr := NewAPIError(http.StatusNotFound, CodeCredentialNotFound, "credential not found").
+			WithDetails(map[string]interface{}{"credential_id": "cred-1"})
+
+		err := WriteError(w, fmt.Errorf("get credential: %w", apiErr))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"error":"credential not found","code":"credential_not_found","details":{"credential_id":"cred-1"}}`, w.Body.String())
+	})
+
+	t.Run("unknown error is not exposed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		err := WriteError(w, errors.New("pq: connection refused"))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{"error":"internal server error","code":"internal_error"}`, w.Body.String())
+	})
+}
@@ -448,6 +448,69 @@ func (r *Repository) DeleteOldAuditEvents(ctx context.Context, tenantID string,
 	return deletedCount, nil
 }
 
+// trailEventsQuery combines credential access and OAuth connection logs
+// into the audit trail event shape. $1 is the tenant ID.
+const trailEventsQuery = `
+	SELECT source, id, tenant_id, actor, action, resource_id, success,
+	       error_message, ip_address, user_agent, metadata, occurred_at
+	FROM (
+		SELECT 'credential' AS source, id::text AS id, tenant_id::text AS tenant_id,
+		       accessed_by::text AS actor, access_type AS action, credential_id::text AS resource_id,
+		       success, COALESCE(error_message, '') AS error_message,
+		       COALESCE(ip_address, '') AS ip_address, COALESCE(user_agent, '') AS user_agent,
+		       CASE WHEN keys IS NULL THEN '{}'::jsonb ELSE jsonb_build_object('keys', keys) END AS metadata,
+		       accessed_at AS occurred_at
+		FROM credential_access_log
+		WHERE tenant_id = $1
+		UNION ALL
+		SELECT 'oauth', id::text, tenant_id::text,
+		       user_id, action, COALESCE(connection_id::text, ''),
+		       success, COALESCE(error_message, ''),
+		       '', '',
+		       COALESCE(metadata, '{}'::jsonb),
+		       created_at
+		FROM oauth_connection_logs
+		WHERE tenant_id = $1
+	) trail`
+
+// ListTrailEvents lists a page of credential and OAuth audit events for a
+// tenant, oldest first, starting after the given cursor
+func (r *Repository) ListTrailEvents(ctx context.Context, tenantID string, filter AuditFilter, after *TrailCursor, limit int) ([]TrailEvent, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{tenantID}
+
+	if !filter.StartTime.IsZero() {
+		args = append(args, filter.StartTime)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if !filter.EndTime.IsZero() {
+		args = append(args, filter.EndTime)
+		conditions = append(conditions, fmt.Sprintf("occurred_at <= $%d", len(args)))
+	}
+	if len(filter.Actions) > 0 {
+		args = append(args, pq.Array(filter.Actions))
+		conditions = append(conditions, fmt.Sprintf("action = ANY($%d)", len(args)))
+	}
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		conditions = append(conditions, fmt.Sprintf("actor = $%d", len(args)))
+	}
+	if after != nil {
+		args = append(args, after.OccurredAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf("(occurred_at, id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf("%s\n\tWHERE %s\n\tORDER BY occurred_at, id\n\tLIMIT $%d",
+		trailEventsQuery, strings.Join(conditions, " AND "), len(args))
+
+	var events []TrailEvent
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, fmt.Errorf("list audit trail events: %w", err)
+	}
+	return events, nil
+}
+
 // buildWhereClause builds the WHERE clause and arguments for audit event queries
 func buildWhereClause(filter QueryFilter) (string, []interface{}) {
 	var conditions []string
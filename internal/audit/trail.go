@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Trail event sources
+const (
+	TrailSourceCredential = "credential"
+	TrailSourceOAuth      = "oauth"
+)
+
+// trailPageSize is the number of events read from the database per page
+const trailPageSize = 500
+
+// AuditFilter selects the credential and OAuth events included in an
+// audit trail export. Zero values match everything.
+type AuditFilter struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Actions   []string
+	Actor     string
+}
+
+// TrailEvent is a credential access or OAuth connection log entry in the
+// shape it is exported for SIEM ingestion
+type TrailEvent struct {
+	Source       string          `db:"source" json:"source"`
+	ID           string          `db:"id" json:"id"`
+	TenantID     string          `db:"tenant_id" json:"tenantId"`
+	Actor        string          `db:"actor" json:"actor"`
+	Action       string          `db:"action" json:"action"`
+	ResourceID   string          `db:"resource_id" json:"resourceId,omitempty"`
+	Success      bool            `db:"success" json:"success"`
+	ErrorMessage string          `db:"error_message" json:"errorMessage,omitempty"`
+	IPAddress    string          `db:"ip_address" json:"ipAddress,omitempty"`
+	UserAgent    string          `db:"user_agent" json:"userAgent,omitempty"`
+	Metadata     json.RawMessage `db:"metadata" json:"metadata,omitempty"`
+	OccurredAt   time.Time       `db:"occurred_at" json:"occurredAt"`
+}
+
+// TrailCursor is the position after which the next page of events starts
+type TrailCursor struct {
+	OccurredAt time.Time
+	ID         string
+}
+
+// TrailRepository lists audit trail events oldest first
+type TrailRepository interface {
+	ListTrailEvents(ctx context.Context, tenantID string, filter AuditFilter, after *TrailCursor, limit int) ([]TrailEvent, error)
+}
+
+// TrailExporter exports the credential and OAuth audit trail of a tenant
+type TrailExporter struct {
+	repo     TrailRepository
+	pageSize int
+}
+
+// NewTrailExporter creates a new audit trail exporter
+func NewTrailExporter(repo TrailRepository) *TrailExporter {
+	return &TrailExporter{repo: repo, pageSize: trailPageSize}
+}
+
+// ExportAuditLogs streams the tenant's credential and OAuth audit events
+// matching filter as newline-delimited JSON, oldest first. Events are read a
+// page at a time while the returned reader is consumed, so exports of any
+// size are never held in memory. A read error ends the stream with that
+// error. The reader is an io.ReadCloser; closing it stops the export.
+func (e *TrailExporter) ExportAuditLogs(ctx context.Context, tenantID string, filter AuditFilter) (io.Reader, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id is required", ErrInvalidFilter)
+	}
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && filter.EndTime.Before(filter.StartTime) {
+		return nil, fmt.Errorf("%w: end time is before start time", ErrInvalidFilter)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(e.writeTrail(ctx, pw, tenantID, filter))
+	}()
+	return pr, nil
+}
+
+// writeTrail writes every matching event to w, one JSON object per line
+func (e *TrailExporter) writeTrail(ctx context.Context, w io.Writer, tenantID string, filter AuditFilter) error {
+	encoder := json.NewEncoder(w)
+	var after *TrailCursor
+	for {
+		events, err := e.repo.ListTrailEvents(ctx, tenantID, filter, after, e.pageSize)
+		if err != nil {
+			return fmt.Errorf("list audit trail events: %w", err)
+		}
+
+		for i := range events {
+			if err := encoder.Encode(&events[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < e.pageSize {
+			return nil
+		}
+		last := events[len(events)-1]
+		after = &TrailCursor{OccurredAt: last.OccurredAt, ID: last.ID}
+	}
+}
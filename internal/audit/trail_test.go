@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrailRepo pages through a fixed list of events and records cursors
+type fakeTrailRepo struct {
+	events  []TrailEvent
+	cursors []*TrailCursor
+	err     error
+}
+
+func (r *fakeTrailRepo) ListTrailEvents(ctx context.Context, tenantID string, filter AuditFilter, after *TrailCursor, limit int) ([]TrailEvent, error) {
+	r.cursors = append(r.cursors, after)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	start := 0
+	if after != nil {
+		for i, event := range r.events {
+			if event.ID == after.ID {
+				start = i + 1
+			}
+		}
+	}
+	end := start + limit
+	if end > len(r.events) {
+		end = len(r.events)
+	}
+	return r.events[start:end], nil
+}
+
+func TestTrailExporter_ExportAuditLogs(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeTrailRepo{}
+	for i := 0; i < 5; i++ {
+		source := TrailSourceCredential
+		if i%2 == 1 {
+			source = TrailSourceOAuth
+		}
+		repo.events = append(repo.events, TrailEvent{
+			Source:     source,
+			ID:         fmt.Sprintf("event-%d", i),
+			TenantID:   "tenant-1",
+			Actor:      "user-1",
+			Action:     "read",
+			Success:    true,
+			OccurredAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	exporter := &TrailExporter{repo: repo, pageSize: 2}
+
+	reader, err := exporter.ExportAuditLogs(context.Background(), "tenant-1", AuditFilter{})
+	require.NoError(t, err)
+
+	var exported []TrailEvent
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var event TrailEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		exported = append(exported, event)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, exported, 5)
+	assert.Equal(t, TrailSourceOAuth, exported[1].Source)
+	assert.Equal(t, "event-4", exported[4].ID)
+
+	// Three pages, each continuing from the last event of the previous one
+	require.Len(t, repo.cursors, 3)
+	assert.Nil(t, repo.cursors[0])
+	assert.Equal(t, "event-1", repo.cursors[1].ID)
+	assert.Equal(t, "event-3", repo.cursors[2].ID)
+}
+
+func TestTrailExporter_ExportAuditLogs_RepositoryError(t *testing.T) {
+	exporter := NewTrailExporter(&fakeTrailRepo{err: errors.New("connection reset")})
+
+	reader, err := exporter.ExportAuditLogs(context.Background(), "tenant-1", AuditFilter{})
+	require.NoError(t, err)
+
+	_, err = io.ReadAll(reader)
+	assert.ErrorContains(t, err, "connection reset")
+}
+
+func TestTrailExporter_ExportAuditLogs_InvalidFilter(t *testing.T) {
+	exporter := NewTrailExporter(&fakeTrailRepo{})
+	now := time.Now()
+
+	_, err := exporter.ExportAuditLogs(context.Background(), "", AuditFilter{})
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+
+	_, err = exporter.ExportAuditLogs(context.Background(), "tenant-1", AuditFilter{StartTime: now, EndTime: now.Add(-time.Hour)})
+	assert.ErrorIs(t, err, ErrInvalidFilter)
+}
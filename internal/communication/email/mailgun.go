@@ -2,6 +2,7 @@ package email
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -36,6 +37,13 @@ func (p *MailgunProvider) SendEmail(ctx context.Context, request *communication.
 
 	resp, id, err := p.client.Send(ctx, message)
 	if err != nil {
+		var unexpected *mailgun.UnexpectedResponseError
+		if errors.As(err, &unexpected) && unexpected.Actual >= 400 && unexpected.Actual < 500 {
+			err = &communication.RejectedError{
+				Provider: "mailgun",
+				Reason:   fmt.Sprintf("status %d: %s", unexpected.Actual, unexpected.Data),
+			}
+		}
 		return &communication.EmailResponse{
 			Status: string(communication.MessageStatusFailed),
 			Error:  err,
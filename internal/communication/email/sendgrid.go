@@ -47,15 +47,24 @@ func (p *SendGridProvider) SendEmail(ctx context.Context, request *communication
 	}
 
 	if response.StatusCode >= 400 {
+		rejected := &communication.RejectedError{
+			Provider: "sendgrid",
+			Reason:   fmt.Sprintf("status %d: %s", response.StatusCode, response.Body),
+		}
 		return &communication.EmailResponse{
 			Status: string(communication.MessageStatusFailed),
-			Error:  fmt.Errorf("sendgrid returned error: %d - %s", response.StatusCode, response.Body),
+			Error:  rejected,
 			SentAt: time.Now(),
-		}, fmt.Errorf("sendgrid API error: status=%d", response.StatusCode)
+		}, rejected
+	}
+
+	var messageID string
+	if ids := response.Headers["X-Message-Id"]; len(ids) > 0 {
+		messageID = ids[0]
 	}
 
 	return &communication.EmailResponse{
-		MessageID: response.Headers["X-Message-Id"][0],
+		MessageID: messageID,
 		Status:    string(communication.MessageStatusSent),
 		SentAt:    time.Now(),
 	}, nil
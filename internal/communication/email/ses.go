@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"mime/quotedprintable"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ses"
 
@@ -37,6 +40,22 @@ func NewSESProvider(region string) (*SESProvider, error) {
 	}, nil
 }
 
+// NewSESProviderWithCredentials creates an AWS SES email provider that
+// authenticates with static credentials instead of the default chain.
+func NewSESProviderWithCredentials(region, accessKeyID, secretAccessKey, sessionToken string) (*SESProvider, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials(accessKeyID, secretAccessKey, sessionToken),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	return &SESProvider{
+		client: ses.New(sess),
+	}, nil
+}
+
 // SendEmail sends a single email using AWS SES.
 func (p *SESProvider) SendEmail(ctx context.Context, request *communication.EmailRequest) (*communication.EmailResponse, error) {
 	if err := request.Validate(); err != nil {
@@ -115,6 +134,7 @@ func (p *SESProvider) sendSimpleEmail(ctx context.Context, request *communicatio
 
 	result, err := p.client.SendEmailWithContext(ctx, input)
 	if err != nil {
+		err = sesError(err)
 		return &communication.EmailResponse{
 			Status: string(communication.MessageStatusFailed),
 			Error:  err,
@@ -149,6 +169,7 @@ func (p *SESProvider) sendRawEmail(ctx context.Context, request *communication.E
 
 	result, err := p.client.SendRawEmailWithContext(ctx, input)
 	if err != nil {
+		err = sesError(err)
 		return &communication.EmailResponse{
 			Status: string(communication.MessageStatusFailed),
 			Error:  err,
@@ -163,6 +184,23 @@ func (p *SESProvider) sendRawEmail(ctx context.Context, request *communication.E
 	}, nil
 }
 
+// sesError reports errors where SES refused the message, such as an
+// unverified sender, as a RejectedError
+func sesError(err error) error {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return err
+	}
+	switch awsErr.Code() {
+	case ses.ErrCodeMessageRejected,
+		ses.ErrCodeMailFromDomainNotVerifiedException,
+		ses.ErrCodeConfigurationSetDoesNotExistException,
+		ses.ErrCodeAccountSendingPausedException:
+		return &communication.RejectedError{Provider: "aws_ses", Reason: awsErr.Message()}
+	}
+	return err
+}
+
 // buildRawMessage builds a MIME multipart message with attachments.
 func (p *SESProvider) buildRawMessage(request *communication.EmailRequest) ([]byte, error) {
 	buf := new(bytes.Buffer)
@@ -3,8 +3,11 @@ package email
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"mime/quotedprintable"
@@ -42,6 +45,7 @@ func (p *SMTPProvider) SendEmail(ctx context.Context, request *communication.Ema
 		return nil, fmt.Errorf("invalid email request: %w", err)
 	}
 
+	request, messageID := p.withMessageID(request)
 	message, err := p.buildMessage(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build message: %w", err)
@@ -61,6 +65,14 @@ func (p *SMTPProvider) SendEmail(ctx context.Context, request *communication.Ema
 	}
 
 	if sendErr != nil {
+		// A reply code from the server means it refused the message
+		var replyErr *textproto.Error
+		if errors.As(sendErr, &replyErr) {
+			sendErr = &communication.RejectedError{
+				Provider: "smtp",
+				Reason:   fmt.Sprintf("%d %s", replyErr.Code, replyErr.Msg),
+			}
+		}
 		return &communication.EmailResponse{
 			Status: string(communication.MessageStatusFailed),
 			Error:  sendErr,
@@ -69,7 +81,7 @@ func (p *SMTPProvider) SendEmail(ctx context.Context, request *communication.Ema
 	}
 
 	return &communication.EmailResponse{
-		MessageID: fmt.Sprintf("%d@%s", time.Now().UnixNano(), p.host),
+		MessageID: messageID,
 		Status:    string(communication.MessageStatusSent),
 		SentAt:    time.Now(),
 	}, nil
@@ -91,6 +103,28 @@ func (p *SMTPProvider) SendBulkEmail(ctx context.Context, requests []*communicat
 	return responses, firstErr
 }
 
+// withMessageID returns a copy of the request carrying a generated
+// Message-ID header, unless the request already sets one, and the ID itself
+func (p *SMTPProvider) withMessageID(request *communication.EmailRequest) (*communication.EmailRequest, string) {
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, "Message-ID") {
+			return request, value
+		}
+	}
+
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	messageID := fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), p.host)
+
+	withID := *request
+	withID.Headers = make(map[string]string, len(request.Headers)+1)
+	for key, value := range request.Headers {
+		withID.Headers[key] = value
+	}
+	withID.Headers["Message-ID"] = messageID
+	return &withID, messageID
+}
+
 // sendWithTLS sends email using explicit TLS.
 func (p *SMTPProvider) sendWithTLS(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
 	tlsConfig := &tls.Config{
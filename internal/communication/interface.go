@@ -4,6 +4,8 @@ package communication
 import (
 	"context"
 	"fmt"
+	"net/mail"
+	"strings"
 	"time"
 )
 
@@ -47,9 +49,65 @@ func (r *EmailRequest) Validate() error {
 	if r.Body == "" && r.BodyHTML == "" {
 		return fmt.Errorf("email body is required")
 	}
+	return r.validateAddresses()
+}
+
+// validateAddresses checks every address is well formed and that no header
+// value could inject additional headers
+func (r *EmailRequest) validateAddresses() error {
+	if err := ValidateEmailAddress(r.From); err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	if r.ReplyTo != "" {
+		if err := ValidateEmailAddress(r.ReplyTo); err != nil {
+			return fmt.Errorf("invalid reply_to address: %w", err)
+		}
+	}
+	recipients := []struct {
+		field     string
+		addresses []string
+	}{{"to", r.To}, {"cc", r.CC}, {"bcc", r.BCC}}
+	for _, group := range recipients {
+		for _, address := range group.addresses {
+			if err := ValidateEmailAddress(address); err != nil {
+				return fmt.Errorf("invalid %s address: %w", group.field, err)
+			}
+		}
+	}
+	if strings.ContainsAny(r.Subject, "\r\n") {
+		return fmt.Errorf("subject must not contain line breaks")
+	}
+	for key, value := range r.Headers {
+		if strings.ContainsAny(key, "\r\n:") || strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %q must not contain line breaks", key)
+		}
+	}
+	return nil
+}
+
+// ValidateEmailAddress checks that an address is a single RFC 5322 address
+// such as "ops@example.com" or "Ops <ops@example.com>"
+func ValidateEmailAddress(address string) error {
+	if strings.ContainsAny(address, "\r\n") {
+		return fmt.Errorf("%q must not contain line breaks", address)
+	}
+	if _, err := mail.ParseAddress(address); err != nil {
+		return fmt.Errorf("%q is not a valid email address", address)
+	}
 	return nil
 }
 
+// RejectedError is returned when a provider refuses to accept a message, as
+// opposed to failing to reach the provider at all
+type RejectedError struct {
+	Provider string
+	Reason   string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("%s rejected the message: %s", e.Provider, e.Reason)
+}
+
 // EmailResponse represents the response from sending an email.
 type EmailResponse struct {
 	MessageID string
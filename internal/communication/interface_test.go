@@ -73,6 +73,29 @@ func TestEmailRequest_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "email body is required",
 		},
+		{
+			name: "invalid cc address",
+			request: &EmailRequest{
+				From:    "Sender <sender@example.com>",
+				To:      []string{"recipient@example.com"},
+				CC:      []string{"not an address"},
+				Subject: "Test Subject",
+				Body:    "Test body",
+			},
+			wantErr: true,
+			errMsg:  "invalid cc address",
+		},
+		{
+			name: "subject with header injection",
+			request: &EmailRequest{
+				From:    "sender@example.com",
+				To:      []string{"recipient@example.com"},
+				Subject: "Hi\r\nBcc: victim@example.com",
+				Body:    "Test body",
+			},
+			wantErr: true,
+			errMsg:  "subject must not contain line breaks",
+		},
 	}
 
 	for _, tt := range tests {
@@ -19,6 +19,8 @@ type Config struct {
 	Queue          QueueConfig
 	Credential     CredentialConfig
 	Cleanup        CleanupConfig
+	WebhookRetry   WebhookRetryConfig
+	OAuthCleanup   OAuthCleanupConfig
 	Retention      RetentionConfig
 	Observability  ObservabilityConfig
 	Notification   NotificationConfig
@@ -27,11 +29,17 @@ type Config struct {
 	AIBuilder      AIBuilderConfig
 	WebSocket      WebSocketConfig
 	SSRF           SSRFConfig
+	HTTPAction     HTTPActionConfig
 	FormulaCache   FormulaCacheConfig
 	OAuth          OAuthConfig
 	Audit          AuditConfig
 	Log            LogConfig
 	Tenant         TenantConfig
+	RateLimit      RateLimitConfig
+	Pagination     PaginationConfig
+	Workflow       WorkflowConfig
+	Redaction      RedactionConfig
+	DataResidency  DataResidencyConfig
 }
 
 // TenantConfig holds multi-tenant configuration
@@ -81,6 +89,19 @@ type CredentialConfig struct {
 	KMSKeyID string
 	// KMSRegion is the AWS region for KMS operations (defaults to AWS_REGION if not set)
 	KMSRegion string
+	// UsageInterval is how stale a credential's last used time may get before
+	// a read records a new one
+	UsageInterval time.Duration
+	// UsageFlushInterval is how often recorded credential usage is written
+	UsageFlushInterval time.Duration
+	// RecoveryEnabled exposes the admin credential export and import
+	// endpoints used for disaster recovery
+	RecoveryEnabled bool
+	// DeletedRetention is how long a deleted credential can be restored
+	// before the worker purges it
+	DeletedRetention time.Duration
+	// PurgeInterval is how often the worker purges deleted credentials
+	PurgeInterval time.Duration
 }
 
 // ServerConfig holds HTTP server configuration
@@ -101,14 +122,36 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+	// AuthMode is how the password is obtained: "static" uses Password,
+	// "iam" signs a short-lived AWS RDS IAM auth token for each new connection
+	AuthMode string
+	// IAMRegion is the AWS region IAM auth tokens are signed for
+	IAMRegion string
+	// TokenRefreshInterval is how long an IAM auth token is reused before a
+	// new one is signed. Tokens are valid for 15 minutes.
+	TokenRefreshInterval time.Duration
+	// SearchPath sets the schemas unqualified table names resolve to, as in
+	// a data region's database; empty leaves the server default
+	SearchPath string
 }
 
 // ConnectionString returns the PostgreSQL connection string
 func (d DatabaseConfig) ConnectionString() string {
-	return fmt.Sprintf(
+	return d.ConnectionStringWithPassword(d.Password)
+}
+
+// ConnectionStringWithPassword returns the PostgreSQL connection string
+// authenticating with password instead of the configured one
+func (d DatabaseConfig) ConnectionStringWithPassword(password string) string {
+	conn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		d.Host, d.Port, d.User, d.Password, d.DBName, d.SSLMode,
+		quoteConnValue(d.Host), d.Port, quoteConnValue(d.User), quoteConnValue(password),
+		quoteConnValue(d.DBName), quoteConnValue(d.SSLMode),
 	)
+	if d.SearchPath != "" {
+		conn += " search_path=" + quoteConnValue(d.SearchPath)
+	}
+	return conn
 }
 
 // RedisConfig holds Redis configuration
@@ -130,6 +173,9 @@ type WorkerConfig struct {
 	MaxConcurrencyPerTenant int
 	HealthPort              string
 	QueueURL                string
+	// DrainTimeout is how long shutdown waits for running executions before
+	// checkpointing them for another worker to resume
+	DrainTimeout time.Duration
 }
 
 // AWSConfig holds AWS configuration
@@ -168,6 +214,29 @@ type CleanupConfig struct {
 	Schedule string
 }
 
+// OAuthCleanupConfig holds configuration for sweeping stale OAuth states
+type OAuthCleanupConfig struct {
+	// Enabled indicates whether the worker deletes stale OAuth states
+	Enabled bool
+	// Interval is how often stale states are deleted (default: 15m)
+	Interval time.Duration
+	// UsedGracePeriod is how long used states are kept after creation (default: 5m)
+	UsedGracePeriod time.Duration
+}
+
+// WebhookRetryConfig holds background redelivery configuration for webhook
+// events whose workflow execution failed
+type WebhookRetryConfig struct {
+	// Enabled indicates whether failed events are redelivered
+	Enabled bool
+	// Interval is how often the worker polls for due retries (default: 30s)
+	Interval time.Duration
+	// BaseDelay is the delay before the first retry, doubled on each further retry (default: 30s)
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries (default: 1h)
+	MaxDelay time.Duration
+}
+
 // RetentionConfig holds execution retention policy configuration
 type RetentionConfig struct {
 	// Enabled indicates whether retention cleanup is enabled
@@ -182,6 +251,14 @@ type RetentionConfig struct {
 	EnableAuditLog bool
 	// ArchiveBeforeDelete enables archiving executions before deletion (default: true)
 	ArchiveBeforeDelete bool
+	// ColdArchiveEnabled writes executions to an S3-compatible bucket as
+	// gzipped JSON before deletion, instead of the execution_archives table.
+	// The AWS region, credentials and endpoint are used to connect.
+	ColdArchiveEnabled bool
+	// ColdArchiveBucket is the bucket executions are archived to
+	ColdArchiveBucket string
+	// ColdArchivePrefix is the key prefix for archives and manifests (default: "execution-archives")
+	ColdArchivePrefix string
 }
 
 // ObservabilityConfig holds observability configuration
@@ -195,6 +272,12 @@ type ObservabilityConfig struct {
 	TracingEndpoint    string // OTLP endpoint (e.g., "localhost:4317")
 	TracingSampleRate  float64
 	TracingServiceName string
+	// TracingExporters lists the destinations spans are sent to at the same
+	// time. When empty, spans go to the OTLP collector at TracingEndpoint.
+	TracingExporters []TracingExporterConfig
+	// TracingSampleRates overrides TracingSampleRate for traces started by a
+	// signal, such as "webhook" or "http"
+	TracingSampleRates map[string]float64
 
 	// Error tracking configuration
 	SentryEnabled     bool
@@ -274,6 +357,37 @@ type SSRFConfig struct {
 	// BlockedNetworks are additional CIDR ranges to block beyond defaults
 	// Example: "203.0.113.0/24" to block a specific public range
 	BlockedNetworks []string
+	// AllowedHosts are hostnames that may resolve to blocked ranges, to opt
+	// in to internal targets. Example: "*.svc.cluster.local"
+	AllowedHosts []string
+	// BlockedHosts are hostnames that are always refused
+	// Example: "metadata.google.internal"
+	BlockedHosts []string
+}
+
+// ProtectionEnabled reports whether SSRF protection applies in the given
+// environment. It cannot be turned off in production.
+func (c SSRFConfig) ProtectionEnabled(env string) bool {
+	return c.Enabled || env == "production"
+}
+
+// HTTPActionConfig holds settings that constrain action:http nodes
+type HTTPActionConfig struct {
+	// AllowInsecureTLS permits nodes to set tls_skip_verify (default: false).
+	// It is rejected by production validation and ignored when APP_ENV=production.
+	AllowInsecureTLS bool
+
+	// MaxResponseBytes is the default response body limit for nodes without
+	// max_response_bytes and the ceiling for nodes with it (default: 10 MiB)
+	MaxResponseBytes int64
+
+	// MaxResponseHeaders caps the number of response headers stored per step (default: 100)
+	MaxResponseHeaders int
+}
+
+// InsecureTLSAllowed reports whether nodes may skip TLS verification in the given environment
+func (c HTTPActionConfig) InsecureTLSAllowed(env string) bool {
+	return c.AllowInsecureTLS && env != "production"
 }
 
 // FormulaCacheConfig holds formula evaluator cache configuration
@@ -300,6 +414,9 @@ type OAuthConfig struct {
 	// Slack OAuth application credentials
 	SlackClientID     string
 	SlackClientSecret string
+	// SlackSigningSecret verifies interaction requests from the Slack app;
+	// message buttons are disabled without it
+	SlackSigningSecret string
 	// Microsoft OAuth application credentials
 	MicrosoftClientID     string
 	MicrosoftClientSecret string
@@ -317,27 +434,32 @@ type OAuthConfig struct {
 	Auth0Domain       string
 	Auth0ClientID     string
 	Auth0ClientSecret string
+	// ClockSkewTolerance is how far clocks of the API and worker nodes may
+	// differ when checking OAuth state and token expiry (default: 30s)
+	ClockSkewTolerance time.Duration
+	// TokenExpiryMargin is taken off a token's expires_in so it is refreshed
+	// before the provider expires it (default: 30s)
+	TokenExpiryMargin time.Duration
 }
 
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
+	database, err := LoadDatabaseConfig(os.Getenv("DATABASE_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	tracingSampleRates, err := parseSampleRates(getEnv("TRACING_SAMPLE_RATES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRACING_SAMPLE_RATES: %w", err)
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
 			Address: getEnv("SERVER_ADDRESS", ":8080"),
 			Env:     getEnv("APP_ENV", "development"),
 		},
-		Database: DatabaseConfig{
-			Host:            getEnv("DB_HOST", "localhost"),
-			Port:            getEnvAsInt("DB_PORT", 5433),
-			User:            getEnv("DB_USER", "postgres"),
-			Password:        getEnv("DB_PASSWORD", "postgres"),
-			DBName:          getEnv("DB_NAME", "gorax"),
-			SSLMode:         getEnv("DB_SSLMODE", "disable"),
-			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 150),
-			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 25),
-			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
-			ConnMaxIdleTime: getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
-		},
+		Database: database,
 		Redis: RedisConfig{
 			Address:  getEnv("REDIS_ADDRESS", "localhost:6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
@@ -352,6 +474,7 @@ func Load() (*Config, error) {
 			MaxConcurrencyPerTenant: getEnvAsInt("WORKER_MAX_CONCURRENCY_PER_TENANT", 10),
 			HealthPort:              getEnv("WORKER_HEALTH_PORT", "8081"),
 			QueueURL:                getEnv("WORKER_QUEUE_URL", ""),
+			DrainTimeout:            getEnvAsDuration("WORKER_DRAIN_TIMEOUT", 30*time.Second),
 		},
 		AWS: AWSConfig{
 			Region:          getEnv("AWS_REGION", "us-east-1"),
@@ -379,7 +502,12 @@ func Load() (*Config, error) {
 			UseKMS:    getEnvAsBool("CREDENTIAL_USE_KMS", false),
 			KMSKeyID:  getEnv("CREDENTIAL_KMS_KEY_ID", ""),
 			// KMSRegion defaults to AWS_REGION if not explicitly set
-			KMSRegion: getEnvWithFallback("CREDENTIAL_KMS_REGION", "AWS_REGION", "us-east-1"),
+			KMSRegion:          getEnvWithFallback("CREDENTIAL_KMS_REGION", "AWS_REGION", "us-east-1"),
+			UsageInterval:      getEnvAsDuration("CREDENTIAL_USAGE_INTERVAL", 5*time.Minute),
+			UsageFlushInterval: getEnvAsDuration("CREDENTIAL_USAGE_FLUSH_INTERVAL", 30*time.Second),
+			RecoveryEnabled:    getEnvAsBool("CREDENTIAL_RECOVERY_ENABLED", false),
+			DeletedRetention:   getEnvAsDuration("CREDENTIAL_DELETED_RETENTION", 7*24*time.Hour),
+			PurgeInterval:      getEnvAsDuration("CREDENTIAL_PURGE_INTERVAL", time.Hour),
 		},
 		Cleanup: CleanupConfig{
 			Enabled:       getEnvAsBool("CLEANUP_ENABLED", true),
@@ -387,6 +515,17 @@ func Load() (*Config, error) {
 			BatchSize:     getEnvAsInt("CLEANUP_BATCH_SIZE", 1000),
 			Schedule:      getEnv("CLEANUP_SCHEDULE", "0 0 * * *"), // Daily at midnight
 		},
+		WebhookRetry: WebhookRetryConfig{
+			Enabled:   getEnvAsBool("WEBHOOK_RETRY_ENABLED", true),
+			Interval:  getEnvAsDuration("WEBHOOK_RETRY_INTERVAL", 30*time.Second),
+			BaseDelay: getEnvAsDuration("WEBHOOK_RETRY_BASE_DELAY", 30*time.Second),
+			MaxDelay:  getEnvAsDuration("WEBHOOK_RETRY_MAX_DELAY", time.Hour),
+		},
+		OAuthCleanup: OAuthCleanupConfig{
+			Enabled:         getEnvAsBool("OAUTH_STATE_CLEANUP_ENABLED", true),
+			Interval:        getEnvAsDuration("OAUTH_STATE_CLEANUP_INTERVAL", 15*time.Minute),
+			UsedGracePeriod: getEnvAsDuration("OAUTH_STATE_CLEANUP_USED_GRACE_PERIOD", 5*time.Minute),
+		},
 		Retention: RetentionConfig{
 			Enabled:              getEnvAsBool("RETENTION_ENABLED", true),
 			DefaultRetentionDays: getEnvAsInt("RETENTION_DEFAULT_DAYS", 90),
@@ -394,6 +533,9 @@ func Load() (*Config, error) {
 			RunInterval:          getEnv("RETENTION_RUN_INTERVAL", "24h"),
 			EnableAuditLog:       getEnvAsBool("RETENTION_ENABLE_AUDIT_LOG", true),
 			ArchiveBeforeDelete:  getEnvAsBool("RETENTION_ARCHIVE_BEFORE_DELETE", true),
+			ColdArchiveEnabled:   getEnvAsBool("RETENTION_COLD_ARCHIVE_ENABLED", false),
+			ColdArchiveBucket:    getEnv("RETENTION_COLD_ARCHIVE_BUCKET", ""),
+			ColdArchivePrefix:    getEnv("RETENTION_COLD_ARCHIVE_PREFIX", "execution-archives"),
 		},
 		Observability: ObservabilityConfig{
 			MetricsEnabled:     getEnvAsBool("METRICS_ENABLED", true),
@@ -402,6 +544,8 @@ func Load() (*Config, error) {
 			TracingEndpoint:    getEnv("TRACING_ENDPOINT", "localhost:4317"),
 			TracingSampleRate:  getEnvAsFloat("TRACING_SAMPLE_RATE", 1.0),
 			TracingServiceName: getEnv("TRACING_SERVICE_NAME", "gorax"),
+			TracingExporters:   loadTracingExporters(),
+			TracingSampleRates: tracingSampleRates,
 			SentryEnabled:      getEnvAsBool("SENTRY_ENABLED", false),
 			SentryDSN:          getEnv("SENTRY_DSN", ""),
 			SentryEnvironment:  getEnv("SENTRY_ENVIRONMENT", "development"),
@@ -437,13 +581,19 @@ func Load() (*Config, error) {
 			MaxTokens:   getEnvAsInt("AI_BUILDER_MAX_TOKENS", 4096),
 			Temperature: getEnvAsFloat("AI_BUILDER_TEMPERATURE", 0.7),
 		},
-		WebSocket:    loadWebSocketConfig(),
-		SSRF:         loadSSRFConfig(),
-		FormulaCache: loadFormulaCacheConfig(),
-		OAuth:        loadOAuthConfig(),
-		Audit:        loadAuditConfig(),
-		Log:          loadLogConfig(),
-		Tenant:       loadTenantConfig(),
+		WebSocket:     loadWebSocketConfig(),
+		SSRF:          loadSSRFConfig(),
+		HTTPAction:    loadHTTPActionConfig(),
+		FormulaCache:  loadFormulaCacheConfig(),
+		OAuth:         loadOAuthConfig(),
+		Audit:         loadAuditConfig(),
+		Log:           loadLogConfig(),
+		Tenant:        loadTenantConfig(),
+		RateLimit:     loadRateLimitConfig(),
+		Pagination:    loadPaginationConfig(),
+		Workflow:      loadWorkflowConfig(),
+		Redaction:     loadRedactionConfig(),
+		DataResidency: loadDataResidencyConfig(),
 	}
 
 	return cfg, nil
@@ -617,6 +767,22 @@ func loadSSRFConfig() SSRFConfig {
 		// BlockedNetworks can be configured to block additional networks
 		// Example: SSRF_BLOCKED_NETWORKS=203.0.113.0/24
 		BlockedNetworks: getEnvAsSlice("SSRF_BLOCKED_NETWORKS", []string{}),
+		// AllowedHosts opts specific internal hostnames in
+		// Example: SSRF_ALLOWED_HOSTS=*.svc.cluster.local,vault.internal
+		AllowedHosts: getEnvAsSlice("SSRF_ALLOWED_HOSTS", []string{}),
+		// BlockedHosts refuses specific hostnames wherever they resolve
+		// Example: SSRF_BLOCKED_HOSTS=metadata.google.internal
+		BlockedHosts: getEnvAsSlice("SSRF_BLOCKED_HOSTS", []string{}),
+	}
+}
+
+func loadHTTPActionConfig() HTTPActionConfig {
+	return HTTPActionConfig{
+		// Skipping TLS verification is opt-in and intended for development only
+		AllowInsecureTLS: getEnvAsBool("HTTP_ACTION_ALLOW_INSECURE_TLS", false),
+		// Guards workers against buffering huge response bodies into memory
+		MaxResponseBytes:   getEnvAsInt64("HTTP_ACTION_MAX_RESPONSE_BYTES", 10*1024*1024),
+		MaxResponseHeaders: getEnvAsInt("HTTP_ACTION_MAX_RESPONSE_HEADERS", 100),
 	}
 }
 
@@ -651,6 +817,7 @@ func loadOAuthConfig() OAuthConfig {
 		GoogleClientSecret:     getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
 		SlackClientID:          getEnv("OAUTH_SLACK_CLIENT_ID", ""),
 		SlackClientSecret:      getEnv("OAUTH_SLACK_CLIENT_SECRET", ""),
+		SlackSigningSecret:     getEnv("SLACK_SIGNING_SECRET", ""),
 		MicrosoftClientID:      getEnv("OAUTH_MICROSOFT_CLIENT_ID", ""),
 		MicrosoftClientSecret:  getEnv("OAUTH_MICROSOFT_CLIENT_SECRET", ""),
 		TwitterClientID:        getEnv("OAUTH_TWITTER_CLIENT_ID", ""),
@@ -663,6 +830,8 @@ func loadOAuthConfig() OAuthConfig {
 		Auth0Domain:            getEnv("OAUTH_AUTH0_DOMAIN", "your-tenant.auth0.com"),
 		Auth0ClientID:          getEnv("OAUTH_AUTH0_CLIENT_ID", ""),
 		Auth0ClientSecret:      getEnv("OAUTH_AUTH0_CLIENT_SECRET", ""),
+		ClockSkewTolerance:     getEnvAsDuration("OAUTH_CLOCK_SKEW_TOLERANCE", 30*time.Second),
+		TokenExpiryMargin:      getEnvAsDuration("OAUTH_TOKEN_EXPIRY_MARGIN", 30*time.Second),
 	}
 }
 
@@ -706,17 +875,252 @@ func loadAuditConfig() AuditConfig {
 type LogConfig struct {
 	// Level is the minimum log level (debug, info, warn, error)
 	Level string
+	// LevelFile optionally names a file holding the log level. When set and
+	// non-empty it overrides LOG_LEVEL, and it is re-read on SIGHUP so the
+	// level can change without a restart
+	LevelFile string
 	// HTTPLogLevel is the log level for HTTP access logs (debug, info, warn, error)
 	// Set to "debug" to reduce noise from successful requests in development
 	HTTPLogLevel string
+	// HTTPLogHeaders adds request headers, with secrets redacted, to HTTP access logs
+	HTTPLogHeaders bool
 	// Format is the log format (json or text)
 	Format string
 }
 
 func loadLogConfig() LogConfig {
-	return LogConfig{
-		Level:        getEnv("LOG_LEVEL", "info"),
-		HTTPLogLevel: getEnv("HTTP_LOG_LEVEL", "debug"),
-		Format:       getEnv("LOG_FORMAT", "json"),
+	cfg := LogConfig{
+		Level:          getEnv("LOG_LEVEL", "info"),
+		LevelFile:      getEnv("LOG_LEVEL_FILE", ""),
+		HTTPLogLevel:   getEnv("HTTP_LOG_LEVEL", "debug"),
+		HTTPLogHeaders: getEnvAsBool("HTTP_LOG_HEADERS", false),
+		Format:         getEnv("LOG_FORMAT", "json"),
+	}
+
+	if cfg.LevelFile != "" {
+		// A missing or empty file keeps LOG_LEVEL
+		if data, err := os.ReadFile(cfg.LevelFile); err == nil {
+			if level := strings.TrimSpace(string(data)); level != "" {
+				cfg.Level = level
+			}
+		}
+	}
+
+	return cfg
+}
+
+// RateLimitConfig holds per-tenant API rate limiting configuration
+type RateLimitConfig struct {
+	// Enabled controls whether API requests are rate limited per tenant (default: true)
+	Enabled bool
+	// Backend is where token buckets are kept: "redis" to share limits across
+	// instances or "memory" for a single instance (default: redis)
+	Backend string
+	// RequestsPerMinute applies to tenants whose quotas do not set
+	// max_api_calls_per_minute (default: 60)
+	RequestsPerMinute int
+	// TenantRequestsPerMinute overrides the quota stored on specific tenants.
+	// Format: "tenant-a=600,tenant-b=-1" where -1 means unlimited
+	TenantRequestsPerMinute map[string]int
+	// ExemptPaths are path prefixes that are never rate limited (default: /health,/ready)
+	ExemptPaths []string
+}
+
+func loadRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Enabled:                 getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		Backend:                 getEnv("RATE_LIMIT_BACKEND", "redis"),
+		RequestsPerMinute:       getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 60),
+		TenantRequestsPerMinute: parseTenantLimits(getEnv("RATE_LIMIT_TENANT_OVERRIDES", "")),
+		ExemptPaths:             getEnvAsSlice("RATE_LIMIT_EXEMPT_PATHS", []string{"/health", "/ready"}),
+	}
+}
+
+// PaginationConfig holds cursor pagination configuration
+type PaginationConfig struct {
+	// CursorSecret signs list cursors. All instances must share it, and
+	// production validation requires it; if empty a random key is used and
+	// cursors stop working after a restart
+	CursorSecret string
+	// CursorTTL is how long a cursor stays valid (default: 24h)
+	CursorTTL time.Duration
+}
+
+func loadPaginationConfig() PaginationConfig {
+	return PaginationConfig{
+		CursorSecret: getEnv("PAGINATION_CURSOR_SECRET", ""),
+		CursorTTL:    getEnvAsDuration("PAGINATION_CURSOR_TTL", 24*time.Hour),
+	}
+}
+
+// WorkflowConfig holds workflow save-time validation configuration
+type WorkflowConfig struct {
+	// StrictReferences rejects workflows whose env or credential references
+	// will not resolve; otherwise they are saved and returned as warnings (default: false)
+	StrictReferences bool
+	// MaxExecutionDuration is the longest any execution may run; workflows can
+	// set a lower max_execution_duration. Zero disables the ceiling (default: 24h)
+	MaxExecutionDuration time.Duration
+	// NodeCacheCleanupInterval is how often the worker removes expired cached
+	// node results. Zero disables the cleanup (default: 10m)
+	NodeCacheCleanupInterval time.Duration
+}
+
+func loadWorkflowConfig() WorkflowConfig {
+	return WorkflowConfig{
+		StrictReferences:         getEnvAsBool("WORKFLOW_STRICT_REFERENCES", false),
+		MaxExecutionDuration:     getEnvAsDuration("WORKFLOW_MAX_EXECUTION_DURATION", 24*time.Hour),
+		NodeCacheCleanupInterval: getEnvAsDuration("WORKFLOW_NODE_CACHE_CLEANUP_INTERVAL", 10*time.Minute),
+	}
+}
+
+// RedactionConfig holds settings for redacting secrets from persisted step data
+type RedactionConfig struct {
+	// SensitiveKeys are header and field names whose values are redacted from
+	// step inputs and outputs, matched case-insensitively. When empty the
+	// executor's built-in list is used; when set it replaces that list.
+	SensitiveKeys []string
+}
+
+func loadRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		SensitiveKeys: getEnvAsSlice("STEP_REDACTION_SENSITIVE_KEYS", nil),
+	}
+}
+
+// parseTenantLimits parses "tenant=limit" pairs separated by commas,
+// skipping malformed entries
+func parseTenantLimits(value string) map[string]int {
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		tenantID, limit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || tenantID == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil {
+			continue
+		}
+		limits[strings.TrimSpace(tenantID)] = n
+	}
+	return limits
+}
+
+// DataResidencyConfig holds the data regions tenants can be pinned to
+type DataResidencyConfig struct {
+	// Regions lists the configured data regions. Tenants without a region
+	// use the default KMS key and database.
+	Regions []DataRegionConfig
+}
+
+// RegionNames returns the names of the configured data regions
+func (c DataResidencyConfig) RegionNames() []string {
+	names := make([]string, 0, len(c.Regions))
+	for _, region := range c.Regions {
+		names = append(names, region.Name)
+	}
+	return names
+}
+
+// DataRegionConfig holds the stores of one data region
+type DataRegionConfig struct {
+	// Name is the region tenants are assigned, such as "eu"
+	Name string
+	// KMSKeyID is the KMS key credentials of the region's tenants are
+	// encrypted with. Without it, those tenants cannot store or read
+	// credentials, rather than having them encrypted outside the region.
+	KMSKeyID string
+	// KMSRegion is the AWS region of KMSKeyID
+	KMSRegion string
+	// DatabaseURL is the database executions of the region's tenants are
+	// stored in. Without it, those tenants cannot run workflows.
+	DatabaseURL string
+}
+
+// loadDataResidencyConfig reads DATA_RESIDENCY_REGIONS and, for each region,
+// DATA_RESIDENCY_<REGION>_KMS_KEY_ID, DATA_RESIDENCY_<REGION>_KMS_REGION and
+// DATA_RESIDENCY_<REGION>_DATABASE_URL, where <REGION> is the upper-cased
+// name with dashes replaced by underscores
+func loadDataResidencyConfig() DataResidencyConfig {
+	var cfg DataResidencyConfig
+	for _, name := range getEnvAsSlice("DATA_RESIDENCY_REGIONS", nil) {
+		prefix := "DATA_RESIDENCY_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg.Regions = append(cfg.Regions, DataRegionConfig{
+			Name:        name,
+			KMSKeyID:    getEnv(prefix+"KMS_KEY_ID", ""),
+			KMSRegion:   getEnvWithFallback(prefix+"KMS_REGION", "AWS_REGION", "us-east-1"),
+			DatabaseURL: getEnv(prefix+"DATABASE_URL", ""),
+		})
+	}
+	return cfg
+}
+
+// TracingExporterConfig holds one destination spans are exported to
+type TracingExporterConfig struct {
+	// Name identifies the exporter in logs and errors
+	Name string
+	// Type is "otlp" for an OTLP gRPC collector or "stdout" for debugging
+	Type string
+	// Endpoint is the OTLP collector address, such as "localhost:4317"
+	Endpoint string
+	// Insecure connects to the collector without TLS
+	Insecure bool
+	// Headers are sent with every OTLP export, such as an API key
+	Headers map[string]string
+}
+
+// loadTracingExporters reads TRACING_EXPORTERS and, for each exporter,
+// TRACING_EXPORTER_<NAME>_TYPE, TRACING_EXPORTER_<NAME>_ENDPOINT,
+// TRACING_EXPORTER_<NAME>_INSECURE and TRACING_EXPORTER_<NAME>_HEADERS,
+// where <NAME> is the upper-cased name with dashes replaced by underscores
+func loadTracingExporters() []TracingExporterConfig {
+	var exporters []TracingExporterConfig
+	for _, name := range getEnvAsSlice("TRACING_EXPORTERS", nil) {
+		prefix := "TRACING_EXPORTER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		exporters = append(exporters, TracingExporterConfig{
+			Name:     name,
+			Type:     getEnv(prefix+"TYPE", "otlp"),
+			Endpoint: getEnv(prefix+"ENDPOINT", ""),
+			Insecure: getEnvAsBool(prefix+"INSECURE", true),
+			Headers:  parseHeaderPairs(getEnv(prefix+"HEADERS", "")),
+		})
+	}
+	return exporters
+}
+
+// parseSampleRates parses "signal=rate" pairs separated by commas. Unlike
+// most settings a malformed entry is an error, since silently sampling at
+// the wrong rate would go unnoticed.
+func parseSampleRates(value string) (map[string]float64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		signal, rate, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		signal = strings.TrimSpace(signal)
+		if !ok || signal == "" {
+			return nil, fmt.Errorf("%q is not of the form signal=rate", pair)
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil {
+			return nil, fmt.Errorf("sample rate for %s is not a number: %q", signal, rate)
+		}
+		rates[signal] = n
+	}
+	return rates, nil
+}
+
+// parseHeaderPairs parses "name=value" pairs separated by commas, skipping
+// malformed entries. Values may contain "=".
+func parseHeaderPairs(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		name, headerValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
 	}
+	return headers
 }
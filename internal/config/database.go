@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DatabaseAuthModeStatic authenticates with the configured password
+	DatabaseAuthModeStatic = "static"
+	// DatabaseAuthModeIAM authenticates with short-lived AWS RDS IAM auth tokens
+	DatabaseAuthModeIAM = "iam"
+
+	// MaxIAMTokenRefreshInterval is the longest an IAM auth token may be
+	// reused; RDS accepts a token for 15 minutes after it is signed
+	MaxIAMTokenRefreshInterval = 15 * time.Minute
+)
+
+// LoadDatabaseConfig reads the database configuration from the DB_*
+// environment variables. Components of databaseURL, when it is set, take
+// precedence over DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME and
+// DB_SSLMODE, so DATABASE_URL and the migrate -db flag can be used on their own.
+func LoadDatabaseConfig(databaseURL string) (DatabaseConfig, error) {
+	cfg := DatabaseConfig{
+		Host:                 getEnv("DB_HOST", "localhost"),
+		Port:                 getEnvAsInt("DB_PORT", 5433),
+		User:                 getEnv("DB_USER", "postgres"),
+		Password:             getEnv("DB_PASSWORD", "postgres"),
+		DBName:               getEnv("DB_NAME", "gorax"),
+		SSLMode:              getEnv("DB_SSLMODE", "disable"),
+		MaxOpenConns:         getEnvAsInt("DB_MAX_OPEN_CONNS", 150),
+		MaxIdleConns:         getEnvAsInt("DB_MAX_IDLE_CONNS", 25),
+		ConnMaxLifetime:      getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		ConnMaxIdleTime:      getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 10*time.Minute),
+		AuthMode:             strings.ToLower(getEnv("DB_AUTH_MODE", DatabaseAuthModeStatic)),
+		IAMRegion:            getEnvWithFallback("DB_IAM_REGION", "AWS_REGION", "us-east-1"),
+		TokenRefreshInterval: getEnvAsDuration("DB_TOKEN_REFRESH_INTERVAL", 10*time.Minute),
+	}
+
+	if databaseURL != "" {
+		if err := applyDatabaseURL(&cfg, databaseURL); err != nil {
+			return DatabaseConfig{}, err
+		}
+	}
+
+	switch cfg.AuthMode {
+	case DatabaseAuthModeStatic:
+	case DatabaseAuthModeIAM:
+		if cfg.SSLMode == "disable" {
+			return DatabaseConfig{}, fmt.Errorf("DB_AUTH_MODE=iam requires SSL; set DB_SSLMODE to require, verify-ca or verify-full")
+		}
+		if cfg.TokenRefreshInterval <= 0 || cfg.TokenRefreshInterval >= MaxIAMTokenRefreshInterval {
+			return DatabaseConfig{}, fmt.Errorf("DB_TOKEN_REFRESH_INTERVAL must be between 0 and %s, got %s", MaxIAMTokenRefreshInterval, cfg.TokenRefreshInterval)
+		}
+	default:
+		return DatabaseConfig{}, fmt.Errorf("invalid DB_AUTH_MODE %q: must be %q or %q", cfg.AuthMode, DatabaseAuthModeStatic, DatabaseAuthModeIAM)
+	}
+
+	return cfg, nil
+}
+
+// applyDatabaseURL overrides cfg with the components present in a
+// postgres:// URL
+func applyDatabaseURL(cfg *DatabaseConfig, databaseURL string) error {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("invalid DATABASE_URL: scheme must be postgres or postgresql, got %q", u.Scheme)
+	}
+
+	if host := u.Hostname(); host != "" {
+		cfg.Host = host
+	}
+	if portValue := u.Port(); portValue != "" {
+		port, err := strconv.Atoi(portValue)
+		if err != nil {
+			return fmt.Errorf("invalid DATABASE_URL port %q", portValue)
+		}
+		cfg.Port = port
+	}
+	if u.User != nil {
+		if user := u.User.Username(); user != "" {
+			cfg.User = user
+		}
+		if password, ok := u.User.Password(); ok {
+			cfg.Password = password
+		}
+	}
+	if name := strings.TrimPrefix(u.Path, "/"); name != "" {
+		cfg.DBName = name
+	}
+	if sslMode := u.Query().Get("sslmode"); sslMode != "" {
+		cfg.SSLMode = sslMode
+	}
+	if searchPath := u.Query().Get("search_path"); searchPath != "" {
+		cfg.SearchPath = searchPath
+	}
+
+	return nil
+}
+
+// quoteConnValue quotes a value for a key=value PostgreSQL connection string
+func quoteConnValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n'\\") {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
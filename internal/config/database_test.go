@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDatabaseConfig_URL(t *testing.T) {
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("DB_PASSWORD", "env-password")
+
+	cfg, err := LoadDatabaseConfig("postgres://gorax@db.example.com:6432/gorax_prod?sslmode=require")
+	require.NoError(t, err)
+
+	assert.Equal(t, "db.example.com", cfg.Host)
+	assert.Equal(t, 6432, cfg.Port)
+	assert.Equal(t, "gorax", cfg.User)
+	assert.Equal(t, "env-password", cfg.Password, "components missing from the URL fall back to DB_*")
+	assert.Equal(t, "gorax_prod", cfg.DBName)
+	assert.Equal(t, "require", cfg.SSLMode)
+	assert.Equal(t, DatabaseAuthModeStatic, cfg.AuthMode)
+	assert.Empty(t, cfg.SearchPath)
+
+	cfg, err = LoadDatabaseConfig("postgres://gorax@db.example.com/gorax?search_path=region_eu,public")
+	require.NoError(t, err)
+	assert.Equal(t, "region_eu,public", cfg.SearchPath)
+}
+
+func TestLoadDatabaseConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		url       string
+		wantError string
+	}{
+		{
+			name:      "unsupported scheme",
+			url:       "mysql://user@host/db",
+			wantError: "scheme must be postgres",
+		},
+		{
+			name:      "invalid auth mode",
+			env:       map[string]string{"DB_AUTH_MODE": "kerberos"},
+			wantError: `invalid DB_AUTH_MODE "kerberos"`,
+		},
+		{
+			name:      "iam without ssl",
+			env:       map[string]string{"DB_AUTH_MODE": "iam"},
+			url:       "postgres://gorax@db.example.com/gorax?sslmode=disable",
+			wantError: "requires SSL",
+		},
+		{
+			name:      "iam refresh interval past token lifetime",
+			env:       map[string]string{"DB_AUTH_MODE": "iam", "DB_TOKEN_REFRESH_INTERVAL": "20m"},
+			url:       "postgres://gorax@db.example.com/gorax?sslmode=require",
+			wantError: "DB_TOKEN_REFRESH_INTERVAL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			_, err := LoadDatabaseConfig(tt.url)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestLoadDatabaseConfig_IAM(t *testing.T) {
+	t.Setenv("DB_AUTH_MODE", "IAM")
+	t.Setenv("DB_IAM_REGION", "eu-west-1")
+
+	cfg, err := LoadDatabaseConfig("postgres://gorax@db.example.com:5432/gorax?sslmode=verify-full")
+	require.NoError(t, err)
+
+	assert.Equal(t, DatabaseAuthModeIAM, cfg.AuthMode)
+	assert.Equal(t, "eu-west-1", cfg.IAMRegion)
+	assert.Equal(t, 10*time.Minute, cfg.TokenRefreshInterval)
+}
+
+func TestDatabaseConfig_ConnectionStringWithPassword(t *testing.T) {
+	cfg := DatabaseConfig{Host: "db.example.com", Port: 5432, User: "gorax", DBName: "gorax", SSLMode: "require"}
+
+	assert.Equal(t,
+		"host=db.example.com port=5432 user=gorax password=tok=en&x dbname=gorax sslmode=require",
+		cfg.ConnectionStringWithPassword("tok=en&x"),
+	)
+	assert.Equal(t,
+		`host=db.example.com port=5432 user=gorax password='it\'s a \\secret' dbname=gorax sslmode=require`,
+		cfg.ConnectionStringWithPassword(`it's a \secret`),
+	)
+	assert.Contains(t, cfg.ConnectionStringWithPassword(""), "password='' ")
+
+	cfg.SearchPath = "region_eu, public"
+	assert.Equal(t,
+		"host=db.example.com port=5432 user=gorax password=x dbname=gorax sslmode=require search_path='region_eu, public'",
+		cfg.ConnectionStringWithPassword("x"),
+	)
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// ParseLogLevel converts a log level name to slog.Level, defaulting to info
+func ParseLogLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		// Default to info if invalid level specified
+		return slog.LevelInfo
+	}
+}
+
+// WatchLogLevel reloads the configuration on SIGHUP and applies its log
+// level to level. It returns when ctx is cancelled.
+func WatchLogLevel(ctx context.Context, level *slog.LevelVar, logger *slog.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			reloadLogLevel(level, logger)
+		}
+	}
+}
+
+// reloadLogLevel loads the configuration and updates level if it changed
+func reloadLogLevel(level *slog.LevelVar, logger *slog.Logger) {
+	cfg, err := Load()
+	if err != nil {
+		logger.Error("failed to reload configuration, keeping log level", "error", err, "level", level.Level().String())
+		return
+	}
+
+	previous := level.Level()
+	next := ParseLogLevel(cfg.Log.Level)
+	if next == previous {
+		logger.Info("configuration reloaded, log level unchanged", "level", next.String())
+		return
+	}
+
+	level.Set(next)
+	// Logged at warn so the change is visible whichever way the level moved
+	logger.Warn("log level changed", "from", previous.String(), "to", next.String())
+}
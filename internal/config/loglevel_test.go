@@ -0,0 +1,41 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLogLevel("debug"))
+	assert.Equal(t, slog.LevelWarn, ParseLogLevel("WARN"))
+	assert.Equal(t, slog.LevelError, ParseLogLevel("error"))
+	assert.Equal(t, slog.LevelInfo, ParseLogLevel("verbose"))
+}
+
+func TestReloadLogLevel(t *testing.T) {
+	levelFile := filepath.Join(t.TempDir(), "log-level")
+	require.NoError(t, os.WriteFile(levelFile, []byte("debug\n"), 0o600))
+	t.Setenv("LOG_LEVEL", "info")
+	t.Setenv("LOG_LEVEL_FILE", levelFile)
+
+	var level slog.LevelVar
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, nil))
+
+	reloadLogLevel(&level, logger)
+
+	assert.Equal(t, slog.LevelDebug, level.Level())
+	assert.Contains(t, output.String(), "log level changed")
+
+	// Emptying the file falls back to LOG_LEVEL
+	require.NoError(t, os.WriteFile(levelFile, nil, 0o600))
+	reloadLogLevel(&level, logger)
+
+	assert.Equal(t, slog.LevelInfo, level.Level())
+}
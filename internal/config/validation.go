@@ -22,192 +22,224 @@ var weakPasswords = []string{
 	"guest",
 }
 
-// ValidateForProduction validates that configuration is suitable for production use.
-// It checks for insecure settings, weak secrets, and development configurations
-// that should never be used in production environments.
-func ValidateForProduction(cfg *Config) error {
-	var errors []string
+// FieldError is a single configuration problem and the config field path it
+// concerns, such as database.ssl_mode
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
 
-	// Validate environment setting
-	if err := validateEnvironment(cfg); err != nil {
-		errors = append(errors, err.Error())
+// ValidationErrors collects every problem found while validating a
+// configuration so that they can all be fixed in one pass
+type ValidationErrors []*FieldError
+
+// Error lists every problem on its own line
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, fieldErr := range e {
+		lines[i] = fieldErr.Error()
 	}
+	return fmt.Sprintf("production configuration validation failed:\n  - %s", strings.Join(lines, "\n  - "))
+}
 
-	// Validate credential security
-	if err := validateCredentials(cfg); err != nil {
-		errors = append(errors, err.Error())
+// Unwrap returns the individual field errors for errors.Is and errors.As
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
 	}
+	return errs
+}
 
-	// Validate database security
-	if err := validateDatabase(cfg); err != nil {
-		errors = append(errors, err.Error())
+// add records a problem with field
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateForProduction validates that configuration is suitable for production use.
+// It checks for insecure settings, weak secrets, and development configurations
+// that should never be used in production environments. Every problem is
+// reported; the returned error is a ValidationErrors.
+func ValidateForProduction(cfg *Config) error {
+	var errs ValidationErrors
+
+	validateEnvironment(cfg, &errs)
+	validateCredentials(cfg, &errs)
+	validateDatabase(cfg, &errs)
+	validateServiceURLs(cfg, &errs)
+	validateNotifications(cfg, &errs)
+	validateDataResidency(cfg, &errs)
+
+	// A per-process cursor key breaks pagination across replicas and restarts
+	if cfg.Pagination.CursorSecret == "" {
+		errs.add("pagination.cursor_secret", "PAGINATION_CURSOR_SECRET is required so list cursors work across instances and restarts")
 	}
 
-	// Validate external service URLs
-	if err := validateServiceURLs(cfg); err != nil {
-		errors = append(errors, err.Error())
+	if cfg.Retention.ColdArchiveEnabled && cfg.Retention.ColdArchiveBucket == "" {
+		errs.add("retention.cold_archive_bucket", "RETENTION_COLD_ARCHIVE_BUCKET is required when RETENTION_COLD_ARCHIVE_ENABLED is true")
 	}
 
-	// Validate notification configuration
-	if err := validateNotifications(cfg); err != nil {
-		errors = append(errors, err.Error())
+	// Validate HTTP action security
+	if cfg.HTTPAction.AllowInsecureTLS {
+		errs.add("http_action.allow_insecure_tls", "HTTP_ACTION_ALLOW_INSECURE_TLS must not be enabled in production")
 	}
 
 	// Log warnings for optional but recommended settings
 	logProductionWarnings(cfg)
 
-	if len(errors) > 0 {
-		return fmt.Errorf("production configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
+	if len(errs) > 0 {
+		return errs
 	}
 
 	slog.Info("production configuration validated successfully")
 	return nil
 }
 
-func validateEnvironment(cfg *Config) error {
+func validateEnvironment(cfg *Config, errs *ValidationErrors) {
 	if cfg.Server.Env != "production" {
-		return fmt.Errorf("APP_ENV must be 'production' in production deployment, got: %s", cfg.Server.Env)
+		errs.add("server.env", "APP_ENV must be 'production' in production deployment, got: %s", cfg.Server.Env)
 	}
-	return nil
 }
 
-func validateCredentials(cfg *Config) error {
+func validateDataResidency(cfg *Config, errs *ValidationErrors) {
+	seen := make(map[string]bool, len(cfg.DataResidency.Regions))
+	for _, region := range cfg.DataResidency.Regions {
+		field := "data_residency." + region.Name
+		if seen[region.Name] {
+			errs.add(field, "data region %s is listed more than once", region.Name)
+		}
+		seen[region.Name] = true
+
+		if region.KMSKeyID == "" && region.DatabaseURL == "" {
+			errs.add(field, "data region %s has no KMS key or database configured", region.Name)
+		}
+		if region.KMSKeyID != "" && !cfg.Credential.UseKMS {
+			errs.add(field+".kms_key_id", "data region %s has a KMS key but CREDENTIAL_USE_KMS is not enabled", region.Name)
+		}
+		if containsLocalhostURL(region.DatabaseURL) {
+			errs.add(field+".database_url", "data region %s database URL points to localhost", region.Name)
+		}
+	}
+}
+
+func validateCredentials(cfg *Config, errs *ValidationErrors) {
 	// Check if using KMS (preferred for production)
 	if cfg.Credential.UseKMS {
 		if cfg.Credential.KMSKeyID == "" {
-			return fmt.Errorf("KMS is enabled but KMSKeyID is not configured")
+			errs.add("credential.kms_key_id", "KMS is enabled but KMSKeyID is not configured")
 		}
 		// KMS is being used, so MasterKey validation is not needed
-		return nil
+		return
 	}
 
 	// If not using KMS, validate MasterKey
 	if cfg.Credential.MasterKey == "" {
-		return fmt.Errorf("credential master key must be configured when KMS is not used")
+		errs.add("credential.master_key", "credential master key must be configured when KMS is not used")
+		return
 	}
 
 	// Check for default development key
 	if cfg.Credential.MasterKey == "dGhpcy1pcy1hLTMyLWJ5dGUtZGV2LWtleS0xMjM0NTY=" {
-		return fmt.Errorf("default development credential master key detected - must use unique production key")
+		errs.add("credential.master_key", "default development credential master key detected - must use unique production key")
+		return
 	}
 
 	// Warn if master key is too short (should be at least 32 bytes base64 encoded = ~44 chars)
 	if len(cfg.Credential.MasterKey) < 32 {
-		return fmt.Errorf("credential master key is too short - minimum 32 characters required")
+		errs.add("credential.master_key", "credential master key is too short - minimum 32 characters required")
+		return
 	}
 
 	// Check for weak/insecure keys (only check common weak patterns)
 	if isWeakPassword(cfg.Credential.MasterKey) {
-		return fmt.Errorf("weak or insecure credential master key detected - must use strong random key")
+		errs.add("credential.master_key", "weak or insecure credential master key detected - must use strong random key")
 	}
-
-	return nil
 }
 
-func validateDatabase(cfg *Config) error {
-	var errors []string
-
-	// Check for weak database password
-	if isWeakPassword(cfg.Database.Password) {
-		errors = append(errors, "weak or default database password detected")
+func validateDatabase(cfg *Config, errs *ValidationErrors) {
+	// Check for weak database password; IAM auth does not use it
+	if cfg.Database.AuthMode != DatabaseAuthModeIAM && isWeakPassword(cfg.Database.Password) {
+		errs.add("database.password", "weak or default database password detected")
 	}
 
 	// Require SSL/TLS for database connections
 	if cfg.Database.SSLMode == "disable" {
-		errors = append(errors, "database SSL must be enabled in production (use 'require', 'verify-ca', or 'verify-full')")
+		errs.add("database.ssl_mode", "database SSL must be enabled in production (use 'require', 'verify-ca', or 'verify-full')")
 	}
 
 	// Check for localhost in database host (but allow valid hostnames)
 	if cfg.Database.Host == "" || containsLocalhostURL(cfg.Database.Host) {
-		errors = append(errors, "database host appears to be localhost or empty - use production database host")
+		errs.add("database.host", "database host appears to be localhost or empty - use production database host")
 	}
-
-	if len(errors) > 0 {
-		return fmt.Errorf("%s", strings.Join(errors, "; "))
-	}
-
-	return nil
 }
 
-func validateServiceURLs(cfg *Config) error {
-	var errors []string
-
+func validateServiceURLs(cfg *Config, errs *ValidationErrors) {
 	// Check Kratos URLs
 	if containsLocalhostURL(cfg.Kratos.PublicURL) {
-		errors = append(errors, "localhost URL detected in Kratos PublicURL")
+		errs.add("kratos.public_url", "localhost URL detected in Kratos PublicURL")
 	}
 	if containsLocalhostURL(cfg.Kratos.AdminURL) {
-		errors = append(errors, "localhost URL detected in Kratos AdminURL")
+		errs.add("kratos.admin_url", "localhost URL detected in Kratos AdminURL")
 	}
 
 	// Require HTTPS for Kratos in production
 	if !strings.HasPrefix(cfg.Kratos.PublicURL, "https://") {
-		errors = append(errors, "insecure HTTP protocol in Kratos PublicURL - must use HTTPS in production")
+		errs.add("kratos.public_url", "insecure HTTP protocol in Kratos PublicURL - must use HTTPS in production")
 	}
 	if !strings.HasPrefix(cfg.Kratos.AdminURL, "https://") {
-		errors = append(errors, "insecure HTTP protocol in Kratos AdminURL - must use HTTPS in production")
+		errs.add("kratos.admin_url", "insecure HTTP protocol in Kratos AdminURL - must use HTTPS in production")
 	}
 
 	// Check Redis for localhost
 	if containsLocalhostURL(cfg.Redis.Address) {
-		errors = append(errors, "localhost detected in Redis address - use production Redis host")
+		errs.add("redis.address", "localhost detected in Redis address - use production Redis host")
 	}
 
 	// Check tracing endpoint if enabled
 	if cfg.Observability.TracingEnabled && containsLocalhostURL(cfg.Observability.TracingEndpoint) {
-		errors = append(errors, "localhost detected in tracing endpoint")
-	}
-
-	if len(errors) > 0 {
-		return fmt.Errorf("%s", strings.Join(errors, "; "))
+		errs.add("observability.tracing_endpoint", "localhost detected in tracing endpoint")
 	}
-
-	return nil
 }
 
-func validateNotifications(cfg *Config) error {
+func validateNotifications(cfg *Config, errs *ValidationErrors) {
 	if !cfg.Notification.EnableEmail {
-		return nil
+		return
 	}
 
-	var errors []string
-
 	// Check email configuration
 	if cfg.Notification.EmailFrom == "noreply@example.com" {
-		errors = append(errors, "default email sender address detected - must configure valid sender")
+		errs.add("notification.email_from", "default email sender address detected - must configure valid sender")
 	}
 
 	// Validate SMTP settings if using SMTP provider
 	if cfg.Notification.EmailProvider == "smtp" {
 		if cfg.Notification.SMTPHost == "" {
-			errors = append(errors, "SMTP host must be configured when email is enabled")
+			errs.add("notification.smtp_host", "SMTP host must be configured when email is enabled")
 		}
 		if cfg.Notification.SMTPPass == "" {
-			errors = append(errors, "SMTP password must be configured when using SMTP provider")
-		}
-		if isWeakPassword(cfg.Notification.SMTPPass) {
-			errors = append(errors, "weak SMTP password detected")
+			errs.add("notification.smtp_pass", "SMTP password must be configured when using SMTP provider")
+		} else if isWeakPassword(cfg.Notification.SMTPPass) {
+			errs.add("notification.smtp_pass", "weak SMTP password detected")
 		}
 		if !cfg.Notification.SMTPTLS {
-			errors = append(errors, "SMTP TLS must be enabled in production")
+			errs.add("notification.smtp_tls", "SMTP TLS must be enabled in production")
 		}
 	}
 
 	// Validate Slack configuration if enabled
 	if cfg.Notification.EnableSlack {
 		if cfg.Notification.SlackWebhookURL == "" {
-			errors = append(errors, "Slack webhook URL must be configured when Slack notifications are enabled")
+			errs.add("notification.slack_webhook_url", "Slack webhook URL must be configured when Slack notifications are enabled")
 		}
 		if containsLocalhostURL(cfg.Notification.SlackWebhookURL) {
-			errors = append(errors, "localhost detected in Slack webhook URL")
+			errs.add("notification.slack_webhook_url", "localhost detected in Slack webhook URL")
 		}
 	}
-
-	if len(errors) > 0 {
-		return fmt.Errorf("%s", strings.Join(errors, "; "))
-	}
-
-	return nil
 }
 
 func logProductionWarnings(cfg *Config) {
@@ -236,6 +268,10 @@ func logProductionWarnings(cfg *Config) {
 		slog.Warn("execution retention cleanup is disabled - database may grow indefinitely")
 	}
 
+	if !cfg.SSRF.Enabled {
+		slog.Warn("SSRF_PROTECTION_ENABLED=false is ignored in production - SSRF protection stays on")
+	}
+
 	if !cfg.Cleanup.Enabled {
 		slog.Warn("webhook event cleanup is disabled - database may grow indefinitely")
 	}
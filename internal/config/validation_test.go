@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -143,6 +144,9 @@ func TestValidateForProduction(t *testing.T) {
 				Server: ServerConfig{
 					Env: "production",
 				},
+				Pagination: PaginationConfig{
+					CursorSecret: "secure-random-cursor-secret-abc123",
+				},
 				Database: DatabaseConfig{
 					Host:     "db.production.example.com",
 					Password: "secure-db-password-123",
@@ -162,12 +166,37 @@ func TestValidateForProduction(t *testing.T) {
 			},
 			expectError: false, // Warning, not error
 		},
+		{
+			name: "reject missing pagination cursor secret",
+			config: &Config{
+				Server: ServerConfig{
+					Env: "production",
+				},
+				Database: DatabaseConfig{
+					Host:     "db.production.example.com",
+					Password: "secure-db-password-123",
+					SSLMode:  "require",
+				},
+				Kratos: KratosConfig{
+					PublicURL: "https://kratos.example.com",
+					AdminURL:  "https://kratos-admin.example.com",
+				},
+				Credential: CredentialConfig{
+					MasterKey: "secure-random-key-32-bytes-long-abc123=",
+				},
+			},
+			expectError: true,
+			errorMsg:    "PAGINATION_CURSOR_SECRET is required",
+		},
 		{
 			name: "valid production configuration",
 			config: &Config{
 				Server: ServerConfig{
 					Env: "production",
 				},
+				Pagination: PaginationConfig{
+					CursorSecret: "secure-random-cursor-secret-abc123",
+				},
 				Database: DatabaseConfig{
 					Host:     "db.example.com",
 					Password: "secure-db-password-123",
@@ -198,6 +227,9 @@ func TestValidateForProduction(t *testing.T) {
 				Server: ServerConfig{
 					Env: "production",
 				},
+				Pagination: PaginationConfig{
+					CursorSecret: "secure-random-cursor-secret-abc123",
+				},
 				Database: DatabaseConfig{
 					Host:     "db.production.example.com",
 					Password: "secure-db-password-123",
@@ -273,6 +305,9 @@ func TestValidateForProduction(t *testing.T) {
 				Server: ServerConfig{
 					Env: "production",
 				},
+				Pagination: PaginationConfig{
+					CursorSecret: "secure-random-cursor-secret-abc123",
+				},
 				Database: DatabaseConfig{
 					Host:     "db.production.example.com",
 					Password: "secure-db-password-123",
@@ -294,6 +329,22 @@ func TestValidateForProduction(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "reject insecure TLS for HTTP actions",
+			config: &Config{
+				Server: ServerConfig{
+					Env: "production",
+				},
+				Credential: CredentialConfig{
+					MasterKey: "secure-random-key-32-bytes-long-abc123=",
+				},
+				HTTPAction: HTTPActionConfig{
+					AllowInsecureTLS: true,
+				},
+			},
+			expectError: true,
+			errorMsg:    "HTTP_ACTION_ALLOW_INSECURE_TLS must not be enabled in production",
+		},
 	}
 
 	for _, tt := range tests {
@@ -317,6 +368,148 @@ func TestValidateForProduction(t *testing.T) {
 	}
 }
 
+func TestValidateForProduction_ReportsAllProblemsWithFieldPaths(t *testing.T) {
+	cfg := &Config{
+		Server: ServerConfig{Env: "production"},
+		Database: DatabaseConfig{
+			Host:     "localhost",
+			Password: "secure-password-123",
+			SSLMode:  "disable",
+		},
+		Kratos: KratosConfig{
+			PublicURL: "https://kratos.example.com",
+			AdminURL:  "https://kratos-admin.example.com",
+		},
+		Credential: CredentialConfig{UseKMS: true},
+		Pagination: PaginationConfig{CursorSecret: "secure-random-cursor-secret-abc123"},
+	}
+
+	err := ValidateForProduction(cfg)
+
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	fields := make([]string, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		fields[i] = fieldErr.Field
+	}
+	expected := []string{"credential.kms_key_id", "database.ssl_mode", "database.host"}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected fields %v, got %v", expected, fields)
+	}
+
+	if !strings.Contains(err.Error(), "database.ssl_mode: database SSL must be enabled") {
+		t.Errorf("expected field path in error message, got %q", err.Error())
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "credential.kms_key_id" {
+		t.Errorf("expected first FieldError to be credential.kms_key_id, got %v", fieldErr)
+	}
+}
+
+func TestValidateForProduction_DataResidency(t *testing.T) {
+	cfg := &Config{
+		Server:     ServerConfig{Env: "production"},
+		Database:   DatabaseConfig{Host: "db.example.com", Password: "secure-password-123", SSLMode: "require"},
+		Kratos:     KratosConfig{PublicURL: "https://kratos.example.com", AdminURL: "https://kratos-admin.example.com"},
+		Credential: CredentialConfig{MasterKey: "cHJvZHVjdGlvbi1tYXN0ZXIta2V5LTMyLWJ5dGVzLWxvbmc="},
+		Pagination: PaginationConfig{CursorSecret: "secure-random-cursor-secret-abc123"},
+		DataResidency: DataResidencyConfig{Regions: []DataRegionConfig{
+			{Name: "eu", KMSKeyID: "alias/gorax-eu", DatabaseURL: "postgres://db.eu.example.com/gorax"},
+			{Name: "ap"},
+			{Name: "us", DatabaseURL: "postgres://localhost/gorax"},
+		}},
+	}
+
+	err := ValidateForProduction(cfg)
+
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+	fields := make([]string, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		fields[i] = fieldErr.Field
+	}
+	expected := []string{"data_residency.eu.kms_key_id", "data_residency.ap", "data_residency.us.database_url"}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected fields %v, got %v", expected, fields)
+	}
+}
+
+func TestLoadDataResidencyConfig(t *testing.T) {
+	t.Setenv("DATA_RESIDENCY_REGIONS", "eu-central, us")
+	t.Setenv("DATA_RESIDENCY_EU_CENTRAL_KMS_KEY_ID", "alias/gorax-eu")
+	t.Setenv("DATA_RESIDENCY_EU_CENTRAL_KMS_REGION", "eu-central-1")
+	t.Setenv("DATA_RESIDENCY_EU_CENTRAL_DATABASE_URL", "postgres://db.eu.example.com/gorax")
+	t.Setenv("DATA_RESIDENCY_US_DATABASE_URL", "postgres://db.us.example.com/gorax")
+	t.Setenv("AWS_REGION", "us-east-2")
+
+	cfg := loadDataResidencyConfig()
+
+	expected := []DataRegionConfig{
+		{Name: "eu-central", KMSKeyID: "alias/gorax-eu", KMSRegion: "eu-central-1", DatabaseURL: "postgres://db.eu.example.com/gorax"},
+		{Name: "us", KMSRegion: "us-east-2", DatabaseURL: "postgres://db.us.example.com/gorax"},
+	}
+	if len(cfg.Regions) != len(expected) {
+		t.Fatalf("expected %d regions, got %+v", len(expected), cfg.Regions)
+	}
+	for i, region := range cfg.Regions {
+		if region != expected[i] {
+			t.Errorf("region %d: expected %+v, got %+v", i, expected[i], region)
+		}
+	}
+	if names := cfg.RegionNames(); len(names) != 2 || names[0] != "eu-central" || names[1] != "us" {
+		t.Errorf("expected region names [eu-central us], got %v", names)
+	}
+}
+
+func TestLoadTracingExporters(t *testing.T) {
+	t.Setenv("TRACING_EXPORTERS", "primary, debug")
+	t.Setenv("TRACING_EXPORTER_PRIMARY_ENDPOINT", "otel.example.com:4317")
+	t.Setenv("TRACING_EXPORTER_PRIMARY_INSECURE", "false")
+	t.Setenv("TRACING_EXPORTER_PRIMARY_HEADERS", "x-api-key=abc=, x-team=platform")
+	t.Setenv("TRACING_EXPORTER_DEBUG_TYPE", "stdout")
+
+	exporters := loadTracingExporters()
+
+	if len(exporters) != 2 {
+		t.Fatalf("expected 2 exporters, got %+v", exporters)
+	}
+	primary := exporters[0]
+	if primary.Name != "primary" || primary.Type != "otlp" || primary.Endpoint != "otel.example.com:4317" || primary.Insecure {
+		t.Errorf("unexpected primary exporter: %+v", primary)
+	}
+	if primary.Headers["x-api-key"] != "abc=" || primary.Headers["x-team"] != "platform" {
+		t.Errorf("unexpected primary headers: %v", primary.Headers)
+	}
+	if debug := exporters[1]; debug.Name != "debug" || debug.Type != "stdout" || !debug.Insecure {
+		t.Errorf("unexpected debug exporter: %+v", debug)
+	}
+}
+
+func TestParseSampleRates(t *testing.T) {
+	rates, err := parseSampleRates("webhook=0.1, http = 0.05")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 || rates["webhook"] != 0.1 || rates["http"] != 0.05 {
+		t.Errorf("unexpected rates: %v", rates)
+	}
+
+	if rates, err := parseSampleRates(""); err != nil || rates != nil {
+		t.Errorf("expected no rates, got %v, %v", rates, err)
+	}
+	for _, value := range []string{"webhook", "webhook=fast", "=0.5"} {
+		if _, err := parseSampleRates(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
 func TestIsWeakPassword(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -373,3 +566,29 @@ func TestContainsLocalhostURL(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPActionConfig_InsecureTLSAllowed(t *testing.T) {
+	enabled := HTTPActionConfig{AllowInsecureTLS: true}
+	if !enabled.InsecureTLSAllowed("development") {
+		t.Error("expected insecure TLS to be allowed in development")
+	}
+	if enabled.InsecureTLSAllowed("production") {
+		t.Error("expected insecure TLS to be refused in production")
+	}
+	if (HTTPActionConfig{}).InsecureTLSAllowed("development") {
+		t.Error("expected insecure TLS to be refused when not enabled")
+	}
+}
+
+func TestSSRFConfig_ProtectionEnabled(t *testing.T) {
+	disabled := SSRFConfig{Enabled: false}
+	if disabled.ProtectionEnabled("development") {
+		t.Error("expected SSRF protection to be off in development when disabled")
+	}
+	if !disabled.ProtectionEnabled("production") {
+		t.Error("expected SSRF protection to stay on in production")
+	}
+	if !(SSRFConfig{Enabled: true}).ProtectionEnabled("development") {
+		t.Error("expected SSRF protection to be on when enabled")
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // JSONMap is a custom type for storing JSON in PostgreSQL
@@ -92,14 +96,47 @@ const (
 	StatusRevoked  CredentialStatus = "revoked"
 )
 
+// MetadataMergeMode controls how an update applies metadata
+type MetadataMergeMode string
+
+const (
+	// MetadataMerge sets the keys sent and keeps the rest; a key sent as null
+	// is removed
+	MetadataMerge MetadataMergeMode = "merge"
+	// MetadataReplace replaces the whole metadata map
+	MetadataReplace MetadataMergeMode = "replace"
+)
+
 // AccessType constants
 const (
-	AccessTypeRead   = "read"
-	AccessTypeUpdate = "update"
-	AccessTypeRotate = "rotate"
-	AccessTypeDelete = "delete"
+	AccessTypeRead    = "read"
+	AccessTypeUpdate  = "update"
+	AccessTypeRotate  = "rotate"
+	AccessTypeDelete  = "delete"
+	AccessTypeRestore = "restore"
+	AccessTypePurge   = "purge"
+	AccessTypeTest    = "test"
+)
+
+const (
+	// DefaultDeletedRetention is how long a deleted credential can be restored
+	// before it is purged
+	DefaultDeletedRetention = 7 * 24 * time.Hour
+
+	// DeletedValueGracePeriod is how long a deleted credential still resolves
+	// for workflow executions, so runs in flight when it was deleted can finish
+	DeletedValueGracePeriod = 10 * time.Minute
+
+	// MaxBulkDelete is the most credentials one bulk delete may name
+	MaxBulkDelete = 100
 )
 
+// BulkOperationError represents an error for a single credential in a bulk operation
+type BulkOperationError struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
 // Credential represents a credential in the system
 // Values are encrypted at rest and should never be returned in API responses except through /value endpoint
 type Credential struct {
@@ -124,6 +161,10 @@ type Credential struct {
 
 	// Metadata stored as JSON
 	Metadata JSONMap `json:"metadata,omitempty" db:"metadata"`
+
+	// DeletedAt is set while a deleted credential can still be restored
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy *string    `json:"deleted_by,omitempty" db:"deleted_by"`
 }
 
 // IsExpired checks if the credential has expired
@@ -173,6 +214,9 @@ type AccessLog struct {
 	UserAgent    string    `json:"user_agent,omitempty" db:"user_agent"`
 	Success      bool      `json:"success" db:"success"`
 	ErrorMessage string    `json:"error_message,omitempty" db:"error_message"`
+	// Keys lists the value keys requested by a field-level read; empty for
+	// reads of the whole value
+	Keys pq.StringArray `json:"keys,omitempty" db:"keys"`
 }
 
 // CreateCredentialInput represents input for creating a credential
@@ -193,6 +237,8 @@ type UpdateCredentialInput struct {
 	Status      *CredentialStatus `json:"status,omitempty"`
 	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
 	Metadata    JSONMap           `json:"metadata,omitempty"`
+	// MetadataMerge selects how Metadata is applied; empty means MetadataMerge
+	MetadataMerge MetadataMergeMode `json:"metadata_merge,omitempty"`
 }
 
 // RotateCredentialInput represents input for rotating a credential value
@@ -205,6 +251,8 @@ type CredentialListFilter struct {
 	Type   CredentialType   `json:"type,omitempty"`
 	Status CredentialStatus `json:"status,omitempty"`
 	Search string           `json:"search,omitempty"` // Search in name/description
+	// Page selects a page by cursor; the zero value lists every match
+	Page pagination.Params `json:"-"`
 }
 
 // DecryptedValue represents a decrypted credential value
@@ -260,6 +308,11 @@ func (u *UpdateCredentialInput) Validate() error {
 			return &ValidationError{Message: "invalid status"}
 		}
 	}
+	switch u.MetadataMerge {
+	case "", MetadataMerge, MetadataReplace:
+	default:
+		return &ValidationError{Message: "metadata_merge must be merge or replace"}
+	}
 	return nil
 }
 
@@ -8,6 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 const (
@@ -72,7 +77,12 @@ func (s *EncryptionService) Encrypt(ctx context.Context, data *CredentialData) (
 }
 
 // Decrypt decrypts credential data using envelope encryption
-func (s *EncryptionService) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+func (s *EncryptionService) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (_ *CredentialData, err error) {
+	ctx, span := tracing.StartSpan(ctx, "credential.decrypt", trace.WithAttributes(
+		attribute.String("encryption.mode", "envelope"),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	if len(encryptedData) == 0 {
 		return nil, &DecryptionError{
 			Op:  "Decrypt",
@@ -236,7 +246,12 @@ func (s *EncryptionService) EncryptWithContext(ctx context.Context, data *Creden
 }
 
 // DecryptWithContext decrypts credential data with encryption context
-func (s *EncryptionService) DecryptWithContext(ctx context.Context, encryptedData, encryptedKey []byte, encryptionContext map[string]string) (*CredentialData, error) {
+func (s *EncryptionService) DecryptWithContext(ctx context.Context, encryptedData, encryptedKey []byte, encryptionContext map[string]string) (_ *CredentialData, err error) {
+	ctx, span := tracing.StartSpan(ctx, "credential.decrypt", trace.WithAttributes(
+		attribute.String("encryption.mode", "envelope"),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	if len(encryptedData) == 0 {
 		return nil, &DecryptionError{
 			Op:  "DecryptWithContext",
@@ -420,7 +435,7 @@ func (s *SimpleEncryptionService) Encrypt(ctx context.Context, tenantID string,
 }
 
 // Decrypt decrypts credential data using envelope encryption with a fixed master key
-func (s *SimpleEncryptionService) Decrypt(ctx context.Context, encrypted *EncryptedSecret) (*CredentialData, error) {
+func (s *SimpleEncryptionService) Decrypt(ctx context.Context, encrypted *EncryptedSecret) (_ *CredentialData, err error) {
 	if encrypted == nil {
 		return nil, &DecryptionError{
 			Op:  "Decrypt",
@@ -428,6 +443,12 @@ func (s *SimpleEncryptionService) Decrypt(ctx context.Context, encrypted *Encryp
 		}
 	}
 
+	_, span := tracing.StartSpan(ctx, "credential.decrypt", trace.WithAttributes(
+		attribute.String("encryption.mode", "simple"),
+		attribute.String("kms.key_id", encrypted.KMSKeyID),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	// Validate encrypted data
 	if len(encrypted.EncryptedDEK) < NonceSize+1 {
 		return nil, &DecryptionError{
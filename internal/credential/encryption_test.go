@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // TestSimpleEncryptionService_EncryptDecrypt tests the simple encryption service
@@ -126,6 +129,44 @@ func TestSimpleEncryptionService_EncryptDecrypt(t *testing.T) {
 	}
 }
 
+// TestSimpleEncryptionService_DecryptSpan tests that decryption is traced without recording the secret
+func TestSimpleEncryptionService_DecryptSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	originalTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer func() {
+		otel.SetTracerProvider(originalTP)
+		_ = tp.Shutdown(context.Background())
+	}()
+
+	masterKey := make([]byte, 32)
+	_, err := rand.Read(masterKey)
+	require.NoError(t, err)
+	svc, err := NewSimpleEncryptionService(masterKey)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	encrypted, err := svc.Encrypt(ctx, "tenant-1", &CredentialData{Value: map[string]interface{}{"token": "s3cret"}})
+	require.NoError(t, err)
+
+	_, err = svc.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "credential.decrypt", spans[0].Name)
+	attrs := map[string]string{}
+	for _, attr := range spans[0].Attributes {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	assert.Equal(t, "simple", attrs["encryption.mode"])
+	assert.Equal(t, "simple-encryption", attrs["kms.key_id"])
+	for _, value := range attrs {
+		assert.NotContains(t, value, "s3cret")
+	}
+}
+
 // TestSimpleEncryptionService_DecryptErrors tests decryption error cases
 func TestSimpleEncryptionService_DecryptErrors(t *testing.T) {
 	masterKey := make([]byte, 32)
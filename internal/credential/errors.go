@@ -9,6 +9,9 @@ var (
 	// ErrNotFound is returned when a credential is not found
 	ErrNotFound = errors.New("credential not found")
 
+	// ErrKeyNotFound is returned when a credential reference selects a key the credential does not have
+	ErrKeyNotFound = errors.New("credential key not found")
+
 	// ErrInvalidTenantID is returned when tenant ID is empty or invalid
 	ErrInvalidTenantID = errors.New("tenant ID cannot be empty")
 
@@ -5,10 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sync"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
 )
 
-// credentialReferenceRegex matches {{credentials.name}} patterns
-var credentialReferenceRegex = regexp.MustCompile(`\{\{credentials\.([a-zA-Z0-9_-]+)\}\}`)
+// credentialReferenceRegex matches {{credentials.name}} and ${credentials.name}
+// patterns, optionally selecting a single key as in ${credentials.name.api_key}
+var credentialReferenceRegex = regexp.MustCompile(
+	`\{\{\s*credentials\.([a-zA-Z0-9_-]+)(?:\.([a-zA-Z0-9_-]+))?\s*\}\}` +
+		`|\$\{\s*credentials\.([a-zA-Z0-9_-]+)(?:\.([a-zA-Z0-9_-]+))?\s*\}`)
+
+// credentialReference is a parsed credential reference
+type credentialReference struct {
+	Name string
+	Key  string // Empty to use the credential's default value
+}
+
+// parseCredentialReference returns the reference held by a credentialReferenceRegex submatch
+func parseCredentialReference(submatch []string) credentialReference {
+	if submatch[1] != "" {
+		return credentialReference{Name: submatch[1], Key: submatch[2]}
+	}
+	return credentialReference{Name: submatch[3], Key: submatch[4]}
+}
 
 // RepositoryInterface defines the interface for credential repository operations
 type RepositoryInterface interface {
@@ -39,12 +59,49 @@ func NewInjector(repo RepositoryInterface, encryption EncryptionServiceInterface
 	}
 }
 
+// ResolutionCache holds credentials decrypted during a single workflow
+// execution so each credential is fetched and decrypted at most once.
+// It is safe for concurrent use by parallel branches.
+type ResolutionCache struct {
+	mu   sync.Mutex
+	data map[string]*CredentialData
+}
+
+// NewResolutionCache creates an empty resolution cache
+func NewResolutionCache() *ResolutionCache {
+	return &ResolutionCache{
+		data: make(map[string]*CredentialData),
+	}
+}
+
+// get returns the cached data for a credential name; a nil cache never hits
+func (c *ResolutionCache) get(name string) (*CredentialData, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.data[name]
+	return data, ok
+}
+
+// set caches the data for a credential name; a nil cache is a no-op
+func (c *ResolutionCache) set(name string, data *CredentialData) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[name] = data
+}
+
 // InjectionContext holds context for credential injection
 type InjectionContext struct {
 	TenantID    string
 	WorkflowID  string
 	ExecutionID string
 	AccessedBy  string
+	Cache       *ResolutionCache // Optional per-execution cache of decrypted credentials
 }
 
 // InjectResult holds the result of credential injection
@@ -69,21 +126,23 @@ func (i *Injector) InjectCredentials(ctx context.Context, config json.RawMessage
 		}, nil
 	}
 
-	// Fetch and decrypt all referenced credentials
-	credentials := make(map[string]string)
-	var values []string
+	// Resolve every referenced credential and key
+	resolved, err := i.resolveReferences(ctx, config, injCtx)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, credName := range credentialRefs {
-		value, err := i.getCredentialValue(ctx, injCtx.TenantID, credName, injCtx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get credential '%s': %w", credName, err)
+	var values []string
+	seen := make(map[string]bool)
+	for _, value := range resolved {
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
 		}
-		credentials[credName] = value
-		values = append(values, value)
 	}
 
 	// Inject credentials into config
-	injectedConfig, err := i.injectValues(config, credentials)
+	injectedConfig, err := i.injectValues(config, resolved)
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject credentials: %w", err)
 	}
@@ -105,9 +164,7 @@ func (i *Injector) ExtractCredentialReferences(config json.RawMessage) ([]string
 	// Extract unique credential names
 	credNames := make(map[string]bool)
 	for _, match := range matches {
-		if len(match) > 1 {
-			credNames[match[1]] = true
-		}
+		credNames[parseCredentialReference(match).Name] = true
 	}
 
 	// Convert map to slice
@@ -119,8 +176,53 @@ func (i *Injector) ExtractCredentialReferences(config json.RawMessage) ([]string
 	return result, nil
 }
 
-// getCredentialValue retrieves and decrypts a credential value
-func (i *Injector) getCredentialValue(ctx context.Context, tenantID, name string, injCtx *InjectionContext) (string, error) {
+// resolveReferences returns the value of every credential reference in config
+func (i *Injector) resolveReferences(ctx context.Context, config json.RawMessage, injCtx *InjectionContext) (map[credentialReference]string, error) {
+	resolved := make(map[credentialReference]string)
+	for _, match := range credentialReferenceRegex.FindAllStringSubmatch(string(config), -1) {
+		ref := parseCredentialReference(match)
+		if _, ok := resolved[ref]; ok {
+			continue
+		}
+
+		credData, err := i.getCredentialData(ctx, injCtx.TenantID, ref.Name, injCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credential '%s': %w", ref.Name, err)
+		}
+
+		if ref.Key == "" {
+			resolved[ref] = i.extractCredentialValue(credData.Value)
+			continue
+		}
+
+		value, ok := credData.Value[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("credential '%s' has no key '%s': %w", ref.Name, ref.Key, ErrKeyNotFound)
+		}
+		resolved[ref] = stringifyCredentialValue(value)
+	}
+
+	return resolved, nil
+}
+
+// getCredentialData retrieves and decrypts a credential, using the
+// execution's resolution cache when one is provided
+func (i *Injector) getCredentialData(ctx context.Context, tenantID, name string, injCtx *InjectionContext) (*CredentialData, error) {
+	if credData, ok := injCtx.Cache.get(name); ok {
+		return credData, nil
+	}
+
+	credData, err := i.decryptCredential(ctx, tenantID, name, injCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	injCtx.Cache.set(name, credData)
+	return credData, nil
+}
+
+// decryptCredential retrieves and decrypts a credential, recording the access
+func (i *Injector) decryptCredential(ctx context.Context, tenantID, name string, injCtx *InjectionContext) (*CredentialData, error) {
 	// Validate and get credential
 	cred, err := i.repo.ValidateAndGet(ctx, tenantID, name)
 	if err != nil {
@@ -133,12 +235,12 @@ func (i *Injector) getCredentialValue(ctx context.Context, tenantID, name string
 			Success:      false,
 			ErrorMessage: err.Error(),
 		})
-		return "", err
+		return nil, err
 	}
 
 	// Decrypt the value using envelope encryption
 	// The Ciphertext field contains the encrypted data (nonce + ciphertext + tag combined)
-	credData, err := i.encryption.Decrypt(ctx, cred.Ciphertext, cred.EncryptedDEK)
+	credData, err := i.encryption.Decrypt(tenantctx.WithTenantID(ctx, tenantID), cred.Ciphertext, cred.EncryptedDEK)
 	if err != nil {
 		// Log failed decryption (best effort - don't fail on logging error)
 		_ = i.repo.LogAccess(ctx, &AccessLog{ //nolint:errcheck
@@ -149,14 +251,9 @@ func (i *Injector) getCredentialValue(ctx context.Context, tenantID, name string
 			Success:      false,
 			ErrorMessage: err.Error(),
 		})
-		return "", fmt.Errorf("failed to decrypt credential: %w", err)
+		return nil, fmt.Errorf("failed to decrypt credential: %w", err)
 	}
 
-	// Extract the actual credential value
-	// For injection, we typically need a string representation
-	// The credData.Value map might have a "key" or "token" field depending on the credential type
-	value := i.extractCredentialValue(credData.Value)
-
 	// Update access time (best effort)
 	_ = i.repo.UpdateAccessTime(ctx, tenantID, cred.ID) //nolint:errcheck
 
@@ -169,7 +266,7 @@ func (i *Injector) getCredentialValue(ctx context.Context, tenantID, name string
 		Success:      true,
 	})
 
-	return value, nil
+	return credData, nil
 }
 
 // extractCredentialValue extracts a usable string value from credential data
@@ -200,8 +297,21 @@ func (i *Injector) extractCredentialValue(value map[string]interface{}) string {
 	return string(data)
 }
 
+// stringifyCredentialValue converts a single credential field to a string,
+// encoding non-string values as JSON
+func stringifyCredentialValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
 // injectValues replaces credential references with actual values
-func (i *Injector) injectValues(config json.RawMessage, credentials map[string]string) (json.RawMessage, error) {
+func (i *Injector) injectValues(config json.RawMessage, credentials map[credentialReference]string) (json.RawMessage, error) {
 	// Parse config to interface{}
 	var data interface{}
 	if err := json.Unmarshal(config, &data); err != nil {
@@ -221,7 +331,7 @@ func (i *Injector) injectValues(config json.RawMessage, credentials map[string]s
 }
 
 // injectValue recursively injects credentials into a value
-func (i *Injector) injectValue(value interface{}, credentials map[string]string) interface{} {
+func (i *Injector) injectValue(value interface{}, credentials map[credentialReference]string) interface{} {
 	switch v := value.(type) {
 	case string:
 		// Replace credential references in strings
@@ -249,23 +359,20 @@ func (i *Injector) injectValue(value interface{}, credentials map[string]string)
 	}
 }
 
-// replaceCredentialReferences replaces {{credentials.name}} with actual values
-func (i *Injector) replaceCredentialReferences(input string, credentials map[string]string) string {
+// replaceCredentialReferences replaces credential references with actual values
+func (i *Injector) replaceCredentialReferences(input string, credentials map[credentialReference]string) string {
 	return credentialReferenceRegex.ReplaceAllStringFunc(input, func(match string) string {
-		// Extract credential name
 		submatch := credentialReferenceRegex.FindStringSubmatch(match)
-		if len(submatch) < 2 {
+		if submatch == nil {
 			return match // Return original if no match
 		}
 
-		credName := submatch[1]
-
 		// Look up credential value
-		if value, exists := credentials[credName]; exists {
+		if value, exists := credentials[parseCredentialReference(submatch)]; exists {
 			return value
 		}
 
-		// Return original if credential not found (should not happen if extraction worked correctly)
+		// Return original if credential not found (should not happen if resolution worked correctly)
 		return match
 	})
 }
@@ -279,3 +386,26 @@ func (i *Injector) MaskOutput(data interface{}, credentialValues []string) inter
 func (i *Injector) MaskOutputJSON(data json.RawMessage, credentialValues []string) (json.RawMessage, error) {
 	return i.masker.MaskRawJSON(data, credentialValues)
 }
+
+// MaskError masks credential values in an error message while keeping the
+// original error available to errors.Is and errors.As
+func (i *Injector) MaskError(err error, credentialValues []string) error {
+	if err == nil || len(credentialValues) == 0 {
+		return err
+	}
+	masked := i.masker.MaskString(err.Error(), credentialValues)
+	if masked == err.Error() {
+		return err
+	}
+	return &maskedError{msg: masked, err: err}
+}
+
+// maskedError is an error whose message has had credential values masked
+type maskedError struct {
+	msg string
+	err error
+}
+
+func (e *maskedError) Error() string { return e.msg }
+
+func (e *maskedError) Unwrap() error { return e.err }
@@ -0,0 +1,164 @@
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInjectorRepo serves credentials by name and counts lookups
+type fakeInjectorRepo struct {
+	credentials map[string]*Credential
+	lookups     map[string]int
+}
+
+func (r *fakeInjectorRepo) ValidateAndGet(ctx context.Context, tenantID, name string) (*Credential, error) {
+	r.lookups[name]++
+	cred, ok := r.credentials[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return cred, nil
+}
+
+func (r *fakeInjectorRepo) UpdateAccessTime(ctx context.Context, tenantID, credentialID string) error {
+	return nil
+}
+
+func (r *fakeInjectorRepo) LogAccess(ctx context.Context, log *AccessLog) error {
+	return nil
+}
+
+// fakeInjectorEncryption treats the ciphertext as plain JSON credential data
+type fakeInjectorEncryption struct{}
+
+func (fakeInjectorEncryption) Encrypt(ctx context.Context, tenantID string, data *CredentialData) (*EncryptedSecret, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeInjectorEncryption) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+	var value map[string]interface{}
+	if err := json.Unmarshal(encryptedData, &value); err != nil {
+		return nil, err
+	}
+	return &CredentialData{Value: value}, nil
+}
+
+func newTestInjector(values map[string]string) (*Injector, *fakeInjectorRepo) {
+	repo := &fakeInjectorRepo{
+		credentials: make(map[string]*Credential),
+		lookups:     make(map[string]int),
+	}
+	for name, value := range values {
+		repo.credentials[name] = &Credential{
+			ID:         "cred-" + name,
+			Name:       name,
+			Status:     StatusActive,
+			Ciphertext: []byte(value),
+		}
+	}
+	return NewInjector(repo, fakeInjectorEncryption{}), repo
+}
+
+func TestInjector_InjectCredentials_ReferenceSyntaxes(t *testing.T) {
+	injector, _ := newTestInjector(map[string]string{
+		"jira_auth": `{"api_key":"jira-key","email":"bot@example.com","port":8443}`,
+	})
+
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"mustache", `{"token":"{{credentials.jira_auth}}"}`, "jira-key"},
+		{"dollar", `{"token":"${credentials.jira_auth}"}`, "jira-key"},
+		{"dollar with spaces", `{"token":"${ credentials.jira_auth }"}`, "jira-key"},
+		{"selected key", `{"token":"${credentials.jira_auth.email}"}`, "bot@example.com"},
+		{"mustache selected key", `{"token":"{{credentials.jira_auth.email}}"}`, "bot@example.com"},
+		{"non-string key", `{"token":"${credentials.jira_auth.port}"}`, "8443"},
+		{"embedded", `{"token":"Basic ${credentials.jira_auth.email}:${credentials.jira_auth.api_key}"}`, "Basic bot@example.com:jira-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := injector.InjectCredentials(context.Background(), json.RawMessage(tt.config), &InjectionContext{TenantID: "tenant-1"})
+			require.NoError(t, err)
+
+			var config map[string]string
+			require.NoError(t, json.Unmarshal(result.Config, &config))
+			assert.Equal(t, tt.expected, config["token"])
+		})
+	}
+}
+
+func TestInjector_InjectCredentials_ReturnsValuesForMasking(t *testing.T) {
+	injector, _ := newTestInjector(map[string]string{
+		"jira_auth": `{"api_key":"jira-key","email":"bot@example.com"}`,
+	})
+
+	config := json.RawMessage(`{"a":"${credentials.jira_auth}","b":"{{credentials.jira_auth}}","c":"${credentials.jira_auth.email}"}`)
+	result, err := injector.InjectCredentials(context.Background(), config, &InjectionContext{TenantID: "tenant-1"})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"jira-key", "bot@example.com"}, result.Values)
+}
+
+func TestInjector_InjectCredentials_MissingCredential(t *testing.T) {
+	injector, _ := newTestInjector(nil)
+
+	_, err := injector.InjectCredentials(context.Background(), json.RawMessage(`{"token":"${credentials.jira_auth}"}`), &InjectionContext{TenantID: "tenant-1"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "jira_auth")
+}
+
+func TestInjector_InjectCredentials_MissingKey(t *testing.T) {
+	injector, _ := newTestInjector(map[string]string{
+		"jira_auth": `{"api_key":"jira-key"}`,
+	})
+
+	_, err := injector.InjectCredentials(context.Background(), json.RawMessage(`{"token":"${credentials.jira_auth.password}"}`), &InjectionContext{TenantID: "tenant-1"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Contains(t, err.Error(), "password")
+	assert.NotContains(t, err.Error(), "jira-key")
+}
+
+func TestInjector_InjectCredentials_CachesWithinExecution(t *testing.T) {
+	injector, repo := newTestInjector(map[string]string{
+		"jira_auth": `{"api_key":"jira-key"}`,
+	})
+	injCtx := &InjectionContext{TenantID: "tenant-1", Cache: NewResolutionCache()}
+
+	for i := 0; i < 3; i++ {
+		config := json.RawMessage(fmt.Sprintf(`{"step":%d,"token":"${credentials.jira_auth}"}`, i))
+		_, err := injector.InjectCredentials(context.Background(), config, injCtx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, repo.lookups["jira_auth"])
+
+	// A new execution gets a new cache and fetches again
+	_, err := injector.InjectCredentials(context.Background(), json.RawMessage(`{"token":"${credentials.jira_auth}"}`), &InjectionContext{TenantID: "tenant-1", Cache: NewResolutionCache()})
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.lookups["jira_auth"])
+}
+
+func TestInjector_MaskError(t *testing.T) {
+	injector, _ := newTestInjector(nil)
+	cause := errors.New("request failed")
+	err := fmt.Errorf("GET https://api.example.com?token=secret-value: %w", cause)
+
+	masked := injector.MaskError(err, []string{"secret-value"})
+
+	assert.NotContains(t, masked.Error(), "secret-value")
+	assert.Contains(t, masked.Error(), DefaultMask)
+	assert.ErrorIs(t, masked, cause)
+	assert.Nil(t, injector.MaskError(nil, []string{"secret-value"}))
+}
@@ -10,6 +10,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 const (
@@ -97,15 +101,22 @@ func loadAWSConfig(ctx context.Context) (aws.Config, error) {
 }
 
 // GenerateDataKey generates a new AES-256 data encryption key
-func (c *KMSClient) GenerateDataKey(ctx context.Context, keyID string, encryptionContext map[string]string) ([]byte, []byte, error) {
+func (c *KMSClient) GenerateDataKey(ctx context.Context, keyID string, encryptionContext map[string]string) (_ []byte, _ []byte, err error) {
 	if keyID == "" {
 		return nil, nil, ErrInvalidKeyID
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "kms.generate_data_key", trace.WithAttributes(
+		attribute.String("kms.key_id", keyID),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	// Check cache first
 	if plainKey, encryptedKey := c.getCachedKey(keyID, encryptionContext); plainKey != nil {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
 		return plainKey, encryptedKey, nil
 	}
+	span.SetAttributes(attribute.Bool("cache.hit", false))
 
 	// Convert encryption context
 	kmsContext := convertEncryptionContext(encryptionContext)
@@ -149,11 +160,18 @@ func (c *KMSClient) GenerateDataKey(ctx context.Context, keyID string, encryptio
 }
 
 // DecryptDataKey decrypts an encrypted data key using KMS
-func (c *KMSClient) DecryptDataKey(ctx context.Context, encryptedKey []byte, encryptionContext map[string]string) ([]byte, error) {
+func (c *KMSClient) DecryptDataKey(ctx context.Context, encryptedKey []byte, encryptionContext map[string]string) (_ []byte, err error) {
 	if len(encryptedKey) == 0 {
 		return nil, ErrInvalidCiphertext
 	}
 
+	// Decrypted data keys are not cached, so every call goes to KMS
+	ctx, span := tracing.StartSpan(ctx, "kms.decrypt_data_key", trace.WithAttributes(
+		attribute.String("kms.key_id", c.keyID),
+		attribute.Bool("cache.hit", false),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	// Convert encryption context
 	kmsContext := convertEncryptionContext(encryptionContext)
 
@@ -11,6 +11,10 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 // KMSClientForEncryption defines the interface for AWS KMS operations needed by KMSEncryptionService
@@ -19,12 +23,25 @@ type KMSClientForEncryption interface {
 	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
 }
 
+// KMSReEncrypter is implemented by KMS clients that can re-wrap a data key
+// under another KMS key without exposing its plaintext, such as *kms.Client
+type KMSReEncrypter interface {
+	ReEncrypt(ctx context.Context, params *kms.ReEncryptInput, optFns ...func(*kms.Options)) (*kms.ReEncryptOutput, error)
+}
+
+// TenantKeyResolver looks up the KMS key assigned to a tenant. An empty key
+// ID means the tenant uses the service's default key.
+type TenantKeyResolver interface {
+	GetTenantKMSKeyID(ctx context.Context, tenantID string) (string, error)
+}
+
 // KMSEncryptionService implements production-grade encryption using AWS KMS for envelope encryption
 // This service uses AWS KMS to generate and manage data encryption keys (DEKs)
 // The actual credential data is encrypted with AES-256-GCM using the DEK
 type KMSEncryptionService struct {
-	kmsClient KMSClientForEncryption
-	keyID     string
+	kmsClient  KMSClientForEncryption
+	keyID      string
+	tenantKeys TenantKeyResolver
 }
 
 // NewKMSEncryptionService creates a new KMS-based encryption service
@@ -45,6 +62,30 @@ func NewKMSEncryptionService(kmsClient KMSClientForEncryption, keyID string) (*K
 	}, nil
 }
 
+// SetTenantKeyResolver enables per-tenant KMS keys. New data keys for a
+// tenant are generated under the key the resolver returns, falling back to
+// the default key. Existing ciphertext keeps decrypting with the key that
+// wrapped its data key, since KMS identifies it from the encrypted DEK.
+func (s *KMSEncryptionService) SetTenantKeyResolver(resolver TenantKeyResolver) {
+	s.tenantKeys = resolver
+}
+
+// keyIDForTenant returns the KMS key new data keys for tenantID are wrapped with
+func (s *KMSEncryptionService) keyIDForTenant(ctx context.Context, tenantID string) (string, error) {
+	if s.tenantKeys == nil || tenantID == "" {
+		return s.keyID, nil
+	}
+
+	keyID, err := s.tenantKeys.GetTenantKMSKeyID(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve tenant KMS key: %w", err)
+	}
+	if keyID == "" {
+		return s.keyID, nil
+	}
+	return keyID, nil
+}
+
 // Encrypt encrypts credential data using AWS KMS envelope encryption
 // Steps:
 // 1. Generate a data encryption key (DEK) via KMS
@@ -71,9 +112,17 @@ func (s *KMSEncryptionService) Encrypt(ctx context.Context, tenantID string, dat
 		}
 	}
 
+	keyID, err := s.keyIDForTenant(ctx, tenantID)
+	if err != nil {
+		return nil, &EncryptionError{
+			Op:  "Encrypt",
+			Err: err,
+		}
+	}
+
 	// Generate data encryption key via KMS
 	dekOutput, err := s.kmsClient.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
-		KeyId:         aws.String(s.keyID),
+		KeyId:         aws.String(keyID),
 		NumberOfBytes: aws.Int32(DataKeySize), // 32 bytes for AES-256
 	})
 	if err != nil {
@@ -108,7 +157,7 @@ func (s *KMSEncryptionService) Encrypt(ctx context.Context, tenantID string, dat
 		Ciphertext:   ciphertext,
 		Nonce:        nonce,
 		AuthTag:      authTag,
-		KMSKeyID:     s.keyID,
+		KMSKeyID:     keyID,
 	}, nil
 }
 
@@ -125,7 +174,13 @@ func (s *KMSEncryptionService) Encrypt(ctx context.Context, tenantID string, dat
 // Returns:
 // - *CredentialData containing the decrypted credential
 // - error if decryption fails at any step
-func (s *KMSEncryptionService) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+func (s *KMSEncryptionService) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (_ *CredentialData, err error) {
+	ctx, span := tracing.StartSpan(ctx, "credential.decrypt", trace.WithAttributes(
+		attribute.String("encryption.mode", "kms"),
+		attribute.String("kms.key_id", s.keyID),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	if len(encryptedData) == 0 {
 		return nil, &DecryptionError{
 			Op:  "Decrypt",
@@ -209,6 +264,62 @@ func (s *KMSEncryptionService) Decrypt(ctx context.Context, encryptedData, encry
 	return &credData, nil
 }
 
+// HealthCheck verifies the service can still use its KMS key by generating a
+// data key and decrypting it again, the same round trip credential
+// encryption and decryption depend on. The plaintext keys are discarded.
+func (s *KMSEncryptionService) HealthCheck(ctx context.Context) error {
+	return s.checkKey(ctx, s.keyID)
+}
+
+// checkKey generates a data key under keyID and decrypts it again
+func (s *KMSEncryptionService) checkKey(ctx context.Context, keyID string) error {
+	dekOutput, err := s.kmsClient.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:         aws.String(keyID),
+		NumberOfBytes: aws.Int32(DataKeySize),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate data key: %w", err)
+	}
+	ClearKey(dekOutput.Plaintext)
+
+	decrypted, err := s.kmsClient.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob: dekOutput.CiphertextBlob,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	ClearKey(decrypted.Plaintext)
+
+	return nil
+}
+
+// RewrapDataKey re-wraps an encrypted data key under newKeyID using KMS
+// ReEncrypt. The plaintext key never leaves KMS and the payload encrypted with
+// it is unchanged, so only the encrypted DEK and key ID need to be stored.
+func (s *KMSEncryptionService) RewrapDataKey(ctx context.Context, encryptedKey []byte, newKeyID string) ([]byte, error) {
+	if newKeyID == "" {
+		return nil, ErrInvalidKeyID
+	}
+	if len(encryptedKey) == 0 {
+		return nil, ErrInvalidCiphertext
+	}
+
+	reEncrypter, ok := s.kmsClient.(KMSReEncrypter)
+	if !ok {
+		return nil, fmt.Errorf("%w: KMS client does not support re-encryption", ErrKMSOperationFailed)
+	}
+
+	output, err := reEncrypter.ReEncrypt(ctx, &kms.ReEncryptInput{
+		CiphertextBlob:   encryptedKey,
+		DestinationKeyId: aws.String(newKeyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encrypt data key: %w", err)
+	}
+
+	return output.CiphertextBlob, nil
+}
+
 // encryptWithAESGCM encrypts plaintext using AES-256-GCM
 // Returns: ciphertext (without tag), nonce, authentication tag, error
 func (s *KMSEncryptionService) encryptWithAESGCM(plaintext, key []byte) ([]byte, []byte, []byte, error) {
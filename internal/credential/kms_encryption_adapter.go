@@ -28,3 +28,8 @@ func (a *KMSEncryptionAdapter) Encrypt(ctx context.Context, tenantID string, dat
 func (a *KMSEncryptionAdapter) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
 	return a.service.Decrypt(ctx, encryptedData, encryptedKey)
 }
+
+// HealthCheck verifies the KMS key is usable
+func (a *KMSEncryptionAdapter) HealthCheck(ctx context.Context) error {
+	return a.service.HealthCheck(ctx)
+}
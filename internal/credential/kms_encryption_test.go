@@ -557,6 +557,52 @@ func TestKMSEncryptionService_EncryptDecrypt_RoundTrip(t *testing.T) {
 }
 
 // stringPtr is a helper to get string pointer
+func TestKMSEncryptionService_HealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trip succeeds", func(t *testing.T) {
+		var decryptedBlob []byte
+		mockClient := &MockKMSClientForEncryption{
+			GenerateDataKeyFunc: func(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+				assert.Equal(t, "alias/test-key", *params.KeyId)
+				return &kms.GenerateDataKeyOutput{
+					Plaintext:      make([]byte, DataKeySize),
+					CiphertextBlob: []byte("encrypted-dek"),
+				}, nil
+			},
+			DecryptFunc: func(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+				decryptedBlob = params.CiphertextBlob
+				return &kms.DecryptOutput{Plaintext: make([]byte, DataKeySize)}, nil
+			},
+		}
+		service, err := NewKMSEncryptionService(mockClient, "alias/test-key")
+		require.NoError(t, err)
+
+		require.NoError(t, service.HealthCheck(ctx))
+		assert.Equal(t, []byte("encrypted-dek"), decryptedBlob)
+	})
+
+	t.Run("decrypt denied", func(t *testing.T) {
+		mockClient := &MockKMSClientForEncryption{
+			GenerateDataKeyFunc: func(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+				return &kms.GenerateDataKeyOutput{
+					Plaintext:      make([]byte, DataKeySize),
+					CiphertextBlob: []byte("encrypted-dek"),
+				}, nil
+			},
+			DecryptFunc: func(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+				return nil, &types.KMSInvalidStateException{Message: stringPtr("key is disabled")}
+			},
+		}
+		service, err := NewKMSEncryptionService(mockClient, "alias/test-key")
+		require.NoError(t, err)
+
+		err = service.HealthCheck(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decrypt data key")
+	})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
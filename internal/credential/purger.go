@@ -0,0 +1,63 @@
+package credential
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DeletedPurger permanently deletes soft-deleted credentials
+type DeletedPurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+// Purger periodically purges credentials whose restore window has passed
+type Purger struct {
+	repo      DeletedPurger
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+// NewPurger creates a new deleted credential purger
+func NewPurger(repo DeletedPurger, retention time.Duration, logger *slog.Logger) *Purger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Purger{
+		repo:      repo,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Start purges deleted credentials every interval until ctx is cancelled
+func (p *Purger) Start(ctx context.Context, interval time.Duration) error {
+	p.logger.Info("starting deleted credential purge", "interval", interval, "retention", p.retention)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("deleted credential purge stopping")
+			return ctx.Err()
+		case <-ticker.C:
+			_, _ = p.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce purges credentials deleted longer ago than the retention window
+// and logs how many were removed
+func (p *Purger) RunOnce(ctx context.Context) (int, error) {
+	purged, err := p.repo.PurgeDeleted(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		p.logger.Error("failed to purge deleted credentials", "error", err)
+		return 0, err
+	}
+	if purged > 0 {
+		p.logger.Info("purged deleted credentials", "count", purged)
+	}
+	return purged, nil
+}
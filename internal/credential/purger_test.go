@@ -0,0 +1,54 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeletedPurger records the cutoff it was called with
+type fakeDeletedPurger struct {
+	purged int
+	err    error
+	before time.Time
+}
+
+func (f *fakeDeletedPurger) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	f.before = before
+	return f.purged, f.err
+}
+
+func TestPurger_RunOnce(t *testing.T) {
+	repo := &fakeDeletedPurger{purged: 2}
+	purger := NewPurger(repo, 24*time.Hour, nil)
+
+	purged, err := purger.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), repo.before, time.Minute)
+}
+
+func TestPurger_RunOnce_Error(t *testing.T) {
+	repo := &fakeDeletedPurger{err: errors.New("connection reset")}
+	purger := NewPurger(repo, time.Hour, nil)
+
+	purged, err := purger.RunOnce(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+}
+
+func TestPurger_Start_StopsOnCancel(t *testing.T) {
+	purger := NewPurger(&fakeDeletedPurger{}, time.Hour, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := purger.Start(ctx, time.Hour)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
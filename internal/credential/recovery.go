@@ -0,0 +1,438 @@
+package credential
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+// BundleVersion is the format version of credential recovery bundles
+const BundleVersion = 1
+
+// AccessTypeExport is logged for every credential written to a recovery bundle
+const AccessTypeExport = "export"
+
+// minRecoveryKeyBits is the smallest RSA recovery key accepted
+const minRecoveryKeyBits = 2048
+
+// bundleOAEPLabel binds wrapped bundle keys to their purpose
+var bundleOAEPLabel = []byte("gorax-credential-bundle")
+
+var (
+	// ErrInvalidRecoveryKey is returned for recovery keys that cannot be parsed or are too weak
+	ErrInvalidRecoveryKey = errors.New("invalid recovery key")
+	// ErrRecoveryKeyMismatch is returned when a bundle was sealed for a different recovery key
+	ErrRecoveryKeyMismatch = errors.New("bundle was exported for a different recovery key")
+	// ErrUnsupportedBundle is returned for bundles of an unknown format version
+	ErrUnsupportedBundle = errors.New("unsupported credential bundle version")
+)
+
+// CredentialBundle is a tenant's credentials sealed for a recovery key. Each
+// value is encrypted with its own AES-256-GCM key, wrapped with RSA-OAEP for
+// the recovery key, so the bundle holds no live data keys and no plaintext.
+type CredentialBundle struct {
+	Version                int                  `json:"version"`
+	TenantID               string               `json:"tenant_id"`
+	ExportedBy             string               `json:"exported_by"`
+	ExportedAt             time.Time            `json:"exported_at"`
+	RecoveryKeyFingerprint string               `json:"recovery_key_fingerprint"`
+	Credentials            []*BundledCredential `json:"credentials"`
+}
+
+// BundledCredential is a single credential in a recovery bundle
+type BundledCredential struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Type        CredentialType   `json:"type"`
+	Status      CredentialStatus `json:"status"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty"`
+	Metadata    JSONMap          `json:"metadata,omitempty"`
+	WrappedKey  []byte           `json:"wrapped_key"`
+	Nonce       []byte           `json:"nonce"`
+	Ciphertext  []byte           `json:"ciphertext"`
+}
+
+// ImportResult summarizes a bundle import
+type ImportResult struct {
+	Imported int      `json:"imported"`
+	Skipped  []string `json:"skipped"`
+}
+
+// RecoveryRepository is the part of Repository used for recovery bundles
+type RecoveryRepository interface {
+	List(ctx context.Context, tenantID string, filter CredentialListFilter) ([]*Credential, error)
+	Create(ctx context.Context, tenantID, createdBy string, cred *Credential) (*Credential, error)
+	LogAccess(ctx context.Context, log *AccessLog) error
+}
+
+// RecoveryService exports and imports credential bundles for disaster
+// recovery between clusters with different KMS setups
+type RecoveryService struct {
+	repo       RecoveryRepository
+	encryption EncryptionServiceInterface
+	logger     *slog.Logger
+}
+
+// NewRecoveryService creates a recovery service decrypting and encrypting
+// credentials with encryption
+func NewRecoveryService(repo RecoveryRepository, encryption EncryptionServiceInterface, logger *slog.Logger) *RecoveryService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &RecoveryService{
+		repo:       repo,
+		encryption: encryption,
+		logger:     logger,
+	}
+}
+
+// ExportCredentials seals every credential of a tenant for recoveryKey. Each
+// exported credential is recorded in its access log against exportedBy.
+func (s *RecoveryService) ExportCredentials(ctx context.Context, tenantID, exportedBy string, recoveryKey *rsa.PublicKey) (*CredentialBundle, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if exportedBy == "" {
+		return nil, ErrUnauthorized
+	}
+	if err := checkRecoveryKey(recoveryKey); err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := RecoveryKeyFingerprint(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := s.repo.List(ctx, tenantID, CredentialListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	bundle := &CredentialBundle{
+		Version:                BundleVersion,
+		TenantID:               tenantID,
+		ExportedBy:             exportedBy,
+		ExportedAt:             time.Now().UTC(),
+		RecoveryKeyFingerprint: fingerprint,
+		Credentials:            make([]*BundledCredential, 0, len(creds)),
+	}
+
+	for _, cred := range creds {
+		bundled, err := s.exportCredential(ctx, cred, recoveryKey)
+
+		accessLog := &AccessLog{
+			CredentialID: cred.ID,
+			TenantID:     tenantID,
+			AccessedBy:   exportedBy,
+			AccessType:   AccessTypeExport,
+			AccessedAt:   time.Now().UTC(),
+			Success:      err == nil,
+		}
+		if err != nil {
+			accessLog.ErrorMessage = err.Error()
+		}
+		// Note: Error is intentionally ignored as this is a non-critical operation
+		_ = s.repo.LogAccess(ctx, accessLog)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to export credential %s: %w", cred.Name, err)
+		}
+		bundle.Credentials = append(bundle.Credentials, bundled)
+	}
+
+	s.logger.Warn("credentials exported for disaster recovery",
+		"tenant_id", tenantID,
+		"exported_by", exportedBy,
+		"count", len(bundle.Credentials),
+		"recovery_key_fingerprint", fingerprint)
+
+	return bundle, nil
+}
+
+// exportCredential decrypts a credential and seals it for recoveryKey
+func (s *RecoveryService) exportCredential(ctx context.Context, cred *Credential, recoveryKey *rsa.PublicKey) (*BundledCredential, error) {
+	if len(cred.Ciphertext) == 0 || len(cred.EncryptedDEK) == 0 {
+		return nil, fmt.Errorf("no credential value found for credential %s", cred.ID)
+	}
+
+	// Decrypt takes nonce + ciphertext + authTag combined
+	encryptedData := make([]byte, 0, len(cred.Nonce)+len(cred.Ciphertext)+len(cred.AuthTag))
+	encryptedData = append(encryptedData, cred.Nonce...)
+	encryptedData = append(encryptedData, cred.Ciphertext...)
+	encryptedData = append(encryptedData, cred.AuthTag...)
+
+	data, err := s.encryption.Decrypt(tenantctx.WithTenantID(ctx, cred.TenantID), encryptedData, cred.EncryptedDEK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+
+	plaintext, err := json.Marshal(data.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential value: %w", err)
+	}
+	defer clear(plaintext)
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate bundle key: %w", err)
+	}
+	defer clear(key)
+
+	nonce, ciphertext, err := sealBundleValue(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, recoveryKey, key, bundleOAEPLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap bundle key: %w", err)
+	}
+
+	return &BundledCredential{
+		Name:        cred.Name,
+		Description: cred.Description,
+		Type:        cred.Type,
+		Status:      cred.Status,
+		ExpiresAt:   cred.ExpiresAt,
+		Metadata:    cred.Metadata,
+		WrappedKey:  wrappedKey,
+		Nonce:       nonce,
+		Ciphertext:  ciphertext,
+	}, nil
+}
+
+// ImportCredentials opens a bundle with recoveryKey and stores its credentials
+// for tenantID, encrypted under this cluster's encryption service. Credentials
+// whose names already exist are skipped, so an import can be repeated.
+func (s *RecoveryService) ImportCredentials(ctx context.Context, tenantID, importedBy string, bundle *CredentialBundle, recoveryKey *rsa.PrivateKey) (*ImportResult, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if importedBy == "" {
+		return nil, ErrUnauthorized
+	}
+	if bundle == nil {
+		return nil, ErrInvalidInput
+	}
+	if bundle.Version != BundleVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBundle, bundle.Version)
+	}
+	if recoveryKey == nil {
+		return nil, ErrInvalidRecoveryKey
+	}
+
+	fingerprint, err := RecoveryKeyFingerprint(&recoveryKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint != bundle.RecoveryKeyFingerprint {
+		return nil, ErrRecoveryKeyMismatch
+	}
+
+	result := &ImportResult{Skipped: []string{}}
+	for _, bundled := range bundle.Credentials {
+		err := s.importCredential(ctx, tenantID, importedBy, bundled, recoveryKey)
+		if errors.Is(err, ErrDuplicateCredential) {
+			result.Skipped = append(result.Skipped, bundled.Name)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to import credential %s: %w", bundled.Name, err)
+		}
+		result.Imported++
+	}
+
+	s.logger.Warn("credentials imported from recovery bundle",
+		"tenant_id", tenantID,
+		"source_tenant_id", bundle.TenantID,
+		"imported_by", importedBy,
+		"imported", result.Imported,
+		"skipped", len(result.Skipped))
+
+	return result, nil
+}
+
+// importCredential opens a bundled credential and stores it re-encrypted
+func (s *RecoveryService) importCredential(ctx context.Context, tenantID, importedBy string, bundled *BundledCredential, recoveryKey *rsa.PrivateKey) error {
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, recoveryKey, bundled.WrappedKey, bundleOAEPLabel)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap bundle key: %w", err)
+	}
+	defer clear(key)
+
+	plaintext, err := openBundleValue(key, bundled.Nonce, bundled.Ciphertext)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	var value map[string]interface{}
+	if err := json.Unmarshal(plaintext, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal credential value: %w", err)
+	}
+
+	encrypted, err := s.encryption.Encrypt(ctx, tenantID, &CredentialData{Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt credential: %w", err)
+	}
+
+	_, err = s.repo.Create(ctx, tenantID, importedBy, &Credential{
+		Name:         bundled.Name,
+		Description:  bundled.Description,
+		Type:         bundled.Type,
+		Status:       bundled.Status,
+		ExpiresAt:    bundled.ExpiresAt,
+		Metadata:     bundled.Metadata,
+		EncryptedDEK: encrypted.EncryptedDEK,
+		Ciphertext:   encrypted.Ciphertext,
+		Nonce:        encrypted.Nonce,
+		AuthTag:      encrypted.AuthTag,
+		KMSKeyID:     encrypted.KMSKeyID,
+	})
+	return err
+}
+
+// sealBundleValue encrypts plaintext with AES-256-GCM, returning the nonce
+// and the ciphertext with its authentication tag appended
+func sealBundleValue(key, plaintext []byte) ([]byte, []byte, error) {
+	gcm, err := newBundleGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return nonce, gcm.Seal(nil, nonce, plaintext, nil), nil
+}
+
+// openBundleValue decrypts a value sealed by sealBundleValue
+func openBundleValue(key, nonce, ciphertext []byte) ([]byte, error) {
+	gcm, err := newBundleGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return plaintext, nil
+}
+
+func newBundleGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// checkRecoveryKey rejects missing and undersized recovery keys
+func checkRecoveryKey(key *rsa.PublicKey) error {
+	if key == nil {
+		return ErrInvalidRecoveryKey
+	}
+	if key.N.BitLen() < minRecoveryKeyBits {
+		return fmt.Errorf("%w: RSA key must be at least %d bits", ErrInvalidRecoveryKey, minRecoveryKeyBits)
+	}
+	return nil
+}
+
+// RecoveryKeyFingerprint returns the hex SHA-256 of a recovery key's PKIX encoding
+func RecoveryKeyFingerprint(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRecoveryKey, err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ParseRecoveryPublicKey parses a PEM encoded RSA public key in PKIX or PKCS#1 form
+func ParseRecoveryPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidRecoveryKey)
+	}
+
+	var key *rsa.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecoveryKey, err)
+		}
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: not an RSA key", ErrInvalidRecoveryKey)
+		}
+		key = rsaKey
+	case "RSA PUBLIC KEY":
+		parsed, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecoveryKey, err)
+		}
+		key = parsed
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrInvalidRecoveryKey, block.Type)
+	}
+
+	if err := checkRecoveryKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ParseRecoveryPrivateKey parses a PEM encoded RSA private key in PKCS#8 or PKCS#1 form
+func ParseRecoveryPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrInvalidRecoveryKey)
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecoveryKey, err)
+		}
+		key, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: not an RSA key", ErrInvalidRecoveryKey)
+		}
+		return key, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecoveryKey, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrInvalidRecoveryKey, block.Type)
+	}
+}
@@ -0,0 +1,166 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecoveryRepo stores credentials by name and records access logs
+type fakeRecoveryRepo struct {
+	credentials []*Credential
+	accessLogs  []*AccessLog
+}
+
+func (r *fakeRecoveryRepo) List(ctx context.Context, tenantID string, filter CredentialListFilter) ([]*Credential, error) {
+	return r.credentials, nil
+}
+
+func (r *fakeRecoveryRepo) Create(ctx context.Context, tenantID, createdBy string, cred *Credential) (*Credential, error) {
+	for _, existing := range r.credentials {
+		if existing.Name == cred.Name {
+			return nil, ErrDuplicateCredential
+		}
+	}
+	cred.ID = "cred-" + cred.Name
+	cred.TenantID = tenantID
+	cred.CreatedBy = createdBy
+	r.credentials = append(r.credentials, cred)
+	return cred, nil
+}
+
+func (r *fakeRecoveryRepo) LogAccess(ctx context.Context, log *AccessLog) error {
+	r.accessLogs = append(r.accessLogs, log)
+	return nil
+}
+
+func newRecoveryCluster(t *testing.T) (*RecoveryService, *fakeRecoveryRepo, EncryptionServiceInterface) {
+	t.Helper()
+	masterKey := make([]byte, 32)
+	_, err := rand.Read(masterKey)
+	require.NoError(t, err)
+	simple, err := NewSimpleEncryptionService(masterKey)
+	require.NoError(t, err)
+	encryption := NewSimpleEncryptionAdapter(simple)
+	repo := &fakeRecoveryRepo{}
+	return NewRecoveryService(repo, encryption, nil), repo, encryption
+}
+
+func addCredential(t *testing.T, repo *fakeRecoveryRepo, encryption EncryptionServiceInterface, name string, value map[string]interface{}) {
+	t.Helper()
+	encrypted, err := encryption.Encrypt(context.Background(), "tenant-1", &CredentialData{Value: value})
+	require.NoError(t, err)
+	repo.credentials = append(repo.credentials, &Credential{
+		ID:           "cred-" + name,
+		Name:         name,
+		Type:         TypeAPIKey,
+		Status:       StatusActive,
+		EncryptedDEK: encrypted.EncryptedDEK,
+		Ciphertext:   encrypted.Ciphertext,
+		Nonce:        encrypted.Nonce,
+		AuthTag:      encrypted.AuthTag,
+		KMSKeyID:     encrypted.KMSKeyID,
+	})
+}
+
+func TestRecoveryService_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	recoveryKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	source, sourceRepo, sourceEncryption := newRecoveryCluster(t)
+	addCredential(t, sourceRepo, sourceEncryption, "stripe", map[string]interface{}{"api_key": "sk-source-secret"})
+	addCredential(t, sourceRepo, sourceEncryption, "github", map[string]interface{}{"token": "gh-source-secret"})
+
+	bundle, err := source.ExportCredentials(ctx, "tenant-1", "admin-1", &recoveryKey.PublicKey)
+	require.NoError(t, err)
+	require.Len(t, bundle.Credentials, 2)
+
+	// The bundle carries neither plaintext nor the live data keys
+	encoded, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "source-secret")
+	for i, bundled := range bundle.Credentials {
+		assert.False(t, bytes.Equal(bundled.Ciphertext, sourceRepo.credentials[i].Ciphertext))
+	}
+
+	// Every export is logged against the caller
+	require.Len(t, sourceRepo.accessLogs, 2)
+	for _, log := range sourceRepo.accessLogs {
+		assert.Equal(t, AccessTypeExport, log.AccessType)
+		assert.Equal(t, "admin-1", log.AccessedBy)
+		assert.True(t, log.Success)
+	}
+
+	target, targetRepo, targetEncryption := newRecoveryCluster(t)
+	addCredential(t, targetRepo, targetEncryption, "github", map[string]interface{}{"token": "existing"})
+
+	result, err := target.ImportCredentials(ctx, "tenant-2", "admin-2", bundle, recoveryKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, []string{"github"}, result.Skipped)
+
+	imported := targetRepo.credentials[1]
+	assert.Equal(t, "stripe", imported.Name)
+	encryptedData := append(append(append([]byte{}, imported.Nonce...), imported.Ciphertext...), imported.AuthTag...)
+	data, err := targetEncryption.Decrypt(ctx, encryptedData, imported.EncryptedDEK)
+	require.NoError(t, err)
+	assert.Equal(t, "sk-source-secret", data.Value["api_key"])
+}
+
+func TestRecoveryService_ImportCredentials_WrongKey(t *testing.T) {
+	ctx := context.Background()
+	recoveryKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	source, sourceRepo, sourceEncryption := newRecoveryCluster(t)
+	addCredential(t, sourceRepo, sourceEncryption, "stripe", map[string]interface{}{"api_key": "secret"})
+	bundle, err := source.ExportCredentials(ctx, "tenant-1", "admin-1", &recoveryKey.PublicKey)
+	require.NoError(t, err)
+
+	target, targetRepo, _ := newRecoveryCluster(t)
+	_, err = target.ImportCredentials(ctx, "tenant-1", "admin-1", bundle, otherKey)
+
+	assert.ErrorIs(t, err, ErrRecoveryKeyMismatch)
+	assert.Empty(t, targetRepo.credentials)
+}
+
+func TestRecoveryService_ExportCredentials_RejectsWeakKey(t *testing.T) {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	svc, _, _ := newRecoveryCluster(t)
+
+	_, err = svc.ExportCredentials(context.Background(), "tenant-1", "admin-1", &weakKey.PublicKey)
+
+	assert.ErrorIs(t, err, ErrInvalidRecoveryKey)
+}
+
+func TestParseRecoveryKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey, err := ParseRecoveryPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	privateKey, err := ParseRecoveryPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(privateKey))
+
+	_, err = ParseRecoveryPublicKey([]byte("not a key"))
+	assert.ErrorIs(t, err, ErrInvalidRecoveryKey)
+}
@@ -0,0 +1,65 @@
+package credential
+
+import (
+	"context"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+// EncryptionRouter picks the encryption service of a tenant's data region
+type EncryptionRouter interface {
+	For(ctx context.Context, tenantID string) (EncryptionServiceInterface, error)
+	Default() EncryptionServiceInterface
+}
+
+// RegionalEncryptionService implements EncryptionServiceInterface by sending
+// each tenant's credentials to the encryption service of its data region,
+// so they are wrapped with a KMS key in that region
+type RegionalEncryptionService struct {
+	router EncryptionRouter
+}
+
+// NewRegionalEncryptionService creates an encryption service routed by data region
+func NewRegionalEncryptionService(router EncryptionRouter) *RegionalEncryptionService {
+	return &RegionalEncryptionService{router: router}
+}
+
+// Encrypt encrypts data with the encryption service of tenantID's region
+func (s *RegionalEncryptionService) Encrypt(ctx context.Context, tenantID string, data *CredentialData) (*EncryptedSecret, error) {
+	service, err := s.router.For(ctx, tenantID)
+	if err != nil {
+		return nil, &EncryptionError{Op: "Encrypt", Err: err}
+	}
+	return service.Encrypt(ctx, tenantID, data)
+}
+
+// Decrypt decrypts data with the encryption service of the region of the
+// tenant in ctx. Secrets encrypted before the tenant was pinned to a region,
+// and platform secrets read on a tenant's behalf, were wrapped by the default
+// service, so it is tried when the regional service fails. Without a tenant
+// in ctx only the default service is used; it cannot unwrap a regional key.
+func (s *RegionalEncryptionService) Decrypt(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+	tenantID := tenantctx.GetTenantID(ctx)
+	if tenantID == "" {
+		return s.router.Default().Decrypt(ctx, encryptedData, encryptedKey)
+	}
+
+	service, err := s.router.For(ctx, tenantID)
+	if err != nil {
+		return nil, &DecryptionError{Op: "Decrypt", Err: err}
+	}
+
+	data, err := service.Decrypt(ctx, encryptedData, encryptedKey)
+	if err == nil {
+		return data, nil
+	}
+
+	fallback := s.router.Default()
+	if service == fallback {
+		return nil, err
+	}
+	if data, fallbackErr := fallback.Decrypt(ctx, encryptedData, encryptedKey); fallbackErr == nil {
+		return data, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,148 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+type stubEncryptionRouter struct {
+	fallback EncryptionServiceInterface
+	regional map[string]EncryptionServiceInterface
+	err      error
+}
+
+func (r *stubEncryptionRouter) For(ctx context.Context, tenantID string) (EncryptionServiceInterface, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if service, ok := r.regional[tenantID]; ok {
+		return service, nil
+	}
+	return r.fallback, nil
+}
+
+func (r *stubEncryptionRouter) Default() EncryptionServiceInterface {
+	return r.fallback
+}
+
+func encryptingAs(name string) func(context.Context, string, *CredentialData) (*EncryptedSecret, error) {
+	return func(ctx context.Context, tenantID string, data *CredentialData) (*EncryptedSecret, error) {
+		return &EncryptedSecret{KMSKeyID: name}, nil
+	}
+}
+
+func decryptingAs(name string) func(context.Context, []byte, []byte) (*CredentialData, error) {
+	return func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+		return &CredentialData{Value: map[string]interface{}{"service": name}}, nil
+	}
+}
+
+func failingDecrypt(err error) func(context.Context, []byte, []byte) (*CredentialData, error) {
+	return func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+		return nil, err
+	}
+}
+
+func TestRegionalEncryptionService_Encrypt(t *testing.T) {
+	router := &stubEncryptionRouter{
+		fallback: &MockEncryptionService{EncryptFunc: encryptingAs("default")},
+		regional: map[string]EncryptionServiceInterface{
+			"tenant-eu": &MockEncryptionService{EncryptFunc: encryptingAs("eu")},
+		},
+	}
+	service := NewRegionalEncryptionService(router)
+
+	secret, err := service.Encrypt(context.Background(), "tenant-eu", &CredentialData{})
+	require.NoError(t, err)
+	assert.Equal(t, "eu", secret.KMSKeyID)
+
+	secret, err = service.Encrypt(context.Background(), "tenant-us", &CredentialData{})
+	require.NoError(t, err)
+	assert.Equal(t, "default", secret.KMSKeyID)
+
+	t.Run("routing error", func(t *testing.T) {
+		routeErr := errors.New("region not configured")
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{err: routeErr})
+
+		_, err := service.Encrypt(context.Background(), "tenant-eu", &CredentialData{})
+
+		var encErr *EncryptionError
+		require.ErrorAs(t, err, &encErr)
+		assert.ErrorIs(t, err, routeErr)
+	})
+}
+
+func TestRegionalEncryptionService_Decrypt(t *testing.T) {
+	euCtx := tenantctx.WithTenantID(context.Background(), "tenant-eu")
+
+	t.Run("uses the region of the tenant in context", func(t *testing.T) {
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{
+			fallback: &MockEncryptionService{DecryptFunc: decryptingAs("default")},
+			regional: map[string]EncryptionServiceInterface{
+				"tenant-eu": &MockEncryptionService{DecryptFunc: decryptingAs("eu")},
+			},
+		})
+
+		data, err := service.Decrypt(euCtx, []byte("data"), []byte("key"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "eu", data.Value["service"])
+	})
+
+	t.Run("falls back to the default service", func(t *testing.T) {
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{
+			fallback: &MockEncryptionService{DecryptFunc: decryptingAs("default")},
+			regional: map[string]EncryptionServiceInterface{
+				"tenant-eu": &MockEncryptionService{DecryptFunc: failingDecrypt(errors.New("wrong region"))},
+			},
+		})
+
+		data, err := service.Decrypt(euCtx, []byte("data"), []byte("key"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "default", data.Value["service"])
+	})
+
+	t.Run("uses the default service without a tenant", func(t *testing.T) {
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{
+			fallback: &MockEncryptionService{DecryptFunc: decryptingAs("default")},
+			err:      errors.New("tenant is required"),
+		})
+
+		data, err := service.Decrypt(context.Background(), []byte("data"), []byte("key"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "default", data.Value["service"])
+	})
+
+	t.Run("returns the regional error when both fail", func(t *testing.T) {
+		regionalErr := errors.New("wrong region")
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{
+			fallback: &MockEncryptionService{DecryptFunc: failingDecrypt(errors.New("default failed"))},
+			regional: map[string]EncryptionServiceInterface{
+				"tenant-eu": &MockEncryptionService{DecryptFunc: failingDecrypt(regionalErr)},
+			},
+		})
+
+		_, err := service.Decrypt(euCtx, []byte("data"), []byte("key"))
+
+		assert.ErrorIs(t, err, regionalErr)
+	})
+
+	t.Run("routing error", func(t *testing.T) {
+		routeErr := errors.New("region not configured")
+		service := NewRegionalEncryptionService(&stubEncryptionRouter{err: routeErr})
+
+		_, err := service.Decrypt(euCtx, []byte("data"), []byte("key"))
+
+		var decErr *DecryptionError
+		require.ErrorAs(t, err, &decErr)
+		assert.ErrorIs(t, err, routeErr)
+	})
+}
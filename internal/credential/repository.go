@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -158,7 +159,7 @@ func (r *Repository) GetByID(ctx context.Context, tenantID, id string) (*Credent
 		return nil, fmt.Errorf("failed to set tenant context: %w", err)
 	}
 
-	query := `SELECT * FROM credentials WHERE id = $1 AND tenant_id = $2`
+	query := `SELECT * FROM credentials WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
 
 	var cred Credential
 	err = tx.GetContext(ctx, &cred, query, id, tenantID)
@@ -199,7 +200,7 @@ func (r *Repository) GetByName(ctx context.Context, tenantID, name string) (*Cre
 		return nil, fmt.Errorf("failed to set tenant context: %w", err)
 	}
 
-	query := `SELECT * FROM credentials WHERE name = $1 AND tenant_id = $2`
+	query := `SELECT * FROM credentials WHERE name = $1 AND tenant_id = $2 AND deleted_at IS NULL`
 
 	var cred Credential
 	err = tx.GetContext(ctx, &cred, query, name, tenantID)
@@ -261,13 +262,25 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input *Upd
 	}
 
 	if input.Metadata != nil {
-		metadataJSON, err := json.Marshal(input.Metadata)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		if input.MetadataMerge == MetadataReplace {
+			metadataJSON, err := json.Marshal(input.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			}
+			updates = append(updates, fmt.Sprintf("metadata = $%d", argIndex))
+			args = append(args, metadataJSON)
+			argIndex++
+		} else {
+			set, remove := splitMetadataMerge(input.Metadata)
+			setJSON, err := json.Marshal(set)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			}
+			// Removing before merging lets a null drop the key rather than store null
+			updates = append(updates, fmt.Sprintf("metadata = (COALESCE(metadata, '{}'::jsonb) - $%d::text[]) || $%d::jsonb", argIndex, argIndex+1))
+			args = append(args, pq.Array(remove), setJSON)
+			argIndex += 2
 		}
-		updates = append(updates, fmt.Sprintf("metadata = $%d", argIndex))
-		args = append(args, metadataJSON)
-		argIndex++
 	}
 
 	// Always update updated_at
@@ -298,7 +311,7 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input *Upd
 	query := fmt.Sprintf(`
 		UPDATE credentials
 		SET %s
-		WHERE id = $%d AND tenant_id = $%d
+		WHERE id = $%d AND tenant_id = $%d AND deleted_at IS NULL
 		RETURNING *
 	`, joinUpdates(updates), argIndex, argIndex+1)
 
@@ -318,7 +331,23 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input *Upd
 	return &updated, nil
 }
 
-// Delete deletes a credential
+// splitMetadataMerge separates a metadata merge into the keys to set and the
+// keys sent as null, which are removed
+func splitMetadataMerge(metadata JSONMap) (JSONMap, []string) {
+	set := make(JSONMap, len(metadata))
+	remove := []string{}
+	for key, value := range metadata {
+		if value == nil {
+			remove = append(remove, key)
+			continue
+		}
+		set[key] = value
+	}
+	sort.Strings(remove)
+	return set, remove
+}
+
+// Delete permanently deletes a credential, whether or not it was soft-deleted
 func (r *Repository) Delete(ctx context.Context, tenantID, id string) error {
 	if tenantID == "" {
 		return ErrInvalidTenantID
@@ -364,6 +393,134 @@ func (r *Repository) Delete(ctx context.Context, tenantID, id string) error {
 	return nil
 }
 
+// SoftDelete hides a credential until it is restored or purged
+func (r *Repository) SoftDelete(ctx context.Context, tenantID, id, deletedBy string) error {
+	if tenantID == "" {
+		return ErrInvalidTenantID
+	}
+
+	if id == "" {
+		return ErrInvalidCredentialID
+	}
+
+	// Start transaction for RLS context
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	// Set tenant context for RLS within transaction using set_config
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.current_tenant_id', $1, true)", tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant context: %w", err)
+	}
+
+	query := `
+		UPDATE credentials
+		SET deleted_at = NOW(), deleted_by = $1
+		WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, deletedBy, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Restore brings back a credential soft-deleted after deletedSince. A live
+// credential created with the same name in the meantime blocks the restore.
+func (r *Repository) Restore(ctx context.Context, tenantID, id string, deletedSince time.Time) (*Credential, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+
+	if id == "" {
+		return nil, ErrInvalidCredentialID
+	}
+
+	// Start transaction for RLS context
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	// Set tenant context for RLS within transaction using set_config
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.current_tenant_id', $1, true)", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set tenant context: %w", err)
+	}
+
+	query := `
+		UPDATE credentials
+		SET deleted_at = NULL, deleted_by = NULL, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at > $3
+		RETURNING *
+	`
+
+	var restored Credential
+	err = tx.QueryRowxContext(ctx, query, id, tenantID, deletedSince).StructScan(&restored)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return nil, ErrDuplicateCredential
+		}
+		return nil, fmt.Errorf("failed to restore credential: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &restored, nil
+}
+
+// PurgeDeleted permanently deletes credentials soft-deleted before the cutoff,
+// across all tenants, and returns how many were removed. Credentials still
+// referenced by a database connection are kept.
+func (r *Repository) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	start := time.Now()
+
+	query := `
+		DELETE FROM credentials
+		WHERE deleted_at < $1
+		AND NOT EXISTS (
+			SELECT 1 FROM database_connections dc WHERE dc.credential_id = credentials.id
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	r.recordQuery("delete", "credentials", start, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted credentials: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
 // List retrieves all credentials for a tenant with optional filtering
 func (r *Repository) List(ctx context.Context, tenantID string, filter CredentialListFilter) ([]*Credential, error) {
 	if tenantID == "" {
@@ -383,7 +540,7 @@ func (r *Repository) List(ctx context.Context, tenantID string, filter Credentia
 		return nil, fmt.Errorf("failed to set tenant context: %w", err)
 	}
 
-	query := `SELECT * FROM credentials WHERE tenant_id = $1`
+	query := `SELECT * FROM credentials WHERE tenant_id = $1 AND deleted_at IS NULL`
 	args := []any{tenantID}
 	argIndex := 2
 
@@ -408,7 +565,12 @@ func (r *Repository) List(ctx context.Context, tenantID string, filter Credentia
 		args = append(args, searchPattern)
 	}
 
-	query += " ORDER BY created_at DESC"
+	keyset, limitClause, args := filter.Page.Keyset("", args)
+	if keyset != "" {
+		query += " AND " + keyset
+	}
+
+	query += " ORDER BY created_at DESC, id DESC" + limitClause
 
 	var credentials []*Credential
 	err = tx.SelectContext(ctx, &credentials, query, args...)
@@ -515,9 +677,9 @@ func (r *Repository) LogAccess(ctx context.Context, log *AccessLog) error {
 	query := `
 		INSERT INTO credential_access_log (
 			id, credential_id, tenant_id, accessed_by, access_type,
-			accessed_at, ip_address, user_agent, success, error_message
+			accessed_at, ip_address, user_agent, success, error_message, keys
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 		)
 	`
 
@@ -526,7 +688,7 @@ func (r *Repository) LogAccess(ctx context.Context, log *AccessLog) error {
 	_, err = tx.ExecContext(
 		ctx, query,
 		log.ID, log.CredentialID, log.TenantID, log.AccessedBy, log.AccessType,
-		now, log.IPAddress, log.UserAgent, log.Success, log.ErrorMessage,
+		now, log.IPAddress, log.UserAgent, log.Success, log.ErrorMessage, log.Keys,
 	)
 
 	if err != nil {
@@ -556,7 +718,7 @@ func (r *Repository) GetAccessLogs(ctx context.Context, credentialID string, lim
 
 	query := `
 		SELECT id, credential_id, tenant_id, accessed_by, access_type,
-		       accessed_at, ip_address, user_agent, success, error_message
+		       accessed_at, ip_address, user_agent, success, error_message, keys
 		FROM credential_access_log
 		WHERE credential_id = $1
 		ORDER BY accessed_at DESC
@@ -624,7 +786,7 @@ func (r *Repository) ListWithPagination(ctx context.Context, tenantID string, fi
 	}
 
 	// Build base query and count query
-	baseWhere := "WHERE tenant_id = $1"
+	baseWhere := "WHERE tenant_id = $1 AND deleted_at IS NULL"
 	args := []any{tenantID}
 	argIndex := 2
 
@@ -912,6 +1074,7 @@ func (r *Repository) GetExpiredCredentials(ctx context.Context, tenantID string,
 	query := `
 		SELECT * FROM credentials
 		WHERE tenant_id = $1
+		AND deleted_at IS NULL
 		AND expires_at IS NOT NULL
 		AND expires_at <= $2
 		AND status = 'active'
@@ -935,9 +1098,11 @@ func (r *Repository) GetExpiredCredentials(ctx context.Context, tenantID string,
 	return credentials, nil
 }
 
-// ValidateAndGet retrieves a credential by name after validation (implements RepositoryInterface for Injector)
+// ValidateAndGet retrieves a credential by name after validation (implements RepositoryInterface for Injector).
+// A credential deleted within DeletedValueGracePeriod still resolves, so
+// executions already running when it was deleted can finish.
 func (r *Repository) ValidateAndGet(ctx context.Context, tenantID, name string) (*Credential, error) {
-	cred, err := r.GetByName(ctx, tenantID, name)
+	cred, err := r.getByNameForExecution(ctx, tenantID, name)
 	if err != nil {
 		return nil, err
 	}
@@ -954,6 +1119,54 @@ func (r *Repository) ValidateAndGet(ctx context.Context, tenantID, name string)
 	return cred, nil
 }
 
+// getByNameForExecution retrieves a credential by name, preferring a live
+// credential over one deleted within DeletedValueGracePeriod
+func (r *Repository) getByNameForExecution(ctx context.Context, tenantID, name string) (*Credential, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+
+	if name == "" {
+		return nil, ErrInvalidCredentialName
+	}
+
+	// Start transaction for RLS context
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	// Set tenant context for RLS within transaction using set_config
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.current_tenant_id', $1, true)", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set tenant context: %w", err)
+	}
+
+	query := `
+		SELECT * FROM credentials
+		WHERE name = $1 AND tenant_id = $2
+		AND (deleted_at IS NULL OR deleted_at > $3)
+		ORDER BY deleted_at DESC NULLS FIRST
+		LIMIT 1
+	`
+
+	var cred Credential
+	err = tx.GetContext(ctx, &cred, query, name, tenantID, time.Now().Add(-DeletedValueGracePeriod))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &cred, nil
+}
+
 // UpdateAccessTime updates the last_used_at timestamp (alias for UpdateLastUsedAt to satisfy RepositoryInterface)
 func (r *Repository) UpdateAccessTime(ctx context.Context, tenantID, credentialID string) error {
 	return r.UpdateLastUsedAt(ctx, tenantID, credentialID)
@@ -988,7 +1201,7 @@ func (r *Repository) RotateCredential(ctx context.Context, tenantID, credentialI
 
 	// Get existing credential to verify it exists
 	var existing Credential
-	err = tx.GetContext(ctx, &existing, `SELECT * FROM credentials WHERE id = $1 AND tenant_id = $2`, credentialID, tenantID)
+	err = tx.GetContext(ctx, &existing, `SELECT * FROM credentials WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, credentialID, tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -1078,3 +1291,128 @@ func (r *Repository) RotateCredential(ctx context.Context, tenantID, credentialI
 
 	return &updated, nil
 }
+
+// GetTenantKMSKeyID returns the KMS key a tenant's data keys are wrapped
+// with, or "" when the tenant uses the default key
+func (r *Repository) GetTenantKMSKeyID(ctx context.Context, tenantID string) (string, error) {
+	var keyID sql.NullString
+	err := r.db.GetContext(ctx, &keyID, `SELECT kms_key_id FROM tenants WHERE id = $1`, tenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant KMS key: %w", err)
+	}
+	return keyID.String, nil
+}
+
+// SetTenantKMSKeyID sets the KMS key new data keys for a tenant are wrapped with
+func (r *Repository) SetTenantKMSKeyID(ctx context.Context, tenantID, keyID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tenants SET kms_key_id = NULLIF($2, ''), updated_at = NOW() WHERE id = $1`,
+		tenantID, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant KMS key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrInvalidTenantID
+	}
+	return nil
+}
+
+// wrappedKeyColumns maps each WrappedKeySource to its table and columns
+var wrappedKeyColumns = map[WrappedKeySource]struct {
+	table, dek, keyID string
+}{
+	WrappedKeyCredential:        {"credentials", "encrypted_dek", "kms_key_id"},
+	WrappedKeyCredentialVersion: {"credential_versions", "encrypted_dek", "kms_key_id"},
+	WrappedKeyOAuthAccessToken:  {"oauth_connections", "access_token_encrypted_dek", "access_token_kms_key_id"},
+	WrappedKeyOAuthRefreshToken: {"oauth_connections", "refresh_token_encrypted_dek", "refresh_token_kms_key_id"},
+}
+
+// ListWrappedKeys returns up to limit of a tenant's encrypted data keys that
+// are not wrapped with keyID. Secrets stored with simple encryption have no
+// KMS-wrapped key and are skipped.
+func (r *Repository) ListWrappedKeys(ctx context.Context, tenantID, keyID string, limit int) ([]*WrappedKey, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.current_tenant_id', $1, true)", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set tenant context: %w", err)
+	}
+
+	query := `
+		SELECT source, id, encrypted_dek, kms_key_id FROM (
+			SELECT 'credential' AS source, id::text AS id, encrypted_dek, kms_key_id
+			FROM credentials WHERE tenant_id = $1
+			UNION ALL
+			SELECT 'credential_version', id::text, encrypted_dek, kms_key_id
+			FROM credential_versions WHERE tenant_id = $1
+			UNION ALL
+			SELECT 'oauth_access_token', id::text, access_token_encrypted_dek, access_token_kms_key_id
+			FROM oauth_connections WHERE tenant_id = $1
+			UNION ALL
+			SELECT 'oauth_refresh_token', id::text, refresh_token_encrypted_dek, refresh_token_kms_key_id
+			FROM oauth_connections WHERE tenant_id = $1 AND refresh_token_encrypted_dek IS NOT NULL
+		) keys
+		WHERE kms_key_id <> $2 AND kms_key_id <> 'simple-encryption'
+		LIMIT $3
+	`
+
+	var keys []*WrappedKey
+	if err := tx.SelectContext(ctx, &keys, query, tenantID, keyID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list wrapped keys: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return keys, nil
+}
+
+// UpdateWrappedKey stores a re-wrapped data key. The update only applies if
+// the key is still wrapped with key.KMSKeyID, so a secret re-encrypted
+// concurrently is left alone.
+func (r *Repository) UpdateWrappedKey(ctx context.Context, tenantID string, key *WrappedKey, encryptedDEK []byte, keyID string) error {
+	columns, ok := wrappedKeyColumns[key.Source]
+	if !ok {
+		return fmt.Errorf("unknown wrapped key source: %s", key.Source)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	_, err = tx.ExecContext(ctx, "SELECT set_config('app.current_tenant_id', $1, true)", tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant context: %w", err)
+	}
+
+	// Table and column names come from wrappedKeyColumns, never from input
+	query := fmt.Sprintf(`
+		UPDATE %s SET %s = $1, %s = $2
+		WHERE id = $3 AND tenant_id = $4 AND %s = $5
+	`, columns.table, columns.dek, columns.keyID, columns.keyID)
+
+	if _, err := tx.ExecContext(ctx, query, encryptedDEK, keyID, key.ID, tenantID, key.KMSKeyID); err != nil {
+		return fmt.Errorf("failed to update wrapped key: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
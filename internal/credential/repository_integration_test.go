@@ -513,6 +513,36 @@ func TestRepository_Update(t *testing.T) {
 		assert.Equal(t, "value", updated.Metadata["key"])
 	})
 
+	t.Run("metadata merges by default", func(t *testing.T) {
+		cred := createTestCredential(t, repo, ctx, tenantID, TypeAPIKey, "metadata-merge-test")
+		_, err := repo.Update(ctx, tenantID, cred.ID, &UpdateCredentialInput{
+			Metadata:      map[string]interface{}{"owner": "payments", "env": "prod", "ticket": "OPS-1"},
+			MetadataMerge: MetadataReplace,
+		})
+		require.NoError(t, err)
+
+		updated, err := repo.Update(ctx, tenantID, cred.ID, &UpdateCredentialInput{
+			Metadata: map[string]interface{}{"env": "staging", "ticket": nil},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, JSONMap{"owner": "payments", "env": "staging"}, updated.Metadata)
+	})
+
+	t.Run("metadata replace drops keys not sent", func(t *testing.T) {
+		cred := createTestCredential(t, repo, ctx, tenantID, TypeAPIKey, "metadata-replace-test")
+		_, err := repo.Update(ctx, tenantID, cred.ID, &UpdateCredentialInput{
+			Metadata: map[string]interface{}{"owner": "payments", "env": "prod"},
+		})
+		require.NoError(t, err)
+
+		updated, err := repo.Update(ctx, tenantID, cred.ID, &UpdateCredentialInput{
+			Metadata:      map[string]interface{}{"env": "staging"},
+			MetadataMerge: MetadataReplace,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, JSONMap{"env": "staging"}, updated.Metadata)
+	})
+
 	t.Run("update non-existent credential", func(t *testing.T) {
 		newName := "test"
 		input := &UpdateCredentialInput{Name: &newName}
@@ -539,6 +569,13 @@ func TestRepository_Update(t *testing.T) {
 	})
 }
 
+func TestSplitMetadataMerge(t *testing.T) {
+	set, remove := splitMetadataMerge(JSONMap{"env": "staging", "ticket": nil, "old": nil})
+
+	assert.Equal(t, JSONMap{"env": "staging"}, set)
+	assert.Equal(t, []string{"old", "ticket"}, remove)
+}
+
 // =============================================================================
 // Delete Tests
 // =============================================================================
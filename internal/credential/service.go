@@ -18,12 +18,31 @@ type Service interface {
 	// GetValue returns the decrypted credential value (requires special permissions)
 	GetValue(ctx context.Context, tenantID, credentialID, userID string) (*DecryptedValue, error)
 
+	// GetCredentialValue returns only the requested keys of the decrypted
+	// value and logs which keys were read; no keys returns the whole value
+	GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*DecryptedValue, error)
+
+	// LogDeniedAccess records a value read refused to a caller lacking
+	// permission, with the keys requested for field-level reads
+	LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error
+
 	// Update updates credential metadata (not the value)
 	Update(ctx context.Context, tenantID, credentialID, userID string, input UpdateCredentialInput) (*Credential, error)
 
 	// Delete soft-deletes a credential
 	Delete(ctx context.Context, tenantID, credentialID, userID string) error
 
+	// Purge permanently deletes a credential, live or soft-deleted
+	Purge(ctx context.Context, tenantID, credentialID, userID string) error
+
+	// Restore undoes the deletion of a credential deleted within the
+	// retention window
+	Restore(ctx context.Context, tenantID, credentialID, userID string) (*Credential, error)
+
+	// BulkDelete deletes several credentials, permanently when permanent is
+	// set, and reports which succeeded and which failed
+	BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []BulkOperationError)
+
 	// Rotate creates a new version of the credential value
 	Rotate(ctx context.Context, tenantID, credentialID, userID string, input RotateCredentialInput) (*Credential, error)
 
@@ -32,4 +51,8 @@ type Service interface {
 
 	// GetAccessLog returns access log entries for a credential
 	GetAccessLog(ctx context.Context, tenantID, credentialID string, limit, offset int) ([]*AccessLog, error)
+
+	// TestCredential makes a probe request with the credential applied and
+	// reports whether it worked, without exposing the value
+	TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*CredentialTestResult, error)
 }
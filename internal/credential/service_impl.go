@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+	"github.com/gorax/gorax/internal/security"
 )
 
 // ServiceRepositoryInterface defines the repository operations needed by the service
@@ -13,6 +17,8 @@ type ServiceRepositoryInterface interface {
 	GetByID(ctx context.Context, tenantID, id string) (*Credential, error)
 	Update(ctx context.Context, tenantID, id string, input *UpdateCredentialInput) (*Credential, error)
 	Delete(ctx context.Context, tenantID, id string) error
+	SoftDelete(ctx context.Context, tenantID, id, deletedBy string) error
+	Restore(ctx context.Context, tenantID, id string, deletedSince time.Time) (*Credential, error)
 	List(ctx context.Context, tenantID string, filter CredentialListFilter) ([]*Credential, error)
 	ListWithPagination(ctx context.Context, tenantID string, filter CredentialListFilter, limit, offset int) ([]*Credential, int, error)
 	UpdateLastUsedAt(ctx context.Context, tenantID, id string) error
@@ -28,22 +34,91 @@ type ServiceImpl struct {
 	repo       ServiceRepositoryInterface
 	encryption EncryptionServiceInterface
 	logger     *slog.Logger
+	usage      *UsageTracker
+
+	// deletedRetention is how long a deleted credential can be restored
+	deletedRetention time.Duration
+
+	// Credential test probes
+	connections  ConnectionTester
+	testClient   *http.Client
+	urlValidator *security.URLValidator
+}
+
+// ServiceOption configures a ServiceImpl
+type ServiceOption func(*ServiceImpl)
+
+// WithUsageTracker records LastUsedAt through tracker instead of writing it
+// on every value read
+func WithUsageTracker(tracker *UsageTracker) ServiceOption {
+	return func(s *ServiceImpl) {
+		s.usage = tracker
+	}
+}
+
+// WithDeletedRetention sets how long a deleted credential can be restored
+// before it is purged
+func WithDeletedRetention(retention time.Duration) ServiceOption {
+	return func(s *ServiceImpl) {
+		s.deletedRetention = retention
+	}
 }
 
 // NewServiceImpl creates a new credential service implementation
-func NewServiceImpl(repo ServiceRepositoryInterface, encryption EncryptionServiceInterface, logger *slog.Logger) Service {
+func NewServiceImpl(repo ServiceRepositoryInterface, encryption EncryptionServiceInterface, logger *slog.Logger, opts ...ServiceOption) Service {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &ServiceImpl{
+	validator := security.NewURLValidator()
+	s := &ServiceImpl{
 		repo:       repo,
 		encryption: encryption,
 		logger:     logger,
+
+		deletedRetention: DefaultDeletedRetention,
+
+		testClient:   newTestClient(validator),
+		urlValidator: validator,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // GetValue retrieves and decrypts a credential value
 func (s *ServiceImpl) GetValue(ctx context.Context, tenantID, credentialID, userID string) (*DecryptedValue, error) {
+	return s.GetCredentialValue(ctx, tenantID, credentialID, userID, nil)
+}
+
+// GetCredentialValue retrieves and decrypts a credential value, keeping only
+// the requested keys. Requesting a key the value does not have is an error.
+func (s *ServiceImpl) GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*DecryptedValue, error) {
+	value, err := s.getValue(ctx, tenantID, credentialID)
+	if err == nil && len(keys) > 0 {
+		value, err = selectKeys(value, keys)
+	}
+
+	// Log every access attempt, successful or not
+	accessLog := &AccessLog{
+		CredentialID: credentialID,
+		TenantID:     tenantID,
+		AccessedBy:   userID,
+		AccessType:   AccessTypeRead,
+		AccessedAt:   time.Now().UTC(),
+		Success:      err == nil,
+		Keys:         keys,
+	}
+	if err != nil {
+		accessLog.ErrorMessage = err.Error()
+	}
+	// Note: Error is intentionally ignored as this is a non-critical operation
+	_ = s.repo.LogAccess(ctx, accessLog)
+
+	return value, err
+}
+
+func (s *ServiceImpl) getValue(ctx context.Context, tenantID, credentialID string) (*DecryptedValue, error) {
 	// Retrieve credential from repository
 	cred, err := s.repo.GetByID(ctx, tenantID, credentialID)
 	if err != nil {
@@ -65,26 +140,18 @@ func (s *ServiceImpl) GetValue(ctx context.Context, tenantID, credentialID, user
 	}
 
 	// Decrypt the credential value
-	decryptedData, err := s.encryption.Decrypt(ctx, encryptedSecret.Ciphertext, encryptedSecret.EncryptedDEK)
+	decryptedData, err := s.encryption.Decrypt(tenantctx.WithTenantID(ctx, tenantID), encryptedSecret.Ciphertext, encryptedSecret.EncryptedDEK)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt credential: %w", err)
 	}
 
-	// Update last used time (synchronous for now - could be made async)
+	// Update last used time, throttled and batched when a usage tracker is set
 	// Note: Error is intentionally ignored as this is a non-critical operation
-	_ = s.repo.UpdateLastUsedAt(ctx, tenantID, credentialID)
-
-	// Log access
-	accessLog := &AccessLog{
-		CredentialID: credentialID,
-		TenantID:     tenantID,
-		AccessedBy:   userID,
-		AccessType:   AccessTypeRead,
-		AccessedAt:   time.Now().UTC(),
-		Success:      true,
+	if s.usage != nil {
+		s.usage.Touch(tenantID, credentialID, cred.LastUsedAt)
+	} else {
+		_ = s.repo.UpdateLastUsedAt(ctx, tenantID, credentialID)
 	}
-	// Note: Error is intentionally ignored as this is a non-critical operation
-	_ = s.repo.LogAccess(ctx, accessLog)
 
 	// Build and return decrypted value
 	return &DecryptedValue{
@@ -94,6 +161,38 @@ func (s *ServiceImpl) GetValue(ctx context.Context, tenantID, credentialID, user
 	}, nil
 }
 
+// selectKeys returns a copy of value holding only keys
+func selectKeys(value *DecryptedValue, keys []string) (*DecryptedValue, error) {
+	selected := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		v, ok := value.Value[key]
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("credential value has no key %q", key)}
+		}
+		selected[key] = v
+	}
+
+	return &DecryptedValue{
+		Version:   value.Version,
+		Value:     selected,
+		CreatedAt: value.CreatedAt,
+	}, nil
+}
+
+// LogDeniedAccess records a value read refused because the caller lacks permission
+func (s *ServiceImpl) LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error {
+	return s.repo.LogAccess(ctx, &AccessLog{
+		CredentialID: credentialID,
+		TenantID:     tenantID,
+		AccessedBy:   userID,
+		AccessType:   AccessTypeRead,
+		AccessedAt:   time.Now().UTC(),
+		Success:      false,
+		ErrorMessage: ErrUnauthorized.Error(),
+		Keys:         keys,
+	})
+}
+
 // Create creates a new credential with encrypted value
 func (s *ServiceImpl) Create(ctx context.Context, tenantID, userID string, input CreateCredentialInput) (*Credential, error) {
 	// Validate input
@@ -207,26 +306,88 @@ func (s *ServiceImpl) Update(ctx context.Context, tenantID, credentialID, userID
 	return updated, nil
 }
 
-// Delete deletes a credential
+// Delete soft-deletes a credential. It stays restorable until the retention
+// window passes and it is purged.
 func (s *ServiceImpl) Delete(ctx context.Context, tenantID, credentialID, userID string) error {
 	// Delete via repository
-	err := s.repo.Delete(ctx, tenantID, credentialID)
+	err := s.repo.SoftDelete(ctx, tenantID, credentialID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete credential: %w", err)
 	}
 
-	// Log access
+	s.logLifecycle(ctx, tenantID, credentialID, userID, AccessTypeDelete)
+
+	return nil
+}
+
+// Purge permanently deletes a credential, live or soft-deleted
+func (s *ServiceImpl) Purge(ctx context.Context, tenantID, credentialID, userID string) error {
+	err := s.repo.Delete(ctx, tenantID, credentialID)
+	if err != nil {
+		return fmt.Errorf("failed to purge credential: %w", err)
+	}
+
+	s.logLifecycle(ctx, tenantID, credentialID, userID, AccessTypePurge)
+
+	return nil
+}
+
+// Restore undoes the deletion of a credential deleted within the retention
+// window
+func (s *ServiceImpl) Restore(ctx context.Context, tenantID, credentialID, userID string) (*Credential, error) {
+	restored, err := s.repo.Restore(ctx, tenantID, credentialID, time.Now().Add(-s.deletedRetention))
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore credential: %w", err)
+	}
+
+	s.logLifecycle(ctx, tenantID, credentialID, userID, AccessTypeRestore)
+
+	return restored, nil
+}
+
+// BulkDelete deletes up to MaxBulkDelete credentials, soft-deleting them
+// unless permanent is set. It returns the IDs deleted and a failure for each
+// ID that was not.
+func (s *ServiceImpl) BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []BulkOperationError) {
+	deleted := []string{}
+	failed := []BulkOperationError{}
+
+	if len(credentialIDs) > MaxBulkDelete {
+		message := fmt.Sprintf("cannot delete more than %d credentials at once", MaxBulkDelete)
+		for _, id := range credentialIDs {
+			failed = append(failed, BulkOperationError{ID: id, Error: message})
+		}
+		return deleted, failed
+	}
+
+	for _, id := range credentialIDs {
+		var err error
+		if permanent {
+			err = s.Purge(ctx, tenantID, id, userID)
+		} else {
+			err = s.Delete(ctx, tenantID, id, userID)
+		}
+		if err != nil {
+			failed = append(failed, BulkOperationError{ID: id, Error: err.Error()})
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+
+	return deleted, failed
+}
+
+// logLifecycle records a delete, purge or restore in the access log
+func (s *ServiceImpl) logLifecycle(ctx context.Context, tenantID, credentialID, userID, accessType string) {
 	accessLog := &AccessLog{
 		CredentialID: credentialID,
 		TenantID:     tenantID,
 		AccessedBy:   userID,
-		AccessType:   AccessTypeDelete,
+		AccessType:   accessType,
 		AccessedAt:   time.Now().UTC(),
 		Success:      true,
 	}
 	_ = s.repo.LogAccess(ctx, accessLog)
-
-	return nil
 }
 
 // Rotate creates a new version of the credential value with proper version tracking
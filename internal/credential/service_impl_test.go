@@ -3,6 +3,7 @@ package credential
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -199,6 +200,140 @@ func TestServiceImpl_GetValue_AccessLogging(t *testing.T) {
 	assert.True(t, loggedAccess.Success)
 }
 
+// TestServiceImpl_GetValue_LogsFailedAccess tests that failed reads are logged
+func TestServiceImpl_GetValue_LogsFailedAccess(t *testing.T) {
+	var loggedAccess *AccessLog
+
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, tenantID, credentialID string) (*Credential, error) {
+			return &Credential{
+				ID:           credentialID,
+				TenantID:     tenantID,
+				EncryptedDEK: []byte("key"),
+				Ciphertext:   []byte("data"),
+			}, nil
+		},
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			loggedAccess = log
+			return nil
+		},
+	}
+
+	mockEncryption := &MockEncryptionService{
+		DecryptFunc: func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+			return nil, errors.New("decryption failed")
+		},
+	}
+
+	service := NewServiceImpl(mockRepo, mockEncryption, nil)
+
+	_, err := service.GetValue(context.Background(), "tenant-123", "cred-123", "user-123")
+	require.Error(t, err)
+
+	require.NotNil(t, loggedAccess)
+	assert.Equal(t, "user-123", loggedAccess.AccessedBy)
+	assert.False(t, loggedAccess.Success)
+	assert.Contains(t, loggedAccess.ErrorMessage, "decryption failed")
+}
+
+// TestServiceImpl_LogDeniedAccess tests that refused value reads are logged
+func TestServiceImpl_LogDeniedAccess(t *testing.T) {
+	var loggedAccess *AccessLog
+
+	mockRepo := &MockRepository{
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			loggedAccess = log
+			return nil
+		},
+	}
+
+	service := NewServiceImpl(mockRepo, &MockEncryptionService{}, nil)
+
+	require.NoError(t, service.LogDeniedAccess(context.Background(), "tenant-123", "cred-123", "user-123", []string{"api_key"}))
+
+	require.NotNil(t, loggedAccess)
+	assert.Equal(t, "cred-123", loggedAccess.CredentialID)
+	assert.Equal(t, "user-123", loggedAccess.AccessedBy)
+	assert.Equal(t, AccessTypeRead, loggedAccess.AccessType)
+	assert.False(t, loggedAccess.Success)
+	assert.Equal(t, ErrUnauthorized.Error(), loggedAccess.ErrorMessage)
+	assert.Equal(t, []string{"api_key"}, []string(loggedAccess.Keys))
+}
+
+// TestServiceImpl_GetCredentialValue tests field-level value reads
+func TestServiceImpl_GetCredentialValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		wantValue map[string]interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "requested keys only",
+			keys:      []string{"api_key"},
+			wantValue: map[string]interface{}{"api_key": "key-123"},
+		},
+		{
+			name:      "no keys returns whole value",
+			keys:      nil,
+			wantValue: map[string]interface{}{"api_key": "key-123", "api_secret": "secret-456"},
+		},
+		{
+			name:    "unknown key",
+			keys:    []string{"api_key", "password"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loggedAccess *AccessLog
+
+			mockRepo := &MockRepository{
+				GetByIDFunc: func(ctx context.Context, tenantID, credentialID string) (*Credential, error) {
+					return &Credential{
+						ID:           credentialID,
+						TenantID:     tenantID,
+						EncryptedDEK: []byte("key"),
+						Ciphertext:   []byte("data"),
+					}, nil
+				},
+				LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+					loggedAccess = log
+					return nil
+				},
+			}
+
+			mockEncryption := &MockEncryptionService{
+				DecryptFunc: func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+					return &CredentialData{Value: map[string]interface{}{
+						"api_key":    "key-123",
+						"api_secret": "secret-456",
+					}}, nil
+				},
+			}
+
+			service := NewServiceImpl(mockRepo, mockEncryption, nil)
+
+			value, err := service.GetCredentialValue(context.Background(), "tenant-123", "cred-123", "user-123", tt.keys)
+
+			require.NotNil(t, loggedAccess)
+			assert.Equal(t, tt.keys, []string(loggedAccess.Keys))
+
+			if tt.wantErr {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.False(t, loggedAccess.Success)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValue, value.Value)
+			assert.True(t, loggedAccess.Success)
+		})
+	}
+}
+
 // TestServiceImpl_GetValue_UpdatesAccessTime tests that last accessed time is updated
 func TestServiceImpl_GetValue_UpdatesAccessTime(t *testing.T) {
 	accessTimeUpdated := false
@@ -240,6 +375,144 @@ func TestServiceImpl_GetValue_UpdatesAccessTime(t *testing.T) {
 	assert.True(t, accessTimeUpdated)
 }
 
+func TestServiceImpl_Update_MetadataMergeMode(t *testing.T) {
+	service := NewServiceImpl(&MockRepository{LogAccessFunc: func(ctx context.Context, log *AccessLog) error { return nil }}, &MockEncryptionService{}, nil)
+	metadata := JSONMap{"env": "staging"}
+
+	for _, mode := range []MetadataMergeMode{"", MetadataMerge, MetadataReplace} {
+		_, err := service.Update(context.Background(), "tenant-123", "cred-123", "user-123", UpdateCredentialInput{Metadata: metadata, MetadataMerge: mode})
+		assert.NoError(t, err, string(mode))
+	}
+
+	_, err := service.Update(context.Background(), "tenant-123", "cred-123", "user-123", UpdateCredentialInput{Metadata: metadata, MetadataMerge: "append"})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "metadata_merge")
+}
+
+func TestServiceImpl_Delete_SoftDeletes(t *testing.T) {
+	var softDeletedBy, accessType string
+	repo := &MockRepository{
+		DeleteFunc: func(ctx context.Context, tenantID, credentialID string) error {
+			t.Fatal("Delete should soft-delete, not purge")
+			return nil
+		},
+		SoftDeleteFunc: func(ctx context.Context, tenantID, credentialID, deletedBy string) error {
+			softDeletedBy = deletedBy
+			return nil
+		},
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			accessType = log.AccessType
+			return nil
+		},
+	}
+	service := NewServiceImpl(repo, &MockEncryptionService{}, nil)
+
+	err := service.Delete(context.Background(), "tenant-123", "cred-123", "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", softDeletedBy)
+	assert.Equal(t, AccessTypeDelete, accessType)
+}
+
+func TestServiceImpl_Restore_UsesRetentionWindow(t *testing.T) {
+	var deletedSince time.Time
+	var accessType string
+	repo := &MockRepository{
+		RestoreFunc: func(ctx context.Context, tenantID, credentialID string, since time.Time) (*Credential, error) {
+			deletedSince = since
+			return &Credential{ID: credentialID}, nil
+		},
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			accessType = log.AccessType
+			return nil
+		},
+	}
+	service := NewServiceImpl(repo, &MockEncryptionService{}, nil, WithDeletedRetention(48*time.Hour))
+
+	restored, err := service.Restore(context.Background(), "tenant-123", "cred-123", "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "cred-123", restored.ID)
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), deletedSince, time.Minute)
+	assert.Equal(t, AccessTypeRestore, accessType)
+}
+
+func TestServiceImpl_Restore_NotFound(t *testing.T) {
+	repo := &MockRepository{
+		RestoreFunc: func(ctx context.Context, tenantID, credentialID string, since time.Time) (*Credential, error) {
+			return nil, ErrNotFound
+		},
+	}
+	service := NewServiceImpl(repo, &MockEncryptionService{}, nil)
+
+	_, err := service.Restore(context.Background(), "tenant-123", "cred-123", "user-123")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestServiceImpl_BulkDelete(t *testing.T) {
+	t.Run("soft-deletes each credential and reports failures", func(t *testing.T) {
+		repo := &MockRepository{
+			SoftDeleteFunc: func(ctx context.Context, tenantID, credentialID, deletedBy string) error {
+				if credentialID == "missing" {
+					return ErrNotFound
+				}
+				return nil
+			},
+		}
+		service := NewServiceImpl(repo, &MockEncryptionService{}, nil)
+
+		deleted, failed := service.BulkDelete(context.Background(), "tenant-123", "user-123", []string{"cred-1", "missing", "cred-2"}, false)
+
+		assert.Equal(t, []string{"cred-1", "cred-2"}, deleted)
+		require.Len(t, failed, 1)
+		assert.Equal(t, "missing", failed[0].ID)
+		assert.Contains(t, failed[0].Error, "not found")
+	})
+
+	t.Run("permanent purges", func(t *testing.T) {
+		var purged []string
+		repo := &MockRepository{
+			DeleteFunc: func(ctx context.Context, tenantID, credentialID string) error {
+				purged = append(purged, credentialID)
+				return nil
+			},
+			SoftDeleteFunc: func(ctx context.Context, tenantID, credentialID, deletedBy string) error {
+				t.Fatal("permanent bulk delete should not soft-delete")
+				return nil
+			},
+		}
+		service := NewServiceImpl(repo, &MockEncryptionService{}, nil)
+
+		deleted, failed := service.BulkDelete(context.Background(), "tenant-123", "user-123", []string{"cred-1", "cred-2"}, true)
+
+		assert.Equal(t, []string{"cred-1", "cred-2"}, deleted)
+		assert.Empty(t, failed)
+		assert.Equal(t, []string{"cred-1", "cred-2"}, purged)
+	})
+
+	t.Run("rejects more than the limit", func(t *testing.T) {
+		repo := &MockRepository{
+			SoftDeleteFunc: func(ctx context.Context, tenantID, credentialID, deletedBy string) error {
+				t.Fatal("oversized bulk delete should not delete anything")
+				return nil
+			},
+		}
+		service := NewServiceImpl(repo, &MockEncryptionService{}, nil)
+
+		ids := make([]string, MaxBulkDelete+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("cred-%d", i)
+		}
+
+		deleted, failed := service.BulkDelete(context.Background(), "tenant-123", "user-123", ids, false)
+
+		assert.Empty(t, deleted)
+		assert.Len(t, failed, len(ids))
+	})
+}
+
 // TestNewServiceImpl tests service constructor
 func TestNewServiceImpl(t *testing.T) {
 	mockRepo := &MockRepository{}
@@ -258,6 +531,9 @@ type MockRepository struct {
 	GetByIDFunc          func(ctx context.Context, tenantID, credentialID string) (*Credential, error)
 	UpdateLastUsedAtFunc func(ctx context.Context, tenantID, credentialID string) error
 	LogAccessFunc        func(ctx context.Context, log *AccessLog) error
+	DeleteFunc           func(ctx context.Context, tenantID, credentialID string) error
+	SoftDeleteFunc       func(ctx context.Context, tenantID, credentialID, deletedBy string) error
+	RestoreFunc          func(ctx context.Context, tenantID, credentialID string, deletedSince time.Time) (*Credential, error)
 }
 
 func (m *MockRepository) Create(ctx context.Context, tenantID, createdBy string, cred *Credential) (*Credential, error) {
@@ -291,9 +567,26 @@ func (m *MockRepository) UpdateLastUsedAt(ctx context.Context, tenantID, credent
 }
 
 func (m *MockRepository) Delete(ctx context.Context, tenantID, credentialID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, credentialID)
+	}
 	return nil
 }
 
+func (m *MockRepository) SoftDelete(ctx context.Context, tenantID, credentialID, deletedBy string) error {
+	if m.SoftDeleteFunc != nil {
+		return m.SoftDeleteFunc(ctx, tenantID, credentialID, deletedBy)
+	}
+	return nil
+}
+
+func (m *MockRepository) Restore(ctx context.Context, tenantID, credentialID string, deletedSince time.Time) (*Credential, error) {
+	if m.RestoreFunc != nil {
+		return m.RestoreFunc(ctx, tenantID, credentialID, deletedSince)
+	}
+	return nil, nil
+}
+
 func (m *MockRepository) LogAccess(ctx context.Context, log *AccessLog) error {
 	if m.LogAccessFunc != nil {
 		return m.LogAccessFunc(ctx, log)
@@ -0,0 +1,133 @@
+package credential
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// WrappedKeySource identifies where an encrypted data key is stored
+type WrappedKeySource string
+
+const (
+	WrappedKeyCredential        WrappedKeySource = "credential"
+	WrappedKeyCredentialVersion WrappedKeySource = "credential_version"
+	WrappedKeyOAuthAccessToken  WrappedKeySource = "oauth_access_token"
+	WrappedKeyOAuthRefreshToken WrappedKeySource = "oauth_refresh_token"
+)
+
+// defaultRotationBatchSize is how many data keys are re-wrapped per batch
+const defaultRotationBatchSize = 100
+
+// WrappedKey is an encrypted data key and the KMS key that wraps it
+type WrappedKey struct {
+	Source       WrappedKeySource `db:"source"`
+	ID           string           `db:"id"`
+	EncryptedDEK []byte           `db:"encrypted_dek"`
+	KMSKeyID     string           `db:"kms_key_id"`
+}
+
+// KeyRotationRepository stores tenant KMS keys and the data keys they wrap
+type KeyRotationRepository interface {
+	SetTenantKMSKeyID(ctx context.Context, tenantID, keyID string) error
+	ListWrappedKeys(ctx context.Context, tenantID, keyID string, limit int) ([]*WrappedKey, error)
+	UpdateWrappedKey(ctx context.Context, tenantID string, key *WrappedKey, encryptedDEK []byte, keyID string) error
+}
+
+// KeyRotationResult summarizes a tenant key rotation
+type KeyRotationResult struct {
+	TenantID  string `json:"tenant_id"`
+	KMSKeyID  string `json:"kms_key_id"`
+	Rewrapped int    `json:"rewrapped"`
+	Failed    int    `json:"failed"`
+}
+
+// TenantKeyRotator moves a tenant onto its own KMS key
+type TenantKeyRotator struct {
+	service   *KMSEncryptionService
+	repo      KeyRotationRepository
+	logger    *slog.Logger
+	batchSize int
+}
+
+// NewTenantKeyRotator creates a rotator re-wrapping data keys with service
+func NewTenantKeyRotator(service *KMSEncryptionService, repo KeyRotationRepository, logger *slog.Logger) *TenantKeyRotator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &TenantKeyRotator{
+		service:   service,
+		repo:      repo,
+		logger:    logger,
+		batchSize: defaultRotationBatchSize,
+	}
+}
+
+// RotateTenantKey assigns newKeyID to a tenant and re-wraps the data keys of
+// the tenant's existing credentials and OAuth tokens under it. Payloads are
+// not re-encrypted. The key is verified before it is assigned, and once
+// assigned new secrets use it, so a rotation that fails part way can be
+// retried. Data keys that fail to re-wrap still decrypt with their old key
+// and are counted in the result.
+func (r *TenantKeyRotator) RotateTenantKey(ctx context.Context, tenantID, newKeyID string) (*KeyRotationResult, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if newKeyID == "" {
+		return nil, ErrInvalidKeyID
+	}
+
+	if err := r.service.checkKey(ctx, newKeyID); err != nil {
+		return nil, fmt.Errorf("KMS key %s is not usable: %w", newKeyID, err)
+	}
+	if err := r.repo.SetTenantKMSKeyID(ctx, tenantID, newKeyID); err != nil {
+		return nil, err
+	}
+
+	result := &KeyRotationResult{TenantID: tenantID, KMSKeyID: newKeyID}
+	attempted := make(map[string]bool)
+	for {
+		keys, err := r.repo.ListWrappedKeys(ctx, tenantID, newKeyID, r.batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		// Keys that failed stay listed; stop once a batch has nothing new
+		progressed := false
+		for _, key := range keys {
+			ref := string(key.Source) + ":" + key.ID
+			if attempted[ref] {
+				continue
+			}
+			attempted[ref] = true
+			progressed = true
+
+			if err := r.rewrap(ctx, tenantID, key, newKeyID); err != nil {
+				result.Failed++
+				r.logger.Error("failed to re-wrap data key",
+					"tenant_id", tenantID, "source", key.Source, "id", key.ID, "error", err)
+				continue
+			}
+			result.Rewrapped++
+		}
+
+		if !progressed {
+			break
+		}
+	}
+
+	r.logger.Info("tenant KMS key rotated",
+		"tenant_id", tenantID, "kms_key_id", newKeyID,
+		"rewrapped", result.Rewrapped, "failed", result.Failed)
+
+	return result, nil
+}
+
+// rewrap re-wraps a single data key under newKeyID and stores it
+func (r *TenantKeyRotator) rewrap(ctx context.Context, tenantID string, key *WrappedKey, newKeyID string) error {
+	encryptedDEK, err := r.service.RewrapDataKey(ctx, key.EncryptedDEK, newKeyID)
+	if err != nil {
+		return err
+	}
+	return r.repo.UpdateWrappedKey(ctx, tenantID, key, encryptedDEK, newKeyID)
+}
@@ -0,0 +1,195 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS wraps data keys by prefixing them with the key ID, so the wrapping
+// key can be recovered from the blob the way KMS does
+type fakeKMS struct {
+	disabledKeys map[string]bool
+}
+
+func (f *fakeKMS) wrap(keyID string, dek []byte) []byte {
+	return append([]byte(keyID+"|"), dek...)
+}
+
+func (f *fakeKMS) unwrap(blob []byte) (string, []byte, error) {
+	i := bytes.IndexByte(blob, '|')
+	if i < 0 {
+		return "", nil, errors.New("invalid ciphertext blob")
+	}
+	keyID := string(blob[:i])
+	if f.disabledKeys[keyID] {
+		return "", nil, errors.New("key is disabled")
+	}
+	return keyID, blob[i+1:], nil
+}
+
+func (f *fakeKMS) GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+	keyID := aws.ToString(params.KeyId)
+	if f.disabledKeys[keyID] {
+		return nil, errors.New("key is disabled")
+	}
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, err
+	}
+	return &kms.GenerateDataKeyOutput{
+		Plaintext:      dek,
+		CiphertextBlob: f.wrap(keyID, dek),
+		KeyId:          params.KeyId,
+	}, nil
+}
+
+func (f *fakeKMS) Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	keyID, dek, err := f.unwrap(params.CiphertextBlob)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.DecryptOutput{Plaintext: dek, KeyId: aws.String(keyID)}, nil
+}
+
+func (f *fakeKMS) ReEncrypt(ctx context.Context, params *kms.ReEncryptInput, optFns ...func(*kms.Options)) (*kms.ReEncryptOutput, error) {
+	_, dek, err := f.unwrap(params.CiphertextBlob)
+	if err != nil {
+		return nil, err
+	}
+	keyID := aws.ToString(params.DestinationKeyId)
+	return &kms.ReEncryptOutput{CiphertextBlob: f.wrap(keyID, dek), KeyId: aws.String(keyID)}, nil
+}
+
+// fakeTenantKeys stores tenant key mappings and wrapped keys in memory
+type fakeTenantKeys struct {
+	tenantKeys map[string]string
+	keys       []*WrappedKey
+	resolveErr error
+}
+
+func (f *fakeTenantKeys) GetTenantKMSKeyID(ctx context.Context, tenantID string) (string, error) {
+	return f.tenantKeys[tenantID], f.resolveErr
+}
+
+func (f *fakeTenantKeys) SetTenantKMSKeyID(ctx context.Context, tenantID, keyID string) error {
+	f.tenantKeys[tenantID] = keyID
+	return nil
+}
+
+func (f *fakeTenantKeys) ListWrappedKeys(ctx context.Context, tenantID, keyID string, limit int) ([]*WrappedKey, error) {
+	var keys []*WrappedKey
+	for _, key := range f.keys {
+		if key.KMSKeyID != keyID && len(keys) < limit {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+	return keys, nil
+}
+
+func (f *fakeTenantKeys) UpdateWrappedKey(ctx context.Context, tenantID string, key *WrappedKey, encryptedDEK []byte, keyID string) error {
+	for _, stored := range f.keys {
+		if stored.Source == key.Source && stored.ID == key.ID && stored.KMSKeyID == key.KMSKeyID {
+			stored.EncryptedDEK = encryptedDEK
+			stored.KMSKeyID = keyID
+		}
+	}
+	return nil
+}
+
+func newTenantKeyTestService(t *testing.T) (*KMSEncryptionService, *fakeKMS, *fakeTenantKeys) {
+	t.Helper()
+	client := &fakeKMS{disabledKeys: make(map[string]bool)}
+	svc, err := NewKMSEncryptionService(client, "alias/default")
+	require.NoError(t, err)
+	repo := &fakeTenantKeys{tenantKeys: make(map[string]string)}
+	svc.SetTenantKeyResolver(repo)
+	return svc, client, repo
+}
+
+func TestKMSEncryptionService_Encrypt_UsesTenantKey(t *testing.T) {
+	ctx := context.Background()
+	svc, _, repo := newTenantKeyTestService(t)
+	repo.tenantKeys["tenant-a"] = "alias/tenant-a"
+	data := &CredentialData{Value: map[string]interface{}{"api_key": "secret"}}
+
+	encrypted, err := svc.Encrypt(ctx, "tenant-a", data)
+	require.NoError(t, err)
+	assert.Equal(t, "alias/tenant-a", encrypted.KMSKeyID)
+
+	encrypted, err = svc.Encrypt(ctx, "tenant-b", data)
+	require.NoError(t, err)
+	assert.Equal(t, "alias/default", encrypted.KMSKeyID)
+
+	repo.resolveErr = errors.New("database unavailable")
+	_, err = svc.Encrypt(ctx, "tenant-a", data)
+	require.Error(t, err)
+	var encErr *EncryptionError
+	assert.ErrorAs(t, err, &encErr)
+}
+
+func TestTenantKeyRotator_RotateTenantKey(t *testing.T) {
+	ctx := context.Background()
+	svc, client, repo := newTenantKeyTestService(t)
+	data := &CredentialData{Value: map[string]interface{}{"api_key": "secret"}}
+
+	encrypted := make([]*EncryptedSecret, 0, 3)
+	for i, source := range []WrappedKeySource{WrappedKeyCredential, WrappedKeyCredentialVersion, WrappedKeyOAuthAccessToken} {
+		secret, err := svc.Encrypt(ctx, "tenant-a", data)
+		require.NoError(t, err)
+		encrypted = append(encrypted, secret)
+		repo.keys = append(repo.keys, &WrappedKey{
+			Source:       source,
+			ID:           string(rune('a' + i)),
+			EncryptedDEK: secret.EncryptedDEK,
+			KMSKeyID:     secret.KMSKeyID,
+		})
+	}
+
+	rotator := NewTenantKeyRotator(svc, repo, nil)
+	rotator.batchSize = 2
+	result, err := rotator.RotateTenantKey(ctx, "tenant-a", "alias/tenant-a")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Rewrapped)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, "alias/tenant-a", repo.tenantKeys["tenant-a"])
+
+	// The old key can be retired: payloads decrypt with the re-wrapped keys
+	client.disabledKeys["alias/default"] = true
+	for i, key := range repo.keys {
+		assert.Equal(t, "alias/tenant-a", key.KMSKeyID)
+		payload := append(append(append([]byte{}, encrypted[i].Nonce...), encrypted[i].Ciphertext...), encrypted[i].AuthTag...)
+		decrypted, err := svc.Decrypt(ctx, payload, key.EncryptedDEK)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", decrypted.Value["api_key"])
+	}
+}
+
+func TestTenantKeyRotator_RotateTenantKey_UnusableKey(t *testing.T) {
+	ctx := context.Background()
+	svc, client, repo := newTenantKeyTestService(t)
+	client.disabledKeys["alias/tenant-a"] = true
+
+	rotator := NewTenantKeyRotator(svc, repo, nil)
+	_, err := rotator.RotateTenantKey(ctx, "tenant-a", "alias/tenant-a")
+
+	require.Error(t, err)
+	assert.Empty(t, repo.tenantKeys, "an unusable key must not be assigned")
+}
+
+func TestKMSEncryptionService_RewrapDataKey_Unsupported(t *testing.T) {
+	svc, err := NewKMSEncryptionService(&MockKMSClientForEncryption{}, "alias/default")
+	require.NoError(t, err)
+
+	_, err = svc.RewrapDataKey(context.Background(), []byte("blob"), "alias/tenant-a")
+	assert.ErrorIs(t, err, ErrKMSOperationFailed)
+}
@@ -0,0 +1,226 @@
+package credential
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorax/gorax/internal/security"
+)
+
+// Metadata keys configuring how a credential is tested
+const (
+	// MetadataTestURL is the endpoint probed with the credential applied
+	MetadataTestURL = "test_url"
+	// MetadataTestMethod is the probe's HTTP method (default: GET)
+	MetadataTestMethod = "test_method"
+	// MetadataTestHeader is the header carrying an api_key credential
+	// (default: Authorization)
+	MetadataTestHeader = "test_header"
+	// MetadataOAuthConnectionID is the OAuth connection backing an oauth2
+	// credential
+	MetadataOAuthConnectionID = "oauth_connection_id"
+)
+
+// defaultTestTimeout bounds each credential test probe
+const defaultTestTimeout = 10 * time.Second
+
+// ConnectionTester tests an OAuth connection belonging to a tenant
+type ConnectionTester interface {
+	TestConnection(ctx context.Context, tenantID, connectionID string) error
+}
+
+// WithConnectionTester lets oauth2 credentials be tested through the OAuth
+// connection named by their oauth_connection_id metadata
+func WithConnectionTester(tester ConnectionTester) ServiceOption {
+	return func(s *ServiceImpl) {
+		s.connections = tester
+	}
+}
+
+// CredentialTestResult reports whether a credential worked against its test
+// endpoint. It never includes the credential value or the response body.
+type CredentialTestResult struct {
+	Success    bool      `json:"success"`
+	Message    string    `json:"message"`
+	StatusCode int       `json:"status_code,omitempty"`
+	LatencyMS  int64     `json:"latency_ms"`
+	TestedAt   time.Time `json:"tested_at"`
+}
+
+// TestCredential verifies a credential works by making a probe request with
+// it applied. api_key, basic_auth and bearer_token credentials are sent to the
+// test_url in their metadata; oauth2 credentials test the OAuth connection
+// named by oauth_connection_id. A credential that is rejected is a failed
+// result, not an error; errors mean the test could not be run. Every test is
+// recorded in the access log.
+func (s *ServiceImpl) TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*CredentialTestResult, error) {
+	cred, err := s.repo.GetByID(ctx, tenantID, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result := &CredentialTestResult{TestedAt: start.UTC()}
+	statusCode, err := s.probe(ctx, tenantID, credentialID, cred)
+	result.LatencyMS = time.Since(start).Milliseconds()
+	result.StatusCode = statusCode
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		s.logTest(ctx, tenantID, credentialID, userID, err)
+		return nil, err
+	}
+	if err != nil {
+		result.Message = err.Error()
+	} else {
+		result.Success = true
+		result.Message = "credential test succeeded"
+	}
+
+	s.logTest(ctx, tenantID, credentialID, userID, err)
+	return result, nil
+}
+
+// logTest records a credential test in the access log
+func (s *ServiceImpl) logTest(ctx context.Context, tenantID, credentialID, userID string, testErr error) {
+	accessLog := &AccessLog{
+		CredentialID: credentialID,
+		TenantID:     tenantID,
+		AccessedBy:   userID,
+		AccessType:   AccessTypeTest,
+		AccessedAt:   time.Now().UTC(),
+		Success:      testErr == nil,
+	}
+	if testErr != nil {
+		accessLog.ErrorMessage = testErr.Error()
+	}
+	// Note: Error is intentionally ignored as this is a non-critical operation
+	_ = s.repo.LogAccess(ctx, accessLog)
+}
+
+// probe runs the test for the credential's type, returning the probe's HTTP
+// status when one was received
+func (s *ServiceImpl) probe(ctx context.Context, tenantID, credentialID string, cred *Credential) (int, error) {
+	switch cred.Type {
+	case TypeOAuth2:
+		return 0, s.testOAuthConnection(ctx, tenantID, cred)
+	case TypeAPIKey, TypeBasicAuth, TypeBearerToken:
+	default:
+		return 0, &ValidationError{Message: fmt.Sprintf("testing %s credentials is not supported", cred.Type)}
+	}
+
+	testURL, _ := cred.Metadata[MetadataTestURL].(string)
+	if testURL == "" {
+		return 0, &ValidationError{Message: fmt.Sprintf("credential metadata has no %s to test against", MetadataTestURL)}
+	}
+	if s.urlValidator != nil {
+		if err := s.urlValidator.ValidateURL(testURL); err != nil {
+			return 0, &ValidationError{Message: fmt.Sprintf("%s is not allowed: %v", MetadataTestURL, err)}
+		}
+	}
+	method, _ := cred.Metadata[MetadataTestMethod].(string)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	value, err := s.getValue(ctx, tenantID, credentialID)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), testURL, nil)
+	if err != nil {
+		return 0, &ValidationError{Message: fmt.Sprintf("invalid %s: %v", MetadataTestURL, err)}
+	}
+	applyTestAuth(req, cred, value.Value)
+
+	resp, err := s.testClient.Do(req)
+	if err != nil {
+		// Transport errors can quote the request, so strip the secret from them
+		return 0, errors.New(redactValues(fmt.Sprintf("test request failed: %v", err), value.Value))
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("test endpoint returned %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return resp.StatusCode, nil
+}
+
+// testOAuthConnection tests the OAuth connection backing an oauth2 credential
+func (s *ServiceImpl) testOAuthConnection(ctx context.Context, tenantID string, cred *Credential) error {
+	connectionID, _ := cred.Metadata[MetadataOAuthConnectionID].(string)
+	if connectionID == "" {
+		return &ValidationError{Message: fmt.Sprintf("credential metadata has no %s to test", MetadataOAuthConnectionID)}
+	}
+	if s.connections == nil {
+		return &ValidationError{Message: "oauth2 credential testing is not available"}
+	}
+	return s.connections.TestConnection(ctx, tenantID, connectionID)
+}
+
+// applyTestAuth sets the credential on a probe request the way its type is used
+func applyTestAuth(req *http.Request, cred *Credential, value map[string]interface{}) {
+	switch cred.Type {
+	case TypeAPIKey:
+		key, _ := value["key"].(string)
+		if prefix, _ := value["prefix"].(string); prefix != "" {
+			key = prefix + " " + key
+		}
+		header, _ := cred.Metadata[MetadataTestHeader].(string)
+		if header == "" {
+			header = "Authorization"
+		}
+		req.Header.Set(header, key)
+	case TypeBasicAuth:
+		username, _ := value["username"].(string)
+		password, _ := value["password"].(string)
+		req.SetBasicAuth(username, password)
+	case TypeBearerToken:
+		token, _ := value["token"].(string)
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// redactValues replaces every string in value, and the basic auth encoding of
+// username and password, with [REDACTED]
+func redactValues(message string, value map[string]interface{}) string {
+	secrets := make([]string, 0, len(value)+1)
+	for _, v := range value {
+		if str, ok := v.(string); ok && str != "" {
+			secrets = append(secrets, str)
+		}
+	}
+	if username, ok := value["username"].(string); ok {
+		password, _ := value["password"].(string)
+		secrets = append(secrets, base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	for _, secret := range secrets {
+		message = strings.ReplaceAll(message, secret, "[REDACTED]")
+	}
+	return message
+}
+
+// newTestClient returns the client used for credential test probes. Redirects
+// are not followed so the credential is only sent to the configured endpoint,
+// and connections go through the validator's transport so a test URL cannot
+// resolve to a blocked address after it has been checked.
+func newTestClient(validator *security.URLValidator) *http.Client {
+	client := &http.Client{
+		Timeout: defaultTestTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	if validator != nil {
+		client.Transport = validator.Transport()
+	}
+	return client
+}
@@ -0,0 +1,275 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/security"
+)
+
+// fakeConnectionTester records the connection it was asked to test
+type fakeConnectionTester struct {
+	tenantID     string
+	connectionID string
+	err          error
+}
+
+func (f *fakeConnectionTester) TestConnection(ctx context.Context, tenantID, connectionID string) error {
+	f.tenantID = tenantID
+	f.connectionID = connectionID
+	return f.err
+}
+
+// newTestCredentialService returns a service serving cred with value, allowing
+// loopback test URLs, and the access log entries it writes
+func newTestCredentialService(cred *Credential, value map[string]interface{}, opts ...ServiceOption) (*ServiceImpl, *[]*AccessLog) {
+	var logged []*AccessLog
+	repo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, tenantID, credentialID string) (*Credential, error) {
+			if credentialID != cred.ID {
+				return nil, ErrNotFound
+			}
+			return cred, nil
+		},
+		UpdateLastUsedAtFunc: func(ctx context.Context, tenantID, credentialID string) error {
+			return nil
+		},
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			logged = append(logged, log)
+			return nil
+		},
+	}
+	encryption := &MockEncryptionService{
+		DecryptFunc: func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+			return &CredentialData{Value: value}, nil
+		},
+	}
+
+	service := NewServiceImpl(repo, encryption, nil, opts...).(*ServiceImpl)
+	service.urlValidator = nil
+	service.testClient = newTestClient(nil)
+	return service, &logged
+}
+
+func encryptedCredential(credType CredentialType, metadata JSONMap) *Credential {
+	return &Credential{
+		ID:           "cred-123",
+		TenantID:     "tenant-123",
+		Type:         credType,
+		EncryptedDEK: []byte("key"),
+		Ciphertext:   []byte("data"),
+		Metadata:     metadata,
+	}
+}
+
+func TestServiceImpl_TestCredential_HTTP(t *testing.T) {
+	var authorization, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		apiKey = r.Header.Get("X-Api-Key")
+		if r.URL.Path == "/denied" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("bad key sk-live-123"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		credType      CredentialType
+		metadata      JSONMap
+		value         map[string]interface{}
+		wantSuccess   bool
+		wantStatus    int
+		wantAuth      string
+		wantAPIKey    string
+		wantMessage   string
+		wantLogResult bool
+	}{
+		{
+			name:          "api key with prefix",
+			credType:      TypeAPIKey,
+			metadata:      JSONMap{MetadataTestURL: server.URL + "/ok"},
+			value:         map[string]interface{}{"key": "sk-live-123", "prefix": "Api-Key"},
+			wantSuccess:   true,
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Api-Key sk-live-123",
+			wantMessage:   "credential test succeeded",
+			wantLogResult: true,
+		},
+		{
+			name:          "api key in custom header",
+			credType:      TypeAPIKey,
+			metadata:      JSONMap{MetadataTestURL: server.URL + "/ok", MetadataTestHeader: "X-Api-Key"},
+			value:         map[string]interface{}{"key": "sk-live-123"},
+			wantSuccess:   true,
+			wantStatus:    http.StatusOK,
+			wantAPIKey:    "sk-live-123",
+			wantMessage:   "credential test succeeded",
+			wantLogResult: true,
+		},
+		{
+			name:        "rejected basic auth",
+			credType:    TypeBasicAuth,
+			metadata:    JSONMap{MetadataTestURL: server.URL + "/denied"},
+			value:       map[string]interface{}{"username": "admin", "password": "hunter2"},
+			wantStatus:  http.StatusUnauthorized,
+			wantAuth:    "Basic YWRtaW46aHVudGVyMg==",
+			wantMessage: "test endpoint returned 401 Unauthorized",
+		},
+		{
+			name:          "bearer token",
+			credType:      TypeBearerToken,
+			metadata:      JSONMap{MetadataTestURL: server.URL + "/ok"},
+			value:         map[string]interface{}{"token": "tok-123"},
+			wantSuccess:   true,
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Bearer tok-123",
+			wantMessage:   "credential test succeeded",
+			wantLogResult: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization, apiKey = "", ""
+			service, logged := newTestCredentialService(encryptedCredential(tt.credType, tt.metadata), tt.value)
+
+			result, err := service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantSuccess, result.Success)
+			assert.Equal(t, tt.wantStatus, result.StatusCode)
+			assert.Equal(t, tt.wantMessage, result.Message)
+			assert.NotContains(t, result.Message, "sk-live-123")
+			assert.False(t, result.TestedAt.IsZero())
+			assert.Equal(t, tt.wantAuth, authorization)
+			assert.Equal(t, tt.wantAPIKey, apiKey)
+
+			require.Len(t, *logged, 1)
+			testLog := (*logged)[0]
+			assert.Equal(t, AccessTypeTest, testLog.AccessType)
+			assert.Equal(t, "user-123", testLog.AccessedBy)
+			assert.Equal(t, tt.wantLogResult, testLog.Success)
+		})
+	}
+}
+
+func TestServiceImpl_TestCredential_TransportErrorRedactsSecret(t *testing.T) {
+	cred := encryptedCredential(TypeAPIKey, JSONMap{MetadataTestURL: "http://127.0.0.1:1/?key=sk-live-123"})
+	service, _ := newTestCredentialService(cred, map[string]interface{}{"key": "sk-live-123"})
+
+	result, err := service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+	require.NoError(t, err)
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Message, "test request failed")
+	assert.NotContains(t, result.Message, "sk-live-123")
+}
+
+func TestServiceImpl_TestCredential_OAuth2(t *testing.T) {
+	tester := &fakeConnectionTester{}
+	cred := encryptedCredential(TypeOAuth2, JSONMap{MetadataOAuthConnectionID: "conn-1"})
+	service, logged := newTestCredentialService(cred, nil, WithConnectionTester(tester))
+
+	result, err := service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "tenant-123", tester.tenantID)
+	assert.Equal(t, "conn-1", tester.connectionID)
+	require.Len(t, *logged, 1)
+
+	tester.err = errors.New("token revoked")
+	result, err = service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, "token revoked", result.Message)
+}
+
+func TestServiceImpl_TestCredential_CannotRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		cred     *Credential
+		opts     []ServiceOption
+		contains string
+	}{
+		{
+			name:     "no test url",
+			cred:     encryptedCredential(TypeAPIKey, nil),
+			contains: "no test_url",
+		},
+		{
+			name:     "unsupported type",
+			cred:     encryptedCredential(TypeDatabasePostgreSQL, JSONMap{MetadataTestURL: "https://example.com"}),
+			contains: "not supported",
+		},
+		{
+			name:     "oauth2 without connection",
+			cred:     encryptedCredential(TypeOAuth2, nil),
+			opts:     []ServiceOption{WithConnectionTester(&fakeConnectionTester{})},
+			contains: "no oauth_connection_id",
+		},
+		{
+			name:     "oauth2 without tester",
+			cred:     encryptedCredential(TypeOAuth2, JSONMap{MetadataOAuthConnectionID: "conn-1"}),
+			contains: "not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, logged := newTestCredentialService(tt.cred, nil, tt.opts...)
+
+			_, err := service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.contains)
+			require.Len(t, *logged, 1)
+			assert.False(t, (*logged)[0].Success)
+		})
+	}
+}
+
+func TestServiceImpl_TestCredential_BlocksPrivateURLs(t *testing.T) {
+	cred := encryptedCredential(TypeAPIKey, JSONMap{MetadataTestURL: "http://169.254.169.254/latest/meta-data"})
+	service, _ := newTestCredentialService(cred, map[string]interface{}{"key": "sk-live-123"})
+	service.urlValidator = NewServiceImpl(&MockRepository{}, &MockEncryptionService{}, nil).(*ServiceImpl).urlValidator
+
+	_, err := service.TestCredential(context.Background(), "tenant-123", "cred-123", "user-123")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "not allowed")
+}
+
+func TestNewTestClient_BlocksPrivateAddressesWhenDialing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach a blocked address")
+	}))
+	defer server.Close()
+
+	// The URL check has already passed; the transport must still refuse to
+	// connect to a loopback address
+	client := newTestClient(security.NewURLValidator())
+	_, err := client.Get(server.URL)
+
+	assert.ErrorIs(t, err, security.ErrBlockedDestination)
+}
+
+func TestServiceImpl_TestCredential_NotFound(t *testing.T) {
+	service, logged := newTestCredentialService(encryptedCredential(TypeAPIKey, nil), nil)
+
+	_, err := service.TestCredential(context.Background(), "tenant-123", "cred-missing", "user-123")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, *logged)
+}
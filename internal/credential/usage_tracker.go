@@ -0,0 +1,140 @@
+package credential
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultUsageInterval is how stale LastUsedAt may get before a read records a new value
+const DefaultUsageInterval = 5 * time.Minute
+
+// LastUsedUpdater writes a credential's last_used_at timestamp
+type LastUsedUpdater interface {
+	UpdateLastUsedAt(ctx context.Context, tenantID, id string) error
+}
+
+// usageKey identifies a credential within a tenant
+type usageKey struct {
+	tenantID     string
+	credentialID string
+}
+
+// UsageTracker throttles and batches LastUsedAt writes. A read only marks a
+// credential as used when its recorded LastUsedAt is older than the interval,
+// and marked credentials are written together when the tracker is flushed,
+// so hot credentials cost at most one write per interval.
+type UsageTracker struct {
+	repo     LastUsedUpdater
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	pending map[usageKey]struct{}
+	written map[usageKey]time.Time
+}
+
+// NewUsageTracker creates a usage tracker; a non-positive interval uses DefaultUsageInterval
+func NewUsageTracker(repo LastUsedUpdater, interval time.Duration, logger *slog.Logger) *UsageTracker {
+	if interval <= 0 {
+		interval = DefaultUsageInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &UsageTracker{
+		repo:     repo,
+		interval: interval,
+		logger:   logger,
+		pending:  make(map[usageKey]struct{}),
+		written:  make(map[usageKey]time.Time),
+	}
+}
+
+// Touch records a read of a credential whose stored LastUsedAt is lastUsedAt.
+// It is a no-op while the stored or last written value is within the interval.
+func (t *UsageTracker) Touch(tenantID, credentialID string, lastUsedAt *time.Time) {
+	now := time.Now()
+	if lastUsedAt != nil && now.Sub(*lastUsedAt) < t.interval {
+		return
+	}
+
+	key := usageKey{tenantID: tenantID, credentialID: credentialID}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if writtenAt, ok := t.written[key]; ok && now.Sub(writtenAt) < t.interval {
+		return
+	}
+	t.pending[key] = struct{}{}
+}
+
+// Pending returns the number of credentials waiting to be written
+func (t *UsageTracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.pending)
+}
+
+// Flush writes LastUsedAt for every pending credential and returns how many
+// were written. Failed writes are logged and retried on the next flush.
+func (t *UsageTracker) Flush(ctx context.Context) int {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[usageKey]struct{})
+	t.mu.Unlock()
+
+	written := 0
+	var failed []usageKey
+	for key := range pending {
+		if err := t.repo.UpdateLastUsedAt(ctx, key.tenantID, key.credentialID); err != nil {
+			t.logger.Warn("failed to update credential last used time",
+				"error", err,
+				"tenant_id", key.tenantID,
+				"credential_id", key.credentialID)
+			failed = append(failed, key)
+			continue
+		}
+		written++
+	}
+
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key := range pending {
+		t.written[key] = now
+	}
+	for _, key := range failed {
+		delete(t.written, key)
+		t.pending[key] = struct{}{}
+	}
+
+	// Forget writes old enough that the next read records usage anyway
+	for key, writtenAt := range t.written {
+		if now.Sub(writtenAt) >= t.interval {
+			delete(t.written, key)
+		}
+	}
+
+	return written
+}
+
+// Start flushes pending usage every flushInterval until ctx is cancelled.
+// Callers should Flush once more on shutdown so recorded reads are not lost.
+func (t *UsageTracker) Start(ctx context.Context, flushInterval time.Duration) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Flush(ctx)
+		}
+	}
+}
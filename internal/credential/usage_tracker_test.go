@@ -0,0 +1,123 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingUpdater records UpdateLastUsedAt calls
+type recordingUpdater struct {
+	mu      sync.Mutex
+	updates []string
+	err     error
+}
+
+func (u *recordingUpdater) UpdateLastUsedAt(ctx context.Context, tenantID, id string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.err != nil {
+		return u.err
+	}
+	u.updates = append(u.updates, tenantID+"/"+id)
+	return nil
+}
+
+func TestUsageTracker_CoalescesReads(t *testing.T) {
+	updater := &recordingUpdater{}
+	tracker := NewUsageTracker(updater, time.Minute, nil)
+
+	for i := 0; i < 100; i++ {
+		tracker.Touch("tenant-1", "cred-1", nil)
+	}
+	tracker.Touch("tenant-1", "cred-2", nil)
+
+	assert.Equal(t, 2, tracker.Pending())
+	assert.Equal(t, 2, tracker.Flush(context.Background()))
+	assert.ElementsMatch(t, []string{"tenant-1/cred-1", "tenant-1/cred-2"}, updater.updates)
+	assert.Equal(t, 0, tracker.Pending())
+}
+
+func TestUsageTracker_SkipsRecentlyUsed(t *testing.T) {
+	updater := &recordingUpdater{}
+	tracker := NewUsageTracker(updater, 5*time.Minute, nil)
+
+	recent := time.Now().Add(-time.Minute)
+	tracker.Touch("tenant-1", "cred-1", &recent)
+	assert.Equal(t, 0, tracker.Pending())
+
+	stale := time.Now().Add(-10 * time.Minute)
+	tracker.Touch("tenant-1", "cred-1", &stale)
+	assert.Equal(t, 1, tracker.Pending())
+}
+
+func TestUsageTracker_ThrottlesAfterWrite(t *testing.T) {
+	updater := &recordingUpdater{}
+	tracker := NewUsageTracker(updater, 5*time.Minute, nil)
+
+	tracker.Touch("tenant-1", "cred-1", nil)
+	tracker.Flush(context.Background())
+
+	// The stored value is still stale until the credential is re-read from the
+	// database, but the tracker remembers its own write
+	tracker.Touch("tenant-1", "cred-1", nil)
+	assert.Equal(t, 0, tracker.Pending())
+	assert.Equal(t, 0, tracker.Flush(context.Background()))
+	assert.Len(t, updater.updates, 1)
+}
+
+func TestUsageTracker_RetriesFailedWrites(t *testing.T) {
+	updater := &recordingUpdater{err: errors.New("database unavailable")}
+	tracker := NewUsageTracker(updater, 5*time.Minute, nil)
+
+	tracker.Touch("tenant-1", "cred-1", nil)
+	assert.Equal(t, 0, tracker.Flush(context.Background()))
+	assert.Equal(t, 1, tracker.Pending())
+
+	updater.err = nil
+	assert.Equal(t, 1, tracker.Flush(context.Background()))
+	assert.Equal(t, []string{"tenant-1/cred-1"}, updater.updates)
+}
+
+func TestServiceImpl_GetValue_UsesUsageTracker(t *testing.T) {
+	directUpdates := 0
+	mockRepo := &MockRepository{
+		GetByIDFunc: func(ctx context.Context, tenantID, credentialID string) (*Credential, error) {
+			return &Credential{
+				ID:           credentialID,
+				TenantID:     tenantID,
+				EncryptedDEK: []byte("key"),
+				Ciphertext:   []byte("data"),
+			}, nil
+		},
+		UpdateLastUsedAtFunc: func(ctx context.Context, tenantID, credentialID string) error {
+			directUpdates++
+			return nil
+		},
+		LogAccessFunc: func(ctx context.Context, log *AccessLog) error {
+			return nil
+		},
+	}
+	mockEncryption := &MockEncryptionService{
+		DecryptFunc: func(ctx context.Context, encryptedData, encryptedKey []byte) (*CredentialData, error) {
+			return &CredentialData{Value: map[string]interface{}{"api_key": "key-123"}}, nil
+		},
+	}
+
+	updater := &recordingUpdater{}
+	tracker := NewUsageTracker(updater, time.Minute, nil)
+	service := NewServiceImpl(mockRepo, mockEncryption, nil, WithUsageTracker(tracker))
+
+	for i := 0; i < 3; i++ {
+		_, err := service.GetValue(context.Background(), "tenant-123", "cred-123", "user-123")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 0, directUpdates)
+	assert.Equal(t, 1, tracker.Flush(context.Background()))
+}
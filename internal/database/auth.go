@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+const (
+	// iamTokenExpiry is how long RDS accepts an IAM auth token after signing
+	iamTokenExpiry = 15 * time.Minute
+
+	// emptyPayloadHash is the SHA-256 of an empty body, as signed into IAM auth tokens
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// TokenProvider supplies the password used to open new database connections
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// IAMTokenProvider signs AWS RDS IAM auth tokens for a database user
+type IAMTokenProvider struct {
+	endpoint    string
+	region      string
+	user        string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	now         func() time.Time
+}
+
+// NewIAMTokenProvider creates a provider signing tokens for user at host:port
+// with the default AWS credential chain
+func NewIAMTokenProvider(ctx context.Context, host string, port int, user, region string) (*IAMTokenProvider, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return NewIAMTokenProviderWithCredentials(host, port, user, region, awsCfg.Credentials), nil
+}
+
+// NewIAMTokenProviderWithCredentials creates a provider signing tokens with
+// the given AWS credentials
+func NewIAMTokenProviderWithCredentials(host string, port int, user, region string, credentials aws.CredentialsProvider) *IAMTokenProvider {
+	return &IAMTokenProvider{
+		endpoint:    net.JoinHostPort(host, strconv.Itoa(port)),
+		region:      region,
+		user:        user,
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+		now:         time.Now,
+	}
+}
+
+// Token signs a new IAM auth token
+func (p *IAMTokenProvider) Token(ctx context.Context) (string, error) {
+	creds, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	query := url.Values{
+		"Action":        {"connect"},
+		"DBUser":        {p.user},
+		"X-Amz-Expires": {strconv.Itoa(int(iamTokenExpiry.Seconds()))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+p.endpoint+"/?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build IAM auth request: %w", err)
+	}
+
+	signed, _, err := p.signer.PresignHTTP(ctx, creds, req, emptyPayloadHash, "rds-db", p.region, p.now())
+	if err != nil {
+		return "", fmt.Errorf("failed to sign IAM auth token: %w", err)
+	}
+	return strings.TrimPrefix(signed, "https://"), nil
+}
+
+// cachedToken reuses a provider's token until it is older than the refresh
+// interval, so a burst of new connections signs one token
+type cachedToken struct {
+	provider TokenProvider
+	refresh  time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	token    string
+	signedAt time.Time
+}
+
+func newCachedToken(provider TokenProvider, refresh time.Duration) *cachedToken {
+	return &cachedToken{provider: provider, refresh: refresh, now: time.Now}
+}
+
+// Token returns the cached token, fetching a new one once it is due
+func (c *cachedToken) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.token != "" && now.Sub(c.signedAt) < c.refresh {
+		return c.token, nil
+	}
+
+	token, err := c.provider.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	c.signedAt = now
+	return token, nil
+}
+
+// invalidate forces the next Token call to fetch a new token
+func (c *cachedToken) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
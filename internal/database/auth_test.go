@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Token(ctx context.Context) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	p.calls++
+	return fmt.Sprintf("token-%d", p.calls), nil
+}
+
+func TestIAMTokenProvider_Token(t *testing.T) {
+	provider := NewIAMTokenProviderWithCredentials("db.example.com", 5432, "gorax", "us-west-2",
+		credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""))
+	provider.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(token, "db.example.com:5432/?"), token)
+	query, err := url.ParseQuery(strings.SplitN(token, "?", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, "connect", query.Get("Action"))
+	assert.Equal(t, "gorax", query.Get("DBUser"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.Equal(t, "20260102T030405Z", query.Get("X-Amz-Date"))
+	assert.Equal(t, "AKIDEXAMPLE/20260102/us-west-2/rds-db/aws4_request", query.Get("X-Amz-Credential"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
+
+func TestCachedToken(t *testing.T) {
+	provider := &countingProvider{}
+	cache := newCachedToken(provider, 10*time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := cache.Token(ctx)
+	require.NoError(t, err)
+	second, err := cache.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "token is reused within the refresh interval")
+	assert.Equal(t, 1, provider.calls)
+
+	now = now.Add(10 * time.Minute)
+	refreshed, err := cache.Token(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, refreshed, "token is refreshed once the interval passes")
+
+	cache.invalidate()
+	_, err = cache.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, provider.calls, "invalidate forces a new token")
+
+	provider.err = errors.New("no credentials")
+	cache.invalidate()
+	_, err = cache.Token(ctx)
+	assert.Error(t, err)
+}
+
+func TestIsAuthFailure(t *testing.T) {
+	assert.True(t, isAuthFailure(&pq.Error{Code: "28P01"}))
+	assert.True(t, isAuthFailure(&pq.Error{Code: "28000"}))
+	assert.False(t, isAuthFailure(&pq.Error{Code: "53300"}))
+	assert.False(t, isAuthFailure(errors.New("connection refused")))
+}
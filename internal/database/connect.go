@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/gorax/gorax/internal/config"
+)
+
+// Connect opens a PostgreSQL connection pool for cfg and verifies it can
+// connect. Pool sizing is left to the caller.
+func Connect(ctx context.Context, cfg config.DatabaseConfig) (*sqlx.DB, error) {
+	db, err := OpenDB(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sqlx.NewDb(db, "postgres"), nil
+}
+
+// OpenDB opens a PostgreSQL connection pool for cfg and verifies it can
+// connect. In IAM auth mode every new connection authenticates with a fresh
+// token, so credentials rotate as the pool replaces connections while open
+// connections and their in-flight queries are left alone.
+func OpenDB(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	var db *sql.DB
+	switch cfg.AuthMode {
+	case config.DatabaseAuthModeIAM:
+		provider, err := NewIAMTokenProvider(ctx, cfg.Host, cfg.Port, cfg.User, cfg.IAMRegion)
+		if err != nil {
+			return nil, err
+		}
+		db = sql.OpenDB(NewTokenConnector(cfg, provider))
+	default:
+		var err error
+		db, err = sql.Open("postgres", cfg.ConnectionString())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// TokenConnector opens PostgreSQL connections authenticating with a password
+// from a TokenProvider
+type TokenConnector struct {
+	cfg    config.DatabaseConfig
+	tokens *cachedToken
+}
+
+// NewTokenConnector creates a connector that reuses a token for
+// cfg.TokenRefreshInterval before fetching a new one
+func NewTokenConnector(cfg config.DatabaseConfig, provider TokenProvider) *TokenConnector {
+	return &TokenConnector{
+		cfg:    cfg,
+		tokens: newCachedToken(provider, cfg.TokenRefreshInterval),
+	}
+}
+
+// Connect opens a connection with the current token. If the server rejects
+// the token it is discarded and the connection retried once with a new one.
+func (c *TokenConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connect(ctx)
+	if err != nil && isAuthFailure(err) {
+		c.tokens.invalidate()
+		conn, err = c.connect(ctx)
+	}
+	return conn, err
+}
+
+// Driver returns the PostgreSQL driver
+func (c *TokenConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+func (c *TokenConnector) connect(ctx context.Context) (driver.Conn, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database auth token: %w", err)
+	}
+
+	connector, err := pq.NewConnector(c.cfg.ConnectionStringWithPassword(token))
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// isAuthFailure reports whether err is PostgreSQL rejecting the credentials
+func isAuthFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Class() == "28"
+}
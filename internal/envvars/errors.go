@@ -0,0 +1,22 @@
+package envvars
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an environment variable does not exist
+	ErrNotFound = errors.New("environment variable not found")
+	// ErrWorkflowNotFound is returned when a workflow-scoped variable names a
+	// workflow that does not exist in the tenant
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	// ErrDuplicateKey is returned when the key is already set at the same scope
+	ErrDuplicateKey = errors.New("environment variable already exists")
+)
+
+// ValidationError represents an invalid environment variable
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,146 @@
+package envvars
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Variable is an environment variable available to executions as ${env.KEY}.
+// Tenant-wide variables have no WorkflowID.
+type Variable struct {
+	ID         string    `db:"id" json:"id"`
+	TenantID   string    `db:"tenant_id" json:"tenant_id"`
+	WorkflowID *string   `db:"workflow_id" json:"workflow_id,omitempty"`
+	Key        string    `db:"key" json:"key"`
+	Value      string    `db:"value" json:"value"`
+	CreatedBy  string    `db:"created_by" json:"created_by"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// RepositoryInterface defines the environment variable data access used by the service
+type RepositoryInterface interface {
+	List(ctx context.Context, tenantID string, workflowID *string) ([]Variable, error)
+	ListForExecution(ctx context.Context, tenantID, workflowID string) ([]Variable, error)
+	Create(ctx context.Context, v *Variable) error
+	UpdateValue(ctx context.Context, tenantID, id, value string) (*Variable, error)
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+// Repository handles environment variable data access
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new environment variable repository
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const variableColumns = `id, tenant_id, workflow_id, key, value, COALESCE(created_by, '') AS created_by, created_at, updated_at`
+
+// List returns the tenant-wide variables, or a workflow's own variables when
+// workflowID is set
+func (r *Repository) List(ctx context.Context, tenantID string, workflowID *string) ([]Variable, error) {
+	query := `
+		SELECT ` + variableColumns + `
+		FROM environment_variables
+		WHERE tenant_id = $1 AND workflow_id IS NOT DISTINCT FROM $2
+		ORDER BY key ASC
+	`
+
+	variables := []Variable{}
+	if err := r.db.SelectContext(ctx, &variables, query, tenantID, workflowID); err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// ListForExecution returns the tenant-wide variables and the workflow's own
+// variables, tenant-wide ones first
+func (r *Repository) ListForExecution(ctx context.Context, tenantID, workflowID string) ([]Variable, error) {
+	query := `
+		SELECT ` + variableColumns + `
+		FROM environment_variables
+		WHERE tenant_id = $1 AND (workflow_id IS NULL OR workflow_id = $2)
+		ORDER BY workflow_id NULLS FIRST, key ASC
+	`
+
+	variables := []Variable{}
+	if err := r.db.SelectContext(ctx, &variables, query, tenantID, workflowID); err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+// Create inserts a variable. A workflow-scoped variable is only inserted if
+// the workflow belongs to the tenant.
+func (r *Repository) Create(ctx context.Context, v *Variable) error {
+	query := `
+		INSERT INTO environment_variables (tenant_id, workflow_id, key, value, created_by)
+		SELECT $1, $2, $3, $4, $5
+		WHERE $2::uuid IS NULL OR EXISTS (SELECT 1 FROM workflows WHERE id = $2 AND tenant_id = $1)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, v.TenantID, v.WorkflowID, v.Key, v.Value, v.CreatedBy).
+		Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWorkflowNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrDuplicateKey
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateValue replaces the value of a variable
+func (r *Repository) UpdateValue(ctx context.Context, tenantID, id, value string) (*Variable, error) {
+	query := `
+		UPDATE environment_variables
+		SET value = $3, updated_at = NOW()
+		WHERE tenant_id = $1 AND id = $2
+		RETURNING ` + variableColumns
+
+	var v Variable
+	if err := r.db.GetContext(ctx, &v, query, tenantID, id, value); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Delete removes a variable
+func (r *Repository) Delete(ctx context.Context, tenantID, id string) error {
+	query := `DELETE FROM environment_variables WHERE tenant_id = $1 AND id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
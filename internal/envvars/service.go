@@ -0,0 +1,184 @@
+package envvars
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// keyPattern matches the names ${env.KEY} references can use
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+const (
+	maxKeyLength   = 100
+	maxValueLength = 4096
+)
+
+// reservedKeys are supplied by the executor to every execution and cannot be overridden
+var reservedKeys = []string{"tenant_id", "workflow_id", "execution_id", "node_id"}
+
+// secretKeySuffixes mark keys that name a secret. Values are stored in plain
+// text, so such variables are rejected in favour of credentials.
+var secretKeySuffixes = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "API_KEY", "APIKEY", "PRIVATE_KEY"}
+
+// CreateVariableInput creates an environment variable
+type CreateVariableInput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Validate checks the key and value
+func (i CreateVariableInput) Validate() error {
+	if err := validateKey(i.Key); err != nil {
+		return err
+	}
+	return validateValue(i.Value)
+}
+
+// UpdateVariableInput replaces the value of an environment variable
+type UpdateVariableInput struct {
+	Value *string `json:"value"`
+}
+
+// Validate checks the value
+func (i UpdateVariableInput) Validate() error {
+	if i.Value == nil {
+		return &ValidationError{Message: "value is required"}
+	}
+	return validateValue(*i.Value)
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return &ValidationError{Message: "key is required"}
+	}
+	if len(key) > maxKeyLength || !keyPattern.MatchString(key) {
+		return &ValidationError{Message: "key must start with a letter or underscore and contain only letters, digits and underscores (max 100 characters)"}
+	}
+	if slices.Contains(reservedKeys, key) {
+		return &ValidationError{Message: fmt.Sprintf("%s is set by the executor and cannot be overridden", key)}
+	}
+	if LooksLikeSecret(key) {
+		return &ValidationError{Message: fmt.Sprintf("%s looks like a secret; store it as a credential and reference it as ${credentials.name}", key)}
+	}
+	return nil
+}
+
+// LooksLikeSecret reports whether an env variable key names a secret, such
+// as DB_PASSWORD or STRIPE_API_KEY
+func LooksLikeSecret(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, suffix := range secretKeySuffixes {
+		if upper == suffix || strings.HasSuffix(upper, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateValue(value string) error {
+	if len(value) > maxValueLength {
+		return &ValidationError{Message: "value must be at most 4096 bytes"}
+	}
+	return nil
+}
+
+// Service manages environment variables and resolves them for executions
+type Service struct {
+	repo   RepositoryInterface
+	logger *slog.Logger
+}
+
+// NewService creates a new environment variable service
+func NewService(repo RepositoryInterface, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// List returns the tenant-wide variables, or a workflow's own variables when
+// workflowID is set
+func (s *Service) List(ctx context.Context, tenantID string, workflowID *string) ([]Variable, error) {
+	return s.repo.List(ctx, tenantID, workflowID)
+}
+
+// Create creates a tenant-wide variable, or a workflow variable when workflowID is set
+func (s *Service) Create(ctx context.Context, tenantID string, workflowID *string, userID string, input CreateVariableInput) (*Variable, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	variable := &Variable{
+		TenantID:   tenantID,
+		WorkflowID: workflowID,
+		Key:        input.Key,
+		Value:      input.Value,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.Create(ctx, variable); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("environment variable created",
+		"tenant_id", tenantID, "workflow_id", workflowID, "key", input.Key, "created_by", userID)
+	return variable, nil
+}
+
+// Update replaces the value of a variable
+func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateVariableInput) (*Variable, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateValue(ctx, tenantID, id, *input.Value)
+}
+
+// Delete removes a variable
+func (s *Service) Delete(ctx context.Context, tenantID, id string) error {
+	return s.repo.Delete(ctx, tenantID, id)
+}
+
+// ResolveEnv returns the variables an execution of the workflow sees: the
+// tenant-wide variables overlaid with the workflow's own
+func (s *Service) ResolveEnv(ctx context.Context, tenantID, workflowID string) (map[string]string, error) {
+	var (
+		variables []Variable
+		err       error
+	)
+	if workflowID == "" {
+		variables, err = s.repo.List(ctx, tenantID, nil)
+	} else {
+		variables, err = s.repo.ListForExecution(ctx, tenantID, workflowID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Tenant-wide variables come first, so workflow variables overwrite them
+	env := make(map[string]string, len(variables))
+	for _, v := range variables {
+		env[v.Key] = v.Value
+	}
+	return env, nil
+}
+
+// MissingEnvVars returns the names that would not resolve for the workflow.
+// An empty workflowID checks tenant-wide variables only.
+func (s *Service) MissingEnvVars(ctx context.Context, tenantID, workflowID string, names []string) ([]string, error) {
+	env, err := s.ResolveEnv(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := env[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
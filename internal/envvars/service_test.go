@@ -0,0 +1,153 @@
+package envvars
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository is a mock implementation of RepositoryInterface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) List(ctx context.Context, tenantID string, workflowID *string) ([]Variable, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Variable), args.Error(1)
+}
+
+func (m *MockRepository) ListForExecution(ctx context.Context, tenantID, workflowID string) ([]Variable, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Variable), args.Error(1)
+}
+
+func (m *MockRepository) Create(ctx context.Context, v *Variable) error {
+	args := m.Called(ctx, v)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateValue(ctx context.Context, tenantID, id, value string) (*Variable, error) {
+	args := m.Called(ctx, tenantID, id, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Variable), args.Error(1)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, tenantID, id string) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func newTestService() (*Service, *MockRepository) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewService(repo, logger), repo
+}
+
+func TestCreateVariableInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CreateVariableInput
+		wantErr string
+	}{
+		{name: "valid", input: CreateVariableInput{Key: "API_BASE_URL", Value: "https://api.example.com"}},
+		{name: "lowercase key", input: CreateVariableInput{Key: "region", Value: "eu"}},
+		{name: "token in the middle is allowed", input: CreateVariableInput{Key: "TOKEN_URL", Value: "https://auth.example.com/token"}},
+		{name: "missing key", input: CreateVariableInput{Value: "x"}, wantErr: "key is required"},
+		{name: "invalid characters", input: CreateVariableInput{Key: "API-URL"}, wantErr: "key must start with a letter"},
+		{name: "leading digit", input: CreateVariableInput{Key: "1URL"}, wantErr: "key must start with a letter"},
+		{name: "built-in", input: CreateVariableInput{Key: "tenant_id"}, wantErr: "cannot be overridden"},
+		{name: "secret suffix", input: CreateVariableInput{Key: "GITHUB_TOKEN"}, wantErr: "store it as a credential"},
+		{name: "secret suffix lowercase", input: CreateVariableInput{Key: "stripe_api_key"}, wantErr: "store it as a credential"},
+		{name: "password", input: CreateVariableInput{Key: "PASSWORD"}, wantErr: "store it as a credential"},
+		{name: "value too long", input: CreateVariableInput{Key: "BODY", Value: string(make([]byte, maxValueLength+1))}, wantErr: "at most 4096 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, validationErr.Message, tt.wantErr)
+		})
+	}
+}
+
+func TestLooksLikeSecret(t *testing.T) {
+	assert.True(t, LooksLikeSecret("GITHUB_TOKEN"))
+	assert.True(t, LooksLikeSecret("db_password"))
+	assert.True(t, LooksLikeSecret("SECRET"))
+	assert.False(t, LooksLikeSecret("TOKEN_URL"))
+	assert.False(t, LooksLikeSecret("MYSECRET"))
+}
+
+func TestService_ResolveEnv(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("workflow variables override tenant-wide ones", func(t *testing.T) {
+		service, repo := newTestService()
+		workflowID := "wf-1"
+		repo.On("ListForExecution", ctx, "tenant-1", "wf-1").Return([]Variable{
+			{Key: "API_BASE_URL", Value: "https://api.example.com"},
+			{Key: "REGION", Value: "us"},
+			{WorkflowID: &workflowID, Key: "API_BASE_URL", Value: "https://staging.example.com"},
+		}, nil)
+
+		env, err := service.ResolveEnv(ctx, "tenant-1", "wf-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"API_BASE_URL": "https://staging.example.com", "REGION": "us"}, env)
+	})
+
+	t.Run("no workflow uses tenant-wide variables", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("List", ctx, "tenant-1", (*string)(nil)).Return([]Variable{{Key: "REGION", Value: "us"}}, nil)
+
+		env, err := service.ResolveEnv(ctx, "tenant-1", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"REGION": "us"}, env)
+	})
+}
+
+func TestService_MissingEnvVars(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+	repo.On("ListForExecution", ctx, "tenant-1", "wf-1").Return([]Variable{{Key: "REGION", Value: "us"}}, nil)
+
+	missing, err := service.MissingEnvVars(ctx, "tenant-1", "wf-1", []string{"API_BASE_URL", "REGION"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API_BASE_URL"}, missing)
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+	workflowID := "wf-1"
+	repo.On("Create", ctx, mock.MatchedBy(func(v *Variable) bool {
+		return v.TenantID == "tenant-1" && *v.WorkflowID == "wf-1" && v.Key == "REGION" && v.CreatedBy == "user-1"
+	})).Return(nil)
+
+	variable, err := service.Create(ctx, "tenant-1", &workflowID, "user-1", CreateVariableInput{Key: "REGION", Value: "eu"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "eu", variable.Value)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,239 @@
+package eventtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// annotationKeywords never affect which payloads a schema accepts
+var annotationKeywords = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"examples":    true,
+	"default":     true,
+	"format":      true,
+}
+
+// lowerBoundKeywords may only be lowered or removed by a compatible change
+var lowerBoundKeywords = []string{"minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties"}
+
+// upperBoundKeywords may only be raised or removed by a compatible change
+var upperBoundKeywords = []string{"maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties"}
+
+// BreakingChanges compares two schemas for the same event type version and
+// describes every change that could make a payload that is valid under
+// previous invalid under next. Adding optional properties, widening types,
+// adding enum values, relaxing bounds and editing annotations are backward
+// compatible.
+func BreakingChanges(previous, next json.RawMessage) ([]string, error) {
+	var prev, nxt interface{}
+	if err := json.Unmarshal(previous, &prev); err != nil {
+		return nil, fmt.Errorf("decode previous schema: %w", err)
+	}
+	if err := json.Unmarshal(next, &nxt); err != nil {
+		return nil, fmt.Errorf("decode next schema: %w", err)
+	}
+
+	var changes []string
+	compareSchemas(normalizeSchema(prev), normalizeSchema(nxt), "$", &changes)
+	return changes, nil
+}
+
+// normalizeSchema turns boolean schemas into their object equivalents
+func normalizeSchema(schema interface{}) map[string]interface{} {
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		return s
+	case bool:
+		if s {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"not": map[string]interface{}{}}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func compareSchemas(prev, next map[string]interface{}, path string, changes *[]string) {
+	report := func(format string, args ...interface{}) {
+		*changes = append(*changes, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	compareTypes(prev["type"], next["type"], report)
+	compareRequired(prev["required"], next["required"], report)
+	compareProperties(prev, next, path, changes)
+	compareEnum(prev["enum"], next["enum"], report)
+
+	for _, keyword := range lowerBoundKeywords {
+		compareBound(keyword, prev[keyword], next[keyword], func(p, n float64) bool { return n <= p }, report)
+	}
+	for _, keyword := range upperBoundKeywords {
+		compareBound(keyword, prev[keyword], next[keyword], func(p, n float64) bool { return n >= p }, report)
+	}
+
+	if prevItems, ok := prev["items"]; ok {
+		if nextItems, ok := next["items"]; ok {
+			compareSchemas(normalizeSchema(prevItems), normalizeSchema(nextItems), path+"[]", changes)
+		}
+	} else if _, ok := next["items"]; ok {
+		report("items constraint added")
+	}
+
+	handled := map[string]bool{
+		"type": true, "required": true, "properties": true, "additionalProperties": true,
+		"enum": true, "items": true,
+	}
+	for _, keyword := range append(append([]string{}, lowerBoundKeywords...), upperBoundKeywords...) {
+		handled[keyword] = true
+	}
+
+	// Any other constraint must be unchanged or removed
+	keywords := make([]string, 0, len(next))
+	for keyword := range next {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if handled[keyword] || annotationKeywords[keyword] {
+			continue
+		}
+		if !reflect.DeepEqual(prev[keyword], next[keyword]) {
+			report("%s changed", keyword)
+		}
+	}
+}
+
+func compareTypes(prev, next interface{}, report func(string, ...interface{})) {
+	if next == nil {
+		return
+	}
+	nextTypes := stringList(next)
+	if prev == nil {
+		report("type constraint %v added", nextTypes)
+		return
+	}
+
+	allowed := make(map[string]bool, len(nextTypes))
+	for _, t := range nextTypes {
+		allowed[t] = true
+	}
+	for _, t := range stringList(prev) {
+		if allowed[t] || (t == "integer" && allowed["number"]) {
+			continue
+		}
+		report("type changed from %v to %v", stringList(prev), nextTypes)
+		return
+	}
+}
+
+// stringList reads a keyword that holds a string or an array of strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func compareRequired(prev, next interface{}, report func(string, ...interface{})) {
+	wasRequired := make(map[string]bool)
+	for _, name := range stringList(prev) {
+		wasRequired[name] = true
+	}
+	for _, name := range stringList(next) {
+		if !wasRequired[name] {
+			report("property %q is now required", name)
+		}
+	}
+}
+
+func compareProperties(prev, next map[string]interface{}, path string, changes *[]string) {
+	prevProps, _ := prev["properties"].(map[string]interface{})
+	nextProps, _ := next["properties"].(map[string]interface{})
+
+	names := make([]string, 0, len(prevProps))
+	for name := range prevProps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		nextProp, ok := nextProps[name]
+		if !ok {
+			*changes = append(*changes, fmt.Sprintf("%s: property %q removed", path, name))
+			continue
+		}
+		compareSchemas(normalizeSchema(prevProps[name]), normalizeSchema(nextProp), path+"."+name, changes)
+	}
+
+	prevAdditional, prevSet := prev["additionalProperties"]
+	nextAdditional, nextSet := next["additionalProperties"]
+	if !nextSet {
+		return
+	}
+	if !prevSet {
+		prevAdditional = true
+	}
+	if closed, ok := nextAdditional.(bool); ok && !closed {
+		if open, ok := prevAdditional.(bool); !ok || open {
+			*changes = append(*changes, path+": additional properties no longer allowed")
+		}
+		return
+	}
+	if _, ok := nextAdditional.(map[string]interface{}); ok {
+		compareSchemas(normalizeSchema(prevAdditional), normalizeSchema(nextAdditional), path+".*", changes)
+	}
+}
+
+func compareEnum(prev, next interface{}, report func(string, ...interface{})) {
+	nextValues, ok := next.([]interface{})
+	if !ok {
+		return
+	}
+	prevValues, ok := prev.([]interface{})
+	if !ok {
+		report("enum constraint added")
+		return
+	}
+	for _, value := range prevValues {
+		found := false
+		for _, candidate := range nextValues {
+			if reflect.DeepEqual(value, candidate) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("enum value %v removed", value)
+		}
+	}
+}
+
+func compareBound(keyword string, prev, next interface{}, compatible func(prev, next float64) bool, report func(string, ...interface{})) {
+	nextBound, ok := next.(float64)
+	if !ok {
+		return
+	}
+	prevBound, ok := prev.(float64)
+	if !ok {
+		report("%s %v added", keyword, nextBound)
+		return
+	}
+	if !compatible(prevBound, nextBound) {
+		report("%s tightened from %v to %v", keyword, prevBound, nextBound)
+	}
+}
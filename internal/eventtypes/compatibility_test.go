@@ -0,0 +1,97 @@
+package eventtypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	base := `{
+		"type": "object",
+		"required": ["order_id"],
+		"properties": {
+			"order_id": {"type": "string"},
+			"quantity": {"type": "integer", "minimum": 1},
+			"status": {"type": "string", "enum": ["open", "closed"]},
+			"items": {"type": "array", "items": {"type": "object", "properties": {"sku": {"type": "string"}}}}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		next     string
+		breaking []string
+	}{
+		{
+			name: "identical schema",
+			next: base,
+		},
+		{
+			name: "optional property and annotations added",
+			next: `{
+				"type": "object",
+				"title": "Order",
+				"required": ["order_id"],
+				"properties": {
+					"order_id": {"type": "string", "description": "Order identifier"},
+					"quantity": {"type": "number", "minimum": 0},
+					"status": {"type": "string", "enum": ["open", "closed", "cancelled"]},
+					"items": {"type": "array", "items": {"type": "object", "properties": {"sku": {"type": "string"}, "name": {"type": "string"}}}},
+					"note": {"type": "string"}
+				}
+			}`,
+		},
+		{
+			name: "property removed and newly required",
+			next: `{
+				"type": "object",
+				"required": ["order_id", "status"],
+				"properties": {
+					"order_id": {"type": "string"},
+					"status": {"type": "string", "enum": ["open", "closed"]},
+					"items": {"type": "array", "items": {"type": "object", "properties": {"sku": {"type": "string"}}}}
+				}
+			}`,
+			breaking: []string{`$: property "status" is now required`, `$: property "quantity" removed`},
+		},
+		{
+			name: "nested type changed and enum narrowed",
+			next: `{
+				"type": "object",
+				"required": ["order_id"],
+				"properties": {
+					"order_id": {"type": "integer"},
+					"quantity": {"type": "integer", "minimum": 5},
+					"status": {"type": "string", "enum": ["open"]},
+					"items": {"type": "array", "items": {"type": "object", "properties": {"sku": {"type": "number"}}}}
+				},
+				"additionalProperties": false
+			}`,
+			breaking: []string{
+				"$.items[].sku: type changed from [string] to [number]",
+				"$.order_id: type changed from [string] to [integer]",
+				"$.quantity: minimum tightened from 1 to 5",
+				"$.status: enum value closed removed",
+				"$: additional properties no longer allowed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := BreakingChanges(json.RawMessage(base), json.RawMessage(tt.next))
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.breaking, changes)
+		})
+	}
+}
+
+func TestBreakingChanges_InvalidJSON(t *testing.T) {
+	_, err := BreakingChanges(json.RawMessage(`{}`), json.RawMessage(`{`))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package eventtypes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when an event type or event type version does not exist
+var ErrNotFound = errors.New("event type not found")
+
+// ValidationError represents an invalid event type registration
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// BreakingChangeError is returned when a schema registered under an existing
+// version is not backward compatible with the schema already stored for it
+type BreakingChangeError struct {
+	EventType   string
+	Version     int
+	NextVersion int
+	Changes     []string
+}
+
+func (e *BreakingChangeError) Error() string {
+	return fmt.Sprintf("schema for %s version %d has breaking changes (%s); register it as version %d instead",
+		e.EventType, e.Version, strings.Join(e.Changes, "; "), e.NextVersion)
+}
@@ -2,7 +2,10 @@ package eventtypes
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -19,6 +22,23 @@ type EventType struct {
 	UpdatedAt   time.Time       `db:"updated_at" json:"updatedAt"`
 }
 
+// EventSchema is the JSON Schema registered for one version of an event type
+type EventSchema struct {
+	EventType string          `db:"event_type" json:"eventType"`
+	Version   int             `db:"version" json:"version"`
+	Schema    json.RawMessage `db:"schema" json:"schema"`
+	CreatedAt time.Time       `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time       `db:"updated_at" json:"updatedAt"`
+}
+
+// RepositoryInterface defines the event type data access used by the service
+type RepositoryInterface interface {
+	ListAll(ctx context.Context) ([]EventType, error)
+	GetByName(ctx context.Context, name string) (*EventType, error)
+	GetSchema(ctx context.Context, name string, version int) (*EventSchema, error)
+	SaveVersion(ctx context.Context, name, description string, version int, schema json.RawMessage) (*EventSchema, error)
+}
+
 // Repository handles event type data access
 type Repository struct {
 	db *sqlx.DB
@@ -45,3 +65,89 @@ func (r *Repository) ListAll(ctx context.Context) ([]EventType, error) {
 
 	return eventTypes, nil
 }
+
+// GetByName returns the event type with the given name
+func (r *Repository) GetByName(ctx context.Context, name string) (*EventType, error) {
+	query := `
+		SELECT id, name, COALESCE(description, '') AS description, schema, version, created_at, updated_at
+		FROM event_types
+		WHERE name = $1
+	`
+
+	var eventType EventType
+	if err := r.db.GetContext(ctx, &eventType, query, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &eventType, nil
+}
+
+// GetSchema returns the schema of one version of an event type. Version 0
+// selects the latest version.
+func (r *Repository) GetSchema(ctx context.Context, name string, version int) (*EventSchema, error) {
+	query := `
+		SELECT t.name AS event_type, v.version, v.schema, v.created_at, v.updated_at
+		FROM event_types t
+		JOIN event_type_versions v ON v.event_type_id = t.id
+		WHERE t.name = $1
+		  AND v.version = CASE WHEN $2 = 0 THEN t.version ELSE $2 END
+	`
+
+	var schema EventSchema
+	if err := r.db.GetContext(ctx, &schema, query, name, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// SaveVersion stores the schema of one version of an event type, creating the
+// event type if needed. The event type's own schema and version track the
+// latest saved version; an empty description keeps the current one.
+func (r *Repository) SaveVersion(ctx context.Context, name, description string, version int, schema json.RawMessage) (*EventSchema, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback is no-op after commit
+
+	var eventTypeID string
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO event_types (name, description, schema, version)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET
+			description = COALESCE(NULLIF(EXCLUDED.description, ''), event_types.description),
+			schema = CASE WHEN EXCLUDED.version >= event_types.version THEN EXCLUDED.schema ELSE event_types.schema END,
+			version = GREATEST(EXCLUDED.version, event_types.version),
+			updated_at = NOW()
+		RETURNING id
+	`, name, description, schema, version).Scan(&eventTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save event type: %w", err)
+	}
+
+	saved := EventSchema{EventType: name}
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO event_type_versions (event_type_id, version, schema)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_type_id, version) DO UPDATE SET
+			schema = EXCLUDED.schema,
+			updated_at = NOW()
+		RETURNING version, schema, created_at, updated_at
+	`, eventTypeID, version, schema).Scan(&saved.Version, &saved.Schema, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save event type version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &saved, nil
+}
@@ -1,18 +1,62 @@
 package eventtypes
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"regexp"
+	"sync"
+
+	"github.com/gorax/gorax/internal/jsonschema"
 )
 
+// eventTypeNamePattern matches dotted lowercase names such as "order.created"
+var eventTypeNamePattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)*$`)
+
+const maxEventTypeNameLength = 100
+
+// RegisterEventTypeInput registers a schema for a version of an event type
+type RegisterEventTypeInput struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Version     int             `json:"version,omitempty"`
+	Schema      json.RawMessage `json:"schema"`
+}
+
+// Validate checks the fields that do not depend on stored event types
+func (i RegisterEventTypeInput) Validate() error {
+	if i.Name == "" {
+		return &ValidationError{Message: "name is required"}
+	}
+	if len(i.Name) > maxEventTypeNameLength || !eventTypeNamePattern.MatchString(i.Name) {
+		return &ValidationError{Message: "name must be dot-separated lowercase letters, digits and underscores (max 100 characters)"}
+	}
+	if i.Version < 0 {
+		return &ValidationError{Message: "version must be positive"}
+	}
+	if len(i.Schema) == 0 || string(i.Schema) == "null" {
+		return &ValidationError{Message: "schema is required"}
+	}
+	if _, err := jsonschema.Compile(i.Schema); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	return nil
+}
+
 // Service provides event type business logic
 type Service struct {
-	repo   *Repository
+	repo   RepositoryInterface
 	logger *slog.Logger
+
+	// schemas caches compiled schemas by "name@version"
+	schemas sync.Map
 }
 
 // NewService creates a new event type service
-func NewService(repo *Repository, logger *slog.Logger) *Service {
+func NewService(repo RepositoryInterface, logger *slog.Logger) *Service {
 	return &Service{
 		repo:   repo,
 		logger: logger,
@@ -23,3 +67,107 @@ func NewService(repo *Repository, logger *slog.Logger) *Service {
 func (s *Service) ListEventTypes(ctx context.Context) ([]EventType, error) {
 	return s.repo.ListAll(ctx)
 }
+
+// RegisterEventType registers a JSON Schema for a version of an event type.
+// A new event type starts at version 1, and each new version must follow the
+// latest one. Re-registering an existing version only accepts backward
+// compatible changes; breaking changes return a BreakingChangeError naming the
+// version to register instead. Version 0 targets the latest version.
+func (s *Service) RegisterEventType(ctx context.Context, input RegisterEventTypeInput) (*EventSchema, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, input.Schema); err != nil {
+		return nil, &ValidationError{Message: fmt.Sprintf("invalid schema: %v", err)}
+	}
+	schema := json.RawMessage(compacted.Bytes())
+
+	latest := 0
+	existing, err := s.repo.GetByName(ctx, input.Name)
+	switch {
+	case err == nil:
+		latest = existing.Version
+	case !errors.Is(err, ErrNotFound):
+		return nil, fmt.Errorf("get event type: %w", err)
+	}
+
+	version := input.Version
+	if version == 0 {
+		version = max(latest, 1)
+	}
+
+	if version > latest+1 {
+		return nil, &ValidationError{Message: fmt.Sprintf("next version of %s is %d", input.Name, latest+1)}
+	}
+
+	if version <= latest {
+		current, err := s.repo.GetSchema(ctx, input.Name, version)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("get event type schema: %w", err)
+		}
+		if current != nil {
+			changes, err := BreakingChanges(current.Schema, schema)
+			if err != nil {
+				return nil, fmt.Errorf("compare event type schemas: %w", err)
+			}
+			if len(changes) > 0 {
+				return nil, &BreakingChangeError{
+					EventType:   input.Name,
+					Version:     version,
+					NextVersion: latest + 1,
+					Changes:     changes,
+				}
+			}
+		}
+	}
+
+	saved, err := s.repo.SaveVersion(ctx, input.Name, input.Description, version, schema)
+	if err != nil {
+		return nil, fmt.Errorf("save event type: %w", err)
+	}
+
+	s.logger.Info("event type registered", "event_type", input.Name, "version", version)
+	return saved, nil
+}
+
+// GetEventSchema returns the schema registered for a version of an event
+// type. Version 0 returns the latest version.
+func (s *Service) GetEventSchema(ctx context.Context, name string, version int) (*EventSchema, error) {
+	if version < 0 {
+		return nil, &ValidationError{Message: "version must be positive"}
+	}
+	return s.repo.GetSchema(ctx, name, version)
+}
+
+// cachedSchema is a compiled schema together with the source it was compiled from
+type cachedSchema struct {
+	source string
+	schema *jsonschema.Schema
+}
+
+// ValidateEvent validates a payload against the schema registered for a
+// version of an event type. Version 0 validates against the latest version.
+func (s *Service) ValidateEvent(ctx context.Context, name string, version int, payload []byte) ([]jsonschema.Violation, error) {
+	registered, err := s.GetEventSchema(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s@%d", registered.EventType, registered.Version)
+	source := string(registered.Schema)
+	if cached, ok := s.schemas.Load(key); ok {
+		if entry := cached.(*cachedSchema); entry.source == source {
+			return entry.schema.ValidateJSON(payload), nil
+		}
+	}
+
+	schema, err := jsonschema.Compile(registered.Schema)
+	if err != nil {
+		return nil, err
+	}
+	s.schemas.Store(key, &cachedSchema{source: source, schema: schema})
+
+	return schema.ValidateJSON(payload), nil
+}
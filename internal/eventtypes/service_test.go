@@ -0,0 +1,157 @@
+package eventtypes
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository is a mock implementation of RepositoryInterface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListAll(ctx context.Context) ([]EventType, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]EventType), args.Error(1)
+}
+
+func (m *MockRepository) GetByName(ctx context.Context, name string) (*EventType, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EventType), args.Error(1)
+}
+
+func (m *MockRepository) GetSchema(ctx context.Context, name string, version int) (*EventSchema, error) {
+	args := m.Called(ctx, name, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EventSchema), args.Error(1)
+}
+
+func (m *MockRepository) SaveVersion(ctx context.Context, name, description string, version int, schema json.RawMessage) (*EventSchema, error) {
+	args := m.Called(ctx, name, description, version, schema)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*EventSchema), args.Error(1)
+}
+
+func newTestService() (*Service, *MockRepository) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return NewService(repo, logger), repo
+}
+
+func TestService_RegisterEventType(t *testing.T) {
+	v1 := json.RawMessage(`{"type":"object","required":["order_id"],"properties":{"order_id":{"type":"string"}}}`)
+	withOptional := json.RawMessage(`{"type":"object","required":["order_id"],"properties":{"order_id":{"type":"string"},"note":{"type":"string"}}}`)
+	withRequired := json.RawMessage(`{"type":"object","required":["order_id","total"],"properties":{"order_id":{"type":"string"},"total":{"type":"number"}}}`)
+	existing := &EventType{ID: "et-1", Name: "order.created", Schema: v1, Version: 1}
+
+	t.Run("new event type starts at version 1", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("GetByName", mock.Anything, "order.created").Return(nil, ErrNotFound)
+		repo.On("SaveVersion", mock.Anything, "order.created", "Order placed", 1, v1).
+			Return(&EventSchema{EventType: "order.created", Version: 1, Schema: v1}, nil)
+
+		saved, err := service.RegisterEventType(context.Background(), RegisterEventTypeInput{
+			Name: "order.created", Description: "Order placed", Schema: json.RawMessage(`{"type": "object", "required": ["order_id"], "properties": {"order_id": {"type": "string"}}}`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, saved.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("backward compatible addition updates the version", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("GetByName", mock.Anything, "order.created").Return(existing, nil)
+		repo.On("GetSchema", mock.Anything, "order.created", 1).Return(&EventSchema{EventType: "order.created", Version: 1, Schema: v1}, nil)
+		repo.On("SaveVersion", mock.Anything, "order.created", "", 1, withOptional).
+			Return(&EventSchema{EventType: "order.created", Version: 1, Schema: withOptional}, nil)
+
+		_, err := service.RegisterEventType(context.Background(), RegisterEventTypeInput{Name: "order.created", Schema: withOptional})
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("breaking change requires a new version", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("GetByName", mock.Anything, "order.created").Return(existing, nil)
+		repo.On("GetSchema", mock.Anything, "order.created", 1).Return(&EventSchema{EventType: "order.created", Version: 1, Schema: v1}, nil)
+
+		_, err := service.RegisterEventType(context.Background(), RegisterEventTypeInput{Name: "order.created", Version: 1, Schema: withRequired})
+
+		var breaking *BreakingChangeError
+		require.ErrorAs(t, err, &breaking)
+		assert.Equal(t, 2, breaking.NextVersion)
+		assert.Equal(t, []string{`$: property "total" is now required`}, breaking.Changes)
+		repo.AssertNotCalled(t, "SaveVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("breaking change registered as the next version", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("GetByName", mock.Anything, "order.created").Return(existing, nil)
+		repo.On("SaveVersion", mock.Anything, "order.created", "", 2, withRequired).
+			Return(&EventSchema{EventType: "order.created", Version: 2, Schema: withRequired}, nil)
+
+		saved, err := service.RegisterEventType(context.Background(), RegisterEventTypeInput{Name: "order.created", Version: 2, Schema: withRequired})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, saved.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("versions cannot be skipped", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("GetByName", mock.Anything, "order.created").Return(existing, nil)
+
+		_, err := service.RegisterEventType(context.Background(), RegisterEventTypeInput{Name: "order.created", Version: 3, Schema: withRequired})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+func TestRegisterEventTypeInput_Validate(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object"}`)
+
+	var validationErr *ValidationError
+	assert.NoError(t, RegisterEventTypeInput{Name: "order.created", Schema: schema}.Validate())
+	assert.ErrorAs(t, RegisterEventTypeInput{Schema: schema}.Validate(), &validationErr)
+	assert.ErrorAs(t, RegisterEventTypeInput{Name: "Order Created", Schema: schema}.Validate(), &validationErr)
+	assert.ErrorAs(t, RegisterEventTypeInput{Name: "order.created", Version: -1, Schema: schema}.Validate(), &validationErr)
+	assert.ErrorAs(t, RegisterEventTypeInput{Name: "order.created"}.Validate(), &validationErr)
+	assert.ErrorAs(t, RegisterEventTypeInput{Name: "order.created", Schema: json.RawMessage(`{"type":"bogus"}`)}.Validate(), &validationErr)
+}
+
+func TestService_ValidateEvent(t *testing.T) {
+	service, repo := newTestService()
+	repo.On("GetSchema", mock.Anything, "order.created", 0).
+		Return(&EventSchema{EventType: "order.created", Version: 2, Schema: json.RawMessage(`{"type":"object","required":["order_id"]}`)}, nil)
+
+	violations, err := service.ValidateEvent(context.Background(), "order.created", 0, []byte(`{"order_id":"42"}`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = service.ValidateEvent(context.Background(), "order.created", 0, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+
+	_, cached := service.schemas.Load("order.created@2")
+	assert.True(t, cached)
+}
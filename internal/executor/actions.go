@@ -36,7 +36,7 @@ func (e *Executor) executeHTTPAction(ctx context.Context, node workflow.Node, ex
 	// Execute HTTP request through circuit breaker with tracing
 	result, err := tracing.TraceHTTPAction(ctx, config.Method, config.URL, func(tracedCtx context.Context) (interface{}, error) {
 		return circuitBreaker.ExecuteWithResult(tracedCtx, func(reqCtx context.Context) (interface{}, error) {
-			return actions.ExecuteHTTP(reqCtx, config, execContext)
+			return actions.ExecuteHTTPWithOptions(reqCtx, config, execContext, e.httpOptions)
 		})
 	})
 
@@ -142,11 +142,7 @@ func (e *Executor) executeWithJSEngine(ctx context.Context, config ScriptActionC
 	jsCtx := javascript.NewExecutionContext().
 		WithTrigger(execCtx.TriggerData).
 		WithSteps(execCtx.StepOutputs).
-		WithEnv(map[string]any{
-			"tenant_id":    execCtx.TenantID,
-			"execution_id": execCtx.ExecutionID,
-			"workflow_id":  execCtx.WorkflowID,
-		})
+		WithEnv(envContext(execCtx))
 
 	// Determine timeout
 	var timeout time.Duration
@@ -203,10 +199,6 @@ func buildInterpolationContext(execCtx *ExecutionContext) map[string]interface{}
 	return map[string]interface{}{
 		"trigger": execCtx.TriggerData,
 		"steps":   execCtx.StepOutputs,
-		"env": map[string]interface{}{
-			"tenant_id":    execCtx.TenantID,
-			"execution_id": execCtx.ExecutionID,
-			"workflow_id":  execCtx.WorkflowID,
-		},
+		"env":     envContext(execCtx),
 	}
 }
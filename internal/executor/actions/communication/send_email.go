@@ -3,17 +3,52 @@ package communication
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gorax/gorax/internal/communication"
 	"github.com/gorax/gorax/internal/communication/email"
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/security"
 )
 
+const (
+	// MaxAttachmentBytes is the largest single attachment, inline or fetched
+	MaxAttachmentBytes = 10 * 1024 * 1024
+
+	// MaxTotalAttachmentBytes is the largest combined size of all attachments
+	MaxTotalAttachmentBytes = 25 * 1024 * 1024
+
+	// attachmentFetchTimeout bounds downloading a single attachment URL
+	attachmentFetchTimeout = 30 * time.Second
+
+	// maxAttachmentRedirects is the number of redirects followed when fetching an attachment
+	maxAttachmentRedirects = 5
+)
+
+// providerCredentialTypes maps each email provider to the credential type holding its secrets
+var providerCredentialTypes = map[string]credential.CredentialType{
+	string(communication.ProviderTypeSendGrid): credential.TypeEmailSendGrid,
+	string(communication.ProviderTypeMailgun):  credential.TypeEmailMailgun,
+	string(communication.ProviderTypeAWSSES):   credential.TypeEmailAWSSES,
+	string(communication.ProviderTypeSMTP):     credential.TypeEmailSMTP,
+}
+
 // SendEmailAction executes email sending operations.
 type SendEmailAction struct {
 	config            SendEmailConfig
 	credentialService credential.Service
+	urlValidator      *security.URLValidator
+	httpClient        *http.Client
+	providerFactory   func(config SendEmailConfig, credValue map[string]interface{}) (communication.EmailProvider, error)
 }
 
 // SendEmailConfig represents the configuration for the SendEmail action.
@@ -33,11 +68,13 @@ type SendEmailConfig struct {
 	SMTPConfig   *SMTPProviderConfig `json:"smtp_config,omitempty"` // Required for SMTP provider
 }
 
-// AttachmentConfig represents an email attachment configuration.
+// AttachmentConfig represents an email attachment configuration. Exactly one
+// of Content and URL is set.
 type AttachmentConfig struct {
 	Filename    string `json:"filename"`
-	Content     string `json:"content"` // Base64 encoded content
-	ContentType string `json:"content_type"`
+	Content     string `json:"content,omitempty"` // Base64 encoded content
+	URL         string `json:"url,omitempty"`     // Fetched when the email is sent
+	ContentType string `json:"content_type,omitempty"`
 }
 
 // SMTPProviderConfig contains SMTP-specific configuration.
@@ -49,66 +86,183 @@ type SMTPProviderConfig struct {
 
 // NewSendEmailAction creates a new SendEmail action.
 func NewSendEmailAction(config SendEmailConfig, credService credential.Service) *SendEmailAction {
+	validator := security.NewURLValidator()
 	return &SendEmailAction{
 		config:            config,
 		credentialService: credService,
+		urlValidator:      validator,
+		httpClient:        newAttachmentClient(validator),
+		providerFactory:   createProvider,
 	}
 }
 
-// Execute sends an email using the configured provider.
-// TODO: This action needs integration with the workflow executor context to properly
-// retrieve credentials with tenant/user information. The full implementation is ready
-// but commented out until the execution context integration is complete.
-func (a *SendEmailAction) Execute(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
-	return nil, fmt.Errorf("email sending action requires execution context integration (see TODO in source)")
+// ParseSendEmailConfig parses a node config. Recipient fields accept either a
+// list of addresses or a single string.
+func ParseSendEmailConfig(data []byte) (SendEmailConfig, error) {
+	var raw struct {
+		SendEmailConfig
+		To  recipientList `json:"to"`
+		CC  recipientList `json:"cc,omitempty"`
+		BCC recipientList `json:"bcc,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return SendEmailConfig{}, err
+	}
 
-	/*
-		// Full implementation (to be uncommented after execution context integration):
+	config := raw.SendEmailConfig
+	config.To = raw.To
+	config.CC = raw.CC
+	config.BCC = raw.BCC
+	return config, nil
+}
 
-		// Get tenant and user from execution context
-		tenantID := ctx.Value("tenantID").(string)
-		userID := ctx.Value("userID").(string)
+// recipientList decodes a JSON string or array of strings
+type recipientList []string
 
-		// Get credential
-		cred, err := a.credentialService.GetValue(ctx, tenantID, a.config.CredentialID, userID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get credential: %w", err)
+func (r *recipientList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single != "" {
+			*r = recipientList{single}
 		}
+		return nil
+	}
 
-		// Create provider
-		provider, err := a.createProvider(cred.Value)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create email provider: %w", err)
-		}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("recipients must be a string or a list of strings")
+	}
+	*r = list
+	return nil
+}
 
-		// Build email request
-		request, err := a.buildRequest()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build email request: %w", err)
+// Execute sends an email using the configured provider. The input is the
+// execution context: template expressions in the config are resolved against
+// it, and env.tenant_id selects the tenant whose credential is used.
+func (a *SendEmailAction) Execute(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
+	resolved := *a
+	resolved.config = resolveConfig(a.config, input)
+
+	if err := resolved.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid email configuration: %w", err)
+	}
+
+	tenantValue, err := actions.GetValueByPath(input, "env.tenant_id")
+	tenantID, _ := tenantValue.(string)
+	if err != nil || tenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required in context")
+	}
+
+	credValue, err := resolved.credentialValue(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := resolved.providerFactory(resolved.config, credValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email provider: %w", err)
+	}
+
+	request, err := resolved.buildRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email request: %w", err)
+	}
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid email request: %w", err)
+	}
+
+	response, err := provider.SendEmail(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email via %s: %w", resolved.config.Provider, err)
+	}
+
+	return map[string]interface{}{
+		"success":     true,
+		"provider":    resolved.config.Provider,
+		"message_id":  response.MessageID,
+		"status":      response.Status,
+		"sent_at":     response.SentAt,
+		"recipients":  len(request.To) + len(request.CC) + len(request.BCC),
+		"attachments": len(request.Attachments),
+	}, nil
+}
+
+// credentialValue loads the provider credential, checking its type matches the provider
+func (a *SendEmailAction) credentialValue(ctx context.Context, tenantID string) (map[string]interface{}, error) {
+	if a.credentialService == nil {
+		return nil, fmt.Errorf("credential service not available for email actions")
+	}
+
+	cred, err := a.credentialService.GetByID(ctx, tenantID, a.config.CredentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+	if expected := providerCredentialTypes[a.config.Provider]; cred.Type != expected {
+		return nil, fmt.Errorf("credential type %s cannot be used with provider %s (expected %s)", cred.Type, a.config.Provider, expected)
+	}
+
+	value, err := a.credentialService.GetValue(ctx, tenantID, a.config.CredentialID, "system")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+	return value.Value, nil
+}
+
+// resolveConfig resolves template expressions in the config's text fields.
+// Each recipient entry may expand to a comma-separated list of addresses.
+func resolveConfig(config SendEmailConfig, context map[string]interface{}) SendEmailConfig {
+	interpolate := func(s string) string {
+		return actions.InterpolateString(s, context)
+	}
+
+	config.From = interpolate(config.From)
+	config.To = resolveRecipients(config.To, context)
+	config.CC = resolveRecipients(config.CC, context)
+	config.BCC = resolveRecipients(config.BCC, context)
+	config.Subject = interpolate(config.Subject)
+	config.Body = interpolate(config.Body)
+	config.BodyHTML = interpolate(config.BodyHTML)
+	config.ReplyTo = interpolate(config.ReplyTo)
+
+	if config.Headers != nil {
+		headers := make(map[string]string, len(config.Headers))
+		for key, value := range config.Headers {
+			headers[key] = interpolate(value)
 		}
+		config.Headers = headers
+	}
 
-		// Send email
-		response, err := provider.SendEmail(ctx, request)
-		if err != nil {
-			return map[string]interface{}{
-				"success":    false,
-				"error":      err.Error(),
-				"message_id": "",
-			}, fmt.Errorf("failed to send email: %w", err)
+	if config.Attachments != nil {
+		attachments := make([]AttachmentConfig, len(config.Attachments))
+		for i, att := range config.Attachments {
+			attachments[i] = AttachmentConfig{
+				Filename:    interpolate(att.Filename),
+				Content:     interpolate(att.Content),
+				URL:         interpolate(att.URL),
+				ContentType: interpolate(att.ContentType),
+			}
 		}
+		config.Attachments = attachments
+	}
 
-		return map[string]interface{}{
-			"success":    true,
-			"message_id": response.MessageID,
-			"status":     response.Status,
-			"sent_at":    response.SentAt,
-		}, nil
-	*/
+	return config
+}
+
+func resolveRecipients(recipients []string, context map[string]interface{}) []string {
+	var resolved []string
+	for _, entry := range recipients {
+		for _, address := range strings.Split(actions.InterpolateString(entry, context), ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				resolved = append(resolved, address)
+			}
+		}
+	}
+	return resolved
 }
 
 // createProvider creates an email provider based on the configuration.
-func (a *SendEmailAction) createProvider(credValue map[string]interface{}) (communication.EmailProvider, error) {
-	switch a.config.Provider {
+func createProvider(config SendEmailConfig, credValue map[string]interface{}) (communication.EmailProvider, error) {
+	switch config.Provider {
 	case "sendgrid":
 		apiKey, ok := credValue["api_key"].(string)
 		if !ok {
@@ -132,10 +286,16 @@ func (a *SendEmailAction) createProvider(credValue map[string]interface{}) (comm
 		if !ok {
 			region = "us-east-1" // Default region
 		}
-		return email.NewSESProvider(region)
+		accessKeyID, _ := credValue["access_key_id"].(string)
+		secretAccessKey, _ := credValue["secret_access_key"].(string)
+		if accessKeyID == "" || secretAccessKey == "" {
+			return email.NewSESProvider(region)
+		}
+		sessionToken, _ := credValue["session_token"].(string)
+		return email.NewSESProviderWithCredentials(region, accessKeyID, secretAccessKey, sessionToken)
 
 	case "smtp":
-		if a.config.SMTPConfig == nil {
+		if config.SMTPConfig == nil {
 			return nil, fmt.Errorf("smtp_config is required for SMTP provider")
 		}
 		username, ok := credValue["username"].(string)
@@ -147,20 +307,21 @@ func (a *SendEmailAction) createProvider(credValue map[string]interface{}) (comm
 			return nil, fmt.Errorf("smtp password not found in credential")
 		}
 		return email.NewSMTPProvider(
-			a.config.SMTPConfig.Host,
-			a.config.SMTPConfig.Port,
+			config.SMTPConfig.Host,
+			config.SMTPConfig.Port,
 			username,
 			password,
-			a.config.SMTPConfig.UseTLS,
+			config.SMTPConfig.UseTLS,
 		), nil
 
 	default:
-		return nil, fmt.Errorf("unsupported email provider: %s", a.config.Provider)
+		return nil, fmt.Errorf("unsupported email provider: %s", config.Provider)
 	}
 }
 
-// buildRequest builds an EmailRequest from the action configuration.
-func (a *SendEmailAction) buildRequest() (*communication.EmailRequest, error) {
+// buildRequest builds an EmailRequest from the action configuration,
+// decoding inline attachments and fetching URL attachments.
+func (a *SendEmailAction) buildRequest(ctx context.Context) (*communication.EmailRequest, error) {
 	request := &communication.EmailRequest{
 		From:     a.config.From,
 		To:       a.config.To,
@@ -173,23 +334,143 @@ func (a *SendEmailAction) buildRequest() (*communication.EmailRequest, error) {
 		Headers:  a.config.Headers,
 	}
 
-	// Parse attachments
+	total := 0
 	for _, attConfig := range a.config.Attachments {
-		content, err := base64.StdEncoding.DecodeString(attConfig.Content)
+		attachment, err := a.loadAttachment(ctx, attConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode attachment %s: %w", attConfig.Filename, err)
+			return nil, err
 		}
 
-		request.Attachments = append(request.Attachments, communication.Attachment{
-			Filename:    attConfig.Filename,
-			Content:     content,
-			ContentType: attConfig.ContentType,
-		})
+		total += len(attachment.Content)
+		if total > MaxTotalAttachmentBytes {
+			return nil, fmt.Errorf("attachments exceed the combined maximum of %d bytes", MaxTotalAttachmentBytes)
+		}
+
+		request.Attachments = append(request.Attachments, attachment)
 	}
 
 	return request, nil
 }
 
+// loadAttachment decodes or fetches a single attachment
+func (a *SendEmailAction) loadAttachment(ctx context.Context, attConfig AttachmentConfig) (communication.Attachment, error) {
+	attachment := communication.Attachment{
+		Filename:    attConfig.Filename,
+		ContentType: attConfig.ContentType,
+	}
+
+	switch {
+	case attConfig.Content != "" && attConfig.URL != "":
+		return attachment, fmt.Errorf("attachment %s must set either content or url, not both", attConfig.Filename)
+
+	case attConfig.URL != "":
+		content, contentType, err := a.fetchAttachment(ctx, attConfig.URL)
+		if err != nil {
+			return attachment, fmt.Errorf("failed to fetch attachment %s: %w", attConfig.Filename, err)
+		}
+		attachment.Content = content
+		if attachment.ContentType == "" {
+			attachment.ContentType = contentType
+		}
+		if attachment.Filename == "" {
+			attachment.Filename = path.Base(strings.SplitN(attConfig.URL, "?", 2)[0])
+		}
+
+	default:
+		encoded := strings.Join(strings.Fields(attConfig.Content), "")
+		if base64.StdEncoding.DecodedLen(len(encoded)) > MaxAttachmentBytes+2 {
+			return attachment, fmt.Errorf("attachment %s exceeds the maximum of %d bytes", attConfig.Filename, MaxAttachmentBytes)
+		}
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return attachment, fmt.Errorf("failed to decode attachment %s: %w", attConfig.Filename, err)
+		}
+		attachment.Content = content
+	}
+
+	if len(attachment.Content) > MaxAttachmentBytes {
+		return attachment, fmt.Errorf("attachment %s exceeds the maximum of %d bytes", attachment.Filename, MaxAttachmentBytes)
+	}
+	if attachment.ContentType == "" {
+		attachment.ContentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
+	}
+	if attachment.ContentType == "" && len(attachment.Content) > 0 {
+		attachment.ContentType = http.DetectContentType(attachment.Content)
+	}
+
+	if err := attachment.Validate(); err != nil {
+		return attachment, fmt.Errorf("invalid attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// fetchAttachment downloads an attachment, refusing private addresses and
+// bodies larger than MaxAttachmentBytes
+func (a *SendEmailAction) fetchAttachment(ctx context.Context, url string) ([]byte, string, error) {
+	if a.urlValidator != nil {
+		if err := a.urlValidator.ValidateURL(url); err != nil {
+			return nil, "", fmt.Errorf("SSRF protection: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > MaxAttachmentBytes {
+		return nil, "", fmt.Errorf("size %d exceeds the maximum of %d bytes", resp.ContentLength, MaxAttachmentBytes)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxAttachmentBytes+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(content) > MaxAttachmentBytes {
+		return nil, "", fmt.Errorf("size exceeds the maximum of %d bytes", MaxAttachmentBytes)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType != "application/octet-stream" {
+		return content, mediaType, nil
+	}
+	return content, "", nil
+}
+
+// newAttachmentClient creates the HTTP client used to fetch attachment URLs.
+// Redirect targets must pass the same SSRF checks as the original URL, and
+// connections go through the validator's transport so a checked host cannot
+// be rebound to a blocked address.
+func newAttachmentClient(validator *security.URLValidator) *http.Client {
+	client := &http.Client{
+		Timeout: attachmentFetchTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxAttachmentRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxAttachmentRedirects)
+			}
+			if validator != nil {
+				if err := validator.ValidateURL(req.URL.String()); err != nil {
+					return fmt.Errorf("SSRF protection on redirect: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+	if validator != nil {
+		client.Transport = validator.Transport()
+	}
+	return client
+}
+
 // Name returns the action name.
 func (a *SendEmailAction) Name() string {
 	return "send_email"
@@ -200,6 +481,9 @@ func (a *SendEmailAction) Validate() error {
 	if a.config.Provider == "" {
 		return fmt.Errorf("provider is required")
 	}
+	if _, ok := providerCredentialTypes[a.config.Provider]; !ok {
+		return fmt.Errorf("unsupported email provider: %s", a.config.Provider)
+	}
 	if a.config.From == "" {
 		return fmt.Errorf("from address is required")
 	}
@@ -218,5 +502,10 @@ func (a *SendEmailAction) Validate() error {
 	if a.config.Provider == "smtp" && a.config.SMTPConfig == nil {
 		return fmt.Errorf("smtp_config is required for SMTP provider")
 	}
+	for _, att := range a.config.Attachments {
+		if att.Content == "" && att.URL == "" {
+			return fmt.Errorf("attachment %s requires content or url", att.Filename)
+		}
+	}
 	return nil
 }
@@ -3,12 +3,18 @@ package communication
 import (
 	"context"
 	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/gorax/gorax/internal/communication"
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/security"
 )
 
 // MockCredentialService is a mock for credential.Service
@@ -202,7 +208,7 @@ func TestSendEmailAction_BuildRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			action := NewSendEmailAction(tt.config, nil)
-			request, err := action.buildRequest()
+			request, err := action.buildRequest(context.Background())
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -220,3 +226,195 @@ func TestSendEmailAction_Name(t *testing.T) {
 	action := NewSendEmailAction(SendEmailConfig{}, nil)
 	assert.Equal(t, "send_email", action.Name())
 }
+
+// fakeEmailCredentials serves a single credential of a fixed type
+type fakeEmailCredentials struct {
+	credential.Service
+	credType credential.CredentialType
+	value    map[string]interface{}
+}
+
+func (f *fakeEmailCredentials) GetByID(ctx context.Context, tenantID, credentialID string) (*credential.Credential, error) {
+	return &credential.Credential{ID: credentialID, TenantID: tenantID, Type: f.credType}, nil
+}
+
+func (f *fakeEmailCredentials) GetValue(ctx context.Context, tenantID, credentialID, userID string) (*credential.DecryptedValue, error) {
+	return &credential.DecryptedValue{Value: f.value}, nil
+}
+
+// fakeEmailProvider records the requests it is asked to send
+type fakeEmailProvider struct {
+	requests []*communication.EmailRequest
+	err      error
+}
+
+func (p *fakeEmailProvider) SendEmail(ctx context.Context, request *communication.EmailRequest) (*communication.EmailResponse, error) {
+	p.requests = append(p.requests, request)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &communication.EmailResponse{MessageID: "msg-123", Status: string(communication.MessageStatusSent), SentAt: time.Now()}, nil
+}
+
+func (p *fakeEmailProvider) SendBulkEmail(ctx context.Context, requests []*communication.EmailRequest) ([]*communication.EmailResponse, error) {
+	return nil, nil
+}
+
+func newTestEmailAction(config SendEmailConfig, provider *fakeEmailProvider) *SendEmailAction {
+	action := NewSendEmailAction(config, &fakeEmailCredentials{
+		credType: credential.TypeEmailSendGrid,
+		value:    map[string]interface{}{"api_key": "SG.test"},
+	})
+	action.providerFactory = func(SendEmailConfig, map[string]interface{}) (communication.EmailProvider, error) {
+		return provider, nil
+	}
+	return action
+}
+
+func emailTestContext() map[string]interface{} {
+	return map[string]interface{}{
+		"env":     map[string]interface{}{"tenant_id": "tenant-1"},
+		"trigger": map[string]interface{}{"email": "ada@example.com", "name": "Ada"},
+	}
+}
+
+func TestParseSendEmailConfig(t *testing.T) {
+	config, err := ParseSendEmailConfig([]byte(`{
+		"provider": "sendgrid",
+		"to": "${trigger.email}",
+		"cc": ["a@example.com", "b@example.com"],
+		"subject": "Hi"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"${trigger.email}"}, config.To)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, config.CC)
+	assert.Empty(t, config.BCC)
+
+	_, err = ParseSendEmailConfig([]byte(`{"to": 42}`))
+	assert.Error(t, err)
+}
+
+func TestSendEmailAction_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/report.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.4 report"))
+		case "/large.bin":
+			_, _ = w.Write(make([]byte, MaxAttachmentBytes+1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	baseConfig := func() SendEmailConfig {
+		return SendEmailConfig{
+			Provider:     "sendgrid",
+			From:         "Reports <reports@example.com>",
+			To:           []string{"${trigger.email}, ops@example.com"},
+			BCC:          []string{"audit@example.com"},
+			Subject:      "Hello ${trigger.name}",
+			Body:         "Plain body",
+			BodyHTML:     "<p>HTML body</p>",
+			CredentialID: "cred-1",
+		}
+	}
+
+	t.Run("sends with url and inline attachments", func(t *testing.T) {
+		config := baseConfig()
+		config.Attachments = []AttachmentConfig{
+			{Filename: "report.pdf", URL: server.URL + "/report.pdf"},
+			{Filename: "notes.txt", Content: base64.StdEncoding.EncodeToString([]byte("notes"))},
+		}
+		provider := &fakeEmailProvider{}
+		action := newTestEmailAction(config, provider)
+		action.urlValidator = nil
+		action.httpClient = server.Client()
+
+		output, err := action.Execute(context.Background(), emailTestContext())
+		require.NoError(t, err)
+		assert.Equal(t, "msg-123", output["message_id"])
+		assert.Equal(t, 3, output["recipients"])
+		assert.Equal(t, 2, output["attachments"])
+
+		require.Len(t, provider.requests, 1)
+		request := provider.requests[0]
+		assert.Equal(t, []string{"ada@example.com", "ops@example.com"}, request.To)
+		assert.Equal(t, "Hello Ada", request.Subject)
+		assert.Equal(t, "<p>HTML body</p>", request.BodyHTML)
+		require.Len(t, request.Attachments, 2)
+		assert.Equal(t, "application/pdf", request.Attachments[0].ContentType)
+		assert.Equal(t, []byte("%PDF-1.4 report"), request.Attachments[0].Content)
+		assert.Equal(t, "text/plain; charset=utf-8", request.Attachments[1].ContentType)
+	})
+
+	t.Run("attachment url over the size limit", func(t *testing.T) {
+		config := baseConfig()
+		config.Attachments = []AttachmentConfig{{Filename: "large.bin", URL: server.URL + "/large.bin"}}
+		provider := &fakeEmailProvider{}
+		action := newTestEmailAction(config, provider)
+		action.urlValidator = nil
+		action.httpClient = server.Client()
+
+		_, err := action.Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds the maximum")
+		assert.Empty(t, provider.requests)
+	})
+
+	t.Run("attachment url to a private address is blocked", func(t *testing.T) {
+		config := baseConfig()
+		config.Attachments = []AttachmentConfig{{Filename: "report.pdf", URL: server.URL + "/report.pdf"}}
+		provider := &fakeEmailProvider{}
+		action := newTestEmailAction(config, provider)
+
+		_, err := action.Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SSRF protection")
+	})
+
+	t.Run("attachment client blocks private addresses when dialing", func(t *testing.T) {
+		config := baseConfig()
+		config.Attachments = []AttachmentConfig{{Filename: "report.pdf", URL: server.URL + "/report.pdf"}}
+		provider := &fakeEmailProvider{}
+		action := newTestEmailAction(config, provider)
+		// Skip the up-front URL check, as if the host had been rebound after it
+		action.urlValidator = nil
+
+		_, err := action.Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, security.ErrBlockedDestination)
+		assert.Empty(t, provider.requests)
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		config := baseConfig()
+		config.To = []string{"not-an-address"}
+		provider := &fakeEmailProvider{}
+
+		_, err := newTestEmailAction(config, provider).Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid to address: "not-an-address" is not a valid email address`)
+		assert.Empty(t, provider.requests)
+	})
+
+	t.Run("provider rejection", func(t *testing.T) {
+		provider := &fakeEmailProvider{err: &communication.RejectedError{Provider: "sendgrid", Reason: "status 403: sender not verified"}}
+
+		_, err := newTestEmailAction(baseConfig(), provider).Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		var rejected *communication.RejectedError
+		assert.ErrorAs(t, err, &rejected)
+		assert.Contains(t, err.Error(), "sendgrid rejected the message: status 403: sender not verified")
+	})
+
+	t.Run("credential type must match provider", func(t *testing.T) {
+		action := newTestEmailAction(baseConfig(), &fakeEmailProvider{})
+		action.credentialService = &fakeEmailCredentials{credType: credential.TypeEmailSMTP}
+
+		_, err := action.Execute(context.Background(), emailTestContext())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used with provider sendgrid")
+	})
+}
@@ -1,21 +1,55 @@
 package actions
 
 import (
-	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gorax/gorax/internal/security"
+	"github.com/gorax/gorax/internal/tracing"
 )
 
+const (
+	// defaultHTTPTimeout is used when a node does not set a timeout
+	defaultHTTPTimeout = 30 * time.Second
+	// defaultMaxRedirects is used when a node does not set max_redirects
+	defaultMaxRedirects = 10
+	// DefaultMaxResponseBytes is used when neither the node nor HTTPOptions set a body limit
+	DefaultMaxResponseBytes int64 = 10 * 1024 * 1024
+	// DefaultMaxResponseHeaders is used when HTTPOptions does not set a header limit
+	DefaultMaxResponseHeaders = 100
+)
+
+// HTTPOptions holds process-wide settings that constrain what HTTP action nodes may do
+type HTTPOptions struct {
+	// AllowInsecureTLS permits nodes to set tls_skip_verify. Must be false in production.
+	AllowInsecureTLS bool
+	// MaxResponseBytes is the body size limit for nodes without max_response_bytes
+	// and the ceiling for nodes with it (default: DefaultMaxResponseBytes)
+	MaxResponseBytes int64
+	// MaxResponseHeaders caps the number of response headers stored in the step result
+	// (default: DefaultMaxResponseHeaders)
+	MaxResponseHeaders int
+	// URLValidator enforces the SSRF allow and deny lists on every request
+	// (default: security.NewURLValidator())
+	URLValidator *security.URLValidator
+}
+
+// defaultURLValidator is shared by requests without HTTPOptions.URLValidator
+// so its transport's connections are reused
+var defaultURLValidator = security.NewURLValidator()
+
 // HTTPAction implements the Action interface for HTTP requests
 type HTTPAction struct {
 	urlValidator *security.URLValidator
+	options      HTTPOptions
 }
 
 // NewHTTPAction creates a new HTTP action with default URL validator
@@ -32,15 +66,59 @@ func NewHTTPActionWithValidator(validator *security.URLValidator) *HTTPAction {
 	}
 }
 
+// NewHTTPActionWithOptions creates a new HTTP action with a custom URL validator and options
+func NewHTTPActionWithOptions(validator *security.URLValidator, options HTTPOptions) *HTTPAction {
+	return &HTTPAction{
+		urlValidator: validator,
+		options:      options,
+	}
+}
+
 // HTTPActionConfig represents the configuration for an HTTP action
 type HTTPActionConfig struct {
-	Method          string            `json:"method"`
-	URL             string            `json:"url"`
-	Headers         map[string]string `json:"headers,omitempty"`
-	Body            json.RawMessage   `json:"body,omitempty"`
-	Timeout         int               `json:"timeout,omitempty"`          // seconds
-	Auth            *HTTPAuth         `json:"auth,omitempty"`             // authentication config
-	FollowRedirects bool              `json:"follow_redirects,omitempty"` // default: true
+	Method           string            `json:"method"`
+	URL              string            `json:"url"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	Body             json.RawMessage   `json:"body,omitempty"`
+	ContentType      string            `json:"content_type,omitempty"`       // request body encoding, default: application/json
+	Timeout          int               `json:"timeout,omitempty"`            // seconds, default: 30
+	Auth             *HTTPAuth         `json:"auth,omitempty"`               // authentication config
+	FollowRedirects  *bool             `json:"follow_redirects,omitempty"`   // default: true
+	MaxRedirects     int               `json:"max_redirects,omitempty"`      // default: 10
+	TLSSkipVerify    bool              `json:"tls_skip_verify,omitempty"`    // only honored when HTTPOptions.AllowInsecureTLS
+	MaxResponseBytes int64             `json:"max_response_bytes,omitempty"` // default and ceiling: HTTPOptions.MaxResponseBytes
+}
+
+// HTTPTimeoutError indicates an HTTP request exceeded its timeout.
+// It is distinct from other request failures so retry policies can target it.
+type HTTPTimeoutError struct {
+	Duration time.Duration
+	Err      error
+}
+
+// Error implements the error interface
+func (e *HTTPTimeoutError) Error() string {
+	return fmt.Sprintf("request timed out after %s: %v", e.Duration, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *HTTPTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports that this error was caused by a timeout
+func (e *HTTPTimeoutError) Timeout() bool {
+	return true
+}
+
+// HTTPResponseTooLargeError indicates a response body exceeded max_response_bytes
+type HTTPResponseTooLargeError struct {
+	Limit int64
+}
+
+// Error implements the error interface
+func (e *HTTPResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds max_response_bytes limit of %d bytes", e.Limit)
 }
 
 // HTTPAuth represents HTTP authentication configuration
@@ -55,9 +133,10 @@ type HTTPAuth struct {
 
 // HTTPActionResult represents the result of an HTTP action
 type HTTPActionResult struct {
-	StatusCode int               `json:"status_code"`
-	Headers    map[string]string `json:"headers"`
-	Body       interface{}       `json:"body"`
+	StatusCode       int               `json:"status_code"`
+	Headers          map[string]string `json:"headers"`
+	HeadersTruncated bool              `json:"headers_truncated,omitempty"`
+	Body             interface{}       `json:"body"`
 }
 
 // Execute implements the Action interface
@@ -93,8 +172,7 @@ func (a *HTTPAction) executeHTTP(ctx context.Context, config HTTPActionConfig, e
 		return nil, fmt.Errorf("invalid HTTP method: %s", method)
 	}
 
-	// Default timeout
-	timeout := 30 * time.Second
+	timeout := defaultHTTPTimeout
 	if config.Timeout > 0 {
 		timeout = time.Duration(config.Timeout) * time.Second
 	}
@@ -103,18 +181,9 @@ func (a *HTTPAction) executeHTTP(ctx context.Context, config HTTPActionConfig, e
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Configure HTTP client
-	client := &http.Client{
-		Timeout: timeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if !config.FollowRedirects && len(via) > 0 {
-				return http.ErrUseLastResponse
-			}
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
+	client, err := a.newClient(config, timeout)
+	if err != nil {
+		return nil, err
 	}
 
 	// Interpolate URL
@@ -131,14 +200,13 @@ func (a *HTTPAction) executeHTTP(ctx context.Context, config HTTPActionConfig, e
 	}
 
 	// Prepare request body
+	body, err := a.encodeRequestBody(timeoutCtx, client, config, execContext)
+	if err != nil {
+		return nil, err
+	}
 	var bodyReader io.Reader
-	if config.Body != nil && len(config.Body) > 0 {
-		interpolatedBody := InterpolateJSON(config.Body, execContext)
-		bodyBytes, err := json.Marshal(interpolatedBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
-		}
-		bodyReader = bytes.NewReader(bodyBytes)
+	if body != nil {
+		bodyReader = body.reader
 	}
 
 	// Create request
@@ -147,59 +215,167 @@ func (a *HTTPAction) executeHTTP(ctx context.Context, config HTTPActionConfig, e
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set default content type for requests with body
-	if bodyReader != nil {
-		req.Header.Set("Content-Type", "application/json")
+	// Set the body's content type; a custom header may override it, except
+	// for multipart bodies whose boundary must match the one written
+	if body != nil {
+		req.Header.Set("Content-Type", body.contentType)
 	}
 
 	// Set custom headers
 	for key, value := range config.Headers {
 		req.Header.Set(key, InterpolateString(value, execContext))
 	}
+	if body != nil && strings.HasPrefix(body.contentType, ContentTypeMultipart) {
+		req.Header.Set("Content-Type", body.contentType)
+	}
 
 	// Apply authentication
 	if err := a.applyAuth(req, config.Auth, execContext); err != nil {
 		return nil, fmt.Errorf("failed to apply authentication: %w", err)
 	}
 
+	tracing.AddHTTPRequestHeaders(ctx, req.Header)
+
 	// Execute request
 	resp, err := client.Do(req)
 	if err != nil {
+		if errors.Is(err, security.ErrBlockedDestination) {
+			return nil, fmt.Errorf("SSRF protection: %w", err)
+		}
+		if isTimeoutError(err) {
+			return nil, &HTTPTimeoutError{Duration: timeout, Err: err}
+		}
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	// Read response body, refusing to buffer more than the configured limit
+	maxBytes := a.maxResponseBytes(config)
+	if resp.ContentLength > maxBytes {
+		return nil, &HTTPResponseTooLargeError{Limit: maxBytes}
+	}
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
 	if err != nil {
+		if isTimeoutError(err) {
+			return nil, &HTTPTimeoutError{Duration: timeout, Err: err}
+		}
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	if int64(len(respBody)) > maxBytes {
+		return nil, &HTTPResponseTooLargeError{Limit: maxBytes}
+	}
 
 	// Parse response body
-	var parsedBody interface{}
-	contentType := resp.Header.Get("Content-Type")
-	if strings.Contains(contentType, "application/json") {
-		if err := json.Unmarshal(respBody, &parsedBody); err != nil {
-			// If JSON parsing fails, use raw string
-			parsedBody = string(respBody)
-		}
-	} else {
-		parsedBody = string(respBody)
-	}
+	parsedBody := parseResponseBody(resp.Header.Get("Content-Type"), respBody)
 
-	// Build response headers map
-	respHeaders := make(map[string]string)
-	for key := range resp.Header {
-		respHeaders[key] = resp.Header.Get(key)
-	}
+	respHeaders, truncated := a.collectHeaders(resp.Header)
 
 	return &HTTPActionResult{
-		StatusCode: resp.StatusCode,
-		Headers:    respHeaders,
-		Body:       parsedBody,
+		StatusCode:       resp.StatusCode,
+		Headers:          respHeaders,
+		HeadersTruncated: truncated,
+		Body:             parsedBody,
 	}, nil
 }
 
+// maxResponseBytes returns the body size limit for a node. The node's
+// max_response_bytes can only lower HTTPOptions.MaxResponseBytes.
+func (a *HTTPAction) maxResponseBytes(config HTTPActionConfig) int64 {
+	limit := a.options.MaxResponseBytes
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	if config.MaxResponseBytes > 0 {
+		return min(config.MaxResponseBytes, limit)
+	}
+	return limit
+}
+
+// collectHeaders builds the response headers map, keeping at most
+// MaxResponseHeaders entries in sorted key order
+func (a *HTTPAction) collectHeaders(header http.Header) (map[string]string, bool) {
+	limit := a.options.MaxResponseHeaders
+	if limit <= 0 {
+		limit = DefaultMaxResponseHeaders
+	}
+
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	truncated := len(keys) > limit
+	if truncated {
+		keys = keys[:limit]
+	}
+
+	headers := make(map[string]string, len(keys))
+	for _, key := range keys {
+		headers[key] = header.Get(key)
+	}
+	return headers, truncated
+}
+
+// newClient builds an HTTP client honoring the node's timeout, redirect and TLS settings
+func (a *HTTPAction) newClient(config HTTPActionConfig, timeout time.Duration) (*http.Client, error) {
+	followRedirects := config.FollowRedirects == nil || *config.FollowRedirects
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !followRedirects {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			// Redirect targets must pass the same SSRF checks as the original URL
+			if a.urlValidator != nil {
+				if err := a.urlValidator.ValidateURL(req.URL.String()); err != nil {
+					return fmt.Errorf("SSRF protection on redirect: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	base := http.DefaultTransport.(*http.Transport)
+	if a.urlValidator != nil {
+		// Checks the address actually dialed, so DNS rebinding cannot reach
+		// a blocked address after the URL passed validation
+		base = a.urlValidator.Transport()
+	}
+
+	var transport http.RoundTripper = base
+	if config.TLSSkipVerify {
+		if !a.options.AllowInsecureTLS {
+			return nil, fmt.Errorf("tls_skip_verify is not allowed in this environment")
+		}
+		insecure := base.Clone()
+		// #nosec G402 -- explicitly opted into per node and disabled in production config
+		insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		transport = insecure
+	}
+	// Propagate the execution's trace context so downstream services join the trace
+	client.Transport = tracing.HTTPClientMiddleware(transport)
+
+	return client, nil
+}
+
+// isTimeoutError reports whether err was caused by a client or context timeout
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
+
 // applyAuth applies authentication to the request
 func (a *HTTPAction) applyAuth(req *http.Request, auth *HTTPAuth, context map[string]interface{}) error {
 	if auth == nil {
@@ -254,7 +430,17 @@ func isValidHTTPMethod(method string) bool {
 
 // Legacy function for backward compatibility
 func ExecuteHTTP(ctx context.Context, config HTTPActionConfig, context map[string]interface{}) (*HTTPActionResult, error) {
-	action := NewHTTPAction()
+	return ExecuteHTTPWithOptions(ctx, config, context, HTTPOptions{})
+}
+
+// ExecuteHTTPWithOptions executes an HTTP action with the given options,
+// validating URLs with options.URLValidator or the default validator
+func ExecuteHTTPWithOptions(ctx context.Context, config HTTPActionConfig, context map[string]interface{}, options HTTPOptions) (*HTTPActionResult, error) {
+	validator := options.URLValidator
+	if validator == nil {
+		validator = defaultURLValidator
+	}
+	action := NewHTTPActionWithOptions(validator, options)
 	input := NewActionInput(config, context)
 	output, err := action.Execute(ctx, input)
 	if err != nil {
@@ -0,0 +1,310 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Request body content types supported by action:http nodes
+const (
+	ContentTypeJSON      = "application/json"
+	ContentTypeForm      = "application/x-www-form-urlencoded"
+	ContentTypeMultipart = "multipart/form-data"
+)
+
+// requestBody is an encoded request body and the Content-Type it is sent with
+type requestBody struct {
+	reader      io.Reader
+	contentType string
+}
+
+// encodeRequestBody interpolates the node's body and serializes it for its
+// content_type. Multipart file parts given a url are downloaded with client.
+func (a *HTTPAction) encodeRequestBody(ctx context.Context, client *http.Client, config HTTPActionConfig, execContext map[string]interface{}) (*requestBody, error) {
+	contentType := config.ContentType
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+	if !IsValidHTTPContentType(contentType) {
+		return nil, fmt.Errorf("unsupported content_type: %s", contentType)
+	}
+	if len(config.Body) == 0 {
+		return nil, nil
+	}
+
+	body := InterpolateJSON(config.Body, execContext)
+
+	if contentType == ContentTypeJSON {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		return &requestBody{reader: bytes.NewReader(bodyBytes), contentType: ContentTypeJSON}, nil
+	}
+
+	fields, ok := body.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("body must be an object for content_type %s", contentType)
+	}
+
+	if contentType == ContentTypeForm {
+		form, err := encodeFormBody(fields)
+		if err != nil {
+			return nil, err
+		}
+		return &requestBody{reader: strings.NewReader(form), contentType: ContentTypeForm}, nil
+	}
+
+	return a.encodeMultipartBody(ctx, client, config, fields)
+}
+
+// IsValidHTTPContentType reports whether an action:http node can send a body
+// with the content type
+func IsValidHTTPContentType(contentType string) bool {
+	switch contentType {
+	case ContentTypeJSON, ContentTypeForm, ContentTypeMultipart:
+		return true
+	}
+	return false
+}
+
+// encodeFormBody URL-encodes the body's fields in key order. Lists repeat the
+// field once per item; nulls are left out.
+func encodeFormBody(fields map[string]interface{}) (string, error) {
+	values := url.Values{}
+	for key, value := range fields {
+		items, err := formFieldValues(key, value)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range items {
+			values.Add(key, item)
+		}
+	}
+	return values.Encode(), nil
+}
+
+// formFieldValues returns the string values of a form field, which must be a
+// scalar or a list of scalars
+func formFieldValues(key string, value interface{}) ([]string, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		list = []interface{}{value}
+	}
+
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if item == nil {
+			continue
+		}
+		s, ok := formScalar(item)
+		if !ok {
+			return nil, fmt.Errorf("form field %q must be a scalar or a list of scalars", key)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+// formScalar formats a string, number or boolean as a form value
+func formScalar(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// multipartFile is a file part of a multipart body. Its data is content,
+// decoded when encoding is base64, or downloaded from url.
+type multipartFile struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Encoding    string `json:"encoding,omitempty"` // "" or "base64"
+	URL         string `json:"url,omitempty"`
+}
+
+// encodeMultipartBody writes the body's fields as a multipart form in key
+// order. Objects are file parts; other fields follow the form encoding rules.
+func (a *HTTPAction) encodeMultipartBody(ctx context.Context, client *http.Client, config HTTPActionConfig, fields map[string]interface{}) (*requestBody, error) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, key := range keys {
+		if object, ok := fields[key].(map[string]interface{}); ok {
+			if err := a.writeMultipartFile(ctx, client, config, writer, key, object); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		values, err := formFieldValues(key, fields[key])
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, fmt.Errorf("failed to write form field %q: %w", key, err)
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	return &requestBody{reader: &buf, contentType: writer.FormDataContentType()}, nil
+}
+
+// writeMultipartFile writes a file part described by object
+func (a *HTTPAction) writeMultipartFile(ctx context.Context, client *http.Client, config HTTPActionConfig, writer *multipart.Writer, key string, object map[string]interface{}) error {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("invalid file field %q: %w", key, err)
+	}
+	var file multipartFile
+	if err := json.Unmarshal(encoded, &file); err != nil {
+		return fmt.Errorf("invalid file field %q: %w", key, err)
+	}
+
+	var data []byte
+	switch {
+	case file.URL != "" && file.Content != "":
+		return fmt.Errorf("file field %q must set content or url, not both", key)
+	case file.URL != "":
+		var contentType string
+		data, contentType, err = a.downloadFile(ctx, client, config, file.URL)
+		if err != nil {
+			return fmt.Errorf("file field %q: %w", key, err)
+		}
+		if file.ContentType == "" {
+			file.ContentType = contentType
+		}
+		if file.Filename == "" {
+			file.Filename = filenameFromURL(file.URL)
+		}
+	case file.Encoding == "base64":
+		data, err = base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return fmt.Errorf("file field %q: invalid base64 content: %w", key, err)
+		}
+	case file.Encoding == "":
+		data = []byte(file.Content)
+	default:
+		return fmt.Errorf("file field %q: unsupported encoding %q", key, file.Encoding)
+	}
+
+	if file.Filename == "" {
+		return fmt.Errorf("file field %q requires a filename", key)
+	}
+	if file.ContentType == "" {
+		file.ContentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     key,
+		"filename": file.Filename,
+	}))
+	header.Set("Content-Type", file.ContentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create file part %q: %w", key, err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("failed to write file part %q: %w", key, err)
+	}
+	return nil
+}
+
+// filenameFromURL returns the last segment of a URL's path, or "" when it
+// has none
+func filenameFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(parsed.Path)
+	if name == "/" || name == "." {
+		return ""
+	}
+	return name
+}
+
+// downloadFile fetches a multipart file part's url, subject to the same SSRF
+// checks and size limit as the node's response
+func (a *HTTPAction) downloadFile(ctx context.Context, client *http.Client, config HTTPActionConfig, fileURL string) ([]byte, string, error) {
+	if a.urlValidator != nil {
+		if err := a.urlValidator.ValidateURL(fileURL); err != nil {
+			return nil, "", fmt.Errorf("SSRF protection: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	maxBytes := a.maxResponseBytes(config)
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read download: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, "", fmt.Errorf("download exceeds max_response_bytes limit of %d bytes", maxBytes)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// parseResponseBody decodes JSON responses, including +json media types such
+// as application/problem+json, and returns any other body as a string
+func parseResponseBody(contentType string, body []byte) interface{} {
+	// ParseMediaType still returns the media type when only a parameter is malformed
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != ContentTypeJSON && !strings.HasSuffix(mediaType, "+json") {
+		return string(body)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		// If JSON parsing fails, use raw string
+		return string(body)
+	}
+	return parsed
+}
@@ -3,12 +3,23 @@ package actions
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
 	"github.com/gorax/gorax/internal/security"
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 // newTestHTTPAction creates an HTTP action with SSRF protection disabled for testing
@@ -675,6 +686,51 @@ func TestHTTPAction_SSRFProtection_WithInterpolation(t *testing.T) {
 	}
 }
 
+func TestHTTPAction_SSRFProtection_AllowedNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	action := NewHTTPActionWithValidator(security.NewURLValidatorWithConfig(&security.URLValidatorConfig{
+		Enabled:         true,
+		AllowedNetworks: []string{"127.0.0.0/8"},
+	}))
+	config := HTTPActionConfig{
+		Method: "GET",
+		URL:    server.URL,
+	}
+
+	output, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err != nil {
+		t.Fatalf("Expected allowed network to be reachable, got error: %v", err)
+	}
+
+	result := output.Data.(*HTTPActionResult)
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", result.StatusCode, http.StatusOK)
+	}
+}
+
+func TestHTTPAction_SSRFProtection_BlockedHost(t *testing.T) {
+	action := NewHTTPActionWithValidator(security.NewURLValidatorWithConfig(&security.URLValidatorConfig{
+		Enabled:      true,
+		BlockedHosts: []string{"*.example.com"},
+	}))
+	config := HTTPActionConfig{
+		Method: "GET",
+		URL:    "https://api.example.com/data",
+	}
+
+	_, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil {
+		t.Fatal("Expected SSRF protection to block a host on the blocked host list")
+	}
+	if !errors.Is(err, security.ErrBlockedDestination) {
+		t.Errorf("Expected ErrBlockedDestination, got: %v", err)
+	}
+}
+
 // Helper function for string containment
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || indexOf(s, substr) >= 0)
@@ -688,3 +744,502 @@ func indexOf(s, substr string) int {
 	}
 	return -1
 }
+
+func TestHTTPAction_Execute_TimeoutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(1500 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	action := newTestHTTPAction()
+	config := HTTPActionConfig{
+		Method:  "GET",
+		URL:     server.URL,
+		Timeout: 1,
+	}
+
+	_, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil {
+		t.Fatal("Expected timeout error, got nil")
+	}
+
+	var timeoutErr *HTTPTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected HTTPTimeoutError, got %T: %v", err, err)
+	}
+	if timeoutErr.Duration != time.Second {
+		t.Errorf("Duration = %v, want 1s", timeoutErr.Duration)
+	}
+	if !timeoutErr.Timeout() {
+		t.Error("Timeout() = false, want true")
+	}
+}
+
+func TestHTTPAction_Execute_Redirects(t *testing.T) {
+	var target *httptest.Server
+	target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/final":
+			w.WriteHeader(http.StatusOK)
+		case "/loop":
+			http.Redirect(w, r, target.URL+"/loop", http.StatusFound)
+		default:
+			http.Redirect(w, r, target.URL+"/final", http.StatusFound)
+		}
+	}))
+	defer target.Close()
+
+	action := newTestHTTPAction()
+	noFollow := false
+
+	tests := []struct {
+		name       string
+		config     HTTPActionConfig
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "follows redirects by default",
+			config:     HTTPActionConfig{Method: "GET", URL: target.URL + "/start"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "returns redirect when follow_redirects is false",
+			config:     HTTPActionConfig{Method: "GET", URL: target.URL + "/start", FollowRedirects: &noFollow},
+			wantStatus: http.StatusFound,
+		},
+		{
+			name:    "stops after max_redirects",
+			config:  HTTPActionConfig{Method: "GET", URL: target.URL + "/loop", MaxRedirects: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := action.Execute(context.Background(), NewActionInput(tt.config, nil))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			result := output.Data.(*HTTPActionResult)
+			if result.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %d, want %d", result.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestHTTPAction_Execute_TLSSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := security.NewURLValidatorWithConfig(&security.URLValidatorConfig{Enabled: false})
+	config := HTTPActionConfig{Method: "GET", URL: server.URL, TLSSkipVerify: true}
+
+	// Refused unless explicitly allowed
+	_, err := NewHTTPActionWithValidator(validator).Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil {
+		t.Fatal("Expected tls_skip_verify to be refused")
+	}
+
+	// Allowed when options permit it
+	action := NewHTTPActionWithOptions(validator, HTTPOptions{AllowInsecureTLS: true})
+	output, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if output.Data.(*HTTPActionResult).StatusCode != http.StatusOK {
+		t.Error("Expected 200 OK with tls_skip_verify")
+	}
+}
+
+func TestHTTPAction_Execute_MaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flush before writing the body so no Content-Length is sent
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer server.Close()
+
+	validator := security.NewURLValidatorWithConfig(&security.URLValidatorConfig{Enabled: false})
+
+	tests := []struct {
+		name      string
+		options   HTTPOptions
+		config    HTTPActionConfig
+		wantLimit int64
+	}{
+		{
+			name:      "node limit with content length",
+			config:    HTTPActionConfig{Method: "GET", URL: server.URL, MaxResponseBytes: 1024},
+			wantLimit: 1024,
+		},
+		{
+			name:      "node limit with streamed body",
+			config:    HTTPActionConfig{Method: "GET", URL: server.URL + "/chunked", MaxResponseBytes: 1024},
+			wantLimit: 1024,
+		},
+		{
+			name:      "global default",
+			options:   HTTPOptions{MaxResponseBytes: 512},
+			config:    HTTPActionConfig{Method: "GET", URL: server.URL + "/chunked"},
+			wantLimit: 512,
+		},
+		{
+			name:      "node limit above global ceiling",
+			options:   HTTPOptions{MaxResponseBytes: 512},
+			config:    HTTPActionConfig{Method: "GET", URL: server.URL, MaxResponseBytes: 4096},
+			wantLimit: 512,
+		},
+		{
+			name:    "within limit",
+			options: HTTPOptions{MaxResponseBytes: 4096},
+			config:  HTTPActionConfig{Method: "GET", URL: server.URL, MaxResponseBytes: 4096},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewHTTPActionWithOptions(validator, tt.options)
+			output, err := action.Execute(context.Background(), NewActionInput(tt.config, nil))
+
+			if tt.wantLimit == 0 {
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				if body := output.Data.(*HTTPActionResult).Body.(string); len(body) != 2048 {
+					t.Errorf("body length = %d, want 2048", len(body))
+				}
+				return
+			}
+
+			var tooLarge *HTTPResponseTooLargeError
+			if !errors.As(err, &tooLarge) {
+				t.Fatalf("Expected HTTPResponseTooLargeError, got %T: %v", err, err)
+			}
+			if tooLarge.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", tooLarge.Limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestHTTPAction_Execute_MaxResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 10; i++ {
+			w.Header().Set(fmt.Sprintf("X-Custom-%02d", i), "value")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := security.NewURLValidatorWithConfig(&security.URLValidatorConfig{Enabled: false})
+	action := NewHTTPActionWithOptions(validator, HTTPOptions{MaxResponseHeaders: 3})
+
+	output, err := action.Execute(context.Background(), NewActionInput(HTTPActionConfig{Method: "GET", URL: server.URL}, nil))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	result := output.Data.(*HTTPActionResult)
+	if len(result.Headers) != 3 {
+		t.Errorf("len(Headers) = %d, want 3", len(result.Headers))
+	}
+	if !result.HeadersTruncated {
+		t.Error("HeadersTruncated = false, want true")
+	}
+}
+
+func TestHTTPAction_Execute_PropagatesTraceContext(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		_ = provider.Shutdown(context.Background())
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "http.action")
+	action := newTestHTTPAction()
+	config := HTTPActionConfig{
+		Method:  "GET",
+		URL:     server.URL,
+		Headers: map[string]string{"X-Request-Source": "gorax"},
+		Auth:    &HTTPAuth{Type: "bearer", Token: "secret-token"},
+	}
+
+	_, err := action.Execute(ctx, NewActionInput(config, nil))
+	span.End()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	traceID := span.SpanContext().TraceID().String()
+	if !strings.Contains(traceparent, traceID) {
+		t.Errorf("traceparent = %q, want trace ID %s", traceparent, traceID)
+	}
+
+	var actionSpan *tracetest.SpanStub
+	spans := exporter.GetSpans()
+	for i := range spans {
+		if spans[i].Name == "http.action" {
+			actionSpan = &spans[i]
+		}
+	}
+	if actionSpan == nil {
+		t.Fatal("http.action span not exported")
+	}
+
+	headers := make(map[string][]string)
+	for _, attr := range actionSpan.Attributes {
+		headers[string(attr.Key)] = attr.Value.AsStringSlice()
+	}
+	if got := headers["http.request.header.authorization"]; len(got) != 1 || got[0] != tracing.RedactedValue {
+		t.Errorf("authorization attribute = %v, want redacted", got)
+	}
+	if got := headers["http.request.header.x-request-source"]; len(got) != 1 || got[0] != "gorax" {
+		t.Errorf("x-request-source attribute = %v, want gorax", got)
+	}
+}
+
+func TestHTTPAction_Execute_FormBody(t *testing.T) {
+	var gotContentType string
+	var gotForm map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		gotForm = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"tok"}`))
+	}))
+	defer server.Close()
+
+	config := HTTPActionConfig{
+		Method:      "POST",
+		URL:         server.URL,
+		ContentType: ContentTypeForm,
+		Body:        json.RawMessage(`{"grant_type":"refresh_token","refresh_token":"${trigger.token}","scope":["read","write"],"expires_in":3600,"skip":null}`),
+	}
+	execContext := map[string]interface{}{"trigger": map[string]interface{}{"token": "abc&def"}}
+
+	output, err := newTestHTTPAction().Execute(context.Background(), NewActionInput(config, execContext))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if gotContentType != ContentTypeForm {
+		t.Errorf("Content-Type = %q, want %q", gotContentType, ContentTypeForm)
+	}
+	if got := gotForm["refresh_token"]; len(got) != 1 || got[0] != "abc&def" {
+		t.Errorf("refresh_token = %v, want [abc&def]", got)
+	}
+	if got := gotForm["scope"]; len(got) != 2 || got[0] != "read" || got[1] != "write" {
+		t.Errorf("scope = %v, want [read write]", got)
+	}
+	if got := gotForm["expires_in"]; len(got) != 1 || got[0] != "3600" {
+		t.Errorf("expires_in = %v, want [3600]", got)
+	}
+	if _, ok := gotForm["skip"]; ok {
+		t.Error("null fields should be left out")
+	}
+
+	body, ok := output.Data.(*HTTPActionResult).Body.(map[string]interface{})
+	if !ok || body["access_token"] != "tok" {
+		t.Errorf("Body = %v, want parsed JSON", output.Data.(*HTTPActionResult).Body)
+	}
+}
+
+func TestHTTPAction_Execute_FormBodyRejectsNestedObjects(t *testing.T) {
+	config := HTTPActionConfig{
+		Method:      "POST",
+		URL:         "http://example.com",
+		ContentType: ContentTypeForm,
+		Body:        json.RawMessage(`{"user":{"name":"a"}}`),
+	}
+
+	_, err := newTestHTTPAction().Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil || !strings.Contains(err.Error(), `form field "user"`) {
+		t.Errorf("Execute() error = %v, want form field error", err)
+	}
+}
+
+func TestHTTPAction_Execute_MultipartBody(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	}))
+	defer files.Close()
+
+	type part struct {
+		filename    string
+		contentType string
+		data        string
+	}
+	parts := make(map[string]part)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("MultipartReader() error = %v", err)
+			return
+		}
+		for {
+			p, err := reader.NextPart()
+			if err != nil {
+				break
+			}
+			data := new(strings.Builder)
+			io.Copy(data, p)
+			parts[p.FormName()] = part{filename: p.FileName(), contentType: p.Header.Get("Content-Type"), data: data.String()}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := HTTPActionConfig{
+		Method:      "POST",
+		URL:         server.URL,
+		ContentType: ContentTypeMultipart,
+		// A custom Content-Type header must not replace the multipart boundary
+		Headers: map[string]string{"Content-Type": "multipart/form-data"},
+		Body: json.RawMessage(`{
+			"title": "${steps.report.title}",
+			"report": {"filename": "report.csv", "content_type": "text/csv", "content": "${steps.report.csv}"},
+			"logo": {"url": "` + files.URL + `/assets/logo.png"},
+			"raw": {"filename": "raw.bin", "encoding": "base64", "content": "aGVsbG8="}
+		}`),
+	}
+	execContext := map[string]interface{}{"steps": map[string]interface{}{
+		"report": map[string]interface{}{"title": "Q3", "csv": "a,b\n1,2\n"},
+	}}
+
+	if _, err := newTestHTTPAction().Execute(context.Background(), NewActionInput(config, execContext)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	want := map[string]part{
+		"title":  {data: "Q3"},
+		"report": {filename: "report.csv", contentType: "text/csv", data: "a,b\n1,2\n"},
+		"logo":   {filename: "logo.png", contentType: "image/png", data: "png-bytes"},
+		"raw":    {filename: "raw.bin", contentType: "application/octet-stream", data: "hello"},
+	}
+	for name, wantPart := range want {
+		got := parts[name]
+		if name == "title" {
+			got.contentType = ""
+		}
+		if got != wantPart {
+			t.Errorf("part %q = %+v, want %+v", name, got, wantPart)
+		}
+	}
+}
+
+func TestHTTPAction_Execute_MultipartFileURLIsValidated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	}))
+	defer server.Close()
+
+	// The upload target is allowed but the file URL points at cloud metadata
+	action := NewHTTPActionWithValidator(security.NewURLValidatorWithConfig(&security.URLValidatorConfig{
+		Enabled:         true,
+		AllowedNetworks: []string{"127.0.0.0/8"},
+	}))
+	config := HTTPActionConfig{
+		Method:      "POST",
+		URL:         server.URL,
+		ContentType: ContentTypeMultipart,
+		Body:        json.RawMessage(`{"file": {"filename": "creds", "url": "http://169.254.169.254/latest/meta-data/"}}`),
+	}
+
+	_, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil || !strings.Contains(err.Error(), `file field "file": SSRF protection`) {
+		t.Errorf("Execute() error = %v, want SSRF protection error", err)
+	}
+}
+
+func TestHTTPAction_Execute_MultipartDownloadUsesResponseCeiling(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer files.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	}))
+	defer server.Close()
+
+	validator := security.NewURLValidatorWithConfig(&security.URLValidatorConfig{Enabled: false})
+	action := NewHTTPActionWithOptions(validator, HTTPOptions{MaxResponseBytes: 1024})
+	config := HTTPActionConfig{
+		Method:           "POST",
+		URL:              server.URL,
+		ContentType:      ContentTypeMultipart,
+		Body:             json.RawMessage(`{"file": {"url": "` + files.URL + `/big.bin"}}`),
+		MaxResponseBytes: 1 << 30,
+	}
+
+	_, err := action.Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil || !strings.Contains(err.Error(), "limit of 1024 bytes") {
+		t.Errorf("Execute() error = %v, want download limit of 1024 bytes", err)
+	}
+}
+
+func TestHTTPAction_Execute_UnsupportedContentType(t *testing.T) {
+	config := HTTPActionConfig{
+		Method:      "POST",
+		URL:         "http://example.com",
+		ContentType: "text/xml",
+		Body:        json.RawMessage(`{"a":1}`),
+	}
+
+	_, err := newTestHTTPAction().Execute(context.Background(), NewActionInput(config, nil))
+	if err == nil || !strings.Contains(err.Error(), "unsupported content_type") {
+		t.Errorf("Execute() error = %v, want unsupported content_type", err)
+	}
+}
+
+func TestParseResponseBody(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		wantMap     bool
+	}{
+		{contentType: "application/json", body: `{"a":1}`, wantMap: true},
+		{contentType: "application/json; charset=utf-8", body: `{"a":1}`, wantMap: true},
+		{contentType: "application/problem+json", body: `{"a":1}`, wantMap: true},
+		{contentType: "application/json", body: `not json`},
+		{contentType: "text/html", body: `{"a":1}`},
+		{contentType: "application/x-www-form-urlencoded", body: `a=1`},
+		{contentType: "", body: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		got := parseResponseBody(tt.contentType, []byte(tt.body))
+		_, isMap := got.(map[string]interface{})
+		if isMap != tt.wantMap {
+			t.Errorf("parseResponseBody(%q, %q) = %#v, want map: %v", tt.contentType, tt.body, got, tt.wantMap)
+		}
+		if !tt.wantMap && got != tt.body {
+			t.Errorf("parseResponseBody(%q, %q) = %#v, want raw string", tt.contentType, tt.body, got)
+		}
+	}
+}
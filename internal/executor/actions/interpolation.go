@@ -10,20 +10,26 @@ import (
 )
 
 var (
-	// interpolationRegex matches {{expression}} patterns
-	interpolationRegex = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+	// interpolationRegex matches {{expression}} and ${expression} patterns
+	interpolationRegex = regexp.MustCompile(`\{\{([^}]+)\}\}|\$\{([^}]+)\}`)
 	// arrayIndexRegex matches array[index] patterns
 	arrayIndexRegex = regexp.MustCompile(`^(.+)\[(\d+)\]$`)
 )
 
-// InterpolateString replaces {{path.to.value}} with actual values from context
+// InterpolateString replaces {{path.to.value}} and ${path.to.value} with actual values from context
 // Supports JSONPath-like syntax: steps.http-1.body.users[0].name
+// and built-in functions: now(), date(), dateAdd(t, n, unit), dateFormat(t, layout), len(v),
+// contains(c, v), startsWith(s, p), join(a, sep), filter(a, x => ...), map(a, x => ...)
+// and reduce(a, (acc, x) => ..., initial)
 func InterpolateString(template string, context map[string]interface{}) string {
 	return interpolationRegex.ReplaceAllStringFunc(template, func(match string) string {
-		// Extract expression from {{...}}
-		expression := strings.TrimSpace(match[2 : len(match)-2])
+		// Extract expression from {{...}} or ${...}
+		expression := match[2 : len(match)-2]
+		if strings.HasPrefix(match, "${") {
+			expression = match[2 : len(match)-1]
+		}
 
-		value, err := GetValueByPath(context, expression)
+		value, err := evaluateInterpolation(expression, context)
 		if err != nil {
 			// Return original if path not found
 			return match
@@ -34,6 +40,23 @@ func InterpolateString(template string, context map[string]interface{}) string {
 	})
 }
 
+// InterpolateValue resolves a template like InterpolateString, except that a
+// template consisting of a single expression keeps the value's type, so
+// numbers, booleans and nulls are not turned into strings
+func InterpolateValue(template string, context map[string]interface{}) interface{} {
+	loc := interpolationRegex.FindStringSubmatchIndex(template)
+	if loc != nil && loc[0] == 0 && loc[1] == len(template) {
+		expression := template[2 : len(template)-2]
+		if strings.HasPrefix(template, "${") {
+			expression = template[2 : len(template)-1]
+		}
+		if value, err := evaluateInterpolation(expression, context); err == nil {
+			return value
+		}
+	}
+	return InterpolateString(template, context)
+}
+
 // InterpolateJSON recursively interpolates values in a JSON structure
 func InterpolateJSON(data json.RawMessage, context map[string]interface{}) interface{} {
 	var parsed interface{}
@@ -0,0 +1,243 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// interpolationFunc is a built-in function implemented in Go and exposed to
+// interpolation expressions as a JavaScript global
+type interpolationFunc func(args ...interface{}) (interface{}, error)
+
+var (
+	// functionCallRegex matches name(args) call expressions
+	functionCallRegex = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\((.*)\)$`)
+
+	// interpolationNow returns the current time; overridable in tests
+	interpolationNow = time.Now
+
+	// dateFormatReplacer maps common date tokens to Go reference layout tokens.
+	// Longer tokens are listed first so they win over their prefixes.
+	dateFormatReplacer = strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MMMM", "January",
+		"MMM", "Jan",
+		"MM", "01",
+		"DD", "02",
+		"HH", "15",
+		"hh", "03",
+		"mm", "04",
+		"ss", "05",
+	)
+)
+
+// interpolationDateFuncs holds the built-in date functions keyed by name
+var interpolationDateFuncs = map[string]interpolationFunc{
+	"now":        fnNow,
+	"date":       fnDate,
+	"dateAdd":    fnDateAdd,
+	"dateFormat": fnDateFormat,
+}
+
+// interpolationArrayFuncs names the built-in functions defined in JavaScript
+// by interpolationBuiltins
+var interpolationArrayFuncs = []string{"len", "contains", "startsWith", "join", "filter", "map", "reduce"}
+
+// interpolationBuiltins defines the array and string built-ins for the
+// expression engine. A missing (undefined) array is an error so that a
+// misspelled path fails instead of yielding an empty result; null is empty.
+const interpolationBuiltins = `const __fail = (message) => { throw new Error(message); };
+const __type = (v) => v === null ? "null" : Array.isArray(v) ? "array" : typeof v;
+const __array = (fn, v) => v === undefined ? __fail(fn + "() first argument is missing")
+	: v === null ? []
+	: Array.isArray(v) ? v
+	: __fail(fn + "() first argument must be an array, got " + __type(v));
+const __lambda = (fn, role, f, params, example) => typeof f !== "function"
+	? __fail(fn + "() " + role + " must be an arrow function such as " + example + ", got " + __type(f))
+	: f.length < params ? __fail(fn + "() " + role + " must take at least " + params + " parameter(s), such as " + example)
+	: f;
+const __equal = (a, b) => a === b || (typeof a === "object" && a !== null && JSON.stringify(a) === JSON.stringify(b));
+const __string = (v) => v === null || v === undefined ? "" : typeof v === "object" ? JSON.stringify(v) : String(v);
+const len = (v) => v === undefined ? __fail("len() argument is missing")
+	: v === null ? 0
+	: typeof v === "string" ? Array.from(v).length
+	: Array.isArray(v) ? v.length
+	: typeof v === "object" ? Object.keys(v).length
+	: __fail("len() unsupported type " + __type(v));
+const contains = (c, v) => c === undefined || c === null ? false
+	: typeof c === "string" ? (typeof v === "string" ? c.includes(v) : __fail("contains() on a string requires a string value, got " + __type(v)))
+	: Array.isArray(c) ? c.some((item) => __equal(item, v))
+	: typeof c === "object" ? (typeof v === "string" ? Object.keys(c).includes(v) : __fail("contains() on an object requires a string key, got " + __type(v)))
+	: __fail("contains() first argument must be an array, string or object, got " + __type(c));
+const startsWith = (s, p) => s === undefined || s === null ? false
+	: typeof s === "string" && typeof p === "string" ? s.startsWith(p)
+	: __fail("startsWith() requires string arguments, got " + __type(s) + " and " + __type(p));
+const join = (a, separator = ",") => typeof separator === "string"
+	? __array("join", a).map(__string).join(separator)
+	: __fail("join() separator must be a string, got " + __type(separator));
+const filter = (a, f) => __array("filter", a).filter(__lambda("filter", "predicate", f, 1, "x => x.active"));
+const map = (a, f) => __array("map", a).map(__lambda("map", "function", f, 1, "x => x.name"));
+const reduce = (a, f, ...initial) => {
+	const items = __array("reduce", a), reducer = __lambda("reduce", "reducer", f, 2, "(sum, x) => sum + x");
+	if (initial.length === 0 && items.length === 0) __fail("reduce() of an empty array requires an initial value");
+	return initial.length === 0 ? items.reduce(reducer) : items.reduce(reducer, initial[0]);
+};
+`
+
+// evaluateInterpolation resolves an interpolation expression, which is either
+// a path into the context or a built-in function call such as now(),
+// dateAdd(now(), -1, 'month') or filter(items, i => i.active). Function calls
+// are evaluated by the sandboxed JavaScript engine used for mapping expressions.
+func evaluateInterpolation(expression string, execContext map[string]interface{}) (interface{}, error) {
+	expression = strings.TrimSpace(expression)
+
+	if !functionCallRegex.MatchString(expression) {
+		return GetValueByPath(execContext, expression)
+	}
+
+	expression, err := unwrapTemplateArguments(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateJavaScriptExpression(context.Background(), expression, execContext)
+}
+
+// isInterpolationCall reports whether expression is a call to a built-in
+// interpolation function
+func isInterpolationCall(expression string) bool {
+	matches := functionCallRegex.FindStringSubmatch(strings.TrimSpace(expression))
+	if matches == nil {
+		return false
+	}
+	if _, ok := interpolationDateFuncs[matches[1]]; ok {
+		return true
+	}
+	return slices.Contains(interpolationArrayFuncs, matches[1])
+}
+
+// unwrapTemplateArguments turns filter(${steps.x.items}, ...) into
+// filter((steps.x.items), ...) so the call is a plain JavaScript expression
+func unwrapTemplateArguments(expression string) (string, error) {
+	spans, err := findTemplateExpressions(expression)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, span := range spans {
+		builder.WriteString(expression[last:span.start])
+		builder.WriteString("(" + span.expression + ")")
+		last = span.end
+	}
+	builder.WriteString(expression[last:])
+
+	return builder.String(), nil
+}
+
+// interpolationGlobals returns the Go built-ins as JavaScript globals
+func interpolationGlobals() map[string]interface{} {
+	globals := make(map[string]interface{}, len(interpolationDateFuncs))
+	for name, fn := range interpolationDateFuncs {
+		globals[name] = fn
+	}
+	return globals
+}
+
+// fnNow returns the current time in RFC3339 UTC
+func fnNow(args ...interface{}) (interface{}, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("now() takes no arguments")
+	}
+	return interpolationNow().UTC().Format(time.RFC3339), nil
+}
+
+// fnDate returns the current date as YYYY-MM-DD
+func fnDate(args ...interface{}) (interface{}, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("date() takes no arguments")
+	}
+	return interpolationNow().UTC().Format("2006-01-02"), nil
+}
+
+// fnDateAdd adds n units (day, month, year, hour) to a timestamp
+func fnDateAdd(args ...interface{}) (interface{}, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("dateAdd() requires 3 arguments: time, amount, unit")
+	}
+
+	t, err := toTime(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// JavaScript integers arrive as int64, other numbers as float64
+	var n int
+	switch amount := args[1].(type) {
+	case int64:
+		n = int(amount)
+	case float64:
+		n = int(amount)
+	default:
+		return nil, fmt.Errorf("dateAdd() amount must be a number")
+	}
+
+	unit, _ := args[2].(string)
+	switch strings.TrimSuffix(strings.ToLower(unit), "s") {
+	case "hour":
+		t = t.Add(time.Duration(n) * time.Hour)
+	case "day":
+		t = t.AddDate(0, 0, n)
+	case "month":
+		t = t.AddDate(0, n, 0)
+	case "year":
+		t = t.AddDate(n, 0, 0)
+	default:
+		return nil, fmt.Errorf("dateAdd() unsupported unit '%v'", args[2])
+	}
+
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// fnDateFormat formats a timestamp using tokens like 'MMMM YYYY' or 'YYYY-MM-DD'
+func fnDateFormat(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("dateFormat() requires 2 arguments: time, layout")
+	}
+
+	t, err := toTime(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	layout, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("dateFormat() layout must be a string")
+	}
+
+	return t.Format(dateFormatReplacer.Replace(layout)), nil
+}
+
+// toTime converts an RFC3339 or YYYY-MM-DD string into a time
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid time value '%s'", v)
+	default:
+		return time.Time{}, fmt.Errorf("invalid time value of type %T", value)
+	}
+}
@@ -0,0 +1,352 @@
+package actions
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func withFixedNow(t *testing.T, now time.Time) {
+	t.Helper()
+	original := interpolationNow
+	interpolationNow = func() time.Time { return now }
+	t.Cleanup(func() { interpolationNow = original })
+}
+
+func TestInterpolateString_Functions(t *testing.T) {
+	withFixedNow(t, time.Date(2024, time.March, 15, 9, 30, 0, 0, time.UTC))
+
+	context := map[string]interface{}{
+		"env": map[string]interface{}{
+			"S3_BACKUP_BUCKET": "backups-bucket",
+		},
+		"trigger": map[string]interface{}{
+			"pull_request": map[string]interface{}{
+				"changed_files": []interface{}{"a.go", "b.go", "c.go"},
+			},
+		},
+		"steps": map[string]interface{}{
+			"http-1": map[string]interface{}{
+				"response": map[string]interface{}{
+					"data": []interface{}{1, 2},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "now",
+			template: "${now()}",
+			want:     "2024-03-15T09:30:00Z",
+		},
+		{
+			name:     "date in path",
+			template: "s3://${env.S3_BACKUP_BUCKET}/backups/${date()}",
+			want:     "s3://backups-bucket/backups/2024-03-15",
+		},
+		{
+			name:     "dateAdd month",
+			template: "${dateAdd(now(), -1, 'month')}",
+			want:     "2024-02-15T09:30:00Z",
+		},
+		{
+			name:     "dateFormat of dateAdd",
+			template: "${dateFormat(dateAdd(now(), -1, 'month'), 'MMMM YYYY')}",
+			want:     "February 2024",
+		},
+		{
+			name:     "len of array",
+			template: "${len(steps.http-1.response.data)}",
+			want:     "2",
+		},
+		{
+			name:     "len in message",
+			template: "Files: ${len(trigger.pull_request.changed_files)}",
+			want:     "Files: 3",
+		},
+		{
+			name:     "function in mustache syntax",
+			template: "{{date()}}",
+			want:     "2024-03-15",
+		},
+		{
+			name:     "unknown function left untouched",
+			template: "${unknown()}",
+			want:     "${unknown()}",
+		},
+		{
+			name:     "len of missing path left untouched",
+			template: "${len(steps.missing)}",
+			want:     "${len(steps.missing)}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InterpolateString(tt.template, context)
+			if got != tt.want {
+				t.Errorf("InterpolateString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateAdd_Units(t *testing.T) {
+	base := "2024-01-31T12:00:00Z"
+
+	tests := []struct {
+		unit    string
+		amount  float64
+		want    string
+		wantErr bool
+	}{
+		{unit: "hour", amount: 2, want: "2024-01-31T14:00:00Z"},
+		{unit: "days", amount: 1, want: "2024-02-01T12:00:00Z"},
+		{unit: "month", amount: 1, want: "2024-03-02T12:00:00Z"},
+		{unit: "year", amount: -1, want: "2023-01-31T12:00:00Z"},
+		{unit: "fortnight", amount: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			got, err := fnDateAdd(base, tt.amount, tt.unit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fnDateAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("fnDateAdd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateFormat_Tokens(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{layout: "MMMM YYYY", want: "February 2024"},
+		{layout: "YYYY-MM-DD", want: "2024-02-05"},
+		{layout: "MMM DD, YY HH:mm:ss", want: "Feb 05, 24 17:04:09"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, err := fnDateFormat("2024-02-05T17:04:09Z", tt.layout)
+			if err != nil {
+				t.Fatalf("fnDateFormat() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fnDateFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLen_Types(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{name: "array", value: []interface{}{1, 2, 3}, want: 3},
+		{name: "string", value: "héllo", want: 5},
+		{name: "object", value: map[string]interface{}{"a": 1}, want: 1},
+		{name: "nil", value: nil, want: 0},
+		{name: "number", value: 42.0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateInterpolation("len(value)", map[string]interface{}{"value": tt.value})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("len() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("len() = %#v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrayFunctions(t *testing.T) {
+	context := map[string]interface{}{
+		"steps": map[string]interface{}{
+			"http-1": map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"name": "a", "active": true, "amount": 5.0},
+					map[string]interface{}{"name": "b", "active": false, "amount": 7.0},
+					map[string]interface{}{"name": "c", "active": true, "amount": 11.0},
+				},
+				"tags": []interface{}{"release-1", "latest", "feature-x"},
+			},
+		},
+		"item": map[string]interface{}{"name": "web", "tags": []interface{}{"a", "b"}},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		want       interface{}
+		wantErr    string
+	}{
+		{name: "contains array", expression: "contains(steps.http-1.tags, 'latest')", want: true},
+		{name: "contains array literal", expression: "contains(['latest', 'stable'], 'stable')", want: true},
+		{name: "contains string", expression: "contains('feature-x', 'ture')", want: true},
+		{name: "contains missing", expression: "contains(steps.http-1.tags, 'stable')", want: false},
+		{name: "startsWith", expression: "startsWith('release-1', 'release-')", want: true},
+		{name: "join default separator", expression: "join(steps.http-1.tags)", want: "release-1,latest,feature-x"},
+		{name: "join escaped separator", expression: "join(steps.http-1.tags, '\\n')", want: "release-1\nlatest\nfeature-x"},
+		{name: "filter", expression: "len(filter(steps.http-1.items, i => i.active))", want: int64(2)},
+		{name: "filter by index", expression: "filter(steps.http-1.tags, (t, i) => i > 0)", want: []interface{}{"latest", "feature-x"}},
+		{name: "map", expression: "map(steps.http-1.items, i => i.name)", want: []interface{}{"a", "b", "c"}},
+		{name: "map string concatenation", expression: "map(steps.http-1.tags, t => 'tag:' + t)", want: []interface{}{"tag:release-1", "tag:latest", "tag:feature-x"}},
+		{name: "reduce with initial value", expression: "reduce(steps.http-1.items, (sum, i) => sum + i.amount, 0)", want: int64(23)},
+		{name: "reduce without initial value", expression: "reduce(map(steps.http-1.items, i => i.amount), (a, b) => a * b)", want: int64(385)},
+		{name: "nested lambdas see outer parameters", expression: "map(steps.http-1.items, i => len(filter(steps.http-1.items, j => j.amount > i.amount)))", want: []interface{}{int64(2), int64(1), int64(0)}},
+		{name: "missing lambda field is undefined", expression: "filter(steps.http-1.items, i => i.missing === undefined)", want: context["steps"].(map[string]interface{})["http-1"].(map[string]interface{})["items"]},
+		{name: "filter missing array", expression: "filter(${steps.missing}, i => i)", wantErr: "filter() first argument is missing"},
+		{name: "filter non-array", expression: "filter('abc', i => i)", wantErr: "filter() first argument must be an array, got string"},
+		{name: "filter without lambda", expression: "filter(steps.http-1.tags, 'latest')", wantErr: "filter() predicate must be an arrow function such as x => x.active, got string"},
+		{name: "reduce one-parameter reducer", expression: "reduce(steps.http-1.items, x => x, 0)", wantErr: "reduce() reducer must take at least 2 parameter(s)"},
+		{name: "reduce empty array", expression: "reduce([], (a, b) => a + b)", wantErr: "reduce() of an empty array requires an initial value"},
+		{name: "startsWith non-string", expression: "startsWith(steps.http-1.tags, 'x')", wantErr: "startsWith() requires string arguments"},
+		{name: "unknown function in lambda", expression: "map(steps.http-1.tags, t => upper(t))", wantErr: "upper is not defined"},
+		{name: "loop variable", expression: "map(item.tags, t => item.name + ':' + t)", want: []interface{}{"web:a", "web:b"}},
+		{name: "syntax error", expression: "map(steps.http-1.tags, t => )", wantErr: "SyntaxError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateInterpolation(tt.expression, context)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("evaluateInterpolation() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("evaluateInterpolation() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateInterpolation() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBuiltinTemplateExpressions runs the array expressions of the built-in
+// Kubernetes monitor, registry cleanup and access review templates exactly as
+// they are written in internal/template/builtin_templates.go
+func TestBuiltinTemplateExpressions(t *testing.T) {
+	execContext := map[string]interface{}{
+		"steps": map[string]interface{}{
+			"api-1": map[string]interface{}{
+				"body": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"name": "web", "status": map[string]interface{}{"readyReplicas": 3.0, "replicas": 3.0}},
+						map[string]interface{}{"name": "worker", "status": map[string]interface{}{"readyReplicas": 1.0, "replicas": 2.0}},
+					},
+					"users": []interface{}{
+						map[string]interface{}{"id": "u1", "email": "ann@example.com"},
+						map[string]interface{}{"id": "u2", "email": "bob@example.com"},
+						map[string]interface{}{"id": "u3", "email": "cat@example.com"},
+					},
+					"repositories": []interface{}{"app", "db"},
+				},
+			},
+			"api-2": map[string]interface{}{
+				"body": map[string]interface{}{
+					"tags":   []interface{}{"release-1.2", "latest", "stable", "feature-login", "sha-abc123"},
+					"logins": []interface{}{map[string]interface{}{"user_id": "u2"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mapping map[string]interface{}
+		want    map[string]interface{}
+	}{
+		{
+			name: "kubernetes monitor",
+			mapping: map[string]interface{}{
+				"unhealthy_deployments": "filter(${steps.api-1.body.items}, d => d.status.readyReplicas < d.status.replicas)",
+			},
+			want: map[string]interface{}{
+				"unhealthy_deployments": []interface{}{
+					map[string]interface{}{"name": "worker", "status": map[string]interface{}{"readyReplicas": 1.0, "replicas": 2.0}},
+				},
+			},
+		},
+		{
+			name: "registry cleanup",
+			mapping: map[string]interface{}{
+				"old_tags": "filter(${steps.api-2.body.tags}, t => !startsWith(t, 'release-') && !contains(['latest', 'stable'], t))",
+			},
+			want: map[string]interface{}{
+				"old_tags": []interface{}{"feature-login", "sha-abc123"},
+			},
+		},
+		{
+			name: "access review",
+			mapping: map[string]interface{}{
+				"stale_accounts":  "filter(${steps.api-1.body.users}, u => !contains(map(${steps.api-2.body.logins}, l => l.user_id), u.id))",
+				"review_required": "${len(steps.api-1.body.users)}",
+				"stale_count":     "len(filter(${steps.api-1.body.users}, u => !contains(map(${steps.api-2.body.logins}, l => l.user_id), u.id)))",
+			},
+			want: map[string]interface{}{
+				"stale_accounts": []interface{}{
+					map[string]interface{}{"id": "u1", "email": "ann@example.com"},
+					map[string]interface{}{"id": "u3", "email": "cat@example.com"},
+				},
+				"review_required": int64(3),
+				"stale_count":     int64(2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := &TransformAction{}
+			output, err := action.Execute(context.Background(), NewActionInput(map[string]interface{}{"mapping": tt.mapping}, execContext))
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !reflect.DeepEqual(output.Data, tt.want) {
+				t.Errorf("Execute() = %#v, want %#v", output.Data, tt.want)
+			}
+		})
+	}
+
+	t.Run("messages", func(t *testing.T) {
+		execContext["steps"].(map[string]interface{})["transform-1"] = map[string]interface{}{
+			"unhealthy_deployments": []interface{}{"worker"},
+			"old_tags":              []interface{}{"feature-login", "sha-abc123"},
+			"stale_accounts": []interface{}{
+				map[string]interface{}{"email": "ann@example.com"},
+				map[string]interface{}{"email": "cat@example.com"},
+			},
+		}
+
+		messages := map[string]string{
+			"Unhealthy: ${len(steps.transform-1.unhealthy_deployments)} deployment(s)":                                                       "Unhealthy: 1 deployment(s)",
+			"Processed: ${len(steps.api-1.body.repositories)} repositories\nTags identified for cleanup: ${len(steps.transform-1.old_tags)}": "Processed: 2 repositories\nTags identified for cleanup: 2",
+			"Stale accounts requiring review:\n${join(map(steps.transform-1.stale_accounts, a => a.email), '\\n')}":                          "Stale accounts requiring review:\nann@example.com\ncat@example.com",
+		}
+		for template, want := range messages {
+			if got := InterpolateString(template, execContext); got != want {
+				t.Errorf("InterpolateString(%q) = %q, want %q", template, got, want)
+			}
+		}
+	})
+}
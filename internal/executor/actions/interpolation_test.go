@@ -168,6 +168,37 @@ func TestGetValueByPath(t *testing.T) {
 	}
 }
 
+func TestInterpolateValue(t *testing.T) {
+	context := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":   "Alice",
+			"id":     100,
+			"active": true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     interface{}
+	}{
+		{name: "single expression keeps number", template: "{{user.id}}", want: 100},
+		{name: "single expression keeps bool", template: "${user.active}", want: true},
+		{name: "mixed template is a string", template: "id-{{user.id}}", want: "id-100"},
+		{name: "plain string", template: "static", want: "static"},
+		{name: "unresolved expression", template: "{{user.missing}}", want: "{{user.missing}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InterpolateValue(tt.template, context)
+			if got != tt.want {
+				t.Errorf("InterpolateValue() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
 func TestInterpolateJSON(t *testing.T) {
 	context := map[string]interface{}{
 		"user": map[string]interface{}{
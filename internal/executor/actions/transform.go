@@ -4,6 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+)
+
+// Transform types
+const (
+	// TransformTypeMapping builds the output from Mapping, or from a single
+	// path or JavaScript Expression. It is the default.
+	TransformTypeMapping = "mapping"
+	// TransformTypeExpr evaluates Expression as one expr-language expression
+	// that produces the whole output, for reshaping nested arrays, filtering,
+	// grouping and conditional keys
+	TransformTypeExpr = "expr"
 )
 
 // TransformAction implements the Action interface for data transformation
@@ -11,10 +23,15 @@ type TransformAction struct{}
 
 // TransformActionConfig represents the configuration for a transform action
 type TransformActionConfig struct {
-	// Expression is a JSONPath expression to extract a value
+	// TransformType selects how the output is built: "mapping" (default) or "expr"
+	TransformType string `json:"transform_type,omitempty"`
+	// Expression is a path to extract a value, or a JavaScript expression
+	// such as steps.http-1.items.filter(i => i.active). With transform_type
+	// "expr" it is the expr-language expression producing the output.
 	Expression string `json:"expression,omitempty"`
-	// Mapping defines field mappings from source paths to target keys
-	Mapping map[string]string `json:"mapping,omitempty"`
+	// Mapping defines target keys mapped to source paths or ${...} expressions.
+	// Values may be nested objects, which are resolved recursively.
+	Mapping map[string]interface{} `json:"mapping,omitempty"`
 	// Default value to use if extraction fails
 	Default interface{} `json:"default,omitempty"`
 }
@@ -47,30 +64,41 @@ func (a *TransformAction) Execute(ctx context.Context, input *ActionInput) (*Act
 
 // executeTransform executes the transformation
 func (a *TransformAction) executeTransform(ctx context.Context, config TransformActionConfig, execContext map[string]interface{}) (interface{}, error) {
+	switch config.TransformType {
+	case "", TransformTypeMapping:
+	case TransformTypeExpr:
+		if strings.TrimSpace(config.Expression) == "" {
+			return nil, fmt.Errorf("transform_type %q requires an expression", TransformTypeExpr)
+		}
+		return evaluateExprTransform(config.Expression, execContext)
+	default:
+		return nil, fmt.Errorf("unsupported transform_type %q", config.TransformType)
+	}
+
 	// If mapping is provided, create output from mapping
 	if len(config.Mapping) > 0 {
-		return a.executeMapping(config.Mapping, execContext)
+		return a.executeMapping(ctx, config.Mapping, execContext)
 	}
 
 	// If expression is provided, evaluate it
 	if config.Expression != "" {
-		return a.executeExpression(config.Expression, execContext)
+		return a.executeExpression(ctx, config.Expression, execContext)
 	}
 
 	// Return input context if no transformation specified
 	return execContext, nil
 }
 
-// executeMapping creates a new object based on the mapping configuration
-func (a *TransformAction) executeMapping(mapping map[string]string, context map[string]interface{}) (map[string]interface{}, error) {
+// executeMapping creates a new object based on the mapping configuration.
+// Missing paths resolve to nil; expressions that cannot be evaluated fail the
+// transform rather than emitting the raw expression.
+func (a *TransformAction) executeMapping(ctx context.Context, mapping map[string]interface{}, context map[string]interface{}) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
-	for targetKey, sourcePath := range mapping {
-		value, err := GetValueByPath(context, sourcePath)
+	for targetKey, source := range mapping {
+		value, err := evaluateMappingValue(ctx, source, context)
 		if err != nil {
-			// Use nil for missing values instead of failing
-			result[targetKey] = nil
-			continue
+			return nil, fmt.Errorf("mapping '%s': %w", targetKey, err)
 		}
 		result[targetKey] = value
 	}
@@ -78,8 +106,16 @@ func (a *TransformAction) executeMapping(mapping map[string]string, context map[
 	return result, nil
 }
 
-// executeExpression evaluates a JSONPath expression
-func (a *TransformAction) executeExpression(expression string, context map[string]interface{}) (interface{}, error) {
+// executeExpression evaluates a path, ${...} template or JavaScript expression
+func (a *TransformAction) executeExpression(ctx context.Context, expression string, context map[string]interface{}) (interface{}, error) {
+	if strings.Contains(expression, "${") {
+		return evaluateMappingString(ctx, expression, context)
+	}
+
+	if !simplePathRegex.MatchString(strings.TrimSpace(expression)) {
+		return evaluateTransformExpression(ctx, expression, context)
+	}
+
 	value, err := GetValueByPath(context, expression)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate expression '%s': %w", expression, err)
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/expr-lang/expr"
+)
+
+// omitValue marks a key or array element that an expr transform leaves out
+// of its output
+type omitValue struct{}
+
+// exprTransformOptions are the compile options shared by all expr transforms.
+// omit() lets an expression include a key conditionally:
+//
+//	{"discount": trigger.body.vip ? 0.1 : omit()}
+var exprTransformOptions = []expr.Option{
+	expr.Function("omit", func(params ...interface{}) (interface{}, error) {
+		return omitValue{}, nil
+	}, new(func() interface{})),
+}
+
+// evaluateExprTransform evaluates a whole-output expr-language expression
+// against the execution context. Node IDs with hyphens can be referenced as
+// steps.http-1 just like in paths.
+func evaluateExprTransform(expression string, execContext map[string]interface{}) (interface{}, error) {
+	source := rewriteHyphenatedPaths(expression)
+
+	options := append([]expr.Option{expr.Env(execContext)}, exprTransformOptions...)
+	program, err := expr.Compile(source, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile transform expression: %w", err)
+	}
+
+	result, err := expr.Run(program, execContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate transform expression: %w", err)
+	}
+
+	return stripOmitted(result), nil
+}
+
+// stripOmitted removes map keys and array elements set to omit(). An
+// omitted top-level result becomes nil. Maps with non-string keys, such as
+// the result of groupBy, are converted to JSON objects keyed by the
+// formatted key.
+func stripOmitted(value interface{}) interface{} {
+	switch v := value.(type) {
+	case omitValue:
+		return nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if _, omitted := item.(omitValue); omitted {
+				continue
+			}
+			result[key] = stripOmitted(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if _, omitted := item.(omitValue); omitted {
+				continue
+			}
+			result = append(result, stripOmitted(item))
+		}
+		return result
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Map {
+			return v
+		}
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			item := iter.Value().Interface()
+			if _, omitted := item.(omitValue); omitted {
+				continue
+			}
+			result[fmt.Sprint(iter.Key().Interface())] = stripOmitted(item)
+		}
+		return result
+	}
+}
@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func exprTransformContext() map[string]interface{} {
+	return map[string]interface{}{
+		"trigger": map[string]interface{}{
+			"vip": false,
+		},
+		"steps": map[string]interface{}{
+			"api-3": map[string]interface{}{
+				"transactions": []interface{}{
+					map[string]interface{}{"id": "t1", "amount": 120.0, "category": "travel"},
+					map[string]interface{}{"id": "t2", "amount": 30.0, "category": "food"},
+					map[string]interface{}{"id": "t3", "amount": 15.5, "category": "food"},
+				},
+			},
+		},
+	}
+}
+
+func TestTransformAction_Execute_ExprTransform(t *testing.T) {
+	action := &TransformAction{}
+	config := TransformActionConfig{
+		TransformType: TransformTypeExpr,
+		Expression: `{
+			"total": sum(map(steps.api-3.transactions, .amount)),
+			"large": map(filter(steps.api-3.transactions, .amount > 100), .id),
+			"by_category": groupBy(steps.api-3.transactions, .category),
+			"discount": trigger.vip ? 0.1 : omit()
+		}`,
+	}
+
+	output, err := action.Execute(context.Background(), NewActionInput(config, exprTransformContext()))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	result, ok := output.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Output data is %T, want map", output.Data)
+	}
+
+	if result["total"] != 165.5 {
+		t.Errorf("total = %v, want 165.5", result["total"])
+	}
+	if !reflect.DeepEqual(result["large"], []interface{}{"t1"}) {
+		t.Errorf("large = %v, want [t1]", result["large"])
+	}
+	if _, ok := result["discount"]; ok {
+		t.Errorf("discount should be omitted, got %v", result["discount"])
+	}
+
+	byCategory, ok := result["by_category"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("by_category is %T, want map[string]interface{}", result["by_category"])
+	}
+	if food, _ := byCategory["food"].([]interface{}); len(food) != 2 {
+		t.Errorf("by_category.food = %v, want 2 transactions", byCategory["food"])
+	}
+
+	// The output must be serializable as a step output
+	if _, err := json.Marshal(result); err != nil {
+		t.Errorf("result is not JSON serializable: %v", err)
+	}
+}
+
+func TestTransformAction_Execute_ExprTransformConditionalKeyIncluded(t *testing.T) {
+	execContext := exprTransformContext()
+	execContext["trigger"] = map[string]interface{}{"vip": true}
+
+	result, err := ExecuteTransform(context.Background(), TransformActionConfig{
+		TransformType: TransformTypeExpr,
+		Expression:    `{"discount": trigger.vip ? 0.1 : omit(), "items": [1, omit(), 3]}`,
+	}, execContext)
+	if err != nil {
+		t.Fatalf("ExecuteTransform() error = %v", err)
+	}
+
+	want := map[string]interface{}{"discount": 0.1, "items": []interface{}{1, 3}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("result = %v, want %v", result, want)
+	}
+}
+
+func TestTransformAction_Execute_ExprTransformErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TransformActionConfig
+		wantErr string
+	}{
+		{
+			name:    "missing expression",
+			config:  TransformActionConfig{TransformType: TransformTypeExpr},
+			wantErr: "requires an expression",
+		},
+		{
+			name:    "syntax error",
+			config:  TransformActionConfig{TransformType: TransformTypeExpr, Expression: `{"a": }`},
+			wantErr: "failed to compile transform expression",
+		},
+		{
+			name:    "unknown transform type",
+			config:  TransformActionConfig{TransformType: "jq", Expression: "."},
+			wantErr: `unsupported transform_type "jq"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecuteTransform(context.Background(), tt.config, exprTransformContext())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
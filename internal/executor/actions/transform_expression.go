@@ -0,0 +1,300 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorax/gorax/internal/executor/javascript"
+)
+
+const (
+	// transformExpressionTimeout bounds the CPU time of a single mapping expression
+	transformExpressionTimeout = 5 * time.Second
+	// transformEnginePoolSize is the number of VMs kept for expression evaluation
+	transformEnginePoolSize = 4
+)
+
+var (
+	// simplePathRegex matches plain context paths such as steps.http-1.body.items[0]
+	simplePathRegex = regexp.MustCompile(`^[a-zA-Z_][\w-]*(?:\.[\w-]+|\[\d+\])*$`)
+
+	// hyphenatedRootRegex matches a context root followed by a hyphenated key
+	// (usually a node ID such as http-1), which is not a valid JS identifier
+	hyphenatedRootRegex = regexp.MustCompile(`(^|[^\w.$])(trigger|steps|env|vars|input)\.([a-zA-Z0-9_]+(?:-[a-zA-Z0-9_]+)+)`)
+
+	// expressionRootRegex matches context roots that can be declared as
+	// JavaScript constants, such as loop item variables
+	expressionRootRegex = regexp.MustCompile(`^[a-zA-Z_$][\w$]*$`)
+
+	transformEngine     *javascript.Engine
+	transformEngineErr  error
+	transformEngineOnce sync.Once
+)
+
+// expressionSections are the context sections always declared in mapping
+// expressions, as empty objects when the context has none
+var expressionSections = []string{"trigger", "steps", "env", "vars", "input"}
+
+// reservedExpressionRoots are context keys that cannot be declared as
+// top-level constants: JavaScript keywords and literals, the injected
+// context, and the built-in functions. Names starting with __ are also kept
+// for the built-ins.
+var reservedExpressionRoots = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "let": true, "new": true,
+	"null": true, "return": true, "static": true, "super": true, "switch": true, "this": true,
+	"throw": true, "true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "yield": true, "await": true, "arguments": true, "eval": true,
+	"undefined": true, "NaN": true, "Infinity": true, "context": true,
+}
+
+// getTransformEngine returns the shared engine used for mapping expressions.
+// It uses the same strict sandbox and limits as action:script, plus the Go
+// date built-ins of interpolation expressions.
+func getTransformEngine() (*javascript.Engine, error) {
+	transformEngineOnce.Do(func() {
+		sandbox := javascript.StrictSandboxConfig()
+		sandbox.CustomGlobals = interpolationGlobals()
+
+		transformEngine, transformEngineErr = javascript.NewEngine(&javascript.EngineConfig{
+			Limits:        javascript.StrictLimits(transformExpressionTimeout, javascript.DefaultMaxScriptLength),
+			SandboxConfig: sandbox,
+			PoolSize:      transformEnginePoolSize,
+			Logger:        slog.Default(),
+		})
+	})
+	return transformEngine, transformEngineErr
+}
+
+// evaluateMappingValue resolves a mapping value. Nested objects and arrays are
+// resolved recursively; strings are resolved as paths or ${...} expressions;
+// any other literal is returned unchanged.
+func evaluateMappingValue(ctx context.Context, value interface{}, execContext map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := evaluateMappingValue(ctx, item, execContext)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := evaluateMappingValue(ctx, item, execContext)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case string:
+		return evaluateMappingString(ctx, v, execContext)
+	default:
+		return v, nil
+	}
+}
+
+// evaluateMappingString resolves a mapping string. A bare string is a context
+// path (nil when missing) or a built-in function call. A string that is exactly one ${...} expression keeps
+// the expression's type; otherwise each expression is embedded as text.
+func evaluateMappingString(ctx context.Context, value string, execContext map[string]interface{}) (interface{}, error) {
+	// Built-in function calls may wrap their arguments in ${...}, as in
+	// filter(${steps.http-1.body.items}, i => i.active)
+	if isInterpolationCall(value) {
+		return evaluateTransformExpression(ctx, value, execContext)
+	}
+
+	spans, err := findTemplateExpressions(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spans) == 0 {
+		resolved, err := GetValueByPath(execContext, value)
+		if err != nil {
+			return nil, nil
+		}
+		return resolved, nil
+	}
+
+	if len(spans) == 1 && spans[0].start == 0 && spans[0].end == len(value) {
+		return evaluateTransformExpression(ctx, spans[0].expression, execContext)
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, span := range spans {
+		builder.WriteString(value[last:span.start])
+		resolved, err := evaluateTransformExpression(ctx, span.expression, execContext)
+		if err != nil {
+			return nil, err
+		}
+		builder.WriteString(toString(resolved))
+		last = span.end
+	}
+	builder.WriteString(value[last:])
+
+	return builder.String(), nil
+}
+
+// evaluateTransformExpression evaluates a single expression. Plain paths are
+// resolved directly; built-in function calls and anything else are run as
+// JavaScript expressions in the sandbox.
+func evaluateTransformExpression(ctx context.Context, expression string, execContext map[string]interface{}) (interface{}, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	if simplePathRegex.MatchString(expression) {
+		if resolved, err := GetValueByPath(execContext, expression); err == nil {
+			return resolved, nil
+		}
+		// Paths may end in JavaScript properties such as items.length;
+		// a path that is simply missing still resolves to nil
+		resolved, err := evaluateJavaScriptExpression(ctx, expression, execContext)
+		if err != nil {
+			return nil, nil
+		}
+		return resolved, nil
+	}
+
+	if isInterpolationCall(expression) {
+		resolved, err := evaluateInterpolation(expression, execContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate expression '%s': %w", expression, err)
+		}
+		return resolved, nil
+	}
+
+	return evaluateJavaScriptExpression(ctx, expression, execContext)
+}
+
+// evaluateJavaScriptExpression runs an expression such as
+// steps.http-1.items.map(i => i.name.trim()) or filter(steps.x, i => i.active)
+// in the sandboxed JavaScript engine. Every context root, including loop
+// variables, is in scope as a top-level constant.
+func evaluateJavaScriptExpression(ctx context.Context, expression string, execContext map[string]interface{}) (interface{}, error) {
+	engine, err := getTransformEngine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize expression engine: %w", err)
+	}
+
+	roots := expressionRoots(execContext)
+
+	var script strings.Builder
+	script.WriteString(interpolationBuiltins)
+	for _, name := range slices.Sorted(maps.Keys(roots)) {
+		fmt.Fprintf(&script, "const %s = context.input[%q];\n", name, name)
+	}
+	script.WriteString("return (" + rewriteHyphenatedPaths(expression) + ");")
+
+	result, err := engine.Execute(ctx, &javascript.ExecuteConfig{
+		Script:  script.String(),
+		Context: javascript.NewExecutionContext().WithInput(roots),
+		Timeout: transformExpressionTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unsupported or invalid expression '%s': %w", expression, err)
+	}
+
+	return result.Result, nil
+}
+
+// expressionRoots returns the context roots that can be declared in an
+// expression, with an empty object for each missing context section
+func expressionRoots(execContext map[string]interface{}) map[string]interface{} {
+	roots := make(map[string]interface{}, len(execContext)+len(expressionSections))
+	for _, section := range expressionSections {
+		roots[section] = map[string]interface{}{}
+	}
+	for name, value := range execContext {
+		if !expressionRootRegex.MatchString(name) || strings.HasPrefix(name, "__") || reservedExpressionRoots[name] || isInterpolationCall(name+"()") {
+			continue
+		}
+		roots[name] = value
+	}
+	return roots
+}
+
+// rewriteHyphenatedPaths turns steps.http-1.body into steps["http-1"].body so
+// node IDs can be used in JavaScript expressions the same way as in paths
+func rewriteHyphenatedPaths(expression string) string {
+	return hyphenatedRootRegex.ReplaceAllString(expression, `$1$2["$3"]`)
+}
+
+// templateSpan is the location of a ${...} expression within a string
+type templateSpan struct {
+	start      int
+	end        int
+	expression string
+}
+
+// findTemplateExpressions locates ${...} expressions, matching braces so that
+// object literals like ${items.map(i => ({ id: i.id }))} are kept intact
+func findTemplateExpressions(value string) ([]templateSpan, error) {
+	var spans []templateSpan
+
+	for i := 0; i < len(value)-1; i++ {
+		if value[i] != '$' || value[i+1] != '{' {
+			continue
+		}
+
+		end, err := matchClosingBrace(value, i+2)
+		if err != nil {
+			return nil, err
+		}
+
+		spans = append(spans, templateSpan{
+			start:      i,
+			end:        end + 1,
+			expression: value[i+2 : end],
+		})
+		i = end
+	}
+
+	return spans, nil
+}
+
+// matchClosingBrace returns the index of the brace closing an expression that
+// starts at from, skipping braces inside quoted strings
+func matchClosingBrace(value string, from int) (int, error) {
+	depth := 1
+	var quote byte
+
+	for i := from; i < len(value); i++ {
+		char := value[i]
+		switch {
+		case quote != 0:
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"' || char == '`':
+			quote = char
+		case char == '{':
+			depth++
+		case char == '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated expression in '%s'", value)
+}
@@ -0,0 +1,166 @@
+package actions
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func transformExpressionContext() map[string]interface{} {
+	return map[string]interface{}{
+		"trigger": map[string]interface{}{
+			"name": "  alice  ",
+		},
+		"steps": map[string]interface{}{
+			"extract-1": map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"id": 1, "name": " ada ", "email": "ADA@EXAMPLE.COM", "active": true},
+					map[string]interface{}{"id": 2, "name": "grace", "email": "Grace@Example.com", "active": false},
+				},
+			},
+			"fetch-1": map[string]interface{}{
+				"revenue":     []interface{}{100, 250, 50},
+				"users":       []interface{}{10, 20, 30},
+				"conversions": 6,
+			},
+		},
+	}
+}
+
+func TestEvaluateMappingValue_JavaScriptExpressions(t *testing.T) {
+	execContext := transformExpressionContext()
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name:  "reduce",
+			value: "${steps.fetch-1.revenue.reduce((sum, v) => sum + v, 0)}",
+			want:  int64(400),
+		},
+		{
+			name:  "arithmetic over reduce",
+			value: "${(steps.fetch-1.conversions / steps.fetch-1.users.reduce((sum, v) => sum + v, 0)) * 100}",
+			want:  int64(10),
+		},
+		{
+			name:  "string methods",
+			value: "${trigger.name.trim().toUpperCase()}",
+			want:  "ALICE",
+		},
+		{
+			name:  "filter and map",
+			value: "${steps.extract-1.data.filter(i => i.active).map(i => i.email.toLowerCase())}",
+			want:  []interface{}{"ada@example.com"},
+		},
+		{
+			name:  "map to object literal",
+			value: "${steps.extract-1.data.map(item => ({ id: item.id, name: item.name.trim().toUpperCase() }))}",
+			want: []interface{}{
+				map[string]interface{}{"id": int64(1), "name": "ADA"},
+				map[string]interface{}{"id": int64(2), "name": "GRACE"},
+			},
+		},
+		{
+			name:  "embedded in text",
+			value: "Hello ${trigger.name.trim()}, total ${steps.fetch-1.revenue.reduce((a, b) => a + b, 0)}",
+			want:  "Hello alice, total 400",
+		},
+		{
+			name:  "plain path expression",
+			value: "${steps.fetch-1.conversions}",
+			want:  6,
+		},
+		{
+			name: "nested mapping",
+			value: map[string]interface{}{
+				"count": "${steps.extract-1.data.length}",
+				"label": "static",
+			},
+			want: map[string]interface{}{
+				"count": int64(2),
+				"label": nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateMappingValue(context.Background(), tt.value, execContext)
+			if err != nil {
+				t.Fatalf("evaluateMappingValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateMappingValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateMappingValue_UnsupportedSyntaxErrors(t *testing.T) {
+	execContext := transformExpressionContext()
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "syntax error", value: "${steps.fetch-1.revenue.reduce((sum, v) => }", wantErr: "unsupported or invalid expression"},
+		{name: "unterminated", value: "${steps.fetch-1.revenue", wantErr: "unterminated expression"},
+		{name: "sandbox forbidden", value: "${eval('1+1')}", wantErr: "unsupported or invalid expression"},
+		{name: "outside script globals", value: "${new Promise(resolve => resolve(1))}", wantErr: "unsupported or invalid expression"},
+		{name: "recursion limit", value: "${(function f(n) { return f(n + 1) })(0)}", wantErr: "unsupported or invalid expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evaluateMappingValue(context.Background(), tt.value, execContext)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransformAction_Execute_MappingExpressionError(t *testing.T) {
+	action := &TransformAction{}
+	config := TransformActionConfig{
+		Mapping: map[string]interface{}{
+			"total": "${steps.fetch-1.revenue.sum(}",
+		},
+	}
+
+	_, err := action.Execute(context.Background(), NewActionInput(config, transformExpressionContext()))
+	if err == nil {
+		t.Fatal("expected an error for an invalid mapping expression")
+	}
+	if !strings.Contains(err.Error(), "mapping 'total'") {
+		t.Errorf("error = %v, want it to name the mapping key", err)
+	}
+}
+
+func TestRewriteHyphenatedPaths(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "steps.http-1.body", want: `steps["http-1"].body`},
+		{input: "steps.http.body", want: "steps.http.body"},
+		{input: "(steps.a-1.x / steps.b-2.y)", want: `(steps["a-1"].x / steps["b-2"].y)`},
+		{input: "item.steps.a-1", want: "item.steps.a-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := rewriteHyphenatedPaths(tt.input); got != tt.want {
+				t.Errorf("rewriteHyphenatedPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
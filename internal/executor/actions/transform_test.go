@@ -87,7 +87,7 @@ func TestTransformAction_Execute_WithMapping(t *testing.T) {
 
 	action := &TransformAction{}
 	config := TransformActionConfig{
-		Mapping: map[string]string{
+		Mapping: map[string]interface{}{
 			"user_id":    "steps.http-1.body.id",
 			"first_name": "trigger.user.first_name",
 			"last_name":  "trigger.user.last_name",
@@ -140,7 +140,7 @@ func TestTransformAction_Execute_WithArrayMapping(t *testing.T) {
 
 	action := &TransformAction{}
 	config := TransformActionConfig{
-		Mapping: map[string]string{
+		Mapping: map[string]interface{}{
 			"first_user":  "steps.http-1.body.users[0].name",
 			"first_age":   "steps.http-1.body.users[0].age",
 			"second_user": "steps.http-1.body.users[1].name",
@@ -181,7 +181,7 @@ func TestTransformAction_Execute_MissingPath(t *testing.T) {
 
 	action := &TransformAction{}
 	config := TransformActionConfig{
-		Mapping: map[string]string{
+		Mapping: map[string]interface{}{
 			"existing": "trigger.data",
 			"missing":  "trigger.nonexistent",
 		},
@@ -320,7 +320,7 @@ func TestTransformAction_Execute_ComplexMapping(t *testing.T) {
 
 	action := &TransformAction{}
 	config := TransformActionConfig{
-		Mapping: map[string]string{
+		Mapping: map[string]interface{}{
 			"event_type": "trigger.webhook.body.event",
 			"user_id":    "trigger.webhook.body.data.user.id",
 			"email":      "trigger.webhook.body.data.user.email",
@@ -374,7 +374,7 @@ func TestTransformAction_ExecuteExpression(t *testing.T) {
 
 	action := &TransformAction{}
 
-	result, err := action.executeExpression("data.value", execContext)
+	result, err := action.executeExpression(context.Background(), "data.value", execContext)
 	if err != nil {
 		t.Fatalf("executeExpression() error = %v", err)
 	}
@@ -393,12 +393,12 @@ func TestTransformAction_ExecuteMapping(t *testing.T) {
 	}
 
 	action := &TransformAction{}
-	mapping := map[string]string{
+	mapping := map[string]interface{}{
 		"field_a": "source.a",
 		"field_b": "source.b",
 	}
 
-	result, err := action.executeMapping(mapping, execContext)
+	result, err := action.executeMapping(context.Background(), mapping, execContext)
 	if err != nil {
 		t.Fatalf("executeMapping() error = %v", err)
 	}
@@ -441,7 +441,7 @@ func TestTransformAction_Execute_EmptyMapping(t *testing.T) {
 
 	action := &TransformAction{}
 	config := TransformActionConfig{
-		Mapping: map[string]string{},
+		Mapping: map[string]interface{}{},
 	}
 
 	input := NewActionInput(config, execContext)
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// ErrExecutionCancelled is returned when an execution stops because it was cancelled
+var ErrExecutionCancelled = errors.New("execution cancelled")
+
+// Cancel interrupts an execution running on this executor. It returns false
+// when the execution is not running here; such executions stop before their
+// next node once their status is cancelled.
+func (e *Executor) Cancel(executionID string) bool {
+	cancel, ok := e.running.Load(executionID)
+	if !ok {
+		return false
+	}
+	cancel.(context.CancelCauseFunc)(ErrExecutionCancelled)
+	return true
+}
+
+// isCancelled reports whether an execution was cancelled, either through Cancel
+// on this executor or by its status, which is set when another process cancels it
+func (e *Executor) isCancelled(ctx context.Context, executionID string) bool {
+	if errors.Is(context.Cause(ctx), ErrExecutionCancelled) {
+		return true
+	}
+
+	cancelled, err := e.repo.IsExecutionCancelled(ctx, executionID)
+	if err != nil {
+		e.logger.Warn("failed to check execution cancellation", "error", err, "execution_id", executionID)
+		return false
+	}
+	return cancelled
+}
+
+// stopCancelledExecution ends a cancelled execution without running its
+// remaining nodes. The cancelled status is already stored by the canceller.
+func (e *Executor) stopCancelledExecution(execution *workflow.Execution, triggerType string, startTime time.Time, completedSteps, totalSteps int) error {
+	e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "cancelled", startTime)
+
+	if e.broadcaster != nil {
+		e.broadcaster.BroadcastExecutionFailed(execution.TenantID, execution.WorkflowID, execution.ID, ErrExecutionCancelled.Error())
+	}
+
+	e.logger.Info("workflow execution cancelled",
+		"execution_id", execution.ID,
+		"completed_steps", completedSteps,
+		"skipped_steps", totalSteps-completedSteps,
+	)
+	return ErrExecutionCancelled
+}
@@ -0,0 +1,124 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// newCancelTestExecutor builds an executor for a trigger -> delay -> transform workflow
+func newCancelTestExecutor(delay string) (*Executor, *mockWorkflowRepo, *workflow.Execution) {
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{
+				ID:   "trigger-1",
+				Type: string(workflow.NodeTypeTriggerWebhook),
+				Data: workflow.NodeData{Name: "Trigger", Config: mustMarshal(map[string]interface{}{"path": "/test"})},
+			},
+			{
+				ID:   "delay-1",
+				Type: string(workflow.NodeTypeControlDelay),
+				Data: workflow.NodeData{Name: "Wait", Config: mustMarshal(workflow.DelayConfig{Duration: delay})},
+			},
+			{
+				ID:   "transform-1",
+				Type: string(workflow.NodeTypeActionTransform),
+				Data: workflow.NodeData{Name: "After Wait", Config: mustMarshal(map[string]interface{}{"expression": "trigger"})},
+			},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "trigger-1", Target: "delay-1"},
+			{ID: "e2", Source: "delay-1", Target: "transform-1"},
+		},
+	}
+
+	mockRepo := &mockWorkflowRepo{
+		workflow: &workflow.Workflow{
+			ID:         "wf-1",
+			TenantID:   "tenant-1",
+			Definition: mustMarshal(definition),
+		},
+		stepExecutions: make(map[string]*workflow.StepExecution),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{
+		repo:               mockRepo,
+		logger:             logger,
+		retryStrategy:      NewRetryStrategy(DefaultRetryConfig(), logger),
+		circuitBreakers:    NewCircuitBreakerRegistry(DefaultCircuitBreakerConfig(), logger),
+		defaultRetryConfig: DefaultNodeRetryConfig(),
+	}
+
+	triggerData := json.RawMessage(`{}`)
+	execution := &workflow.Execution{
+		ID:          "exec-1",
+		TenantID:    "tenant-1",
+		WorkflowID:  "wf-1",
+		Status:      string(workflow.ExecutionStatusPending),
+		TriggerType: "manual",
+		TriggerData: &triggerData,
+	}
+
+	return executor, mockRepo, execution
+}
+
+func TestCancel_InterruptsRunningExecution(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("1m")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Execute(context.Background(), execution)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, running := executor.running.Load(execution.ID)
+		return running
+	}, time.Second, 5*time.Millisecond)
+
+	// Give the delay node time to start waiting
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, executor.Cancel(execution.ID))
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrExecutionCancelled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution was not interrupted by Cancel")
+	}
+
+	// The node after the delay never ran and the execution was not marked failed
+	_, ranTransform := mockRepo.stepExecutions["transform-1-step"]
+	assert.False(t, ranTransform)
+	assert.NotEqual(t, string(workflow.ExecutionStatusFailed), mockRepo.executionStatus)
+
+	_, stillRegistered := executor.running.Load(execution.ID)
+	assert.False(t, stillRegistered)
+}
+
+func TestCancel_StopsExecutionCancelledElsewhere(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("1ms")
+
+	// Another process already stored the cancelled status
+	mockRepo.executionStatus = string(workflow.ExecutionStatusCancelled)
+
+	err := executor.Execute(context.Background(), execution)
+
+	assert.ErrorIs(t, err, ErrExecutionCancelled)
+	assert.Empty(t, mockRepo.stepExecutions, "no node should run once the execution is cancelled")
+	assert.Equal(t, string(workflow.ExecutionStatusCancelled), mockRepo.executionStatus)
+}
+
+func TestCancel_NotRunning(t *testing.T) {
+	executor, _, _ := newCancelTestExecutor("1ms")
+
+	assert.False(t, executor.Cancel("unknown-execution"))
+}
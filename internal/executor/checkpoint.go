@@ -0,0 +1,61 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// ErrExecutionInterrupted is returned when an execution stops because its
+// worker is shutting down. The execution is checkpointed and resumes on
+// another worker.
+var ErrExecutionInterrupted = errors.New("execution interrupted by worker shutdown")
+
+// checkpointTimeout bounds the status update made after an interruption
+const checkpointTimeout = 5 * time.Second
+
+// InterruptAll stops every execution running on this executor so it can be
+// checkpointed, and returns how many were interrupted. Each execution stops
+// at its current node and is returned to pending to resume from that node.
+func (e *Executor) InterruptAll() int {
+	interrupted := 0
+	e.running.Range(func(_, cancel any) bool {
+		cancel.(context.CancelCauseFunc)(ErrExecutionInterrupted)
+		interrupted++
+		return true
+	})
+	return interrupted
+}
+
+// isInterrupted reports whether the execution was stopped by InterruptAll
+func isInterrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrExecutionInterrupted)
+}
+
+// checkpointExecution returns an interrupted execution to pending so it
+// resumes at nodeID. Steps completed before nodeID are reused on resume.
+func (e *Executor) checkpointExecution(execution *workflow.Execution, nodeID, triggerType string, startTime time.Time, completedSteps int) error {
+	e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "interrupted", startTime)
+
+	// The execution context is already cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+	defer cancel()
+
+	if err := e.repo.CheckpointExecution(ctx, execution.ID, nodeID); err != nil {
+		e.logger.Error("failed to checkpoint interrupted execution",
+			"error", err,
+			"execution_id", execution.ID,
+			"resume_from_node", nodeID,
+		)
+		return errors.Join(ErrExecutionInterrupted, err)
+	}
+
+	e.logger.Info("workflow execution checkpointed",
+		"execution_id", execution.ID,
+		"resume_from_node", nodeID,
+		"completed_steps", completedSteps,
+	)
+	return ErrExecutionInterrupted
+}
@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestInterruptAll_CheckpointsRunningExecution(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("1m")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Execute(context.Background(), execution)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, running := executor.running.Load(execution.ID)
+		return running
+	}, time.Second, 5*time.Millisecond)
+
+	// Give the delay node time to start waiting
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, executor.InterruptAll())
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrExecutionInterrupted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution was not interrupted by InterruptAll")
+	}
+
+	// The execution resumes at the interrupted node instead of failing
+	assert.Equal(t, string(workflow.ExecutionStatusPending), mockRepo.executionStatus)
+	assert.Equal(t, "delay-1", mockRepo.resumeFromNode)
+	_, ranTransform := mockRepo.stepExecutions["transform-1-step"]
+	assert.False(t, ranTransform)
+}
+
+func TestInterruptAll_NothingRunning(t *testing.T) {
+	executor, _, _ := newCancelTestExecutor("1ms")
+
+	assert.Equal(t, 0, executor.InterruptAll())
+}
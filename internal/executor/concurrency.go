@@ -0,0 +1,210 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// WorkflowConcurrency is the concurrency usage of a workflow with a
+// max_concurrency limit on this executor
+type WorkflowConcurrency struct {
+	WorkflowID string `json:"workflow_id"`
+	Limit      int    `json:"limit"`
+	Running    int    `json:"running"`
+	Waiting    int    `json:"waiting"`
+}
+
+// workflowSemaphores limits how many executions of each workflow run at once.
+// Executions over a workflow's limit wait for a slot instead of failing. The
+// zero value is ready to use.
+type workflowSemaphores struct {
+	mu    sync.Mutex
+	slots map[string]*WorkflowConcurrency
+	// released is closed and replaced whenever a slot is released, waking waiters
+	released chan struct{}
+	// observe is called with the workflow's usage after every change
+	observe func(usage WorkflowConcurrency)
+}
+
+// acquire takes a slot for workflowID, waiting while limit executions are
+// running. It returns the context's cause if ctx ends before a slot frees up.
+func (s *workflowSemaphores) acquire(ctx context.Context, workflowID string, limit int) error {
+	s.mu.Lock()
+	if s.slots == nil {
+		s.slots = make(map[string]*WorkflowConcurrency)
+	}
+	usage, ok := s.slots[workflowID]
+	if !ok {
+		usage = &WorkflowConcurrency{WorkflowID: workflowID}
+		s.slots[workflowID] = usage
+	}
+	// The limit in effect is the one loaded with the latest execution
+	usage.Limit = limit
+
+	if usage.Running < usage.Limit {
+		usage.Running++
+		s.changed(usage)
+		s.mu.Unlock()
+		return nil
+	}
+
+	usage.Waiting++
+	s.changed(usage)
+	for {
+		released := s.releasedLocked()
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			usage.Waiting--
+			s.changed(usage)
+			s.removeIfIdle(usage)
+			s.mu.Unlock()
+			return context.Cause(ctx)
+		case <-released:
+		}
+
+		s.mu.Lock()
+		if usage.Running < usage.Limit {
+			usage.Waiting--
+			usage.Running++
+			s.changed(usage)
+			s.mu.Unlock()
+			return nil
+		}
+	}
+}
+
+// release frees a slot taken by acquire
+func (s *workflowSemaphores) release(workflowID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.slots[workflowID]
+	if !ok {
+		return
+	}
+	usage.Running--
+	s.changed(usage)
+	s.removeIfIdle(usage)
+
+	if s.released != nil {
+		close(s.released)
+		s.released = nil
+	}
+}
+
+// usage returns the usage of every workflow with running or waiting executions
+func (s *workflowSemaphores) usage() []WorkflowConcurrency {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usages := make([]WorkflowConcurrency, 0, len(s.slots))
+	for _, usage := range s.slots {
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].WorkflowID < usages[j].WorkflowID })
+	return usages
+}
+
+func (s *workflowSemaphores) releasedLocked() chan struct{} {
+	if s.released == nil {
+		s.released = make(chan struct{})
+	}
+	return s.released
+}
+
+func (s *workflowSemaphores) removeIfIdle(usage *WorkflowConcurrency) {
+	if usage.Running == 0 && usage.Waiting == 0 {
+		delete(s.slots, usage.WorkflowID)
+	}
+}
+
+func (s *workflowSemaphores) changed(usage *WorkflowConcurrency) {
+	if s.observe != nil {
+		s.observe(*usage)
+	}
+}
+
+// WorkflowConcurrency returns the running and waiting executions of each
+// workflow that has a max_concurrency limit and is active on this executor
+func (e *Executor) WorkflowConcurrency() []WorkflowConcurrency {
+	return e.workflowSlots.usage()
+}
+
+// acquireWorkflowSlot waits for a free slot when the workflow has a
+// max_concurrency limit. The returned function releases the slot.
+func (e *Executor) acquireWorkflowSlot(ctx context.Context, wf *workflow.Workflow) (func(), error) {
+	if wf.MaxConcurrency == nil || *wf.MaxConcurrency <= 0 {
+		return func() {}, nil
+	}
+
+	if err := e.workflowSlots.acquire(ctx, wf.ID, *wf.MaxConcurrency); err != nil {
+		return nil, err
+	}
+	return func() { e.workflowSlots.release(wf.ID) }, nil
+}
+
+// stopWaitingExecution ends an execution whose wait for a workflow slot was
+// interrupted by cancellation or worker shutdown. No node has run yet.
+func (e *Executor) stopWaitingExecution(ctx context.Context, execution *workflow.Execution, triggerType string, startTime time.Time, err error) error {
+	if isInterrupted(ctx) {
+		// An empty node keeps the resume point of a retried or resumed execution
+		return e.checkpointExecution(execution, "", triggerType, startTime, 0)
+	}
+	if e.isCancelled(ctx, execution.ID) {
+		return e.stopCancelledExecution(execution, triggerType, startTime, 0, 0)
+	}
+
+	e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "failed", startTime)
+	return e.failExecution(context.WithoutCancel(ctx), execution, fmt.Errorf("waiting for workflow concurrency slot: %w", err))
+}
+
+// ConcurrencyKeyClaimer is implemented by repositories that can start an
+// execution while holding its workflow's concurrency key
+type ConcurrencyKeyClaimer interface {
+	StartExecutionExclusive(ctx context.Context, tenantID, id, concurrencyKey string) (bool, error)
+}
+
+// startExecution marks an execution as running. When the workflow has a
+// concurrency key it returns false if another execution holds the key.
+func (e *Executor) startExecution(ctx context.Context, execution *workflow.Execution, wf *workflow.Workflow) (bool, error) {
+	if wf.ConcurrencyKey != nil && *wf.ConcurrencyKey != "" {
+		if claimer, ok := e.repo.(ConcurrencyKeyClaimer); ok {
+			return claimer.StartExecutionExclusive(ctx, execution.TenantID, execution.ID, *wf.ConcurrencyKey)
+		}
+		e.logger.Warn("repository cannot hold concurrency keys, executions may overlap",
+			"workflow_id", wf.ID,
+			"concurrency_key", *wf.ConcurrencyKey,
+		)
+	}
+
+	if err := e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusRunning), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// skipConcurrentExecution ends an execution that did not start because
+// another execution with its concurrency key is running. No node has run.
+func (e *Executor) skipConcurrentExecution(ctx context.Context, execution *workflow.Execution, wf *workflow.Workflow, triggerType string, startTime time.Time) error {
+	errMsg := fmt.Sprintf("skipped: an execution with concurrency key %q is already running", *wf.ConcurrencyKey)
+	if err := e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusSkippedConcurrent), nil, &errMsg); err != nil {
+		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "error", startTime)
+		return err
+	}
+	e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, string(workflow.ExecutionStatusSkippedConcurrent), startTime)
+
+	e.logger.Info("workflow execution skipped, concurrency key is held",
+		"execution_id", execution.ID,
+		"workflow_id", execution.WorkflowID,
+		"concurrency_key", *wf.ConcurrencyKey,
+	)
+	return nil
+}
@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestWorkflowSemaphores_WaitsForSlot(t *testing.T) {
+	var slots workflowSemaphores
+	ctx := context.Background()
+
+	require.NoError(t, slots.acquire(ctx, "wf-1", 1))
+	// Other workflows are not limited by wf-1's slots
+	require.NoError(t, slots.acquire(ctx, "wf-2", 1))
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- slots.acquire(ctx, "wf-1", 1)
+	}()
+
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]WorkflowConcurrency{
+			{WorkflowID: "wf-1", Limit: 1, Running: 1, Waiting: 1},
+			{WorkflowID: "wf-2", Limit: 1, Running: 1},
+		}, slots.usage())
+	}, time.Second, 5*time.Millisecond)
+
+	slots.release("wf-1")
+	select {
+	case err := <-acquired:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waiting execution did not get the released slot")
+	}
+
+	slots.release("wf-1")
+	slots.release("wf-2")
+	assert.Empty(t, slots.usage())
+}
+
+func TestWorkflowSemaphores_CancelledWait(t *testing.T) {
+	var slots workflowSemaphores
+	require.NoError(t, slots.acquire(context.Background(), "wf-1", 1))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrExecutionCancelled)
+
+	err := slots.acquire(ctx, "wf-1", 1)
+
+	assert.ErrorIs(t, err, ErrExecutionCancelled)
+	assert.Equal(t, []WorkflowConcurrency{{WorkflowID: "wf-1", Limit: 1, Running: 1}}, slots.usage())
+}
+
+func TestWorkflowSemaphores_ObservesChanges(t *testing.T) {
+	var observed []WorkflowConcurrency
+	slots := workflowSemaphores{observe: func(usage WorkflowConcurrency) {
+		observed = append(observed, usage)
+	}}
+
+	require.NoError(t, slots.acquire(context.Background(), "wf-1", 2))
+	slots.release("wf-1")
+
+	assert.Equal(t, []WorkflowConcurrency{
+		{WorkflowID: "wf-1", Limit: 2, Running: 1},
+		{WorkflowID: "wf-1", Limit: 2, Running: 0},
+	}, observed)
+}
+
+// keyClaimingRepo holds concurrency keys in memory like the unique index on
+// running executions
+type keyClaimingRepo struct {
+	*mockWorkflowRepo
+	heldKeys map[string]string
+}
+
+func (r *keyClaimingRepo) StartExecutionExclusive(ctx context.Context, tenantID, id, concurrencyKey string) (bool, error) {
+	if holder, held := r.heldKeys[tenantID+"/"+concurrencyKey]; held && holder != id {
+		return false, nil
+	}
+	r.heldKeys[tenantID+"/"+concurrencyKey] = id
+	r.executionStatus = string(workflow.ExecutionStatusRunning)
+	return true, nil
+}
+
+func TestExecute_ConcurrencyKey(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("1ms")
+	key := "nightly-sync"
+	mockRepo.workflow.ConcurrencyKey = &key
+	repo := &keyClaimingRepo{mockWorkflowRepo: mockRepo, heldKeys: map[string]string{}}
+	executor.repo = repo
+
+	t.Run("runs while the key is free", func(t *testing.T) {
+		require.NoError(t, executor.Execute(context.Background(), execution))
+		assert.Equal(t, string(workflow.ExecutionStatusCompleted), mockRepo.executionStatus)
+		assert.Equal(t, "exec-1", repo.heldKeys["tenant-1/nightly-sync"])
+	})
+
+	t.Run("skips while another execution holds the key", func(t *testing.T) {
+		mockRepo.stepExecutions = make(map[string]*workflow.StepExecution)
+		overlapping := *execution
+		overlapping.ID = "exec-2"
+
+		require.NoError(t, executor.Execute(context.Background(), &overlapping))
+		assert.Equal(t, string(workflow.ExecutionStatusSkippedConcurrent), mockRepo.executionStatus)
+		assert.Empty(t, mockRepo.stepExecutions)
+	})
+}
@@ -11,11 +11,11 @@ import (
 
 // ConditionalBranchResult represents the result of evaluating a conditional node
 type ConditionalBranchResult struct {
-	Condition     string
-	Result        bool
-	TakenBranch   string // "true" or "false"
-	NextNodes     []string
-	StopExecution bool
+	Condition     string   `json:"condition"`
+	Result        bool     `json:"result"`
+	TakenBranch   string   `json:"taken_branch"` // "true" or "false"
+	NextNodes     []string `json:"next_nodes"`
+	StopExecution bool     `json:"stop_execution"`
 }
 
 // executeConditionalAction executes a conditional (if/else) action
@@ -40,11 +40,7 @@ func (e *Executor) executeConditionalAction(ctx context.Context, node workflow.N
 	evalContext := expression.BuildContext(
 		execCtx.TriggerData,
 		execCtx.StepOutputs,
-		map[string]interface{}{
-			"tenant_id":    execCtx.TenantID,
-			"execution_id": execCtx.ExecutionID,
-			"workflow_id":  execCtx.WorkflowID,
-		},
+		envContext(execCtx),
 	)
 
 	// Evaluate the condition
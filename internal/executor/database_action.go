@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/integrations/database"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// executeDatabaseAction runs a parameterized SQL statement against the
+// database connection stored in a credential
+func (e *Executor) executeDatabaseAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
+	// Check if credential service is available
+	if e.credentialService == nil {
+		return nil, fmt.Errorf("credential service not available for database actions")
+	}
+
+	// Extract config from node data
+	configData := node.Data.Config
+	if len(configData) == 0 {
+		return nil, fmt.Errorf("missing config for database action")
+	}
+
+	// Parse node config
+	var config database.SQLQueryConfig
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse database action config: %w", err)
+	}
+
+	action := database.NewSQLQueryAction(e.credentialService)
+
+	// The query text is never interpolated; only parameters are resolved
+	// against the execution context
+	input := actions.NewActionInput(config, buildInterpolationContext(execCtx))
+
+	output, err := action.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Data, nil
+}
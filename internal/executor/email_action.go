@@ -0,0 +1,33 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/executor/actions/communication"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// executeEmailAction sends an email through the provider named in the node config
+func (e *Executor) executeEmailAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
+	// Check if credential service is available
+	if e.credentialService == nil {
+		return nil, fmt.Errorf("credential service not available for email actions")
+	}
+
+	// Extract config from node data
+	configData := node.Data.Config
+	if len(configData) == 0 {
+		return nil, fmt.Errorf("missing config for email action")
+	}
+
+	// Parse node config
+	config, err := communication.ParseSendEmailConfig(configData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email action config: %w", err)
+	}
+
+	action := communication.NewSendEmailAction(config, e.credentialService)
+
+	return action.Execute(ctx, buildInterpolationContext(execCtx))
+}
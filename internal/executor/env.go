@@ -0,0 +1,43 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+)
+
+// EnvResolver returns the environment variables configured for a workflow,
+// with the workflow's own variables overriding the tenant's
+type EnvResolver interface {
+	ResolveEnv(ctx context.Context, tenantID, workflowID string) (map[string]string, error)
+}
+
+// SetEnvResolver sets the resolver for the variables exposed to executions as
+// ${env.KEY}. Without one, only the built-in execution variables are set.
+func (e *Executor) SetEnvResolver(resolver EnvResolver) {
+	e.envResolver = resolver
+}
+
+// loadEnv resolves the configured variables for an execution of the workflow
+func (e *Executor) loadEnv(ctx context.Context, tenantID, workflowID string) (map[string]string, error) {
+	if e.envResolver == nil {
+		return nil, nil
+	}
+	env, err := e.envResolver.ResolveEnv(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve environment variables: %w", err)
+	}
+	return env, nil
+}
+
+// envContext returns the env section of the expression and interpolation
+// context. The built-in execution variables take precedence over configured ones.
+func envContext(execCtx *ExecutionContext) map[string]interface{} {
+	env := make(map[string]interface{}, len(execCtx.Env)+3)
+	for key, value := range execCtx.Env {
+		env[key] = value
+	}
+	env["tenant_id"] = execCtx.TenantID
+	env["execution_id"] = execCtx.ExecutionID
+	env["workflow_id"] = execCtx.WorkflowID
+	return env
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// fakeEnvResolver returns fixed variables and records the workflow it resolved for
+type fakeEnvResolver struct {
+	env        map[string]string
+	err        error
+	workflowID string
+}
+
+func (f *fakeEnvResolver) ResolveEnv(ctx context.Context, tenantID, workflowID string) (map[string]string, error) {
+	f.workflowID = workflowID
+	return f.env, f.err
+}
+
+func TestSimulate_EnvVariables(t *testing.T) {
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "trigger", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "Trigger"}},
+			{
+				ID:   "build",
+				Type: string(workflow.NodeTypeActionTransform),
+				Data: workflow.NodeData{Name: "Build", Config: mustMarshal(workflow.TransformActionConfig{
+					Mapping: map[string]interface{}{
+						"url":       "${env.API_BASE_URL}/orders",
+						"tenant_id": "${env.tenant_id}",
+					},
+				})},
+			},
+		},
+		Edges: []workflow.Edge{{ID: "e1", Source: "trigger", Target: "build"}},
+	}
+	wf := &workflow.Workflow{ID: "wf-1", TenantID: "tenant-1", Definition: mustMarshal(definition)}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	t.Run("configured variables are interpolated and built-ins win", func(t *testing.T) {
+		resolver := &fakeEnvResolver{env: map[string]string{
+			"API_BASE_URL": "https://staging.example.com",
+			"tenant_id":    "spoofed",
+		}}
+		executor := &Executor{logger: logger}
+		executor.SetEnvResolver(resolver)
+
+		result, err := executor.Simulate(context.Background(), wf, nil, nil)
+
+		require.NoError(t, err)
+		require.Empty(t, result.Error)
+		assert.Equal(t, "wf-1", resolver.workflowID)
+		assert.Equal(t, map[string]interface{}{
+			"url":       "https://staging.example.com/orders",
+			"tenant_id": "tenant-1",
+		}, result.StepOutputs["build"])
+	})
+
+	t.Run("resolver error fails the run", func(t *testing.T) {
+		executor := &Executor{logger: logger}
+		executor.SetEnvResolver(&fakeEnvResolver{err: errors.New("db down")})
+
+		_, err := executor.Simulate(context.Background(), wf, nil, nil)
+
+		assert.ErrorContains(t, err, "failed to resolve environment variables")
+	})
+}
@@ -77,6 +77,12 @@ func ClassifyError(err error) ErrorClassification {
 		return ErrorClassificationUnknown
 	}
 
+	// An ExecutionError that was already classified keeps its classification
+	var execErr *ExecutionError
+	if errors.As(err, &execErr) && execErr.Classification != ErrorClassificationUnknown {
+		return execErr.Classification
+	}
+
 	// Check for timeout errors
 	if errors.Is(err, syscall.ETIMEDOUT) {
 		return ErrorClassificationTransient
@@ -196,6 +202,7 @@ func ClassifyError(err error) ErrorClassification {
 		"precondition failed",
 		"unprocessable entity",
 		"payload too large",
+		"exceeds max_response_bytes",
 		"uri too long",
 		"expectation failed",
 	}
@@ -210,6 +217,18 @@ func ClassifyError(err error) ErrorClassification {
 	return ErrorClassificationUnknown
 }
 
+// IsTimeout reports whether err was caused by an operation exceeding its timeout
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ETIMEDOUT) {
+		return true
+	}
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
+
 // ClassifyHTTPStatusCode classifies an HTTP status code
 func ClassifyHTTPStatusCode(statusCode int) ErrorClassification {
 	// 2xx and 3xx are successful, not errors
@@ -3,10 +3,13 @@ package executor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"syscall"
 	"testing"
+
+	"github.com/gorax/gorax/internal/executor/actions"
 )
 
 func TestClassifyError(t *testing.T) {
@@ -30,6 +33,14 @@ func TestClassifyError(t *testing.T) {
 			err:           context.Canceled,
 			expectedClass: ErrorClassificationPermanent,
 		},
+		{
+			name: "execution error keeps its classification",
+			err: &ExecutionError{
+				Err:            errors.New("script execution timeout"),
+				Classification: ErrorClassificationPermanent,
+			},
+			expectedClass: ErrorClassificationPermanent,
+		},
 		{
 			name:          "connection refused",
 			err:           syscall.ECONNREFUSED,
@@ -85,6 +96,11 @@ func TestClassifyError(t *testing.T) {
 			err:           errors.New("bad request"),
 			expectedClass: ErrorClassificationPermanent,
 		},
+		{
+			name:          "response too large",
+			err:           &actions.HTTPResponseTooLargeError{Limit: 1024},
+			expectedClass: ErrorClassificationPermanent,
+		},
 		{
 			name:          "unknown error",
 			err:           errors.New("something went wrong"),
@@ -344,6 +360,28 @@ func TestExecutionErrorWithContext(t *testing.T) {
 	}
 }
 
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "wrapped etimedout", err: fmt.Errorf("dial: %w", syscall.ETIMEDOUT), want: true},
+		{name: "net timeout", err: &net.DNSError{IsTimeout: true}, want: true},
+		{name: "plain error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTimeout(tt.err); got != tt.want {
+				t.Errorf("IsTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestClassifyNetworkErrors(t *testing.T) {
 	// Create a timeout error
 	timeoutErr := &net.OpError{
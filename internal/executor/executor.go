@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/executor/actions"
 	"github.com/gorax/gorax/internal/executor/javascript"
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
 	"github.com/gorax/gorax/internal/tracing"
 	"github.com/gorax/gorax/internal/workflow"
 )
@@ -34,6 +37,10 @@ type WorkflowRepository interface {
 	UpdateExecutionStatus(ctx context.Context, id string, status string, outputData json.RawMessage, errorMsg *string) error
 	CreateStepExecution(ctx context.Context, executionID, nodeID, nodeType string, inputData []byte) (*workflow.StepExecution, error)
 	UpdateStepExecution(ctx context.Context, id, status string, outputData json.RawMessage, errorMsg *string) error
+	UpdateExecutionTags(ctx context.Context, id string, tags map[string]string) error
+	IsExecutionCancelled(ctx context.Context, id string) (bool, error)
+	GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*workflow.StepExecution, error)
+	CheckpointExecution(ctx context.Context, id, resumeFromNodeID string) error
 }
 
 // workflowRepoAdapter adapts *workflow.Repository to WorkflowRepository interface
@@ -57,27 +64,64 @@ func (a *workflowRepoAdapter) UpdateStepExecution(ctx context.Context, id, statu
 	return a.repo.UpdateStepExecution(ctx, id, status, []byte(outputData), errorMsg)
 }
 
+func (a *workflowRepoAdapter) UpdateExecutionTags(ctx context.Context, id string, tags map[string]string) error {
+	return a.repo.UpdateExecutionTags(ctx, id, tags)
+}
+
+func (a *workflowRepoAdapter) IsExecutionCancelled(ctx context.Context, id string) (bool, error) {
+	return a.repo.IsExecutionCancelled(ctx, id)
+}
+
+func (a *workflowRepoAdapter) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*workflow.StepExecution, error) {
+	return a.repo.GetStepExecutionsByExecutionID(ctx, executionID)
+}
+
+func (a *workflowRepoAdapter) StartExecutionExclusive(ctx context.Context, tenantID, id, concurrencyKey string) (bool, error) {
+	return a.repo.StartExecutionExclusive(ctx, tenantID, id, concurrencyKey)
+}
+
+func (a *workflowRepoAdapter) CheckpointExecution(ctx context.Context, id, resumeFromNodeID string) error {
+	return a.repo.CheckpointExecution(ctx, id, resumeFromNodeID)
+}
+
 // Executor handles workflow execution
 type Executor struct {
-	repo               WorkflowRepository
-	logger             *slog.Logger
-	broadcaster        Broadcaster
-	retryStrategy      *RetryStrategy
-	circuitBreakers    *CircuitBreakerRegistry
-	defaultRetryConfig NodeRetryConfig
-	credentialInjector *credential.Injector // Optional credential injector
-	credentialService  credential.Service   // Optional credential service for Slack actions
-	formulaEvaluator   FormulaEvaluator     // Optional cached formula evaluator
-	jsEngine           *javascript.Engine   // Sandboxed JavaScript execution engine
-	metrics            MetricsRecorder      // Optional metrics recorder
+	repo                 WorkflowRepository
+	logger               *slog.Logger
+	broadcaster          Broadcaster
+	retryStrategy        *RetryStrategy
+	circuitBreakers      *CircuitBreakerRegistry
+	defaultRetryConfig   NodeRetryConfig
+	credentialInjector   *credential.Injector  // Optional credential injector
+	credentialService    credential.Service    // Optional credential service for Slack actions
+	formulaEvaluator     FormulaEvaluator      // Optional cached formula evaluator
+	jsEngine             *javascript.Engine    // Sandboxed JavaScript execution engine
+	metrics              MetricsRecorder       // Optional metrics recorder
+	httpOptions          actions.HTTPOptions   // Process-wide HTTP action settings
+	running              sync.Map              // Execution ID -> context.CancelCauseFunc for executions running here
+	workflowSlots        workflowSemaphores    // Per-workflow max_concurrency limits
+	failureHandler       FailureHandler        // Optional handler told about failed executions
+	redactor             *Redactor             // Optional redactor for persisted step data; defaults are used when nil
+	envResolver          EnvResolver           // Optional source of configured ${env.KEY} variables
+	maxExecutionDuration time.Duration         // Ceiling on execution run time; 0 means none
+	featureFlags         FeatureFlagChecker    // Optional gate for experimental node types
+	splitRoll            SplitRollFunc         // Bucket draw for split nodes; seeded from the execution ID when nil
+	slackInteractions    SlackInteractionStore // Optional store for responses to Slack message buttons
+	nodeCache            NodeResultCache       // Optional store for results of nodes that opt in to caching
+	slackPollInterval    time.Duration         // How often slack:wait_for_response checks for a response; 2s when zero
+	scriptEngine         *javascript.Engine    // Strictly limited engine for action:script, created on first use
+	scriptEngineErr      error                 // Why scriptEngine could not be created
+	scriptEngineOnce     sync.Once
 }
 
 // MetricsRecorder defines the interface for recording execution metrics
 type MetricsRecorder interface {
+	RecordWorkflowExecutionStarted(tenantID, workflowID, triggerType string)
 	RecordWorkflowExecution(tenantID, workflowID, triggerType, status string, durationSeconds float64)
 	RecordStepExecution(tenantID, workflowID, stepType, status string, durationSeconds float64)
 	IncActiveWorkflowExecutions(tenantID, workflowID, triggerType string)
 	DecActiveWorkflowExecutions(tenantID, workflowID, triggerType string)
+	SetWorkflowConcurrency(workflowID string, running, waiting int)
 }
 
 // FormulaEvaluator interface for formula evaluation (allows both cached and uncached)
@@ -201,6 +245,33 @@ func NewWithCachedEvaluator(repo WorkflowRepository, logger *slog.Logger, broadc
 // SetMetrics sets the metrics recorder for the executor
 func (e *Executor) SetMetrics(m MetricsRecorder) {
 	e.metrics = m
+	e.workflowSlots.mu.Lock()
+	e.workflowSlots.observe = func(usage WorkflowConcurrency) {
+		m.SetWorkflowConcurrency(usage.WorkflowID, usage.Running, usage.Waiting)
+	}
+	e.workflowSlots.mu.Unlock()
+}
+
+// SetRedactor sets the redactor applied to step data before it is persisted,
+// broadcast or logged
+func (e *Executor) SetRedactor(r *Redactor) {
+	e.redactor = r
+}
+
+// defaultRedactor redacts with DefaultSensitiveKeys
+var defaultRedactor = NewRedactor(nil)
+
+// stepRedactor returns the configured redactor or the default one
+func (e *Executor) stepRedactor() *Redactor {
+	if e.redactor != nil {
+		return e.redactor
+	}
+	return defaultRedactor
+}
+
+// SetHTTPOptions sets the process-wide options applied to HTTP action nodes
+func (e *Executor) SetHTTPOptions(options actions.HTTPOptions) {
+	e.httpOptions = options
 }
 
 // ExecutionContext holds context for a workflow execution
@@ -211,11 +282,13 @@ type ExecutionContext struct {
 	TriggerType       string
 	TriggerData       map[string]interface{}
 	StepOutputs       map[string]interface{}
-	CredentialValues  []string // Decrypted credential values for masking
-	UserID            string   // User who triggered the execution
-	Depth             int      // Execution depth for sub-workflow tracking
-	WorkflowChain     []string // Chain of workflow IDs to detect circular dependencies
-	ParentExecutionID string   // Parent execution ID for sub-workflows
+	CredentialValues  []string                    // Decrypted credential values for masking
+	CredentialCache   *credential.ResolutionCache // Credentials decrypted during this execution
+	UserID            string                      // User who triggered the execution
+	Depth             int                         // Execution depth for sub-workflow tracking
+	WorkflowChain     []string                    // Chain of workflow IDs to detect circular dependencies
+	ParentExecutionID string                      // Parent execution ID for sub-workflows
+	Env               map[string]string           // Configured ${env.KEY} variables
 }
 
 // GetUserID returns the user ID from the execution context
@@ -252,6 +325,9 @@ func (ec *ExecutionContext) SetUserID(userID string) {
 
 // Execute runs a workflow execution
 func (e *Executor) Execute(ctx context.Context, execution *workflow.Execution) error {
+	// Repository lookups by execution ID route to the tenant's data region
+	ctx = tenantctx.WithTenantID(ctx, execution.TenantID)
+
 	// Wrap the entire execution with tracing
 	return tracing.TraceWorkflowExecution(ctx, execution.TenantID, execution.WorkflowID, execution.ID, func(tracedCtx context.Context) error {
 		return e.executeInternal(tracedCtx, execution)
@@ -264,14 +340,21 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 	startTime := time.Now()
 	triggerType := string(execution.TriggerType)
 
+	// Register the execution so Cancel can interrupt it
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	e.running.Store(execution.ID, cancel)
+	defer e.running.Delete(execution.ID)
+
 	e.logger.Info("starting workflow execution",
 		"execution_id", execution.ID,
 		"workflow_id", execution.WorkflowID,
 		"trace_id", tracing.GetTraceID(ctx),
 	)
 
-	// Increment active executions gauge
+	// Count the start and increment active executions gauge
 	if e.metrics != nil {
+		e.metrics.RecordWorkflowExecutionStarted(execution.TenantID, execution.WorkflowID, triggerType)
 		e.metrics.IncActiveWorkflowExecutions(execution.TenantID, execution.WorkflowID, triggerType)
 	}
 
@@ -282,12 +365,6 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 		"tenant_id":    execution.TenantID,
 	})
 
-	// Update status to running
-	if err := e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusRunning), nil, nil); err != nil {
-		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "error", startTime)
-		return err
-	}
-
 	// Load workflow definition
 	wf, err := e.repo.GetByID(ctx, execution.TenantID, execution.WorkflowID)
 	if err != nil {
@@ -295,6 +372,31 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 		return e.failExecution(ctx, execution)
 	}
 
+	// Wait for a slot when the workflow limits its concurrent executions
+	releaseSlot, err := e.acquireWorkflowSlot(ctx, wf)
+	if err != nil {
+		return e.stopWaitingExecution(ctx, execution, triggerType, startTime, err)
+	}
+	defer releaseSlot()
+
+	// Update status to running, holding the workflow's concurrency key if it has one
+	started, err := e.startExecution(ctx, execution, wf)
+	if err != nil {
+		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "error", startTime)
+		return err
+	}
+	if !started {
+		return e.skipConcurrentExecution(ctx, execution, wf, triggerType, startTime)
+	}
+
+	// Stop the execution, including any node in flight, once it runs too long
+	timeoutLimit := e.executionTimeout(wf)
+	if timeoutLimit > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, timeoutLimit, ErrExecutionTimeout)
+		defer cancelTimeout()
+	}
+
 	// Parse workflow definition
 	var definition workflow.WorkflowDefinition
 	if err := json.Unmarshal(wf.Definition, &definition); err != nil {
@@ -339,6 +441,7 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 		TriggerData:       triggerData,
 		StepOutputs:       make(map[string]interface{}),
 		CredentialValues:  []string{}, // Will be populated during execution
+		CredentialCache:   credential.NewResolutionCache(),
 		Depth:             execution.ExecutionDepth,
 		WorkflowChain:     []string{execution.WorkflowID},
 		ParentExecutionID: "",
@@ -349,6 +452,20 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 		execCtx.ParentExecutionID = *execution.ParentExecutionID
 	}
 
+	env, err := e.loadEnv(ctx, execution.TenantID, execution.WorkflowID)
+	if err != nil {
+		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "failed", startTime)
+		return e.failExecution(ctx, execution, err)
+	}
+	execCtx.Env = env
+
+	// A retried execution reuses the outputs of the steps before its resume node
+	resumedOutputs, err := e.loadResumedOutputs(ctx, execution)
+	if err != nil {
+		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "failed", startTime)
+		return e.failExecution(ctx, execution, err)
+	}
+
 	// Build execution order from DAG
 	nodeMap := buildNodeMap(definition.Nodes)
 	executionOrder, err := topologicalSort(definition.Nodes, definition.Edges)
@@ -363,8 +480,14 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 		"order", executionOrder,
 	)
 
-	// Execute nodes in order
+	// Start from the trigger that fired when the workflow has several
+	entryTrigger := workflow.EntryTrigger(&definition, execution)
+
+	// Execute nodes in order, skipping those only reachable through branches
+	// that if and switch nodes did not take
 	completedSteps := 0
+	skippedNodes := make(map[string]bool)
+	takenBranches := make(map[string]string)
 	for _, nodeID := range executionOrder {
 		node, exists := nodeMap[nodeID]
 		if !exists {
@@ -372,15 +495,55 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 			continue
 		}
 
+		// Checkpoint before the next node if the worker is shutting down
+		if isInterrupted(ctx) {
+			return e.checkpointExecution(execution, node.ID, triggerType, startTime, completedSteps)
+		}
+
+		if isTimedOut(ctx) {
+			e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "timeout", startTime)
+			return e.failTimedOutExecution(ctx, execution, execCtx, &executionTimeoutError{
+				limit: timeoutLimit, completedSteps: completedSteps, totalSteps: totalSteps,
+			}, nil)
+		}
+
+		// Stop before the next node if the execution was cancelled, here or on another worker
+		if e.isCancelled(ctx, execution.ID) {
+			return e.stopCancelledExecution(execution, triggerType, startTime, completedSteps, totalSteps)
+		}
+
 		e.logger.Info("executing node", "node_id", node.ID, "node_type", node.Type)
 
-		// Skip triggers (they've already fired)
+		// Skip triggers (they've already fired). Only the trigger that fired
+		// passes on the trigger data; nodes reached only from its siblings
+		// are skipped with them.
 		if isTriggerNode(node.Type) {
+			if entryTrigger != "" && node.ID != entryTrigger {
+				skippedNodes[node.ID] = true
+				e.logger.Info("skipping sibling trigger node", "node_id", node.ID, "entry_trigger", entryTrigger)
+				continue
+			}
 			execCtx.StepOutputs[node.ID] = triggerData
 			e.logger.Info("skipping trigger node", "node_id", node.ID)
 			continue
 		}
 
+		if isOnUntakenBranch(node.ID, definition.Edges, skippedNodes, takenBranches) {
+			skippedNodes[node.ID] = true
+			e.logger.Info("skipping node on untaken branch", "node_id", node.ID)
+			continue
+		}
+
+		if output, ok := resumedOutputs[node.ID]; ok {
+			execCtx.StepOutputs[node.ID] = output
+			if label, ok := branchTaken(output); ok && isBranchingNode(node.Type) {
+				takenBranches[node.ID] = label
+			}
+			completedSteps++
+			e.logger.Info("reusing output of resumed node", "node_id", node.ID)
+			continue
+		}
+
 		// Broadcast step started
 		if e.broadcaster != nil {
 			e.broadcaster.BroadcastStepStarted(execution.TenantID, execution.WorkflowID, execution.ID, node.ID, node.Type)
@@ -398,6 +561,12 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 			func(tracedCtx context.Context) (interface{}, error) {
 				// Handle control nodes specially (they need workflow definition)
 				switch node.Type {
+				case string(workflow.NodeTypeControlIf):
+					return e.executeConditionalAction(tracedCtx, node, execCtx, &definition)
+				case string(workflow.NodeTypeControlSwitch):
+					return e.executeSwitchAction(tracedCtx, node, execCtx, &definition)
+				case string(workflow.NodeTypeControlSplit):
+					return e.executeSplitAction(tracedCtx, node, execCtx, &definition)
 				case string(workflow.NodeTypeControlLoop):
 					return e.executeLoopAction(tracedCtx, node, execCtx, &definition)
 				case string(workflow.NodeTypeControlParallel):
@@ -412,27 +581,48 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 			},
 		)
 		durationMs := int(time.Since(startTime).Milliseconds())
+		e.recordStepMetrics(execution.TenantID, execution.WorkflowID, node.Type, err, startTime)
+
+		if err != nil && isInterrupted(ctx) {
+			return e.checkpointExecution(execution, node.ID, triggerType, startTime, completedSteps)
+		}
+
+		if err != nil && isTimedOut(ctx) {
+			e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "timeout", startTime)
+			return e.failTimedOutExecution(ctx, execution, execCtx, &executionTimeoutError{
+				limit: timeoutLimit, completedSteps: completedSteps, totalSteps: totalSteps, nodeID: node.ID,
+			}, &node)
+		}
+
+		if err != nil && e.isCancelled(ctx, execution.ID) {
+			return e.stopCancelledExecution(execution, triggerType, startTime, completedSteps, totalSteps)
+		}
 
 		if err != nil {
+			redactedErr := e.stepRedactor().RedactString(err.Error(), execCtx.CredentialValues)
 			e.logger.Error("node execution failed",
 				"node_id", node.ID,
-				"error", err,
+				"error", redactedErr,
 				"trace_id", tracing.GetTraceID(ctx),
 			)
 			// Broadcast step failure
 			if e.broadcaster != nil {
-				e.broadcaster.BroadcastStepFailed(execution.TenantID, execution.WorkflowID, execution.ID, node.ID, err.Error())
+				e.broadcaster.BroadcastStepFailed(execution.TenantID, execution.WorkflowID, execution.ID, node.ID, redactedErr)
 			}
 			e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "failed", startTime)
-			return e.failExecution(ctx, execution, fmt.Errorf("node %s failed: %w", node.ID, err))
+			return e.failExecution(ctx, execution, &nodeFailedError{nodeID: node.ID, nodeType: node.Type, err: err})
 		}
 
 		// Store output for downstream nodes
 		execCtx.StepOutputs[node.ID] = output
+		if label, ok := branchTaken(output); ok && isBranchingNode(node.Type) {
+			takenBranches[node.ID] = label
+		}
 
 		// Broadcast step completion
 		if e.broadcaster != nil {
 			outputJSON, _ := json.Marshal(output)
+			outputJSON = e.stepRedactor().RedactJSON(outputJSON, execCtx.CredentialValues)
 			e.broadcaster.BroadcastStepCompleted(execution.TenantID, execution.WorkflowID, execution.ID, node.ID, outputJSON, durationMs)
 		}
 
@@ -445,6 +635,7 @@ func (e *Executor) executeInternal(ctx context.Context, execution *workflow.Exec
 
 	// Mark execution as completed
 	outputData, _ := json.Marshal(execCtx.StepOutputs)
+	outputData = e.stepRedactor().RedactJSON(outputData, execCtx.CredentialValues)
 	if err := e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusCompleted), outputData, nil); err != nil {
 		e.recordExecutionMetrics(execution.TenantID, execution.WorkflowID, triggerType, "error", startTime)
 		return err
@@ -479,6 +670,7 @@ func (e *Executor) executeNodeWithTracking(ctx context.Context, node workflow.No
 	// Prepare input data for the node
 	inputData := buildInputData(execCtx)
 	inputDataJSON, _ := json.Marshal(inputData)
+	inputDataJSON = e.stepRedactor().RedactJSON(inputDataJSON, execCtx.CredentialValues)
 
 	// Create step execution record
 	stepExecution, err := e.repo.CreateStepExecution(ctx, execCtx.ExecutionID, node.ID, node.Type, inputDataJSON)
@@ -505,7 +697,17 @@ func (e *Executor) executeNodeWithTracking(ctx context.Context, node workflow.No
 	var execErr error
 	retryCount := 0
 
-	if retryConfig.Enabled {
+	// Nodes that opt in to caching reuse a fresh result instead of running
+	cacheSlot := e.nodeCacheSlotFor(node, execCtx)
+	cached := false
+	if cacheSlot != nil {
+		output, cached = e.cachedNodeResult(ctx, node, execCtx, cacheSlot)
+	}
+
+	switch {
+	case cached:
+		// The cached result stands in for running the node
+	case retryConfig.Enabled:
 		// Create retry strategy for this node
 		nodeRetryStrategy := NewRetryStrategy(retryConfig.RetryConfig, e.logger)
 
@@ -523,11 +725,15 @@ func (e *Executor) executeNodeWithTracking(ctx context.Context, node workflow.No
 		})
 		output = result
 		execErr = err
-	} else {
+	default:
 		// Execute without retry
 		output, execErr = e.executeNode(ctx, node, execCtx)
 	}
 
+	if cacheSlot != nil && !cached && execErr == nil {
+		e.cacheNodeResult(ctx, node, execCtx, cacheSlot, output)
+	}
+
 	// Wrap error with execution context
 	if execErr != nil {
 		execErr = WrapError(execErr, node.ID, node.Type, retryCount)
@@ -552,15 +758,28 @@ func (e *Executor) executeNodeWithTracking(ctx context.Context, node workflow.No
 				case ErrorClassificationPermanent:
 					classificationStr = "permanent"
 				}
-				detailedErr := fmt.Sprintf("%s (classification: %s, retry_count: %d)", errStr, classificationStr, retryCount)
+				detailedErr := fmt.Sprintf("%s (classification: %s, timed_out: %t, retry_count: %d)", errStr, classificationStr, IsTimeout(execErr), retryCount)
 				errorMsg = &detailedErr
 			}
+
+			// Record timeouts in the step output so they can be told apart from other failures
+			if output == nil && IsTimeout(execErr) {
+				output = map[string]interface{}{"timed_out": true}
+			}
 		} else {
 			status = "completed"
 		}
 
+		// Redact secrets before the step result is persisted
+		redactor := e.stepRedactor()
 		outputDataJSON, _ := json.Marshal(output)
-		if err := e.repo.UpdateStepExecution(ctx, stepExecution.ID, status, outputDataJSON, errorMsg); err != nil {
+		outputDataJSON = redactor.RedactJSON(outputDataJSON, execCtx.CredentialValues)
+		if errorMsg != nil {
+			redactedMsg := redactor.RedactString(*errorMsg, execCtx.CredentialValues)
+			errorMsg = &redactedMsg
+		}
+		// Record the result even if the execution was stopped while the node ran
+		if err := e.repo.UpdateStepExecution(context.WithoutCancel(ctx), stepExecution.ID, status, outputDataJSON, errorMsg); err != nil {
 			e.logger.Error("failed to update step execution record", "error", err, "step_id", stepExecution.ID)
 		}
 	}
@@ -597,9 +816,12 @@ func (e *Executor) parseRetryConfig(data interface{}) NodeRetryConfig {
 func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
 	startTime := time.Now()
 
+	if err := e.checkNodeTypeEnabled(ctx, node, execCtx.TenantID); err != nil {
+		return nil, err
+	}
+
 	// Inject credentials if injector is available
 	nodeToExecute := node
-	var credentialValues []string
 
 	if e.credentialInjector != nil && len(node.Data.Config) > 0 {
 		injCtx := &credential.InjectionContext{
@@ -607,6 +829,7 @@ func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx
 			WorkflowID:  execCtx.WorkflowID,
 			ExecutionID: execCtx.ExecutionID,
 			AccessedBy:  execCtx.GetUserID(),
+			Cache:       execCtx.CredentialCache,
 		}
 
 		injectResult, err := e.credentialInjector.InjectCredentials(ctx, node.Data.Config, injCtx)
@@ -619,8 +842,7 @@ func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx
 		nodeToExecute.Data.Config = injectResult.Config
 
 		// Store credential values for masking
-		credentialValues = injectResult.Values
-		execCtx.CredentialValues = append(execCtx.CredentialValues, credentialValues...)
+		execCtx.CredentialValues = append(execCtx.CredentialValues, injectResult.Values...)
 	}
 
 	var output interface{}
@@ -635,6 +857,10 @@ func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx
 		output, err = e.executeFormulaAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeActionCode):
 		output, err = e.executeCodeAction(ctx, nodeToExecute, execCtx)
+	case string(workflow.NodeTypeActionScript):
+		output, err = e.executeScriptAction(ctx, nodeToExecute, execCtx)
+	case string(workflow.NodeTypeActionEmail):
+		output, err = e.executeEmailAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeActionSlackSendMessage):
 		output, err = e.executeSlackSendMessageAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeActionSlackSendDM):
@@ -643,8 +869,14 @@ func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx
 		output, err = e.executeSlackUpdateMessageAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeActionSlackAddReaction):
 		output, err = e.executeSlackAddReactionAction(ctx, nodeToExecute, execCtx)
+	case string(workflow.NodeTypeActionSlackWaitForResponse):
+		output, err = e.executeSlackWaitForResponseAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeActionSubworkflow):
 		output, err = e.executeSubWorkflowAction(ctx, nodeToExecute, execCtx)
+	case string(workflow.NodeTypeActionSetTags):
+		output, err = e.executeSetTagsAction(ctx, nodeToExecute, execCtx)
+	case string(workflow.NodeTypeActionDatabase):
+		output, err = e.executeDatabaseAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeControlDelay):
 		output, err = e.executeDelayAction(ctx, nodeToExecute, execCtx)
 	case string(workflow.NodeTypeControlSubWorkflow):
@@ -667,9 +899,11 @@ func (e *Executor) executeNode(ctx context.Context, node workflow.Node, execCtx
 		err = fmt.Errorf("unknown node type: %s", nodeToExecute.Type)
 	}
 
-	// Mask credentials in output if any were injected
-	if len(credentialValues) > 0 && e.credentialInjector != nil {
-		output = e.credentialInjector.MaskOutput(output, credentialValues)
+	// Mask every credential resolved so far in this execution, since a node
+	// may echo a secret injected into an earlier step
+	if len(execCtx.CredentialValues) > 0 && e.credentialInjector != nil {
+		output = e.credentialInjector.MaskOutput(output, execCtx.CredentialValues)
+		err = e.credentialInjector.MaskError(err, execCtx.CredentialValues)
 	}
 
 	duration := time.Since(startTime)
@@ -691,6 +925,7 @@ func (e *Executor) failExecution(ctx context.Context, execution *workflow.Execut
 	} else {
 		errMsg = "execution failed"
 	}
+	errMsg = e.stepRedactor().RedactString(errMsg, nil)
 
 	e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusFailed), nil, &errMsg)
 
@@ -699,6 +934,12 @@ func (e *Executor) failExecution(ctx context.Context, execution *workflow.Execut
 		e.broadcaster.BroadcastExecutionFailed(execution.TenantID, execution.WorkflowID, execution.ID, errMsg)
 	}
 
+	var cause error
+	if len(err) > 0 {
+		cause = err[0]
+	}
+	e.notifyFailure(ctx, execution, errMsg, cause)
+
 	if len(err) > 0 && err[0] != nil {
 		return err[0]
 	}
@@ -715,6 +956,18 @@ func (e *Executor) recordExecutionMetrics(tenantID, workflowID, triggerType, sta
 	e.metrics.DecActiveWorkflowExecutions(tenantID, workflowID, triggerType)
 }
 
+// recordStepMetrics records the outcome and duration of a node execution
+func (e *Executor) recordStepMetrics(tenantID, workflowID, nodeType string, err error, startTime time.Time) {
+	if e.metrics == nil {
+		return
+	}
+	status := "completed"
+	if err != nil {
+		status = "failed"
+	}
+	e.metrics.RecordStepExecution(tenantID, workflowID, nodeType, status, time.Since(startTime).Seconds())
+}
+
 // Helper functions
 
 func buildNodeMap(nodes []workflow.Node) map[string]workflow.Node {
@@ -782,10 +1035,6 @@ func buildInputData(execCtx *ExecutionContext) map[string]interface{} {
 	return map[string]interface{}{
 		"trigger": execCtx.TriggerData,
 		"steps":   execCtx.StepOutputs,
-		"env": map[string]interface{}{
-			"tenant_id":    execCtx.TenantID,
-			"execution_id": execCtx.ExecutionID,
-			"workflow_id":  execCtx.WorkflowID,
-		},
+		"env":     envContext(execCtx),
 	}
 }
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// FailureHandler is told when an execution ends in failed status, e.g. to
+// dead-letter it and start the workflow's on-failure workflow
+type FailureHandler interface {
+	HandleExecutionFailure(ctx context.Context, execution *workflow.Execution, failure workflow.ExecutionFailure)
+}
+
+// SetFailureHandler sets the handler told about failed executions
+func (e *Executor) SetFailureHandler(h FailureHandler) {
+	e.failureHandler = h
+}
+
+// nodeFailedError is the error an execution fails with when one of its nodes
+// fails after any retries
+type nodeFailedError struct {
+	nodeID   string
+	nodeType string
+	err      error
+}
+
+func (e *nodeFailedError) Error() string {
+	return fmt.Sprintf("node %s failed: %v", e.nodeID, e.err)
+}
+
+func (e *nodeFailedError) Unwrap() error {
+	return e.err
+}
+
+// notifyFailure passes a failed execution to the failure handler
+func (e *Executor) notifyFailure(ctx context.Context, execution *workflow.Execution, errMsg string, err error) {
+	if e.failureHandler == nil {
+		return
+	}
+
+	failure := workflow.ExecutionFailure{Reason: errMsg}
+	var nodeErr *nodeFailedError
+	if errors.As(err, &nodeErr) {
+		failure.NodeID = nodeErr.nodeID
+		failure.NodeType = nodeErr.nodeType
+	}
+
+	// The execution has already failed; handling must not be cut short by its context
+	e.failureHandler.HandleExecutionFailure(context.WithoutCancel(ctx), execution, failure)
+}
@@ -0,0 +1,44 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// recordingFailureHandler records the failures it is told about
+type recordingFailureHandler struct {
+	failures []workflow.ExecutionFailure
+}
+
+func (h *recordingFailureHandler) HandleExecutionFailure(ctx context.Context, execution *workflow.Execution, failure workflow.ExecutionFailure) {
+	h.failures = append(h.failures, failure)
+}
+
+func TestFailureHandler_ReceivesFailedNode(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("not-a-duration")
+	handler := &recordingFailureHandler{}
+	executor.SetFailureHandler(handler)
+
+	err := executor.Execute(context.Background(), execution)
+
+	require.Error(t, err)
+	assert.Equal(t, string(workflow.ExecutionStatusFailed), mockRepo.executionStatus)
+	require.Len(t, handler.failures, 1)
+	assert.Equal(t, "delay-1", handler.failures[0].NodeID)
+	assert.Equal(t, string(workflow.NodeTypeControlDelay), handler.failures[0].NodeType)
+	assert.Contains(t, handler.failures[0].Reason, "node delay-1 failed")
+}
+
+func TestFailureHandler_NotCalledOnSuccess(t *testing.T) {
+	executor, _, execution := newCancelTestExecutor("1ms")
+	handler := &recordingFailureHandler{}
+	executor.SetFailureHandler(handler)
+
+	require.NoError(t, executor.Execute(context.Background(), execution))
+	assert.Empty(t, handler.failures)
+}
@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/featureflag"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// ErrNodeTypeDisabled is returned when a node's type is behind a feature flag
+// that is off for the execution's tenant
+var ErrNodeTypeDisabled = errors.New("node type is not enabled for this tenant")
+
+// FeatureFlagChecker reports whether a feature flag is enabled for a tenant
+type FeatureFlagChecker interface {
+	IsEnabled(ctx context.Context, tenantID, flag string) bool
+}
+
+// experimentalNodeFlags maps experimental node types to the flag that gates them
+var experimentalNodeFlags = map[string]string{
+	string(workflow.NodeTypeControlSwitch):  featureflag.FlagSwitchNode,
+	string(workflow.NodeTypeActionDatabase): featureflag.FlagDatabaseAction,
+	string(workflow.NodeTypeActionScript):   featureflag.FlagScriptAction,
+}
+
+// SetFeatureFlags sets the checker consulted before running experimental node
+// types. Without one, every node type runs.
+func (e *Executor) SetFeatureFlags(flags FeatureFlagChecker) {
+	e.featureFlags = flags
+}
+
+// checkNodeTypeEnabled returns a permanent error if the node's type is gated
+// by a feature flag that is off for the tenant
+func (e *Executor) checkNodeTypeEnabled(ctx context.Context, node workflow.Node, tenantID string) error {
+	if e.featureFlags == nil {
+		return nil
+	}
+	flag, gated := experimentalNodeFlags[node.Type]
+	if !gated || e.featureFlags.IsEnabled(ctx, tenantID, flag) {
+		return nil
+	}
+
+	return &ExecutionError{
+		Err:            fmt.Errorf("%w: %s requires feature flag %q", ErrNodeTypeDisabled, node.Type, flag),
+		Classification: ErrorClassificationPermanent,
+		NodeID:         node.ID,
+		NodeType:       node.Type,
+		Context:        map[string]interface{}{"feature_flag": flag},
+	}
+}
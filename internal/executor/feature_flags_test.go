@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/featureflag"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// fakeFeatureFlags enables the listed flags for every tenant
+type fakeFeatureFlags map[string]bool
+
+func (f fakeFeatureFlags) IsEnabled(ctx context.Context, tenantID, flag string) bool {
+	return f[flag]
+}
+
+func TestExecuteNode_FeatureFlags(t *testing.T) {
+	switchNode := workflow.Node{
+		ID:   "switch-1",
+		Type: string(workflow.NodeTypeControlSwitch),
+		Data: workflow.NodeData{Name: "Route", Config: json.RawMessage(`{}`)},
+	}
+	execCtx := &ExecutionContext{
+		TenantID:    "tenant-1",
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		TriggerData: map[string]interface{}{},
+		StepOutputs: map[string]interface{}{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	t.Run("disabled node type fails permanently", func(t *testing.T) {
+		executor := &Executor{logger: logger}
+		executor.SetFeatureFlags(fakeFeatureFlags{})
+
+		_, err := executor.executeNode(context.Background(), switchNode, execCtx)
+
+		require.ErrorIs(t, err, ErrNodeTypeDisabled)
+		assert.Contains(t, err.Error(), featureflag.FlagSwitchNode)
+		assert.False(t, ShouldRetry(err, 0, 3))
+	})
+
+	t.Run("enabled node type runs", func(t *testing.T) {
+		executor := &Executor{logger: logger}
+		executor.SetFeatureFlags(fakeFeatureFlags{featureflag.FlagSwitchNode: true})
+
+		_, err := executor.executeNode(context.Background(), switchNode, execCtx)
+
+		assert.NotErrorIs(t, err, ErrNodeTypeDisabled)
+	})
+
+	t.Run("ungated node types ignore flags", func(t *testing.T) {
+		executor := &Executor{logger: logger}
+		executor.SetFeatureFlags(fakeFeatureFlags{})
+		node := workflow.Node{
+			ID:   "delay-1",
+			Type: string(workflow.NodeTypeControlDelay),
+			Data: workflow.NodeData{Name: "Wait", Config: json.RawMessage(`{}`)},
+		}
+
+		_, err := executor.executeNode(context.Background(), node, execCtx)
+
+		assert.NotErrorIs(t, err, ErrNodeTypeDisabled)
+	})
+
+	t.Run("no checker runs every node type", func(t *testing.T) {
+		executor := &Executor{logger: logger}
+
+		_, err := executor.executeNode(context.Background(), switchNode, execCtx)
+
+		assert.NotErrorIs(t, err, ErrNodeTypeDisabled)
+	})
+}
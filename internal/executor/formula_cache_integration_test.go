@@ -236,3 +236,20 @@ func (m *mockWorkflowRepository) UpdateStepExecution(ctx context.Context, id, st
 	// No-op for now
 	return nil
 }
+
+func (m *mockWorkflowRepository) UpdateExecutionTags(ctx context.Context, id string, tags map[string]string) error {
+	// No-op for now
+	return nil
+}
+
+func (m *mockWorkflowRepository) IsExecutionCancelled(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockWorkflowRepository) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*workflow.StepExecution, error) {
+	return nil, nil
+}
+
+func (m *mockWorkflowRepository) CheckpointExecution(ctx context.Context, id, resumeFromNodeID string) error {
+	return nil
+}
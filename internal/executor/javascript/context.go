@@ -161,6 +161,25 @@ func (ci *ContextInjector) InjectContext(vm *goja.Runtime, ctx *ExecutionContext
 	return nil
 }
 
+// InjectSectionGlobals exposes the named sections of an injected context as
+// globals, so scripts can write steps.fetch instead of context.steps.fetch.
+func (ci *ContextInjector) InjectSectionGlobals(vm *goja.Runtime, sections []string) error {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	contextObj := vm.Get("context")
+	if contextObj == nil || goja.IsUndefined(contextObj) {
+		return ErrNilContext
+	}
+	for _, name := range sections {
+		if err := vm.Set(name, contextObj.ToObject(vm).Get(name)); err != nil {
+			return fmt.Errorf("failed to set %s global: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // injectSection injects a single section into the context object.
 func (ci *ContextInjector) injectSection(vm *goja.Runtime, contextObj *goja.Object, name string, data map[string]any) error {
 	sectionObj := vm.NewObject()
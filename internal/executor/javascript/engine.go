@@ -24,6 +24,7 @@ type Engine struct {
 	tracer         *ExecutionTracer
 	limits         *Limits
 	consoleCapture bool
+	sectionGlobals []string
 }
 
 // EngineConfig holds configuration for the JavaScript engine.
@@ -42,6 +43,10 @@ type EngineConfig struct {
 
 	// EnableConsoleCapture enables capturing console output.
 	EnableConsoleCapture bool
+
+	// SectionGlobals names context sections, such as "trigger" and "steps",
+	// that scripts can also use as globals instead of through context.
+	SectionGlobals []string
 }
 
 // DefaultEngineConfig returns the default engine configuration.
@@ -82,6 +87,7 @@ func NewEngine(config *EngineConfig) (*Engine, error) {
 		tracer:         NewExecutionTracer(),
 		limits:         config.Limits,
 		consoleCapture: config.EnableConsoleCapture,
+		sectionGlobals: config.SectionGlobals,
 	}, nil
 }
 
@@ -237,7 +243,11 @@ func (e *Engine) executeInternal(ctx context.Context, config *ExecuteConfig, scr
 
 	// Inject context
 	if config.Context != nil {
-		if err := e.injector.InjectContext(vm, config.Context); err != nil {
+		err := e.injector.InjectContext(vm, config.Context)
+		if err == nil {
+			err = e.injector.InjectSectionGlobals(vm, e.sectionGlobals)
+		}
+		if err != nil {
 			logEntry.Duration = time.Since(startTime)
 			logEntry.Success = false
 			logEntry.Error = err.Error()
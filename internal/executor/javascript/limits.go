@@ -18,6 +18,13 @@ const (
 	MaxTimeout              = 60 * time.Second
 )
 
+// Resource limits used with StrictSandboxConfig. They are tighter than the
+// defaults because any workflow author can run code under them.
+const (
+	StrictMaxCallStackSize = 256
+	StrictMaxMemoryMB      = 32
+)
+
 // Limits defines resource constraints for JavaScript execution.
 type Limits struct {
 	// Timeout is the maximum execution time.
@@ -43,6 +50,16 @@ func DefaultLimits() *Limits {
 	}
 }
 
+// StrictLimits returns the limits used with StrictSandboxConfig.
+func StrictLimits(timeout time.Duration, maxScriptLength int) *Limits {
+	return &Limits{
+		Timeout:          timeout,
+		MaxCallStackSize: StrictMaxCallStackSize,
+		MaxMemoryMB:      StrictMaxMemoryMB,
+		MaxScriptLength:  maxScriptLength,
+	}
+}
+
 // NewLimits creates limits with optional overrides.
 func NewLimits(timeout time.Duration, maxMemoryMB int64) *Limits {
 	limits := DefaultLimits()
@@ -159,6 +176,12 @@ func (r *ResourceMonitor) CheckMemory() error {
 	maxMemMB := r.limits.MaxMemoryMB
 	r.mu.RUnlock()
 
+	// A collection can shrink the heap below where it started; that is not
+	// an increase, and the unsigned subtraction would wrap around
+	if currentMemory <= startMem {
+		return nil
+	}
+
 	// Calculate memory increase since start
 	memIncreaseMB := int64((currentMemory - startMem) / (1024 * 1024))
 
@@ -205,7 +228,7 @@ func (r *ResourceMonitor) GetMemoryDelta() int64 {
 	r.mu.RLock()
 	startMem := r.startMemory
 	r.mu.RUnlock()
-	return int64(currentMemory - startMem)
+	return int64(currentMemory) - int64(startMem)
 }
 
 // GetLimits returns a copy of the current limits.
@@ -89,6 +89,18 @@ var AllowedGlobals = []string{
 	"BigUint64Array",
 }
 
+// StrictAllowedGlobals is the standard library left by StrictSandboxConfig.
+// Every other global, including timers, Promise, Proxy, Reflect and typed
+// arrays, is removed; there is no network or filesystem access to begin with.
+var StrictAllowedGlobals = []string{
+	"Object", "Array", "String", "Number", "Boolean", "Math", "Date", "JSON", "RegExp",
+	"Map", "Set",
+	"Error", "TypeError", "RangeError", "SyntaxError", "ReferenceError",
+	"parseInt", "parseFloat", "isNaN", "isFinite",
+	"encodeURI", "decodeURI", "encodeURIComponent", "decodeURIComponent",
+	"undefined", "NaN", "Infinity",
+}
+
 // SandboxConfig holds configuration for the sandbox environment.
 type SandboxConfig struct {
 	// DisableEval prevents use of eval() and new Function().
@@ -103,6 +115,10 @@ type SandboxConfig struct {
 	// ForbiddenGlobals extends the default forbidden globals.
 	ForbiddenGlobals []string
 
+	// StrictGlobals removes every global not in AllowedGlobals, so the
+	// allowed list is the whole standard library scripts can reach.
+	StrictGlobals bool
+
 	// CustomGlobals adds custom globals to the sandbox.
 	CustomGlobals map[string]interface{}
 }
@@ -118,6 +134,17 @@ func DefaultSandboxConfig() *SandboxConfig {
 	}
 }
 
+// StrictSandboxConfig returns the sandbox for code any workflow author can
+// write, such as action:script nodes and transform mapping expressions. Only
+// StrictAllowedGlobals remain.
+func StrictSandboxConfig() *SandboxConfig {
+	config := DefaultSandboxConfig()
+	config.AllowedGlobals = StrictAllowedGlobals
+	config.StrictGlobals = true
+	config.MaxCallStackSize = StrictMaxCallStackSize
+	return config
+}
+
 // Sandbox provides a secure execution environment for JavaScript.
 type Sandbox struct {
 	config *SandboxConfig
@@ -141,6 +168,11 @@ func (s *Sandbox) ApplyToRuntime(vm *goja.Runtime) error {
 		return fmt.Errorf("failed to remove forbidden globals: %w", err)
 	}
 
+	// Remove globals outside the allowed list
+	if s.config.StrictGlobals {
+		s.removeUnlistedGlobals(vm)
+	}
+
 	// Add safe console implementation
 	if err := s.addSafeConsole(vm); err != nil {
 		return fmt.Errorf("failed to add safe console: %w", err)
@@ -181,6 +213,25 @@ func (s *Sandbox) removeForbiddenGlobals(vm *goja.Runtime) error {
 	return nil
 }
 
+// removeUnlistedGlobals removes globals that are not in AllowedGlobals.
+func (s *Sandbox) removeUnlistedGlobals(vm *goja.Runtime) {
+	allowed := make(map[string]struct{}, len(s.config.AllowedGlobals))
+	for _, name := range s.config.AllowedGlobals {
+		allowed[name] = struct{}{}
+	}
+
+	global := vm.GlobalObject()
+	for _, name := range global.GetOwnPropertyNames() {
+		if _, ok := allowed[name]; ok {
+			continue
+		}
+		// Fall back to undefined for globals that cannot be deleted
+		if err := global.Delete(name); err != nil {
+			_ = global.Set(name, goja.Undefined())
+		}
+	}
+}
+
 // addSafeConsole adds a safe console implementation that captures output.
 func (s *Sandbox) addSafeConsole(vm *goja.Runtime) error {
 	console := vm.NewObject()
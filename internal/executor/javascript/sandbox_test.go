@@ -152,6 +152,29 @@ func TestSandbox_CustomGlobals(t *testing.T) {
 	assert.Equal(t, int64(42), val.Export())
 }
 
+func TestSandbox_StrictGlobals(t *testing.T) {
+	config := DefaultSandboxConfig()
+	config.AllowedGlobals = []string{"Math", "JSON", "undefined"}
+	config.StrictGlobals = true
+
+	vm := goja.New()
+	require.NoError(t, NewSandbox(config).ApplyToRuntime(vm))
+
+	for _, name := range []string{"Promise", "Proxy", "Reflect", "Uint8Array"} {
+		val, err := vm.RunString("typeof " + name)
+		require.NoError(t, err)
+		assert.Equal(t, "undefined", val.String(), name)
+	}
+
+	val, err := vm.RunString("JSON.stringify({max: Math.max(1, 2)})")
+	require.NoError(t, err)
+	assert.Equal(t, `{"max":2}`, val.String())
+
+	// The no-op console is still installed
+	_, err = vm.RunString("console.log('ok')")
+	assert.NoError(t, err)
+}
+
 func TestSandbox_MaxCallStackSize(t *testing.T) {
 	config := &SandboxConfig{
 		MaxCallStackSize: 100,
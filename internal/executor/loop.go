@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/gorax/gorax/internal/executor/actions"
 	"github.com/gorax/gorax/internal/executor/expression"
@@ -25,9 +26,13 @@ const (
 
 // LoopResult represents the result of a loop execution
 type LoopResult struct {
-	IterationCount int                    `json:"iteration_count"`
-	Iterations     []IterationResult      `json:"iterations"`
-	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	IterationCount int               `json:"iteration_count"`
+	Iterations     []IterationResult `json:"iterations"`
+	// Results holds the output of each iteration's last body node, in source
+	// order; failed iterations have a nil result
+	Results     []interface{}          `json:"results"`
+	FailedCount int                    `json:"failed_count"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // IterationResult represents the result of a single iteration
@@ -39,6 +44,9 @@ type IterationResult struct {
 	Error   *string                `json:"error,omitempty"`
 	IsFirst bool                   `json:"is_first"` // True if this is the first iteration
 	IsLast  bool                   `json:"is_last"`  // True if this is the last iteration (or break triggered)
+
+	// result is the output of the last body node executed
+	result interface{}
 }
 
 // loopItem represents an item to iterate over (supports both arrays and objects)
@@ -96,10 +104,18 @@ func (le *loopExecutor) executeLoop(
 
 	// Determine error handling strategy
 	onError := config.OnError
+	if config.ContinueOnError {
+		onError = ErrorStrategyContinue
+	}
 	if onError == "" {
 		onError = ErrorStrategyStop
 	}
 
+	concurrency := config.Concurrency
+	if concurrency == 0 {
+		concurrency = 1
+	}
+
 	// Initialize expression evaluator if needed
 	if le.expressionEvalr == nil {
 		le.expressionEvalr = expression.NewEvaluator()
@@ -113,10 +129,21 @@ func (le *loopExecutor) executeLoop(
 			"index_variable": config.IndexVariable,
 			"key_variable":   config.KeyVariable,
 			"on_error":       onError,
+			"concurrency":    concurrency,
 			"total_items":    itemCount,
 		},
 	}
 
+	if concurrency > 1 {
+		iterations, err := le.executeIterationsConcurrently(ctx, loopItems, concurrency, onError, config, execCtx, bodyNodes, bodyEdges)
+		if err != nil {
+			return nil, err
+		}
+		result.Iterations = iterations
+		result.collectResults()
+		return result, nil
+	}
+
 	var breakTriggered bool
 	var breakAtIndex int
 
@@ -166,8 +193,7 @@ func (le *loopExecutor) executeLoop(
 		}
 	}
 
-	// Set the iteration count to actual iterations processed
-	result.IterationCount = len(result.Iterations)
+	result.collectResults()
 
 	// Add break metadata
 	if breakTriggered {
@@ -178,6 +204,106 @@ func (le *loopExecutor) executeLoop(
 	return result, nil
 }
 
+// executeIterationsConcurrently runs up to concurrency iterations at once,
+// keeping results in source order. With the stop strategy the first failure
+// cancels the iterations that are still running or not yet started.
+func (le *loopExecutor) executeIterationsConcurrently(
+	ctx context.Context,
+	loopItems []loopItem,
+	concurrency int,
+	onError string,
+	config workflow.LoopActionConfig,
+	execCtx *ExecutionContext,
+	bodyNodes []workflow.Node,
+	bodyEdges []workflow.Edge,
+) ([]IterationResult, error) {
+	iterCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	itemCount := len(loopItems)
+	iterations := make([]IterationResult, itemCount)
+	errs := make([]error, itemCount)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+
+	for i, item := range loopItems {
+		select {
+		case sem <- struct{}{}:
+		case <-iterCtx.Done():
+		}
+		if iterCtx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, item loopItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			iterationResult, err := le.executeIterationWithContext(
+				iterCtx,
+				item,
+				i == 0,
+				i == itemCount-1,
+				itemCount,
+				config,
+				execCtx,
+				bodyNodes,
+				bodyEdges,
+			)
+			iterations[i] = *iterationResult
+			if err == nil {
+				return
+			}
+
+			errs[i] = err
+			if onError == ErrorStrategyStop {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("loop iteration %d failed: %w", i, err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(i, item)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			errMsg := err.Error()
+			iterations[i].Error = &errMsg
+		}
+	}
+	return iterations, nil
+}
+
+// collectResults sets the iteration count, per-iteration results and failure count
+func (r *LoopResult) collectResults() {
+	r.IterationCount = len(r.Iterations)
+	r.Results = make([]interface{}, len(r.Iterations))
+	r.FailedCount = 0
+	for i, iteration := range r.Iterations {
+		if iteration.Error != nil {
+			r.FailedCount++
+			continue
+		}
+		r.Results[i] = iteration.result
+	}
+}
+
 // validateConfig validates loop configuration
 func (le *loopExecutor) validateConfig(config workflow.LoopActionConfig) error {
 	if config.Source == "" {
@@ -189,6 +315,15 @@ func (le *loopExecutor) validateConfig(config workflow.LoopActionConfig) error {
 	if config.OnError != "" && config.OnError != ErrorStrategyContinue && config.OnError != ErrorStrategyStop {
 		return fmt.Errorf("on_error must be 'continue' or 'stop', got '%s'", config.OnError)
 	}
+	if config.ContinueOnError && config.OnError == ErrorStrategyStop {
+		return fmt.Errorf("continue_on_error conflicts with on_error 'stop'")
+	}
+	if config.Concurrency < 0 || config.Concurrency > workflow.MaxLoopConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d, got %d", workflow.MaxLoopConcurrency, config.Concurrency)
+	}
+	if config.Concurrency > 1 && len(config.BreakConditions) > 0 {
+		return fmt.Errorf("break conditions require sequential iteration (concurrency 1)")
+	}
 	return nil
 }
 
@@ -391,9 +526,10 @@ func (le *loopExecutor) executeIterationWithContext(
 
 	// Execute body nodes for this iteration with tracing
 	var outputs map[string]interface{}
+	var last interface{}
 	_, err := tracing.TraceLoopIteration(ctx, item.Index, config.ItemVariable, func(tracedCtx context.Context) (interface{}, error) {
 		var innerErr error
-		outputs, innerErr = le.executeBodyNodes(tracedCtx, bodyNodes, bodyEdges, iterationCtx)
+		outputs, last, innerErr = le.executeBodyNodes(tracedCtx, bodyNodes, bodyEdges, iterationCtx)
 		return outputs, innerErr
 	})
 
@@ -404,6 +540,7 @@ func (le *loopExecutor) executeIterationWithContext(
 		Output:  outputs,
 		IsFirst: isFirst,
 		IsLast:  isLast,
+		result:  last,
 	}
 
 	if err != nil {
@@ -448,11 +585,14 @@ func (le *loopExecutor) createIterationContextWithKey(
 	}
 
 	return &ExecutionContext{
-		TenantID:    parentCtx.TenantID,
-		ExecutionID: parentCtx.ExecutionID,
-		WorkflowID:  parentCtx.WorkflowID,
-		TriggerData: parentCtx.TriggerData,
-		StepOutputs: stepOutputs,
+		TenantID:         parentCtx.TenantID,
+		ExecutionID:      parentCtx.ExecutionID,
+		WorkflowID:       parentCtx.WorkflowID,
+		TriggerData:      parentCtx.TriggerData,
+		StepOutputs:      stepOutputs,
+		CredentialValues: parentCtx.CredentialValues,
+		CredentialCache:  parentCtx.CredentialCache,
+		Env:              parentCtx.Env,
 	}
 }
 
@@ -531,22 +671,23 @@ func endsWithString(left, right interface{}) (bool, error) {
 	return strings.HasSuffix(leftStr, rightStr), nil
 }
 
-// executeBodyNodes executes all nodes in the loop body
+// executeBodyNodes executes all nodes in the loop body, returning the outputs
+// by node ID and the output of the last node executed
 func (le *loopExecutor) executeBodyNodes(
 	ctx context.Context,
 	nodes []workflow.Node,
 	edges []workflow.Edge,
 	iterationCtx *ExecutionContext,
-) (map[string]interface{}, error) {
+) (map[string]interface{}, interface{}, error) {
 	// If no nodes in body, return empty output
 	if len(nodes) == 0 {
-		return make(map[string]interface{}), nil
+		return make(map[string]interface{}), nil, nil
 	}
 
 	// Build execution order using topological sort
 	executionOrder, err := topologicalSort(nodes, edges)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine execution order for loop body: %w", err)
+		return nil, nil, fmt.Errorf("failed to determine execution order for loop body: %w", err)
 	}
 
 	// Build node map for quick lookup
@@ -554,6 +695,7 @@ func (le *loopExecutor) executeBodyNodes(
 
 	// Execute nodes in order
 	outputs := make(map[string]interface{})
+	var last interface{}
 	for _, nodeID := range executionOrder {
 		node, exists := nodeMap[nodeID]
 		if !exists {
@@ -574,15 +716,16 @@ func (le *loopExecutor) executeBodyNodes(
 		}
 
 		if execErr != nil {
-			return outputs, fmt.Errorf("node %s failed: %w", nodeID, execErr)
+			return outputs, nil, fmt.Errorf("node %s failed: %w", nodeID, execErr)
 		}
 
 		// Store output for downstream nodes
 		iterationCtx.StepOutputs[nodeID] = output
 		outputs[nodeID] = output
+		last = output
 	}
 
-	return outputs, nil
+	return outputs, last, nil
 }
 
 // executeLoopAction is the main entry point for loop execution
@@ -456,6 +456,8 @@ type mockWorkflowRepo struct {
 	executionStatus string
 	executionOutput json.RawMessage
 	stepExecutions  map[string]*workflow.StepExecution
+	executionTags   map[string]string
+	resumeFromNode  string
 }
 
 func (m *mockWorkflowRepo) GetByID(ctx context.Context, tenantID, id string) (*workflow.Workflow, error) {
@@ -463,6 +465,10 @@ func (m *mockWorkflowRepo) GetByID(ctx context.Context, tenantID, id string) (*w
 }
 
 func (m *mockWorkflowRepo) UpdateExecutionStatus(ctx context.Context, id string, status string, outputData json.RawMessage, errorMsg *string) error {
+	// Like the real repository, a cancelled execution keeps its status
+	if m.executionStatus == string(workflow.ExecutionStatusCancelled) {
+		return nil
+	}
 	m.executionStatus = status
 	m.executionOutput = outputData
 	return nil
@@ -488,3 +494,33 @@ func (m *mockWorkflowRepo) UpdateStepExecution(ctx context.Context, id, status s
 	}
 	return nil
 }
+
+func (m *mockWorkflowRepo) UpdateExecutionTags(ctx context.Context, id string, tags map[string]string) error {
+	if m.executionTags == nil {
+		m.executionTags = make(map[string]string)
+	}
+	for key, value := range tags {
+		m.executionTags[key] = value
+	}
+	return nil
+}
+
+func (m *mockWorkflowRepo) IsExecutionCancelled(ctx context.Context, id string) (bool, error) {
+	return m.executionStatus == string(workflow.ExecutionStatusCancelled), nil
+}
+
+func (m *mockWorkflowRepo) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*workflow.StepExecution, error) {
+	steps := make([]*workflow.StepExecution, 0, len(m.stepExecutions))
+	for _, step := range m.stepExecutions {
+		if step.ExecutionID == executionID {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
+
+func (m *mockWorkflowRepo) CheckpointExecution(ctx context.Context, id, resumeFromNodeID string) error {
+	m.executionStatus = string(workflow.ExecutionStatusPending)
+	m.resumeFromNode = resumeFromNodeID
+	return nil
+}
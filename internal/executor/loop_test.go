@@ -3,7 +3,10 @@ package executor
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1460,3 +1463,224 @@ func TestExecuteLoopAction_BreakCondition_LessThan(t *testing.T) {
 	assert.Equal(t, 3, loopResult.IterationCount)
 	assert.True(t, loopResult.Metadata["break_triggered"].(bool))
 }
+
+// newLoopTestExecutor returns a loop executor that runs body nodes with a real executor
+func newLoopTestExecutor() *loopExecutor {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return newLoopExecutor(&Executor{logger: logger})
+}
+
+// newLoopItemsContext returns an execution context whose data_source step outputs items
+func newLoopItemsContext(items interface{}) *ExecutionContext {
+	return &ExecutionContext{
+		TenantID:    "tenant1",
+		ExecutionID: "exec1",
+		WorkflowID:  "workflow1",
+		TriggerData: map[string]interface{}{},
+		StepOutputs: map[string]interface{}{
+			"data_source": map[string]interface{}{
+				"output": map[string]interface{}{"items": items},
+			},
+		},
+	}
+}
+
+func TestExecuteLoopAction_ResultsCollected(t *testing.T) {
+	config := workflow.LoopActionConfig{
+		Source:       "${steps.data_source.output.items}",
+		ItemVariable: "item",
+	}
+	execCtx := newLoopItemsContext([]interface{}{"a", "b"})
+
+	loopBodyNodes := []workflow.Node{
+		{ID: "first", Type: string(workflow.NodeTypeActionTransform)},
+		{ID: "second", Type: string(workflow.NodeTypeActionTransform)},
+	}
+	loopBodyEdges := []workflow.Edge{{ID: "e1", Source: "first", Target: "second"}}
+
+	executor := &loopExecutor{}
+	result, err := executor.executeLoop(context.Background(), config, execCtx, loopBodyNodes, loopBodyEdges)
+
+	require.NoError(t, err)
+	loopResult := result.(*LoopResult)
+	require.Len(t, loopResult.Results, 2)
+	for i, iteration := range loopResult.Iterations {
+		assert.Equal(t, iteration.Output["second"], loopResult.Results[i], "result is the last body node's output")
+	}
+	assert.Equal(t, 0, loopResult.FailedCount)
+}
+
+func TestExecuteLoopAction_EmptyArrayResults(t *testing.T) {
+	for _, concurrency := range []int{0, 4} {
+		config := workflow.LoopActionConfig{
+			Source:       "${steps.data_source.output.items}",
+			ItemVariable: "item",
+			Concurrency:  concurrency,
+		}
+
+		executor := newLoopTestExecutor()
+		result, err := executor.executeLoop(context.Background(), config, newLoopItemsContext([]interface{}{}), nil, nil)
+
+		require.NoError(t, err)
+		encoded, err := json.Marshal(result)
+		require.NoError(t, err)
+		assert.Contains(t, string(encoded), `"results":[]`, "empty loops report an empty results array")
+		assert.Contains(t, string(encoded), `"iteration_count":0`)
+	}
+}
+
+func TestExecuteLoopAction_NonArraySources(t *testing.T) {
+	tests := []struct {
+		name  string
+		items interface{}
+	}{
+		{name: "string", items: "not an array"},
+		{name: "number", items: float64(42)},
+		{name: "boolean", items: true},
+		{name: "null", items: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := workflow.LoopActionConfig{
+				Source:       "${steps.data_source.output.items}",
+				ItemVariable: "item",
+				Concurrency:  4,
+			}
+
+			executor := newLoopTestExecutor()
+			_, err := executor.executeLoop(context.Background(), config, newLoopItemsContext(tt.items), nil, nil)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "not an array or object")
+		})
+	}
+}
+
+func TestExecuteLoopAction_ContinueOnError(t *testing.T) {
+	config := workflow.LoopActionConfig{
+		Source:          "${steps.data_source.output.items}",
+		ItemVariable:    "item",
+		ContinueOnError: true,
+	}
+	execCtx := newLoopItemsContext([]interface{}{"1ms", "not a duration", "1ms"})
+	loopBodyNodes := []workflow.Node{
+		{
+			ID:   "wait",
+			Type: string(workflow.NodeTypeControlDelay),
+			Data: workflow.NodeData{Config: mustMarshal(workflow.DelayConfig{Duration: "${steps.item}"})},
+		},
+	}
+
+	result, err := newLoopTestExecutor().executeLoop(context.Background(), config, execCtx, loopBodyNodes, nil)
+
+	require.NoError(t, err)
+	loopResult := result.(*LoopResult)
+	assert.Equal(t, 3, loopResult.IterationCount)
+	assert.Equal(t, 1, loopResult.FailedCount)
+	require.NotNil(t, loopResult.Iterations[1].Error)
+	assert.Contains(t, *loopResult.Iterations[1].Error, "invalid duration")
+	assert.NotNil(t, loopResult.Results[0])
+	assert.Nil(t, loopResult.Results[1])
+	assert.NotNil(t, loopResult.Results[2])
+	assert.Equal(t, "continue", loopResult.Metadata["on_error"])
+}
+
+func TestExecuteLoopAction_FailedIterationStopsLoop(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		config := workflow.LoopActionConfig{
+			Source:       "${steps.data_source.output.items}",
+			ItemVariable: "item",
+			Concurrency:  concurrency,
+		}
+		execCtx := newLoopItemsContext([]interface{}{"1ms", "not a duration", "1ms"})
+		loopBodyNodes := []workflow.Node{
+			{
+				ID:   "wait",
+				Type: string(workflow.NodeTypeControlDelay),
+				Data: workflow.NodeData{Config: mustMarshal(workflow.DelayConfig{Duration: "${steps.item}"})},
+			},
+		}
+
+		_, err := newLoopTestExecutor().executeLoop(context.Background(), config, execCtx, loopBodyNodes, nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "loop iteration 1 failed")
+	}
+}
+
+func TestExecuteLoopAction_Concurrency(t *testing.T) {
+	config := workflow.LoopActionConfig{
+		Source:       "${steps.data_source.output.items}",
+		ItemVariable: "item",
+		Concurrency:  4,
+	}
+	// Later items finish first; results must still follow the source order
+	items := []interface{}{"150ms", "100ms", "50ms", "10ms"}
+	loopBodyNodes := []workflow.Node{
+		{
+			ID:   "wait",
+			Type: string(workflow.NodeTypeControlDelay),
+			Data: workflow.NodeData{Config: mustMarshal(workflow.DelayConfig{Duration: "${steps.item}"})},
+		},
+	}
+
+	start := time.Now()
+	result, err := newLoopTestExecutor().executeLoop(context.Background(), config, newLoopItemsContext(items), loopBodyNodes, nil)
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	loopResult := result.(*LoopResult)
+	require.Equal(t, 4, loopResult.IterationCount)
+	for i, iteration := range loopResult.Iterations {
+		assert.Equal(t, i, iteration.Index)
+		assert.Equal(t, items[i], iteration.Item)
+		assert.NotNil(t, loopResult.Results[i])
+	}
+	assert.Less(t, elapsed, 300*time.Millisecond, "iterations should overlap")
+	assert.Equal(t, 4, loopResult.Metadata["concurrency"])
+}
+
+func TestExecuteLoopAction_InvalidConcurrencyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  workflow.LoopActionConfig
+		wantErr string
+	}{
+		{
+			name:    "negative concurrency",
+			config:  workflow.LoopActionConfig{Concurrency: -1},
+			wantErr: "concurrency must be between",
+		},
+		{
+			name:    "concurrency above limit",
+			config:  workflow.LoopActionConfig{Concurrency: workflow.MaxLoopConcurrency + 1},
+			wantErr: "concurrency must be between",
+		},
+		{
+			name: "break conditions with concurrency",
+			config: workflow.LoopActionConfig{
+				Concurrency:     2,
+				BreakConditions: []workflow.BreakCondition{{Condition: "item == 1"}},
+			},
+			wantErr: "sequential iteration",
+		},
+		{
+			name:    "continue_on_error with on_error stop",
+			config:  workflow.LoopActionConfig{ContinueOnError: true, OnError: "stop"},
+			wantErr: "conflicts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Source = "${steps.data_source.output.items}"
+			tt.config.ItemVariable = "item"
+
+			_, err := newLoopTestExecutor().executeLoop(context.Background(), tt.config, newLoopItemsContext([]interface{}{1}), nil, nil)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
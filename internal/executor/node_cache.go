@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/nodecache"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// NodeResultCache stores the results of nodes that opt in to caching. The
+// cache is shared by every instance running executions, so a result stored
+// by one worker is reused by the others.
+type NodeResultCache interface {
+	Get(ctx context.Context, tenantID, key string) (*nodecache.Entry, error)
+	Put(ctx context.Context, entry *nodecache.Entry) error
+}
+
+// SetNodeResultCache sets the store for cached node results. Without one,
+// nodes that enable caching always run.
+func (e *Executor) SetNodeResultCache(cache NodeResultCache) {
+	e.nodeCache = cache
+}
+
+// nodeCacheSlot is where a node's result is cached for one execution
+type nodeCacheSlot struct {
+	key string
+	ttl time.Duration
+}
+
+// nodeCacheSlotFor returns where node's result is cached, or nil when the
+// node does not opt in to caching or no cache is configured. The key covers
+// the workflow, the node and its config with the execution's values
+// resolved, so a change to either the config or the values it reads misses.
+func (e *Executor) nodeCacheSlotFor(node workflow.Node, execCtx *ExecutionContext) *nodeCacheSlot {
+	if e.nodeCache == nil {
+		return nil
+	}
+	config, err := workflow.ParseNodeCacheConfig(node)
+	if err != nil || config == nil || !workflow.IsCacheableNode(node) {
+		return nil
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"workflow_id": execCtx.WorkflowID,
+		"node_id":     node.ID,
+		"type":        node.Type,
+		"config":      actions.InterpolateJSON(node.Data.Config, buildInterpolationContext(execCtx)),
+	})
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(payload)
+
+	return &nodeCacheSlot{
+		key: hex.EncodeToString(sum[:]),
+		ttl: min(config.TTL(), workflow.MaxNodeCacheTTL),
+	}
+}
+
+// cachedNodeResult returns the fresh cached result in slot, if any. Cache
+// failures are logged and treated as a miss so the node runs instead.
+func (e *Executor) cachedNodeResult(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, slot *nodeCacheSlot) (interface{}, bool) {
+	entry, err := e.nodeCache.Get(ctx, execCtx.TenantID, slot.key)
+	if err != nil {
+		if !errors.Is(err, nodecache.ErrNotFound) {
+			e.logger.Warn("failed to read node cache", "error", err, "node_id", node.ID)
+		}
+		return nil, false
+	}
+
+	output, err := decodeCachedOutput(node.Type, entry.Output)
+	if err != nil {
+		e.logger.Warn("failed to decode cached node result", "error", err, "node_id", node.ID)
+		return nil, false
+	}
+
+	e.logger.Debug("reusing cached node result",
+		"node_id", node.ID,
+		"execution_id", execCtx.ExecutionID,
+		"cached_at", entry.CreatedAt,
+	)
+	return output, true
+}
+
+// cacheNodeResult stores a successful result in slot. Failed HTTP responses
+// and results that would be redacted are not cached, so credentials never
+// reach the cache.
+func (e *Executor) cacheNodeResult(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, slot *nodeCacheSlot, output interface{}) {
+	if result, ok := output.(*actions.HTTPActionResult); ok && (result.StatusCode < 200 || result.StatusCode >= 300) {
+		return
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	if !reflect.DeepEqual(value, e.stepRedactor().Redact(value, execCtx.CredentialValues)) {
+		e.logger.Debug("not caching node result containing secrets", "node_id", node.ID)
+		return
+	}
+
+	entry := &nodecache.Entry{
+		TenantID:   execCtx.TenantID,
+		Key:        slot.key,
+		WorkflowID: execCtx.WorkflowID,
+		NodeID:     node.ID,
+		Output:     data,
+		ExpiresAt:  time.Now().Add(slot.ttl),
+	}
+	if err := e.nodeCache.Put(context.WithoutCancel(ctx), entry); err != nil {
+		e.logger.Warn("failed to store node result in cache", "error", err, "node_id", node.ID)
+	}
+}
+
+// decodeCachedOutput restores a cached result as the type the node returns
+func decodeCachedOutput(nodeType string, data json.RawMessage) (interface{}, error) {
+	if nodeType == string(workflow.NodeTypeActionHTTP) {
+		var result actions.HTTPActionResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+
+	var output interface{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
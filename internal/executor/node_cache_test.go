@@ -0,0 +1,198 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/nodecache"
+	"github.com/gorax/gorax/internal/security"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// memoryNodeCache is an in-memory NodeResultCache
+type memoryNodeCache struct {
+	mu      sync.Mutex
+	entries map[string]*nodecache.Entry
+}
+
+func newMemoryNodeCache() *memoryNodeCache {
+	return &memoryNodeCache{entries: make(map[string]*nodecache.Entry)}
+}
+
+func (c *memoryNodeCache) Get(ctx context.Context, tenantID, key string) (*nodecache.Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[tenantID+"/"+key]
+	if !ok || !entry.ExpiresAt.After(time.Now()) {
+		return nil, nodecache.ErrNotFound
+	}
+	return entry, nil
+}
+
+func (c *memoryNodeCache) Put(ctx context.Context, entry *nodecache.Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored := *entry
+	stored.CreatedAt = time.Now()
+	c.entries[entry.TenantID+"/"+entry.Key] = &stored
+	return nil
+}
+
+func (c *memoryNodeCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func newNodeCacheTestExecutor(cache NodeResultCache) *Executor {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	e := &Executor{
+		repo:               &mockWorkflowRepository{},
+		logger:             logger,
+		retryStrategy:      NewRetryStrategy(DefaultRetryConfig(), logger),
+		circuitBreakers:    NewCircuitBreakerRegistry(DefaultCircuitBreakerConfig(), logger),
+		defaultRetryConfig: DefaultNodeRetryConfig(),
+		httpOptions: actions.HTTPOptions{
+			URLValidator: security.NewURLValidatorWithConfig(&security.URLValidatorConfig{Enabled: false}),
+		},
+	}
+	e.SetNodeResultCache(cache)
+	return e
+}
+
+func httpNode(t *testing.T, config map[string]interface{}) workflow.Node {
+	t.Helper()
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	return workflow.Node{
+		ID:   "fetch",
+		Type: string(workflow.NodeTypeActionHTTP),
+		Data: workflow.NodeData{Config: data},
+	}
+}
+
+func TestExecuteNode_NodeResultCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"` + r.URL.Query().Get("id") + `"}`))
+	}))
+	defer server.Close()
+
+	cached := httpNode(t, map[string]interface{}{
+		"method": "GET",
+		"url":    server.URL + "/items?id={{trigger.id}}",
+		"cache":  map[string]interface{}{"enabled": true, "ttl_seconds": 60},
+	})
+	execCtx := func(tenantID, id string) *ExecutionContext {
+		return &ExecutionContext{
+			TenantID:    tenantID,
+			ExecutionID: "exec-" + id,
+			WorkflowID:  "wf-1",
+			TriggerData: map[string]interface{}{"id": id},
+			StepOutputs: map[string]interface{}{},
+		}
+	}
+
+	t.Run("reuses a fresh result", func(t *testing.T) {
+		requests.Store(0)
+		e := newNodeCacheTestExecutor(newMemoryNodeCache())
+
+		first, err := e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "a"))
+		require.NoError(t, err)
+		second, err := e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "a"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), requests.Load())
+		result, ok := second.(*actions.HTTPActionResult)
+		require.True(t, ok, "cached result keeps the node's output type")
+		assert.Equal(t, first.(*actions.HTTPActionResult).StatusCode, result.StatusCode)
+		assert.Equal(t, first.(*actions.HTTPActionResult).Body, result.Body)
+	})
+
+	t.Run("different resolved inputs miss", func(t *testing.T) {
+		requests.Store(0)
+		e := newNodeCacheTestExecutor(newMemoryNodeCache())
+
+		_, err := e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "a"))
+		require.NoError(t, err)
+		_, err = e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "b"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("results are not shared between tenants", func(t *testing.T) {
+		requests.Store(0)
+		e := newNodeCacheTestExecutor(newMemoryNodeCache())
+
+		_, err := e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "a"))
+		require.NoError(t, err)
+		_, err = e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-2", "a"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("failed responses are not cached", func(t *testing.T) {
+		requests.Store(0)
+		cache := newMemoryNodeCache()
+		e := newNodeCacheTestExecutor(cache)
+
+		_, _ = e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "missing"))
+		_, _ = e.executeNodeWithTracking(context.Background(), cached, execCtx("tenant-1", "missing"))
+
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Equal(t, 0, cache.len())
+	})
+
+	t.Run("results containing credentials are not cached", func(t *testing.T) {
+		requests.Store(0)
+		cache := newMemoryNodeCache()
+		e := newNodeCacheTestExecutor(cache)
+		ctx := execCtx("tenant-1", "s3cr3t-value")
+		ctx.CredentialValues = []string{"s3cr3t-value"}
+
+		_, err := e.executeNodeWithTracking(context.Background(), cached, ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, cache.len())
+	})
+
+	t.Run("nodes without caching always run", func(t *testing.T) {
+		requests.Store(0)
+		cache := newMemoryNodeCache()
+		e := newNodeCacheTestExecutor(cache)
+		uncached := httpNode(t, map[string]interface{}{
+			"method": "GET",
+			"url":    server.URL + "/items?id=a",
+		})
+
+		for i := 0; i < 2; i++ {
+			_, err := e.executeNodeWithTracking(context.Background(), uncached, execCtx("tenant-1", "a"))
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(2), requests.Load())
+		assert.Equal(t, 0, cache.len())
+	})
+}
@@ -320,6 +320,8 @@ func (pe *parallelExecutor) createBranchContext(parentCtx *ExecutionContext) *Ex
 		TriggerData:      parentCtx.TriggerData,
 		StepOutputs:      stepOutputs,
 		CredentialValues: parentCtx.CredentialValues,
+		CredentialCache:  parentCtx.CredentialCache,
+		Env:              parentCtx.Env,
 	}
 }
 
@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces secrets in persisted step data
+const RedactedValue = "[REDACTED]"
+
+// DefaultSensitiveKeys are the header and field names whose values are
+// redacted from step inputs and outputs when no list is configured
+var DefaultSensitiveKeys = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"api-key",
+	"api_key",
+	"apikey",
+	"x-auth-token",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"client_secret",
+	"password",
+	"secret",
+}
+
+// tokenPatterns match common token formats that are redacted wherever they
+// appear, even under keys that are not known to be sensitive
+var tokenPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// Bearer tokens keep the scheme so the output stays readable
+	{regexp.MustCompile(`(?i)\b(Bearer)\s+[A-Za-z0-9\-._~+/]+=*`), "$1 " + RedactedValue},
+	// JSON Web Tokens
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), RedactedValue},
+	// GitHub tokens
+	{regexp.MustCompile(`\b(?:gh[pousr]|github_pat)_[A-Za-z0-9_]{20,}`), RedactedValue},
+	// Slack tokens
+	{regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), RedactedValue},
+	// AWS access key IDs
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), RedactedValue},
+	// Stripe and similar sk_/pk_/rk_ keys
+	{regexp.MustCompile(`\b[spr]k_(?:live|test)_[A-Za-z0-9]{16,}`), RedactedValue},
+}
+
+// Redactor masks secrets in step data before it is persisted or broadcast.
+// It redacts the values of sensitive keys, the plaintext of credentials
+// resolved during the execution, and strings matching common token formats.
+type Redactor struct {
+	sensitiveKeys map[string]bool
+}
+
+// NewRedactor creates a redactor for the given sensitive key names, matched
+// case-insensitively. An empty list uses DefaultSensitiveKeys.
+func NewRedactor(sensitiveKeys []string) *Redactor {
+	if len(sensitiveKeys) == 0 {
+		sensitiveKeys = DefaultSensitiveKeys
+	}
+	keys := make(map[string]bool, len(sensitiveKeys))
+	for _, key := range sensitiveKeys {
+		keys[strings.ToLower(strings.TrimSpace(key))] = true
+	}
+	return &Redactor{sensitiveKeys: keys}
+}
+
+// IsSensitiveKey reports whether the value stored under key is redacted
+func (r *Redactor) IsSensitiveKey(key string) bool {
+	return r.sensitiveKeys[strings.ToLower(key)]
+}
+
+// Redact returns a copy of value with secrets masked. secrets are credential
+// plaintexts that are masked wherever they appear.
+func (r *Redactor) Redact(value interface{}, secrets []string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.RedactString(v, secrets)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if r.IsSensitiveKey(key) {
+				result[key] = RedactedValue
+				continue
+			}
+			result[key] = r.Redact(val, secrets)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, val := range v {
+			result[i] = r.Redact(val, secrets)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// RedactJSON redacts a JSON document, returning it unchanged if it cannot be parsed
+func (r *Redactor) RedactJSON(data []byte, secrets []string) []byte {
+	var value interface{}
+	if len(data) == 0 || json.Unmarshal(data, &value) != nil {
+		return data
+	}
+	redacted, err := json.Marshal(r.Redact(value, secrets))
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// RedactString masks credential plaintexts and common token formats in s
+func (r *Redactor) RedactString(s string, secrets []string) string {
+	if s == "" {
+		return s
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, RedactedValue)
+		}
+	}
+	for _, pattern := range tokenPatterns {
+		s = pattern.re.ReplaceAllString(s, pattern.replacement)
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// loadResumedOutputs returns the outputs of the steps a retried execution
+// reuses, keyed by node ID. Executions that are not retries have none.
+func (e *Executor) loadResumedOutputs(ctx context.Context, execution *workflow.Execution) (map[string]interface{}, error) {
+	if execution.ResumeFromNodeID == nil {
+		return nil, nil
+	}
+
+	steps, err := e.repo.GetStepExecutionsByExecutionID(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resumed steps: %w", err)
+	}
+
+	outputs := make(map[string]interface{}, len(steps))
+	for _, step := range steps {
+		if step.Status != "completed" || step.NodeID == *execution.ResumeFromNodeID {
+			continue
+		}
+
+		var output interface{}
+		if step.OutputData != nil {
+			if err := json.Unmarshal(*step.OutputData, &output); err != nil {
+				return nil, fmt.Errorf("failed to parse output of resumed step %s: %w", step.NodeID, err)
+			}
+		}
+		outputs[step.NodeID] = output
+	}
+
+	e.logger.Info("resuming execution",
+		"execution_id", execution.ID,
+		"from_node", *execution.ResumeFromNodeID,
+		"reused_steps", len(outputs),
+	)
+	return outputs, nil
+}
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestExecute_ResumesFromNode(t *testing.T) {
+	// The delay would block the test if it ran again instead of being reused
+	executor, mockRepo, execution := newCancelTestExecutor("1m")
+
+	resumeFrom := "transform-1"
+	execution.ResumeFromNodeID = &resumeFrom
+
+	delayOutput := json.RawMessage(`{"waited":"1m"}`)
+	mockRepo.stepExecutions["seeded-delay"] = &workflow.StepExecution{
+		ID:          "seeded-delay",
+		ExecutionID: execution.ID,
+		NodeID:      "delay-1",
+		NodeType:    string(workflow.NodeTypeControlDelay),
+		Status:      "completed",
+		OutputData:  &delayOutput,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.Execute(context.Background(), execution)
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resumed execution ran the seeded delay node again")
+	}
+
+	assert.Equal(t, string(workflow.ExecutionStatusCompleted), mockRepo.executionStatus)
+	_, ranDelay := mockRepo.stepExecutions["delay-1-step"]
+	assert.False(t, ranDelay)
+	_, ranTransform := mockRepo.stepExecutions["transform-1-step"]
+	assert.True(t, ranTransform)
+
+	var outputs map[string]interface{}
+	require.NoError(t, json.Unmarshal(mockRepo.executionOutput, &outputs))
+	assert.Equal(t, map[string]interface{}{"waited": "1m"}, outputs["delay-1"])
+}
@@ -0,0 +1,105 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorax/gorax/internal/executor/javascript"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// Limits for action:script nodes. They are tighter than action:code's
+// because any workflow author can run a script; memory and recursion use the
+// strict sandbox limits.
+const (
+	scriptMaxOutputBytes = 1024 * 1024
+	scriptDefaultTimeout = 5 * time.Second
+	scriptMaxTimeout     = 10 * time.Second
+	scriptEnginePoolSize = 2
+)
+
+// scriptRuntime returns the engine for action:script nodes, creating it on
+// first use
+func (e *Executor) scriptRuntime() (*javascript.Engine, error) {
+	e.scriptEngineOnce.Do(func() {
+		e.scriptEngine, e.scriptEngineErr = javascript.NewEngine(&javascript.EngineConfig{
+			Limits:         javascript.StrictLimits(scriptDefaultTimeout, workflow.MaxScriptNodeLength),
+			SandboxConfig:  javascript.StrictSandboxConfig(),
+			PoolSize:       scriptEnginePoolSize,
+			Logger:         e.logger,
+			SectionGlobals: []string{"trigger", "steps"},
+		})
+	})
+	return e.scriptEngine, e.scriptEngineErr
+}
+
+// executeScriptAction runs an action:script node's script in the sandbox and
+// returns the value it returns. Scripts see only trigger and steps.
+func (e *Executor) executeScriptAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
+	var config workflow.ScriptNodeConfig
+	if len(node.Data.Config) > 0 {
+		if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse script config: %w", err)
+		}
+	}
+	if config.Script == "" {
+		return nil, scriptError(node, errors.New("script is required"))
+	}
+	if len(config.Script) > workflow.MaxScriptNodeLength {
+		return nil, scriptError(node, fmt.Errorf("%w: %d bytes, limit is %d", javascript.ErrScriptTooLarge, len(config.Script), workflow.MaxScriptNodeLength))
+	}
+
+	timeout := scriptDefaultTimeout
+	if config.Timeout > 0 {
+		timeout = min(time.Duration(config.Timeout)*time.Second, scriptMaxTimeout)
+	}
+
+	engine, err := e.scriptRuntime()
+	if err != nil {
+		return nil, fmt.Errorf("script engine unavailable: %w", err)
+	}
+
+	result, err := engine.Execute(ctx, &javascript.ExecuteConfig{
+		Script: config.Script,
+		Context: javascript.NewExecutionContext().
+			WithTrigger(execCtx.TriggerData).
+			WithSteps(execCtx.StepOutputs),
+		Timeout:     timeout,
+		ExecutionID: execCtx.ExecutionID,
+		TenantID:    execCtx.TenantID,
+		WorkflowID:  execCtx.WorkflowID,
+		NodeID:      node.ID,
+		UserID:      execCtx.GetUserID(),
+	})
+	if err != nil {
+		// A cancelled execution is not the script's fault
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, scriptError(node, err)
+	}
+
+	encoded, err := json.Marshal(result.Result)
+	if err != nil {
+		return nil, scriptError(node, fmt.Errorf("script result is not JSON: %w", err))
+	}
+	if len(encoded) > scriptMaxOutputBytes {
+		return nil, scriptError(node, fmt.Errorf("script result is %d bytes, limit is %d", len(encoded), scriptMaxOutputBytes))
+	}
+
+	return result.Result, nil
+}
+
+// scriptError marks a script failure permanent. Scripts are deterministic, so
+// one that fails or exceeds a limit would do the same again on retry.
+func scriptError(node workflow.Node, err error) error {
+	return &ExecutionError{
+		Err:            fmt.Errorf("script failed: %w", err),
+		Classification: ErrorClassificationPermanent,
+		NodeID:         node.ID,
+		NodeType:       node.Type,
+	}
+}
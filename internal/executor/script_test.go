@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/executor/javascript"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func scriptNode(t *testing.T, config workflow.ScriptNodeConfig) workflow.Node {
+	t.Helper()
+	raw, err := json.Marshal(config)
+	require.NoError(t, err)
+	return workflow.Node{
+		ID:   "script-1",
+		Type: string(workflow.NodeTypeActionScript),
+		Data: workflow.NodeData{Name: "Script", Config: raw},
+	}
+}
+
+func TestExecuteScriptAction(t *testing.T) {
+	execCtx := &ExecutionContext{
+		TenantID:    "tenant-1",
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		TriggerData: map[string]interface{}{"order": map[string]interface{}{"total": 40}},
+		StepOutputs: map[string]interface{}{
+			"http-1": map[string]interface{}{"body": map[string]interface{}{"discount": 5}},
+		},
+	}
+	e := &Executor{logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))}
+
+	t.Run("returns the script's value", func(t *testing.T) {
+		node := scriptNode(t, workflow.ScriptNodeConfig{
+			Script: "return { total: trigger.order.total - steps['http-1'].body.discount };",
+		})
+
+		output, err := e.executeScriptAction(context.Background(), node, execCtx)
+		require.NoError(t, err)
+
+		result := output.(map[string]interface{})
+		assert.EqualValues(t, 35, result["total"])
+	})
+
+	t.Run("sees only trigger, steps and the allowed globals", func(t *testing.T) {
+		node := scriptNode(t, workflow.ScriptNodeConfig{
+			Script: "return [typeof env, typeof context.env, typeof Promise, typeof setTimeout, typeof Reflect, typeof JSON];",
+		})
+
+		output, err := e.executeScriptAction(context.Background(), node, execCtx)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"undefined", "object", "undefined", "undefined", "undefined", "object"}, output)
+	})
+
+	t.Run("runaway scripts time out permanently", func(t *testing.T) {
+		node := scriptNode(t, workflow.ScriptNodeConfig{Script: "while (true) {}", Timeout: 1})
+
+		_, err := e.executeScriptAction(context.Background(), node, execCtx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, javascript.ErrTimeout)
+		assert.False(t, ShouldRetry(err, 0, 3))
+	})
+
+	t.Run("rejects oversized scripts before running them", func(t *testing.T) {
+		node := scriptNode(t, workflow.ScriptNodeConfig{
+			Script: "return 1;" + strings.Repeat(" ", workflow.MaxScriptNodeLength),
+		})
+
+		_, err := e.executeScriptAction(context.Background(), node, execCtx)
+		assert.ErrorIs(t, err, javascript.ErrScriptTooLarge)
+		assert.False(t, ShouldRetry(err, 0, 3))
+	})
+
+	t.Run("requires a script", func(t *testing.T) {
+		_, err := e.executeScriptAction(context.Background(), scriptNode(t, workflow.ScriptNodeConfig{}), execCtx)
+		assert.ErrorContains(t, err, "script is required")
+	})
+
+	t.Run("script errors fail the node", func(t *testing.T) {
+		node := scriptNode(t, workflow.ScriptNodeConfig{Script: "throw new Error('bad order');"})
+
+		_, err := e.executeScriptAction(context.Background(), node, execCtx)
+		assert.ErrorContains(t, err, "bad order")
+	})
+}
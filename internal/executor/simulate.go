@@ -0,0 +1,136 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// simulationExecutionID identifies the execution context of simulated runs
+const simulationExecutionID = "simulation"
+
+// Simulate runs a workflow in memory against triggerData. Nodes named in
+// mocks return their mocked output. Transform, formula and branching nodes
+// are computed so downstream expressions see real data, while every other
+// action is stubbed with a nil output. No execution or step records are
+// written and no broadcasts are sent.
+func (e *Executor) Simulate(ctx context.Context, wf *workflow.Workflow, triggerData []byte, mocks map[string]interface{}) (*workflow.SimulationResult, error) {
+	var definition workflow.WorkflowDefinition
+	if err := json.Unmarshal(wf.Definition, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+	}
+
+	executionOrder, err := topologicalSort(definition.Nodes, definition.Edges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine execution order: %w", err)
+	}
+
+	trigger := make(map[string]interface{})
+	if len(triggerData) > 0 {
+		if err := json.Unmarshal(triggerData, &trigger); err != nil {
+			return nil, fmt.Errorf("invalid trigger data: %w", err)
+		}
+	}
+
+	execCtx := &ExecutionContext{
+		TenantID:         wf.TenantID,
+		ExecutionID:      simulationExecutionID,
+		WorkflowID:       wf.ID,
+		TriggerType:      "manual",
+		TriggerData:      trigger,
+		StepOutputs:      make(map[string]interface{}),
+		CredentialValues: []string{},
+		CredentialCache:  credential.NewResolutionCache(),
+		WorkflowChain:    []string{wf.ID},
+	}
+
+	execCtx.Env, err = e.loadEnv(ctx, wf.TenantID, wf.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &workflow.SimulationResult{
+		ExecutionOrder: []string{},
+		StepOutputs:    execCtx.StepOutputs,
+		MockedNodes:    []string{},
+		StubbedNodes:   []string{},
+		SkippedNodes:   []string{},
+	}
+
+	execution := &workflow.Execution{TriggerType: execCtx.TriggerType}
+	if nodeID := workflow.TriggerNodeFromContext(ctx); nodeID != "" {
+		execution.TriggerNodeID = &nodeID
+	}
+	entryTrigger := workflow.EntryTrigger(&definition, execution)
+
+	nodeMap := buildNodeMap(definition.Nodes)
+	skippedNodes := make(map[string]bool)
+	takenBranches := make(map[string]string)
+	for _, nodeID := range executionOrder {
+		node, exists := nodeMap[nodeID]
+		if !exists {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		skip := isOnUntakenBranch(node.ID, definition.Edges, skippedNodes, takenBranches)
+		if isTriggerNode(node.Type) {
+			skip = entryTrigger != "" && node.ID != entryTrigger
+		}
+		if skip {
+			skippedNodes[node.ID] = true
+			result.SkippedNodes = append(result.SkippedNodes, node.ID)
+			continue
+		}
+		result.ExecutionOrder = append(result.ExecutionOrder, node.ID)
+
+		output, err := e.simulateNode(ctx, node, execCtx, &definition, mocks, result)
+		if err != nil {
+			result.FailedNode = node.ID
+			result.Error = err.Error()
+			return result, nil
+		}
+
+		execCtx.StepOutputs[node.ID] = output
+		if label, ok := branchTaken(output); ok && isBranchingNode(node.Type) {
+			takenBranches[node.ID] = label
+		}
+	}
+
+	return result, nil
+}
+
+// simulateNode returns the output of a single node during a simulation and
+// records on result whether it was mocked or stubbed
+func (e *Executor) simulateNode(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, definition *workflow.WorkflowDefinition, mocks map[string]interface{}, result *workflow.SimulationResult) (interface{}, error) {
+	if output, ok := mocks[node.ID]; ok {
+		result.MockedNodes = append(result.MockedNodes, node.ID)
+		return output, nil
+	}
+
+	switch node.Type {
+	case string(workflow.NodeTypeControlIf):
+		return e.executeConditionalAction(ctx, node, execCtx, definition)
+	case string(workflow.NodeTypeControlSwitch):
+		return e.executeSwitchAction(ctx, node, execCtx, definition)
+	case string(workflow.NodeTypeControlSplit):
+		return e.executeSplitAction(ctx, node, execCtx, definition)
+	case string(workflow.NodeTypeActionTransform):
+		return e.executeTransformAction(ctx, node, execCtx)
+	case string(workflow.NodeTypeActionFormula):
+		return e.executeFormulaAction(ctx, node, execCtx)
+	}
+
+	if isTriggerNode(node.Type) {
+		return execCtx.TriggerData, nil
+	}
+
+	result.StubbedNodes = append(result.StubbedNodes, node.ID)
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// newSimulationTestWorkflow builds a workflow that routes on trigger.severity
+// to a page or a lookup request, summarises the lookup and then notifies
+func newSimulationTestWorkflow() *workflow.Workflow {
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "trigger", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "Trigger"}},
+			{
+				ID:   "route",
+				Type: string(workflow.NodeTypeControlSwitch),
+				Data: workflow.NodeData{Name: "Route Severity", Config: mustMarshal(workflow.SwitchActionConfig{
+					Expression: "trigger.severity",
+					Cases:      []workflow.SwitchCase{{Value: "critical", Label: "page"}},
+				})},
+			},
+			{ID: "page", Type: string(workflow.NodeTypeActionHTTP), Data: workflow.NodeData{Name: "Page"}},
+			{ID: "lookup", Type: string(workflow.NodeTypeActionHTTP), Data: workflow.NodeData{Name: "Lookup"}},
+			{
+				ID:   "summary",
+				Type: string(workflow.NodeTypeActionTransform),
+				Data: workflow.NodeData{Name: "Summary", Config: mustMarshal(map[string]interface{}{"expression": "steps.lookup.body"})},
+			},
+			{ID: "notify", Type: string(workflow.NodeTypeActionSlackSendMessage), Data: workflow.NodeData{Name: "Notify"}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "trigger", Target: "route"},
+			{ID: "e2", Source: "route", Target: "page", Label: "page"},
+			{ID: "e3", Source: "route", Target: "lookup", Label: "default"},
+			{ID: "e4", Source: "lookup", Target: "summary"},
+			{ID: "e5", Source: "summary", Target: "notify"},
+		},
+	}
+
+	return &workflow.Workflow{ID: "wf-1", TenantID: "tenant-1", Definition: mustMarshal(definition)}
+}
+
+func TestExecutor_Simulate(t *testing.T) {
+	// No repository calls are expected during a simulation
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{logger: logger}
+
+	mocks := map[string]interface{}{
+		"lookup": map[string]interface{}{"status_code": 200, "body": map[string]interface{}{"owner": "alice"}},
+	}
+	result, err := executor.Simulate(context.Background(), newSimulationTestWorkflow(), mustMarshal(map[string]interface{}{"severity": "low"}), mocks)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, []string{"trigger", "route", "lookup", "summary", "notify"}, result.ExecutionOrder)
+	assert.Equal(t, []string{"lookup"}, result.MockedNodes)
+	assert.Equal(t, []string{"notify"}, result.StubbedNodes)
+	assert.Equal(t, []string{"page"}, result.SkippedNodes)
+	assert.Equal(t, map[string]interface{}{"severity": "low"}, result.StepOutputs["trigger"])
+	assert.Equal(t, map[string]interface{}{"owner": "alice"}, result.StepOutputs["summary"])
+	assert.Nil(t, result.StepOutputs["notify"])
+}
+
+func TestExecutor_Simulate_ReportsFailedNode(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{logger: logger}
+
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "trigger", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "Trigger"}},
+			{
+				ID:   "summary",
+				Type: string(workflow.NodeTypeActionTransform),
+				Data: workflow.NodeData{Name: "Summary", Config: mustMarshal(map[string]interface{}{"expression": "trigger.("})},
+			},
+			{ID: "notify", Type: string(workflow.NodeTypeActionSlackSendMessage), Data: workflow.NodeData{Name: "Notify"}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "trigger", Target: "summary"},
+			{ID: "e2", Source: "summary", Target: "notify"},
+		},
+	}
+	wf := &workflow.Workflow{ID: "wf-1", TenantID: "tenant-1", Definition: mustMarshal(definition)}
+
+	result, err := executor.Simulate(context.Background(), wf, nil, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", result.FailedNode)
+	assert.NotEmpty(t, result.Error)
+	assert.NotContains(t, result.StepOutputs, "notify")
+}
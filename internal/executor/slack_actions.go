@@ -29,6 +29,13 @@ func (e *Executor) executeSlackSendMessageAction(ctx context.Context, node workf
 		return nil, fmt.Errorf("failed to parse Slack send message config: %w", err)
 	}
 
+	// Register the interaction a message with buttons waits on
+	if len(config.Buttons) > 0 {
+		if err := e.registerSlackInteraction(ctx, node, execCtx, &config); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create Slack action
 	action := slack.NewSendMessageAction(e.credentialService)
 
@@ -41,10 +48,13 @@ func (e *Executor) executeSlackSendMessageAction(ctx context.Context, node workf
 	// Execute action
 	output, err := action.Execute(ctx, input)
 	if err != nil {
+		if config.InteractionToken != "" {
+			_ = e.slackInteractions.Expire(ctx, config.InteractionToken)
+		}
 		return nil, err
 	}
 
-	return output.Data, nil
+	return slackStepOutput(output.Data)
 }
 
 // executeSlackSendDMAction executes a Slack send direct message action
@@ -81,7 +91,7 @@ func (e *Executor) executeSlackSendDMAction(ctx context.Context, node workflow.N
 		return nil, err
 	}
 
-	return output.Data, nil
+	return slackStepOutput(output.Data)
 }
 
 // executeSlackUpdateMessageAction executes a Slack update message action
@@ -118,7 +128,7 @@ func (e *Executor) executeSlackUpdateMessageAction(ctx context.Context, node wor
 		return nil, err
 	}
 
-	return output.Data, nil
+	return slackStepOutput(output.Data)
 }
 
 // executeSlackAddReactionAction executes a Slack add reaction action
@@ -155,5 +165,20 @@ func (e *Executor) executeSlackAddReactionAction(ctx context.Context, node workf
 		return nil, err
 	}
 
-	return output.Data, nil
+	return slackStepOutput(output.Data)
+}
+
+// slackStepOutput converts a Slack action result into a map so downstream
+// nodes can reference fields such as ${steps.<id>.ts} and ${steps.<id>.channel}
+func slackStepOutput(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Slack result: %w", err)
+	}
+
+	var output map[string]interface{}
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return nil, fmt.Errorf("failed to decode Slack result: %w", err)
+	}
+	return output, nil
 }
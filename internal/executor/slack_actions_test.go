@@ -50,6 +50,18 @@ func (m *MockCredentialService) Delete(ctx context.Context, tenantID, credential
 	return nil
 }
 
+func (m *MockCredentialService) Purge(ctx context.Context, tenantID, credentialID, userID string) error {
+	return nil
+}
+
+func (m *MockCredentialService) Restore(ctx context.Context, tenantID, credentialID, userID string) (*credential.Credential, error) {
+	return nil, nil
+}
+
+func (m *MockCredentialService) BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []credential.BulkOperationError) {
+	return nil, nil
+}
+
 func (m *MockCredentialService) Rotate(ctx context.Context, tenantID, credentialID, userID string, input credential.RotateCredentialInput) (*credential.Credential, error) {
 	return nil, nil
 }
@@ -62,6 +74,18 @@ func (m *MockCredentialService) GetAccessLog(ctx context.Context, tenantID, cred
 	return nil, nil
 }
 
+func (m *MockCredentialService) GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*credential.DecryptedValue, error) {
+	return m.GetValue(ctx, tenantID, credentialID, userID)
+}
+
+func (m *MockCredentialService) LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error {
+	return nil
+}
+
+func (m *MockCredentialService) TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*credential.CredentialTestResult, error) {
+	return nil, nil
+}
+
 // TestExecuteSlackSendMessageAction tests the Slack send message execution
 func TestExecuteSlackSendMessageAction(t *testing.T) {
 	tests := []struct {
@@ -628,8 +652,10 @@ func TestExecuteSlackActions_CredentialInjection(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	// Mock credential service that returns valid Slack token
+	credentialResolved := false
 	credService := &MockCredentialService{
 		GetValueFunc: func(ctx context.Context, tenantID, credentialID, userID string) (*credential.DecryptedValue, error) {
+			credentialResolved = true
 			assert.Equal(t, "tenant-123", tenantID)
 			assert.Equal(t, "cred-123", credentialID)
 			return &credential.DecryptedValue{
@@ -653,14 +679,14 @@ func TestExecuteSlackActions_CredentialInjection(t *testing.T) {
 		Data: workflow.NodeData{
 			Name: "Send Message",
 			Config: json.RawMessage(`{
+				"credential_id": "cred-123",
 				"channel": "C1234567890",
 				"text": "Test message with credential"
 			}`),
 		},
 	}
 
-	// In real execution, credential_id would be injected by the credential injector
-	// Here we simulate it being in the context
+	// The node's credential_id is resolved through the credential service
 	execCtx := &ExecutionContext{
 		TenantID:    "tenant-123",
 		ExecutionID: "exec-123",
@@ -672,10 +698,11 @@ func TestExecuteSlackActions_CredentialInjection(t *testing.T) {
 	ctx := context.Background()
 	_, err := executor.executeSlackSendMessageAction(ctx, node, execCtx)
 
-	// We expect an error because credential_id is not in the ActionInput context
-	// This is expected - in real execution, the credential injector would handle this
+	// The Slack API call itself may fail without network access, but the
+	// credential must have been resolved from the node config
+	assert.True(t, credentialResolved)
 	if err != nil {
-		assert.Contains(t, err.Error(), "credential_id is required in context")
+		assert.NotContains(t, err.Error(), "credential_id is required")
 	}
 }
 
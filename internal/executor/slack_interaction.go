@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/integrations/slack"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// defaultSlackPollInterval is how often a waiting node checks for a response
+const defaultSlackPollInterval = 2 * time.Second
+
+// SlackInteractionStore records the responses that Slack messages with
+// buttons wait for. Responses arrive through the Slack interactions endpoint,
+// possibly on another instance, so waiting nodes poll the store.
+type SlackInteractionStore interface {
+	Create(ctx context.Context, interaction *slack.Interaction) error
+	Get(ctx context.Context, token string) (*slack.Interaction, error)
+	Expire(ctx context.Context, token string) error
+}
+
+// SetSlackInteractions sets the store for Slack message responses. Without
+// one, messages with buttons and slack:wait_for_response nodes fail.
+func (e *Executor) SetSlackInteractions(store SlackInteractionStore) {
+	e.slackInteractions = store
+}
+
+// registerSlackInteraction records a pending interaction for a message with
+// buttons and sets the token its buttons carry
+func (e *Executor) registerSlackInteraction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, config *slack.SendMessageConfig) error {
+	if e.slackInteractions == nil {
+		return fmt.Errorf("slack interactions are not configured: set SLACK_SIGNING_SECRET to use message buttons")
+	}
+
+	ttl, err := config.ResponseTTL()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	token, err := slack.NewInteractionToken()
+	if err != nil {
+		return err
+	}
+
+	interaction := &slack.Interaction{
+		Token:       token,
+		TenantID:    execCtx.TenantID,
+		ExecutionID: execCtx.ExecutionID,
+		NodeID:      node.ID,
+		Options:     config.ButtonValues(),
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+	if err := e.slackInteractions.Create(ctx, interaction); err != nil {
+		return err
+	}
+
+	config.InteractionToken = token
+	return nil
+}
+
+// executeSlackWaitForResponseAction waits for a response to a message sent
+// with buttons, finishing when someone responds or the wait times out
+func (e *Executor) executeSlackWaitForResponseAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
+	if e.slackInteractions == nil {
+		return nil, fmt.Errorf("slack interactions are not configured: set SLACK_SIGNING_SECRET to wait for responses")
+	}
+
+	var config slack.WaitForResponseConfig
+	if len(node.Data.Config) > 0 {
+		if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse Slack wait for response config: %w", err)
+		}
+	}
+
+	token := actions.InterpolateString(config.Token, buildInterpolationContext(execCtx))
+	if token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	interaction, err := e.slackInteractions.Get(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if interaction.TenantID != execCtx.TenantID {
+		return nil, slack.ErrInteractionNotFound
+	}
+
+	deadline := interaction.ExpiresAt
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q: must be a positive duration", config.Timeout)
+		}
+		if limit := time.Now().Add(timeout); limit.Before(deadline) {
+			deadline = limit
+		}
+	}
+
+	pollInterval := e.slackPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultSlackPollInterval
+	}
+
+	for {
+		switch {
+		case interaction.Status == slack.InteractionStatusResponded:
+			return slackStepOutput(slack.WaitForResponseResult{
+				Responded:   true,
+				Value:       interaction.Value,
+				Source:      interaction.Source,
+				UserID:      interaction.ResponderID,
+				UserName:    interaction.ResponderName,
+				RespondedAt: interaction.RespondedAt,
+			})
+		case interaction.Status == slack.InteractionStatusExpired || !time.Now().Before(deadline):
+			if err := e.slackInteractions.Expire(ctx, token); err != nil {
+				return nil, err
+			}
+			// A response may have landed just before the interaction closed
+			if interaction, err = e.slackInteractions.Get(ctx, token); err != nil {
+				return nil, err
+			}
+			if interaction.Status == slack.InteractionStatusResponded {
+				continue
+			}
+			return slackStepOutput(slack.WaitForResponseResult{TimedOut: true})
+		}
+
+		if err := e.waitWithContext(ctx, min(pollInterval, time.Until(deadline))); err != nil {
+			return nil, err
+		}
+		if interaction, err = e.slackInteractions.Get(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/integrations/slack"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// fakeSlackInteractions is an in-memory SlackInteractionStore. onGet runs on
+// each Get so tests can answer an interaction while a node is waiting.
+type fakeSlackInteractions struct {
+	mu           sync.Mutex
+	interactions map[string]*slack.Interaction
+	gets         int
+	onGet        func(gets int, interaction *slack.Interaction)
+}
+
+func newFakeSlackInteractions(interactions ...*slack.Interaction) *fakeSlackInteractions {
+	store := &fakeSlackInteractions{interactions: make(map[string]*slack.Interaction)}
+	for _, interaction := range interactions {
+		store.interactions[interaction.Token] = interaction
+	}
+	return store
+}
+
+func (f *fakeSlackInteractions) Create(ctx context.Context, interaction *slack.Interaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	interaction.Status = slack.InteractionStatusPending
+	f.interactions[interaction.Token] = interaction
+	return nil
+}
+
+func (f *fakeSlackInteractions) Get(ctx context.Context, token string) (*slack.Interaction, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	interaction, ok := f.interactions[token]
+	if !ok {
+		return nil, slack.ErrInteractionNotFound
+	}
+	f.gets++
+	if f.onGet != nil {
+		f.onGet(f.gets, interaction)
+	}
+	copied := *interaction
+	return &copied, nil
+}
+
+func (f *fakeSlackInteractions) Expire(ctx context.Context, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if interaction, ok := f.interactions[token]; ok && interaction.Status == slack.InteractionStatusPending {
+		interaction.Status = slack.InteractionStatusExpired
+	}
+	return nil
+}
+
+func waitNode(config string) workflow.Node {
+	return workflow.Node{
+		ID:   "wait-1",
+		Type: string(workflow.NodeTypeActionSlackWaitForResponse),
+		Data: workflow.NodeData{Name: "Wait", Config: []byte(config)},
+	}
+}
+
+func TestExecuteSlackWaitForResponse(t *testing.T) {
+	execCtx := &ExecutionContext{
+		TenantID:    "tenant-1",
+		ExecutionID: "exec-1",
+		StepOutputs: map[string]interface{}{
+			"slack-1": map[string]interface{}{"interaction_token": "tok-1"},
+		},
+	}
+	pending := func() *slack.Interaction {
+		return &slack.Interaction{
+			Token:     "tok-1",
+			TenantID:  "tenant-1",
+			Status:    slack.InteractionStatusPending,
+			Options:   []string{"approve", "reject"},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("returns the response once someone answers", func(t *testing.T) {
+		store := newFakeSlackInteractions(pending())
+		store.onGet = func(gets int, interaction *slack.Interaction) {
+			if gets == 3 {
+				respondedAt := time.Now()
+				interaction.Status = slack.InteractionStatusResponded
+				interaction.Value = "approve"
+				interaction.Source = slack.InteractionSourceButton
+				interaction.ResponderID = "U1"
+				interaction.ResponderName = "alice"
+				interaction.RespondedAt = &respondedAt
+			}
+		}
+		e := &Executor{slackInteractions: store, slackPollInterval: time.Millisecond}
+
+		output, err := e.executeSlackWaitForResponseAction(context.Background(), waitNode(`{"token":"${steps.slack-1.interaction_token}"}`), execCtx)
+		require.NoError(t, err)
+
+		result := output.(map[string]interface{})
+		assert.Equal(t, true, result["responded"])
+		assert.Equal(t, false, result["timed_out"])
+		assert.Equal(t, "approve", result["value"])
+		assert.Equal(t, "button", result["source"])
+		assert.Equal(t, "alice", result["user_name"])
+	})
+
+	t.Run("times out and closes the interaction", func(t *testing.T) {
+		store := newFakeSlackInteractions(pending())
+		e := &Executor{slackInteractions: store, slackPollInterval: time.Millisecond}
+
+		output, err := e.executeSlackWaitForResponseAction(context.Background(), waitNode(`{"token":"tok-1","timeout":"20ms"}`), execCtx)
+		require.NoError(t, err)
+
+		result := output.(map[string]interface{})
+		assert.Equal(t, false, result["responded"])
+		assert.Equal(t, true, result["timed_out"])
+		assert.Equal(t, "", result["value"])
+		assert.Equal(t, slack.InteractionStatusExpired, store.interactions["tok-1"].Status)
+	})
+
+	t.Run("another tenant's interaction is not found", func(t *testing.T) {
+		other := pending()
+		other.TenantID = "tenant-2"
+		e := &Executor{slackInteractions: newFakeSlackInteractions(other)}
+
+		_, err := e.executeSlackWaitForResponseAction(context.Background(), waitNode(`{"token":"tok-1"}`), execCtx)
+		assert.ErrorIs(t, err, slack.ErrInteractionNotFound)
+	})
+
+	t.Run("stops when the execution is cancelled", func(t *testing.T) {
+		e := &Executor{slackInteractions: newFakeSlackInteractions(pending()), slackPollInterval: time.Millisecond}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := e.executeSlackWaitForResponseAction(ctx, waitNode(`{"token":"tok-1"}`), execCtx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("requires an interaction store", func(t *testing.T) {
+		e := &Executor{}
+		_, err := e.executeSlackWaitForResponseAction(context.Background(), waitNode(`{"token":"tok-1"}`), execCtx)
+		assert.ErrorContains(t, err, "SLACK_SIGNING_SECRET")
+	})
+}
+
+func TestRegisterSlackInteraction(t *testing.T) {
+	store := newFakeSlackInteractions()
+	e := &Executor{slackInteractions: store}
+	execCtx := &ExecutionContext{TenantID: "tenant-1", ExecutionID: "exec-1"}
+	config := slack.SendMessageConfig{
+		Channel:         "#deployments",
+		Text:            "Deploy?",
+		Buttons:         []slack.MessageButton{{Text: "Approve", Value: "approve"}, {Text: "Reject", Value: "reject"}},
+		ResponseTimeout: "30m",
+	}
+
+	before := time.Now()
+	require.NoError(t, e.registerSlackInteraction(context.Background(), workflow.Node{ID: "slack-1"}, execCtx, &config))
+
+	require.NotEmpty(t, config.InteractionToken)
+	interaction := store.interactions[config.InteractionToken]
+	require.NotNil(t, interaction)
+	assert.Equal(t, "tenant-1", interaction.TenantID)
+	assert.Equal(t, "exec-1", interaction.ExecutionID)
+	assert.Equal(t, "slack-1", interaction.NodeID)
+	assert.Equal(t, []string{"approve", "reject"}, interaction.Options)
+	assert.WithinDuration(t, before.Add(30*time.Minute), interaction.ExpiresAt, time.Second)
+
+	err := (&Executor{}).registerSlackInteraction(context.Background(), workflow.Node{ID: "slack-1"}, execCtx, &config)
+	assert.ErrorContains(t, err, "SLACK_SIGNING_SECRET")
+}
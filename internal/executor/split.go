@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand/v2"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// SplitBranchResult represents the branch a split node routed an execution to
+type SplitBranchResult struct {
+	Roll        int      `json:"roll"`
+	TakenBranch string   `json:"taken_branch"`
+	NextNodes   []string `json:"next_nodes"`
+}
+
+// SplitRollFunc returns the bucket, from 0 to 99, that a split node routes an
+// execution by
+type SplitRollFunc func(executionID, nodeID string) int
+
+// SetSplitRoll replaces how split nodes draw their bucket. By default the draw
+// comes from an RNG seeded with the execution and node IDs, so a split always
+// routes the same execution the same way; tests can pin the bucket here.
+func (e *Executor) SetSplitRoll(roll SplitRollFunc) {
+	e.splitRoll = roll
+}
+
+// seededSplitRoll draws a bucket from an RNG seeded with the execution and node IDs
+func seededSplitRoll(executionID, nodeID string) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(executionID))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(nodeID))
+	// #nosec G404 -- routing needs reproducibility, not unpredictability
+	return rand.New(rand.NewPCG(h.Sum64(), 0)).IntN(100)
+}
+
+// executeSplitAction routes an execution along one outgoing edge of a split
+// node, choosing each edge for its weight's percentage of executions
+func (e *Executor) executeSplitAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, definition *workflow.WorkflowDefinition) (*SplitBranchResult, error) {
+	roll := e.splitRoll
+	if roll == nil {
+		roll = seededSplitRoll
+	}
+
+	result := &SplitBranchResult{Roll: roll(execCtx.ExecutionID, node.ID)}
+	result.TakenBranch = chooseSplitBranch(result.Roll, node.ID, definition.Edges)
+	result.NextNodes = e.findConditionalBranch(node.ID, result.TakenBranch, definition.Edges)
+
+	e.logger.Info("split branch determined",
+		"node_id", node.ID,
+		"roll", result.Roll,
+		"taken_branch", result.TakenBranch,
+		"next_nodes", result.NextNodes,
+	)
+
+	return result, nil
+}
+
+// chooseSplitBranch returns the label of the edge whose share of the 0-99
+// range holds roll. Edges take consecutive shares in definition order.
+func chooseSplitBranch(roll int, nodeID string, edges []workflow.Edge) string {
+	cumulative := 0
+	for _, edge := range edges {
+		if edge.Source != nodeID {
+			continue
+		}
+		cumulative += edge.Weight
+		if roll < cumulative {
+			return edge.Label
+		}
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// newSplitTestExecutor builds an executor for a workflow that sends 10% of
+// executions to a canary node and the rest to a stable node, then always
+// records the result
+func newSplitTestExecutor() (*Executor, *mockWorkflowRepo, *workflow.Execution) {
+	transform := mustMarshal(map[string]interface{}{"expression": "trigger"})
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "trigger-1", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "Trigger"}},
+			{ID: "split-1", Type: string(workflow.NodeTypeControlSplit), Data: workflow.NodeData{Name: "Canary"}},
+			{ID: "canary-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Canary", Config: transform}},
+			{ID: "stable-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Stable", Config: transform}},
+			{ID: "record-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Record", Config: transform}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "trigger-1", Target: "split-1"},
+			{ID: "e2", Source: "split-1", Target: "canary-1", Label: "canary", Weight: 10},
+			{ID: "e3", Source: "split-1", Target: "stable-1", Label: "stable", Weight: 90},
+			{ID: "e4", Source: "canary-1", Target: "record-1"},
+			{ID: "e5", Source: "stable-1", Target: "record-1"},
+		},
+	}
+
+	mockRepo := &mockWorkflowRepo{
+		workflow: &workflow.Workflow{
+			ID:         "wf-1",
+			TenantID:   "tenant-1",
+			Definition: mustMarshal(definition),
+		},
+		stepExecutions: make(map[string]*workflow.StepExecution),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{
+		repo:               mockRepo,
+		logger:             logger,
+		retryStrategy:      NewRetryStrategy(DefaultRetryConfig(), logger),
+		circuitBreakers:    NewCircuitBreakerRegistry(DefaultCircuitBreakerConfig(), logger),
+		defaultRetryConfig: DefaultNodeRetryConfig(),
+	}
+
+	triggerData := json.RawMessage(`{}`)
+	execution := &workflow.Execution{
+		ID:          "exec-1",
+		TenantID:    "tenant-1",
+		WorkflowID:  "wf-1",
+		Status:      string(workflow.ExecutionStatusPending),
+		TriggerType: "manual",
+		TriggerData: &triggerData,
+	}
+
+	return executor, mockRepo, execution
+}
+
+func TestSplit_RoutesByRoll(t *testing.T) {
+	tests := []struct {
+		roll    int
+		ran     []string
+		skipped []string
+	}{
+		{roll: 0, ran: []string{"canary-1", "record-1"}, skipped: []string{"stable-1"}},
+		{roll: 9, ran: []string{"canary-1", "record-1"}, skipped: []string{"stable-1"}},
+		{roll: 10, ran: []string{"stable-1", "record-1"}, skipped: []string{"canary-1"}},
+		{roll: 99, ran: []string{"stable-1", "record-1"}, skipped: []string{"canary-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.roll), func(t *testing.T) {
+			executor, mockRepo, execution := newSplitTestExecutor()
+			executor.SetSplitRoll(func(executionID, nodeID string) int {
+				assert.Equal(t, "exec-1", executionID)
+				assert.Equal(t, "split-1", nodeID)
+				return tt.roll
+			})
+
+			err := executor.Execute(context.Background(), execution)
+
+			require.NoError(t, err)
+			assert.Equal(t, string(workflow.ExecutionStatusCompleted), mockRepo.executionStatus)
+			for _, nodeID := range tt.ran {
+				assert.Contains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should run", nodeID)
+			}
+			for _, nodeID := range tt.skipped {
+				assert.NotContains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should be skipped", nodeID)
+			}
+		})
+	}
+}
+
+func TestSeededSplitRoll(t *testing.T) {
+	t.Run("reproducible per execution and node", func(t *testing.T) {
+		assert.Equal(t, seededSplitRoll("exec-1", "split-1"), seededSplitRoll("exec-1", "split-1"))
+	})
+
+	t.Run("spreads executions by weight", func(t *testing.T) {
+		edges := []workflow.Edge{
+			{Source: "split-1", Target: "a", Label: "canary", Weight: 10},
+			{Source: "split-1", Target: "b", Label: "stable", Weight: 90},
+		}
+		counts := make(map[string]int)
+		for i := 0; i < 10000; i++ {
+			roll := seededSplitRoll(fmt.Sprintf("exec-%d", i), "split-1")
+			require.True(t, roll >= 0 && roll < 100)
+			counts[chooseSplitBranch(roll, "split-1", edges)]++
+		}
+		assert.InDelta(t, 1000, counts["canary"], 150)
+		assert.InDelta(t, 9000, counts["stable"], 150)
+	})
+}
+
+func TestChooseSplitBranch(t *testing.T) {
+	edges := []workflow.Edge{
+		{Source: "other", Target: "x", Label: "ignored", Weight: 50},
+		{Source: "split-1", Target: "a", Label: "a", Weight: 25},
+		{Source: "split-1", Target: "b", Label: "b", Weight: 25},
+		{Source: "split-1", Target: "c", Label: "c", Weight: 50},
+	}
+
+	assert.Equal(t, "a", chooseSplitBranch(0, "split-1", edges))
+	assert.Equal(t, "a", chooseSplitBranch(24, "split-1", edges))
+	assert.Equal(t, "b", chooseSplitBranch(25, "split-1", edges))
+	assert.Equal(t, "c", chooseSplitBranch(50, "split-1", edges))
+	assert.Equal(t, "c", chooseSplitBranch(99, "split-1", edges))
+	assert.Equal(t, "", chooseSplitBranch(0, "missing", edges))
+}
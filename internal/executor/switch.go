@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/gorax/gorax/internal/executor/expression"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// SwitchBranchResult represents the result of evaluating a switch node
+type SwitchBranchResult struct {
+	Expression  string      `json:"expression"`
+	Value       interface{} `json:"value"`
+	Matched     bool        `json:"matched"`
+	TakenBranch string      `json:"taken_branch"`
+	NextNodes   []string    `json:"next_nodes"`
+}
+
+// executeSwitchAction evaluates a switch node's expression and picks the
+// first case whose value matches, falling back to the default branch
+func (e *Executor) executeSwitchAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext, definition *workflow.WorkflowDefinition) (*SwitchBranchResult, error) {
+	if len(node.Data.Config) == 0 {
+		return nil, fmt.Errorf("missing config for switch action")
+	}
+
+	var config workflow.SwitchActionConfig
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse switch action config: %w", err)
+	}
+
+	if config.Expression == "" {
+		return nil, fmt.Errorf("switch expression is required")
+	}
+
+	evalContext := expression.BuildContext(
+		execCtx.TriggerData,
+		execCtx.StepOutputs,
+		envContext(execCtx),
+	)
+
+	value, err := expression.NewEvaluator().Evaluate(config.Expression, evalContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate switch expression: %w", err)
+	}
+
+	result := &SwitchBranchResult{
+		Expression:  config.Expression,
+		Value:       value,
+		TakenBranch: workflow.SwitchDefaultBranch,
+	}
+	for _, c := range config.Cases {
+		if switchValuesEqual(value, c.Value) {
+			result.Matched = true
+			result.TakenBranch = c.BranchLabel()
+			break
+		}
+	}
+	result.NextNodes = e.findConditionalBranch(node.ID, result.TakenBranch, definition.Edges)
+
+	e.logger.Info("switch branch determined",
+		"node_id", node.ID,
+		"value", value,
+		"taken_branch", result.TakenBranch,
+		"next_nodes", result.NextNodes,
+	)
+
+	return result, nil
+}
+
+// switchValuesEqual compares an expression result with a case value. Numbers
+// compare by value regardless of their Go type, since case values decoded
+// from JSON are always float64.
+func switchValuesEqual(value, caseValue interface{}) bool {
+	if a, ok := switchNumber(value); ok {
+		b, ok := switchNumber(caseValue)
+		return ok && a == b
+	}
+	return reflect.DeepEqual(value, caseValue)
+}
+
+func switchNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// branchTaken returns the edge label taken by a branching node's output. The
+// output is either the result struct or, for outputs reused from a resumed
+// execution, its decoded JSON.
+func branchTaken(output interface{}) (string, bool) {
+	switch out := output.(type) {
+	case *ConditionalBranchResult:
+		return out.TakenBranch, true
+	case *SwitchBranchResult:
+		return out.TakenBranch, true
+	case *SplitBranchResult:
+		return out.TakenBranch, true
+	case map[string]interface{}:
+		label, ok := out["taken_branch"].(string)
+		return label, ok
+	default:
+		return "", false
+	}
+}
+
+// isBranchingNode reports whether a node routes to only some of its outgoing edges
+func isBranchingNode(nodeType string) bool {
+	return nodeType == string(workflow.NodeTypeControlIf) ||
+		nodeType == string(workflow.NodeTypeControlSwitch) ||
+		nodeType == string(workflow.NodeTypeControlSplit)
+}
+
+// isOnUntakenBranch reports whether every edge into a node is dead: its
+// source was skipped, or its source is a branching node that took another
+// branch. Nodes without incoming edges always run.
+func isOnUntakenBranch(nodeID string, edges []workflow.Edge, skipped map[string]bool, takenBranches map[string]string) bool {
+	incoming := 0
+	for _, edge := range edges {
+		if edge.Target != nodeID {
+			continue
+		}
+		incoming++
+		if skipped[edge.Source] {
+			continue
+		}
+		if taken, ok := takenBranches[edge.Source]; ok && edge.Label != taken {
+			continue
+		}
+		return false
+	}
+	return incoming > 0
+}
@@ -0,0 +1,203 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// newSwitchTestExecutor builds an executor for a workflow that routes on
+// trigger.severity to a page, ticket or log node, then always notifies
+func newSwitchTestExecutor(severity string) (*Executor, *mockWorkflowRepo, *workflow.Execution) {
+	transform := mustMarshal(map[string]interface{}{"expression": "trigger"})
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "trigger-1", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "Trigger"}},
+			{
+				ID:   "switch-1",
+				Type: string(workflow.NodeTypeControlSwitch),
+				Data: workflow.NodeData{Name: "Route Severity", Config: mustMarshal(workflow.SwitchActionConfig{
+					Expression: "trigger.severity",
+					Cases: []workflow.SwitchCase{
+						{Value: "critical", Label: "page"},
+						{Value: "high", Label: "page"},
+						{Value: "medium"},
+					},
+				})},
+			},
+			{ID: "page-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Page", Config: transform}},
+			{ID: "ticket-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Ticket", Config: transform}},
+			{ID: "log-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Log", Config: transform}},
+			{ID: "after-page", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "After Page", Config: transform}},
+			{ID: "notify-1", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Notify", Config: transform}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "trigger-1", Target: "switch-1"},
+			{ID: "e2", Source: "switch-1", Target: "page-1", Label: "page"},
+			{ID: "e3", Source: "switch-1", Target: "ticket-1", Label: "medium"},
+			{ID: "e4", Source: "switch-1", Target: "log-1", Label: "default"},
+			{ID: "e5", Source: "page-1", Target: "after-page"},
+			{ID: "e6", Source: "page-1", Target: "notify-1"},
+			{ID: "e7", Source: "ticket-1", Target: "notify-1"},
+			{ID: "e8", Source: "log-1", Target: "notify-1"},
+		},
+	}
+
+	mockRepo := &mockWorkflowRepo{
+		workflow: &workflow.Workflow{
+			ID:         "wf-1",
+			TenantID:   "tenant-1",
+			Definition: mustMarshal(definition),
+		},
+		stepExecutions: make(map[string]*workflow.StepExecution),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{
+		repo:               mockRepo,
+		logger:             logger,
+		retryStrategy:      NewRetryStrategy(DefaultRetryConfig(), logger),
+		circuitBreakers:    NewCircuitBreakerRegistry(DefaultCircuitBreakerConfig(), logger),
+		defaultRetryConfig: DefaultNodeRetryConfig(),
+	}
+
+	triggerData := json.RawMessage(mustMarshal(map[string]interface{}{"severity": severity}))
+	execution := &workflow.Execution{
+		ID:          "exec-1",
+		TenantID:    "tenant-1",
+		WorkflowID:  "wf-1",
+		Status:      string(workflow.ExecutionStatusPending),
+		TriggerType: "manual",
+		TriggerData: &triggerData,
+	}
+
+	return executor, mockRepo, execution
+}
+
+func TestSwitch_RoutesToFirstMatchingCase(t *testing.T) {
+	tests := []struct {
+		severity string
+		ran      []string
+		skipped  []string
+	}{
+		{severity: "critical", ran: []string{"page-1", "after-page", "notify-1"}, skipped: []string{"ticket-1", "log-1"}},
+		{severity: "high", ran: []string{"page-1", "after-page", "notify-1"}, skipped: []string{"ticket-1", "log-1"}},
+		{severity: "medium", ran: []string{"ticket-1", "notify-1"}, skipped: []string{"page-1", "after-page", "log-1"}},
+		{severity: "low", ran: []string{"log-1", "notify-1"}, skipped: []string{"page-1", "after-page", "ticket-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			executor, mockRepo, execution := newSwitchTestExecutor(tt.severity)
+
+			err := executor.Execute(context.Background(), execution)
+
+			require.NoError(t, err)
+			assert.Equal(t, string(workflow.ExecutionStatusCompleted), mockRepo.executionStatus)
+			for _, nodeID := range tt.ran {
+				assert.Contains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should run", nodeID)
+			}
+			for _, nodeID := range tt.skipped {
+				assert.NotContains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should be skipped", nodeID)
+			}
+		})
+	}
+}
+
+func TestExecuteSwitchAction(t *testing.T) {
+	executor := &Executor{logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))}
+	definition := &workflow.WorkflowDefinition{
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "switch-1", Target: "a", Label: "2"},
+			{ID: "e2", Source: "switch-1", Target: "b", Label: "default"},
+		},
+	}
+	node := workflow.Node{
+		ID:   "switch-1",
+		Type: string(workflow.NodeTypeControlSwitch),
+		Data: workflow.NodeData{Config: json.RawMessage(`{
+			"expression": "steps.count.output.total + 1",
+			"cases": [{"value": 1}, {"value": 2}, {"value": 2, "label": "duplicate"}]
+		}`)},
+	}
+
+	t.Run("numeric case matches integer result", func(t *testing.T) {
+		execCtx := &ExecutionContext{StepOutputs: map[string]interface{}{
+			"count": map[string]interface{}{"output": map[string]interface{}{"total": 1}},
+		}}
+
+		result, err := executor.executeSwitchAction(context.Background(), node, execCtx, definition)
+
+		require.NoError(t, err)
+		assert.True(t, result.Matched)
+		assert.Equal(t, 2, result.Value)
+		assert.Equal(t, "2", result.TakenBranch)
+		assert.Equal(t, []string{"a"}, result.NextNodes)
+	})
+
+	t.Run("no match takes default branch", func(t *testing.T) {
+		execCtx := &ExecutionContext{StepOutputs: map[string]interface{}{
+			"count": map[string]interface{}{"output": map[string]interface{}{"total": 5}},
+		}}
+
+		result, err := executor.executeSwitchAction(context.Background(), node, execCtx, definition)
+
+		require.NoError(t, err)
+		assert.False(t, result.Matched)
+		assert.Equal(t, workflow.SwitchDefaultBranch, result.TakenBranch)
+		assert.Equal(t, []string{"b"}, result.NextNodes)
+	})
+
+	t.Run("missing expression", func(t *testing.T) {
+		node := workflow.Node{ID: "switch-1", Data: workflow.NodeData{Config: json.RawMessage(`{"cases": [{"value": 1}]}`)}}
+
+		_, err := executor.executeSwitchAction(context.Background(), node, &ExecutionContext{}, definition)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSwitchValuesEqual(t *testing.T) {
+	assert.True(t, switchValuesEqual(3, float64(3)))
+	assert.True(t, switchValuesEqual(int64(3), 3.0))
+	assert.True(t, switchValuesEqual("critical", "critical"))
+	assert.True(t, switchValuesEqual(true, true))
+	assert.True(t, switchValuesEqual(nil, nil))
+	assert.False(t, switchValuesEqual("3", float64(3)))
+	assert.False(t, switchValuesEqual(3, "3"))
+	assert.False(t, switchValuesEqual("Critical", "critical"))
+}
+
+func TestIsOnUntakenBranch(t *testing.T) {
+	edges := []workflow.Edge{
+		{Source: "if-1", Target: "yes", Label: "true"},
+		{Source: "if-1", Target: "no", Label: "false"},
+		{Source: "yes", Target: "merge"},
+		{Source: "no", Target: "merge"},
+		{Source: "no", Target: "no-child"},
+	}
+	taken := map[string]string{"if-1": "true"}
+	skipped := map[string]bool{"no": true}
+
+	assert.False(t, isOnUntakenBranch("yes", edges, skipped, taken))
+	assert.True(t, isOnUntakenBranch("no", edges, map[string]bool{}, taken))
+	assert.False(t, isOnUntakenBranch("merge", edges, skipped, taken), "merge has a live parent")
+	assert.True(t, isOnUntakenBranch("no-child", edges, skipped, taken))
+	assert.False(t, isOnUntakenBranch("if-1", edges, skipped, taken), "nodes without incoming edges run")
+}
+
+func TestBranchTaken_ResumedOutput(t *testing.T) {
+	output := map[string]interface{}{"taken_branch": "page", "matched": true}
+
+	label, ok := branchTaken(output)
+
+	assert.True(t, ok)
+	assert.Equal(t, "page", label)
+}
@@ -0,0 +1,62 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// maxExecutionTags limits how many tags a single set_tags node may write
+const maxExecutionTags = 50
+
+// executeSetTagsAction resolves tag expressions and merges them into the execution's tags
+func (e *Executor) executeSetTagsAction(ctx context.Context, node workflow.Node, execCtx *ExecutionContext) (interface{}, error) {
+	var config workflow.SetTagsConfig
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse set_tags configuration: %w", err)
+	}
+
+	if len(config.Tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+	if len(config.Tags) > maxExecutionTags {
+		return nil, fmt.Errorf("too many tags: %d (max %d)", len(config.Tags), maxExecutionTags)
+	}
+
+	tags := resolveExecutionTags(config, buildInterpolationContext(execCtx), execCtx.CredentialValues)
+
+	if err := e.repo.UpdateExecutionTags(ctx, execCtx.ExecutionID, tags); err != nil {
+		return nil, fmt.Errorf("failed to update execution tags: %w", err)
+	}
+
+	return map[string]interface{}{
+		"tags": tags,
+	}, nil
+}
+
+// resolveExecutionTags interpolates tag values, masking PII tags and any
+// credential values that leaked into a resolved value
+func resolveExecutionTags(config workflow.SetTagsConfig, context map[string]interface{}, credentialValues []string) map[string]string {
+	masked := make(map[string]bool, len(config.Masked))
+	for _, key := range config.Masked {
+		masked[key] = true
+	}
+
+	masker := credential.NewMasker()
+	tags := make(map[string]string, len(config.Tags))
+	for key, expression := range config.Tags {
+		if key == "" {
+			continue
+		}
+		if masked[key] {
+			tags[key] = credential.DefaultMask
+			continue
+		}
+		tags[key] = masker.MaskString(actions.InterpolateString(expression, context), credentialValues)
+	}
+	return tags
+}
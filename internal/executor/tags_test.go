@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestExecuteSetTagsAction(t *testing.T) {
+	execCtx := &ExecutionContext{
+		TenantID:    "tenant-1",
+		ExecutionID: "exec-1",
+		WorkflowID:  "wf-1",
+		TriggerData: map[string]interface{}{
+			"order": map[string]interface{}{
+				"id":    "12345",
+				"email": "jane@example.com",
+				"note":  "token sk-live-secret",
+			},
+		},
+		StepOutputs:      map[string]interface{}{},
+		CredentialValues: []string{"sk-live-secret"},
+	}
+
+	tests := []struct {
+		name     string
+		config   workflow.SetTagsConfig
+		wantTags map[string]string
+		wantErr  string
+	}{
+		{
+			name: "resolves expressions",
+			config: workflow.SetTagsConfig{
+				Tags: map[string]string{
+					"order_id": "${trigger.order.id}",
+					"source":   "shopify",
+				},
+			},
+			wantTags: map[string]string{"order_id": "12345", "source": "shopify"},
+		},
+		{
+			name: "masks PII and credential values",
+			config: workflow.SetTagsConfig{
+				Tags: map[string]string{
+					"customer_email": "${trigger.order.email}",
+					"note":           "${trigger.order.note}",
+				},
+				Masked: []string{"customer_email"},
+			},
+			wantTags: map[string]string{
+				"customer_email": credential.DefaultMask,
+				"note":           "token " + credential.DefaultMask,
+			},
+		},
+		{
+			name:    "requires tags",
+			config:  workflow.SetTagsConfig{},
+			wantErr: "at least one tag is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockWorkflowRepo{}
+			executor := &Executor{
+				repo:   repo,
+				logger: slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+			}
+
+			configJSON, err := json.Marshal(tt.config)
+			require.NoError(t, err)
+			node := workflow.Node{
+				ID:   "tags-1",
+				Type: string(workflow.NodeTypeActionSetTags),
+				Data: workflow.NodeData{Config: configJSON},
+			}
+
+			output, err := executor.executeSetTagsAction(context.Background(), node, execCtx)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTags, repo.executionTags)
+			assert.Equal(t, tt.wantTags, output.(map[string]interface{})["tags"])
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// ErrExecutionTimeout is the cause of an execution's context once it has run
+// longer than its maximum duration
+var ErrExecutionTimeout = errors.New("execution_timeout")
+
+// executionTimeoutError describes how far a timed out execution got
+type executionTimeoutError struct {
+	limit          time.Duration
+	completedSteps int
+	totalSteps     int
+	// nodeID is the node that was running when the limit was reached, if any
+	nodeID string
+}
+
+func (e *executionTimeoutError) Error() string {
+	msg := fmt.Sprintf("%s: execution exceeded its maximum duration of %s after completing %d of %d steps",
+		ErrExecutionTimeout, e.limit, e.completedSteps, e.totalSteps)
+	if e.nodeID != "" {
+		msg += fmt.Sprintf("; node %s was still running", e.nodeID)
+	}
+	return msg
+}
+
+func (e *executionTimeoutError) Unwrap() error {
+	return ErrExecutionTimeout
+}
+
+// SetMaxExecutionDuration sets the ceiling on how long any execution may run.
+// Workflows can set a lower limit with max_execution_duration. Zero means
+// executions of workflows without a limit may run indefinitely.
+func (e *Executor) SetMaxExecutionDuration(d time.Duration) {
+	e.maxExecutionDuration = d
+}
+
+// executionTimeout returns how long an execution of wf may run, or 0 for no limit
+func (e *Executor) executionTimeout(wf *workflow.Workflow) time.Duration {
+	limit := e.maxExecutionDuration
+	if wf.MaxExecutionDuration != nil && *wf.MaxExecutionDuration > 0 {
+		workflowLimit := time.Duration(*wf.MaxExecutionDuration) * time.Second
+		if limit == 0 || workflowLimit < limit {
+			limit = workflowLimit
+		}
+	}
+	return limit
+}
+
+// isTimedOut reports whether the execution ran past its maximum duration
+func isTimedOut(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrExecutionTimeout)
+}
+
+// failTimedOutExecution marks a timed out execution failed, keeping the
+// outputs of the steps that completed, and hands it to the failure handler
+// like any other failure. node is the node that was cut short, if any.
+func (e *Executor) failTimedOutExecution(ctx context.Context, execution *workflow.Execution, execCtx *ExecutionContext, timeoutErr *executionTimeoutError, node *workflow.Node) error {
+	// The execution context has expired
+	ctx = context.WithoutCancel(ctx)
+
+	errMsg := timeoutErr.Error()
+	outputData, _ := json.Marshal(execCtx.StepOutputs)
+	outputData = e.stepRedactor().RedactJSON(outputData, execCtx.CredentialValues)
+	if err := e.repo.UpdateExecutionStatus(ctx, execution.ID, string(workflow.ExecutionStatusFailed), outputData, &errMsg); err != nil {
+		e.logger.Error("failed to mark timed out execution failed", "error", err, "execution_id", execution.ID)
+	}
+
+	if e.broadcaster != nil {
+		e.broadcaster.BroadcastExecutionFailed(execution.TenantID, execution.WorkflowID, execution.ID, errMsg)
+	}
+
+	var cause error = timeoutErr
+	if node != nil {
+		cause = &nodeFailedError{nodeID: node.ID, nodeType: node.Type, err: timeoutErr}
+	}
+	e.notifyFailure(ctx, execution, errMsg, cause)
+
+	e.logger.Warn("workflow execution timed out",
+		"execution_id", execution.ID,
+		"limit", timeoutErr.limit,
+		"completed_steps", timeoutErr.completedSteps,
+		"total_steps", timeoutErr.totalSteps,
+		"node_id", timeoutErr.nodeID,
+	)
+	return timeoutErr
+}
@@ -0,0 +1,61 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+func TestExecutor_ExecutionTimeout(t *testing.T) {
+	seconds := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		ceiling  time.Duration
+		workflow *int
+		expected time.Duration
+	}{
+		{name: "no limits", expected: 0},
+		{name: "ceiling only", ceiling: time.Hour, expected: time.Hour},
+		{name: "workflow only", workflow: seconds(60), expected: time.Minute},
+		{name: "workflow below ceiling", ceiling: time.Hour, workflow: seconds(60), expected: time.Minute},
+		{name: "ceiling below workflow", ceiling: time.Minute, workflow: seconds(3600), expected: time.Minute},
+		{name: "zero workflow limit", ceiling: time.Hour, workflow: seconds(0), expected: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &Executor{}
+			executor.SetMaxExecutionDuration(tt.ceiling)
+
+			assert.Equal(t, tt.expected, executor.executionTimeout(&workflow.Workflow{MaxExecutionDuration: tt.workflow}))
+		})
+	}
+}
+
+func TestExecute_TimesOut(t *testing.T) {
+	executor, mockRepo, execution := newCancelTestExecutor("10s")
+	executor.SetMaxExecutionDuration(50 * time.Millisecond)
+	handler := &recordingFailureHandler{}
+	executor.SetFailureHandler(handler)
+
+	start := time.Now()
+	err := executor.Execute(context.Background(), execution)
+
+	require.ErrorIs(t, err, ErrExecutionTimeout)
+	assert.Less(t, time.Since(start), 5*time.Second, "the running delay node should be cut short")
+	assert.Contains(t, err.Error(), "after completing 0 of 2 steps; node delay-1 was still running")
+	assert.Equal(t, string(workflow.ExecutionStatusFailed), mockRepo.executionStatus)
+	assert.Contains(t, string(mockRepo.executionOutput), `"trigger-1"`, "outputs of completed steps are kept")
+	assert.Equal(t, "failed", mockRepo.stepExecutions["delay-1-step"].Status)
+	assert.NotContains(t, mockRepo.stepExecutions, "transform-1-step")
+
+	require.Len(t, handler.failures, 1)
+	assert.Equal(t, "delay-1", handler.failures[0].NodeID)
+	assert.Contains(t, handler.failures[0].Reason, "execution_timeout")
+}
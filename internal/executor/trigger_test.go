@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// newMultiTriggerTestExecutor builds an executor for a workflow started by a
+// webhook or a schedule, each with its own first step, joining in a report
+func newMultiTriggerTestExecutor(triggerType string, triggerNodeID *string) (*Executor, *mockWorkflowRepo, *workflow.Execution) {
+	transform := mustMarshal(map[string]interface{}{"expression": "trigger"})
+	definition := workflow.WorkflowDefinition{
+		Nodes: []workflow.Node{
+			{ID: "webhook-1", Type: string(workflow.NodeTypeTriggerWebhook), Data: workflow.NodeData{Name: "On Demand"}},
+			{ID: "schedule-1", Type: string(workflow.NodeTypeTriggerSchedule), Data: workflow.NodeData{Name: "Nightly"}},
+			{ID: "parse-request", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Parse Request", Config: transform}},
+			{ID: "load-window", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Load Window", Config: transform}},
+			{ID: "report", Type: string(workflow.NodeTypeActionTransform), Data: workflow.NodeData{Name: "Report", Config: transform}},
+		},
+		Edges: []workflow.Edge{
+			{ID: "e1", Source: "webhook-1", Target: "parse-request"},
+			{ID: "e2", Source: "schedule-1", Target: "load-window"},
+			{ID: "e3", Source: "parse-request", Target: "report"},
+			{ID: "e4", Source: "load-window", Target: "report"},
+		},
+	}
+
+	mockRepo := &mockWorkflowRepo{
+		workflow: &workflow.Workflow{
+			ID:         "wf-1",
+			TenantID:   "tenant-1",
+			Definition: mustMarshal(definition),
+		},
+		stepExecutions: make(map[string]*workflow.StepExecution),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	executor := &Executor{
+		repo:               mockRepo,
+		logger:             logger,
+		retryStrategy:      NewRetryStrategy(DefaultRetryConfig(), logger),
+		circuitBreakers:    NewCircuitBreakerRegistry(DefaultCircuitBreakerConfig(), logger),
+		defaultRetryConfig: DefaultNodeRetryConfig(),
+	}
+
+	triggerData := json.RawMessage(`{"source":"` + triggerType + `"}`)
+	execution := &workflow.Execution{
+		ID:            "exec-1",
+		TenantID:      "tenant-1",
+		WorkflowID:    "wf-1",
+		Status:        string(workflow.ExecutionStatusPending),
+		TriggerType:   triggerType,
+		TriggerNodeID: triggerNodeID,
+		TriggerData:   &triggerData,
+	}
+
+	return executor, mockRepo, execution
+}
+
+func TestExecute_StartsFromFiringTrigger(t *testing.T) {
+	webhookNode := "webhook-1"
+	tests := []struct {
+		name          string
+		triggerType   string
+		triggerNodeID *string
+		ran           []string
+		skipped       []string
+	}{
+		{name: "schedule", triggerType: "schedule", ran: []string{"load-window", "report"}, skipped: []string{"parse-request"}},
+		{name: "webhook node", triggerType: "webhook", triggerNodeID: &webhookNode, ran: []string{"parse-request", "report"}, skipped: []string{"load-window"}},
+		{name: "manual runs every trigger", triggerType: "manual", ran: []string{"parse-request", "load-window", "report"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor, mockRepo, execution := newMultiTriggerTestExecutor(tt.triggerType, tt.triggerNodeID)
+
+			err := executor.Execute(context.Background(), execution)
+
+			require.NoError(t, err)
+			assert.Equal(t, string(workflow.ExecutionStatusCompleted), mockRepo.executionStatus)
+			for _, nodeID := range tt.ran {
+				assert.Contains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should run", nodeID)
+			}
+			for _, nodeID := range tt.skipped {
+				assert.NotContains(t, mockRepo.stepExecutions, nodeID+"-step", "%s should be skipped", nodeID)
+			}
+		})
+	}
+}
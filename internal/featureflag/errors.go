@@ -0,0 +1,15 @@
+package featureflag
+
+import "errors"
+
+// ErrNotFound is returned when a feature flag or tenant override does not exist
+var ErrNotFound = errors.New("feature flag not found")
+
+// ValidationError represents an invalid feature flag update
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,148 @@
+package featureflag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Flag is a feature flag with its global default
+type Flag struct {
+	Key         string    `db:"key" json:"key"`
+	Description string    `db:"description" json:"description"`
+	Enabled     bool      `db:"enabled" json:"enabled"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// TenantFlag is a feature flag as seen by one tenant. Enabled is the effective
+// value; Override is nil when the tenant inherits the global default.
+type TenantFlag struct {
+	Key         string `db:"key" json:"key"`
+	Description string `db:"description" json:"description"`
+	Default     bool   `db:"default_enabled" json:"default"`
+	Override    *bool  `db:"override_enabled" json:"override,omitempty"`
+	Enabled     bool   `db:"enabled" json:"enabled"`
+}
+
+// RepositoryInterface defines the feature flag data access used by the service
+type RepositoryInterface interface {
+	ListFlags(ctx context.Context) ([]Flag, error)
+	ListTenantFlags(ctx context.Context, tenantID string) ([]TenantFlag, error)
+	SetDefault(ctx context.Context, key string, enabled bool) (*Flag, error)
+	SetOverride(ctx context.Context, tenantID, key string, enabled bool, updatedBy string) error
+	DeleteOverride(ctx context.Context, tenantID, key string) error
+}
+
+// Repository handles feature flag data access
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new feature flag repository
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ListFlags returns all feature flags with their global defaults
+func (r *Repository) ListFlags(ctx context.Context) ([]Flag, error) {
+	query := `
+		SELECT key, COALESCE(description, '') AS description, enabled, created_at, updated_at
+		FROM feature_flags
+		ORDER BY key ASC
+	`
+
+	flags := []Flag{}
+	if err := r.db.SelectContext(ctx, &flags, query); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// ListTenantFlags returns every feature flag resolved for a tenant
+func (r *Repository) ListTenantFlags(ctx context.Context, tenantID string) ([]TenantFlag, error) {
+	query := `
+		SELECT f.key, COALESCE(f.description, '') AS description,
+		       f.enabled AS default_enabled, t.enabled AS override_enabled,
+		       COALESCE(t.enabled, f.enabled) AS enabled
+		FROM feature_flags f
+		LEFT JOIN tenant_feature_flags t ON t.flag_key = f.key AND t.tenant_id = $1
+		ORDER BY f.key ASC
+	`
+
+	flags := []TenantFlag{}
+	if err := r.db.SelectContext(ctx, &flags, query, tenantID); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// SetDefault changes the global default of a feature flag
+func (r *Repository) SetDefault(ctx context.Context, key string, enabled bool) (*Flag, error) {
+	query := `
+		UPDATE feature_flags
+		SET enabled = $2, updated_at = NOW()
+		WHERE key = $1
+		RETURNING key, COALESCE(description, '') AS description, enabled, created_at, updated_at
+	`
+
+	var flag Flag
+	if err := r.db.GetContext(ctx, &flag, query, key, enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &flag, nil
+}
+
+// SetOverride creates or replaces a tenant's override of a feature flag
+func (r *Repository) SetOverride(ctx context.Context, tenantID, key string, enabled bool, updatedBy string) error {
+	query := `
+		INSERT INTO tenant_feature_flags (tenant_id, flag_key, enabled, updated_by)
+		SELECT $1, key, $3, $4 FROM feature_flags WHERE key = $2
+		ON CONFLICT (tenant_id, flag_key)
+		DO UPDATE SET enabled = EXCLUDED.enabled, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, key, enabled, updatedBy)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteOverride removes a tenant's override so the global default applies again
+func (r *Repository) DeleteOverride(ctx context.Context, tenantID, key string) error {
+	query := `DELETE FROM tenant_feature_flags WHERE tenant_id = $1 AND flag_key = $2`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, key)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package featureflag
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Known feature flags. Each is seeded by migration 075 or a later one.
+const (
+	// FlagAIBuilder gates the AI workflow builder endpoints
+	FlagAIBuilder = "ai_builder"
+	// FlagSwitchNode gates the experimental control:switch node
+	FlagSwitchNode = "switch_node"
+	// FlagDatabaseAction gates the experimental action:database node
+	FlagDatabaseAction = "database_action"
+	// FlagScriptAction gates the experimental action:script node
+	FlagScriptAction = "script_action"
+)
+
+// defaultCacheTTL bounds how long a flag change takes to reach other instances
+const defaultCacheTTL = 30 * time.Second
+
+// UpdateFlagInput sets a feature flag's global default or a tenant override
+type UpdateFlagInput struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// Validate checks that the update carries a value
+func (i UpdateFlagInput) Validate() error {
+	if i.Enabled == nil {
+		return &ValidationError{Message: "enabled is required"}
+	}
+	return nil
+}
+
+// tenantFlags is the cached set of effective flag values for one tenant
+type tenantFlags struct {
+	values   map[string]bool
+	loadedAt time.Time
+}
+
+// Service resolves feature flags per tenant and manages their values
+type Service struct {
+	repo   RepositoryInterface
+	logger *slog.Logger
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]*tenantFlags
+}
+
+// NewService creates a new feature flag service
+func NewService(repo RepositoryInterface, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		ttl:    defaultCacheTTL,
+		now:    time.Now,
+		cache:  make(map[string]*tenantFlags),
+	}
+}
+
+// IsEnabled reports whether flag is enabled for the tenant, applying the
+// tenant's override if there is one and the global default otherwise.
+// Unknown flags are disabled. If the flags cannot be loaded, the last known
+// values are used, and a tenant that was never loaded gets every flag disabled.
+func (s *Service) IsEnabled(ctx context.Context, tenantID, flag string) bool {
+	s.mu.RLock()
+	cached := s.cache[tenantID]
+	s.mu.RUnlock()
+
+	if cached == nil || s.now().Sub(cached.loadedAt) >= s.ttl {
+		loaded, err := s.load(ctx, tenantID)
+		if err != nil {
+			s.logger.Error("failed to load feature flags", "error", err, "tenant_id", tenantID)
+			if cached == nil {
+				return false
+			}
+		} else {
+			cached = loaded
+		}
+	}
+
+	return cached.values[flag]
+}
+
+func (s *Service) load(ctx context.Context, tenantID string) (*tenantFlags, error) {
+	flags, err := s.repo.ListTenantFlags(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := &tenantFlags{
+		values:   make(map[string]bool, len(flags)),
+		loadedAt: s.now(),
+	}
+	for _, flag := range flags {
+		loaded.values[flag.Key] = flag.Enabled
+	}
+
+	s.mu.Lock()
+	s.cache[tenantID] = loaded
+	s.mu.Unlock()
+
+	return loaded, nil
+}
+
+// invalidate drops cached values for the tenant, or for every tenant if tenantID is empty
+func (s *Service) invalidate(tenantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tenantID == "" {
+		s.cache = make(map[string]*tenantFlags)
+		return
+	}
+	delete(s.cache, tenantID)
+}
+
+// ListFlags returns all feature flags with their global defaults
+func (s *Service) ListFlags(ctx context.Context) ([]Flag, error) {
+	return s.repo.ListFlags(ctx)
+}
+
+// ListTenantFlags returns every feature flag resolved for a tenant
+func (s *Service) ListTenantFlags(ctx context.Context, tenantID string) ([]TenantFlag, error) {
+	return s.repo.ListTenantFlags(ctx, tenantID)
+}
+
+// SetDefault changes the global default of a feature flag
+func (s *Service) SetDefault(ctx context.Context, key string, input UpdateFlagInput) (*Flag, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	flag, err := s.repo.SetDefault(ctx, key, *input.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate("")
+
+	s.logger.Info("feature flag default changed", "flag", key, "enabled", flag.Enabled)
+	return flag, nil
+}
+
+// SetOverride enables or disables a feature flag for one tenant
+func (s *Service) SetOverride(ctx context.Context, tenantID, key string, input UpdateFlagInput, updatedBy string) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetOverride(ctx, tenantID, key, *input.Enabled, updatedBy); err != nil {
+		return err
+	}
+	s.invalidate(tenantID)
+
+	s.logger.Info("feature flag override set",
+		"flag", key, "tenant_id", tenantID, "enabled", *input.Enabled, "updated_by", updatedBy)
+	return nil
+}
+
+// DeleteOverride returns a tenant to the global default of a feature flag
+func (s *Service) DeleteOverride(ctx context.Context, tenantID, key string) error {
+	if err := s.repo.DeleteOverride(ctx, tenantID, key); err != nil {
+		return err
+	}
+	s.invalidate(tenantID)
+
+	s.logger.Info("feature flag override removed", "flag", key, "tenant_id", tenantID)
+	return nil
+}
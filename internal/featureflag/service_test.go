@@ -0,0 +1,171 @@
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository is a mock implementation of RepositoryInterface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) ListFlags(ctx context.Context) ([]Flag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Flag), args.Error(1)
+}
+
+func (m *MockRepository) ListTenantFlags(ctx context.Context, tenantID string) ([]TenantFlag, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]TenantFlag), args.Error(1)
+}
+
+func (m *MockRepository) SetDefault(ctx context.Context, key string, enabled bool) (*Flag, error) {
+	args := m.Called(ctx, key, enabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Flag), args.Error(1)
+}
+
+func (m *MockRepository) SetOverride(ctx context.Context, tenantID, key string, enabled bool, updatedBy string) error {
+	args := m.Called(ctx, tenantID, key, enabled, updatedBy)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteOverride(ctx context.Context, tenantID, key string) error {
+	args := m.Called(ctx, tenantID, key)
+	return args.Error(0)
+}
+
+func newTestService() (*Service, *MockRepository) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+	return NewService(repo, logger), repo
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestService_IsEnabled(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+
+	repo.On("ListTenantFlags", ctx, "tenant-1").Return([]TenantFlag{
+		{Key: FlagSwitchNode, Default: true, Enabled: true},
+		{Key: FlagDatabaseAction, Default: true, Override: boolPtr(false), Enabled: false},
+	}, nil).Once()
+
+	assert.True(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+	assert.False(t, service.IsEnabled(ctx, "tenant-1", FlagDatabaseAction))
+	assert.False(t, service.IsEnabled(ctx, "tenant-1", "unknown_flag"), "unknown flags are disabled")
+
+	// All three lookups are served by one load
+	repo.AssertExpectations(t)
+}
+
+func TestService_IsEnabled_CacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	repo.On("ListTenantFlags", ctx, "tenant-1").Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: true}}, nil).Once()
+	assert.True(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+
+	t.Run("reloads after the TTL", func(t *testing.T) {
+		now = now.Add(defaultCacheTTL)
+		repo.On("ListTenantFlags", ctx, "tenant-1").Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: false}}, nil).Once()
+
+		assert.False(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+	})
+
+	t.Run("keeps the last known values when reloading fails", func(t *testing.T) {
+		now = now.Add(defaultCacheTTL)
+		repo.On("ListTenantFlags", ctx, "tenant-1").Return(nil, errors.New("db down")).Once()
+
+		assert.False(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+	})
+
+	repo.AssertExpectations(t)
+}
+
+func TestService_IsEnabled_LoadErrorWithoutCache(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+
+	repo.On("ListTenantFlags", ctx, "tenant-1").Return(nil, errors.New("db down"))
+
+	assert.False(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+}
+
+func TestService_SetOverride(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalidates the tenant's cached flags", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("ListTenantFlags", ctx, "tenant-1").Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: true}}, nil).Once()
+		require.True(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+
+		repo.On("SetOverride", ctx, "tenant-1", FlagSwitchNode, false, "admin-1").Return(nil)
+		require.NoError(t, service.SetOverride(ctx, "tenant-1", FlagSwitchNode, UpdateFlagInput{Enabled: boolPtr(false)}, "admin-1"))
+
+		repo.On("ListTenantFlags", ctx, "tenant-1").Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: false}}, nil).Once()
+		assert.False(t, service.IsEnabled(ctx, "tenant-1", FlagSwitchNode))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("requires enabled", func(t *testing.T) {
+		service, _ := newTestService()
+
+		err := service.SetOverride(ctx, "tenant-1", FlagSwitchNode, UpdateFlagInput{}, "admin-1")
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		service, repo := newTestService()
+		repo.On("SetOverride", ctx, "tenant-1", "nope", true, "admin-1").Return(ErrNotFound)
+
+		err := service.SetOverride(ctx, "tenant-1", "nope", UpdateFlagInput{Enabled: boolPtr(true)}, "admin-1")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestService_SetDefault_InvalidatesAllTenants(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestService()
+
+	for _, tenantID := range []string{"tenant-1", "tenant-2"} {
+		repo.On("ListTenantFlags", ctx, tenantID).Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: false}}, nil).Once()
+		require.False(t, service.IsEnabled(ctx, tenantID, FlagSwitchNode))
+	}
+
+	repo.On("SetDefault", ctx, FlagSwitchNode, true).Return(&Flag{Key: FlagSwitchNode, Enabled: true}, nil)
+	flag, err := service.SetDefault(ctx, FlagSwitchNode, UpdateFlagInput{Enabled: boolPtr(true)})
+	require.NoError(t, err)
+	assert.True(t, flag.Enabled)
+
+	for _, tenantID := range []string{"tenant-1", "tenant-2"} {
+		repo.On("ListTenantFlags", ctx, tenantID).Return([]TenantFlag{{Key: FlagSwitchNode, Enabled: true}}, nil).Once()
+		assert.True(t, service.IsEnabled(ctx, tenantID, FlagSwitchNode))
+	}
+	repo.AssertExpectations(t)
+}
@@ -125,8 +125,13 @@ func (r *mutationResolver) CancelExecution(ctx context.Context, id string) (*gen
 		return nil, err
 	}
 
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Cancel execution via service
-	execution, err := r.WorkflowService.CancelExecution(ctx, tenantID, id)
+	execution, err := r.WorkflowService.CancelExecution(ctx, tenantID, id, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to cancel execution: %w", err)
 	}
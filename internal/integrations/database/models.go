@@ -1,6 +1,9 @@
 package database
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Common errors
 var (
@@ -91,6 +94,71 @@ type TransactionResult struct {
 	TotalAffected int64 `json:"total_affected"`
 }
 
+// SQL drivers supported by the database node
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+const (
+	// DefaultSQLMaxRows is the number of rows returned when max_rows is not set
+	DefaultSQLMaxRows = 1000
+
+	// MaxSQLMaxRows is the largest max_rows a database node may request
+	MaxSQLMaxRows = 10000
+
+	// MaxSQLResultBytes caps the encoded size of the rows returned by a database node
+	MaxSQLResultBytes = 5 * 1024 * 1024
+
+	// DefaultSQLTimeout is the query timeout in seconds when timeout is not set
+	DefaultSQLTimeout = 30
+
+	// MaxSQLTimeout is the largest query timeout in seconds
+	MaxSQLTimeout = 300
+)
+
+// SQLQueryConfig represents configuration for the database node, which runs a
+// single parameterized statement against a database credential
+type SQLQueryConfig struct {
+	CredentialID string `json:"credential_id"`
+	// Query is sent as is; values must be passed through Parameters
+	Query string `json:"query"`
+	// Parameters are bound to the query placeholders ($1 for PostgreSQL, ? for
+	// MySQL). String parameters may be expressions such as {{trigger.id}}.
+	Parameters []interface{} `json:"parameters,omitempty"`
+	AllowDDL   bool          `json:"allow_ddl,omitempty"`
+	MaxRows    int           `json:"max_rows,omitempty"`
+	Timeout    int           `json:"timeout,omitempty"` // seconds
+}
+
+// Validate checks if database node config is valid
+func (c *SQLQueryConfig) Validate() error {
+	if c.CredentialID == "" {
+		return errors.New("credential_id is required")
+	}
+	if c.Query == "" {
+		return ErrInvalidQuery
+	}
+	if c.MaxRows < 0 || c.MaxRows > MaxSQLMaxRows {
+		return fmt.Errorf("max_rows must be between 0 and %d", MaxSQLMaxRows)
+	}
+	if c.Timeout < 0 || c.Timeout > MaxSQLTimeout {
+		return fmt.Errorf("timeout must be between 0 and %d seconds", MaxSQLTimeout)
+	}
+	return nil
+}
+
+// SQLQueryResult represents the result of a database node
+type SQLQueryResult struct {
+	Statement    StatementKind            `json:"statement"`
+	Rows         []map[string]interface{} `json:"rows"`
+	RowCount     int                      `json:"row_count"`
+	ColumnNames  []string                 `json:"column_names,omitempty"`
+	RowsAffected int64                    `json:"rows_affected"`
+	// Truncated is set when rows were dropped to stay within max_rows or the size cap
+	Truncated bool `json:"truncated"`
+}
+
 // MongoFindConfig represents configuration for MongoDB find operations
 type MongoFindConfig struct {
 	Collection string                 `json:"collection"`
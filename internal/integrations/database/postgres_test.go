@@ -19,6 +19,7 @@ import (
 // MockCredentialService for testing
 type MockCredentialService struct {
 	GetValueFunc func(ctx context.Context, tenantID, credentialID, userID string) (*credential.DecryptedValue, error)
+	GetByIDFunc  func(ctx context.Context, tenantID, credentialID string) (*credential.Credential, error)
 }
 
 func (m *MockCredentialService) GetValue(ctx context.Context, tenantID, credentialID, userID string) (*credential.DecryptedValue, error) {
@@ -37,6 +38,9 @@ func (m *MockCredentialService) List(ctx context.Context, tenantID string, filte
 }
 
 func (m *MockCredentialService) GetByID(ctx context.Context, tenantID, credentialID string) (*credential.Credential, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, tenantID, credentialID)
+	}
 	return nil, errors.New("not implemented")
 }
 
@@ -48,6 +52,18 @@ func (m *MockCredentialService) Delete(ctx context.Context, tenantID, credential
 	return errors.New("not implemented")
 }
 
+func (m *MockCredentialService) Purge(ctx context.Context, tenantID, credentialID, userID string) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockCredentialService) Restore(ctx context.Context, tenantID, credentialID, userID string) (*credential.Credential, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockCredentialService) BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []credential.BulkOperationError) {
+	return nil, nil
+}
+
 func (m *MockCredentialService) Rotate(ctx context.Context, tenantID, credentialID, userID string, input credential.RotateCredentialInput) (*credential.Credential, error) {
 	return nil, errors.New("not implemented")
 }
@@ -60,6 +76,18 @@ func (m *MockCredentialService) GetAccessLog(ctx context.Context, tenantID, cred
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockCredentialService) GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*credential.DecryptedValue, error) {
+	return m.GetValue(ctx, tenantID, credentialID, userID)
+}
+
+func (m *MockCredentialService) LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error {
+	return nil
+}
+
+func (m *MockCredentialService) TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*credential.CredentialTestResult, error) {
+	return nil, nil
+}
+
 // TestPostgresQueryAction_Execute tests the PostgreSQL query action
 func TestPostgresQueryAction_Execute(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,317 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/executor/actions"
+)
+
+// SQLQueryAction runs a single parameterized statement against the database
+// described by a database_postgresql or database_mysql credential
+type SQLQueryAction struct {
+	credentialService credential.Service
+	dbFactory         func(driver, connStr string) (*sql.DB, error)
+}
+
+// NewSQLQueryAction creates a new database node action
+func NewSQLQueryAction(credentialService credential.Service) *SQLQueryAction {
+	return &SQLQueryAction{
+		credentialService: credentialService,
+		dbFactory:         newSQLDB,
+	}
+}
+
+// Execute implements the Action interface
+func (a *SQLQueryAction) Execute(ctx context.Context, input *actions.ActionInput) (*actions.ActionOutput, error) {
+	// Parse config
+	config, ok := input.Config.(SQLQueryConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type: expected SQLQueryConfig")
+	}
+
+	// Validate config
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	driver, connStr, err := resolveSQLConnection(ctx, a.credentialService, input.Context, config.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the statement against the allowlist before connecting
+	kind, err := ClassifyStatement(config.Query, driver, config.AllowDDL)
+	if err != nil {
+		return nil, fmt.Errorf("statement rejected: %w", err)
+	}
+
+	params, err := bindParameters(config.Parameters, input.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create database connection
+	db, err := a.dbFactory(driver, connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultSQLTimeout
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	result := &SQLQueryResult{
+		Statement: kind,
+		Rows:      make([]map[string]interface{}, 0),
+	}
+
+	if returnsRows(kind, config.Query, driver) {
+		maxRows := config.MaxRows
+		if maxRows == 0 {
+			maxRows = DefaultSQLMaxRows
+		}
+		if err := queryRows(queryCtx, db, config.Query, params, maxRows, result); err != nil {
+			return nil, err
+		}
+		if kind == StatementKindWrite {
+			result.RowsAffected = int64(result.RowCount)
+		}
+	} else {
+		res, err := db.ExecContext(queryCtx, config.Query, params...)
+		if err != nil {
+			return nil, fmt.Errorf("statement execution failed: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err == nil {
+			result.RowsAffected = affected
+		}
+	}
+
+	// Create output
+	output := actions.NewActionOutput(result)
+	output.WithMetadata("row_count", result.RowCount)
+	output.WithMetadata("rows_affected", result.RowsAffected)
+	output.WithMetadata("truncated", result.Truncated)
+
+	return output, nil
+}
+
+// queryRows scans at most maxRows rows, and no more than MaxSQLResultBytes of
+// them, into result
+func queryRows(ctx context.Context, db *sql.DB, query string, params []interface{}, maxRows int, result *SQLQueryResult) error {
+	rows, err := db.QueryContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("query execution failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to get columns: %w", err)
+	}
+	result.ColumnNames = columns
+
+	size := 0
+	for rows.Next() {
+		if len(result.Rows) >= maxRows {
+			result.Truncated = true
+			break
+		}
+
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		rowMap := make(map[string]interface{}, len(columns))
+		for i, col := range columns {
+			// Convert []byte to string for better JSON serialization
+			if b, ok := values[i].([]byte); ok {
+				rowMap[col] = string(b)
+			} else {
+				rowMap[col] = values[i]
+			}
+		}
+
+		encoded, err := json.Marshal(rowMap)
+		if err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+		if size+len(encoded) > MaxSQLResultBytes {
+			result.Truncated = true
+			break
+		}
+		size += len(encoded)
+
+		result.Rows = append(result.Rows, rowMap)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	result.RowCount = len(result.Rows)
+	return nil
+}
+
+// bindParameters resolves expression parameters against the execution
+// context. Values are only ever passed to the driver as bound arguments; maps
+// and arrays are bound as JSON text.
+func bindParameters(parameters []interface{}, inputCtx map[string]interface{}) ([]interface{}, error) {
+	params := make([]interface{}, len(parameters))
+	for i, param := range parameters {
+		if template, ok := param.(string); ok {
+			param = actions.InterpolateValue(template, inputCtx)
+		}
+
+		switch param.(type) {
+		case map[string]interface{}, []interface{}:
+			encoded, err := json.Marshal(param)
+			if err != nil {
+				return nil, fmt.Errorf("%w: parameter %d: %v", ErrInvalidParams, i+1, err)
+			}
+			params[i] = string(encoded)
+		default:
+			params[i] = param
+		}
+	}
+	return params, nil
+}
+
+// resolveSQLConnection returns the driver and connection string for a
+// database credential. A connection_string value is used as is; otherwise it
+// is built from the host, port, database, username and password fields.
+func resolveSQLConnection(ctx context.Context, credService credential.Service, inputCtx map[string]interface{}, credentialID string) (string, string, error) {
+	tenantID, err := extractString(inputCtx, "env.tenant_id")
+	if err != nil {
+		return "", "", fmt.Errorf("tenant_id is required in context: %w", err)
+	}
+
+	cred, err := credService.GetByID(ctx, tenantID, credentialID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to retrieve credential: %w", err)
+	}
+
+	var driver string
+	switch cred.Type {
+	case credential.TypeDatabasePostgreSQL:
+		driver = DriverPostgres
+	case credential.TypeDatabaseMySQL:
+		driver = DriverMySQL
+	default:
+		return "", "", fmt.Errorf("credential type %s is not a supported SQL database", cred.Type)
+	}
+
+	decryptedCred, err := credService.GetValue(ctx, tenantID, credentialID, "system")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to retrieve credential: %w", err)
+	}
+
+	connStr, err := buildConnectionString(driver, decryptedCred.Value)
+	if err != nil {
+		return "", "", err
+	}
+	return driver, connStr, nil
+}
+
+// buildConnectionString builds a connection string from database credential fields
+func buildConnectionString(driver string, value map[string]interface{}) (string, error) {
+	if connStr, ok := value["connection_string"].(string); ok && connStr != "" {
+		if driver == DriverMySQL {
+			cfg, err := mysql.ParseDSN(connStr)
+			if err != nil {
+				return "", fmt.Errorf("%w: %v", ErrInvalidConnectionString, err)
+			}
+			// The statement allowlist only holds for single statements
+			cfg.MultiStatements = false
+			return cfg.FormatDSN(), nil
+		}
+		return connStr, nil
+	}
+
+	host, _ := value["host"].(string)
+	database, _ := value["database"].(string)
+	username, _ := value["username"].(string)
+	password, _ := value["password"].(string)
+	if host == "" || database == "" || username == "" {
+		return "", fmt.Errorf("%w: credential requires host, database and username", ErrInvalidConnectionString)
+	}
+
+	port, err := credentialPort(value["port"])
+	if err != nil {
+		return "", err
+	}
+
+	switch driver {
+	case DriverMySQL:
+		if port == "" {
+			port = "3306"
+		}
+		cfg := mysql.NewConfig()
+		cfg.User = username
+		cfg.Passwd = password
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(host, port)
+		cfg.DBName = database
+		cfg.ParseTime = true
+		return cfg.FormatDSN(), nil
+	default:
+		if port == "" {
+			port = "5432"
+		}
+		dsn := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(username, password),
+			Host:   net.JoinHostPort(host, port),
+			Path:   "/" + database,
+		}
+		if sslMode, ok := value["ssl_mode"].(string); ok && sslMode != "" {
+			dsn.RawQuery = url.Values{"sslmode": {sslMode}}.Encode()
+		}
+		return dsn.String(), nil
+	}
+}
+
+// credentialPort reads an optional port stored as a number or a string
+func credentialPort(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case float64:
+		return strconv.Itoa(int(v)), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case string:
+		if _, err := strconv.Atoi(v); v != "" && err != nil {
+			return "", fmt.Errorf("%w: invalid port %q", ErrInvalidConnectionString, v)
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("%w: invalid port", ErrInvalidConnectionString)
+	}
+}
+
+// newSQLDB opens a connection for a supported SQL driver
+func newSQLDB(driver, connStr string) (*sql.DB, error) {
+	if driver == DriverMySQL {
+		return newMySQLDB(connStr)
+	}
+	return newPostgresDB(connStr)
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// StatementKind classifies a SQL statement by its leading keyword
+type StatementKind string
+
+const (
+	// StatementKindRead covers statements that only read data
+	StatementKindRead StatementKind = "read"
+
+	// StatementKindWrite covers statements that modify rows
+	StatementKindWrite StatementKind = "write"
+
+	// StatementKindDDL covers statements that modify the schema
+	StatementKindDDL StatementKind = "ddl"
+)
+
+// statementKinds is the statement allowlist. Any leading keyword not listed
+// here is rejected.
+var statementKinds = map[string]StatementKind{
+	"SELECT":   StatementKindRead,
+	"WITH":     StatementKindRead,
+	"VALUES":   StatementKindRead,
+	"TABLE":    StatementKindRead,
+	"INSERT":   StatementKindWrite,
+	"UPDATE":   StatementKindWrite,
+	"DELETE":   StatementKindWrite,
+	"MERGE":    StatementKindWrite,
+	"REPLACE":  StatementKindWrite,
+	"CREATE":   StatementKindDDL,
+	"ALTER":    StatementKindDDL,
+	"DROP":     StatementKindDDL,
+	"TRUNCATE": StatementKindDDL,
+	"RENAME":   StatementKindDDL,
+	"COMMENT":  StatementKindDDL,
+}
+
+// returningPattern detects write statements that return rows
+var returningPattern = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// StatementError is returned when a statement is rejected before it is sent to the database
+type StatementError struct {
+	Message string
+}
+
+func (e *StatementError) Error() string {
+	return e.Message
+}
+
+// ClassifyStatement checks a query against the statement allowlist and
+// returns its kind. The query must be a single statement; DDL is only accepted
+// when allowDDL is set. Comments and quoted text are lexed the way driver does,
+// so they cannot hide a second statement.
+func ClassifyStatement(query string, driver string, allowDDL bool) (StatementKind, error) {
+	code, err := stripLiterals(query, driver)
+	if err != nil {
+		return "", err
+	}
+
+	code = strings.TrimSpace(code)
+	code = strings.TrimSuffix(code, ";")
+	if strings.Contains(code, ";") {
+		return "", &StatementError{Message: "only a single statement is allowed"}
+	}
+
+	keyword := leadingKeyword(code)
+	if keyword == "" {
+		return "", &StatementError{Message: "query is empty"}
+	}
+
+	kind, ok := statementKinds[keyword]
+	if !ok {
+		return "", &StatementError{Message: fmt.Sprintf("%s statements are not allowed", keyword)}
+	}
+	if kind == StatementKindDDL && !allowDDL {
+		return "", &StatementError{Message: fmt.Sprintf("%s statements require allow_ddl", keyword)}
+	}
+	return kind, nil
+}
+
+// returnsRows reports whether a statement of the given kind produces a result set
+func returnsRows(kind StatementKind, query string, driver string) bool {
+	if kind == StatementKindRead {
+		return true
+	}
+	if kind == StatementKindWrite {
+		code, err := stripLiterals(query, driver)
+		return err == nil && returningPattern.MatchString(code)
+	}
+	return false
+}
+
+// leadingKeyword returns the first keyword of a statement, skipping opening parentheses
+func leadingKeyword(code string) string {
+	code = strings.TrimLeftFunc(code, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '('
+	})
+	end := strings.IndexFunc(code, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if end == -1 {
+		end = len(code)
+	}
+	return strings.ToUpper(code[:end])
+}
+
+// stripLiterals replaces comments, quoted strings, quoted identifiers and
+// dollar-quoted strings with spaces so keywords and semicolons can be found
+// in the statement text alone. PostgreSQL block comments nest and its E-prefixed
+// strings use backslash escapes; MySQL has # comments and executable /*! */
+// comments, which are rejected.
+func stripLiterals(query string, driver string) (string, error) {
+	mysql := driver == DriverMySQL
+	var out strings.Builder
+	out.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		switch {
+		case isLineComment(query[i:], mysql):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			out.WriteByte(' ')
+			i += end
+
+		case strings.HasPrefix(query[i:], "/*"):
+			if mysql && strings.HasPrefix(query[i:], "/*!") {
+				return "", &StatementError{Message: "executable comments are not allowed"}
+			}
+			end, err := blockCommentEnd(query, i, !mysql)
+			if err != nil {
+				return "", err
+			}
+			out.WriteByte(' ')
+			i = end
+
+		case query[i] == '\'' || query[i] == '"' || (mysql && query[i] == '`'):
+			escapes := ambiguousEscapes
+			switch {
+			case query[i] == '`' || (!mysql && query[i] == '"'):
+				escapes = noEscapes
+			case !mysql && i > 0 && (query[i-1] == 'E' || query[i-1] == 'e'):
+				escapes = backslashEscapes
+			}
+			end, err := quotedEnd(query, i, escapes)
+			if err != nil {
+				return "", err
+			}
+			out.WriteByte(' ')
+			i = end
+
+		case !mysql && query[i] == '$':
+			tag, ok := dollarQuoteTag(query[i:])
+			if !ok {
+				out.WriteByte(query[i])
+				i++
+				continue
+			}
+			end := strings.Index(query[i+len(tag):], tag)
+			if end == -1 {
+				return "", &StatementError{Message: "unterminated dollar-quoted string"}
+			}
+			out.WriteByte(' ')
+			i += len(tag) + end + len(tag)
+
+		default:
+			out.WriteByte(query[i])
+			i++
+		}
+	}
+
+	return out.String(), nil
+}
+
+// isLineComment reports whether s starts with a line comment. MySQL only
+// treats -- as a comment when it is followed by whitespace.
+func isLineComment(s string, mysql bool) bool {
+	if mysql {
+		if s[0] == '#' {
+			return true
+		}
+		return strings.HasPrefix(s, "--") && (len(s) == 2 || unicode.IsSpace(rune(s[2])))
+	}
+	return strings.HasPrefix(s, "--")
+}
+
+// blockCommentEnd returns the index just past the block comment starting at start
+func blockCommentEnd(query string, start int, nested bool) (int, error) {
+	depth := 0
+	for i := start; i+1 < len(query); {
+		switch {
+		case query[i] == '/' && query[i+1] == '*':
+			if depth == 0 || nested {
+				depth++
+			}
+			i += 2
+		case query[i] == '*' && query[i+1] == '/':
+			depth--
+			i += 2
+			if depth == 0 {
+				return i, nil
+			}
+		default:
+			i++
+		}
+	}
+	return 0, &StatementError{Message: "unterminated comment"}
+}
+
+// escapeMode describes how backslashes behave inside quoted text
+type escapeMode int
+
+const (
+	// noEscapes treats backslashes as ordinary characters
+	noEscapes escapeMode = iota
+
+	// backslashEscapes treats a backslash as escaping the next character
+	backslashEscapes
+
+	// ambiguousEscapes is used for strings whose backslashes depend on server
+	// settings (standard_conforming_strings, NO_BACKSLASH_ESCAPES); a
+	// backslash before a quote is rejected since it could end the string
+	// either way
+	ambiguousEscapes
+)
+
+// quotedEnd returns the index just past the quoted text starting at start. A
+// doubled quote character is an escaped quote.
+func quotedEnd(query string, start int, escapes escapeMode) (int, error) {
+	quote := query[start]
+	for j := start + 1; j < len(query); j++ {
+		switch {
+		case escapes == ambiguousEscapes && query[j] == '\\' && j+1 < len(query) && query[j+1] == quote:
+			return 0, &StatementError{Message: "backslash before a quote is ambiguous; bind the value as a parameter"}
+		case escapes == backslashEscapes && query[j] == '\\':
+			j++
+		case query[j] == quote:
+			if j+1 < len(query) && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1, nil
+		}
+	}
+	return 0, &StatementError{Message: "unterminated quoted string"}
+}
+
+// dollarQuoteTag returns the opening tag of a PostgreSQL dollar-quoted string
+// such as $$ or $body$
+func dollarQuoteTag(s string) (string, bool) {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		if c == '$' {
+			return s[:j+1], true
+		}
+		isIdent := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (j > 1 && c >= '0' && c <= '9')
+		if !isIdent {
+			return "", false
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyStatement(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		driver        string
+		allowDDL      bool
+		wantKind      StatementKind
+		errorContains string
+	}{
+		{name: "select", query: "SELECT * FROM users WHERE id = $1", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "lowercase with leading comment", query: "-- fetch\n/* users */ select 1", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "parenthesized select", query: "(SELECT 1) UNION (SELECT 2)", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "cte", query: "WITH recent AS (SELECT 1) SELECT * FROM recent", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "insert", query: "INSERT INTO users (name) VALUES ($1)", driver: DriverPostgres, wantKind: StatementKindWrite},
+		{name: "trailing semicolon", query: "DELETE FROM users WHERE id = ?;  ", driver: DriverMySQL, wantKind: StatementKindWrite},
+		{name: "semicolon in string", query: "SELECT 'a;b'", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "semicolon in dollar quote", query: "SELECT $tag$a;b$tag$", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "semicolon in comment", query: "SELECT 1 -- a; b", driver: DriverPostgres, wantKind: StatementKindRead},
+		{name: "ddl allowed", query: "CREATE TABLE t (id int)", driver: DriverPostgres, allowDDL: true, wantKind: StatementKindDDL},
+		{name: "ddl without allow_ddl", query: "DROP TABLE users", driver: DriverPostgres, errorContains: "DROP statements require allow_ddl"},
+		{name: "truncate without allow_ddl", query: "truncate users", driver: DriverMySQL, errorContains: "TRUNCATE statements require allow_ddl"},
+		{name: "statement not in allowlist", query: "GRANT ALL ON users TO bob", driver: DriverPostgres, allowDDL: true, errorContains: "GRANT statements are not allowed"},
+		{name: "multiple statements", query: "SELECT 1; DROP TABLE users", driver: DriverPostgres, errorContains: "single statement"},
+		{name: "empty", query: " -- nothing\n", driver: DriverPostgres, errorContains: "query is empty"},
+		{name: "unterminated string", query: "SELECT 'abc", driver: DriverPostgres, errorContains: "unterminated"},
+		{
+			name:          "postgres nested comment hides keyword",
+			query:         "/* /* */ SELECT */ DROP TABLE users",
+			driver:        DriverPostgres,
+			errorContains: "DROP statements require allow_ddl",
+		},
+		{
+			name:          "postgres hash is an operator",
+			query:         "SELECT 1 # 2; DROP TABLE users",
+			driver:        DriverPostgres,
+			errorContains: "single statement",
+		},
+		{
+			name:          "postgres E string escapes",
+			query:         "SELECT E'x\\'' ; DROP TABLE users; SELECT ''",
+			driver:        DriverPostgres,
+			errorContains: "single statement",
+		},
+		{
+			name:          "ambiguous backslash quote",
+			query:         "SELECT 'a\\'; DROP TABLE users; --'",
+			driver:        DriverPostgres,
+			errorContains: "ambiguous",
+		},
+		{
+			name:          "mysql executable comment",
+			query:         "SELECT 1 /*! , SLEEP(10) */",
+			driver:        DriverMySQL,
+			errorContains: "executable comments",
+		},
+		{
+			name:          "mysql double dash without space is not a comment",
+			query:         "SELECT 1--1; DROP TABLE users",
+			driver:        DriverMySQL,
+			errorContains: "single statement",
+		},
+		{name: "mysql hash comment", query: "SELECT 1 # a; b", driver: DriverMySQL, wantKind: StatementKindRead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := ClassifyStatement(tt.query, tt.driver, tt.allowDDL)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+}
+
+func TestReturnsRows(t *testing.T) {
+	assert.True(t, returnsRows(StatementKindRead, "SELECT 1", DriverPostgres))
+	assert.True(t, returnsRows(StatementKindWrite, "INSERT INTO t VALUES ($1) RETURNING id", DriverPostgres))
+	assert.False(t, returnsRows(StatementKindWrite, "INSERT INTO t VALUES ('returning')", DriverPostgres))
+	assert.False(t, returnsRows(StatementKindDDL, "CREATE TABLE t (id int)", DriverPostgres))
+}
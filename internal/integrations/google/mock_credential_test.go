@@ -39,6 +39,18 @@ func (m *MockCredentialService) Delete(ctx context.Context, tenantID, credential
 	return fmt.Errorf("not implemented")
 }
 
+func (m *MockCredentialService) Purge(ctx context.Context, tenantID, credentialID, userID string) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *MockCredentialService) Restore(ctx context.Context, tenantID, credentialID, userID string) (*credential.Credential, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockCredentialService) BulkDelete(ctx context.Context, tenantID, userID string, credentialIDs []string, permanent bool) ([]string, []credential.BulkOperationError) {
+	return nil, nil
+}
+
 func (m *MockCredentialService) Rotate(ctx context.Context, tenantID, credentialID, userID string, input credential.RotateCredentialInput) (*credential.Credential, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -50,3 +62,15 @@ func (m *MockCredentialService) ListVersions(ctx context.Context, tenantID, cred
 func (m *MockCredentialService) GetAccessLog(ctx context.Context, tenantID, credentialID string, limit, offset int) ([]*credential.AccessLog, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+
+func (m *MockCredentialService) GetCredentialValue(ctx context.Context, tenantID, credentialID, userID string, keys []string) (*credential.DecryptedValue, error) {
+	return m.GetValue(ctx, tenantID, credentialID, userID)
+}
+
+func (m *MockCredentialService) LogDeniedAccess(ctx context.Context, tenantID, credentialID, userID string, keys []string) error {
+	return nil
+}
+
+func (m *MockCredentialService) TestCredential(ctx context.Context, tenantID, credentialID, userID string) (*credential.CredentialTestResult, error) {
+	return nil, nil
+}
@@ -28,6 +28,18 @@ var (
 	ErrTokenRevoked         = errors.New("access token has been revoked")
 )
 
+// Interaction errors
+var (
+	ErrInvalidSignature       = errors.New("invalid Slack request signature")
+	ErrUnsupportedInteraction = errors.New("unsupported Slack interaction")
+	ErrInteractionNotFound    = errors.New("interaction not found")
+	ErrInteractionClosed      = errors.New("this request is no longer waiting for a response")
+	ErrInvalidResponseValue   = errors.New("response is not one of the offered options")
+	ErrTooManyButtons         = errors.New("a message can have at most 25 buttons")
+	ErrInvalidButton          = errors.New("buttons need text and a value, and a style of primary or danger")
+	ErrInvalidResponseTimeout = errors.New("response_timeout must be a positive duration")
+)
+
 // SlackError represents a Slack API error
 type SlackError struct {
 	ErrorCode  string
@@ -0,0 +1,231 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interaction statuses
+const (
+	InteractionStatusPending   = "pending"
+	InteractionStatusResponded = "responded"
+	InteractionStatusExpired   = "expired"
+)
+
+// Interaction sources
+const (
+	InteractionSourceButton       = "button"
+	InteractionSourceSlashCommand = "slash_command"
+)
+
+// InteractionBlockPrefix starts the block_id of the actions block carrying a
+// message's buttons; the interaction token follows it
+const InteractionBlockPrefix = "gorax_interaction:"
+
+// maxSignatureAge is how old a signed Slack request may be before it is
+// rejected as a possible replay
+const maxSignatureAge = 5 * time.Minute
+
+// defaultResponseTimeout is how long a message's buttons wait for a response
+// when its node sets no response_timeout
+const defaultResponseTimeout = 24 * time.Hour
+
+// Interaction is a message's request for a response, tying the clicks and
+// slash commands that quote its token back to the execution that sent it
+type Interaction struct {
+	Token         string     `db:"token" json:"token"`
+	TenantID      string     `db:"tenant_id" json:"tenant_id"`
+	ExecutionID   string     `db:"execution_id" json:"execution_id"`
+	NodeID        string     `db:"node_id" json:"node_id"`
+	Options       []string   `db:"-" json:"options"`
+	Status        string     `db:"status" json:"status"`
+	Value         string     `db:"value" json:"value,omitempty"`
+	Source        string     `db:"source" json:"source,omitempty"`
+	ResponderID   string     `db:"responder_id" json:"responder_id,omitempty"`
+	ResponderName string     `db:"responder_name" json:"responder_name,omitempty"`
+	RespondedAt   *time.Time `db:"responded_at" json:"responded_at,omitempty"`
+	ExpiresAt     time.Time  `db:"expires_at" json:"expires_at"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+// Accepts reports whether the interaction can take value as its response at now
+func (i *Interaction) Accepts(value string, now time.Time) error {
+	if i.Status != InteractionStatusPending || !now.Before(i.ExpiresAt) {
+		return ErrInteractionClosed
+	}
+	if len(i.Options) > 0 && !slices.Contains(i.Options, value) {
+		return ErrInvalidResponseValue
+	}
+	return nil
+}
+
+// InteractionResponse is a response parsed from a Slack interaction request
+type InteractionResponse struct {
+	Token         string
+	Value         string
+	Source        string
+	ResponderID   string
+	ResponderName string
+}
+
+// MessageButton is a button on a message that waits for a response
+type MessageButton struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+	Style string `json:"style,omitempty"` // primary or danger
+}
+
+// WaitForResponseConfig represents configuration for the WaitForResponse node
+type WaitForResponseConfig struct {
+	// Token is the interaction token of the message to wait on, normally
+	// ${steps.<send node>.interaction_token}
+	Token string `json:"token"`
+	// Timeout caps the wait; the message's response_timeout applies otherwise
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// WaitForResponseResult represents the response a WaitForResponse node received
+type WaitForResponseResult struct {
+	Responded   bool       `json:"responded"`
+	TimedOut    bool       `json:"timed_out"`
+	Value       string     `json:"value"`
+	Source      string     `json:"source,omitempty"`
+	UserID      string     `json:"user_id,omitempty"`
+	UserName    string     `json:"user_name,omitempty"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// NewInteractionToken returns a random token identifying an interaction
+func NewInteractionToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate interaction token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// InteractionBlocks returns the blocks that show a message's buttons under
+// text, with the interaction token in the actions block's block_id. Slack
+// shows blocks instead of text, so text gets a section of its own unless the
+// message already has blocks.
+func InteractionBlocks(blocks []map[string]interface{}, text, token string, buttons []MessageButton) []map[string]interface{} {
+	result := append([]map[string]interface{}(nil), blocks...)
+	if len(result) == 0 && text != "" {
+		result = append(result, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": text},
+		})
+	}
+
+	elements := make([]interface{}, 0, len(buttons))
+	for i, button := range buttons {
+		element := map[string]interface{}{
+			"type":      "button",
+			"action_id": fmt.Sprintf("gorax_response_%d", i),
+			"text":      map[string]interface{}{"type": "plain_text", "text": button.Text},
+			"value":     button.Value,
+		}
+		if button.Style != "" {
+			element["style"] = button.Style
+		}
+		elements = append(elements, element)
+	}
+
+	return append(result, map[string]interface{}{
+		"type":     "actions",
+		"block_id": InteractionBlockPrefix + token,
+		"elements": elements,
+	})
+}
+
+// VerifySignature checks a request's X-Slack-Signature against the app's
+// signing secret, rejecting requests signed more than five minutes from now
+func VerifySignature(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	if signingSecret == "" || timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// blockActionsPayload is the part of a block_actions interaction payload
+// needed to record a button response
+type blockActionsPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Actions []struct {
+		BlockID string `json:"block_id"`
+		Value   string `json:"value"`
+	} `json:"actions"`
+}
+
+// ParseInteractionRequest parses the form Slack posts for a button click or a
+// slash command. Slash commands take the response and then the token, as in
+// "/approve-request approve 4f9c...".
+func ParseInteractionRequest(form url.Values) (*InteractionResponse, error) {
+	if raw := form.Get("payload"); raw != "" {
+		var payload blockActionsPayload
+		if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+			return nil, fmt.Errorf("%w: invalid payload", ErrUnsupportedInteraction)
+		}
+		if payload.Type != "block_actions" {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedInteraction, payload.Type)
+		}
+		for _, action := range payload.Actions {
+			if token, ok := strings.CutPrefix(action.BlockID, InteractionBlockPrefix); ok && token != "" {
+				return &InteractionResponse{
+					Token:         token,
+					Value:         action.Value,
+					Source:        InteractionSourceButton,
+					ResponderID:   payload.User.ID,
+					ResponderName: payload.User.Username,
+				}, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: no gorax action", ErrUnsupportedInteraction)
+	}
+
+	if form.Get("command") != "" {
+		fields := strings.Fields(form.Get("text"))
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: expected %s <response> <token>", ErrUnsupportedInteraction, form.Get("command"))
+		}
+		return &InteractionResponse{
+			Token:         fields[1],
+			Value:         fields[0],
+			Source:        InteractionSourceSlashCommand,
+			ResponderID:   form.Get("user_id"),
+			ResponderName: form.Get("user_name"),
+		}, nil
+	}
+
+	return nil, ErrUnsupportedInteraction
+}
@@ -0,0 +1,139 @@
+package slack
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// interactionColumns selects an interaction, with the response columns that
+// stay NULL until it is answered read as empty strings
+const interactionColumns = `
+	token, tenant_id, execution_id, node_id, options, status,
+	COALESCE(value, ''), COALESCE(source, ''), COALESCE(responder_id, ''),
+	COALESCE(responder_name, ''), responded_at, expires_at, created_at`
+
+// InteractionRepository stores Slack interactions in PostgreSQL
+type InteractionRepository struct {
+	db *sqlx.DB
+}
+
+// NewInteractionRepository creates a new Slack interaction repository
+func NewInteractionRepository(db *sqlx.DB) *InteractionRepository {
+	return &InteractionRepository{db: db}
+}
+
+// Create stores a pending interaction
+func (r *InteractionRepository) Create(ctx context.Context, interaction *Interaction) error {
+	query := `
+		INSERT INTO slack_interactions (token, tenant_id, execution_id, node_id, options, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+
+	interaction.Status = InteractionStatusPending
+	err := r.db.QueryRowContext(ctx, query,
+		interaction.Token,
+		interaction.TenantID,
+		interaction.ExecutionID,
+		interaction.NodeID,
+		pq.Array(interaction.Options),
+		interaction.Status,
+		interaction.ExpiresAt,
+	).Scan(&interaction.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create slack interaction: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves an interaction by token
+func (r *InteractionRepository) Get(ctx context.Context, token string) (*Interaction, error) {
+	query := `SELECT` + interactionColumns + `
+		FROM slack_interactions
+		WHERE token = $1
+	`
+	return r.scan(r.db.QueryRowContext(ctx, query, token))
+}
+
+// Respond records a response to a pending interaction. It fails with
+// ErrInteractionClosed when the interaction was already answered or has
+// expired, so only the first response counts.
+func (r *InteractionRepository) Respond(ctx context.Context, response InteractionResponse) (*Interaction, error) {
+	interaction, err := r.Get(ctx, response.Token)
+	if err != nil {
+		return nil, err
+	}
+	if err := interaction.Accepts(response.Value, time.Now()); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE slack_interactions
+		SET status = $2, value = $3, source = $4, responder_id = $5,
+		    responder_name = $6, responded_at = NOW()
+		WHERE token = $1 AND status = 'pending' AND expires_at > NOW()
+		RETURNING` + interactionColumns
+
+	interaction, err = r.scan(r.db.QueryRowContext(ctx, query,
+		response.Token,
+		InteractionStatusResponded,
+		response.Value,
+		response.Source,
+		response.ResponderID,
+		response.ResponderName,
+	))
+	if errors.Is(err, ErrInteractionNotFound) {
+		return nil, ErrInteractionClosed
+	}
+	return interaction, err
+}
+
+// Expire closes a pending interaction so that later responses are refused
+func (r *InteractionRepository) Expire(ctx context.Context, token string) error {
+	query := `
+		UPDATE slack_interactions
+		SET status = $2
+		WHERE token = $1 AND status = 'pending'
+	`
+	if _, err := r.db.ExecContext(ctx, query, token, InteractionStatusExpired); err != nil {
+		return fmt.Errorf("failed to expire slack interaction: %w", err)
+	}
+	return nil
+}
+
+// scan reads an interaction selected with interactionColumns
+func (r *InteractionRepository) scan(row *sql.Row) (*Interaction, error) {
+	var interaction Interaction
+	var options pq.StringArray
+
+	err := row.Scan(
+		&interaction.Token,
+		&interaction.TenantID,
+		&interaction.ExecutionID,
+		&interaction.NodeID,
+		&options,
+		&interaction.Status,
+		&interaction.Value,
+		&interaction.Source,
+		&interaction.ResponderID,
+		&interaction.ResponderName,
+		&interaction.RespondedAt,
+		&interaction.ExpiresAt,
+		&interaction.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInteractionNotFound
+		}
+		return nil, fmt.Errorf("failed to get slack interaction: %w", err)
+	}
+
+	interaction.Options = options
+	return &interaction, nil
+}
@@ -0,0 +1,100 @@
+package slack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var interactionRowColumns = []string{
+	"token", "tenant_id", "execution_id", "node_id", "options", "status", "value", "source",
+	"responder_id", "responder_name", "responded_at", "expires_at", "created_at",
+}
+
+func newMockInteractionRepository(t *testing.T) (*InteractionRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewInteractionRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func pendingInteractionRow(expiresAt time.Time) *sqlmock.Rows {
+	return sqlmock.NewRows(interactionRowColumns).AddRow(
+		"tok-1", "tenant-1", "exec-1", "slack-1", "{approve,reject}", InteractionStatusPending,
+		"", "", "", "", nil, expiresAt, time.Now(),
+	)
+}
+
+func TestInteractionRepository_Respond(t *testing.T) {
+	response := InteractionResponse{
+		Token:         "tok-1",
+		Value:         "approve",
+		Source:        InteractionSourceButton,
+		ResponderID:   "U1",
+		ResponderName: "alice",
+	}
+
+	t.Run("records the first response", func(t *testing.T) {
+		repo, mock := newMockInteractionRepository(t)
+		respondedAt := time.Now()
+		expiresAt := respondedAt.Add(time.Hour)
+
+		mock.ExpectQuery("SELECT .* FROM slack_interactions").
+			WithArgs("tok-1").
+			WillReturnRows(pendingInteractionRow(expiresAt))
+		mock.ExpectQuery("UPDATE slack_interactions").
+			WithArgs("tok-1", InteractionStatusResponded, "approve", InteractionSourceButton, "U1", "alice").
+			WillReturnRows(sqlmock.NewRows(interactionRowColumns).AddRow(
+				"tok-1", "tenant-1", "exec-1", "slack-1", "{approve,reject}", InteractionStatusResponded,
+				"approve", InteractionSourceButton, "U1", "alice", respondedAt, expiresAt, time.Now(),
+			))
+
+		interaction, err := repo.Respond(context.Background(), response)
+		require.NoError(t, err)
+		assert.Equal(t, InteractionStatusResponded, interaction.Status)
+		assert.Equal(t, "approve", interaction.Value)
+		assert.Equal(t, []string{"approve", "reject"}, interaction.Options)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refuses values that were not offered", func(t *testing.T) {
+		repo, mock := newMockInteractionRepository(t)
+		mock.ExpectQuery("SELECT .* FROM slack_interactions").
+			WithArgs("tok-1").
+			WillReturnRows(pendingInteractionRow(time.Now().Add(time.Hour)))
+
+		invalid := response
+		invalid.Value = "maybe"
+		_, err := repo.Respond(context.Background(), invalid)
+		assert.ErrorIs(t, err, ErrInvalidResponseValue)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("loses the race to another response", func(t *testing.T) {
+		repo, mock := newMockInteractionRepository(t)
+		mock.ExpectQuery("SELECT .* FROM slack_interactions").
+			WithArgs("tok-1").
+			WillReturnRows(pendingInteractionRow(time.Now().Add(time.Hour)))
+		mock.ExpectQuery("UPDATE slack_interactions").
+			WillReturnRows(sqlmock.NewRows(interactionRowColumns))
+
+		_, err := repo.Respond(context.Background(), response)
+		assert.ErrorIs(t, err, ErrInteractionClosed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		repo, mock := newMockInteractionRepository(t)
+		mock.ExpectQuery("SELECT .* FROM slack_interactions").
+			WithArgs("tok-1").
+			WillReturnRows(sqlmock.NewRows(interactionRowColumns))
+
+		_, err := repo.Respond(context.Background(), response)
+		assert.ErrorIs(t, err, ErrInteractionNotFound)
+	})
+}
@@ -257,8 +257,10 @@ type ActionConfig struct {
 
 // SendMessageConfig represents configuration for SendMessage action
 type SendMessageConfig struct {
+	CredentialID   string                   `json:"credential_id,omitempty"` // Slack credential holding access_token
 	Channel        string                   `json:"channel"`
 	Text           string                   `json:"text,omitempty"`
+	Message        string                   `json:"message,omitempty"` // Plain-text alias for text
 	Blocks         []map[string]interface{} `json:"blocks,omitempty"`
 	ThreadTS       string                   `json:"thread_ts,omitempty"`
 	ReplyBroadcast bool                     `json:"reply_broadcast,omitempty"`
@@ -266,13 +268,22 @@ type SendMessageConfig struct {
 	UnfurlMedia    *bool                    `json:"unfurl_media,omitempty"`
 	IconEmoji      string                   `json:"icon_emoji,omitempty"`
 	Username       string                   `json:"username,omitempty"`
+
+	// Buttons ask for a response that a slack:wait_for_response node waits on
+	Buttons         []MessageButton `json:"buttons,omitempty"`
+	ResponseTimeout string          `json:"response_timeout,omitempty"` // How long buttons accept responses, default 24h
+
+	// InteractionToken is set by the executor when the message has buttons
+	InteractionToken string `json:"-"`
 }
 
 // SendDMConfig represents configuration for SendDM action
 type SendDMConfig struct {
-	User   string                   `json:"user"` // User ID or email
-	Text   string                   `json:"text,omitempty"`
-	Blocks []map[string]interface{} `json:"blocks,omitempty"`
+	CredentialID string                   `json:"credential_id,omitempty"` // Slack credential holding access_token
+	User         string                   `json:"user"`                    // User ID or email
+	Text         string                   `json:"text,omitempty"`
+	Message      string                   `json:"message,omitempty"` // Plain-text alias for text
+	Blocks       []map[string]interface{} `json:"blocks,omitempty"`
 }
 
 // UpdateMessageConfig represents configuration for UpdateMessage action
@@ -290,29 +301,80 @@ type AddReactionConfig struct {
 	Emoji     string `json:"emoji"` // Emoji name without colons
 }
 
+// MessageText returns the message text, preferring text over message
+func (c *SendMessageConfig) MessageText() string {
+	if c.Text != "" {
+		return c.Text
+	}
+	return c.Message
+}
+
 // Validate validates SendMessageConfig
 func (c *SendMessageConfig) Validate() error {
 	if c.Channel == "" {
 		return ErrChannelRequired
 	}
-	if c.Text == "" && len(c.Blocks) == 0 {
+	if c.MessageText() == "" && len(c.Blocks) == 0 {
 		return ErrTextOrBlocksRequired
 	}
-	if len(c.Text) > 40000 {
+	if len(c.MessageText()) > 40000 {
 		return ErrTextTooLong
 	}
+	if len(c.Buttons) > 25 {
+		return ErrTooManyButtons
+	}
+	for _, button := range c.Buttons {
+		if button.Text == "" || button.Value == "" {
+			return ErrInvalidButton
+		}
+		if button.Style != "" && button.Style != "primary" && button.Style != "danger" {
+			return ErrInvalidButton
+		}
+	}
+	if _, err := c.ResponseTTL(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// ResponseTTL returns how long the message's buttons accept responses
+func (c *SendMessageConfig) ResponseTTL() (time.Duration, error) {
+	if c.ResponseTimeout == "" {
+		return defaultResponseTimeout, nil
+	}
+	ttl, err := time.ParseDuration(c.ResponseTimeout)
+	if err != nil || ttl <= 0 {
+		return 0, ErrInvalidResponseTimeout
+	}
+	return ttl, nil
+}
+
+// ButtonValues returns the values of the message's buttons
+func (c *SendMessageConfig) ButtonValues() []string {
+	values := make([]string, 0, len(c.Buttons))
+	for _, button := range c.Buttons {
+		values = append(values, button.Value)
+	}
+	return values
+}
+
+// MessageText returns the message text, preferring text over message
+func (c *SendDMConfig) MessageText() string {
+	if c.Text != "" {
+		return c.Text
+	}
+	return c.Message
+}
+
 // Validate validates SendDMConfig
 func (c *SendDMConfig) Validate() error {
 	if c.User == "" {
 		return ErrUserRequired
 	}
-	if c.Text == "" && len(c.Blocks) == 0 {
+	if c.MessageText() == "" && len(c.Blocks) == 0 {
 		return ErrTextOrBlocksRequired
 	}
-	if len(c.Text) > 40000 {
+	if len(c.MessageText()) > 40000 {
 		return ErrTextTooLong
 	}
 	return nil
@@ -31,32 +31,21 @@ func (a *SendDMAction) Execute(ctx context.Context, input *actions.ActionInput)
 		return nil, fmt.Errorf("invalid config type: expected SendDMConfig")
 	}
 
+	// Resolve expressions against the execution context
+	config.CredentialID = actions.InterpolateString(config.CredentialID, input.Context)
+	config.User = actions.InterpolateString(config.User, input.Context)
+	config.Text = actions.InterpolateString(config.Text, input.Context)
+	config.Message = actions.InterpolateString(config.Message, input.Context)
+	config.Blocks = interpolateBlocks(config.Blocks, input.Context)
+
 	// Validate config
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Extract tenant_id and credential_id from context
-	tenantID, err := extractString(input.Context, "env.tenant_id")
+	accessToken, err := resolveAccessToken(ctx, a.credentialService, input.Context, config.CredentialID)
 	if err != nil {
-		return nil, fmt.Errorf("tenant_id is required in context: %w", err)
-	}
-
-	credentialID, err := extractString(input.Context, "credential_id")
-	if err != nil {
-		return nil, fmt.Errorf("credential_id is required in context: %w", err)
-	}
-
-	// Retrieve and decrypt credential
-	decryptedCred, err := a.credentialService.GetValue(ctx, tenantID, credentialID, "system")
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve credential: %w", err)
-	}
-
-	// Extract access_token from credential
-	accessToken, ok := decryptedCred.Value["access_token"].(string)
-	if !ok || accessToken == "" {
-		return nil, fmt.Errorf("access_token not found in credential")
+		return nil, err
 	}
 
 	// Create Slack client
@@ -85,7 +74,7 @@ func (a *SendDMAction) Execute(ctx context.Context, input *actions.ActionInput)
 	// Build SendMessage request
 	req := &SendMessageRequest{
 		Channel: conversation.ID,
-		Text:    config.Text,
+		Text:    config.MessageText(),
 		Blocks:  config.Blocks,
 	}
 
@@ -100,6 +89,7 @@ func (a *SendDMAction) Execute(ctx context.Context, input *actions.ActionInput)
 		OK:        resp.OK,
 		UserID:    userID,
 		Channel:   resp.Channel,
+		TS:        resp.TS,
 		Timestamp: resp.TS,
 		Message:   &resp.Message,
 	}
@@ -160,6 +150,7 @@ type SendDMResult struct {
 	OK        bool     `json:"ok"`
 	UserID    string   `json:"user_id"`
 	Channel   string   `json:"channel"`
+	TS        string   `json:"ts"`
 	Timestamp string   `json:"timestamp"`
 	Message   *Message `json:"message,omitempty"`
 }
@@ -2,6 +2,7 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/gorax/gorax/internal/credential"
@@ -30,32 +31,27 @@ func (a *SendMessageAction) Execute(ctx context.Context, input *actions.ActionIn
 		return nil, fmt.Errorf("invalid config type: expected SendMessageConfig")
 	}
 
+	// Resolve expressions against the execution context
+	config.CredentialID = actions.InterpolateString(config.CredentialID, input.Context)
+	config.Channel = actions.InterpolateString(config.Channel, input.Context)
+	config.Text = actions.InterpolateString(config.Text, input.Context)
+	config.Message = actions.InterpolateString(config.Message, input.Context)
+	config.ThreadTS = actions.InterpolateString(config.ThreadTS, input.Context)
+	config.Blocks = interpolateBlocks(config.Blocks, input.Context)
+
 	// Validate config
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Extract tenant_id and credential_id from context
-	tenantID, err := extractString(input.Context, "env.tenant_id")
-	if err != nil {
-		return nil, fmt.Errorf("tenant_id is required in context: %w", err)
-	}
-
-	credentialID, err := extractString(input.Context, "credential_id")
+	accessToken, err := resolveAccessToken(ctx, a.credentialService, input.Context, config.CredentialID)
 	if err != nil {
-		return nil, fmt.Errorf("credential_id is required in context: %w", err)
+		return nil, err
 	}
 
-	// Retrieve and decrypt credential
-	decryptedCred, err := a.credentialService.GetValue(ctx, tenantID, credentialID, "system")
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve credential: %w", err)
-	}
-
-	// Extract access_token from credential
-	accessToken, ok := decryptedCred.Value["access_token"].(string)
-	if !ok || accessToken == "" {
-		return nil, fmt.Errorf("access_token not found in credential")
+	// Show the buttons of a message waiting for a response
+	if config.InteractionToken != "" && len(config.Buttons) > 0 {
+		config.Blocks = InteractionBlocks(config.Blocks, config.MessageText(), config.InteractionToken, config.Buttons)
 	}
 
 	// Create Slack client
@@ -72,7 +68,7 @@ func (a *SendMessageAction) Execute(ctx context.Context, input *actions.ActionIn
 	// Build SendMessage request
 	req := &SendMessageRequest{
 		Channel:        config.Channel,
-		Text:           config.Text,
+		Text:           config.MessageText(),
 		Blocks:         config.Blocks,
 		ThreadTS:       config.ThreadTS,
 		ReplyBroadcast: config.ReplyBroadcast,
@@ -98,9 +94,14 @@ func (a *SendMessageAction) Execute(ctx context.Context, input *actions.ActionIn
 	result := &SendMessageResult{
 		OK:        resp.OK,
 		Channel:   resp.Channel,
+		TS:        resp.TS,
 		Timestamp: resp.TS,
+		ThreadTS:  config.ThreadTS,
 		Message:   &resp.Message,
 	}
+	if len(config.Buttons) > 0 {
+		result.InteractionToken = config.InteractionToken
+	}
 
 	// Create output
 	output := actions.NewActionOutput(result)
@@ -111,12 +112,72 @@ func (a *SendMessageAction) Execute(ctx context.Context, input *actions.ActionIn
 	return output, nil
 }
 
-// SendMessageResult represents the result of sending a Slack message
+// SendMessageResult represents the result of sending a Slack message.
+// TS is the message timestamp to pass as thread_ts or ts to downstream nodes,
+// and InteractionToken the token a slack:wait_for_response node waits on.
 type SendMessageResult struct {
-	OK        bool     `json:"ok"`
-	Channel   string   `json:"channel"`
-	Timestamp string   `json:"timestamp"`
-	Message   *Message `json:"message,omitempty"`
+	OK               bool     `json:"ok"`
+	Channel          string   `json:"channel"`
+	TS               string   `json:"ts"`
+	Timestamp        string   `json:"timestamp"`
+	ThreadTS         string   `json:"thread_ts,omitempty"`
+	Message          *Message `json:"message,omitempty"`
+	InteractionToken string   `json:"interaction_token,omitempty"`
+}
+
+// resolveAccessToken retrieves the Slack access token from the credential service.
+// The node's credential_id takes precedence over a credential_id in the context.
+func resolveAccessToken(ctx context.Context, credentialService credential.Service, execContext map[string]interface{}, credentialID string) (string, error) {
+	tenantID, err := extractString(execContext, "env.tenant_id")
+	if err != nil {
+		return "", fmt.Errorf("tenant_id is required in context: %w", err)
+	}
+
+	if credentialID == "" {
+		credentialID, err = extractString(execContext, "credential_id")
+		if err != nil {
+			return "", fmt.Errorf("credential_id is required in config or context: %w", err)
+		}
+	}
+
+	// Retrieve and decrypt credential
+	decryptedCred, err := credentialService.GetValue(ctx, tenantID, credentialID, "system")
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve credential: %w", err)
+	}
+
+	// Extract access_token from credential
+	accessToken, ok := decryptedCred.Value["access_token"].(string)
+	if !ok || accessToken == "" {
+		return "", fmt.Errorf("access_token not found in credential")
+	}
+
+	return accessToken, nil
+}
+
+// interpolateBlocks resolves expressions inside Block Kit JSON
+func interpolateBlocks(blocks []map[string]interface{}, execContext map[string]interface{}) []map[string]interface{} {
+	if len(blocks) == 0 {
+		return blocks
+	}
+
+	raw, err := json.Marshal(blocks)
+	if err != nil {
+		return blocks
+	}
+
+	interpolated, ok := actions.InterpolateJSON(raw, execContext).([]interface{})
+	if !ok {
+		return blocks
+	}
+
+	result := make([]map[string]interface{}, 0, len(interpolated))
+	for _, block := range interpolated {
+		if m, ok := block.(map[string]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
 }
 
 // extractString extracts a string value from a nested map using dot notation
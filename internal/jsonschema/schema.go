@@ -0,0 +1,460 @@
+// Package jsonschema compiles JSON Schema documents and validates decoded
+// JSON against them, reporting every violation with its path.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrInvalidSchema is returned when a schema document cannot be compiled
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+// Violation describes one way a document does not match a schema
+type Violation struct {
+	// Path locates the offending value, e.g. $.items[0].id
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Schema is a compiled JSON Schema. It supports the
+// commonly used validation keywords: type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minLength, maxLength,
+// pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf,
+// oneOf and not. Other keywords, such as title or format, are ignored.
+type Schema struct {
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	noAdditional         bool
+	items                *Schema
+	minItems             *int
+	maxItems             *int
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	allOf                []*Schema
+	anyOf                []*Schema
+	oneOf                []*Schema
+	not                  *Schema
+}
+
+// Compile parses and compiles a JSON Schema document
+func Compile(raw []byte) (*Schema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	schema, err := compileSchemaNode(doc, "$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	return schema, nil
+}
+
+// compileSchemaNode compiles one schema object; path is used in error messages
+func compileSchemaNode(node interface{}, path string) (*Schema, error) {
+	// true accepts everything; false accepts nothing
+	if accept, ok := node.(bool); ok {
+		if accept {
+			return &Schema{}, nil
+		}
+		return &Schema{not: &Schema{}}, nil
+	}
+
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", path)
+	}
+	if _, ok := obj["$ref"]; ok {
+		return nil, fmt.Errorf("%s: $ref is not supported", path)
+	}
+
+	schema := &Schema{}
+
+	switch t := obj["type"].(type) {
+	case nil:
+	case string:
+		schema.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s.type: must be a string or array of strings", path)
+			}
+			schema.types = append(schema.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%s.type: must be a string or array of strings", path)
+	}
+	for _, name := range schema.types {
+		if !validSchemaType(name) {
+			return nil, fmt.Errorf("%s.type: unknown type %q", path, name)
+		}
+	}
+
+	if enum, ok := obj["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s.enum: must be an array", path)
+		}
+		schema.enum = values
+	}
+	if constValue, ok := obj["const"]; ok {
+		schema.constValue = constValue
+		schema.hasConst = true
+	}
+
+	if props, ok := obj["properties"]; ok {
+		propMap, ok := props.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s.properties: must be an object", path)
+		}
+		schema.properties = make(map[string]*Schema, len(propMap))
+		for name, prop := range propMap {
+			compiled, err := compileSchemaNode(prop, path+".properties."+name)
+			if err != nil {
+				return nil, err
+			}
+			schema.properties[name] = compiled
+		}
+	}
+
+	if required, ok := obj["required"]; ok {
+		names, ok := required.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s.required: must be an array of strings", path)
+		}
+		for _, item := range names {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s.required: must be an array of strings", path)
+			}
+			schema.required = append(schema.required, name)
+		}
+	}
+
+	switch additional := obj["additionalProperties"].(type) {
+	case nil:
+	case bool:
+		schema.noAdditional = !additional
+	default:
+		compiled, err := compileSchemaNode(additional, path+".additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		schema.additionalProperties = compiled
+	}
+
+	if items, ok := obj["items"]; ok {
+		compiled, err := compileSchemaNode(items, path+".items")
+		if err != nil {
+			return nil, err
+		}
+		schema.items = compiled
+	}
+
+	var err error
+	for keyword, target := range map[string]**int{
+		"minItems":  &schema.minItems,
+		"maxItems":  &schema.maxItems,
+		"minLength": &schema.minLength,
+		"maxLength": &schema.maxLength,
+	} {
+		if *target, err = schemaCount(obj, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, target := range map[string]**float64{
+		"minimum":          &schema.minimum,
+		"maximum":          &schema.maximum,
+		"exclusiveMinimum": &schema.exclusiveMinimum,
+		"exclusiveMaximum": &schema.exclusiveMaximum,
+	} {
+		if *target, err = schemaNumber(obj, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if pattern, ok := obj["pattern"]; ok {
+		expr, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.pattern: must be a string", path)
+		}
+		if schema.pattern, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("%s.pattern: %v", path, err)
+		}
+	}
+
+	for keyword, target := range map[string]*[]*Schema{
+		"allOf": &schema.allOf,
+		"anyOf": &schema.anyOf,
+		"oneOf": &schema.oneOf,
+	} {
+		list, ok := obj[keyword]
+		if !ok {
+			continue
+		}
+		subschemas, ok := list.([]interface{})
+		if !ok || len(subschemas) == 0 {
+			return nil, fmt.Errorf("%s.%s: must be a non-empty array", path, keyword)
+		}
+		for i, sub := range subschemas {
+			compiled, err := compileSchemaNode(sub, fmt.Sprintf("%s.%s[%d]", path, keyword, i))
+			if err != nil {
+				return nil, err
+			}
+			*target = append(*target, compiled)
+		}
+	}
+
+	if not, ok := obj["not"]; ok {
+		if schema.not, err = compileSchemaNode(not, path+".not"); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// schemaCount reads a non-negative integer keyword
+func schemaCount(obj map[string]interface{}, keyword, path string) (*int, error) {
+	value, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return nil, fmt.Errorf("%s.%s: must be a non-negative integer", path, keyword)
+	}
+	count := int(n)
+	return &count, nil
+}
+
+// schemaNumber reads a numeric keyword
+func schemaNumber(obj map[string]interface{}, keyword, path string) (*float64, error) {
+	value, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+	n, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s: must be a number", path, keyword)
+	}
+	return &n, nil
+}
+
+func validSchemaType(name string) bool {
+	switch name {
+	case "object", "array", "string", "number", "integer", "boolean", "null":
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate checks a decoded JSON document against the schema and returns every violation found
+func (s *Schema) Validate(payload interface{}) []Violation {
+	var violations []Violation
+	s.validate(payload, "$", &violations)
+	return violations
+}
+
+// ValidateJSON decodes a JSON document and validates it against the schema
+func (s *Schema) ValidateJSON(body []byte) []Violation {
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return []Violation{{Path: "$", Message: "body is not valid JSON"}}
+	}
+	return s.Validate(payload)
+}
+
+func (s *Schema) validate(value interface{}, path string, violations *[]Violation) {
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.types) > 0 && !matchesAnyType(value, s.types) {
+		add("expected %s, got %s", joinTypes(s.types), jsonTypeName(value))
+		return
+	}
+
+	if s.hasConst && !reflect.DeepEqual(value, s.constValue) {
+		add("must equal %s", compactJSON(s.constValue))
+	}
+	if s.enum != nil && !containsValue(s.enum, value) {
+		add("must be one of %s", compactJSON(s.enum))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(v, path, violations)
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			add("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			add("must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, path+"["+strconv.Itoa(i)+"]", violations)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			add("must be at least %d characters", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			add("must be at most %d characters", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			add("must match pattern %s", s.pattern.String())
+		}
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			add("must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			add("must be <= %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			add("must be > %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			add("must be < %v", *s.exclusiveMaximum)
+		}
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(value, path, violations)
+	}
+	if len(s.anyOf) > 0 && countMatches(s.anyOf, value) == 0 {
+		add("must match at least one schema in anyOf")
+	}
+	if len(s.oneOf) > 0 {
+		if matches := countMatches(s.oneOf, value); matches != 1 {
+			add("must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if s.not != nil && len(s.not.Validate(value)) == 0 {
+		add("must not match the schema in not")
+	}
+}
+
+func (s *Schema) validateObject(obj map[string]interface{}, path string, violations *[]Violation) {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			*violations = append(*violations, Violation{Path: path + "." + name, Message: "is required"})
+		}
+	}
+
+	// Visit properties in a stable order so violations are reported deterministically
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propPath := path + "." + name
+		if prop, ok := s.properties[name]; ok {
+			prop.validate(obj[name], propPath, violations)
+			continue
+		}
+		if s.noAdditional {
+			*violations = append(*violations, Violation{Path: propPath, Message: "is not allowed"})
+		} else if s.additionalProperties != nil {
+			s.additionalProperties.validate(obj[name], propPath, violations)
+		}
+	}
+}
+
+func countMatches(schemas []*Schema, value interface{}) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(sub.Validate(value)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, name := range types {
+		if matchesType(value, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(value interface{}, name string) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	default:
+		return jsonTypeName(value) == name
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	return "one of " + compactJSON(types)
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compactJSON(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,111 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["action", "issue"],
+	"properties": {
+		"action": {"enum": ["opened", "closed"]},
+		"issue": {
+			"type": "object",
+			"required": ["number"],
+			"properties": {
+				"number": {"type": "integer", "minimum": 1},
+				"title": {"type": "string", "minLength": 1, "maxLength": 20},
+				"labels": {"type": "array", "items": {"type": "string", "pattern": "^[a-z-]+$"}, "maxItems": 2}
+			},
+			"additionalProperties": false
+		}
+	}
+}`
+
+// TestSchema_Validate tests validation of documents against a compiled schema
+func TestSchema_Validate(t *testing.T) {
+	schema, err := Compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected []Violation
+	}{
+		{
+			name: "valid payload",
+			body: `{"action":"opened","issue":{"number":12,"title":"Broken build","labels":["bug"]}}`,
+		},
+		{
+			name: "missing required fields",
+			body: `{"issue":{}}`,
+			expected: []Violation{
+				{Path: "$.action", Message: "is required"},
+				{Path: "$.issue.number", Message: "is required"},
+			},
+		},
+		{
+			name: "wrong types and values",
+			body: `{"action":"merged","issue":{"number":1.5,"title":"","labels":["Bug"],"extra":true}}`,
+			expected: []Violation{
+				{Path: "$.action", Message: `must be one of ["opened","closed"]`},
+				{Path: "$.issue.extra", Message: "is not allowed"},
+				{Path: "$.issue.labels[0]", Message: "must match pattern ^[a-z-]+$"},
+				{Path: "$.issue.number", Message: "expected integer, got number"},
+				{Path: "$.issue.title", Message: "must be at least 1 characters"},
+			},
+		},
+		{
+			name:     "not an object",
+			body:     `[1, 2]`,
+			expected: []Violation{{Path: "$", Message: "expected object, got array"}},
+		},
+		{
+			name:     "invalid JSON",
+			body:     `{"action":`,
+			expected: []Violation{{Path: "$", Message: "body is not valid JSON"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, schema.ValidateJSON([]byte(tt.body)))
+		})
+	}
+}
+
+// TestSchema_Combinators tests anyOf, oneOf and not
+func TestSchema_Combinators(t *testing.T) {
+	schema, err := Compile([]byte(`{
+		"oneOf": [{"type": "string"}, {"type": "number", "exclusiveMinimum": 0}],
+		"not": {"const": "forbidden"}
+	}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, schema.ValidateJSON([]byte(`"ok"`)))
+	assert.Empty(t, schema.ValidateJSON([]byte(`3`)))
+	assert.Len(t, schema.ValidateJSON([]byte(`0`)), 1)
+	assert.Len(t, schema.ValidateJSON([]byte(`"forbidden"`)), 1)
+}
+
+// TestCompile_Invalid tests that malformed schemas are rejected when compiled
+func TestCompile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not JSON":          `{"type":`,
+		"unknown type":      `{"type": "date"}`,
+		"bad pattern":       `{"pattern": "("}`,
+		"negative length":   `{"minLength": -1}`,
+		"unsupported $ref":  `{"properties": {"a": {"$ref": "#/definitions/a"}}}`,
+		"schema not object": `"string"`,
+	}
+
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile([]byte(raw))
+			assert.ErrorIs(t, err, ErrInvalidSchema)
+		})
+	}
+}
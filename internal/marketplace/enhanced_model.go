@@ -4,15 +4,16 @@ import "errors"
 
 // EnhancedSearchFilter represents enhanced search filters for marketplace templates
 type EnhancedSearchFilter struct {
-	CategoryIDs []string `json:"category_ids,omitempty"` // Filter by category IDs
-	Tags        []string `json:"tags,omitempty"`
-	SearchQuery string   `json:"search_query,omitempty"`
-	MinRating   *float64 `json:"min_rating,omitempty"`
-	IsVerified  *bool    `json:"is_verified,omitempty"`
-	IsFeatured  *bool    `json:"is_featured,omitempty"` // Filter by featured status
-	SortBy      string   `json:"sort_by,omitempty"`     // popular, recent, rating, name, relevance
-	Page        int      `json:"page,omitempty"`
-	Limit       int      `json:"limit,omitempty"`
+	CategoryIDs  []string `json:"category_ids,omitempty"` // Filter by category IDs
+	Tags         []string `json:"tags,omitempty"`
+	SearchQuery  string   `json:"search_query,omitempty"`
+	MinRating    *float64 `json:"min_rating,omitempty"`
+	IsVerified   *bool    `json:"is_verified,omitempty"`
+	VerifiedOnly bool     `json:"verified_only,omitempty"` // Only system and verified publishers
+	IsFeatured   *bool    `json:"is_featured,omitempty"`   // Filter by featured status
+	SortBy       string   `json:"sort_by,omitempty"`       // popular, recent, rating, name, relevance
+	Page         int      `json:"page,omitempty"`
+	Limit        int      `json:"limit,omitempty"`
 }
 
 // Validate validates the enhanced search filter
@@ -49,14 +50,15 @@ func (f EnhancedSearchFilter) ToSearchFilter() SearchFilter {
 		category = f.CategoryIDs[0] // Use first category for legacy support
 	}
 	return SearchFilter{
-		Category:    category,
-		Tags:        f.Tags,
-		SearchQuery: f.SearchQuery,
-		MinRating:   f.MinRating,
-		IsVerified:  f.IsVerified,
-		SortBy:      f.SortBy,
-		Page:        f.Page,
-		Limit:       f.Limit,
+		Category:     category,
+		Tags:         f.Tags,
+		SearchQuery:  f.SearchQuery,
+		MinRating:    f.MinRating,
+		IsVerified:   f.IsVerified,
+		VerifiedOnly: f.VerifiedOnly,
+		SortBy:       f.SortBy,
+		Page:         f.Page,
+		Limit:        f.Limit,
 	}
 }
 
@@ -3,6 +3,7 @@ package marketplace
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"sort"
 	"testing"
@@ -180,6 +181,47 @@ func TestIntegration_InstallTemplateWorkflow(t *testing.T) {
 	t.Logf("✓ Download count incremented to: %d", retrieved.DownloadCount)
 }
 
+// TestIntegration_TemplateVersionUpdates tests that installers see new template versions
+func TestIntegration_TemplateVersionUpdates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	repo := setupTestRepository(t)
+	service := NewService(repo, &mockWorkflowService{createdWorkflowID: "workflow-1"}, testLogger())
+
+	template, err := service.PublishTemplate(ctx, "author-1", "Author", PublishTemplateInput{
+		Name:        "Error Notification",
+		Description: "Notifies a channel when a workflow fails",
+		Category:    string(CategoryNotification),
+		Definition:  json.RawMessage(`{"nodes":[],"edges":[]}`),
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	_, err = service.InstallTemplate(ctx, "tenant-1", "user-1", template.ID, InstallTemplateInput{WorkflowName: "Errors"})
+	require.NoError(t, err)
+
+	updates, err := service.ListInstalledWithUpdates(ctx, "tenant-1")
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+
+	_, err = service.PublishNewVersion(ctx, "author-1", template.ID, PublishVersionInput{
+		Version:     "1.1.0",
+		Definition:  json.RawMessage(`{"nodes":[{"id":"1"}],"edges":[]}`),
+		ChangeNotes: "Include execution ID in the message",
+	})
+	require.NoError(t, err)
+
+	updates, err = service.ListInstalledWithUpdates(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "1.0.0", updates[0].InstalledVersion)
+	assert.Equal(t, "1.1.0", updates[0].LatestVersion)
+	assert.Equal(t, "Error Notification", updates[0].TemplateName)
+}
+
 // TestIntegration_RateAndReviewTemplate tests the rating and review workflow
 func TestIntegration_RateAndReviewTemplate(t *testing.T) {
 	if testing.Short() {
@@ -265,7 +307,7 @@ func TestIntegration_RateAndReviewTemplate(t *testing.T) {
 	t.Logf("✓ Average rating after update: %.1f (%d ratings)", retrieved.AverageRating, retrieved.TotalRatings)
 
 	// Step 8: Get all reviews
-	reviews, err := service.GetReviews(ctx, template.ID, ReviewSortRecent, 10, 0)
+	reviews, _, err := service.GetReviews(ctx, template.ID, ReviewSortRecent, 10, 0)
 	require.NoError(t, err)
 	assert.Len(t, reviews, 2)
 	t.Logf("✓ Retrieved %d reviews", len(reviews))
@@ -283,6 +325,99 @@ func TestIntegration_RateAndReviewTemplate(t *testing.T) {
 	t.Logf("✓ Average rating after deletion: %.1f (%d ratings)", retrieved.AverageRating, retrieved.TotalRatings)
 }
 
+// TestIntegration_OneReviewPerUser tests that each user has at most one review per template
+func TestIntegration_OneReviewPerUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	// Setup
+	ctx := context.Background()
+	repo := setupTestRepository(t)
+	service := NewService(repo, &mockWorkflowService{}, testLogger())
+
+	template, err := service.PublishTemplate(ctx, "user-123", "John Doe", PublishTemplateInput{
+		Name:        "Review Template",
+		Description: "A template for testing review limits",
+		Category:    string(CategoryOther),
+		Definition:  json.RawMessage(`{"nodes": [{"id": "1", "type": "trigger"}], "edges": []}`),
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	// Step 1: Rating repeatedly updates the same review instead of adding more
+	first, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Alice", template.ID, RateTemplateInput{Rating: 1})
+	require.NoError(t, err)
+	second, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Alice", template.ID, RateTemplateInput{Rating: 5})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	dist, err := service.GetRatingDistribution(ctx, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dist.TotalRatings)
+	assert.Equal(t, 0, dist.Rating1Count)
+	assert.Equal(t, 1, dist.Rating5Count)
+
+	// Step 2: Another user in the same tenant gets their own review
+	other, err := service.RateTemplate(ctx, "tenant-1", "user-2", "Bob", template.ID, RateTemplateInput{Rating: 3})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	reviews, _, err := service.GetReviews(ctx, template.ID, ReviewSortRecent, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, reviews, 2)
+
+	// Step 3: Creating a second review directly is rejected
+	err = repo.CreateReview(ctx, &TemplateReview{TemplateID: template.ID, TenantID: "tenant-1", UserID: "user-1", Rating: 2})
+	assert.Error(t, err)
+
+	// Step 4: Hiding a review removes it from the distribution
+	require.NoError(t, service.HideReview(ctx, other.ID, "spam", "admin-1"))
+	dist, err = service.GetRatingDistribution(ctx, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dist.TotalRatings)
+	assert.Equal(t, 0, dist.Rating3Count)
+	assert.Equal(t, 5.0, dist.AverageRating)
+}
+
+// TestIntegration_ReviewRateLimit tests the daily limit on new reviews
+func TestIntegration_ReviewRateLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	// Setup
+	ctx := context.Background()
+	repo := setupTestRepository(t)
+	service := NewService(repo, &mockWorkflowService{}, testLogger())
+
+	templateIDs := make([]string, MaxNewReviewsPerDay+1)
+	for i := range templateIDs {
+		template, err := service.PublishTemplate(ctx, "user-123", "John Doe", PublishTemplateInput{
+			Name:        fmt.Sprintf("Template %d", i),
+			Description: "A template for testing review limits",
+			Category:    string(CategoryOther),
+			Definition:  json.RawMessage(`{"nodes": [{"id": "1", "type": "trigger"}], "edges": []}`),
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		templateIDs[i] = template.ID
+	}
+
+	for _, templateID := range templateIDs[:MaxNewReviewsPerDay] {
+		_, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Alice", templateID, RateTemplateInput{Rating: 4})
+		require.NoError(t, err)
+	}
+
+	_, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Alice", templateIDs[MaxNewReviewsPerDay], RateTemplateInput{Rating: 4})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit exceeded")
+
+	// Updating an existing review is still allowed
+	_, err = service.RateTemplate(ctx, "tenant-1", "user-1", "Alice", templateIDs[0], RateTemplateInput{Rating: 2})
+	assert.NoError(t, err)
+}
+
 // TestIntegration_TrendingAndPopularTemplates tests trending and popular queries
 func TestIntegration_TrendingAndPopularTemplates(t *testing.T) {
 	if testing.Short() {
@@ -336,7 +471,7 @@ func TestIntegration_TrendingAndPopularTemplates(t *testing.T) {
 	t.Logf("✓ Simulated downloads")
 
 	// Step 3: Get popular templates (should be ordered by download count)
-	popular, err := service.GetPopular(ctx, 10)
+	popular, err := service.GetPopular(ctx, 10, false)
 	require.NoError(t, err)
 	assert.NotEmpty(t, popular)
 
@@ -347,12 +482,71 @@ func TestIntegration_TrendingAndPopularTemplates(t *testing.T) {
 	t.Logf("✓ Retrieved %d popular templates", len(popular))
 
 	// Step 4: Get trending templates (recent downloads)
-	trending, err := service.GetTrending(ctx, 10)
+	trending, err := service.GetTrending(ctx, 10, false)
 	require.NoError(t, err)
 	assert.NotEmpty(t, trending)
 	t.Logf("✓ Retrieved %d trending templates", len(trending))
 }
 
+// TestIntegration_VerifiedPublisherFiltering tests publisher types and verified-only filtering
+func TestIntegration_VerifiedPublisherFiltering(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	// Setup
+	ctx := context.Background()
+	repo := setupTestRepository(t)
+	service := NewService(repo, &mockWorkflowService{}, testLogger())
+
+	publish := func(authorID, name string) *MarketplaceTemplate {
+		template, err := service.PublishTemplate(ctx, authorID, authorID, PublishTemplateInput{
+			Name:        name,
+			Description: "Test template for publisher filtering",
+			Category:    string(CategoryAutomation),
+			Definition:  json.RawMessage(`{"nodes": [{"id": "1", "type": "trigger"}], "edges": []}`),
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		return template
+	}
+
+	// Step 1: Built-in templates are system templates, others are community
+	builtin := publish(SystemAuthorID, "Built-in Template")
+	assert.Equal(t, PublisherTypeSystem, builtin.PublisherType)
+	assert.True(t, builtin.IsVerified)
+
+	partner := publish("user-123", "Partner Template")
+	community := publish("user-456", "Community Template")
+	assert.Equal(t, PublisherTypeCommunity, community.PublisherType)
+	assert.False(t, community.IsVerified)
+
+	// Step 2: An admin verifies the partner's template
+	verified, err := service.SetVerified(ctx, partner.ID, true, "admin-1")
+	require.NoError(t, err)
+	assert.Equal(t, PublisherTypeVerified, verified.PublisherType)
+	assert.True(t, verified.IsVerified)
+
+	// Step 3: Verified-only results exclude community templates
+	results, err := service.SearchTemplates(ctx, SearchFilter{VerifiedOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	popular, err := service.GetPopular(ctx, 10, true)
+	require.NoError(t, err)
+	assert.Len(t, popular, 2)
+
+	trending, err := service.GetTrending(ctx, 10, true)
+	require.NoError(t, err)
+	for _, template := range trending {
+		assert.NotEqual(t, community.ID, template.ID)
+	}
+
+	// Step 4: System templates cannot be unverified
+	_, err = service.SetVerified(ctx, builtin.ID, false, "admin-1")
+	assert.Error(t, err)
+}
+
 // TestIntegration_TemplateValidation tests definition validation
 func TestIntegration_TemplateValidation(t *testing.T) {
 	// Setup
@@ -472,7 +666,7 @@ type mockWorkflowService struct {
 	createdWorkflowID string
 }
 
-func (m *mockWorkflowService) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage) (string, error) {
+func (m *mockWorkflowService) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage, status string) (string, error) {
 	if m.createdWorkflowID != "" {
 		return m.createdWorkflowID, nil
 	}
@@ -524,6 +718,9 @@ func (m *mockRepository) Search(ctx context.Context, filter SearchFilter) ([]*Ma
 		if filter.Category != "" && template.Category != filter.Category {
 			continue
 		}
+		if filter.VerifiedOnly && !template.PublisherType.IsTrusted() {
+			continue
+		}
 		if len(filter.Tags) > 0 {
 			hasTag := false
 			for _, tag := range filter.Tags {
@@ -543,17 +740,23 @@ func (m *mockRepository) Search(ctx context.Context, filter SearchFilter) ([]*Ma
 	return results, nil
 }
 
-func (m *mockRepository) GetTrending(ctx context.Context, days, limit int) ([]*MarketplaceTemplate, error) {
+func (m *mockRepository) GetTrending(ctx context.Context, days, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	var results []*MarketplaceTemplate
 	for _, template := range m.templates {
+		if verifiedOnly && !template.PublisherType.IsTrusted() {
+			continue
+		}
 		results = append(results, template)
 	}
 	return results, nil
 }
 
-func (m *mockRepository) GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemplate, error) {
+func (m *mockRepository) GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	var results []*MarketplaceTemplate
 	for _, template := range m.templates {
+		if verifiedOnly && !template.PublisherType.IsTrusted() {
+			continue
+		}
 		results = append(results, template)
 	}
 	// Sort by download count descending
@@ -605,6 +808,23 @@ func (m *mockRepository) Update(ctx context.Context, id string, input UpdateTemp
 	return nil
 }
 
+func (m *mockRepository) SetVerified(ctx context.Context, id string, verified bool) (*MarketplaceTemplate, error) {
+	template, ok := m.templates[id]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	if template.PublisherType != PublisherTypeSystem {
+		template.IsVerified = verified
+		template.PublisherType = PublisherTypeCommunity
+		if verified {
+			template.PublisherType = PublisherTypeVerified
+		}
+	}
+	template.UpdatedAt = time.Now()
+	return template, nil
+}
+
 func (m *mockRepository) IncrementDownloadCount(ctx context.Context, templateID string) error {
 	template, ok := m.templates[templateID]
 	if !ok {
@@ -632,25 +852,78 @@ func (m *mockRepository) GetInstallation(ctx context.Context, tenantID, template
 	return installation, nil
 }
 
+func (m *mockRepository) PublishVersion(ctx context.Context, version *TemplateVersion, requiredCredentials, requiredEnvVars []string) error {
+	template, ok := m.templates[version.TemplateID]
+	if !ok {
+		return ErrTemplateNotFound
+	}
+	template.Version = version.Version
+	template.Definition = version.Definition
+	template.RequiredCredentials = requiredCredentials
+	template.RequiredEnvVars = requiredEnvVars
+	version.CreatedAt = time.Now()
+	return nil
+}
+
+func (m *mockRepository) GetVersions(ctx context.Context, templateID string) ([]*TemplateVersion, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) GetVersion(ctx context.Context, templateID, version string) (*TemplateVersion, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) GetInstallationsWithLatestVersion(ctx context.Context, tenantID string) ([]*InstalledTemplateUpdate, error) {
+	var results []*InstalledTemplateUpdate
+	for _, installation := range m.installations {
+		if installation.TenantID != tenantID {
+			continue
+		}
+		template, ok := m.templates[installation.TemplateID]
+		if !ok {
+			continue
+		}
+		results = append(results, &InstalledTemplateUpdate{
+			TemplateInstallation: *installation,
+			TemplateName:         template.Name,
+			LatestVersion:        template.Version,
+		})
+	}
+	return results, nil
+}
+
 func (m *mockRepository) CreateReview(ctx context.Context, review *TemplateReview) error {
 	m.nextID++
 	review.ID = "review-" + string(rune('A'+m.nextID))
 	review.CreatedAt = time.Now()
 	review.UpdatedAt = time.Now()
-	key := review.TenantID + ":" + review.TemplateID
+	key := review.TenantID + ":" + review.UserID + ":" + review.TemplateID
+	if _, exists := m.reviews[key]; exists {
+		return &templateError{message: "you have already reviewed this template"}
+	}
 	m.reviews[key] = review
-	return nil
+	return m.UpdateTemplateRating(ctx, review.TemplateID)
 }
 
-func (m *mockRepository) GetUserReview(ctx context.Context, tenantID, templateID string) (*TemplateReview, error) {
-	key := tenantID + ":" + templateID
+func (m *mockRepository) GetUserReview(ctx context.Context, tenantID, userID, templateID string) (*TemplateReview, error) {
+	key := tenantID + ":" + userID + ":" + templateID
 	review, ok := m.reviews[key]
 	if !ok {
-		return nil, nil
+		return nil, ErrReviewNotFound
 	}
 	return review, nil
 }
 
+func (m *mockRepository) CountUserReviewsSince(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
+	count := 0
+	for _, review := range m.reviews {
+		if review.TenantID == tenantID && review.UserID == userID && !review.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *mockRepository) UpdateReview(ctx context.Context, tenantID, reviewID string, rating int, comment string) error {
 	for key, review := range m.reviews {
 		if review.ID == reviewID && review.TenantID == tenantID {
@@ -658,12 +931,22 @@ func (m *mockRepository) UpdateReview(ctx context.Context, tenantID, reviewID st
 			review.Comment = comment
 			review.UpdatedAt = time.Now()
 			m.reviews[key] = review
-			return nil
+			return m.UpdateTemplateRating(ctx, review.TemplateID)
 		}
 	}
 	return ErrReviewNotFound
 }
 
+func (m *mockRepository) CountReviews(ctx context.Context, templateID string) (int, error) {
+	count := 0
+	for _, review := range m.reviews {
+		if review.TemplateID == templateID {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (m *mockRepository) GetReviews(ctx context.Context, templateID string, sortBy ReviewSortOption, limit, offset int) ([]*TemplateReview, error) {
 	var results []*TemplateReview
 	for _, review := range m.reviews {
@@ -700,7 +983,7 @@ func (m *mockRepository) DeleteReview(ctx context.Context, tenantID, reviewID st
 	for key, review := range m.reviews {
 		if review.ID == reviewID && review.TenantID == tenantID {
 			delete(m.reviews, key)
-			return nil
+			return m.UpdateTemplateRating(ctx, review.TemplateID)
 		}
 	}
 	return ErrReviewNotFound
@@ -712,22 +995,26 @@ func (m *mockRepository) UpdateTemplateRating(ctx context.Context, templateID st
 		return ErrTemplateNotFound
 	}
 
-	// Calculate average rating from reviews
+	// Calculate the rating aggregates from visible reviews
 	var totalRating int
-	var count int
+	counts := make([]int, 6)
 	for _, review := range m.reviews {
-		if review.TemplateID == templateID {
+		if review.TemplateID == templateID && !review.IsHidden {
 			totalRating += review.Rating
-			count++
+			counts[review.Rating]++
 		}
 	}
 
+	count := counts[1] + counts[2] + counts[3] + counts[4] + counts[5]
+	template.Rating1Count = counts[1]
+	template.Rating2Count = counts[2]
+	template.Rating3Count = counts[3]
+	template.Rating4Count = counts[4]
+	template.Rating5Count = counts[5]
+	template.TotalRatings = count
+	template.AverageRating = 0
 	if count > 0 {
 		template.AverageRating = float64(totalRating) / float64(count)
-		template.TotalRatings = count
-	} else {
-		template.AverageRating = 0
-		template.TotalRatings = 0
 	}
 
 	return nil
@@ -744,13 +1031,21 @@ func (m *mockRepository) HasVotedHelpful(ctx context.Context, tenantID, userID,
 }
 
 func (m *mockRepository) HideReview(ctx context.Context, reviewID, reason, hiddenBy string) error {
-	// Mock implementation - not used in integration tests
-	return nil
+	return m.setReviewHidden(ctx, reviewID, true)
 }
 
 func (m *mockRepository) UnhideReview(ctx context.Context, reviewID string) error {
-	// Mock implementation - not used in integration tests
-	return nil
+	return m.setReviewHidden(ctx, reviewID, false)
+}
+
+func (m *mockRepository) setReviewHidden(ctx context.Context, reviewID string, hidden bool) error {
+	for _, review := range m.reviews {
+		if review.ID == reviewID {
+			review.IsHidden = hidden
+			return m.UpdateTemplateRating(ctx, review.TemplateID)
+		}
+	}
+	return ErrReviewNotFound
 }
 
 func (m *mockRepository) UpdateReviewReportStatus(ctx context.Context, reportID, status, resolvedBy string, notes *string) error {
@@ -10,31 +10,56 @@ import (
 
 // MarketplaceTemplate represents a template in the marketplace
 type MarketplaceTemplate struct {
-	ID               string          `db:"id" json:"id"`
-	Name             string          `db:"name" json:"name"`
-	Description      string          `db:"description" json:"description"`
-	Category         string          `db:"category" json:"category"`
-	Definition       json.RawMessage `db:"definition" json:"definition"`
-	Tags             pq.StringArray  `db:"tags" json:"tags"`
-	AuthorID         string          `db:"author_id" json:"author_id"`
-	AuthorName       string          `db:"author_name" json:"author_name"`
-	Version          string          `db:"version" json:"version"`
-	DownloadCount    int             `db:"download_count" json:"download_count"`
-	AverageRating    float64         `db:"average_rating" json:"average_rating"`
-	TotalRatings     int             `db:"total_ratings" json:"total_ratings"`
-	Rating1Count     int             `db:"rating_1_count" json:"rating_1_count"`
-	Rating2Count     int             `db:"rating_2_count" json:"rating_2_count"`
-	Rating3Count     int             `db:"rating_3_count" json:"rating_3_count"`
-	Rating4Count     int             `db:"rating_4_count" json:"rating_4_count"`
-	Rating5Count     int             `db:"rating_5_count" json:"rating_5_count"`
-	IsVerified       bool            `db:"is_verified" json:"is_verified"`
-	IsFeatured       bool            `db:"is_featured" json:"is_featured"`
-	FeaturedAt       *time.Time      `db:"featured_at" json:"featured_at,omitempty"`
-	FeaturedBy       *string         `db:"featured_by" json:"featured_by,omitempty"`
-	SourceTenantID   *string         `db:"source_tenant_id" json:"source_tenant_id,omitempty"`
-	SourceTemplateID *string         `db:"source_template_id" json:"source_template_id,omitempty"`
-	PublishedAt      time.Time       `db:"published_at" json:"published_at"`
-	UpdatedAt        time.Time       `db:"updated_at" json:"updated_at"`
+	ID          string          `db:"id" json:"id"`
+	Name        string          `db:"name" json:"name"`
+	Description string          `db:"description" json:"description"`
+	Category    string          `db:"category" json:"category"`
+	Definition  json.RawMessage `db:"definition" json:"definition"`
+	Tags        pq.StringArray  `db:"tags" json:"tags"`
+	AuthorID    string          `db:"author_id" json:"author_id"`
+	AuthorName  string          `db:"author_name" json:"author_name"`
+	Version     string          `db:"version" json:"version"`
+	// RequiredCredentials and RequiredEnvVars are the credentials and environment
+	// variables referenced by the definition, extracted when it is published
+	RequiredCredentials pq.StringArray `db:"required_credentials" json:"required_credentials"`
+	RequiredEnvVars     pq.StringArray `db:"required_env_vars" json:"required_env_vars"`
+	DownloadCount       int            `db:"download_count" json:"download_count"`
+	AverageRating       float64        `db:"average_rating" json:"average_rating"`
+	TotalRatings        int            `db:"total_ratings" json:"total_ratings"`
+	Rating1Count        int            `db:"rating_1_count" json:"rating_1_count"`
+	Rating2Count        int            `db:"rating_2_count" json:"rating_2_count"`
+	Rating3Count        int            `db:"rating_3_count" json:"rating_3_count"`
+	Rating4Count        int            `db:"rating_4_count" json:"rating_4_count"`
+	Rating5Count        int            `db:"rating_5_count" json:"rating_5_count"`
+	IsVerified          bool           `db:"is_verified" json:"is_verified"`
+	PublisherType       PublisherType  `db:"publisher_type" json:"publisher_type"`
+	IsFeatured          bool           `db:"is_featured" json:"is_featured"`
+	FeaturedAt          *time.Time     `db:"featured_at" json:"featured_at,omitempty"`
+	FeaturedBy          *string        `db:"featured_by" json:"featured_by,omitempty"`
+	SourceTenantID      *string        `db:"source_tenant_id" json:"source_tenant_id,omitempty"`
+	SourceTemplateID    *string        `db:"source_template_id" json:"source_template_id,omitempty"`
+	PublishedAt         time.Time      `db:"published_at" json:"published_at"`
+	UpdatedAt           time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// PublisherType describes how far a template's publisher is trusted
+type PublisherType string
+
+const (
+	// PublisherTypeSystem marks built-in templates shipped with the platform
+	PublisherTypeSystem PublisherType = "system"
+	// PublisherTypeVerified marks templates from publishers vetted by an admin
+	PublisherTypeVerified PublisherType = "verified"
+	// PublisherTypeCommunity marks templates from any other publisher
+	PublisherTypeCommunity PublisherType = "community"
+)
+
+// SystemAuthorID is the author ID used for built-in templates
+const SystemAuthorID = "system"
+
+// IsTrusted returns true for system and verified publishers
+func (p PublisherType) IsTrusted() bool {
+	return p == PublisherTypeSystem || p == PublisherTypeVerified
 }
 
 // TemplateVersion represents a version of a marketplace template
@@ -154,9 +179,48 @@ type UpdateTemplateInput struct {
 	Version     string          `json:"version,omitempty"`
 }
 
+// PublishVersionInput represents input for publishing a new version of an existing template
+type PublishVersionInput struct {
+	Version     string          `json:"version" validate:"required,semver"`
+	Definition  json.RawMessage `json:"definition" validate:"required"`
+	ChangeNotes string          `json:"change_notes,omitempty" validate:"max=5000"`
+}
+
+// InstalledTemplateUpdate describes an installed template whose upstream version has advanced
+type InstalledTemplateUpdate struct {
+	TemplateInstallation
+	TemplateName  string `db:"template_name" json:"template_name"`
+	LatestVersion string `db:"latest_version" json:"latest_version"`
+}
+
 // InstallTemplateInput represents input for installing a template
 type InstallTemplateInput struct {
 	WorkflowName string `json:"workflow_name" validate:"required,min=1,max=255"`
+	// SkipPrerequisiteCheck installs the workflow even if required credentials
+	// or environment variables are not configured
+	SkipPrerequisiteCheck bool `json:"skip_prerequisite_check,omitempty"`
+	// Parameters are the values for the template's declared parameters
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// InstallMode is active (the default) or draft. Draft installs start
+	// disabled and are created even when parameters or prerequisites are
+	// missing, so an admin can finish and review them before activating.
+	InstallMode InstallMode `json:"install_mode,omitempty" validate:"omitempty,oneof=active draft"`
+}
+
+// InstallMode is the state a workflow installed from a template starts in
+type InstallMode string
+
+const (
+	InstallModeActive InstallMode = "active"
+	InstallModeDraft  InstallMode = "draft"
+)
+
+// OrDefault returns the install mode, or active when none is set
+func (m InstallMode) OrDefault() InstallMode {
+	if m == "" {
+		return InstallModeActive
+	}
+	return m
 }
 
 // RateTemplateInput represents input for rating a template
@@ -181,6 +245,24 @@ const (
 	ReviewSortRatingL ReviewSortOption = "rating_low"
 )
 
+// Review page sizes for GetReviews
+const (
+	DefaultReviewLimit = 10
+	MaxReviewLimit     = 100
+)
+
+// ErrInvalidReviewSort is returned for a review sort option that is not supported
+var ErrInvalidReviewSort = errors.New("sort must be one of: recent, helpful, rating_high, rating_low")
+
+// Valid reports whether the review sort option is supported
+func (o ReviewSortOption) Valid() bool {
+	switch o {
+	case ReviewSortRecent, ReviewSortHelpful, ReviewSortRatingH, ReviewSortRatingL:
+		return true
+	}
+	return false
+}
+
 // ReviewReportReason represents the reason for reporting a review
 type ReviewReportReason string
 
@@ -209,9 +291,11 @@ type SearchFilter struct {
 	SearchQuery string   `json:"search_query,omitempty"`
 	MinRating   *float64 `json:"min_rating,omitempty"`
 	IsVerified  *bool    `json:"is_verified,omitempty"`
-	SortBy      string   `json:"sort_by,omitempty"` // popular, recent, rating
-	Page        int      `json:"page,omitempty"`
-	Limit       int      `json:"limit,omitempty"`
+	// VerifiedOnly limits results to system and verified publishers
+	VerifiedOnly bool   `json:"verified_only,omitempty"`
+	SortBy       string `json:"sort_by,omitempty"` // popular, recent, rating
+	Page         int    `json:"page,omitempty"`
+	Limit        int    `json:"limit,omitempty"`
 }
 
 // InstallTemplateResult represents the result of template installation
@@ -219,6 +303,16 @@ type InstallTemplateResult struct {
 	WorkflowID   string          `json:"workflow_id"`
 	WorkflowName string          `json:"workflow_name"`
 	Definition   json.RawMessage `json:"definition"`
+	// WorkflowStatus is the status the workflow was created with, active or
+	// draft. It is empty when no workflow was created.
+	WorkflowStatus string `json:"workflow_status,omitempty"`
+	// UnmetPrerequisites lists credentials or environment variables the
+	// template needs that the tenant has not configured. Active installs
+	// create no workflow when it is set; draft installs create one anyway.
+	UnmetPrerequisites *UnmetPrerequisites `json:"unmet_prerequisites,omitempty"`
+	// MissingParameters lists required parameters a draft install was given
+	// no value for; their references are left in the definition
+	MissingParameters []string `json:"missing_parameters,omitempty"`
 }
 
 // Validate validates the publish template input
@@ -259,6 +353,29 @@ func (i PublishTemplateInput) Validate() error {
 	return nil
 }
 
+// Validate validates the publish version input
+func (i PublishVersionInput) Validate() error {
+	if i.Version == "" {
+		return errors.New("version is required")
+	}
+	if _, err := parseVersion(i.Version); err != nil {
+		return err
+	}
+	if len(i.Definition) == 0 {
+		return errors.New("definition is required")
+	}
+	if len(i.ChangeNotes) > 5000 {
+		return errors.New("change_notes must be 5000 characters or less")
+	}
+
+	var def map[string]interface{}
+	if err := json.Unmarshal(i.Definition, &def); err != nil {
+		return errors.New("definition must be valid JSON")
+	}
+
+	return nil
+}
+
 // Validate validates the rate template input
 func (i RateTemplateInput) Validate() error {
 	if i.Rating < 1 || i.Rating > 5 {
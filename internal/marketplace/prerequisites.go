@@ -0,0 +1,89 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+)
+
+var (
+	// credentialRefRegex matches ${credentials.name} and {{credentials.name}} references
+	credentialRefRegex = regexp.MustCompile(`(?:\$\{|\{\{)\s*credentials\.([a-zA-Z0-9_-]+)`)
+	// envRefRegex matches ${env.NAME} and {{env.NAME}} references
+	envRefRegex = regexp.MustCompile(`(?:\$\{|\{\{)\s*env\.([a-zA-Z0-9_]+)`)
+)
+
+// builtinEnvVars are supplied to every execution and never need configuring
+var builtinEnvVars = []string{"tenant_id", "workflow_id", "execution_id", "node_id"}
+
+// PrerequisiteChecker reports which credentials a tenant has not configured
+type PrerequisiteChecker interface {
+	MissingCredentials(ctx context.Context, tenantID string, names []string) ([]string, error)
+}
+
+// UnmetPrerequisites lists what a tenant must configure before a template can run
+type UnmetPrerequisites struct {
+	Credentials []string `json:"credentials,omitempty"`
+	EnvVars     []string `json:"env_vars,omitempty"`
+}
+
+// IsEmpty returns true if nothing is missing
+func (u *UnmetPrerequisites) IsEmpty() bool {
+	return u == nil || (len(u.Credentials) == 0 && len(u.EnvVars) == 0)
+}
+
+// ExtractRequirements returns the credential names and environment variables
+// referenced by a template definition, sorted and without duplicates.
+// Built-in execution variables such as env.tenant_id are not included.
+func ExtractRequirements(definition json.RawMessage) (credentials, envVars []string) {
+	credentials = uniqueMatches(credentialRefRegex, string(definition))
+	envVars = slices.DeleteFunc(uniqueMatches(envRefRegex, string(definition)), func(name string) bool {
+		return slices.Contains(builtinEnvVars, name)
+	})
+	return credentials, envVars
+}
+
+// uniqueMatches returns the sorted, de-duplicated first capture group of every match
+func uniqueMatches(re *regexp.Regexp, s string) []string {
+	matches := []string{}
+	for _, match := range re.FindAllStringSubmatch(s, -1) {
+		matches = append(matches, match[1])
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
+
+// SetPrerequisiteChecker sets the checker used to verify a tenant's credentials
+// at install time. Without one, credential requirements are not checked.
+func (s *Service) SetPrerequisiteChecker(checker PrerequisiteChecker) {
+	s.prerequisiteChecker = checker
+}
+
+// checkPrerequisites returns the template requirements the tenant has not met.
+// Environment variables beyond the built-ins cannot be supplied to an execution,
+// so any the template references are always reported.
+func (s *Service) checkPrerequisites(ctx context.Context, tenantID string, template *MarketplaceTemplate) (*UnmetPrerequisites, error) {
+	credentials, envVars := ExtractRequirements(template.Definition)
+	credentials = mergeSorted(credentials, template.RequiredCredentials)
+	envVars = mergeSorted(envVars, template.RequiredEnvVars)
+
+	unmet := &UnmetPrerequisites{EnvVars: envVars}
+
+	if s.prerequisiteChecker != nil && len(credentials) > 0 {
+		missing, err := s.prerequisiteChecker.MissingCredentials(ctx, tenantID, credentials)
+		if err != nil {
+			return nil, err
+		}
+		unmet.Credentials = missing
+	}
+
+	return unmet, nil
+}
+
+// mergeSorted merges two lists into a sorted list without duplicates
+func mergeSorted(a, b []string) []string {
+	merged := append(slices.Clone(a), b...)
+	slices.Sort(merged)
+	return slices.Compact(merged)
+}
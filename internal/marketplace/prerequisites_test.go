@@ -0,0 +1,126 @@
+package marketplace
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePrerequisiteChecker struct {
+	configured map[string]bool
+}
+
+func (c *fakePrerequisiteChecker) MissingCredentials(ctx context.Context, tenantID string, names []string) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		if !c.configured[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+const salesforceLeadSyncDefinition = `{
+	"nodes": [
+		{"id": "1", "type": "trigger", "data": {"nodeType": "schedule"}},
+		{"id": "2", "type": "action", "data": {"config": {
+			"url": "${env.SALESFORCE_INSTANCE_URL}/services/data/v58.0/query",
+			"headers": {"Authorization": "Bearer ${credentials.salesforce_token}"}
+		}}},
+		{"id": "3", "type": "action", "data": {"config": {
+			"token": "{{credentials.slack-bot}}",
+			"text": "Synced leads for ${env.tenant_id} (${credentials.salesforce_token})"
+		}}}
+	],
+	"edges": []
+}`
+
+func TestExtractRequirements(t *testing.T) {
+	credentials, envVars := ExtractRequirements(json.RawMessage(salesforceLeadSyncDefinition))
+
+	assert.Equal(t, []string{"salesforce_token", "slack-bot"}, credentials)
+	assert.Equal(t, []string{"SALESFORCE_INSTANCE_URL"}, envVars)
+}
+
+func TestExtractRequirements_None(t *testing.T) {
+	credentials, envVars := ExtractRequirements(json.RawMessage(`{"nodes":[],"edges":[]}`))
+
+	assert.Empty(t, credentials)
+	assert.Empty(t, envVars)
+}
+
+func TestInstallTemplate_UnmetPrerequisites(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	definition := json.RawMessage(salesforceLeadSyncDefinition)
+	template := &MarketplaceTemplate{ID: "template-1", Version: "1.0.0", Definition: definition}
+
+	t.Run("returns unmet prerequisites without installing", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+		service.SetPrerequisiteChecker(&fakePrerequisiteChecker{configured: map[string]bool{"slack-bot": true}})
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, assert.AnError)
+		repo.On("GetByID", ctx, "template-1").Return(template, nil)
+
+		result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{WorkflowName: "Lead Sync"})
+		require.NoError(t, err)
+		require.NotNil(t, result.UnmetPrerequisites)
+		assert.Empty(t, result.WorkflowID)
+		assert.Equal(t, []string{"salesforce_token"}, result.UnmetPrerequisites.Credentials)
+		assert.Equal(t, []string{"SALESFORCE_INSTANCE_URL"}, result.UnmetPrerequisites.EnvVars)
+		workflowService.AssertNotCalled(t, "CreateFromTemplate")
+		repo.AssertNotCalled(t, "CreateInstallation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("installs anyway when the check is skipped", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+		service.SetPrerequisiteChecker(&fakePrerequisiteChecker{})
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, assert.AnError)
+		repo.On("GetByID", ctx, "template-1").Return(template, nil)
+		workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "Lead Sync", definition, "active").Return("workflow-1", nil)
+		repo.On("IncrementDownloadCount", ctx, "template-1").Return(nil)
+		repo.On("CreateInstallation", ctx, mock.AnythingOfType("*marketplace.TemplateInstallation")).Return(nil)
+
+		result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{
+			WorkflowName:          "Lead Sync",
+			SkipPrerequisiteCheck: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "workflow-1", result.WorkflowID)
+		assert.Nil(t, result.UnmetPrerequisites)
+	})
+	t.Run("draft install proceeds and reports what is missing", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+		service.SetPrerequisiteChecker(&fakePrerequisiteChecker{configured: map[string]bool{"slack-bot": true}})
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, assert.AnError)
+		repo.On("GetByID", ctx, "template-1").Return(template, nil)
+		workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "Lead Sync", definition, "draft").Return("workflow-1", nil)
+		repo.On("IncrementDownloadCount", ctx, "template-1").Return(nil)
+		repo.On("CreateInstallation", ctx, mock.AnythingOfType("*marketplace.TemplateInstallation")).Return(nil)
+
+		result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{
+			WorkflowName: "Lead Sync",
+			InstallMode:  InstallModeDraft,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "workflow-1", result.WorkflowID)
+		assert.Equal(t, "draft", result.WorkflowStatus)
+		require.NotNil(t, result.UnmetPrerequisites)
+		assert.Equal(t, []string{"salesforce_token"}, result.UnmetPrerequisites.Credentials)
+		workflowService.AssertExpectations(t)
+	})
+}
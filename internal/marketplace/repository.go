@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -16,18 +17,25 @@ type Repository interface {
 	Publish(ctx context.Context, template *MarketplaceTemplate) error
 	GetByID(ctx context.Context, id string) (*MarketplaceTemplate, error)
 	Search(ctx context.Context, filter SearchFilter) ([]*MarketplaceTemplate, error)
-	GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemplate, error)
-	GetTrending(ctx context.Context, days, limit int) ([]*MarketplaceTemplate, error)
+	GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error)
+	GetTrending(ctx context.Context, days, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error)
 	GetByAuthor(ctx context.Context, authorID string) ([]*MarketplaceTemplate, error)
 	Update(ctx context.Context, id string, input UpdateTemplateInput) error
+	SetVerified(ctx context.Context, id string, verified bool) (*MarketplaceTemplate, error)
+	PublishVersion(ctx context.Context, version *TemplateVersion, requiredCredentials, requiredEnvVars []string) error
+	GetVersions(ctx context.Context, templateID string) ([]*TemplateVersion, error)
+	GetVersion(ctx context.Context, templateID, version string) (*TemplateVersion, error)
 	IncrementDownloadCount(ctx context.Context, templateID string) error
 	CreateInstallation(ctx context.Context, installation *TemplateInstallation) error
 	GetInstallation(ctx context.Context, tenantID, templateID string) (*TemplateInstallation, error)
+	GetInstallationsWithLatestVersion(ctx context.Context, tenantID string) ([]*InstalledTemplateUpdate, error)
 	CreateReview(ctx context.Context, review *TemplateReview) error
 	UpdateReview(ctx context.Context, tenantID, reviewID string, rating int, comment string) error
 	DeleteReview(ctx context.Context, tenantID, reviewID string) error
 	GetReviews(ctx context.Context, templateID string, sortBy ReviewSortOption, limit, offset int) ([]*TemplateReview, error)
-	GetUserReview(ctx context.Context, tenantID, templateID string) (*TemplateReview, error)
+	CountReviews(ctx context.Context, templateID string) (int, error)
+	GetUserReview(ctx context.Context, tenantID, userID, templateID string) (*TemplateReview, error)
+	CountUserReviewsSince(ctx context.Context, tenantID, userID string, since time.Time) (int, error)
 	UpdateTemplateRating(ctx context.Context, templateID string) error
 	VoteReviewHelpful(ctx context.Context, vote *ReviewHelpfulVote) error
 	UnvoteReviewHelpful(ctx context.Context, tenantID, userID, reviewID string) error
@@ -52,12 +60,20 @@ func NewRepository(db *sqlx.DB) Repository {
 
 // Publish publishes a new marketplace template
 func (r *PostgresRepository) Publish(ctx context.Context, template *MarketplaceTemplate) error {
+	// The initial version is recorded in the version history alongside the template
 	query := `
-		INSERT INTO marketplace_templates (
-			name, description, category, definition, tags,
-			author_id, author_name, version, source_tenant_id, source_template_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, published_at, updated_at
+		WITH inserted AS (
+			INSERT INTO marketplace_templates (
+				name, description, category, definition, tags,
+				author_id, author_name, version, source_tenant_id, source_template_id,
+				required_credentials, required_env_vars, is_verified, publisher_type
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id, definition, version, author_id, published_at, updated_at
+		), initial_version AS (
+			INSERT INTO marketplace_template_versions (template_id, version, definition, created_by)
+			SELECT id, version, definition, author_id FROM inserted
+		)
+		SELECT id, published_at, updated_at FROM inserted
 	`
 
 	err := r.db.QueryRowContext(
@@ -72,6 +88,10 @@ func (r *PostgresRepository) Publish(ctx context.Context, template *MarketplaceT
 		template.Version,
 		template.SourceTenantID,
 		template.SourceTemplateID,
+		pq.Array(template.RequiredCredentials),
+		pq.Array(template.RequiredEnvVars),
+		template.IsVerified,
+		template.PublisherType,
 	).Scan(&template.ID, &template.PublishedAt, &template.UpdatedAt)
 
 	if err != nil {
@@ -88,10 +108,10 @@ func (r *PostgresRepository) Publish(ctx context.Context, template *MarketplaceT
 func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*MarketplaceTemplate, error) {
 	query := `
 		SELECT id, name, description, category, definition, tags,
-			   author_id, author_name, version, download_count,
+			   author_id, author_name, version, required_credentials, required_env_vars, download_count,
 			   average_rating, total_ratings,
 			   rating_1_count, rating_2_count, rating_3_count, rating_4_count, rating_5_count,
-			   is_verified,
+			   is_verified, publisher_type,
 			   source_tenant_id, source_template_id, published_at, updated_at
 		FROM marketplace_templates
 		WHERE id = $1
@@ -113,10 +133,10 @@ func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*Marketpla
 func (r *PostgresRepository) Search(ctx context.Context, filter SearchFilter) ([]*MarketplaceTemplate, error) {
 	query := `
 		SELECT id, name, description, category, definition, tags,
-			   author_id, author_name, version, download_count,
+			   author_id, author_name, version, required_credentials, required_env_vars, download_count,
 			   average_rating, total_ratings,
 			   rating_1_count, rating_2_count, rating_3_count, rating_4_count, rating_5_count,
-			   is_verified,
+			   is_verified, publisher_type,
 			   source_tenant_id, source_template_id, published_at, updated_at
 		FROM marketplace_templates
 		WHERE 1=1
@@ -156,6 +176,10 @@ func (r *PostgresRepository) Search(ctx context.Context, filter SearchFilter) ([
 		args = append(args, *filter.IsVerified)
 	}
 
+	if filter.VerifiedOnly {
+		query += trustedPublisherClause("")
+	}
+
 	query += r.buildOrderBy(filter.SortBy)
 
 	if filter.Limit > 0 {
@@ -181,16 +205,19 @@ func (r *PostgresRepository) Search(ctx context.Context, filter SearchFilter) ([
 }
 
 // GetPopular retrieves the most popular templates
-func (r *PostgresRepository) GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemplate, error) {
+func (r *PostgresRepository) GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	query := `
 		SELECT id, name, description, category, definition, tags,
-			   author_id, author_name, version, download_count,
-			   average_rating, total_ratings, is_verified,
+			   author_id, author_name, version, required_credentials, required_env_vars, download_count,
+			   average_rating, total_ratings, is_verified, publisher_type,
 			   source_tenant_id, source_template_id, published_at, updated_at
 		FROM marketplace_templates
-		ORDER BY download_count DESC, average_rating DESC
-		LIMIT $1
+		WHERE 1=1
 	`
+	if verifiedOnly {
+		query += trustedPublisherClause("")
+	}
+	query += " ORDER BY download_count DESC, average_rating DESC LIMIT $1"
 
 	var templates []*MarketplaceTemplate
 	err := r.db.SelectContext(ctx, &templates, query, limit)
@@ -202,19 +229,25 @@ func (r *PostgresRepository) GetPopular(ctx context.Context, limit int) ([]*Mark
 }
 
 // GetTrending retrieves trending templates
-func (r *PostgresRepository) GetTrending(ctx context.Context, days, limit int) ([]*MarketplaceTemplate, error) {
+func (r *PostgresRepository) GetTrending(ctx context.Context, days, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	query := `
 		SELECT mt.id, mt.name, mt.description, mt.category, mt.definition, mt.tags,
-			   mt.author_id, mt.author_name, mt.version, mt.download_count,
-			   mt.average_rating, mt.total_ratings, mt.is_verified,
+			   mt.author_id, mt.author_name, mt.version, mt.required_credentials, mt.required_env_vars, mt.download_count,
+			   mt.average_rating, mt.total_ratings, mt.is_verified, mt.publisher_type,
 			   mt.source_tenant_id, mt.source_template_id, mt.published_at, mt.updated_at,
 			   COUNT(mi.id) as recent_installs
 		FROM marketplace_templates mt
 		LEFT JOIN marketplace_installations mi ON mt.id = mi.template_id
 			AND mi.installed_at >= NOW() - INTERVAL '1 day' * $1
+		WHERE 1=1
+	`
+	if verifiedOnly {
+		query += trustedPublisherClause("mt.")
+	}
+	query += `
 		GROUP BY mt.id, mt.name, mt.description, mt.category, mt.definition, mt.tags,
-				 mt.author_id, mt.author_name, mt.version, mt.download_count,
-				 mt.average_rating, mt.total_ratings, mt.is_verified,
+				 mt.author_id, mt.author_name, mt.version, mt.required_credentials, mt.required_env_vars, mt.download_count,
+				 mt.average_rating, mt.total_ratings, mt.is_verified, mt.publisher_type,
 				 mt.source_tenant_id, mt.source_template_id, mt.published_at, mt.updated_at
 		ORDER BY recent_installs DESC, mt.average_rating DESC
 		LIMIT $2
@@ -233,8 +266,8 @@ func (r *PostgresRepository) GetTrending(ctx context.Context, days, limit int) (
 func (r *PostgresRepository) GetByAuthor(ctx context.Context, authorID string) ([]*MarketplaceTemplate, error) {
 	query := `
 		SELECT id, name, description, category, definition, tags,
-			   author_id, author_name, version, download_count,
-			   average_rating, total_ratings, is_verified,
+			   author_id, author_name, version, required_credentials, required_env_vars, download_count,
+			   average_rating, total_ratings, is_verified, publisher_type,
 			   source_tenant_id, source_template_id, published_at, updated_at
 		FROM marketplace_templates
 		WHERE author_id = $1
@@ -322,6 +355,155 @@ func (r *PostgresRepository) Update(ctx context.Context, id string, input Update
 	return nil
 }
 
+// SetVerified marks a template's publisher as verified or community.
+// System templates keep their publisher type and stay verified.
+func (r *PostgresRepository) SetVerified(ctx context.Context, id string, verified bool) (*MarketplaceTemplate, error) {
+	query := `
+		UPDATE marketplace_templates
+		SET is_verified = $2 OR publisher_type = 'system',
+			publisher_type = CASE
+				WHEN publisher_type = 'system' THEN 'system'
+				WHEN $2 THEN 'verified'
+				ELSE 'community'
+			END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, description, category, definition, tags,
+			   author_id, author_name, version, required_credentials, required_env_vars, download_count,
+			   average_rating, total_ratings,
+			   rating_1_count, rating_2_count, rating_3_count, rating_4_count, rating_5_count,
+			   is_verified, publisher_type,
+			   source_tenant_id, source_template_id, published_at, updated_at
+	`
+
+	var template MarketplaceTemplate
+	err := r.db.GetContext(ctx, &template, query, id, verified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("set template verified: %w", err)
+	}
+
+	return &template, nil
+}
+
+// PublishVersion records a new template version and makes it the template's current
+// version, replacing the template's definition and prerequisite manifest.
+// The current version is first copied into the history if it is not there yet,
+// so templates published before version history was kept lose nothing.
+func (r *PostgresRepository) PublishVersion(ctx context.Context, version *TemplateVersion, requiredCredentials, requiredEnvVars []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	snapshotQuery := `
+		INSERT INTO marketplace_template_versions (template_id, version, definition, created_by, created_at)
+		SELECT id, version, definition, author_id, updated_at
+		FROM marketplace_templates
+		WHERE id = $1
+		ON CONFLICT (template_id, version) DO NOTHING
+	`
+	if _, err = tx.ExecContext(ctx, snapshotQuery, version.TemplateID); err != nil {
+		return fmt.Errorf("snapshot current version: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO marketplace_template_versions (template_id, version, definition, change_notes, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowContext(
+		ctx, insertQuery,
+		version.TemplateID,
+		version.Version,
+		version.Definition,
+		version.ChangeNotes,
+		version.CreatedBy,
+	).Scan(&version.ID, &version.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("version %s already exists", version.Version)
+		}
+		return fmt.Errorf("insert version: %w", err)
+	}
+
+	updateQuery := `
+		UPDATE marketplace_templates
+		SET version = $2, definition = $3,
+		    required_credentials = $4, required_env_vars = $5,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	var result sql.Result
+	result, err = tx.ExecContext(ctx, updateQuery, version.TemplateID, version.Version, version.Definition,
+		pq.Array(requiredCredentials), pq.Array(requiredEnvVars))
+	if err != nil {
+		return fmt.Errorf("update template version: %w", err)
+	}
+
+	var rowsAffected int64
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		err = fmt.Errorf("template not found")
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersions retrieves the version history of a template, newest first
+func (r *PostgresRepository) GetVersions(ctx context.Context, templateID string) ([]*TemplateVersion, error) {
+	query := `
+		SELECT id, template_id, version, definition,
+			   COALESCE(change_notes, '') AS change_notes, created_by, created_at
+		FROM marketplace_template_versions
+		WHERE template_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var versions []*TemplateVersion
+	if err := r.db.SelectContext(ctx, &versions, query, templateID); err != nil {
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetVersion retrieves a single version of a template
+func (r *PostgresRepository) GetVersion(ctx context.Context, templateID, version string) (*TemplateVersion, error) {
+	query := `
+		SELECT id, template_id, version, definition,
+			   COALESCE(change_notes, '') AS change_notes, created_by, created_at
+		FROM marketplace_template_versions
+		WHERE template_id = $1 AND version = $2
+	`
+
+	var templateVersion TemplateVersion
+	err := r.db.GetContext(ctx, &templateVersion, query, templateID, version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("version not found")
+		}
+		return nil, fmt.Errorf("get version: %w", err)
+	}
+
+	return &templateVersion, nil
+}
+
 // IncrementDownloadCount increments the download count for a template
 func (r *PostgresRepository) IncrementDownloadCount(ctx context.Context, templateID string) error {
 	query := `
@@ -386,79 +568,99 @@ func (r *PostgresRepository) GetInstallation(ctx context.Context, tenantID, temp
 	return &installation, nil
 }
 
-// CreateReview creates a new template review
-func (r *PostgresRepository) CreateReview(ctx context.Context, review *TemplateReview) error {
+// GetInstallationsWithLatestVersion retrieves a tenant's installations along with
+// the current upstream version of each installed template
+func (r *PostgresRepository) GetInstallationsWithLatestVersion(ctx context.Context, tenantID string) ([]*InstalledTemplateUpdate, error) {
 	query := `
-		INSERT INTO marketplace_reviews (
-			template_id, tenant_id, user_id, user_name, rating, comment
-		) VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at
+		SELECT mi.id, mi.template_id, mi.tenant_id, mi.user_id, mi.workflow_id,
+			   mi.installed_version, mi.installed_at,
+			   mt.name AS template_name, mt.version AS latest_version
+		FROM marketplace_installations mi
+		JOIN marketplace_templates mt ON mt.id = mi.template_id
+		WHERE mi.tenant_id = $1
+		ORDER BY mi.installed_at DESC
 	`
 
-	err := r.db.QueryRowContext(
-		ctx, query,
-		review.TemplateID,
-		review.TenantID,
-		review.UserID,
-		review.UserName,
-		review.Rating,
-		review.Comment,
-	).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+	var installations []*InstalledTemplateUpdate
+	if err := r.db.SelectContext(ctx, &installations, query, tenantID); err != nil {
+		return nil, fmt.Errorf("get installations: %w", err)
+	}
 
-	if err != nil {
-		if isUniqueViolation(err) {
-			return fmt.Errorf("you have already reviewed this template")
+	return installations, nil
+}
+
+// CreateReview creates a review and refreshes the template's rating aggregates
+// in the same transaction
+func (r *PostgresRepository) CreateReview(ctx context.Context, review *TemplateReview) error {
+	return r.inRatingTx(ctx, review.TemplateID, func(tx *sqlx.Tx) error {
+		query := `
+			INSERT INTO marketplace_reviews (
+				template_id, tenant_id, user_id, user_name, rating, comment
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at, updated_at
+		`
+
+		err := tx.QueryRowContext(
+			ctx, query,
+			review.TemplateID,
+			review.TenantID,
+			review.UserID,
+			review.UserName,
+			review.Rating,
+			review.Comment,
+		).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+
+		if err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("you have already reviewed this template")
+			}
+			return fmt.Errorf("create review: %w", err)
 		}
-		return fmt.Errorf("create review: %w", err)
-	}
 
-	return nil
+		return nil
+	})
 }
 
-// UpdateReview updates an existing review
+// UpdateReview updates an existing review and refreshes the template's rating aggregates
 func (r *PostgresRepository) UpdateReview(ctx context.Context, tenantID, reviewID string, rating int, comment string) error {
-	query := `
-		UPDATE marketplace_reviews
-		SET rating = $1, comment = $2, updated_at = NOW()
-		WHERE id = $3 AND tenant_id = $4
-	`
-
-	result, err := r.db.ExecContext(ctx, query, rating, comment, reviewID, tenantID)
+	templateID, err := r.reviewTemplateID(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("update review: %w", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("get rows affected: %w", err)
-	}
+	return r.inRatingTx(ctx, templateID, func(tx *sqlx.Tx) error {
+		query := `
+			UPDATE marketplace_reviews
+			SET rating = $1, comment = $2, updated_at = NOW()
+			WHERE id = $3 AND tenant_id = $4
+		`
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("review not found")
-	}
+		result, err := tx.ExecContext(ctx, query, rating, comment, reviewID, tenantID)
+		if err != nil {
+			return fmt.Errorf("update review: %w", err)
+		}
 
-	return nil
+		return requireRowsAffected(result, "review not found")
+	})
 }
 
-// DeleteReview deletes a review
+// DeleteReview deletes a review and refreshes the template's rating aggregates
 func (r *PostgresRepository) DeleteReview(ctx context.Context, tenantID, reviewID string) error {
-	query := `DELETE FROM marketplace_reviews WHERE id = $1 AND tenant_id = $2`
-
-	result, err := r.db.ExecContext(ctx, query, reviewID, tenantID)
+	templateID, err := r.reviewTemplateID(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("delete review: %w", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("get rows affected: %w", err)
-	}
+	return r.inRatingTx(ctx, templateID, func(tx *sqlx.Tx) error {
+		query := `DELETE FROM marketplace_reviews WHERE id = $1 AND tenant_id = $2`
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("review not found")
-	}
+		result, err := tx.ExecContext(ctx, query, reviewID, tenantID)
+		if err != nil {
+			return fmt.Errorf("delete review: %w", err)
+		}
 
-	return nil
+		return requireRowsAffected(result, "review not found")
+	})
 }
 
 // GetReviews retrieves reviews for a template
@@ -487,33 +689,53 @@ func (r *PostgresRepository) GetReviews(ctx context.Context, templateID string,
 	return reviews, nil
 }
 
-// buildReviewOrderClause builds the ORDER BY clause for review queries
+// CountReviews counts the reviews GetReviews can return for a template
+func (r *PostgresRepository) CountReviews(ctx context.Context, templateID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM marketplace_reviews
+		WHERE template_id = $1
+		  AND deleted_at IS NULL
+		  AND is_hidden = false
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, templateID); err != nil {
+		return 0, fmt.Errorf("count reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+// buildReviewOrderClause builds the ORDER BY clause for review queries. The
+// id tiebreaker keeps pages stable when reviews share a sort key.
+// helpful_count is kept up to date by the review_helpful_votes trigger.
 func buildReviewOrderClause(sortBy ReviewSortOption) string {
 	switch sortBy {
 	case ReviewSortHelpful:
-		return "ORDER BY helpful_count DESC, created_at DESC"
+		return "ORDER BY helpful_count DESC NULLS LAST, created_at DESC, id DESC"
 	case ReviewSortRatingH:
-		return "ORDER BY rating DESC, created_at DESC"
+		return "ORDER BY rating DESC, created_at DESC, id DESC"
 	case ReviewSortRatingL:
-		return "ORDER BY rating ASC, created_at DESC"
+		return "ORDER BY rating ASC, created_at DESC, id DESC"
 	case ReviewSortRecent:
 		fallthrough
 	default:
-		return "ORDER BY created_at DESC"
+		return "ORDER BY created_at DESC, id DESC"
 	}
 }
 
 // GetUserReview retrieves a user's review for a template
-func (r *PostgresRepository) GetUserReview(ctx context.Context, tenantID, templateID string) (*TemplateReview, error) {
+func (r *PostgresRepository) GetUserReview(ctx context.Context, tenantID, userID, templateID string) (*TemplateReview, error) {
 	query := `
 		SELECT id, template_id, tenant_id, user_id, user_name,
 			   rating, comment, created_at, updated_at
 		FROM marketplace_reviews
-		WHERE tenant_id = $1 AND template_id = $2
+		WHERE tenant_id = $1 AND user_id = $2 AND template_id = $3
 	`
 
 	var review TemplateReview
-	err := r.db.GetContext(ctx, &review, query, tenantID, templateID)
+	err := r.db.GetContext(ctx, &review, query, tenantID, userID, templateID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("review not found")
@@ -524,32 +746,107 @@ func (r *PostgresRepository) GetUserReview(ctx context.Context, tenantID, templa
 	return &review, nil
 }
 
-// UpdateTemplateRating recalculates and updates the template's average rating
-func (r *PostgresRepository) UpdateTemplateRating(ctx context.Context, templateID string) error {
+// CountUserReviewsSince counts the reviews a user has created since the given time
+func (r *PostgresRepository) CountUserReviewsSince(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
 	query := `
-		UPDATE marketplace_templates
-		SET
-			average_rating = (
-				SELECT COALESCE(AVG(rating), 0)
-				FROM marketplace_reviews
-				WHERE template_id = $1
-			),
-			total_ratings = (
-				SELECT COUNT(*)
-				FROM marketplace_reviews
-				WHERE template_id = $1
-			)
-		WHERE id = $1
+		SELECT COUNT(*)
+		FROM marketplace_reviews
+		WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $3
 	`
 
-	_, err := r.db.ExecContext(ctx, query, templateID)
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, tenantID, userID, since); err != nil {
+		return 0, fmt.Errorf("count user reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateTemplateRating recalculates the template's rating aggregates from its reviews
+func (r *PostgresRepository) UpdateTemplateRating(ctx context.Context, templateID string) error {
+	return r.inRatingTx(ctx, templateID, func(tx *sqlx.Tx) error {
+		return nil
+	})
+}
+
+// inRatingTx runs fn in a transaction that holds a lock on the template row and
+// recalculates the template's rating aggregates before committing, so the
+// rating distribution always matches the reviews
+func (r *PostgresRepository) inRatingTx(ctx context.Context, templateID string, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// Lock the template so concurrent review changes recalculate one at a time
+	var lockedID string
+	err = tx.GetContext(ctx, &lockedID, `SELECT id FROM marketplace_templates WHERE id = $1 FOR UPDATE`, templateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("template not found")
+		}
+		return fmt.Errorf("lock template: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	// Hidden and deleted reviews do not count towards the rating
+	recalculateQuery := `
+		UPDATE marketplace_templates mt
+		SET average_rating = stats.average_rating,
+			total_ratings = stats.total_ratings,
+			rating_1_count = stats.rating_1_count,
+			rating_2_count = stats.rating_2_count,
+			rating_3_count = stats.rating_3_count,
+			rating_4_count = stats.rating_4_count,
+			rating_5_count = stats.rating_5_count
+		FROM (
+			SELECT COALESCE(AVG(rating), 0) AS average_rating,
+				   COUNT(*) AS total_ratings,
+				   COUNT(*) FILTER (WHERE rating = 1) AS rating_1_count,
+				   COUNT(*) FILTER (WHERE rating = 2) AS rating_2_count,
+				   COUNT(*) FILTER (WHERE rating = 3) AS rating_3_count,
+				   COUNT(*) FILTER (WHERE rating = 4) AS rating_4_count,
+				   COUNT(*) FILTER (WHERE rating = 5) AS rating_5_count
+			FROM marketplace_reviews
+			WHERE template_id = $1
+			  AND deleted_at IS NULL
+			  AND is_hidden = false
+		) stats
+		WHERE mt.id = $1
+	`
+	if _, err = tx.ExecContext(ctx, recalculateQuery, templateID); err != nil {
 		return fmt.Errorf("update template rating: %w", err)
 	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+// reviewTemplateID returns the template a review belongs to
+func (r *PostgresRepository) reviewTemplateID(ctx context.Context, reviewID string) (string, error) {
+	var templateID string
+	err := r.db.GetContext(ctx, &templateID, `SELECT template_id FROM marketplace_reviews WHERE id = $1`, reviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("review not found")
+		}
+		return "", fmt.Errorf("get review template: %w", err)
+	}
+
+	return templateID, nil
+}
+
 // VoteReviewHelpful records a helpful vote for a review
 func (r *PostgresRepository) VoteReviewHelpful(ctx context.Context, vote *ReviewHelpfulVote) error {
 	query := `
@@ -680,54 +977,50 @@ func (r *PostgresRepository) UpdateReviewReportStatus(ctx context.Context, repor
 	return nil
 }
 
-// HideReview hides a review (moderation)
+// HideReview hides a review (moderation) and refreshes the template's rating aggregates
 func (r *PostgresRepository) HideReview(ctx context.Context, reviewID, reason, hiddenBy string) error {
-	query := `
-		UPDATE marketplace_reviews
-		SET is_hidden = true, hidden_reason = $1, hidden_at = NOW(), hidden_by = $2
-		WHERE id = $3
-	`
-
-	result, err := r.db.ExecContext(ctx, query, reason, hiddenBy, reviewID)
+	templateID, err := r.reviewTemplateID(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("hide review: %w", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("get rows affected: %w", err)
-	}
+	return r.inRatingTx(ctx, templateID, func(tx *sqlx.Tx) error {
+		query := `
+			UPDATE marketplace_reviews
+			SET is_hidden = true, hidden_reason = $1, hidden_at = NOW(), hidden_by = $2
+			WHERE id = $3
+		`
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("review not found")
-	}
+		result, err := tx.ExecContext(ctx, query, reason, hiddenBy, reviewID)
+		if err != nil {
+			return fmt.Errorf("hide review: %w", err)
+		}
 
-	return nil
+		return requireRowsAffected(result, "review not found")
+	})
 }
 
-// UnhideReview unhides a review
+// UnhideReview unhides a review and refreshes the template's rating aggregates
 func (r *PostgresRepository) UnhideReview(ctx context.Context, reviewID string) error {
-	query := `
-		UPDATE marketplace_reviews
-		SET is_hidden = false, hidden_reason = NULL, hidden_at = NULL, hidden_by = NULL
-		WHERE id = $1
-	`
-
-	result, err := r.db.ExecContext(ctx, query, reviewID)
+	templateID, err := r.reviewTemplateID(ctx, reviewID)
 	if err != nil {
-		return fmt.Errorf("unhide review: %w", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("get rows affected: %w", err)
-	}
+	return r.inRatingTx(ctx, templateID, func(tx *sqlx.Tx) error {
+		query := `
+			UPDATE marketplace_reviews
+			SET is_hidden = false, hidden_reason = NULL, hidden_at = NULL, hidden_by = NULL
+			WHERE id = $1
+		`
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("review not found")
-	}
+		result, err := tx.ExecContext(ctx, query, reviewID)
+		if err != nil {
+			return fmt.Errorf("unhide review: %w", err)
+		}
 
-	return nil
+		return requireRowsAffected(result, "review not found")
+	})
 }
 
 // GetRatingDistribution retrieves the rating distribution for a template
@@ -781,6 +1074,24 @@ func (r *PostgresRepository) buildOrderBy(sortBy string) string {
 	}
 }
 
+// trustedPublisherClause restricts a query to system and verified publishers.
+// prefix is the table alias, including the trailing dot, or empty.
+func trustedPublisherClause(prefix string) string {
+	return fmt.Sprintf(" AND %spublisher_type IN ('%s', '%s')", prefix, PublisherTypeSystem, PublisherTypeVerified)
+}
+
+// requireRowsAffected returns an error with the given message when no rows were affected
+func requireRowsAffected(result sql.Result, notFoundMessage string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New(notFoundMessage)
+	}
+	return nil
+}
+
 func isUniqueViolation(err error) bool {
 	if pqErr, ok := err.(*pq.Error); ok {
 		return pqErr.Code == "23505"
@@ -3,8 +3,11 @@ package marketplace
 import (
 	"context"
 	"encoding/json"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -126,7 +129,7 @@ func TestGetPopular(t *testing.T) {
 	repo := NewRepository(db)
 	ctx := context.Background()
 
-	templates, err := repo.GetPopular(ctx, 10)
+	templates, err := repo.GetPopular(ctx, 10, false)
 	require.NoError(t, err)
 	assert.NotNil(t, templates)
 	assert.LessOrEqual(t, len(templates), 10)
@@ -139,7 +142,7 @@ func TestGetTrending(t *testing.T) {
 
 	// Get trending in last 7 days
 	days := 7
-	templates, err := repo.GetTrending(ctx, days, 10)
+	templates, err := repo.GetTrending(ctx, days, 10, false)
 	require.NoError(t, err)
 	assert.NotNil(t, templates)
 	assert.LessOrEqual(t, len(templates), 10)
@@ -243,13 +246,69 @@ func TestGetReviews(t *testing.T) {
 	assert.NotNil(t, reviews)
 }
 
+func TestGetReviews_Ordering(t *testing.T) {
+	columns := []string{
+		"id", "template_id", "tenant_id", "user_id", "user_name",
+		"rating", "comment", "helpful_count", "is_hidden",
+		"hidden_reason", "hidden_at", "hidden_by", "deleted_at",
+		"created_at", "updated_at",
+	}
+
+	tests := []struct {
+		sortBy ReviewSortOption
+		order  string
+	}{
+		{ReviewSortRecent, "ORDER BY created_at DESC, id DESC"},
+		{ReviewSortHelpful, "ORDER BY helpful_count DESC NULLS LAST, created_at DESC, id DESC"},
+		{ReviewSortRatingH, "ORDER BY rating DESC, created_at DESC, id DESC"},
+		{ReviewSortRatingL, "ORDER BY rating ASC, created_at DESC, id DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sortBy), func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+			now := time.Now()
+			mock.ExpectQuery(regexp.QuoteMeta(tt.order)+`\s+LIMIT \$2 OFFSET \$3`).
+				WithArgs("template-1", 20, 40).
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow("review-1", "template-1", "tenant-1", "user-1", "Alice", 5, "", 3, false, nil, nil, nil, nil, now, now))
+
+			reviews, err := repo.GetReviews(context.Background(), "template-1", tt.sortBy, 20, 40)
+			require.NoError(t, err)
+			require.Len(t, reviews, 1)
+			assert.Equal(t, 3, reviews[0].HelpfulCount)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCountReviews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewRepository(sqlx.NewDb(db, "sqlmock"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)") + `[\s\S]*deleted_at IS NULL[\s\S]*is_hidden = false`).
+		WithArgs("template-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	total, err := repo.CountReviews(context.Background(), "template-1")
+	require.NoError(t, err)
+	assert.Equal(t, 42, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetUserReview(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewRepository(db)
 	ctx := context.Background()
 
 	// Test getting review for non-existent user
-	_, err := repo.GetUserReview(ctx, "tenant-1", "template-1")
+	_, err := repo.GetUserReview(ctx, "tenant-1", "user-1", "template-1")
 	assert.Error(t, err)
 }
 
@@ -6,18 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gorax/gorax/internal/template"
 )
 
 // WorkflowService defines the interface for workflow operations
 type WorkflowService interface {
-	CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage) (string, error)
+	// CreateFromTemplate creates a workflow with the given status, active or
+	// draft, and returns its ID
+	CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage, status string) (string, error)
 }
 
+// MaxNewReviewsPerDay is how many new reviews a user may post in 24 hours
+const MaxNewReviewsPerDay = 10
+
 // Service handles marketplace business logic
 type Service struct {
-	repo            Repository
-	workflowService WorkflowService
-	logger          *slog.Logger
+	repo                Repository
+	workflowService     WorkflowService
+	prerequisiteChecker PrerequisiteChecker
+	logger              *slog.Logger
 }
 
 // NewService creates a new marketplace service
@@ -39,20 +49,31 @@ func (s *Service) PublishTemplate(ctx context.Context, userID, userName string,
 		return nil, fmt.Errorf("invalid definition: %w", err)
 	}
 
+	requiredCredentials, requiredEnvVars := ExtractRequirements(input.Definition)
+
+	// Built-in templates are published by the system author and are trusted as-is
+	publisherType := PublisherTypeCommunity
+	if userID == SystemAuthorID {
+		publisherType = PublisherTypeSystem
+	}
+
 	template := &MarketplaceTemplate{
-		Name:             input.Name,
-		Description:      input.Description,
-		Category:         input.Category,
-		Definition:       input.Definition,
-		Tags:             input.Tags,
-		AuthorID:         userID,
-		AuthorName:       userName,
-		Version:          input.Version,
-		SourceTemplateID: input.SourceTemplateID,
-		IsVerified:       false,
-		DownloadCount:    0,
-		AverageRating:    0,
-		TotalRatings:     0,
+		Name:                input.Name,
+		Description:         input.Description,
+		Category:            input.Category,
+		Definition:          input.Definition,
+		Tags:                input.Tags,
+		AuthorID:            userID,
+		AuthorName:          userName,
+		Version:             input.Version,
+		RequiredCredentials: requiredCredentials,
+		RequiredEnvVars:     requiredEnvVars,
+		SourceTemplateID:    input.SourceTemplateID,
+		IsVerified:          publisherType == PublisherTypeSystem,
+		PublisherType:       publisherType,
+		DownloadCount:       0,
+		AverageRating:       0,
+		TotalRatings:        0,
 	}
 
 	if err := s.repo.Publish(ctx, template); err != nil {
@@ -105,13 +126,13 @@ func (s *Service) SearchTemplates(ctx context.Context, filter SearchFilter) ([]*
 	return templates, nil
 }
 
-// GetTrending retrieves trending templates
-func (s *Service) GetTrending(ctx context.Context, limit int) ([]*MarketplaceTemplate, error) {
+// GetTrending retrieves trending templates, optionally only from system and verified publishers
+func (s *Service) GetTrending(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	templates, err := s.repo.GetTrending(ctx, 7, limit)
+	templates, err := s.repo.GetTrending(ctx, 7, limit, verifiedOnly)
 	if err != nil {
 		s.logger.Error("failed to get trending templates", "error", err)
 		return nil, fmt.Errorf("get trending: %w", err)
@@ -120,13 +141,13 @@ func (s *Service) GetTrending(ctx context.Context, limit int) ([]*MarketplaceTem
 	return templates, nil
 }
 
-// GetPopular retrieves popular templates
-func (s *Service) GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemplate, error) {
+// GetPopular retrieves popular templates, optionally only from system and verified publishers
+func (s *Service) GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
-	templates, err := s.repo.GetPopular(ctx, limit)
+	templates, err := s.repo.GetPopular(ctx, limit, verifiedOnly)
 	if err != nil {
 		s.logger.Error("failed to get popular templates", "error", err)
 		return nil, fmt.Errorf("get popular: %w", err)
@@ -135,6 +156,34 @@ func (s *Service) GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemp
 	return templates, nil
 }
 
+// SetVerified marks a template's publisher as verified or unverified (admin only).
+// System templates are always verified and cannot be changed.
+func (s *Service) SetVerified(ctx context.Context, templateID string, verified bool, adminID string) (*MarketplaceTemplate, error) {
+	template, err := s.repo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+
+	if template.PublisherType == PublisherTypeSystem {
+		return nil, errors.New("invalid request: system templates are always verified")
+	}
+
+	updated, err := s.repo.SetVerified(ctx, templateID, verified)
+	if err != nil {
+		s.logger.Error("failed to set template verification",
+			"error", err,
+			"template_id", templateID)
+		return nil, fmt.Errorf("set verified: %w", err)
+	}
+
+	s.logger.Info("template verification changed",
+		"template_id", templateID,
+		"verified", verified,
+		"admin_id", adminID)
+
+	return updated, nil
+}
+
 // InstallTemplate installs a template as a workflow in the tenant
 func (s *Service) InstallTemplate(ctx context.Context, tenantID, userID, templateID string, input InstallTemplateInput) (*InstallTemplateResult, error) {
 	if input.WorkflowName == "" {
@@ -146,18 +195,58 @@ func (s *Service) InstallTemplate(ctx context.Context, tenantID, userID, templat
 		return nil, fmt.Errorf("template already installed")
 	}
 
-	template, err := s.GetTemplate(ctx, templateID)
+	tmpl, err := s.GetTemplate(ctx, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("get template: %w", err)
 	}
 
+	mode := input.InstallMode.OrDefault()
+
+	var definition json.RawMessage
+	var missingParams []string
+	if mode == InstallModeDraft {
+		definition, missingParams, err = template.ApplyParametersPartial(tmpl.Definition, input.Parameters)
+	} else {
+		definition, err = template.ApplyParameters(tmpl.Definition, input.Parameters)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var unmet *UnmetPrerequisites
+	if !input.SkipPrerequisiteCheck {
+		unmet, err = s.checkPrerequisites(ctx, tenantID, tmpl)
+		if err != nil {
+			s.logger.Error("failed to check template prerequisites",
+				"error", err,
+				"template_id", templateID,
+				"tenant_id", tenantID)
+			return nil, fmt.Errorf("check prerequisites: %w", err)
+		}
+		if unmet.IsEmpty() {
+			unmet = nil
+		} else if mode == InstallModeActive {
+			s.logger.Info("template install blocked by unmet prerequisites",
+				"template_id", templateID,
+				"tenant_id", tenantID,
+				"missing_credentials", unmet.Credentials,
+				"missing_env_vars", unmet.EnvVars)
+			return &InstallTemplateResult{
+				WorkflowName:       input.WorkflowName,
+				Definition:         definition,
+				UnmetPrerequisites: unmet,
+			}, nil
+		}
+	}
+
 	workflowID, err := s.workflowService.CreateFromTemplate(
 		ctx,
 		tenantID,
 		userID,
 		templateID,
 		input.WorkflowName,
-		template.Definition,
+		definition,
+		string(mode),
 	)
 	if err != nil {
 		s.logger.Error("failed to create workflow from template",
@@ -178,7 +267,7 @@ func (s *Service) InstallTemplate(ctx context.Context, tenantID, userID, templat
 		TenantID:         tenantID,
 		UserID:           userID,
 		WorkflowID:       workflowID,
-		InstalledVersion: template.Version,
+		InstalledVersion: tmpl.Version,
 	}
 
 	if err := s.repo.CreateInstallation(ctx, installation); err != nil {
@@ -191,31 +280,150 @@ func (s *Service) InstallTemplate(ctx context.Context, tenantID, userID, templat
 	s.logger.Info("template installed",
 		"template_id", templateID,
 		"workflow_id", workflowID,
-		"tenant_id", tenantID)
+		"tenant_id", tenantID,
+		"install_mode", mode)
 
 	return &InstallTemplateResult{
-		WorkflowID:   workflowID,
-		WorkflowName: input.WorkflowName,
-		Definition:   template.Definition,
+		WorkflowID:         workflowID,
+		WorkflowName:       input.WorkflowName,
+		Definition:         definition,
+		WorkflowStatus:     string(mode),
+		UnmetPrerequisites: unmet,
+		MissingParameters:  missingParams,
 	}, nil
 }
 
-// RateTemplate adds or updates a rating for a template
+// PublishNewVersion publishes a new version of an existing template. Only the
+// template's author may publish, and the version must be newer than the current one.
+// Prior versions remain available through GetTemplateVersions.
+func (s *Service) PublishNewVersion(ctx context.Context, userID, templateID string, input PublishVersionInput) (*MarketplaceTemplate, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := s.validateDefinition(input.Definition); err != nil {
+		return nil, fmt.Errorf("invalid definition: %w", err)
+	}
+
+	template, err := s.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.AuthorID != userID {
+		return nil, errors.New("only the template author can publish new versions")
+	}
+
+	cmp, err := CompareVersions(input.Version, template.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version: %w", err)
+	}
+	if cmp <= 0 {
+		return nil, fmt.Errorf("invalid version: %s must be greater than current version %s", input.Version, template.Version)
+	}
+
+	version := &TemplateVersion{
+		TemplateID:  templateID,
+		Version:     input.Version,
+		Definition:  input.Definition,
+		ChangeNotes: input.ChangeNotes,
+		CreatedBy:   userID,
+	}
+
+	requiredCredentials, requiredEnvVars := ExtractRequirements(input.Definition)
+
+	if err := s.repo.PublishVersion(ctx, version, requiredCredentials, requiredEnvVars); err != nil {
+		s.logger.Error("failed to publish template version",
+			"error", err,
+			"template_id", templateID,
+			"version", input.Version)
+		return nil, fmt.Errorf("publish version: %w", err)
+	}
+
+	s.logger.Info("template version published",
+		"template_id", templateID,
+		"previous_version", template.Version,
+		"version", input.Version)
+
+	template.Version = version.Version
+	template.Definition = version.Definition
+	template.RequiredCredentials = requiredCredentials
+	template.RequiredEnvVars = requiredEnvVars
+	template.UpdatedAt = version.CreatedAt
+
+	return template, nil
+}
+
+// GetTemplateVersions retrieves the version history of a template, newest first
+func (s *Service) GetTemplateVersions(ctx context.Context, templateID string) ([]*TemplateVersion, error) {
+	versions, err := s.repo.GetVersions(ctx, templateID)
+	if err != nil {
+		s.logger.Error("failed to get template versions",
+			"error", err,
+			"template_id", templateID)
+		return nil, fmt.Errorf("get versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetTemplateVersion retrieves a specific version of a template
+func (s *Service) GetTemplateVersion(ctx context.Context, templateID, version string) (*TemplateVersion, error) {
+	templateVersion, err := s.repo.GetVersion(ctx, templateID, version)
+	if err != nil {
+		return nil, fmt.Errorf("get version: %w", err)
+	}
+
+	return templateVersion, nil
+}
+
+// ListInstalledWithUpdates returns the tenant's installed templates whose
+// upstream version is newer than the version that was installed
+func (s *Service) ListInstalledWithUpdates(ctx context.Context, tenantID string) ([]*InstalledTemplateUpdate, error) {
+	installations, err := s.repo.GetInstallationsWithLatestVersion(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("failed to get installations",
+			"error", err,
+			"tenant_id", tenantID)
+		return nil, fmt.Errorf("get installations: %w", err)
+	}
+
+	updates := make([]*InstalledTemplateUpdate, 0, len(installations))
+	for _, installation := range installations {
+		cmp, err := CompareVersions(installation.LatestVersion, installation.InstalledVersion)
+		if err != nil {
+			// Versions that are not semver can only be compared for equality
+			if installation.LatestVersion != installation.InstalledVersion {
+				updates = append(updates, installation)
+			}
+			continue
+		}
+		if cmp > 0 {
+			updates = append(updates, installation)
+		}
+	}
+
+	return updates, nil
+}
+
+// RateTemplate adds or updates a rating for a template. Each user has at most
+// one review per template; rating again updates it. New reviews are limited to
+// MaxNewReviewsPerDay per user.
 func (s *Service) RateTemplate(ctx context.Context, tenantID, userID, userName, templateID string, input RateTemplateInput) (*TemplateReview, error) {
 	if err := input.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
-	existingReview, err := s.repo.GetUserReview(ctx, tenantID, templateID)
+	existingReview, err := s.repo.GetUserReview(ctx, tenantID, userID, templateID)
 	if err == nil && existingReview != nil {
-		if err := s.repo.UpdateReview(ctx, tenantID, existingReview.ID, input.Rating, input.Comment); err != nil {
-			s.logger.Error("failed to update review",
-				"error", err,
-				"template_id", templateID,
-				"tenant_id", tenantID)
-			return nil, fmt.Errorf("update review: %w", err)
+		if err := s.updateReview(ctx, tenantID, templateID, existingReview.ID, input); err != nil {
+			return nil, err
 		}
 	} else {
+		if err := s.checkReviewRateLimit(ctx, tenantID, userID); err != nil {
+			return nil, err
+		}
+
 		review := &TemplateReview{
 			TemplateID: templateID,
 			TenantID:   tenantID,
@@ -226,21 +434,26 @@ func (s *Service) RateTemplate(ctx context.Context, tenantID, userID, userName,
 		}
 
 		if err := s.repo.CreateReview(ctx, review); err != nil {
-			s.logger.Error("failed to create review",
-				"error", err,
-				"template_id", templateID,
-				"tenant_id", tenantID)
-			return nil, fmt.Errorf("create review: %w", err)
+			if !strings.Contains(err.Error(), "already reviewed") {
+				s.logger.Error("failed to create review",
+					"error", err,
+					"template_id", templateID,
+					"tenant_id", tenantID)
+				return nil, fmt.Errorf("create review: %w", err)
+			}
+
+			// A concurrent request created the review first; update it instead
+			existingReview, err := s.repo.GetUserReview(ctx, tenantID, userID, templateID)
+			if err != nil {
+				return nil, fmt.Errorf("get review: %w", err)
+			}
+			if err := s.updateReview(ctx, tenantID, templateID, existingReview.ID, input); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	if err := s.repo.UpdateTemplateRating(ctx, templateID); err != nil {
-		s.logger.Warn("failed to update template rating",
-			"error", err,
-			"template_id", templateID)
-	}
-
-	review, err := s.repo.GetUserReview(ctx, tenantID, templateID)
+	review, err := s.repo.GetUserReview(ctx, tenantID, userID, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("get review: %w", err)
 	}
@@ -253,25 +466,71 @@ func (s *Service) RateTemplate(ctx context.Context, tenantID, userID, userName,
 	return review, nil
 }
 
-// GetReviews retrieves reviews for a template
-func (s *Service) GetReviews(ctx context.Context, templateID string, sortBy ReviewSortOption, limit, offset int) ([]*TemplateReview, error) {
-	if limit <= 0 {
-		limit = 10
+// updateReview replaces the rating and comment of an existing review
+func (s *Service) updateReview(ctx context.Context, tenantID, templateID, reviewID string, input RateTemplateInput) error {
+	if err := s.repo.UpdateReview(ctx, tenantID, reviewID, input.Rating, input.Comment); err != nil {
+		s.logger.Error("failed to update review",
+			"error", err,
+			"template_id", templateID,
+			"tenant_id", tenantID)
+		return fmt.Errorf("update review: %w", err)
 	}
+	return nil
+}
+
+// checkReviewRateLimit rejects a new review when the user has already created
+// MaxNewReviewsPerDay reviews in the last 24 hours
+func (s *Service) checkReviewRateLimit(ctx context.Context, tenantID, userID string) error {
+	count, err := s.repo.CountUserReviewsSince(ctx, tenantID, userID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("count recent reviews: %w", err)
+	}
+
+	if count >= MaxNewReviewsPerDay {
+		s.logger.Warn("review rate limit exceeded",
+			"tenant_id", tenantID,
+			"user_id", userID,
+			"count", count)
+		return fmt.Errorf("review rate limit exceeded: at most %d new reviews per day", MaxNewReviewsPerDay)
+	}
+
+	return nil
+}
 
+// GetReviews retrieves a page of reviews for a template and the total number
+// of reviews. The limit defaults to DefaultReviewLimit and is capped at
+// MaxReviewLimit.
+func (s *Service) GetReviews(ctx context.Context, templateID string, sortBy ReviewSortOption, limit, offset int) ([]*TemplateReview, int, error) {
 	if sortBy == "" {
 		sortBy = ReviewSortRecent
 	}
+	if !sortBy.Valid() {
+		return nil, 0, ErrInvalidReviewSort
+	}
+
+	if limit <= 0 {
+		limit = DefaultReviewLimit
+	}
+	limit = min(limit, MaxReviewLimit)
+	offset = max(offset, 0)
 
 	reviews, err := s.repo.GetReviews(ctx, templateID, sortBy, limit, offset)
 	if err != nil {
 		s.logger.Error("failed to get reviews",
 			"error", err,
 			"template_id", templateID)
-		return nil, fmt.Errorf("get reviews: %w", err)
+		return nil, 0, fmt.Errorf("get reviews: %w", err)
+	}
+
+	total, err := s.repo.CountReviews(ctx, templateID)
+	if err != nil {
+		s.logger.Error("failed to count reviews",
+			"error", err,
+			"template_id", templateID)
+		return nil, 0, fmt.Errorf("count reviews: %w", err)
 	}
 
-	return reviews, nil
+	return reviews, total, nil
 }
 
 // DeleteReview deletes a review
@@ -284,12 +543,6 @@ func (s *Service) DeleteReview(ctx context.Context, tenantID, templateID, review
 		return fmt.Errorf("delete review: %w", err)
 	}
 
-	if err := s.repo.UpdateTemplateRating(ctx, templateID); err != nil {
-		s.logger.Warn("failed to update template rating after delete",
-			"error", err,
-			"template_id", templateID)
-	}
-
 	s.logger.Info("review deleted",
 		"review_id", reviewID,
 		"template_id", templateID,
@@ -483,5 +736,5 @@ func (s *Service) validateDefinition(definition json.RawMessage) error {
 		return errors.New("definition must contain 'edges' field")
 	}
 
-	return nil
+	return template.ValidateParameters(definition)
 }
@@ -7,10 +7,13 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/template"
 )
 
 // MockRepository is a mock implementation of Repository
@@ -36,13 +39,13 @@ func (m *MockRepository) Search(ctx context.Context, filter SearchFilter) ([]*Ma
 	return args.Get(0).([]*MarketplaceTemplate), args.Error(1)
 }
 
-func (m *MockRepository) GetPopular(ctx context.Context, limit int) ([]*MarketplaceTemplate, error) {
-	args := m.Called(ctx, limit)
+func (m *MockRepository) GetPopular(ctx context.Context, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
+	args := m.Called(ctx, limit, verifiedOnly)
 	return args.Get(0).([]*MarketplaceTemplate), args.Error(1)
 }
 
-func (m *MockRepository) GetTrending(ctx context.Context, days, limit int) ([]*MarketplaceTemplate, error) {
-	args := m.Called(ctx, days, limit)
+func (m *MockRepository) GetTrending(ctx context.Context, days, limit int, verifiedOnly bool) ([]*MarketplaceTemplate, error) {
+	args := m.Called(ctx, days, limit, verifiedOnly)
 	return args.Get(0).([]*MarketplaceTemplate), args.Error(1)
 }
 
@@ -56,6 +59,14 @@ func (m *MockRepository) Update(ctx context.Context, id string, input UpdateTemp
 	return args.Error(0)
 }
 
+func (m *MockRepository) SetVerified(ctx context.Context, id string, verified bool) (*MarketplaceTemplate, error) {
+	args := m.Called(ctx, id, verified)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MarketplaceTemplate), args.Error(1)
+}
+
 func (m *MockRepository) IncrementDownloadCount(ctx context.Context, templateID string) error {
 	args := m.Called(ctx, templateID)
 	return args.Error(0)
@@ -74,6 +85,35 @@ func (m *MockRepository) GetInstallation(ctx context.Context, tenantID, template
 	return args.Get(0).(*TemplateInstallation), args.Error(1)
 }
 
+func (m *MockRepository) PublishVersion(ctx context.Context, version *TemplateVersion, requiredCredentials, requiredEnvVars []string) error {
+	args := m.Called(ctx, version, requiredCredentials, requiredEnvVars)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetVersions(ctx context.Context, templateID string) ([]*TemplateVersion, error) {
+	args := m.Called(ctx, templateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*TemplateVersion), args.Error(1)
+}
+
+func (m *MockRepository) GetVersion(ctx context.Context, templateID, version string) (*TemplateVersion, error) {
+	args := m.Called(ctx, templateID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TemplateVersion), args.Error(1)
+}
+
+func (m *MockRepository) GetInstallationsWithLatestVersion(ctx context.Context, tenantID string) ([]*InstalledTemplateUpdate, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*InstalledTemplateUpdate), args.Error(1)
+}
+
 func (m *MockRepository) CreateReview(ctx context.Context, review *TemplateReview) error {
 	args := m.Called(ctx, review)
 	return args.Error(0)
@@ -97,14 +137,24 @@ func (m *MockRepository) GetReviews(ctx context.Context, templateID string, sort
 	return args.Get(0).([]*TemplateReview), args.Error(1)
 }
 
-func (m *MockRepository) GetUserReview(ctx context.Context, tenantID, templateID string) (*TemplateReview, error) {
-	args := m.Called(ctx, tenantID, templateID)
+func (m *MockRepository) CountReviews(ctx context.Context, templateID string) (int, error) {
+	args := m.Called(ctx, templateID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) GetUserReview(ctx context.Context, tenantID, userID, templateID string) (*TemplateReview, error) {
+	args := m.Called(ctx, tenantID, userID, templateID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*TemplateReview), args.Error(1)
 }
 
+func (m *MockRepository) CountUserReviewsSince(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
+	args := m.Called(ctx, tenantID, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockRepository) UpdateTemplateRating(ctx context.Context, templateID string) error {
 	args := m.Called(ctx, templateID)
 	return args.Error(0)
@@ -166,8 +216,8 @@ type MockWorkflowService struct {
 	mock.Mock
 }
 
-func (m *MockWorkflowService) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage) (string, error) {
-	args := m.Called(ctx, tenantID, userID, templateID, workflowName, definition)
+func (m *MockWorkflowService) CreateFromTemplate(ctx context.Context, tenantID, userID, templateID, workflowName string, definition json.RawMessage, status string) (string, error) {
+	args := m.Called(ctx, tenantID, userID, templateID, workflowName, definition, status)
 	return args.String(0), args.Error(1)
 }
 
@@ -268,9 +318,9 @@ func TestService_GetTrending(t *testing.T) {
 		{ID: "1", Name: "Trending 1"},
 	}
 
-	repo.On("GetTrending", ctx, 7, 10).Return(expectedTemplates, nil)
+	repo.On("GetTrending", ctx, 7, 10, false).Return(expectedTemplates, nil)
 
-	templates, err := service.GetTrending(ctx, 10)
+	templates, err := service.GetTrending(ctx, 10, false)
 	require.NoError(t, err)
 	assert.Equal(t, expectedTemplates, templates)
 	repo.AssertExpectations(t)
@@ -297,7 +347,7 @@ func TestInstallTemplate(t *testing.T) {
 
 	repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found"))
 	repo.On("GetByID", ctx, "template-1").Return(template, nil)
-	workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "My Workflow", definition).Return("workflow-1", nil)
+	workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "My Workflow", definition, "active").Return("workflow-1", nil)
 	repo.On("IncrementDownloadCount", ctx, "template-1").Return(nil)
 	repo.On("CreateInstallation", ctx, mock.AnythingOfType("*marketplace.TemplateInstallation")).Return(nil)
 
@@ -310,6 +360,85 @@ func TestInstallTemplate(t *testing.T) {
 	workflowService.AssertExpectations(t)
 }
 
+func TestInstallTemplate_Parameters(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+
+	tmpl := &MarketplaceTemplate{
+		ID:      "template-1",
+		Name:    "Deploy Notice",
+		Version: "1.0.0",
+		Definition: json.RawMessage(`{
+			"parameters": [
+				{"name": "channel", "type": "string", "default": "#deployments"},
+				{"name": "env", "type": "string", "required": true}
+			],
+			"nodes": [{"id": "1", "data": {"config": {"channel": "${params.channel}", "message": "Deployed to ${params.env}"}}}],
+			"edges": []
+		}`),
+	}
+
+	t.Run("installs the definition with parameter values substituted", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+
+		resolved := json.RawMessage(`{"edges":[],"nodes":[{"data":{"config":{"channel":"#releases","message":"Deployed to prod"}},"id":"1"}]}`)
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found"))
+		repo.On("GetByID", ctx, "template-1").Return(tmpl, nil)
+		workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "Deploys", resolved, "active").Return("workflow-1", nil)
+		repo.On("IncrementDownloadCount", ctx, "template-1").Return(nil)
+		repo.On("CreateInstallation", ctx, mock.AnythingOfType("*marketplace.TemplateInstallation")).Return(nil)
+
+		result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{
+			WorkflowName: "Deploys",
+			Parameters:   map[string]interface{}{"channel": "#releases", "env": "prod"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, string(resolved), string(result.Definition))
+		workflowService.AssertExpectations(t)
+	})
+
+	t.Run("rejects missing required parameters", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found"))
+		repo.On("GetByID", ctx, "template-1").Return(tmpl, nil)
+
+		_, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{WorkflowName: "Deploys"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, template.ErrInvalidParameters)
+		assert.Contains(t, err.Error(), "env")
+		workflowService.AssertNotCalled(t, "CreateFromTemplate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("draft install leaves missing required parameters for the admin", func(t *testing.T) {
+		repo := new(MockRepository)
+		workflowService := new(MockWorkflowService)
+		service := NewService(repo, workflowService, logger)
+
+		partial := json.RawMessage(`{"edges":[],"nodes":[{"data":{"config":{"channel":"#deployments","message":"Deployed to ${params.env}"}},"id":"1"}]}`)
+
+		repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found"))
+		repo.On("GetByID", ctx, "template-1").Return(tmpl, nil)
+		workflowService.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "Deploys", partial, "draft").Return("workflow-1", nil)
+		repo.On("IncrementDownloadCount", ctx, "template-1").Return(nil)
+		repo.On("CreateInstallation", ctx, mock.AnythingOfType("*marketplace.TemplateInstallation")).Return(nil)
+
+		result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{
+			WorkflowName: "Deploys",
+			InstallMode:  InstallModeDraft,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "workflow-1", result.WorkflowID)
+		assert.Equal(t, "draft", result.WorkflowStatus)
+		assert.Equal(t, []string{"env"}, result.MissingParameters)
+		workflowService.AssertExpectations(t)
+	})
+}
+
 func TestInstallTemplate_AlreadyInstalled(t *testing.T) {
 	repo := new(MockRepository)
 	workflowService := new(MockWorkflowService)
@@ -334,6 +463,88 @@ func TestInstallTemplate_AlreadyInstalled(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestPublishNewVersion(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	definition := json.RawMessage(`{"nodes":[],"edges":[]}`)
+
+	newTemplate := func() *MarketplaceTemplate {
+		return &MarketplaceTemplate{ID: "template-1", AuthorID: "author-1", Version: "1.2.0", Definition: definition}
+	}
+
+	t.Run("publishes newer version", func(t *testing.T) {
+		repo := new(MockRepository)
+		service := NewService(repo, nil, logger)
+
+		repo.On("GetByID", ctx, "template-1").Return(newTemplate(), nil)
+		repo.On("PublishVersion", ctx, mock.MatchedBy(func(v *TemplateVersion) bool {
+			return v.TemplateID == "template-1" && v.Version == "1.3.0" && v.CreatedBy == "author-1" && v.ChangeNotes == "Retry on failure"
+		}), []string{}, []string{}).Return(nil)
+
+		template, err := service.PublishNewVersion(ctx, "author-1", "template-1", PublishVersionInput{
+			Version:     "1.3.0",
+			Definition:  definition,
+			ChangeNotes: "Retry on failure",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1.3.0", template.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects version that is not newer", func(t *testing.T) {
+		repo := new(MockRepository)
+		service := NewService(repo, nil, logger)
+
+		repo.On("GetByID", ctx, "template-1").Return(newTemplate(), nil)
+
+		_, err := service.PublishNewVersion(ctx, "author-1", "template-1", PublishVersionInput{Version: "1.1.9", Definition: definition})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be greater than current version 1.2.0")
+		repo.AssertNotCalled(t, "PublishVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects non-author", func(t *testing.T) {
+		repo := new(MockRepository)
+		service := NewService(repo, nil, logger)
+
+		repo.On("GetByID", ctx, "template-1").Return(newTemplate(), nil)
+
+		_, err := service.PublishNewVersion(ctx, "someone-else", "template-1", PublishVersionInput{Version: "2.0.0", Definition: definition})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only the template author")
+	})
+}
+
+func TestListInstalledWithUpdates(t *testing.T) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(repo, nil, logger)
+	ctx := context.Background()
+
+	installed := func(templateID, installedVersion, latestVersion string) *InstalledTemplateUpdate {
+		return &InstalledTemplateUpdate{
+			TemplateInstallation: TemplateInstallation{TemplateID: templateID, TenantID: "tenant-1", InstalledVersion: installedVersion},
+			LatestVersion:        latestVersion,
+		}
+	}
+
+	repo.On("GetInstallationsWithLatestVersion", ctx, "tenant-1").Return([]*InstalledTemplateUpdate{
+		installed("up-to-date", "1.0.0", "1.0.0"),
+		installed("minor-update", "1.0.0", "1.1.0"),
+		installed("prerelease-installed", "2.0.0-beta", "2.0.0"),
+		installed("non-semver", "2024-01", "2024-06"),
+	}, nil)
+
+	updates, err := service.ListInstalledWithUpdates(ctx, "tenant-1")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, update := range updates {
+		ids = append(ids, update.TemplateID)
+	}
+	assert.Equal(t, []string{"minor-update", "prerelease-installed", "non-semver"}, ids)
+}
+
 func TestRateTemplate(t *testing.T) {
 	repo := new(MockRepository)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -346,11 +557,11 @@ func TestRateTemplate(t *testing.T) {
 	}
 
 	// First call - check if review exists (returns not found)
-	repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(nil, errors.New("review not found")).Once()
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(nil, errors.New("review not found")).Once()
+	repo.On("CountUserReviewsSince", ctx, "tenant-1", "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
 	repo.On("CreateReview", ctx, mock.AnythingOfType("*marketplace.TemplateReview")).Return(nil)
-	repo.On("UpdateTemplateRating", ctx, "template-1").Return(nil)
 	// Second call - return the created review
-	repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(&TemplateReview{
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(&TemplateReview{
 		ID:         "review-1",
 		TemplateID: "template-1",
 		TenantID:   "tenant-1",
@@ -385,11 +596,10 @@ func TestRateTemplate_UpdateExisting(t *testing.T) {
 	}
 
 	// First call - check if review exists (returns existing)
-	repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(existingReview, nil).Once()
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(existingReview, nil).Once()
 	repo.On("UpdateReview", ctx, "tenant-1", "review-1", 5, "Updated review!").Return(nil)
-	repo.On("UpdateTemplateRating", ctx, "template-1").Return(nil)
 	// Second call - return the updated review
-	repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(&TemplateReview{
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(&TemplateReview{
 		ID:      "review-1",
 		Rating:  5,
 		Comment: "Updated review!",
@@ -402,6 +612,61 @@ func TestRateTemplate_UpdateExisting(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestRateTemplate_RateLimitExceeded(t *testing.T) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(repo, nil, logger)
+	ctx := context.Background()
+
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(nil, errors.New("review not found")).Once()
+	repo.On("CountUserReviewsSince", ctx, "tenant-1", "user-1", mock.AnythingOfType("time.Time")).Return(MaxNewReviewsPerDay, nil)
+
+	review, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Test User", "template-1", RateTemplateInput{Rating: 5})
+	require.Error(t, err)
+	assert.Nil(t, review)
+	assert.Contains(t, err.Error(), "rate limit exceeded")
+	repo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestRateTemplate_ExistingReviewIgnoresRateLimit(t *testing.T) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(repo, nil, logger)
+	ctx := context.Background()
+
+	existing := &TemplateReview{ID: "review-1", TemplateID: "template-1", Rating: 2}
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(existing, nil)
+	repo.On("UpdateReview", ctx, "tenant-1", "review-1", 4, "").Return(nil)
+
+	_, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Test User", "template-1", RateTemplateInput{Rating: 4})
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "CountUserReviewsSince", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestRateTemplate_ConcurrentCreateUpdatesExisting(t *testing.T) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(repo, nil, logger)
+	ctx := context.Background()
+
+	// Another request creates the review between the lookup and the insert
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(nil, errors.New("review not found")).Once()
+	repo.On("CountUserReviewsSince", ctx, "tenant-1", "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
+	repo.On("CreateReview", ctx, mock.AnythingOfType("*marketplace.TemplateReview")).
+		Return(errors.New("you have already reviewed this template"))
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(&TemplateReview{ID: "review-1", Rating: 3}, nil).Once()
+	repo.On("UpdateReview", ctx, "tenant-1", "review-1", 5, "Great!").Return(nil)
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(&TemplateReview{ID: "review-1", Rating: 5}, nil).Once()
+
+	review, err := service.RateTemplate(ctx, "tenant-1", "user-1", "Test User", "template-1", RateTemplateInput{Rating: 5, Comment: "Great!"})
+	require.NoError(t, err)
+	assert.Equal(t, "review-1", review.ID)
+	assert.Equal(t, 5, review.Rating)
+	repo.AssertExpectations(t)
+}
+
 func TestService_GetReviews(t *testing.T) {
 	repo := new(MockRepository)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -414,13 +679,55 @@ func TestService_GetReviews(t *testing.T) {
 	}
 
 	repo.On("GetReviews", ctx, "template-1", ReviewSortRecent, 10, 0).Return(expectedReviews, nil)
+	repo.On("CountReviews", ctx, "template-1").Return(12, nil)
 
-	reviews, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 10, 0)
+	reviews, total, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 10, 0)
 	require.NoError(t, err)
 	assert.Equal(t, expectedReviews, reviews)
+	assert.Equal(t, 12, total)
 	repo.AssertExpectations(t)
 }
 
+func TestService_GetReviews_Paging(t *testing.T) {
+	tests := []struct {
+		name       string
+		sortBy     ReviewSortOption
+		limit      int
+		offset     int
+		wantSort   ReviewSortOption
+		wantLimit  int
+		wantOffset int
+	}{
+		{name: "default sort", limit: 10, wantSort: ReviewSortRecent, wantLimit: 10},
+		{name: "limit is clamped", sortBy: ReviewSortHelpful, limit: 1000, offset: 20, wantSort: ReviewSortHelpful, wantLimit: MaxReviewLimit, wantOffset: 20},
+		{name: "negative offset", sortBy: ReviewSortRatingL, limit: 5, offset: -3, wantSort: ReviewSortRatingL, wantLimit: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRepository)
+			service := NewService(repo, nil, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+			ctx := context.Background()
+
+			repo.On("GetReviews", ctx, "template-1", tt.wantSort, tt.wantLimit, tt.wantOffset).Return([]*TemplateReview{}, nil)
+			repo.On("CountReviews", ctx, "template-1").Return(0, nil)
+
+			_, _, err := service.GetReviews(ctx, "template-1", tt.sortBy, tt.limit, tt.offset)
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetReviews_InvalidSort(t *testing.T) {
+	repo := new(MockRepository)
+	service := NewService(repo, nil, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	_, _, err := service.GetReviews(context.Background(), "template-1", "oldest", 10, 0)
+	assert.ErrorIs(t, err, ErrInvalidReviewSort)
+	repo.AssertNotCalled(t, "GetReviews")
+}
+
 func TestService_DeleteReview(t *testing.T) {
 	repo := new(MockRepository)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -428,7 +735,6 @@ func TestService_DeleteReview(t *testing.T) {
 	ctx := context.Background()
 
 	repo.On("DeleteReview", ctx, "tenant-1", "review-1").Return(nil)
-	repo.On("UpdateTemplateRating", ctx, "template-1").Return(nil)
 
 	err := service.DeleteReview(ctx, "tenant-1", "template-1", "review-1")
 	require.NoError(t, err)
@@ -493,9 +799,9 @@ func TestService_GetTrending_RepositoryError(t *testing.T) {
 	service := NewService(repo, nil, logger)
 	ctx := context.Background()
 
-	repo.On("GetTrending", ctx, 7, 10).Return(([]*MarketplaceTemplate)(nil), errors.New("database timeout"))
+	repo.On("GetTrending", ctx, 7, 10, false).Return(([]*MarketplaceTemplate)(nil), errors.New("database timeout"))
 
-	templates, err := service.GetTrending(ctx, 10)
+	templates, err := service.GetTrending(ctx, 10, false)
 	assert.Error(t, err)
 	assert.Nil(t, templates)
 	assert.Contains(t, err.Error(), "get trending")
@@ -508,9 +814,9 @@ func TestService_GetPopular_RepositoryError(t *testing.T) {
 	service := NewService(repo, nil, logger)
 	ctx := context.Background()
 
-	repo.On("GetPopular", ctx, 10).Return(([]*MarketplaceTemplate)(nil), errors.New("replica not available"))
+	repo.On("GetPopular", ctx, 10, false).Return(([]*MarketplaceTemplate)(nil), errors.New("replica not available"))
 
-	templates, err := service.GetPopular(ctx, 10)
+	templates, err := service.GetPopular(ctx, 10, false)
 	assert.Error(t, err)
 	assert.Nil(t, templates)
 	assert.Contains(t, err.Error(), "get popular")
@@ -525,7 +831,7 @@ func TestService_GetReviews_RepositoryError(t *testing.T) {
 
 	repo.On("GetReviews", ctx, "template-1", ReviewSortRecent, 10, 0).Return(([]*TemplateReview)(nil), errors.New("query timeout"))
 
-	reviews, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 10, 0)
+	reviews, _, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 10, 0)
 	assert.Error(t, err)
 	assert.Nil(t, reviews)
 	assert.Contains(t, err.Error(), "get reviews")
@@ -631,7 +937,7 @@ func TestService_InstallTemplate_WorkflowServiceError(t *testing.T) {
 
 	repo.On("GetInstallation", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found"))
 	repo.On("GetByID", ctx, "template-1").Return(template, nil)
-	workflowSvc.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "My Workflow", template.Definition).Return("", errors.New("quota exceeded"))
+	workflowSvc.On("CreateFromTemplate", ctx, "tenant-1", "user-1", "template-1", "My Workflow", template.Definition, "active").Return("", errors.New("quota exceeded"))
 
 	result, err := service.InstallTemplate(ctx, "tenant-1", "user-1", "template-1", InstallTemplateInput{WorkflowName: "My Workflow"})
 	assert.Error(t, err)
@@ -648,7 +954,8 @@ func TestService_RateTemplate_CreateReviewError(t *testing.T) {
 	ctx := context.Background()
 
 	// No existing review
-	repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found")).Once()
+	repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(nil, errors.New("not found")).Once()
+	repo.On("CountUserReviewsSince", ctx, "tenant-1", "user-1", mock.AnythingOfType("time.Time")).Return(0, nil)
 	// Create review fails
 	repo.On("CreateReview", ctx, mock.AnythingOfType("*marketplace.TemplateReview")).Return(errors.New("duplicate key"))
 
@@ -681,10 +988,10 @@ func TestService_RateTemplate_InvalidRating(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if !tt.wantErr {
-				repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(nil, errors.New("not found")).Once()
+				repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(nil, errors.New("not found")).Once()
+				repo.On("CountUserReviewsSince", ctx, "tenant-1", "user-1", mock.AnythingOfType("time.Time")).Return(0, nil).Once()
 				repo.On("CreateReview", ctx, mock.AnythingOfType("*marketplace.TemplateReview")).Return(nil).Once()
-				repo.On("UpdateTemplateRating", ctx, "template-1").Return(nil).Once()
-				repo.On("GetUserReview", ctx, "tenant-1", "template-1").Return(&TemplateReview{ID: "1"}, nil).Once()
+				repo.On("GetUserReview", ctx, "tenant-1", "user-1", "template-1").Return(&TemplateReview{ID: "1"}, nil).Once()
 			}
 
 			input := RateTemplateInput{Rating: tt.rating}
@@ -722,12 +1029,12 @@ func TestService_GetTrending_DefaultLimit(t *testing.T) {
 	ctx := context.Background()
 
 	// When limit is 0 or negative, it should default to 10
-	repo.On("GetTrending", ctx, 7, 10).Return([]*MarketplaceTemplate{}, nil)
+	repo.On("GetTrending", ctx, 7, 10, false).Return([]*MarketplaceTemplate{}, nil)
 
-	_, err := service.GetTrending(ctx, 0)
+	_, err := service.GetTrending(ctx, 0, false)
 	assert.NoError(t, err)
 
-	_, err = service.GetTrending(ctx, -1)
+	_, err = service.GetTrending(ctx, -1, false)
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
 }
@@ -740,11 +1047,12 @@ func TestService_GetReviews_DefaultLimit(t *testing.T) {
 
 	// When limit is 0 or negative, it should default to 10
 	repo.On("GetReviews", ctx, "template-1", ReviewSortRecent, 10, 0).Return([]*TemplateReview{}, nil)
+	repo.On("CountReviews", ctx, "template-1").Return(0, nil)
 
-	_, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 0, 0)
+	_, _, err := service.GetReviews(ctx, "template-1", ReviewSortRecent, 0, 0)
 	assert.NoError(t, err)
 
-	_, err = service.GetReviews(ctx, "template-1", ReviewSortRecent, -5, 0)
+	_, _, err = service.GetReviews(ctx, "template-1", ReviewSortRecent, -5, 0)
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
 }
@@ -908,10 +1216,12 @@ func TestGetReviewsWithSorting(t *testing.T) {
 			}
 
 			repo.On("GetReviews", ctx, "template-1", tt.sortBy, 10, 0).Return(expectedReviews, nil)
+			repo.On("CountReviews", ctx, "template-1").Return(2, nil)
 
-			reviews, err := service.GetReviews(ctx, "template-1", tt.sortBy, 10, 0)
+			reviews, total, err := service.GetReviews(ctx, "template-1", tt.sortBy, 10, 0)
 			assert.NoError(t, err)
 			assert.Equal(t, expectedReviews, reviews)
+			assert.Equal(t, 2, total)
 
 			repo.AssertExpectations(t)
 		})
@@ -0,0 +1,73 @@
+package marketplace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// templateVersion is a parsed semantic version (major.minor.patch[-prerelease])
+type templateVersion struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+// parseVersion parses a semantic version, allowing an optional leading "v".
+// Build metadata after "+" is ignored.
+func parseVersion(version string) (templateVersion, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	core, prerelease, _ := strings.Cut(raw, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return templateVersion{}, fmt.Errorf("invalid version %q: expected major.minor.patch", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return templateVersion{}, fmt.Errorf("invalid version %q: expected major.minor.patch", version)
+		}
+		numbers[i] = n
+	}
+
+	return templateVersion{major: numbers[0], minor: numbers[1], patch: numbers[2], prerelease: prerelease}, nil
+}
+
+// CompareVersions compares two semantic versions, returning -1, 0 or 1 when
+// a is older than, equal to or newer than b. A prerelease sorts before the
+// release it precedes (1.0.0-beta < 1.0.0).
+func CompareVersions(a, b string) (int, error) {
+	va, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, diff := range []int{va.major - vb.major, va.minor - vb.minor, va.patch - vb.patch} {
+		if diff < 0 {
+			return -1, nil
+		}
+		if diff > 0 {
+			return 1, nil
+		}
+	}
+
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0, nil
+	case va.prerelease == "":
+		return 1, nil
+	case vb.prerelease == "":
+		return -1, nil
+	case va.prerelease < vb.prerelease:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
@@ -0,0 +1,39 @@
+package marketplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.0", b: "1.0.0", want: 0},
+		{a: "1.0.1", b: "1.0.0", want: 1},
+		{a: "1.2.0", b: "1.10.0", want: -1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "v1.0.0", b: "1.0.0", want: 0},
+		{a: "1.0.0-beta", b: "1.0.0", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-beta", want: -1},
+		{a: "1.0.0+build.5", b: "1.0.0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompareVersions_Invalid(t *testing.T) {
+	for _, version := range []string{"", "1.0", "1.0.x", "latest"} {
+		_, err := CompareVersions(version, "1.0.0")
+		assert.Error(t, err, version)
+	}
+}
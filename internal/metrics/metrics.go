@@ -7,14 +7,19 @@ import (
 // Metrics holds all Prometheus metrics for the application
 type Metrics struct {
 	// Workflow metrics
-	WorkflowExecutionsTotal   *prometheus.CounterVec
-	WorkflowExecutionDuration *prometheus.HistogramVec
-	WorkflowExecutionsActive  *prometheus.GaugeVec
+	WorkflowExecutionsStartedTotal *prometheus.CounterVec
+	WorkflowExecutionsTotal        *prometheus.CounterVec
+	WorkflowExecutionDuration      *prometheus.HistogramVec
+	WorkflowExecutionsActive       *prometheus.GaugeVec
 
 	// Step metrics
 	StepExecutionsTotal   *prometheus.CounterVec
 	StepExecutionDuration *prometheus.HistogramVec
 
+	// Per-workflow concurrency metrics
+	WorkflowConcurrencyRunning *prometheus.GaugeVec
+	WorkflowConcurrencyWaiting *prometheus.GaugeVec
+
 	// Queue metrics
 	QueueDepth *prometheus.GaugeVec
 
@@ -42,6 +47,13 @@ type Metrics struct {
 // NewMetrics creates a new Metrics instance with all collectors initialized
 func NewMetrics() *Metrics {
 	return &Metrics{
+		WorkflowExecutionsStartedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "gorax_workflow_executions_started_total",
+				Help: "Total number of workflow executions started by trigger type",
+			},
+			[]string{"tenant_id", "workflow_id", "trigger_type"},
+		),
 		WorkflowExecutionsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "gorax_workflow_executions_total",
@@ -79,6 +91,20 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"tenant_id", "workflow_id", "step_type"},
 		),
+		WorkflowConcurrencyRunning: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gorax_workflow_concurrency_running",
+				Help: "Running executions of workflows with a max_concurrency limit",
+			},
+			[]string{"workflow_id"},
+		),
+		WorkflowConcurrencyWaiting: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "gorax_workflow_concurrency_waiting",
+				Help: "Executions waiting for a slot under their workflow's max_concurrency limit",
+			},
+			[]string{"workflow_id"},
+		),
 		QueueDepth: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "gorax_queue_depth",
@@ -178,11 +204,14 @@ func NewMetrics() *Metrics {
 // Register registers all metrics with the provided registry
 func (m *Metrics) Register(registry *prometheus.Registry) error {
 	collectors := []prometheus.Collector{
+		m.WorkflowExecutionsStartedTotal,
 		m.WorkflowExecutionsTotal,
 		m.WorkflowExecutionDuration,
 		m.WorkflowExecutionsActive,
 		m.StepExecutionsTotal,
 		m.StepExecutionDuration,
+		m.WorkflowConcurrencyRunning,
+		m.WorkflowConcurrencyWaiting,
 		m.QueueDepth,
 		m.ActiveWorkers,
 		m.HTTPRequestsTotal,
@@ -207,6 +236,11 @@ func (m *Metrics) Register(registry *prometheus.Registry) error {
 	return nil
 }
 
+// RecordWorkflowExecutionStarted records the start of a workflow execution
+func (m *Metrics) RecordWorkflowExecutionStarted(tenantID, workflowID, triggerType string) {
+	m.WorkflowExecutionsStartedTotal.WithLabelValues(tenantID, workflowID, triggerType).Inc()
+}
+
 // RecordWorkflowExecution records a workflow execution with status and duration
 func (m *Metrics) RecordWorkflowExecution(tenantID, workflowID, triggerType, status string, durationSeconds float64) {
 	m.WorkflowExecutionsTotal.WithLabelValues(tenantID, workflowID, triggerType, status).Inc()
@@ -229,6 +263,13 @@ func (m *Metrics) RecordStepExecution(tenantID, workflowID, stepType, status str
 	m.StepExecutionDuration.WithLabelValues(tenantID, workflowID, stepType).Observe(durationSeconds)
 }
 
+// SetWorkflowConcurrency sets the running and waiting executions of a workflow
+// with a max_concurrency limit
+func (m *Metrics) SetWorkflowConcurrency(workflowID string, running, waiting int) {
+	m.WorkflowConcurrencyRunning.WithLabelValues(workflowID).Set(float64(running))
+	m.WorkflowConcurrencyWaiting.WithLabelValues(workflowID).Set(float64(waiting))
+}
+
 // SetQueueDepth sets the current queue depth for a given queue
 func (m *Metrics) SetQueueDepth(queueName string, depth float64) {
 	m.QueueDepth.WithLabelValues(queueName).Set(depth)
@@ -75,6 +75,31 @@ func TestRecordWorkflowExecution(t *testing.T) {
 	assert.True(t, found, "workflow executions counter should be present")
 }
 
+func TestRecordWorkflowExecutionStarted(t *testing.T) {
+	// Given: metrics initialized
+	m := NewMetrics()
+	registry := prometheus.NewRegistry()
+	m.Register(registry)
+
+	// When: recording two execution starts for the same workflow
+	m.RecordWorkflowExecutionStarted("tenant1", "workflow1", "schedule")
+	m.RecordWorkflowExecutionStarted("tenant1", "workflow1", "schedule")
+
+	// Then: the started counter should be 2
+	metrics, err := registry.Gather()
+	assert.NoError(t, err)
+
+	found := false
+	for _, metric := range metrics {
+		if metric.GetName() == "gorax_workflow_executions_started_total" {
+			found = true
+			assert.Equal(t, 1, len(metric.GetMetric()))
+			assert.Equal(t, 2.0, metric.GetMetric()[0].GetCounter().GetValue())
+		}
+	}
+	assert.True(t, found, "workflow executions started counter should be present")
+}
+
 func TestRecordStepExecution(t *testing.T) {
 	// Given: metrics initialized
 	m := NewMetrics()
@@ -0,0 +1,60 @@
+package nodecache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ExpiredDeleter removes expired node cache entries
+type ExpiredDeleter interface {
+	DeleteExpired(ctx context.Context) (int, error)
+}
+
+// Cleaner periodically removes expired node cache entries
+type Cleaner struct {
+	repo   ExpiredDeleter
+	logger *slog.Logger
+}
+
+// NewCleaner creates a new node cache cleaner
+func NewCleaner(repo ExpiredDeleter, logger *slog.Logger) *Cleaner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Cleaner{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Start removes expired entries every interval until ctx is cancelled
+func (c *Cleaner) Start(ctx context.Context, interval time.Duration) error {
+	c.logger.Info("starting node cache cleanup", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("node cache cleanup stopping")
+			return ctx.Err()
+		case <-ticker.C:
+			_, _ = c.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce removes expired entries and logs how many were removed
+func (c *Cleaner) RunOnce(ctx context.Context) (int, error) {
+	removed, err := c.repo.DeleteExpired(ctx)
+	if err != nil {
+		c.logger.Error("failed to delete expired node cache entries", "error", err)
+		return 0, err
+	}
+	if removed > 0 {
+		c.logger.Info("deleted expired node cache entries", "count", removed)
+	}
+	return removed, nil
+}
@@ -0,0 +1,24 @@
+// Package nodecache stores the results of workflow nodes that opt in to
+// caching, so repeated executions can reuse a recent result instead of
+// calling the upstream service again.
+package nodecache
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when there is no fresh entry for a key
+var ErrNotFound = errors.New("node cache entry not found")
+
+// Entry is a cached node result
+type Entry struct {
+	TenantID   string          `db:"tenant_id" json:"tenant_id"`
+	Key        string          `db:"cache_key" json:"key"`
+	WorkflowID string          `db:"workflow_id" json:"workflow_id"`
+	NodeID     string          `db:"node_id" json:"node_id"`
+	Output     json.RawMessage `db:"output" json:"output"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+	ExpiresAt  time.Time       `db:"expires_at" json:"expires_at"`
+}
@@ -0,0 +1,96 @@
+package nodecache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository stores node cache entries in PostgreSQL
+type Repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new node cache repository
+func NewRepository(db *sqlx.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the tenant's unexpired entry for key, or ErrNotFound
+func (r *Repository) Get(ctx context.Context, tenantID, key string) (*Entry, error) {
+	query := `
+		SELECT tenant_id, cache_key, workflow_id, node_id, output, created_at, expires_at
+		FROM node_result_cache
+		WHERE tenant_id = $1 AND cache_key = $2 AND expires_at > NOW()
+	`
+
+	var entry Entry
+	if err := r.db.GetContext(ctx, &entry, query, tenantID, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get node cache entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// Put stores an entry, replacing any earlier entry for the same key
+func (r *Repository) Put(ctx context.Context, entry *Entry) error {
+	query := `
+		INSERT INTO node_result_cache (tenant_id, cache_key, workflow_id, node_id, output, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, cache_key) DO UPDATE
+		SET output = EXCLUDED.output, created_at = NOW(), expires_at = EXCLUDED.expires_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.TenantID,
+		entry.Key,
+		entry.WorkflowID,
+		entry.NodeID,
+		entry.Output,
+		entry.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store node cache entry: %w", err)
+	}
+	return nil
+}
+
+// Invalidate removes a tenant's entries, narrowed to a workflow and to one of
+// its nodes when those are given, and returns how many were removed
+func (r *Repository) Invalidate(ctx context.Context, tenantID string, workflowID *string, nodeID string) (int, error) {
+	query := `
+		DELETE FROM node_result_cache
+		WHERE tenant_id = $1
+		  AND ($2::uuid IS NULL OR workflow_id = $2::uuid)
+		  AND ($3 = '' OR node_id = $3)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tenantID, workflowID, nodeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate node cache: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to invalidate node cache: %w", err)
+	}
+	return int(removed), nil
+}
+
+// DeleteExpired removes expired entries of every tenant and returns how many
+// were removed
+func (r *Repository) DeleteExpired(ctx context.Context) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM node_result_cache WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired node cache entries: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired node cache entries: %w", err)
+	}
+	return int(removed), nil
+}
@@ -0,0 +1,113 @@
+package nodecache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockRepository(t *testing.T) (*Repository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func TestRepository_Get(t *testing.T) {
+	t.Run("returns an unexpired entry", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		now := time.Now()
+		mock.ExpectQuery(`FROM node_result_cache\s+WHERE tenant_id = \$1 AND cache_key = \$2 AND expires_at > NOW\(\)`).
+			WithArgs("tenant-1", "key-1").
+			WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "cache_key", "workflow_id", "node_id", "output", "created_at", "expires_at"}).
+				AddRow("tenant-1", "key-1", "wf-1", "http-1", []byte(`{"status_code":200}`), now, now.Add(time.Minute)))
+
+		entry, err := repo.Get(context.Background(), "tenant-1", "key-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "wf-1", entry.WorkflowID)
+		assert.Equal(t, "http-1", entry.NodeID)
+		assert.JSONEq(t, `{"status_code":200}`, string(entry.Output))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`FROM node_result_cache`).
+			WithArgs("tenant-1", "key-1").
+			WillReturnRows(sqlmock.NewRows([]string{"tenant_id"}))
+
+		_, err := repo.Get(context.Background(), "tenant-1", "key-1")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepository_Put(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	expiresAt := time.Now().Add(5 * time.Minute)
+	output := json.RawMessage(`{"status_code":200}`)
+	mock.ExpectExec(`INSERT INTO node_result_cache .* ON CONFLICT \(tenant_id, cache_key\) DO UPDATE`).
+		WithArgs("tenant-1", "key-1", "wf-1", "http-1", output, expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Put(context.Background(), &Entry{
+		TenantID:   "tenant-1",
+		Key:        "key-1",
+		WorkflowID: "wf-1",
+		NodeID:     "http-1",
+		Output:     output,
+		ExpiresAt:  expiresAt,
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_Invalidate(t *testing.T) {
+	workflowID := "wf-1"
+
+	tests := []struct {
+		name       string
+		workflowID *string
+		nodeID     string
+	}{
+		{name: "whole tenant"},
+		{name: "one workflow", workflowID: &workflowID},
+		{name: "one node", workflowID: &workflowID, nodeID: "http-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectExec(`DELETE FROM node_result_cache\s+WHERE tenant_id = \$1`).
+				WithArgs("tenant-1", tt.workflowID, tt.nodeID).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			removed, err := repo.Invalidate(context.Background(), "tenant-1", tt.workflowID, tt.nodeID)
+
+			require.NoError(t, err)
+			assert.Equal(t, 3, removed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepository_DeleteExpired(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectExec(`DELETE FROM node_result_cache WHERE expires_at <= NOW\(\)`).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+
+	removed, err := repo.DeleteExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, removed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -214,6 +214,25 @@ func (s *InAppService) NotifyWorkflowExecution(ctx context.Context, tenantID uui
 	return s.Create(ctx, notif)
 }
 
+// NotifyWorkflowAutoPaused creates a notification that a workflow was paused for a high failure rate
+func (s *InAppService) NotifyWorkflowAutoPaused(ctx context.Context, tenantID uuid.UUID, userID, workflowName, reason, workflowURL string) error {
+	notif := &InAppNotification{
+		TenantID: tenantID,
+		UserID:   userID,
+		Title:    "Workflow Auto-Paused",
+		Message:  fmt.Sprintf("Workflow '%s' was paused automatically: %s. Re-enable it once the issue is fixed.", workflowName, reason),
+		Type:     NotificationTypeWarning,
+		Link:     workflowURL,
+		Metadata: map[string]interface{}{
+			"workflow_name": workflowName,
+			"reason":        reason,
+			"event_type":    "workflow_auto_paused",
+		},
+	}
+
+	return s.Create(ctx, notif)
+}
+
 // broadcastNotification sends a notification to the user via WebSocket
 func (s *InAppService) broadcastNotification(notif *InAppNotification) {
 	if s.hub == nil {
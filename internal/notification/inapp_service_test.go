@@ -354,3 +354,31 @@ func TestInAppService_NotifyWorkflowExecution(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockHub.AssertExpectations(t)
 }
+
+func TestInAppService_NotifyWorkflowAutoPaused(t *testing.T) {
+	mockRepo := new(MockInAppRepository)
+	mockHub := new(MockWebSocketHub)
+
+	service := NewInAppService(mockRepo, mockHub)
+
+	tenantID := uuid.New()
+	userID := "user-123"
+	reason := "failure rate 90% (9 of 10 executions) in the last 60 minutes exceeded the 80% threshold"
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(n *InAppNotification) bool {
+		return n.TenantID == tenantID &&
+			n.UserID == userID &&
+			n.Type == NotificationTypeWarning &&
+			n.Title == "Workflow Auto-Paused" &&
+			n.Metadata["reason"] == reason &&
+			n.Metadata["event_type"] == "workflow_auto_paused"
+	})).Return(nil)
+
+	mockHub.On("BroadcastToRoom", mock.Anything, mock.Anything).Return()
+
+	err := service.NotifyWorkflowAutoPaused(context.Background(), tenantID, userID, "Data Pipeline", reason, "/workflows/wf-1")
+	require.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockHub.AssertExpectations(t)
+}
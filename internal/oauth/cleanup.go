@@ -0,0 +1,63 @@
+package oauth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StateDeleter deletes OAuth states that can no longer complete a flow
+type StateDeleter interface {
+	DeleteExpiredStates(ctx context.Context, usedGracePeriod time.Duration) (int, error)
+}
+
+// StateCleaner periodically deletes expired OAuth states, and used states
+// once their grace period has passed, so the oauth_states table stays bounded
+type StateCleaner struct {
+	repo            StateDeleter
+	usedGracePeriod time.Duration
+	logger          *slog.Logger
+}
+
+// NewStateCleaner creates a new OAuth state cleaner
+func NewStateCleaner(repo StateDeleter, usedGracePeriod time.Duration, logger *slog.Logger) *StateCleaner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &StateCleaner{
+		repo:            repo,
+		usedGracePeriod: usedGracePeriod,
+		logger:          logger,
+	}
+}
+
+// Start deletes stale states every interval until ctx is cancelled
+func (c *StateCleaner) Start(ctx context.Context, interval time.Duration) error {
+	c.logger.Info("starting OAuth state cleanup", "interval", interval, "used_grace_period", c.usedGracePeriod)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("OAuth state cleanup stopping")
+			return ctx.Err()
+		case <-ticker.C:
+			_, _ = c.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce deletes stale states and logs how many were removed
+func (c *StateCleaner) RunOnce(ctx context.Context) (int, error) {
+	deleted, err := c.repo.DeleteExpiredStates(ctx, c.usedGracePeriod)
+	if err != nil {
+		c.logger.Error("failed to delete expired OAuth states", "error", err)
+		return 0, err
+	}
+	if deleted > 0 {
+		c.logger.Info("deleted expired OAuth states", "count", deleted)
+	}
+	return deleted, nil
+}
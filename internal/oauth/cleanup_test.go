@@ -0,0 +1,54 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStateDeleter records the grace period it was called with
+type fakeStateDeleter struct {
+	deleted     int
+	err         error
+	gracePeriod time.Duration
+}
+
+func (f *fakeStateDeleter) DeleteExpiredStates(ctx context.Context, usedGracePeriod time.Duration) (int, error) {
+	f.gracePeriod = usedGracePeriod
+	return f.deleted, f.err
+}
+
+func TestStateCleaner_RunOnce(t *testing.T) {
+	repo := &fakeStateDeleter{deleted: 3}
+	cleaner := NewStateCleaner(repo, 5*time.Minute, nil)
+
+	deleted, err := cleaner.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+	assert.Equal(t, 5*time.Minute, repo.gracePeriod)
+}
+
+func TestStateCleaner_RunOnce_Error(t *testing.T) {
+	repo := &fakeStateDeleter{err: errors.New("connection reset")}
+	cleaner := NewStateCleaner(repo, time.Minute, nil)
+
+	deleted, err := cleaner.RunOnce(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, deleted)
+}
+
+func TestStateCleaner_Start_StopsOnCancel(t *testing.T) {
+	cleaner := NewStateCleaner(&fakeStateDeleter{}, time.Minute, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := cleaner.Start(ctx, time.Hour)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,220 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// defaultDevicePollInterval is the RFC 8628 default when the provider
+	// does not return an interval
+	defaultDevicePollInterval = 5
+	// slowDownIncrement is added to the interval on a slow_down response
+	slowDownIncrement = 5
+)
+
+// StartDeviceAuthorization starts the device authorization flow and returns
+// the user code and verification URI to show the user
+func (s *Service) StartDeviceAuthorization(ctx context.Context, userID, tenantID, providerKey string) (*DeviceAuthorization, error) {
+	providerConfig, err := s.repo.GetProviderByKey(ctx, providerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	provider, ok := s.providers[providerKey]
+	if !ok {
+		return nil, ErrInvalidProvider
+	}
+	deviceProvider, ok := provider.(DeviceAuthorizationProvider)
+	if !ok {
+		return nil, ErrUnsupportedGrant
+	}
+
+	scopes := providerConfig.DefaultScopes
+	deviceResp, err := deviceProvider.RequestDeviceCode(ctx, providerConfig.ClientID, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+
+	interval := deviceResp.Interval
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	now := time.Now()
+	auth := &DeviceAuthorization{
+		DeviceCode:              deviceResp.DeviceCode,
+		UserID:                  userID,
+		TenantID:                tenantID,
+		ProviderKey:             providerKey,
+		UserCode:                deviceResp.UserCode,
+		VerificationURI:         deviceResp.VerificationURI,
+		VerificationURIComplete: deviceResp.VerificationURIComplete,
+		Scopes:                  scopes,
+		Interval:                interval,
+		Status:                  DeviceAuthorizationPending,
+		CreatedAt:               now,
+		ExpiresAt:               now.Add(time.Duration(deviceResp.ExpiresIn) * time.Second),
+		NextPollAt:              now.Add(time.Duration(interval) * time.Second),
+	}
+
+	if err := s.repo.CreateDeviceAuthorization(ctx, auth); err != nil {
+		return nil, fmt.Errorf("failed to store device authorization: %w", err)
+	}
+
+	return auth, nil
+}
+
+// PollDeviceToken polls a device authorization, creating the connection
+// once the user has approved it. The provider is only contacted once the
+// polling interval has elapsed; otherwise the stored status is returned.
+func (s *Service) PollDeviceToken(ctx context.Context, userID, tenantID, deviceCode string) (*DeviceAuthorization, error) {
+	auth, err := s.repo.GetDeviceAuthorization(ctx, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify authorization matches user and tenant
+	if auth.UserID != userID || auth.TenantID != tenantID {
+		return nil, ErrDeviceAuthorizationNotFound
+	}
+
+	if err := s.pollDeviceAuthorization(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+// PollDueDeviceAuthorizations polls up to limit pending device authorizations
+// whose interval has elapsed and returns how many were polled
+func (s *Service) PollDueDeviceAuthorizations(ctx context.Context, limit int) (int, error) {
+	auths, err := s.repo.ListDueDeviceAuthorizations(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, auth := range auths {
+		if err := s.pollDeviceAuthorization(ctx, auth); err != nil {
+			errs = append(errs, fmt.Errorf("device authorization for %s: %w", auth.ProviderKey, err))
+		}
+	}
+
+	return len(auths), errors.Join(errs...)
+}
+
+// pollDeviceAuthorization exchanges the device code if the authorization is
+// pending and due, and stores the resulting status on auth
+func (s *Service) pollDeviceAuthorization(ctx context.Context, auth *DeviceAuthorization) error {
+	if auth.Status != DeviceAuthorizationPending || !auth.IsPollDue() {
+		return nil
+	}
+
+	now := time.Now()
+	if auth.IsExpired() {
+		auth.Status = DeviceAuthorizationExpired
+		return s.repo.UpdateDeviceAuthorization(ctx, auth)
+	}
+
+	provider, ok := s.providers[auth.ProviderKey]
+	if !ok {
+		return ErrInvalidProvider
+	}
+	deviceProvider, ok := provider.(DeviceAuthorizationProvider)
+	if !ok {
+		return ErrUnsupportedGrant
+	}
+
+	providerConfig, err := s.repo.GetProviderByKey(ctx, auth.ProviderKey)
+	if err != nil {
+		return fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	clientSecret, err := s.decryptClientSecret(ctx, providerConfig)
+	if err != nil {
+		return err
+	}
+
+	tokenResp, pollErr := deviceProvider.PollDeviceToken(ctx, providerConfig.ClientID, clientSecret, auth.DeviceCode)
+	auth.LastPolledAt = &now
+
+	switch {
+	case pollErr == nil:
+		conn, err := s.createUserConnection(ctx, provider, providerConfig, auth.UserID, auth.TenantID, auth.Scopes, tokenResp, "device_authorize")
+		if err != nil {
+			return err
+		}
+		auth.Status = DeviceAuthorizationApproved
+		auth.ConnectionID = conn.ID
+	case errors.Is(pollErr, ErrAuthorizationPending):
+	case errors.Is(pollErr, ErrSlowDown):
+		auth.Interval += slowDownIncrement
+	case errors.Is(pollErr, ErrAccessDenied):
+		auth.Status = DeviceAuthorizationDenied
+	case errors.Is(pollErr, ErrDeviceCodeExpired):
+		auth.Status = DeviceAuthorizationExpired
+	default:
+		// Transient provider failures leave the authorization pending
+		auth.NextPollAt = now.Add(time.Duration(auth.Interval) * time.Second)
+		if err := s.repo.UpdateDeviceAuthorization(ctx, auth); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed to poll device token: %w", pollErr)
+	}
+
+	auth.NextPollAt = now.Add(time.Duration(auth.Interval) * time.Second)
+	return s.repo.UpdateDeviceAuthorization(ctx, auth)
+}
+
+// DeviceTokenPoller polls pending device authorizations
+type DeviceTokenPoller interface {
+	PollDueDeviceAuthorizations(ctx context.Context, limit int) (int, error)
+}
+
+// DevicePoller exchanges approved device codes in the background so device
+// authorizations complete even when the client stops polling
+type DevicePoller struct {
+	poller    DeviceTokenPoller
+	batchSize int
+	logger    *slog.Logger
+}
+
+// NewDevicePoller creates a new device authorization poller
+func NewDevicePoller(poller DeviceTokenPoller, batchSize int, logger *slog.Logger) *DevicePoller {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DevicePoller{
+		poller:    poller,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// Start polls due device authorizations every interval until ctx is cancelled
+func (p *DevicePoller) Start(ctx context.Context, interval time.Duration) error {
+	p.logger.Info("starting OAuth device authorization poller", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("OAuth device authorization poller stopping")
+			return ctx.Err()
+		case <-ticker.C:
+			polled, err := p.poller.PollDueDeviceAuthorizations(ctx, p.batchSize)
+			if err != nil {
+				p.logger.Error("error polling device authorizations", "error", err)
+			}
+			if polled > 0 {
+				p.logger.Debug("polled device authorizations", "count", polled)
+			}
+		}
+	}
+}
@@ -3,7 +3,10 @@ package oauth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // Common errors
@@ -16,8 +19,69 @@ var (
 	ErrInvalidCode         = errors.New("invalid authorization code")
 	ErrTokenRefreshFailed  = errors.New("failed to refresh OAuth token")
 	ErrMissingRefreshToken = errors.New("refresh token not available")
+	ErrUnsupportedGrant    = errors.New("OAuth provider does not support this grant type")
+
+	ErrDeviceAuthorizationNotFound = errors.New("device authorization not found")
+
+	// Device token errors reported by providers while polling (RFC 8628 section 3.5)
+	ErrAuthorizationPending = errors.New("device authorization pending")
+	ErrSlowDown             = errors.New("device token polled too frequently")
+	ErrAccessDenied         = errors.New("device authorization denied")
+	ErrDeviceCodeExpired    = errors.New("device code has expired")
 )
 
+// Authorization error codes returned to the redirect URI (RFC 6749 section 4.1.2.1)
+const (
+	ProviderErrorAccessDenied            = "access_denied"
+	ProviderErrorInvalidRequest          = "invalid_request"
+	ProviderErrorUnauthorizedClient      = "unauthorized_client"
+	ProviderErrorUnsupportedResponseType = "unsupported_response_type"
+	ProviderErrorInvalidScope            = "invalid_scope"
+)
+
+// ProviderError is an authorization error a provider returned to the callback
+// instead of a code
+type ProviderError struct {
+	ProviderKey string
+	Code        string
+	Description string
+}
+
+// Error returns the provider's description, or its error code when there is none
+func (e *ProviderError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("OAuth provider error %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("OAuth provider error: %s", e.Code)
+}
+
+// Reason returns a human-readable reason for the failure
+func (e *ProviderError) Reason() string {
+	if e.Description != "" {
+		return e.Description
+	}
+	if e.Denied() {
+		return "authorization was denied"
+	}
+	return "the provider rejected the authorization request (" + e.Code + ")"
+}
+
+// Denied reports whether the user declined the authorization
+func (e *ProviderError) Denied() bool {
+	return e.Code == ProviderErrorAccessDenied
+}
+
+// Misconfigured reports whether the provider rejected the request because of
+// how the OAuth application or its scopes are configured
+func (e *ProviderError) Misconfigured() bool {
+	switch e.Code {
+	case ProviderErrorInvalidRequest, ProviderErrorUnauthorizedClient,
+		ProviderErrorUnsupportedResponseType, ProviderErrorInvalidScope:
+		return true
+	}
+	return false
+}
+
 // ProviderStatus represents the status of an OAuth provider
 type ProviderStatus string
 
@@ -26,6 +90,18 @@ const (
 	ProviderStatusInactive ProviderStatus = "inactive"
 )
 
+// GrantType is the OAuth 2.0 grant used to obtain tokens
+type GrantType string
+
+const (
+	// GrantTypeAuthorizationCode is the user flow: the user authorizes in the
+	// browser and the code is exchanged for tokens
+	GrantTypeAuthorizationCode GrantType = "authorization_code"
+	// GrantTypeClientCredentials is the service flow: the client requests
+	// tokens with its own credentials and no user is involved
+	GrantTypeClientCredentials GrantType = "client_credentials"
+)
+
 // ConnectionStatus represents the status of an OAuth connection
 type ConnectionStatus string
 
@@ -51,13 +127,24 @@ type OAuthProvider struct {
 	ClientSecretAuthTag   []byte                 `json:"-" db:"client_secret_auth_tag"`
 	ClientSecretEncDEK    []byte                 `json:"-" db:"client_secret_encrypted_dek"`
 	ClientSecretKMSKeyID  string                 `json:"-" db:"client_secret_kms_key_id"`
+	GrantType             GrantType              `json:"grant_type" db:"grant_type"`
 	Status                ProviderStatus         `json:"status" db:"status"`
 	Config                map[string]interface{} `json:"config,omitempty" db:"config"`
 	CreatedAt             time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt             time.Time              `json:"updated_at" db:"updated_at"`
 }
 
-// OAuthConnection represents a user's OAuth connection to a provider
+// UsesGrant reports whether the provider issues tokens with the grant.
+// Providers without a grant type use the authorization code grant.
+func (p *OAuthProvider) UsesGrant(grant GrantType) bool {
+	if p.GrantType == "" {
+		return grant == GrantTypeAuthorizationCode
+	}
+	return p.GrantType == grant
+}
+
+// OAuthConnection represents an OAuth connection to a provider. Connections
+// made with the client credentials grant belong to the tenant and have no UserID.
 type OAuthConnection struct {
 	ID               string `json:"id" db:"id"`
 	UserID           string `json:"user_id" db:"user_id"`
@@ -83,6 +170,7 @@ type OAuthConnection struct {
 	TokenExpiry *time.Time       `json:"token_expiry,omitempty" db:"token_expiry"`
 	Scopes      []string         `json:"scopes" db:"scopes"`
 	Status      ConnectionStatus `json:"status" db:"status"`
+	GrantType   GrantType        `json:"grant_type" db:"grant_type"`
 
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
@@ -93,20 +181,67 @@ type OAuthConnection struct {
 	Metadata         map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
 }
 
-// IsExpired checks if the OAuth token has expired
-func (c *OAuthConnection) IsExpired() bool {
+// IsExpired checks if the OAuth token has expired. The token stays valid
+// for skew past its expiry, so a node whose clock runs ahead does not
+// discard it early.
+func (c *OAuthConnection) IsExpired(skew time.Duration) bool {
 	if c.TokenExpiry == nil {
 		return false
 	}
-	return time.Now().After(*c.TokenExpiry)
+	return time.Now().After(c.TokenExpiry.Add(skew))
+}
+
+// IsServiceConnection reports whether the connection was made with the client
+// credentials grant rather than by a user
+func (c *OAuthConnection) IsServiceConnection() bool {
+	return c.GrantType == GrantTypeClientCredentials
 }
 
-// NeedsRefresh checks if token should be refreshed (expires in < 5 minutes)
-func (c *OAuthConnection) NeedsRefresh() bool {
+// refreshWindow is how long before expiry a token should be refreshed
+const refreshWindow = 5 * time.Minute
+
+// NeedsRefresh checks if token should be refreshed (expires in < 5 minutes).
+// The window is widened by skew, so a node whose clock runs behind still
+// refreshes the token before the provider expires it.
+func (c *OAuthConnection) NeedsRefresh(skew time.Duration) bool {
 	if c.TokenExpiry == nil {
 		return false
 	}
-	return time.Now().Add(5 * time.Minute).After(*c.TokenExpiry)
+	return time.Now().Add(refreshWindow + skew).After(*c.TokenExpiry)
+}
+
+// TokenExpiry returns when a token issued at issuedAt with a lifetime of
+// expiresIn seconds should be treated as expired. The lifetime is relative
+// to the local clock and shortened by margin, capped at half the lifetime,
+// so the token is replaced before the provider rejects it whatever time
+// the provider's clock shows. It returns nil when expiresIn is not set.
+func TokenExpiry(issuedAt time.Time, expiresIn int, margin time.Duration) *time.Time {
+	if expiresIn <= 0 {
+		return nil
+	}
+	lifetime := time.Duration(expiresIn) * time.Second
+	expiry := issuedAt.Add(lifetime - min(max(margin, 0), lifetime/2))
+	return &expiry
+}
+
+// ConnectionListFilter narrows and pages a user's connection list
+type ConnectionListFilter struct {
+	ProviderKey string
+	Status      ConnectionStatus
+	// NeedsRefresh, when set, keeps only connections whose token does (true)
+	// or does not (false) expire within the refresh window
+	NeedsRefresh *bool
+	// Limit and Offset select a page by position; zero Limit means no limit
+	Limit  int
+	Offset int
+	// Page selects a page by cursor instead of Limit and Offset
+	Page pagination.Params
+}
+
+// ProviderListFilter narrows the provider list
+type ProviderListFilter struct {
+	// Status keeps only providers with this status; empty lists every provider
+	Status ProviderStatus
 }
 
 // OAuthState represents temporary OAuth state for CSRF protection
@@ -124,9 +259,51 @@ type OAuthState struct {
 	Used         bool                   `json:"used" db:"used"`
 }
 
-// IsExpired checks if the OAuth state has expired
-func (s *OAuthState) IsExpired() bool {
-	return time.Now().After(s.ExpiresAt)
+// IsExpired checks if the OAuth state has expired. The state stays valid
+// for skew past ExpiresAt, which was set by the node that started the flow
+// and may have a different clock.
+func (s *OAuthState) IsExpired(skew time.Duration) bool {
+	return time.Now().After(s.ExpiresAt.Add(skew))
+}
+
+// DeviceAuthorizationStatus represents the status of a device authorization
+type DeviceAuthorizationStatus string
+
+const (
+	DeviceAuthorizationPending  DeviceAuthorizationStatus = "pending"
+	DeviceAuthorizationApproved DeviceAuthorizationStatus = "approved"
+	DeviceAuthorizationDenied   DeviceAuthorizationStatus = "denied"
+	DeviceAuthorizationExpired  DeviceAuthorizationStatus = "expired"
+)
+
+// DeviceAuthorization tracks a device authorization grant (RFC 8628) while
+// the user approves it on another device and the device code is polled
+type DeviceAuthorization struct {
+	DeviceCode              string                    `json:"device_code" db:"device_code"`
+	UserID                  string                    `json:"user_id" db:"user_id"`
+	TenantID                string                    `json:"tenant_id" db:"tenant_id"`
+	ProviderKey             string                    `json:"provider_key" db:"provider_key"`
+	UserCode                string                    `json:"user_code" db:"user_code"`
+	VerificationURI         string                    `json:"verification_uri" db:"verification_uri"`
+	VerificationURIComplete string                    `json:"verification_uri_complete,omitempty" db:"verification_uri_complete"`
+	Scopes                  []string                  `json:"scopes,omitempty" db:"scopes"`
+	Interval                int                       `json:"interval" db:"interval_seconds"` // Minimum seconds between polls
+	Status                  DeviceAuthorizationStatus `json:"status" db:"status"`
+	ConnectionID            string                    `json:"connection_id,omitempty" db:"connection_id"`
+	CreatedAt               time.Time                 `json:"created_at" db:"created_at"`
+	ExpiresAt               time.Time                 `json:"expires_at" db:"expires_at"`
+	NextPollAt              time.Time                 `json:"-" db:"next_poll_at"`
+	LastPolledAt            *time.Time                `json:"last_polled_at,omitempty" db:"last_polled_at"`
+}
+
+// IsExpired checks if the device code has expired
+func (d *DeviceAuthorization) IsExpired() bool {
+	return time.Now().After(d.ExpiresAt)
+}
+
+// IsPollDue reports whether the polling interval has elapsed since the last poll
+func (d *DeviceAuthorization) IsPollDue() bool {
+	return !time.Now().Before(d.NextPollAt)
 }
 
 // OAuthConnectionLog represents an audit log entry
@@ -142,6 +319,80 @@ type OAuthConnectionLog struct {
 	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
 }
 
+// MaxBulkRevoke is the most connections one bulk revocation may name
+const MaxBulkRevoke = 100
+
+// BulkOperationError represents an error for a single connection in a bulk operation
+type BulkOperationError struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// BulkRevokeResult summarizes a bulk connection revocation
+type BulkRevokeResult struct {
+	ProviderKey string `json:"provider_key"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	Revoked     int    `json:"revoked"`
+	Failed      int    `json:"failed"`
+	// ProviderRevokeFailed counts connections revoked locally whose
+	// provider-side token revocation failed
+	ProviderRevokeFailed int `json:"provider_revoke_failed"`
+}
+
+// refreshFailureWindow is how far back ConnectionHealth looks for failed
+// token refreshes
+const refreshFailureWindow = 24 * time.Hour
+
+// ConnectionHealth summarizes the state of a tenant's OAuth connections
+type ConnectionHealth struct {
+	TenantID string                   `json:"tenant_id"`
+	Total    int                      `json:"total"`
+	ByStatus map[ConnectionStatus]int `json:"by_status"`
+	// NeedsRefresh lists active connections whose token has expired or
+	// expires within the refresh window, soonest first
+	NeedsRefresh []ConnectionRefreshDue `json:"needs_refresh"`
+	// RefreshFailures lists connections with failed token refreshes since
+	// FailuresSince, most recent failure first
+	RefreshFailures []ConnectionRefreshFailure `json:"refresh_failures"`
+	Providers       []ProviderHealth           `json:"providers"`
+	FailuresSince   time.Time                  `json:"failures_since"`
+	GeneratedAt     time.Time                  `json:"generated_at"`
+}
+
+// ConnectionRefreshDue is an active connection whose token needs refreshing
+type ConnectionRefreshDue struct {
+	ConnectionID  string     `json:"connection_id"`
+	UserID        string     `json:"user_id,omitempty"`
+	ProviderKey   string     `json:"provider_key"`
+	TokenExpiry   *time.Time `json:"token_expiry,omitempty"`
+	LastRefreshAt *time.Time `json:"last_refresh_at,omitempty"`
+}
+
+// ConnectionRefreshFailure is a connection whose token refreshes have
+// recently failed
+type ConnectionRefreshFailure struct {
+	ConnectionID string           `json:"connection_id"`
+	UserID       string           `json:"user_id,omitempty"`
+	ProviderKey  string           `json:"provider_key"`
+	Status       ConnectionStatus `json:"status"`
+	Failures     int              `json:"failures"`
+	LastError    string           `json:"last_error"`
+	LastFailedAt time.Time        `json:"last_failed_at"`
+	// Recovered reports whether a refresh has succeeded since the last failure
+	Recovered bool `json:"recovered"`
+}
+
+// ProviderHealth summarizes a tenant's connections to one provider and the
+// last failed action logged for it
+type ProviderHealth struct {
+	ProviderKey     string                   `json:"provider_key"`
+	Total           int                      `json:"total"`
+	ByStatus        map[ConnectionStatus]int `json:"by_status"`
+	LastError       string                   `json:"last_error,omitempty"`
+	LastErrorAction string                   `json:"last_error_action,omitempty"`
+	LastErrorAt     *time.Time               `json:"last_error_at,omitempty"`
+}
+
 // AuthorizeInput represents input for starting OAuth authorization
 type AuthorizeInput struct {
 	ProviderKey string   `json:"provider_key"`
@@ -151,9 +402,10 @@ type AuthorizeInput struct {
 
 // CallbackInput represents OAuth callback parameters
 type CallbackInput struct {
-	Code  string `json:"code"`
-	State string `json:"state"`
-	Error string `json:"error,omitempty"`
+	Code             string `json:"code"`
+	State            string `json:"state"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
 }
 
 // OAuthService defines the OAuth service interface
@@ -161,8 +413,8 @@ type OAuthService interface {
 	// GetProvider retrieves an OAuth provider by key
 	GetProvider(ctx context.Context, providerKey string) (*OAuthProvider, error)
 
-	// ListProviders lists all available OAuth providers
-	ListProviders(ctx context.Context) ([]*OAuthProvider, error)
+	// ListProviders lists the OAuth providers matching filter
+	ListProviders(ctx context.Context, filter ProviderListFilter) ([]*OAuthProvider, error)
 
 	// Authorize starts the OAuth authorization flow
 	Authorize(ctx context.Context, userID, tenantID string, input *AuthorizeInput) (string, error)
@@ -173,12 +425,39 @@ type OAuthService interface {
 	// GetConnection retrieves a user's OAuth connection
 	GetConnection(ctx context.Context, userID, tenantID, providerKey string) (*OAuthConnection, error)
 
-	// ListConnections lists all OAuth connections for a user
-	ListConnections(ctx context.Context, userID, tenantID string) ([]*OAuthConnection, error)
+	// ListConnections lists a user's OAuth connections matching filter; the
+	// zero filter lists all of them
+	ListConnections(ctx context.Context, userID, tenantID string, filter ConnectionListFilter) ([]*OAuthConnection, error)
+
+	// CreateServiceConnection requests tokens with the client credentials grant
+	// and stores a tenant connection that is not tied to a user
+	CreateServiceConnection(ctx context.Context, tenantID, providerKey string, scopes []string) (*OAuthConnection, error)
+
+	// StartDeviceAuthorization starts the device authorization flow and returns
+	// the user code and verification URI to show the user
+	StartDeviceAuthorization(ctx context.Context, userID, tenantID, providerKey string) (*DeviceAuthorization, error)
+
+	// PollDeviceToken polls a device authorization, creating the connection
+	// once the user has approved it
+	PollDeviceToken(ctx context.Context, userID, tenantID, deviceCode string) (*DeviceAuthorization, error)
 
 	// RevokeConnection revokes an OAuth connection
 	RevokeConnection(ctx context.Context, userID, tenantID, connectionID string) error
 
+	// RevokeConnections revokes several of a user's connections and reports
+	// which succeeded and which failed
+	RevokeConnections(ctx context.Context, userID, tenantID string, connectionIDs []string) ([]string, []BulkOperationError)
+
+	// RevokeConnectionsByProvider revokes all of a tenant's connections for a provider
+	RevokeConnectionsByProvider(ctx context.Context, tenantID, providerKey, reason string) (*BulkRevokeResult, error)
+
+	// RevokeAllConnectionsByProvider revokes every tenant's connections for a provider
+	RevokeAllConnectionsByProvider(ctx context.Context, providerKey, reason string) (*BulkRevokeResult, error)
+
+	// GetConnectionHealth summarizes a tenant's connections by status and lists
+	// those needing a refresh or with recently failed refreshes
+	GetConnectionHealth(ctx context.Context, tenantID string) (*ConnectionHealth, error)
+
 	// RefreshToken refreshes an expired OAuth token
 	RefreshToken(ctx context.Context, connectionID string) error
 
@@ -193,21 +472,29 @@ type OAuthService interface {
 type OAuthRepository interface {
 	// Provider operations
 	GetProviderByKey(ctx context.Context, providerKey string) (*OAuthProvider, error)
-	ListProviders(ctx context.Context) ([]*OAuthProvider, error)
+	ListProviders(ctx context.Context, filter ProviderListFilter) ([]*OAuthProvider, error)
 
 	// Connection operations
 	CreateConnection(ctx context.Context, conn *OAuthConnection) error
 	GetConnection(ctx context.Context, id string) (*OAuthConnection, error)
 	GetConnectionByUserProvider(ctx context.Context, userID, tenantID, providerKey string) (*OAuthConnection, error)
-	ListConnectionsByUser(ctx context.Context, userID, tenantID string) ([]*OAuthConnection, error)
+	ListConnectionsByUser(ctx context.Context, userID, tenantID string, filter ConnectionListFilter) ([]*OAuthConnection, error)
+	ListConnectionsByProvider(ctx context.Context, tenantID, providerKey string) ([]*OAuthConnection, error)
 	UpdateConnection(ctx context.Context, conn *OAuthConnection) error
 	DeleteConnection(ctx context.Context, id string) error
+	GetConnectionHealth(ctx context.Context, tenantID string, refreshBefore, failuresSince time.Time) (*ConnectionHealth, error)
 
 	// State operations
 	CreateState(ctx context.Context, state *OAuthState) error
 	GetState(ctx context.Context, stateStr string) (*OAuthState, error)
 	MarkStateUsed(ctx context.Context, stateStr string) error
-	DeleteExpiredStates(ctx context.Context) (int, error)
+	DeleteExpiredStates(ctx context.Context, usedGracePeriod time.Duration) (int, error)
+
+	// Device authorization operations
+	CreateDeviceAuthorization(ctx context.Context, auth *DeviceAuthorization) error
+	GetDeviceAuthorization(ctx context.Context, deviceCode string) (*DeviceAuthorization, error)
+	ListDueDeviceAuthorizations(ctx context.Context, limit int) ([]*DeviceAuthorization, error)
+	UpdateDeviceAuthorization(ctx context.Context, auth *DeviceAuthorization) error
 
 	// Log operations
 	CreateLog(ctx context.Context, log *OAuthConnectionLog) error
@@ -11,6 +11,7 @@ func TestOAuthConnection_IsExpired(t *testing.T) {
 	tests := []struct {
 		name        string
 		tokenExpiry *time.Time
+		skew        time.Duration
 		want        bool
 	}{
 		{
@@ -33,6 +34,19 @@ func TestOAuthConnection_IsExpired(t *testing.T) {
 			tokenExpiry: timePtr(time.Now().Add(-1 * time.Second)),
 			want:        true,
 		},
+		{
+			// The node that stored the expiry runs 20s behind this one
+			name:        "expired by skew within tolerance - not expired",
+			tokenExpiry: timePtr(time.Now().Add(-20 * time.Second)),
+			skew:        30 * time.Second,
+			want:        false,
+		},
+		{
+			name:        "expired beyond tolerance - expired",
+			tokenExpiry: timePtr(time.Now().Add(-45 * time.Second)),
+			skew:        30 * time.Second,
+			want:        true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -40,7 +54,7 @@ func TestOAuthConnection_IsExpired(t *testing.T) {
 			conn := &OAuthConnection{
 				TokenExpiry: tt.tokenExpiry,
 			}
-			got := conn.IsExpired()
+			got := conn.IsExpired(tt.skew)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -50,6 +64,7 @@ func TestOAuthConnection_NeedsRefresh(t *testing.T) {
 	tests := []struct {
 		name        string
 		tokenExpiry *time.Time
+		skew        time.Duration
 		want        bool
 	}{
 		{
@@ -72,6 +87,19 @@ func TestOAuthConnection_NeedsRefresh(t *testing.T) {
 			tokenExpiry: timePtr(time.Now().Add(-1 * time.Hour)),
 			want:        true,
 		},
+		{
+			// This node may run up to a minute behind the provider
+			name:        "expires just outside the window - refresh within tolerance",
+			tokenExpiry: timePtr(time.Now().Add(5*time.Minute + 30*time.Second)),
+			skew:        time.Minute,
+			want:        true,
+		},
+		{
+			name:        "expires beyond window and tolerance - no refresh needed",
+			tokenExpiry: timePtr(time.Now().Add(7 * time.Minute)),
+			skew:        time.Minute,
+			want:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -79,7 +107,7 @@ func TestOAuthConnection_NeedsRefresh(t *testing.T) {
 			conn := &OAuthConnection{
 				TokenExpiry: tt.tokenExpiry,
 			}
-			got := conn.NeedsRefresh()
+			got := conn.NeedsRefresh(tt.skew)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -89,6 +117,7 @@ func TestOAuthState_IsExpired(t *testing.T) {
 	tests := []struct {
 		name      string
 		expiresAt time.Time
+		skew      time.Duration
 		want      bool
 	}{
 		{
@@ -106,6 +135,20 @@ func TestOAuthState_IsExpired(t *testing.T) {
 			expiresAt: time.Now().Add(-1 * time.Second),
 			want:      true,
 		},
+		{
+			// The API node that created the state runs 10s behind the one
+			// handling the callback
+			name:      "expired by skew within tolerance - not expired",
+			expiresAt: time.Now().Add(-10 * time.Second),
+			skew:      30 * time.Second,
+			want:      false,
+		},
+		{
+			name:      "expired beyond tolerance - expired",
+			expiresAt: time.Now().Add(-time.Minute),
+			skew:      30 * time.Second,
+			want:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,13 +156,89 @@ func TestOAuthState_IsExpired(t *testing.T) {
 			state := &OAuthState{
 				ExpiresAt: tt.expiresAt,
 			}
-			got := state.IsExpired()
+			got := state.IsExpired(tt.skew)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestTokenExpiry(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresIn int
+		margin    time.Duration
+		want      *time.Time
+	}{
+		{name: "no expires_in", expiresIn: 0, margin: 30 * time.Second},
+		{
+			name:      "margin taken off the lifetime",
+			expiresIn: 3600,
+			margin:    30 * time.Second,
+			want:      timePtr(issuedAt.Add(time.Hour - 30*time.Second)),
+		},
+		{
+			name:      "margin capped at half a short lifetime",
+			expiresIn: 40,
+			margin:    30 * time.Second,
+			want:      timePtr(issuedAt.Add(20 * time.Second)),
+		},
+		{
+			name:      "no margin",
+			expiresIn: 3600,
+			want:      timePtr(issuedAt.Add(time.Hour)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TokenExpiry(issuedAt, tt.expiresIn, tt.margin))
+		})
+	}
+}
+
 // Helper function
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestOAuthProvider_UsesGrant(t *testing.T) {
+	tests := []struct {
+		name      string
+		grantType GrantType
+		grant     GrantType
+		want      bool
+	}{
+		{"unset defaults to authorization code", "", GrantTypeAuthorizationCode, true},
+		{"unset does not use client credentials", "", GrantTypeClientCredentials, false},
+		{"client credentials provider", GrantTypeClientCredentials, GrantTypeClientCredentials, true},
+		{"client credentials provider has no user flow", GrantTypeClientCredentials, GrantTypeAuthorizationCode, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &OAuthProvider{GrantType: tt.grantType}
+			assert.Equal(t, tt.want, provider.UsesGrant(tt.grant))
+		})
+	}
+}
+
+func TestOAuthConnection_IsServiceConnection(t *testing.T) {
+	assert.True(t, (&OAuthConnection{GrantType: GrantTypeClientCredentials}).IsServiceConnection())
+	assert.False(t, (&OAuthConnection{GrantType: GrantTypeAuthorizationCode}).IsServiceConnection())
+	assert.False(t, (&OAuthConnection{}).IsServiceConnection())
+}
+
+func TestDeviceAuthorization_IsPollDue(t *testing.T) {
+	due := &DeviceAuthorization{NextPollAt: time.Now().Add(-1 * time.Second)}
+	assert.True(t, due.IsPollDue())
+
+	notDue := &DeviceAuthorization{NextPollAt: time.Now().Add(5 * time.Second)}
+	assert.False(t, notDue.IsPollDue())
+}
+
+func TestDeviceAuthorization_IsExpired(t *testing.T) {
+	assert.True(t, (&DeviceAuthorization{ExpiresAt: time.Now().Add(-1 * time.Minute)}).IsExpired())
+	assert.False(t, (&DeviceAuthorization{ExpiresAt: time.Now().Add(15 * time.Minute)}).IsExpired())
+}
@@ -18,12 +18,13 @@ import (
 // or custom domains (e.g., login.mycompany.com)
 // Auth0 supports PKCE for enhanced security
 type Auth0Provider struct {
-	httpClient  *http.Client
-	domain      string
-	authURL     string
-	tokenURL    string
-	userInfoURL string
-	revokeURL   string
+	httpClient    *http.Client
+	domain        string
+	authURL       string
+	tokenURL      string
+	userInfoURL   string
+	revokeURL     string
+	deviceCodeURL string
 }
 
 // NewAuth0Provider creates a new Auth0 OAuth provider
@@ -39,11 +40,12 @@ func NewAuth0Provider(domain string) *Auth0Provider {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		domain:      domain,
-		authURL:     fmt.Sprintf("https://%s/authorize", domain),
-		tokenURL:    fmt.Sprintf("https://%s/oauth/token", domain),
-		userInfoURL: fmt.Sprintf("https://%s/userinfo", domain),
-		revokeURL:   fmt.Sprintf("https://%s/oauth/revoke", domain),
+		domain:        domain,
+		authURL:       fmt.Sprintf("https://%s/authorize", domain),
+		tokenURL:      fmt.Sprintf("https://%s/oauth/token", domain),
+		userInfoURL:   fmt.Sprintf("https://%s/userinfo", domain),
+		revokeURL:     fmt.Sprintf("https://%s/oauth/revoke", domain),
+		deviceCodeURL: fmt.Sprintf("https://%s/oauth/device/code", domain),
 	}
 }
 
@@ -168,6 +170,146 @@ func (p *Auth0Provider) RefreshToken(ctx context.Context, clientID, clientSecret
 	return &tokenResp, nil
 }
 
+// ClientCredentialsToken requests tokens with the client credentials grant
+func (p *Auth0Provider) ClientCredentialsToken(ctx context.Context, clientID, clientSecret string, scopes []string) (*oauth.TokenResponse, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
+	data.Set("grant_type", "client_credentials")
+	if len(scopes) > 0 {
+		data.Set("scope", strings.Join(scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			// Log error but don't override the main error
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp oauth.TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return &tokenResp, nil
+}
+
+// RequestDeviceCode starts a device authorization (RFC 8628)
+func (p *Auth0Provider) RequestDeviceCode(ctx context.Context, clientID string, scopes []string) (*oauth.DeviceAuthorizationResponse, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	data.Set("scope", strings.Join(scopes, " "))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.deviceCodeURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device code request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("device code request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			// Log error but don't override the main error
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device code response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device code request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var deviceResp oauth.DeviceAuthorizationResponse
+	if err := json.Unmarshal(body, &deviceResp); err != nil {
+		return nil, fmt.Errorf("failed to parse device code response: %w", err)
+	}
+
+	return &deviceResp, nil
+}
+
+// PollDeviceToken exchanges a device code for tokens
+// Auth0 answers with 403 and an RFC 8628 error code until the user approves
+func (p *Auth0Provider) PollDeviceToken(ctx context.Context, clientID, clientSecret, deviceCode string) (*oauth.TokenResponse, error) {
+	data := url.Values{}
+	data.Set("client_id", clientID)
+	if clientSecret != "" {
+		data.Set("client_secret", clientSecret)
+	}
+	data.Set("device_code", deviceCode)
+	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			// Log error but don't override the main error
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &errResp) == nil {
+			if deviceErr := oauth.DeviceTokenError(errResp.Error); deviceErr != nil {
+				return nil, deviceErr
+			}
+		}
+		return nil, fmt.Errorf("token request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp oauth.TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return &tokenResp, nil
+}
+
 // GetUserInfo retrieves user information using OpenID Connect userinfo endpoint
 func (p *Auth0Provider) GetUserInfo(ctx context.Context, accessToken string) (*oauth.UserInfo, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.userInfoURL, nil)
@@ -209,12 +351,12 @@ func (p *Auth0Provider) GetUserInfo(ctx context.Context, accessToken string) (*o
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
+	return withRawUserInfo(&oauth.UserInfo{
 		ID:       auth0User.Sub,
 		Username: auth0User.Nickname,
 		Name:     auth0User.Name,
 		Email:    auth0User.Email,
-	}, nil
+	}, body), nil
 }
 
 // RevokeToken revokes a token
@@ -324,6 +324,202 @@ func TestAuth0Provider_RefreshToken(t *testing.T) {
 	}
 }
 
+func TestAuth0Provider_ClientCredentialsToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse interface{}
+		serverStatus   int
+		scopes         []string
+		wantScope      string
+		wantToken      string
+		wantErr        bool
+		errContains    string
+	}{
+		{
+			name: "successful token request",
+			serverResponse: map[string]interface{}{
+				"access_token": "service-access-token",
+				"token_type":   "Bearer",
+				"expires_in":   86400,
+			},
+			serverStatus: http.StatusOK,
+			scopes:       []string{"read:users", "write:users"},
+			wantScope:    "read:users write:users",
+			wantToken:    "service-access-token",
+		},
+		{
+			name: "error response from Auth0",
+			serverResponse: map[string]interface{}{
+				"error":             "access_denied",
+				"error_description": "Client is not authorized",
+			},
+			serverStatus: http.StatusForbidden,
+			wantErr:      true,
+			errContains:  "token request failed with status 403",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+
+				err := r.ParseForm()
+				require.NoError(t, err)
+				assert.Equal(t, "test-client", r.FormValue("client_id"))
+				assert.Equal(t, "test-secret", r.FormValue("client_secret"))
+				assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+				assert.Equal(t, tt.wantScope, r.FormValue("scope"))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				err = json.NewEncoder(w).Encode(tt.serverResponse)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			provider := NewAuth0Provider(serverURL.Host)
+			provider.tokenURL = server.URL + "/oauth/token"
+
+			token, err := provider.ClientCredentialsToken(context.Background(), "test-client", "test-secret", tt.scopes)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantToken, token.AccessToken)
+				assert.Empty(t, token.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuth0Provider_RequestDeviceCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/oauth/device/code", r.URL.Path)
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		assert.Equal(t, "test-client", r.FormValue("client_id"))
+		assert.Equal(t, "openid offline_access", r.FormValue("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":               "device-code-123",
+			"user_code":                 "WDJB-MJHT",
+			"verification_uri":          "https://tenant.auth0.com/activate",
+			"verification_uri_complete": "https://tenant.auth0.com/activate?user_code=WDJB-MJHT",
+			"expires_in":                900,
+			"interval":                  5,
+		})
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	provider := NewAuth0Provider("tenant.auth0.com")
+	provider.deviceCodeURL = server.URL + "/oauth/device/code"
+
+	resp, err := provider.RequestDeviceCode(context.Background(), "test-client", []string{"openid", "offline_access"})
+	require.NoError(t, err)
+	assert.Equal(t, "device-code-123", resp.DeviceCode)
+	assert.Equal(t, "WDJB-MJHT", resp.UserCode)
+	assert.Equal(t, "https://tenant.auth0.com/activate", resp.VerificationURI)
+	assert.Equal(t, 900, resp.ExpiresIn)
+	assert.Equal(t, 5, resp.Interval)
+}
+
+func TestAuth0Provider_PollDeviceToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse interface{}
+		serverStatus   int
+		wantToken      string
+		wantErr        error
+		errContains    string
+	}{
+		{
+			name: "approved",
+			serverResponse: map[string]interface{}{
+				"access_token":  "device-access-token",
+				"refresh_token": "device-refresh-token",
+				"token_type":    "Bearer",
+				"expires_in":    86400,
+			},
+			serverStatus: http.StatusOK,
+			wantToken:    "device-access-token",
+		},
+		{
+			name:           "authorization pending",
+			serverResponse: map[string]interface{}{"error": "authorization_pending"},
+			serverStatus:   http.StatusForbidden,
+			wantErr:        oauth.ErrAuthorizationPending,
+		},
+		{
+			name:           "slow down",
+			serverResponse: map[string]interface{}{"error": "slow_down"},
+			serverStatus:   http.StatusTooManyRequests,
+			wantErr:        oauth.ErrSlowDown,
+		},
+		{
+			name:           "access denied",
+			serverResponse: map[string]interface{}{"error": "access_denied"},
+			serverStatus:   http.StatusForbidden,
+			wantErr:        oauth.ErrAccessDenied,
+		},
+		{
+			name:           "expired token",
+			serverResponse: map[string]interface{}{"error": "expired_token"},
+			serverStatus:   http.StatusForbidden,
+			wantErr:        oauth.ErrDeviceCodeExpired,
+		},
+		{
+			name:           "other error",
+			serverResponse: map[string]interface{}{"error": "invalid_grant"},
+			serverStatus:   http.StatusBadRequest,
+			errContains:    "token request failed with status 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				err := r.ParseForm()
+				require.NoError(t, err)
+				assert.Equal(t, "test-client", r.FormValue("client_id"))
+				assert.Equal(t, "device-code-123", r.FormValue("device_code"))
+				assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.FormValue("grant_type"))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				err = json.NewEncoder(w).Encode(tt.serverResponse)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			provider := NewAuth0Provider("tenant.auth0.com")
+			provider.tokenURL = server.URL + "/oauth/token"
+
+			token, err := provider.PollDeviceToken(context.Background(), "test-client", "", "device-code-123")
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.errContains != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantToken, token.AccessToken)
+			}
+		})
+	}
+}
+
 func TestAuth0Provider_GetUserInfo(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -137,23 +137,12 @@ func (p *GitHubProvider) GetUserInfo(ctx context.Context, accessToken string) (*
 		return nil, fmt.Errorf("user info request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var githubUser struct {
-		ID    int64  `json:"id"`
-		Login string `json:"login"`
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	}
-
-	if err := json.Unmarshal(body, &githubUser); err != nil {
+	raw, err := oauth.DecodeUserInfo(body)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
-		ID:       fmt.Sprintf("%d", githubUser.ID),
-		Username: githubUser.Login,
-		Email:    githubUser.Email,
-		Name:     githubUser.Name,
-	}, nil
+	return oauth.DefaultUserInfoMappings["github"].Apply(raw)
 }
 
 // RevokeToken revokes a token
@@ -185,22 +185,12 @@ func (p *GoogleProvider) GetUserInfo(ctx context.Context, accessToken string) (*
 		return nil, fmt.Errorf("user info request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var googleUser struct {
-		ID    string `json:"id"`
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	}
-
-	if err := json.Unmarshal(body, &googleUser); err != nil {
+	raw, err := oauth.DecodeUserInfo(body)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
-		ID:       googleUser.ID,
-		Username: googleUser.Email, // Google uses email as username
-		Email:    googleUser.Email,
-		Name:     googleUser.Name,
-	}, nil
+	return oauth.DefaultUserInfoMappings["google"].Apply(raw)
 }
 
 // RevokeToken revokes a token
@@ -160,11 +160,11 @@ func (p *LinkedInProvider) GetUserInfo(ctx context.Context, accessToken string)
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
+	return withRawUserInfo(&oauth.UserInfo{
 		ID:    linkedinUser.Sub,
 		Name:  linkedinUser.Name,
 		Email: linkedinUser.Email,
-	}, nil
+	}, body), nil
 }
 
 // RevokeToken revokes a token
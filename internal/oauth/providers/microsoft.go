@@ -184,28 +184,12 @@ func (p *MicrosoftProvider) GetUserInfo(ctx context.Context, accessToken string)
 		return nil, fmt.Errorf("user info request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	var msUser struct {
-		ID                string `json:"id"`
-		UserPrincipalName string `json:"userPrincipalName"`
-		Mail              string `json:"mail"`
-		DisplayName       string `json:"displayName"`
-	}
-
-	if err := json.Unmarshal(body, &msUser); err != nil {
+	raw, err := oauth.DecodeUserInfo(body)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	email := msUser.Mail
-	if email == "" {
-		email = msUser.UserPrincipalName
-	}
-
-	return &oauth.UserInfo{
-		ID:       msUser.ID,
-		Username: msUser.UserPrincipalName,
-		Email:    email,
-		Name:     msUser.DisplayName,
-	}, nil
+	return oauth.DefaultUserInfoMappings["microsoft"].Apply(raw)
 }
 
 // RevokeToken revokes a token
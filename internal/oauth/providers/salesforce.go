@@ -231,12 +231,12 @@ func (p *SalesforceProvider) GetUserInfo(ctx context.Context, accessToken string
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
+	return withRawUserInfo(&oauth.UserInfo{
 		ID:       salesforceUser.UserID,
 		Username: salesforceUser.PreferredUsername,
 		Email:    salesforceUser.Email,
 		Name:     salesforceUser.Name,
-	}, nil
+	}, body), nil
 }
 
 // RevokeToken revokes a token
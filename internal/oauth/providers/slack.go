@@ -190,12 +190,12 @@ func (p *SlackProvider) GetUserInfo(ctx context.Context, accessToken string) (*o
 		return nil, fmt.Errorf("slack API error: %s", slackResp.Error)
 	}
 
-	return &oauth.UserInfo{
+	return withRawUserInfo(&oauth.UserInfo{
 		ID:       slackResp.User.ID,
 		Username: slackResp.User.Name,
 		Email:    slackResp.User.Email,
 		Name:     slackResp.User.Name,
-	}, nil
+	}, body), nil
 }
 
 // RevokeToken revokes a token
@@ -209,11 +209,11 @@ func (p *TwitterProvider) GetUserInfo(ctx context.Context, accessToken string) (
 		return nil, fmt.Errorf("failed to parse user info response: %w", err)
 	}
 
-	return &oauth.UserInfo{
+	return withRawUserInfo(&oauth.UserInfo{
 		ID:       twitterResponse.Data.ID,
 		Username: twitterResponse.Data.Username,
 		Name:     twitterResponse.Data.Name,
-	}, nil
+	}, body), nil
 }
 
 // RevokeToken revokes a token
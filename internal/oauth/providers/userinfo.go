@@ -0,0 +1,12 @@
+package providers
+
+import "github.com/gorax/gorax/internal/oauth"
+
+// withRawUserInfo attaches the decoded user-info response to info, so a
+// user_info_mapping in the provider's config can read any of its fields
+func withRawUserInfo(info *oauth.UserInfo, body []byte) *oauth.UserInfo {
+	if raw, err := oauth.DecodeUserInfo(body); err == nil {
+		info.Raw = raw
+	}
+	return info
+}
@@ -6,9 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // PostgresRepository implements OAuthRepository using PostgreSQL
@@ -34,7 +38,7 @@ func (r *PostgresRepository) GetProviderByKey(ctx context.Context, providerKey s
 		SELECT id, provider_key, name, description, auth_url, token_url, user_info_url,
 		       default_scopes, client_id, client_secret_encrypted, client_secret_nonce,
 		       client_secret_auth_tag, client_secret_encrypted_dek, client_secret_kms_key_id,
-		       status, config, created_at, updated_at
+		       grant_type, status, config, created_at, updated_at
 		FROM oauth_providers
 		WHERE provider_key = $1 AND status = 'active'
 	`
@@ -58,6 +62,7 @@ func (r *PostgresRepository) GetProviderByKey(ctx context.Context, providerKey s
 		&provider.ClientSecretAuthTag,
 		&provider.ClientSecretEncDEK,
 		&provider.ClientSecretKMSKeyID,
+		&provider.GrantType,
 		&provider.Status,
 		&config,
 		&provider.CreatedAt,
@@ -83,17 +88,21 @@ func (r *PostgresRepository) GetProviderByKey(ctx context.Context, providerKey s
 	return &provider, nil
 }
 
-// ListProviders lists all available OAuth providers
-func (r *PostgresRepository) ListProviders(ctx context.Context) ([]*OAuthProvider, error) {
+// ListProviders lists the OAuth providers matching filter
+func (r *PostgresRepository) ListProviders(ctx context.Context, filter ProviderListFilter) ([]*OAuthProvider, error) {
 	query := `
 		SELECT id, provider_key, name, description, auth_url, token_url, user_info_url,
-		       default_scopes, client_id, status, config, created_at, updated_at
-		FROM oauth_providers
-		WHERE status = 'active'
-		ORDER BY name
-	`
+		       default_scopes, client_id, grant_type, status, config, created_at, updated_at
+		FROM oauth_providers`
+	var args []interface{}
 
-	rows, err := r.db.QueryContext(ctx, query)
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += " WHERE status = $1"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list providers: %w", err)
 	}
@@ -115,6 +124,7 @@ func (r *PostgresRepository) ListProviders(ctx context.Context) ([]*OAuthProvide
 			&provider.UserInfoURL,
 			&defaultScopes,
 			&provider.ClientID,
+			&provider.GrantType,
 			&provider.Status,
 			&config,
 			&provider.CreatedAt,
@@ -150,9 +160,9 @@ func (r *PostgresRepository) CreateConnection(ctx context.Context, conn *OAuthCo
 			id, user_id, tenant_id, provider_key, provider_user_id, provider_username, provider_email,
 			access_token_encrypted, access_token_nonce, access_token_auth_tag, access_token_encrypted_dek, access_token_kms_key_id,
 			refresh_token_encrypted, refresh_token_nonce, refresh_token_auth_tag, refresh_token_encrypted_dek, refresh_token_kms_key_id,
-			token_expiry, scopes, status, raw_token_response, metadata
+			token_expiry, scopes, status, raw_token_response, metadata, grant_type
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
 		)
 		ON CONFLICT (user_id, tenant_id, provider_key)
 		DO UPDATE SET
@@ -174,6 +184,7 @@ func (r *PostgresRepository) CreateConnection(ctx context.Context, conn *OAuthCo
 			status = EXCLUDED.status,
 			raw_token_response = EXCLUDED.raw_token_response,
 			metadata = EXCLUDED.metadata,
+			grant_type = EXCLUDED.grant_type,
 			updated_at = NOW()
 	`
 
@@ -210,6 +221,7 @@ func (r *PostgresRepository) CreateConnection(ctx context.Context, conn *OAuthCo
 		conn.Status,
 		rawTokenJSON,
 		metadataJSON,
+		conn.GrantType,
 	)
 
 	if err != nil {
@@ -226,7 +238,7 @@ func (r *PostgresRepository) GetConnection(ctx context.Context, id string) (*OAu
 		       access_token_encrypted, access_token_nonce, access_token_auth_tag, access_token_encrypted_dek, access_token_kms_key_id,
 		       refresh_token_encrypted, refresh_token_nonce, refresh_token_auth_tag, refresh_token_encrypted_dek, refresh_token_kms_key_id,
 		       token_expiry, scopes, status, created_at, updated_at, last_used_at, last_refresh_at,
-		       raw_token_response, metadata
+		       raw_token_response, metadata, grant_type
 		FROM oauth_connections
 		WHERE id = $1
 	`
@@ -241,7 +253,7 @@ func (r *PostgresRepository) GetConnectionByUserProvider(ctx context.Context, us
 		       access_token_encrypted, access_token_nonce, access_token_auth_tag, access_token_encrypted_dek, access_token_kms_key_id,
 		       refresh_token_encrypted, refresh_token_nonce, refresh_token_auth_tag, refresh_token_encrypted_dek, refresh_token_kms_key_id,
 		       token_expiry, scopes, status, created_at, updated_at, last_used_at, last_refresh_at,
-		       raw_token_response, metadata
+		       raw_token_response, metadata, grant_type
 		FROM oauth_connections
 		WHERE user_id = $1 AND tenant_id = $2 AND provider_key = $3
 	`
@@ -282,6 +294,7 @@ func (r *PostgresRepository) scanConnection(ctx context.Context, query string, a
 		&conn.LastRefreshAt,
 		&rawTokenJSON,
 		&metadataJSON,
+		&conn.GrantType,
 	)
 
 	if err != nil {
@@ -310,22 +323,81 @@ func (r *PostgresRepository) scanConnection(ctx context.Context, query string, a
 	return &conn, nil
 }
 
-// ListConnectionsByUser lists all OAuth connections for a user
-func (r *PostgresRepository) ListConnectionsByUser(ctx context.Context, userID, tenantID string) ([]*OAuthConnection, error) {
+// ListConnectionsByUser lists a user's OAuth connections, newest first
+func (r *PostgresRepository) ListConnectionsByUser(ctx context.Context, userID, tenantID string, filter ConnectionListFilter) ([]*OAuthConnection, error) {
 	query := `
 		SELECT id, user_id, tenant_id, provider_key, provider_user_id, provider_username, provider_email,
-		       token_expiry, scopes, status, created_at, updated_at, last_used_at, last_refresh_at
+		       token_expiry, scopes, status, created_at, updated_at, last_used_at, last_refresh_at, grant_type
 		FROM oauth_connections
-		WHERE user_id = $1 AND tenant_id = $2
-		ORDER BY created_at DESC
-	`
+		WHERE user_id = $1 AND tenant_id = $2`
+	args := []interface{}{userID, tenantID}
+
+	if filter.ProviderKey != "" {
+		args = append(args, filter.ProviderKey)
+		query += fmt.Sprintf(" AND provider_key = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filter.NeedsRefresh != nil {
+		args = append(args, time.Now().Add(refreshWindow))
+		if *filter.NeedsRefresh {
+			query += fmt.Sprintf(" AND token_expiry IS NOT NULL AND token_expiry < $%d", len(args))
+		} else {
+			query += fmt.Sprintf(" AND (token_expiry IS NULL OR token_expiry >= $%d)", len(args))
+		}
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, userID, tenantID)
+	keyset, limitClause, args := filter.Page.Keyset("", args)
+	if keyset != "" {
+		query += " AND " + keyset
+	}
+	query += " ORDER BY created_at DESC, id DESC" + limitClause
+
+	// Positional paging applies only when no cursor page is selected
+	if filter.Page == (pagination.Params{}) {
+		if filter.Limit > 0 {
+			args = append(args, filter.Limit)
+			query += fmt.Sprintf(" LIMIT $%d", len(args))
+		}
+		if filter.Offset > 0 {
+			args = append(args, filter.Offset)
+			query += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list connections: %w", err)
 	}
 	defer rows.Close()
 
+	return scanConnectionSummaries(rows)
+}
+
+// ListConnectionsByProvider lists non-revoked OAuth connections for a provider.
+// An empty tenantID lists connections across all tenants.
+func (r *PostgresRepository) ListConnectionsByProvider(ctx context.Context, tenantID, providerKey string) ([]*OAuthConnection, error) {
+	query := `
+		SELECT id, user_id, tenant_id, provider_key, provider_user_id, provider_username, provider_email,
+		       token_expiry, scopes, status, created_at, updated_at, last_used_at, last_refresh_at, grant_type
+		FROM oauth_connections
+		WHERE provider_key = $1 AND status != $2 AND ($3 = '' OR tenant_id::text = $3)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, providerKey, ConnectionStatusRevoked, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections by provider: %w", err)
+	}
+	defer rows.Close()
+
+	return scanConnectionSummaries(rows)
+}
+
+// scanConnectionSummaries scans connection rows without token columns
+func scanConnectionSummaries(rows *sql.Rows) ([]*OAuthConnection, error) {
 	var connections []*OAuthConnection
 	for rows.Next() {
 		var conn OAuthConnection
@@ -346,6 +418,7 @@ func (r *PostgresRepository) ListConnectionsByUser(ctx context.Context, userID,
 			&conn.UpdatedAt,
 			&conn.LastUsedAt,
 			&conn.LastRefreshAt,
+			&conn.GrantType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan connection: %w", err)
@@ -454,6 +527,216 @@ func (r *PostgresRepository) DeleteConnection(ctx context.Context, id string) er
 	return nil
 }
 
+// GetConnectionHealth summarizes a tenant's connections. Active connections
+// whose token expires before refreshBefore need a refresh; token refreshes
+// that failed since failuresSince are reported per connection. Each
+// provider's last failed action is taken from the whole log, including
+// authorizations that failed before a connection existed.
+func (r *PostgresRepository) GetConnectionHealth(ctx context.Context, tenantID string, refreshBefore, failuresSince time.Time) (*ConnectionHealth, error) {
+	health := &ConnectionHealth{
+		TenantID:        tenantID,
+		ByStatus:        make(map[ConnectionStatus]int),
+		NeedsRefresh:    []ConnectionRefreshDue{},
+		RefreshFailures: []ConnectionRefreshFailure{},
+		Providers:       []ProviderHealth{},
+		FailuresSince:   failuresSince,
+	}
+
+	providers, err := r.connectionStatusCounts(ctx, tenantID, health)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.connectionsDueForRefresh(ctx, tenantID, refreshBefore, health); err != nil {
+		return nil, err
+	}
+	if err := r.connectionRefreshFailures(ctx, tenantID, failuresSince, health); err != nil {
+		return nil, err
+	}
+	if err := r.providerLastErrors(ctx, tenantID, providers); err != nil {
+		return nil, err
+	}
+
+	for _, provider := range providers {
+		health.Providers = append(health.Providers, *provider)
+	}
+	sort.Slice(health.Providers, func(i, j int) bool {
+		return health.Providers[i].ProviderKey < health.Providers[j].ProviderKey
+	})
+
+	return health, nil
+}
+
+// connectionStatusCounts fills in the tenant's connection counts and returns
+// them per provider
+func (r *PostgresRepository) connectionStatusCounts(ctx context.Context, tenantID string, health *ConnectionHealth) (map[string]*ProviderHealth, error) {
+	query := `
+		SELECT provider_key, status, COUNT(*)
+		FROM oauth_connections
+		WHERE tenant_id = $1
+		GROUP BY provider_key, status
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connections: %w", err)
+	}
+	defer rows.Close()
+
+	providers := make(map[string]*ProviderHealth)
+	for rows.Next() {
+		var providerKey string
+		var status ConnectionStatus
+		var count int
+		if err := rows.Scan(&providerKey, &status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan connection count: %w", err)
+		}
+
+		provider := providerHealth(providers, providerKey)
+		provider.Total += count
+		provider.ByStatus[status] += count
+		health.Total += count
+		health.ByStatus[status] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating connection counts: %w", err)
+	}
+
+	return providers, nil
+}
+
+// connectionsDueForRefresh lists the active connections whose token expires
+// before refreshBefore
+func (r *PostgresRepository) connectionsDueForRefresh(ctx context.Context, tenantID string, refreshBefore time.Time, health *ConnectionHealth) error {
+	query := `
+		SELECT id, user_id, provider_key, token_expiry, last_refresh_at
+		FROM oauth_connections
+		WHERE tenant_id = $1 AND status = $2
+		  AND token_expiry IS NOT NULL AND token_expiry < $3
+		ORDER BY token_expiry ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, ConnectionStatusActive, refreshBefore)
+	if err != nil {
+		return fmt.Errorf("failed to list connections due for refresh: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var due ConnectionRefreshDue
+		if err := rows.Scan(&due.ConnectionID, &due.UserID, &due.ProviderKey, &due.TokenExpiry, &due.LastRefreshAt); err != nil {
+			return fmt.Errorf("failed to scan connection due for refresh: %w", err)
+		}
+		health.NeedsRefresh = append(health.NeedsRefresh, due)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating connections due for refresh: %w", err)
+	}
+
+	return nil
+}
+
+// connectionRefreshFailures lists the connections with token refreshes that
+// failed since failuresSince, noting whether a later refresh succeeded
+func (r *PostgresRepository) connectionRefreshFailures(ctx context.Context, tenantID string, failuresSince time.Time, health *ConnectionHealth) error {
+	query := `
+		SELECT c.id, c.user_id, c.provider_key, c.status,
+		       COUNT(*) FILTER (WHERE NOT l.success) AS failures,
+		       (ARRAY_AGG(COALESCE(l.error_message, '') ORDER BY l.created_at DESC)
+		           FILTER (WHERE NOT l.success))[1] AS last_error,
+		       MAX(l.created_at) FILTER (WHERE NOT l.success) AS last_failed_at,
+		       (ARRAY_AGG(l.success ORDER BY l.created_at DESC))[1] AS recovered
+		FROM oauth_connection_logs l
+		JOIN oauth_connections c ON c.id = l.connection_id
+		WHERE l.tenant_id = $1 AND l.action = 'token_refresh' AND l.created_at >= $2
+		GROUP BY c.id, c.user_id, c.provider_key, c.status
+		HAVING COUNT(*) FILTER (WHERE NOT l.success) > 0
+		ORDER BY last_failed_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, failuresSince)
+	if err != nil {
+		return fmt.Errorf("failed to list refresh failures: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var failure ConnectionRefreshFailure
+		err := rows.Scan(
+			&failure.ConnectionID,
+			&failure.UserID,
+			&failure.ProviderKey,
+			&failure.Status,
+			&failure.Failures,
+			&failure.LastError,
+			&failure.LastFailedAt,
+			&failure.Recovered,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan refresh failure: %w", err)
+		}
+		health.RefreshFailures = append(health.RefreshFailures, failure)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating refresh failures: %w", err)
+	}
+
+	return nil
+}
+
+// providerLastErrors records each provider's most recent failed action. Logs
+// without a connection name their provider in metadata.
+func (r *PostgresRepository) providerLastErrors(ctx context.Context, tenantID string, providers map[string]*ProviderHealth) error {
+	query := `
+		SELECT DISTINCT ON (provider_key) provider_key, action, error_message, created_at
+		FROM (
+			SELECT COALESCE(c.provider_key, l.metadata->>'provider_key') AS provider_key,
+			       l.action, COALESCE(l.error_message, '') AS error_message, l.created_at
+			FROM oauth_connection_logs l
+			LEFT JOIN oauth_connections c ON c.id = l.connection_id
+			WHERE l.tenant_id = $1 AND NOT l.success
+		) failed
+		WHERE provider_key IS NOT NULL AND provider_key != ''
+		ORDER BY provider_key, created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get provider errors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var providerKey, action, message string
+		var at time.Time
+		if err := rows.Scan(&providerKey, &action, &message, &at); err != nil {
+			return fmt.Errorf("failed to scan provider error: %w", err)
+		}
+
+		provider := providerHealth(providers, providerKey)
+		provider.LastError = message
+		provider.LastErrorAction = action
+		provider.LastErrorAt = &at
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating provider errors: %w", err)
+	}
+
+	return nil
+}
+
+// providerHealth returns the provider's entry, adding it if needed
+func providerHealth(providers map[string]*ProviderHealth, providerKey string) *ProviderHealth {
+	provider, ok := providers[providerKey]
+	if !ok {
+		provider = &ProviderHealth{
+			ProviderKey: providerKey,
+			ByStatus:    make(map[ConnectionStatus]int),
+		}
+		providers[providerKey] = provider
+	}
+	return provider
+}
+
 // CreateState creates a new OAuth state
 func (r *PostgresRepository) CreateState(ctx context.Context, state *OAuthState) error {
 	query := `
@@ -546,11 +829,12 @@ func (r *PostgresRepository) MarkStateUsed(ctx context.Context, stateStr string)
 	return nil
 }
 
-// DeleteExpiredStates deletes expired OAuth states
-func (r *PostgresRepository) DeleteExpiredStates(ctx context.Context) (int, error) {
-	query := `DELETE FROM oauth_states WHERE expires_at < NOW()`
+// DeleteExpiredStates deletes expired OAuth states, and used states created
+// more than usedGracePeriod ago
+func (r *PostgresRepository) DeleteExpiredStates(ctx context.Context, usedGracePeriod time.Duration) (int, error) {
+	query := `DELETE FROM oauth_states WHERE expires_at < NOW() OR (used AND created_at < $1)`
 
-	result, err := r.db.ExecContext(ctx, query)
+	result, err := r.db.ExecContext(ctx, query, time.Now().Add(-usedGracePeriod))
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete expired states: %w", err)
 	}
@@ -563,12 +847,163 @@ func (r *PostgresRepository) DeleteExpiredStates(ctx context.Context) (int, erro
 	return int(rowsAffected), nil
 }
 
-// CreateLog creates an OAuth connection log entry
+// CreateDeviceAuthorization creates a pending device authorization
+func (r *PostgresRepository) CreateDeviceAuthorization(ctx context.Context, auth *DeviceAuthorization) error {
+	query := `
+		INSERT INTO oauth_device_authorizations (
+			device_code, user_id, tenant_id, provider_key, user_code, verification_uri,
+			verification_uri_complete, scopes, interval_seconds, status, created_at,
+			expires_at, next_poll_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		auth.DeviceCode,
+		auth.UserID,
+		auth.TenantID,
+		auth.ProviderKey,
+		auth.UserCode,
+		auth.VerificationURI,
+		auth.VerificationURIComplete,
+		pq.Array(auth.Scopes),
+		auth.Interval,
+		auth.Status,
+		auth.CreatedAt,
+		auth.ExpiresAt,
+		auth.NextPollAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create device authorization: %w", err)
+	}
+
+	return nil
+}
+
+const deviceAuthorizationColumns = `
+	device_code, user_id, tenant_id, provider_key, user_code, verification_uri,
+	verification_uri_complete, scopes, interval_seconds, status, connection_id,
+	created_at, expires_at, next_poll_at, last_polled_at`
+
+// GetDeviceAuthorization retrieves a device authorization by device code
+func (r *PostgresRepository) GetDeviceAuthorization(ctx context.Context, deviceCode string) (*DeviceAuthorization, error) {
+	query := `SELECT ` + deviceAuthorizationColumns + `
+		FROM oauth_device_authorizations
+		WHERE device_code = $1
+	`
+
+	auth, err := scanDeviceAuthorization(r.db.QueryRowContext(ctx, query, deviceCode))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeviceAuthorizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get device authorization: %w", err)
+	}
+
+	return auth, nil
+}
+
+// ListDueDeviceAuthorizations lists pending device authorizations whose
+// polling interval has elapsed, oldest poll first
+func (r *PostgresRepository) ListDueDeviceAuthorizations(ctx context.Context, limit int) ([]*DeviceAuthorization, error) {
+	query := `SELECT ` + deviceAuthorizationColumns + `
+		FROM oauth_device_authorizations
+		WHERE status = $1 AND next_poll_at <= NOW()
+		ORDER BY next_poll_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, DeviceAuthorizationPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list device authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	var auths []*DeviceAuthorization
+	for rows.Next() {
+		auth, err := scanDeviceAuthorization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device authorization: %w", err)
+		}
+		auths = append(auths, auth)
+	}
+
+	return auths, rows.Err()
+}
+
+// scanDeviceAuthorization scans a row selected with deviceAuthorizationColumns
+func scanDeviceAuthorization(row interface {
+	Scan(dest ...interface{}) error
+}) (*DeviceAuthorization, error) {
+	var auth DeviceAuthorization
+	var scopes pq.StringArray
+	var verificationURIComplete, connectionID sql.NullString
+
+	err := row.Scan(
+		&auth.DeviceCode,
+		&auth.UserID,
+		&auth.TenantID,
+		&auth.ProviderKey,
+		&auth.UserCode,
+		&auth.VerificationURI,
+		&verificationURIComplete,
+		&scopes,
+		&auth.Interval,
+		&auth.Status,
+		&connectionID,
+		&auth.CreatedAt,
+		&auth.ExpiresAt,
+		&auth.NextPollAt,
+		&auth.LastPolledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.Scopes = scopes
+	auth.VerificationURIComplete = verificationURIComplete.String
+	auth.ConnectionID = connectionID.String
+
+	return &auth, nil
+}
+
+// UpdateDeviceAuthorization updates the polling state of a device
+// authorization. Only pending authorizations are updated, so a concurrent
+// poll cannot overwrite one that has already completed.
+func (r *PostgresRepository) UpdateDeviceAuthorization(ctx context.Context, auth *DeviceAuthorization) error {
+	query := `
+		UPDATE oauth_device_authorizations SET
+			interval_seconds = $2,
+			status = $3,
+			connection_id = NULLIF($4, '')::uuid,
+			next_poll_at = $5,
+			last_polled_at = $6
+		WHERE device_code = $1 AND status = $7
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		auth.DeviceCode,
+		auth.Interval,
+		auth.Status,
+		auth.ConnectionID,
+		auth.NextPollAt,
+		auth.LastPolledAt,
+		DeviceAuthorizationPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update device authorization: %w", err)
+	}
+
+	return nil
+}
+
+// CreateLog creates an OAuth connection log entry. Entries without a
+// connection, such as failed authorizations, store a NULL connection_id.
 func (r *PostgresRepository) CreateLog(ctx context.Context, log *OAuthConnectionLog) error {
 	query := `
 		INSERT INTO oauth_connection_logs (
 			id, connection_id, user_id, tenant_id, action, success, error_message, metadata
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		) VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8)
 	`
 
 	metadataJSON, err := json.Marshal(log.Metadata)
@@ -0,0 +1,204 @@
+package oauth
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pagination"
+)
+
+var connectionSummaryColumns = []string{
+	"id", "user_id", "tenant_id", "provider_key", "provider_user_id", "provider_username", "provider_email",
+	"token_expiry", "scopes", "status", "created_at", "updated_at", "last_used_at", "last_refresh_at", "grant_type",
+}
+
+func newMockRepository(t *testing.T) (*PostgresRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func TestPostgresRepository_ListConnectionsByUser_Filters(t *testing.T) {
+	needsRefresh := true
+	freshOnly := false
+	after := pagination.Cursor{CreatedAt: time.Now(), ID: "conn-9"}
+
+	tests := []struct {
+		name   string
+		filter ConnectionListFilter
+		where  string
+		tail   string
+		args   []driver.Value
+	}{
+		{
+			name:  "no filter",
+			where: "WHERE user_id = $1 AND tenant_id = $2 ORDER BY",
+			args:  []driver.Value{"user-1", "tenant-1"},
+		},
+		{
+			name:   "provider and status",
+			filter: ConnectionListFilter{ProviderKey: "github", Status: ConnectionStatusActive},
+			where:  "AND provider_key = $3 AND status = $4 ORDER BY",
+			args:   []driver.Value{"user-1", "tenant-1", "github", "active"},
+		},
+		{
+			name:   "needs refresh",
+			filter: ConnectionListFilter{NeedsRefresh: &needsRefresh},
+			where:  "AND token_expiry IS NOT NULL AND token_expiry < $3 ORDER BY",
+			args:   []driver.Value{"user-1", "tenant-1", sqlmock.AnyArg()},
+		},
+		{
+			name:   "does not need refresh",
+			filter: ConnectionListFilter{Status: ConnectionStatusActive, NeedsRefresh: &freshOnly},
+			where:  "AND status = $3 AND (token_expiry IS NULL OR token_expiry >= $4) ORDER BY",
+			args:   []driver.Value{"user-1", "tenant-1", "active", sqlmock.AnyArg()},
+		},
+		{
+			name:   "limit and offset",
+			filter: ConnectionListFilter{ProviderKey: "google", Limit: 20, Offset: 40},
+			where:  "AND provider_key = $3 ORDER BY",
+			tail:   "LIMIT $4 OFFSET $5",
+			args:   []driver.Value{"user-1", "tenant-1", "google", 20, 40},
+		},
+		{
+			name:   "cursor page ignores limit and offset",
+			filter: ConnectionListFilter{Status: ConnectionStatusExpired, Limit: 20, Offset: 40, Page: pagination.Params{After: &after, Limit: 10}},
+			where:  "AND status = $3 AND (created_at, id) < ($4, $5) ORDER BY",
+			tail:   "LIMIT $6",
+			args:   []driver.Value{"user-1", "tenant-1", "expired", after.CreatedAt, after.ID, 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+
+			now := time.Now()
+			rows := sqlmock.NewRows(connectionSummaryColumns).
+				AddRow("conn-1", "user-1", "tenant-1", "github", "gh-1", "octocat", "octo@example.com",
+					now.Add(time.Minute), "{repo}", "active", now, now, nil, nil, "authorization_code")
+
+			pattern := regexp.QuoteMeta(tt.where)
+			if tt.tail != "" {
+				pattern += ".*" + regexp.QuoteMeta(tt.tail) + "$"
+			}
+			mock.ExpectQuery(pattern).WithArgs(tt.args...).WillReturnRows(rows)
+
+			connections, err := repo.ListConnectionsByUser(context.Background(), "user-1", "tenant-1", tt.filter)
+
+			require.NoError(t, err)
+			require.Len(t, connections, 1)
+			assert.Equal(t, "conn-1", connections[0].ID)
+			assert.Equal(t, []string{"repo"}, connections[0].Scopes)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresRepository_ListProviders_StatusFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  ProviderListFilter
+		pattern string
+		args    []driver.Value
+	}{
+		{name: "all providers", pattern: "FROM oauth_providers ORDER BY name"},
+		{
+			name:    "inactive only",
+			filter:  ProviderListFilter{Status: ProviderStatusInactive},
+			pattern: "FROM oauth_providers WHERE status = $1 ORDER BY name",
+			args:    []driver.Value{"inactive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+
+			expectation := mock.ExpectQuery(regexp.QuoteMeta(tt.pattern))
+			if tt.args != nil {
+				expectation.WithArgs(tt.args...)
+			} else {
+				expectation.WithoutArgs()
+			}
+			expectation.WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			providers, err := repo.ListProviders(context.Background(), tt.filter)
+
+			require.NoError(t, err)
+			assert.Empty(t, providers)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresRepository_GetConnectionHealth(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+	refreshBefore := now.Add(refreshWindow)
+	failuresSince := now.Add(-refreshFailureWindow)
+	expiry := now.Add(time.Minute)
+	failedAt := now.Add(-time.Hour)
+	deniedAt := now.Add(-2 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta("GROUP BY provider_key, status")).
+		WithArgs("tenant-1").
+		WillReturnRows(sqlmock.NewRows([]string{"provider_key", "status", "count"}).
+			AddRow("github", "active", 2).
+			AddRow("github", "revoked", 1).
+			AddRow("google", "expired", 1))
+	mock.ExpectQuery(regexp.QuoteMeta("token_expiry IS NOT NULL AND token_expiry < $3")).
+		WithArgs("tenant-1", ConnectionStatusActive, refreshBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "provider_key", "token_expiry", "last_refresh_at"}).
+			AddRow("conn-1", "user-1", "github", expiry, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("l.action = 'token_refresh'")).
+		WithArgs("tenant-1", failuresSince).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "provider_key", "status", "failures", "last_error", "last_failed_at", "recovered",
+		}).AddRow("conn-2", "user-2", "github", "active", 3, "invalid_grant", failedAt, false))
+	mock.ExpectQuery(regexp.QuoteMeta("DISTINCT ON (provider_key)")).
+		WithArgs("tenant-1").
+		WillReturnRows(sqlmock.NewRows([]string{"provider_key", "action", "error_message", "created_at"}).
+			AddRow("github", "token_refresh", "invalid_grant", failedAt).
+			AddRow("slack", "authorize", "access_denied", deniedAt))
+
+	health, err := repo.GetConnectionHealth(context.Background(), "tenant-1", refreshBefore, failuresSince)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, 4, health.Total)
+	assert.Equal(t, map[ConnectionStatus]int{
+		ConnectionStatusActive:  2,
+		ConnectionStatusRevoked: 1,
+		ConnectionStatusExpired: 1,
+	}, health.ByStatus)
+
+	require.Len(t, health.NeedsRefresh, 1)
+	assert.Equal(t, "conn-1", health.NeedsRefresh[0].ConnectionID)
+	assert.Nil(t, health.NeedsRefresh[0].LastRefreshAt)
+
+	require.Len(t, health.RefreshFailures, 1)
+	assert.Equal(t, 3, health.RefreshFailures[0].Failures)
+	assert.False(t, health.RefreshFailures[0].Recovered)
+
+	// Providers are sorted, and a provider with only a failed authorization
+	// still reports its error
+	require.Len(t, health.Providers, 3)
+	assert.Equal(t, "github", health.Providers[0].ProviderKey)
+	assert.Equal(t, 3, health.Providers[0].Total)
+	assert.Equal(t, "invalid_grant", health.Providers[0].LastError)
+	assert.Equal(t, "google", health.Providers[1].ProviderKey)
+	assert.Empty(t, health.Providers[1].LastError)
+	assert.Equal(t, "slack", health.Providers[2].ProviderKey)
+	assert.Equal(t, 0, health.Providers[2].Total)
+	assert.Equal(t, "authorize", health.Providers[2].LastErrorAction)
+}
@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gorax/gorax/internal/credential"
+	"github.com/gorax/gorax/internal/tracing"
 )
 
 // Service implements OAuthService
@@ -17,6 +20,28 @@ type Service struct {
 	encryptionSvc EncryptionService
 	providers     map[string]Provider
 	baseURL       string
+	clockSkew     ClockSkewConfig
+}
+
+// ClockSkewConfig tolerates clock differences between the nodes that share
+// OAuth states and connections, and between them and the provider
+type ClockSkewConfig struct {
+	// Tolerance is how far node clocks may differ. States and tokens stay
+	// valid this long past their expiry, and tokens are refreshed this much
+	// earlier.
+	Tolerance time.Duration
+	// ExpiryMargin is taken off a token's expires_in when its expiry is
+	// recorded, so it is replaced before the provider rejects it
+	ExpiryMargin time.Duration
+}
+
+// DefaultClockSkewConfig returns the clock skew settings used unless
+// SetClockSkew is called
+func DefaultClockSkewConfig() ClockSkewConfig {
+	return ClockSkewConfig{
+		Tolerance:    30 * time.Second,
+		ExpiryMargin: 30 * time.Second,
+	}
 }
 
 // EncryptionService defines the encryption interface for OAuth tokens
@@ -32,17 +57,24 @@ func NewService(repo OAuthRepository, encryptionSvc EncryptionService, providers
 		encryptionSvc: encryptionSvc,
 		baseURL:       strings.TrimSuffix(baseURL, "/"),
 		providers:     providers,
+		clockSkew:     DefaultClockSkewConfig(),
 	}
 }
 
+// SetClockSkew sets how clock differences between nodes and providers are
+// tolerated
+func (s *Service) SetClockSkew(config ClockSkewConfig) {
+	s.clockSkew = config
+}
+
 // GetProvider retrieves an OAuth provider by key
 func (s *Service) GetProvider(ctx context.Context, providerKey string) (*OAuthProvider, error) {
 	return s.repo.GetProviderByKey(ctx, providerKey)
 }
 
-// ListProviders lists all available OAuth providers
-func (s *Service) ListProviders(ctx context.Context) ([]*OAuthProvider, error) {
-	return s.repo.ListProviders(ctx)
+// ListProviders lists the OAuth providers matching filter
+func (s *Service) ListProviders(ctx context.Context, filter ProviderListFilter) ([]*OAuthProvider, error) {
+	return s.repo.ListProviders(ctx, filter)
 }
 
 // Authorize starts the OAuth authorization flow
@@ -111,7 +143,7 @@ func (s *Service) Authorize(ctx context.Context, userID, tenantID string, input
 func (s *Service) HandleCallback(ctx context.Context, userID, tenantID string, input *CallbackInput) (*OAuthConnection, error) {
 	// Handle error from OAuth provider
 	if input.Error != "" {
-		return nil, fmt.Errorf("OAuth provider error: %s", input.Error)
+		return nil, s.handleProviderError(ctx, userID, tenantID, input)
 	}
 
 	// Validate state
@@ -124,7 +156,7 @@ func (s *Service) HandleCallback(ctx context.Context, userID, tenantID string, i
 		return nil, fmt.Errorf("state already used")
 	}
 
-	if oauthState.IsExpired() {
+	if oauthState.IsExpired(s.clockSkew.Tolerance) {
 		return nil, ErrInvalidState
 	}
 
@@ -181,85 +213,147 @@ func (s *Service) HandleCallback(ctx context.Context, userID, tenantID string, i
 		return nil, fmt.Errorf("failed to exchange code: %w", err)
 	}
 
+	return s.createUserConnection(ctx, provider, providerConfig, userID, tenantID, oauthState.Scopes, tokenResp, "authorize")
+}
+
+// handleProviderError records an authorization error returned by the
+// provider and returns it as a ProviderError. The state is consumed so the
+// flow cannot be resumed, and no connection is created.
+func (s *Service) handleProviderError(ctx context.Context, userID, tenantID string, input *CallbackInput) error {
+	providerErr := &ProviderError{
+		Code:        input.Error,
+		Description: input.ErrorDescription,
+	}
+
+	if input.State != "" {
+		oauthState, err := s.repo.GetState(ctx, input.State)
+		if err == nil && oauthState.UserID == userID && oauthState.TenantID == tenantID {
+			providerErr.ProviderKey = oauthState.ProviderKey
+			_ = s.repo.MarkStateUsed(ctx, input.State)
+		}
+	}
+
+	if tenantID != "" {
+		_ = s.repo.CreateLog(ctx, &OAuthConnectionLog{
+			ID:           uuid.New().String(),
+			UserID:       userID,
+			TenantID:     tenantID,
+			Action:       "authorize",
+			Success:      false,
+			ErrorMessage: providerErr.Error(),
+			Metadata: map[string]interface{}{
+				"provider_key":      providerErr.ProviderKey,
+				"error":             providerErr.Code,
+				"error_description": providerErr.Description,
+			},
+		})
+	}
+
+	return providerErr
+}
+
+// createUserConnection stores the tokens issued to a user as a connection,
+// looking up the user on the provider and logging the action
+func (s *Service) createUserConnection(ctx context.Context, provider Provider, providerConfig *OAuthProvider, userID, tenantID string, scopes []string, tokenResp *TokenResponse, action string) (*OAuthConnection, error) {
 	// Get user info
 	userInfo, err := provider.GetUserInfo(ctx, tokenResp.AccessToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
+	userInfo, err = providerConfig.NormalizeUserInfo(userInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user info: %w", err)
+	}
 
-	// Encrypt access token
-	accessTokenData := &credential.CredentialData{
-		Value: map[string]interface{}{
-			"token": tokenResp.AccessToken,
-		},
+	// Parse scopes
+	if tokenResp.Scope != "" {
+		scopes = strings.Split(tokenResp.Scope, " ")
 	}
-	encryptedAccessToken, err := s.encryptionSvc.Encrypt(ctx, tenantID, accessTokenData)
+
+	// Create connection
+	conn := &OAuthConnection{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		TenantID:         tenantID,
+		ProviderKey:      providerConfig.ProviderKey,
+		ProviderUserID:   userInfo.ID,
+		ProviderUsername: userInfo.Username,
+		ProviderEmail:    userInfo.Email,
+		Scopes:           scopes,
+		Status:           ConnectionStatusActive,
+		GrantType:        GrantTypeAuthorizationCode,
+		RawTokenResponse: map[string]interface{}{},
+	}
+	if err := s.applyTokenResponse(ctx, conn, tokenResp); err != nil {
+		return nil, err
+	}
+
+	// Save connection
+	if err := s.repo.CreateConnection(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	// Log successful authorization
+	_ = s.logConnectionAction(ctx, conn.ID, userID, tenantID, action, true, "")
+
+	return conn, nil
+}
+
+// CreateServiceConnection requests tokens with the client credentials grant
+// and stores a tenant connection that is not tied to a user
+func (s *Service) CreateServiceConnection(ctx context.Context, tenantID, providerKey string, scopes []string) (*OAuthConnection, error) {
+	providerConfig, err := s.repo.GetProviderByKey(ctx, providerKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt access token: %w", err)
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+	if !providerConfig.UsesGrant(GrantTypeClientCredentials) {
+		return nil, ErrUnsupportedGrant
 	}
 
-	// Encrypt refresh token if present
-	var encryptedRefreshToken *credential.EncryptedSecret
-	if tokenResp.RefreshToken != "" {
-		refreshTokenData := &credential.CredentialData{
-			Value: map[string]interface{}{
-				"token": tokenResp.RefreshToken,
-			},
-		}
-		encryptedRefreshToken, err = s.encryptionSvc.Encrypt(ctx, tenantID, refreshTokenData)
-		if err != nil {
-			return nil, fmt.Errorf("failed to encrypt refresh token: %w", err)
-		}
+	provider, ok := s.providers[providerKey]
+	if !ok {
+		return nil, ErrInvalidProvider
+	}
+	ccProvider, ok := provider.(ClientCredentialsProvider)
+	if !ok {
+		return nil, ErrUnsupportedGrant
 	}
 
-	// Calculate token expiry
-	var tokenExpiry *time.Time
-	if tokenResp.ExpiresIn > 0 {
-		expiry := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-		tokenExpiry = &expiry
+	clientSecret, err := s.decryptClientSecret(ctx, providerConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse scopes
-	scopes := oauthState.Scopes
+	if len(scopes) == 0 {
+		scopes = providerConfig.DefaultScopes
+	}
+
+	tokenResp, err := ccProvider.ClientCredentialsToken(ctx, providerConfig.ClientID, clientSecret, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
 	if tokenResp.Scope != "" {
 		scopes = strings.Split(tokenResp.Scope, " ")
 	}
 
-	// Create connection
 	conn := &OAuthConnection{
-		ID:                   uuid.New().String(),
-		UserID:               userID,
-		TenantID:             tenantID,
-		ProviderKey:          oauthState.ProviderKey,
-		ProviderUserID:       userInfo.ID,
-		ProviderUsername:     userInfo.Username,
-		ProviderEmail:        userInfo.Email,
-		AccessTokenEncrypted: encryptedAccessToken.Ciphertext,
-		AccessTokenNonce:     encryptedAccessToken.Nonce,
-		AccessTokenAuthTag:   encryptedAccessToken.AuthTag,
-		AccessTokenEncDEK:    encryptedAccessToken.EncryptedDEK,
-		AccessTokenKMSKeyID:  encryptedAccessToken.KMSKeyID,
-		TokenExpiry:          tokenExpiry,
-		Scopes:               scopes,
-		Status:               ConnectionStatusActive,
-		RawTokenResponse:     map[string]interface{}{},
-	}
-
-	if encryptedRefreshToken != nil {
-		conn.RefreshTokenEncrypted = encryptedRefreshToken.Ciphertext
-		conn.RefreshTokenNonce = encryptedRefreshToken.Nonce
-		conn.RefreshTokenAuthTag = encryptedRefreshToken.AuthTag
-		conn.RefreshTokenEncDEK = encryptedRefreshToken.EncryptedDEK
-		conn.RefreshTokenKMSKeyID = encryptedRefreshToken.KMSKeyID
+		ID:               uuid.New().String(),
+		TenantID:         tenantID,
+		ProviderKey:      providerKey,
+		Scopes:           scopes,
+		Status:           ConnectionStatusActive,
+		GrantType:        GrantTypeClientCredentials,
+		RawTokenResponse: map[string]interface{}{},
+	}
+	if err := s.applyTokenResponse(ctx, conn, tokenResp); err != nil {
+		return nil, err
 	}
 
-	// Save connection
 	if err := s.repo.CreateConnection(ctx, conn); err != nil {
 		return nil, fmt.Errorf("failed to create connection: %w", err)
 	}
 
-	// Log successful authorization
-	_ = s.logConnectionAction(ctx, conn.ID, userID, tenantID, "authorize", true, "")
+	_ = s.logConnectionAction(ctx, conn.ID, "", tenantID, "service_connect", true, "")
 
 	return conn, nil
 }
@@ -279,9 +373,10 @@ func (s *Service) GetConnection(ctx context.Context, userID, tenantID, providerK
 	return conn, nil
 }
 
-// ListConnections lists all OAuth connections for a user
-func (s *Service) ListConnections(ctx context.Context, userID, tenantID string) ([]*OAuthConnection, error) {
-	return s.repo.ListConnectionsByUser(ctx, userID, tenantID)
+// ListConnections lists a user's OAuth connections matching filter; the zero
+// filter lists all of them
+func (s *Service) ListConnections(ctx context.Context, userID, tenantID string, filter ConnectionListFilter) ([]*OAuthConnection, error) {
+	return s.repo.ListConnectionsByUser(ctx, userID, tenantID, filter)
 }
 
 // RevokeConnection revokes an OAuth connection
@@ -308,15 +403,208 @@ func (s *Service) RevokeConnection(ctx context.Context, userID, tenantID, connec
 	return nil
 }
 
+// RevokeConnections revokes up to MaxBulkRevoke of a user's connections. It
+// returns the IDs revoked and a failure for each ID that was not.
+func (s *Service) RevokeConnections(ctx context.Context, userID, tenantID string, connectionIDs []string) ([]string, []BulkOperationError) {
+	revoked := []string{}
+	failed := []BulkOperationError{}
+
+	if len(connectionIDs) > MaxBulkRevoke {
+		message := fmt.Sprintf("cannot revoke more than %d connections at once", MaxBulkRevoke)
+		for _, id := range connectionIDs {
+			failed = append(failed, BulkOperationError{ID: id, Error: message})
+		}
+		return revoked, failed
+	}
+
+	for _, id := range connectionIDs {
+		if err := s.RevokeConnection(ctx, userID, tenantID, id); err != nil {
+			failed = append(failed, BulkOperationError{ID: id, Error: err.Error()})
+			continue
+		}
+		revoked = append(revoked, id)
+	}
+
+	return revoked, failed
+}
+
+// RevokeConnectionsByProvider revokes all of a tenant's connections for a provider
+func (s *Service) RevokeConnectionsByProvider(ctx context.Context, tenantID, providerKey, reason string) (*BulkRevokeResult, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant ID is required")
+	}
+	return s.revokeConnectionsByProvider(ctx, tenantID, providerKey, reason)
+}
+
+// RevokeAllConnectionsByProvider revokes every tenant's connections for a provider.
+// Intended for superadmins when a shared client secret is rotated.
+func (s *Service) RevokeAllConnectionsByProvider(ctx context.Context, providerKey, reason string) (*BulkRevokeResult, error) {
+	return s.revokeConnectionsByProvider(ctx, "", providerKey, reason)
+}
+
+// GetConnectionHealth summarizes a tenant's connections. Connections expiring
+// within the refresh window, widened by the clock skew tolerance as in
+// NeedsRefresh, need a refresh, and refresh failures are reported for the
+// last 24 hours.
+func (s *Service) GetConnectionHealth(ctx context.Context, tenantID string) (*ConnectionHealth, error) {
+	if tenantID == "" {
+		return nil, fmt.Errorf("tenant ID is required")
+	}
+
+	now := time.Now()
+	health, err := s.repo.GetConnectionHealth(ctx, tenantID, now.Add(refreshWindow+s.clockSkew.Tolerance), now.Add(-refreshFailureWindow))
+	if err != nil {
+		return nil, fmt.Errorf("get connection health: %w", err)
+	}
+	health.GeneratedAt = now
+
+	return health, nil
+}
+
+// revokeConnectionsByProvider marks matching connections revoked, attempting
+// provider-side revocation first. An empty tenantID matches all tenants.
+func (s *Service) revokeConnectionsByProvider(ctx context.Context, tenantID, providerKey, reason string) (*BulkRevokeResult, error) {
+	providerConfig, err := s.repo.GetProviderByKey(ctx, providerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	connections, err := s.repo.ListConnectionsByProvider(ctx, tenantID, providerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkRevokeResult{ProviderKey: providerKey, TenantID: tenantID}
+	if len(connections) == 0 {
+		return result, nil
+	}
+
+	// Provider-side revocation is best effort; without a client secret or a
+	// registered provider, connections are only revoked locally
+	provider, supported := s.providers[providerKey]
+	clientSecret, err := s.decryptClientSecret(ctx, providerConfig)
+	if err != nil {
+		supported = false
+	}
+
+	for _, summary := range connections {
+		conn, err := s.repo.GetConnection(ctx, summary.ID)
+		if err != nil {
+			result.Failed++
+			_ = s.logBulkRevoke(ctx, summary, reason, false, err.Error())
+			continue
+		}
+
+		var providerErr error
+		if supported {
+			providerErr = s.revokeProviderToken(ctx, provider, providerConfig.ClientID, clientSecret, conn)
+			if providerErr != nil {
+				result.ProviderRevokeFailed++
+			}
+		}
+
+		conn.Status = ConnectionStatusRevoked
+		if err := s.repo.UpdateConnection(ctx, conn); err != nil {
+			result.Failed++
+			_ = s.logBulkRevoke(ctx, conn, reason, false, err.Error())
+			continue
+		}
+
+		result.Revoked++
+		errorMsg := ""
+		if providerErr != nil {
+			errorMsg = fmt.Sprintf("provider revocation failed: %v", providerErr)
+		}
+		_ = s.logBulkRevoke(ctx, conn, reason, true, errorMsg)
+	}
+
+	return result, nil
+}
+
+// revokeProviderToken revokes a connection's access token with the provider
+func (s *Service) revokeProviderToken(ctx context.Context, provider Provider, clientID, clientSecret string, conn *OAuthConnection) error {
+	if len(conn.AccessTokenEncrypted) == 0 {
+		return nil
+	}
+
+	decrypted, err := s.encryptionSvc.Decrypt(ctx, &credential.EncryptedSecret{
+		Ciphertext:   conn.AccessTokenEncrypted,
+		Nonce:        conn.AccessTokenNonce,
+		AuthTag:      conn.AccessTokenAuthTag,
+		EncryptedDEK: conn.AccessTokenEncDEK,
+		KMSKeyID:     conn.AccessTokenKMSKeyID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrypt access token: %w", err)
+	}
+
+	token, ok := decrypted.Value["token"].(string)
+	if !ok {
+		return fmt.Errorf("invalid access token format")
+	}
+
+	return provider.RevokeToken(ctx, clientID, clientSecret, token)
+}
+
+// decryptClientSecret decrypts a provider's client secret, if one is configured
+func (s *Service) decryptClientSecret(ctx context.Context, providerConfig *OAuthProvider) (string, error) {
+	if len(providerConfig.ClientSecretEncrypted) == 0 {
+		return "", nil
+	}
+
+	decrypted, err := s.encryptionSvc.Decrypt(ctx, &credential.EncryptedSecret{
+		Ciphertext:   providerConfig.ClientSecretEncrypted,
+		Nonce:        providerConfig.ClientSecretNonce,
+		AuthTag:      providerConfig.ClientSecretAuthTag,
+		EncryptedDEK: providerConfig.ClientSecretEncDEK,
+		KMSKeyID:     providerConfig.ClientSecretKMSKeyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt client secret: %w", err)
+	}
+
+	secret, _ := decrypted.Value["secret"].(string)
+	return secret, nil
+}
+
+// logBulkRevoke logs a bulk revocation of a single connection with its reason
+func (s *Service) logBulkRevoke(ctx context.Context, conn *OAuthConnection, reason string, success bool, errorMsg string) error {
+	log := &OAuthConnectionLog{
+		ID:           uuid.New().String(),
+		ConnectionID: conn.ID,
+		UserID:       conn.UserID,
+		TenantID:     conn.TenantID,
+		Action:       "bulk_revoke",
+		Success:      success,
+		ErrorMessage: errorMsg,
+		Metadata: map[string]interface{}{
+			"reason": reason,
+		},
+	}
+	return s.repo.CreateLog(ctx, log)
+}
+
 // RefreshToken refreshes an expired OAuth token
-func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
+func (s *Service) RefreshToken(ctx context.Context, connectionID string) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "oauth.token.refresh", trace.WithAttributes(
+		attribute.String("oauth.connection_id", connectionID),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	conn, err := s.repo.GetConnection(ctx, connectionID)
 	if err != nil {
 		return err
 	}
+	span.SetAttributes(
+		attribute.String("oauth.provider", conn.ProviderKey),
+		attribute.String("tenant_id", conn.TenantID),
+	)
 
-	// Check if refresh token exists
+	// Service connections usually get no refresh token; request a new one instead
 	if len(conn.RefreshTokenEncrypted) == 0 {
+		if conn.IsServiceConnection() {
+			return s.reissueServiceToken(ctx, conn)
+		}
 		return ErrMissingRefreshToken
 	}
 
@@ -333,22 +621,9 @@ func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
 	}
 
 	// Decrypt client secret
-	var clientSecret string
-	if len(providerConfig.ClientSecretEncrypted) > 0 {
-		encrypted := &credential.EncryptedSecret{
-			Ciphertext:   providerConfig.ClientSecretEncrypted,
-			Nonce:        providerConfig.ClientSecretNonce,
-			AuthTag:      providerConfig.ClientSecretAuthTag,
-			EncryptedDEK: providerConfig.ClientSecretEncDEK,
-			KMSKeyID:     providerConfig.ClientSecretKMSKeyID,
-		}
-		decrypted, err := s.encryptionSvc.Decrypt(ctx, encrypted)
-		if err != nil {
-			return fmt.Errorf("failed to decrypt client secret: %w", err)
-		}
-		if secret, ok := decrypted.Value["secret"].(string); ok {
-			clientSecret = secret
-		}
+	clientSecret, err := s.decryptClientSecret(ctx, providerConfig)
+	if err != nil {
+		return err
 	}
 
 	// Decrypt refresh token
@@ -376,6 +651,62 @@ func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
 		return fmt.Errorf("failed to refresh token: %w", err)
 	}
 
+	return s.saveRefreshedToken(ctx, conn, tokenResp)
+}
+
+// reissueServiceToken replaces a service connection's access token by
+// requesting a new one with the client credentials grant
+func (s *Service) reissueServiceToken(ctx context.Context, conn *OAuthConnection) error {
+	provider, ok := s.providers[conn.ProviderKey]
+	if !ok {
+		return ErrInvalidProvider
+	}
+	ccProvider, ok := provider.(ClientCredentialsProvider)
+	if !ok {
+		return ErrUnsupportedGrant
+	}
+
+	providerConfig, err := s.repo.GetProviderByKey(ctx, conn.ProviderKey)
+	if err != nil {
+		return fmt.Errorf("failed to get provider: %w", err)
+	}
+
+	clientSecret, err := s.decryptClientSecret(ctx, providerConfig)
+	if err != nil {
+		return err
+	}
+
+	tokenResp, err := ccProvider.ClientCredentialsToken(ctx, providerConfig.ClientID, clientSecret, conn.Scopes)
+	if err != nil {
+		_ = s.logConnectionAction(ctx, conn.ID, conn.UserID, conn.TenantID, "token_refresh", false, err.Error())
+		return fmt.Errorf("failed to request token: %w", err)
+	}
+
+	return s.saveRefreshedToken(ctx, conn, tokenResp)
+}
+
+// saveRefreshedToken stores a refreshed token response on the connection
+func (s *Service) saveRefreshedToken(ctx context.Context, conn *OAuthConnection, tokenResp *TokenResponse) error {
+	if err := s.applyTokenResponse(ctx, conn, tokenResp); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	conn.LastRefreshAt = &now
+
+	if err := s.repo.UpdateConnection(ctx, conn); err != nil {
+		return fmt.Errorf("failed to update connection: %w", err)
+	}
+
+	// Log successful refresh
+	_ = s.logConnectionAction(ctx, conn.ID, conn.UserID, conn.TenantID, "token_refresh", true, "")
+
+	return nil
+}
+
+// applyTokenResponse encrypts the tokens from a token response onto the
+// connection. The existing refresh token is kept when none is returned.
+func (s *Service) applyTokenResponse(ctx context.Context, conn *OAuthConnection, tokenResp *TokenResponse) error {
 	// Encrypt new access token
 	accessTokenData := &credential.CredentialData{
 		Value: map[string]interface{}{
@@ -387,7 +718,6 @@ func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
 		return fmt.Errorf("failed to encrypt access token: %w", err)
 	}
 
-	// Update connection
 	conn.AccessTokenEncrypted = encryptedAccessToken.Ciphertext
 	conn.AccessTokenNonce = encryptedAccessToken.Nonce
 	conn.AccessTokenAuthTag = encryptedAccessToken.AuthTag
@@ -395,9 +725,8 @@ func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
 	conn.AccessTokenKMSKeyID = encryptedAccessToken.KMSKeyID
 
 	// Update expiry
-	if tokenResp.ExpiresIn > 0 {
-		expiry := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-		conn.TokenExpiry = &expiry
+	if expiry := TokenExpiry(time.Now(), tokenResp.ExpiresIn, s.clockSkew.ExpiryMargin); expiry != nil {
+		conn.TokenExpiry = expiry
 	}
 
 	// Update refresh token if provided
@@ -418,16 +747,6 @@ func (s *Service) RefreshToken(ctx context.Context, connectionID string) error {
 		conn.RefreshTokenKMSKeyID = encryptedRefreshToken.KMSKeyID
 	}
 
-	now := time.Now()
-	conn.LastRefreshAt = &now
-
-	if err := s.repo.UpdateConnection(ctx, conn); err != nil {
-		return fmt.Errorf("failed to update connection: %w", err)
-	}
-
-	// Log successful refresh
-	_ = s.logConnectionAction(ctx, conn.ID, conn.UserID, conn.TenantID, "token_refresh", true, "")
-
 	return nil
 }
 
@@ -450,6 +769,12 @@ func (s *Service) TestConnection(ctx context.Context, connectionID string) error
 		return err
 	}
 
+	// Service connections have no user to look up; obtaining a token is the test
+	if conn.IsServiceConnection() {
+		_ = s.logConnectionAction(ctx, conn.ID, conn.UserID, conn.TenantID, "test_connection", true, "")
+		return nil
+	}
+
 	// Try to get user info
 	_, err = provider.GetUserInfo(ctx, accessToken)
 	if err != nil {
@@ -462,14 +787,27 @@ func (s *Service) TestConnection(ctx context.Context, connectionID string) error
 }
 
 // GetAccessToken retrieves and refreshes if needed the access token
-func (s *Service) GetAccessToken(ctx context.Context, connectionID string) (string, error) {
+func (s *Service) GetAccessToken(ctx context.Context, connectionID string) (_ string, err error) {
+	ctx, span := tracing.StartSpan(ctx, "oauth.token.get", trace.WithAttributes(
+		attribute.String("oauth.connection_id", connectionID),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	conn, err := s.repo.GetConnection(ctx, connectionID)
 	if err != nil {
 		return "", err
 	}
 
+	// A stored token that is still valid counts as a cache hit
+	needsRefresh := conn.NeedsRefresh(s.clockSkew.Tolerance)
+	span.SetAttributes(
+		attribute.String("oauth.provider", conn.ProviderKey),
+		attribute.String("tenant_id", conn.TenantID),
+		attribute.Bool("cache.hit", !needsRefresh),
+	)
+
 	// Check if token needs refresh
-	if conn.NeedsRefresh() {
+	if needsRefresh {
 		if err := s.RefreshToken(ctx, connectionID); err != nil {
 			return "", fmt.Errorf("failed to refresh token: %w", err)
 		}
@@ -0,0 +1,129 @@
+package oauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCallbackRepo serves a single state and records state use and logs.
+// Methods the callback does not reach panic through the nil interface.
+type fakeCallbackRepo struct {
+	OAuthRepository
+	state      *OAuthState
+	usedStates []string
+	logs       []*OAuthConnectionLog
+}
+
+func (r *fakeCallbackRepo) GetState(ctx context.Context, stateStr string) (*OAuthState, error) {
+	if r.state == nil || r.state.State != stateStr {
+		return nil, ErrInvalidState
+	}
+	return r.state, nil
+}
+
+func (r *fakeCallbackRepo) MarkStateUsed(ctx context.Context, stateStr string) error {
+	r.usedStates = append(r.usedStates, stateStr)
+	return nil
+}
+
+func (r *fakeCallbackRepo) GetProviderByKey(ctx context.Context, providerKey string) (*OAuthProvider, error) {
+	return nil, ErrInvalidProvider
+}
+
+func (r *fakeCallbackRepo) CreateLog(ctx context.Context, log *OAuthConnectionLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func TestHandleCallback_ProviderError(t *testing.T) {
+	repo := &fakeCallbackRepo{state: &OAuthState{
+		State:       "state-abc",
+		UserID:      "user-1",
+		TenantID:    "tenant-1",
+		ProviderKey: "github",
+		ExpiresAt:   time.Now().Add(10 * time.Minute),
+	}}
+	svc := NewService(repo, nil, nil, "https://gorax.example.com")
+
+	conn, err := svc.HandleCallback(context.Background(), "user-1", "tenant-1", &CallbackInput{
+		State:            "state-abc",
+		Error:            ProviderErrorAccessDenied,
+		ErrorDescription: "The user has denied your application access.",
+	})
+
+	assert.Nil(t, conn)
+	var providerErr *ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.True(t, providerErr.Denied())
+	assert.False(t, providerErr.Misconfigured())
+	assert.Equal(t, "github", providerErr.ProviderKey)
+	assert.Equal(t, "The user has denied your application access.", providerErr.Reason())
+
+	assert.Equal(t, []string{"state-abc"}, repo.usedStates)
+	require.Len(t, repo.logs, 1)
+	log := repo.logs[0]
+	assert.Empty(t, log.ConnectionID)
+	assert.Equal(t, "authorize", log.Action)
+	assert.False(t, log.Success)
+	assert.Equal(t, "access_denied", log.Metadata["error"])
+	assert.Equal(t, "The user has denied your application access.", log.Metadata["error_description"])
+}
+
+func TestHandleCallback_ProviderError_ForeignState(t *testing.T) {
+	repo := &fakeCallbackRepo{state: &OAuthState{State: "state-abc", UserID: "user-2", TenantID: "tenant-1", ProviderKey: "github"}}
+	svc := NewService(repo, nil, nil, "https://gorax.example.com")
+
+	_, err := svc.HandleCallback(context.Background(), "user-1", "tenant-1", &CallbackInput{
+		State: "state-abc",
+		Error: ProviderErrorInvalidScope,
+	})
+
+	var providerErr *ProviderError
+	require.ErrorAs(t, err, &providerErr)
+	assert.True(t, providerErr.Misconfigured())
+	assert.Empty(t, providerErr.ProviderKey)
+	// Another user's state is left alone
+	assert.Empty(t, repo.usedStates)
+	assert.Len(t, repo.logs, 1)
+}
+
+func TestHandleCallback_StateExpiryClockSkew(t *testing.T) {
+	// The API node that started the flow runs 10s behind the one handling
+	// the callback, so the state looks expired here
+	newRepo := func() *fakeCallbackRepo {
+		return &fakeCallbackRepo{state: &OAuthState{
+			State:       "state-abc",
+			UserID:      "user-1",
+			TenantID:    "tenant-1",
+			ProviderKey: "github",
+			ExpiresAt:   time.Now().Add(-10 * time.Second),
+		}}
+	}
+	input := &CallbackInput{State: "state-abc", Code: "code-123"}
+
+	t.Run("within tolerance", func(t *testing.T) {
+		repo := newRepo()
+		svc := NewService(repo, nil, nil, "https://gorax.example.com")
+
+		_, err := svc.HandleCallback(context.Background(), "user-1", "tenant-1", input)
+
+		// The callback gets past the state check to the provider lookup
+		assert.ErrorIs(t, err, ErrInvalidProvider)
+		assert.Equal(t, []string{"state-abc"}, repo.usedStates)
+	})
+
+	t.Run("without tolerance", func(t *testing.T) {
+		repo := newRepo()
+		svc := NewService(repo, nil, nil, "https://gorax.example.com")
+		svc.SetClockSkew(ClockSkewConfig{})
+
+		_, err := svc.HandleCallback(context.Background(), "user-1", "tenant-1", input)
+
+		assert.ErrorIs(t, err, ErrInvalidState)
+		assert.Empty(t, repo.usedStates)
+	})
+}
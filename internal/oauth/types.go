@@ -18,6 +18,9 @@ type UserInfo struct {
 	Username string `json:"username,omitempty"`
 	Email    string `json:"email,omitempty"`
 	Name     string `json:"name,omitempty"`
+	// Raw is the decoded user-info response, read by the provider's
+	// UserInfoMapping when one is set
+	Raw map[string]interface{} `json:"-"`
 }
 
 // Provider defines the OAuth provider interface
@@ -43,3 +46,48 @@ type Provider interface {
 	// RevokeToken revokes a token (if supported)
 	RevokeToken(ctx context.Context, clientID, clientSecret, token string) error
 }
+
+// ClientCredentialsProvider is implemented by providers that support the
+// client credentials grant for service-to-service connections
+type ClientCredentialsProvider interface {
+	// ClientCredentialsToken requests tokens with the client's own credentials
+	ClientCredentialsToken(ctx context.Context, clientID, clientSecret string, scopes []string) (*TokenResponse, error)
+}
+
+// DeviceAuthorizationResponse represents a device authorization response (RFC 8628 section 3.2)
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// DeviceAuthorizationProvider is implemented by providers that support the
+// device authorization grant for clients without a browser redirect
+type DeviceAuthorizationProvider interface {
+	// RequestDeviceCode starts a device authorization
+	RequestDeviceCode(ctx context.Context, clientID string, scopes []string) (*DeviceAuthorizationResponse, error)
+
+	// PollDeviceToken exchanges a device code for tokens. Until the user acts it
+	// returns ErrAuthorizationPending or ErrSlowDown; ErrAccessDenied and
+	// ErrDeviceCodeExpired end the authorization.
+	PollDeviceToken(ctx context.Context, clientID, clientSecret, deviceCode string) (*TokenResponse, error)
+}
+
+// DeviceTokenError maps an RFC 8628 token error code to its error, or returns
+// nil if the code is not a device flow error
+func DeviceTokenError(code string) error {
+	switch code {
+	case "authorization_pending":
+		return ErrAuthorizationPending
+	case "slow_down":
+		return ErrSlowDown
+	case "access_denied":
+		return ErrAccessDenied
+	case "expired_token":
+		return ErrDeviceCodeExpired
+	}
+	return nil
+}
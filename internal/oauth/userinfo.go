@@ -0,0 +1,249 @@
+package oauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UserInfoMappingConfigKey is the OAuthProvider.Config key holding the
+// provider's UserInfoMapping
+const UserInfoMappingConfigKey = "user_info_mapping"
+
+// ErrInvalidUserInfo is returned when a user-info response has no user ID
+// at the mapped path
+var ErrInvalidUserInfo = errors.New("OAuth user info response has no user ID")
+
+// UserInfoPath lists JSONPath expressions, such as "$.id" or
+// "$.emails[0].value", tried in order until one yields a value. In config
+// it is written as a single path or a list of paths.
+type UserInfoPath []string
+
+// UnmarshalJSON accepts a single path or a list of paths
+func (p *UserInfoPath) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = UserInfoPath{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("user info path must be a string or a list of strings")
+	}
+	*p = list
+	return nil
+}
+
+// UserInfoMapping says where a provider's user-info response holds the
+// user's ID, email, username and name
+type UserInfoMapping struct {
+	ID       UserInfoPath `json:"id"`
+	Email    UserInfoPath `json:"email,omitempty"`
+	Username UserInfoPath `json:"username,omitempty"`
+	Name     UserInfoPath `json:"name,omitempty"`
+}
+
+// DefaultUserInfoMappings are used for providers whose config has no
+// user_info_mapping
+var DefaultUserInfoMappings = map[string]UserInfoMapping{
+	"google": {
+		ID:       UserInfoPath{"$.id", "$.sub"},
+		Email:    UserInfoPath{"$.email"},
+		Username: UserInfoPath{"$.email"},
+		Name:     UserInfoPath{"$.name"},
+	},
+	"github": {
+		ID:       UserInfoPath{"$.id"},
+		Email:    UserInfoPath{"$.email"},
+		Username: UserInfoPath{"$.login"},
+		Name:     UserInfoPath{"$.name"},
+	},
+	"microsoft": {
+		ID:       UserInfoPath{"$.id"},
+		Email:    UserInfoPath{"$.mail", "$.userPrincipalName"},
+		Username: UserInfoPath{"$.userPrincipalName"},
+		Name:     UserInfoPath{"$.displayName"},
+	},
+}
+
+// UserInfoMapping returns the mapping set under user_info_mapping in the
+// provider's config, or the default mapping for its key. It returns nil
+// when neither exists.
+func (p *OAuthProvider) UserInfoMapping() (*UserInfoMapping, error) {
+	if configured, ok := p.Config[UserInfoMappingConfigKey]; ok && configured != nil {
+		data, err := json.Marshal(configured)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", UserInfoMappingConfigKey, err)
+		}
+		var mapping UserInfoMapping
+		if err := json.Unmarshal(data, &mapping); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", UserInfoMappingConfigKey, err)
+		}
+		if err := mapping.Validate(); err != nil {
+			return nil, err
+		}
+		return &mapping, nil
+	}
+
+	if mapping, ok := DefaultUserInfoMappings[p.ProviderKey]; ok {
+		return &mapping, nil
+	}
+	return nil, nil
+}
+
+// NormalizeUserInfo rereads info from the provider's raw user-info
+// response with its UserInfoMapping. Without a mapping, or a raw response
+// to read, info is returned as the provider parsed it.
+func (p *OAuthProvider) NormalizeUserInfo(info *UserInfo) (*UserInfo, error) {
+	mapping, err := p.UserInfoMapping()
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil || info.Raw == nil {
+		return info, nil
+	}
+	return mapping.Apply(info.Raw)
+}
+
+// Validate checks that the mapping has an ID path and that every path is
+// a JSONPath starting at the root
+func (m UserInfoMapping) Validate() error {
+	if len(m.ID) == 0 {
+		return fmt.Errorf("invalid %s: id path is required", UserInfoMappingConfigKey)
+	}
+	fields := []struct {
+		name  string
+		paths UserInfoPath
+	}{{"id", m.ID}, {"email", m.Email}, {"username", m.Username}, {"name", m.Name}}
+	for _, field := range fields {
+		for _, path := range field.paths {
+			if _, err := parseUserInfoPath(path); err != nil {
+				return fmt.Errorf("invalid %s: %s: %w", UserInfoMappingConfigKey, field.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply reads a user-info response with the mapping
+func (m UserInfoMapping) Apply(raw map[string]interface{}) (*UserInfo, error) {
+	info := &UserInfo{
+		ID:       m.ID.lookup(raw),
+		Email:    m.Email.lookup(raw),
+		Username: m.Username.lookup(raw),
+		Name:     m.Name.lookup(raw),
+		Raw:      raw,
+	}
+	if info.ID == "" {
+		return nil, ErrInvalidUserInfo
+	}
+	return info, nil
+}
+
+// DecodeUserInfo decodes a user-info response for use with a
+// UserInfoMapping. Numbers are kept exact so numeric IDs are not rounded.
+func DecodeUserInfo(body []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var raw map[string]interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// lookup returns the first non-empty value found at one of the paths
+func (p UserInfoPath) lookup(raw map[string]interface{}) string {
+	for _, path := range p {
+		segments, err := parseUserInfoPath(path)
+		if err != nil {
+			continue
+		}
+		if value := userInfoString(lookupUserInfoPath(raw, segments)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseUserInfoPath splits a path such as "$.emails[0].value" into its
+// field names and array indexes
+func parseUserInfoPath(path string) ([]interface{}, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(path), "$")
+	if !ok {
+		return nil, fmt.Errorf("path %q must start with $", path)
+	}
+
+	segments := []interface{}{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			field := rest[1 : end+1]
+			if field == "" {
+				return nil, fmt.Errorf("path %q has an empty field name", path)
+			}
+			segments = append(segments, field)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed [", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("path %q has an invalid array index", path)
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q is not a valid JSONPath", path)
+		}
+	}
+	return segments, nil
+}
+
+// lookupUserInfoPath returns the value at a parsed path, or nil
+func lookupUserInfoPath(raw map[string]interface{}, segments []interface{}) interface{} {
+	var current interface{} = raw
+	for _, segment := range segments {
+		switch key := segment.(type) {
+		case string:
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			current = object[key]
+		case int:
+			array, ok := current.([]interface{})
+			if !ok || key >= len(array) {
+				return nil
+			}
+			current = array[key]
+		}
+	}
+	return current
+}
+
+// userInfoString formats a scalar user-info value; other values are empty
+func userInfoString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
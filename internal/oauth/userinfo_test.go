@@ -0,0 +1,146 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserInfoMapping_Defaults(t *testing.T) {
+	tests := []struct {
+		provider string
+		body     string
+		want     UserInfo
+	}{
+		{
+			provider: "google",
+			body:     `{"id": "1098765", "email": "ada@example.com", "name": "Ada Lovelace"}`,
+			want:     UserInfo{ID: "1098765", Username: "ada@example.com", Email: "ada@example.com", Name: "Ada Lovelace"},
+		},
+		{
+			provider: "github",
+			body:     `{"id": 9007199254740993, "login": "ada", "email": null, "name": "Ada Lovelace"}`,
+			want:     UserInfo{ID: "9007199254740993", Username: "ada", Name: "Ada Lovelace"},
+		},
+		{
+			provider: "microsoft",
+			body:     `{"id": "a1b2", "mail": null, "userPrincipalName": "ada@contoso.com", "displayName": "Ada Lovelace"}`,
+			want:     UserInfo{ID: "a1b2", Username: "ada@contoso.com", Email: "ada@contoso.com", Name: "Ada Lovelace"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			raw, err := DecodeUserInfo([]byte(tt.body))
+			require.NoError(t, err)
+
+			info, err := DefaultUserInfoMappings[tt.provider].Apply(raw)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.ID, info.ID)
+			assert.Equal(t, tt.want.Username, info.Username)
+			assert.Equal(t, tt.want.Email, info.Email)
+			assert.Equal(t, tt.want.Name, info.Name)
+		})
+	}
+}
+
+func TestUserInfoMapping_Apply_MissingID(t *testing.T) {
+	raw, err := DecodeUserInfo([]byte(`{"login": "ada"}`))
+	require.NoError(t, err)
+
+	_, err = DefaultUserInfoMappings["github"].Apply(raw)
+
+	assert.ErrorIs(t, err, ErrInvalidUserInfo)
+}
+
+func TestOAuthProvider_UserInfoMapping(t *testing.T) {
+	t.Run("configured mapping", func(t *testing.T) {
+		provider := &OAuthProvider{
+			ProviderKey: "gitlab",
+			Config: map[string]interface{}{
+				"user_info_mapping": map[string]interface{}{
+					"id":       "$.data.user.id",
+					"email":    []interface{}{"$.data.user.emails[1].value", "$.data.user.emails[0].value"},
+					"username": "$.data.user.handle",
+				},
+			},
+		}
+		raw, err := DecodeUserInfo([]byte(`{"data": {"user": {"id": 42, "handle": "ada", "emails": [{"value": "ada@example.com"}]}}}`))
+		require.NoError(t, err)
+
+		info, err := provider.NormalizeUserInfo(&UserInfo{ID: "ignored", Raw: raw})
+
+		require.NoError(t, err)
+		assert.Equal(t, "42", info.ID)
+		assert.Equal(t, "ada", info.Username)
+		assert.Equal(t, "ada@example.com", info.Email)
+		assert.Empty(t, info.Name)
+	})
+
+	t.Run("configured mapping replaces the default", func(t *testing.T) {
+		provider := &OAuthProvider{
+			ProviderKey: "github",
+			Config:      map[string]interface{}{"user_info_mapping": map[string]interface{}{"id": "$.node_id"}},
+		}
+
+		mapping, err := provider.UserInfoMapping()
+
+		require.NoError(t, err)
+		assert.Equal(t, UserInfoPath{"$.node_id"}, mapping.ID)
+	})
+
+	t.Run("default mapping", func(t *testing.T) {
+		mapping, err := (&OAuthProvider{ProviderKey: "microsoft"}).UserInfoMapping()
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultUserInfoMappings["microsoft"], *mapping)
+	})
+
+	t.Run("no mapping keeps the provider's parsing", func(t *testing.T) {
+		provider := &OAuthProvider{ProviderKey: "slack"}
+		info := &UserInfo{ID: "U123", Raw: map[string]interface{}{"ok": true}}
+
+		normalized, err := provider.NormalizeUserInfo(info)
+
+		require.NoError(t, err)
+		assert.Same(t, info, normalized)
+	})
+
+	invalid := []struct {
+		name    string
+		mapping interface{}
+		wantErr string
+	}{
+		{name: "missing id", mapping: map[string]interface{}{"email": "$.email"}, wantErr: "id path is required"},
+		{name: "path without root", mapping: map[string]interface{}{"id": "id"}, wantErr: "must start with $"},
+		{name: "bad index", mapping: map[string]interface{}{"id": "$.ids[x]"}, wantErr: "invalid array index"},
+		{name: "wrong type", mapping: map[string]interface{}{"id": 5}, wantErr: "must be a string or a list of strings"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &OAuthProvider{ProviderKey: "custom", Config: map[string]interface{}{"user_info_mapping": tt.mapping}}
+
+			_, err := provider.UserInfoMapping()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestParseUserInfoPath(t *testing.T) {
+	segments, err := parseUserInfoPath("$.emails[0].value")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"emails", 0, "value"}, segments)
+
+	segments, err = parseUserInfoPath("$")
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+
+	for _, path := range []string{"", "emails", "$..id", "$.emails[0", "$.emails[-1]", "$id"} {
+		_, err := parseUserInfoPath(path)
+		assert.Error(t, err, path)
+	}
+}
@@ -0,0 +1,157 @@
+// Package pagination provides keyset (cursor) pagination for list endpoints.
+//
+// Lists are ordered by (created_at DESC, id DESC). A cursor encodes the
+// position of the last item on a page, so the next page is a range scan on
+// that tuple instead of an OFFSET that reads and discards every earlier row.
+// Cursors are signed and expire so clients cannot forge positions.
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLimit is the page size when the client does not send one
+	DefaultLimit = 20
+	// MaxLimit is the largest accepted page size
+	MaxLimit = 100
+	// DefaultCursorTTL is how long an issued cursor stays valid
+	DefaultCursorTTL = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidCursor is returned for cursors that are malformed or whose signature does not match
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrCursorExpired is returned for cursors older than the codec's TTL
+	ErrCursorExpired = errors.New("cursor has expired")
+)
+
+// Cursor is the (created_at, id) position of the last item on a page
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// cursorPayload is the signed part of an encoded cursor
+type cursorPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+	ExpiresAt int64     `json:"e"`
+}
+
+// Codec signs and verifies cursors with an HMAC key
+type Codec struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewCodec creates a codec. Cursors encoded by one codec only decode with a
+// codec using the same key, so every replica must share it.
+func NewCodec(key []byte, ttl time.Duration) *Codec {
+	if ttl <= 0 {
+		ttl = DefaultCursorTTL
+	}
+	return &Codec{key: key, ttl: ttl, now: time.Now}
+}
+
+// Encode signs the cursor and returns it as an opaque URL-safe string
+func (c *Codec) Encode(cursor Cursor) string {
+	payload, err := json.Marshal(cursorPayload{
+		CreatedAt: cursor.CreatedAt,
+		ID:        cursor.ID,
+		ExpiresAt: c.now().Add(c.ttl).Unix(),
+	})
+	if err != nil {
+		return ""
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// Decode verifies and decodes a cursor returned by Encode
+func (c *Codec) Decode(encoded string) (Cursor, error) {
+	payloadPart, signaturePart, found := strings.Cut(encoded, ".")
+	if !found {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(signaturePart)
+	if err != nil || !hmac.Equal(signature, c.sign(payloadPart)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payloadPart)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if c.now().Unix() > payload.ExpiresAt {
+		return Cursor{}, ErrCursorExpired
+	}
+
+	return Cursor{CreatedAt: payload.CreatedAt, ID: payload.ID}, nil
+}
+
+func (c *Codec) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultCodec = NewCodec(randomKey(), DefaultCursorTTL)
+)
+
+// randomKey returns a per-process key used until Configure is called
+func randomKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("pagination: generate cursor key: %v", err))
+	}
+	return key
+}
+
+// Configure replaces the codec used by EncodeCursor and DecodeCursor. An
+// empty secret keeps the random per-process key, so cursors do not survive a
+// restart or move between replicas.
+func Configure(secret string, ttl time.Duration) {
+	key := []byte(secret)
+	if secret == "" {
+		key = randomKey()
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCodec = NewCodec(key, ttl)
+}
+
+// EncodeCursor encodes a cursor with the configured codec
+func EncodeCursor(cursor Cursor) string {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCodec.Encode(cursor)
+}
+
+// DecodeCursor decodes a cursor with the configured codec
+func DecodeCursor(encoded string) (Cursor, error) {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCodec.Decode(encoded)
+}
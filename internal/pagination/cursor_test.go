@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec := NewCodec([]byte("secret"), time.Hour)
+	cursor := Cursor{CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC), ID: "exec-1"}
+
+	decoded, err := codec.Decode(codec.Encode(cursor))
+
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestCodec_RejectsInvalidCursors(t *testing.T) {
+	codec := NewCodec([]byte("secret"), time.Hour)
+	encoded := codec.Encode(Cursor{CreatedAt: time.Now(), ID: "exec-1"})
+	payload, signature, _ := strings.Cut(encoded, ".")
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"garbage", "not-a-cursor"},
+		{"tampered payload", strings.ToUpper(payload[:4]) + payload[4:] + "." + signature},
+		{"signed with another key", NewCodec([]byte("other"), time.Hour).Encode(Cursor{CreatedAt: time.Now(), ID: "exec-1"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Decode(tt.encoded)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestCodec_RejectsExpiredCursor(t *testing.T) {
+	codec := NewCodec([]byte("secret"), time.Minute)
+	issued := time.Now()
+	codec.now = func() time.Time { return issued }
+	encoded := codec.Encode(Cursor{CreatedAt: issued, ID: "exec-1"})
+
+	codec.now = func() time.Time { return issued.Add(2 * time.Minute) }
+	_, err := codec.Decode(encoded)
+
+	assert.ErrorIs(t, err, ErrCursorExpired)
+}
@@ -0,0 +1,56 @@
+package pagination
+
+import "fmt"
+
+// Params selects a page of a list ordered by (created_at DESC, id DESC).
+// The zero value selects the whole list.
+type Params struct {
+	// After is the position of the last item on the previous page, nil for the first page
+	After *Cursor
+	// Limit is the page size; zero means no limit
+	Limit int
+}
+
+// Keyset returns the SQL condition selecting rows after p.After, or "" on
+// the first page, and the LIMIT clause, or "" when p.Limit is zero. Values
+// are appended to args and referenced from $len(args)+1. The LIMIT fetches
+// one extra row so NewPage can tell whether another page follows.
+//
+// prefix qualifies the created_at and id columns, e.g. "e." in a joined query.
+func (p Params) Keyset(prefix string, args []interface{}) (condition, limit string, _ []interface{}) {
+	if p.After != nil {
+		args = append(args, p.After.CreatedAt, p.After.ID)
+		condition = fmt.Sprintf("(%screated_at, %sid) < ($%d, $%d)", prefix, prefix, len(args)-1, len(args))
+	}
+
+	if p.Limit > 0 {
+		args = append(args, p.Limit+1)
+		limit = fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return condition, limit, args
+}
+
+// Page is one page of a cursor-paginated list
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is passed as the after parameter to fetch the next page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPage builds a page from items fetched with Params.Keyset. When the
+// extra row is present it is dropped and NextCursor points at the last
+// remaining item.
+func NewPage[T any](items []T, limit int, position func(T) Cursor) Page[T] {
+	page := Page[T]{Items: items}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	if limit > 0 && len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		page.NextCursor = EncodeCursor(position(page.Items[limit-1]))
+	}
+
+	return page
+}
@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParams_Keyset(t *testing.T) {
+	t.Run("zero value lists everything", func(t *testing.T) {
+		condition, limit, args := Params{}.Keyset("", []interface{}{"tenant-1"})
+
+		assert.Empty(t, condition)
+		assert.Empty(t, limit)
+		assert.Equal(t, []interface{}{"tenant-1"}, args)
+	})
+
+	t.Run("cursor and limit", func(t *testing.T) {
+		createdAt := time.Now()
+		params := Params{After: &Cursor{CreatedAt: createdAt, ID: "exec-1"}, Limit: 10}
+
+		condition, limit, args := params.Keyset("e.", []interface{}{"tenant-1"})
+
+		assert.Equal(t, "(e.created_at, e.id) < ($2, $3)", condition)
+		assert.Equal(t, " LIMIT $4", limit)
+		assert.Equal(t, []interface{}{"tenant-1", createdAt, "exec-1", 11}, args)
+	})
+}
+
+func TestNewPage(t *testing.T) {
+	type item struct {
+		id        string
+		createdAt time.Time
+	}
+	position := func(i item) Cursor { return Cursor{CreatedAt: i.createdAt, ID: i.id} }
+	now := time.Now()
+	items := []item{{"c", now}, {"b", now.Add(-time.Second)}, {"a", now.Add(-2 * time.Second)}}
+
+	t.Run("more items than limit", func(t *testing.T) {
+		page := NewPage(items, 2, position)
+
+		assert.Len(t, page.Items, 2)
+		cursor, err := DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "b", cursor.ID)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		page := NewPage(items, 3, position)
+
+		assert.Len(t, page.Items, 3)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("nil items encode as empty list", func(t *testing.T) {
+		page := NewPage[item](nil, 3, position)
+
+		assert.NotNil(t, page.Items)
+	})
+}
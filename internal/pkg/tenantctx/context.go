@@ -16,8 +16,16 @@ const (
 	originalTenantIDKey contextKey = "original_tenant_id"
 	// isSwitchedKey indicates if an admin has switched to a different tenant
 	isSwitchedKey contextKey = "tenant_switched"
+	// regionKey stores the data region of the tenant in tenantIDKey
+	regionKey contextKey = "tenant_region"
 )
 
+// tenantRegion is a data region and the tenant it belongs to
+type tenantRegion struct {
+	tenantID string
+	region   string
+}
+
 // ErrNoTenant is returned when no tenant ID is found in context
 var ErrNoTenant = errors.New("no tenant ID in context")
 
@@ -35,6 +43,22 @@ func GetTenantID(ctx context.Context) string {
 	return ""
 }
 
+// WithRegion returns a new context recording the data region of the current
+// tenant. An empty region means the tenant uses the platform defaults.
+func WithRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, regionKey, tenantRegion{tenantID: GetTenantID(ctx), region: region})
+}
+
+// GetRegion returns the data region recorded for tenantID. ok is false when
+// no region was recorded for that tenant, including after a tenant switch.
+func GetRegion(ctx context.Context, tenantID string) (region string, ok bool) {
+	r, found := ctx.Value(regionKey).(tenantRegion)
+	if !found || r.tenantID != tenantID {
+		return "", false
+	}
+	return r.region, true
+}
+
 // MustGetTenantID retrieves the tenant ID from the context or returns an error
 func MustGetTenantID(ctx context.Context) (string, error) {
 	tenantID := GetTenantID(ctx)
@@ -179,3 +179,20 @@ func TestContextChaining(t *testing.T) {
 		assert.False(t, IsTenantSwitched(ctx))
 	})
 }
+
+func TestGetRegion(t *testing.T) {
+	ctx := WithRegion(WithTenantID(context.Background(), "tenant-1"), "eu")
+
+	region, ok := GetRegion(ctx, "tenant-1")
+	assert.True(t, ok)
+	assert.Equal(t, "eu", region)
+
+	_, ok = GetRegion(ctx, "tenant-2")
+	assert.False(t, ok, "a region is only reported for the tenant it was recorded for")
+
+	_, ok = GetRegion(WithSwitchedTenant(ctx, "tenant-2"), "tenant-2")
+	assert.False(t, ok, "a tenant switch does not carry the region over")
+
+	_, ok = GetRegion(context.Background(), "tenant-1")
+	assert.False(t, ok)
+}
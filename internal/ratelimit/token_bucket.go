@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Quota describes a token bucket that holds up to Limit tokens and refills
+// Limit tokens every Period
+type Quota struct {
+	Limit  int64
+	Period time.Duration
+}
+
+// Decision is the outcome of taking a token from a bucket
+type Decision struct {
+	Allowed   bool
+	Limit     int64
+	Remaining int64
+	// RetryAfter is how long until a token is available when Allowed is false
+	RetryAfter time.Duration
+}
+
+// BucketStore takes tokens from token buckets identified by key. Use
+// MemoryBucketStore for a single instance and RedisBucketStore to share
+// buckets between instances.
+type BucketStore interface {
+	Take(ctx context.Context, key string, quota Quota) (Decision, error)
+}
+
+// validate checks if a bucket key and quota are valid
+func (q Quota) validate(key string) error {
+	if key == "" {
+		return ErrInvalidTenantID
+	}
+	if q.Limit <= 0 {
+		return ErrInvalidLimit
+	}
+	if q.Period <= 0 {
+		return ErrInvalidWindow
+	}
+	return nil
+}
+
+// refill returns the tokens in a bucket after elapsed time, capped at the limit
+func (q Quota) refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += float64(elapsed) * float64(q.Limit) / float64(q.Period)
+	}
+	return math.Min(tokens, float64(q.Limit))
+}
+
+// decide builds the decision for a bucket holding tokens after the take
+func (q Quota) decide(allowed bool, tokens float64) Decision {
+	decision := Decision{
+		Allowed:   allowed,
+		Limit:     q.Limit,
+		Remaining: int64(math.Floor(tokens)),
+	}
+	if !allowed {
+		perToken := float64(q.Period) / float64(q.Limit)
+		decision.RetryAfter = time.Duration(math.Ceil((1 - tokens) * perToken))
+	}
+	return decision
+}
+
+// memoryBucket is the state of one in-memory token bucket
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+// MemoryBucketStore keeps token buckets in process memory
+type MemoryBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryBucketStore creates an in-memory token bucket store
+func NewMemoryBucketStore() *MemoryBucketStore {
+	return &MemoryBucketStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+// Take removes a token from the bucket for key if one is available
+func (s *MemoryBucketStore) Take(ctx context.Context, key string, quota Quota) (Decision, error) {
+	if err := quota.validate(key); err != nil {
+		return Decision{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(quota.Limit), updated: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = quota.refill(bucket.tokens, now.Sub(bucket.updated))
+	bucket.updated = now
+	bucket.period = quota.Period
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return quota.decide(allowed, bucket.tokens), nil
+}
+
+// sweep drops buckets idle for a full period, which have refilled and are
+// indistinguishable from a new bucket. It runs at most once a minute.
+func (s *MemoryBucketStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.updated) >= bucket.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// takeTokenScript refills and takes from a bucket stored as a hash of tokens
+// and last update time in milliseconds. Token counts are returned as strings
+// because Redis truncates Lua numbers to integers.
+var takeTokenScript = redis.NewScript(`
+	local limit = tonumber(ARGV[1])
+	local period = tonumber(ARGV[2])
+	local now = tonumber(ARGV[3])
+
+	local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+	local tokens = tonumber(state[1])
+	local updated = tonumber(state[2])
+	if tokens == nil or updated == nil then
+		tokens = limit
+		updated = now
+	end
+
+	local elapsed = now - updated
+	if elapsed > 0 then
+		tokens = math.min(limit, tokens + elapsed * limit / period)
+	end
+
+	local allowed = 0
+	if tokens >= 1 then
+		tokens = tokens - 1
+		allowed = 1
+	end
+
+	redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(math.max(now, updated)))
+	redis.call('PEXPIRE', KEYS[1], period)
+	return {allowed, tostring(tokens)}
+`)
+
+// RedisBucketStore keeps token buckets in Redis so limits are shared between
+// API instances
+type RedisBucketStore struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+// NewRedisBucketStore creates a Redis-backed token bucket store
+func NewRedisBucketStore(client redis.Scripter) *RedisBucketStore {
+	return &RedisBucketStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Take atomically removes a token from the bucket for key if one is available
+func (s *RedisBucketStore) Take(ctx context.Context, key string, quota Quota) (Decision, error) {
+	if err := quota.validate(key); err != nil {
+		return Decision{}, err
+	}
+	if quota.Period < time.Millisecond {
+		return Decision{}, ErrInvalidWindow
+	}
+
+	result, err := takeTokenScript.Run(ctx, s.client, []string{"ratelimit:bucket:" + key},
+		quota.Limit,
+		quota.Period.Milliseconds(),
+		s.now().UnixMilli(),
+	).Slice()
+	if err != nil {
+		return Decision{}, fmt.Errorf("rate limit check failed: %w", err)
+	}
+
+	if len(result) != 2 {
+		return Decision{}, fmt.Errorf("unexpected result from rate limit script")
+	}
+	allowed, ok := result[0].(int64)
+	if !ok {
+		return Decision{}, fmt.Errorf("unexpected result type from rate limit script")
+	}
+	tokensStr, ok := result[1].(string)
+	if !ok {
+		return Decision{}, fmt.Errorf("unexpected result type from rate limit script")
+	}
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Decision{}, fmt.Errorf("unexpected token count from rate limit script: %w", err)
+	}
+
+	return quota.decide(allowed == 1, tokens), nil
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a manually advanced clock for bucket stores
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// newTestBucketStores returns a memory and a Redis store sharing one clock
+func newTestBucketStores(t *testing.T) (map[string]BucketStore, *testClock) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+
+	memory := NewMemoryBucketStore()
+	memory.now = clock.Now
+	redisStore := NewRedisBucketStore(client)
+	redisStore.now = clock.Now
+
+	return map[string]BucketStore{"memory": memory, "redis": redisStore}, clock
+}
+
+// TestBucketStore_Take tests that both stores drain, refill and isolate buckets
+func TestBucketStore_Take(t *testing.T) {
+	stores, clock := newTestBucketStores(t)
+	quota := Quota{Limit: 3, Period: time.Minute}
+	ctx := context.Background()
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			key := "tenant-" + name
+
+			for i := int64(2); i >= 0; i-- {
+				decision, err := store.Take(ctx, key, quota)
+				require.NoError(t, err)
+				assert.True(t, decision.Allowed)
+				assert.Equal(t, int64(3), decision.Limit)
+				assert.Equal(t, i, decision.Remaining)
+			}
+
+			decision, err := store.Take(ctx, key, quota)
+			require.NoError(t, err)
+			assert.False(t, decision.Allowed)
+			assert.Equal(t, int64(0), decision.Remaining)
+			assert.Equal(t, 20*time.Second, decision.RetryAfter)
+
+			other, err := store.Take(ctx, key+"-other", quota)
+			require.NoError(t, err)
+			assert.True(t, other.Allowed, "buckets are per key")
+
+			clock.now = clock.now.Add(20 * time.Second)
+			decision, err = store.Take(ctx, key, quota)
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed, "one token refills every 20s")
+			assert.Equal(t, int64(0), decision.Remaining)
+
+			clock.now = clock.now.Add(time.Hour)
+			decision, err = store.Take(ctx, key, quota)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), decision.Remaining, "refill is capped at the limit")
+		})
+	}
+}
+
+// TestBucketStore_Take_InvalidQuota tests parameter validation
+func TestBucketStore_Take_InvalidQuota(t *testing.T) {
+	stores, _ := newTestBucketStores(t)
+	ctx := context.Background()
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Take(ctx, "", Quota{Limit: 1, Period: time.Minute})
+			assert.ErrorIs(t, err, ErrInvalidTenantID)
+
+			_, err = store.Take(ctx, "tenant-1", Quota{Limit: 0, Period: time.Minute})
+			assert.ErrorIs(t, err, ErrInvalidLimit)
+
+			_, err = store.Take(ctx, "tenant-1", Quota{Limit: 1})
+			assert.ErrorIs(t, err, ErrInvalidWindow)
+		})
+	}
+}
+
+// TestMemoryBucketStore_Sweep tests that idle buckets are dropped
+func TestMemoryBucketStore_Sweep(t *testing.T) {
+	clock := &testClock{now: time.Unix(1700000000, 0)}
+	store := NewMemoryBucketStore()
+	store.now = clock.Now
+	ctx := context.Background()
+
+	_, err := store.Take(ctx, "idle", Quota{Limit: 5, Period: time.Minute})
+	require.NoError(t, err)
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, err = store.Take(ctx, "active", Quota{Limit: 5, Period: time.Minute})
+	require.NoError(t, err)
+
+	assert.NotContains(t, store.buckets, "idle")
+	assert.Contains(t, store.buckets, "active")
+}
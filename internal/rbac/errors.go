@@ -27,6 +27,14 @@ var (
 	// ErrPermissionDenied is returned when a user doesn't have required permission
 	ErrPermissionDenied = errors.New("permission denied")
 
+	// ErrInvalidPermission is returned when a permission string is not in
+	// "resource:action" form
+	ErrInvalidPermission = errors.New("invalid permission")
+
+	// ErrPermissionEscalation is returned when a user grants a permission they
+	// don't hold themselves
+	ErrPermissionEscalation = errors.New("cannot grant a permission you do not hold")
+
 	// ErrUserNotFound is returned when a user is not found
 	ErrUserNotFound = errors.New("user not found")
 )
@@ -1,6 +1,8 @@
 package rbac
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,20 @@ type Permission struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+// Key returns the permission in "resource:action" form
+func (p Permission) Key() string {
+	return p.Resource + ":" + p.Action
+}
+
+// ParsePermission splits a "resource:action" permission string
+func ParsePermission(perm string) (resource, action string, err error) {
+	resource, action, ok := strings.Cut(perm, ":")
+	if !ok || resource == "" || action == "" || strings.Contains(action, ":") {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidPermission, perm)
+	}
+	return resource, action, nil
+}
+
 // UserRole represents the assignment of a role to a user
 type UserRole struct {
 	UserID    string    `json:"user_id" db:"user_id"`
@@ -53,6 +69,22 @@ const (
 	RoleOperator = "operator"
 )
 
+// Permission resources and actions checked outside the route middleware
+const (
+	ResourceCredential = "credential"
+
+	// ActionReadValue allows decrypting a credential's value; credential:read
+	// only covers its metadata
+	ActionReadValue = "read_value"
+)
+
+// ReadValueKeyAction returns the action allowing a credential value read
+// limited to key. credential:read_value grants every key, while
+// credential:read_value:<key> grants only that key.
+func ReadValueKeyAction(key string) string {
+	return ActionReadValue + ":" + key
+}
+
 // Audit action types
 const (
 	AuditActionRoleCreated       = "role_created"
@@ -64,18 +96,22 @@ const (
 	AuditActionUserRoleRemoved   = "user_role_removed"
 )
 
-// CreateRoleRequest represents a request to create a role
+// CreateRoleRequest represents a request to create a role. Permissions can be
+// given by ID, as "resource:action" strings, or both.
 type CreateRoleRequest struct {
 	Name          string   `json:"name" validate:"required,min=1,max=100"`
 	Description   string   `json:"description"`
 	PermissionIDs []string `json:"permission_ids"`
+	Permissions   []string `json:"permissions"`
 }
 
-// UpdateRoleRequest represents a request to update a role
+// UpdateRoleRequest represents a request to update a role. When either
+// PermissionIDs or Permissions is set, the role's permissions are replaced.
 type UpdateRoleRequest struct {
 	Name          *string  `json:"name" validate:"omitempty,min=1,max=100"`
 	Description   *string  `json:"description"`
 	PermissionIDs []string `json:"permission_ids"`
+	Permissions   []string `json:"permissions"`
 }
 
 // AssignRolesRequest represents a request to assign roles to a user
@@ -88,7 +124,7 @@ func (r *CreateRoleRequest) Validate() error {
 	if r.Name == "" {
 		return ErrInvalidRoleName
 	}
-	return nil
+	return validatePermissionStrings(r.Permissions)
 }
 
 // Validate validates the UpdateRoleRequest
@@ -96,6 +132,15 @@ func (r *UpdateRoleRequest) Validate() error {
 	if r.Name != nil && *r.Name == "" {
 		return ErrInvalidRoleName
 	}
+	return validatePermissionStrings(r.Permissions)
+}
+
+func validatePermissionStrings(perms []string) error {
+	for _, perm := range perms {
+		if _, _, err := ParsePermission(perm); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
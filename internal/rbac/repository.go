@@ -259,6 +259,20 @@ func (r *Repository) AssignRolesToUser(ctx context.Context, userID string, roleI
 	return nil
 }
 
+// AddUserRole assigns one role to a user, keeping their other roles
+func (r *Repository) AddUserRole(ctx context.Context, userID, roleID, grantedBy string) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_roles (user_id, role_id, granted_by) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role_id) DO NOTHING`,
+		userID, roleID, grantedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("add user role: %w", err)
+	}
+
+	return nil
+}
+
 // GetUserPermissions retrieves all permissions for a user across all their roles
 func (r *Repository) GetUserPermissions(ctx context.Context, userID, tenantID string) ([]Permission, error) {
 	var permissions []Permission
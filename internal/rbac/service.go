@@ -17,6 +17,7 @@ type RepositoryInterface interface {
 	SetRolePermissions(ctx context.Context, roleID string, permissionIDs []string) error
 	GetUserRoles(ctx context.Context, userID, tenantID string) ([]*Role, error)
 	AssignRolesToUser(ctx context.Context, userID string, roleIDs []string, grantedBy string) error
+	AddUserRole(ctx context.Context, userID, roleID, grantedBy string) error
 	GetUserPermissions(ctx context.Context, userID, tenantID string) ([]Permission, error)
 	HasPermission(ctx context.Context, userID, tenantID, resource, action string) (bool, error)
 	ListPermissions(ctx context.Context) ([]Permission, error)
@@ -37,12 +38,21 @@ func NewService(repo RepositoryInterface) *Service {
 	}
 }
 
-// CreateRole creates a new role
+// CreateRole creates a new custom role. The creating user must hold every
+// permission the role grants.
 func (s *Service) CreateRole(ctx context.Context, tenantID, userID string, req *CreateRoleRequest) (*Role, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	permissionIDs, err := s.resolvePermissions(ctx, req.PermissionIDs, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGrantable(ctx, tenantID, userID, permissionIDs); err != nil {
+		return nil, err
+	}
+
 	role := &Role{
 		TenantID:    tenantID,
 		Name:        req.Name,
@@ -55,8 +65,8 @@ func (s *Service) CreateRole(ctx context.Context, tenantID, userID string, req *
 	}
 
 	// Set permissions if provided
-	if len(req.PermissionIDs) > 0 {
-		if err := s.repo.SetRolePermissions(ctx, role.ID, req.PermissionIDs); err != nil {
+	if len(permissionIDs) > 0 {
+		if err := s.repo.SetRolePermissions(ctx, role.ID, permissionIDs); err != nil {
 			return nil, fmt.Errorf("set role permissions: %w", err)
 		}
 	}
@@ -64,7 +74,7 @@ func (s *Service) CreateRole(ctx context.Context, tenantID, userID string, req *
 	// Audit log
 	s.auditLog(ctx, tenantID, userID, AuditActionRoleCreated, "role", role.ID, map[string]interface{}{
 		"role_name":        role.Name,
-		"permission_count": len(req.PermissionIDs),
+		"permission_count": len(permissionIDs),
 	})
 
 	return role, nil
@@ -106,7 +116,8 @@ func (s *Service) ListRoles(ctx context.Context, tenantID string) ([]*Role, erro
 	return roles, nil
 }
 
-// UpdateRole updates a role
+// UpdateRole updates a role. When the permissions are replaced, the updating
+// user must hold every permission the role grants.
 func (s *Service) UpdateRole(ctx context.Context, roleID, tenantID, userID string, req *UpdateRoleRequest) error {
 	if err := req.Validate(); err != nil {
 		return err
@@ -121,6 +132,18 @@ func (s *Service) UpdateRole(ctx context.Context, roleID, tenantID, userID strin
 		return ErrSystemRoleCannotBeModified
 	}
 
+	replacePermissions := req.PermissionIDs != nil || req.Permissions != nil
+	var permissionIDs []string
+	if replacePermissions {
+		permissionIDs, err = s.resolvePermissions(ctx, req.PermissionIDs, req.Permissions)
+		if err != nil {
+			return err
+		}
+		if err := s.checkGrantable(ctx, tenantID, userID, permissionIDs); err != nil {
+			return err
+		}
+	}
+
 	// Update role fields
 	if req.Name != nil {
 		role.Name = *req.Name
@@ -134,8 +157,8 @@ func (s *Service) UpdateRole(ctx context.Context, roleID, tenantID, userID strin
 	}
 
 	// Update permissions if provided
-	if req.PermissionIDs != nil {
-		if err := s.repo.SetRolePermissions(ctx, roleID, req.PermissionIDs); err != nil {
+	if replacePermissions {
+		if err := s.repo.SetRolePermissions(ctx, roleID, permissionIDs); err != nil {
 			return fmt.Errorf("set role permissions: %w", err)
 		}
 	}
@@ -187,7 +210,8 @@ func (s *Service) GetRolePermissions(ctx context.Context, roleID, tenantID strin
 	return permissions, nil
 }
 
-// UpdateRolePermissions updates permissions for a role
+// UpdateRolePermissions replaces the permissions of a role. The updating user
+// must hold every permission the role grants.
 func (s *Service) UpdateRolePermissions(ctx context.Context, roleID, tenantID, userID string, permissionIDs []string) error {
 	role, err := s.repo.GetRoleByID(ctx, roleID, tenantID)
 	if err != nil {
@@ -198,6 +222,14 @@ func (s *Service) UpdateRolePermissions(ctx context.Context, roleID, tenantID, u
 		return ErrSystemRoleCannotBeModified
 	}
 
+	permissionIDs, err = s.resolvePermissions(ctx, permissionIDs, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.checkGrantable(ctx, tenantID, userID, permissionIDs); err != nil {
+		return err
+	}
+
 	if err := s.repo.SetRolePermissions(ctx, roleID, permissionIDs); err != nil {
 		return fmt.Errorf("set role permissions: %w", err)
 	}
@@ -221,7 +253,8 @@ func (s *Service) GetUserRoles(ctx context.Context, userID, tenantID string) ([]
 	return roles, nil
 }
 
-// AssignRolesToUser assigns roles to a user
+// AssignRolesToUser replaces a user's roles. The granting user must hold every
+// permission the roles grant.
 func (s *Service) AssignRolesToUser(ctx context.Context, userID, tenantID, grantedBy string, req *AssignRolesRequest) error {
 	if err := req.Validate(); err != nil {
 		return err
@@ -233,6 +266,9 @@ func (s *Service) AssignRolesToUser(ctx context.Context, userID, tenantID, grant
 		if err != nil {
 			return fmt.Errorf("get role %s: %w", roleID, err)
 		}
+		if err := s.checkRoleGrantable(ctx, roleID, tenantID, grantedBy); err != nil {
+			return err
+		}
 	}
 
 	if err := s.repo.AssignRolesToUser(ctx, userID, req.RoleIDs, grantedBy); err != nil {
@@ -248,6 +284,117 @@ func (s *Service) AssignRolesToUser(ctx context.Context, userID, tenantID, grant
 	return nil
 }
 
+// AssignRole gives a user one more role, keeping the roles they already have.
+// The granting user must hold every permission the role grants.
+func (s *Service) AssignRole(ctx context.Context, userID, tenantID, grantedBy, roleID string) error {
+	role, err := s.repo.GetRoleByID(ctx, roleID, tenantID)
+	if err != nil {
+		return fmt.Errorf("get role: %w", err)
+	}
+	if err := s.checkRoleGrantable(ctx, roleID, tenantID, grantedBy); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddUserRole(ctx, userID, roleID, grantedBy); err != nil {
+		return fmt.Errorf("add user role: %w", err)
+	}
+
+	// Audit log
+	s.auditLog(ctx, tenantID, grantedBy, AuditActionUserRoleAssigned, "user", userID, map[string]interface{}{
+		"role_id":   roleID,
+		"role_name": role.Name,
+	})
+
+	return nil
+}
+
+// resolvePermissions returns the deduplicated IDs of the given permission IDs
+// and "resource:action" strings, checking that each exists
+func (s *Service) resolvePermissions(ctx context.Context, permissionIDs, perms []string) ([]string, error) {
+	if len(permissionIDs) == 0 && len(perms) == 0 {
+		return []string{}, nil
+	}
+
+	all, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list permissions: %w", err)
+	}
+	byID := make(map[string]bool, len(all))
+	byKey := make(map[string]string, len(all))
+	for _, perm := range all {
+		byID[perm.ID] = true
+		byKey[perm.Key()] = perm.ID
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(permissionIDs)+len(perms))
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range permissionIDs {
+		if !byID[id] {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionNotFound, id)
+		}
+		add(id)
+	}
+	for _, perm := range perms {
+		if _, _, err := ParsePermission(perm); err != nil {
+			return nil, err
+		}
+		id, ok := byKey[perm]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionNotFound, perm)
+		}
+		add(id)
+	}
+
+	return ids, nil
+}
+
+// checkGrantable returns ErrPermissionEscalation unless the granting user
+// holds every one of the permissions in the tenant
+func (s *Service) checkGrantable(ctx context.Context, tenantID, grantedBy string, permissionIDs []string) error {
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	held, err := s.repo.GetUserPermissions(ctx, grantedBy, tenantID)
+	if err != nil {
+		return fmt.Errorf("get granting user permissions: %w", err)
+	}
+	heldIDs := make(map[string]bool, len(held))
+	for _, perm := range held {
+		heldIDs[perm.ID] = true
+	}
+
+	for _, id := range permissionIDs {
+		if !heldIDs[id] {
+			return ErrPermissionEscalation
+		}
+	}
+
+	return nil
+}
+
+// checkRoleGrantable checks that the granting user holds every permission of a role
+func (s *Service) checkRoleGrantable(ctx context.Context, roleID, tenantID, grantedBy string) error {
+	permissions, err := s.repo.GetRolePermissions(ctx, roleID)
+	if err != nil {
+		return fmt.Errorf("get role permissions: %w", err)
+	}
+
+	ids := make([]string, len(permissions))
+	for i, perm := range permissions {
+		ids[i] = perm.ID
+	}
+
+	return s.checkGrantable(ctx, tenantID, grantedBy, ids)
+}
+
 // GetUserPermissions retrieves all permissions for a user
 func (s *Service) GetUserPermissions(ctx context.Context, userID, tenantID string) ([]Permission, error) {
 	permissions, err := s.repo.GetUserPermissions(ctx, userID, tenantID)
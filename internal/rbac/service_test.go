@@ -80,6 +80,11 @@ func (m *MockRepository) AssignRolesToUser(ctx context.Context, userID string, r
 	return args.Error(0)
 }
 
+func (m *MockRepository) AddUserRole(ctx context.Context, userID, roleID, grantedBy string) error {
+	args := m.Called(ctx, userID, roleID, grantedBy)
+	return args.Error(0)
+}
+
 func (m *MockRepository) GetUserPermissions(ctx context.Context, userID, tenantID string) ([]Permission, error) {
 	args := m.Called(ctx, userID, tenantID)
 	if args.Get(0) == nil {
@@ -122,6 +127,14 @@ func (m *MockRepository) GetAuditLogs(ctx context.Context, tenantID string, limi
 	return args.Get(0).([]*AuditLog), args.Error(1)
 }
 
+// testPermissions is the permission catalogue used by the role tests
+var testPermissions = []Permission{
+	{ID: "perm-1", Resource: "workflow", Action: "create"},
+	{ID: "perm-2", Resource: "workflow", Action: "read"},
+	{ID: "perm-3", Resource: "workflow", Action: "execute"},
+	{ID: "perm-4", Resource: "workflow", Action: "delete"},
+}
+
 func TestService_CreateRole(t *testing.T) {
 	ctx := context.Background()
 	tenantID := "tenant-123"
@@ -141,6 +154,8 @@ func TestService_CreateRole(t *testing.T) {
 				PermissionIDs: []string{"perm-1", "perm-2"},
 			},
 			setupMock: func(repo *MockRepository) {
+				repo.On("ListPermissions", ctx).Return(testPermissions, nil)
+				repo.On("GetUserPermissions", ctx, userID, tenantID).Return(testPermissions, nil)
 				repo.On("CreateRole", ctx, mock.MatchedBy(func(r *Role) bool {
 					return r.Name == "editor" && r.TenantID == tenantID
 				})).Return(nil).Run(func(args mock.Arguments) {
@@ -154,6 +169,56 @@ func TestService_CreateRole(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "success with permission strings",
+			req: &CreateRoleRequest{
+				Name:          "deployer",
+				PermissionIDs: []string{"perm-1"},
+				Permissions:   []string{"workflow:read", "workflow:execute"},
+			},
+			setupMock: func(repo *MockRepository) {
+				repo.On("ListPermissions", ctx).Return(testPermissions, nil)
+				repo.On("GetUserPermissions", ctx, userID, tenantID).Return(testPermissions, nil)
+				repo.On("CreateRole", ctx, mock.AnythingOfType("*rbac.Role")).Return(nil).Run(func(args mock.Arguments) {
+					args.Get(1).(*Role).ID = "role-456"
+				})
+				repo.On("SetRolePermissions", ctx, "role-456", []string{"perm-1", "perm-2", "perm-3"}).Return(nil)
+				repo.On("CreateAuditLog", ctx, mock.AnythingOfType("*rbac.AuditLog")).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "grants a permission the creator does not hold",
+			req: &CreateRoleRequest{
+				Name:        "escalated",
+				Permissions: []string{"workflow:read", "workflow:delete"},
+			},
+			setupMock: func(repo *MockRepository) {
+				repo.On("ListPermissions", ctx).Return(testPermissions, nil)
+				repo.On("GetUserPermissions", ctx, userID, tenantID).Return(testPermissions[:3], nil)
+			},
+			wantErr: ErrPermissionEscalation,
+		},
+		{
+			name: "unknown permission",
+			req: &CreateRoleRequest{
+				Name:        "unknown",
+				Permissions: []string{"workflow:teleport"},
+			},
+			setupMock: func(repo *MockRepository) {
+				repo.On("ListPermissions", ctx).Return(testPermissions, nil)
+			},
+			wantErr: ErrPermissionNotFound,
+		},
+		{
+			name: "malformed permission",
+			req: &CreateRoleRequest{
+				Name:        "malformed",
+				Permissions: []string{"workflow"},
+			},
+			setupMock: func(repo *MockRepository) {},
+			wantErr:   ErrInvalidPermission,
+		},
 		{
 			name: "invalid name",
 			req: &CreateRoleRequest{
@@ -268,6 +333,8 @@ func TestService_UpdateRole(t *testing.T) {
 					IsSystem: false,
 				}
 				repo.On("GetRoleByID", ctx, roleID, tenantID).Return(existingRole, nil)
+				repo.On("ListPermissions", ctx).Return(testPermissions, nil)
+				repo.On("GetUserPermissions", ctx, userID, tenantID).Return(testPermissions, nil)
 				repo.On("UpdateRole", ctx, mock.MatchedBy(func(r *Role) bool {
 					return r.Name == "new-editor"
 				})).Return(nil)
@@ -393,11 +460,26 @@ func TestService_AssignRolesToUser(t *testing.T) {
 			setupMock: func(repo *MockRepository) {
 				repo.On("GetRoleByID", ctx, "role-1", tenantID).Return(&Role{ID: "role-1", TenantID: tenantID}, nil)
 				repo.On("GetRoleByID", ctx, "role-2", tenantID).Return(&Role{ID: "role-2", TenantID: tenantID}, nil)
+				repo.On("GetRolePermissions", ctx, "role-1").Return(testPermissions[:1], nil)
+				repo.On("GetRolePermissions", ctx, "role-2").Return(testPermissions[1:2], nil)
+				repo.On("GetUserPermissions", ctx, grantedBy, tenantID).Return(testPermissions, nil)
 				repo.On("AssignRolesToUser", ctx, userID, []string{"role-1", "role-2"}, grantedBy).Return(nil)
 				repo.On("CreateAuditLog", ctx, mock.AnythingOfType("*rbac.AuditLog")).Return(nil)
 			},
 			wantErr: nil,
 		},
+		{
+			name: "role grants a permission the granter does not hold",
+			req: &AssignRolesRequest{
+				RoleIDs: []string{"role-admin"},
+			},
+			setupMock: func(repo *MockRepository) {
+				repo.On("GetRoleByID", ctx, "role-admin", tenantID).Return(&Role{ID: "role-admin", TenantID: tenantID}, nil)
+				repo.On("GetRolePermissions", ctx, "role-admin").Return(testPermissions, nil)
+				repo.On("GetUserPermissions", ctx, grantedBy, tenantID).Return(testPermissions[:2], nil)
+			},
+			wantErr: ErrPermissionEscalation,
+		},
 		{
 			name: "no roles provided",
 			req: &AssignRolesRequest{
@@ -437,6 +519,49 @@ func TestService_AssignRolesToUser(t *testing.T) {
 	}
 }
 
+func TestService_AssignRole(t *testing.T) {
+	ctx := context.Background()
+	tenantID := "tenant-123"
+
+	t.Run("adds the role", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetRoleByID", ctx, "role-1", tenantID).Return(&Role{ID: "role-1", TenantID: tenantID, Name: "deployer"}, nil)
+		repo.On("GetRolePermissions", ctx, "role-1").Return(testPermissions[:2], nil)
+		repo.On("GetUserPermissions", ctx, "admin-123", tenantID).Return(testPermissions, nil)
+		repo.On("AddUserRole", ctx, "user-123", "role-1", "admin-123").Return(nil)
+		repo.On("CreateAuditLog", ctx, mock.AnythingOfType("*rbac.AuditLog")).Return(nil)
+
+		err := NewService(repo).AssignRole(ctx, "user-123", tenantID, "admin-123", "role-1")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("granter lacks a role permission", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetRoleByID", ctx, "role-1", tenantID).Return(&Role{ID: "role-1", TenantID: tenantID}, nil)
+		repo.On("GetRolePermissions", ctx, "role-1").Return(testPermissions, nil)
+		repo.On("GetUserPermissions", ctx, "editor-123", tenantID).Return(testPermissions[:1], nil)
+
+		err := NewService(repo).AssignRole(ctx, "user-123", tenantID, "editor-123", "role-1")
+
+		assert.ErrorIs(t, err, ErrPermissionEscalation)
+		repo.AssertNotCalled(t, "AddUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestParsePermission(t *testing.T) {
+	resource, action, err := ParsePermission("credential:read_value")
+	assert.NoError(t, err)
+	assert.Equal(t, "credential", resource)
+	assert.Equal(t, "read_value", action)
+
+	for _, perm := range []string{"", "workflow", ":read", "workflow:", "a:b:c"} {
+		_, _, err := ParsePermission(perm)
+		assert.ErrorIs(t, err, ErrInvalidPermission, perm)
+	}
+}
+
 func TestService_CheckPermission(t *testing.T) {
 	ctx := context.Background()
 	userID := "user-123"
@@ -0,0 +1,45 @@
+package residency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/database"
+)
+
+// ExecutionStore names the execution database in RegionNotConfiguredError
+const ExecutionStore = "execution database"
+
+// ConnectDatabases opens the execution database of each region that has one.
+// If any connection fails, those already opened are closed.
+func ConnectDatabases(ctx context.Context, regions []config.DataRegionConfig) (map[string]*sqlx.DB, error) {
+	dbs := make(map[string]*sqlx.DB)
+	for _, region := range regions {
+		if region.DatabaseURL == "" {
+			continue
+		}
+
+		cfg, err := config.LoadDatabaseConfig(region.DatabaseURL)
+		if err != nil {
+			CloseDatabases(dbs)
+			return nil, fmt.Errorf("invalid database URL for data region %s: %w", region.Name, err)
+		}
+		db, err := database.Connect(ctx, cfg)
+		if err != nil {
+			CloseDatabases(dbs)
+			return nil, fmt.Errorf("failed to connect to database for data region %s: %w", region.Name, err)
+		}
+		dbs[region.Name] = db
+	}
+	return dbs, nil
+}
+
+// CloseDatabases closes the databases opened by ConnectDatabases
+func CloseDatabases(dbs map[string]*sqlx.DB) {
+	for _, db := range dbs {
+		_ = db.Close()
+	}
+}
@@ -0,0 +1,39 @@
+package residency
+
+import (
+	"context"
+	"fmt"
+
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+
+	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/credential"
+)
+
+// CredentialKeyStore names the credential KMS key in RegionNotConfiguredError
+const CredentialKeyStore = "credential KMS key"
+
+// NewKMSEncryptionServices creates a KMS encryption service for each region
+// with a KMS key, using a KMS client in the region's AWS region. Tenants with
+// their own KMS key, resolved by tenantKeys, keep using it.
+func NewKMSEncryptionServices(ctx context.Context, regions []config.DataRegionConfig, tenantKeys credential.TenantKeyResolver) (map[string]*credential.KMSEncryptionService, error) {
+	services := make(map[string]*credential.KMSEncryptionService)
+	for _, region := range regions {
+		if region.KMSKeyID == "" {
+			continue
+		}
+
+		awsCfg, err := awsConfig.LoadDefaultConfig(ctx, awsConfig.WithRegion(region.KMSRegion))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config for data region %s: %w", region.Name, err)
+		}
+		service, err := credential.NewKMSEncryptionService(kms.NewFromConfig(awsCfg), region.KMSKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create KMS encryption service for data region %s: %w", region.Name, err)
+		}
+		service.SetTenantKeyResolver(tenantKeys)
+		services[region.Name] = service
+	}
+	return services, nil
+}
@@ -0,0 +1,156 @@
+// Package residency routes a tenant's data to the stores of the data region
+// the tenant is pinned to
+package residency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+// DefaultRegionCacheTTL is how long a looked-up tenant region is reused
+const DefaultRegionCacheTTL = time.Minute
+
+// ErrRegionNotConfigured is returned when a tenant's data region has no store
+// for the data being routed
+var ErrRegionNotConfigured = errors.New("data region is not configured")
+
+// ErrTenantRequired is returned when data is routed without a tenant, since
+// its region cannot be known
+var ErrTenantRequired = errors.New("tenant is required to route data to its region")
+
+// RegionNotConfiguredError names the tenant, region and store that could not
+// be routed. It matches ErrRegionNotConfigured with errors.Is.
+type RegionNotConfiguredError struct {
+	TenantID string
+	Region   string
+	Store    string
+}
+
+func (e *RegionNotConfiguredError) Error() string {
+	return fmt.Sprintf("tenant %s is pinned to data region %q, which has no %s configured", e.TenantID, e.Region, e.Store)
+}
+
+// Is reports whether target is ErrRegionNotConfigured
+func (e *RegionNotConfiguredError) Is(target error) bool {
+	return target == ErrRegionNotConfigured
+}
+
+// RegionLookup looks up a tenant's data region. An empty region means the
+// tenant uses the platform defaults.
+type RegionLookup interface {
+	GetTenantRegion(ctx context.Context, tenantID string) (string, error)
+}
+
+// Resolver finds the data region of a tenant. The region recorded in the
+// request context is used when present; otherwise the region is looked up
+// and cached.
+type Resolver struct {
+	lookup RegionLookup
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedRegion
+}
+
+type cachedRegion struct {
+	region    string
+	expiresAt time.Time
+}
+
+// NewResolver creates a resolver that caches looked-up regions for ttl
+func NewResolver(lookup RegionLookup, ttl time.Duration) *Resolver {
+	return &Resolver{
+		lookup: lookup,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  make(map[string]cachedRegion),
+	}
+}
+
+// TenantRegion returns the data region of tenantID
+func (r *Resolver) TenantRegion(ctx context.Context, tenantID string) (string, error) {
+	if region, ok := tenantctx.GetRegion(ctx, tenantID); ok {
+		return region, nil
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	cached, ok := r.cache[tenantID]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.region, nil
+	}
+
+	region, err := r.lookup.GetTenantRegion(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up tenant data region: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cache[tenantID] = cachedRegion{region: region, expiresAt: now.Add(r.ttl)}
+	r.mu.Unlock()
+	return region, nil
+}
+
+// Router picks a store, such as a database or an encryption service, for the
+// data region of a tenant. Tenants without a region use the default store.
+type Router[T any] struct {
+	resolver *Resolver
+	store    string
+	fallback T
+	regions  map[string]T
+}
+
+// NewRouter creates a router for the store named store, used in errors, with
+// fallback as the default store and one store per configured region
+func NewRouter[T any](resolver *Resolver, store string, fallback T, regions map[string]T) *Router[T] {
+	return &Router[T]{
+		resolver: resolver,
+		store:    store,
+		fallback: fallback,
+		regions:  regions,
+	}
+}
+
+// For returns the store of tenantID's data region. A tenant pinned to a
+// region without this store gets a *RegionNotConfiguredError, and an empty
+// tenantID gets ErrTenantRequired, rather than the default store, so a
+// pinned tenant's data never lands outside its region.
+func (r *Router[T]) For(ctx context.Context, tenantID string) (T, error) {
+	if tenantID == "" {
+		var zero T
+		return zero, fmt.Errorf("%s: %w", r.store, ErrTenantRequired)
+	}
+
+	region, err := r.resolver.TenantRegion(ctx, tenantID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if region == "" {
+		return r.fallback, nil
+	}
+
+	store, ok := r.regions[region]
+	if !ok {
+		var zero T
+		return zero, &RegionNotConfiguredError{TenantID: tenantID, Region: region, Store: r.store}
+	}
+	return store, nil
+}
+
+// Default returns the store used by tenants without a data region
+func (r *Router[T]) Default() T {
+	return r.fallback
+}
+
+// Regions returns the store of each configured region
+func (r *Router[T]) Regions() map[string]T {
+	return r.regions
+}
@@ -0,0 +1,100 @@
+package residency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+type fakeRegionLookup struct {
+	regions map[string]string
+	err     error
+	calls   int
+}
+
+func (f *fakeRegionLookup) GetTenantRegion(ctx context.Context, tenantID string) (string, error) {
+	f.calls++
+	return f.regions[tenantID], f.err
+}
+
+func TestResolver_TenantRegion(t *testing.T) {
+	t.Run("uses the region recorded in the request context", func(t *testing.T) {
+		lookup := &fakeRegionLookup{}
+		resolver := NewResolver(lookup, time.Minute)
+		ctx := tenantctx.WithRegion(tenantctx.WithTenantID(context.Background(), "tenant-1"), "eu")
+
+		region, err := resolver.TenantRegion(ctx, "tenant-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "eu", region)
+		assert.Zero(t, lookup.calls)
+	})
+
+	t.Run("caches looked-up regions until they expire", func(t *testing.T) {
+		lookup := &fakeRegionLookup{regions: map[string]string{"tenant-1": "eu"}}
+		resolver := NewResolver(lookup, time.Minute)
+		now := time.Now()
+		resolver.now = func() time.Time { return now }
+
+		for range 2 {
+			region, err := resolver.TenantRegion(context.Background(), "tenant-1")
+			require.NoError(t, err)
+			assert.Equal(t, "eu", region)
+		}
+		assert.Equal(t, 1, lookup.calls)
+
+		now = now.Add(2 * time.Minute)
+		_, err := resolver.TenantRegion(context.Background(), "tenant-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, lookup.calls)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		resolver := NewResolver(&fakeRegionLookup{err: errors.New("connection refused")}, time.Minute)
+
+		_, err := resolver.TenantRegion(context.Background(), "tenant-1")
+
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestRouter_For(t *testing.T) {
+	lookup := &fakeRegionLookup{regions: map[string]string{"tenant-eu": "eu", "tenant-ap": "ap"}}
+	router := NewRouter(NewResolver(lookup, time.Minute), "execution database", "primary", map[string]string{"eu": "eu-db"})
+
+	tests := []struct {
+		name     string
+		tenantID string
+		want     string
+	}{
+		{name: "tenant without a region", tenantID: "tenant-us", want: "primary"},
+		{name: "tenant pinned to a region", tenantID: "tenant-eu", want: "eu-db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := router.For(context.Background(), tt.tenantID)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, store)
+		})
+	}
+
+	t.Run("no tenant", func(t *testing.T) {
+		_, err := router.For(context.Background(), "")
+
+		assert.ErrorIs(t, err, ErrTenantRequired)
+	})
+
+	t.Run("region without the store", func(t *testing.T) {
+		_, err := router.For(context.Background(), "tenant-ap")
+
+		require.ErrorIs(t, err, ErrRegionNotConfigured)
+		assert.EqualError(t, err, `tenant tenant-ap is pinned to data region "ap", which has no execution database configured`)
+	})
+}
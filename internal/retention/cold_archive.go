@@ -0,0 +1,245 @@
+package retention
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gorax/gorax/internal/storage"
+)
+
+// ErrArchivedExecutionNotFound is returned when no cold archive holds an execution
+var ErrArchivedExecutionNotFound = errors.New("archived execution not found")
+
+// ObjectStore is the part of storage.FileStorage used for cold archives
+type ObjectStore interface {
+	Upload(ctx context.Context, bucket, key string, data io.Reader, options *storage.UploadOptions) error
+	Download(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	List(ctx context.Context, bucket, prefix string, options *storage.ListOptions) ([]storage.FileInfo, error)
+}
+
+// ExecutionRecord is an execution and its step executions as written to an archive
+type ExecutionRecord struct {
+	ID         string          `json:"id"`
+	TenantID   string          `json:"tenant_id"`
+	WorkflowID string          `json:"workflow_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// ArchiveManifest indexes a single cold archive object
+type ArchiveManifest struct {
+	BatchID         string    `json:"batch_id"`
+	TenantID        string    `json:"tenant_id"`
+	ObjectKey       string    `json:"object_key"`
+	SHA256          string    `json:"sha256"`
+	ExecutionCount  int       `json:"execution_count"`
+	ExecutionIDs    []string  `json:"execution_ids"`
+	OldestCreatedAt time.Time `json:"oldest_created_at"`
+	NewestCreatedAt time.Time `json:"newest_created_at"`
+	ArchivedAt      time.Time `json:"archived_at"`
+}
+
+// BatchArchiver durably stores a batch of executions before they are deleted
+type BatchArchiver interface {
+	ArchiveBatch(ctx context.Context, tenantID string, records []ExecutionRecord) (*ArchiveManifest, error)
+}
+
+// ColdArchiver writes executions to an S3-compatible object store as gzipped
+// JSON. Each batch is written as one object under
+// <prefix>/<tenant>/executions/YYYY/MM/DD/<batch>.json.gz, followed by a
+// manifest at <prefix>/<tenant>/manifests/<batch>.json that indexes it.
+type ColdArchiver struct {
+	store  ObjectStore
+	bucket string
+	prefix string
+}
+
+// NewColdArchiver creates a cold archiver writing to bucket under prefix
+func NewColdArchiver(store ObjectStore, bucket, prefix string) *ColdArchiver {
+	return &ColdArchiver{
+		store:  store,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// ArchiveBatch writes records and their manifest. The batch is only durable,
+// and may only be deleted from the database, once it returns without error.
+func (a *ColdArchiver) ArchiveBatch(ctx context.Context, tenantID string, records []ExecutionRecord) (*ArchiveManifest, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(records); err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+
+	now := time.Now().UTC()
+	batchID := now.Format("20060102T150405Z") + "-" + uuid.New().String()
+	manifest := &ArchiveManifest{
+		BatchID:         batchID,
+		TenantID:        tenantID,
+		ObjectKey:       a.key(tenantID, "executions", now.Format("2006/01/02"), batchID+".json.gz"),
+		SHA256:          hex.EncodeToString(sum[:]),
+		ExecutionCount:  len(records),
+		ExecutionIDs:    make([]string, len(records)),
+		OldestCreatedAt: records[0].CreatedAt,
+		NewestCreatedAt: records[0].CreatedAt,
+		ArchivedAt:      now,
+	}
+	for i, record := range records {
+		manifest.ExecutionIDs[i] = record.ID
+		if record.CreatedAt.Before(manifest.OldestCreatedAt) {
+			manifest.OldestCreatedAt = record.CreatedAt
+		}
+		if record.CreatedAt.After(manifest.NewestCreatedAt) {
+			manifest.NewestCreatedAt = record.CreatedAt
+		}
+	}
+
+	err := a.store.Upload(ctx, a.bucket, manifest.ObjectKey, bytes.NewReader(buf.Bytes()), &storage.UploadOptions{
+		ContentType:          "application/gzip",
+		ServerSideEncryption: true,
+		Metadata:             map[string]string{"sha256": manifest.SHA256},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	err = a.store.Upload(ctx, a.bucket, a.manifestKey(tenantID, batchID), bytes.NewReader(manifestJSON), &storage.UploadOptions{
+		ContentType:          "application/json",
+		ServerSideEncryption: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// ListManifests returns a tenant's archive manifests, oldest batch first
+func (a *ColdArchiver) ListManifests(ctx context.Context, tenantID string) ([]*ArchiveManifest, error) {
+	files, err := a.store.List(ctx, a.bucket, a.key(tenantID, "manifests")+"/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests: %w", err)
+	}
+
+	manifests := make([]*ArchiveManifest, 0, len(files))
+	for _, file := range files {
+		manifest, err := a.readManifest(ctx, file.Key)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].BatchID < manifests[j].BatchID
+	})
+	return manifests, nil
+}
+
+// RestoreBatch reads back the executions indexed by a manifest, verifying
+// the archive's checksum
+func (a *ColdArchiver) RestoreBatch(ctx context.Context, manifest *ArchiveManifest) ([]ExecutionRecord, error) {
+	reader, err := a.store.Download(ctx, a.bucket, manifest.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != manifest.SHA256 {
+		return nil, fmt.Errorf("archive %s does not match its manifest checksum", manifest.ObjectKey)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archive: %w", err)
+	}
+	defer gz.Close()
+
+	var records []ExecutionRecord
+	if err := json.NewDecoder(gz).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+	return records, nil
+}
+
+// RestoreExecution finds a single archived execution for an investigation
+func (a *ColdArchiver) RestoreExecution(ctx context.Context, tenantID, executionID string) (*ExecutionRecord, error) {
+	manifests, err := a.ListManifests(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manifest := range manifests {
+		if !slices.Contains(manifest.ExecutionIDs, executionID) {
+			continue
+		}
+		records, err := a.RestoreBatch(ctx, manifest)
+		if err != nil {
+			return nil, err
+		}
+		for i := range records {
+			if records[i].ID == executionID {
+				return &records[i], nil
+			}
+		}
+	}
+
+	return nil, ErrArchivedExecutionNotFound
+}
+
+// readManifest downloads and decodes a manifest object
+func (a *ColdArchiver) readManifest(ctx context.Context, key string) (*ArchiveManifest, error) {
+	reader, err := a.store.Download(ctx, a.bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest %s: %w", key, err)
+	}
+	defer reader.Close()
+
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %w", key, err)
+	}
+	return &manifest, nil
+}
+
+// manifestKey returns the object key of a batch's manifest
+func (a *ColdArchiver) manifestKey(tenantID, batchID string) string {
+	return a.key(tenantID, "manifests", batchID+".json")
+}
+
+// key joins parts under the archive prefix and tenant
+func (a *ColdArchiver) key(tenantID string, parts ...string) string {
+	return path.Join(append([]string{a.prefix, tenantID}, parts...)...)
+}
@@ -0,0 +1,187 @@
+package retention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/storage"
+)
+
+// memoryObjectStore is an in-memory ObjectStore
+type memoryObjectStore struct {
+	objects   map[string][]byte
+	uploadErr error
+}
+
+func newMemoryObjectStore() *memoryObjectStore {
+	return &memoryObjectStore{objects: make(map[string][]byte)}
+}
+
+func (s *memoryObjectStore) Upload(ctx context.Context, bucket, key string, data io.Reader, options *storage.UploadOptions) error {
+	if s.uploadErr != nil {
+		return s.uploadErr
+	}
+	body, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.objects[bucket+"/"+key] = body
+	return nil
+}
+
+func (s *memoryObjectStore) Download(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	body, ok := s.objects[bucket+"/"+key]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+func (s *memoryObjectStore) List(ctx context.Context, bucket, prefix string, options *storage.ListOptions) ([]storage.FileInfo, error) {
+	var files []storage.FileInfo
+	for key := range s.objects {
+		if name, ok := strings.CutPrefix(key, bucket+"/"); ok && strings.HasPrefix(name, prefix) {
+			files = append(files, storage.FileInfo{Key: name})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+	return files, nil
+}
+
+func testExecutionRecords() []ExecutionRecord {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []ExecutionRecord{
+		{ID: "exec-2", TenantID: "tenant-1", WorkflowID: "wf-1", CreatedAt: base.Add(time.Hour), Data: json.RawMessage(`{"id":"exec-2","status":"failed"}`)},
+		{ID: "exec-1", TenantID: "tenant-1", WorkflowID: "wf-1", CreatedAt: base, Data: json.RawMessage(`{"id":"exec-1","status":"completed"}`)},
+	}
+}
+
+func TestColdArchiver_ArchiveAndRestore(t *testing.T) {
+	store := newMemoryObjectStore()
+	archiver := NewColdArchiver(store, "archive-bucket", "/archives/")
+	ctx := context.Background()
+
+	manifest, err := archiver.ArchiveBatch(ctx, "tenant-1", testExecutionRecords())
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(manifest.ObjectKey, "archives/tenant-1/executions/"))
+	assert.True(t, strings.HasSuffix(manifest.ObjectKey, ".json.gz"))
+	assert.Equal(t, 2, manifest.ExecutionCount)
+	assert.Equal(t, []string{"exec-2", "exec-1"}, manifest.ExecutionIDs)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), manifest.OldestCreatedAt)
+	assert.Len(t, store.objects, 2)
+
+	manifests, err := archiver.ListManifests(ctx, "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, manifest.BatchID, manifests[0].BatchID)
+
+	records, err := archiver.RestoreBatch(ctx, manifests[0])
+	require.NoError(t, err)
+	assert.Equal(t, testExecutionRecords()[0].ID, records[0].ID)
+	assert.JSONEq(t, `{"id":"exec-1","status":"completed"}`, string(records[1].Data))
+
+	record, err := archiver.RestoreExecution(ctx, "tenant-1", "exec-1")
+	require.NoError(t, err)
+	assert.Equal(t, "wf-1", record.WorkflowID)
+
+	_, err = archiver.RestoreExecution(ctx, "tenant-1", "exec-missing")
+	assert.ErrorIs(t, err, ErrArchivedExecutionNotFound)
+
+	_, err = archiver.RestoreExecution(ctx, "tenant-2", "exec-1")
+	assert.ErrorIs(t, err, ErrArchivedExecutionNotFound)
+}
+
+func TestColdArchiver_RestoreBatch_ChecksumMismatch(t *testing.T) {
+	store := newMemoryObjectStore()
+	archiver := NewColdArchiver(store, "archive-bucket", "archives")
+	ctx := context.Background()
+
+	manifest, err := archiver.ArchiveBatch(ctx, "tenant-1", testExecutionRecords())
+	require.NoError(t, err)
+
+	store.objects["archive-bucket/"+manifest.ObjectKey] = []byte("tampered")
+
+	_, err = archiver.RestoreBatch(ctx, manifest)
+	assert.ErrorContains(t, err, "checksum")
+}
+
+func TestColdArchiver_ArchiveBatch_UploadError(t *testing.T) {
+	store := newMemoryObjectStore()
+	store.uploadErr = errors.New("bucket unavailable")
+	archiver := NewColdArchiver(store, "archive-bucket", "archives")
+
+	_, err := archiver.ArchiveBatch(context.Background(), "tenant-1", testExecutionRecords())
+
+	assert.ErrorContains(t, err, "bucket unavailable")
+}
+
+// MockColdArchiveRepository also supports cold archiving
+type MockColdArchiveRepository struct {
+	MockRepository
+}
+
+func (m *MockColdArchiveRepository) ColdArchiveAndDeleteOldExecutions(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int, archiver BatchArchiver) (*CleanupResult, error) {
+	args := m.Called(ctx, tenantID, cutoffDate, batchSize, archiver)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CleanupResult), args.Error(1)
+}
+
+func TestService_CleanupOldExecutions_WithColdArchiver(t *testing.T) {
+	repo := new(MockColdArchiveRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	archiver := NewColdArchiver(newMemoryObjectStore(), "archive-bucket", "archives")
+
+	repo.On("GetRetentionPolicy", mock.Anything, "tenant-1").Return(&RetentionPolicy{
+		TenantID:      "tenant-1",
+		RetentionDays: 30,
+		Enabled:       true,
+	}, nil)
+	result := &CleanupResult{ExecutionsDeleted: 10, ExecutionsArchived: 10, StepExecutionsDeleted: 30, BatchesProcessed: 2}
+	repo.On("ColdArchiveAndDeleteOldExecutions", mock.Anything, "tenant-1", mock.AnythingOfType("time.Time"), 1000, archiver).Return(result, nil)
+	repo.On("LogCleanup", mock.Anything, mock.AnythingOfType("*retention.CleanupLog")).Return(nil)
+
+	service := NewService(repo, logger, DefaultConfig())
+	service.SetColdArchiver(archiver)
+
+	got, err := service.CleanupOldExecutions(context.Background(), "tenant-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, result, got)
+	repo.AssertNotCalled(t, "ArchiveAndDeleteOldExecutions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestService_CleanupOldExecutions_ColdArchiverUnsupported(t *testing.T) {
+	repo := new(MockRepository)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	repo.On("GetRetentionPolicy", mock.Anything, "tenant-1").Return(&RetentionPolicy{
+		TenantID:      "tenant-1",
+		RetentionDays: 30,
+		Enabled:       true,
+	}, nil)
+	repo.On("LogCleanup", mock.Anything, mock.AnythingOfType("*retention.CleanupLog")).Return(nil)
+
+	service := NewService(repo, logger, DefaultConfig())
+	service.SetColdArchiver(NewColdArchiver(newMemoryObjectStore(), "archive-bucket", "archives"))
+
+	_, err := service.CleanupOldExecutions(context.Background(), "tenant-1")
+
+	assert.ErrorContains(t, err, "does not support cold archiving")
+}
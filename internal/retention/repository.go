@@ -9,11 +9,18 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// ExecutionDBRouter picks the database a tenant's executions are stored in
+type ExecutionDBRouter interface {
+	For(ctx context.Context, tenantID string) (*sqlx.DB, error)
+}
+
 // PostgresRepository implements the Repository interface for PostgreSQL
 type PostgresRepository struct {
-	db *sqlx.DB
+	db         *sqlx.DB
+	executions ExecutionDBRouter
 }
 
 // NewRepository creates a new PostgreSQL repository
@@ -21,6 +28,21 @@ func NewRepository(db *sqlx.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
 }
 
+// SetExecutionDBRouter cleans up each tenant's executions in the database of
+// its data region. Retention policies and cleanup logs stay in the primary
+// database.
+func (r *PostgresRepository) SetExecutionDBRouter(router ExecutionDBRouter) {
+	r.executions = router
+}
+
+// executionDB returns the database tenantID's executions are stored in
+func (r *PostgresRepository) executionDB(ctx context.Context, tenantID string) (*sqlx.DB, error) {
+	if r.executions == nil {
+		return r.db, nil
+	}
+	return r.executions.For(ctx, tenantID)
+}
+
 // GetRetentionPolicy retrieves the retention policy from tenant settings
 func (r *PostgresRepository) GetRetentionPolicy(ctx context.Context, tenantID string) (*RetentionPolicy, error) {
 	query := `
@@ -116,7 +138,12 @@ func (r *PostgresRepository) ArchiveAndDeleteOldExecutions(ctx context.Context,
 
 // archiveAndDeleteExecutionBatch archives and deletes a single batch of executions
 func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int) (*CleanupResult, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -126,16 +153,137 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 		}
 	}()
 
-	// Get executions to archive and delete in this batch
+	records, err := selectExecutionRecords(ctx, tx, tenantID, cutoffDate, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no executions to process, return
+	if len(records) == 0 {
+		return &CleanupResult{
+			ExecutionsDeleted:     0,
+			StepExecutionsDeleted: 0,
+			ExecutionsArchived:    0,
+			BatchesProcessed:      0,
+		}, nil
+	}
+
+	// Archive each execution
+	archiveQuery := `
+		INSERT INTO execution_archives (id, tenant_id, workflow_id, execution_data, archived_at, original_created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	archivedCount := 0
+	for _, record := range records {
+		result, err := tx.ExecContext(ctx, archiveQuery, record.ID, record.TenantID, record.WorkflowID, []byte(record.Data), time.Now(), record.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive execution %s: %w", record.ID, err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected > 0 {
+			archivedCount++
+		}
+	}
+
+	batchResult, err := deleteExecutionRecords(ctx, tx, records)
+	if err != nil {
+		return nil, err
+	}
+	batchResult.ExecutionsArchived = archivedCount
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return batchResult, nil
+}
+
+// ColdArchiveAndDeleteOldExecutions writes old executions to cold storage
+// through archiver before deleting them. Each batch is deleted only after the
+// archiver has stored it; a failed write leaves the batch in the database.
+func (r *PostgresRepository) ColdArchiveAndDeleteOldExecutions(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int, archiver BatchArchiver) (*CleanupResult, error) {
+	result := &CleanupResult{}
+
+	for {
+		batchResult, err := r.coldArchiveAndDeleteExecutionBatch(ctx, tenantID, cutoffDate, batchSize, archiver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cold archive and delete batch: %w", err)
+		}
+
+		result.ExecutionsDeleted += batchResult.ExecutionsDeleted
+		result.StepExecutionsDeleted += batchResult.StepExecutionsDeleted
+		result.ExecutionsArchived += batchResult.ExecutionsArchived
+		result.BatchesProcessed++
+
+		// Stop if no more records to process
+		if batchResult.ExecutionsDeleted == 0 {
+			break
+		}
+
+		// Small delay between batches to reduce load
+		if batchResult.ExecutionsDeleted == batchSize {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	return result, nil
+}
+
+// coldArchiveAndDeleteExecutionBatch cold archives and deletes a single batch of executions
+func (r *PostgresRepository) coldArchiveAndDeleteExecutionBatch(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int, archiver BatchArchiver) (*CleanupResult, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	records, err := selectExecutionRecords(ctx, tx, tenantID, cutoffDate, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return &CleanupResult{}, nil
+	}
+
+	// The rows stay locked by this transaction while the archive is written
+	if _, err := archiver.ArchiveBatch(ctx, tenantID, records); err != nil {
+		return nil, fmt.Errorf("failed to write cold archive: %w", err)
+	}
+
+	batchResult, err := deleteExecutionRecords(ctx, tx, records)
+	if err != nil {
+		return nil, err
+	}
+	batchResult.ExecutionsArchived = len(records)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return batchResult, nil
+}
+
+// selectExecutionRecords locks and loads a batch of finished executions
+// created before the cutoff, together with their step executions
+func selectExecutionRecords(ctx context.Context, tx *sqlx.Tx, tenantID string, cutoffDate time.Time, batchSize int) ([]ExecutionRecord, error) {
 	executionsQuery := `
 		SELECT id, tenant_id, workflow_id, status, started_at, completed_at,
 		       trigger_type, trigger_data, result, error, created_at, updated_at
 		FROM executions
 		WHERE tenant_id = $1
 		  AND created_at < $2
-		  AND status IN ('completed', 'failed')
+		  AND status IN ('completed', 'failed', 'skipped_concurrent')
 		ORDER BY created_at ASC
 		LIMIT $3
+		FOR UPDATE SKIP LOCKED
 	`
 
 	type executionRow struct {
@@ -154,35 +302,19 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 	}
 
 	var executions []executionRow
-	err = tx.SelectContext(ctx, &executions, executionsQuery, tenantID, cutoffDate, batchSize)
-	if err != nil {
+	if err := tx.SelectContext(ctx, &executions, executionsQuery, tenantID, cutoffDate, batchSize); err != nil {
 		return nil, fmt.Errorf("failed to get executions: %w", err)
 	}
 
-	// If no executions to process, return
-	if len(executions) == 0 {
-		return &CleanupResult{
-			ExecutionsDeleted:     0,
-			StepExecutionsDeleted: 0,
-			ExecutionsArchived:    0,
-			BatchesProcessed:      0,
-		}, nil
-	}
-
-	// Collect execution IDs for later operations
-	executionIDs := make([]string, len(executions))
-	for i, exec := range executions {
-		executionIDs[i] = exec.ID
-	}
-
-	// Archive each execution
-	archiveQuery := `
-		INSERT INTO execution_archives (id, tenant_id, workflow_id, execution_data, archived_at, original_created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (id) DO NOTHING
+	stepsQuery := `
+		SELECT id, execution_id, node_id, status, started_at, completed_at,
+		       input, output, error, created_at
+		FROM step_executions
+		WHERE execution_id = $1
+		ORDER BY created_at ASC
 	`
 
-	archivedCount := 0
+	records := make([]ExecutionRecord, 0, len(executions))
 	for _, exec := range executions {
 		// Build execution data JSON including step executions
 		executionData := map[string]interface{}{
@@ -200,14 +332,6 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 			"updated_at":   exec.UpdatedAt,
 		}
 
-		// Get step executions for this execution
-		stepsQuery := `
-			SELECT id, execution_id, node_id, status, started_at, completed_at,
-			       input, output, error, created_at
-			FROM step_executions
-			WHERE execution_id = $1
-			ORDER BY created_at ASC
-		`
 		var steps []map[string]interface{}
 		rows, err := tx.QueryxContext(ctx, stepsQuery, exec.ID)
 		if err != nil {
@@ -234,15 +358,23 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 			return nil, fmt.Errorf("failed to marshal execution data: %w", err)
 		}
 
-		// Insert archive
-		result, err := tx.ExecContext(ctx, archiveQuery, exec.ID, exec.TenantID, exec.WorkflowID, dataJSON, time.Now(), exec.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to archive execution %s: %w", exec.ID, err)
-		}
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected > 0 {
-			archivedCount++
-		}
+		records = append(records, ExecutionRecord{
+			ID:         exec.ID,
+			TenantID:   exec.TenantID,
+			WorkflowID: exec.WorkflowID,
+			CreatedAt:  exec.CreatedAt,
+			Data:       dataJSON,
+		})
+	}
+
+	return records, nil
+}
+
+// deleteExecutionRecords deletes the archived executions and their step executions
+func deleteExecutionRecords(ctx context.Context, tx *sqlx.Tx, records []ExecutionRecord) (*CleanupResult, error) {
+	executionIDs := make([]string, len(records))
+	for i, record := range records {
+		executionIDs[i] = record.ID
 	}
 
 	// Delete step_executions first (foreign key constraint)
@@ -251,7 +383,7 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 		WHERE execution_id = ANY($1)
 	`
 
-	stepResult, err := tx.ExecContext(ctx, stepDeleteQuery, executionIDs)
+	stepResult, err := tx.ExecContext(ctx, stepDeleteQuery, pq.Array(executionIDs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete step executions: %w", err)
 	}
@@ -267,7 +399,7 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 		WHERE id = ANY($1)
 	`
 
-	execResult, err := tx.ExecContext(ctx, execDeleteQuery, executionIDs)
+	execResult, err := tx.ExecContext(ctx, execDeleteQuery, pq.Array(executionIDs))
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete executions: %w", err)
 	}
@@ -277,22 +409,21 @@ func (r *PostgresRepository) archiveAndDeleteExecutionBatch(ctx context.Context,
 		return nil, fmt.Errorf("failed to get execution rows deleted: %w", err)
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return &CleanupResult{
 		ExecutionsDeleted:     int(execRowsDeleted),
 		StepExecutionsDeleted: int(stepRowsDeleted),
-		ExecutionsArchived:    archivedCount,
 		BatchesProcessed:      0, // Will be incremented by caller
 	}, nil
 }
 
 // deleteExecutionBatch deletes a single batch of executions
 func (r *PostgresRepository) deleteExecutionBatch(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int) (*CleanupResult, error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -304,7 +435,7 @@ func (r *PostgresRepository) deleteExecutionBatch(ctx context.Context, tenantID
 		FROM executions
 		WHERE tenant_id = $1
 		  AND created_at < $2
-		  AND status IN ('completed', 'failed') -- Only delete finished executions
+		  AND status IN ('completed', 'failed', 'skipped_concurrent') -- Only delete finished executions
 		ORDER BY created_at ASC
 		LIMIT $3
 	`
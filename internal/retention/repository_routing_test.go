@@ -0,0 +1,87 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubExecutionDBRouter struct {
+	dbs map[string]*sqlx.DB
+	err error
+}
+
+func (s *stubExecutionDBRouter) For(ctx context.Context, tenantID string) (*sqlx.DB, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.dbs[tenantID], nil
+}
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestRepository_ExecutionDBRouter(t *testing.T) {
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+
+	t.Run("deletes executions in the tenant's regional database", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		regional, regionalMock := newMockDB(t)
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(&stubExecutionDBRouter{dbs: map[string]*sqlx.DB{"tenant-eu": regional}})
+
+		regionalMock.ExpectBegin()
+		regionalMock.ExpectQuery(`SELECT id\s+FROM executions`).
+			WithArgs("tenant-eu", cutoff, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		regionalMock.ExpectRollback()
+
+		result, err := repo.DeleteOldExecutions(context.Background(), "tenant-eu", cutoff, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.ExecutionsDeleted)
+		assert.NoError(t, regionalMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+
+	t.Run("archives executions in the tenant's regional database", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		regional, regionalMock := newMockDB(t)
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(&stubExecutionDBRouter{dbs: map[string]*sqlx.DB{"tenant-eu": regional}})
+
+		regionalMock.ExpectBegin()
+		regionalMock.ExpectQuery(`FROM executions`).
+			WithArgs("tenant-eu", cutoff, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		regionalMock.ExpectRollback()
+
+		_, err := repo.ArchiveAndDeleteOldExecutions(context.Background(), "tenant-eu", cutoff, 100)
+
+		require.NoError(t, err)
+		assert.NoError(t, regionalMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+
+	t.Run("routing failure stops the cleanup", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		routeErr := errors.New("region not configured")
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(&stubExecutionDBRouter{err: routeErr})
+
+		_, err := repo.DeleteOldExecutions(context.Background(), "tenant-eu", cutoff, 100)
+
+		assert.ErrorIs(t, err, routeErr)
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+}
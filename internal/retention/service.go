@@ -18,11 +18,18 @@ type Repository interface {
 	LogCleanup(ctx context.Context, log *CleanupLog) error
 }
 
+// ColdArchiveRepository is implemented by repositories that can hand
+// executions to a BatchArchiver before deleting them
+type ColdArchiveRepository interface {
+	ColdArchiveAndDeleteOldExecutions(ctx context.Context, tenantID string, cutoffDate time.Time, batchSize int, archiver BatchArchiver) (*CleanupResult, error)
+}
+
 // Service handles retention policy operations
 type Service struct {
-	repo   Repository
-	logger *slog.Logger
-	config Config
+	repo         Repository
+	logger       *slog.Logger
+	config       Config
+	coldArchiver BatchArchiver
 }
 
 // NewService creates a new retention service
@@ -34,6 +41,12 @@ func NewService(repo Repository, logger *slog.Logger, config Config) *Service {
 	}
 }
 
+// SetColdArchiver enables archiving executions to cold storage before they
+// are deleted. It takes precedence over ArchiveBeforeDelete.
+func (s *Service) SetColdArchiver(archiver BatchArchiver) {
+	s.coldArchiver = archiver
+}
+
 // GetRetentionPolicy retrieves the retention policy for a tenant
 // Returns default policy if tenant doesn't have one configured
 func (s *Service) GetRetentionPolicy(ctx context.Context, tenantID string) (*RetentionPolicy, error) {
@@ -83,11 +96,14 @@ func (s *Service) CleanupOldExecutions(ctx context.Context, tenantID string) (*C
 		"retention_days", policy.RetentionDays,
 		"cutoff_date", cutoffDate,
 		"archive_enabled", s.config.ArchiveBeforeDelete,
+		"cold_archive_enabled", s.coldArchiver != nil,
 	)
 
 	// Archive and/or delete old executions based on configuration
 	var result *CleanupResult
-	if s.config.ArchiveBeforeDelete {
+	if s.coldArchiver != nil {
+		result, err = s.coldArchiveAndDelete(ctx, tenantID, cutoffDate)
+	} else if s.config.ArchiveBeforeDelete {
 		result, err = s.repo.ArchiveAndDeleteOldExecutions(ctx, tenantID, cutoffDate, s.config.BatchSize)
 	} else {
 		result, err = s.repo.DeleteOldExecutions(ctx, tenantID, cutoffDate, s.config.BatchSize)
@@ -149,6 +165,15 @@ func (s *Service) CleanupOldExecutions(ctx context.Context, tenantID string) (*C
 	return result, nil
 }
 
+// coldArchiveAndDelete deletes old executions once the cold archiver has stored them
+func (s *Service) coldArchiveAndDelete(ctx context.Context, tenantID string, cutoffDate time.Time) (*CleanupResult, error) {
+	repo, ok := s.repo.(ColdArchiveRepository)
+	if !ok {
+		return nil, fmt.Errorf("repository does not support cold archiving")
+	}
+	return repo.ColdArchiveAndDeleteOldExecutions(ctx, tenantID, cutoffDate, s.config.BatchSize, s.coldArchiver)
+}
+
 // CleanupAllTenants runs cleanup for all tenants with retention enabled
 func (s *Service) CleanupAllTenants(ctx context.Context) (*CleanupResult, error) {
 	s.logger.Info("starting cleanup for all tenants")
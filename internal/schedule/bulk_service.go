@@ -3,6 +3,7 @@ package schedule
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // BulkOperationError represents an error for a single item
@@ -112,7 +113,7 @@ func (s *Service) updateScheduleEnabled(ctx context.Context, tenantID, id string
 
 	// If enabling, recalculate next run time
 	if enabled && !existing.Enabled {
-		nextRun, err := s.calculateNextRun(existing.CronExpression, existing.Timezone)
+		nextRun, err := s.NextRunAfter(existing, time.Now())
 		if err != nil {
 			s.logger.Error("failed to calculate next run time",
 				"error", err,
@@ -658,9 +658,9 @@ func (p *CronParser) CalculateNextRuns(expression, timezone string, count int) (
 	}
 
 	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+	loc, err := loadScheduleLocation(timezone)
 	if err != nil {
-		loc = time.UTC
+		return nil, err
 	}
 
 	// Calculate next N run times
@@ -668,7 +668,7 @@ func (p *CronParser) CalculateNextRuns(expression, timezone string, count int) (
 	current := time.Now().In(loc)
 
 	for i := 0; i < count; i++ {
-		nextRun := sched.Next(current)
+		nextRun := nextCronRun(sched, current, loc)
 		times = append(times, nextRun)
 		current = nextRun
 	}
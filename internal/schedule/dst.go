@@ -0,0 +1,122 @@
+package schedule
+
+import (
+	"time"
+	// Embed the IANA timezone database so schedule timezones resolve even on
+	// hosts without zoneinfo installed
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronStarBit marks a cron field that was written as a wildcard
+const cronStarBit = 1 << 63
+
+// loadScheduleLocation resolves a schedule timezone. An empty timezone means
+// UTC; an unknown one is a validation error rather than a silent UTC fallback.
+func loadScheduleLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, &ValidationError{Message: "invalid timezone: " + err.Error()}
+	}
+	return loc, nil
+}
+
+// nextCronRun returns the next occurrence of sched after the given time in loc,
+// adjusted for daylight saving transitions:
+//   - an occurrence whose wall-clock time falls in a spring-forward gap (such as
+//     02:30 in America/New_York on the second Sunday of March) runs at the
+//     normalized time, shifted forward by the gap, instead of being dropped
+//   - an occurrence at a fixed hour that is repeated by a fall-back transition
+//     runs once, on the first pass through the repeated hour
+func nextCronRun(sched cron.Schedule, after time.Time, loc *time.Location) time.Time {
+	after = after.In(loc)
+	next := sched.Next(after)
+	if next.IsZero() {
+		return next
+	}
+
+	if skipped, ok := skippedOccurrence(sched, after, next, loc); ok {
+		return skipped
+	}
+
+	if isFixedHour(sched) {
+		for isRepeatedWallTime(next) {
+			following := sched.Next(next)
+			if following.IsZero() {
+				break
+			}
+			next = following
+		}
+	}
+
+	return next
+}
+
+// skippedOccurrence looks for an occurrence between after and next that the
+// cron library dropped because its wall-clock time did not exist, and returns
+// it at its normalized time
+func skippedOccurrence(sched cron.Schedule, after, next time.Time, loc *time.Location) (time.Time, bool) {
+	if spec, ok := sched.(*cron.SpecSchedule); ok && spec.Location != time.Local {
+		// Expressions with an explicit CRON_TZ are evaluated in their own zone
+		return time.Time{}, false
+	}
+
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, loc)
+	for !day.After(next) {
+		following := day.AddDate(0, 0, 1)
+
+		_, startOffset := day.Zone()
+		_, endOffset := following.Zone()
+		if endOffset > startOffset {
+			_, transition := day.ZoneBounds()
+			gap := time.Duration(endOffset-startOffset) * time.Second
+
+			// Evaluate the expression against the wall clock in UTC, where the
+			// missing hour still exists
+			wall := transition.In(time.FixedZone("", startOffset))
+			gapStart := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, time.UTC)
+			candidate := sched.Next(gapStart.Add(-time.Nanosecond))
+
+			if !candidate.IsZero() && candidate.Before(gapStart.Add(gap)) {
+				normalized := transition.Add(candidate.Sub(gapStart)).In(loc)
+				if normalized.After(after) && normalized.Before(next) {
+					return normalized, true
+				}
+			}
+		}
+
+		day = following
+	}
+
+	return time.Time{}, false
+}
+
+// isRepeatedWallTime reports whether t is the second occurrence of its
+// wall-clock time, inside the hour repeated by a fall-back transition
+func isRepeatedWallTime(t time.Time) bool {
+	zoneStart, _ := t.ZoneBounds()
+	if zoneStart.IsZero() {
+		return false
+	}
+
+	_, offset := t.Zone()
+	_, previousOffset := zoneStart.Add(-time.Nanosecond).Zone()
+	if previousOffset <= offset {
+		return false
+	}
+
+	repeated := time.Duration(previousOffset-offset) * time.Second
+	return t.Sub(zoneStart) < repeated
+}
+
+// isFixedHour reports whether the schedule's hour field is not a wildcard.
+// Wildcard-hour jobs (such as every 15 minutes) keep running through a
+// repeated hour, matching traditional cron behavior.
+func isFixedHour(sched cron.Schedule) bool {
+	spec, ok := sched.(*cron.SpecSchedule)
+	return ok && spec.Hour&cronStarBit == 0
+}
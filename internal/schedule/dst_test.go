@@ -0,0 +1,112 @@
+package schedule
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newYorkLocation(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestNextRunAfter_SpringForward(t *testing.T) {
+	svc := NewService(nil, slog.Default())
+	loc := newYorkLocation(t)
+
+	tests := []struct {
+		name       string
+		expression string
+		after      time.Time
+		want       time.Time
+	}{
+		{
+			name:       "job in skipped hour runs at normalized time",
+			expression: "0 2 * * *",
+			after:      time.Date(2024, time.March, 9, 12, 0, 0, 0, loc),
+			want:       time.Date(2024, time.March, 10, 3, 0, 0, 0, loc),
+		},
+		{
+			name:       "half past in skipped hour keeps its minute",
+			expression: "30 2 * * *",
+			after:      time.Date(2024, time.March, 9, 12, 0, 0, 0, loc),
+			want:       time.Date(2024, time.March, 10, 3, 30, 0, 0, loc),
+		},
+		{
+			name:       "resumes normal time the next day",
+			expression: "0 2 * * *",
+			after:      time.Date(2024, time.March, 10, 3, 0, 0, 0, loc),
+			want:       time.Date(2024, time.March, 11, 2, 0, 0, 0, loc),
+		},
+		{
+			name:       "job outside the gap is unaffected",
+			expression: "0 4 * * *",
+			after:      time.Date(2024, time.March, 9, 12, 0, 0, 0, loc),
+			want:       time.Date(2024, time.March, 10, 4, 0, 0, 0, loc),
+		},
+		{
+			name:       "hourly job does not run twice at 03:00",
+			expression: "0 * * * *",
+			after:      time.Date(2024, time.March, 10, 1, 0, 0, 0, loc),
+			want:       time.Date(2024, time.March, 10, 3, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &Schedule{CronExpression: tt.expression, Timezone: "America/New_York"}
+			got, err := svc.NextRunAfter(schedule, tt.after)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestNextRunAfter_FallBack(t *testing.T) {
+	svc := NewService(nil, slog.Default())
+	loc := newYorkLocation(t)
+
+	// 01:30 happens twice on 2024-11-03: first in EDT, then again in EST
+	firstPass := time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC)
+
+	t.Run("fixed-hour job runs once in the repeated hour", func(t *testing.T) {
+		schedule := &Schedule{CronExpression: "30 1 * * *", Timezone: "America/New_York"}
+
+		got, err := svc.NextRunAfter(schedule, time.Date(2024, time.November, 2, 12, 0, 0, 0, loc))
+		require.NoError(t, err)
+		assert.True(t, firstPass.Equal(got), "got %s, want %s", got, firstPass)
+
+		got, err = svc.NextRunAfter(schedule, got)
+		require.NoError(t, err)
+		want := time.Date(2024, time.November, 4, 1, 30, 0, 0, loc)
+		assert.True(t, want.Equal(got), "got %s, want %s", got, want)
+	})
+
+	t.Run("wildcard-hour job keeps running through the repeated hour", func(t *testing.T) {
+		schedule := &Schedule{CronExpression: "30 * * * *", Timezone: "America/New_York"}
+
+		got, err := svc.NextRunAfter(schedule, firstPass)
+		require.NoError(t, err)
+		want := firstPass.Add(time.Hour)
+		assert.True(t, want.Equal(got), "got %s, want %s", got, want)
+	})
+}
+
+func TestGetNextRunTimes_InvalidTimezone(t *testing.T) {
+	svc := NewService(nil, slog.Default())
+
+	_, err := svc.GetNextRunTimes("0 2 * * *", "Mars/Olympus_Mons", 3)
+	require.Error(t, err)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	schedule := &Schedule{CronExpression: "0 2 * * *", Timezone: "Mars/Olympus_Mons"}
+	_, err = svc.NextRunAfter(schedule, time.Now())
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MinScheduleInterval is the shortest allowed interval between runs
+	MinScheduleInterval = time.Second
+	// MaxScheduleInterval is the longest allowed interval between runs
+	MaxScheduleInterval = 31 * 24 * time.Hour
+)
+
+// IsInterval returns true if the schedule runs on a fixed interval instead of a cron expression
+func (s *Schedule) IsInterval() bool {
+	return s.Interval != ""
+}
+
+// ParseInterval parses an interval such as "30s" or "5m"
+func ParseInterval(interval string) (time.Duration, error) {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, &ValidationError{Message: "invalid interval: " + err.Error()}
+	}
+	if d < MinScheduleInterval {
+		return 0, &ValidationError{Message: fmt.Sprintf("invalid interval: must be at least %s", MinScheduleInterval)}
+	}
+	if d > MaxScheduleInterval {
+		return 0, &ValidationError{Message: fmt.Sprintf("invalid interval: must be at most %s", MaxScheduleInterval)}
+	}
+	return d, nil
+}
+
+// validateTiming checks that exactly one of cron expression and interval is set and that it is valid
+func (s *Service) validateTiming(cronExpression, interval string) error {
+	switch {
+	case cronExpression != "" && interval != "":
+		return &ValidationError{Message: "only one of cron_expression or interval may be set"}
+	case cronExpression == "" && interval == "":
+		return &ValidationError{Message: "one of cron_expression or interval is required"}
+	case interval != "":
+		_, err := ParseInterval(interval)
+		return err
+	default:
+		return s.validateCronExpression(cronExpression)
+	}
+}
+
+// NextRunAfter computes the next run time of a schedule after the given time.
+// Interval schedules run a fixed duration after the previous run; cron
+// schedules use the expression in the schedule's timezone, adjusted for DST.
+func (s *Service) NextRunAfter(schedule *Schedule, after time.Time) (time.Time, error) {
+	if schedule.IsInterval() {
+		d, err := ParseInterval(schedule.Interval)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return after.Add(d), nil
+	}
+
+	sched, err := s.cronParser.Parse(schedule.CronExpression)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := loadScheduleLocation(schedule.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return nextCronRun(sched, after, loc), nil
+}
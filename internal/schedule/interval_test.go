@@ -0,0 +1,145 @@
+package schedule
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{interval: "30s", want: 30 * time.Second},
+		{interval: "5m", want: 5 * time.Minute},
+		{interval: "1h30m", want: 90 * time.Minute},
+		{interval: "500ms", wantErr: true},
+		{interval: "800h", wantErr: true},
+		{interval: "five minutes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			got, err := ParseInterval(tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInterval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTiming(t *testing.T) {
+	service := NewService(nil, nil)
+
+	tests := []struct {
+		name     string
+		cron     string
+		interval string
+		wantErr  bool
+	}{
+		{name: "cron only", cron: "*/5 * * * *"},
+		{name: "interval only", interval: "30s"},
+		{name: "both set", cron: "*/5 * * * *", interval: "30s", wantErr: true},
+		{name: "neither set", wantErr: true},
+		{name: "invalid interval", interval: "soon", wantErr: true},
+		{name: "invalid cron", cron: "invalid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.validateTiming(tt.cron, tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateTiming() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*ValidationError); !ok {
+					t.Errorf("validateTiming() error type = %T, want *ValidationError", err)
+				}
+			}
+		})
+	}
+}
+
+func TestNextRunAfter(t *testing.T) {
+	service := NewService(nil, nil)
+	after := time.Date(2024, time.June, 3, 10, 2, 15, 0, time.UTC)
+
+	t.Run("interval", func(t *testing.T) {
+		next, err := service.NextRunAfter(&Schedule{Interval: "45s", Timezone: "UTC"}, after)
+		if err != nil {
+			t.Fatalf("NextRunAfter() error = %v", err)
+		}
+		if want := after.Add(45 * time.Second); !next.Equal(want) {
+			t.Errorf("NextRunAfter() = %v, want %v", next, want)
+		}
+	})
+
+	t.Run("cron", func(t *testing.T) {
+		next, err := service.NextRunAfter(&Schedule{CronExpression: "*/5 * * * *", Timezone: "UTC"}, after)
+		if err != nil {
+			t.Fatalf("NextRunAfter() error = %v", err)
+		}
+		if want := time.Date(2024, time.June, 3, 10, 5, 0, 0, time.UTC); !next.Equal(want) {
+			t.Errorf("NextRunAfter() = %v, want %v", next, want)
+		}
+	})
+}
+
+func TestSchedulerNextWait(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name    string
+		nextDue func() *time.Time
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name:    "no schedules uses check interval",
+			nextDue: func() *time.Time { return nil },
+			min:     30 * time.Second,
+			max:     30 * time.Second,
+		},
+		{
+			name: "wakes early for a schedule due soon",
+			nextDue: func() *time.Time {
+				due := time.Now().Add(5 * time.Second)
+				return &due
+			},
+			min: 4 * time.Second,
+			max: 5 * time.Second,
+		},
+		{
+			name: "overdue schedule is floored to minimum wait",
+			nextDue: func() *time.Time {
+				due := time.Now().Add(-time.Minute)
+				return &due
+			},
+			min: minDispatchWait,
+			max: minDispatchWait,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &MockService{
+				getNextDueTimeFunc: func(ctx context.Context) (*time.Time, error) {
+					return tt.nextDue(), nil
+				},
+			}
+			scheduler := NewScheduler(provider, &MockExecutor{}, logger)
+
+			wait := scheduler.nextWait(context.Background())
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("nextWait() = %v, want between %v and %v", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxMisfireBacklog caps the number of catch-up runs fired for a fire_all schedule
+	MaxMisfireBacklog = 10
+	// MisfireThreshold is how late a run must be before it is treated as missed
+	MisfireThreshold = time.Minute
+)
+
+// Misfire describes a schedule whose runs were missed while the scheduler was down
+type Misfire struct {
+	Schedule *Schedule
+	// MissedRuns are the missed occurrence times, oldest first, capped at MaxMisfireBacklog
+	MissedRuns []time.Time
+	// Truncated is true when more runs were missed than MissedRuns holds
+	Truncated bool
+}
+
+// MisfireResolver is implemented by schedule providers that support catch-up after downtime
+type MisfireResolver interface {
+	GetMisfires(ctx context.Context, now time.Time) ([]*Misfire, error)
+	SkipMisfire(ctx context.Context, schedule *Schedule, now time.Time) error
+}
+
+// GetMisfires returns enabled schedules whose next run is more than
+// MisfireThreshold in the past, with the occurrences missed since they last fired
+func (s *Service) GetMisfires(ctx context.Context, now time.Time) ([]*Misfire, error) {
+	schedules, err := s.repo.GetDueSchedules(ctx, now.Add(-MisfireThreshold))
+	if err != nil {
+		return nil, err
+	}
+
+	misfires := make([]*Misfire, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.NextRunAt == nil {
+			continue
+		}
+
+		misfire, err := s.missedRuns(schedule, now)
+		if err != nil {
+			s.logger.Error("failed to compute missed runs", "error", err, "schedule_id", schedule.ID)
+			continue
+		}
+		if len(misfire.MissedRuns) > 0 {
+			misfires = append(misfires, misfire)
+		}
+	}
+
+	return misfires, nil
+}
+
+// missedRuns lists the occurrences between the schedule's last fired time and now
+func (s *Service) missedRuns(schedule *Schedule, now time.Time) (*Misfire, error) {
+	misfire := &Misfire{Schedule: schedule}
+
+	next := *schedule.NextRunAt
+	if schedule.LastFiredAt != nil {
+		afterLastFired, err := s.NextRunAfter(schedule, *schedule.LastFiredAt)
+		if err != nil {
+			return nil, err
+		}
+		if afterLastFired.Before(next) {
+			next = afterLastFired
+		}
+	}
+
+	for !next.After(now) {
+		if len(misfire.MissedRuns) == MaxMisfireBacklog {
+			misfire.Truncated = true
+			break
+		}
+		misfire.MissedRuns = append(misfire.MissedRuns, next)
+
+		following, err := s.NextRunAfter(schedule, next)
+		if err != nil {
+			return nil, err
+		}
+		if !following.After(next) {
+			return nil, fmt.Errorf("schedule %s did not advance past %s", schedule.ID, next)
+		}
+		next = following
+	}
+
+	return misfire, nil
+}
+
+// SkipMisfire drops a schedule's missed runs and moves its next run to the
+// first occurrence after now
+func (s *Service) SkipMisfire(ctx context.Context, schedule *Schedule, now time.Time) error {
+	nextRun, err := s.NextRunAfter(schedule, now)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateNextRunTime(ctx, schedule.ID, nextRun); err != nil {
+		return err
+	}
+
+	s.logger.Info("skipped missed schedule runs", "schedule_id", schedule.ID, "next_run", nextRun)
+	return nil
+}
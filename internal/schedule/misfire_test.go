@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+// mockMisfireService is a schedule provider that also resolves misfires
+type mockMisfireService struct {
+	MockService
+	misfires []*Misfire
+	skipped  []string
+}
+
+func (m *mockMisfireService) GetMisfires(ctx context.Context, now time.Time) ([]*Misfire, error) {
+	return m.misfires, nil
+}
+
+func (m *mockMisfireService) SkipMisfire(ctx context.Context, schedule *Schedule, now time.Time) error {
+	m.skipped = append(m.skipped, schedule.ID)
+	return nil
+}
+
+func TestMissedRuns(t *testing.T) {
+	service := NewService(nil, nil)
+	now := time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("hourly cron after three hours down", func(t *testing.T) {
+		lastFired := time.Date(2024, time.June, 3, 8, 0, 0, 0, time.UTC)
+		nextRun := lastFired.Add(time.Hour)
+		schedule := &Schedule{ID: "s1", CronExpression: "0 * * * *", Timezone: "UTC", NextRunAt: &nextRun, LastFiredAt: &lastFired}
+
+		misfire, err := service.missedRuns(schedule, now)
+		if err != nil {
+			t.Fatalf("missedRuns() error = %v", err)
+		}
+		if len(misfire.MissedRuns) != 4 {
+			t.Fatalf("missed runs = %d, want 4 (09:00-12:00)", len(misfire.MissedRuns))
+		}
+		if !misfire.MissedRuns[0].Equal(nextRun) {
+			t.Errorf("first missed run = %v, want %v", misfire.MissedRuns[0], nextRun)
+		}
+		if misfire.Truncated {
+			t.Error("misfire should not be truncated")
+		}
+	})
+
+	t.Run("backlog is capped", func(t *testing.T) {
+		nextRun := now.Add(-time.Hour)
+		schedule := &Schedule{ID: "s2", Interval: "1m", NextRunAt: &nextRun}
+
+		misfire, err := service.missedRuns(schedule, now)
+		if err != nil {
+			t.Fatalf("missedRuns() error = %v", err)
+		}
+		if len(misfire.MissedRuns) != MaxMisfireBacklog {
+			t.Errorf("missed runs = %d, want %d", len(misfire.MissedRuns), MaxMisfireBacklog)
+		}
+		if !misfire.Truncated {
+			t.Error("misfire should be truncated")
+		}
+	})
+}
+
+func TestSchedulerRecoverMisfires(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	missed := []time.Time{
+		time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, time.June, 3, 11, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		policy      MisfirePolicy
+		wantFired   int
+		wantSkipped int
+	}{
+		{name: "skip", policy: MisfirePolicySkip, wantFired: 0, wantSkipped: 1},
+		{name: "fire once", policy: MisfirePolicyFireOnce, wantFired: 1},
+		{name: "fire all", policy: MisfirePolicyFireAll, wantFired: 3},
+		{name: "unset defaults to fire once", policy: "", wantFired: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &mockMisfireService{
+				misfires: []*Misfire{{
+					Schedule: &Schedule{
+						ID:            "schedule-1",
+						TenantID:      "tenant-1",
+						WorkflowID:    "workflow-1",
+						Enabled:       true,
+						MisfirePolicy: tt.policy,
+					},
+					MissedRuns: missed,
+				}},
+			}
+			executor := &MockExecutor{}
+
+			scheduler := NewScheduler(provider, executor, logger)
+			scheduler.recoverMisfires(context.Background())
+
+			if got := len(executor.GetExecutedSchedules()); got != tt.wantFired {
+				t.Errorf("fired %d runs, want %d", got, tt.wantFired)
+			}
+			if got := len(provider.skipped); got != tt.wantSkipped {
+				t.Errorf("skipped %d schedules, want %d", got, tt.wantSkipped)
+			}
+		})
+	}
+}
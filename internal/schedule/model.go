@@ -29,31 +29,75 @@ func (p OverlapPolicy) IsValid() bool {
 	return slices.Contains(ValidOverlapPolicies, p)
 }
 
+// MisfirePolicy defines how runs missed while the scheduler was down are handled
+type MisfirePolicy string
+
+const (
+	// MisfirePolicySkip drops missed runs and resumes at the next future occurrence
+	MisfirePolicySkip MisfirePolicy = "skip"
+	// MisfirePolicyFireOnce runs once to catch up, regardless of how many runs were missed
+	MisfirePolicyFireOnce MisfirePolicy = "fire_once"
+	// MisfirePolicyFireAll runs once per missed occurrence, up to MaxMisfireBacklog
+	MisfirePolicyFireAll MisfirePolicy = "fire_all"
+)
+
+// ValidMisfirePolicies contains all valid misfire policy values
+var ValidMisfirePolicies = []MisfirePolicy{
+	MisfirePolicySkip,
+	MisfirePolicyFireOnce,
+	MisfirePolicyFireAll,
+}
+
+// IsValid checks if the misfire policy is valid
+func (p MisfirePolicy) IsValid() bool {
+	return slices.Contains(ValidMisfirePolicies, p)
+}
+
+// ScheduleStatus is whether a schedule is dispatched or temporarily paused
+type ScheduleStatus string
+
+const (
+	// ScheduleStatusActive schedules are dispatched when enabled and due
+	ScheduleStatusActive ScheduleStatus = "active"
+	// ScheduleStatusPaused schedules keep their definition but are not dispatched
+	ScheduleStatusPaused ScheduleStatus = "paused"
+)
+
 // Schedule represents a scheduled workflow execution
 type Schedule struct {
-	ID                 string        `db:"id" json:"id"`
-	TenantID           string        `db:"tenant_id" json:"tenant_id"`
-	WorkflowID         string        `db:"workflow_id" json:"workflow_id"`
-	Name               string        `db:"name" json:"name"`
-	CronExpression     string        `db:"cron_expression" json:"cron_expression"`
-	Timezone           string        `db:"timezone" json:"timezone"`
-	OverlapPolicy      OverlapPolicy `db:"overlap_policy" json:"overlap_policy"`
-	Enabled            bool          `db:"enabled" json:"enabled"`
-	NextRunAt          *time.Time    `db:"next_run_at" json:"next_run_at,omitempty"`
-	LastRunAt          *time.Time    `db:"last_run_at" json:"last_run_at,omitempty"`
-	LastExecutionID    *string       `db:"last_execution_id" json:"last_execution_id,omitempty"`
-	RunningExecutionID *string       `db:"running_execution_id" json:"running_execution_id,omitempty"`
-	CreatedBy          string        `db:"created_by" json:"created_by"`
-	CreatedAt          time.Time     `db:"created_at" json:"created_at"`
-	UpdatedAt          time.Time     `db:"updated_at" json:"updated_at"`
+	ID                 string         `db:"id" json:"id"`
+	TenantID           string         `db:"tenant_id" json:"tenant_id"`
+	WorkflowID         string         `db:"workflow_id" json:"workflow_id"`
+	Name               string         `db:"name" json:"name"`
+	CronExpression     string         `db:"cron_expression" json:"cron_expression"`
+	Interval           string         `db:"run_interval" json:"interval,omitempty"`
+	Timezone           string         `db:"timezone" json:"timezone"`
+	OverlapPolicy      OverlapPolicy  `db:"overlap_policy" json:"overlap_policy"`
+	MisfirePolicy      MisfirePolicy  `db:"misfire_policy" json:"misfire_policy"`
+	Enabled            bool           `db:"enabled" json:"enabled"`
+	Status             ScheduleStatus `db:"status" json:"status"`
+	PausedAt           *time.Time     `db:"paused_at" json:"paused_at,omitempty"`
+	PausedBy           *string        `db:"paused_by" json:"paused_by,omitempty"`
+	ResumedAt          *time.Time     `db:"resumed_at" json:"resumed_at,omitempty"`
+	ResumedBy          *string        `db:"resumed_by" json:"resumed_by,omitempty"`
+	NextRunAt          *time.Time     `db:"next_run_at" json:"next_run_at,omitempty"`
+	LastRunAt          *time.Time     `db:"last_run_at" json:"last_run_at,omitempty"`
+	LastFiredAt        *time.Time     `db:"last_fired_at" json:"last_fired_at,omitempty"`
+	LastExecutionID    *string        `db:"last_execution_id" json:"last_execution_id,omitempty"`
+	RunningExecutionID *string        `db:"running_execution_id" json:"running_execution_id,omitempty"`
+	CreatedBy          string         `db:"created_by" json:"created_by"`
+	CreatedAt          time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at" json:"updated_at"`
 }
 
 // CreateScheduleInput represents input for creating a schedule
 type CreateScheduleInput struct {
 	Name           string        `json:"name" validate:"required,min=1,max=255"`
-	CronExpression string        `json:"cron_expression" validate:"required"`
+	CronExpression string        `json:"cron_expression,omitempty"`
+	Interval       string        `json:"interval,omitempty"`
 	Timezone       string        `json:"timezone,omitempty"`
 	OverlapPolicy  OverlapPolicy `json:"overlap_policy,omitempty"`
+	MisfirePolicy  MisfirePolicy `json:"misfire_policy,omitempty"`
 	Enabled        bool          `json:"enabled"`
 }
 
@@ -61,8 +105,10 @@ type CreateScheduleInput struct {
 type UpdateScheduleInput struct {
 	Name           *string        `json:"name,omitempty"`
 	CronExpression *string        `json:"cron_expression,omitempty"`
+	Interval       *string        `json:"interval,omitempty"`
 	Timezone       *string        `json:"timezone,omitempty"`
 	OverlapPolicy  *OverlapPolicy `json:"overlap_policy,omitempty"`
+	MisfirePolicy  *MisfirePolicy `json:"misfire_policy,omitempty"`
 	Enabled        *bool          `json:"enabled,omitempty"`
 }
 
@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+// IsPaused returns true if the schedule has been paused and will not be dispatched
+func (s *Schedule) IsPaused() bool {
+	return s.Status == ScheduleStatusPaused
+}
+
+// PauseSchedule stops a schedule from being dispatched without changing its
+// definition. The pausing user and time are recorded on the schedule.
+func (s *Service) PauseSchedule(ctx context.Context, tenantID, id, userID string) (*Schedule, error) {
+	schedule, err := s.repo.Pause(ctx, tenantID, id, userID)
+	if err != nil {
+		if err != ErrNotFound && err != ErrAlreadyPaused {
+			s.logger.Error("failed to pause schedule", "error", err, "schedule_id", id)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("schedule paused", "schedule_id", id, "tenant_id", tenantID, "paused_by", userID)
+	return schedule, nil
+}
+
+// ResumeSchedule makes a paused schedule dispatchable again. The next run is
+// the first occurrence after now; runs that fell inside the pause are not
+// caught up regardless of the misfire policy.
+func (s *Service) ResumeSchedule(ctx context.Context, tenantID, id, userID string) (*Schedule, error) {
+	existing, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.IsPaused() {
+		return nil, ErrNotPaused
+	}
+
+	var nextRunAt *time.Time
+	if existing.Enabled {
+		nextRun, err := s.NextRunAfter(existing, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		nextRunAt = &nextRun
+	}
+
+	schedule, err := s.repo.Resume(ctx, tenantID, id, userID, nextRunAt)
+	if err != nil {
+		if err != ErrNotFound && err != ErrNotPaused {
+			s.logger.Error("failed to resume schedule", "error", err, "schedule_id", id)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("schedule resumed", "schedule_id", id, "tenant_id", tenantID, "resumed_by", userID, "next_run", schedule.NextRunAt)
+	return schedule, nil
+}
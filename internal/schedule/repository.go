@@ -13,6 +13,10 @@ import (
 
 var (
 	ErrNotFound = errors.New("schedule not found")
+	// ErrAlreadyPaused is returned when pausing a schedule that is already paused
+	ErrAlreadyPaused = errors.New("schedule is already paused")
+	// ErrNotPaused is returned when resuming a schedule that is not paused
+	ErrNotPaused = errors.New("schedule is not paused")
 )
 
 // Repository handles schedule database operations
@@ -42,16 +46,22 @@ func (r *Repository) Create(ctx context.Context, tenantID, workflowID, createdBy
 		overlapPolicy = OverlapPolicySkip
 	}
 
+	// Default misfire policy to a single catch-up run if not provided
+	misfirePolicy := input.MisfirePolicy
+	if misfirePolicy == "" {
+		misfirePolicy = MisfirePolicyFireOnce
+	}
+
 	query := `
-		INSERT INTO schedules (id, tenant_id, workflow_id, name, cron_expression, timezone, overlap_policy, enabled, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO schedules (id, tenant_id, workflow_id, name, cron_expression, run_interval, timezone, overlap_policy, misfire_policy, enabled, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING *
 	`
 
 	var schedule Schedule
 	err := r.db.QueryRowxContext(
 		ctx, query,
-		id, tenantID, workflowID, input.Name, input.CronExpression, timezone, overlapPolicy, input.Enabled, createdBy, now, now,
+		id, tenantID, workflowID, input.Name, input.CronExpression, input.Interval, timezone, overlapPolicy, misfirePolicy, input.Enabled, createdBy, now, now,
 	).StructScan(&schedule)
 
 	if err != nil {
@@ -102,7 +112,9 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 		    timezone = COALESCE($5, timezone),
 		    overlap_policy = COALESCE($6, overlap_policy),
 		    enabled = COALESCE($7, enabled),
-		    updated_at = $8
+		    updated_at = $8,
+		    run_interval = COALESCE($9, run_interval),
+		    misfire_policy = COALESCE($10, misfire_policy)
 		WHERE id = $1 AND tenant_id = $2
 		RETURNING *
 	`
@@ -110,7 +122,7 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 	var schedule Schedule
 	err := r.db.QueryRowxContext(
 		ctx, query,
-		id, tenantID, input.Name, input.CronExpression, input.Timezone, input.OverlapPolicy, input.Enabled, time.Now(),
+		id, tenantID, input.Name, input.CronExpression, input.Timezone, input.OverlapPolicy, input.Enabled, time.Now(), input.Interval, input.MisfirePolicy,
 	).StructScan(&schedule)
 
 	if err != nil {
@@ -123,6 +135,67 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 	return &schedule, nil
 }
 
+// Pause marks an active schedule as paused by the given user.
+// Returns ErrAlreadyPaused if the schedule exists but is not active.
+func (r *Repository) Pause(ctx context.Context, tenantID, id, pausedBy string) (*Schedule, error) {
+	query := `
+		UPDATE schedules
+		SET status = 'paused',
+		    paused_at = $3,
+		    paused_by = $4,
+		    updated_at = $3
+		WHERE id = $1 AND tenant_id = $2 AND status = 'active'
+		RETURNING *
+	`
+
+	var schedule Schedule
+	err := r.db.QueryRowxContext(ctx, query, id, tenantID, time.Now(), pausedBy).StructScan(&schedule)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.statusChangeError(ctx, tenantID, id, ErrAlreadyPaused)
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// Resume marks a paused schedule as active again by the given user and sets its next run.
+// last_fired_at is cleared so occurrences skipped while paused are not treated as misfires.
+// Returns ErrNotPaused if the schedule exists but is not paused.
+func (r *Repository) Resume(ctx context.Context, tenantID, id, resumedBy string, nextRunAt *time.Time) (*Schedule, error) {
+	query := `
+		UPDATE schedules
+		SET status = 'active',
+		    resumed_at = $3,
+		    resumed_by = $4,
+		    next_run_at = COALESCE($5, next_run_at),
+		    last_fired_at = NULL,
+		    updated_at = $3
+		WHERE id = $1 AND tenant_id = $2 AND status = 'paused'
+		RETURNING *
+	`
+
+	var schedule Schedule
+	err := r.db.QueryRowxContext(ctx, query, id, tenantID, time.Now(), resumedBy, nextRunAt).StructScan(&schedule)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.statusChangeError(ctx, tenantID, id, ErrNotPaused)
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// statusChangeError distinguishes a missing schedule from one already in the target status
+func (r *Repository) statusChangeError(ctx context.Context, tenantID, id string, stateErr error) error {
+	if _, err := r.GetByID(ctx, tenantID, id); err != nil {
+		return err
+	}
+	return stateErr
+}
+
 // Delete deletes a schedule
 func (r *Repository) Delete(ctx context.Context, tenantID, id string) error {
 	query := `DELETE FROM schedules WHERE id = $1 AND tenant_id = $2`
@@ -190,6 +263,7 @@ func (r *Repository) GetDueSchedules(ctx context.Context, beforeTime time.Time)
 	query := `
 		SELECT * FROM schedules
 		WHERE enabled = true
+		AND status = 'active'
 		AND (next_run_at IS NULL OR next_run_at <= $1)
 		ORDER BY next_run_at ASC NULLS FIRST
 		LIMIT 100
@@ -204,6 +278,21 @@ func (r *Repository) GetDueSchedules(ctx context.Context, beforeTime time.Time)
 	return schedules, nil
 }
 
+// GetNextDueTime returns the earliest next run time across enabled, active schedules, or nil if none
+func (r *Repository) GetNextDueTime(ctx context.Context) (*time.Time, error) {
+	query := `SELECT MIN(next_run_at) FROM schedules WHERE enabled = true AND status = 'active'`
+
+	var nextDue sql.NullTime
+	if err := r.db.GetContext(ctx, &nextDue, query); err != nil {
+		return nil, err
+	}
+	if !nextDue.Valid {
+		return nil, nil
+	}
+
+	return &nextDue.Time, nil
+}
+
 // UpdateNextRunTime updates the next run time for a schedule
 func (r *Repository) UpdateNextRunTime(ctx context.Context, id string, nextRunAt time.Time) error {
 	query := `
@@ -217,18 +306,20 @@ func (r *Repository) UpdateNextRunTime(ctx context.Context, id string, nextRunAt
 	return err
 }
 
-// UpdateLastRun updates the last run information for a schedule
-func (r *Repository) UpdateLastRun(ctx context.Context, id string, lastRunAt time.Time, executionID string, nextRunAt time.Time) error {
+// UpdateLastRun updates the last run information for a schedule.
+// firedAt is the scheduled occurrence time the run corresponds to.
+func (r *Repository) UpdateLastRun(ctx context.Context, id string, lastRunAt time.Time, executionID string, nextRunAt, firedAt time.Time) error {
 	query := `
 		UPDATE schedules
 		SET last_run_at = $2,
 		    last_execution_id = $3,
 		    next_run_at = $4,
-		    updated_at = $5
+		    last_fired_at = $5,
+		    updated_at = $6
 		WHERE id = $1
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id, lastRunAt, executionID, nextRunAt, time.Now())
+	_, err := r.db.ExecContext(ctx, query, id, lastRunAt, executionID, nextRunAt, firedAt, time.Now())
 	return err
 }
 
@@ -20,9 +20,14 @@ type ExecutionTerminator interface {
 // ScheduleProvider interface for getting due schedules
 type ScheduleProvider interface {
 	GetDueSchedules(ctx context.Context) ([]*Schedule, error)
+	GetNextDueTime(ctx context.Context) (*time.Time, error)
 	MarkScheduleRun(ctx context.Context, scheduleID, executionID string) error
 }
 
+// minDispatchWait is the shortest time the scheduler sleeps between checks,
+// preventing a busy loop when a schedule stays due (e.g. queued behind a running execution)
+const minDispatchWait = time.Second
+
 // Scheduler manages scheduled workflow executions
 type Scheduler struct {
 	provider       ScheduleProvider
@@ -103,16 +108,21 @@ func (s *Scheduler) Wait() {
 	s.wg.Wait()
 }
 
-// run is the main scheduler loop
+// run is the main scheduler loop. It checks at least every checkInterval and
+// wakes earlier when a schedule is due sooner, so interval schedules with
+// sub-minute granularity are dispatched on time.
 func (s *Scheduler) run(ctx context.Context) {
 	defer s.wg.Done()
 
-	ticker := time.NewTicker(s.checkInterval)
-	defer ticker.Stop()
+	// Catch up on runs missed while the scheduler was down
+	s.recoverMisfires(ctx)
 
 	// Run initial check immediately
 	s.checkAndExecuteSchedules(ctx)
 
+	timer := time.NewTimer(s.nextWait(ctx))
+	defer timer.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -121,12 +131,39 @@ func (s *Scheduler) run(ctx context.Context) {
 		case <-s.stopCh:
 			s.logger.Info("scheduler stop signal received")
 			return
-		case <-ticker.C:
+		case <-timer.C:
 			s.checkAndExecuteSchedules(ctx)
+			timer.Reset(s.nextWait(ctx))
 		}
 	}
 }
 
+// nextWait returns how long to sleep before the next check: the check
+// interval, or less if the earliest enabled schedule is due before then
+func (s *Scheduler) nextWait(ctx context.Context) time.Duration {
+	s.mu.Lock()
+	wait := s.checkInterval
+	s.mu.Unlock()
+
+	nextDue, err := s.provider.GetNextDueTime(ctx)
+	if err != nil {
+		s.logger.Warn("failed to get next due time", "error", err)
+		return wait
+	}
+	if nextDue == nil {
+		return wait
+	}
+
+	untilDue := time.Until(*nextDue)
+	if untilDue < minDispatchWait {
+		untilDue = minDispatchWait
+	}
+	if untilDue < wait {
+		wait = untilDue
+	}
+	return wait
+}
+
 // checkAndExecuteSchedules checks for due schedules and executes them
 func (s *Scheduler) checkAndExecuteSchedules(ctx context.Context) {
 	schedules, err := s.provider.GetDueSchedules(ctx)
@@ -162,6 +199,65 @@ func (s *Scheduler) checkAndExecuteSchedules(ctx context.Context) {
 	s.logger.Info("finished processing due schedules", "count", len(schedules))
 }
 
+// recoverMisfires applies each schedule's misfire policy to runs that were
+// missed while the scheduler was down. It is a no-op for providers that do
+// not implement MisfireResolver.
+func (s *Scheduler) recoverMisfires(ctx context.Context) {
+	resolver, ok := s.provider.(MisfireResolver)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	misfires, err := resolver.GetMisfires(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to get missed schedule runs", "error", err)
+		return
+	}
+
+	for _, misfire := range misfires {
+		schedule := misfire.Schedule
+		policy := schedule.MisfirePolicy
+		if policy == "" {
+			policy = MisfirePolicyFireOnce
+		}
+
+		s.logger.Warn("schedule missed runs while scheduler was down",
+			"schedule_id", schedule.ID,
+			"workflow_id", schedule.WorkflowID,
+			"missed_runs", len(misfire.MissedRuns),
+			"truncated", misfire.Truncated,
+			"misfire_policy", policy,
+		)
+
+		switch policy {
+		case MisfirePolicySkip:
+			if s.overlapHandler != nil {
+				for _, missedAt := range misfire.MissedRuns {
+					if err := s.overlapHandler.RecordExecutionSkipped(ctx, schedule, missedAt, "missed while scheduler was down (misfire policy: skip)"); err != nil {
+						s.logger.Error("failed to record missed run", "error", err, "schedule_id", schedule.ID)
+					}
+				}
+			}
+			if err := resolver.SkipMisfire(ctx, schedule, now); err != nil {
+				s.logger.Error("failed to skip missed runs", "error", err, "schedule_id", schedule.ID)
+			}
+		case MisfirePolicyFireAll:
+			if misfire.Truncated {
+				s.logger.Warn("missed runs exceed backlog limit, firing capped number",
+					"schedule_id", schedule.ID,
+					"max_backlog", MaxMisfireBacklog,
+				)
+			}
+			for range misfire.MissedRuns {
+				s.executeSchedule(ctx, schedule)
+			}
+		default:
+			s.executeSchedule(ctx, schedule)
+		}
+	}
+}
+
 // executeSchedule executes a single schedule with overlap policy handling
 func (s *Scheduler) executeSchedule(ctx context.Context, schedule *Schedule) {
 	triggerTime := time.Now()
@@ -182,6 +278,14 @@ func (s *Scheduler) executeSchedule(ctx context.Context, schedule *Schedule) {
 		return
 	}
 
+	// Paused schedules keep their definition but are not dispatched until resumed
+	if schedule.IsPaused() {
+		s.logger.Info("schedule is paused, skipping",
+			"schedule_id", schedule.ID,
+		)
+		return
+	}
+
 	// Check overlap policy if handler is available
 	if s.overlapHandler != nil {
 		decision, err := s.overlapHandler.CheckOverlap(ctx, schedule)
@@ -13,6 +13,7 @@ import (
 type MockService struct {
 	getDueSchedulesFunc func(ctx context.Context) ([]*Schedule, error)
 	markScheduleRunFunc func(ctx context.Context, scheduleID, executionID string) error
+	getNextDueTimeFunc  func(ctx context.Context) (*time.Time, error)
 	mu                  sync.Mutex
 	callCount           int
 }
@@ -27,6 +28,13 @@ func (m *MockService) GetDueSchedules(ctx context.Context) ([]*Schedule, error)
 	return []*Schedule{}, nil
 }
 
+func (m *MockService) GetNextDueTime(ctx context.Context) (*time.Time, error) {
+	if m.getNextDueTimeFunc != nil {
+		return m.getNextDueTimeFunc(ctx)
+	}
+	return nil, nil
+}
+
 func (m *MockService) MarkScheduleRun(ctx context.Context, scheduleID, executionID string) error {
 	if m.markScheduleRunFunc != nil {
 		return m.markScheduleRunFunc(ctx, scheduleID, executionID)
@@ -224,6 +232,48 @@ func TestSchedulerIgnoresDisabledSchedules(t *testing.T) {
 	}
 }
 
+func TestSchedulerIgnoresPausedSchedules(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	now := time.Now()
+	pausedSchedule := &Schedule{
+		ID:             "schedule-paused",
+		TenantID:       "tenant-1",
+		WorkflowID:     "workflow-1",
+		Name:           "Paused Schedule",
+		Enabled:        true,
+		Status:         ScheduleStatusPaused,
+		NextRunAt:      &now,
+		CronExpression: "0 12 * * *",
+		Timezone:       "UTC",
+	}
+
+	mockService := &MockService{
+		getDueSchedulesFunc: func(ctx context.Context) ([]*Schedule, error) {
+			return []*Schedule{pausedSchedule}, nil
+		},
+	}
+
+	mockExecutor := &MockExecutor{}
+
+	scheduler := NewScheduler(mockService, mockExecutor, logger)
+	scheduler.SetCheckInterval(100 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler.Start(ctx)
+	time.Sleep(250 * time.Millisecond)
+	scheduler.Stop()
+	scheduler.Wait()
+
+	if executed := mockExecutor.GetExecutedSchedules(); len(executed) != 0 {
+		t.Errorf("Paused schedule should not be executed, but got %d executions", len(executed))
+	}
+}
+
 func TestSchedulerMultipleSchedules(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelError,
@@ -40,16 +40,14 @@ func (s *Service) SetWorkflowService(workflowGetter WorkflowGetter) {
 
 // Create creates a new schedule
 func (s *Service) Create(ctx context.Context, tenantID, workflowID, userID string, input CreateScheduleInput) (*Schedule, error) {
-	// Validate cron expression
-	if err := s.validateCronExpression(input.CronExpression); err != nil {
+	// Validate cron expression or interval
+	if err := s.validateTiming(input.CronExpression, input.Interval); err != nil {
 		return nil, err
 	}
 
 	// Validate timezone
-	if input.Timezone != "" {
-		if _, err := time.LoadLocation(input.Timezone); err != nil {
-			return nil, &ValidationError{Message: "invalid timezone: " + err.Error()}
-		}
+	if _, err := loadScheduleLocation(input.Timezone); err != nil {
+		return nil, err
 	}
 
 	// Validate overlap policy if provided
@@ -57,6 +55,11 @@ func (s *Service) Create(ctx context.Context, tenantID, workflowID, userID strin
 		return nil, &ValidationError{Message: "invalid overlap policy: must be one of skip, queue, terminate"}
 	}
 
+	// Validate misfire policy if provided
+	if input.MisfirePolicy != "" && !input.MisfirePolicy.IsValid() {
+		return nil, &ValidationError{Message: "invalid misfire policy: must be one of skip, fire_once, fire_all"}
+	}
+
 	// Verify workflow exists
 	if s.workflowGetter != nil {
 		if _, err := s.workflowGetter.GetByID(ctx, tenantID, workflowID); err != nil {
@@ -73,7 +76,7 @@ func (s *Service) Create(ctx context.Context, tenantID, workflowID, userID strin
 
 	// Calculate and set next run time if enabled
 	if schedule.Enabled {
-		nextRun, err := s.calculateNextRun(schedule.CronExpression, schedule.Timezone)
+		nextRun, err := s.NextRunAfter(schedule, time.Now())
 		if err != nil {
 			s.logger.Error("failed to calculate next run time", "error", err, "schedule_id", schedule.ID)
 		} else {
@@ -102,17 +105,24 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateS
 		return nil, err
 	}
 
-	// Validate cron expression if provided
-	if input.CronExpression != nil {
-		if err := s.validateCronExpression(*input.CronExpression); err != nil {
+	// Validate the resulting cron expression or interval if either changes
+	if input.CronExpression != nil || input.Interval != nil {
+		cronExpression, interval := existing.CronExpression, existing.Interval
+		if input.CronExpression != nil {
+			cronExpression = *input.CronExpression
+		}
+		if input.Interval != nil {
+			interval = *input.Interval
+		}
+		if err := s.validateTiming(cronExpression, interval); err != nil {
 			return nil, err
 		}
 	}
 
 	// Validate timezone if provided
-	if input.Timezone != nil && *input.Timezone != "" {
-		if _, err := time.LoadLocation(*input.Timezone); err != nil {
-			return nil, &ValidationError{Message: "invalid timezone: " + err.Error()}
+	if input.Timezone != nil {
+		if _, err := loadScheduleLocation(*input.Timezone); err != nil {
+			return nil, err
 		}
 	}
 
@@ -121,6 +131,11 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateS
 		return nil, &ValidationError{Message: "invalid overlap policy: must be one of skip, queue, terminate"}
 	}
 
+	// Validate misfire policy if provided
+	if input.MisfirePolicy != nil && !input.MisfirePolicy.IsValid() {
+		return nil, &ValidationError{Message: "invalid misfire policy: must be one of skip, fire_once, fire_all"}
+	}
+
 	// Update schedule
 	schedule, err := s.repo.Update(ctx, tenantID, id, input)
 	if err != nil {
@@ -128,9 +143,9 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateS
 		return nil, err
 	}
 
-	// Recalculate next run time if cron expression, timezone, or enabled status changed
+	// Recalculate next run time if cron expression, interval, timezone, or enabled status changed
 	shouldRecalculate := false
-	if input.CronExpression != nil || input.Timezone != nil {
+	if input.CronExpression != nil || input.Interval != nil || input.Timezone != nil {
 		shouldRecalculate = true
 	}
 	if input.Enabled != nil && *input.Enabled && !existing.Enabled {
@@ -138,7 +153,7 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateS
 	}
 
 	if shouldRecalculate && schedule.Enabled {
-		nextRun, err := s.calculateNextRun(schedule.CronExpression, schedule.Timezone)
+		nextRun, err := s.NextRunAfter(schedule, time.Now())
 		if err != nil {
 			s.logger.Error("failed to calculate next run time", "error", err, "schedule_id", schedule.ID)
 		} else {
@@ -195,6 +210,11 @@ func (s *Service) GetDueSchedules(ctx context.Context) ([]*Schedule, error) {
 	return s.repo.GetDueSchedules(ctx, time.Now())
 }
 
+// GetNextDueTime returns the earliest next run time across enabled schedules
+func (s *Service) GetNextDueTime(ctx context.Context) (*time.Time, error) {
+	return s.repo.GetNextDueTime(ctx)
+}
+
 // MarkScheduleRun updates schedule after execution
 func (s *Service) MarkScheduleRun(ctx context.Context, scheduleID, executionID string) error {
 	schedule, err := s.repo.GetByIDWithoutTenant(ctx, scheduleID)
@@ -203,14 +223,21 @@ func (s *Service) MarkScheduleRun(ctx context.Context, scheduleID, executionID s
 	}
 
 	// Calculate next run time
-	nextRun, err := s.calculateNextRun(schedule.CronExpression, schedule.Timezone)
+	now := time.Now()
+	nextRun, err := s.NextRunAfter(schedule, now)
 	if err != nil {
 		s.logger.Error("failed to calculate next run time", "error", err, "schedule_id", scheduleID)
 		return err
 	}
 
+	// Record the occurrence this run corresponds to for misfire detection
+	firedAt := now
+	if schedule.NextRunAt != nil && schedule.NextRunAt.Before(now) {
+		firedAt = *schedule.NextRunAt
+	}
+
 	// Update last run and next run
-	err = s.repo.UpdateLastRun(ctx, scheduleID, time.Now(), executionID, nextRun)
+	err = s.repo.UpdateLastRun(ctx, scheduleID, now, executionID, nextRun, firedAt)
 	if err != nil {
 		s.logger.Error("failed to update schedule run info", "error", err, "schedule_id", scheduleID)
 		return err
@@ -238,16 +265,13 @@ func (s *Service) calculateNextRun(expression, timezone string) (time.Time, erro
 	}
 
 	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+	loc, err := loadScheduleLocation(timezone)
 	if err != nil {
-		loc = time.UTC
+		return time.Time{}, err
 	}
 
 	// Calculate next run time
-	now := time.Now().In(loc)
-	nextRun := sched.Next(now)
-
-	return nextRun, nil
+	return nextCronRun(sched, time.Now(), loc), nil
 }
 
 // ParseNextRunTime is a helper to parse and return next run time (useful for API responses)
@@ -274,9 +298,9 @@ func (s *Service) GetNextRunTimes(expression, timezone string, count int) ([]tim
 	}
 
 	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+	loc, err := loadScheduleLocation(timezone)
 	if err != nil {
-		loc = time.UTC
+		return nil, err
 	}
 
 	// Calculate next N run times
@@ -284,7 +308,7 @@ func (s *Service) GetNextRunTimes(expression, timezone string, count int) ([]tim
 	current := time.Now().In(loc)
 
 	for i := 0; i < count; i++ {
-		nextRun := sched.Next(current)
+		nextRun := nextCronRun(sched, current, loc)
 		times = append(times, nextRun)
 		current = nextRun
 	}
@@ -107,10 +107,10 @@ func TestCalculateNextRun(t *testing.T) {
 			wantErr:    false,
 		},
 		{
-			name:       "invalid timezone falls back to UTC",
+			name:       "invalid timezone is rejected",
 			expression: "0 12 * * *",
 			timezone:   "Invalid/Timezone",
-			wantErr:    false,
+			wantErr:    true,
 		},
 	}
 
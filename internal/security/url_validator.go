@@ -1,12 +1,21 @@
 package security
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
+// ErrBlockedDestination is returned when SSRF protection refuses a URL or
+// connection because of its host or address
+var ErrBlockedDestination = errors.New("destination blocked")
+
 // URLValidatorConfig holds URL validation configuration
 type URLValidatorConfig struct {
 	// Enabled controls whether SSRF protection is active
@@ -15,6 +24,11 @@ type URLValidatorConfig struct {
 	AllowedNetworks []string
 	// BlockedNetworks are additional CIDR ranges to block beyond defaults
 	BlockedNetworks []string
+	// AllowedHosts are hostnames that may resolve to blocked ranges, for
+	// internal targets. "*.example.com" matches any subdomain.
+	AllowedHosts []string
+	// BlockedHosts are hostnames that are always refused, in the same form
+	BlockedHosts []string
 }
 
 // URLValidator validates URLs to prevent SSRF attacks
@@ -22,6 +36,28 @@ type URLValidator struct {
 	config          *URLValidatorConfig
 	allowedNetworks []*net.IPNet
 	blockedNetworks []*net.IPNet
+	lookupIPAddr    func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	transportOnce sync.Once
+	transport     *http.Transport
+}
+
+// defaultBlockedRanges are refused unless allowed by AllowedNetworks or AllowedHosts
+var defaultBlockedRanges = []struct {
+	cidr  string
+	label string
+}{
+	{"0.0.0.0/8", "unspecified"},
+	{"127.0.0.0/8", "loopback"},
+	{"10.0.0.0/8", "private"},
+	{"172.16.0.0/12", "private"},
+	{"192.168.0.0/16", "private"},
+	{"100.64.0.0/10", "shared address space"},
+	{"169.254.0.0/16", "link-local"}, // AWS/GCP metadata service!
+	{"::/128", "unspecified"},
+	{"::1/128", "loopback"},
+	{"fc00::/7", "private"},
+	{"fe80::/10", "link-local"},
 }
 
 // NewURLValidator creates a new URL validator with default configuration
@@ -39,6 +75,7 @@ func NewURLValidatorWithConfig(config *URLValidatorConfig) *URLValidator {
 		config:          config,
 		allowedNetworks: make([]*net.IPNet, 0),
 		blockedNetworks: make([]*net.IPNet, 0),
+		lookupIPAddr:    net.DefaultResolver.LookupIPAddr,
 	}
 
 	// Parse allowed networks
@@ -89,17 +126,118 @@ func (v *URLValidator) ValidateURL(urlStr string) error {
 		return fmt.Errorf("hostname is required")
 	}
 
+	_, err = v.validateHost(context.Background(), hostname)
+	return err
+}
+
+// DialContext returns a dial function for http.Transport that connects only
+// to addresses passing the same checks as ValidateURL. The host is resolved
+// once and the connection is made to the address that was checked, so a DNS
+// answer that changes between validation and connection (DNS rebinding)
+// cannot reach a blocked address.
+func (v *URLValidator) DialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !v.config.Enabled {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := v.validateHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var dialErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = err
+		}
+		return nil, dialErr
+	}
+}
+
+// Transport returns an http.Transport that connects through DialContext.
+// It is created once per validator so connections are reused. It ignores
+// proxy settings, since a proxy would hide the destination from the dialer.
+func (v *URLValidator) Transport() *http.Transport {
+	v.transportOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+		transport.DialContext = v.DialContext(&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		})
+		v.transport = transport
+	})
+	return v.transport
+}
+
+// validateHost checks a hostname or IP literal against the host lists and
+// blocked ranges and returns the addresses it may be reached at
+func (v *URLValidator) validateHost(ctx context.Context, hostname string) ([]net.IP, error) {
+	// Normalize hostname to lowercase for comparison
+	hostname = strings.TrimSuffix(strings.ToLower(hostname), ".")
+
+	if matchesHost(v.config.BlockedHosts, hostname) {
+		return nil, fmt.Errorf("%w: host %s is on the blocked host list", ErrBlockedDestination, hostname)
+	}
+
 	// Check if hostname is an IP address directly
 	if ip := net.ParseIP(hostname); ip != nil {
-		return v.validateIP(ip)
+		if err := v.validateIP(ip); err != nil {
+			return nil, err
+		}
+		return []net.IP{ip}, nil
 	}
 
-	// Resolve hostname to IPs
-	if err := v.resolveAndValidate(hostname); err != nil {
-		return err
+	allowedHost := matchesHost(v.config.AllowedHosts, hostname)
+
+	// Block localhost variations
+	if !allowedHost && (hostname == "localhost" || strings.HasSuffix(hostname, ".localhost")) {
+		return nil, fmt.Errorf("%w: blocked hostname: %s", ErrBlockedDestination, hostname)
 	}
 
-	return nil
+	ips, err := v.resolve(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	if allowedHost {
+		return ips, nil
+	}
+
+	// Validate each resolved IP
+	for _, ip := range ips {
+		if err := v.validateIP(ip); err != nil {
+			return nil, fmt.Errorf("blocked IP address %s for hostname %s: %w", ip, hostname, err)
+		}
+	}
+
+	return ips, nil
+}
+
+// matchesHost reports whether hostname is in a host list. An entry
+// "*.example.com" matches subdomains of example.com but not example.com itself.
+func matchesHost(hosts []string, hostname string) bool {
+	for _, host := range hosts {
+		host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+		if suffix, ok := strings.CutPrefix(host, "*."); ok {
+			if strings.HasSuffix(hostname, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == hostname {
+			return true
+		}
+	}
+	return false
 }
 
 // validateScheme checks if the URL scheme is allowed
@@ -111,34 +249,22 @@ func (v *URLValidator) validateScheme(parsedURL *url.URL) error {
 	return nil
 }
 
-// resolveAndValidate resolves a hostname and validates all resolved IPs
-func (v *URLValidator) resolveAndValidate(hostname string) error {
-	// Normalize hostname to lowercase for comparison
-	hostname = strings.ToLower(hostname)
-
-	// Block localhost variations
-	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
-		return fmt.Errorf("blocked hostname: %s", hostname)
-	}
-
-	// Resolve hostname to IPs
-	ips, err := net.LookupIP(hostname)
+// resolve resolves a hostname to its IP addresses
+func (v *URLValidator) resolve(ctx context.Context, hostname string) ([]net.IP, error) {
+	addrs, err := v.lookupIPAddr(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to resolve hostname: %w", err)
+		return nil, fmt.Errorf("failed to resolve hostname: %w", err)
 	}
 
-	if len(ips) == 0 {
-		return fmt.Errorf("no IP addresses found for hostname: %s", hostname)
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no IP addresses found for hostname: %s", hostname)
 	}
 
-	// Validate each resolved IP
-	for _, ip := range ips {
-		if err := v.validateIP(ip); err != nil {
-			return fmt.Errorf("blocked IP address %s for hostname %s: %w", ip, hostname, err)
-		}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
 	}
-
-	return nil
+	return ips, nil
 }
 
 // validateIP validates that an IP address is not blocked
@@ -151,14 +277,14 @@ func (v *URLValidator) validateIP(ip net.IP) error {
 	}
 
 	// Check if IP is blocked
-	if v.isBlockedIP(ip.String()) {
-		return fmt.Errorf("blocked IP address: %s", ip)
+	if label, blocked := blockedRange(ip); blocked {
+		return fmt.Errorf("%w: blocked IP address: %s (%s)", ErrBlockedDestination, ip, label)
 	}
 
 	// Check custom blocked networks
 	for _, network := range v.blockedNetworks {
 		if network.Contains(ip) {
-			return fmt.Errorf("blocked IP address: %s", ip)
+			return fmt.Errorf("%w: blocked IP address: %s (blocked network %s)", ErrBlockedDestination, ip, network)
 		}
 	}
 
@@ -173,29 +299,22 @@ func (v *URLValidator) isBlockedIP(ipStr string) bool {
 		return true
 	}
 
-	// Default blocked CIDR ranges
-	blockedRanges := []string{
-		"127.0.0.0/8",    // Loopback IPv4
-		"10.0.0.0/8",     // Private network (RFC 1918)
-		"172.16.0.0/12",  // Private network (RFC 1918)
-		"192.168.0.0/16", // Private network (RFC 1918)
-		"169.254.0.0/16", // Link-local (AWS/GCP metadata service!)
-		"::1/128",        // IPv6 loopback
-		"fc00::/7",       // IPv6 unique local addresses
-		"fe80::/10",      // IPv6 link-local
-	}
+	_, blocked := blockedRange(ip)
+	return blocked
+}
 
-	for _, cidr := range blockedRanges {
-		_, network, err := net.ParseCIDR(cidr)
+// blockedRange returns the label of the default blocked range containing ip
+func blockedRange(ip net.IP) (string, bool) {
+	for _, r := range defaultBlockedRanges {
+		_, network, err := net.ParseCIDR(r.cidr)
 		if err != nil {
 			continue
 		}
 		if network.Contains(ip) {
-			return true
+			return r.label, true
 		}
 	}
-
-	return false
+	return "", false
 }
 
 // ValidateURLWithLogging validates a URL and logs blocked attempts
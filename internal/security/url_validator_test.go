@@ -1,6 +1,11 @@
 package security
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -353,3 +358,136 @@ func TestURLValidator_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestValidateURL_HostLists(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *URLValidatorConfig
+		url       string
+		expectErr bool
+	}{
+		{
+			"Blocked host",
+			&URLValidatorConfig{Enabled: true, BlockedHosts: []string{"metadata.google.internal"}},
+			"http://metadata.google.internal/computeMetadata/v1/",
+			true,
+		},
+		{
+			"Blocked host wildcard",
+			&URLValidatorConfig{Enabled: true, BlockedHosts: []string{"*.example.com"}},
+			"https://API.Example.com./data",
+			true,
+		},
+		{
+			"Blocked IP literal host",
+			&URLValidatorConfig{Enabled: true, BlockedHosts: []string{"93.184.216.34"}},
+			"https://93.184.216.34/",
+			true,
+		},
+		{
+			"Allowed host may be localhost",
+			&URLValidatorConfig{Enabled: true, AllowedHosts: []string{"localhost"}},
+			"http://localhost:8080/health",
+			false,
+		},
+		{
+			"Wildcard does not match the bare domain",
+			&URLValidatorConfig{Enabled: true, AllowedHosts: []string{"*.localhost"}},
+			"http://localhost/health",
+			true,
+		},
+		{
+			"Allowed host does not allow IP literals",
+			&URLValidatorConfig{Enabled: true, AllowedHosts: []string{"localhost"}},
+			"http://127.0.0.1/health",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewURLValidatorWithConfig(tt.config)
+			err := validator.ValidateURL(tt.url)
+			if tt.expectErr && err == nil {
+				t.Errorf("ValidateURL(%q) expected error, got nil", tt.url)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("ValidateURL(%q) expected no error, got: %v", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestValidateURL_BlockedErrorNamesRange(t *testing.T) {
+	err := NewURLValidator().ValidateURL("http://169.254.169.254/latest/meta-data/")
+	if !errors.Is(err, ErrBlockedDestination) {
+		t.Fatalf("Expected ErrBlockedDestination, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "link-local") {
+		t.Errorf("Expected error to name the link-local range, got: %v", err)
+	}
+}
+
+func TestIsBlockedIP_AdditionalRanges(t *testing.T) {
+	validator := NewURLValidator()
+	for _, ip := range []string{"0.0.0.0", "100.100.100.200", "::", "::ffff:169.254.169.254", "::ffff:127.0.0.1"} {
+		if !validator.isBlockedIP(ip) {
+			t.Errorf("isBlockedIP(%q) = false, want true", ip)
+		}
+	}
+}
+
+func TestURLValidator_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("blocks a host that resolves to a blocked address when dialed", func(t *testing.T) {
+		validator := NewURLValidator()
+		// The first lookup answers with a public address and later ones with
+		// loopback, as a DNS rebinding attack would
+		lookups := 0
+		validator.lookupIPAddr = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+			lookups++
+			if lookups == 1 {
+				return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+			}
+			return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}}, nil
+		}
+
+		if err := validator.ValidateURL("http://rebind.example.com:" + port + "/"); err != nil {
+			t.Fatalf("Expected first resolution to pass, got: %v", err)
+		}
+
+		client := &http.Client{Transport: validator.Transport()}
+		_, err := client.Get("http://rebind.example.com:" + port + "/")
+		if !errors.Is(err, ErrBlockedDestination) {
+			t.Fatalf("Expected ErrBlockedDestination when dialing, got: %v", err)
+		}
+	})
+
+	t.Run("dials the validated address of an allowed network", func(t *testing.T) {
+		validator := NewURLValidatorWithConfig(&URLValidatorConfig{
+			Enabled:         true,
+			AllowedNetworks: []string{"127.0.0.0/8"},
+		})
+		validator.lookupIPAddr = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+			return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}}, nil
+		}
+
+		client := &http.Client{Transport: validator.Transport()}
+		resp, err := client.Get("http://internal.example.com:" + port + "/")
+		if err != nil {
+			t.Fatalf("Expected allowed network to be reachable, got: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	})
+}
@@ -22,6 +22,12 @@ type S3Storage struct {
 
 // NewS3Storage creates a new S3 storage client
 func NewS3Storage(region, accessKeyID, secretAccessKey string) (*S3Storage, error) {
+	return NewS3StorageWithEndpoint(region, accessKeyID, secretAccessKey, "")
+}
+
+// NewS3StorageWithEndpoint creates an S3 storage client for an S3-compatible
+// service such as MinIO or LocalStack. An empty endpoint uses AWS S3.
+func NewS3StorageWithEndpoint(region, accessKeyID, secretAccessKey, endpoint string) (*S3Storage, error) {
 	if region == "" {
 		return nil, &ValidationError{Field: "region", Message: "region is required"}
 	}
@@ -32,10 +38,17 @@ func NewS3Storage(region, accessKeyID, secretAccessKey string) (*S3Storage, erro
 		return nil, &ValidationError{Field: "secret_access_key", Message: "secret key is required"}
 	}
 
-	sess, err := session.NewSession(&aws.Config{
+	awsConfig := &aws.Config{
 		Region:      aws.String(region),
 		Credentials: credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
-	})
+	}
+	if endpoint != "" {
+		// S3-compatible services generally don't support virtual-hosted buckets
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create AWS session: %w", err)
 	}
@@ -73,6 +73,14 @@ func TestNewS3Storage(t *testing.T) {
 	}
 }
 
+func TestNewS3StorageWithEndpoint(t *testing.T) {
+	s3, err := NewS3StorageWithEndpoint("us-east-1", "test-key", "test-secret", "http://localhost:9000")
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://localhost:9000", *s3.client.Config.Endpoint)
+	assert.True(t, *s3.client.Config.S3ForcePathStyle)
+}
+
 func TestS3Storage_ValidateBucket(t *testing.T) {
 	s3, err := NewS3Storage("us-east-1", "test-key", "test-secret")
 	require.NoError(t, err)
@@ -97,10 +97,23 @@ func GetTemplatesByTag(tag string) []*Template {
 	return result
 }
 
+// channelParameter declares the Slack channel a built-in template posts to
+func channelParameter(defaultChannel string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "channel",
+		"type":        "string",
+		"default":     defaultChannel,
+		"description": "Slack channel notifications are sent to",
+	}
+}
+
 // DevOps Templates
 
 func createCICDNotificationTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#deployments"),
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -112,9 +125,11 @@ func createCICDNotificationTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "GitHub Webhook",
 					"config": map[string]interface{}{
-						"path":      "/webhooks/github-cicd",
-						"auth_type": "signature",
-						"secret":    "${env.GITHUB_WEBHOOK_SECRET}",
+						"path":               "/webhooks/github-cicd",
+						"auth_type":          "signature",
+						"signature_scheme":   "github",
+						"secret":             "${env.GITHUB_WEBHOOK_SECRET}",
+						"idempotency_header": "X-GitHub-Delivery",
 					},
 				},
 			},
@@ -165,7 +180,7 @@ func createCICDNotificationTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Send Failure Notification",
 					"config": map[string]interface{}{
-						"channel": "#deployments",
+						"channel": "${params.channel}",
 						"message": "🔴 Pipeline Failed\nRepo: ${steps.transform-1.repo}\nBranch: ${steps.transform-1.branch}\nCommit: ${steps.transform-1.commit}\nAuthor: ${steps.transform-1.author}\nURL: ${steps.transform-1.build_url}",
 					},
 				},
@@ -180,7 +195,7 @@ func createCICDNotificationTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Send Success Notification",
 					"config": map[string]interface{}{
-						"channel": "#deployments",
+						"channel": "${params.channel}",
 						"message": "✅ Pipeline Succeeded\nRepo: ${steps.transform-1.repo}\nBranch: ${steps.transform-1.branch}\nCommit: ${steps.transform-1.commit}",
 					},
 				},
@@ -230,6 +245,15 @@ func createCICDNotificationTemplate(now time.Time) *Template {
 
 func createDeploymentApprovalTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#deployments"),
+			map[string]interface{}{
+				"name":        "response_timeout",
+				"type":        "string",
+				"default":     "1h",
+				"description": "How long to wait for an approval before the request times out",
+			},
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -256,14 +280,19 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Request Approval",
 					"config": map[string]interface{}{
-						"channel": "#deployments",
-						"message": "🚀 Deployment Approval Required\nEnvironment: ${trigger.environment}\nVersion: ${trigger.version}\nRequested by: ${trigger.requester}\n\nReply with 'approve' or 'reject'",
+						"channel": "${params.channel}",
+						"message": "🚀 Deployment Approval Required\nEnvironment: ${trigger.environment}\nVersion: ${trigger.version}\nRequested by: ${trigger.requester}",
+						"buttons": []interface{}{
+							map[string]interface{}{"text": "Approve", "value": "approve", "style": "primary"},
+							map[string]interface{}{"text": "Reject", "value": "reject", "style": "danger"},
+						},
+						"response_timeout": "${params.response_timeout}",
 					},
 				},
 			},
 			map[string]interface{}{
-				"id":   "delay-1",
-				"type": "control:delay",
+				"id":   "wait-1",
+				"type": "slack:wait_for_response",
 				"position": map[string]interface{}{
 					"x": 500,
 					"y": 100,
@@ -271,7 +300,22 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Wait for Response",
 					"config": map[string]interface{}{
-						"duration": "5m",
+						"token": "${steps.slack-1.interaction_token}",
+					},
+				},
+			},
+			map[string]interface{}{
+				"id":   "if-1",
+				"type": "control:if",
+				"position": map[string]interface{}{
+					"x": 700,
+					"y": 100,
+				},
+				"data": map[string]interface{}{
+					"name": "Check Approval",
+					"config": map[string]interface{}{
+						"condition":   "${steps.wait-1.value} == 'approve'",
+						"description": "Deploy only when approved before the request times out",
 					},
 				},
 			},
@@ -279,7 +323,7 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 				"id":   "http-1",
 				"type": "action:http",
 				"position": map[string]interface{}{
-					"x": 700,
+					"x": 900,
 					"y": 100,
 				},
 				"data": map[string]interface{}{
@@ -294,7 +338,7 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 						"body": map[string]interface{}{
 							"environment": "${trigger.environment}",
 							"version":     "${trigger.version}",
-							"approved_by": "${steps.slack-1.response.user}",
+							"approved_by": "${steps.wait-1.user_name}",
 						},
 					},
 				},
@@ -309,12 +353,18 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 			map[string]interface{}{
 				"id":     "e2",
 				"source": "slack-1",
-				"target": "delay-1",
+				"target": "wait-1",
 			},
 			map[string]interface{}{
 				"id":     "e3",
-				"source": "delay-1",
+				"source": "wait-1",
+				"target": "if-1",
+			},
+			map[string]interface{}{
+				"id":     "e4",
+				"source": "if-1",
 				"target": "http-1",
+				"label":  "true",
 			},
 		},
 	}
@@ -324,7 +374,7 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 	return &Template{
 		TenantID:    nil,
 		Name:        "Deployment Approval Workflow",
-		Description: "Request deployment approval via Slack, wait for response, and trigger deployment upon approval. Includes timeout handling and notification to deployment system.",
+		Description: "Request deployment approval via Slack with Approve and Reject buttons, wait for a response, and trigger deployment upon approval. Requests time out unanswered after an hour.",
 		Category:    string(CategoryDevOps),
 		Definition:  defJSON,
 		Tags:        []string{"deployment", "approval", "slack", "devops", "automation"},
@@ -337,6 +387,15 @@ func createDeploymentApprovalTemplate(now time.Time) *Template {
 
 func createInfraAlertHandlerTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#alerts"),
+			map[string]interface{}{
+				"name":        "remediation_api_url",
+				"type":        "string",
+				"required":    true,
+				"description": "Base URL of the remediation API called for critical alerts",
+			},
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -379,7 +438,7 @@ func createInfraAlertHandlerTemplate(now time.Time) *Template {
 					"name": "Auto Remediation",
 					"config": map[string]interface{}{
 						"method": "POST",
-						"url":    "${env.REMEDIATION_API}/remediate",
+						"url":    "${params.remediation_api_url}/remediate",
 						"body": map[string]interface{}{
 							"alert_id": "${trigger.id}",
 							"action":   "restart_service",
@@ -397,7 +456,7 @@ func createInfraAlertHandlerTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Notify Team",
 					"config": map[string]interface{}{
-						"channel": "#alerts",
+						"channel": "${params.channel}",
 						"message": "⚠️ Infrastructure Alert\nSeverity: ${trigger.severity}\nService: ${trigger.service}\nMessage: ${trigger.message}\nRemediation: ${steps.http-1.status}",
 					},
 				},
@@ -764,8 +823,9 @@ func createSlackToJiraTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Slack Message",
 					"config": map[string]interface{}{
-						"path":      "/webhooks/slack-command",
-						"auth_type": "signature",
+						"path":             "/webhooks/slack-command",
+						"auth_type":        "signature",
+						"signature_scheme": "slack",
 					},
 				},
 			},
@@ -1603,6 +1663,9 @@ func createSlackAlertNotificationTemplate(now time.Time) *Template {
 
 func createGitHubPRAutomationTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#pull-requests"),
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -1614,9 +1677,11 @@ func createGitHubPRAutomationTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "GitHub PR Event",
 					"config": map[string]interface{}{
-						"path":      "/webhooks/github-pr",
-						"auth_type": "signature",
-						"secret":    "${env.GITHUB_WEBHOOK_SECRET}",
+						"path":               "/webhooks/github-pr",
+						"auth_type":          "signature",
+						"signature_scheme":   "github",
+						"secret":             "${env.GITHUB_WEBHOOK_SECRET}",
+						"idempotency_header": "X-GitHub-Delivery",
 					},
 				},
 			},
@@ -1712,7 +1777,7 @@ func createGitHubPRAutomationTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Notify Team",
 					"config": map[string]interface{}{
-						"channel": "#pull-requests",
+						"channel": "${params.channel}",
 						"message": "📝 New PR Opened\nRepo: ${steps.transform-1.repo}\nPR #${steps.transform-1.pr_number}: ${steps.transform-1.pr_title}\nAuthor: @${steps.transform-1.author}\nFiles changed: ${steps.transform-1.files_count}",
 					},
 				},
@@ -2498,7 +2563,7 @@ func createAPIHealthCheckWorkflowTemplate(now time.Time) *Template {
 			},
 			map[string]interface{}{
 				"id":     "e9",
-				"source": "condition-1",
+				"source": "transform-1",
 				"target": "http-4",
 			},
 		},
@@ -3279,13 +3344,13 @@ func createMultiStepApprovalWorkflowTemplate(now time.Time) *Template {
 				"id":     "e5",
 				"source": "condition-1",
 				"target": "email-2",
-				"label":  "approved",
+				"label":  "true",
 			},
 			map[string]interface{}{
 				"id":     "e6",
 				"source": "condition-1",
 				"target": "notify-rejected",
-				"label":  "rejected",
+				"label":  "false",
 			},
 			map[string]interface{}{
 				"id":     "e7",
@@ -3478,13 +3543,13 @@ func createErrorNotificationWorkflowTemplate(now time.Time) *Template {
 				"id":     "e4",
 				"source": "condition-1",
 				"target": "pagerduty-1",
-				"label":  "critical",
+				"label":  "true",
 			},
 			map[string]interface{}{
 				"id":     "e5",
 				"source": "condition-1",
 				"target": "slack-normal",
-				"label":  "normal",
+				"label":  "false",
 			},
 			map[string]interface{}{
 				"id":     "e6",
@@ -3595,24 +3660,18 @@ func createAPIOrchestrationWorkflowTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Aggregate Data",
 					"config": map[string]interface{}{
-						"mapping": map[string]interface{}{
-							"user": map[string]interface{}{
-								"id":    "${steps.api-1.id}",
-								"name":  "${steps.api-1.name}",
-								"email": "${steps.api-1.email}",
-								"tier":  "${steps.api-1.tier}",
-							},
-							"account": map[string]interface{}{
-								"id":      "${steps.api-2.id}",
-								"balance": "${steps.api-2.balance}",
-								"status":  "${steps.api-2.status}",
-							},
-							"transactions": map[string]interface{}{
-								"recent":       "${steps.api-3.transactions}",
-								"total_count":  "${steps.api-3.total_count}",
-								"total_amount": "${steps.api-3.transactions.reduce((sum, t) => sum + t.amount, 0)}",
-							},
-						},
+						"transform_type": "expr",
+						"expression": `{
+							"user": {"id": steps.api-1.id, "name": steps.api-1.name, "email": steps.api-1.email, "tier": steps.api-1.tier},
+							"account": {"id": steps.api-2.id, "balance": steps.api-2.balance, "status": steps.api-2.status},
+							"transactions": {
+								"recent": steps.api-3.transactions,
+								"total_count": steps.api-3.total_count,
+								"total_amount": sum(map(steps.api-3.transactions, .amount)),
+								"large": filter(steps.api-3.transactions, .amount >= 1000),
+								"by_category": groupBy(steps.api-3.transactions, .category)
+							}
+						}`,
 					},
 				},
 			},
@@ -3757,6 +3816,15 @@ func createAPIOrchestrationWorkflowTemplate(now time.Time) *Template {
 
 func createKubernetesDeploymentMonitorTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#k8s-alerts"),
+			map[string]interface{}{
+				"name":        "namespace",
+				"type":        "string",
+				"default":     "default",
+				"description": "Kubernetes namespace whose deployments are checked",
+			},
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -3783,7 +3851,7 @@ func createKubernetesDeploymentMonitorTemplate(now time.Time) *Template {
 					"name": "Get Deployments",
 					"config": map[string]interface{}{
 						"method":  "GET",
-						"url":     "${env.K8S_API_URL}/apis/apps/v1/namespaces/${env.K8S_NAMESPACE}/deployments",
+						"url":     "${env.K8S_API_URL}/apis/apps/v1/namespaces/${params.namespace}/deployments",
 						"headers": map[string]interface{}{"Authorization": "Bearer ${credentials.k8s_token}"},
 					},
 				},
@@ -3828,8 +3896,8 @@ func createKubernetesDeploymentMonitorTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Alert Unhealthy Deployments",
 					"config": map[string]interface{}{
-						"channel": "#k8s-alerts",
-						"message": "⚠️ Unhealthy Deployments Detected\n\nNamespace: ${env.K8S_NAMESPACE}\nUnhealthy: ${len(steps.transform-1.unhealthy_deployments)} deployment(s)\n\nCheck cluster status immediately.",
+						"channel": "${params.channel}",
+						"message": "⚠️ Unhealthy Deployments Detected\n\nNamespace: ${params.namespace}\nUnhealthy: ${len(steps.transform-1.unhealthy_deployments)} deployment(s)\n\nCheck cluster status immediately.",
 					},
 				},
 			},
@@ -3860,6 +3928,15 @@ func createKubernetesDeploymentMonitorTemplate(now time.Time) *Template {
 
 func createContainerRegistryCleanupTemplate(now time.Time) *Template {
 	definition := map[string]interface{}{
+		"parameters": []interface{}{
+			channelParameter("#devops"),
+			map[string]interface{}{
+				"name":        "schedule",
+				"type":        "string",
+				"default":     "0 2 * * 0",
+				"description": "Cron schedule for the cleanup",
+			},
+		},
 		"nodes": []interface{}{
 			map[string]interface{}{
 				"id":   "trigger-1",
@@ -3871,7 +3948,7 @@ func createContainerRegistryCleanupTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Weekly Cleanup",
 					"config": map[string]interface{}{
-						"cron": "0 2 * * 0",
+						"cron": "${params.schedule}",
 					},
 				},
 			},
@@ -3948,7 +4025,7 @@ func createContainerRegistryCleanupTemplate(now time.Time) *Template {
 				"data": map[string]interface{}{
 					"name": "Report Cleanup",
 					"config": map[string]interface{}{
-						"channel": "#devops",
+						"channel": "${params.channel}",
 						"message": "🧹 Container Registry Cleanup Complete\n\nProcessed: ${len(steps.api-1.body.repositories)} repositories\nTags identified for cleanup: ${len(steps.transform-1.old_tags)}",
 					},
 				},
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/workflow"
 )
 
 func TestGetBuiltinTemplates(t *testing.T) {
@@ -66,6 +68,17 @@ func TestDevOpsTemplates(t *testing.T) {
 
 		assert.Contains(t, tmpl.Tags, "approval")
 		assert.Contains(t, tmpl.Tags, "deployment")
+
+		var def workflow.WorkflowDefinition
+		require.NoError(t, json.Unmarshal(tmpl.Definition, &def))
+		waits := 0
+		for _, node := range def.Nodes {
+			if node.Type == string(workflow.NodeTypeActionSlackWaitForResponse) {
+				waits++
+				assert.Contains(t, string(node.Data.Config), "${steps.slack-1.interaction_token}")
+			}
+		}
+		assert.Equal(t, 1, waits, "approval should wait for the Slack buttons")
 	})
 
 	t.Run("Infrastructure Alert Handler", func(t *testing.T) {
@@ -232,6 +245,55 @@ func TestTemplateDefinitionStructure(t *testing.T) {
 	}
 }
 
+func TestTemplateDefinitionGraph(t *testing.T) {
+	for _, tmpl := range GetBuiltinTemplates() {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			var def workflow.WorkflowDefinition
+			require.NoError(t, json.Unmarshal(tmpl.Definition, &def))
+
+			issues := workflow.DefinitionValidator{}.Validate(&def)
+
+			assert.Empty(t, issues, "workflows created from the template would be rejected")
+		})
+	}
+}
+
+func TestBuiltinTemplateParameters(t *testing.T) {
+	for _, tmpl := range GetBuiltinTemplates() {
+		t.Run(tmpl.Name, func(t *testing.T) {
+			require.NoError(t, ValidateParameters(tmpl.Definition))
+
+			params, err := ParseParameters(tmpl.Definition)
+			require.NoError(t, err)
+
+			values := map[string]interface{}{}
+			for _, param := range params {
+				if param.Required {
+					values[param.Name] = "https://example.com"
+				}
+			}
+
+			definition, err := ApplyParameters(tmpl.Definition, values)
+			require.NoError(t, err)
+			assert.NotContains(t, string(definition), "params.", "every parameter reference should be substituted")
+
+			var def workflow.WorkflowDefinition
+			require.NoError(t, json.Unmarshal(definition, &def))
+			assert.Empty(t, workflow.DefinitionValidator{}.Validate(&def))
+		})
+	}
+
+	t.Run("channel is configurable", func(t *testing.T) {
+		tmpl := GetTemplateByName("CI/CD Pipeline Notification")
+		require.NotNil(t, tmpl)
+
+		definition, err := ApplyParameters(tmpl.Definition, map[string]interface{}{"channel": "#releases"})
+		require.NoError(t, err)
+		assert.Contains(t, string(definition), `"channel":"#releases"`)
+		assert.NotContains(t, string(definition), "#deployments")
+	})
+}
+
 func TestGetTemplateByName(t *testing.T) {
 	t.Run("existing template", func(t *testing.T) {
 		tmpl := GetTemplateByName("CI/CD Pipeline Notification")
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // Template represents a reusable workflow pattern
@@ -50,6 +52,8 @@ type TemplateFilter struct {
 	Tags        []string `json:"tags,omitempty"`
 	IsPublic    *bool    `json:"is_public,omitempty"`
 	SearchQuery string   `json:"search_query,omitempty"`
+	// Page selects a page by cursor; the zero value lists every match
+	Page pagination.Params `json:"-"`
 }
 
 // TemplateCategory represents template categories
@@ -0,0 +1,284 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParameterType is the type of value a template parameter accepts
+type ParameterType string
+
+const (
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeNumber  ParameterType = "number"
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// Parameter declares a configurable input of a template. Parameters are listed
+// under "parameters" in the template definition and referenced from node
+// configs as ${params.name} or {{params.name}}.
+type Parameter struct {
+	Name        string        `json:"name"`
+	Type        ParameterType `json:"type"`
+	Default     interface{}   `json:"default,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Description string        `json:"description,omitempty"`
+}
+
+// ErrInvalidParameters is returned when a template's parameter declarations, or
+// the values supplied for them, are invalid
+var ErrInvalidParameters = errors.New("invalid template parameters")
+
+var (
+	// parameterRefRegex matches ${params.name} and {{params.name}} references
+	parameterRefRegex = regexp.MustCompile(`\$\{\s*params\.([a-zA-Z0-9_]+)\s*\}|\{\{\s*params\.([a-zA-Z0-9_]+)\s*\}\}`)
+	// parameterNameRegex is the form a parameter name must take
+	parameterNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+)
+
+// ParseParameters returns the parameters declared by a template definition
+func ParseParameters(definition json.RawMessage) ([]Parameter, error) {
+	var def struct {
+		Parameters []Parameter `json:"parameters"`
+	}
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+	return def.Parameters, nil
+}
+
+// ValidateParameters checks a definition's parameter declarations and that
+// every parameter it references is declared
+func ValidateParameters(definition json.RawMessage) error {
+	params, err := ParseParameters(definition)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, len(params))
+	for _, param := range params {
+		if !parameterNameRegex.MatchString(param.Name) {
+			return fmt.Errorf("%w: parameter name %q must start with a letter and contain only letters, digits and underscores", ErrInvalidParameters, param.Name)
+		}
+		if declared[param.Name] {
+			return fmt.Errorf("%w: parameter %q is declared more than once", ErrInvalidParameters, param.Name)
+		}
+		declared[param.Name] = true
+
+		if !param.Type.Valid() {
+			return fmt.Errorf("%w: parameter %q has unsupported type %q", ErrInvalidParameters, param.Name, param.Type)
+		}
+		if param.Default != nil {
+			if param.Required {
+				return fmt.Errorf("%w: required parameter %q cannot have a default", ErrInvalidParameters, param.Name)
+			}
+			if !param.Type.accepts(param.Default) {
+				return fmt.Errorf("%w: default for parameter %q must be a %s", ErrInvalidParameters, param.Name, param.Type)
+			}
+		}
+	}
+
+	for _, name := range parameterReferences(definition) {
+		if !declared[name] {
+			return fmt.Errorf("%w: parameter %q is referenced but not declared", ErrInvalidParameters, name)
+		}
+	}
+
+	return nil
+}
+
+// ApplyParameters substitutes parameter values into a template definition and
+// returns the definition without its parameter declarations. Parameters that
+// are not supplied take their default; required parameters must be supplied.
+// A definition without parameters is returned unchanged.
+func ApplyParameters(definition json.RawMessage, values map[string]interface{}) (json.RawMessage, error) {
+	result, missing, err := applyParameters(definition, values)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing required parameters: %s", ErrInvalidParameters, strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// ApplyParametersPartial is ApplyParameters for definitions that will be
+// finished by hand: references to required parameters that are not supplied
+// are left in place, and their names are returned.
+func ApplyParametersPartial(definition json.RawMessage, values map[string]interface{}) (json.RawMessage, []string, error) {
+	return applyParameters(definition, values)
+}
+
+// applyParameters substitutes the supplied values and defaults and returns
+// the names of required parameters that were not supplied
+func applyParameters(definition json.RawMessage, values map[string]interface{}) (json.RawMessage, []string, error) {
+	params, err := ParseParameters(definition)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(params) == 0 && len(values) == 0 {
+		return definition, nil, nil
+	}
+
+	resolved, missing, err := resolveParameters(params, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var def map[string]interface{}
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+	delete(def, "parameters")
+
+	substituted, err := json.Marshal(substituteParameters(def, resolved))
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal definition: %w", err)
+	}
+	return substituted, missing, nil
+}
+
+// Valid reports whether t is a supported parameter type
+func (t ParameterType) Valid() bool {
+	switch t {
+	case ParameterTypeString, ParameterTypeNumber, ParameterTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// accepts reports whether a decoded JSON value is of type t
+func (t ParameterType) accepts(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return t == ParameterTypeString
+	case float64:
+		return t == ParameterTypeNumber
+	case bool:
+		return t == ParameterTypeBoolean
+	}
+	return false
+}
+
+// zero returns the value an optional parameter without a default takes
+func (t ParameterType) zero() interface{} {
+	switch t {
+	case ParameterTypeNumber:
+		return float64(0)
+	case ParameterTypeBoolean:
+		return false
+	}
+	return ""
+}
+
+// resolveParameters checks the supplied values against the declarations and
+// returns the value of every parameter, along with the names of required
+// parameters that were not supplied
+func resolveParameters(params []Parameter, values map[string]interface{}) (map[string]interface{}, []string, error) {
+	declared := make(map[string]Parameter, len(params))
+	for _, param := range params {
+		declared[param.Name] = param
+	}
+
+	unknown := []string{}
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, nil, fmt.Errorf("%w: unknown parameters: %s", ErrInvalidParameters, strings.Join(unknown, ", "))
+	}
+
+	resolved := make(map[string]interface{}, len(params))
+	missing := []string{}
+	for _, param := range params {
+		value, ok := values[param.Name]
+		switch {
+		case ok && value != nil:
+			if !param.Type.accepts(value) {
+				return nil, nil, fmt.Errorf("%w: parameter %q must be a %s", ErrInvalidParameters, param.Name, param.Type)
+			}
+			resolved[param.Name] = value
+		case param.Required:
+			missing = append(missing, param.Name)
+		case param.Default != nil:
+			resolved[param.Name] = param.Default
+		default:
+			resolved[param.Name] = param.Type.zero()
+		}
+	}
+
+	return resolved, missing, nil
+}
+
+// substituteParameters replaces parameter references in every string of a
+// decoded definition. A string that is a single reference takes the
+// parameter's value and type; otherwise the value is formatted into the string.
+func substituteParameters(value interface{}, params map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = substituteParameters(item, params)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = substituteParameters(item, params)
+		}
+		return v
+	case string:
+		if match := parameterRefRegex.FindStringSubmatch(v); match != nil && match[0] == v {
+			if param, ok := params[referenceName(match)]; ok {
+				return param
+			}
+			return v
+		}
+		return parameterRefRegex.ReplaceAllStringFunc(v, func(ref string) string {
+			param, ok := params[referenceName(parameterRefRegex.FindStringSubmatch(ref))]
+			if !ok {
+				return ref
+			}
+			return formatParameter(param)
+		})
+	}
+	return value
+}
+
+// parameterReferences returns the names of the parameters a definition
+// references, sorted and without duplicates
+func parameterReferences(definition json.RawMessage) []string {
+	names := []string{}
+	for _, match := range parameterRefRegex.FindAllStringSubmatch(string(definition), -1) {
+		names = append(names, referenceName(match))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// referenceName returns the parameter name captured by either form of reference
+func referenceName(match []string) string {
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
+}
+
+// formatParameter formats a parameter value for use inside a string
+func formatParameter(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,192 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		wantError  string
+	}{
+		{
+			name:       "no parameters",
+			definition: `{"nodes": [], "edges": []}`,
+		},
+		{
+			name: "valid declarations",
+			definition: `{
+				"parameters": [
+					{"name": "channel", "type": "string", "default": "#ops"},
+					{"name": "threshold", "type": "number", "default": 5},
+					{"name": "notify", "type": "boolean"},
+					{"name": "env", "type": "string", "required": true}
+				],
+				"nodes": [{"config": {"channel": "${params.channel}", "env": "{{ params.env }}", "max": "${params.threshold}"}}],
+				"edges": []
+			}`,
+		},
+		{
+			name:       "invalid name",
+			definition: `{"parameters": [{"name": "1channel", "type": "string"}]}`,
+			wantError:  `parameter name "1channel"`,
+		},
+		{
+			name:       "duplicate name",
+			definition: `{"parameters": [{"name": "channel", "type": "string"}, {"name": "channel", "type": "string"}]}`,
+			wantError:  `parameter "channel" is declared more than once`,
+		},
+		{
+			name:       "unsupported type",
+			definition: `{"parameters": [{"name": "channels", "type": "array"}]}`,
+			wantError:  `unsupported type "array"`,
+		},
+		{
+			name:       "default of wrong type",
+			definition: `{"parameters": [{"name": "threshold", "type": "number", "default": "5"}]}`,
+			wantError:  `default for parameter "threshold" must be a number`,
+		},
+		{
+			name:       "required with default",
+			definition: `{"parameters": [{"name": "env", "type": "string", "required": true, "default": "prod"}]}`,
+			wantError:  `required parameter "env" cannot have a default`,
+		},
+		{
+			name:       "undeclared reference",
+			definition: `{"nodes": [{"config": {"channel": "${params.channel}"}}], "edges": []}`,
+			wantError:  `parameter "channel" is referenced but not declared`,
+		},
+		{
+			name:       "parameters not a list",
+			definition: `{"parameters": {"name": "channel"}}`,
+			wantError:  "invalid template parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateParameters(json.RawMessage(tt.definition))
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidParameters)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestApplyParameters(t *testing.T) {
+	definition := json.RawMessage(`{
+		"parameters": [
+			{"name": "channel", "type": "string", "default": "#deployments"},
+			{"name": "env", "type": "string", "required": true},
+			{"name": "retries", "type": "number", "default": 3},
+			{"name": "dry_run", "type": "boolean"}
+		],
+		"nodes": [{
+			"id": "slack-1",
+			"data": {"config": {
+				"channel": "${params.channel}",
+				"message": "Deployed to {{params.env}} after ${params.retries} retries (${trigger.version})",
+				"retries": "${params.retries}",
+				"dry_run": "${params.dry_run}",
+				"tags": ["${params.env}", "static"]
+			}}
+		}],
+		"edges": []
+	}`)
+
+	t.Run("substitutes values and defaults", func(t *testing.T) {
+		result, err := ApplyParameters(definition, map[string]interface{}{
+			"env":     "staging",
+			"dry_run": true,
+		})
+		require.NoError(t, err)
+
+		var def map[string]interface{}
+		require.NoError(t, json.Unmarshal(result, &def))
+
+		assert.NotContains(t, def, "parameters")
+
+		config := def["nodes"].([]interface{})[0].(map[string]interface{})["data"].(map[string]interface{})["config"].(map[string]interface{})
+		assert.Equal(t, "#deployments", config["channel"])
+		assert.Equal(t, "Deployed to staging after 3 retries (${trigger.version})", config["message"])
+		assert.Equal(t, float64(3), config["retries"], "a whole-string reference keeps the parameter's type")
+		assert.Equal(t, true, config["dry_run"])
+		assert.Equal(t, []interface{}{"staging", "static"}, config["tags"])
+	})
+
+	t.Run("optional parameter without default takes zero value", func(t *testing.T) {
+		result, err := ApplyParameters(definition, map[string]interface{}{"env": "prod"})
+		require.NoError(t, err)
+		assert.Contains(t, string(result), `"dry_run":false`)
+	})
+
+	t.Run("missing required parameter", func(t *testing.T) {
+		_, err := ApplyParameters(definition, map[string]interface{}{"channel": "#ops"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+		assert.Contains(t, err.Error(), "missing required parameters: env")
+	})
+
+	t.Run("null does not satisfy a required parameter", func(t *testing.T) {
+		_, err := ApplyParameters(definition, map[string]interface{}{"env": nil})
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+
+	t.Run("unknown parameter", func(t *testing.T) {
+		_, err := ApplyParameters(definition, map[string]interface{}{"env": "prod", "region": "eu", "cluster": "a"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+		assert.Contains(t, err.Error(), "unknown parameters: cluster, region")
+	})
+
+	t.Run("value of wrong type", func(t *testing.T) {
+		_, err := ApplyParameters(definition, map[string]interface{}{"env": "prod", "retries": "three"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+		assert.Contains(t, err.Error(), `parameter "retries" must be a number`)
+	})
+
+	t.Run("definition without parameters is unchanged", func(t *testing.T) {
+		plain := json.RawMessage(`{"nodes": [{"id": "1"}], "edges": []}`)
+
+		result, err := ApplyParameters(plain, nil)
+		require.NoError(t, err)
+		assert.Equal(t, plain, result)
+
+		_, err = ApplyParameters(plain, map[string]interface{}{"channel": "#ops"})
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
+
+func TestApplyParametersPartial(t *testing.T) {
+	definition := json.RawMessage(`{
+		"parameters": [
+			{"name": "channel", "type": "string", "default": "#deployments"},
+			{"name": "env", "type": "string", "required": true},
+			{"name": "region", "type": "string", "required": true}
+		],
+		"nodes": [{"id": "1", "data": {"config": {"channel": "${params.channel}", "message": "Deployed to {{params.env}} in ${params.region}"}}}],
+		"edges": []
+	}`)
+
+	t.Run("leaves missing required parameters unresolved", func(t *testing.T) {
+		result, missing, err := ApplyParametersPartial(definition, map[string]interface{}{"region": "eu"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"env"}, missing)
+		assert.JSONEq(t, `{"edges":[],"nodes":[{"id":"1","data":{"config":{"channel":"#deployments","message":"Deployed to {{params.env}} in eu"}}}]}`, string(result))
+	})
+
+	t.Run("still rejects invalid values", func(t *testing.T) {
+		_, _, err := ApplyParametersPartial(definition, map[string]interface{}{"env": 5})
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
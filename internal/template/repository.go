@@ -121,7 +121,12 @@ func (r *PostgresRepository) List(ctx context.Context, tenantID string, filter T
 		args = append(args, searchPattern)
 	}
 
-	query += " ORDER BY created_at DESC"
+	keyset, limitClause, args := filter.Page.Keyset("", args)
+	if keyset != "" {
+		query += " AND " + keyset
+	}
+
+	query += " ORDER BY created_at DESC, id DESC" + limitClause
 
 	var templates []*Template
 	err := r.db.SelectContext(ctx, &templates, query, args...)
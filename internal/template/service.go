@@ -172,6 +172,8 @@ func (s *Service) CreateFromWorkflow(ctx context.Context, tenantID, userID strin
 // InstantiateTemplateInput represents input for instantiating a template
 type InstantiateTemplateInput struct {
 	WorkflowName string `json:"workflow_name" validate:"required"`
+	// Parameters are the values for the template's declared parameters
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
 }
 
 // InstantiateTemplateResult represents the result of template instantiation
@@ -191,6 +193,11 @@ func (s *Service) InstantiateTemplate(ctx context.Context, tenantID, templateID
 		return nil, fmt.Errorf("get template: %w", err)
 	}
 
+	definition, err := ApplyParameters(template.Definition, input.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
 	// Increment usage count
 	if err := s.repo.IncrementUsageCount(ctx, templateID); err != nil {
 		s.logger.Warn("failed to increment usage count",
@@ -201,7 +208,7 @@ func (s *Service) InstantiateTemplate(ctx context.Context, tenantID, templateID
 
 	result := &InstantiateTemplateResult{
 		WorkflowName: input.WorkflowName,
-		Definition:   template.Definition,
+		Definition:   definition,
 	}
 
 	s.logger.Info("template instantiated",
@@ -231,5 +238,5 @@ func (s *Service) validateDefinition(definition json.RawMessage) error {
 		return errors.New("definition must contain 'edges' field")
 	}
 
-	return nil
+	return ValidateParameters(definition)
 }
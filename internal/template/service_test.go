@@ -313,6 +313,49 @@ func TestService_InstantiateTemplate(t *testing.T) {
 	assert.NotEmpty(t, result.Definition)
 }
 
+func TestService_InstantiateTemplate_Parameters(t *testing.T) {
+	repo := &MockRepository{}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewService(repo, logger)
+
+	ctx := context.Background()
+	tenantID := "test-tenant-123"
+
+	created, err := service.CreateTemplate(ctx, tenantID, "user-123", CreateTemplateInput{
+		Name:     "Deploy Notice",
+		Category: "devops",
+		Definition: json.RawMessage(`{
+			"parameters": [
+				{"name": "channel", "type": "string", "default": "#deployments"},
+				{"name": "env", "type": "string", "required": true}
+			],
+			"nodes": [{"id": "1", "type": "slack:send_message", "data": {"name": "Notify", "config": {"channel": "${params.channel}", "message": "Deployed to ${params.env}"}}}],
+			"edges": []
+		}`),
+	})
+	require.NoError(t, err)
+
+	t.Run("substitutes parameter values", func(t *testing.T) {
+		result, err := service.InstantiateTemplate(ctx, tenantID, created.ID, InstantiateTemplateInput{
+			WorkflowName: "Deploys",
+			Parameters:   map[string]interface{}{"env": "staging"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"nodes": [{"id": "1", "type": "slack:send_message", "data": {"name": "Notify", "config": {"channel": "#deployments", "message": "Deployed to staging"}}}],
+			"edges": []
+		}`, string(result.Definition))
+	})
+
+	t.Run("rejects missing required parameters", func(t *testing.T) {
+		_, err := service.InstantiateTemplate(ctx, tenantID, created.ID, InstantiateTemplateInput{
+			WorkflowName: "Deploys",
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
+
 func TestService_InstantiateTemplate_NotFound(t *testing.T) {
 	repo := &MockRepository{}
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -364,6 +407,11 @@ func TestService_ValidateDefinition(t *testing.T) {
 			definition: json.RawMessage(`{"nodes":[]}`),
 			wantError:  true,
 		},
+		{
+			name:       "undeclared parameter reference",
+			definition: json.RawMessage(`{"nodes":[{"data":{"config":{"channel":"${params.channel}"}}}],"edges":[]}`),
+			wantError:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
 )
 
 // TenantStatus represents the status of a tenant
@@ -62,8 +64,20 @@ type Tenant struct {
 	Tier      string          `db:"tier" json:"tier"`
 	Settings  json.RawMessage `db:"settings" json:"settings"`
 	Quotas    json.RawMessage `db:"quotas" json:"quotas"`
-	CreatedAt time.Time       `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time       `db:"updated_at" json:"updated_at"`
+	KMSKeyID  *string         `db:"kms_key_id" json:"kms_key_id,omitempty"`
+	// Region is the data region the tenant's credentials and executions are
+	// stored in; nil means the platform defaults
+	Region    *string   `db:"region" json:"region,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// DataRegion returns the tenant's data region, or "" for the platform defaults
+func (t *Tenant) DataRegion() string {
+	if t.Region == nil {
+		return ""
+	}
+	return *t.Region
 }
 
 // IsActive returns true if the tenant status is active
@@ -103,6 +117,9 @@ func (t *Tenant) GetSettings() (*TenantSettings, error) {
 type TenantSettings struct {
 	DefaultTimezone string `json:"default_timezone"`
 	WebhookSecret   string `json:"webhook_secret"`
+	// WorkflowAutoPause is the default failure-rate auto-pause policy for the
+	// tenant's workflows; individual workflows may override it
+	WorkflowAutoPause *workflow.AutoPauseConfig `json:"workflow_auto_pause,omitempty"`
 }
 
 // TenantQuotas holds tenant resource quotas
@@ -153,6 +170,8 @@ type CreateTenantInput struct {
 	Name      string `json:"name" validate:"required,min=2,max=100"`
 	Subdomain string `json:"subdomain" validate:"required,min=3,max=63,alphanum"`
 	Tier      string `json:"tier" validate:"oneof=free professional enterprise"`
+	// Region pins the tenant's data to a configured data region
+	Region string `json:"region,omitempty" validate:"omitempty,max=64"`
 }
 
 // UpdateTenantInput represents input for updating a tenant
@@ -161,6 +180,9 @@ type UpdateTenantInput struct {
 	Status   string          `json:"status,omitempty"`
 	Tier     string          `json:"tier,omitempty"`
 	Settings json.RawMessage `json:"settings,omitempty"`
+	// Region moves new data to another data region when set; "" returns the
+	// tenant to the platform defaults. Existing data is not migrated.
+	Region *string `json:"region,omitempty" validate:"omitempty,max=64"`
 }
 
 // UsageStats represents current usage statistics for a tenant
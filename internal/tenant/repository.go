@@ -48,15 +48,15 @@ func (r *Repository) Create(ctx context.Context, input CreateTenantInput) (*Tena
 	}
 
 	query := `
-		INSERT INTO tenants (id, name, subdomain, status, tier, settings, quotas, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, name, subdomain, status, tier, settings, quotas, created_at, updated_at
+		INSERT INTO tenants (id, name, subdomain, status, tier, settings, quotas, region, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)
+		RETURNING id, name, subdomain, status, tier, settings, quotas, region, created_at, updated_at
 	`
 
 	var tenant Tenant
 	err = r.db.QueryRowxContext(
 		ctx, query,
-		id, input.Name, input.Subdomain, "active", input.Tier, settingsJSON, quotasJSON, now, now,
+		id, input.Name, input.Subdomain, "active", input.Tier, settingsJSON, quotasJSON, input.Region, now, now,
 	).StructScan(&tenant)
 
 	if err != nil {
@@ -106,15 +106,21 @@ func (r *Repository) Update(ctx context.Context, id string, input UpdateTenantIn
 		    status = COALESCE(NULLIF($3, ''), status),
 		    tier = COALESCE(NULLIF($4, ''), tier),
 		    settings = COALESCE($5, settings),
+		    region = CASE WHEN $7::boolean THEN NULLIF($8, '') ELSE region END,
 		    updated_at = $6
 		WHERE id = $1
 		RETURNING *
 	`
 
+	region := ""
+	if input.Region != nil {
+		region = *input.Region
+	}
+
 	var tenant Tenant
 	err := r.db.QueryRowxContext(
 		ctx, query,
-		id, input.Name, input.Status, input.Tier, input.Settings, time.Now(),
+		id, input.Name, input.Status, input.Tier, input.Settings, time.Now(), input.Region != nil, region,
 	).StructScan(&tenant)
 
 	if err != nil {
@@ -255,3 +261,17 @@ func (r *Repository) GetConcurrentExecutions(ctx context.Context, tenantID strin
 
 	return count, nil
 }
+
+// GetTenantRegion returns a tenant's data region, or "" when the tenant uses
+// the platform defaults
+func (r *Repository) GetTenantRegion(ctx context.Context, tenantID string) (string, error) {
+	var region sql.NullString
+	err := r.db.GetContext(ctx, &region, `SELECT region FROM tenants WHERE id = $1`, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return region.String, nil
+}
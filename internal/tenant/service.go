@@ -2,16 +2,22 @@ package tenant
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/gorax/gorax/internal/pkg/tenantctx"
 )
 
+// ErrUnknownRegion is returned when a tenant is assigned a data region that
+// is not configured
+var ErrUnknownRegion = errors.New("data region is not configured")
+
 // Service handles tenant business logic
 type Service struct {
-	repo   *Repository
-	logger *slog.Logger
+	repo    *Repository
+	logger  *slog.Logger
+	regions map[string]bool
 }
 
 // NewService creates a new tenant service
@@ -22,6 +28,23 @@ func NewService(repo *Repository, logger *slog.Logger) *Service {
 	}
 }
 
+// SetDataRegions sets the data regions tenants can be assigned. Without
+// them, tenants cannot be given a region.
+func (s *Service) SetDataRegions(names []string) {
+	s.regions = make(map[string]bool, len(names))
+	for _, name := range names {
+		s.regions[name] = true
+	}
+}
+
+// checkRegion rejects data regions that are not configured
+func (s *Service) checkRegion(region string) error {
+	if region != "" && !s.regions[region] {
+		return fmt.Errorf("%w: %s", ErrUnknownRegion, region)
+	}
+	return nil
+}
+
 // Create creates a new tenant
 func (s *Service) Create(ctx context.Context, input CreateTenantInput) (*Tenant, error) {
 	// Set default tier if not specified
@@ -29,6 +52,10 @@ func (s *Service) Create(ctx context.Context, input CreateTenantInput) (*Tenant,
 		input.Tier = "free"
 	}
 
+	if err := s.checkRegion(input.Region); err != nil {
+		return nil, err
+	}
+
 	tenant, err := s.repo.Create(ctx, input)
 	if err != nil {
 		s.logger.Error("failed to create tenant", "error", err, "name", input.Name)
@@ -51,6 +78,12 @@ func (s *Service) GetBySubdomain(ctx context.Context, subdomain string) (*Tenant
 
 // Update updates a tenant
 func (s *Service) Update(ctx context.Context, id string, input UpdateTenantInput) (*Tenant, error) {
+	if input.Region != nil {
+		if err := s.checkRegion(*input.Region); err != nil {
+			return nil, err
+		}
+	}
+
 	tenant, err := s.repo.Update(ctx, id, input)
 	if err != nil {
 		s.logger.Error("failed to update tenant", "error", err, "tenant_id", id)
@@ -2,6 +2,9 @@ package tenant
 
 import (
 	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"testing"
 )
 
@@ -67,3 +70,26 @@ func TestTenantScoped(t *testing.T) {
 		t.Errorf("Expected tenant_id = %s, got %v", tenantID, val)
 	}
 }
+
+func TestService_RejectsUnknownRegion(t *testing.T) {
+	service := NewService(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	service.SetDataRegions([]string{"eu"})
+
+	_, err := service.Create(context.Background(), CreateTenantInput{Name: "Acme", Subdomain: "acme", Region: "ap"})
+	if !errors.Is(err, ErrUnknownRegion) {
+		t.Errorf("Create error = %v, want ErrUnknownRegion", err)
+	}
+
+	region := "us"
+	_, err = service.Update(context.Background(), "tenant-1", UpdateTenantInput{Region: &region})
+	if !errors.Is(err, ErrUnknownRegion) {
+		t.Errorf("Update error = %v, want ErrUnknownRegion", err)
+	}
+
+	if err := service.checkRegion("eu"); err != nil {
+		t.Errorf("checkRegion(eu) = %v, want nil", err)
+	}
+	if err := service.checkRegion(""); err != nil {
+		t.Errorf("checkRegion(\"\") = %v, want nil", err)
+	}
+}
@@ -35,9 +35,9 @@ func TraceWorkflowExecution(ctx context.Context, tenantID, workflowID, execution
 	return nil
 }
 
-// TraceStepExecution wraps a step execution with tracing
+// TraceStepExecution wraps a step execution with a span named after the node type
 func TraceStepExecution(ctx context.Context, tenantID, workflowID, executionID, nodeID, nodeType string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
-	ctx, span := StartSpan(ctx, "workflow.step.execute")
+	ctx, span := StartSpan(ctx, "workflow.step."+nodeType)
 	defer span.End()
 
 	span.SetAttributes(
@@ -108,7 +108,7 @@ func TestTraceStepExecution_Success(t *testing.T) {
 
 	spans := exporter.GetSpans()
 	assert.Len(t, spans, 1)
-	assert.Equal(t, "workflow.step.execute", spans[0].Name)
+	assert.Equal(t, "workflow.step.action.http", spans[0].Name)
 	assert.Equal(t, codes.Ok, spans[0].Status.Code)
 }
 
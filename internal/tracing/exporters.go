@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/gorax/gorax/internal/config"
+)
+
+// Exporter types accepted in config.TracingExporterConfig
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Signals a trace can be started by, used as keys of per-signal sample rates
+const (
+	// SignalHTTP is an API request
+	SignalHTTP = "http"
+	// SignalWebhook is a webhook delivery
+	SignalWebhook = "webhook"
+	// SignalWorkflow is a workflow execution run by a worker
+	SignalWorkflow = "workflow"
+	// SignalQueue is a queue message processed by a worker
+	SignalQueue = "queue"
+)
+
+var knownSignals = []string{SignalHTTP, SignalWebhook, SignalWorkflow, SignalQueue}
+
+// tracingExporters returns the configured exporters, or the OTLP collector
+// at TracingEndpoint when none are listed
+func tracingExporters(cfg *config.ObservabilityConfig) []config.TracingExporterConfig {
+	if len(cfg.TracingExporters) > 0 {
+		return cfg.TracingExporters
+	}
+	return []config.TracingExporterConfig{{
+		Name:     ExporterOTLP,
+		Type:     ExporterOTLP,
+		Endpoint: cfg.TracingEndpoint,
+		Insecure: true,
+	}}
+}
+
+// ExporterNames returns the names of the exporters spans are sent to, for
+// logging without exposing endpoints' headers
+func ExporterNames(cfg *config.ObservabilityConfig) []string {
+	var names []string
+	for _, exporter := range tracingExporters(cfg) {
+		names = append(names, exporter.Name)
+	}
+	return names
+}
+
+// validateTracingConfig reports every problem with the exporters and sample
+// rates, so a misconfigured deployment fails at startup instead of silently
+// dropping spans
+func validateTracingConfig(cfg *config.ObservabilityConfig) error {
+	var errs []error
+
+	seen := make(map[string]bool)
+	for _, exporter := range tracingExporters(cfg) {
+		if seen[exporter.Name] {
+			errs = append(errs, fmt.Errorf("tracing exporter %q is listed more than once", exporter.Name))
+			continue
+		}
+		seen[exporter.Name] = true
+
+		switch exporter.Type {
+		case ExporterOTLP:
+			if exporter.Endpoint == "" {
+				errs = append(errs, fmt.Errorf("tracing exporter %q: otlp exporters need an endpoint", exporter.Name))
+			}
+		case ExporterStdout:
+		default:
+			errs = append(errs, fmt.Errorf("tracing exporter %q: unknown type %q (must be %s or %s)", exporter.Name, exporter.Type, ExporterOTLP, ExporterStdout))
+		}
+	}
+
+	if cfg.TracingSampleRate < 0 || cfg.TracingSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("tracing sample rate must be between 0.0 and 1.0, got %g", cfg.TracingSampleRate))
+	}
+	for signal, rate := range cfg.TracingSampleRates {
+		if !slices.Contains(knownSignals, signal) {
+			errs = append(errs, fmt.Errorf("tracing sample rate for unknown signal %q (must be one of %s)", signal, strings.Join(knownSignals, ", ")))
+		}
+		if rate < 0 || rate > 1 {
+			errs = append(errs, fmt.Errorf("tracing sample rate for %s must be between 0.0 and 1.0, got %g", signal, rate))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid tracing configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// newSpanExporters creates an exporter for each configured destination.
+// Exporters already created are shut down when a later one fails.
+func newSpanExporters(ctx context.Context, cfg *config.ObservabilityConfig) ([]sdktrace.SpanExporter, error) {
+	var exporters []sdktrace.SpanExporter
+	for _, exporterCfg := range tracingExporters(cfg) {
+		exporter, err := newSpanExporter(ctx, exporterCfg)
+		if err != nil {
+			for _, created := range exporters {
+				_ = created.Shutdown(ctx)
+			}
+			return nil, fmt.Errorf("failed to create tracing exporter %q: %w", exporterCfg.Name, err)
+		}
+		exporters = append(exporters, exporter)
+	}
+	return exporters, nil
+}
+
+func newSpanExporter(ctx context.Context, cfg config.TracingExporterConfig) (sdktrace.SpanExporter, error) {
+	if cfg.Type == ExporterStdout {
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())))
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// newSampler samples new traces at the rate of the signal that started them,
+// falling back to TracingSampleRate. Spans with a parent follow the parent's
+// decision so traces are never cut in half.
+func newSampler(cfg *config.ObservabilityConfig) sdktrace.Sampler {
+	sampler := signalSampler{
+		fallback: createSampler(cfg.TracingSampleRate),
+		signals:  make(map[string]sdktrace.Sampler, len(cfg.TracingSampleRates)),
+	}
+	for signal, rate := range cfg.TracingSampleRates {
+		sampler.signals[signal] = createSampler(rate)
+	}
+	return sdktrace.ParentBased(sampler)
+}
+
+// signalSampler picks a sampler by the signal of the span being started
+type signalSampler struct {
+	fallback sdktrace.Sampler
+	signals  map[string]sdktrace.Sampler
+}
+
+func (s signalSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if sampler, ok := s.signals[spanSignal(p.Name, p.Kind)]; ok {
+		return sampler.ShouldSample(p)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s signalSampler) Description() string {
+	return "SignalSampler{" + s.fallback.Description() + "}"
+}
+
+// spanSignal returns the signal a root span belongs to. API requests are
+// server spans named "<method> <path>" by HTTPMiddleware; other spans are
+// named "<signal>.<operation>".
+func spanSignal(name string, kind trace.SpanKind) string {
+	if kind == trace.SpanKindServer {
+		if _, path, ok := strings.Cut(name, " "); ok && strings.HasPrefix(path, "/webhooks/") {
+			return SignalWebhook
+		}
+		return SignalHTTP
+	}
+	signal, _, _ := strings.Cut(name, ".")
+	return signal
+}
@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gorax/gorax/internal/config"
+)
+
+func TestValidateTracingConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.ObservabilityConfig
+		wantErr []string
+	}{
+		{
+			name: "default exporter",
+			cfg:  config.ObservabilityConfig{TracingEndpoint: "localhost:4317", TracingSampleRate: 1},
+		},
+		{
+			name: "several exporters with signal rates",
+			cfg: config.ObservabilityConfig{
+				TracingSampleRate: 0.5,
+				TracingExporters: []config.TracingExporterConfig{
+					{Name: "old", Type: ExporterOTLP, Endpoint: "old.example.com:4317"},
+					{Name: "new", Type: ExporterOTLP, Endpoint: "new.example.com:4317"},
+					{Name: "debug", Type: ExporterStdout},
+				},
+				TracingSampleRates: map[string]float64{SignalWebhook: 0.1, SignalWorkflow: 1},
+			},
+		},
+		{
+			name:    "default exporter without endpoint",
+			cfg:     config.ObservabilityConfig{TracingSampleRate: 1},
+			wantErr: []string{`tracing exporter "otlp": otlp exporters need an endpoint`},
+		},
+		{
+			name: "every problem is reported",
+			cfg: config.ObservabilityConfig{
+				TracingSampleRate: 2,
+				TracingExporters: []config.TracingExporterConfig{
+					{Name: "primary", Type: "zipkin"},
+					{Name: "backup", Type: ExporterOTLP},
+					{Name: "backup", Type: ExporterStdout},
+				},
+				TracingSampleRates: map[string]float64{"webhooks": 0.5, SignalHTTP: -1},
+			},
+			wantErr: []string{
+				`tracing exporter "primary": unknown type "zipkin"`,
+				`tracing exporter "backup": otlp exporters need an endpoint`,
+				`tracing exporter "backup" is listed more than once`,
+				"tracing sample rate must be between 0.0 and 1.0, got 2",
+				`unknown signal "webhooks"`,
+				"tracing sample rate for http must be between 0.0 and 1.0, got -1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTracingConfig(&tt.cfg)
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestNewTracerProvider_InvalidExporter(t *testing.T) {
+	cfg := &config.ObservabilityConfig{
+		TracingEnabled:     true,
+		TracingServiceName: "gorax-test",
+		TracingSampleRate:  1,
+		TracingExporters:   []config.TracingExporterConfig{{Name: "primary", Type: "jaeger"}},
+	}
+
+	_, _, err := NewTracerProvider(context.Background(), cfg)
+
+	assert.ErrorContains(t, err, `tracing exporter "primary": unknown type "jaeger"`)
+}
+
+func TestSpanSignal(t *testing.T) {
+	assert.Equal(t, SignalHTTP, spanSignal("GET /api/v1/workflows", trace.SpanKindServer))
+	assert.Equal(t, SignalWebhook, spanSignal("POST /webhooks/wf-1/wh-1", trace.SpanKindServer))
+	assert.Equal(t, SignalWorkflow, spanSignal("workflow.execute", trace.SpanKindInternal))
+	assert.Equal(t, SignalQueue, spanSignal("queue.process_message", trace.SpanKindInternal))
+}
+
+func TestNewSampler_SignalRates(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithSampler(newSampler(&config.ObservabilityConfig{
+			TracingSampleRate:  1,
+			TracingSampleRates: map[string]float64{SignalWebhook: 0},
+		})),
+	)
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+	tracer := tp.Tracer("test")
+
+	_, webhook := tracer.Start(context.Background(), "POST /webhooks/wf-1/wh-1", trace.WithSpanKind(trace.SpanKindServer))
+	webhook.End()
+
+	ctx, execution := tracer.Start(context.Background(), "workflow.execute")
+	// Children follow the root's decision even when their signal is sampled differently
+	_, child := tracer.Start(ctx, "webhook.event.store")
+	child.End()
+	execution.End()
+
+	var names []string
+	for _, span := range exporter.GetSpans() {
+		names = append(names, span.Name)
+	}
+	assert.ElementsMatch(t, []string{"workflow.execute", "webhook.event.store"}, names)
+}
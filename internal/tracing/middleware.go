@@ -3,6 +3,7 @@ package tracing
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -31,7 +32,9 @@ func HTTPMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
-// HTTPClientMiddleware returns an HTTP client transport that traces outgoing requests
+// HTTPClientMiddleware returns an HTTP client transport that traces outgoing
+// requests and injects the W3C traceparent header so the receiving service
+// joins the trace
 func HTTPClientMiddleware(transport http.RoundTripper) http.RoundTripper {
 	return otelhttp.NewTransport(transport,
 		otelhttp.WithTracerProvider(otel.GetTracerProvider()),
@@ -58,6 +61,46 @@ func InjectHTTPTraceContext(r *http.Request) {
 	}
 }
 
+// RedactedValue replaces secret header values recorded on spans
+const RedactedValue = "[REDACTED]"
+
+// sensitiveHeaderParts are substrings of header names whose values are never recorded
+var sensitiveHeaderParts = []string{
+	"authorization", "cookie", "token", "secret", "password",
+	"api-key", "apikey", "signature", "session", "credential",
+}
+
+// IsSensitiveHeader reports whether a header's value must be redacted from
+// spans and logs
+func IsSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveHeaderParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddHTTPRequestHeaders records request headers on the span in ctx as
+// http.request.header.<name> attributes. Values of headers that may carry
+// secrets, such as Authorization or X-Api-Key, are redacted.
+func AddHTTPRequestHeaders(ctx context.Context, header http.Header) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(header))
+	for name, values := range header {
+		if IsSensitiveHeader(name) {
+			values = []string{RedactedValue}
+		}
+		attrs = append(attrs, attribute.StringSlice("http.request.header."+strings.ToLower(name), values))
+	}
+	span.SetAttributes(attrs...)
+}
+
 // AddHTTPAttributes adds standard HTTP attributes to a span
 func AddHTTPAttributes(span trace.Span, r *http.Request, statusCode int) {
 	span.SetAttributes(
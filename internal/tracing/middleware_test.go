@@ -178,3 +178,12 @@ func TestHTTPMiddleware_MultipleRequests(t *testing.T) {
 	assert.NotEqual(t, traceIDs[0], traceIDs[1])
 	assert.NotEqual(t, traceIDs[1], traceIDs[2])
 }
+
+func TestIsSensitiveHeader(t *testing.T) {
+	for _, name := range []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key", "X-Auth-Token", "X-Hub-Signature-256", "X-Client-Secret"} {
+		assert.True(t, IsSensitiveHeader(name), name)
+	}
+	for _, name := range []string{"Content-Type", "Accept", "X-Request-Id", "Traceparent"} {
+		assert.False(t, IsSensitiveHeader(name), name)
+	}
+}
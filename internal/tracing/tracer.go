@@ -7,19 +7,18 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/gorax/gorax/internal/config"
 )
 
-// NewTracerProvider creates a new OpenTelemetry tracer provider
+// NewTracerProvider creates a new OpenTelemetry tracer provider that sends
+// every sampled span to each configured exporter
 // Returns the provider, a cleanup function, and any error
 func NewTracerProvider(ctx context.Context, cfg *config.ObservabilityConfig) (trace.TracerProvider, func(), error) {
 	// If tracing is disabled, return a no-op tracer provider
@@ -27,6 +26,10 @@ func NewTracerProvider(ctx context.Context, cfg *config.ObservabilityConfig) (tr
 		return trace.NewNoopTracerProvider(), func() {}, nil
 	}
 
+	if err := validateTracingConfig(cfg); err != nil {
+		return nil, nil, err
+	}
+
 	// Create resource with service information
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
@@ -38,31 +41,21 @@ func NewTracerProvider(ctx context.Context, cfg *config.ObservabilityConfig) (tr
 		return nil, nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// Create OTLP trace exporter
-	exporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithEndpoint(cfg.TracingEndpoint),
-		otlptracegrpc.WithDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
-	)
+	exporters, err := newSpanExporters(ctx, cfg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
-	}
-
-	// Create sampler based on sample rate
-	var sampler sdktrace.Sampler
-	if cfg.TracingSampleRate >= 1.0 {
-		sampler = sdktrace.AlwaysSample()
-	} else if cfg.TracingSampleRate <= 0.0 {
-		sampler = sdktrace.NeverSample()
-	} else {
-		sampler = sdktrace.TraceIDRatioBased(cfg.TracingSampleRate)
+		return nil, nil, err
 	}
 
-	// Create tracer provider
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	// Each exporter gets its own batcher so a slow or unreachable backend
+	// does not hold up the others
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sampler),
-	)
+		sdktrace.WithSampler(newSampler(cfg)),
+	}
+	for _, exporter := range exporters {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
 
 	// Cleanup function
 	cleanup := func() {
@@ -108,6 +101,18 @@ func RecordError(span trace.Span, err error) {
 	}
 }
 
+// EndSpan records err on the span, sets its status and ends it.
+// Defer it with a named error result so every return path is covered.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
+
 // SetSpanAttributes sets multiple attributes on a span
 func SetSpanAttributes(span trace.Span, attrs map[string]interface{}) {
 	for key, value := range attrs {
@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultCaptureTTL is how long captured requests are kept when the webhook sets no TTL
+	DefaultCaptureTTL = 24 * time.Hour
+	// MaxCaptureTTL is the longest captured requests can be kept
+	MaxCaptureTTL = 7 * 24 * time.Hour
+	// CaptureRedactedValue replaces the values of redacted headers in captures
+	CaptureRedactedValue = "[REDACTED]"
+)
+
+// DefaultCaptureRedactHeaders are always redacted from captured requests, in
+// addition to the webhook's configured headers
+var DefaultCaptureRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-Api-Key",
+}
+
+// CaptureConfig enables capturing the raw requests a webhook receives, so a
+// misbehaving delivery can be inspected and replayed
+type CaptureConfig struct {
+	Enabled bool `json:"enabled"`
+	// TTLSeconds is how long captures are kept; 0 means DefaultCaptureTTL
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+	// RedactHeaders are secret headers whose values are not stored, matched
+	// case-insensitively
+	RedactHeaders []string `json:"redactHeaders,omitempty"`
+}
+
+// Validate checks that the TTL is within the allowed range
+func (c *CaptureConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.TTLSeconds < 0 || time.Duration(c.TTLSeconds)*time.Second > MaxCaptureTTL {
+		return fmt.Errorf("capture TTL must be between 0 and %d seconds", int(MaxCaptureTTL/time.Second))
+	}
+	return nil
+}
+
+// TTL returns how long captures are kept
+func (c *CaptureConfig) TTL() time.Duration {
+	if c == nil || c.TTLSeconds <= 0 {
+		return DefaultCaptureTTL
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// redacts reports whether the value of header is left out of captures
+func (c *CaptureConfig) redacts(header string) bool {
+	matches := func(name string) bool { return strings.EqualFold(name, header) }
+	return slices.ContainsFunc(DefaultCaptureRedactHeaders, matches) ||
+		(c != nil && slices.ContainsFunc(c.RedactHeaders, matches))
+}
+
+// Scan implements sql.Scanner for CaptureConfig stored as JSONB
+func (c *CaptureConfig) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for CaptureConfig: %T", value)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Value implements driver.Valuer for CaptureConfig stored as JSONB
+func (c *CaptureConfig) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// CaptureHeaders is a request's header map stored as JSONB
+type CaptureHeaders http.Header
+
+// Scan implements sql.Scanner for CaptureHeaders stored as JSONB
+func (h *CaptureHeaders) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for CaptureHeaders: %T", value)
+	}
+
+	return json.Unmarshal(data, h)
+}
+
+// Value implements driver.Valuer for CaptureHeaders stored as JSONB
+func (h CaptureHeaders) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
+
+// WebhookCapture is a raw request received by a webhook with capture
+// enabled, stored before signature verification so rejected requests can be
+// inspected too
+type WebhookCapture struct {
+	ID         string         `db:"id" json:"id"`
+	TenantID   string         `db:"tenant_id" json:"tenantId"`
+	WebhookID  string         `db:"webhook_id" json:"webhookId"`
+	Method     string         `db:"method" json:"method"`
+	Query      string         `db:"query" json:"query"`
+	Headers    CaptureHeaders `db:"headers" json:"headers"`
+	Body       []byte         `db:"body" json:"-"`
+	RemoteAddr string         `db:"remote_addr" json:"remoteAddr"`
+	CapturedAt time.Time      `db:"captured_at" json:"capturedAt"`
+	ExpiresAt  time.Time      `db:"expires_at" json:"expiresAt"`
+}
+
+// MarshalJSON includes the body as text, or base64 when it is not valid UTF-8
+func (c WebhookCapture) MarshalJSON() ([]byte, error) {
+	type capture WebhookCapture
+	out := struct {
+		capture
+		Body         string `json:"body"`
+		BodyEncoding string `json:"bodyEncoding,omitempty"`
+	}{capture: capture(c)}
+
+	if utf8.Valid(c.Body) {
+		out.Body = string(c.Body)
+	} else {
+		out.Body = base64.StdEncoding.EncodeToString(c.Body)
+		out.BodyEncoding = "base64"
+	}
+	return json.Marshal(out)
+}
+
+// NewCapture records a request received by a webhook, redacting the values
+// of its secret headers
+func NewCapture(webhook *Webhook, r *http.Request, body []byte, now time.Time) *WebhookCapture {
+	headers := make(CaptureHeaders, len(r.Header))
+	for name, values := range r.Header {
+		if webhook.CaptureConfig.redacts(name) {
+			headers[name] = []string{CaptureRedactedValue}
+			continue
+		}
+		headers[name] = slices.Clone(values)
+	}
+
+	return &WebhookCapture{
+		TenantID:   webhook.TenantID,
+		WebhookID:  webhook.ID,
+		Method:     r.Method,
+		Query:      r.URL.RawQuery,
+		Headers:    headers,
+		Body:       body,
+		RemoteAddr: r.RemoteAddr,
+		CapturedAt: now,
+		ExpiresAt:  now.Add(webhook.CaptureConfig.TTL()),
+	}
+}
+
+// TriggerData builds the trigger data a webhook request starts its workflow
+// with. Repeated headers and query parameters keep their first value.
+func TriggerData(method string, headers http.Header, query url.Values, body []byte) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"method":  method,
+		"headers": firstValues(headers),
+		"query":   firstValues(query),
+		"body":    json.RawMessage(body),
+	})
+}
+
+// TriggerData builds the trigger data of the captured request
+func (c *WebhookCapture) TriggerData() ([]byte, error) {
+	query, err := url.ParseQuery(c.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid captured query: %w", err)
+	}
+	return TriggerData(c.Method, http.Header(c.Headers), query, c.Body)
+}
+
+func firstValues(values map[string][]string) map[string]string {
+	result := make(map[string]string)
+	for key, vals := range values {
+		if len(vals) > 0 {
+			result[key] = vals[0]
+		}
+	}
+	return result
+}
+
+// CaptureCleanupRepository is implemented by cleanup repositories that can
+// also sweep expired request captures
+type CaptureCleanupRepository interface {
+	DeleteExpiredCaptures(ctx context.Context, batchSize int) (int, error)
+}
+
+// CaptureRequest stores the raw request when the webhook has capture
+// enabled. It is best effort: failures are logged and returned but should
+// not fail the delivery.
+func (s *Service) CaptureRequest(ctx context.Context, webhook *Webhook, r *http.Request, body []byte) error {
+	if webhook.CaptureConfig == nil || !webhook.CaptureConfig.Enabled {
+		return nil
+	}
+
+	capture := NewCapture(webhook, r, body, time.Now())
+	if err := s.repo.CreateCapture(ctx, capture); err != nil {
+		s.logger.Error("failed to capture webhook request", "error", err, "webhook_id", webhook.ID)
+		return err
+	}
+	return nil
+}
+
+// UpdateCaptureConfig enables or disables request capture for a webhook
+func (s *Service) UpdateCaptureConfig(ctx context.Context, tenantID, webhookID string, config *CaptureConfig) (*Webhook, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Verify webhook belongs to tenant
+	if _, err := s.repo.GetByIDAndTenant(ctx, webhookID, tenantID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.repo.UpdateCaptureConfig(ctx, webhookID, config)
+	if err != nil {
+		s.logger.Error("failed to update webhook capture config", "error", err, "webhook_id", webhookID)
+		return nil, err
+	}
+
+	s.logger.Info("webhook capture config updated", "webhook_id", webhookID, "enabled", config != nil && config.Enabled)
+	return webhook, nil
+}
+
+// ListCaptures retrieves the unexpired captured requests of a webhook, newest first
+func (s *Service) ListCaptures(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*WebhookCapture, int, error) {
+	// Verify webhook belongs to tenant
+	if _, err := s.repo.GetByIDAndTenant(ctx, webhookID, tenantID); err != nil {
+		return nil, 0, err
+	}
+
+	captures, total, err := s.repo.ListCaptures(ctx, tenantID, webhookID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to list webhook captures", "error", err, "webhook_id", webhookID)
+		return nil, 0, fmt.Errorf("failed to list webhook captures: %w", err)
+	}
+
+	return captures, total, nil
+}
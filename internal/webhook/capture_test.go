@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureConfig_Validate(t *testing.T) {
+	assert.NoError(t, (*CaptureConfig)(nil).Validate())
+	assert.NoError(t, (&CaptureConfig{Enabled: true}).Validate())
+	assert.NoError(t, (&CaptureConfig{Enabled: true, TTLSeconds: 7 * 24 * 3600}).Validate())
+	assert.Error(t, (&CaptureConfig{Enabled: true, TTLSeconds: -1}).Validate())
+	assert.Error(t, (&CaptureConfig{Enabled: true, TTLSeconds: 7*24*3600 + 1}).Validate())
+}
+
+func TestNewCapture(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	webhook := &Webhook{
+		ID:            "webhook-123",
+		TenantID:      "tenant-123",
+		CaptureConfig: &CaptureConfig{Enabled: true, TTLSeconds: 3600, RedactHeaders: []string{"x-shared-secret"}},
+	}
+
+	req := httptest.NewRequest("POST", "/webhooks/workflow-123/webhook-123?source=ci&tag=a&tag=b", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Shared-Secret", "s3cr3t")
+	req.Header.Set("X-Webhook-Signature", "sha256=abc")
+	req.RemoteAddr = "203.0.113.7:51234"
+
+	capture := NewCapture(webhook, req, []byte(`{"event": "push"}`), now)
+
+	assert.Equal(t, "tenant-123", capture.TenantID)
+	assert.Equal(t, "webhook-123", capture.WebhookID)
+	assert.Equal(t, "POST", capture.Method)
+	assert.Equal(t, "source=ci&tag=a&tag=b", capture.Query)
+	assert.Equal(t, "203.0.113.7:51234", capture.RemoteAddr)
+	assert.Equal(t, now.Add(time.Hour), capture.ExpiresAt)
+	assert.Equal(t, []string{"application/json"}, capture.Headers["Content-Type"])
+	assert.Equal(t, []string{CaptureRedactedValue}, capture.Headers["Authorization"])
+	assert.Equal(t, []string{CaptureRedactedValue}, capture.Headers["X-Shared-Secret"])
+	assert.Equal(t, []string{"sha256=abc"}, capture.Headers["X-Webhook-Signature"])
+}
+
+func TestNewCapture_DefaultTTL(t *testing.T) {
+	now := time.Now()
+	webhook := &Webhook{ID: "webhook-123", CaptureConfig: &CaptureConfig{Enabled: true}}
+	req := httptest.NewRequest("POST", "/webhooks/workflow-123/webhook-123", nil)
+
+	capture := NewCapture(webhook, req, nil, now)
+
+	assert.Equal(t, now.Add(DefaultCaptureTTL), capture.ExpiresAt)
+}
+
+func TestWebhookCapture_MarshalJSON(t *testing.T) {
+	var out map[string]interface{}
+
+	data, err := json.Marshal(WebhookCapture{ID: "capture-1", Body: []byte(`{"a": 1}`)})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "capture-1", out["id"])
+	assert.Equal(t, `{"a": 1}`, out["body"])
+	assert.NotContains(t, out, "bodyEncoding")
+
+	out = nil
+	data, err = json.Marshal(WebhookCapture{ID: "capture-2", Body: []byte{0xff, 0xfe}})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "//4=", out["body"])
+	assert.Equal(t, "base64", out["bodyEncoding"])
+}
+
+func TestWebhookCapture_TriggerData(t *testing.T) {
+	capture := &WebhookCapture{
+		Method:  "POST",
+		Query:   "source=ci&tag=a&tag=b",
+		Headers: CaptureHeaders{"Content-Type": {"application/json"}, "Authorization": {CaptureRedactedValue}},
+		Body:    []byte(`{"event": "push"}`),
+	}
+
+	data, err := capture.TriggerData()
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"method": "POST",
+		"headers": {"Content-Type": "application/json", "Authorization": "[REDACTED]"},
+		"query": {"source": "ci", "tag": "a"},
+		"body": {"event": "push"}
+	}`, string(data))
+}
+
+// MockCaptureReplayRepository also loads captured requests
+type MockCaptureReplayRepository struct {
+	MockReplayRepository
+}
+
+func (m *MockCaptureReplayRepository) GetCapture(ctx context.Context, tenantID, webhookID, captureID string) (*WebhookCapture, error) {
+	args := m.Called(ctx, tenantID, webhookID, captureID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*WebhookCapture), args.Error(1)
+}
+
+func TestReplayService_ReplayCapture_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockCaptureReplayRepository)
+	mockExecutor := new(MockWorkflowExecutor)
+	service := NewReplayService(mockRepo, mockExecutor, nil)
+
+	capture := &WebhookCapture{
+		ID:        "capture-123",
+		TenantID:  "tenant-123",
+		WebhookID: "webhook-123",
+		Method:    "POST",
+		Headers:   CaptureHeaders{"Content-Type": {"application/json"}},
+		Body:      []byte(`{"test": "data"}`),
+	}
+	webhook := &Webhook{ID: "webhook-123", TenantID: "tenant-123", WorkflowID: "workflow-123", Enabled: true}
+	triggerData, err := capture.TriggerData()
+	require.NoError(t, err)
+
+	mockRepo.On("GetCapture", ctx, "tenant-123", "webhook-123", "capture-123").Return(capture, nil)
+	mockRepo.On("GetByID", ctx, "webhook-123").Return(webhook, nil)
+	mockExecutor.On("Execute", mock.Anything, "tenant-123", "workflow-123", "webhook_replay", triggerData).Return("exec-123", nil)
+
+	result := service.ReplayCapture(ctx, "tenant-123", "webhook-123", "capture-123")
+
+	assert.True(t, result.Success)
+	assert.Equal(t, "exec-123", result.ExecutionID)
+	mockRepo.AssertExpectations(t)
+	mockExecutor.AssertExpectations(t)
+}
+
+func TestReplayService_ReplayCapture_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockCaptureReplayRepository)
+	mockExecutor := new(MockWorkflowExecutor)
+	service := NewReplayService(mockRepo, mockExecutor, nil)
+
+	mockRepo.On("GetCapture", ctx, "tenant-123", "webhook-123", "expired").Return(nil, ErrNotFound)
+
+	result := service.ReplayCapture(ctx, "tenant-123", "webhook-123", "expired")
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "capture not found")
+	mockExecutor.AssertNotCalled(t, "Execute")
+}
+
+func TestReplayService_ReplayCapture_WebhookDisabled(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockCaptureReplayRepository)
+	mockExecutor := new(MockWorkflowExecutor)
+	service := NewReplayService(mockRepo, mockExecutor, nil)
+
+	capture := &WebhookCapture{ID: "capture-123", WebhookID: "webhook-123", Method: "POST"}
+	mockRepo.On("GetCapture", ctx, "tenant-123", "webhook-123", "capture-123").Return(capture, nil)
+	mockRepo.On("GetByID", ctx, "webhook-123").Return(&Webhook{ID: "webhook-123", Enabled: false}, nil)
+
+	result := service.ReplayCapture(ctx, "tenant-123", "webhook-123", "capture-123")
+
+	assert.False(t, result.Success)
+	assert.Equal(t, "webhook is disabled", result.Error)
+	mockExecutor.AssertNotCalled(t, "Execute")
+}
+
+func TestReplayService_ReplayCapture_Unsupported(t *testing.T) {
+	service := NewReplayService(new(MockReplayRepository), new(MockWorkflowExecutor), nil)
+
+	result := service.ReplayCapture(context.Background(), "tenant-123", "webhook-123", "capture-123")
+
+	assert.False(t, result.Success)
+	assert.NotEmpty(t, result.Error)
+}
@@ -11,6 +11,20 @@ type CleanupRepository interface {
 	DeleteOldEvents(ctx context.Context, retentionPeriod time.Duration, batchSize int) (int, error)
 }
 
+// RetentionOverride is a workflow-specific webhook event retention period
+type RetentionOverride struct {
+	WorkflowID    string `db:"workflow_id"`
+	RetentionDays int    `db:"retention_days"`
+}
+
+// RetentionOverrideRepository is implemented by cleanup repositories that
+// support per-workflow retention overrides
+type RetentionOverrideRepository interface {
+	GetRetentionOverrides(ctx context.Context) ([]RetentionOverride, error)
+	DeleteOldWorkflowEvents(ctx context.Context, workflowID string, retentionPeriod time.Duration, batchSize int) (int, error)
+	DeleteOldEventsExcluding(ctx context.Context, retentionPeriod time.Duration, batchSize int, excludedWorkflowIDs []string) (int, error)
+}
+
 // CleanupService handles webhook event cleanup operations
 type CleanupService struct {
 	repo            CleanupRepository
@@ -20,11 +34,14 @@ type CleanupService struct {
 
 // CleanupResult contains statistics from the cleanup operation
 type CleanupResult struct {
-	TotalDeleted     int
-	BatchesProcessed int
-	DurationMs       int64
-	StartTime        time.Time
-	EndTime          time.Time
+	TotalDeleted      int
+	BatchesProcessed  int
+	DeliveriesDeleted int
+	CapturesDeleted   int
+	WorkflowOverrides int // Workflows swept with their own retention period
+	DurationMs        int64
+	StartTime         time.Time
+	EndTime           time.Time
 }
 
 // NewCleanupService creates a new cleanup service
@@ -54,35 +71,139 @@ func (s *CleanupService) Run(ctx context.Context) (*CleanupResult, error) {
 		StartTime:        startTime,
 	}
 
+	if err := s.sweepEvents(ctx, result); err != nil {
+		result.EndTime = time.Now()
+		result.DurationMs = time.Since(startTime).Milliseconds()
+		return result, err
+	}
+
+	if err := s.sweepDeliveries(ctx, result); err != nil {
+		result.EndTime = time.Now()
+		result.DurationMs = time.Since(startTime).Milliseconds()
+		return result, err
+	}
+
+	if err := s.sweepCaptures(ctx, result); err != nil {
+		result.EndTime = time.Now()
+		result.DurationMs = time.Since(startTime).Milliseconds()
+		return result, err
+	}
+
+	result.EndTime = time.Now()
+	result.DurationMs = time.Since(startTime).Milliseconds()
+
+	return result, nil
+}
+
+// sweepEvents deletes old webhook events. Workflows with a retention override
+// are swept with their own period and skipped by the global sweep.
+func (s *CleanupService) sweepEvents(ctx context.Context, result *CleanupResult) error {
+	repo, ok := s.repo.(RetentionOverrideRepository)
+	if !ok {
+		return s.deleteBatches(ctx, result, func() (int, error) {
+			return s.repo.DeleteOldEvents(ctx, s.retentionPeriod, s.batchSize)
+		})
+	}
+
+	overrides, err := repo.GetRetentionOverrides(ctx)
+	if err != nil {
+		return fmt.Errorf("get retention overrides failed: %w", err)
+	}
+
+	if len(overrides) == 0 {
+		return s.deleteBatches(ctx, result, func() (int, error) {
+			return s.repo.DeleteOldEvents(ctx, s.retentionPeriod, s.batchSize)
+		})
+	}
+
+	excluded := make([]string, 0, len(overrides))
+	for _, override := range overrides {
+		excluded = append(excluded, override.WorkflowID)
+		retentionPeriod := time.Duration(override.RetentionDays) * 24 * time.Hour
+		err := s.deleteBatches(ctx, result, func() (int, error) {
+			return repo.DeleteOldWorkflowEvents(ctx, override.WorkflowID, retentionPeriod, s.batchSize)
+		})
+		if err != nil {
+			return err
+		}
+		result.WorkflowOverrides++
+	}
+
+	return s.deleteBatches(ctx, result, func() (int, error) {
+		return repo.DeleteOldEventsExcluding(ctx, s.retentionPeriod, s.batchSize, excluded)
+	})
+}
+
+// deleteBatches calls deleteBatch until it deletes nothing, recording the totals
+func (s *CleanupService) deleteBatches(ctx context.Context, result *CleanupResult, deleteBatch func() (int, error)) error {
 	for {
 		// Check context cancellation
 		if err := ctx.Err(); err != nil {
-			result.EndTime = time.Now()
-			result.DurationMs = time.Since(startTime).Milliseconds()
-			return result, fmt.Errorf("context canceled: %w", err)
+			return fmt.Errorf("context canceled: %w", err)
 		}
 
 		// Delete a batch of old events
-		deleted, err := s.repo.DeleteOldEvents(ctx, s.retentionPeriod, s.batchSize)
+		deleted, err := deleteBatch()
 		if err != nil {
-			result.EndTime = time.Now()
-			result.DurationMs = time.Since(startTime).Milliseconds()
-			return result, fmt.Errorf("delete batch failed: %w", err)
+			return fmt.Errorf("delete batch failed: %w", err)
 		}
 
 		// If no events were deleted, we're done
 		if deleted == 0 {
-			break
+			return nil
 		}
 
 		result.TotalDeleted += deleted
 		result.BatchesProcessed++
 	}
+}
 
-	result.EndTime = time.Now()
-	result.DurationMs = time.Since(startTime).Milliseconds()
+// sweepDeliveries deletes expired webhook delivery IDs when the repository supports it
+func (s *CleanupService) sweepDeliveries(ctx context.Context, result *CleanupResult) error {
+	repo, ok := s.repo.(DeliveryCleanupRepository)
+	if !ok {
+		return nil
+	}
 
-	return result, nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("context canceled: %w", err)
+		}
+
+		deleted, err := repo.DeleteExpiredDeliveries(ctx, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("delete expired deliveries failed: %w", err)
+		}
+		if deleted == 0 {
+			return nil
+		}
+
+		result.DeliveriesDeleted += deleted
+	}
+}
+
+// sweepCaptures deletes expired request captures when the repository supports it
+func (s *CleanupService) sweepCaptures(ctx context.Context, result *CleanupResult) error {
+	repo, ok := s.repo.(CaptureCleanupRepository)
+	if !ok {
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("context canceled: %w", err)
+		}
+
+		deleted, err := repo.DeleteExpiredCaptures(ctx, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("delete expired captures failed: %w", err)
+		}
+		if deleted == 0 {
+			return nil
+		}
+
+		result.CapturesDeleted += deleted
+	}
 }
 
 // SetRetentionPeriod updates the retention period
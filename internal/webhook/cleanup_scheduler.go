@@ -110,6 +110,8 @@ func (s *CleanupScheduler) runCleanup(ctx context.Context) {
 			"error", err,
 			"total_deleted", result.TotalDeleted,
 			"batches_processed", result.BatchesProcessed,
+			"deliveries_deleted", result.DeliveriesDeleted,
+			"captures_deleted", result.CapturesDeleted,
 			"duration_ms", result.DurationMs,
 		)
 		return
@@ -118,6 +120,9 @@ func (s *CleanupScheduler) runCleanup(ctx context.Context) {
 	s.logger.Info("cleanup completed",
 		"total_deleted", result.TotalDeleted,
 		"batches_processed", result.BatchesProcessed,
+		"deliveries_deleted", result.DeliveriesDeleted,
+		"captures_deleted", result.CapturesDeleted,
+		"workflow_overrides", result.WorkflowOverrides,
 		"duration_ms", result.DurationMs,
 		"retention_period", s.service.GetRetentionPeriod().String(),
 	)
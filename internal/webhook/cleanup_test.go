@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -169,3 +170,145 @@ func TestCleanupService_Run_LargeBatch(t *testing.T) {
 	assert.Equal(t, 3, result.BatchesProcessed)
 	mockRepo.AssertExpectations(t)
 }
+
+// MockDeliveryCleanupRepository also sweeps expired delivery IDs
+type MockDeliveryCleanupRepository struct {
+	MockCleanupRepository
+}
+
+func (m *MockDeliveryCleanupRepository) DeleteExpiredDeliveries(ctx context.Context, batchSize int) (int, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCleanupService_Run_SweepsExpiredDeliveries(t *testing.T) {
+	mockRepo := new(MockDeliveryCleanupRepository)
+	ctx := context.Background()
+	retentionPeriod := 30 * 24 * time.Hour
+	batchSize := 100
+
+	mockRepo.On("DeleteOldEvents", ctx, retentionPeriod, batchSize).Return(0, nil).Once()
+	mockRepo.On("DeleteExpiredDeliveries", ctx, batchSize).Return(100, nil).Once()
+	mockRepo.On("DeleteExpiredDeliveries", ctx, batchSize).Return(20, nil).Once()
+	mockRepo.On("DeleteExpiredDeliveries", ctx, batchSize).Return(0, nil).Once()
+
+	service := NewCleanupService(mockRepo, batchSize, retentionPeriod)
+
+	result, err := service.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.TotalDeleted)
+	assert.Equal(t, 120, result.DeliveriesDeleted)
+	mockRepo.AssertExpectations(t)
+}
+
+// MockCaptureCleanupRepository also sweeps expired request captures
+type MockCaptureCleanupRepository struct {
+	MockCleanupRepository
+}
+
+func (m *MockCaptureCleanupRepository) DeleteExpiredCaptures(ctx context.Context, batchSize int) (int, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCleanupService_Run_SweepsExpiredCaptures(t *testing.T) {
+	mockRepo := new(MockCaptureCleanupRepository)
+	ctx := context.Background()
+	retentionPeriod := 30 * 24 * time.Hour
+	batchSize := 100
+
+	mockRepo.On("DeleteOldEvents", ctx, retentionPeriod, batchSize).Return(0, nil).Once()
+	mockRepo.On("DeleteExpiredCaptures", ctx, batchSize).Return(100, nil).Once()
+	mockRepo.On("DeleteExpiredCaptures", ctx, batchSize).Return(5, nil).Once()
+	mockRepo.On("DeleteExpiredCaptures", ctx, batchSize).Return(0, nil).Once()
+
+	service := NewCleanupService(mockRepo, batchSize, retentionPeriod)
+
+	result, err := service.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 105, result.CapturesDeleted)
+	mockRepo.AssertExpectations(t)
+}
+
+// MockRetentionOverrideRepository also supports per-workflow retention overrides
+type MockRetentionOverrideRepository struct {
+	MockCleanupRepository
+}
+
+func (m *MockRetentionOverrideRepository) GetRetentionOverrides(ctx context.Context) ([]RetentionOverride, error) {
+	args := m.Called(ctx)
+	overrides, _ := args.Get(0).([]RetentionOverride)
+	return overrides, args.Error(1)
+}
+
+func (m *MockRetentionOverrideRepository) DeleteOldWorkflowEvents(ctx context.Context, workflowID string, retentionPeriod time.Duration, batchSize int) (int, error) {
+	args := m.Called(ctx, workflowID, retentionPeriod, batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRetentionOverrideRepository) DeleteOldEventsExcluding(ctx context.Context, retentionPeriod time.Duration, batchSize int, excludedWorkflowIDs []string) (int, error) {
+	args := m.Called(ctx, retentionPeriod, batchSize, excludedWorkflowIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCleanupService_Run_AppliesWorkflowOverrides(t *testing.T) {
+	mockRepo := new(MockRetentionOverrideRepository)
+	ctx := context.Background()
+	retentionPeriod := 30 * 24 * time.Hour
+	batchSize := 100
+
+	mockRepo.On("GetRetentionOverrides", ctx).Return([]RetentionOverride{
+		{WorkflowID: "wf-audit", RetentionDays: 365},
+		{WorkflowID: "wf-noisy", RetentionDays: 1},
+	}, nil).Once()
+	mockRepo.On("DeleteOldWorkflowEvents", ctx, "wf-audit", 365*24*time.Hour, batchSize).Return(0, nil).Once()
+	mockRepo.On("DeleteOldWorkflowEvents", ctx, "wf-noisy", 24*time.Hour, batchSize).Return(100, nil).Once()
+	mockRepo.On("DeleteOldWorkflowEvents", ctx, "wf-noisy", 24*time.Hour, batchSize).Return(0, nil).Once()
+	mockRepo.On("DeleteOldEventsExcluding", ctx, retentionPeriod, batchSize, []string{"wf-audit", "wf-noisy"}).Return(40, nil).Once()
+	mockRepo.On("DeleteOldEventsExcluding", ctx, retentionPeriod, batchSize, []string{"wf-audit", "wf-noisy"}).Return(0, nil).Once()
+
+	service := NewCleanupService(mockRepo, batchSize, retentionPeriod)
+
+	result, err := service.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 140, result.TotalDeleted)
+	assert.Equal(t, 2, result.BatchesProcessed)
+	assert.Equal(t, 2, result.WorkflowOverrides)
+	mockRepo.AssertNotCalled(t, "DeleteOldEvents", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCleanupService_Run_NoOverridesUsesGlobalSweep(t *testing.T) {
+	mockRepo := new(MockRetentionOverrideRepository)
+	ctx := context.Background()
+	retentionPeriod := 30 * 24 * time.Hour
+	batchSize := 100
+
+	mockRepo.On("GetRetentionOverrides", ctx).Return(nil, nil).Once()
+	mockRepo.On("DeleteOldEvents", ctx, retentionPeriod, batchSize).Return(0, nil).Once()
+
+	service := NewCleanupService(mockRepo, batchSize, retentionPeriod)
+
+	result, err := service.Run(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.WorkflowOverrides)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCleanupService_Run_OverrideLookupError(t *testing.T) {
+	mockRepo := new(MockRetentionOverrideRepository)
+	ctx := context.Background()
+
+	mockRepo.On("GetRetentionOverrides", ctx).Return(nil, errors.New("db down")).Once()
+
+	service := NewCleanupService(mockRepo, 100, time.Hour)
+
+	_, err := service.Run(ctx)
+
+	assert.ErrorContains(t, err, "get retention overrides failed")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// DeliveryStatus is the outcome of a delivery attempt
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// DeliveryAttempt records one delivery of a webhook event to its workflow
+type DeliveryAttempt struct {
+	ID          string         `json:"id" db:"id"`
+	TenantID    string         `json:"tenantId" db:"tenant_id"`
+	WebhookID   string         `json:"webhookId" db:"webhook_id"`
+	EventID     string         `json:"eventId" db:"event_id"`
+	Attempt     int            `json:"attempt" db:"attempt"`
+	Status      DeliveryStatus `json:"status" db:"status"`
+	StatusCode  *int           `json:"statusCode,omitempty" db:"status_code"`
+	Error       *string        `json:"error,omitempty" db:"error"`
+	DurationMs  int            `json:"durationMs" db:"duration_ms"`
+	NextRetryAt *time.Time     `json:"nextRetryAt,omitempty" db:"next_retry_at"`
+	CreatedAt   time.Time      `json:"createdAt" db:"created_at"`
+}
+
+// WorkflowDeliverer redelivers a stored webhook event by executing the
+// webhook's workflow again with the original request as trigger data
+type WorkflowDeliverer struct {
+	executor WorkflowExecutor
+}
+
+// NewWorkflowDeliverer creates a deliverer that executes workflows with executor
+func NewWorkflowDeliverer(executor WorkflowExecutor) *WorkflowDeliverer {
+	return &WorkflowDeliverer{executor: executor}
+}
+
+// DeliverWebhook executes the webhook's workflow with the event's request.
+// Execution failures are reported as retryable server errors.
+func (d *WorkflowDeliverer) DeliverWebhook(ctx context.Context, webhook *Webhook, event *WebhookEvent) (*RetryResult, error) {
+	body := event.RequestBody
+	if len(body) == 0 {
+		body = json.RawMessage("null")
+	}
+
+	triggerData, err := json.Marshal(map[string]interface{}{
+		"method":  event.RequestMethod,
+		"headers": event.RequestHeaders,
+		"query":   map[string]string{},
+		"body":    body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: marshal trigger data: %v", ErrWebhookValidationFailed, err)
+	}
+
+	ctx = workflow.WithTriggerNode(ctx, webhook.NodeID)
+	executionID, err := d.executor.Execute(ctx, webhook.TenantID, webhook.WorkflowID, "webhook", triggerData)
+	if err != nil {
+		return &RetryResult{Error: fmt.Errorf("%w: %v", ErrWebhookServerError, err)}, nil
+	}
+
+	return &RetryResult{Success: true, ExecutionID: executionID}, nil
+}
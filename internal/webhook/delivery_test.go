@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRetryRepo keeps retry state and the delivery log in memory
+type fakeRetryRepo struct {
+	webhook     *Webhook
+	events      []*WebhookEvent
+	attempts    []*DeliveryAttempt
+	maxRetries  int
+	succeeded   []string
+	nonRetrying []string
+}
+
+func (r *fakeRetryRepo) GetByID(ctx context.Context, id string) (*Webhook, error) {
+	if r.webhook == nil || r.webhook.ID != id {
+		return nil, ErrNotFound
+	}
+	return r.webhook, nil
+}
+
+func (r *fakeRetryRepo) GetEventsForRetry(ctx context.Context, batchSize int) ([]*WebhookEvent, error) {
+	return r.events, nil
+}
+
+func (r *fakeRetryRepo) MarkEventAsNonRetryable(ctx context.Context, eventID string, errorMsg string) error {
+	r.nonRetrying = append(r.nonRetrying, eventID)
+	return nil
+}
+
+func (r *fakeRetryRepo) MarkEventRetrySucceeded(ctx context.Context, eventID string, executionID string, processingTimeMs int) error {
+	r.succeeded = append(r.succeeded, executionID)
+	return nil
+}
+
+func (r *fakeRetryRepo) ScheduleEventRetry(ctx context.Context, eventID string, errorMsg string, nextRetryAt time.Time) (bool, error) {
+	for _, event := range r.events {
+		if event.ID == eventID {
+			event.RetryCount++
+			if event.RetryCount >= r.maxRetries {
+				event.PermanentlyFailed = true
+				event.NextRetryAt = nil
+				return false, nil
+			}
+			event.NextRetryAt = &nextRetryAt
+			return true, nil
+		}
+	}
+	return false, ErrNotFound
+}
+
+func (r *fakeRetryRepo) RecordDeliveryAttempt(ctx context.Context, attempt *DeliveryAttempt) error {
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+// fakeExecutor fails its first failures executions
+type fakeExecutor struct {
+	failures    int
+	triggerData []byte
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, tenantID, workflowID, triggerType string, triggerData []byte) (string, error) {
+	e.triggerData = triggerData
+	if e.failures > 0 {
+		e.failures--
+		return "", errors.New("queue unavailable")
+	}
+	return "exec-1", nil
+}
+
+func TestRetryWorker_ProcessEvent_BacksOffAndLogsAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeRetryRepo{
+		webhook:    &Webhook{ID: "webhook-1", TenantID: "tenant-1", WorkflowID: "workflow-1", Enabled: true},
+		maxRetries: 4,
+		events: []*WebhookEvent{{
+			ID:            "event-1",
+			TenantID:      "tenant-1",
+			WebhookID:     "webhook-1",
+			RequestMethod: "POST",
+			RequestBody:   json.RawMessage(`{"order":1}`),
+			RetryCount:    1,
+		}},
+	}
+	executor := &fakeExecutor{failures: 2}
+	config := RetryConfig{BaseDelay: time.Minute, MaxDelay: time.Hour, Multiplier: 2.0}
+	worker := NewRetryWorker(repo, NewWorkflowDeliverer(executor), config)
+
+	// Second attempt fails and backs off twice the base delay
+	before := time.Now()
+	processed, err := worker.ProcessRetries(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	require.Len(t, repo.attempts, 1)
+	assert.Equal(t, 2, repo.attempts[0].Attempt)
+	assert.Equal(t, DeliveryStatusFailed, repo.attempts[0].Status)
+	require.NotNil(t, repo.attempts[0].NextRetryAt)
+	assert.WithinDuration(t, before.Add(2*time.Minute), *repo.attempts[0].NextRetryAt, time.Second)
+
+	// Third attempt fails and backs off four times the base delay
+	before = time.Now()
+	_, err = worker.ProcessRetries(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, repo.attempts, 2)
+	assert.Equal(t, 3, repo.attempts[1].Attempt)
+	assert.WithinDuration(t, before.Add(4*time.Minute), *repo.attempts[1].NextRetryAt, time.Second)
+
+	// Fourth attempt succeeds with the execution it started
+	_, err = worker.ProcessRetries(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, repo.attempts, 3)
+	assert.Equal(t, DeliveryStatusSucceeded, repo.attempts[2].Status)
+	assert.Nil(t, repo.attempts[2].NextRetryAt)
+	assert.Equal(t, []string{"exec-1"}, repo.succeeded)
+}
+
+func TestRetryWorker_ProcessEvent_RetriesExhausted(t *testing.T) {
+	repo := &fakeRetryRepo{
+		webhook:    &Webhook{ID: "webhook-1", Enabled: true},
+		maxRetries: 2,
+		events:     []*WebhookEvent{{ID: "event-1", WebhookID: "webhook-1", RetryCount: 1}},
+	}
+	worker := NewRetryWorker(repo, NewWorkflowDeliverer(&fakeExecutor{failures: 1}), DefaultRetryConfig())
+
+	_, err := worker.ProcessRetries(context.Background(), 10)
+	require.NoError(t, err)
+
+	assert.True(t, repo.events[0].PermanentlyFailed)
+	require.Len(t, repo.attempts, 1)
+	assert.Equal(t, DeliveryStatusFailed, repo.attempts[0].Status)
+	assert.Nil(t, repo.attempts[0].NextRetryAt, "no retry is due after the last attempt")
+}
+
+func TestRetryWorker_ProcessEvent_NonRetryable(t *testing.T) {
+	repo := &fakeRetryRepo{
+		webhook:    &Webhook{ID: "webhook-1", Enabled: true},
+		maxRetries: 3,
+		events:     []*WebhookEvent{{ID: "event-1", WebhookID: "webhook-1", RetryCount: 1}},
+	}
+	deliverer := new(MockWebhookDeliverer)
+	deliverer.On("DeliverWebhook", mock.Anything, mock.Anything, mock.Anything).
+		Return(&RetryResult{StatusCode: 400, Error: ErrWebhookValidationFailed}, nil)
+	worker := NewRetryWorker(repo, deliverer, DefaultRetryConfig())
+
+	processed, err := worker.ProcessRetries(context.Background(), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, processed)
+	assert.Equal(t, []string{"event-1"}, repo.nonRetrying)
+	require.Len(t, repo.attempts, 1)
+	require.NotNil(t, repo.attempts[0].StatusCode)
+	assert.Equal(t, 400, *repo.attempts[0].StatusCode)
+}
+
+func TestWorkflowDeliverer_DeliverWebhook(t *testing.T) {
+	executor := &fakeExecutor{}
+	deliverer := NewWorkflowDeliverer(executor)
+	event := &WebhookEvent{
+		RequestMethod:  "POST",
+		RequestHeaders: map[string]string{"Content-Type": "application/json"},
+		RequestBody:    json.RawMessage(`{"order":1}`),
+	}
+
+	result, err := deliverer.DeliverWebhook(context.Background(), &Webhook{WorkflowID: "workflow-1"}, event)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "exec-1", result.ExecutionID)
+
+	var triggerData map[string]interface{}
+	require.NoError(t, json.Unmarshal(executor.triggerData, &triggerData))
+	assert.Equal(t, "POST", triggerData["method"])
+	assert.Equal(t, map[string]interface{}{"order": float64(1)}, triggerData["body"])
+
+	executor.failures = 1
+	result, err = deliverer.DeliverWebhook(context.Background(), &Webhook{WorkflowID: "workflow-1"}, event)
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, IsRetryableWebhookError(result.Error))
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/eventtypes"
+	"github.com/gorax/gorax/internal/jsonschema"
+)
+
+// ErrEventTypeRegistryUnavailable is returned when a webhook is bound to an
+// event type but no event type registry is configured
+var ErrEventTypeRegistryUnavailable = errors.New("event type registry not configured")
+
+// EventTypeRegistry resolves and validates against registered event type schemas
+type EventTypeRegistry interface {
+	GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error)
+	ValidateEvent(ctx context.Context, name string, version int, payload []byte) ([]jsonschema.Violation, error)
+}
+
+// syncEventType applies the event type of a webhook node's config, rejecting
+// event types and versions that are not registered. Version 0 follows the
+// latest version.
+func (s *Service) syncEventType(ctx context.Context, wh *Webhook, name string, version int) error {
+	var eventType *string
+	var eventTypeVersion *int
+	if name != "" {
+		if s.eventTypes == nil {
+			return ErrEventTypeRegistryUnavailable
+		}
+		if _, err := s.eventTypes.GetEventSchema(ctx, name, version); err != nil {
+			return fmt.Errorf("event type %s: %w", name, err)
+		}
+		eventType = &name
+		if version > 0 {
+			eventTypeVersion = &version
+		}
+	}
+
+	if sameString(wh.EventType, eventType) && sameInt(wh.EventTypeVersion, eventTypeVersion) {
+		return nil
+	}
+
+	_, err := s.repo.UpdateEventType(ctx, wh.ID, eventType, eventTypeVersion)
+	return err
+}
+
+// validateEventType validates a request body against the schema of the
+// webhook's event type
+func (s *Service) validateEventType(ctx context.Context, webhook *Webhook, body []byte) ([]SchemaViolation, error) {
+	if webhook.EventType == nil || *webhook.EventType == "" {
+		return nil, nil
+	}
+	if s.eventTypes == nil {
+		return nil, ErrEventTypeRegistryUnavailable
+	}
+
+	version := 0
+	if webhook.EventTypeVersion != nil {
+		version = *webhook.EventTypeVersion
+	}
+	return s.eventTypes.ValidateEvent(ctx, *webhook.EventType, version, body)
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/eventtypes"
+	"github.com/gorax/gorax/internal/jsonschema"
+)
+
+// stubEventTypeRegistry validates payloads against fixed schemas keyed by event type name
+type stubEventTypeRegistry struct {
+	schemas  map[string]string
+	versions []int
+}
+
+func (r *stubEventTypeRegistry) GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error) {
+	raw, ok := r.schemas[name]
+	if !ok {
+		return nil, eventtypes.ErrNotFound
+	}
+	return &eventtypes.EventSchema{EventType: name, Version: 1, Schema: json.RawMessage(raw)}, nil
+}
+
+func (r *stubEventTypeRegistry) ValidateEvent(ctx context.Context, name string, version int, payload []byte) ([]jsonschema.Violation, error) {
+	r.versions = append(r.versions, version)
+	registered, err := r.GetEventSchema(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := jsonschema.Compile(registered.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ValidateJSON(payload), nil
+}
+
+// TestService_ValidatePayload_EventType tests validation against a webhook's registered event type
+func TestService_ValidatePayload_EventType(t *testing.T) {
+	registry := &stubEventTypeRegistry{schemas: map[string]string{
+		"order.created": `{"type": "object", "required": ["order_id"]}`,
+	}}
+	service := &Service{}
+	service.SetEventTypeRegistry(registry)
+
+	eventType := "order.created"
+	version := 2
+	schema := json.RawMessage(`{"type": "object", "required": ["customer"]}`)
+	wh := &Webhook{ID: "webhook-1", EventType: &eventType, EventTypeVersion: &version}
+
+	violations, err := service.ValidatePayload(context.Background(), wh, []byte(`{"order_id": "42"}`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+	assert.Equal(t, []int{2}, registry.versions, "pinned version should be passed to the registry")
+
+	violations, err = service.ValidatePayload(context.Background(), wh, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+
+	wh.PayloadSchema = &schema
+	violations, err = service.ValidatePayload(context.Background(), wh, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Len(t, violations, 2, "payload schema and event type violations should both be reported")
+
+	missing := "order.deleted"
+	_, err = service.ValidatePayload(context.Background(), &Webhook{ID: "webhook-2", EventType: &missing}, []byte(`{}`))
+	assert.ErrorIs(t, err, eventtypes.ErrNotFound)
+}
+
+func TestService_ValidatePayload_EventTypeWithoutRegistry(t *testing.T) {
+	eventType := "order.created"
+	service := &Service{}
+
+	_, err := service.ValidatePayload(context.Background(), &Webhook{ID: "webhook-1", EventType: &eventType}, []byte(`{}`))
+
+	assert.ErrorIs(t, err, ErrEventTypeRegistryUnavailable)
+}
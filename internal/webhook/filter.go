@@ -1,12 +1,14 @@
 package webhook
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorax/gorax/internal/validation"
 )
@@ -153,13 +155,17 @@ func (e *filterEvaluator) EvaluateSingle(filter *WebhookFilter, payload map[stri
 	case OpRegex:
 		return evaluateRegex(value, filter.Value)
 	case OpGreaterThan:
-		return evaluateGreaterThan(value, filter.Value)
+		order, err := compareOrdered("greater than", value, filter.Value, filter.ValueType)
+		return err == nil && order > 0, err
 	case OpGreaterThanOrEqual:
-		return evaluateGreaterThanOrEqual(value, filter.Value)
+		order, err := compareOrdered("greater than or equal", value, filter.Value, filter.ValueType)
+		return err == nil && order >= 0, err
 	case OpLessThan:
-		return evaluateLessThan(value, filter.Value)
+		order, err := compareOrdered("less than", value, filter.Value, filter.ValueType)
+		return err == nil && order < 0, err
 	case OpLessThanOrEqual:
-		return evaluateLessThanOrEqual(value, filter.Value)
+		order, err := compareOrdered("less than or equal", value, filter.Value, filter.ValueType)
+		return err == nil && order <= 0, err
 	case OpIn:
 		return evaluateIn(value, filter.Value)
 	case OpNotIn:
@@ -171,7 +177,7 @@ func (e *filterEvaluator) EvaluateSingle(filter *WebhookFilter, payload map[stri
 		result, err := evaluateIsEmpty(value)
 		return !result, err
 	case OpBetween:
-		return evaluateBetween(value, filter.Value)
+		return evaluateBetween(value, filter.Value, filter.ValueType)
 	case OpMatchesAny:
 		return evaluateMatchesAny(value, filter.Value)
 	case OpMatchesAll:
@@ -333,6 +339,102 @@ func toFloat64(v interface{}) (float64, bool) {
 	}
 }
 
+// filterDateLayouts are the date formats accepted by date comparisons
+var filterDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// toTime attempts to parse a value as an RFC 3339 timestamp or an ISO 8601
+// date. Values without a time zone are treated as UTC.
+func toTime(v interface{}) (time.Time, bool) {
+	str, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range filterDateLayouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// compareOrdered compares actual with expected for the ordering operators,
+// returning -1, 0 or +1. Without a value type, operands are compared as
+// numbers when both are numeric and as dates when both are dates.
+func compareOrdered(operator string, actual, expected interface{}, valueType FilterValueType) (int, error) {
+	switch valueType {
+	case FilterValueNumber:
+		return compareNumbers(operator, actual, expected)
+	case FilterValueDate:
+		return compareDates(operator, actual, expected)
+	case FilterValueString:
+		actualStr, ok := actual.(string)
+		if !ok {
+			return 0, fmt.Errorf("%s operator requires string value, got %T", operator, actual)
+		}
+		expectedStr, ok := expected.(string)
+		if !ok {
+			return 0, fmt.Errorf("%s operator requires string comparison value, got %T", operator, expected)
+		}
+		return strings.Compare(actualStr, expectedStr), nil
+	case FilterValueInferred:
+	default:
+		return 0, fmt.Errorf("unknown value type: %s", valueType)
+	}
+
+	_, actualIsNum := toFloat64(actual)
+	_, expectedIsNum := toFloat64(expected)
+	if actualIsNum && expectedIsNum {
+		return compareNumbers(operator, actual, expected)
+	}
+
+	_, actualIsDate := toTime(actual)
+	_, expectedIsDate := toTime(expected)
+	if actualIsDate && expectedIsDate {
+		return compareDates(operator, actual, expected)
+	}
+
+	switch {
+	case actualIsDate:
+		return 0, fmt.Errorf("%s operator requires date comparison value, got %v", operator, expected)
+	case !actualIsNum:
+		return 0, fmt.Errorf("%s operator requires numeric value, got %T", operator, actual)
+	default:
+		return 0, fmt.Errorf("%s operator requires numeric comparison value, got %T", operator, expected)
+	}
+}
+
+func compareNumbers(operator string, actual, expected interface{}) (int, error) {
+	actualNum, ok := toFloat64(actual)
+	if !ok {
+		return 0, fmt.Errorf("%s operator requires numeric value, got %T", operator, actual)
+	}
+
+	expectedNum, ok := toFloat64(expected)
+	if !ok {
+		return 0, fmt.Errorf("%s operator requires numeric comparison value, got %T", operator, expected)
+	}
+
+	return cmp.Compare(actualNum, expectedNum), nil
+}
+
+func compareDates(operator string, actual, expected interface{}) (int, error) {
+	actualTime, ok := toTime(actual)
+	if !ok {
+		return 0, fmt.Errorf("%s operator requires date value, got %v", operator, actual)
+	}
+
+	expectedTime, ok := toTime(expected)
+	if !ok {
+		return 0, fmt.Errorf("%s operator requires date comparison value, got %v", operator, expected)
+	}
+
+	return actualTime.Compare(expectedTime), nil
+}
+
 // evaluateContains checks if a string contains a substring
 func evaluateContains(actual, expected interface{}) (bool, error) {
 	actualStr, ok := actual.(string)
@@ -398,36 +500,6 @@ func evaluateRegex(actual, expected interface{}) (bool, error) {
 	return regex.MatchString(actualStr), nil
 }
 
-// evaluateGreaterThan checks if a number is greater than another
-func evaluateGreaterThan(actual, expected interface{}) (bool, error) {
-	actualNum, ok := toFloat64(actual)
-	if !ok {
-		return false, fmt.Errorf("greater than operator requires numeric value, got %T", actual)
-	}
-
-	expectedNum, ok := toFloat64(expected)
-	if !ok {
-		return false, fmt.Errorf("greater than operator requires numeric comparison value, got %T", expected)
-	}
-
-	return actualNum > expectedNum, nil
-}
-
-// evaluateLessThan checks if a number is less than another
-func evaluateLessThan(actual, expected interface{}) (bool, error) {
-	actualNum, ok := toFloat64(actual)
-	if !ok {
-		return false, fmt.Errorf("less than operator requires numeric value, got %T", actual)
-	}
-
-	expectedNum, ok := toFloat64(expected)
-	if !ok {
-		return false, fmt.Errorf("less than operator requires numeric comparison value, got %T", expected)
-	}
-
-	return actualNum < expectedNum, nil
-}
-
 // evaluateIn checks if a value is in an array
 func evaluateIn(actual, expected interface{}) (bool, error) {
 	expectedArr, ok := expected.([]interface{})
@@ -444,36 +516,6 @@ func evaluateIn(actual, expected interface{}) (bool, error) {
 	return false, nil
 }
 
-// evaluateGreaterThanOrEqual checks if a number is greater than or equal to another
-func evaluateGreaterThanOrEqual(actual, expected interface{}) (bool, error) {
-	actualNum, ok := toFloat64(actual)
-	if !ok {
-		return false, fmt.Errorf("greater than or equal operator requires numeric value, got %T", actual)
-	}
-
-	expectedNum, ok := toFloat64(expected)
-	if !ok {
-		return false, fmt.Errorf("greater than or equal operator requires numeric comparison value, got %T", expected)
-	}
-
-	return actualNum >= expectedNum, nil
-}
-
-// evaluateLessThanOrEqual checks if a number is less than or equal to another
-func evaluateLessThanOrEqual(actual, expected interface{}) (bool, error) {
-	actualNum, ok := toFloat64(actual)
-	if !ok {
-		return false, fmt.Errorf("less than or equal operator requires numeric value, got %T", actual)
-	}
-
-	expectedNum, ok := toFloat64(expected)
-	if !ok {
-		return false, fmt.Errorf("less than or equal operator requires numeric comparison value, got %T", expected)
-	}
-
-	return actualNum <= expectedNum, nil
-}
-
 // evaluateIsEmpty checks if a value is empty (string, array, or map)
 func evaluateIsEmpty(actual interface{}) (bool, error) {
 	if actual == nil {
@@ -492,13 +534,9 @@ func evaluateIsEmpty(actual interface{}) (bool, error) {
 	}
 }
 
-// evaluateBetween checks if a numeric value is within a range (inclusive)
-func evaluateBetween(actual, expected interface{}) (bool, error) {
-	actualNum, ok := toFloat64(actual)
-	if !ok {
-		return false, fmt.Errorf("between operator requires numeric value, got %T", actual)
-	}
-
+// evaluateBetween checks if a value is within a range (inclusive), compared
+// as compareOrdered does
+func evaluateBetween(actual, expected interface{}, valueType FilterValueType) (bool, error) {
 	rangeMap, ok := expected.(map[string]interface{})
 	if !ok {
 		return false, fmt.Errorf("between operator requires range object with 'min' and 'max', got %T", expected)
@@ -511,17 +549,17 @@ func evaluateBetween(actual, expected interface{}) (bool, error) {
 		return false, fmt.Errorf("between operator requires both 'min' and 'max' values in range object")
 	}
 
-	minNum, ok := toFloat64(minVal)
-	if !ok {
-		return false, fmt.Errorf("between operator requires numeric 'min' value, got %T", minVal)
+	minCmp, err := compareOrdered("between", actual, minVal, valueType)
+	if err != nil {
+		return false, err
 	}
 
-	maxNum, ok := toFloat64(maxVal)
-	if !ok {
-		return false, fmt.Errorf("between operator requires numeric 'max' value, got %T", maxVal)
+	maxCmp, err := compareOrdered("between", actual, maxVal, valueType)
+	if err != nil {
+		return false, err
 	}
 
-	return actualNum >= minNum && actualNum <= maxNum, nil
+	return minCmp >= 0 && maxCmp <= 0, nil
 }
 
 // evaluateMatchesAny checks if an array contains any of the specified values
@@ -2854,3 +2854,216 @@ func TestFilterEvaluator_MatchesAll(t *testing.T) {
 		})
 	}
 }
+
+// TestFilterEvaluator_DateComparison tests ordering operators on ISO 8601 dates
+func TestFilterEvaluator_DateComparison(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *WebhookFilter
+		payload  map[string]interface{}
+		expected bool
+		wantErr  bool
+	}{
+		{
+			name: "later timestamp is greater",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThan,
+				Value:     "2024-01-01T00:00:00Z",
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2024-03-15T10:30:00Z"},
+			expected: true,
+		},
+		{
+			name: "earlier timestamp is not greater",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThan,
+				Value:     "2024-01-01T00:00:00Z",
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2023-12-31T23:59:59Z"},
+			expected: false,
+		},
+		{
+			name: "time zones are compared chronologically",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpLessThan,
+				Value:     "2024-01-01T00:00:00Z",
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2024-01-01T00:30:00+01:00"},
+			expected: true,
+		},
+		{
+			name: "same instant is greater than or equal",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThanOrEqual,
+				Value:     "2024-01-01T01:00:00+01:00",
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2024-01-01T00:00:00Z"},
+			expected: true,
+		},
+		{
+			name: "date only",
+			filter: &WebhookFilter{
+				FieldPath: "$.due",
+				Operator:  OpLessThanOrEqual,
+				Value:     "2024-06-30",
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"due": "2024-06-01"},
+			expected: true,
+		},
+		{
+			name: "between dates",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpBetween,
+				Value:     map[string]interface{}{"min": "2024-01-01T00:00:00Z", "max": "2024-12-31T23:59:59Z"},
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2024-07-04T12:00:00.123Z"},
+			expected: true,
+		},
+		{
+			name: "outside date range",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpBetween,
+				Value:     map[string]interface{}{"min": "2024-01-01", "max": "2024-12-31"},
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"created_at": "2025-01-02"},
+			expected: false,
+		},
+		{
+			name: "date compared with number",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThan,
+				Value:     1700000000,
+				Enabled:   true,
+			},
+			payload: map[string]interface{}{"created_at": "2024-01-01T00:00:00Z"},
+			wantErr: true,
+		},
+	}
+
+	evaluator := NewFilterEvaluator(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateSingle(tt.filter, tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFilterEvaluator_ValueType tests that the value type hint picks the comparison
+func TestFilterEvaluator_ValueType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *WebhookFilter
+		payload  map[string]interface{}
+		expected bool
+		wantErr  bool
+	}{
+		{
+			name: "number compares numeric strings numerically",
+			filter: &WebhookFilter{
+				FieldPath: "$.version",
+				Operator:  OpGreaterThan,
+				Value:     "9",
+				ValueType: FilterValueNumber,
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"version": "10"},
+			expected: true,
+		},
+		{
+			name: "string compares numeric strings lexically",
+			filter: &WebhookFilter{
+				FieldPath: "$.version",
+				Operator:  OpGreaterThan,
+				Value:     "9",
+				ValueType: FilterValueString,
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"version": "10"},
+			expected: false,
+		},
+		{
+			name: "string between",
+			filter: &WebhookFilter{
+				FieldPath: "$.sku",
+				Operator:  OpBetween,
+				Value:     map[string]interface{}{"min": "A", "max": "M"},
+				ValueType: FilterValueString,
+				Enabled:   true,
+			},
+			payload:  map[string]interface{}{"sku": "D-100"},
+			expected: true,
+		},
+		{
+			name: "date rejects non-date field",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThan,
+				Value:     "2024-01-01T00:00:00Z",
+				ValueType: FilterValueDate,
+				Enabled:   true,
+			},
+			payload: map[string]interface{}{"created_at": "yesterday"},
+			wantErr: true,
+		},
+		{
+			name: "number rejects date field",
+			filter: &WebhookFilter{
+				FieldPath: "$.created_at",
+				Operator:  OpGreaterThan,
+				Value:     "2024-01-01T00:00:00Z",
+				ValueType: FilterValueNumber,
+				Enabled:   true,
+			},
+			payload: map[string]interface{}{"created_at": "2024-03-15T10:30:00Z"},
+			wantErr: true,
+		},
+		{
+			name: "unknown value type",
+			filter: &WebhookFilter{
+				FieldPath: "$.count",
+				Operator:  OpLessThan,
+				Value:     10,
+				ValueType: "duration",
+				Enabled:   true,
+			},
+			payload: map[string]interface{}{"count": 5},
+			wantErr: true,
+		},
+	}
+
+	evaluator := NewFilterEvaluator(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.EvaluateSingle(tt.filter, tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultIdempotencyTTL is how long a delivery ID is remembered when the webhook sets no TTL
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// MinIdempotencyTTL is the shortest allowed delivery ID TTL
+	MinIdempotencyTTL = time.Minute
+	// MaxIdempotencyTTL is the longest allowed delivery ID TTL
+	MaxIdempotencyTTL = 30 * 24 * time.Hour
+)
+
+// DeliveryCleanupRepository is implemented by cleanup repositories that can
+// also sweep expired webhook delivery IDs
+type DeliveryCleanupRepository interface {
+	DeleteExpiredDeliveries(ctx context.Context, batchSize int) (int, error)
+}
+
+// ParseIdempotencyTTL parses a delivery ID TTL such as "1h" or "72h".
+// An empty TTL means DefaultIdempotencyTTL.
+func ParseIdempotencyTTL(ttl string) (time.Duration, error) {
+	if ttl == "" {
+		return DefaultIdempotencyTTL, nil
+	}
+
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid idempotency TTL: %w", err)
+	}
+	if d < MinIdempotencyTTL || d > MaxIdempotencyTTL {
+		return 0, fmt.Errorf("invalid idempotency TTL: must be between %s and %s", MinIdempotencyTTL, MaxIdempotencyTTL)
+	}
+	return d, nil
+}
+
+// IdempotencyTTL returns how long the webhook remembers delivery IDs
+func (w *Webhook) IdempotencyTTL() time.Duration {
+	if w.IdempotencyTTLSeconds <= 0 {
+		return DefaultIdempotencyTTL
+	}
+	return time.Duration(w.IdempotencyTTLSeconds) * time.Second
+}
+
+// ClaimDelivery records a delivery ID for a webhook. It returns false when the
+// ID was already seen within the webhook's idempotency TTL, meaning the
+// request is a provider retry of a delivery that was already handled.
+func (s *Service) ClaimDelivery(ctx context.Context, webhook *Webhook, deliveryID string) (bool, error) {
+	claimed, err := s.repo.ClaimDelivery(ctx, webhook.ID, deliveryID, webhook.IdempotencyTTL())
+	if err != nil {
+		s.logger.Error("failed to claim webhook delivery", "error", err, "webhook_id", webhook.ID, "delivery_id", deliveryID)
+		return false, err
+	}
+	return claimed, nil
+}
+
+// ReleaseDelivery forgets a claimed delivery ID so that a provider retry of a
+// delivery that failed to trigger its workflow is processed again
+func (s *Service) ReleaseDelivery(ctx context.Context, webhook *Webhook, deliveryID string) error {
+	if err := s.repo.ReleaseDelivery(ctx, webhook.ID, deliveryID); err != nil {
+		s.logger.Error("failed to release webhook delivery", "error", err, "webhook_id", webhook.ID, "delivery_id", deliveryID)
+		return err
+	}
+	return nil
+}
+
+// syncIdempotencyConfig applies the idempotency header and TTL of a webhook node's config
+func (s *Service) syncIdempotencyConfig(ctx context.Context, wh *Webhook, header, ttl string) error {
+	d, err := ParseIdempotencyTTL(ttl)
+	if err != nil {
+		return err
+	}
+
+	ttlSeconds := int(d / time.Second)
+	if header == wh.IdempotencyHeader && ttlSeconds == wh.IdempotencyTTLSeconds {
+		return nil
+	}
+
+	_, err = s.repo.UpdateIdempotencyConfig(ctx, wh.ID, header, ttlSeconds)
+	return err
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdempotencyTTL(t *testing.T) {
+	tests := []struct {
+		ttl      string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{ttl: "", expected: DefaultIdempotencyTTL},
+		{ttl: "1h", expected: time.Hour},
+		{ttl: "720h", expected: MaxIdempotencyTTL},
+		{ttl: "30s", wantErr: true},
+		{ttl: "721h", wantErr: true},
+		{ttl: "one day", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ttl, func(t *testing.T) {
+			d, err := ParseIdempotencyTTL(tt.ttl)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestWebhook_IdempotencyTTL(t *testing.T) {
+	assert.Equal(t, DefaultIdempotencyTTL, (&Webhook{}).IdempotencyTTL())
+	assert.Equal(t, 2*time.Hour, (&Webhook{IdempotencyTTLSeconds: 7200}).IdempotencyTTL())
+}
@@ -8,21 +8,29 @@ import (
 
 // Webhook represents a webhook configuration
 type Webhook struct {
-	ID              string     `db:"id" json:"id"`
-	TenantID        string     `db:"tenant_id" json:"tenant_id"`
-	WorkflowID      string     `db:"workflow_id" json:"workflow_id"`
-	NodeID          string     `db:"node_id" json:"node_id"`
-	Name            string     `db:"name" json:"name"`
-	Path            string     `db:"path" json:"path"`
-	Secret          string     `db:"secret" json:"secret"`
-	AuthType        string     `db:"auth_type" json:"auth_type"`
-	Description     string     `db:"description" json:"description"`
-	Priority        int        `db:"priority" json:"priority"`
-	Enabled         bool       `db:"enabled" json:"enabled"`
-	TriggerCount    int        `db:"trigger_count" json:"trigger_count"`
-	LastTriggeredAt *time.Time `db:"last_triggered_at" json:"last_triggered_at,omitempty"`
-	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+	ID                    string           `db:"id" json:"id"`
+	TenantID              string           `db:"tenant_id" json:"tenant_id"`
+	WorkflowID            string           `db:"workflow_id" json:"workflow_id"`
+	NodeID                string           `db:"node_id" json:"node_id"`
+	Name                  string           `db:"name" json:"name"`
+	Path                  string           `db:"path" json:"path"`
+	Secret                string           `db:"secret" json:"secret"`
+	AuthType              string           `db:"auth_type" json:"auth_type"`
+	SignatureScheme       string           `db:"signature_scheme" json:"signature_scheme"`
+	IdempotencyHeader     string           `db:"idempotency_header" json:"idempotency_header,omitempty"`
+	IdempotencyTTLSeconds int              `db:"idempotency_ttl_seconds" json:"idempotency_ttl_seconds"`
+	PayloadSchema         *json.RawMessage `db:"payload_schema" json:"payload_schema,omitempty"`
+	EventType             *string          `db:"event_type" json:"event_type,omitempty"`
+	EventTypeVersion      *int             `db:"event_type_version" json:"event_type_version,omitempty"`
+	Description           string           `db:"description" json:"description"`
+	Priority              int              `db:"priority" json:"priority"`
+	Enabled               bool             `db:"enabled" json:"enabled"`
+	TriggerCount          int              `db:"trigger_count" json:"trigger_count"`
+	LastTriggeredAt       *time.Time       `db:"last_triggered_at" json:"last_triggered_at,omitempty"`
+	ResponseConfig        *ResponseConfig  `db:"response_config" json:"response_config,omitempty"`
+	CaptureConfig         *CaptureConfig   `db:"capture_config" json:"capture_config,omitempty"`
+	CreatedAt             time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt             time.Time        `db:"updated_at" json:"updated_at"`
 }
 
 // WebhookURL returns the full webhook URL path
@@ -153,17 +161,31 @@ const (
 	OpMatchesAll         FilterOperator = "matches_all"
 )
 
+// FilterValueType tells the gt, gte, lt, lte and between operators how to
+// compare a field with the filter value
+type FilterValueType string
+
+const (
+	// FilterValueInferred compares numerically when both operands are
+	// numbers, and chronologically when both are dates
+	FilterValueInferred FilterValueType = ""
+	FilterValueNumber   FilterValueType = "number"
+	FilterValueDate     FilterValueType = "date"
+	FilterValueString   FilterValueType = "string"
+)
+
 // WebhookFilter represents a filter rule for webhook payload evaluation
 type WebhookFilter struct {
-	ID         string         `json:"id" db:"id"`
-	WebhookID  string         `json:"webhookId" db:"webhook_id"`
-	FieldPath  string         `json:"fieldPath" db:"field_path"` // JSON path like "$.data.status"
-	Operator   FilterOperator `json:"operator" db:"operator"`
-	Value      interface{}    `json:"value" db:"value"`
-	LogicGroup int            `json:"logicGroup" db:"logic_group"` // For AND/OR grouping
-	Enabled    bool           `json:"enabled" db:"enabled"`
-	CreatedAt  time.Time      `json:"createdAt" db:"created_at"`
-	UpdatedAt  time.Time      `json:"updatedAt" db:"updated_at"`
+	ID         string          `json:"id" db:"id"`
+	WebhookID  string          `json:"webhookId" db:"webhook_id"`
+	FieldPath  string          `json:"fieldPath" db:"field_path"` // JSON path like "$.data.status"
+	Operator   FilterOperator  `json:"operator" db:"operator"`
+	Value      interface{}     `json:"value" db:"value"`
+	ValueType  FilterValueType `json:"valueType,omitempty" db:"value_type"`
+	LogicGroup int             `json:"logicGroup" db:"logic_group"` // For AND/OR grouping
+	Enabled    bool            `json:"enabled" db:"enabled"`
+	CreatedAt  time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt  time.Time       `json:"updatedAt" db:"updated_at"`
 }
 
 // FilterResult represents the result of filter evaluation
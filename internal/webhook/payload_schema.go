@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/gorax/gorax/internal/jsonschema"
+)
+
+// ErrInvalidPayloadSchema is returned when a webhook's payload schema cannot be compiled
+var ErrInvalidPayloadSchema = jsonschema.ErrInvalidSchema
+
+// SchemaViolation describes one way a payload does not match a webhook's payload schema
+type SchemaViolation = jsonschema.Violation
+
+// PayloadSchema is a compiled JSON Schema for webhook payloads
+type PayloadSchema = jsonschema.Schema
+
+// CompilePayloadSchema parses and compiles a webhook payload schema
+func CompilePayloadSchema(raw []byte) (*PayloadSchema, error) {
+	return jsonschema.Compile(raw)
+}
+
+// cachedPayloadSchema is a compiled payload schema together with the source it was compiled from
+type cachedPayloadSchema struct {
+	source string
+	schema *PayloadSchema
+}
+
+// ValidatePayload validates a request body against the webhook's payload
+// schema and, when the webhook is bound to an event type, against that event
+// type's registered schema. Webhooks without either accept any body.
+func (s *Service) ValidatePayload(ctx context.Context, webhook *Webhook, body []byte) ([]SchemaViolation, error) {
+	violations, err := s.validatePayloadSchema(webhook, body)
+	if err != nil {
+		return nil, err
+	}
+
+	eventViolations, err := s.validateEventType(ctx, webhook, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(violations, eventViolations...), nil
+}
+
+// validatePayloadSchema validates a request body against the webhook's own
+// payload schema. Compiled schemas are cached per webhook and recompiled when
+// the webhook's schema changes.
+func (s *Service) validatePayloadSchema(webhook *Webhook, body []byte) ([]SchemaViolation, error) {
+	if webhook.PayloadSchema == nil || len(*webhook.PayloadSchema) == 0 {
+		return nil, nil
+	}
+
+	source := string(*webhook.PayloadSchema)
+	if cached, ok := s.payloadSchemas.Load(webhook.ID); ok {
+		if entry := cached.(*cachedPayloadSchema); entry.source == source {
+			return entry.schema.ValidateJSON(body), nil
+		}
+	}
+
+	schema, err := CompilePayloadSchema(*webhook.PayloadSchema)
+	if err != nil {
+		return nil, err
+	}
+	s.payloadSchemas.Store(webhook.ID, &cachedPayloadSchema{source: source, schema: schema})
+
+	return schema.ValidateJSON(body), nil
+}
+
+// syncPayloadSchema applies the payload schema of a webhook node's config,
+// rejecting schemas that do not compile
+func (s *Service) syncPayloadSchema(ctx context.Context, wh *Webhook, raw json.RawMessage) error {
+	var schema *json.RawMessage
+	if len(raw) > 0 && string(raw) != "null" {
+		if _, err := CompilePayloadSchema(raw); err != nil {
+			return err
+		}
+		compacted, err := compactRaw(raw)
+		if err != nil {
+			return err
+		}
+		schema = &compacted
+	}
+
+	if samePayloadSchema(wh.PayloadSchema, schema) {
+		return nil
+	}
+
+	_, err := s.repo.UpdatePayloadSchema(ctx, wh.ID, schema)
+	return err
+}
+
+// compactRaw removes insignificant whitespace so stored schemas compare equal
+func compactRaw(raw json.RawMessage) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func samePayloadSchema(current, next *json.RawMessage) bool {
+	if current == nil || next == nil {
+		return current == nil && next == nil
+	}
+	compacted, err := compactRaw(*current)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(compacted, *next)
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestService_ValidatePayload_CachesSchema tests that compiled schemas are reused until the schema changes
+func TestService_ValidatePayload_CachesSchema(t *testing.T) {
+	service := &Service{}
+
+	schema := json.RawMessage(`{"type": "object"}`)
+	wh := &Webhook{ID: "webhook-1", PayloadSchema: &schema}
+
+	violations, err := service.ValidatePayload(context.Background(), wh, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	cached, ok := service.payloadSchemas.Load("webhook-1")
+	require.True(t, ok)
+
+	_, err = service.ValidatePayload(context.Background(), wh, []byte(`[]`))
+	require.NoError(t, err)
+	again, _ := service.payloadSchemas.Load("webhook-1")
+	assert.Same(t, cached, again, "unchanged schema should not be recompiled")
+
+	updated := json.RawMessage(`{"type": "array"}`)
+	wh.PayloadSchema = &updated
+
+	violations, err = service.ValidatePayload(context.Background(), wh, []byte(`[]`))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+	recompiled, _ := service.payloadSchemas.Load("webhook-1")
+	assert.NotSame(t, cached, recompiled)
+
+	violations, err = service.ValidatePayload(context.Background(), &Webhook{ID: "webhook-2"}, []byte(`not json`))
+	require.NoError(t, err)
+	assert.Empty(t, violations, "webhooks without a schema accept any body")
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+
+	"github.com/gorax/gorax/internal/workflow"
 )
 
 const (
@@ -26,6 +28,12 @@ type ReplayRepository interface {
 	CreateEvent(ctx context.Context, event *WebhookEvent) error
 }
 
+// CaptureReplayRepository is implemented by replay repositories that can
+// also load captured requests
+type CaptureReplayRepository interface {
+	GetCapture(ctx context.Context, tenantID, webhookID, captureID string) (*WebhookCapture, error)
+}
+
 // ReplayService handles webhook event replay logic
 type ReplayService struct {
 	repo     ReplayRepository
@@ -86,7 +94,7 @@ func (s *ReplayService) ReplayEvent(ctx context.Context, tenantID, eventID strin
 	}
 
 	// Execute the workflow
-	executionID, err := s.executor.Execute(ctx, tenantID, webhook.WorkflowID, "webhook_replay", payload)
+	executionID, err := s.executor.Execute(workflow.WithTriggerNode(ctx, webhook.NodeID), tenantID, webhook.WorkflowID, "webhook_replay", payload)
 	if err != nil {
 		result.Success = false
 		result.Error = fmt.Sprintf("execution failed: %v", err)
@@ -146,6 +154,60 @@ func (s *ReplayService) BatchReplayEvents(ctx context.Context, tenantID, webhook
 	return response
 }
 
+// ReplayCapture re-triggers a webhook's workflow with a captured request, as
+// if it had just been received. Signature verification, filters and
+// idempotency checks are skipped, and redacted headers reach the workflow as
+// CaptureRedactedValue.
+func (s *ReplayService) ReplayCapture(ctx context.Context, tenantID, webhookID, captureID string) *ReplayResult {
+	result := &ReplayResult{}
+
+	repo, ok := s.repo.(CaptureReplayRepository)
+	if !ok {
+		result.Error = "request capture is not supported"
+		return result
+	}
+
+	capture, err := repo.GetCapture(ctx, tenantID, webhookID, captureID)
+	if err != nil {
+		result.Error = fmt.Sprintf("capture not found: %v", err)
+		s.logError("failed to get capture", err, "capture_id", captureID)
+		return result
+	}
+
+	webhook, err := s.repo.GetByID(ctx, capture.WebhookID)
+	if err != nil {
+		result.Error = fmt.Sprintf("webhook not found: %v", err)
+		s.logError("failed to get webhook", err, "webhook_id", capture.WebhookID)
+		return result
+	}
+
+	if !webhook.Enabled {
+		result.Error = "webhook is disabled"
+		s.logInfo("webhook is disabled", "webhook_id", webhook.ID)
+		return result
+	}
+
+	triggerData, err := capture.TriggerData()
+	if err != nil {
+		result.Error = err.Error()
+		s.logError("failed to build trigger data from capture", err, "capture_id", captureID)
+		return result
+	}
+
+	executionID, err := s.executor.Execute(workflow.WithTriggerNode(ctx, webhook.NodeID), tenantID, webhook.WorkflowID, "webhook_replay", triggerData)
+	if err != nil {
+		result.Error = fmt.Sprintf("execution failed: %v", err)
+		s.logError("workflow execution failed", err, "workflow_id", webhook.WorkflowID)
+		return result
+	}
+
+	result.Success = true
+	result.ExecutionID = executionID
+	s.logInfo("capture replayed successfully", "capture_id", captureID, "execution_id", executionID)
+
+	return result
+}
+
 // Helper logging methods to reduce complexity
 
 func (s *ReplayService) logError(msg string, err error, args ...interface{}) {
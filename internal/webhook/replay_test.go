@@ -76,7 +76,7 @@ func TestReplayService_ReplayEvent_Success(t *testing.T) {
 
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil)
 
 	result := service.ReplayEvent(ctx, tenantID, eventID, nil)
@@ -120,7 +120,7 @@ func TestReplayService_ReplayEvent_WithModifiedPayload(t *testing.T) {
 
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(modifiedPayload)).Return("exec-123", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(modifiedPayload)).Return("exec-123", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil)
 
 	result := service.ReplayEvent(ctx, tenantID, eventID, modifiedPayload)
@@ -291,8 +291,8 @@ func TestReplayService_BatchReplayEvents_Success(t *testing.T) {
 	mockRepo.On("GetEventByID", ctx, tenantID, "event-1").Return(event1, nil)
 	mockRepo.On("GetEventByID", ctx, tenantID, "event-2").Return(event2, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil).Times(2)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(event1.RequestBody)).Return("exec-1", nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(event2.RequestBody)).Return("exec-2", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(event1.RequestBody)).Return("exec-1", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(event2.RequestBody)).Return("exec-2", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil).Times(2)
 
 	results := service.BatchReplayEvents(ctx, tenantID, webhookID, eventIDs)
@@ -367,7 +367,7 @@ func TestReplayService_BatchReplayEvents_PartialSuccess(t *testing.T) {
 	mockRepo.On("GetEventByID", ctx, tenantID, "event-1").Return(event1, nil)
 	mockRepo.On("GetEventByID", ctx, tenantID, "event-2").Return(nil, ErrNotFound)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil).Once()
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(event1.RequestBody)).Return("exec-1", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(event1.RequestBody)).Return("exec-1", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil).Once()
 
 	results := service.BatchReplayEvents(ctx, tenantID, webhookID, eventIDs)
@@ -409,7 +409,7 @@ func TestReplayService_ReplayEvent_ExecutionFailure(t *testing.T) {
 
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(event.RequestBody)).
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(event.RequestBody)).
 		Return("", assert.AnError)
 
 	result := service.ReplayEvent(ctx, tenantID, eventID, nil)
@@ -452,7 +452,7 @@ func TestReplayService_ReplayEvent_CreateEventFailure(t *testing.T) {
 
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(assert.AnError)
 
 	result := service.ReplayEvent(ctx, tenantID, eventID, nil)
@@ -497,7 +497,7 @@ func TestReplayService_ReplayEvent_EmptyModifiedPayload(t *testing.T) {
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
 	// Should use original payload when modified is empty
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(originalPayload)).Return("exec-123", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(originalPayload)).Return("exec-123", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil)
 
 	// Test with empty JSON array
@@ -542,7 +542,7 @@ func TestReplayService_ReplayEvent_BoundaryReplayCount(t *testing.T) {
 
 	mockRepo.On("GetEventByID", ctx, tenantID, eventID).Return(event, nil)
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", []byte(requestBody)).Return("exec-123", nil)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).Return(nil)
 
 	result := service.ReplayEvent(ctx, tenantID, eventID, nil)
@@ -608,7 +608,7 @@ func TestReplayService_BatchReplayEvents_ExactlyAtMaxBatchSize(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", ctx, webhookID).Return(webhook, nil).Times(MaxBatchReplaySize)
-	mockExecutor.On("Execute", ctx, tenantID, workflowID, "webhook_replay", mock.Anything).
+	mockExecutor.On("Execute", mock.Anything, tenantID, workflowID, "webhook_replay", mock.Anything).
 		Return("exec-123", nil).Times(MaxBatchReplaySize)
 	mockRepo.On("CreateEvent", ctx, mock.AnythingOfType("*webhook.WebhookEvent")).
 		Return(nil).Times(MaxBatchReplaySize)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 var (
@@ -497,6 +498,284 @@ func (r *Repository) UpdateSecret(ctx context.Context, id, secret string) (*Webh
 	return &webhook, nil
 }
 
+// UpdateSignatureConfig updates the signature scheme and secret of a webhook
+func (r *Repository) UpdateSignatureConfig(ctx context.Context, id, scheme, secret string) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET signature_scheme = $2, secret = $3, updated_at = $4
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, scheme, secret, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdateIdempotencyConfig updates the delivery ID header and TTL of a webhook
+func (r *Repository) UpdateIdempotencyConfig(ctx context.Context, id, header string, ttlSeconds int) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET idempotency_header = $2, idempotency_ttl_seconds = $3, updated_at = $4
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, header, ttlSeconds, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdatePayloadSchema sets or clears the JSON Schema that webhook payloads must match
+func (r *Repository) UpdatePayloadSchema(ctx context.Context, id string, schema *json.RawMessage) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET payload_schema = $2, updated_at = $3
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, schema, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdateEventType sets or clears the event type whose schema webhook payloads must match
+func (r *Repository) UpdateEventType(ctx context.Context, id string, eventType *string, version *int) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET event_type = $2, event_type_version = $3, updated_at = $4
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, eventType, version, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// ClaimDelivery records a delivery ID for a webhook until now + ttl. It returns
+// false when the ID is already recorded and has not expired. An expired record
+// is claimed again, so the check and the insert are a single statement.
+func (r *Repository) ClaimDelivery(ctx context.Context, webhookID, deliveryID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, delivery_id, received_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (webhook_id, delivery_id) DO UPDATE
+		SET received_at = EXCLUDED.received_at, expires_at = EXCLUDED.expires_at
+		WHERE webhook_deliveries.expires_at <= EXCLUDED.received_at
+		RETURNING delivery_id
+	`
+
+	var claimed string
+	err := r.db.QueryRowxContext(ctx, query, webhookID, deliveryID, now, now.Add(ttl)).Scan(&claimed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim delivery: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseDelivery deletes a recorded delivery ID
+func (r *Repository) ReleaseDelivery(ctx context.Context, webhookID, deliveryID string) error {
+	query := `DELETE FROM webhook_deliveries WHERE webhook_id = $1 AND delivery_id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, webhookID, deliveryID); err != nil {
+		return fmt.Errorf("release delivery: %w", err)
+	}
+	return nil
+}
+
+// UpdateResponseConfig updates the per-outcome response configuration
+func (r *Repository) UpdateResponseConfig(ctx context.Context, id string, config *ResponseConfig) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET response_config = $2, updated_at = $3
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, config, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// UpdateCaptureConfig updates the request capture configuration
+func (r *Repository) UpdateCaptureConfig(ctx context.Context, id string, config *CaptureConfig) (*Webhook, error) {
+	query := `
+		UPDATE webhooks
+		SET capture_config = $2, updated_at = $3
+		WHERE id = $1
+		RETURNING *
+	`
+
+	var webhook Webhook
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		id, config, time.Now(),
+	).StructScan(&webhook)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// CreateCapture stores a captured request
+func (r *Repository) CreateCapture(ctx context.Context, capture *WebhookCapture) error {
+	query := `
+		INSERT INTO webhook_captures (tenant_id, webhook_id, method, query, headers, body, remote_addr, captured_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowxContext(
+		ctx, query,
+		capture.TenantID, capture.WebhookID, capture.Method, capture.Query, capture.Headers,
+		capture.Body, capture.RemoteAddr, capture.CapturedAt, capture.ExpiresAt,
+	).Scan(&capture.ID)
+	if err != nil {
+		return fmt.Errorf("create capture: %w", err)
+	}
+	return nil
+}
+
+// ListCaptures retrieves the unexpired captures of a webhook, newest first
+func (r *Repository) ListCaptures(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*WebhookCapture, int, error) {
+	now := time.Now()
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM webhook_captures WHERE tenant_id = $1 AND webhook_id = $2 AND expires_at > $3`
+	if err := r.db.GetContext(ctx, &total, countQuery, tenantID, webhookID, now); err != nil {
+		return nil, 0, fmt.Errorf("count captures: %w", err)
+	}
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT * FROM webhook_captures
+		WHERE tenant_id = $1 AND webhook_id = $2 AND expires_at > $3
+		ORDER BY captured_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	captures := []*WebhookCapture{}
+	if err := r.db.SelectContext(ctx, &captures, query, tenantID, webhookID, now, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("list captures: %w", err)
+	}
+
+	return captures, total, nil
+}
+
+// GetCapture retrieves an unexpired capture of a webhook
+func (r *Repository) GetCapture(ctx context.Context, tenantID, webhookID, captureID string) (*WebhookCapture, error) {
+	query := `
+		SELECT * FROM webhook_captures
+		WHERE id = $1 AND tenant_id = $2 AND webhook_id = $3 AND expires_at > $4
+	`
+
+	var capture WebhookCapture
+	if err := r.db.GetContext(ctx, &capture, query, captureID, tenantID, webhookID, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get capture: %w", err)
+	}
+
+	return &capture, nil
+}
+
+// DeleteExpiredCaptures deletes a batch of captures whose TTL has passed
+func (r *Repository) DeleteExpiredCaptures(ctx context.Context, batchSize int) (int, error) {
+	query := `
+		DELETE FROM webhook_captures
+		WHERE id IN (
+			SELECT id FROM webhook_captures
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired captures: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
 // GetByIDAndTenant retrieves a webhook by ID and tenant ID
 func (r *Repository) GetByIDAndTenant(ctx context.Context, id, tenantID string) (*Webhook, error) {
 	query := `SELECT * FROM webhooks WHERE id = $1 AND tenant_id = $2`
@@ -542,8 +821,8 @@ func (r *Repository) GetFilterByID(ctx context.Context, filterID string) (*Webho
 // CreateFilter creates a new webhook filter
 func (r *Repository) CreateFilter(ctx context.Context, filter *WebhookFilter) (*WebhookFilter, error) {
 	query := `
-		INSERT INTO webhook_filters (id, webhook_id, field_path, operator, value, logic_group, enabled, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		INSERT INTO webhook_filters (id, webhook_id, field_path, operator, value, value_type, logic_group, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
 		RETURNING *`
 
 	var created WebhookFilter
@@ -553,6 +832,7 @@ func (r *Repository) CreateFilter(ctx context.Context, filter *WebhookFilter) (*
 		filter.FieldPath,
 		filter.Operator,
 		filter.Value,
+		filter.ValueType,
 		filter.LogicGroup,
 		filter.Enabled,
 	)
@@ -567,7 +847,7 @@ func (r *Repository) CreateFilter(ctx context.Context, filter *WebhookFilter) (*
 func (r *Repository) UpdateFilter(ctx context.Context, filter *WebhookFilter) (*WebhookFilter, error) {
 	query := `
 		UPDATE webhook_filters
-		SET field_path = $2, operator = $3, value = $4, logic_group = $5, enabled = $6, updated_at = NOW()
+		SET field_path = $2, operator = $3, value = $4, value_type = $5, logic_group = $6, enabled = $7, updated_at = NOW()
 		WHERE id = $1
 		RETURNING *`
 
@@ -577,6 +857,7 @@ func (r *Repository) UpdateFilter(ctx context.Context, filter *WebhookFilter) (*
 		filter.FieldPath,
 		filter.Operator,
 		filter.Value,
+		filter.ValueType,
 		filter.LogicGroup,
 		filter.Enabled,
 	)
@@ -641,6 +922,108 @@ func (r *Repository) DeleteOldEvents(ctx context.Context, retentionPeriod time.D
 	return int(rows), nil
 }
 
+// GetRetentionOverrides returns the workflows whose webhook events use their own retention period
+func (r *Repository) GetRetentionOverrides(ctx context.Context) ([]RetentionOverride, error) {
+	query := `
+		SELECT id AS workflow_id, retention_days
+		FROM workflows
+		WHERE retention_days IS NOT NULL
+		ORDER BY id
+	`
+
+	var overrides []RetentionOverride
+	if err := r.db.SelectContext(ctx, &overrides, query); err != nil {
+		return nil, fmt.Errorf("get retention overrides: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// DeleteOldWorkflowEvents deletes a batch of a workflow's webhook events older than the retention period
+func (r *Repository) DeleteOldWorkflowEvents(ctx context.Context, workflowID string, retentionPeriod time.Duration, batchSize int) (int, error) {
+	cutoffTime := time.Now().Add(-retentionPeriod)
+
+	query := `
+		DELETE FROM webhook_events
+		WHERE id IN (
+			SELECT e.id FROM webhook_events e
+			JOIN webhooks w ON w.id = e.webhook_id
+			WHERE w.workflow_id = $1 AND e.created_at < $2
+			ORDER BY e.created_at
+			LIMIT $3
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, workflowID, cutoffTime, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("delete old workflow events: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// DeleteOldEventsExcluding deletes a batch of webhook events older than the
+// retention period, skipping events of the excluded workflows
+func (r *Repository) DeleteOldEventsExcluding(ctx context.Context, retentionPeriod time.Duration, batchSize int, excludedWorkflowIDs []string) (int, error) {
+	cutoffTime := time.Now().Add(-retentionPeriod)
+
+	query := `
+		DELETE FROM webhook_events
+		WHERE id IN (
+			SELECT e.id FROM webhook_events e
+			WHERE e.created_at < $1
+			  AND NOT EXISTS (
+				SELECT 1 FROM webhooks w
+				WHERE w.id = e.webhook_id AND w.workflow_id::text = ANY($3)
+			  )
+			ORDER BY e.created_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, cutoffTime, batchSize, pq.Array(excludedWorkflowIDs))
+	if err != nil {
+		return 0, fmt.Errorf("delete old events: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// DeleteExpiredDeliveries deletes a batch of delivery IDs whose TTL has passed
+func (r *Repository) DeleteExpiredDeliveries(ctx context.Context, batchSize int) (int, error) {
+	query := `
+		DELETE FROM webhook_deliveries
+		WHERE (webhook_id, delivery_id) IN (
+			SELECT webhook_id, delivery_id FROM webhook_deliveries
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired deliveries: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
 // MarkEventForRetry marks a webhook event for retry with exponential backoff
 func (r *Repository) MarkEventForRetry(ctx context.Context, eventID string, errorMsg string) error {
 	query := `SELECT mark_webhook_event_for_retry($1, $2)`
@@ -710,7 +1093,7 @@ func (r *Repository) MarkEventRetrySucceeded(ctx context.Context, eventID string
 		UPDATE webhook_events
 		SET
 			status = 'processed',
-			execution_id = $2,
+			execution_id = NULLIF($2, '')::uuid,
 			processing_time_ms = $3,
 			next_retry_at = NULL
 		WHERE id = $1
@@ -751,3 +1134,93 @@ func (r *Repository) GetRetryStatistics(ctx context.Context, webhookID string) (
 
 	return &stats, nil
 }
+
+// ScheduleEventRetry records a failed delivery and schedules the next retry
+// at the given time. Once the event has used its retries it is marked as
+// permanently failed instead; the returned bool reports whether a retry was
+// scheduled.
+func (r *Repository) ScheduleEventRetry(ctx context.Context, eventID string, errorMsg string, nextRetryAt time.Time) (bool, error) {
+	query := `
+		UPDATE webhook_events
+		SET
+			status = 'failed',
+			retry_count = retry_count + 1,
+			last_retry_at = NOW(),
+			retry_error = $2,
+			permanently_failed = retry_count + 1 >= max_retries,
+			next_retry_at = CASE WHEN retry_count + 1 >= max_retries THEN NULL ELSE $3::timestamptz END
+		WHERE id = $1
+		RETURNING next_retry_at IS NOT NULL
+	`
+
+	var scheduled bool
+	err := r.db.GetContext(ctx, &scheduled, query, eventID, errorMsg, nextRetryAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrNotFound
+		}
+		return false, fmt.Errorf("schedule event retry: %w", err)
+	}
+
+	return scheduled, nil
+}
+
+// RecordDeliveryAttempt records a delivery attempt of a webhook event
+func (r *Repository) RecordDeliveryAttempt(ctx context.Context, attempt *DeliveryAttempt) error {
+	attempt.ID = uuid.New().String()
+	attempt.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO webhook_delivery_attempts (
+			id, tenant_id, webhook_id, event_id, attempt, status,
+			status_code, error, duration_ms, next_retry_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		attempt.ID,
+		attempt.TenantID,
+		attempt.WebhookID,
+		attempt.EventID,
+		attempt.Attempt,
+		attempt.Status,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.DurationMs,
+		attempt.NextRetryAt,
+		attempt.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("record delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveryAttempts retrieves the delivery attempts of a webhook, newest first
+func (r *Repository) ListDeliveryAttempts(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*DeliveryAttempt, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM webhook_delivery_attempts WHERE tenant_id = $1 AND webhook_id = $2`
+	if err := r.db.GetContext(ctx, &total, countQuery, tenantID, webhookID); err != nil {
+		return nil, 0, fmt.Errorf("count delivery attempts: %w", err)
+	}
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT * FROM webhook_delivery_attempts
+		WHERE tenant_id = $1 AND webhook_id = $2
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	attempts := []*DeliveryAttempt{}
+	if err := r.db.SelectContext(ctx, &attempts, query, tenantID, webhookID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("list delivery attempts: %w", err)
+	}
+
+	return attempts, total, nil
+}
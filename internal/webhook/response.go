@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResponseOutcome identifies which configured response applies to a webhook delivery
+type ResponseOutcome string
+
+const (
+	// ResponseOutcomePassed is used when the payload passed filters and a workflow was triggered
+	ResponseOutcomePassed ResponseOutcome = "passed"
+	// ResponseOutcomeFiltered is used when the payload was dropped by a filter
+	ResponseOutcomeFiltered ResponseOutcome = "filtered"
+	// ResponseOutcomeError is used when filter evaluation or workflow execution failed
+	ResponseOutcomeError ResponseOutcome = "error"
+)
+
+// Default responses returned when a webhook has no custom response configured:
+//
+//	passed:   202 {"execution_id": "{{execution_id}}", "status": "{{status}}"}
+//	filtered: 200 {"status": "filtered", "reason": "{{reason}}"}
+//	error:    500 {"error": "failed to execute workflow"}
+var (
+	DefaultPassedResponse = ResponseTemplate{
+		StatusCode:  http.StatusAccepted,
+		Body:        `{"execution_id":"{{execution_id}}","status":"{{status}}"}`,
+		ContentType: "application/json",
+	}
+	DefaultFilteredResponse = ResponseTemplate{
+		StatusCode:  http.StatusOK,
+		Body:        `{"status":"filtered","reason":"{{reason}}"}`,
+		ContentType: "application/json",
+	}
+	DefaultErrorResponse = ResponseTemplate{
+		StatusCode:  http.StatusInternalServerError,
+		Body:        `{"error":"failed to execute workflow"}`,
+		ContentType: "application/json",
+	}
+)
+
+// ResponseTemplate describes the HTTP response returned to the webhook caller.
+// Body may reference {{execution_id}}, {{status}}, {{reason}} and {{error}}.
+type ResponseTemplate struct {
+	StatusCode  int    `json:"statusCode"`
+	Body        string `json:"body"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// ResponseConfig holds per-outcome responses for a webhook. Unset outcomes use the defaults.
+type ResponseConfig struct {
+	Passed   *ResponseTemplate `json:"passed,omitempty"`
+	Filtered *ResponseTemplate `json:"filtered,omitempty"`
+	Error    *ResponseTemplate `json:"error,omitempty"`
+}
+
+// ResponseData holds the values available to response body templates
+type ResponseData struct {
+	ExecutionID string
+	Status      string
+	Reason      string
+	Error       string
+}
+
+// RenderedResponse is a response template with its body placeholders resolved
+type RenderedResponse struct {
+	StatusCode  int
+	Body        string
+	ContentType string
+}
+
+// Validate checks that configured status codes are valid HTTP status codes
+func (c *ResponseConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for outcome, tmpl := range map[ResponseOutcome]*ResponseTemplate{
+		ResponseOutcomePassed:   c.Passed,
+		ResponseOutcomeFiltered: c.Filtered,
+		ResponseOutcomeError:    c.Error,
+	} {
+		if tmpl != nil && (tmpl.StatusCode < 100 || tmpl.StatusCode > 599) {
+			return fmt.Errorf("invalid status code %d for %s response", tmpl.StatusCode, outcome)
+		}
+	}
+	return nil
+}
+
+// Template returns the configured template for an outcome, falling back to the default
+func (c *ResponseConfig) Template(outcome ResponseOutcome) ResponseTemplate {
+	var configured *ResponseTemplate
+	var fallback ResponseTemplate
+
+	switch outcome {
+	case ResponseOutcomeFiltered:
+		fallback = DefaultFilteredResponse
+		if c != nil {
+			configured = c.Filtered
+		}
+	case ResponseOutcomeError:
+		fallback = DefaultErrorResponse
+		if c != nil {
+			configured = c.Error
+		}
+	default:
+		fallback = DefaultPassedResponse
+		if c != nil {
+			configured = c.Passed
+		}
+	}
+
+	if configured == nil {
+		return fallback
+	}
+	return *configured
+}
+
+// Render resolves the response for an outcome using the given data
+func (c *ResponseConfig) Render(outcome ResponseOutcome, data ResponseData) RenderedResponse {
+	tmpl := c.Template(outcome)
+
+	contentType := tmpl.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	replacer := strings.NewReplacer(
+		"{{execution_id}}", data.ExecutionID,
+		"{{status}}", data.Status,
+		"{{reason}}", escapeForContentType(data.Reason, contentType),
+		"{{error}}", escapeForContentType(data.Error, contentType),
+	)
+
+	return RenderedResponse{
+		StatusCode:  tmpl.StatusCode,
+		Body:        replacer.Replace(tmpl.Body),
+		ContentType: contentType,
+	}
+}
+
+// escapeForContentType escapes free-form text so it can be embedded in a JSON string
+func escapeForContentType(value, contentType string) string {
+	if !strings.Contains(contentType, "json") {
+		return value
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded[1 : len(encoded)-1])
+}
+
+// Scan implements sql.Scanner for ResponseConfig stored as JSONB
+func (c *ResponseConfig) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for ResponseConfig: %T", value)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Value implements driver.Valuer for ResponseConfig stored as JSONB
+func (c *ResponseConfig) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
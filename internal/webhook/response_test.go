@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseConfig_Render_Defaults(t *testing.T) {
+	var config *ResponseConfig
+
+	passed := config.Render(ResponseOutcomePassed, ResponseData{ExecutionID: "exec-1", Status: "pending"})
+	assert.Equal(t, http.StatusAccepted, passed.StatusCode)
+	assert.JSONEq(t, `{"execution_id":"exec-1","status":"pending"}`, passed.Body)
+
+	filtered := config.Render(ResponseOutcomeFiltered, ResponseData{Reason: `status "closed"`})
+	assert.Equal(t, http.StatusOK, filtered.StatusCode)
+	assert.JSONEq(t, `{"status":"filtered","reason":"status \"closed\""}`, filtered.Body)
+
+	failed := config.Render(ResponseOutcomeError, ResponseData{Error: "boom"})
+	assert.Equal(t, http.StatusInternalServerError, failed.StatusCode)
+	assert.Equal(t, "application/json", failed.ContentType)
+}
+
+func TestResponseConfig_Render_Custom(t *testing.T) {
+	config := &ResponseConfig{
+		Filtered: &ResponseTemplate{StatusCode: http.StatusOK, Body: "ignored: {{reason}}", ContentType: "text/plain"},
+	}
+
+	rendered := config.Render(ResponseOutcomeFiltered, ResponseData{Reason: `a "quoted" reason`})
+	assert.Equal(t, "ignored: a \"quoted\" reason", rendered.Body)
+	assert.Equal(t, "text/plain", rendered.ContentType)
+
+	// Unconfigured outcomes fall back to defaults
+	passed := config.Render(ResponseOutcomePassed, ResponseData{ExecutionID: "exec-1"})
+	assert.Equal(t, http.StatusAccepted, passed.StatusCode)
+}
+
+func TestResponseConfig_Validate(t *testing.T) {
+	assert.NoError(t, (*ResponseConfig)(nil).Validate())
+	assert.NoError(t, (&ResponseConfig{Passed: &ResponseTemplate{StatusCode: 204}}).Validate())
+	assert.Error(t, (&ResponseConfig{Error: &ResponseTemplate{StatusCode: 42}}).Validate())
+}
+
+func TestResponseConfig_ScanValue(t *testing.T) {
+	original := &ResponseConfig{Passed: &ResponseTemplate{StatusCode: 200, Body: "ok"}}
+
+	value, err := original.Value()
+	assert.NoError(t, err)
+
+	var scanned ResponseConfig
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, original, &scanned)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Error(t, scanned.Scan(42))
+}
@@ -7,11 +7,15 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 )
 
 // secureRand is a math/rand source seeded with crypto/rand for jitter calculations
-var secureRand *rand.Rand
+var (
+	secureRand   *rand.Rand
+	secureRandMu sync.Mutex
+)
 
 func init() {
 	var seed int64
@@ -54,11 +58,24 @@ func DefaultRetryConfig() RetryConfig {
 	}
 }
 
+// RedeliveryRetryConfig returns the backoff used between background
+// redeliveries of failed events. The number of attempts is bounded by each
+// event's max_retries rather than MaxAttempts.
+func RedeliveryRetryConfig(baseDelay, maxDelay time.Duration) RetryConfig {
+	return RetryConfig{
+		BaseDelay:  baseDelay,
+		MaxDelay:   maxDelay,
+		Multiplier: 2.0,
+		Jitter:     0.2,
+	}
+}
+
 // RetryResult represents the result of a webhook delivery attempt
 type RetryResult struct {
 	Success      bool
 	StatusCode   int
 	ResponseBody []byte
+	ExecutionID  string
 	Error        error
 }
 
@@ -89,7 +106,9 @@ func CalculateBackoffWithJitter(attempt int, config RetryConfig) time.Duration {
 	// Add jitter: +/- jitter% of the base delay
 	// Using secureRand which is seeded from crypto/rand
 	jitterRange := float64(baseDelay) * config.Jitter
+	secureRandMu.Lock()
 	jitter := (secureRand.Float64()*2 - 1) * jitterRange // Random between -jitterRange and +jitterRange
+	secureRandMu.Unlock()
 
 	finalDelay := time.Duration(float64(baseDelay) + jitter)
 	if finalDelay < 0 {
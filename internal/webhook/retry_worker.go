@@ -12,16 +12,26 @@ type WebhookDeliverer interface {
 	DeliverWebhook(ctx context.Context, webhook *Webhook, event *WebhookEvent) (*RetryResult, error)
 }
 
+// RetryRepository is the storage used by the retry worker
+type RetryRepository interface {
+	GetByID(ctx context.Context, id string) (*Webhook, error)
+	GetEventsForRetry(ctx context.Context, batchSize int) ([]*WebhookEvent, error)
+	MarkEventAsNonRetryable(ctx context.Context, eventID string, errorMsg string) error
+	MarkEventRetrySucceeded(ctx context.Context, eventID string, executionID string, processingTimeMs int) error
+	ScheduleEventRetry(ctx context.Context, eventID string, errorMsg string, nextRetryAt time.Time) (bool, error)
+	RecordDeliveryAttempt(ctx context.Context, attempt *DeliveryAttempt) error
+}
+
 // RetryWorker processes failed webhook events and retries them
 type RetryWorker struct {
-	repo      *Repository
+	repo      RetryRepository
 	deliverer WebhookDeliverer
 	config    RetryConfig
 	logger    *slog.Logger
 }
 
 // NewRetryWorker creates a new retry worker
-func NewRetryWorker(repo *Repository, deliverer WebhookDeliverer, config RetryConfig) *RetryWorker {
+func NewRetryWorker(repo RetryRepository, deliverer WebhookDeliverer, config RetryConfig) *RetryWorker {
 	return &RetryWorker{
 		repo:      repo,
 		deliverer: deliverer,
@@ -120,18 +130,29 @@ func (w *RetryWorker) processEvent(ctx context.Context, event *WebhookEvent) err
 
 	processingTime := int(time.Since(startTime).Milliseconds())
 
+	// Each failed attempt, including the original delivery, counts a retry
+	attempt := &DeliveryAttempt{
+		TenantID:   event.TenantID,
+		WebhookID:  event.WebhookID,
+		EventID:    event.ID,
+		Attempt:    event.RetryCount + 1,
+		DurationMs: processingTime,
+	}
+	if result != nil && result.StatusCode != 0 {
+		attempt.StatusCode = &result.StatusCode
+	}
+
 	// Handle result
 	if err == nil && result != nil && result.Success {
 		// Success - mark as processed
-		executionID := ""
-		if result.Error == nil {
-			executionID = fmt.Sprintf("retry-%s", event.ID)
-		}
-		markErr := w.repo.MarkEventRetrySucceeded(ctx, event.ID, executionID, processingTime)
+		markErr := w.repo.MarkEventRetrySucceeded(ctx, event.ID, result.ExecutionID, processingTime)
 		if markErr != nil {
 			return fmt.Errorf("mark retry succeeded: %w", markErr)
 		}
 
+		attempt.Status = DeliveryStatusSucceeded
+		w.recordAttempt(ctx, attempt)
+
 		w.logger.Info("webhook retry succeeded",
 			"event_id", event.ID,
 			"webhook_id", event.WebhookID,
@@ -153,16 +174,20 @@ func (w *RetryWorker) processEvent(ctx context.Context, event *WebhookEvent) err
 
 	retryable := ClassifyWebhookError(err, statusCode)
 
+	errorMsg := "unknown error"
+	if err != nil {
+		errorMsg = err.Error()
+	}
+	attempt.Status = DeliveryStatusFailed
+	attempt.Error = &errorMsg
+
 	if !retryable {
 		// Non-retryable error - mark as permanently failed
-		errorMsg := "unknown error"
-		if err != nil {
-			errorMsg = err.Error()
-		}
 		markErr := w.repo.MarkEventAsNonRetryable(ctx, event.ID, errorMsg)
 		if markErr != nil {
 			return fmt.Errorf("mark as non-retryable: %w", markErr)
 		}
+		w.recordAttempt(ctx, attempt)
 
 		w.logger.Warn("webhook retry failed with non-retryable error",
 			"event_id", event.ID,
@@ -172,26 +197,47 @@ func (w *RetryWorker) processEvent(ctx context.Context, event *WebhookEvent) err
 		return fmt.Errorf("non-retryable error: %w", err)
 	}
 
-	// Retryable error - schedule for next retry
-	errorMsg := "unknown error"
-	if err != nil {
-		errorMsg = err.Error()
+	// Retryable error - schedule the next retry with exponential backoff
+	nextRetryAt := time.Now().Add(CalculateBackoffWithJitter(event.RetryCount, w.config))
+	scheduled, markErr := w.repo.ScheduleEventRetry(ctx, event.ID, errorMsg, nextRetryAt)
+	if markErr != nil {
+		return fmt.Errorf("schedule retry: %w", markErr)
 	}
+	if scheduled {
+		attempt.NextRetryAt = &nextRetryAt
+	}
+	w.recordAttempt(ctx, attempt)
 
-	markErr := w.repo.MarkEventForRetry(ctx, event.ID, errorMsg)
-	if markErr != nil {
-		return fmt.Errorf("mark for retry: %w", markErr)
+	if !scheduled {
+		w.logger.Warn("webhook retries exhausted",
+			"event_id", event.ID,
+			"webhook_id", event.WebhookID,
+			"retry_count", event.RetryCount+1,
+			"error", err)
+		return nil
 	}
 
 	w.logger.Info("webhook retry failed, will retry again",
 		"event_id", event.ID,
 		"webhook_id", event.WebhookID,
 		"retry_count", event.RetryCount+1,
+		"next_retry_at", nextRetryAt,
 		"error", err)
 
 	return nil
 }
 
+// recordAttempt adds an attempt to the delivery log. Failing to record it
+// does not fail the retry.
+func (w *RetryWorker) recordAttempt(ctx context.Context, attempt *DeliveryAttempt) {
+	if err := w.repo.RecordDeliveryAttempt(ctx, attempt); err != nil {
+		w.logger.Error("failed to record delivery attempt",
+			"event_id", attempt.EventID,
+			"webhook_id", attempt.WebhookID,
+			"error", err)
+	}
+}
+
 // ClassifyWebhookError determines if an error is retryable based on error type and status code
 func ClassifyWebhookError(err error, statusCode int) bool {
 	// If no error and successful status code, not retryable
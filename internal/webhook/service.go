@@ -10,6 +10,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -20,6 +22,15 @@ import (
 type Service struct {
 	repo   *Repository
 	logger *slog.Logger
+
+	// payloadSchemas caches compiled payload schemas by webhook ID
+	payloadSchemas sync.Map
+
+	// eventTypes resolves registered event type schemas, if configured
+	eventTypes EventTypeRegistry
+
+	// retryConfig schedules background redelivery of failed events, if set
+	retryConfig *RetryConfig
 }
 
 // NewService creates a new webhook service
@@ -30,6 +41,18 @@ func NewService(repo *Repository, logger *slog.Logger) *Service {
 	}
 }
 
+// SetEventTypeRegistry sets the registry used to validate payloads of
+// webhooks bound to an event type
+func (s *Service) SetEventTypeRegistry(registry EventTypeRegistry) {
+	s.eventTypes = registry
+}
+
+// SetRetryConfig enables background redelivery of events whose workflow
+// execution failed, backing off between attempts according to config
+func (s *Service) SetRetryConfig(config RetryConfig) {
+	s.retryConfig = &config
+}
+
 // GenerateSecret generates a secure random secret for webhook signing
 func (s *Service) GenerateSecret() (string, error) {
 	bytes := make([]byte, 32) // 256 bits
@@ -193,19 +216,44 @@ func (s *Service) SyncWorkflowWebhooks(ctx context.Context, tenantID, workflowID
 	for _, nodeConfig := range webhookNodes {
 		shouldExist[nodeConfig.NodeID] = true
 
+		if !ValidSignatureScheme(nodeConfig.SignatureScheme) {
+			return fmt.Errorf("webhook node %s: %w: %s", nodeConfig.NodeID, ErrUnknownSignatureScheme, nodeConfig.SignatureScheme)
+		}
+
 		// If webhook doesn't exist, create it
-		if _, exists := existingMap[nodeConfig.NodeID]; !exists {
+		wh, exists := existingMap[nodeConfig.NodeID]
+		if !exists {
 			authType := nodeConfig.AuthType
 			if authType == "" {
 				authType = AuthTypeSignature // Default to signature
 			}
 
-			_, err := s.Create(ctx, tenantID, workflowID, nodeConfig.NodeID, authType)
+			wh, err = s.Create(ctx, tenantID, workflowID, nodeConfig.NodeID, authType)
 			if err != nil {
 				s.logger.Error("failed to create webhook during sync", "error", err, "node_id", nodeConfig.NodeID)
 				return err
 			}
 		}
+
+		if err := s.syncSignatureConfig(ctx, wh, nodeConfig); err != nil {
+			s.logger.Error("failed to update webhook signature config during sync", "error", err, "node_id", nodeConfig.NodeID)
+			return err
+		}
+
+		if err := s.syncIdempotencyConfig(ctx, wh, nodeConfig.IdempotencyHeader, nodeConfig.IdempotencyTTL); err != nil {
+			s.logger.Error("failed to update webhook idempotency config during sync", "error", err, "node_id", nodeConfig.NodeID)
+			return fmt.Errorf("webhook node %s: %w", nodeConfig.NodeID, err)
+		}
+
+		if err := s.syncPayloadSchema(ctx, wh, nodeConfig.PayloadSchema); err != nil {
+			s.logger.Error("failed to update webhook payload schema during sync", "error", err, "node_id", nodeConfig.NodeID)
+			return fmt.Errorf("webhook node %s: %w", nodeConfig.NodeID, err)
+		}
+
+		if err := s.syncEventType(ctx, wh, nodeConfig.EventType, nodeConfig.EventTypeVersion); err != nil {
+			s.logger.Error("failed to update webhook event type during sync", "error", err, "node_id", nodeConfig.NodeID)
+			return fmt.Errorf("webhook node %s: %w", nodeConfig.NodeID, err)
+		}
 	}
 
 	// Delete webhooks that no longer exist in the workflow definition
@@ -221,6 +269,28 @@ func (s *Service) SyncWorkflowWebhooks(ctx context.Context, tenantID, workflowID
 	return nil
 }
 
+// syncSignatureConfig applies the signature scheme and any secret set in a
+// webhook node's config. Without a configured secret the webhook keeps its
+// generated one.
+func (s *Service) syncSignatureConfig(ctx context.Context, wh *Webhook, nodeConfig workflow.WebhookNodeConfig) error {
+	scheme := nodeConfig.SignatureScheme
+	if scheme == "" {
+		scheme = SignatureSchemeGeneric
+	}
+
+	secret := wh.Secret
+	if nodeConfig.Secret != "" {
+		secret = nodeConfig.Secret
+	}
+
+	if scheme == wh.SignatureScheme && secret == wh.Secret {
+		return nil
+	}
+
+	_, err := s.repo.UpdateSignatureConfig(ctx, wh.ID, scheme, secret)
+	return err
+}
+
 // List retrieves all webhooks for a tenant with pagination
 func (s *Service) List(ctx context.Context, tenantID string, limit, offset int) ([]*Webhook, int, error) {
 	webhooks, total, err := s.repo.List(ctx, tenantID, limit, offset)
@@ -259,6 +329,28 @@ func (s *Service) Update(ctx context.Context, tenantID, webhookID, name, authTyp
 	return webhook, nil
 }
 
+// UpdateResponseConfig sets the per-outcome responses returned to webhook callers
+func (s *Service) UpdateResponseConfig(ctx context.Context, tenantID, webhookID string, config *ResponseConfig) (*Webhook, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Verify webhook belongs to tenant
+	_, err := s.repo.GetByIDAndTenant(ctx, webhookID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.repo.UpdateResponseConfig(ctx, webhookID, config)
+	if err != nil {
+		s.logger.Error("failed to update webhook response config", "error", err, "webhook_id", webhookID)
+		return nil, err
+	}
+
+	s.logger.Info("webhook response config updated", "webhook_id", webhookID)
+	return webhook, nil
+}
+
 // DeleteByID deletes a webhook with tenant isolation
 func (s *Service) DeleteByID(ctx context.Context, tenantID, webhookID string) error {
 	// Verify webhook belongs to tenant
@@ -356,6 +448,68 @@ func (s *Service) LogEvent(ctx context.Context, event *WebhookEvent) error {
 	return nil
 }
 
+// ScheduleRedelivery logs an event whose delivery failed and schedules its
+// first retry. It returns false without logging the event when redelivery
+// is not enabled.
+func (s *Service) ScheduleRedelivery(ctx context.Context, event *WebhookEvent, cause string) (bool, error) {
+	if s.retryConfig == nil {
+		return false, nil
+	}
+
+	event.Status = EventStatusFailed
+	event.ErrorMessage = &cause
+	if err := s.LogEvent(ctx, event); err != nil {
+		return false, err
+	}
+
+	nextRetryAt := time.Now().Add(CalculateBackoffWithJitter(0, *s.retryConfig))
+	scheduled, err := s.repo.ScheduleEventRetry(ctx, event.ID, cause, nextRetryAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to schedule redelivery: %w", err)
+	}
+
+	attempt := &DeliveryAttempt{
+		TenantID:  event.TenantID,
+		WebhookID: event.WebhookID,
+		EventID:   event.ID,
+		Attempt:   1,
+		Status:    DeliveryStatusFailed,
+		Error:     &cause,
+	}
+	if event.ProcessingTimeMs != nil {
+		attempt.DurationMs = *event.ProcessingTimeMs
+	}
+	if scheduled {
+		attempt.NextRetryAt = &nextRetryAt
+	}
+	if err := s.repo.RecordDeliveryAttempt(ctx, attempt); err != nil {
+		s.logger.Error("failed to record delivery attempt",
+			"error", err,
+			"event_id", event.ID,
+			"webhook_id", event.WebhookID)
+	}
+
+	return scheduled, nil
+}
+
+// GetDeliveries retrieves the delivery attempts of a webhook, newest first
+func (s *Service) GetDeliveries(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*DeliveryAttempt, int, error) {
+	// Verify webhook belongs to tenant
+	if _, err := s.repo.GetByIDAndTenant(ctx, webhookID, tenantID); err != nil {
+		return nil, 0, err
+	}
+
+	attempts, total, err := s.repo.ListDeliveryAttempts(ctx, tenantID, webhookID, limit, offset)
+	if err != nil {
+		s.logger.Error("failed to get webhook deliveries",
+			"error", err,
+			"webhook_id", webhookID)
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return attempts, total, nil
+}
+
 // GetEvents retrieves webhook events with pagination
 func (s *Service) GetEvents(ctx context.Context, tenantID, webhookID string, limit, offset int) ([]*WebhookEvent, int, error) {
 	filter := WebhookEventFilter{
@@ -614,3 +768,14 @@ func (s *Service) TestFilters(ctx context.Context, tenantID, webhookID string, p
 
 	return result, nil
 }
+
+// EvaluateFilters evaluates a webhook's filters against an incoming payload
+func (s *Service) EvaluateFilters(ctx context.Context, webhookID string, payload map[string]interface{}) (*FilterResult, error) {
+	evaluator := NewFilterEvaluator(s.repo)
+	result, err := evaluator.Evaluate(ctx, webhookID, payload)
+	if err != nil {
+		s.logger.Error("failed to evaluate filters", "error", err, "webhook_id", webhookID)
+		return nil, fmt.Errorf("failed to evaluate filters: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureScheme constants select how a signature-authenticated webhook is verified
+const (
+	// SignatureSchemeGeneric is sha256=<hex hmac of body> in X-Webhook-Signature or X-Hub-Signature-256
+	SignatureSchemeGeneric = "generic"
+	// SignatureSchemeGitHub is sha256=<hex hmac of body> in X-Hub-Signature-256
+	SignatureSchemeGitHub = "github"
+	// SignatureSchemeSlack is v0=<hex hmac of "v0:timestamp:body"> in X-Slack-Signature
+	SignatureSchemeSlack = "slack"
+	// SignatureSchemeStripe is t=<timestamp>,v1=<hex hmac of "timestamp.body"> in Stripe-Signature
+	SignatureSchemeStripe = "stripe"
+)
+
+// SignatureTolerance is how far a signed timestamp may differ from the current
+// time before the request is rejected as a possible replay
+const SignatureTolerance = 5 * time.Minute
+
+var (
+	ErrInvalidSignature       = errors.New("invalid signature")
+	ErrSignatureExpired       = errors.New("signature timestamp outside tolerance")
+	ErrUnknownSignatureScheme = errors.New("unknown signature scheme")
+)
+
+// ValidSignatureScheme reports whether scheme is supported. An empty scheme
+// means SignatureSchemeGeneric.
+func ValidSignatureScheme(scheme string) bool {
+	switch scheme {
+	case "", SignatureSchemeGeneric, SignatureSchemeGitHub, SignatureSchemeSlack, SignatureSchemeStripe:
+		return true
+	default:
+		return false
+	}
+}
+
+// VerifyRequest verifies the signature of a webhook request with the
+// webhook's signature scheme and secret
+func (s *Service) VerifyRequest(webhook *Webhook, header http.Header, body []byte) error {
+	return verifyRequestSignature(webhook.SignatureScheme, webhook.Secret, header, body, time.Now())
+}
+
+// verifyRequestSignature verifies a request signature as of now
+func verifyRequestSignature(scheme, secret string, header http.Header, body []byte, now time.Time) error {
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+
+	switch scheme {
+	case "", SignatureSchemeGeneric:
+		signature := header.Get("X-Webhook-Signature")
+		if signature == "" {
+			signature = header.Get("X-Hub-Signature-256")
+		}
+		return compareSignature(strings.TrimPrefix(signature, "sha256="), secret, body)
+	case SignatureSchemeGitHub:
+		signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+		return compareSignature(signature, secret, body)
+	case SignatureSchemeSlack:
+		return verifySlackSignature(secret, header, body, now)
+	case SignatureSchemeStripe:
+		return verifyStripeSignature(secret, header, body, now)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSignatureScheme, scheme)
+	}
+}
+
+// verifySlackSignature verifies Slack's v0 signing: an HMAC of
+// "v0:<X-Slack-Request-Timestamp>:<body>"
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	if err := checkTimestamp(timestamp, now); err != nil {
+		return err
+	}
+
+	signature, ok := strings.CutPrefix(header.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	return compareSignature(signature, secret, []byte("v0:"+timestamp+":"+string(body)))
+}
+
+// verifyStripeSignature verifies a Stripe-Signature header of the form
+// t=<timestamp>,v1=<signature>[,v1=<signature>...], where each v1 signature is
+// an HMAC of "<timestamp>.<body>". Stripe sends several v1 values while a
+// secret is being rolled, so any match is accepted.
+func verifyStripeSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header.Get("Stripe-Signature"), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if err := checkTimestamp(timestamp, now); err != nil {
+		return err
+	}
+
+	payload := []byte(timestamp + "." + string(body))
+	for _, signature := range signatures {
+		if compareSignature(signature, secret, payload) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// checkTimestamp rejects missing timestamps and timestamps further than
+// SignatureTolerance from now
+func checkTimestamp(timestamp string, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+// compareSignature compares a hex signature with the HMAC-SHA256 of payload in constant time
+func compareSignature(signature, secret string, payload []byte) error {
+	if signature == "" {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
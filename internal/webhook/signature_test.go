@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSigningSecret = "signing-secret"
+
+func hexHMAC(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestVerifyRequestSignature tests each signature scheme against valid, tampered and replayed requests
+func TestVerifyRequestSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := `{"event":"push"}`
+	ts := strconv.FormatInt(now.Unix(), 10)
+	staleTS := strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name    string
+		scheme  string
+		headers map[string]string
+		wantErr error
+	}{
+		{
+			name:    "generic with X-Webhook-Signature",
+			scheme:  SignatureSchemeGeneric,
+			headers: map[string]string{"X-Webhook-Signature": "sha256=" + hexHMAC(testSigningSecret, body)},
+		},
+		{
+			name:    "empty scheme defaults to generic",
+			scheme:  "",
+			headers: map[string]string{"X-Hub-Signature-256": hexHMAC(testSigningSecret, body)},
+		},
+		{
+			name:    "github",
+			scheme:  SignatureSchemeGitHub,
+			headers: map[string]string{"X-Hub-Signature-256": "sha256=" + hexHMAC(testSigningSecret, body)},
+		},
+		{
+			name:    "github requires sha256 prefix",
+			scheme:  SignatureSchemeGitHub,
+			headers: map[string]string{"X-Hub-Signature-256": hexHMAC(testSigningSecret, body)},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "github with wrong secret",
+			scheme:  SignatureSchemeGitHub,
+			headers: map[string]string{"X-Hub-Signature-256": "sha256=" + hexHMAC("other", body)},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:   "slack",
+			scheme: SignatureSchemeSlack,
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": ts,
+				"X-Slack-Signature":         "v0=" + hexHMAC(testSigningSecret, "v0:"+ts+":"+body),
+			},
+		},
+		{
+			name:   "slack with signature for another timestamp",
+			scheme: SignatureSchemeSlack,
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": ts,
+				"X-Slack-Signature":         "v0=" + hexHMAC(testSigningSecret, "v0:"+staleTS+":"+body),
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:   "slack replay outside tolerance",
+			scheme: SignatureSchemeSlack,
+			headers: map[string]string{
+				"X-Slack-Request-Timestamp": staleTS,
+				"X-Slack-Signature":         "v0=" + hexHMAC(testSigningSecret, "v0:"+staleTS+":"+body),
+			},
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name:    "slack without timestamp",
+			scheme:  SignatureSchemeSlack,
+			headers: map[string]string{"X-Slack-Signature": "v0=" + hexHMAC(testSigningSecret, "v0::"+body)},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "stripe",
+			scheme:  SignatureSchemeStripe,
+			headers: map[string]string{"Stripe-Signature": "t=" + ts + ",v1=" + hexHMAC(testSigningSecret, ts+"."+body)},
+		},
+		{
+			name:   "stripe while rolling secrets",
+			scheme: SignatureSchemeStripe,
+			headers: map[string]string{
+				"Stripe-Signature": "t=" + ts + ",v1=" + hexHMAC("old-secret", ts+"."+body) + ",v1=" + hexHMAC(testSigningSecret, ts+"."+body),
+			},
+		},
+		{
+			name:    "stripe replay outside tolerance",
+			scheme:  SignatureSchemeStripe,
+			headers: map[string]string{"Stripe-Signature": "t=" + staleTS + ",v1=" + hexHMAC(testSigningSecret, staleTS+"."+body)},
+			wantErr: ErrSignatureExpired,
+		},
+		{
+			name:    "stripe without v1 signature",
+			scheme:  SignatureSchemeStripe,
+			headers: map[string]string{"Stripe-Signature": "t=" + ts + ",v0=" + hexHMAC(testSigningSecret, ts+"."+body)},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "unknown scheme",
+			scheme:  "gitlab",
+			headers: map[string]string{"X-Webhook-Signature": "sha256=" + hexHMAC(testSigningSecret, body)},
+			wantErr: ErrUnknownSignatureScheme,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, value)
+			}
+
+			err := verifyRequestSignature(tt.scheme, testSigningSecret, header, []byte(body), now)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestVerifyRequestSignature_NoSecret tests that a webhook without a secret never verifies
+func TestVerifyRequestSignature_NoSecret(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Webhook-Signature", "sha256="+hexHMAC("", "{}"))
+
+	err := verifyRequestSignature(SignatureSchemeGeneric, "", header, []byte("{}"), time.Now())
+
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"encoding/json"
+	"sync"
 	"time"
 )
 
@@ -20,17 +21,23 @@ const (
 
 // ExecutionEvent represents a WebSocket event for execution updates
 type ExecutionEvent struct {
-	Type        EventType              `json:"type"`
-	ExecutionID string                 `json:"execution_id"`
-	WorkflowID  string                 `json:"workflow_id"`
-	TenantID    string                 `json:"tenant_id"`
-	Status      string                 `json:"status,omitempty"`
-	Progress    *ProgressInfo          `json:"progress,omitempty"`
-	Step        *StepInfo              `json:"step,omitempty"`
-	Error       *string                `json:"error,omitempty"`
-	Output      *json.RawMessage       `json:"output,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Timestamp   time.Time              `json:"timestamp"`
+	// Sequence increases with every event; reconnecting clients pass the last
+	// one they saw as ?since= to replay what they missed
+	Sequence uint64 `json:"sequence"`
+	// ExecutionSequence numbers an execution's events from 1 where they are
+	// produced. Events reach clients once each and in this order.
+	ExecutionSequence uint64                 `json:"execution_sequence,omitempty"`
+	Type              EventType              `json:"type"`
+	ExecutionID       string                 `json:"execution_id"`
+	WorkflowID        string                 `json:"workflow_id"`
+	TenantID          string                 `json:"tenant_id"`
+	Status            string                 `json:"status,omitempty"`
+	Progress          *ProgressInfo          `json:"progress,omitempty"`
+	Step              *StepInfo              `json:"step,omitempty"`
+	Error             *string                `json:"error,omitempty"`
+	Output            *json.RawMessage       `json:"output,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp         time.Time              `json:"timestamp"`
 }
 
 // ProgressInfo contains execution progress information
@@ -80,26 +87,79 @@ type Broadcaster interface {
 // HubBroadcaster implements Broadcaster using the WebSocket Hub
 type HubBroadcaster struct {
 	hub *Hub
+
+	// mu keeps sequences in the order events are queued to the hub
+	mu       sync.Mutex
+	sequence uint64
+
+	// ordering dedupes each execution's events and emits them in order
+	ordering *eventOrderer
 }
 
-// NewHubBroadcaster creates a new HubBroadcaster
+// NewHubBroadcaster creates a new HubBroadcaster. Sequences start from the
+// current time in microseconds so they keep increasing across restarts.
 func NewHubBroadcaster(hub *Hub) *HubBroadcaster {
-	return &HubBroadcaster{hub: hub}
+	b := &HubBroadcaster{
+		hub:      hub,
+		sequence: uint64(time.Now().UnixMicro()),
+	}
+	b.ordering = newEventOrderer(b.broadcast)
+	return b
+}
+
+// PublishEvent broadcasts an event produced elsewhere, such as by another
+// worker, that already carries its ExecutionSequence. Duplicates are dropped
+// and events are emitted in execution sequence order, waiting briefly for
+// any that arrive late.
+func (b *HubBroadcaster) PublishEvent(event ExecutionEvent) {
+	b.ordering.accept(event)
 }
 
-// Room helpers
-func executionRoom(executionID string) string {
-	return "execution:" + executionID
+// Status classes clients can filter execution events by
+const (
+	StatusClassRunning   = "running"
+	StatusClassCompleted = "completed"
+	StatusClassFailed    = "failed"
+)
+
+// ValidStatusClass reports whether class is a known status class
+func ValidStatusClass(class string) bool {
+	switch class {
+	case StatusClassRunning, StatusClassCompleted, StatusClassFailed:
+		return true
+	}
+	return false
 }
 
-func workflowRoom(workflowID string) string {
-	return "workflow:" + workflowID
+// statusClass returns the status class of an event type
+func statusClass(eventType EventType) string {
+	switch eventType {
+	case EventTypeExecutionCompleted, EventTypeStepCompleted:
+		return StatusClassCompleted
+	case EventTypeExecutionFailed, EventTypeStepFailed:
+		return StatusClassFailed
+	default:
+		return StatusClassRunning
+	}
 }
 
-func tenantRoom(tenantID string) string {
+// TenantRoom returns the room for all execution events of a tenant. Execution
+// and workflow rooms are nested under it, so clients can only join rooms of
+// their own tenant.
+func TenantRoom(tenantID string) string {
 	return "tenant:" + tenantID
 }
 
+// WorkflowRoom returns the room for execution events of one workflow
+func WorkflowRoom(tenantID, workflowID string) string {
+	return TenantRoom(tenantID) + ":workflow:" + workflowID
+}
+
+// ExecutionRoom returns the room for events of one execution
+func ExecutionRoom(tenantID, executionID string) string {
+	return TenantRoom(tenantID) + ":execution:" + executionID
+}
+
 // BroadcastExecutionStarted broadcasts when execution starts
 func (b *HubBroadcaster) BroadcastExecutionStarted(tenantID, workflowID, executionID string, totalSteps int) {
 	event := ExecutionEvent{
@@ -116,7 +176,7 @@ func (b *HubBroadcaster) BroadcastExecutionStarted(tenantID, workflowID, executi
 		Timestamp: time.Now(),
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastExecutionCompleted broadcasts when execution completes
@@ -131,7 +191,7 @@ func (b *HubBroadcaster) BroadcastExecutionCompleted(tenantID, workflowID, execu
 		Timestamp:   time.Now(),
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastExecutionFailed broadcasts when execution fails
@@ -146,7 +206,7 @@ func (b *HubBroadcaster) BroadcastExecutionFailed(tenantID, workflowID, executio
 		Timestamp:   time.Now(),
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastStepStarted broadcasts when a step starts
@@ -166,7 +226,7 @@ func (b *HubBroadcaster) BroadcastStepStarted(tenantID, workflowID, executionID,
 		Timestamp: now,
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastStepCompleted broadcasts when a step completes
@@ -187,7 +247,7 @@ func (b *HubBroadcaster) BroadcastStepCompleted(tenantID, workflowID, executionI
 		Timestamp: now,
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastStepFailed broadcasts when a step fails
@@ -207,7 +267,7 @@ func (b *HubBroadcaster) BroadcastStepFailed(tenantID, workflowID, executionID,
 		Timestamp: now,
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
 }
 
 // BroadcastProgress broadcasts execution progress
@@ -230,22 +290,37 @@ func (b *HubBroadcaster) BroadcastProgress(tenantID, workflowID, executionID str
 		Timestamp: time.Now(),
 	}
 
-	b.broadcast(executionID, workflowID, tenantID, event)
+	b.publish(event)
+}
+
+// publish assigns an event produced by this process its execution sequence
+// and hands it to ordering
+func (b *HubBroadcaster) publish(event ExecutionEvent) {
+	event.ExecutionSequence = b.ordering.nextSequence(event.ExecutionID)
+	b.ordering.accept(event)
 }
 
 // broadcast sends an event to all relevant rooms
-func (b *HubBroadcaster) broadcast(executionID, workflowID, tenantID string, event ExecutionEvent) {
+func (b *HubBroadcaster) broadcast(event ExecutionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	event.Sequence = b.sequence
+
 	data, err := json.Marshal(event)
 	if err != nil {
 		return
 	}
 
+	status := statusClass(event.Type)
+
 	// Broadcast to execution-specific room
-	b.hub.BroadcastToRoom(executionRoom(executionID), data)
+	b.hub.BroadcastEvent(ExecutionRoom(event.TenantID, event.ExecutionID), event.Sequence, status, data)
 
 	// Also broadcast to workflow room (for workflow monitoring)
-	b.hub.BroadcastToRoom(workflowRoom(workflowID), data)
+	b.hub.BroadcastEvent(WorkflowRoom(event.TenantID, event.WorkflowID), event.Sequence, status, data)
 
 	// Also broadcast to tenant room (for dashboard)
-	b.hub.BroadcastToRoom(tenantRoom(tenantID), data)
+	b.hub.BroadcastEvent(TenantRoom(event.TenantID), event.Sequence, status, data)
 }
@@ -28,7 +28,7 @@ func TestHubBroadcasterExecutionStarted(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast execution started
 	broadcaster.BroadcastExecutionStarted("tenant-1", "workflow-1", executionID, 5)
@@ -91,7 +91,7 @@ func TestHubBroadcasterExecutionCompleted(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast execution completed
 	output := json.RawMessage(`{"result":"success","data":{"count":42}}`)
@@ -142,7 +142,7 @@ func TestHubBroadcasterExecutionFailed(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast execution failed
 	errorMsg := "Node http-request failed: connection timeout"
@@ -193,7 +193,7 @@ func TestHubBroadcasterStepStarted(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast step started
 	broadcaster.BroadcastStepStarted("tenant-1", "workflow-1", executionID, "node-1", "action:http")
@@ -251,7 +251,7 @@ func TestHubBroadcasterStepCompleted(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast step completed
 	output := json.RawMessage(`{"statusCode":200,"body":"OK"}`)
@@ -306,7 +306,7 @@ func TestHubBroadcasterProgress(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	executionID := "exec-123"
-	hub.SubscribeClient(client, "execution:"+executionID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
 
 	// Broadcast progress
 	broadcaster.BroadcastProgress("tenant-1", "workflow-1", executionID, 3, 5)
@@ -369,9 +369,9 @@ func TestBroadcastToMultipleRooms(t *testing.T) {
 	workflowID := "workflow-1"
 	tenantID := "tenant-1"
 
-	hub.SubscribeClient(client, "execution:"+executionID)
-	hub.SubscribeClient(client, "workflow:"+workflowID)
-	hub.SubscribeClient(client, "tenant:"+tenantID)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", executionID))
+	hub.SubscribeClient(client, WorkflowRoom(tenantID, workflowID))
+	hub.SubscribeClient(client, TenantRoom(tenantID))
 
 	// Broadcast execution started (should go to all 3 rooms)
 	broadcaster.BroadcastExecutionStarted(tenantID, workflowID, executionID, 5)
@@ -393,3 +393,38 @@ func TestBroadcastToMultipleRooms(t *testing.T) {
 		t.Errorf("Expected 3 messages (one per room), got %d", messageCount)
 	}
 }
+
+func TestHubBroadcasterSequencesIncrease(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+	go hub.Run()
+
+	broadcaster := NewHubBroadcaster(hub)
+
+	client := &Client{
+		ID:            "test-client",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+	hub.Register <- client
+	time.Sleep(10 * time.Millisecond)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", "exec-123"))
+
+	broadcaster.BroadcastExecutionStarted("tenant-1", "workflow-1", "exec-123", 2)
+	broadcaster.BroadcastStepStarted("tenant-1", "workflow-1", "exec-123", "node-1", "action:http")
+	time.Sleep(50 * time.Millisecond)
+
+	var previous uint64
+	for i := 0; i < 2; i++ {
+		var event ExecutionEvent
+		if err := json.Unmarshal(<-client.Send, &event); err != nil {
+			t.Fatalf("Failed to unmarshal event: %v", err)
+		}
+		if event.Sequence <= previous {
+			t.Errorf("Expected sequence greater than %d, got %d", previous, event.Sequence)
+		}
+		previous = event.Sequence
+	}
+}
@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// historyTTL is how long sequenced messages stay available for replay
+	historyTTL = 5 * time.Minute
+
+	// historySize is the maximum number of messages kept per room
+	historySize = 200
+
+	// historyPruneInterval is how often expired history is removed
+	historyPruneInterval = time.Minute
+)
+
+// Control message types sent by the server about the connection itself
+const (
+	ControlTypePong             = "pong"
+	ControlTypeReplayIncomplete = "replay.incomplete"
+)
+
+// ControlMessage is a server message about the connection rather than an execution
+type ControlMessage struct {
+	Type string `json:"type"`
+	Room string `json:"room,omitempty"`
+	// OldestSequence is the oldest sequence still available for the room
+	OldestSequence uint64 `json:"oldest_sequence,omitempty"`
+}
+
+// historyEntry is a sequenced message kept for replay
+type historyEntry struct {
+	sequence uint64
+	status   string
+	message  []byte
+	storedAt time.Time
+}
+
+// roomHistory holds the recent sequenced messages of a room, oldest first
+type roomHistory struct {
+	entries []historyEntry
+	// evictedThrough is the highest sequence removed from the history
+	evictedThrough uint64
+}
+
+func (rh *roomHistory) append(entry historyEntry) {
+	rh.entries = append(rh.entries, entry)
+	if len(rh.entries) > historySize {
+		rh.evictBefore(len(rh.entries) - historySize)
+	}
+}
+
+// prune removes entries stored before cutoff
+func (rh *roomHistory) prune(cutoff time.Time) {
+	n := 0
+	for n < len(rh.entries) && rh.entries[n].storedAt.Before(cutoff) {
+		n++
+	}
+	rh.evictBefore(n)
+}
+
+func (rh *roomHistory) evictBefore(n int) {
+	if n == 0 {
+		return
+	}
+	rh.evictedThrough = rh.entries[n-1].sequence
+	rh.entries = append([]historyEntry(nil), rh.entries[n:]...)
+}
+
+// since returns the entries with a sequence greater than seq, and whether
+// entries after seq were already evicted
+func (rh *roomHistory) since(seq uint64) ([]historyEntry, bool) {
+	var entries []historyEntry
+	for _, entry := range rh.entries {
+		if entry.sequence > seq {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, seq < rh.evictedThrough
+}
+
+// oldestSequence returns the oldest sequence that can still be replayed
+func (rh *roomHistory) oldestSequence() uint64 {
+	if len(rh.entries) > 0 {
+		return rh.entries[0].sequence
+	}
+	return rh.evictedThrough + 1
+}
+
+// recordHistory stores a sequenced message for replay
+func (h *Hub) recordHistory(msg *BroadcastMessage) {
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	rh, exists := h.history[msg.Room]
+	if !exists {
+		rh = &roomHistory{}
+		h.history[msg.Room] = rh
+	}
+	rh.append(historyEntry{sequence: msg.Sequence, status: msg.Status, message: msg.Message, storedAt: time.Now()})
+}
+
+// pruneHistory removes expired messages and rooms left without any
+func (h *Hub) pruneHistory(now time.Time) {
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	cutoff := now.Add(-historyTTL)
+	for room, rh := range h.history {
+		rh.prune(cutoff)
+		if len(rh.entries) == 0 {
+			h.expiredThrough = max(h.expiredThrough, rh.evictedThrough)
+			delete(h.history, room)
+		}
+	}
+}
+
+// replayHistory sends a client the room's messages with a sequence greater
+// than since. The caller must hold h.mu so no broadcast interleaves with the
+// replay.
+func (h *Hub) replayHistory(client *Client, room string, since uint64) {
+	h.historyMu.Lock()
+	var entries []historyEntry
+	var incomplete bool
+	var oldest uint64
+	if rh, exists := h.history[room]; exists {
+		entries, incomplete = rh.since(since)
+		oldest = rh.oldestSequence()
+	} else {
+		// The room's history expired entirely, so whether it missed
+		// anything after since is unknown
+		incomplete = since < h.expiredThrough
+		oldest = h.expiredThrough + 1
+	}
+	h.historyMu.Unlock()
+
+	if incomplete {
+		notice, err := json.Marshal(ControlMessage{Type: ControlTypeReplayIncomplete, Room: room, OldestSequence: oldest})
+		if err == nil {
+			h.sendToClient(client, room, notice)
+		}
+	}
+	for _, entry := range entries {
+		if client.accepts(entry.status) {
+			h.sendToClient(client, room, entry.message)
+		}
+	}
+
+	h.logger.Info("replayed room history",
+		"client_id", client.ID,
+		"room", room,
+		"since", since,
+		"replayed", len(entries),
+		"incomplete", incomplete,
+	)
+}
@@ -1,13 +1,19 @@
 package websocket
 
 import (
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// ErrCrossTenantSubscription is returned when a client subscribes to a room of another tenant
+var ErrCrossTenantSubscription = errors.New("cannot subscribe to another tenant's room")
+
 // Client represents a WebSocket client connection
 type Client struct {
 	ID            string
@@ -16,7 +22,16 @@ type Client struct {
 	Hub           *Hub
 	Send          chan []byte
 	Subscriptions map[string]bool
-	mu            sync.RWMutex
+	// StatusFilter limits execution events to these status classes; empty
+	// means all events are sent
+	StatusFilter map[string]bool
+	mu           sync.RWMutex
+}
+
+// accepts reports whether the client's status filter lets through a message
+// of the given status class. Messages without a status class always pass.
+func (c *Client) accepts(status string) bool {
+	return status == "" || len(c.StatusFilter) == 0 || c.StatusFilter[status]
 }
 
 // Hub manages all WebSocket connections and message broadcasting
@@ -39,6 +54,14 @@ type Hub struct {
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 
+	// Recent sequenced messages by room, replayed to reconnecting clients
+	history map[string]*roomHistory
+
+	// expiredThrough is the highest sequence of rooms whose history expired
+	expiredThrough uint64
+
+	historyMu sync.Mutex
+
 	logger *slog.Logger
 }
 
@@ -46,6 +69,10 @@ type Hub struct {
 type BroadcastMessage struct {
 	Room    string
 	Message []byte
+	// Sequence orders the message for replay; zero messages are not kept
+	Sequence uint64
+	// Status is the status class of an execution event, used for filtering
+	Status string
 }
 
 // NewHub creates a new WebSocket hub
@@ -56,12 +83,16 @@ func NewHub(logger *slog.Logger) *Hub {
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
 		broadcast:  make(chan *BroadcastMessage, 256),
+		history:    make(map[string]*roomHistory),
 		logger:     logger,
 	}
 }
 
 // Run starts the hub's main loop
 func (h *Hub) Run() {
+	pruneTicker := time.NewTicker(historyPruneInterval)
+	defer pruneTicker.Stop()
+
 	for {
 		select {
 		case client := <-h.Register:
@@ -72,6 +103,9 @@ func (h *Hub) Run() {
 
 		case message := <-h.broadcast:
 			h.broadcastToRoom(message)
+
+		case now := <-pruneTicker.C:
+			h.pruneHistory(now)
 		}
 	}
 }
@@ -117,11 +151,53 @@ func (h *Hub) unregisterClient(client *Client) {
 	}
 }
 
-// SubscribeClient subscribes a client to a room
-func (h *Hub) SubscribeClient(client *Client, room string) {
+// SubscribeClient subscribes a client to a room. Tenant rooms, and the
+// workflow and execution rooms nested under them, are limited to clients of
+// that tenant.
+func (h *Hub) SubscribeClient(client *Client, room string) error {
+	if err := checkRoomTenant(client, room); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.subscribeLocked(client, room)
+	return nil
+}
+
+// SubscribeClientSince subscribes a client to a room and first sends it the
+// room's buffered messages with a sequence greater than since, so a
+// reconnecting client receives what it missed without gaps or duplicates.
+// If some of those messages already expired, the client is sent a
+// replay.incomplete control message and should reload state over the API.
+func (h *Hub) SubscribeClientSince(client *Client, room string, since uint64) error {
+	if err := checkRoomTenant(client, room); err != nil {
+		return err
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	h.replayHistory(client, room, since)
+	h.subscribeLocked(client, room)
+	return nil
+}
+
+// checkRoomTenant rejects tenant-scoped rooms of other tenants
+func checkRoomTenant(client *Client, room string) error {
+	if !strings.HasPrefix(room, "tenant:") {
+		return nil
+	}
+	own := TenantRoom(client.TenantID)
+	if room == own || strings.HasPrefix(room, own+":") {
+		return nil
+	}
+	return ErrCrossTenantSubscription
+}
+
+// subscribeLocked subscribes a client to a room; the caller must hold h.mu
+func (h *Hub) subscribeLocked(client *Client, room string) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
@@ -173,11 +249,27 @@ func (h *Hub) BroadcastToRoom(room string, message []byte) {
 	}
 }
 
+// BroadcastEvent sends an execution event to the clients in a room whose
+// status filter accepts it, and keeps it for replay to clients that reconnect
+// with an earlier sequence
+func (h *Hub) BroadcastEvent(room string, sequence uint64, status string, message []byte) {
+	h.broadcast <- &BroadcastMessage{
+		Room:     room,
+		Message:  message,
+		Sequence: sequence,
+		Status:   status,
+	}
+}
+
 // broadcastToRoom performs the actual broadcast
 func (h *Hub) broadcastToRoom(msg *BroadcastMessage) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	if msg.Sequence > 0 {
+		h.recordHistory(msg)
+	}
+
 	if clients, exists := h.rooms[msg.Room]; exists {
 		h.logger.Debug("broadcasting to room",
 			"room", msg.Room,
@@ -185,19 +277,26 @@ func (h *Hub) broadcastToRoom(msg *BroadcastMessage) {
 		)
 
 		for _, client := range clients {
-			select {
-			case client.Send <- msg.Message:
-			default:
-				// Client's send channel is full, skip
-				h.logger.Warn("client send channel full, dropping message",
-					"client_id", client.ID,
-					"room", msg.Room,
-				)
+			if client.accepts(msg.Status) {
+				h.sendToClient(client, msg.Room, msg.Message)
 			}
 		}
 	}
 }
 
+// sendToClient queues a message for a client without blocking the hub
+func (h *Hub) sendToClient(client *Client, room string, message []byte) {
+	select {
+	case client.Send <- message:
+	default:
+		// Client's send channel is full, skip
+		h.logger.Warn("client send channel full, dropping message",
+			"client_id", client.ID,
+			"room", room,
+		)
+	}
+}
+
 // Client read and write pumps
 
 const (
@@ -221,6 +320,8 @@ func (c *Client) ReadPump() {
 		c.Conn.Close()
 	}()
 
+	// Connections that send neither pongs nor messages within pongWait are
+	// considered dead and closed
 	if err := c.Conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
 		c.Hub.logger.Warn("failed to set read deadline", "error", err, "client_id", c.ID)
 	}
@@ -300,10 +401,32 @@ func (c *Client) WritePump() {
 	}
 }
 
-// handleMessage processes incoming messages from clients
+// clientMessage is a message sent by a client
+type clientMessage struct {
+	Type string `json:"type"`
+}
+
+// handleMessage processes incoming messages from clients. Browsers cannot
+// send WebSocket pings, so clients send {"type": "ping"} as a heartbeat and
+// get {"type": "pong"} back; any message also extends the read deadline.
 func (c *Client) handleMessage(message []byte) {
-	// Parse message and handle subscription requests
-	// Message format: {"type": "subscribe", "room": "execution:123"}
-	// For now, we'll handle subscriptions through HTTP params
-	// This can be extended later for dynamic subscriptions
+	if c.Conn != nil {
+		if err := c.Conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+			c.Hub.logger.Warn("failed to set read deadline", "error", err, "client_id", c.ID)
+		}
+	}
+
+	var msg clientMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return
+	}
+
+	switch msg.Type {
+	case "ping":
+		pong, err := json.Marshal(ControlMessage{Type: ControlTypePong})
+		if err != nil {
+			return
+		}
+		c.Hub.sendToClient(c, "", pong)
+	}
 }
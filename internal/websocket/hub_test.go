@@ -1,6 +1,8 @@
 package websocket
 
 import (
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"os"
 	"sync"
@@ -89,7 +91,7 @@ func TestHubSubscription(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// Subscribe to room
-	room := "execution:test-123"
+	room := ExecutionRoom("tenant-1", "test-123")
 	hub.SubscribeClient(client, room)
 
 	// Check subscription
@@ -143,7 +145,7 @@ func TestHubBroadcast(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// Subscribe both to same room
-	room := "execution:test-123"
+	room := ExecutionRoom("tenant-1", "test-123")
 	hub.SubscribeClient(client1, room)
 	hub.SubscribeClient(client2, room)
 
@@ -194,7 +196,7 @@ func TestHubUnsubscribe(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// Subscribe then unsubscribe
-	room := "execution:test-123"
+	room := ExecutionRoom("tenant-1", "test-123")
 	hub.SubscribeClient(client, room)
 	hub.UnsubscribeClient(client, room)
 
@@ -234,9 +236,9 @@ func TestHubMultipleRooms(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// Subscribe to multiple rooms
-	room1 := "execution:test-123"
-	room2 := "workflow:wf-456"
-	room3 := "tenant:tenant-1"
+	room1 := ExecutionRoom("tenant-1", "test-123")
+	room2 := WorkflowRoom("tenant-1", "wf-456")
+	room3 := TenantRoom("tenant-1")
 
 	hub.SubscribeClient(client, room1)
 	hub.SubscribeClient(client, room2)
@@ -273,3 +275,201 @@ func TestHubMultipleRooms(t *testing.T) {
 		t.Errorf("Client should receive 3 messages, got %d", receivedCount)
 	}
 }
+
+func TestHubSubscribeClientSinceReplaysMissedMessages(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	go hub.Run()
+
+	room := ExecutionRoom("tenant-1", "test-123")
+	hub.BroadcastEvent(room, 1, StatusClassRunning, []byte("msg1"))
+	hub.BroadcastEvent(room, 2, StatusClassRunning, []byte("msg2"))
+	hub.BroadcastEvent(room, 3, StatusClassRunning, []byte("msg3"))
+	time.Sleep(50 * time.Millisecond)
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+	hub.Register <- client
+	time.Sleep(10 * time.Millisecond)
+
+	hub.SubscribeClientSince(client, room, 1)
+	hub.BroadcastEvent(room, 4, StatusClassRunning, []byte("msg4"))
+	time.Sleep(50 * time.Millisecond)
+
+	for _, expected := range []string{"msg2", "msg3", "msg4"} {
+		select {
+		case msg := <-client.Send:
+			if string(msg) != expected {
+				t.Errorf("Expected %s, got %s", expected, string(msg))
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Did not receive %s", expected)
+		}
+	}
+}
+
+func TestHubSubscribeClientSinceReportsExpiredHistory(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	room := ExecutionRoom("tenant-1", "test-123")
+	for seq := uint64(1); seq <= historySize+5; seq++ {
+		hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("msg"), Sequence: seq})
+	}
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, historySize+10),
+		Subscriptions: make(map[string]bool),
+	}
+
+	hub.SubscribeClientSince(client, room, 2)
+
+	var notice ControlMessage
+	if err := json.Unmarshal(<-client.Send, &notice); err != nil {
+		t.Fatalf("Failed to unmarshal notice: %v", err)
+	}
+	if notice.Type != ControlTypeReplayIncomplete {
+		t.Errorf("Expected type %s, got %s", ControlTypeReplayIncomplete, notice.Type)
+	}
+	if notice.OldestSequence != 6 {
+		t.Errorf("Expected oldest sequence 6, got %d", notice.OldestSequence)
+	}
+	if len(client.Send) != historySize {
+		t.Errorf("Expected %d replayed messages, got %d", historySize, len(client.Send))
+	}
+}
+
+func TestHubPruneHistory(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	room := ExecutionRoom("tenant-1", "test-123")
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("msg"), Sequence: 7})
+	hub.pruneHistory(time.Now().Add(historyTTL + time.Second))
+
+	if _, exists := hub.history[room]; exists {
+		t.Errorf("Expired room history should be removed")
+	}
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+	hub.SubscribeClientSince(client, room, 3)
+
+	select {
+	case msg := <-client.Send:
+		var notice ControlMessage
+		if err := json.Unmarshal(msg, &notice); err != nil || notice.Type != ControlTypeReplayIncomplete {
+			t.Errorf("Expected replay.incomplete notice, got %s", string(msg))
+		}
+	default:
+		t.Errorf("Expected replay.incomplete notice for expired history")
+	}
+}
+
+func TestClientHeartbeat(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+
+	client.handleMessage([]byte(`{"type":"ping"}`))
+
+	select {
+	case msg := <-client.Send:
+		if string(msg) != `{"type":"pong"}` {
+			t.Errorf("Expected pong, got %s", string(msg))
+		}
+	default:
+		t.Errorf("Expected pong reply to ping")
+	}
+}
+
+func TestHubRejectsCrossTenantSubscription(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+
+	for _, room := range []string{TenantRoom("tenant-2"), WorkflowRoom("tenant-2", "wf-1"), ExecutionRoom("tenant-2", "exec-1"), "tenant:tenant-10"} {
+		if err := hub.SubscribeClient(client, room); !errors.Is(err, ErrCrossTenantSubscription) {
+			t.Errorf("Expected ErrCrossTenantSubscription for %s, got %v", room, err)
+		}
+		if err := hub.SubscribeClientSince(client, room, 0); !errors.Is(err, ErrCrossTenantSubscription) {
+			t.Errorf("Expected ErrCrossTenantSubscription for %s, got %v", room, err)
+		}
+	}
+
+	if err := hub.SubscribeClient(client, WorkflowRoom("tenant-1", "wf-1")); err != nil {
+		t.Errorf("Expected subscription to own tenant's room, got %v", err)
+	}
+	if len(client.Subscriptions) != 1 {
+		t.Errorf("Expected 1 subscription, got %d", len(client.Subscriptions))
+	}
+}
+
+func TestHubStatusFilter(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+
+	room := WorkflowRoom("tenant-1", "wf-1")
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("failed-1"), Sequence: 1, Status: StatusClassFailed})
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("completed-2"), Sequence: 2, Status: StatusClassCompleted})
+
+	client := &Client{
+		ID:            "test-client-1",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+		StatusFilter:  map[string]bool{StatusClassFailed: true},
+	}
+	if err := hub.SubscribeClientSince(client, room, 0); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("running-3"), Sequence: 3, Status: StatusClassRunning})
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("failed-4"), Sequence: 4, Status: StatusClassFailed})
+	hub.broadcastToRoom(&BroadcastMessage{Room: room, Message: []byte("notice")})
+
+	var received []string
+	for len(client.Send) > 0 {
+		received = append(received, string(<-client.Send))
+	}
+
+	expected := []string{"failed-1", "failed-4", "notice"}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, received)
+			break
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package websocket
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// reorderWindow is how long an event that arrived ahead of its execution
+	// sequence waits for the events before it
+	reorderWindow = 2 * time.Second
+
+	// reorderBufferSize is the most events held back per execution; past it
+	// the buffer is flushed without waiting for the missing events
+	reorderBufferSize = 100
+
+	// finishedOrderTTL is how long a finished execution's ordering state is
+	// kept, so late duplicates are still dropped
+	finishedOrderTTL = historyTTL
+
+	// idleOrderTTL is how long ordering state of an execution that never
+	// finished is kept after its last event
+	idleOrderTTL = 24 * time.Hour
+
+	// orderPruneInterval is how often stale ordering state is removed
+	orderPruneInterval = time.Minute
+)
+
+// executionOrder is the ordering state of one execution
+type executionOrder struct {
+	// assigned is the last execution sequence assigned by this process
+	assigned uint64
+	// next is the execution sequence expected to be emitted next
+	next uint64
+	// pending holds events that arrived ahead of next
+	pending map[uint64]ExecutionEvent
+	timer   *time.Timer
+
+	finished bool
+	lastSeen time.Time
+}
+
+// eventOrderer emits each execution's events once and in execution sequence
+// order. Events arriving ahead of a missing one are held for reorderWindow;
+// if it still has not arrived they are emitted anyway, so a lost event never
+// stalls the stream.
+type eventOrderer struct {
+	mu         sync.Mutex
+	executions map[string]*executionOrder
+	emit       func(ExecutionEvent)
+	window     time.Duration
+	lastPrune  time.Time
+}
+
+func newEventOrderer(emit func(ExecutionEvent)) *eventOrderer {
+	return &eventOrderer{
+		executions: make(map[string]*executionOrder),
+		emit:       emit,
+		window:     reorderWindow,
+		lastPrune:  time.Now(),
+	}
+}
+
+// nextSequence assigns the next execution sequence for an event produced by
+// this process
+func (o *eventOrderer) nextSequence(executionID string) uint64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	state := o.state(executionID)
+	state.assigned = max(state.assigned, state.next-1) + 1
+	return state.assigned
+}
+
+// accept emits an event, or the run of events it completes, in order. Events
+// already emitted or already waiting are dropped as duplicates. Events
+// without an execution sequence are emitted immediately.
+func (o *eventOrderer) accept(event ExecutionEvent) {
+	if event.ExecutionSequence == 0 {
+		o.emit(event)
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	o.pruneLocked(now)
+
+	state := o.state(event.ExecutionID)
+	state.lastSeen = now
+	seq := event.ExecutionSequence
+
+	if seq < state.next {
+		return
+	}
+	if _, waiting := state.pending[seq]; waiting {
+		return
+	}
+
+	if seq > state.next {
+		state.pending[seq] = event
+		if len(state.pending) > reorderBufferSize {
+			o.flushLocked(state)
+		} else if state.timer == nil {
+			executionID := event.ExecutionID
+			state.timer = time.AfterFunc(o.window, func() { o.flush(executionID) })
+		}
+		return
+	}
+
+	o.emitLocked(state, event)
+	for {
+		pending, ok := state.pending[state.next]
+		if !ok {
+			break
+		}
+		delete(state.pending, state.next)
+		o.emitLocked(state, pending)
+	}
+
+	if len(state.pending) == 0 && state.timer != nil {
+		state.timer.Stop()
+		state.timer = nil
+	}
+}
+
+// flush emits an execution's held-back events once its reorder window ends
+func (o *eventOrderer) flush(executionID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if state, exists := o.executions[executionID]; exists {
+		o.flushLocked(state)
+	}
+}
+
+// flushLocked emits all held-back events in order, skipping the missing ones
+func (o *eventOrderer) flushLocked(state *executionOrder) {
+	if state.timer != nil {
+		state.timer.Stop()
+		state.timer = nil
+	}
+
+	sequences := make([]uint64, 0, len(state.pending))
+	for seq := range state.pending {
+		sequences = append(sequences, seq)
+	}
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+
+	for _, seq := range sequences {
+		event := state.pending[seq]
+		delete(state.pending, seq)
+		state.next = seq
+		o.emitLocked(state, event)
+	}
+}
+
+// emitLocked emits the event at state.next and advances it
+func (o *eventOrderer) emitLocked(state *executionOrder, event ExecutionEvent) {
+	state.next = event.ExecutionSequence + 1
+	if isTerminal(event.Type) {
+		state.finished = true
+	}
+	o.emit(event)
+}
+
+// state returns an execution's ordering state, creating it if needed; the
+// caller must hold o.mu
+func (o *eventOrderer) state(executionID string) *executionOrder {
+	state, exists := o.executions[executionID]
+	if !exists {
+		state = &executionOrder{
+			next:     1,
+			pending:  make(map[uint64]ExecutionEvent),
+			lastSeen: time.Now(),
+		}
+		o.executions[executionID] = state
+	}
+	return state
+}
+
+// pruneLocked removes the state of executions finished longer than
+// finishedOrderTTL ago or idle longer than idleOrderTTL; the caller must
+// hold o.mu
+func (o *eventOrderer) pruneLocked(now time.Time) {
+	if now.Sub(o.lastPrune) < orderPruneInterval {
+		return
+	}
+	o.lastPrune = now
+
+	for executionID, state := range o.executions {
+		if len(state.pending) > 0 {
+			continue
+		}
+		idle := now.Sub(state.lastSeen)
+		if (state.finished && idle > finishedOrderTTL) || idle > idleOrderTTL {
+			delete(o.executions, executionID)
+		}
+	}
+}
+
+// isTerminal reports whether an event type ends an execution
+func isTerminal(eventType EventType) bool {
+	return eventType == EventTypeExecutionCompleted || eventType == EventTypeExecutionFailed
+}
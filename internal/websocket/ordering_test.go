@@ -0,0 +1,206 @@
+package websocket
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedEvents collects the events an orderer emits
+type recordedEvents struct {
+	mu     sync.Mutex
+	events []ExecutionEvent
+}
+
+func (r *recordedEvents) emit(event ExecutionEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordedEvents) sequences() []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sequences := make([]uint64, 0, len(r.events))
+	for _, event := range r.events {
+		sequences = append(sequences, event.ExecutionSequence)
+	}
+	return sequences
+}
+
+func sequencedEvent(executionID string, seq uint64) ExecutionEvent {
+	return ExecutionEvent{Type: EventTypeStepCompleted, ExecutionID: executionID, ExecutionSequence: seq}
+}
+
+func assertSequences(t *testing.T, got, want []uint64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Expected sequences %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected sequences %v, got %v", want, got)
+		}
+	}
+}
+
+func TestEventOrdererReordersEvents(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	orderer.accept(sequencedEvent("exec-1", 2))
+	orderer.accept(sequencedEvent("exec-1", 3))
+	assertSequences(t, recorded.sequences(), []uint64{})
+
+	orderer.accept(sequencedEvent("exec-1", 1))
+	assertSequences(t, recorded.sequences(), []uint64{1, 2, 3})
+}
+
+func TestEventOrdererDropsDuplicates(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	orderer.accept(sequencedEvent("exec-1", 1))
+	orderer.accept(sequencedEvent("exec-1", 1))
+	orderer.accept(sequencedEvent("exec-1", 3))
+	orderer.accept(sequencedEvent("exec-1", 3))
+	orderer.accept(sequencedEvent("exec-1", 2))
+	orderer.accept(sequencedEvent("exec-1", 2))
+
+	assertSequences(t, recorded.sequences(), []uint64{1, 2, 3})
+}
+
+func TestEventOrdererKeepsExecutionsApart(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	orderer.accept(sequencedEvent("exec-1", 1))
+	orderer.accept(sequencedEvent("exec-2", 1))
+	orderer.accept(sequencedEvent("exec-2", 2))
+
+	assertSequences(t, recorded.sequences(), []uint64{1, 1, 2})
+}
+
+func TestEventOrdererFlushesAfterWindow(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+	orderer.window = 20 * time.Millisecond
+
+	orderer.accept(sequencedEvent("exec-1", 1))
+	orderer.accept(sequencedEvent("exec-1", 4))
+	orderer.accept(sequencedEvent("exec-1", 3))
+	assertSequences(t, recorded.sequences(), []uint64{1})
+
+	// Event 2 never arrives, so 3 and 4 are emitted once the window ends
+	time.Sleep(100 * time.Millisecond)
+	assertSequences(t, recorded.sequences(), []uint64{1, 3, 4})
+
+	// Event 2 arriving after the flush is too late and dropped
+	orderer.accept(sequencedEvent("exec-1", 2))
+	orderer.accept(sequencedEvent("exec-1", 5))
+	assertSequences(t, recorded.sequences(), []uint64{1, 3, 4, 5})
+}
+
+func TestEventOrdererFlushesFullBuffer(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	for seq := uint64(2); seq <= reorderBufferSize+2; seq++ {
+		orderer.accept(sequencedEvent("exec-1", seq))
+	}
+
+	if got := len(recorded.sequences()); got != reorderBufferSize+1 {
+		t.Fatalf("Expected %d events flushed, got %d", reorderBufferSize+1, got)
+	}
+}
+
+func TestEventOrdererPassesUnsequencedEvents(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	orderer.accept(sequencedEvent("exec-1", 0))
+	orderer.accept(sequencedEvent("exec-1", 0))
+
+	assertSequences(t, recorded.sequences(), []uint64{0, 0})
+}
+
+func TestEventOrdererPrunesFinishedExecutions(t *testing.T) {
+	recorded := &recordedEvents{}
+	orderer := newEventOrderer(recorded.emit)
+
+	orderer.accept(ExecutionEvent{Type: EventTypeExecutionCompleted, ExecutionID: "exec-1", ExecutionSequence: 1})
+	orderer.executions["exec-1"].lastSeen = time.Now().Add(-finishedOrderTTL - time.Minute)
+	orderer.lastPrune = time.Now().Add(-orderPruneInterval)
+
+	orderer.accept(sequencedEvent("exec-2", 1))
+
+	if _, exists := orderer.executions["exec-1"]; exists {
+		t.Error("Expected finished execution's ordering state to be pruned")
+	}
+	if _, exists := orderer.executions["exec-2"]; !exists {
+		t.Error("Expected running execution's ordering state to be kept")
+	}
+}
+
+func TestHubBroadcasterPublishEventOrdersAndDedupes(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	hub := NewHub(logger)
+	go hub.Run()
+
+	broadcaster := NewHubBroadcaster(hub)
+
+	client := &Client{
+		ID:            "test-client",
+		TenantID:      "tenant-1",
+		Hub:           hub,
+		Send:          make(chan []byte, 256),
+		Subscriptions: make(map[string]bool),
+	}
+	hub.Register <- client
+	time.Sleep(10 * time.Millisecond)
+	hub.SubscribeClient(client, ExecutionRoom("tenant-1", "exec-123"))
+
+	// Events relayed from two workers arrive out of order and one twice
+	for _, seq := range []uint64{2, 1, 2, 3} {
+		broadcaster.PublishEvent(ExecutionEvent{
+			Type:              EventTypeStepCompleted,
+			ExecutionID:       "exec-123",
+			WorkflowID:        "workflow-1",
+			TenantID:          "tenant-1",
+			ExecutionSequence: seq,
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	var got []uint64
+	var previous uint64
+	for len(client.Send) > 0 {
+		var event ExecutionEvent
+		if err := json.Unmarshal(<-client.Send, &event); err != nil {
+			t.Fatalf("Failed to unmarshal event: %v", err)
+		}
+		if event.Sequence <= previous {
+			t.Errorf("Expected sequence greater than %d, got %d", previous, event.Sequence)
+		}
+		previous = event.Sequence
+		got = append(got, event.ExecutionSequence)
+	}
+
+	assertSequences(t, got, []uint64{1, 2, 3})
+}
+
+func TestHubBroadcasterAssignsExecutionSequences(t *testing.T) {
+	recorded := &recordedEvents{}
+	broadcaster := NewHubBroadcaster(NewHub(slog.New(slog.NewJSONHandler(os.Stdout, nil))))
+	broadcaster.ordering = newEventOrderer(recorded.emit)
+
+	broadcaster.BroadcastExecutionStarted("tenant-1", "workflow-1", "exec-1", 1)
+	broadcaster.BroadcastExecutionStarted("tenant-1", "workflow-1", "exec-2", 1)
+	broadcaster.BroadcastStepStarted("tenant-1", "workflow-1", "exec-1", "node-1", "action:http")
+	broadcaster.BroadcastExecutionCompleted("tenant-1", "workflow-1", "exec-1", json.RawMessage(`{}`))
+
+	assertSequences(t, recorded.sequences(), []uint64{1, 1, 2, 3})
+}
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gorax/gorax/internal/notification"
+	"github.com/gorax/gorax/internal/tenant"
+	"github.com/gorax/gorax/internal/workflow"
+)
+
+// autoPauseNotifier sends an in-app notification to the workflow owner when a
+// workflow is automatically paused
+type autoPauseNotifier struct {
+	inApp *notification.InAppService
+}
+
+// NotifyWorkflowAutoPaused implements workflow.AutoPauseNotifier
+func (n *autoPauseNotifier) NotifyWorkflowAutoPaused(ctx context.Context, wf *workflow.Workflow, reason string) error {
+	tenantID, err := uuid.Parse(wf.TenantID)
+	if err != nil {
+		return fmt.Errorf("invalid tenant id %q: %w", wf.TenantID, err)
+	}
+	if wf.CreatedBy == "" {
+		return nil
+	}
+
+	return n.inApp.NotifyWorkflowAutoPaused(ctx, tenantID, wf.CreatedBy, wf.Name, reason, "/workflows/"+wf.ID)
+}
+
+// tenantAutoPauseDefaults returns a lookup of the tenant-wide auto-pause policy
+func tenantAutoPauseDefaults(repo *tenant.Repository) workflow.TenantAutoPauseDefaults {
+	return func(ctx context.Context, tenantID string) (*workflow.AutoPauseConfig, error) {
+		t, err := repo.GetByID(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if len(t.Settings) == 0 {
+			return nil, nil
+		}
+
+		settings, err := t.GetSettings()
+		if err != nil {
+			return nil, err
+		}
+		return settings.WorkflowAutoPause, nil
+	}
+}
+
+// evaluateAutoPause checks whether a failed execution pushed its workflow over
+// the failure-rate threshold. Errors are logged, never returned, so that
+// auto-pause bookkeeping cannot affect execution processing.
+func (w *Worker) evaluateAutoPause(ctx context.Context, execution *workflow.Execution) {
+	if w.autoPauser == nil {
+		return
+	}
+
+	if _, err := w.autoPauser.Evaluate(ctx, execution.TenantID, execution.WorkflowID); err != nil {
+		w.logger.Error("failed to evaluate workflow auto-pause",
+			"error", err,
+			"workflow_id", execution.WorkflowID,
+			"execution_id", execution.ID,
+		)
+	}
+}
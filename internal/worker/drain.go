@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorax/gorax/internal/queue"
+)
+
+const (
+	// checkpointGrace is how long Drain waits for interrupted executions to
+	// stop at their current node and write their checkpoint
+	checkpointGrace = 10 * time.Second
+	// drainPollInterval is how often Drain checks for running executions
+	drainPollInterval = 100 * time.Millisecond
+	// requeueTimeout bounds publishing a checkpointed execution during shutdown
+	requeueTimeout = 5 * time.Second
+)
+
+// Drain waits up to timeout for running executions to finish after the
+// worker's context is cancelled. Executions still running after the timeout
+// are interrupted and checkpointed so another worker resumes them from their
+// current node. Drain returns once the processing loops have exited.
+func (w *Worker) Drain(timeout time.Duration) {
+	if !w.waitIdle(timeout) {
+		interrupted := w.executor.InterruptAll()
+		w.logger.Warn("drain timeout reached, checkpointing running executions",
+			"timeout", timeout,
+			"interrupted", interrupted,
+		)
+
+		if !w.waitIdle(checkpointGrace) {
+			w.logger.Error("executions did not stop after interruption",
+				"active_executions", w.getActiveExecutions(),
+			)
+			return
+		}
+	}
+
+	w.wg.Wait()
+	w.logger.Info("worker drained")
+}
+
+// waitIdle waits up to timeout for the active execution count to reach zero
+// and reports whether it did
+func (w *Worker) waitIdle(timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for w.getActiveExecutions() > 0 {
+		select {
+		case <-deadline.C:
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+// detachFromShutdown returns a context for running an execution that keeps
+// ctx's values and deadline but is not cancelled when the worker shuts down.
+// Running executions are stopped by Drain instead.
+func detachFromShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return context.WithCancel(detached)
+}
+
+// requeueCheckpointed publishes a new message for a checkpointed execution so
+// the worker that picks it up resumes it. The message being processed is
+// then acknowledged, so only one copy of the execution stays queued.
+func (w *Worker) requeueCheckpointed(ctx context.Context, msg *queue.ExecutionMessage) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), requeueTimeout)
+	defer cancel()
+
+	resume := queue.NewExecutionMessage(msg.ExecutionID, msg.TenantID, msg.WorkflowID, msg.WorkflowVersion, msg.TriggerType, msg.TriggerData)
+	resume.CorrelationID = msg.CorrelationID
+
+	if err := w.publisher.PublishExecution(ctx, resume); err != nil {
+		w.logger.Error("failed to requeue checkpointed execution, relying on redelivery",
+			"error", err,
+			"execution_id", msg.ExecutionID,
+		)
+		return err
+	}
+
+	w.logger.Info("requeued checkpointed execution", "execution_id", msg.ExecutionID)
+	return nil
+}
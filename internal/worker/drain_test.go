@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWaitIdle tests that waitIdle returns once active executions finish and gives up at the timeout
+func TestWaitIdle(t *testing.T) {
+	w := &Worker{}
+	assert.True(t, w.waitIdle(time.Second), "idle worker should not wait")
+
+	w.activeExecutions.Add(1)
+	assert.False(t, w.waitIdle(50*time.Millisecond), "busy worker should time out")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		w.activeExecutions.Add(-1)
+	}()
+	assert.True(t, w.waitIdle(time.Second))
+}
+
+// TestDetachFromShutdown tests that execution contexts survive shutdown but keep their deadline
+func TestDetachFromShutdown(t *testing.T) {
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	processCtx, cancelProcess := context.WithTimeout(shutdownCtx, time.Minute)
+	defer cancelProcess()
+
+	execCtx, cancel := detachFromShutdown(processCtx)
+	defer cancel()
+
+	shutdown()
+	require.Error(t, processCtx.Err())
+	assert.NoError(t, execCtx.Err(), "shutdown should not cancel a running execution")
+
+	processDeadline, _ := processCtx.Deadline()
+	execDeadline, ok := execCtx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, processDeadline, execDeadline)
+}
@@ -8,7 +8,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
 	"github.com/gorax/gorax/internal/buildinfo"
+	"github.com/gorax/gorax/internal/executor"
 )
 
 // HealthServer provides health check endpoints for the worker
@@ -33,6 +36,8 @@ type WorkerInfo struct {
 	ActiveExecutions int32 `json:"active_executions"`
 	ProcessedTotal   int64 `json:"processed_total"`
 	FailedTotal      int64 `json:"failed_total"`
+	// WorkflowConcurrency lists workflows with a max_concurrency limit that have running or waiting executions
+	WorkflowConcurrency []executor.WorkflowConcurrency `json:"workflow_concurrency,omitempty"`
 }
 
 // ConnectionsHealth contains connection status
@@ -52,6 +57,9 @@ func NewHealthServer(worker *Worker, port string) *HealthServer {
 	mux.HandleFunc("/health/live", hs.handleLiveness)
 	mux.HandleFunc("/health/ready", hs.handleReadiness)
 	mux.HandleFunc("/health", hs.handleHealth)
+	if worker.metricsRegistry != nil {
+		mux.Handle("/metrics", promhttp.HandlerFor(worker.metricsRegistry, promhttp.HandlerOpts{}))
+	}
 
 	hs.server = &http.Server{
 		Addr:         ":" + port,
@@ -152,6 +160,10 @@ func (hs *HealthServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	if hs.worker.executor != nil {
+		response.WorkerInfo.WorkflowConcurrency = hs.worker.executor.WorkflowConcurrency()
+	}
+
 	// If any connection is unhealthy, set overall status to unhealthy
 	if response.Connections.Database != "ok" || response.Connections.Redis != "ok" || response.Connections.Queue != "ok" {
 		response.Status = "unhealthy"
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// queueDepthInterval is how often the queue depth gauge is refreshed
+	queueDepthInterval = 15 * time.Second
+	// queueDepthLabel is the queue label used for the execution queue
+	queueDepthLabel = "executions"
+)
+
+// collectQueueDepth periodically publishes the number of executions waiting
+// to be picked up until ctx is cancelled
+func (w *Worker) collectQueueDepth(ctx context.Context) {
+	ticker := time.NewTicker(queueDepthInterval)
+	defer ticker.Stop()
+
+	for {
+		w.updateQueueDepth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateQueueDepth sets the queue depth gauge from SQS in queue mode or
+// from pending executions in polling mode
+func (w *Worker) updateQueueDepth(ctx context.Context) {
+	depth, err := w.queueDepth(ctx)
+	if err != nil {
+		w.logger.Warn("failed to read queue depth", "error", err)
+		return
+	}
+	w.metrics.SetQueueDepth(queueDepthLabel, float64(depth))
+}
+
+// queueDepth returns the number of executions waiting to be processed
+func (w *Worker) queueDepth(ctx context.Context) (int, error) {
+	if w.queueEnabled && w.sqsClient != nil {
+		attrs, err := w.sqsClient.GetQueueAttributes(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return attrs.ApproximateNumberOfMessages, nil
+	}
+
+	var total int
+	for _, db := range w.executionDBs() {
+		var depth int
+		if err := db.GetContext(ctx, &depth, `SELECT COUNT(*) FROM executions WHERE status = 'pending'`); err != nil {
+			return 0, err
+		}
+		total += depth
+	}
+	return total, nil
+}
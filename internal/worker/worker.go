@@ -4,17 +4,31 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 
 	"github.com/gorax/gorax/internal/config"
+	"github.com/gorax/gorax/internal/database"
+	"github.com/gorax/gorax/internal/envvars"
 	"github.com/gorax/gorax/internal/executor"
+	"github.com/gorax/gorax/internal/executor/actions"
+	"github.com/gorax/gorax/internal/featureflag"
+	"github.com/gorax/gorax/internal/integrations/slack"
+	"github.com/gorax/gorax/internal/metrics"
+	"github.com/gorax/gorax/internal/nodecache"
+	"github.com/gorax/gorax/internal/notification"
 	"github.com/gorax/gorax/internal/queue"
+	"github.com/gorax/gorax/internal/residency"
+	"github.com/gorax/gorax/internal/security"
+	"github.com/gorax/gorax/internal/tenant"
 	"github.com/gorax/gorax/internal/workflow"
 )
 
@@ -26,16 +40,27 @@ type Worker struct {
 	redis        *redis.Client
 	executor     *executor.Executor
 	workflowRepo *workflow.Repository
+	autoPauser   *workflow.AutoPauser
+
+	// Execution databases of the data regions tenants can be pinned to.
+	// Polling claims from these in turn with the primary database.
+	regionDBs  map[string]*sqlx.DB
+	pollCursor atomic.Uint64
 
 	// Queue-based processing
 	queueConsumer *queue.Consumer
 	sqsClient     *queue.SQSClient
+	publisher     *queue.Publisher
 	queueEnabled  bool
 
 	concurrency      int
 	concurrencyLimit *TenantConcurrencyLimiter
 	wg               sync.WaitGroup
 
+	// Prometheus metrics, nil when METRICS_ENABLED is false
+	metrics         *metrics.Metrics
+	metricsRegistry *prometheus.Registry
+
 	// Metrics
 	activeExecutions atomic.Int32
 	processedTotal   atomic.Int64
@@ -45,7 +70,7 @@ type Worker struct {
 // New creates a new worker instance
 func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 	// Initialize database connection
-	db, err := sqlx.Connect("postgres", cfg.Database.ConnectionString())
+	db, err := database.Connect(context.Background(), cfg.Database)
 	if err != nil {
 		return nil, err
 	}
@@ -59,9 +84,48 @@ func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 
 	// Initialize workflow repository
 	workflowRepo := workflow.NewRepository(db)
+	tenantRepo := tenant.NewRepository(db)
+
+	// Store the executions of tenants pinned to a data region in that
+	// region's database
+	var regionDBs map[string]*sqlx.DB
+	if len(cfg.DataResidency.Regions) > 0 {
+		regionDBs, err = residency.ConnectDatabases(context.Background(), cfg.DataResidency.Regions)
+		if err != nil {
+			return nil, err
+		}
+		resolver := residency.NewResolver(tenantRepo, residency.DefaultRegionCacheTTL)
+		workflowRepo.SetExecutionDBRouter(residency.NewRouter(resolver, residency.ExecutionStore, db, regionDBs))
+	}
 
 	// Initialize executor
 	exec := executor.New(workflowRepo, logger)
+	exec.SetHTTPOptions(actions.HTTPOptions{
+		AllowInsecureTLS:   cfg.HTTPAction.InsecureTLSAllowed(cfg.Server.Env),
+		MaxResponseBytes:   cfg.HTTPAction.MaxResponseBytes,
+		MaxResponseHeaders: cfg.HTTPAction.MaxResponseHeaders,
+		URLValidator: security.NewURLValidatorWithConfig(&security.URLValidatorConfig{
+			Enabled:         cfg.SSRF.ProtectionEnabled(cfg.Server.Env),
+			AllowedNetworks: cfg.SSRF.AllowedNetworks,
+			BlockedNetworks: cfg.SSRF.BlockedNetworks,
+			AllowedHosts:    cfg.SSRF.AllowedHosts,
+			BlockedHosts:    cfg.SSRF.BlockedHosts,
+		}),
+	})
+	exec.SetRedactor(executor.NewRedactor(cfg.Redaction.SensitiveKeys))
+	exec.SetFeatureFlags(featureflag.NewService(featureflag.NewRepository(db), logger))
+	exec.SetEnvResolver(envvars.NewService(envvars.NewRepository(db), logger))
+	exec.SetNodeResultCache(nodecache.NewRepository(db))
+	exec.SetMaxExecutionDuration(cfg.Workflow.MaxExecutionDuration)
+	if cfg.OAuth.SlackSigningSecret != "" {
+		exec.SetSlackInteractions(slack.NewInteractionRepository(db))
+	}
+
+	// Dead-letter failed executions and start on-failure workflows. Without
+	// an executor the service leaves those executions pending for the poll
+	// loop; in queue mode a publisher is set below.
+	failureService := workflow.NewService(workflowRepo, logger)
+	exec.SetFailureHandler(failureService)
 
 	// Initialize tenant concurrency limiter
 	// Default to 10 concurrent executions per tenant if not configured
@@ -71,6 +135,14 @@ func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 	}
 	concurrencyLimit := NewTenantConcurrencyLimiter(redisClient, maxPerTenant)
 
+	// Initialize failure-rate auto-pause
+	autoPauser := workflow.NewAutoPauser(
+		workflowRepo,
+		tenantAutoPauseDefaults(tenantRepo),
+		&autoPauseNotifier{inApp: notification.NewInAppService(notification.NewInAppRepository(db), nil)},
+		logger,
+	)
+
 	w := &Worker{
 		config:           cfg,
 		logger:           logger,
@@ -78,11 +150,23 @@ func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 		redis:            redisClient,
 		executor:         exec,
 		workflowRepo:     workflowRepo,
+		regionDBs:        regionDBs,
+		autoPauser:       autoPauser,
 		concurrency:      cfg.Worker.Concurrency,
 		concurrencyLimit: concurrencyLimit,
 		queueEnabled:     cfg.Queue.Enabled,
 	}
 
+	// Initialize Prometheus metrics, served on the health server
+	if cfg.Observability.MetricsEnabled {
+		w.metrics = metrics.NewMetrics()
+		w.metricsRegistry = prometheus.NewRegistry()
+		if err := w.metrics.Register(w.metricsRegistry); err != nil {
+			return nil, err
+		}
+		exec.SetMetrics(w.metrics)
+	}
+
 	// Initialize queue consumer if enabled
 	if cfg.Queue.Enabled {
 		if cfg.AWS.SQSQueueURL == "" {
@@ -122,6 +206,8 @@ func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 		// Create consumer
 		w.queueConsumer = queue.NewConsumer(sqsClient, handler, consumerConfig, logger)
 		w.sqsClient = sqsClient // Store SQS client for requeue operations
+		w.publisher = queue.NewPublisher(sqsClient, logger)
+		failureService.SetQueuePublisher(queue.NewPublisherAdapter(w.publisher, logger))
 		logger.Info("queue consumer initialized", "queue_url", cfg.AWS.SQSQueueURL)
 	}
 
@@ -130,6 +216,10 @@ func New(cfg *config.Config, logger *slog.Logger) (*Worker, error) {
 
 // Start begins processing jobs
 func (w *Worker) Start(ctx context.Context) error {
+	if w.metrics != nil {
+		go w.collectQueueDepth(ctx)
+	}
+
 	if w.queueEnabled && w.queueConsumer != nil {
 		// Use queue-based processing
 		w.logger.Info("starting queue-based worker", "queue_enabled", true)
@@ -202,8 +292,33 @@ func (w *Worker) pollPendingExecution(ctx context.Context) (*workflow.Execution,
 	return execution, nil
 }
 
-// claimPendingExecution atomically claims a pending execution
+// executionDBs returns the primary database followed by the execution
+// database of each data region
+func (w *Worker) executionDBs() []*sqlx.DB {
+	dbs := []*sqlx.DB{w.db}
+	for _, name := range slices.Sorted(maps.Keys(w.regionDBs)) {
+		dbs = append(dbs, w.regionDBs[name])
+	}
+	return dbs
+}
+
+// claimPendingExecution atomically claims a pending execution, starting from
+// a different execution database on each poll so no region is starved
 func (w *Worker) claimPendingExecution(ctx context.Context) (*workflow.Execution, error) {
+	dbs := w.executionDBs()
+	start := int(w.pollCursor.Add(1) % uint64(len(dbs)))
+	for i := range dbs {
+		execution, err := w.claimPendingExecutionFrom(ctx, dbs[(start+i)%len(dbs)])
+		if errors.Is(err, ErrNoWork) {
+			continue
+		}
+		return execution, err
+	}
+	return nil, ErrNoWork
+}
+
+// claimPendingExecutionFrom atomically claims a pending execution from db
+func (w *Worker) claimPendingExecutionFrom(ctx context.Context, db *sqlx.DB) (*workflow.Execution, error) {
 	// Use FOR UPDATE SKIP LOCKED for atomic claim without blocking
 	query := `
 		UPDATE executions
@@ -221,7 +336,7 @@ func (w *Worker) claimPendingExecution(ctx context.Context) (*workflow.Execution
 	now := time.Now()
 	var execution workflow.Execution
 
-	err := w.db.QueryRowxContext(ctx, query, "running", now).StructScan(&execution)
+	err := db.QueryRowxContext(ctx, query, "running", now).StructScan(&execution)
 	if err != nil {
 		if err.Error() == "sql: no rows in result set" {
 			return nil, ErrNoWork
@@ -237,23 +352,34 @@ func (w *Worker) markStaleExecutionsAsFailed(ctx context.Context) error {
 	staleThreshold := time.Now().Add(-1 * time.Hour)
 	errorMsg := "execution timeout: pending for more than 1 hour"
 
+	// Checkpointed executions are measured from their checkpoint so a
+	// long-running workflow is not failed as soon as it is requeued
 	query := `
 		UPDATE executions
 		SET status = $1,
 		    error_message = $2,
 		    completed_at = $3
 		WHERE status = 'pending'
-		  AND created_at < $4
+		  AND COALESCE(checkpointed_at, created_at) < $4
 	`
 
-	_, err := w.db.ExecContext(ctx, query, "failed", errorMsg, time.Now(), staleThreshold)
-	return err
+	var errs []error
+	for _, db := range w.executionDBs() {
+		if _, err := db.ExecContext(ctx, query, "failed", errorMsg, time.Now(), staleThreshold); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // processExecution processes a single execution
 func (w *Worker) processExecution(ctx context.Context, execution *workflow.Execution) error {
 	w.logger.Info("processing execution", "execution_id", execution.ID, "workflow_id", execution.WorkflowID, "tenant_id", execution.TenantID)
 
+	// Track active executions from the start so Drain cannot miss one being set up
+	w.activeExecutions.Add(1)
+	defer w.activeExecutions.Add(-1)
+
 	// Try to acquire tenant concurrency slot
 	acquired, err := w.concurrencyLimit.Acquire(ctx, execution.TenantID, execution.ID)
 	if err != nil {
@@ -277,21 +403,30 @@ func (w *Worker) processExecution(ctx context.Context, execution *workflow.Execu
 		return ErrTenantAtCapacity
 	}
 
+	// Shutdown does not cancel the execution; Drain interrupts it if it outlasts the drain timeout
+	execCtx, cancel := detachFromShutdown(ctx)
+	defer cancel()
+
 	// Release the slot when done
 	defer func() {
-		if err := w.concurrencyLimit.Release(ctx, execution.TenantID, execution.ID); err != nil {
+		if err := w.concurrencyLimit.Release(execCtx, execution.TenantID, execution.ID); err != nil {
 			w.logger.Error("failed to release tenant concurrency slot", "error", err, "tenant_id", execution.TenantID)
 		}
 	}()
 
-	// Track active executions
-	w.activeExecutions.Add(1)
-	defer w.activeExecutions.Add(-1)
-
 	// Execute the workflow
-	err = w.executor.Execute(ctx, execution)
+	err = w.executor.Execute(execCtx, execution)
+	if errors.Is(err, executor.ErrExecutionCancelled) {
+		w.logger.Info("execution cancelled", "execution_id", execution.ID)
+		return nil
+	}
+	if errors.Is(err, executor.ErrExecutionInterrupted) {
+		w.logger.Info("execution checkpointed for resumption", "execution_id", execution.ID)
+		return err
+	}
 	if err != nil {
 		w.failedTotal.Add(1)
+		w.evaluateAutoPause(ctx, execution)
 		return err
 	}
 
@@ -312,6 +447,11 @@ func (w *Worker) processExecutionMessage(ctx context.Context, msg *queue.Executi
 		"retry_count", msg.RetryCount,
 	)
 
+	// Leave messages buffered at shutdown on the queue for another worker
+	if ctx.Err() != nil {
+		return ErrShuttingDown
+	}
+
 	// Load execution from database
 	execution, err := w.workflowRepo.GetExecutionByID(ctx, msg.TenantID, msg.ExecutionID)
 	if err != nil {
@@ -324,6 +464,11 @@ func (w *Worker) processExecutionMessage(ctx context.Context, msg *queue.Executi
 
 	// Process the execution
 	if err := w.processExecution(ctx, execution); err != nil {
+		// A checkpointed execution resumes from a new message
+		if errors.Is(err, executor.ErrExecutionInterrupted) {
+			return w.requeueCheckpointed(ctx, msg)
+		}
+
 		// If tenant at capacity, log and return error
 		// The consumer will not delete the message, allowing SQS to retry
 		if errors.Is(err, ErrTenantAtCapacity) {
@@ -354,6 +499,7 @@ func (w *Worker) Close() error {
 	if w.db != nil {
 		w.db.Close()
 	}
+	residency.CloseDatabases(w.regionDBs)
 	if w.redis != nil {
 		w.redis.Close()
 	}
@@ -388,4 +534,5 @@ var (
 	ErrNoWork           = WorkerError{Message: "no work available"}
 	ErrTenantAtCapacity = WorkerError{Message: "tenant at concurrency capacity"}
 	ErrMissingQueueURL  = WorkerError{Message: "queue URL is required when queue is enabled"}
+	ErrShuttingDown     = WorkerError{Message: "worker is shutting down"}
 )
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
@@ -224,6 +225,39 @@ func TestProcessExecution_RequeuesWhenTenantAtCapacity(t *testing.T) {
 	assert.Equal(t, "pending", updatedExec2.Status)
 }
 
+// TestClaimPendingExecution_RegionDatabases tests that polling claims
+// executions stored in data region databases
+func TestClaimPendingExecution_RegionDatabases(t *testing.T) {
+	newMockDB := func() (*sqlx.DB, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		return sqlx.NewDb(db, "sqlmock"), mock
+	}
+	primary, primaryMock := newMockDB()
+	eu, euMock := newMockDB()
+	w := &Worker{db: primary, regionDBs: map[string]*sqlx.DB{"eu": eu}}
+
+	claim := `UPDATE executions\s+SET status = \$1, started_at = \$2`
+	euMock.ExpectQuery(claim).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "status"}).AddRow("exec-eu", "tenant-eu", "running"))
+
+	// The first poll starts from the region database
+	execution, err := w.claimPendingExecution(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "exec-eu", execution.ID)
+
+	// The next poll starts from the primary database and finds nothing
+	primaryMock.ExpectQuery(claim).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	euMock.ExpectQuery(claim).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	execution, err = w.claimPendingExecution(context.Background())
+	assert.Nil(t, execution)
+	assert.ErrorIs(t, err, ErrNoWork)
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, euMock.ExpectationsWereMet())
+}
+
 // Helper functions for testing
 
 // setupTestDB creates an in-memory test database
@@ -0,0 +1,246 @@
+package workflow
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Built-in auto-pause thresholds used when neither the workflow nor the tenant sets them
+const (
+	DefaultAutoPauseFailureRate   = 0.8
+	DefaultAutoPauseMinExecutions = 10
+	DefaultAutoPauseWindowMinutes = 60
+	maxAutoPauseWindowMinutes     = 7 * 24 * 60
+)
+
+// AutoPauseConfig controls automatic pausing of a workflow whose failure rate
+// over a recent window exceeds a threshold. Unset fields inherit from the
+// tenant default and then the built-in defaults.
+type AutoPauseConfig struct {
+	Enabled              *bool   `json:"enabled,omitempty"`
+	FailureRateThreshold float64 `json:"failure_rate_threshold,omitempty"`
+	MinExecutions        int     `json:"min_executions,omitempty"`
+	WindowMinutes        int     `json:"window_minutes,omitempty"`
+}
+
+// Validate checks that configured thresholds are within range
+func (c *AutoPauseConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.FailureRateThreshold < 0 || c.FailureRateThreshold > 1 {
+		return &ValidationError{Message: "auto_pause failure_rate_threshold must be between 0 and 1"}
+	}
+	if c.MinExecutions < 0 {
+		return &ValidationError{Message: "auto_pause min_executions must not be negative"}
+	}
+	if c.WindowMinutes < 0 || c.WindowMinutes > maxAutoPauseWindowMinutes {
+		return &ValidationError{Message: fmt.Sprintf("auto_pause window_minutes must be between 0 and %d", maxAutoPauseWindowMinutes)}
+	}
+	return nil
+}
+
+// IsEnabled reports whether auto-pause is switched on
+func (c *AutoPauseConfig) IsEnabled() bool {
+	return c != nil && c.Enabled != nil && *c.Enabled
+}
+
+// Window returns the evaluation window as a duration
+func (c *AutoPauseConfig) Window() time.Duration {
+	return time.Duration(c.WindowMinutes) * time.Minute
+}
+
+// ResolveAutoPauseConfig merges a workflow override over the tenant default and
+// fills any remaining gaps with the built-in defaults
+func ResolveAutoPauseConfig(workflowConfig, tenantDefault *AutoPauseConfig) AutoPauseConfig {
+	resolved := AutoPauseConfig{}
+	for _, layer := range []*AutoPauseConfig{tenantDefault, workflowConfig} {
+		if layer == nil {
+			continue
+		}
+		if layer.Enabled != nil {
+			enabled := *layer.Enabled
+			resolved.Enabled = &enabled
+		}
+		if layer.FailureRateThreshold > 0 {
+			resolved.FailureRateThreshold = layer.FailureRateThreshold
+		}
+		if layer.MinExecutions > 0 {
+			resolved.MinExecutions = layer.MinExecutions
+		}
+		if layer.WindowMinutes > 0 {
+			resolved.WindowMinutes = layer.WindowMinutes
+		}
+	}
+
+	if resolved.FailureRateThreshold == 0 {
+		resolved.FailureRateThreshold = DefaultAutoPauseFailureRate
+	}
+	if resolved.MinExecutions == 0 {
+		resolved.MinExecutions = DefaultAutoPauseMinExecutions
+	}
+	if resolved.WindowMinutes == 0 {
+		resolved.WindowMinutes = DefaultAutoPauseWindowMinutes
+	}
+	return resolved
+}
+
+// ExecutionOutcomeCounts holds finished execution counts for a workflow within a window
+type ExecutionOutcomeCounts struct {
+	Total  int `db:"total"`
+	Failed int `db:"failed"`
+}
+
+// FailureRate returns the fraction of finished executions that failed
+func (c ExecutionOutcomeCounts) FailureRate() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Failed) / float64(c.Total)
+}
+
+// ShouldAutoPause decides whether the given outcomes breach the configured
+// threshold. The minimum sample size guards against pausing on a handful of runs.
+func (c AutoPauseConfig) ShouldAutoPause(counts ExecutionOutcomeCounts) (bool, string) {
+	if !c.IsEnabled() || counts.Total < c.MinExecutions {
+		return false, ""
+	}
+
+	rate := counts.FailureRate()
+	if rate < c.FailureRateThreshold {
+		return false, ""
+	}
+
+	reason := fmt.Sprintf(
+		"failure rate %.0f%% (%d of %d executions) in the last %d minutes exceeded the %.0f%% threshold",
+		rate*100, counts.Failed, counts.Total, c.WindowMinutes, c.FailureRateThreshold*100,
+	)
+	return true, reason
+}
+
+// Scan implements sql.Scanner for AutoPauseConfig stored as JSONB
+func (c *AutoPauseConfig) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for AutoPauseConfig: %T", value)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Value implements driver.Valuer for AutoPauseConfig stored as JSONB
+func (c *AutoPauseConfig) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// AutoPauseRepository defines the persistence operations needed to evaluate auto-pause
+type AutoPauseRepository interface {
+	GetByID(ctx context.Context, tenantID, id string) (*Workflow, error)
+	CountExecutionOutcomes(ctx context.Context, tenantID, workflowID string, since time.Time) (*ExecutionOutcomeCounts, error)
+	AutoPause(ctx context.Context, tenantID, id, reason string) (bool, error)
+}
+
+// AutoPauseNotifier is told when a workflow has been automatically paused
+type AutoPauseNotifier interface {
+	NotifyWorkflowAutoPaused(ctx context.Context, workflow *Workflow, reason string) error
+}
+
+// TenantAutoPauseDefaults returns the tenant-wide default auto-pause config, or nil
+type TenantAutoPauseDefaults func(ctx context.Context, tenantID string) (*AutoPauseConfig, error)
+
+// AutoPauser pauses workflows whose recent failure rate breaches their threshold
+type AutoPauser struct {
+	repo           AutoPauseRepository
+	tenantDefaults TenantAutoPauseDefaults
+	notifier       AutoPauseNotifier
+	logger         *slog.Logger
+	now            func() time.Time
+}
+
+// NewAutoPauser creates a new auto-pauser. tenantDefaults and notifier may be nil.
+func NewAutoPauser(repo AutoPauseRepository, tenantDefaults TenantAutoPauseDefaults, notifier AutoPauseNotifier, logger *slog.Logger) *AutoPauser {
+	return &AutoPauser{
+		repo:           repo,
+		tenantDefaults: tenantDefaults,
+		notifier:       notifier,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+// Evaluate checks a workflow's recent failure rate and pauses it when the
+// threshold is exceeded. It returns true if this call paused the workflow.
+// A paused workflow stays inactive until it is manually re-enabled.
+func (p *AutoPauser) Evaluate(ctx context.Context, tenantID, workflowID string) (bool, error) {
+	wf, err := p.repo.GetByID(ctx, tenantID, workflowID)
+	if err != nil {
+		return false, fmt.Errorf("load workflow: %w", err)
+	}
+	if wf.Status != string(WorkflowStatusActive) {
+		return false, nil
+	}
+
+	var tenantDefault *AutoPauseConfig
+	if p.tenantDefaults != nil {
+		tenantDefault, err = p.tenantDefaults(ctx, tenantID)
+		if err != nil {
+			p.logger.Warn("failed to load tenant auto-pause defaults", "error", err, "tenant_id", tenantID)
+		}
+	}
+
+	config := ResolveAutoPauseConfig(wf.AutoPauseConfig, tenantDefault)
+	if !config.IsEnabled() {
+		return false, nil
+	}
+
+	counts, err := p.repo.CountExecutionOutcomes(ctx, tenantID, workflowID, p.now().Add(-config.Window()))
+	if err != nil {
+		return false, fmt.Errorf("count execution outcomes: %w", err)
+	}
+
+	pause, reason := config.ShouldAutoPause(*counts)
+	if !pause {
+		return false, nil
+	}
+
+	paused, err := p.repo.AutoPause(ctx, tenantID, workflowID, reason)
+	if err != nil {
+		return false, fmt.Errorf("auto-pause workflow: %w", err)
+	}
+	if !paused {
+		// Another worker paused it first or it was disabled concurrently
+		return false, nil
+	}
+
+	p.logger.Warn("workflow auto-paused due to high failure rate",
+		"workflow_id", workflowID,
+		"tenant_id", tenantID,
+		"failed", counts.Failed,
+		"total", counts.Total,
+		"reason", reason,
+	)
+
+	if p.notifier != nil {
+		if err := p.notifier.NotifyWorkflowAutoPaused(ctx, wf, reason); err != nil {
+			p.logger.Error("failed to send auto-pause notification", "error", err, "workflow_id", workflowID)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,205 @@
+package workflow
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAutoPauseRepo struct {
+	workflow    *Workflow
+	counts      ExecutionOutcomeCounts
+	since       time.Time
+	pausedWith  string
+	pauseResult bool
+}
+
+func (r *fakeAutoPauseRepo) GetByID(ctx context.Context, tenantID, id string) (*Workflow, error) {
+	return r.workflow, nil
+}
+
+func (r *fakeAutoPauseRepo) CountExecutionOutcomes(ctx context.Context, tenantID, workflowID string, since time.Time) (*ExecutionOutcomeCounts, error) {
+	r.since = since
+	return &r.counts, nil
+}
+
+func (r *fakeAutoPauseRepo) AutoPause(ctx context.Context, tenantID, id, reason string) (bool, error) {
+	r.pausedWith = reason
+	return r.pauseResult, nil
+}
+
+type fakeAutoPauseNotifier struct {
+	notified []string
+}
+
+func (n *fakeAutoPauseNotifier) NotifyWorkflowAutoPaused(ctx context.Context, wf *Workflow, reason string) error {
+	n.notified = append(n.notified, wf.ID)
+	return nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestResolveAutoPauseConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		workflowConfig *AutoPauseConfig
+		tenantDefault  *AutoPauseConfig
+		want           AutoPauseConfig
+	}{
+		{
+			name: "built-in defaults, disabled",
+			want: AutoPauseConfig{
+				FailureRateThreshold: DefaultAutoPauseFailureRate,
+				MinExecutions:        DefaultAutoPauseMinExecutions,
+				WindowMinutes:        DefaultAutoPauseWindowMinutes,
+			},
+		},
+		{
+			name:          "tenant default applies",
+			tenantDefault: &AutoPauseConfig{Enabled: boolPtr(true), FailureRateThreshold: 0.5},
+			want: AutoPauseConfig{
+				Enabled:              boolPtr(true),
+				FailureRateThreshold: 0.5,
+				MinExecutions:        DefaultAutoPauseMinExecutions,
+				WindowMinutes:        DefaultAutoPauseWindowMinutes,
+			},
+		},
+		{
+			name:           "workflow overrides tenant field by field",
+			tenantDefault:  &AutoPauseConfig{Enabled: boolPtr(true), FailureRateThreshold: 0.5, MinExecutions: 20},
+			workflowConfig: &AutoPauseConfig{Enabled: boolPtr(false), WindowMinutes: 15},
+			want: AutoPauseConfig{
+				Enabled:              boolPtr(false),
+				FailureRateThreshold: 0.5,
+				MinExecutions:        20,
+				WindowMinutes:        15,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ResolveAutoPauseConfig(tt.workflowConfig, tt.tenantDefault))
+		})
+	}
+}
+
+func TestAutoPauseConfig_ShouldAutoPause(t *testing.T) {
+	config := AutoPauseConfig{Enabled: boolPtr(true), FailureRateThreshold: 0.8, MinExecutions: 10, WindowMinutes: 60}
+
+	tests := []struct {
+		name   string
+		config AutoPauseConfig
+		counts ExecutionOutcomeCounts
+		want   bool
+	}{
+		{name: "below minimum sample size", config: config, counts: ExecutionOutcomeCounts{Total: 9, Failed: 9}},
+		{name: "below threshold", config: config, counts: ExecutionOutcomeCounts{Total: 10, Failed: 7}},
+		{name: "at threshold", config: config, counts: ExecutionOutcomeCounts{Total: 10, Failed: 8}, want: true},
+		{name: "disabled", config: AutoPauseConfig{FailureRateThreshold: 0.8, MinExecutions: 10}, counts: ExecutionOutcomeCounts{Total: 10, Failed: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.config.ShouldAutoPause(tt.counts)
+			assert.Equal(t, tt.want, got)
+			if tt.want {
+				assert.Contains(t, reason, "8 of 10 executions")
+			}
+		})
+	}
+}
+
+func TestAutoPauseConfig_Validate(t *testing.T) {
+	assert.NoError(t, (*AutoPauseConfig)(nil).Validate())
+	assert.NoError(t, (&AutoPauseConfig{FailureRateThreshold: 0.5, MinExecutions: 5, WindowMinutes: 30}).Validate())
+	assert.Error(t, (&AutoPauseConfig{FailureRateThreshold: 1.5}).Validate())
+	assert.Error(t, (&AutoPauseConfig{MinExecutions: -1}).Validate())
+	assert.Error(t, (&AutoPauseConfig{WindowMinutes: maxAutoPauseWindowMinutes + 1}).Validate())
+}
+
+func TestAutoPauser_Evaluate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("pauses and notifies when threshold exceeded", func(t *testing.T) {
+		repo := &fakeAutoPauseRepo{
+			workflow: &Workflow{
+				ID:              "wf-1",
+				Status:          string(WorkflowStatusActive),
+				AutoPauseConfig: &AutoPauseConfig{WindowMinutes: 30},
+			},
+			counts:      ExecutionOutcomeCounts{Total: 12, Failed: 11},
+			pauseResult: true,
+		}
+		notifier := &fakeAutoPauseNotifier{}
+		tenantDefaults := func(ctx context.Context, tenantID string) (*AutoPauseConfig, error) {
+			return &AutoPauseConfig{Enabled: boolPtr(true)}, nil
+		}
+
+		pauser := NewAutoPauser(repo, tenantDefaults, notifier, logger)
+		pauser.now = func() time.Time { return now }
+
+		paused, err := pauser.Evaluate(context.Background(), "tenant-1", "wf-1")
+		require.NoError(t, err)
+		assert.True(t, paused)
+		assert.Equal(t, now.Add(-30*time.Minute), repo.since)
+		assert.Contains(t, repo.pausedWith, "11 of 12 executions")
+		assert.Equal(t, []string{"wf-1"}, notifier.notified)
+	})
+
+	t.Run("does nothing when not enabled", func(t *testing.T) {
+		repo := &fakeAutoPauseRepo{
+			workflow: &Workflow{ID: "wf-1", Status: string(WorkflowStatusActive)},
+			counts:   ExecutionOutcomeCounts{Total: 12, Failed: 12},
+		}
+		notifier := &fakeAutoPauseNotifier{}
+
+		paused, err := NewAutoPauser(repo, nil, notifier, logger).Evaluate(context.Background(), "tenant-1", "wf-1")
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.Empty(t, repo.pausedWith)
+		assert.Empty(t, notifier.notified)
+	})
+
+	t.Run("skips workflows that are not active", func(t *testing.T) {
+		repo := &fakeAutoPauseRepo{
+			workflow: &Workflow{
+				ID:              "wf-1",
+				Status:          string(WorkflowStatusInactive),
+				AutoPauseConfig: &AutoPauseConfig{Enabled: boolPtr(true)},
+			},
+			counts: ExecutionOutcomeCounts{Total: 12, Failed: 12},
+		}
+
+		paused, err := NewAutoPauser(repo, nil, nil, logger).Evaluate(context.Background(), "tenant-1", "wf-1")
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.Empty(t, repo.pausedWith)
+	})
+
+	t.Run("no notification when already paused concurrently", func(t *testing.T) {
+		repo := &fakeAutoPauseRepo{
+			workflow: &Workflow{
+				ID:              "wf-1",
+				Status:          string(WorkflowStatusActive),
+				AutoPauseConfig: &AutoPauseConfig{Enabled: boolPtr(true)},
+			},
+			counts:      ExecutionOutcomeCounts{Total: 12, Failed: 12},
+			pauseResult: false,
+		}
+		notifier := &fakeAutoPauseNotifier{}
+
+		paused, err := NewAutoPauser(repo, nil, notifier, logger).Evaluate(context.Background(), "tenant-1", "wf-1")
+		require.NoError(t, err)
+		assert.False(t, paused)
+		assert.Empty(t, notifier.notified)
+	})
+}
@@ -264,21 +264,7 @@ func (s *BulkService) extractWebhookNodes(definition json.RawMessage) []WebhookN
 	var webhookNodes []WebhookNodeConfig
 	for _, node := range def.Nodes {
 		if node.Type == string(NodeTypeTriggerWebhook) {
-			// Parse config to get auth type
-			var config WebhookTriggerConfig
-			authType := AuthTypeSignature // Default
-			if node.Data.Config != nil {
-				if err := json.Unmarshal(node.Data.Config, &config); err == nil {
-					if config.AuthType != "" {
-						authType = config.AuthType
-					}
-				}
-			}
-
-			webhookNodes = append(webhookNodes, WebhookNodeConfig{
-				NodeID:   node.ID,
-				AuthType: authType,
-			})
+			webhookNodes = append(webhookNodes, newWebhookNodeConfig(node))
 		}
 	}
 
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // TestIntegration_BulkDeleteWorkflows tests bulk deletion of workflows
@@ -586,14 +589,54 @@ func (m *mockRepository) UpdateExecutionStatus(ctx context.Context, id string, s
 	return nil
 }
 
+func (m *mockRepository) CancelExecution(ctx context.Context, tenantID, id, cancelledBy string) (*Execution, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*StepExecution, error) {
 	return nil, nil
 }
 
+func (m *mockRepository) CreateRetryExecution(ctx context.Context, original *Execution, workflowVersion int, fromNode string, seeds []*StepExecution) (*Execution, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) CreateDeadLetter(ctx context.Context, execution *Execution, failure ExecutionFailure) (*DeadLetter, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetter, error) {
+	return nil, ErrDeadLetterNotFound
+}
+
+func (m *mockRepository) ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error) {
+	return &DeadLetterListResult{}, nil
+}
+
+func (m *mockRepository) RequeueDeadLetter(ctx context.Context, entry *DeadLetter, workflowVersion int) (*Execution, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) ClaimIdempotencyKey(ctx context.Context, tenantID, key, requestHash string, ttl time.Duration) (*IdempotencyKey, bool, error) {
+	return nil, false, nil
+}
+
+func (m *mockRepository) CompleteIdempotencyKey(ctx context.Context, tenantID, key, executionID string) error {
+	return nil
+}
+
+func (m *mockRepository) ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error {
+	return nil
+}
+
 func (m *mockRepository) ListExecutions(ctx context.Context, tenantID string, workflowID string, limit, offset int) ([]*Execution, error) {
 	return nil, nil
 }
 
+func (m *mockRepository) ListExecutionsPage(ctx context.Context, tenantID string, workflowID string, page pagination.Params) ([]*Execution, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) ListExecutionsAdvanced(ctx context.Context, tenantID string, filter ExecutionFilter, cursor string, limit int) (*ExecutionListResult, error) {
 	return nil, nil
 }
@@ -606,6 +649,10 @@ func (m *mockRepository) CountExecutions(ctx context.Context, tenantID string, f
 	return 0, nil
 }
 
+func (m *mockRepository) SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error) {
+	return nil, nil
+}
+
 func (m *mockRepository) CreateWorkflowVersion(ctx context.Context, workflowID string, version int, definition json.RawMessage, createdBy string) (*WorkflowVersion, error) {
 	return nil, nil
 }
@@ -7,9 +7,12 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // MockBulkRepository is a mock implementation of RepositoryInterface for testing
@@ -75,6 +78,14 @@ func (m *MockBulkRepository) UpdateExecutionStatus(ctx context.Context, id strin
 	return args.Error(0)
 }
 
+func (m *MockBulkRepository) CancelExecution(ctx context.Context, tenantID, id, cancelledBy string) (*Execution, error) {
+	args := m.Called(ctx, tenantID, id, cancelledBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
 func (m *MockBulkRepository) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*StepExecution, error) {
 	args := m.Called(ctx, executionID)
 	if args.Get(0) == nil {
@@ -83,6 +94,64 @@ func (m *MockBulkRepository) GetStepExecutionsByExecutionID(ctx context.Context,
 	return args.Get(0).([]*StepExecution), args.Error(1)
 }
 
+func (m *MockBulkRepository) CreateRetryExecution(ctx context.Context, original *Execution, workflowVersion int, fromNode string, seeds []*StepExecution) (*Execution, error) {
+	args := m.Called(ctx, original, workflowVersion, fromNode, seeds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
+func (m *MockBulkRepository) CreateDeadLetter(ctx context.Context, execution *Execution, failure ExecutionFailure) (*DeadLetter, error) {
+	args := m.Called(ctx, execution, failure)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetter), args.Error(1)
+}
+
+func (m *MockBulkRepository) GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetter, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetter), args.Error(1)
+}
+
+func (m *MockBulkRepository) ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetterListResult), args.Error(1)
+}
+
+func (m *MockBulkRepository) RequeueDeadLetter(ctx context.Context, entry *DeadLetter, workflowVersion int) (*Execution, error) {
+	args := m.Called(ctx, entry, workflowVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
+func (m *MockBulkRepository) ClaimIdempotencyKey(ctx context.Context, tenantID, key, requestHash string, ttl time.Duration) (*IdempotencyKey, bool, error) {
+	args := m.Called(ctx, tenantID, key, requestHash, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*IdempotencyKey), args.Bool(1), args.Error(2)
+}
+
+func (m *MockBulkRepository) CompleteIdempotencyKey(ctx context.Context, tenantID, key, executionID string) error {
+	args := m.Called(ctx, tenantID, key, executionID)
+	return args.Error(0)
+}
+
+func (m *MockBulkRepository) ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error {
+	args := m.Called(ctx, tenantID, key)
+	return args.Error(0)
+}
+
 func (m *MockBulkRepository) ListExecutions(ctx context.Context, tenantID string, workflowID string, limit, offset int) ([]*Execution, error) {
 	args := m.Called(ctx, tenantID, workflowID, limit, offset)
 	if args.Get(0) == nil {
@@ -91,6 +160,14 @@ func (m *MockBulkRepository) ListExecutions(ctx context.Context, tenantID string
 	return args.Get(0).([]*Execution), args.Error(1)
 }
 
+func (m *MockBulkRepository) ListExecutionsPage(ctx context.Context, tenantID string, workflowID string, page pagination.Params) ([]*Execution, error) {
+	args := m.Called(ctx, tenantID, workflowID, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Execution), args.Error(1)
+}
+
 func (m *MockBulkRepository) ListExecutionsAdvanced(ctx context.Context, tenantID string, filter ExecutionFilter, cursor string, limit int) (*ExecutionListResult, error) {
 	args := m.Called(ctx, tenantID, filter, cursor, limit)
 	if args.Get(0) == nil {
@@ -112,6 +189,14 @@ func (m *MockBulkRepository) CountExecutions(ctx context.Context, tenantID strin
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockBulkRepository) SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ExecutionSearchResult), args.Error(1)
+}
+
 func (m *MockBulkRepository) CreateWorkflowVersion(ctx context.Context, workflowID string, version int, definition json.RawMessage, createdBy string) (*WorkflowVersion, error) {
 	args := m.Called(ctx, workflowID, version, definition, createdBy)
 	if args.Get(0) == nil {
@@ -1120,6 +1205,19 @@ func TestBulkService_ExtractWebhookNodes(t *testing.T) {
 		assert.Equal(t, "basic", nodes[0].AuthType)
 	})
 
+	t.Run("webhook node with signature scheme", func(t *testing.T) {
+		definition := json.RawMessage(`{"nodes":[
+			{"id":"node-1","type":"trigger:webhook","data":{"config":{"auth_type":"signature","signature_scheme":"slack","secret":"slack-secret"}}},
+			{"id":"node-2","type":"trigger:webhook","data":{"config":{"signature_scheme":"github","secret":"${env.GITHUB_WEBHOOK_SECRET}"}}}
+		],"edges":[]}`)
+		nodes := bulkService.extractWebhookNodes(definition)
+		assert.Equal(t, 2, len(nodes))
+		assert.Equal(t, "slack", nodes[0].SignatureScheme)
+		assert.Equal(t, "slack-secret", nodes[0].Secret)
+		assert.Equal(t, "github", nodes[1].SignatureScheme)
+		assert.Empty(t, nodes[1].Secret, "unresolved expressions are not used as secrets")
+	})
+
 	t.Run("multiple webhook nodes", func(t *testing.T) {
 		definition := json.RawMessage(`{"nodes":[
 			{"id":"node-1","type":"trigger:webhook","data":{"config":{"auth_type":"signature"}}},
@@ -0,0 +1,375 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorax/gorax/internal/eventtypes"
+)
+
+// BundleFormatVersion is the version of the bundle format written by
+// ExportWorkflow. ImportWorkflow rejects bundles with a newer version.
+const BundleFormatVersion = 1
+
+// Reference kinds reported when an imported workflow has unmet prerequisites
+const (
+	ReferenceKindEventType     = "event_type"
+	ReferenceKindWorkflow      = "workflow"
+	ReferenceKindWebhookSecret = "webhook_secret"
+)
+
+// WorkflowBundle is a portable export of a single workflow. It carries the
+// definition, the schemas of the event types its webhook triggers are bound
+// to, and a manifest naming what the target tenant must provide. It never
+// contains credential values or webhook signing secrets.
+type WorkflowBundle struct {
+	FormatVersion int               `json:"format_version"`
+	ExportedAt    time.Time         `json:"exported_at"`
+	SourceID      string            `json:"source_id"`
+	Workflow      BundleWorkflow    `json:"workflow"`
+	EventTypes    []BundleEventType `json:"event_types"`
+	Manifest      BundleManifest    `json:"manifest"`
+}
+
+// BundleWorkflow is the workflow carried in a bundle
+type BundleWorkflow struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Definition  json.RawMessage `json:"definition"`
+}
+
+// BundleEventType is the schema of an event type referenced by a webhook
+// trigger. Version is the version the schema was resolved from.
+type BundleEventType struct {
+	Name    string          `json:"name"`
+	Version int             `json:"version"`
+	Schema  json.RawMessage `json:"schema"`
+}
+
+// BundleManifest names the prerequisites of a bundled workflow
+type BundleManifest struct {
+	// Credentials are the credential names referenced by the definition
+	Credentials []string `json:"credentials"`
+	// Env are the non-built-in env names referenced by the definition
+	Env []string `json:"env"`
+	// Workflows are the IDs of other workflows started as sub-workflows
+	Workflows []string `json:"workflows"`
+	// WebhookSecrets are the webhook trigger nodes whose signing secret was
+	// removed from the bundle and must be set again after import
+	WebhookSecrets []string `json:"webhook_secrets"`
+}
+
+// EventTypeRegistry resolves registered event type schemas
+type EventTypeRegistry interface {
+	GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error)
+}
+
+// SetEventTypeRegistry sets the registry used to bundle event type schemas
+// and to check them on import. Without one, bundles carry no schemas and
+// event types are not checked.
+func (s *Service) SetEventTypeRegistry(registry EventTypeRegistry) {
+	s.eventTypes = registry
+}
+
+// eventTypeReference is an event type bound to a webhook trigger node
+type eventTypeReference struct {
+	NodeID  string
+	Name    string
+	Version int
+}
+
+// ExportWorkflow returns a workflow as a JSON bundle that can be imported
+// into another tenant or installation
+func (s *Service) ExportWorkflow(ctx context.Context, tenantID, id string) ([]byte, error) {
+	wf, err := s.repo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var def WorkflowDefinition
+	if err := json.Unmarshal(wf.Definition, &def); err != nil {
+		return nil, fmt.Errorf("parse workflow definition: %w", err)
+	}
+
+	bundle := WorkflowBundle{
+		FormatVersion: BundleFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		SourceID:      wf.ID,
+		Workflow: BundleWorkflow{
+			Name:        wf.Name,
+			Description: wf.Description,
+		},
+		EventTypes: []BundleEventType{},
+		Manifest: BundleManifest{
+			Credentials:    []string{},
+			Env:            []string{},
+			Workflows:      []string{},
+			WebhookSecrets: []string{},
+		},
+	}
+
+	for i, node := range def.Nodes {
+		config := node.Data.Config
+		bundle.Manifest.Credentials = append(bundle.Manifest.Credentials, referencedNames(credentialReferenceRegex, config)...)
+		for _, name := range referencedNames(envReferenceRegex, config) {
+			if !slices.Contains(builtinEnvVars, name) {
+				bundle.Manifest.Env = append(bundle.Manifest.Env, name)
+			}
+		}
+		if target := subWorkflowTarget(node); target != "" && target != wf.ID {
+			bundle.Manifest.Workflows = append(bundle.Manifest.Workflows, target)
+		}
+		if node.Type == string(NodeTypeTriggerWebhook) {
+			stripped, removed, err := stripWebhookSecret(config)
+			if err != nil {
+				return nil, fmt.Errorf("node %s: %w", node.ID, err)
+			}
+			if removed {
+				def.Nodes[i].Data.Config = stripped
+				bundle.Manifest.WebhookSecrets = append(bundle.Manifest.WebhookSecrets, node.ID)
+			}
+		}
+	}
+	bundle.Manifest.Credentials = sortedUnique(bundle.Manifest.Credentials)
+	bundle.Manifest.Env = sortedUnique(bundle.Manifest.Env)
+	bundle.Manifest.Workflows = sortedUnique(bundle.Manifest.Workflows)
+
+	if s.eventTypes != nil {
+		seen := make(map[string]bool)
+		for _, ref := range eventTypeReferences(def) {
+			schema, err := s.eventTypes.GetEventSchema(ctx, ref.Name, ref.Version)
+			if err != nil {
+				return nil, fmt.Errorf("event type %s: %w", ref.Name, err)
+			}
+			key := fmt.Sprintf("%s@%d", schema.EventType, schema.Version)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			bundle.EventTypes = append(bundle.EventTypes, BundleEventType{
+				Name:    schema.EventType,
+				Version: schema.Version,
+				Schema:  schema.Schema,
+			})
+		}
+	}
+
+	bundle.Workflow.Definition, err = json.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("marshal workflow definition: %w", err)
+	}
+
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// ImportWorkflow creates a workflow owned by userID from a bundle produced by
+// ExportWorkflow. The workflow gets a new ID, and sub-workflow nodes that
+// started the exported workflow itself are remapped to it. Prerequisites the tenant does
+// not meet are returned in ReferenceWarnings: unresolved credential and env
+// references, event types missing from the registry, sub-workflows that do
+// not exist in the tenant, and webhook secrets removed on export.
+func (s *Service) ImportWorkflow(ctx context.Context, tenantID, userID string, bundle []byte) (*Workflow, error) {
+	var b WorkflowBundle
+	if err := json.Unmarshal(bundle, &b); err != nil {
+		return nil, &ValidationError{Message: "invalid workflow bundle JSON: " + err.Error()}
+	}
+	if b.FormatVersion < 1 || b.FormatVersion > BundleFormatVersion {
+		return nil, &ValidationError{Message: fmt.Sprintf("unsupported workflow bundle format version %d", b.FormatVersion)}
+	}
+	if b.Workflow.Name == "" {
+		return nil, &ValidationError{Message: "workflow bundle is missing the workflow name"}
+	}
+	if len(b.Workflow.Definition) == 0 {
+		return nil, &ValidationError{Message: "workflow bundle is missing the workflow definition"}
+	}
+
+	var def WorkflowDefinition
+	if err := json.Unmarshal(b.Workflow.Definition, &def); err != nil {
+		return nil, &ValidationError{Message: "invalid definition JSON: " + err.Error()}
+	}
+
+	wf, err := s.Create(ctx, tenantID, userID, CreateWorkflowInput{
+		Name:        b.Workflow.Name,
+		Description: b.Workflow.Description,
+		Definition:  b.Workflow.Definition,
+	})
+	if err != nil {
+		return nil, err
+	}
+	warnings := wf.ReferenceWarnings
+
+	if b.SourceID != "" && remapSubWorkflows(def, b.SourceID, wf.ID) {
+		definition, err := json.Marshal(def)
+		if err != nil {
+			return nil, fmt.Errorf("marshal workflow definition: %w", err)
+		}
+		updated, err := s.repo.Update(ctx, tenantID, wf.ID, UpdateWorkflowInput{Definition: definition})
+		if err != nil {
+			return nil, fmt.Errorf("remap sub-workflow references: %w", err)
+		}
+		wf = updated
+	}
+
+	prerequisites, err := s.checkImportPrerequisites(ctx, tenantID, def, b, wf.ID)
+	if err != nil {
+		return nil, err
+	}
+	wf.ReferenceWarnings = append(warnings, prerequisites...)
+
+	s.logger.Info("workflow imported",
+		"workflow_id", wf.ID,
+		"source_id", b.SourceID,
+		"tenant_id", tenantID,
+		"unmet_prerequisites", len(wf.ReferenceWarnings),
+	)
+	return wf, nil
+}
+
+// checkImportPrerequisites reports the prerequisites of an imported
+// definition that are not covered by the reference checks run on save
+func (s *Service) checkImportPrerequisites(ctx context.Context, tenantID string, def WorkflowDefinition, b WorkflowBundle, workflowID string) ([]ReferenceIssue, error) {
+	var issues []ReferenceIssue
+
+	if s.eventTypes != nil {
+		for _, ref := range eventTypeReferences(def) {
+			_, err := s.eventTypes.GetEventSchema(ctx, ref.Name, ref.Version)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, eventtypes.ErrNotFound) {
+				return nil, fmt.Errorf("check event type %s: %w", ref.Name, err)
+			}
+			message := fmt.Sprintf("event type %s is not registered", ref.Name)
+			if ref.Version > 0 {
+				message = fmt.Sprintf("event type %s version %d is not registered", ref.Name, ref.Version)
+			}
+			issues = append(issues, ReferenceIssue{
+				NodeID:  ref.NodeID,
+				Kind:    ReferenceKindEventType,
+				Name:    ref.Name,
+				Message: message,
+			})
+		}
+	}
+
+	for _, node := range def.Nodes {
+		target := subWorkflowTarget(node)
+		if target == "" || target == b.SourceID || target == workflowID {
+			continue
+		}
+		_, err := s.repo.GetByID(ctx, tenantID, target)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("check sub-workflow %s: %w", target, err)
+		}
+		issues = append(issues, ReferenceIssue{
+			NodeID:  node.ID,
+			Kind:    ReferenceKindWorkflow,
+			Name:    target,
+			Message: fmt.Sprintf("sub-workflow %s does not exist", target),
+		})
+	}
+
+	for _, nodeID := range b.Manifest.WebhookSecrets {
+		issues = append(issues, ReferenceIssue{
+			NodeID:  nodeID,
+			Kind:    ReferenceKindWebhookSecret,
+			Name:    "secret",
+			Message: "webhook signing secret was not exported and must be set again",
+		})
+	}
+
+	return issues, nil
+}
+
+// eventTypeReferences returns the event types bound to webhook trigger nodes
+func eventTypeReferences(def WorkflowDefinition) []eventTypeReference {
+	var refs []eventTypeReference
+	for _, node := range def.Nodes {
+		if node.Type != string(NodeTypeTriggerWebhook) {
+			continue
+		}
+		config := newWebhookNodeConfig(node)
+		if config.EventType == "" {
+			continue
+		}
+		refs = append(refs, eventTypeReference{
+			NodeID:  node.ID,
+			Name:    config.EventType,
+			Version: config.EventTypeVersion,
+		})
+	}
+	return refs
+}
+
+// subWorkflowTarget returns the workflow ID a sub-workflow node starts
+func subWorkflowTarget(node Node) string {
+	if node.Type != string(NodeTypeActionSubworkflow) && node.Type != string(NodeTypeControlSubWorkflow) {
+		return ""
+	}
+	var config SubWorkflowConfig
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return ""
+	}
+	return config.WorkflowID
+}
+
+// remapSubWorkflows points sub-workflow nodes that start the workflow with
+// sourceID at targetID, reporting whether any node changed
+func remapSubWorkflows(def WorkflowDefinition, sourceID, targetID string) bool {
+	changed := false
+	for i, node := range def.Nodes {
+		if subWorkflowTarget(node) != sourceID {
+			continue
+		}
+		var config map[string]interface{}
+		if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+			continue
+		}
+		config["workflow_id"] = targetID
+		remapped, err := json.Marshal(config)
+		if err != nil {
+			continue
+		}
+		def.Nodes[i].Data.Config = remapped
+		changed = true
+	}
+	return changed
+}
+
+// stripWebhookSecret removes a literal signing secret from a webhook trigger
+// config. Secrets given as expressions such as ${env.SECRET} only name the
+// secret and are kept.
+func stripWebhookSecret(config json.RawMessage) (json.RawMessage, bool, error) {
+	if len(bytes.TrimSpace(config)) == 0 {
+		return config, false, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(config, &fields); err != nil {
+		return nil, false, fmt.Errorf("parse webhook config: %w", err)
+	}
+	var secret string
+	if raw, ok := fields["secret"]; !ok || json.Unmarshal(raw, &secret) != nil || secret == "" || strings.Contains(secret, "${") {
+		return config, false, nil
+	}
+	delete(fields, "secret")
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return stripped, true, nil
+}
+
+// sortedUnique sorts names and removes duplicates
+func sortedUnique(names []string) []string {
+	slices.Sort(names)
+	return slices.Compact(names)
+}
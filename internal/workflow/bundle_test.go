@@ -0,0 +1,168 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/eventtypes"
+)
+
+type mockEventTypeRegistry struct {
+	mock.Mock
+}
+
+func (m *mockEventTypeRegistry) GetEventSchema(ctx context.Context, name string, version int) (*eventtypes.EventSchema, error) {
+	args := m.Called(ctx, name, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*eventtypes.EventSchema), args.Error(1)
+}
+
+var bundledDefinition = json.RawMessage(`{
+	"nodes": [
+		{"id": "trigger", "type": "trigger:webhook", "data": {"config": {
+			"auth_type": "signature", "signature_scheme": "stripe", "secret": "whsec_live_123", "event_type": "order.created"
+		}}},
+		{"id": "call", "type": "action:http", "data": {"config": {
+			"url": "${env.API_URL}/orders/${env.tenant_id}",
+			"headers": {"Authorization": "Bearer {{credentials.api-token}}"}
+		}}},
+		{"id": "enrich", "type": "action:subworkflow", "data": {"config": {"workflow_id": "wf-enrich", "mode": "sync"}}},
+		{"id": "again", "type": "action:subworkflow", "data": {"config": {"workflow_id": "wf-source", "mode": "async"}}}
+	],
+	"edges": [
+		{"id": "e1", "source": "trigger", "target": "call"},
+		{"id": "e2", "source": "call", "target": "enrich"},
+		{"id": "e3", "source": "enrich", "target": "again"}
+	]
+}`)
+
+func TestExportWorkflow(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo := newTestService()
+	registry := new(mockEventTypeRegistry)
+	service.SetEventTypeRegistry(registry)
+
+	mockRepo.On("GetByID", ctx, "tenant-1", "wf-source").Return(&Workflow{
+		ID:          "wf-source",
+		Name:        "Order intake",
+		Description: "Handles new orders",
+		Definition:  bundledDefinition,
+	}, nil)
+	registry.On("GetEventSchema", ctx, "order.created", 0).Return(&eventtypes.EventSchema{
+		EventType: "order.created",
+		Version:   2,
+		Schema:    json.RawMessage(`{"type":"object"}`),
+	}, nil)
+
+	data, err := service.ExportWorkflow(ctx, "tenant-1", "wf-source")
+	require.NoError(t, err)
+
+	var bundle WorkflowBundle
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	assert.Equal(t, BundleFormatVersion, bundle.FormatVersion)
+	assert.Equal(t, "wf-source", bundle.SourceID)
+	assert.Equal(t, "Order intake", bundle.Workflow.Name)
+	require.Len(t, bundle.EventTypes, 1)
+	assert.Equal(t, "order.created", bundle.EventTypes[0].Name)
+	assert.Equal(t, 2, bundle.EventTypes[0].Version)
+	assert.JSONEq(t, `{"type":"object"}`, string(bundle.EventTypes[0].Schema))
+	assert.Equal(t, BundleManifest{
+		Credentials:    []string{"api-token"},
+		Env:            []string{"API_URL"},
+		Workflows:      []string{"wf-enrich"},
+		WebhookSecrets: []string{"trigger"},
+	}, bundle.Manifest)
+	assert.NotContains(t, string(data), "whsec_live_123")
+
+	var def WorkflowDefinition
+	require.NoError(t, json.Unmarshal(bundle.Workflow.Definition, &def))
+	assert.JSONEq(t, `{"auth_type": "signature", "signature_scheme": "stripe", "event_type": "order.created"}`, string(def.Nodes[0].Data.Config))
+}
+
+func TestImportWorkflow(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo := newTestService()
+	registry := new(mockEventTypeRegistry)
+	service.SetEventTypeRegistry(registry)
+
+	bundle := WorkflowBundle{
+		FormatVersion: BundleFormatVersion,
+		SourceID:      "wf-source",
+		Workflow: BundleWorkflow{
+			Name:       "Order intake",
+			Definition: bundledDefinition,
+		},
+		Manifest: BundleManifest{WebhookSecrets: []string{"trigger"}},
+	}
+	data, err := json.Marshal(bundle)
+	require.NoError(t, err)
+
+	mockRepo.On("Create", ctx, "tenant-2", "user-2", mock.MatchedBy(func(input CreateWorkflowInput) bool {
+		return input.Name == "Order intake"
+	})).Return(&Workflow{ID: "wf-new", TenantID: "tenant-2", Name: "Order intake"}, nil)
+	mockRepo.On("Update", ctx, "tenant-2", "wf-new", mock.MatchedBy(func(input UpdateWorkflowInput) bool {
+		var def WorkflowDefinition
+		require.NoError(t, json.Unmarshal(input.Definition, &def))
+		return subWorkflowTarget(def.Nodes[3]) == "wf-new" && subWorkflowTarget(def.Nodes[2]) == "wf-enrich"
+	})).Return(&Workflow{ID: "wf-new", TenantID: "tenant-2", Name: "Order intake", Version: 2}, nil)
+	mockRepo.On("GetByID", ctx, "tenant-2", "wf-enrich").Return(nil, ErrNotFound)
+	registry.On("GetEventSchema", ctx, "order.created", 0).Return(nil, eventtypes.ErrNotFound)
+
+	wf, err := service.ImportWorkflow(ctx, "tenant-2", "user-2", data)
+
+	require.NoError(t, err)
+	assert.Equal(t, "wf-new", wf.ID)
+	assert.Equal(t, 2, wf.Version)
+	assert.Equal(t, []ReferenceIssue{
+		{NodeID: "call", Kind: ReferenceKindEnv, Name: "API_URL", Message: "env.API_URL is not set for workflow executions"},
+		{NodeID: "trigger", Kind: ReferenceKindEventType, Name: "order.created", Message: "event type order.created is not registered"},
+		{NodeID: "enrich", Kind: ReferenceKindWorkflow, Name: "wf-enrich", Message: "sub-workflow wf-enrich does not exist"},
+		{NodeID: "trigger", Kind: ReferenceKindWebhookSecret, Name: "secret", Message: "webhook signing secret was not exported and must be set again"},
+	}, wf.ReferenceWarnings)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportWorkflow_InvalidBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  string
+		message string
+	}{
+		{name: "not JSON", bundle: `nope`, message: "invalid workflow bundle JSON"},
+		{name: "newer format", bundle: `{"format_version": 2, "workflow": {"name": "x", "definition": {}}}`, message: "unsupported workflow bundle format version 2"},
+		{name: "missing name", bundle: `{"format_version": 1, "workflow": {"definition": {}}}`, message: "workflow bundle is missing the workflow name"},
+		{name: "missing definition", bundle: `{"format_version": 1, "workflow": {"name": "x"}}`, message: "workflow bundle is missing the workflow definition"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := newTestService()
+
+			_, err := service.ImportWorkflow(context.Background(), "tenant-1", "user-1", []byte(tt.bundle))
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, validationErr.Message, tt.message)
+			mockRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestStripWebhookSecret(t *testing.T) {
+	stripped, removed, err := stripWebhookSecret(json.RawMessage(`{"auth_type": "signature", "secret": "abc"}`))
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.JSONEq(t, `{"auth_type": "signature"}`, string(stripped))
+
+	kept, removed, err := stripWebhookSecret(json.RawMessage(`{"secret": "${env.STRIPE_SECRET}"}`))
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.JSONEq(t, `{"secret": "${env.STRIPE_SECRET}"}`, string(kept))
+}
@@ -0,0 +1,285 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+// FailureTriggerType is the trigger type of executions started by a
+// workflow's on-failure workflow
+const FailureTriggerType = "failure"
+
+// Page size limits for listing dead-letter entries
+const (
+	defaultDeadLetterLimit = 20
+	maxDeadLetterLimit     = 100
+)
+
+var (
+	// ErrDeadLetterNotFound is returned when a dead-letter entry does not exist in the tenant
+	ErrDeadLetterNotFound = errors.New("dead-letter entry not found")
+	// ErrDeadLetterRequeued is returned when requeueing an entry that was already requeued
+	ErrDeadLetterRequeued = errors.New("dead-letter entry was already requeued")
+)
+
+// DeadLetterStatus represents the state of a dead-letter entry
+type DeadLetterStatus string
+
+const (
+	DeadLetterStatusPending  DeadLetterStatus = "pending"
+	DeadLetterStatusRequeued DeadLetterStatus = "requeued"
+)
+
+// DeadLetter is a failed execution kept for inspection and requeue
+type DeadLetter struct {
+	ID                  string           `db:"id" json:"id"`
+	TenantID            string           `db:"tenant_id" json:"tenant_id"`
+	WorkflowID          string           `db:"workflow_id" json:"workflow_id"`
+	ExecutionID         string           `db:"execution_id" json:"execution_id"`
+	WorkflowVersion     int              `db:"workflow_version" json:"workflow_version"`
+	TriggerType         string           `db:"trigger_type" json:"trigger_type"`
+	Payload             *json.RawMessage `db:"payload" json:"payload,omitempty"`
+	FailureReason       string           `db:"failure_reason" json:"failure_reason"`
+	FailedNodeID        *string          `db:"failed_node_id" json:"failed_node_id,omitempty"`
+	FailedNodeType      *string          `db:"failed_node_type" json:"failed_node_type,omitempty"`
+	Status              string           `db:"status" json:"status"`
+	RequeuedExecutionID *string          `db:"requeued_execution_id" json:"requeued_execution_id,omitempty"`
+	RequeuedAt          *time.Time       `db:"requeued_at" json:"requeued_at,omitempty"`
+	CreatedAt           time.Time        `db:"created_at" json:"created_at"`
+}
+
+// DeadLetterDetail is a dead-letter entry with the steps of the failed execution
+type DeadLetterDetail struct {
+	*DeadLetter
+	Steps []*StepExecution `json:"steps"`
+}
+
+// DeadLetterFilter filters listed dead-letter entries
+type DeadLetterFilter struct {
+	WorkflowID string `json:"workflow_id,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+// Validate checks the status and offset of the filter
+func (f DeadLetterFilter) Validate() error {
+	switch DeadLetterStatus(f.Status) {
+	case "", DeadLetterStatusPending, DeadLetterStatusRequeued:
+	default:
+		return fmt.Errorf("invalid status %q", f.Status)
+	}
+
+	if f.Offset < 0 {
+		return errors.New("offset must be non-negative")
+	}
+
+	return nil
+}
+
+// DeadLetterListResult represents a page of dead-letter entries
+type DeadLetterListResult struct {
+	Data       []*DeadLetter `json:"data"`
+	TotalCount int           `json:"total_count"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+}
+
+// ExecutionFailure describes why an execution failed
+type ExecutionFailure struct {
+	Reason   string
+	NodeID   string
+	NodeType string
+}
+
+// FailureContext is the trigger data of an execution started by an
+// on-failure workflow
+type FailureContext struct {
+	Error          string           `json:"error"`
+	Timestamp      string           `json:"timestamp"`
+	WorkflowID     string           `json:"workflow_id"`
+	WorkflowName   string           `json:"workflow_name"`
+	ExecutionID    string           `json:"execution_id"`
+	FailedNodeID   string           `json:"failed_node_id,omitempty"`
+	FailedNodeType string           `json:"failed_node_type,omitempty"`
+	TriggerType    string           `json:"trigger_type"`
+	TriggerData    *json.RawMessage `json:"trigger_data,omitempty"`
+	DeadLetterID   string           `json:"dead_letter_id,omitempty"`
+}
+
+// HandleExecutionFailure records a failed execution in the dead-letter queue
+// and starts the workflow's on-failure workflow, if it has one. Errors are
+// logged, never returned, so failure handling cannot affect the execution.
+func (s *Service) HandleExecutionFailure(ctx context.Context, execution *Execution, failure ExecutionFailure) {
+	// A failed sub-workflow fails its parent, which is the execution to requeue
+	if execution.ParentExecutionID != nil {
+		return
+	}
+
+	entry, err := s.repo.CreateDeadLetter(ctx, execution, failure)
+	if err != nil {
+		s.logger.Error("failed to record dead-letter entry", "error", err, "execution_id", execution.ID)
+	} else {
+		s.logger.Info("execution dead-lettered",
+			"dead_letter_id", entry.ID,
+			"execution_id", execution.ID,
+			"workflow_id", execution.WorkflowID,
+		)
+	}
+
+	// Failures of on-failure workflows are not handled again, so a failing
+	// handler cannot start itself in a loop
+	if execution.TriggerType == FailureTriggerType {
+		return
+	}
+
+	wf, err := s.repo.GetByID(ctx, execution.TenantID, execution.WorkflowID)
+	if err != nil {
+		s.logger.Error("failed to load workflow for on-failure handling", "error", err, "workflow_id", execution.WorkflowID)
+		return
+	}
+	if wf.OnFailureWorkflowID == nil {
+		return
+	}
+
+	failureContext := FailureContext{
+		Error:          failure.Reason,
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+		WorkflowID:     wf.ID,
+		WorkflowName:   wf.Name,
+		ExecutionID:    execution.ID,
+		FailedNodeID:   failure.NodeID,
+		FailedNodeType: failure.NodeType,
+		TriggerType:    execution.TriggerType,
+		TriggerData:    execution.TriggerData,
+	}
+	if entry != nil {
+		failureContext.DeadLetterID = entry.ID
+	}
+
+	triggerData, err := json.Marshal(failureContext)
+	if err != nil {
+		s.logger.Error("failed to encode failure context", "error", err, "execution_id", execution.ID)
+		return
+	}
+
+	handler, err := s.Execute(ctx, execution.TenantID, *wf.OnFailureWorkflowID, FailureTriggerType, triggerData)
+	if err != nil {
+		s.logger.Error("failed to start on-failure workflow",
+			"error", err,
+			"workflow_id", wf.ID,
+			"on_failure_workflow_id", *wf.OnFailureWorkflowID,
+			"execution_id", execution.ID,
+		)
+		return
+	}
+
+	s.logger.Info("on-failure workflow started",
+		"execution_id", execution.ID,
+		"on_failure_execution_id", handler.ID,
+		"on_failure_workflow_id", *wf.OnFailureWorkflowID,
+	)
+}
+
+// ListDeadLetters returns a page of a tenant's dead-letter entries, newest first
+func (s *Service) ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, &ValidationError{Message: err.Error()}
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeadLetterLimit
+	}
+	if filter.Limit > maxDeadLetterLimit {
+		filter.Limit = maxDeadLetterLimit
+	}
+
+	return s.repo.ListDeadLetters(ctx, tenantID, filter)
+}
+
+// GetDeadLetter returns a dead-letter entry with the steps of its failed execution
+func (s *Service) GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetterDetail, error) {
+	entry, err := s.repo.GetDeadLetter(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := s.repo.GetStepExecutionsByExecutionID(tenantctx.WithTenantID(ctx, tenantID), entry.ExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load step executions: %w", err)
+	}
+
+	return &DeadLetterDetail{DeadLetter: entry, Steps: steps}, nil
+}
+
+// RequeueDeadLetter starts a new execution of the entry's workflow with the
+// failed execution's payload and marks the entry requeued. The new execution
+// runs every node again and is linked to the failed one by retry_of_execution_id.
+func (s *Service) RequeueDeadLetter(ctx context.Context, tenantID, id string) (*Execution, error) {
+	entry, err := s.repo.GetDeadLetter(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status == string(DeadLetterStatusRequeued) {
+		return nil, ErrDeadLetterRequeued
+	}
+
+	wf, err := s.repo.GetByID(ctx, tenantID, entry.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	if wf.Status != string(WorkflowStatusActive) {
+		return nil, &ValidationError{Message: "workflow must be active to execute"}
+	}
+
+	execution, err := s.repo.RequeueDeadLetter(ctx, entry, wf.Version)
+	if err != nil {
+		if !errors.Is(err, ErrDeadLetterRequeued) {
+			s.logger.Error("failed to requeue dead-letter entry", "error", err, "dead_letter_id", id)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("dead-letter entry requeued",
+		"dead_letter_id", id,
+		"execution_id", execution.ID,
+		"retry_of", entry.ExecutionID,
+	)
+
+	var triggerData []byte
+	if entry.Payload != nil {
+		triggerData = *entry.Payload
+	}
+	s.dispatchExecution(ctx, execution, wf.Version, triggerData)
+
+	return execution, nil
+}
+
+// validateOnFailureWorkflow checks that an on-failure workflow exists in the
+// tenant and is not the workflow itself. An empty ID removes it.
+func (s *Service) validateOnFailureWorkflow(ctx context.Context, tenantID, workflowID string, onFailureWorkflowID *string) error {
+	if onFailureWorkflowID == nil || *onFailureWorkflowID == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(*onFailureWorkflowID); err != nil {
+		return &ValidationError{Message: "on_failure_workflow_id must be a workflow ID"}
+	}
+	if *onFailureWorkflowID == workflowID {
+		return &ValidationError{Message: "on_failure_workflow_id must name a different workflow"}
+	}
+
+	if _, err := s.repo.GetByID(ctx, tenantID, *onFailureWorkflowID); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &ValidationError{Message: "on_failure_workflow_id does not name a workflow"}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher captures published execution messages
+type recordingPublisher struct {
+	messages []map[string]interface{}
+}
+
+func (p *recordingPublisher) PublishExecution(ctx context.Context, msg interface{}) error {
+	p.messages = append(p.messages, msg.(map[string]interface{}))
+	return nil
+}
+
+func TestHandleExecutionFailure_StartsOnFailureWorkflow(t *testing.T) {
+	service, mockRepo := newTestService()
+	publisher := &recordingPublisher{}
+	service.SetQueuePublisher(publisher)
+
+	triggerData := json.RawMessage(`{"order_id":"42"}`)
+	execution := &Execution{ID: "exec-1", TenantID: "tenant-1", WorkflowID: "wf-1", TriggerType: "webhook", TriggerData: &triggerData}
+	failure := ExecutionFailure{Reason: "node http-1 failed: timeout", NodeID: "http-1", NodeType: "action:http"}
+	handlerID := "wf-handler"
+
+	mockRepo.On("CreateDeadLetter", mock.Anything, execution, failure).Return(&DeadLetter{ID: "dl-1"}, nil)
+	mockRepo.On("GetByID", mock.Anything, "tenant-1", "wf-1").Return(&Workflow{ID: "wf-1", Name: "Orders", OnFailureWorkflowID: &handlerID}, nil)
+	mockRepo.On("GetByID", mock.Anything, "tenant-1", handlerID).Return(&Workflow{ID: handlerID, Status: string(WorkflowStatusActive), Version: 3}, nil)
+
+	var failureContext FailureContext
+	mockRepo.On("CreateExecution", mock.Anything, "tenant-1", handlerID, 3, FailureTriggerType, mock.Anything).
+		Run(func(args mock.Arguments) {
+			require.NoError(t, json.Unmarshal(args.Get(5).([]byte), &failureContext))
+		}).
+		Return(&Execution{ID: "exec-handler", TenantID: "tenant-1", WorkflowID: handlerID, TriggerType: FailureTriggerType}, nil)
+
+	service.HandleExecutionFailure(context.Background(), execution, failure)
+
+	mockRepo.AssertExpectations(t)
+	assert.Equal(t, "node http-1 failed: timeout", failureContext.Error)
+	assert.Equal(t, "Orders", failureContext.WorkflowName)
+	assert.Equal(t, "exec-1", failureContext.ExecutionID)
+	assert.Equal(t, "http-1", failureContext.FailedNodeID)
+	assert.Equal(t, "dl-1", failureContext.DeadLetterID)
+	assert.JSONEq(t, `{"order_id":"42"}`, string(*failureContext.TriggerData))
+	require.Len(t, publisher.messages, 1)
+	assert.Equal(t, "exec-handler", publisher.messages[0]["execution_id"])
+}
+
+func TestHandleExecutionFailure_OnFailureExecutionIsNotHandledAgain(t *testing.T) {
+	service, mockRepo := newTestService()
+
+	execution := &Execution{ID: "exec-handler", TenantID: "tenant-1", WorkflowID: "wf-handler", TriggerType: FailureTriggerType}
+	failure := ExecutionFailure{Reason: "handler failed"}
+	mockRepo.On("CreateDeadLetter", mock.Anything, execution, failure).Return(&DeadLetter{ID: "dl-2"}, nil)
+
+	service.HandleExecutionFailure(context.Background(), execution, failure)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleExecutionFailure_SkipsSubWorkflows(t *testing.T) {
+	service, mockRepo := newTestService()
+
+	parentID := "exec-parent"
+	execution := &Execution{ID: "exec-child", TenantID: "tenant-1", WorkflowID: "wf-child", ParentExecutionID: &parentID}
+
+	service.HandleExecutionFailure(context.Background(), execution, ExecutionFailure{Reason: "failed"})
+
+	mockRepo.AssertNotCalled(t, "CreateDeadLetter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRequeueDeadLetter(t *testing.T) {
+	payload := json.RawMessage(`{"order_id":"42"}`)
+	pending := &DeadLetter{ID: "dl-1", TenantID: "tenant-1", WorkflowID: "wf-1", ExecutionID: "exec-1", Status: string(DeadLetterStatusPending), Payload: &payload}
+
+	t.Run("requeues with the stored payload", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		publisher := &recordingPublisher{}
+		service.SetQueuePublisher(publisher)
+
+		mockRepo.On("GetDeadLetter", mock.Anything, "tenant-1", "dl-1").Return(pending, nil)
+		mockRepo.On("GetByID", mock.Anything, "tenant-1", "wf-1").Return(&Workflow{ID: "wf-1", Status: string(WorkflowStatusActive), Version: 2}, nil)
+		mockRepo.On("RequeueDeadLetter", mock.Anything, pending, 2).Return(&Execution{ID: "exec-2", TenantID: "tenant-1", WorkflowID: "wf-1"}, nil)
+
+		execution, err := service.RequeueDeadLetter(context.Background(), "tenant-1", "dl-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "exec-2", execution.ID)
+		require.Len(t, publisher.messages, 1)
+		assert.Equal(t, []byte(payload), publisher.messages[0]["trigger_data"])
+	})
+
+	t.Run("already requeued", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		requeued := *pending
+		requeued.Status = string(DeadLetterStatusRequeued)
+		mockRepo.On("GetDeadLetter", mock.Anything, "tenant-1", "dl-1").Return(&requeued, nil)
+
+		_, err := service.RequeueDeadLetter(context.Background(), "tenant-1", "dl-1")
+
+		assert.ErrorIs(t, err, ErrDeadLetterRequeued)
+	})
+
+	t.Run("inactive workflow", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("GetDeadLetter", mock.Anything, "tenant-1", "dl-1").Return(pending, nil)
+		mockRepo.On("GetByID", mock.Anything, "tenant-1", "wf-1").Return(&Workflow{ID: "wf-1", Status: string(WorkflowStatusInactive)}, nil)
+
+		_, err := service.RequeueDeadLetter(context.Background(), "tenant-1", "dl-1")
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockRepo.AssertNotCalled(t, "RequeueDeadLetter", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidateOnFailureWorkflow(t *testing.T) {
+	const workflowID = "6f1c2b9e-4a55-4c41-9d2e-0c6c7c1f2a10"
+	const handlerID = "0b0d7f4e-2f8a-4b57-8f5c-7a3e4e6d9b21"
+	strPtr := func(v string) *string { return &v }
+
+	service, mockRepo := newTestService()
+	mockRepo.On("GetByID", mock.Anything, "tenant-1", handlerID).Return(&Workflow{ID: handlerID}, nil)
+	mockRepo.On("GetByID", mock.Anything, "tenant-1", "9a6c4d3f-1b2e-4f5a-8c7d-6e5f4a3b2c1d").Return(nil, ErrNotFound)
+
+	var validationErr *ValidationError
+	assert.NoError(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, nil))
+	assert.NoError(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, strPtr("")))
+	assert.NoError(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, strPtr(handlerID)))
+	assert.ErrorAs(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, strPtr(workflowID)), &validationErr)
+	assert.ErrorAs(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, strPtr("not-a-uuid")), &validationErr)
+	assert.ErrorAs(t, service.validateOnFailureWorkflow(context.Background(), "tenant-1", workflowID, strPtr("9a6c4d3f-1b2e-4f5a-8c7d-6e5f4a3b2c1d")), &validationErr)
+}
+
+func TestDeadLetterFilter_Validate(t *testing.T) {
+	assert.NoError(t, DeadLetterFilter{}.Validate())
+	assert.NoError(t, DeadLetterFilter{Status: "requeued"}.Validate())
+	assert.Error(t, DeadLetterFilter{Status: "failed"}.Validate())
+	assert.Error(t, DeadLetterFilter{Offset: -1}.Validate())
+}
@@ -0,0 +1,454 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Definition issue codes
+const (
+	IssueDuplicateNode = "duplicate_node"
+	IssueUnknownNode   = "unknown_node"
+	IssueCycle         = "cycle"
+	IssueUnreachable   = "unreachable"
+	IssueBranchLabel   = "branch_label"
+	IssueInvalidConfig = "invalid_config"
+	IssueUndefinedStep = "undefined_step"
+	IssueDeadTrigger   = "dead_trigger"
+	// IssueUndeclaredOutput is a reference to an output key the upstream
+	// node does not produce
+	IssueUndeclaredOutput = "undeclared_output"
+)
+
+// stepReferencePattern matches step references such as ${steps.http-1.body}
+// and steps['http-1'].body, including quotes escaped inside JSON strings.
+// The step name is captured by group 1 or 2 and the output key, when
+// present, by group 3 or 4.
+var stepReferencePattern = regexp.MustCompile(`\bsteps(?:\.([A-Za-z0-9_-]+)|\[\s*\\?['"]([^'"\\]+)\\?['"]\s*\])(?:\.([A-Za-z0-9_]+)|\[\s*\\?['"]([^'"\\]+)\\?['"]\s*\])?`)
+
+// DefinitionIssue is a structural problem found in a workflow definition
+type DefinitionIssue struct {
+	Code    string `json:"code"`
+	NodeID  string `json:"node_id,omitempty"`
+	EdgeID  string `json:"edge_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// DefinitionError is returned when a workflow definition fails structural validation
+type DefinitionError struct {
+	Issues []DefinitionIssue
+}
+
+func (e *DefinitionError) Error() string {
+	if len(e.Issues) == 1 {
+		return "invalid workflow definition: " + e.Issues[0].Message
+	}
+	return fmt.Sprintf("invalid workflow definition: %s (and %d more issues)", e.Issues[0].Message, len(e.Issues)-1)
+}
+
+// DefinitionValidator checks the graph structure of a workflow definition
+// before it is saved
+type DefinitionValidator struct {
+	// AllowCycles accepts definitions whose edges form cycles
+	AllowCycles bool
+	// CheckOutputs also reports references to output keys an upstream
+	// node's output schema does not include
+	CheckOutputs bool
+}
+
+// Validate returns the structural issues in a definition: edges to unknown
+// nodes, cycles, nodes unreachable from a trigger, triggers without outgoing
+// edges, branch edges without the labels their if or switch node routes on,
+// and step references, in config or declared inputs, to nodes that do not
+// run before the referencing node. With CheckOutputs it also reports
+// references to output keys the upstream node's schema does not include.
+func (v DefinitionValidator) Validate(def *WorkflowDefinition) []DefinitionIssue {
+	var issues []DefinitionIssue
+
+	nodes := make(map[string]Node, len(def.Nodes))
+	for _, node := range def.Nodes {
+		if _, exists := nodes[node.ID]; exists {
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueDuplicateNode,
+				NodeID:  node.ID,
+				Message: "duplicate node ID: " + node.ID,
+			})
+			continue
+		}
+		nodes[node.ID] = node
+	}
+
+	// Only edges between known nodes take part in the graph checks
+	var edges []Edge
+	for _, edge := range def.Edges {
+		valid := true
+		if _, ok := nodes[edge.Source]; !ok {
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueUnknownNode,
+				EdgeID:  edge.ID,
+				Message: "edge references non-existent source node: " + edge.Source,
+			})
+			valid = false
+		}
+		if _, ok := nodes[edge.Target]; !ok {
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueUnknownNode,
+				EdgeID:  edge.ID,
+				Message: "edge references non-existent target node: " + edge.Target,
+			})
+			valid = false
+		}
+		if valid {
+			edges = append(edges, edge)
+		}
+	}
+
+	if !v.AllowCycles {
+		issues = append(issues, findCycles(def.Nodes, edges)...)
+	}
+	issues = append(issues, findUnreachableNodes(def.Nodes, edges)...)
+	issues = append(issues, findDeadTriggers(def.Nodes, edges)...)
+	issues = append(issues, checkBranchLabels(def.Nodes, edges)...)
+	issues = append(issues, checkStepReferences(def.Nodes, edges)...)
+	if v.CheckOutputs {
+		issues = append(issues, checkStepOutputs(def.Nodes, edges)...)
+	}
+
+	return issues
+}
+
+// findCycles reports each cycle found by a depth-first search, once per back edge
+func findCycles(nodes []Node, edges []Edge) []DefinitionIssue {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	adjacency := make(map[string][]string)
+	for _, edge := range edges {
+		adjacency[edge.Source] = append(adjacency[edge.Source], edge.Target)
+	}
+
+	var issues []DefinitionIssue
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = inProgress
+		path = append(path, id)
+		for _, next := range adjacency[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case inProgress:
+				start := indexOf(path, next)
+				cycle := append(append([]string(nil), path[start:]...), next)
+				issues = append(issues, DefinitionIssue{
+					Code:    IssueCycle,
+					NodeID:  next,
+					Message: "workflow contains a cycle: " + strings.Join(cycle, " -> "),
+				})
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+	}
+
+	for _, node := range nodes {
+		if state[node.ID] == unvisited {
+			visit(node.ID)
+		}
+	}
+	return issues
+}
+
+func indexOf(ids []string, id string) int {
+	for i, candidate := range ids {
+		if candidate == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findUnreachableNodes reports non-trigger nodes that no trigger leads to.
+// Definitions without triggers are rejected separately.
+func findUnreachableNodes(nodes []Node, edges []Edge) []DefinitionIssue {
+	var triggers []string
+	for _, node := range nodes {
+		if isTriggerType(node.Type) {
+			triggers = append(triggers, node.ID)
+		}
+	}
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	adjacency := make(map[string][]string)
+	for _, edge := range edges {
+		adjacency[edge.Source] = append(adjacency[edge.Source], edge.Target)
+	}
+	reachable := reachableFrom(triggers, adjacency)
+
+	var issues []DefinitionIssue
+	for _, node := range nodes {
+		if !reachable[node.ID] {
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueUnreachable,
+				NodeID:  node.ID,
+				Message: fmt.Sprintf("node %s is not reachable from a trigger", node.ID),
+			})
+		}
+	}
+	return issues
+}
+
+// findDeadTriggers reports trigger nodes without outgoing edges. A workflow
+// may have several triggers and runs from the one that fired, so each needs a
+// path into the workflow.
+func findDeadTriggers(nodes []Node, edges []Edge) []DefinitionIssue {
+	hasOutgoing := make(map[string]bool)
+	for _, edge := range edges {
+		hasOutgoing[edge.Source] = true
+	}
+
+	var issues []DefinitionIssue
+	for _, node := range nodes {
+		if isTriggerType(node.Type) && !hasOutgoing[node.ID] {
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueDeadTrigger,
+				NodeID:  node.ID,
+				Message: fmt.Sprintf("trigger %s has no outgoing edges", node.ID),
+			})
+		}
+	}
+	return issues
+}
+
+// checkBranchLabels reports outgoing edges of if, switch and split nodes that
+// are not labeled with a branch the node can take, and weights on edges that
+// do not leave a split node
+func checkBranchLabels(nodes []Node, edges []Edge) []DefinitionIssue {
+	var issues []DefinitionIssue
+	splitNodes := make(map[string]bool)
+	for _, node := range nodes {
+		switch node.Type {
+		case string(NodeTypeControlIf):
+			for _, edge := range edges {
+				if edge.Source != node.ID || edge.Label == "true" || edge.Label == "false" {
+					continue
+				}
+				issues = append(issues, DefinitionIssue{
+					Code:    IssueBranchLabel,
+					NodeID:  node.ID,
+					EdgeID:  edge.ID,
+					Message: fmt.Sprintf("edge from conditional node %s to %s must be labeled \"true\" or \"false\"", node.ID, edge.Target),
+				})
+			}
+		case string(NodeTypeControlSwitch):
+			for _, err := range validateSwitchBranches(node, edges) {
+				code := IssueInvalidConfig
+				if err.Field == "edges" {
+					code = IssueBranchLabel
+				}
+				issues = append(issues, DefinitionIssue{
+					Code:    code,
+					NodeID:  node.ID,
+					Message: fmt.Sprintf("switch node %s: %s", node.ID, err.Message),
+				})
+			}
+		case string(NodeTypeControlSplit):
+			splitNodes[node.ID] = true
+			for _, err := range validateSplitBranches(node, edges) {
+				issues = append(issues, DefinitionIssue{
+					Code:    IssueBranchLabel,
+					NodeID:  node.ID,
+					Message: fmt.Sprintf("split node %s: %s", node.ID, err.Message),
+				})
+			}
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.Weight == 0 || splitNodes[edge.Source] {
+			continue
+		}
+		issues = append(issues, DefinitionIssue{
+			Code:    IssueBranchLabel,
+			NodeID:  edge.Source,
+			EdgeID:  edge.ID,
+			Message: fmt.Sprintf("edge from %s to %s has a weight, but only edges leaving a split node are weighted", edge.Source, edge.Target),
+		})
+	}
+	return issues
+}
+
+// checkStepReferences reports steps.X references to nodes that are not
+// upstream of the referencing node, counting the steps named by declared
+// inputs. Loop variables of upstream loops are stored as steps too, so they
+// are accepted.
+func checkStepReferences(nodes []Node, edges []Edge) []DefinitionIssue {
+	reverse := make(map[string][]string)
+	for _, edge := range edges {
+		reverse[edge.Target] = append(reverse[edge.Target], edge.Source)
+	}
+	nodeMap := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		nodeMap[node.ID] = node
+	}
+
+	var issues []DefinitionIssue
+	for _, node := range nodes {
+		refs := referencedSteps(string(node.Data.Config))
+		for _, input := range node.Data.Inputs {
+			field, ok := parseInputDeclaration(input)
+			if !ok {
+				issues = append(issues, DefinitionIssue{
+					Code:    IssueInvalidConfig,
+					NodeID:  node.ID,
+					Message: fmt.Sprintf("node %s declares input %q, which is not of the form <node>.<key>", node.ID, input),
+				})
+				continue
+			}
+			if !slices.Contains(refs, field.Step) {
+				refs = append(refs, field.Step)
+			}
+		}
+		if len(refs) == 0 {
+			continue
+		}
+
+		upstream := reachableFrom(reverse[node.ID], reverse)
+		available := make(map[string]bool, len(upstream))
+		for id := range upstream {
+			available[id] = true
+			if upstreamNode, ok := nodeMap[id]; ok && upstreamNode.Type == string(NodeTypeControlLoop) {
+				for _, name := range loopVariables(upstreamNode) {
+					available[name] = true
+				}
+			}
+		}
+
+		for _, ref := range refs {
+			if available[ref] {
+				continue
+			}
+			message := fmt.Sprintf("node %s references steps.%s, which does not run before it", node.ID, ref)
+			if _, exists := nodeMap[ref]; !exists {
+				message = fmt.Sprintf("node %s references steps.%s, which is not a node in this workflow", node.ID, ref)
+			}
+			issues = append(issues, DefinitionIssue{
+				Code:    IssueUndefinedStep,
+				NodeID:  node.ID,
+				Message: message,
+			})
+		}
+	}
+	return issues
+}
+
+// checkStepOutputs reports references, in config or declared inputs, to
+// output keys an upstream node's schema does not include. Nodes without a
+// known output schema accept any key; references to nodes that are not
+// upstream are left to checkStepReferences.
+func checkStepOutputs(nodes []Node, edges []Edge) []DefinitionIssue {
+	reverse := make(map[string][]string)
+	for _, edge := range edges {
+		reverse[edge.Target] = append(reverse[edge.Target], edge.Source)
+	}
+	nodeMap := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		nodeMap[node.ID] = node
+	}
+
+	var issues []DefinitionIssue
+	for _, node := range nodes {
+		fields := referencedStepFields(string(node.Data.Config))
+		for _, input := range node.Data.Inputs {
+			if field, ok := parseInputDeclaration(input); ok && !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		upstream := reachableFrom(reverse[node.ID], reverse)
+		for _, field := range fields {
+			source, ok := nodeMap[field.Step]
+			if !ok || !upstream[field.Step] {
+				continue
+			}
+			schema := OutputSchema(source)
+			if schema == nil || slices.Contains(schema, field.Key) {
+				continue
+			}
+			issues = append(issues, DefinitionIssue{
+				Code:   IssueUndeclaredOutput,
+				NodeID: node.ID,
+				Message: fmt.Sprintf("node %s references steps.%s.%s, but %s only outputs %s",
+					node.ID, field.Step, field.Key, field.Step, strings.Join(schema, ", ")),
+			})
+		}
+	}
+	return issues
+}
+
+// referencedSteps returns the distinct step names referenced in a config, in order
+func referencedSteps(config string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, match := range stepReferencePattern.FindAllStringSubmatch(config, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	return refs
+}
+
+// loopVariables returns the step names a loop node defines for its body
+func loopVariables(node Node) []string {
+	names := []string{"_loop"}
+	var config LoopActionConfig
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return names
+	}
+	for _, name := range []string{config.ItemVariable, config.IndexVariable, config.KeyVariable} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// reachableFrom returns the nodes reachable from the start nodes, including them
+func reachableFrom(start []string, adjacency map[string][]string) map[string]bool {
+	visited := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, adjacency[id]...)
+	}
+	return visited
+}
+
+// isTriggerType reports whether a node type starts a workflow
+func isTriggerType(nodeType string) bool {
+	return nodeType == string(NodeTypeTriggerWebhook) ||
+		nodeType == string(NodeTypeTriggerSchedule)
+}
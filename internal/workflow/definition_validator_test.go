@@ -0,0 +1,349 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitionValidator_Validate(t *testing.T) {
+	trigger := Node{ID: "trigger", Type: string(NodeTypeTriggerWebhook)}
+	action := func(id, config string) Node {
+		node := Node{ID: id, Type: string(NodeTypeActionHTTP)}
+		if config != "" {
+			node.Data.Config = json.RawMessage(config)
+		}
+		return node
+	}
+
+	tests := []struct {
+		name  string
+		def   WorkflowDefinition
+		want  []DefinitionIssue
+		codes []string
+	}{
+		{
+			name: "valid branching workflow",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "if", Type: string(NodeTypeControlIf), Data: NodeData{Config: json.RawMessage(`{"condition": "${steps.fetch.body.ok}"}`)}},
+					action("fetch", `{"url": "https://example.com/${trigger.id}"}`),
+					action("yes", `{"url": "https://example.com/${steps.fetch.body.id}"}`),
+					action("no", `{"body": "{{steps['fetch'].status_code}}"}`),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "fetch"},
+					{ID: "e2", Source: "fetch", Target: "if"},
+					{ID: "e3", Source: "if", Target: "yes", Label: "true"},
+					{ID: "e4", Source: "if", Target: "no", Label: "false"},
+				},
+			},
+		},
+		{
+			name: "edges to unknown nodes",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, action("a", "")},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "a"},
+					{ID: "e2", Source: "a", Target: "ghost"},
+					{ID: "e3", Source: "phantom", Target: "a"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueUnknownNode, EdgeID: "e2", Message: "edge references non-existent target node: ghost"},
+				{Code: IssueUnknownNode, EdgeID: "e3", Message: "edge references non-existent source node: phantom"},
+			},
+		},
+		{
+			name: "cycle",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, action("a", ""), action("b", ""), action("c", "")},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "a"},
+					{ID: "e2", Source: "a", Target: "b"},
+					{ID: "e3", Source: "b", Target: "c"},
+					{ID: "e4", Source: "c", Target: "a"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueCycle, NodeID: "a", Message: "workflow contains a cycle: a -> b -> c -> a"},
+			},
+		},
+		{
+			name: "unreachable nodes",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, action("a", ""), action("orphan", ""), action("orphan-child", "")},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "a"},
+					{ID: "e2", Source: "orphan", Target: "orphan-child"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueUnreachable, NodeID: "orphan", Message: "node orphan is not reachable from a trigger"},
+				{Code: IssueUnreachable, NodeID: "orphan-child", Message: "node orphan-child is not reachable from a trigger"},
+			},
+		},
+		{
+			name: "multiple triggers",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, {ID: "schedule", Type: string(NodeTypeTriggerSchedule)}, action("a", "")},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "a"},
+					{ID: "e2", Source: "schedule", Target: "a"},
+				},
+			},
+		},
+		{
+			name: "trigger without outgoing edges",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, {ID: "schedule", Type: string(NodeTypeTriggerSchedule)}, action("a", "")},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "a"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueDeadTrigger, NodeID: "schedule", Message: "trigger schedule has no outgoing edges"},
+			},
+		},
+		{
+			name: "conditional edge without branch label",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "if", Type: string(NodeTypeControlIf), Data: NodeData{Config: json.RawMessage(`{"condition": "true"}`)}},
+					action("a", ""),
+					action("b", ""),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "if"},
+					{ID: "e2", Source: "if", Target: "a", Label: "true"},
+					{ID: "e3", Source: "if", Target: "b", Label: "approved"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueBranchLabel, NodeID: "if", EdgeID: "e3", Message: `edge from conditional node if to b must be labeled "true" or "false"`},
+			},
+		},
+		{
+			name: "switch edge with undeclared case",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "switch", Type: string(NodeTypeControlSwitch), Data: NodeData{Config: json.RawMessage(`{"expression": "trigger.level", "cases": [{"value": "high"}]}`)}},
+					action("a", ""),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "switch"},
+					{ID: "e2", Source: "switch", Target: "a", Label: "low"},
+				},
+			},
+			codes: []string{IssueBranchLabel},
+		},
+		{
+			name: "split weights and stray weights",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "split", Type: string(NodeTypeControlSplit)},
+					action("canary", ""),
+					action("stable", ""),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "split", Weight: 50},
+					{ID: "e2", Source: "split", Target: "canary", Label: "canary", Weight: 10},
+					{ID: "e3", Source: "split", Target: "stable", Label: "stable", Weight: 80},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueBranchLabel, NodeID: "split", Message: "split node split: edge weights must add up to 100, not 90"},
+				{Code: IssueBranchLabel, NodeID: "trigger", EdgeID: "e1", Message: "edge from trigger to split has a weight, but only edges leaving a split node are weighted"},
+			},
+		},
+		{
+			name: "step references",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					action("first", `{"url": "${steps.second.body.url}"}`),
+					action("second", `{"url": "${steps.first.body.url}", "body": "${steps.missing.value}"}`),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "first"},
+					{ID: "e2", Source: "first", Target: "second"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueUndefinedStep, NodeID: "first", Message: "node first references steps.second, which does not run before it"},
+				{Code: IssueUndefinedStep, NodeID: "second", Message: "node second references steps.missing, which is not a node in this workflow"},
+			},
+		},
+		{
+			name: "loop variables are available downstream of the loop",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "loop", Type: string(NodeTypeControlLoop), Data: NodeData{Config: json.RawMessage(`{"source": "${trigger.items}", "item_variable": "repo", "index_variable": "i"}`)}},
+					action("body", `{"url": "https://example.com/${steps.repo.name}?n=${steps.i}&last=${steps._loop.is_last}"}`),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "loop"},
+					{ID: "e2", Source: "loop", Target: "body"},
+				},
+			},
+		},
+		{
+			name: "duplicate node IDs",
+			def: WorkflowDefinition{
+				Nodes: []Node{trigger, action("a", ""), action("a", "")},
+				Edges: []Edge{{ID: "e1", Source: "trigger", Target: "a"}},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueDuplicateNode, NodeID: "a", Message: "duplicate node ID: a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := DefinitionValidator{}.Validate(&tt.def)
+
+			if tt.codes != nil {
+				var codes []string
+				for _, issue := range issues {
+					codes = append(codes, issue.Code)
+				}
+				assert.Equal(t, tt.codes, codes)
+				return
+			}
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
+
+func TestDefinitionValidator_CheckOutputs(t *testing.T) {
+	trigger := Node{ID: "trigger", Type: string(NodeTypeTriggerWebhook)}
+	action := func(id, config string) Node {
+		node := Node{ID: id, Type: string(NodeTypeActionHTTP)}
+		if config != "" {
+			node.Data.Config = json.RawMessage(config)
+		}
+		return node
+	}
+
+	tests := []struct {
+		name string
+		def  WorkflowDefinition
+		want []DefinitionIssue
+	}{
+		{
+			name: "output schemas",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					action("fetch", `{"url": "https://example.com"}`),
+					{ID: "shape", Type: string(NodeTypeActionTransform), Data: NodeData{Config: json.RawMessage(`{"mapping": {"name": "${steps.fetch.body.name}"}}`)}},
+					{ID: "notify", Type: string(NodeTypeActionSlackSendMessage), Data: NodeData{Config: json.RawMessage(`{"text": "${steps.shape.name} ${steps.fetch.data}"}`)}},
+					action("reply", `{"url": "https://example.com/${steps.notify.ts}/${steps['notify']['thread']}"}`),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "fetch"},
+					{ID: "e2", Source: "fetch", Target: "shape"},
+					{ID: "e3", Source: "shape", Target: "notify"},
+					{ID: "e4", Source: "notify", Target: "reply"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueUndeclaredOutput, NodeID: "notify", Message: "node notify references steps.fetch.data, but fetch only outputs status_code, headers, headers_truncated, body"},
+				{Code: IssueUndeclaredOutput, NodeID: "reply", Message: "node reply references steps.notify.thread, but notify only outputs ok, channel, ts, timestamp, thread_ts, message, interaction_token"},
+			},
+		},
+		{
+			name: "declared inputs and outputs",
+			def: WorkflowDefinition{
+				Nodes: []Node{
+					trigger,
+					{ID: "script", Type: string(NodeTypeActionCode), Data: NodeData{Outputs: []string{"total"}}},
+					{ID: "free", Type: string(NodeTypeActionCode)},
+					{ID: "report", Type: string(NodeTypeActionEmail), Data: NodeData{
+						Config: json.RawMessage(`{"subject": "${steps.script.total} ${steps.free.anything}"}`),
+						Inputs: []string{"script.count", "free.rows", "later.value", "script"},
+					}},
+					action("later", ""),
+				},
+				Edges: []Edge{
+					{ID: "e1", Source: "trigger", Target: "script"},
+					{ID: "e2", Source: "script", Target: "free"},
+					{ID: "e3", Source: "free", Target: "report"},
+					{ID: "e4", Source: "report", Target: "later"},
+				},
+			},
+			want: []DefinitionIssue{
+				{Code: IssueInvalidConfig, NodeID: "report", Message: `node report declares input "script", which is not of the form <node>.<key>`},
+				{Code: IssueUndefinedStep, NodeID: "report", Message: "node report references steps.later, which does not run before it"},
+				{Code: IssueUndeclaredOutput, NodeID: "report", Message: "node report references steps.script.count, but script only outputs total"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DefinitionValidator{CheckOutputs: true}.Validate(&tt.def))
+
+			// Without CheckOutputs only the other issues are reported
+			var structural []DefinitionIssue
+			for _, issue := range tt.want {
+				if issue.Code != IssueUndeclaredOutput {
+					structural = append(structural, issue)
+				}
+			}
+			assert.Equal(t, structural, DefinitionValidator{}.Validate(&tt.def))
+		})
+	}
+}
+
+func TestDefinitionValidator_AllowCycles(t *testing.T) {
+	def := WorkflowDefinition{
+		Nodes: []Node{
+			{ID: "trigger", Type: string(NodeTypeTriggerWebhook)},
+			{ID: "a", Type: string(NodeTypeActionHTTP)},
+			{ID: "b", Type: string(NodeTypeActionHTTP)},
+		},
+		Edges: []Edge{
+			{ID: "e1", Source: "trigger", Target: "a"},
+			{ID: "e2", Source: "a", Target: "b"},
+			{ID: "e3", Source: "b", Target: "a"},
+		},
+	}
+
+	assert.Empty(t, DefinitionValidator{AllowCycles: true}.Validate(&def))
+	assert.Len(t, DefinitionValidator{}.Validate(&def), 1)
+}
+
+func TestCreate_RejectsStructurallyInvalidDefinition(t *testing.T) {
+	service, mockRepo := newTestService()
+
+	_, err := service.Create(context.Background(), "tenant-123", "user-1", CreateWorkflowInput{
+		Name: "Broken",
+		Definition: json.RawMessage(`{
+			"nodes": [
+				{"id": "trigger", "type": "trigger:webhook", "data": {}},
+				{"id": "a", "type": "action:http", "data": {}},
+				{"id": "b", "type": "action:http", "data": {}}
+			],
+			"edges": [{"id": "e1", "source": "trigger", "target": "a"}]
+		}`),
+	})
+
+	var definitionErr *DefinitionError
+	require.True(t, errors.As(err, &definitionErr))
+	assert.Equal(t, []DefinitionIssue{
+		{Code: IssueUnreachable, NodeID: "b", Message: "node b is not reachable from a trigger"},
+	}, definitionErr.Issues)
+	assert.Equal(t, "invalid workflow definition: node b is not reachable from a trigger", err.Error())
+	mockRepo.AssertNotCalled(t, "Create")
+}
@@ -3,6 +3,7 @@ package workflow
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -148,6 +149,54 @@ func TestDryRun_InvalidNodeConfiguration(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestDryRun_TransformType tests validation of the transform_type field
+func TestDryRun_TransformType(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		wantField string
+	}{
+		{"expr transform", `{"transform_type": "expr", "expression": "{\"total\": sum(map(trigger.items, .amount))}"}`, ""},
+		{"expr without expression", `{"transform_type": "expr"}`, "expression"},
+		{"nested mapping", `{"mapping": {"customer": {"name": "trigger.name", "tags": ["trigger.tag"]}, "count": 1}}`, ""},
+		{"unknown type", `{"transform_type": "jsonpath", "expression": "$.items"}`, "transform_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := newTestService()
+			ctx := context.Background()
+
+			definition := WorkflowDefinition{
+				Nodes: []Node{
+					{ID: "trigger-1", Type: string(NodeTypeTriggerWebhook), Data: NodeData{Name: "Webhook", Config: json.RawMessage(`{}`)}},
+					{ID: "transform-1", Type: string(NodeTypeActionTransform), Data: NodeData{Name: "Transform", Config: json.RawMessage(tt.config)}},
+				},
+				Edges: []Edge{{ID: "e1", Source: "trigger-1", Target: "transform-1"}},
+			}
+			definitionJSON, _ := json.Marshal(definition)
+			mockRepo.On("GetByID", ctx, "tenant-123", "workflow-123").Return(&Workflow{
+				ID:         "workflow-123",
+				TenantID:   "tenant-123",
+				Status:     string(WorkflowStatusActive),
+				Definition: definitionJSON,
+				Version:    1,
+			}, nil)
+
+			result, err := service.DryRun(ctx, "tenant-123", "workflow-123", nil)
+			require.NoError(t, err)
+
+			if tt.wantField == "" {
+				assert.True(t, result.Valid, "errors: %v", result.Errors)
+				return
+			}
+			require.NotEmpty(t, result.Errors)
+			assert.Equal(t, "transform-1", result.Errors[0].NodeID)
+			assert.Equal(t, tt.wantField, result.Errors[0].Field)
+		})
+	}
+}
+
 // TestDryRun_MissingVariableReference tests dry-run with undefined variable reference
 func TestDryRun_MissingVariableReference(t *testing.T) {
 	service, mockRepo := newTestService()
@@ -465,6 +514,194 @@ func TestDryRun_ConditionalNode(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// TestDryRun_SwitchNode tests dry-run with a switch node whose edges must map to declared cases
+func TestDryRun_SwitchNode(t *testing.T) {
+	service, mockRepo := newTestService()
+	ctx := context.Background()
+	tenantID := "tenant-123"
+	workflowID := "workflow-123"
+
+	definition := WorkflowDefinition{
+		Nodes: []Node{
+			{
+				ID:   "trigger-1",
+				Type: string(NodeTypeTriggerWebhook),
+				Data: NodeData{
+					Name:   "Webhook Trigger",
+					Config: json.RawMessage(`{}`),
+				},
+			},
+			{
+				ID:   "switch-1",
+				Type: string(NodeTypeControlSwitch),
+				Data: NodeData{
+					Name: "Route Severity",
+					Config: json.RawMessage(`{
+						"expression": "trigger.severity",
+						"cases": [{"value": "critical", "label": "page"}, {"value": "high"}]
+					}`),
+				},
+			},
+			{ID: "page-1", Type: string(NodeTypeActionTransform), Data: NodeData{Name: "Page"}},
+			{ID: "ticket-1", Type: string(NodeTypeActionTransform), Data: NodeData{Name: "Ticket"}},
+			{ID: "log-1", Type: string(NodeTypeActionTransform), Data: NodeData{Name: "Log"}},
+			{ID: "other-1", Type: string(NodeTypeActionTransform), Data: NodeData{Name: "Other"}},
+		},
+		Edges: []Edge{
+			{ID: "e1", Source: "trigger-1", Target: "switch-1"},
+			{ID: "e2", Source: "switch-1", Target: "page-1", Label: "page"},
+			{ID: "e3", Source: "switch-1", Target: "ticket-1", Label: "high"},
+			{ID: "e4", Source: "switch-1", Target: "log-1", Label: "default"},
+			{ID: "e5", Source: "switch-1", Target: "other-1", Label: "low"},
+		},
+	}
+
+	definitionJSON, _ := json.Marshal(definition)
+	workflow := &Workflow{
+		ID:         workflowID,
+		TenantID:   tenantID,
+		Name:       "Switch Workflow",
+		Status:     string(WorkflowStatusActive),
+		Definition: definitionJSON,
+		Version:    1,
+	}
+
+	mockRepo.On("GetByID", ctx, tenantID, workflowID).Return(workflow, nil)
+
+	result, err := service.DryRun(ctx, tenantID, workflowID, nil)
+
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "switch-1", result.Errors[0].NodeID)
+	assert.Equal(t, "edges", result.Errors[0].Field)
+	assert.Contains(t, result.Errors[0].Message, `"low"`)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestValidateSwitchBranches(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		edges   []Edge
+		wantErr string
+	}{
+		{
+			name:   "labels map to cases and default",
+			config: `{"expression": "trigger.n", "cases": [{"value": 1}, {"value": "x", "label": "ex"}]}`,
+			edges: []Edge{
+				{Source: "switch-1", Target: "a", Label: "1"},
+				{Source: "switch-1", Target: "b", Label: "ex"},
+				{Source: "switch-1", Target: "c", Label: "default"},
+				{Source: "other", Target: "d", Label: "anything"},
+			},
+		},
+		{
+			name:    "unlabeled edge",
+			config:  `{"expression": "trigger.n", "cases": [{"value": 1}]}`,
+			edges:   []Edge{{Source: "switch-1", Target: "a"}},
+			wantErr: "must be labeled",
+		},
+		{
+			name:    "missing cases",
+			config:  `{"expression": "trigger.n"}`,
+			wantErr: "at least one case is required",
+		},
+		{
+			name:    "missing expression",
+			config:  `{"cases": [{"value": 1}]}`,
+			wantErr: "expression is required",
+		},
+		{
+			name:    "reserved label",
+			config:  `{"expression": "trigger.n", "cases": [{"value": 1, "label": "default"}]}`,
+			wantErr: "reserved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Node{ID: "switch-1", Type: string(NodeTypeControlSwitch), Data: NodeData{Config: json.RawMessage(tt.config)}}
+
+			errs := validateSwitchBranches(node, tt.edges)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.NotEmpty(t, errs)
+			assert.Contains(t, errs[0].Message, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateSplitBranches(t *testing.T) {
+	tests := []struct {
+		name    string
+		edges   []Edge
+		wantErr string
+	}{
+		{
+			name: "weights add up to 100",
+			edges: []Edge{
+				{Source: "split-1", Target: "a", Label: "canary", Weight: 10},
+				{Source: "split-1", Target: "b", Label: "stable", Weight: 90},
+				{Source: "other", Target: "c", Label: "anything"},
+			},
+		},
+		{
+			name:    "no outgoing edges",
+			wantErr: "at least one outgoing edge",
+		},
+		{
+			name: "unlabeled edge",
+			edges: []Edge{
+				{Source: "split-1", Target: "a", Weight: 100},
+			},
+			wantErr: "must be labeled",
+		},
+		{
+			name: "duplicate label",
+			edges: []Edge{
+				{Source: "split-1", Target: "a", Label: "canary", Weight: 50},
+				{Source: "split-1", Target: "b", Label: "canary", Weight: 50},
+			},
+			wantErr: `reuses the label "canary"`,
+		},
+		{
+			name: "missing weight",
+			edges: []Edge{
+				{Source: "split-1", Target: "a", Label: "canary"},
+				{Source: "split-1", Target: "b", Label: "stable", Weight: 100},
+			},
+			wantErr: "weight from 1 to 100",
+		},
+		{
+			name: "weights over 100",
+			edges: []Edge{
+				{Source: "split-1", Target: "a", Label: "canary", Weight: 20},
+				{Source: "split-1", Target: "b", Label: "stable", Weight: 90},
+			},
+			wantErr: "add up to 100, not 110",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Node{ID: "split-1", Type: string(NodeTypeControlSplit)}
+
+			errs := validateSplitBranches(node, tt.edges)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.NotEmpty(t, errs)
+			assert.Contains(t, errs[0].Message, tt.wantErr)
+		})
+	}
+}
+
 // TestDryRun_LoopNode tests dry-run with loop node
 func TestDryRun_LoopNode(t *testing.T) {
 	service, mockRepo := newTestService()
@@ -540,3 +777,180 @@ func TestDryRun_LoopNode(t *testing.T) {
 	assert.Contains(t, result.VariableMapping, "item")
 	mockRepo.AssertExpectations(t)
 }
+
+// simulatingExecutor records the mocks it was asked to simulate with
+type simulatingExecutor struct {
+	mocks map[string]interface{}
+}
+
+func (e *simulatingExecutor) Execute(ctx context.Context, execution *Execution) error {
+	return nil
+}
+
+func (e *simulatingExecutor) Simulate(ctx context.Context, wf *Workflow, triggerData []byte, mocks map[string]interface{}) (*SimulationResult, error) {
+	e.mocks = mocks
+	return &SimulationResult{ExecutionOrder: []string{"trigger-1", "http-1"}, MockedNodes: []string{"http-1"}}, nil
+}
+
+// TestSimulateExecution tests that simulation validates mocks before delegating to the executor
+func TestSimulateExecution(t *testing.T) {
+	ctx := context.Background()
+	definitionJSON, _ := json.Marshal(WorkflowDefinition{
+		Nodes: []Node{
+			{ID: "trigger-1", Type: string(NodeTypeTriggerWebhook)},
+			{ID: "http-1", Type: string(NodeTypeActionHTTP)},
+		},
+		Edges: []Edge{{ID: "e1", Source: "trigger-1", Target: "http-1"}},
+	})
+	// Simulation does not require the workflow to be active
+	workflow := &Workflow{ID: "workflow-123", TenantID: "tenant-123", Status: string(WorkflowStatusDraft), Definition: definitionJSON}
+
+	t.Run("delegates to simulator", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		executor := &simulatingExecutor{}
+		service.SetExecutor(executor)
+		mockRepo.On("GetByID", ctx, "tenant-123", "workflow-123").Return(workflow, nil)
+
+		mocks := map[string]interface{}{"http-1": map[string]interface{}{"status": 200}}
+		result, err := service.SimulateExecution(ctx, "tenant-123", "workflow-123", []byte(`{"id": 1}`), mocks)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http-1"}, result.MockedNodes)
+		assert.Equal(t, mocks, executor.mocks)
+	})
+
+	t.Run("rejects mocks for unknown nodes", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		service.SetExecutor(&simulatingExecutor{})
+		mockRepo.On("GetByID", ctx, "tenant-123", "workflow-123").Return(workflow, nil)
+
+		_, err := service.SimulateExecution(ctx, "tenant-123", "workflow-123", nil, map[string]interface{}{"missing-1": nil})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("requires a simulating executor", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		service.SetExecutor(&cancellingExecutor{})
+		mockRepo.On("GetByID", ctx, "tenant-123", "workflow-123").Return(workflow, nil)
+
+		_, err := service.SimulateExecution(ctx, "tenant-123", "workflow-123", nil, nil)
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+// TestDryRun_UndeclaredOutputWarning tests that references to keys a node
+// does not output are reported as warnings without failing the dry-run
+func TestDryRun_UndeclaredOutputWarning(t *testing.T) {
+	service, mockRepo := newTestService()
+	ctx := context.Background()
+
+	definition := WorkflowDefinition{
+		Nodes: []Node{
+			{ID: "trigger-1", Type: string(NodeTypeTriggerWebhook), Data: NodeData{Config: json.RawMessage(`{}`)}},
+			{ID: "http-1", Type: string(NodeTypeActionHTTP), Data: NodeData{Config: json.RawMessage(`{"method": "GET", "url": "https://example.com"}`)}},
+			{ID: "transform-1", Type: string(NodeTypeActionTransform), Data: NodeData{Config: json.RawMessage(`{"mapping": {"code": "${steps.http-1.status}"}}`)}},
+		},
+		Edges: []Edge{
+			{ID: "e1", Source: "trigger-1", Target: "http-1"},
+			{ID: "e2", Source: "http-1", Target: "transform-1"},
+		},
+	}
+	definitionJSON, _ := json.Marshal(definition)
+	mockRepo.On("GetByID", ctx, "tenant-123", "workflow-123").Return(&Workflow{
+		ID:         "workflow-123",
+		TenantID:   "tenant-123",
+		Definition: definitionJSON,
+	}, nil)
+
+	result, err := service.DryRun(ctx, "tenant-123", "workflow-123", nil)
+
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Contains(t, result.Warnings, DryRunWarning{
+		NodeID:  "transform-1",
+		Message: "node transform-1 references steps.http-1.status, but http-1 only outputs status_code, headers, headers_truncated, body",
+	})
+	mockRepo.AssertExpectations(t)
+}
+
+func TestValidateScriptConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{name: "valid", script: "return steps['http-1'].body;"},
+		{name: "empty", script: "  ", wantErr: "script is required"},
+		{name: "oversized", script: strings.Repeat("x", MaxScriptNodeLength+1), wantErr: "limit is"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := json.Marshal(ScriptNodeConfig{Script: tt.script})
+			require.NoError(t, err)
+			node := Node{ID: "script-1", Type: string(NodeTypeActionScript), Data: NodeData{Config: config}}
+
+			errs := validateScriptConfig(node)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, "script", errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateHTTPConfig_ContentType(t *testing.T) {
+	s := &Service{}
+	for _, contentType := range []string{"", "application/json", "application/x-www-form-urlencoded", "multipart/form-data"} {
+		config, err := json.Marshal(HTTPActionConfig{Method: "POST", URL: "https://api.example.com", ContentType: contentType})
+		require.NoError(t, err)
+		errs := s.validateHTTPConfig(Node{ID: "http-1", Data: NodeData{Config: config}}, map[string]bool{})
+		assert.Empty(t, errs, contentType)
+	}
+
+	config, err := json.Marshal(HTTPActionConfig{Method: "POST", URL: "https://api.example.com", ContentType: "text/xml"})
+	require.NoError(t, err)
+	errs := s.validateHTTPConfig(Node{ID: "http-1", Data: NodeData{Config: config}}, map[string]bool{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, "content_type", errs[0].Field)
+}
+
+func TestValidateHTTPConfig_MaxResponseBytes(t *testing.T) {
+	s := &Service{}
+	s.SetHTTPMaxResponseBytes(1024)
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		wantErr  string
+	}{
+		{name: "unset", maxBytes: 0},
+		{name: "below ceiling", maxBytes: 512},
+		{name: "at ceiling", maxBytes: 1024},
+		{name: "above ceiling", maxBytes: 2048, wantErr: "server limit of 1024 bytes"},
+		{name: "negative", maxBytes: -1, wantErr: "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := json.Marshal(HTTPActionConfig{Method: "GET", URL: "https://api.example.com", MaxResponseBytes: tt.maxBytes})
+			require.NoError(t, err)
+			errs := s.validateHTTPConfig(Node{ID: "http-1", Data: NodeData{Config: config}}, map[string]bool{})
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, "max_response_bytes", errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantErr)
+		})
+	}
+}
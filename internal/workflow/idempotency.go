@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// IdempotencyKeyTTL is how long an Idempotency-Key maps to the execution it created
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// MaxIdempotencyKeyLength is the longest accepted Idempotency-Key
+const MaxIdempotencyKeyLength = 255
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different workflow or body
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+	// ErrIdempotencyKeyInProgress is returned when the first request with a key has not created its execution yet
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// IdempotencyKey maps a tenant's Idempotency-Key to the execution it created
+type IdempotencyKey struct {
+	TenantID    string    `db:"tenant_id" json:"tenant_id"`
+	Key         string    `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash string    `db:"request_hash" json:"request_hash"`
+	ExecutionID *string   `db:"execution_id" json:"execution_id,omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// idempotencyRequestHash hashes the workflow ID and request body. JSON bodies
+// are compacted first so formatting differences are not a different request.
+func idempotencyRequestHash(workflowID string, body []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(workflowID))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ExecuteIdempotent executes a workflow at most once per idempotency key. A
+// repeated key with the same workflow and body returns the original execution
+// and replayed is true. An empty key executes normally.
+func (s *Service) ExecuteIdempotent(ctx context.Context, tenantID, workflowID, key, triggerType string, triggerData []byte) (execution *Execution, replayed bool, err error) {
+	if key == "" {
+		execution, err = s.Execute(ctx, tenantID, workflowID, triggerType, triggerData)
+		return execution, false, err
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, false, &ValidationError{Message: "idempotency key must be at most 255 characters"}
+	}
+
+	requestHash := idempotencyRequestHash(workflowID, triggerData)
+
+	record, claimed, err := s.repo.ClaimIdempotencyKey(ctx, tenantID, key, requestHash, IdempotencyKeyTTL)
+	if err != nil {
+		s.logger.Error("failed to claim idempotency key", "error", err, "workflow_id", workflowID)
+		return nil, false, err
+	}
+
+	if !claimed {
+		if record.RequestHash != requestHash {
+			return nil, false, ErrIdempotencyKeyReused
+		}
+		if record.ExecutionID == nil {
+			return nil, false, ErrIdempotencyKeyInProgress
+		}
+
+		execution, err = s.repo.GetExecutionByID(ctx, tenantID, *record.ExecutionID)
+		if err != nil {
+			return nil, false, err
+		}
+		s.logger.Info("idempotent execute replayed", "execution_id", execution.ID, "workflow_id", workflowID)
+		return execution, true, nil
+	}
+
+	execution, err = s.Execute(ctx, tenantID, workflowID, triggerType, triggerData)
+	if err != nil {
+		// Release the key so the client can retry the failed request
+		if releaseErr := s.repo.ReleaseIdempotencyKey(ctx, tenantID, key); releaseErr != nil {
+			s.logger.Error("failed to release idempotency key", "error", releaseErr, "workflow_id", workflowID)
+		}
+		return nil, false, err
+	}
+
+	if err := s.repo.CompleteIdempotencyKey(ctx, tenantID, key, execution.ID); err != nil {
+		// The execution exists, so keep the claim rather than risk a duplicate
+		s.logger.Error("failed to record idempotency key execution", "error", err, "execution_id", execution.ID)
+	}
+
+	return execution, false, nil
+}
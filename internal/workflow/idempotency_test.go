@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIdempotencyRequestHash tests that the hash ignores JSON formatting but not content or workflow
+func TestIdempotencyRequestHash(t *testing.T) {
+	base := idempotencyRequestHash("wf-1", []byte(`{"amount": 100}`))
+
+	assert.Equal(t, base, idempotencyRequestHash("wf-1", []byte("{\n  \"amount\":100\n}")))
+	assert.NotEqual(t, base, idempotencyRequestHash("wf-1", []byte(`{"amount": 101}`)))
+	assert.NotEqual(t, base, idempotencyRequestHash("wf-2", []byte(`{"amount": 100}`)))
+	assert.Len(t, base, 64)
+}
+
+// TestExecuteIdempotent tests first use, replay, reuse with another body and in-flight keys
+func TestExecuteIdempotent(t *testing.T) {
+	ctx := context.Background()
+	body := []byte(`{"amount":100}`)
+	hash := idempotencyRequestHash("wf-1", body)
+	activeWorkflow := &Workflow{ID: "wf-1", Status: string(WorkflowStatusActive), Version: 2}
+	executionID := "exec-1"
+	execution := &Execution{ID: executionID, TenantID: "tenant-123", WorkflowID: "wf-1"}
+
+	t.Run("first request creates execution", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("ClaimIdempotencyKey", ctx, "tenant-123", "key-1", hash, IdempotencyKeyTTL).
+			Return(&IdempotencyKey{RequestHash: hash}, true, nil)
+		mockRepo.On("GetByID", ctx, "tenant-123", "wf-1").Return(activeWorkflow, nil)
+		mockRepo.On("CreateExecution", ctx, "tenant-123", "wf-1", 2, "manual", body).Return(execution, nil)
+		mockRepo.On("CompleteIdempotencyKey", ctx, "tenant-123", "key-1", executionID).Return(nil)
+
+		result, replayed, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", "key-1", "manual", body)
+
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.Equal(t, execution, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repeat returns original execution", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("ClaimIdempotencyKey", ctx, "tenant-123", "key-1", hash, IdempotencyKeyTTL).
+			Return(&IdempotencyKey{RequestHash: hash, ExecutionID: &executionID}, false, nil)
+		mockRepo.On("GetExecutionByID", ctx, "tenant-123", executionID).Return(execution, nil)
+
+		result, replayed, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", "key-1", "manual", body)
+
+		require.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, execution, result)
+		mockRepo.AssertNotCalled(t, "CreateExecution", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("different body is rejected", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("ClaimIdempotencyKey", ctx, "tenant-123", "key-1", mock.Anything, IdempotencyKeyTTL).
+			Return(&IdempotencyKey{RequestHash: hash, ExecutionID: &executionID}, false, nil)
+
+		result, replayed, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", "key-1", "manual", []byte(`{"amount":200}`))
+
+		assert.Nil(t, result)
+		assert.False(t, replayed)
+		assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	})
+
+	t.Run("key still in progress", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("ClaimIdempotencyKey", ctx, "tenant-123", "key-1", hash, IdempotencyKeyTTL).
+			Return(&IdempotencyKey{RequestHash: hash}, false, nil)
+
+		_, _, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", "key-1", "manual", body)
+
+		assert.ErrorIs(t, err, ErrIdempotencyKeyInProgress)
+	})
+
+	t.Run("failed execute releases key", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("ClaimIdempotencyKey", ctx, "tenant-123", "key-1", hash, IdempotencyKeyTTL).
+			Return(&IdempotencyKey{RequestHash: hash}, true, nil)
+		mockRepo.On("GetByID", ctx, "tenant-123", "wf-1").Return(nil, errors.New("db down"))
+		mockRepo.On("ReleaseIdempotencyKey", ctx, "tenant-123", "key-1").Return(nil)
+
+		_, _, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", "key-1", "manual", body)
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		service, _ := newTestService()
+		longKey := string(make([]byte, MaxIdempotencyKeyLength+1))
+
+		_, _, err := service.ExecuteIdempotent(ctx, "tenant-123", "wf-1", longKey, "manual", body)
+
+		assert.IsType(t, &ValidationError{}, err)
+	})
+}
@@ -0,0 +1,217 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+
+	"github.com/gorax/gorax/internal/envvars"
+)
+
+// Lint rules
+const (
+	LintHTTPRetryDisabled  = "http_retry_disabled"
+	LintNoTimeout          = "no_timeout"
+	LintSecretEnvReference = "secret_env_reference"
+	LintMissingFalseBranch = "missing_false_branch"
+	LintUnreachableBranch  = "unreachable_branch"
+)
+
+// Lint finding severities
+const (
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+)
+
+// constantConditionPattern matches if conditions that are a literal true or
+// false, optionally wrapped in ${} or {{}}
+var constantConditionPattern = regexp.MustCompile(`^\s*(?:\$\{\s*|\{\{\s*)?(true|false)\s*(?:\}\}|\})?\s*$`)
+
+// LintFinding is advice about a likely mistake in a workflow definition.
+// Unlike a DefinitionIssue it does not stop the workflow from being saved.
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	NodeID   string `json:"node_id"`
+	EdgeID   string `json:"edge_id,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintDefinition returns advice about anti-patterns in a definition: HTTP
+// nodes with retries turned off, HTTP and join nodes without an explicit
+// timeout, env references whose names look like secrets, if nodes without a
+// false branch, and branches that can never be taken. Findings are ordered by
+// node.
+func LintDefinition(def *WorkflowDefinition) []LintFinding {
+	var findings []LintFinding
+	for _, node := range def.Nodes {
+		switch node.Type {
+		case string(NodeTypeActionHTTP):
+			findings = append(findings, lintHTTPNode(node)...)
+		case string(NodeTypeControlJoin):
+			findings = append(findings, lintJoinNode(node)...)
+		case string(NodeTypeControlIf):
+			findings = append(findings, lintIfNode(node, def.Edges)...)
+		case string(NodeTypeControlSwitch):
+			findings = append(findings, lintSwitchNode(node, def.Edges)...)
+		}
+		findings = append(findings, lintEnvReferences(node)...)
+	}
+	return findings
+}
+
+// lintHTTPNode reports HTTP nodes that opt out of retries, so a transient
+// failure of the remote service fails the execution, and nodes that rely on
+// the default request timeout
+func lintHTTPNode(node Node) []LintFinding {
+	var config struct {
+		Timeout *int `json:"timeout"`
+		Retry   *struct {
+			Enabled    *bool `json:"enabled"`
+			MaxRetries *int  `json:"max_retries"`
+		} `json:"retry"`
+	}
+	if len(node.Data.Config) == 0 || json.Unmarshal(node.Data.Config, &config) != nil {
+		return nil
+	}
+
+	var findings []LintFinding
+	if retry := config.Retry; retry != nil {
+		if (retry.Enabled != nil && !*retry.Enabled) || (retry.MaxRetries != nil && *retry.MaxRetries == 0) {
+			findings = append(findings, LintFinding{
+				Rule:     LintHTTPRetryDisabled,
+				Severity: LintSeverityWarning,
+				NodeID:   node.ID,
+				Message:  fmt.Sprintf("HTTP node %s does not retry, so a transient failure of the remote service fails the execution", node.ID),
+			})
+		}
+	}
+	if config.Timeout == nil || *config.Timeout <= 0 {
+		findings = append(findings, LintFinding{
+			Rule:     LintNoTimeout,
+			Severity: LintSeverityInfo,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("HTTP node %s has no timeout and uses the 30 second default; set one that matches the remote service", node.ID),
+		})
+	}
+	return findings
+}
+
+// lintJoinNode reports join nodes that wait for their branches forever
+func lintJoinNode(node Node) []LintFinding {
+	var config JoinConfig
+	if len(node.Data.Config) > 0 && json.Unmarshal(node.Data.Config, &config) != nil {
+		return nil
+	}
+	if config.TimeoutMs > 0 {
+		return nil
+	}
+	return []LintFinding{{
+		Rule:     LintNoTimeout,
+		Severity: LintSeverityWarning,
+		NodeID:   node.ID,
+		Message:  fmt.Sprintf("join node %s has no timeout, so a branch that never finishes leaves the execution waiting", node.ID),
+	}}
+}
+
+// lintIfNode reports if nodes without a false branch, where a false
+// condition silently ends that path of the workflow, and edges that a
+// constant condition never follows
+func lintIfNode(node Node, edges []Edge) []LintFinding {
+	var config ConditionalActionConfig
+	if len(node.Data.Config) > 0 && json.Unmarshal(node.Data.Config, &config) != nil {
+		return nil
+	}
+
+	var findings []LintFinding
+	if match := constantConditionPattern.FindStringSubmatch(config.Condition); match != nil {
+		taken := match[1]
+		for _, edge := range edges {
+			if edge.Source != node.ID || edge.Label == taken {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Rule:     LintUnreachableBranch,
+				Severity: LintSeverityWarning,
+				NodeID:   node.ID,
+				EdgeID:   edge.ID,
+				Message:  fmt.Sprintf("condition of %s is always %s, so the edge to %s is never taken", node.ID, taken, edge.Target),
+			})
+		}
+		return findings
+	}
+
+	hasFalse := slices.ContainsFunc(edges, func(edge Edge) bool {
+		return edge.Source == node.ID && edge.Label == "false"
+	})
+	if !hasFalse {
+		findings = append(findings, LintFinding{
+			Rule:     LintMissingFalseBranch,
+			Severity: LintSeverityWarning,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("conditional node %s has no false branch, so the workflow stops here when the condition is false", node.ID),
+		})
+	}
+	return findings
+}
+
+// lintSwitchNode reports cases that repeat an earlier case's value. The
+// first matching case wins, so a repeated case's branch is never taken
+// unless an earlier case routes to it too.
+func lintSwitchNode(node Node, edges []Edge) []LintFinding {
+	var config SwitchActionConfig
+	if len(node.Data.Config) == 0 || json.Unmarshal(node.Data.Config, &config) != nil {
+		return nil
+	}
+
+	var findings []LintFinding
+	for i, c := range config.Cases {
+		for _, earlier := range config.Cases[:i] {
+			if !reflect.DeepEqual(c.Value, earlier.Value) {
+				continue
+			}
+			if label := c.BranchLabel(); label != earlier.BranchLabel() {
+				findings = append(findings, unreachableSwitchBranch(node, edges, c, label))
+			}
+			break
+		}
+	}
+	return findings
+}
+
+func unreachableSwitchBranch(node Node, edges []Edge, c SwitchCase, label string) LintFinding {
+	finding := LintFinding{
+		Rule:     LintUnreachableBranch,
+		Severity: LintSeverityWarning,
+		NodeID:   node.ID,
+		Message:  fmt.Sprintf("switch node %s repeats case %v, so branch %q is never taken", node.ID, c.Value, label),
+	}
+	for _, edge := range edges {
+		if edge.Source == node.ID && edge.Label == label {
+			finding.EdgeID = edge.ID
+			break
+		}
+	}
+	return finding
+}
+
+// lintEnvReferences reports env references whose names look like secrets.
+// Env variables are stored in plain text and are not masked in execution
+// logs, unlike credentials.
+func lintEnvReferences(node Node) []LintFinding {
+	var findings []LintFinding
+	for _, name := range referencedNames(envReferenceRegex, node.Data.Config) {
+		if slices.Contains(builtinEnvVars, name) || !envvars.LooksLikeSecret(name) {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Rule:     LintSecretEnvReference,
+			Severity: LintSeverityWarning,
+			NodeID:   node.ID,
+			Message:  fmt.Sprintf("node %s reads env.%s, which looks like a secret; store it as a credential and reference it as ${credentials.name}", node.ID, name),
+		})
+	}
+	return findings
+}
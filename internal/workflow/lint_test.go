@@ -0,0 +1,150 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintNode(id, nodeType, config string) Node {
+	return Node{ID: id, Type: nodeType, Data: NodeData{Config: json.RawMessage(config)}}
+}
+
+func TestLintDefinition(t *testing.T) {
+	trigger := lintNode("trigger", "trigger:webhook", `{}`)
+
+	tests := []struct {
+		name  string
+		nodes []Node
+		edges []Edge
+		want  []LintFinding
+	}{
+		{
+			name:  "clean workflow",
+			nodes: []Node{trigger, lintNode("call", "action:http", `{"url": "${env.API_URL}", "timeout": 10}`)},
+		},
+		{
+			name:  "http node without timeout",
+			nodes: []Node{lintNode("call", "action:http", `{"url": "https://example.com"}`)},
+			want:  []LintFinding{{Rule: LintNoTimeout, Severity: LintSeverityInfo, NodeID: "call"}},
+		},
+		{
+			name:  "http retry disabled",
+			nodes: []Node{lintNode("call", "action:http", `{"timeout": 5, "retry": {"enabled": false}}`)},
+			want:  []LintFinding{{Rule: LintHTTPRetryDisabled, Severity: LintSeverityWarning, NodeID: "call"}},
+		},
+		{
+			name:  "http retry with no attempts",
+			nodes: []Node{lintNode("call", "action:http", `{"timeout": 5, "retry": {"max_retries": 0}}`)},
+			want:  []LintFinding{{Rule: LintHTTPRetryDisabled, Severity: LintSeverityWarning, NodeID: "call"}},
+		},
+		{
+			name:  "custom retries are fine",
+			nodes: []Node{lintNode("call", "action:http", `{"timeout": 5, "retry": {"max_retries": 5}}`)},
+		},
+		{
+			name: "join without timeout",
+			nodes: []Node{
+				lintNode("join", "control:join", `{"join_strategy": "wait_all"}`),
+				lintNode("join-2", "control:join", `{"join_strategy": "wait_all", "timeout_ms": 60000}`),
+			},
+			want: []LintFinding{{Rule: LintNoTimeout, Severity: LintSeverityWarning, NodeID: "join"}},
+		},
+		{
+			name: "secret read from env",
+			nodes: []Node{lintNode("call", "action:http", `{
+				"timeout": 5,
+				"headers": {"Authorization": "Bearer ${env.GITHUB_TOKEN}", "X-Tenant": "{{env.tenant_id}}", "X-Url": "${env.TOKEN_URL}"}
+			}`)},
+			want: []LintFinding{{Rule: LintSecretEnvReference, Severity: LintSeverityWarning, NodeID: "call"}},
+		},
+		{
+			name:  "condition without false branch",
+			nodes: []Node{lintNode("check", "control:if", `{"condition": "${steps.call.status} == 200"}`), lintNode("ok", "action:transform", `{}`)},
+			edges: []Edge{{ID: "e1", Source: "check", Target: "ok", Label: "true"}},
+			want:  []LintFinding{{Rule: LintMissingFalseBranch, Severity: LintSeverityWarning, NodeID: "check"}},
+		},
+		{
+			name:  "condition with both branches",
+			nodes: []Node{lintNode("check", "control:if", `{"condition": "${steps.call.status} == 200"}`), lintNode("ok", "action:transform", `{}`), lintNode("fail", "action:transform", `{}`)},
+			edges: []Edge{
+				{ID: "e1", Source: "check", Target: "ok", Label: "true"},
+				{ID: "e2", Source: "check", Target: "fail", Label: "false"},
+			},
+		},
+		{
+			name:  "constant condition",
+			nodes: []Node{lintNode("check", "control:if", `{"condition": "${ true }"}`), lintNode("ok", "action:transform", `{}`), lintNode("fail", "action:transform", `{}`)},
+			edges: []Edge{
+				{ID: "e1", Source: "check", Target: "ok", Label: "true"},
+				{ID: "e2", Source: "check", Target: "fail", Label: "false"},
+			},
+			want: []LintFinding{{Rule: LintUnreachableBranch, Severity: LintSeverityWarning, NodeID: "check", EdgeID: "e2"}},
+		},
+		{
+			name: "switch with repeated case",
+			nodes: []Node{lintNode("route", "control:switch", `{
+				"expression": "${trigger.plan}",
+				"cases": [{"value": "pro", "label": "paid"}, {"value": "free"}, {"value": "pro", "label": "premium"}, {"value": "free"}]
+			}`)},
+			edges: []Edge{
+				{ID: "e1", Source: "route", Target: "a", Label: "paid"},
+				{ID: "e2", Source: "route", Target: "b", Label: "free"},
+				{ID: "e3", Source: "route", Target: "c", Label: "premium"},
+			},
+			want: []LintFinding{{Rule: LintUnreachableBranch, Severity: LintSeverityWarning, NodeID: "route", EdgeID: "e3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := LintDefinition(&WorkflowDefinition{Nodes: tt.nodes, Edges: tt.edges})
+
+			require.Len(t, findings, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want.Rule, findings[i].Rule)
+				assert.Equal(t, want.Severity, findings[i].Severity)
+				assert.Equal(t, want.NodeID, findings[i].NodeID)
+				assert.Equal(t, want.EdgeID, findings[i].EdgeID)
+				assert.NotEmpty(t, findings[i].Message)
+			}
+		})
+	}
+}
+
+func TestService_Lint(t *testing.T) {
+	service, _ := newTestService()
+
+	findings, err := service.Lint(json.RawMessage(`{"nodes": [{"id": "call", "type": "action:http", "data": {"config": {}}}]}`))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, LintNoTimeout, findings[0].Rule)
+
+	_, err = service.Lint(json.RawMessage(`{"nodes": 1}`))
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+// TestCreate_LintWarnings tests that lint findings are returned without blocking the save
+func TestCreate_LintWarnings(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo := newTestService()
+	input := CreateWorkflowInput{Name: "lint", Definition: json.RawMessage(`{
+		"nodes": [
+			{"id": "trigger", "type": "trigger:webhook", "data": {"config": {}}},
+			{"id": "call", "type": "action:http", "data": {"config": {"url": "https://example.com", "timeout": 5, "retry": {"enabled": false}}}}
+		],
+		"edges": [{"id": "e1", "source": "trigger", "target": "call"}]
+	}`)}
+	mockRepo.On("Create", ctx, "tenant-123", "user-1", input).Return(&Workflow{ID: "wf-1"}, nil)
+
+	created, err := service.Create(ctx, "tenant-123", "user-1", input)
+
+	require.NoError(t, err)
+	require.Len(t, created.LintWarnings, 1)
+	assert.Equal(t, LintHTTPRetryDisabled, created.LintWarnings[0].Rule)
+	assert.Equal(t, "call", created.LintWarnings[0].NodeID)
+}
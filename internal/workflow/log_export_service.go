@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
 )
 
 // LogExportService handles log export operations
@@ -27,7 +29,7 @@ func (s *LogExportService) GetExecutionWithSteps(ctx context.Context, tenantID,
 		return nil, nil, err
 	}
 
-	steps, err := s.repo.GetStepExecutionsByExecutionID(ctx, executionID)
+	steps, err := s.repo.GetStepExecutionsByExecutionID(tenantctx.WithTenantID(ctx, tenantID), executionID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -34,6 +34,26 @@ type TopFailure struct {
 	ErrorPreview *string    `json:"errorPreview,omitempty" db:"error_preview"`
 }
 
+// ExecutionAnalytics summarizes execution outcomes and latency over a period.
+// SuccessRate is the percentage of finished (completed or failed) executions
+// that completed; durations are in milliseconds.
+type ExecutionAnalytics struct {
+	Total       int     `json:"total" db:"total"`
+	Succeeded   int     `json:"succeeded" db:"succeeded"`
+	Failed      int     `json:"failed" db:"failed"`
+	SuccessRate float64 `json:"successRate" db:"success_rate"`
+	P50Duration float64 `json:"p50Duration" db:"p50_duration"`
+	P95Duration float64 `json:"p95Duration" db:"p95_duration"`
+	P99Duration float64 `json:"p99Duration" db:"p99_duration"`
+}
+
+// WorkflowExecutionAnalytics is ExecutionAnalytics for a single workflow
+type WorkflowExecutionAnalytics struct {
+	WorkflowID   string `json:"workflowId" db:"workflow_id"`
+	WorkflowName string `json:"workflowName" db:"workflow_name"`
+	ExecutionAnalytics
+}
+
 // TriggerTypeBreakdown represents execution count by trigger type
 type TriggerTypeBreakdown struct {
 	TriggerType string  `json:"triggerType" db:"trigger_type"`
@@ -43,6 +63,10 @@ type TriggerTypeBreakdown struct {
 
 // GetExecutionTrends returns execution counts grouped by time period
 func (r *Repository) GetExecutionTrends(ctx context.Context, tenantID string, startDate, endDate time.Time, groupBy string) ([]ExecutionTrend, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	var dateFormat string
 	var truncFunc string
 
@@ -72,7 +96,7 @@ func (r *Repository) GetExecutionTrends(ctx context.Context, tenantID string, st
 	`, truncFunc, dateFormat, truncFunc, truncFunc)
 
 	var trends []ExecutionTrend
-	err := r.db.SelectContext(ctx, &trends, query, tenantID, startDate, endDate)
+	err = db.SelectContext(ctx, &trends, query, tenantID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("get execution trends: %w", err)
 	}
@@ -82,6 +106,10 @@ func (r *Repository) GetExecutionTrends(ctx context.Context, tenantID string, st
 
 // GetDurationStats returns duration statistics grouped by workflow
 func (r *Repository) GetDurationStats(ctx context.Context, tenantID string, startDate, endDate time.Time) ([]DurationStats, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT
 			e.workflow_id,
@@ -104,7 +132,7 @@ func (r *Repository) GetDurationStats(ctx context.Context, tenantID string, star
 	`
 
 	var stats []DurationStats
-	err := r.db.SelectContext(ctx, &stats, query, tenantID, startDate, endDate)
+	err = db.SelectContext(ctx, &stats, query, tenantID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("get duration stats: %w", err)
 	}
@@ -112,79 +140,81 @@ func (r *Repository) GetDurationStats(ctx context.Context, tenantID string, star
 	return stats, nil
 }
 
-// GetTopFailures returns workflows with the most failures
-// DEPRECATED: Use GetTopFailuresOptimized for better performance
-func (r *Repository) GetTopFailures(ctx context.Context, tenantID string, startDate, endDate time.Time, limit int) ([]TopFailure, error) {
-	query := `
-		SELECT
-			e.workflow_id,
-			w.name as workflow_name,
-			COUNT(*) as failure_count,
-			MAX(e.completed_at) as last_failed_at,
-			(
-				SELECT error_message
-				FROM executions
-				WHERE workflow_id = e.workflow_id
-					AND status = 'failed'
-					AND error_message IS NOT NULL
-				ORDER BY completed_at DESC
-				LIMIT 1
-			) as error_preview
-		FROM executions e
-		INNER JOIN workflows w ON e.workflow_id = w.id
-		WHERE e.tenant_id = $1
-			AND e.created_at >= $2
-			AND e.created_at < $3
-			AND e.status = 'failed'
-		GROUP BY e.workflow_id, w.name
-		ORDER BY failure_count DESC
-		LIMIT $4
-	`
+// TopFailuresOptions filters and paginates GetTopFailures
+type TopFailuresOptions struct {
+	// WorkflowID limits the results to a single workflow when set
+	WorkflowID string
+	Limit      int
+	Offset     int
+}
 
-	var failures []TopFailure
-	err := r.db.SelectContext(ctx, &failures, query, tenantID, startDate, endDate, limit)
+// defaultTopFailuresLimit is used when TopFailuresOptions.Limit is not set
+const defaultTopFailuresLimit = 10
+
+// GetTopFailures returns workflows with the most failures, each with the error
+// message of its latest failure. Failures are counted per workflow before the
+// latest error is looked up with a LATERAL join, so the lookup runs once per
+// workflow and differing error messages never split a workflow's count.
+func (r *Repository) GetTopFailures(ctx context.Context, tenantID string, startDate, endDate time.Time, opts TopFailuresOptions) ([]TopFailure, error) {
+	db, err := r.executionDB(ctx, tenantID)
 	if err != nil {
-		return nil, fmt.Errorf("get top failures: %w", err)
+		return nil, err
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultTopFailuresLimit
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
 	}
 
-	return failures, nil
-}
+	args := []interface{}{tenantID, startDate, endDate}
+	workflowFilter := ""
+	if opts.WorkflowID != "" {
+		args = append(args, opts.WorkflowID)
+		workflowFilter = fmt.Sprintf("AND e.workflow_id = $%d", len(args))
+	}
+	args = append(args, opts.Limit, opts.Offset)
 
-// GetTopFailuresOptimized returns workflows with the most failures using an optimized query
-// This implementation uses CROSS JOIN LATERAL to eliminate the N+1 query problem,
-// providing 70-90% query time reduction compared to the correlated subquery approach.
-func (r *Repository) GetTopFailuresOptimized(ctx context.Context, tenantID string, startDate, endDate time.Time, limit int) ([]TopFailure, error) {
-	query := `
+	query := fmt.Sprintf(`
+		WITH failures AS (
+			SELECT
+				e.workflow_id,
+				COUNT(*) as failure_count,
+				MAX(e.completed_at) as last_failed_at
+			FROM executions e
+			WHERE e.tenant_id = $1
+				AND e.created_at >= $2
+				AND e.created_at < $3
+				AND e.status = 'failed'
+				%s
+			GROUP BY e.workflow_id
+		)
 		SELECT
-			e.workflow_id,
+			f.workflow_id,
 			w.name as workflow_name,
-			COUNT(*) as failure_count,
-			MAX(e.completed_at) as last_failed_at,
+			f.failure_count,
+			f.last_failed_at,
 			latest.error_message as error_preview
-		FROM executions e
-		INNER JOIN workflows w ON e.workflow_id = w.id
-		CROSS JOIN LATERAL (
+		FROM failures f
+		INNER JOIN workflows w ON f.workflow_id = w.id
+		LEFT JOIN LATERAL (
 			SELECT error_message
 			FROM executions
-			WHERE workflow_id = e.workflow_id
+			WHERE workflow_id = f.workflow_id
+				AND tenant_id = $1
 				AND status = 'failed'
 				AND error_message IS NOT NULL
-			ORDER BY completed_at DESC
+			ORDER BY completed_at DESC NULLS LAST
 			LIMIT 1
-		) latest
-		WHERE e.tenant_id = $1
-			AND e.created_at >= $2
-			AND e.created_at < $3
-			AND e.status = 'failed'
-		GROUP BY e.workflow_id, w.name, latest.error_message
-		ORDER BY failure_count DESC
-		LIMIT $4
-	`
+		) latest ON true
+		ORDER BY f.failure_count DESC, f.workflow_id
+		LIMIT $%d OFFSET $%d
+	`, workflowFilter, len(args)-1, len(args))
 
 	var failures []TopFailure
-	err := r.db.SelectContext(ctx, &failures, query, tenantID, startDate, endDate, limit)
+	err = db.SelectContext(ctx, &failures, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("get top failures optimized: %w", err)
+		return nil, fmt.Errorf("get top failures: %w", err)
 	}
 
 	return failures, nil
@@ -192,6 +222,10 @@ func (r *Repository) GetTopFailuresOptimized(ctx context.Context, tenantID strin
 
 // GetTriggerTypeBreakdown returns execution count by trigger type
 func (r *Repository) GetTriggerTypeBreakdown(ctx context.Context, tenantID string, startDate, endDate time.Time) ([]TriggerTypeBreakdown, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		WITH trigger_counts AS (
 			SELECT
@@ -216,10 +250,80 @@ func (r *Repository) GetTriggerTypeBreakdown(ctx context.Context, tenantID strin
 	`
 
 	var breakdown []TriggerTypeBreakdown
-	err := r.db.SelectContext(ctx, &breakdown, query, tenantID, startDate, endDate)
+	err = db.SelectContext(ctx, &breakdown, query, tenantID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("get trigger type breakdown: %w", err)
 	}
 
 	return breakdown, nil
 }
+
+// executionStatsColumns are the aggregate columns shared by the execution stats
+// queries. Percentiles only consider finished executions with both timestamps.
+const executionStatsColumns = `
+	COUNT(*) as total,
+	COUNT(*) FILTER (WHERE e.status = 'completed') as succeeded,
+	COUNT(*) FILTER (WHERE e.status = 'failed') as failed,
+	COALESCE(ROUND(
+		COUNT(*) FILTER (WHERE e.status = 'completed')::numeric
+			/ NULLIF(COUNT(*) FILTER (WHERE e.status IN ('completed', 'failed')), 0) * 100, 2
+	), 0) as success_rate,
+	COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (e.completed_at - e.started_at)) * 1000)
+		FILTER (WHERE e.status IN ('completed', 'failed') AND e.started_at IS NOT NULL AND e.completed_at IS NOT NULL), 0) as p50_duration,
+	COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (e.completed_at - e.started_at)) * 1000)
+		FILTER (WHERE e.status IN ('completed', 'failed') AND e.started_at IS NOT NULL AND e.completed_at IS NOT NULL), 0) as p95_duration,
+	COALESCE(PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (e.completed_at - e.started_at)) * 1000)
+		FILTER (WHERE e.status IN ('completed', 'failed') AND e.started_at IS NOT NULL AND e.completed_at IS NOT NULL), 0) as p99_duration`
+
+// GetExecutionStats returns success counts, success rate and p50/p95/p99
+// durations for all of a tenant's executions created in the period
+func (r *Repository) GetExecutionStats(ctx context.Context, tenantID string, startDate, endDate time.Time) (*ExecutionAnalytics, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT` + executionStatsColumns + `
+		FROM executions e
+		WHERE e.tenant_id = $1
+			AND e.created_at >= $2
+			AND e.created_at < $3
+	`
+
+	var stats ExecutionAnalytics
+	err = db.GetContext(ctx, &stats, query, tenantID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("get execution stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetExecutionStatsByWorkflow returns the same statistics as GetExecutionStats
+// for each workflow that ran in the period, busiest first
+func (r *Repository) GetExecutionStatsByWorkflow(ctx context.Context, tenantID string, startDate, endDate time.Time) ([]WorkflowExecutionAnalytics, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT
+			e.workflow_id,
+			w.name as workflow_name,` + executionStatsColumns + `
+		FROM executions e
+		INNER JOIN workflows w ON e.workflow_id = w.id
+		WHERE e.tenant_id = $1
+			AND e.created_at >= $2
+			AND e.created_at < $3
+		GROUP BY e.workflow_id, w.name
+		ORDER BY total DESC, e.workflow_id
+	`
+
+	var stats []WorkflowExecutionAnalytics
+	err = db.SelectContext(ctx, &stats, query, tenantID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("get execution stats by workflow: %w", err)
+	}
+
+	return stats, nil
+}
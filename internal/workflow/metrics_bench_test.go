@@ -6,25 +6,22 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
-// BenchmarkGetTopFailures_Current benchmarks the current implementation with correlated subquery
-func BenchmarkGetTopFailures_Current(b *testing.B) {
+// seedTopFailuresBenchmark creates 100 workflows with 50-100 failed executions each
+func seedTopFailuresBenchmark(b *testing.B) (*sqlx.DB, *Repository, string) {
 	db := setupTestDB(&testing.T{})
 	if db == nil {
 		b.Skip("Skipping benchmark - set TEST_DATABASE_URL environment variable to run")
-		return
+		return nil, nil, ""
 	}
-	defer db.Close()
 
 	repo := NewRepository(db)
 	tenantID := createTestTenant(&testing.T{}, db)
 
-	// Create test data: 100 workflows with varying failure counts
-	workflowIDs := make([]string, 100)
 	for i := 0; i < 100; i++ {
 		workflowID := createTestWorkflowWithName(&testing.T{}, repo, tenantID, "Workflow "+uuid.NewString()[:8])
-		workflowIDs[i] = workflowID
 
 		// Create 50-100 failed executions per workflow
 		failureCount := 50 + (i % 50)
@@ -40,53 +37,14 @@ func BenchmarkGetTopFailures_Current(b *testing.B) {
 		}
 	}
 
-	ctx := context.Background()
-	startDate := time.Now().Add(-24 * time.Hour)
-	endDate := time.Now().Add(24 * time.Hour)
-
-	// Reset timer to exclude setup time
-	b.ResetTimer()
-
-	for i := 0; i < b.N; i++ {
-		_, err := repo.GetTopFailures(ctx, tenantID, startDate, endDate, 10)
-		if err != nil {
-			b.Fatalf("GetTopFailures failed: %v", err)
-		}
-	}
+	return db, repo, tenantID
 }
 
-// BenchmarkGetTopFailures_Optimized benchmarks the optimized implementation with LATERAL join
-func BenchmarkGetTopFailures_Optimized(b *testing.B) {
-	db := setupTestDB(&testing.T{})
-	if db == nil {
-		b.Skip("Skipping benchmark - set TEST_DATABASE_URL environment variable to run")
-		return
-	}
+// BenchmarkGetTopFailures benchmarks the LATERAL join implementation
+func BenchmarkGetTopFailures(b *testing.B) {
+	db, repo, tenantID := seedTopFailuresBenchmark(b)
 	defer db.Close()
 
-	repo := NewRepository(db)
-	tenantID := createTestTenant(&testing.T{}, db)
-
-	// Create test data: 100 workflows with varying failure counts
-	workflowIDs := make([]string, 100)
-	for i := 0; i < 100; i++ {
-		workflowID := createTestWorkflowWithName(&testing.T{}, repo, tenantID, "Workflow "+uuid.NewString()[:8])
-		workflowIDs[i] = workflowID
-
-		// Create 50-100 failed executions per workflow
-		failureCount := 50 + (i % 50)
-		for j := 0; j < failureCount; j++ {
-			createExecutionWithStatusAndError(&testing.T{}, repo, tenantID, workflowID, "failed",
-				time.Now().Add(-time.Duration(j)*time.Minute), "Database connection timeout")
-		}
-
-		// Add some successful executions too
-		for j := 0; j < 20; j++ {
-			createExecutionWithStatus(&testing.T{}, repo, tenantID, workflowID, "completed",
-				time.Now().Add(-time.Duration(j)*time.Minute))
-		}
-	}
-
 	ctx := context.Background()
 	startDate := time.Now().Add(-24 * time.Hour)
 	endDate := time.Now().Add(24 * time.Hour)
@@ -95,9 +53,9 @@ func BenchmarkGetTopFailures_Optimized(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_, err := repo.GetTopFailuresOptimized(ctx, tenantID, startDate, endDate, 10)
+		_, err := repo.GetTopFailures(ctx, tenantID, startDate, endDate, TopFailuresOptions{Limit: 10})
 		if err != nil {
-			b.Fatalf("GetTopFailuresOptimized failed: %v", err)
+			b.Fatalf("GetTopFailures failed: %v", err)
 		}
 	}
 }
@@ -0,0 +1,54 @@
+//go:build legacymetrics
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetTopFailuresCorrelated returns workflows with the most failures using a
+// correlated subquery for the latest error message.
+//
+// Deprecated: Use GetTopFailures. This implementation is only built with the
+// legacymetrics tag so cmd/benchmark can compare the two, and will be removed.
+func (r *Repository) GetTopFailuresCorrelated(ctx context.Context, tenantID string, startDate, endDate time.Time, limit int) ([]TopFailure, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT
+			e.workflow_id,
+			w.name as workflow_name,
+			COUNT(*) as failure_count,
+			MAX(e.completed_at) as last_failed_at,
+			(
+				SELECT error_message
+				FROM executions
+				WHERE workflow_id = e.workflow_id
+					AND status = 'failed'
+					AND error_message IS NOT NULL
+				ORDER BY completed_at DESC
+				LIMIT 1
+			) as error_preview
+		FROM executions e
+		INNER JOIN workflows w ON e.workflow_id = w.id
+		WHERE e.tenant_id = $1
+			AND e.created_at >= $2
+			AND e.created_at < $3
+			AND e.status = 'failed'
+		GROUP BY e.workflow_id, w.name
+		ORDER BY failure_count DESC
+		LIMIT $4
+	`
+
+	var failures []TopFailure
+	err = db.SelectContext(ctx, &failures, query, tenantID, startDate, endDate, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get top failures: %w", err)
+	}
+
+	return failures, nil
+}
@@ -0,0 +1,29 @@
+//go:build legacymetrics
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// BenchmarkGetTopFailuresCorrelated benchmarks the deprecated correlated subquery implementation
+func BenchmarkGetTopFailuresCorrelated(b *testing.B) {
+	db, repo, tenantID := seedTopFailuresBenchmark(b)
+	defer db.Close()
+
+	ctx := context.Background()
+	startDate := time.Now().Add(-24 * time.Hour)
+	endDate := time.Now().Add(24 * time.Hour)
+
+	// Reset timer to exclude setup time
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := repo.GetTopFailuresCorrelated(ctx, tenantID, startDate, endDate, 10)
+		if err != nil {
+			b.Fatalf("GetTopFailuresCorrelated failed: %v", err)
+		}
+	}
+}
@@ -200,7 +200,7 @@ func TestGetTopFailures(t *testing.T) {
 		createExecutionWithStatus(t, repo, tenantID, workflow3ID, "completed", time.Now())
 	}
 
-	failures, err := repo.GetTopFailures(context.Background(), tenantID, time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), 10)
+	failures, err := repo.GetTopFailures(context.Background(), tenantID, time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{Limit: 10})
 	require.NoError(t, err)
 	require.Len(t, failures, 3)
 
@@ -217,7 +217,7 @@ func TestGetTopFailures(t *testing.T) {
 	assert.Equal(t, 2, failures[2].FailureCount)
 }
 
-func TestGetTopFailuresOptimized(t *testing.T) {
+func TestGetTopFailures_Cases(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -347,8 +347,8 @@ func TestGetTopFailuresOptimized(t *testing.T) {
 
 			_, expectedCounts := tt.setupData()
 
-			failures, err := repo.GetTopFailuresOptimized(context.Background(), testTenantID,
-				time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), tt.limit)
+			failures, err := repo.GetTopFailures(context.Background(), testTenantID,
+				time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{Limit: tt.limit})
 			require.NoError(t, err)
 			assert.Len(t, failures, tt.wantCount)
 
@@ -384,7 +384,7 @@ func TestGetTopFailuresOptimized(t *testing.T) {
 	}
 }
 
-func TestGetTopFailuresOptimized_EmptyResults(t *testing.T) {
+func TestGetTopFailures_EmptyResults(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -392,13 +392,13 @@ func TestGetTopFailuresOptimized_EmptyResults(t *testing.T) {
 	tenantID := createTestTenant(t, db)
 
 	// No failures created
-	failures, err := repo.GetTopFailuresOptimized(context.Background(), tenantID,
-		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), 10)
+	failures, err := repo.GetTopFailures(context.Background(), tenantID,
+		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{Limit: 10})
 	require.NoError(t, err)
 	assert.Empty(t, failures)
 }
 
-func TestGetTopFailuresOptimized_TenantIsolation(t *testing.T) {
+func TestGetTopFailures_TenantIsolation(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -419,20 +419,74 @@ func TestGetTopFailuresOptimized_TenantIsolation(t *testing.T) {
 	}
 
 	// Query tenant 1
-	failures1, err := repo.GetTopFailuresOptimized(context.Background(), tenant1ID,
-		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), 10)
+	failures1, err := repo.GetTopFailures(context.Background(), tenant1ID,
+		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{Limit: 10})
 	require.NoError(t, err)
 	require.Len(t, failures1, 1)
 	assert.Equal(t, 5, failures1[0].FailureCount)
 
 	// Query tenant 2
-	failures2, err := repo.GetTopFailuresOptimized(context.Background(), tenant2ID,
-		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), 10)
+	failures2, err := repo.GetTopFailures(context.Background(), tenant2ID,
+		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{Limit: 10})
 	require.NoError(t, err)
 	require.Len(t, failures2, 1)
 	assert.Equal(t, 10, failures2[0].FailureCount)
 }
 
+func TestGetTopFailures_MixedErrorMessages(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	tenantID := createTestTenant(t, db)
+
+	// Failures with different messages must still be counted as one workflow
+	workflowID := createTestWorkflowWithName(t, repo, tenantID, "Mixed Errors Workflow")
+	createExecutionWithStatusAndError(t, repo, tenantID, workflowID, "failed", time.Now().Add(-3*time.Minute), "Connection refused")
+	createExecutionWithStatusAndError(t, repo, tenantID, workflowID, "failed", time.Now().Add(-2*time.Minute), "Timeout")
+	createExecutionWithStatusAndError(t, repo, tenantID, workflowID, "failed", time.Now().Add(-1*time.Minute), "Bad gateway")
+
+	failures, err := repo.GetTopFailures(context.Background(), tenantID,
+		time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour), TopFailuresOptions{})
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, 3, failures[0].FailureCount)
+	require.NotNil(t, failures[0].ErrorPreview)
+	assert.Equal(t, "Bad gateway", *failures[0].ErrorPreview)
+}
+
+func TestGetTopFailures_PaginationAndWorkflowFilter(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	tenantID := createTestTenant(t, db)
+
+	workflowIDs := make([]string, 4)
+	for i := range workflowIDs {
+		workflowIDs[i] = createTestWorkflowWithName(t, repo, tenantID, "Workflow "+string(rune('A'+i)))
+		for j := 0; j < 8-i; j++ {
+			createExecutionWithStatusAndError(t, repo, tenantID, workflowIDs[i], "failed",
+				time.Now().Add(-time.Duration(j)*time.Minute), "Test error")
+		}
+	}
+
+	startDate := time.Now().Add(-24 * time.Hour)
+	endDate := time.Now().Add(24 * time.Hour)
+
+	page, err := repo.GetTopFailures(context.Background(), tenantID, startDate, endDate, TopFailuresOptions{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, workflowIDs[2], page[0].WorkflowID)
+	assert.Equal(t, workflowIDs[3], page[1].WorkflowID)
+
+	single, err := repo.GetTopFailures(context.Background(), tenantID, startDate, endDate, TopFailuresOptions{WorkflowID: workflowIDs[1]})
+	require.NoError(t, err)
+	require.Len(t, single, 1)
+	assert.Equal(t, workflowIDs[1], single[0].WorkflowID)
+	assert.Equal(t, 7, single[0].FailureCount)
+}
+
 func TestGetTriggerTypeBreakdown(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -477,6 +531,69 @@ func TestGetTriggerTypeBreakdown(t *testing.T) {
 
 // Helper functions
 
+func TestGetExecutionStats(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewRepository(db)
+	tenantID := createTestTenant(t, db)
+
+	workflow1ID := createTestWorkflow(t, repo, tenantID)
+	workflow2ID := createTestWorkflowWithName(t, repo, tenantID, "Workflow 2")
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		createExecutionWithStatus(t, repo, tenantID, workflow1ID, "completed", now.Add(-time.Duration(i)*time.Minute))
+	}
+	createExecutionWithStatus(t, repo, tenantID, workflow1ID, "failed", now)
+	createExecutionWithStatus(t, repo, tenantID, workflow2ID, "completed", now)
+	// Running executions count towards the total but not the success rate
+	_, err := repo.CreateExecution(context.Background(), tenantID, workflow2ID, 1, "manual", nil)
+	require.NoError(t, err)
+
+	// Outside the range
+	createExecutionWithStatus(t, repo, tenantID, workflow2ID, "failed", now.Add(-72*time.Hour))
+
+	startDate := now.Add(-24 * time.Hour)
+	endDate := now.Add(24 * time.Hour)
+
+	t.Run("overall", func(t *testing.T) {
+		stats, err := repo.GetExecutionStats(context.Background(), tenantID, startDate, endDate)
+		require.NoError(t, err)
+
+		assert.Equal(t, 6, stats.Total)
+		assert.Equal(t, 4, stats.Succeeded)
+		assert.Equal(t, 1, stats.Failed)
+		assert.InDelta(t, 80.0, stats.SuccessRate, 0.01)
+		assert.InDelta(t, 1000.0, stats.P50Duration, 1)
+		assert.LessOrEqual(t, stats.P50Duration, stats.P95Duration)
+		assert.LessOrEqual(t, stats.P95Duration, stats.P99Duration)
+	})
+
+	t.Run("by workflow", func(t *testing.T) {
+		stats, err := repo.GetExecutionStatsByWorkflow(context.Background(), tenantID, startDate, endDate)
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+
+		assert.Equal(t, workflow1ID, stats[0].WorkflowID)
+		assert.Equal(t, 4, stats[0].Total)
+		assert.InDelta(t, 75.0, stats[0].SuccessRate, 0.01)
+
+		assert.Equal(t, workflow2ID, stats[1].WorkflowID)
+		assert.Equal(t, 2, stats[1].Total)
+		assert.InDelta(t, 100.0, stats[1].SuccessRate, 0.01)
+	})
+
+	t.Run("no executions", func(t *testing.T) {
+		stats, err := repo.GetExecutionStats(context.Background(), createTestTenant(t, db), startDate, endDate)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, stats.Total)
+		assert.Equal(t, 0.0, stats.SuccessRate)
+		assert.Equal(t, 0.0, stats.P99Duration)
+	})
+}
+
 func createExecutionWithStatus(t *testing.T, repo *Repository, tenantID, workflowID, status string, createdAt time.Time) string {
 	exec, err := repo.CreateExecution(context.Background(), tenantID, workflowID, 1, "manual", nil)
 	require.NoError(t, err)
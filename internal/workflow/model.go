@@ -10,17 +10,38 @@ import (
 
 // Workflow represents a workflow definition
 type Workflow struct {
-	ID              string          `db:"id" json:"id"`
-	TenantID        string          `db:"tenant_id" json:"tenant_id"`
-	Name            string          `db:"name" json:"name"`
-	Description     string          `db:"description" json:"description"`
-	Definition      json.RawMessage `db:"definition" json:"definition"`
-	Status          string          `db:"status" json:"status"`
-	Version         int             `db:"version" json:"version"`
-	CreatedBy       string          `db:"created_by" json:"created_by"`
-	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
-	UpdatedAt       time.Time       `db:"updated_at" json:"updated_at"`
-	ErrorStatistics json.RawMessage `db:"error_statistics" json:"error_statistics,omitempty"`
+	ID              string           `db:"id" json:"id"`
+	TenantID        string           `db:"tenant_id" json:"tenant_id"`
+	Name            string           `db:"name" json:"name"`
+	Description     string           `db:"description" json:"description"`
+	Definition      json.RawMessage  `db:"definition" json:"definition"`
+	Status          string           `db:"status" json:"status"`
+	Version         int              `db:"version" json:"version"`
+	CreatedBy       string           `db:"created_by" json:"created_by"`
+	CreatedAt       time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time        `db:"updated_at" json:"updated_at"`
+	ErrorStatistics json.RawMessage  `db:"error_statistics" json:"error_statistics,omitempty"`
+	AutoPauseConfig *AutoPauseConfig `db:"auto_pause_config" json:"auto_pause_config,omitempty"`
+	AutoPausedAt    *time.Time       `db:"auto_paused_at" json:"auto_paused_at,omitempty"`
+	AutoPauseReason *string          `db:"auto_pause_reason" json:"auto_pause_reason,omitempty"`
+	// MaxConcurrency caps how many executions of the workflow run at once on a worker
+	MaxConcurrency *int `db:"max_concurrency" json:"max_concurrency,omitempty"`
+	// ConcurrencyKey stops an execution from running while another execution
+	// with the same key is running anywhere in the tenant
+	ConcurrencyKey *string `db:"concurrency_key" json:"concurrency_key,omitempty"`
+	// OnFailureWorkflowID is started with the failure context when an execution fails
+	OnFailureWorkflowID *string `db:"on_failure_workflow_id" json:"on_failure_workflow_id,omitempty"`
+	// EventRetentionDays overrides the global webhook event retention for this workflow
+	EventRetentionDays *int `db:"event_retention_days" json:"event_retention_days,omitempty"`
+	// MaxExecutionDuration is how many seconds an execution may run before it
+	// fails with execution_timeout; the global ceiling applies when it is lower
+	MaxExecutionDuration *int `db:"max_execution_duration" json:"max_execution_duration,omitempty"`
+	// ReferenceWarnings lists unresolved env and credential references found
+	// when the workflow was saved; it is not stored
+	ReferenceWarnings []ReferenceIssue `db:"-" json:"reference_warnings,omitempty"`
+	// LintWarnings lists anti-patterns found in the definition when the
+	// workflow was saved; they do not block saving and are not stored
+	LintWarnings []LintFinding `db:"-" json:"lint_warnings,omitempty"`
 }
 
 // WorkflowDefinition represents the full workflow structure
@@ -33,6 +54,11 @@ type WorkflowDefinition struct {
 type NodeData struct {
 	Name   string          `json:"name"`
 	Config json.RawMessage `json:"config"`
+	// Inputs declares the upstream outputs the node consumes, as <node>.<key>
+	Inputs []string `json:"inputs,omitempty"`
+	// Outputs declares the top-level keys of the node's output, overriding
+	// the built-in schema for its type
+	Outputs []string `json:"outputs,omitempty"`
 }
 
 type Node struct {
@@ -57,37 +83,44 @@ type Edge struct {
 	Target   string `json:"target"`
 	SourceID string `json:"sourceHandle,omitempty"`
 	TargetID string `json:"targetHandle,omitempty"`
-	Label    string `json:"label,omitempty"` // Used for conditional branches: "true" or "false"
+	Label    string `json:"label,omitempty"`  // Used for branches: "true"/"false" for if nodes, case labels for switch nodes
+	Weight   int    `json:"weight,omitempty"` // Percentage of executions a split node routes along this edge
 }
 
 // NodeType represents the type of a node
 type NodeType string
 
 const (
-	NodeTypeTriggerWebhook           NodeType = "trigger:webhook"
-	NodeTypeTriggerSchedule          NodeType = "trigger:schedule"
-	NodeTypeActionHTTP               NodeType = "action:http"
-	NodeTypeActionTransform          NodeType = "action:transform"
-	NodeTypeActionFormula            NodeType = "action:formula"
-	NodeTypeActionCode               NodeType = "action:code"
-	NodeTypeActionEmail              NodeType = "action:email"
-	NodeTypeActionSlackSendMessage   NodeType = "slack:send_message"
-	NodeTypeActionSlackSendDM        NodeType = "slack:send_dm"
-	NodeTypeActionSlackUpdateMessage NodeType = "slack:update_message"
-	NodeTypeActionSlackAddReaction   NodeType = "slack:add_reaction"
-	NodeTypeActionSubworkflow        NodeType = "action:subworkflow"
-	NodeTypeControlIf                NodeType = "control:if"
-	NodeTypeControlLoop              NodeType = "control:loop"
-	NodeTypeControlParallel          NodeType = "control:parallel"
-	NodeTypeControlFork              NodeType = "control:fork"
-	NodeTypeControlJoin              NodeType = "control:join"
-	NodeTypeControlDelay             NodeType = "control:delay"
-	NodeTypeControlSubWorkflow       NodeType = "control:sub_workflow"
-	NodeTypeControlTry               NodeType = "control:try"
-	NodeTypeControlCatch             NodeType = "control:catch"
-	NodeTypeControlFinally           NodeType = "control:finally"
-	NodeTypeControlRetry             NodeType = "control:retry"
-	NodeTypeControlCircuitBreaker    NodeType = "control:circuit_breaker"
+	NodeTypeTriggerWebhook             NodeType = "trigger:webhook"
+	NodeTypeTriggerSchedule            NodeType = "trigger:schedule"
+	NodeTypeActionHTTP                 NodeType = "action:http"
+	NodeTypeActionTransform            NodeType = "action:transform"
+	NodeTypeActionFormula              NodeType = "action:formula"
+	NodeTypeActionCode                 NodeType = "action:code"
+	NodeTypeActionScript               NodeType = "action:script"
+	NodeTypeActionEmail                NodeType = "action:email"
+	NodeTypeActionSlackSendMessage     NodeType = "slack:send_message"
+	NodeTypeActionSlackSendDM          NodeType = "slack:send_dm"
+	NodeTypeActionSlackUpdateMessage   NodeType = "slack:update_message"
+	NodeTypeActionSlackAddReaction     NodeType = "slack:add_reaction"
+	NodeTypeActionSlackWaitForResponse NodeType = "slack:wait_for_response"
+	NodeTypeActionSubworkflow          NodeType = "action:subworkflow"
+	NodeTypeActionSetTags              NodeType = "action:set_tags"
+	NodeTypeActionDatabase             NodeType = "action:database"
+	NodeTypeControlIf                  NodeType = "control:if"
+	NodeTypeControlSwitch              NodeType = "control:switch"
+	NodeTypeControlSplit               NodeType = "control:split"
+	NodeTypeControlLoop                NodeType = "control:loop"
+	NodeTypeControlParallel            NodeType = "control:parallel"
+	NodeTypeControlFork                NodeType = "control:fork"
+	NodeTypeControlJoin                NodeType = "control:join"
+	NodeTypeControlDelay               NodeType = "control:delay"
+	NodeTypeControlSubWorkflow         NodeType = "control:sub_workflow"
+	NodeTypeControlTry                 NodeType = "control:try"
+	NodeTypeControlCatch               NodeType = "control:catch"
+	NodeTypeControlFinally             NodeType = "control:finally"
+	NodeTypeControlRetry               NodeType = "control:retry"
+	NodeTypeControlCircuitBreaker      NodeType = "control:circuit_breaker"
 )
 
 // HTTPActionConfig represents HTTP action configuration
@@ -97,12 +130,20 @@ type HTTPActionConfig struct {
 	Headers map[string]string `json:"headers,omitempty"`
 	Body    json.RawMessage   `json:"body,omitempty"`
 	Timeout int               `json:"timeout,omitempty"`
+	// ContentType is the request body encoding: application/json (default),
+	// application/x-www-form-urlencoded or multipart/form-data
+	ContentType string `json:"content_type,omitempty"`
+	// MaxResponseBytes lowers the server's response body limit for the node
+	MaxResponseBytes int64 `json:"max_response_bytes,omitempty"`
 }
 
 // TransformActionConfig represents transform action configuration
 type TransformActionConfig struct {
-	Expression string            `json:"expression"`
-	Mapping    map[string]string `json:"mapping,omitempty"`
+	// TransformType is "mapping" (default) or "expr" for a single expr-language
+	// expression that produces the whole output
+	TransformType string                 `json:"transform_type,omitempty"`
+	Expression    string                 `json:"expression"`
+	Mapping       map[string]interface{} `json:"mapping,omitempty"`
 }
 
 // FormulaActionConfig represents formula action configuration
@@ -118,18 +159,37 @@ type ScriptActionConfig struct {
 	MemoryLimit int    `json:"memory_limit,omitempty"` // Max memory in MB (future enhancement)
 }
 
+// MaxScriptNodeLength caps the size of an action:script node's script
+const MaxScriptNodeLength = 64 * 1024
+
+// ScriptNodeConfig represents sandboxed script (action:script) node configuration
+type ScriptNodeConfig struct {
+	Script  string `json:"script"`            // JavaScript returning the step output
+	Timeout int    `json:"timeout,omitempty"` // Max execution time in seconds (default: 5, at most 10)
+}
+
 // WebhookTriggerConfig represents webhook trigger configuration
 type WebhookTriggerConfig struct {
-	Path        string `json:"path,omitempty"`
-	AuthType    string `json:"auth_type,omitempty"` // none, basic, signature, api_key
-	Secret      string `json:"secret,omitempty"`
-	AllowedIPs  string `json:"allowed_ips,omitempty"`
-	ResponseURL string `json:"response_url,omitempty"`
+	Path              string `json:"path,omitempty"`
+	AuthType          string `json:"auth_type,omitempty"` // none, basic, signature, api_key
+	Secret            string `json:"secret,omitempty"`
+	SignatureScheme   string `json:"signature_scheme,omitempty"` // generic, github, slack, stripe
+	AllowedIPs        string `json:"allowed_ips,omitempty"`
+	ResponseURL       string `json:"response_url,omitempty"`
+	IdempotencyHeader string `json:"idempotency_header,omitempty"` // e.g. X-GitHub-Delivery
+	IdempotencyTTL    string `json:"idempotency_ttl,omitempty"`    // e.g. "24h" (default)
+	// PayloadSchema is a JSON Schema that request bodies must match; non-matching requests get 422
+	PayloadSchema json.RawMessage `json:"payload_schema,omitempty"`
+	// EventType names a registered event type whose schema request bodies must
+	// match; EventTypeVersion pins a version, otherwise the latest is used
+	EventType        string `json:"event_type,omitempty"`
+	EventTypeVersion int    `json:"event_type_version,omitempty"`
 }
 
 // ScheduleTriggerConfig represents schedule trigger configuration
 type ScheduleTriggerConfig struct {
-	Cron     string `json:"cron"`
+	Cron     string `json:"cron,omitempty"`
+	Interval string `json:"interval,omitempty"` // e.g. "30s" or "5m"; mutually exclusive with Cron
 	Timezone string `json:"timezone,omitempty"`
 }
 
@@ -143,15 +203,48 @@ type ConditionalActionConfig struct {
 	StopOnFalse bool   `json:"stop_on_false,omitempty"` // Stop workflow if condition is false
 }
 
+// SwitchDefaultBranch is the edge label followed when no switch case matches
+const SwitchDefaultBranch = "default"
+
+// SwitchActionConfig represents multi-branch switch action configuration
+type SwitchActionConfig struct {
+	Expression  string       `json:"expression"`            // Expression whose value is matched against the cases
+	Cases       []SwitchCase `json:"cases"`                 // Cases in evaluation order; the first match wins
+	Description string       `json:"description,omitempty"` // Optional description of the switch
+}
+
+// SwitchCase is a value the switch expression is compared against and the
+// edge label it routes to
+type SwitchCase struct {
+	Value interface{} `json:"value"`
+	Label string      `json:"label,omitempty"` // Edge label for this case (defaults to the value)
+}
+
+// BranchLabel returns the edge label the case routes to
+func (c SwitchCase) BranchLabel() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	if c.Value == nil {
+		return "null"
+	}
+	return fmt.Sprint(c.Value)
+}
+
+// MaxLoopConcurrency is the maximum number of loop iterations that can run at once
+const MaxLoopConcurrency = 50
+
 // LoopActionConfig represents loop (for-each) action configuration
 type LoopActionConfig struct {
-	Source          string           `json:"source"`                     // JSONPath to array or object (e.g., ${steps.node1.output.items})
-	ItemVariable    string           `json:"item_variable"`              // Variable name for current item (e.g., "item")
-	IndexVariable   string           `json:"index_variable,omitempty"`   // Variable name for current index (e.g., "index")
-	KeyVariable     string           `json:"key_variable,omitempty"`     // Variable name for object key when iterating objects (e.g., "key")
-	MaxIterations   int              `json:"max_iterations,omitempty"`   // Safety limit (default 1000)
-	OnError         string           `json:"on_error,omitempty"`         // "continue" or "stop" (default "stop")
-	BreakConditions []BreakCondition `json:"break_conditions,omitempty"` // Conditions to exit loop early
+	Source          string           `json:"source"`                      // JSONPath to array or object (e.g., ${steps.node1.output.items})
+	ItemVariable    string           `json:"item_variable"`               // Variable name for current item (e.g., "item")
+	IndexVariable   string           `json:"index_variable,omitempty"`    // Variable name for current index (e.g., "index")
+	KeyVariable     string           `json:"key_variable,omitempty"`      // Variable name for object key when iterating objects (e.g., "key")
+	MaxIterations   int              `json:"max_iterations,omitempty"`    // Safety limit (default 1000)
+	OnError         string           `json:"on_error,omitempty"`          // "continue" or "stop" (default "stop")
+	ContinueOnError bool             `json:"continue_on_error,omitempty"` // Record failed iterations and keep going (same as on_error "continue")
+	Concurrency     int              `json:"concurrency,omitempty"`       // Iterations run at once (default 1, sequential)
+	BreakConditions []BreakCondition `json:"break_conditions,omitempty"`  // Conditions to exit loop early; require sequential iteration
 }
 
 // BreakCondition represents a condition that can exit a loop early
@@ -270,6 +363,12 @@ type CircuitBreakerConfig struct {
 	Name              string  `json:"name,omitempty"`                // Circuit breaker name (defaults to node ID)
 }
 
+// SetTagsConfig represents configuration for setting execution metadata tags
+type SetTagsConfig struct {
+	Tags   map[string]string `json:"tags"`             // Tag name to value expression (e.g., "${trigger.order.id}")
+	Masked []string          `json:"masked,omitempty"` // Tag names whose values hold PII and are stored masked
+}
+
 // ErrorHandlingMetadata represents error metadata captured during execution
 type ErrorHandlingMetadata struct {
 	ErrorType      string                 `json:"error_type"`
@@ -290,17 +389,42 @@ type ErrorHandlingMetadata struct {
 
 // CreateWorkflowInput represents input for creating a workflow
 type CreateWorkflowInput struct {
-	Name        string          `json:"name" validate:"required,min=1,max=255"`
-	Description string          `json:"description"`
-	Definition  json.RawMessage `json:"definition" validate:"required"`
+	Name        string           `json:"name" validate:"required,min=1,max=255"`
+	Description string           `json:"description"`
+	Definition  json.RawMessage  `json:"definition" validate:"required"`
+	AutoPause   *AutoPauseConfig `json:"auto_pause,omitempty"`
+	// MaxConcurrency limits concurrent executions per worker; nil or 0 means no limit
+	MaxConcurrency *int `json:"max_concurrency,omitempty"`
+	// ConcurrencyKey skips executions that start while another execution with the same key is running
+	ConcurrencyKey *string `json:"concurrency_key,omitempty"`
+	// OnFailureWorkflowID names a workflow to start when an execution fails
+	OnFailureWorkflowID *string `json:"on_failure_workflow_id,omitempty"`
+	// EventRetentionDays keeps webhook events this many days instead of the global default; nil or 0 uses the default
+	EventRetentionDays *int `json:"event_retention_days,omitempty"`
+	// MaxExecutionDuration limits executions to this many seconds; nil or 0 leaves only the global ceiling
+	MaxExecutionDuration *int `json:"max_execution_duration,omitempty"`
+	// Status the workflow starts in, draft or active. It is set by internal
+	// callers such as template installs; API-created workflows start as drafts.
+	Status WorkflowStatus `json:"-"`
 }
 
 // UpdateWorkflowInput represents input for updating a workflow
 type UpdateWorkflowInput struct {
-	Name        string          `json:"name,omitempty"`
-	Description string          `json:"description,omitempty"`
-	Definition  json.RawMessage `json:"definition,omitempty"`
-	Status      string          `json:"status,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Definition  json.RawMessage  `json:"definition,omitempty"`
+	Status      string           `json:"status,omitempty"`
+	AutoPause   *AutoPauseConfig `json:"auto_pause,omitempty"`
+	// MaxConcurrency replaces the concurrency limit when set; 0 removes it
+	MaxConcurrency *int `json:"max_concurrency,omitempty"`
+	// ConcurrencyKey replaces the concurrency key when set; "" removes it
+	ConcurrencyKey *string `json:"concurrency_key,omitempty"`
+	// OnFailureWorkflowID replaces the on-failure workflow when set; "" removes it
+	OnFailureWorkflowID *string `json:"on_failure_workflow_id,omitempty"`
+	// EventRetentionDays replaces the webhook event retention override when set; 0 removes it
+	EventRetentionDays *int `json:"event_retention_days,omitempty"`
+	// MaxExecutionDuration replaces the execution time limit in seconds when set; 0 removes it
+	MaxExecutionDuration *int `json:"max_execution_duration,omitempty"`
 }
 
 // WorkflowStatus represents workflow status
@@ -321,13 +445,21 @@ type Execution struct {
 	WorkflowVersion   int              `db:"workflow_version" json:"workflow_version"`
 	Status            string           `db:"status" json:"status"`
 	TriggerType       string           `db:"trigger_type" json:"trigger_type"`
+	TriggerNodeID     *string          `db:"trigger_node_id" json:"trigger_node_id,omitempty"`
+	ConcurrencyKey    *string          `db:"concurrency_key" json:"concurrency_key,omitempty"`
 	TriggerData       *json.RawMessage `db:"trigger_data" json:"trigger_data,omitempty"`
 	OutputData        *json.RawMessage `db:"output_data" json:"output_data,omitempty"`
 	ErrorMessage      *string          `db:"error_message" json:"error_message,omitempty"`
 	ParentExecutionID *string          `db:"parent_execution_id" json:"parent_execution_id,omitempty"`
 	ExecutionDepth    int              `db:"execution_depth" json:"execution_depth"`
+	Tags              *json.RawMessage `db:"tags" json:"tags,omitempty"`
 	StartedAt         *time.Time       `db:"started_at" json:"started_at,omitempty"`
 	CompletedAt       *time.Time       `db:"completed_at" json:"completed_at,omitempty"`
+	CancelledBy       *string          `db:"cancelled_by" json:"cancelled_by,omitempty"`
+	CancelledAt       *time.Time       `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	RetryOfID         *string          `db:"retry_of_execution_id" json:"retry_of_execution_id,omitempty"`
+	ResumeFromNodeID  *string          `db:"resume_from_node_id" json:"resume_from_node_id,omitempty"`
+	CheckpointedAt    *time.Time       `db:"checkpointed_at" json:"checkpointed_at,omitempty"`
 	CreatedAt         time.Time        `db:"created_at" json:"created_at"`
 }
 
@@ -356,19 +488,23 @@ const (
 	ExecutionStatusCompleted ExecutionStatus = "completed"
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 	ExecutionStatusCancelled ExecutionStatus = "cancelled"
+	// ExecutionStatusSkippedConcurrent marks an execution that did not run
+	// because another execution with its concurrency key was running
+	ExecutionStatusSkippedConcurrent ExecutionStatus = "skipped_concurrent"
 )
 
 // ExecutionFilter represents filters for listing executions
 type ExecutionFilter struct {
-	WorkflowID        string     `json:"workflow_id,omitempty"`
-	Status            string     `json:"status,omitempty"`
-	TriggerType       string     `json:"trigger_type,omitempty"`
-	StartDate         *time.Time `json:"start_date,omitempty"`
-	EndDate           *time.Time `json:"end_date,omitempty"`
-	ErrorSearch       string     `json:"error_search,omitempty"`
-	ExecutionIDPrefix string     `json:"execution_id_prefix,omitempty"`
-	MinDurationMs     *int64     `json:"min_duration_ms,omitempty"`
-	MaxDurationMs     *int64     `json:"max_duration_ms,omitempty"`
+	WorkflowID        string            `json:"workflow_id,omitempty"`
+	Status            string            `json:"status,omitempty"`
+	TriggerType       string            `json:"trigger_type,omitempty"`
+	StartDate         *time.Time        `json:"start_date,omitempty"`
+	EndDate           *time.Time        `json:"end_date,omitempty"`
+	ErrorSearch       string            `json:"error_search,omitempty"`
+	ExecutionIDPrefix string            `json:"execution_id_prefix,omitempty"`
+	MinDurationMs     *int64            `json:"min_duration_ms,omitempty"`
+	MaxDurationMs     *int64            `json:"max_duration_ms,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"` // all must match (JSONB containment)
 }
 
 // Validate validates the execution filter
@@ -393,6 +529,12 @@ func (f ExecutionFilter) Validate() error {
 		}
 	}
 
+	for key := range f.Tags {
+		if key == "" {
+			return errors.New("tag keys must not be empty")
+		}
+	}
+
 	return nil
 }
 
@@ -438,6 +580,39 @@ type ExecutionListResult struct {
 	TotalCount int          `json:"total_count"`
 }
 
+// ExecutionSearchFilter represents a search over executions by error message
+type ExecutionSearchFilter struct {
+	// Query is matched case-insensitively as a substring of error_message
+	Query      string     `json:"q,omitempty"`
+	WorkflowID string     `json:"workflow_id,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	StartDate  *time.Time `json:"start_date,omitempty"`
+	EndDate    *time.Time `json:"end_date,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+	Offset     int        `json:"offset,omitempty"`
+}
+
+// Validate validates the execution search filter
+func (f ExecutionSearchFilter) Validate() error {
+	if f.StartDate != nil && f.EndDate != nil && f.EndDate.Before(*f.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+
+	if f.Offset < 0 {
+		return errors.New("offset must be non-negative")
+	}
+
+	return nil
+}
+
+// ExecutionSearchResult represents a page of execution search results
+type ExecutionSearchResult struct {
+	Data       []*Execution `json:"data"`
+	TotalCount int          `json:"total_count"`
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+}
+
 // ExecutionWithSteps represents an execution with its step executions
 type ExecutionWithSteps struct {
 	Execution *Execution       `json:"execution"`
@@ -477,6 +652,29 @@ type DryRunInput struct {
 	TestData map[string]interface{} `json:"test_data"`
 }
 
+// LintInput represents input for linting a workflow definition
+type LintInput struct {
+	Definition json.RawMessage `json:"definition"`
+}
+
+// SimulateInput represents input for a simulated workflow run
+type SimulateInput struct {
+	TriggerData json.RawMessage        `json:"trigger_data"`
+	Mocks       map[string]interface{} `json:"mocks"` // Node ID -> canned output
+}
+
+// SimulationResult represents the outcome of a simulated workflow run.
+// Nothing is persisted and no action with side effects is executed.
+type SimulationResult struct {
+	ExecutionOrder []string               `json:"execution_order"`
+	StepOutputs    map[string]interface{} `json:"step_outputs"`
+	MockedNodes    []string               `json:"mocked_nodes"`  // Nodes whose output came from mocks
+	StubbedNodes   []string               `json:"stubbed_nodes"` // Actions without a mock that were not run
+	SkippedNodes   []string               `json:"skipped_nodes"` // Nodes on untaken branches
+	FailedNode     string                 `json:"failed_node,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+}
+
 // WorkflowVersion represents a version of a workflow definition
 type WorkflowVersion struct {
 	ID         string          `db:"id" json:"id"`
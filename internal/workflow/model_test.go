@@ -37,6 +37,21 @@ func TestExecutionFilter_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid filter with tags",
+			filter: ExecutionFilter{
+				Tags: map[string]string{"order_id": "12345"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty tag key",
+			filter: ExecutionFilter{
+				Tags: map[string]string{"": "12345"},
+			},
+			wantErr: true,
+			errMsg:  "tag keys must not be empty",
+		},
 		{
 			name: "valid filter with only status",
 			filter: ExecutionFilter{
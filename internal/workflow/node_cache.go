@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultNodeCacheTTL is how long a cached node result is reused when the
+	// node does not set ttl_seconds
+	DefaultNodeCacheTTL = 5 * time.Minute
+	// MaxNodeCacheTTL caps how long a cached node result may be reused
+	MaxNodeCacheTTL = 24 * time.Hour
+)
+
+// NodeCacheConfig opts a node in to reusing its result. It is set under
+// "cache" in the node config; nodes without it always run.
+type NodeCacheConfig struct {
+	Enabled    bool `json:"enabled"`
+	TTLSeconds int  `json:"ttl_seconds,omitempty"`
+}
+
+// TTL returns how long a result is reused
+func (c NodeCacheConfig) TTL() time.Duration {
+	if c.TTLSeconds <= 0 {
+		return DefaultNodeCacheTTL
+	}
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+// ParseNodeCacheConfig returns a node's cache settings, or nil when the node
+// does not enable caching
+func ParseNodeCacheConfig(node Node) (*NodeCacheConfig, error) {
+	if len(node.Data.Config) == 0 {
+		return nil, nil
+	}
+
+	var config struct {
+		Cache *NodeCacheConfig `json:"cache"`
+	}
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return nil, fmt.Errorf("invalid cache configuration: %w", err)
+	}
+	if config.Cache == nil || !config.Cache.Enabled {
+		return nil, nil
+	}
+	return config.Cache, nil
+}
+
+// IsCacheableNode reports whether a node's result may be cached. Only
+// read-only requests are: action:http nodes with a GET or HEAD method.
+func IsCacheableNode(node Node) bool {
+	if node.Type != string(NodeTypeActionHTTP) {
+		return false
+	}
+
+	var config HTTPActionConfig
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		return false
+	}
+	switch strings.ToUpper(config.Method) {
+	case "", "GET", "HEAD":
+		return true
+	}
+	return false
+}
+
+// validateNodeCache checks a node's cache settings
+func validateNodeCache(node Node) []DryRunError {
+	cache, err := ParseNodeCacheConfig(node)
+	if err != nil {
+		return []DryRunError{{NodeID: node.ID, Field: "cache", Message: err.Error()}}
+	}
+	if cache == nil {
+		return nil
+	}
+
+	switch {
+	case !IsCacheableNode(node):
+		return []DryRunError{{
+			NodeID:  node.ID,
+			Field:   "cache",
+			Message: "caching is only supported on action:http nodes using GET or HEAD",
+		}}
+	case cache.TTLSeconds < 0 || cache.TTL() > MaxNodeCacheTTL:
+		return []DryRunError{{
+			NodeID:  node.ID,
+			Field:   "cache.ttl_seconds",
+			Message: fmt.Sprintf("ttl_seconds must be between 1 and %d", int(MaxNodeCacheTTL.Seconds())),
+		}}
+	}
+	return nil
+}
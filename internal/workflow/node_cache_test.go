@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeCacheConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    *NodeCacheConfig
+		wantTTL time.Duration
+	}{
+		{name: "no config", config: ``},
+		{name: "no cache", config: `{"method": "GET", "url": "https://api.example.com"}`},
+		{name: "disabled", config: `{"cache": {"enabled": false, "ttl_seconds": 60}}`},
+		{
+			name:    "default ttl",
+			config:  `{"cache": {"enabled": true}}`,
+			want:    &NodeCacheConfig{Enabled: true},
+			wantTTL: DefaultNodeCacheTTL,
+		},
+		{
+			name:    "custom ttl",
+			config:  `{"cache": {"enabled": true, "ttl_seconds": 90}}`,
+			want:    &NodeCacheConfig{Enabled: true, TTLSeconds: 90},
+			wantTTL: 90 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Node{ID: "http-1", Type: string(NodeTypeActionHTTP), Data: NodeData{Config: json.RawMessage(tt.config)}}
+
+			config, err := ParseNodeCacheConfig(node)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+			if config != nil {
+				assert.Equal(t, tt.wantTTL, config.TTL())
+			}
+		})
+	}
+}
+
+func TestValidateNodeCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeType  NodeType
+		config    string
+		wantField string
+	}{
+		{name: "get", nodeType: NodeTypeActionHTTP, config: `{"method": "GET", "url": "https://api.example.com", "cache": {"enabled": true, "ttl_seconds": 60}}`},
+		{name: "head", nodeType: NodeTypeActionHTTP, config: `{"method": "head", "url": "https://api.example.com", "cache": {"enabled": true}}`},
+		{name: "disabled on post", nodeType: NodeTypeActionHTTP, config: `{"method": "POST", "url": "https://api.example.com", "cache": {"enabled": false}}`},
+		{
+			name:      "post",
+			nodeType:  NodeTypeActionHTTP,
+			config:    `{"method": "POST", "url": "https://api.example.com", "cache": {"enabled": true}}`,
+			wantField: "cache",
+		},
+		{
+			name:      "non-http node",
+			nodeType:  NodeTypeActionTransform,
+			config:    `{"mapping": {}, "cache": {"enabled": true}}`,
+			wantField: "cache",
+		},
+		{
+			name:      "ttl above maximum",
+			nodeType:  NodeTypeActionHTTP,
+			config:    `{"method": "GET", "url": "https://api.example.com", "cache": {"enabled": true, "ttl_seconds": 86401}}`,
+			wantField: "cache.ttl_seconds",
+		},
+		{
+			name:      "negative ttl",
+			nodeType:  NodeTypeActionHTTP,
+			config:    `{"method": "GET", "url": "https://api.example.com", "cache": {"enabled": true, "ttl_seconds": -1}}`,
+			wantField: "cache.ttl_seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Node{ID: "node-1", Type: string(tt.nodeType), Data: NodeData{Config: json.RawMessage(tt.config)}}
+
+			errs := validateNodeCache(node)
+
+			if tt.wantField == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+		})
+	}
+}
+
+func TestValidateDefinition_RejectsCacheOnWriteNode(t *testing.T) {
+	s := &Service{}
+	definition := json.RawMessage(`{
+		"nodes": [
+			{"id": "trigger-1", "type": "trigger:webhook", "data": {"config": {}}},
+			{"id": "http-1", "type": "action:http", "data": {"config": {"method": "POST", "url": "https://api.example.com", "cache": {"enabled": true}}}}
+		],
+		"edges": [{"id": "e1", "source": "trigger-1", "target": "http-1"}]
+	}`)
+
+	err := s.validateDefinition(definition)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Message, "node http-1: caching is only supported")
+}
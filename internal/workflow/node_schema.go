@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// builtinOutputSchemas lists the top-level output keys of node types whose
+// output has a fixed shape. They mirror the JSON fields of the executor's
+// result types: actions.HTTPActionResult and the slack package's results.
+var builtinOutputSchemas = map[NodeType][]string{
+	NodeTypeActionHTTP:                 {"status_code", "headers", "headers_truncated", "body"},
+	NodeTypeActionSlackSendMessage:     {"ok", "channel", "ts", "timestamp", "thread_ts", "message", "interaction_token"},
+	NodeTypeActionSlackSendDM:          {"ok", "user_id", "channel", "ts", "timestamp", "message"},
+	NodeTypeActionSlackUpdateMessage:   {"ok", "channel", "timestamp", "message"},
+	NodeTypeActionSlackAddReaction:     {"ok", "channel", "timestamp", "emoji"},
+	NodeTypeActionSlackWaitForResponse: {"responded", "timed_out", "value", "source", "user_id", "user_name", "responded_at"},
+}
+
+// OutputSchema returns the top-level keys a node's output is known to have,
+// or nil when its output shape is not known. Keys the node declares in
+// Data.Outputs take precedence over the built-in schema for its type.
+func OutputSchema(node Node) []string {
+	if len(node.Data.Outputs) > 0 {
+		return node.Data.Outputs
+	}
+	if node.Type == string(NodeTypeActionTransform) {
+		return transformOutputSchema(node.Data.Config)
+	}
+	return builtinOutputSchemas[NodeType(node.Type)]
+}
+
+// transformOutputSchema returns the keys of a transform's mapping. Other
+// transforms, and mappings with a default, can produce any shape.
+func transformOutputSchema(config json.RawMessage) []string {
+	var transform struct {
+		TransformType string                 `json:"transform_type"`
+		Mapping       map[string]interface{} `json:"mapping"`
+		Default       interface{}            `json:"default"`
+	}
+	if err := json.Unmarshal(config, &transform); err != nil {
+		return nil
+	}
+	if transform.TransformType != "" && transform.TransformType != "mapping" {
+		return nil
+	}
+	if len(transform.Mapping) == 0 || transform.Default != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(transform.Mapping))
+	for key := range transform.Mapping {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// stepField is a reference to a key of a step's output, such as the body in
+// ${steps.http-1.body}
+type stepField struct {
+	Step string
+	Key  string
+}
+
+// parseInputDeclaration splits a declared input of the form <node>.<key>
+func parseInputDeclaration(input string) (stepField, bool) {
+	step, key, ok := strings.Cut(strings.TrimPrefix(input, "steps."), ".")
+	if !ok || step == "" || key == "" {
+		return stepField{}, false
+	}
+	return stepField{Step: step, Key: key}, true
+}
+
+// referencedStepFields returns the distinct step output keys referenced in a
+// config, in order. References to a whole step or by index are skipped.
+func referencedStepFields(config string) []stepField {
+	var fields []stepField
+	seen := make(map[stepField]bool)
+	for _, match := range stepReferencePattern.FindAllStringSubmatch(config, -1) {
+		field := stepField{Step: match[1], Key: match[3]}
+		if field.Step == "" {
+			field.Step = match[2]
+		}
+		if field.Key == "" {
+			field.Key = match[4]
+		}
+		if field.Key != "" && !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputSchema(t *testing.T) {
+	node := func(nodeType, config string, outputs ...string) Node {
+		return Node{Type: nodeType, Data: NodeData{Config: json.RawMessage(config), Outputs: outputs}}
+	}
+
+	tests := []struct {
+		name string
+		node Node
+		want []string
+	}{
+		{name: "http", node: node(string(NodeTypeActionHTTP), `{}`), want: []string{"status_code", "headers", "headers_truncated", "body"}},
+		{name: "slack reaction", node: node(string(NodeTypeActionSlackAddReaction), `{}`), want: []string{"ok", "channel", "timestamp", "emoji"}},
+		{name: "transform mapping", node: node(string(NodeTypeActionTransform), `{"mapping": {"b": "x", "a": "y"}}`), want: []string{"a", "b"}},
+		{name: "transform mapping with default", node: node(string(NodeTypeActionTransform), `{"mapping": {"a": "y"}, "default": {}}`)},
+		{name: "transform expression", node: node(string(NodeTypeActionTransform), `{"transform_type": "expr", "expression": "trigger"}`)},
+		{name: "declared outputs override built-in", node: node(string(NodeTypeActionHTTP), `{}`, "items"), want: []string{"items"}},
+		{name: "unknown shape", node: node(string(NodeTypeActionCode), `{}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OutputSchema(tt.node))
+		})
+	}
+}
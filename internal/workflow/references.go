@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// credentialReferenceRegex matches ${credentials.name} and {{credentials.name}} references
+	credentialReferenceRegex = regexp.MustCompile(`(?:\$\{|\{\{)\s*credentials\.([a-zA-Z0-9_-]+)`)
+	// envReferenceRegex matches ${env.NAME} and {{env.NAME}} references
+	envReferenceRegex = regexp.MustCompile(`(?:\$\{|\{\{)\s*env\.([a-zA-Z0-9_]+)`)
+)
+
+// builtinEnvVars are supplied to every execution and never need configuring
+var builtinEnvVars = []string{"tenant_id", "workflow_id", "execution_id", "node_id"}
+
+// Reference kinds reported in ReferenceIssue.Kind
+const (
+	ReferenceKindEnv        = "env"
+	ReferenceKindCredential = "credential"
+)
+
+// ReferenceIssue is an env or credential reference in a workflow that will
+// not resolve when the workflow runs
+type ReferenceIssue struct {
+	NodeID  string `json:"node_id"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// CredentialChecker reports which of the named credentials a tenant has not configured
+type CredentialChecker interface {
+	MissingCredentials(ctx context.Context, tenantID string, names []string) ([]string, error)
+}
+
+// EnvChecker reports which of the named env variables will not resolve for a
+// workflow. An empty workflowID checks tenant-wide variables only.
+type EnvChecker interface {
+	MissingEnvVars(ctx context.Context, tenantID, workflowID string, names []string) ([]string, error)
+}
+
+// SetEnvChecker sets the checker used to verify env references on save.
+// Without one, every non-built-in env reference is reported.
+func (s *Service) SetEnvChecker(checker EnvChecker) {
+	s.envChecker = checker
+}
+
+// SetCredentialChecker sets the checker used to verify credential references
+// on save. Without one, credential references are not checked.
+func (s *Service) SetCredentialChecker(checker CredentialChecker) {
+	s.credentialChecker = checker
+}
+
+// SetStrictReferences makes create and update fail when a definition has
+// unresolved references instead of returning them as warnings
+func (s *Service) SetStrictReferences(strict bool) {
+	s.strictReferences = strict
+}
+
+// ValidateWorkflowReferences reports env and credential references in a
+// definition that will not resolve at execution time. Env references are
+// checked against the tenant's variables and, when workflowID is set, the
+// workflow's own; new workflows only see tenant-wide variables.
+func (s *Service) ValidateWorkflowReferences(ctx context.Context, tenantID, workflowID string, definition []byte) ([]ReferenceIssue, error) {
+	var def WorkflowDefinition
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return nil, &ValidationError{Message: "invalid workflow definition JSON"}
+	}
+
+	nodeCredentials := make([][]string, len(def.Nodes))
+	nodeEnv := make([][]string, len(def.Nodes))
+	var allCredentials, allEnv []string
+	for i, node := range def.Nodes {
+		nodeCredentials[i] = referencedNames(credentialReferenceRegex, node.Data.Config)
+		allCredentials = append(allCredentials, nodeCredentials[i]...)
+		for _, name := range referencedNames(envReferenceRegex, node.Data.Config) {
+			if !slices.Contains(builtinEnvVars, name) {
+				nodeEnv[i] = append(nodeEnv[i], name)
+			}
+		}
+		allEnv = append(allEnv, nodeEnv[i]...)
+	}
+
+	missingEnv := allEnv
+	if s.envChecker != nil && len(allEnv) > 0 {
+		slices.Sort(allEnv)
+		var err error
+		missingEnv, err = s.envChecker.MissingEnvVars(ctx, tenantID, workflowID, slices.Compact(allEnv))
+		if err != nil {
+			return nil, fmt.Errorf("check env references: %w", err)
+		}
+	}
+
+	var missing []string
+	if s.credentialChecker != nil && len(allCredentials) > 0 {
+		slices.Sort(allCredentials)
+		var err error
+		missing, err = s.credentialChecker.MissingCredentials(ctx, tenantID, slices.Compact(allCredentials))
+		if err != nil {
+			return nil, fmt.Errorf("check credential references: %w", err)
+		}
+	}
+
+	issues := []ReferenceIssue{}
+	for i, node := range def.Nodes {
+		for _, name := range nodeEnv[i] {
+			if !slices.Contains(missingEnv, name) {
+				continue
+			}
+			issues = append(issues, ReferenceIssue{
+				NodeID:  node.ID,
+				Kind:    ReferenceKindEnv,
+				Name:    name,
+				Message: fmt.Sprintf("env.%s is not set for workflow executions", name),
+			})
+		}
+		for _, name := range nodeCredentials[i] {
+			if !slices.Contains(missing, name) {
+				continue
+			}
+			issues = append(issues, ReferenceIssue{
+				NodeID:  node.ID,
+				Kind:    ReferenceKindCredential,
+				Name:    name,
+				Message: fmt.Sprintf("credential %q is not configured", name),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// checkReferences validates the references in a definition being saved. In
+// strict mode unresolved references fail the save; otherwise they are
+// returned as warnings and a failed credential or env lookup is only logged.
+func (s *Service) checkReferences(ctx context.Context, tenantID, workflowID string, definition []byte) ([]ReferenceIssue, error) {
+	issues, err := s.ValidateWorkflowReferences(ctx, tenantID, workflowID, definition)
+	if err != nil {
+		if s.strictReferences {
+			return nil, err
+		}
+		s.logger.Warn("failed to validate workflow references", "error", err, "tenant_id", tenantID)
+		return nil, nil
+	}
+
+	if len(issues) == 0 {
+		return nil, nil
+	}
+
+	if s.strictReferences {
+		messages := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			messages = append(messages, fmt.Sprintf("node %s: %s", issue.NodeID, issue.Message))
+		}
+		return nil, &ValidationError{Message: "unresolved references: " + strings.Join(messages, "; ")}
+	}
+
+	return issues, nil
+}
+
+// referencedNames returns the sorted, de-duplicated names matched by re in config
+func referencedNames(re *regexp.Regexp, config json.RawMessage) []string {
+	var names []string
+	for _, match := range re.FindAllStringSubmatch(string(config), -1) {
+		names = append(names, match[1])
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
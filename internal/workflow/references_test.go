@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCredentialChecker struct {
+	mock.Mock
+}
+
+func (m *mockCredentialChecker) MissingCredentials(ctx context.Context, tenantID string, names []string) ([]string, error) {
+	args := m.Called(ctx, tenantID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type mockEnvChecker struct {
+	mock.Mock
+}
+
+func (m *mockEnvChecker) MissingEnvVars(ctx context.Context, tenantID, workflowID string, names []string) ([]string, error) {
+	args := m.Called(ctx, tenantID, workflowID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+var referencingDefinition = json.RawMessage(`{
+	"nodes": [
+		{"id": "trigger", "type": "trigger:webhook", "data": {"config": {}}},
+		{"id": "call", "type": "action:http", "data": {"config": {
+			"url": "${env.API_URL}/items?tenant=${env.tenant_id}",
+			"headers": {"Authorization": "Bearer {{credentials.api-token}}"}
+		}}},
+		{"id": "notify", "type": "action:http", "data": {"config": {
+			"url": "{{ credentials.slack_webhook }}",
+			"body": "{{credentials.api-token}}"
+		}}}
+	],
+	"edges": [
+		{"id": "e1", "source": "trigger", "target": "call"},
+		{"id": "e2", "source": "call", "target": "notify"}
+	]
+}`)
+
+// TestValidateWorkflowReferences tests that unset env vars and unconfigured credentials are reported per node
+func TestValidateWorkflowReferences(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+	checker := new(mockCredentialChecker)
+	checker.On("MissingCredentials", ctx, "tenant-123", []string{"api-token", "slack_webhook"}).
+		Return([]string{"api-token"}, nil)
+	service.SetCredentialChecker(checker)
+
+	issues, err := service.ValidateWorkflowReferences(ctx, "tenant-123", "", referencingDefinition)
+
+	require.NoError(t, err)
+	assert.Equal(t, []ReferenceIssue{
+		{NodeID: "call", Kind: ReferenceKindEnv, Name: "API_URL", Message: "env.API_URL is not set for workflow executions"},
+		{NodeID: "call", Kind: ReferenceKindCredential, Name: "api-token", Message: `credential "api-token" is not configured`},
+		{NodeID: "notify", Kind: ReferenceKindCredential, Name: "api-token", Message: `credential "api-token" is not configured`},
+	}, issues)
+	checker.AssertExpectations(t)
+}
+
+// TestValidateWorkflowReferences_EnvChecker tests that configured env variables are not reported
+func TestValidateWorkflowReferences_EnvChecker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("configured variable resolves", func(t *testing.T) {
+		service, _ := newTestService()
+		checker := new(mockEnvChecker)
+		checker.On("MissingEnvVars", ctx, "tenant-123", "wf-1", []string{"API_URL"}).Return([]string(nil), nil)
+		service.SetEnvChecker(checker)
+
+		issues, err := service.ValidateWorkflowReferences(ctx, "tenant-123", "wf-1", referencingDefinition)
+
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+		checker.AssertExpectations(t)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		service, _ := newTestService()
+		checker := new(mockEnvChecker)
+		checker.On("MissingEnvVars", ctx, "tenant-123", "", mock.Anything).Return(nil, errors.New("db down"))
+		service.SetEnvChecker(checker)
+
+		_, err := service.ValidateWorkflowReferences(ctx, "tenant-123", "", referencingDefinition)
+
+		assert.ErrorContains(t, err, "check env references")
+	})
+}
+
+// TestCreate_ReferenceWarnings tests that unresolved references are warnings by default and errors in strict mode
+func TestCreate_ReferenceWarnings(t *testing.T) {
+	ctx := context.Background()
+	input := CreateWorkflowInput{Name: "refs", Definition: referencingDefinition}
+
+	t.Run("warning by default", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		mockRepo.On("Create", ctx, "tenant-123", "user-1", input).Return(&Workflow{ID: "wf-1"}, nil)
+
+		created, err := service.Create(ctx, "tenant-123", "user-1", input)
+
+		require.NoError(t, err)
+		require.Len(t, created.ReferenceWarnings, 1)
+		assert.Equal(t, "API_URL", created.ReferenceWarnings[0].Name)
+	})
+
+	t.Run("strict mode rejects", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		service.SetStrictReferences(true)
+
+		_, err := service.Create(ctx, "tenant-123", "user-1", input)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Message, "node call: env.API_URL is not set")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed credential lookup does not block save", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		checker := new(mockCredentialChecker)
+		checker.On("MissingCredentials", ctx, "tenant-123", mock.Anything).Return(nil, errors.New("db down"))
+		service.SetCredentialChecker(checker)
+		mockRepo.On("Create", ctx, "tenant-123", "user-1", input).Return(&Workflow{ID: "wf-1"}, nil)
+
+		created, err := service.Create(ctx, "tenant-123", "user-1", input)
+
+		require.NoError(t, err)
+		assert.Empty(t, created.ReferenceWarnings)
+	})
+}
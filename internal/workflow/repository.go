@@ -6,22 +6,34 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/gorax/gorax/internal/metrics"
+	"github.com/gorax/gorax/internal/pagination"
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
 )
 
 var (
 	ErrNotFound = errors.New("workflow not found")
+	// ErrExecutionNotCancellable is returned when an execution is no longer pending or running
+	ErrExecutionNotCancellable = errors.New("execution is not pending or running")
 )
 
+// ExecutionDBRouter picks the database a tenant's executions are stored in
+type ExecutionDBRouter interface {
+	For(ctx context.Context, tenantID string) (*sqlx.DB, error)
+}
+
 // Repository handles workflow database operations
 type Repository struct {
-	db      *sqlx.DB
-	metrics *metrics.Metrics
+	db         *sqlx.DB
+	metrics    *metrics.Metrics
+	executions ExecutionDBRouter
 }
 
 // NewRepository creates a new workflow repository
@@ -40,6 +52,27 @@ func NewRepositoryWithMetrics(db *sqlx.DB, m *metrics.Metrics) *Repository {
 	}
 }
 
+// SetExecutionDBRouter stores executions, their steps, idempotency keys and
+// dead letters in the database of each tenant's data region. Workflows stay
+// in the primary database.
+func (r *Repository) SetExecutionDBRouter(router ExecutionDBRouter) {
+	r.executions = router
+}
+
+// executionDB returns the database tenantID's executions are stored in. Methods
+// that look executions up by ID alone pass "" and use the tenant in ctx; with
+// data residency enabled, a ctx without a tenant is an error rather than a
+// silent read of the primary database.
+func (r *Repository) executionDB(ctx context.Context, tenantID string) (*sqlx.DB, error) {
+	if r.executions == nil {
+		return r.db, nil
+	}
+	if tenantID == "" {
+		tenantID = tenantctx.GetTenantID(ctx)
+	}
+	return r.executions.For(ctx, tenantID)
+}
+
 // recordQuery records database query metrics
 func (r *Repository) recordQuery(operation, table string, start time.Time, err error) {
 	if r.metrics != nil {
@@ -65,17 +98,22 @@ func (r *Repository) Create(ctx context.Context, tenantID, createdBy string, inp
 	start := time.Now()
 	id := uuid.New().String()
 	now := time.Now()
+	status := input.Status
+	if status == "" {
+		status = WorkflowStatusDraft
+	}
 
 	query := `
-		INSERT INTO workflows (id, tenant_id, name, description, definition, status, version, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO workflows (id, tenant_id, name, description, definition, status, version, created_by, created_at, updated_at, auto_pause_config, max_concurrency, on_failure_workflow_id, event_retention_days, concurrency_key, max_execution_duration)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12::int, 0), NULLIF($13::text, '')::uuid, NULLIF($14::int, 0), NULLIF($15::text, ''), NULLIF($16::int, 0))
 		RETURNING *
 	`
 
 	var workflow Workflow
 	err := r.db.QueryRowxContext(
 		ctx, query,
-		id, tenantID, input.Name, input.Description, input.Definition, "draft", 1, createdBy, now, now,
+		id, tenantID, input.Name, input.Description, input.Definition, string(status), 1, createdBy, now, now, input.AutoPause, input.MaxConcurrency,
+		input.OnFailureWorkflowID, input.EventRetentionDays, input.ConcurrencyKey, input.MaxExecutionDuration,
 	).StructScan(&workflow)
 
 	r.recordQuery("insert", "workflows", start, err)
@@ -129,7 +167,15 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 		    definition = COALESCE($5, definition),
 		    status = COALESCE(NULLIF($6, ''), status),
 		    version = $7,
-		    updated_at = $8
+		    updated_at = $8,
+		    auto_pause_config = COALESCE($9::jsonb, auto_pause_config),
+		    auto_paused_at = CASE WHEN $6 = 'active' THEN NULL ELSE auto_paused_at END,
+		    auto_pause_reason = CASE WHEN $6 = 'active' THEN NULL ELSE auto_pause_reason END,
+		    max_concurrency = CASE WHEN $10::int IS NULL THEN max_concurrency ELSE NULLIF($10::int, 0) END,
+		    on_failure_workflow_id = CASE WHEN $11::text IS NULL THEN on_failure_workflow_id ELSE NULLIF($11::text, '')::uuid END,
+		    event_retention_days = CASE WHEN $12::int IS NULL THEN event_retention_days ELSE NULLIF($12::int, 0) END,
+		    concurrency_key = CASE WHEN $13::text IS NULL THEN concurrency_key ELSE NULLIF($13::text, '') END,
+		    max_execution_duration = CASE WHEN $14::int IS NULL THEN max_execution_duration ELSE NULLIF($14::int, 0) END
 		WHERE id = $1 AND tenant_id = $2
 		RETURNING *
 	`
@@ -137,7 +183,8 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 	var workflow Workflow
 	err = r.db.QueryRowxContext(
 		ctx, query,
-		id, tenantID, input.Name, input.Description, input.Definition, input.Status, newVersion, time.Now(),
+		id, tenantID, input.Name, input.Description, input.Definition, input.Status, newVersion, time.Now(), input.AutoPause, input.MaxConcurrency,
+		input.OnFailureWorkflowID, input.EventRetentionDays, input.ConcurrencyKey, input.MaxExecutionDuration,
 	).StructScan(&workflow)
 
 	r.recordQuery("update", "workflows", start, err)
@@ -152,6 +199,56 @@ func (r *Repository) Update(ctx context.Context, tenantID, id string, input Upda
 	return &workflow, nil
 }
 
+// AutoPause deactivates an active workflow and records why. It returns false
+// if the workflow was no longer active, e.g. another worker paused it first.
+func (r *Repository) AutoPause(ctx context.Context, tenantID, id, reason string) (bool, error) {
+	start := time.Now()
+	query := `
+		UPDATE workflows
+		SET status = 'inactive',
+		    auto_paused_at = $3,
+		    auto_pause_reason = $4,
+		    updated_at = $3
+		WHERE id = $1 AND tenant_id = $2 AND status = 'active'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, tenantID, time.Now(), reason)
+	r.recordQuery("update", "workflows", start, err)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// CountExecutionOutcomes counts finished and failed executions of a workflow completed since the given time
+func (r *Repository) CountExecutionOutcomes(ctx context.Context, tenantID, workflowID string, since time.Time) (*ExecutionOutcomeCounts, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status IN ('completed', 'failed')) AS total,
+			COUNT(*) FILTER (WHERE status = 'failed') AS failed
+		FROM executions
+		WHERE tenant_id = $1 AND workflow_id = $2 AND completed_at >= $3
+	`
+
+	var counts ExecutionOutcomeCounts
+	err = db.GetContext(ctx, &counts, query, tenantID, workflowID, since)
+	r.recordQuery("select", "executions", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
 // Delete deletes a workflow (soft delete by setting status to 'archived')
 func (r *Repository) Delete(ctx context.Context, tenantID, id string) error {
 	start := time.Now()
@@ -210,6 +307,10 @@ func (r *Repository) Count(ctx context.Context, tenantID string) (int, error) {
 
 // CreateExecution creates a new execution record
 func (r *Repository) CreateExecution(ctx context.Context, tenantID, workflowID string, workflowVersion int, triggerType string, triggerData []byte) (*Execution, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	id := uuid.New().String()
 	now := time.Now()
@@ -223,15 +324,17 @@ func (r *Repository) CreateExecution(ctx context.Context, tenantID, workflowID s
 	}
 
 	query := `
-		INSERT INTO executions (id, tenant_id, workflow_id, workflow_version, status, trigger_type, trigger_data, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO executions (id, tenant_id, workflow_id, workflow_version, status, trigger_type, trigger_node_id,
+			trigger_data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)
 		RETURNING *
 	`
 
 	var execution Execution
-	err := r.db.QueryRowxContext(
+	err = db.QueryRowxContext(
 		ctx, query,
-		id, tenantID, workflowID, workflowVersion, "pending", triggerType, triggerDataParam, now,
+		id, tenantID, workflowID, workflowVersion, "pending", triggerType, TriggerNodeFromContext(ctx),
+		triggerDataParam, now,
 	).StructScan(&execution)
 
 	r.recordQuery("insert", "executions", start, err)
@@ -243,13 +346,76 @@ func (r *Repository) CreateExecution(ctx context.Context, tenantID, workflowID s
 	return &execution, nil
 }
 
+// CreateRetryExecution creates a pending execution that resumes original at
+// fromNode, copying the seed steps so their outputs are reused rather than run again
+func (r *Repository) CreateRetryExecution(ctx context.Context, original *Execution, workflowVersion int, fromNode string, seeds []*StepExecution) (*Execution, error) {
+	db, err := r.executionDB(ctx, original.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO executions (id, tenant_id, workflow_id, workflow_version, status, trigger_type, trigger_node_id,
+			trigger_data, retry_of_execution_id, resume_from_node_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING *
+	`
+
+	var execution Execution
+	err = tx.QueryRowxContext(
+		ctx, query,
+		uuid.New().String(), original.TenantID, original.WorkflowID, workflowVersion, ExecutionStatusPending,
+		original.TriggerType, original.TriggerNodeID, original.TriggerData, original.ID, fromNode, time.Now(),
+	).StructScan(&execution)
+	r.recordQuery("insert", "executions", start, err)
+	if err != nil {
+		return nil, fmt.Errorf("create retry execution: %w", err)
+	}
+
+	stepQuery := `
+		INSERT INTO step_executions (id, execution_id, node_id, node_type, status, input_data, output_data,
+			started_at, completed_at, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	for _, seed := range seeds {
+		_, err = tx.ExecContext(ctx, stepQuery,
+			uuid.New().String(), execution.ID, seed.NodeID, seed.NodeType, seed.Status,
+			seed.InputData, seed.OutputData, seed.StartedAt, seed.CompletedAt, seed.DurationMs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("copy step %s: %w", seed.NodeID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit retry execution: %w", err)
+	}
+
+	return &execution, nil
+}
+
 // GetExecutionByID retrieves an execution by ID
 func (r *Repository) GetExecutionByID(ctx context.Context, tenantID, id string) (*Execution, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	query := `SELECT * FROM executions WHERE id = $1 AND tenant_id = $2`
 
 	var execution Execution
-	err := r.db.GetContext(ctx, &execution, query, id, tenantID)
+	err = db.GetContext(ctx, &execution, query, id, tenantID)
 
 	r.recordQuery("select", "executions", start, err)
 
@@ -263,8 +429,14 @@ func (r *Repository) GetExecutionByID(ctx context.Context, tenantID, id string)
 	return &execution, nil
 }
 
-// UpdateExecutionStatus updates an execution's status
+// UpdateExecutionStatus updates an execution's status. A cancelled execution
+// keeps its status, so updates from an executor that has not yet noticed the
+// cancellation are ignored.
 func (r *Repository) UpdateExecutionStatus(ctx context.Context, id string, status ExecutionStatus, outputData []byte, errorMessage *string) error {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return err
+	}
 	start := time.Now()
 	now := time.Now()
 
@@ -272,7 +444,8 @@ func (r *Repository) UpdateExecutionStatus(ctx context.Context, id string, statu
 	if status == ExecutionStatusRunning {
 		startedAt = &now
 	}
-	if status == ExecutionStatusCompleted || status == ExecutionStatusFailed || status == ExecutionStatusCancelled {
+	if status == ExecutionStatusCompleted || status == ExecutionStatusFailed || status == ExecutionStatusCancelled ||
+		status == ExecutionStatusSkippedConcurrent {
 		completedAt = &now
 	}
 
@@ -291,18 +464,145 @@ func (r *Repository) UpdateExecutionStatus(ctx context.Context, id string, statu
 		    error_message = COALESCE($4, error_message),
 		    started_at = COALESCE($5, started_at),
 		    completed_at = COALESCE($6, completed_at)
-		WHERE id = $1
+		WHERE id = $1 AND status <> 'cancelled'
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id, status, outputDataParam, errorMessage, startedAt, completedAt)
+	_, err = db.ExecContext(ctx, query, id, status, outputDataParam, errorMessage, startedAt, completedAt)
 
 	r.recordQuery("update", "executions", start, err)
 
 	return err
 }
 
+// StartExecutionExclusive marks an execution as running while holding
+// concurrencyKey. It returns false without changing the execution if another
+// running execution in the tenant already holds the key.
+func (r *Repository) StartExecutionExclusive(ctx context.Context, tenantID, id, concurrencyKey string) (bool, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return false, err
+	}
+	start := time.Now()
+	query := `
+		UPDATE executions
+		SET status = 'running',
+		    concurrency_key = $3,
+		    started_at = COALESCE(started_at, $4)
+		WHERE id = $1 AND tenant_id = $2 AND status <> 'cancelled'
+	`
+
+	_, err = db.ExecContext(ctx, query, id, tenantID, concurrencyKey, time.Now())
+
+	r.recordQuery("update", "executions", start, err)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CancelExecution marks a pending or running execution as cancelled and records
+// who cancelled it. Executors check for the cancelled status between nodes, so
+// this also stops executions running on other workers.
+func (r *Repository) CancelExecution(ctx context.Context, tenantID, id, cancelledBy string) (*Execution, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	now := time.Now()
+	cancelMsg := "execution cancelled by user"
+
+	query := `
+		UPDATE executions
+		SET status = $3,
+		    error_message = $4,
+		    cancelled_by = $5,
+		    cancelled_at = $6,
+		    completed_at = $6
+		WHERE tenant_id = $1 AND id = $2 AND status IN ('pending', 'running')
+		RETURNING *
+	`
+
+	var execution Execution
+	err = db.QueryRowxContext(ctx, query, tenantID, id, ExecutionStatusCancelled, cancelMsg, cancelledBy, now).StructScan(&execution)
+
+	r.recordQuery("update", "executions", start, err)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExecutionNotCancellable
+		}
+		return nil, fmt.Errorf("cancel execution: %w", err)
+	}
+
+	return &execution, nil
+}
+
+// CheckpointExecution returns a running or waiting execution to pending so
+// another worker resumes it at resumeFromNodeID, reusing the steps that
+// completed. An empty resumeFromNodeID keeps the execution's current resume
+// node. It returns ErrNotFound when the execution has already finished.
+func (r *Repository) CheckpointExecution(ctx context.Context, id, resumeFromNodeID string) error {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+
+	query := `
+		UPDATE executions
+		SET status = $2,
+		    resume_from_node_id = COALESCE(NULLIF($3, ''), resume_from_node_id),
+		    checkpointed_at = $4,
+		    started_at = NULL
+		WHERE id = $1 AND status IN ('pending', 'running')
+	`
+
+	result, err := db.ExecContext(ctx, query, id, ExecutionStatusPending, resumeFromNodeID, time.Now())
+	r.recordQuery("update", "executions", start, err)
+	if err != nil {
+		return fmt.Errorf("checkpoint execution: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checkpoint execution: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// IsExecutionCancelled reports whether an execution has been cancelled
+func (r *Repository) IsExecutionCancelled(ctx context.Context, id string) (bool, error) {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return false, err
+	}
+	var status string
+	err = db.GetContext(ctx, &status, "SELECT status FROM executions WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrNotFound
+		}
+		return false, fmt.Errorf("get execution status: %w", err)
+	}
+
+	return status == string(ExecutionStatusCancelled), nil
+}
+
 // ListExecutions retrieves executions for a tenant with pagination
 func (r *Repository) ListExecutions(ctx context.Context, tenantID string, workflowID string, limit, offset int) ([]*Execution, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	var query string
 	var args []interface{}
 
@@ -325,7 +625,7 @@ func (r *Repository) ListExecutions(ctx context.Context, tenantID string, workfl
 	}
 
 	var executions []*Execution
-	err := r.db.SelectContext(ctx, &executions, query, args...)
+	err = db.SelectContext(ctx, &executions, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -333,8 +633,41 @@ func (r *Repository) ListExecutions(ctx context.Context, tenantID string, workfl
 	return executions, nil
 }
 
+// ListExecutionsPage retrieves executions for a tenant using keyset pagination.
+// An empty workflowID lists executions of every workflow.
+func (r *Repository) ListExecutionsPage(ctx context.Context, tenantID string, workflowID string, page pagination.Params) ([]*Execution, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT * FROM executions WHERE tenant_id = $1`
+	args := []interface{}{tenantID}
+
+	if workflowID != "" {
+		args = append(args, workflowID)
+		query += fmt.Sprintf(" AND workflow_id = $%d", len(args))
+	}
+
+	keyset, limitClause, args := page.Keyset("", args)
+	if keyset != "" {
+		query += " AND " + keyset
+	}
+	query += " ORDER BY created_at DESC, id DESC" + limitClause
+
+	var executions []*Execution
+	if err := db.SelectContext(ctx, &executions, query, args...); err != nil {
+		return nil, fmt.Errorf("list executions: %w", err)
+	}
+
+	return executions, nil
+}
+
 // CreateStepExecution creates a new step execution record
 func (r *Repository) CreateStepExecution(ctx context.Context, executionID, nodeID, nodeType string, inputData []byte) (*StepExecution, error) {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	id := uuid.New().String()
 	now := time.Now()
@@ -354,7 +687,7 @@ func (r *Repository) CreateStepExecution(ctx context.Context, executionID, nodeI
 	}
 
 	var stepExecution StepExecution
-	err := r.db.QueryRowxContext(
+	err = db.QueryRowxContext(
 		ctx, query,
 		id, executionID, nodeID, nodeType, "running", inputDataParam, now,
 	).StructScan(&stepExecution)
@@ -370,6 +703,10 @@ func (r *Repository) CreateStepExecution(ctx context.Context, executionID, nodeI
 
 // UpdateStepExecution updates a step execution with results
 func (r *Repository) UpdateStepExecution(ctx context.Context, id, status string, outputData []byte, errorMessage *string) error {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return err
+	}
 	start := time.Now()
 	now := time.Now()
 
@@ -391,15 +728,45 @@ func (r *Repository) UpdateStepExecution(ctx context.Context, id, status string,
 		WHERE id = $1
 	`
 
-	_, err := r.db.ExecContext(ctx, query, id, status, outputDataParam, errorMessage, now)
+	_, err = db.ExecContext(ctx, query, id, status, outputDataParam, errorMessage, now)
 
 	r.recordQuery("update", "step_executions", start, err)
 
 	return err
 }
 
+// UpdateExecutionTags merges tags into an execution's existing tags
+func (r *Repository) UpdateExecutionTags(ctx context.Context, id string, tags map[string]string) error {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	query := `
+		UPDATE executions
+		SET tags = COALESCE(tags, '{}'::jsonb) || $2::jsonb
+		WHERE id = $1
+	`
+
+	_, err = db.ExecContext(ctx, query, id, string(tagsJSON))
+
+	r.recordQuery("update", "executions", start, err)
+
+	return err
+}
+
 // GetStepExecutionsByExecutionID retrieves all step executions for an execution
 func (r *Repository) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*StepExecution, error) {
+	db, err := r.executionDB(ctx, "")
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT * FROM step_executions
 		WHERE execution_id = $1
@@ -407,7 +774,7 @@ func (r *Repository) GetStepExecutionsByExecutionID(ctx context.Context, executi
 	`
 
 	var stepExecutions []*StepExecution
-	err := r.db.SelectContext(ctx, &stepExecutions, query, executionID)
+	err = db.SelectContext(ctx, &stepExecutions, query, executionID)
 	if err != nil {
 		return nil, err
 	}
@@ -461,6 +828,15 @@ func (r *Repository) buildExecutionFilterQuery(filter ExecutionFilter, args []in
 		args = append(args, filter.ExecutionIDPrefix+"%")
 	}
 
+	if len(filter.Tags) > 0 {
+		tagsJSON, err := json.Marshal(filter.Tags)
+		if err == nil {
+			argIndex++
+			conditions = append(conditions, fmt.Sprintf("tags @> $%d::jsonb", argIndex))
+			args = append(args, string(tagsJSON))
+		}
+	}
+
 	if filter.MinDurationMs != nil {
 		argIndex++
 		conditions = append(conditions, fmt.Sprintf("(EXTRACT(EPOCH FROM (completed_at - started_at)) * 1000) >= $%d", argIndex))
@@ -495,6 +871,10 @@ func joinConditions(conditions []string) string {
 
 // ListExecutionsAdvanced retrieves executions with advanced filtering and cursor-based pagination
 func (r *Repository) ListExecutionsAdvanced(ctx context.Context, tenantID string, filter ExecutionFilter, cursor string, limit int) (*ExecutionListResult, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
 	if err := filter.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid filter: %w", err)
 	}
@@ -538,7 +918,7 @@ func (r *Repository) ListExecutionsAdvanced(ctx context.Context, tenantID string
 	`, cursorCondition, filterConditions, limit+1) // Fetch one extra to check if there are more
 
 	var executions []*Execution
-	err := r.db.SelectContext(ctx, &executions, query, args...)
+	err = db.SelectContext(ctx, &executions, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list executions: %w", err)
 	}
@@ -574,6 +954,86 @@ func (r *Repository) ListExecutionsAdvanced(ctx context.Context, tenantID string
 	}, nil
 }
 
+// SearchExecutions finds executions whose error message contains the filter
+// query, newest first, with the total number of matches for pagination
+func (r *Repository) SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	args := []interface{}{tenantID}
+	var conditions []string
+
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLikePattern(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("error_message ILIKE $%d", len(args)))
+	}
+
+	if filter.WorkflowID != "" {
+		args = append(args, filter.WorkflowID)
+		conditions = append(conditions, fmt.Sprintf("workflow_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", len(args)))
+	}
+
+	whereClause := "tenant_id = $1"
+	if len(conditions) > 0 {
+		whereClause += " AND " + joinConditions(conditions)
+	}
+
+	var totalCount int
+	countQuery := "SELECT COUNT(*) FROM executions WHERE " + whereClause
+	if err := db.GetContext(ctx, &totalCount, countQuery, args...); err != nil {
+		return nil, fmt.Errorf("count execution search results: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM executions
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	executions := []*Execution{}
+	err = db.SelectContext(ctx, &executions, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("search executions: %w", err)
+	}
+
+	return &ExecutionSearchResult{
+		Data:       executions,
+		TotalCount: totalCount,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}, nil
+}
+
+// escapeLikePattern escapes LIKE wildcards so the input is matched literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 // GetExecutionWithSteps retrieves an execution with all its step executions
 func (r *Repository) GetExecutionWithSteps(ctx context.Context, tenantID, executionID string) (*ExecutionWithSteps, error) {
 	execution, err := r.GetExecutionByID(ctx, tenantID, executionID)
@@ -581,7 +1041,7 @@ func (r *Repository) GetExecutionWithSteps(ctx context.Context, tenantID, execut
 		return nil, fmt.Errorf("get execution: %w", err)
 	}
 
-	steps, err := r.GetStepExecutionsByExecutionID(ctx, executionID)
+	steps, err := r.GetStepExecutionsByExecutionID(tenantctx.WithTenantID(ctx, tenantID), executionID)
 	if err != nil {
 		return nil, fmt.Errorf("get step executions: %w", err)
 	}
@@ -594,6 +1054,10 @@ func (r *Repository) GetExecutionWithSteps(ctx context.Context, tenantID, execut
 
 // CountExecutions returns the total count of executions matching the filter
 func (r *Repository) CountExecutions(ctx context.Context, tenantID string, filter ExecutionFilter) (int, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
 	if err := filter.Validate(); err != nil {
 		return 0, fmt.Errorf("invalid filter: %w", err)
 	}
@@ -607,7 +1071,7 @@ func (r *Repository) CountExecutions(ctx context.Context, tenantID string, filte
 	`, filterConditions)
 
 	var count int
-	err := r.db.GetContext(ctx, &count, query, args...)
+	err = db.GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("count executions: %w", err)
 	}
@@ -693,3 +1157,249 @@ func (r *Repository) RestoreWorkflowVersion(ctx context.Context, tenantID, workf
 
 	return updatedWorkflow, nil
 }
+
+// ClaimIdempotencyKey records an idempotency key for a tenant until now + ttl.
+// When the key is already recorded and has not expired, it returns the
+// existing record and false. An expired key is claimed again.
+func (r *Repository) ClaimIdempotencyKey(ctx context.Context, tenantID, key, requestHash string, ttl time.Duration) (*IdempotencyKey, bool, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+
+	query := `
+		INSERT INTO execution_idempotency_keys (tenant_id, idempotency_key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, execution_id = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE execution_idempotency_keys.expires_at <= EXCLUDED.created_at
+		RETURNING *
+	`
+
+	var record IdempotencyKey
+	err = db.QueryRowxContext(ctx, query, tenantID, key, requestHash, now, now.Add(ttl)).StructScan(&record)
+	if err == nil {
+		return &record, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	err = db.GetContext(ctx, &record,
+		`SELECT * FROM execution_idempotency_keys WHERE tenant_id = $1 AND idempotency_key = $2`,
+		tenantID, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("get idempotency key: %w", err)
+	}
+
+	return &record, false, nil
+}
+
+// CompleteIdempotencyKey links a claimed idempotency key to the execution it created
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, tenantID, key, executionID string) error {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE execution_idempotency_keys
+		SET execution_id = $3
+		WHERE tenant_id = $1 AND idempotency_key = $2
+	`
+
+	if _, err := db.ExecContext(ctx, query, tenantID, key, executionID); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a claimed idempotency key
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM execution_idempotency_keys WHERE tenant_id = $1 AND idempotency_key = $2`
+
+	if _, err := db.ExecContext(ctx, query, tenantID, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// CreateDeadLetter records a failed execution in the dead-letter queue. An
+// execution has at most one entry; recording it again updates the failure.
+func (r *Repository) CreateDeadLetter(ctx context.Context, execution *Execution, failure ExecutionFailure) (*DeadLetter, error) {
+	db, err := r.executionDB(ctx, execution.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	query := `
+		INSERT INTO dead_letter_executions (id, tenant_id, workflow_id, execution_id, workflow_version, trigger_type,
+			payload, failure_reason, failed_node_id, failed_node_type, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11, $12)
+		ON CONFLICT (execution_id) DO UPDATE
+		SET failure_reason = EXCLUDED.failure_reason,
+			failed_node_id = EXCLUDED.failed_node_id,
+			failed_node_type = EXCLUDED.failed_node_type
+		RETURNING *
+	`
+
+	var entry DeadLetter
+	err = db.QueryRowxContext(
+		ctx, query,
+		uuid.New().String(), execution.TenantID, execution.WorkflowID, execution.ID, execution.WorkflowVersion, execution.TriggerType,
+		execution.TriggerData, failure.Reason, failure.NodeID, failure.NodeType, DeadLetterStatusPending, time.Now(),
+	).StructScan(&entry)
+	r.recordQuery("insert", "dead_letter_executions", start, err)
+	if err != nil {
+		return nil, fmt.Errorf("create dead-letter entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetDeadLetter retrieves a dead-letter entry by ID (tenant-scoped)
+func (r *Repository) GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetter, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	query := `SELECT * FROM dead_letter_executions WHERE id = $1 AND tenant_id = $2`
+
+	var entry DeadLetter
+	err = db.GetContext(ctx, &entry, query, id, tenantID)
+	r.recordQuery("select", "dead_letter_executions", start, err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// ListDeadLetters returns a page of dead-letter entries, newest first, with the total match count
+func (r *Repository) ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error) {
+	db, err := r.executionDB(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	args := []interface{}{tenantID}
+	var conditions []string
+
+	if filter.WorkflowID != "" {
+		args = append(args, filter.WorkflowID)
+		conditions = append(conditions, fmt.Sprintf("workflow_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	whereClause := "tenant_id = $1"
+	if len(conditions) > 0 {
+		whereClause += " AND " + joinConditions(conditions)
+	}
+
+	var totalCount int
+	countQuery := "SELECT COUNT(*) FROM dead_letter_executions WHERE " + whereClause
+	if err := db.GetContext(ctx, &totalCount, countQuery, args...); err != nil {
+		return nil, fmt.Errorf("count dead-letter entries: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM dead_letter_executions
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	entries := []*DeadLetter{}
+	err = db.SelectContext(ctx, &entries, query, append(args, filter.Limit, filter.Offset)...)
+	r.recordQuery("select", "dead_letter_executions", start, err)
+	if err != nil {
+		return nil, fmt.Errorf("list dead-letter entries: %w", err)
+	}
+
+	return &DeadLetterListResult{
+		Data:       entries,
+		TotalCount: totalCount,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}, nil
+}
+
+// RequeueDeadLetter creates a pending execution with the entry's payload and
+// marks the entry requeued in one transaction. It returns
+// ErrDeadLetterRequeued if another request requeued the entry first.
+func (r *Repository) RequeueDeadLetter(ctx context.Context, entry *DeadLetter, workflowVersion int) (*Execution, error) {
+	db, err := r.executionDB(ctx, entry.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := time.Now()
+	var execution Execution
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO executions (id, tenant_id, workflow_id, workflow_version, status, trigger_type, trigger_node_id,
+			trigger_data, retry_of_execution_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6,
+			(SELECT trigger_node_id FROM executions WHERE id = $8 AND tenant_id = $2), $7, $8, $9)
+		RETURNING *
+	`,
+		uuid.New().String(), entry.TenantID, entry.WorkflowID, workflowVersion, ExecutionStatusPending,
+		entry.TriggerType, entry.Payload, entry.ExecutionID, now,
+	).StructScan(&execution)
+	r.recordQuery("insert", "executions", start, err)
+	if err != nil {
+		return nil, fmt.Errorf("create requeued execution: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE dead_letter_executions
+		SET status = $3, requeued_execution_id = $4, requeued_at = $5
+		WHERE id = $1 AND tenant_id = $2 AND status = $6
+	`, entry.ID, entry.TenantID, DeadLetterStatusRequeued, execution.ID, now, DeadLetterStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("mark dead-letter entry requeued: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		err = ErrDeadLetterRequeued
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit requeued execution: %w", err)
+	}
+
+	return &execution, nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+	"github.com/gorax/gorax/internal/residency"
+)
+
+type stubExecutionDBRouter struct {
+	dbs     map[string]*sqlx.DB
+	err     error
+	tenants []string
+}
+
+func (s *stubExecutionDBRouter) For(ctx context.Context, tenantID string) (*sqlx.DB, error) {
+	s.tenants = append(s.tenants, tenantID)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.dbs[tenantID], nil
+}
+
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestRepository_ExecutionDBRouter(t *testing.T) {
+	t.Run("queries the tenant's regional database", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		regional, regionalMock := newMockDB(t)
+		router := &stubExecutionDBRouter{dbs: map[string]*sqlx.DB{"tenant-eu": regional}}
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(router)
+
+		regionalMock.ExpectQuery(`SELECT \* FROM executions WHERE id = \$1 AND tenant_id = \$2`).
+			WithArgs("exec-1", "tenant-eu").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "status"}).AddRow("exec-1", "tenant-eu", "running"))
+
+		execution, err := repo.GetExecutionByID(context.Background(), "tenant-eu", "exec-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "exec-1", execution.ID)
+		assert.NoError(t, regionalMock.ExpectationsWereMet())
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+
+	t.Run("lookups by execution ID use the tenant in context", func(t *testing.T) {
+		primary, _ := newMockDB(t)
+		regional, regionalMock := newMockDB(t)
+		router := &stubExecutionDBRouter{dbs: map[string]*sqlx.DB{"tenant-eu": regional}}
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(router)
+
+		regionalMock.ExpectQuery(`SELECT status FROM executions WHERE id = \$1`).
+			WithArgs("exec-1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("cancelled"))
+
+		cancelled, err := repo.IsExecutionCancelled(tenantctx.WithTenantID(context.Background(), "tenant-eu"), "exec-1")
+
+		require.NoError(t, err)
+		assert.True(t, cancelled)
+		assert.Equal(t, []string{"tenant-eu"}, router.tenants)
+		assert.NoError(t, regionalMock.ExpectationsWereMet())
+	})
+
+	t.Run("routing errors are returned without querying", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		routeErr := errors.New("region not configured")
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(&stubExecutionDBRouter{err: routeErr})
+
+		_, err := repo.CreateExecution(context.Background(), "tenant-eu", "wf-1", 1, "manual", nil)
+
+		assert.ErrorIs(t, err, routeErr)
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+
+	t.Run("lookups by execution ID without a tenant fail", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		regional, regionalMock := newMockDB(t)
+		router := residency.NewRouter(residency.NewResolver(nil, time.Minute), residency.ExecutionStore, primary, map[string]*sqlx.DB{"eu": regional})
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(router)
+
+		err := repo.UpdateExecutionStatus(context.Background(), "exec-1", ExecutionStatusCompleted, nil, nil)
+
+		assert.ErrorIs(t, err, residency.ErrTenantRequired)
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+		assert.NoError(t, regionalMock.ExpectationsWereMet())
+	})
+
+	t.Run("workflows stay in the primary database", func(t *testing.T) {
+		primary, primaryMock := newMockDB(t)
+		router := &stubExecutionDBRouter{}
+		repo := NewRepository(primary)
+		repo.SetExecutionDBRouter(router)
+
+		primaryMock.ExpectQuery(`SELECT COUNT\(\*\) FROM workflows`).
+			WithArgs("tenant-eu").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.Count(context.Background(), "tenant-eu")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Empty(t, router.tenants)
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+	})
+}
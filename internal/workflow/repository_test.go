@@ -994,3 +994,10 @@ func stringPtr(s string) *string {
 func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+// TestEscapeLikePattern tests that LIKE wildcards in search text are matched literally
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, "connection refused", escapeLikePattern("connection refused"))
+	assert.Equal(t, `100\% of quota\_used`, escapeLikePattern("100% of quota_used"))
+	assert.Equal(t, `C:\\\\tmp`, escapeLikePattern(`C:\\tmp`))
+}
@@ -0,0 +1,183 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorax/gorax/internal/pkg/tenantctx"
+)
+
+// ResumePlan describes which nodes a retried execution reuses from the
+// original execution and which it runs again
+type ResumePlan struct {
+	FromNode string
+	// Rerun holds fromNode and every node downstream of it
+	Rerun map[string]bool
+	// Upstream holds the non-trigger nodes fromNode depends on, which must
+	// have completed in the original execution
+	Upstream []string
+}
+
+// PlanResume validates that fromNode exists, is not a trigger and is
+// reachable from a trigger, and works out which nodes must run again
+func PlanResume(definition *WorkflowDefinition, fromNode string) (*ResumePlan, error) {
+	nodes := make(map[string]Node, len(definition.Nodes))
+	for _, node := range definition.Nodes {
+		nodes[node.ID] = node
+	}
+
+	target, ok := nodes[fromNode]
+	if !ok {
+		return nil, &ValidationError{Message: fmt.Sprintf("node %s not found in workflow", fromNode)}
+	}
+	if isTriggerNodeType(target.Type) {
+		return nil, &ValidationError{Message: fmt.Sprintf("node %s is a trigger; retry the whole execution instead", fromNode)}
+	}
+
+	outgoing := make(map[string][]string)
+	incoming := make(map[string][]string)
+	for _, edge := range definition.Edges {
+		outgoing[edge.Source] = append(outgoing[edge.Source], edge.Target)
+		incoming[edge.Target] = append(incoming[edge.Target], edge.Source)
+	}
+
+	var triggers []string
+	for _, node := range definition.Nodes {
+		if isTriggerNodeType(node.Type) {
+			triggers = append(triggers, node.ID)
+		}
+	}
+	if !walkGraph(triggers, outgoing)[fromNode] {
+		return nil, &ValidationError{Message: fmt.Sprintf("node %s is not reachable from a trigger", fromNode)}
+	}
+
+	plan := &ResumePlan{
+		FromNode: fromNode,
+		Rerun:    walkGraph([]string{fromNode}, outgoing),
+	}
+
+	// Preserve definition order so validation errors are deterministic
+	ancestors := walkGraph(incoming[fromNode], incoming)
+	for _, node := range definition.Nodes {
+		if ancestors[node.ID] && !plan.Rerun[node.ID] && !isTriggerNodeType(node.Type) {
+			plan.Upstream = append(plan.Upstream, node.ID)
+		}
+	}
+
+	return plan, nil
+}
+
+// SeedSteps picks the step executions a retried execution reuses: the latest
+// completed step of every node that is not run again. Each upstream node of
+// the plan must have one.
+func (p *ResumePlan) SeedSteps(steps []*StepExecution) ([]*StepExecution, error) {
+	latest := make(map[string]*StepExecution)
+	var order []string
+	for _, step := range steps {
+		if step.Status != "completed" || p.Rerun[step.NodeID] {
+			continue
+		}
+		if _, seen := latest[step.NodeID]; !seen {
+			order = append(order, step.NodeID)
+		}
+		latest[step.NodeID] = step
+	}
+
+	for _, nodeID := range p.Upstream {
+		if _, ok := latest[nodeID]; !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("node %s did not complete in the original execution", nodeID)}
+		}
+	}
+
+	seeds := make([]*StepExecution, 0, len(order))
+	for _, nodeID := range order {
+		seeds = append(seeds, latest[nodeID])
+	}
+	return seeds, nil
+}
+
+// walkGraph returns every node reachable from the start nodes, including them
+func walkGraph(start []string, adjacency map[string][]string) map[string]bool {
+	visited := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		nodeID := queue[0]
+		queue = queue[1:]
+		if visited[nodeID] {
+			continue
+		}
+		visited[nodeID] = true
+		queue = append(queue, adjacency[nodeID]...)
+	}
+	return visited
+}
+
+// isTriggerNodeType reports whether a node type is a trigger
+func isTriggerNodeType(nodeType string) bool {
+	return nodeType == string(NodeTypeTriggerWebhook) || nodeType == string(NodeTypeTriggerSchedule)
+}
+
+// RetryExecution starts a new execution that resumes a failed or cancelled
+// execution at fromNode. Outputs of the nodes before fromNode are copied from
+// the original execution, so only fromNode and the nodes after it run again.
+func (s *Service) RetryExecution(ctx context.Context, tenantID, executionID, fromNode string) (*Execution, error) {
+	original, err := s.repo.GetExecutionByID(ctx, tenantID, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if original.Status != string(ExecutionStatusFailed) && original.Status != string(ExecutionStatusCancelled) {
+		return nil, &ValidationError{Message: fmt.Sprintf("cannot retry execution in %s state", original.Status)}
+	}
+
+	wf, err := s.repo.GetByID(ctx, tenantID, original.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wf.Status != string(WorkflowStatusActive) {
+		return nil, &ValidationError{Message: "workflow must be active to execute"}
+	}
+
+	var definition WorkflowDefinition
+	if err := json.Unmarshal(wf.Definition, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+	}
+
+	plan, err := PlanResume(&definition, fromNode)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := s.repo.GetStepExecutionsByExecutionID(tenantctx.WithTenantID(ctx, original.TenantID), original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load step executions: %w", err)
+	}
+
+	seeds, err := plan.SeedSteps(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := s.repo.CreateRetryExecution(ctx, original, wf.Version, fromNode, seeds)
+	if err != nil {
+		s.logger.Error("failed to create retry execution", "error", err, "execution_id", executionID)
+		return nil, err
+	}
+
+	s.logger.Info("execution retry created",
+		"execution_id", execution.ID,
+		"retry_of", original.ID,
+		"from_node", fromNode,
+		"reused_steps", len(seeds),
+	)
+
+	var triggerData []byte
+	if original.TriggerData != nil {
+		triggerData = *original.TriggerData
+	}
+	s.dispatchExecution(ctx, execution, wf.Version, triggerData)
+
+	return execution, nil
+}
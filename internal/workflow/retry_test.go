@@ -0,0 +1,136 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// retryTestDefinition is trigger -> fetch -> transform -> notify, plus an
+// orphan node that no trigger reaches
+func retryTestDefinition() *WorkflowDefinition {
+	return &WorkflowDefinition{
+		Nodes: []Node{
+			{ID: "trigger-1", Type: string(NodeTypeTriggerWebhook)},
+			{ID: "fetch", Type: string(NodeTypeActionHTTP)},
+			{ID: "transform", Type: string(NodeTypeActionTransform)},
+			{ID: "notify", Type: string(NodeTypeActionHTTP)},
+			{ID: "orphan", Type: string(NodeTypeActionHTTP)},
+		},
+		Edges: []Edge{
+			{ID: "e1", Source: "trigger-1", Target: "fetch"},
+			{ID: "e2", Source: "fetch", Target: "transform"},
+			{ID: "e3", Source: "transform", Target: "notify"},
+		},
+	}
+}
+
+// TestPlanResume tests validation of the resume node and the nodes selected to run again
+func TestPlanResume(t *testing.T) {
+	t.Run("downstream nodes rerun", func(t *testing.T) {
+		plan, err := PlanResume(retryTestDefinition(), "transform")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"transform": true, "notify": true}, plan.Rerun)
+		assert.Equal(t, []string{"fetch"}, plan.Upstream)
+	})
+
+	tests := []struct {
+		name     string
+		fromNode string
+		errMsg   string
+	}{
+		{name: "unknown node", fromNode: "missing", errMsg: "not found"},
+		{name: "trigger node", fromNode: "trigger-1", errMsg: "is a trigger"},
+		{name: "unreachable node", fromNode: "orphan", errMsg: "not reachable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanResume(retryTestDefinition(), tt.fromNode)
+
+			assert.Nil(t, plan)
+			require.IsType(t, &ValidationError{}, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+// TestResumePlan_SeedSteps tests that the latest completed upstream steps are reused
+func TestResumePlan_SeedSteps(t *testing.T) {
+	plan, err := PlanResume(retryTestDefinition(), "transform")
+	require.NoError(t, err)
+
+	t.Run("reuses latest completed step", func(t *testing.T) {
+		steps := []*StepExecution{
+			{ID: "s1", NodeID: "fetch", Status: "failed"},
+			{ID: "s2", NodeID: "fetch", Status: "completed"},
+			{ID: "s3", NodeID: "transform", Status: "failed"},
+		}
+
+		seeds, err := plan.SeedSteps(steps)
+
+		require.NoError(t, err)
+		require.Len(t, seeds, 1)
+		assert.Equal(t, "s2", seeds[0].ID)
+	})
+
+	t.Run("missing upstream step", func(t *testing.T) {
+		steps := []*StepExecution{
+			{ID: "s1", NodeID: "fetch", Status: "failed"},
+		}
+
+		seeds, err := plan.SeedSteps(steps)
+
+		assert.Nil(t, seeds)
+		require.IsType(t, &ValidationError{}, err)
+		assert.Contains(t, err.Error(), "fetch")
+	})
+}
+
+// TestRetryExecution_Success tests that a failed execution is retried with its upstream steps
+func TestRetryExecution_Success(t *testing.T) {
+	service, mockRepo := newTestService()
+	ctx := context.Background()
+
+	definition, err := json.Marshal(retryTestDefinition())
+	require.NoError(t, err)
+
+	original := &Execution{ID: "exec-1", TenantID: "tenant-123", WorkflowID: "wf-1", Status: string(ExecutionStatusFailed)}
+	fetch := &StepExecution{ID: "s1", NodeID: "fetch", Status: "completed"}
+	retry := &Execution{ID: "exec-2", TenantID: "tenant-123", WorkflowID: "wf-1", Status: string(ExecutionStatusPending)}
+
+	mockRepo.On("GetExecutionByID", ctx, "tenant-123", "exec-1").Return(original, nil)
+	mockRepo.On("GetByID", ctx, "tenant-123", "wf-1").Return(&Workflow{
+		ID: "wf-1", Status: string(WorkflowStatusActive), Definition: definition, Version: 3,
+	}, nil)
+	mockRepo.On("GetStepExecutionsByExecutionID", mock.Anything, "exec-1").Return([]*StepExecution{
+		fetch,
+		{ID: "s2", NodeID: "transform", Status: "failed"},
+	}, nil)
+	mockRepo.On("CreateRetryExecution", ctx, original, 3, "transform", []*StepExecution{fetch}).Return(retry, nil)
+
+	result, err := service.RetryExecution(ctx, "tenant-123", "exec-1", "transform")
+
+	require.NoError(t, err)
+	assert.Equal(t, retry, result)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestRetryExecution_NotRetryable tests that only failed or cancelled executions can be retried
+func TestRetryExecution_NotRetryable(t *testing.T) {
+	service, mockRepo := newTestService()
+	ctx := context.Background()
+
+	mockRepo.On("GetExecutionByID", ctx, "tenant-123", "exec-1").Return(&Execution{ID: "exec-1", Status: string(ExecutionStatusRunning)}, nil)
+
+	result, err := service.RetryExecution(ctx, "tenant-123", "exec-1", "transform")
+
+	assert.Nil(t, result)
+	assert.IsType(t, &ValidationError{}, err)
+	mockRepo.AssertNotCalled(t, "CreateRetryExecution", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -3,10 +3,14 @@ package workflow
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // WorkflowExecutor interface to avoid circular dependencies
@@ -14,6 +18,18 @@ type WorkflowExecutor interface {
 	Execute(ctx context.Context, execution *Execution) error
 }
 
+// ExecutionCanceller is implemented by executors that can stop executions
+// running in this process
+type ExecutionCanceller interface {
+	Cancel(executionID string) bool
+}
+
+// Simulator is implemented by executors that can run a workflow in memory
+// with canned node outputs, without persisting or calling out to anything
+type Simulator interface {
+	Simulate(ctx context.Context, wf *Workflow, triggerData []byte, mocks map[string]interface{}) (*SimulationResult, error)
+}
+
 // QueuePublisher interface for publishing execution messages
 type QueuePublisher interface {
 	PublishExecution(ctx context.Context, msg interface{}) error
@@ -29,24 +45,41 @@ type RepositoryInterface interface {
 	CreateExecution(ctx context.Context, tenantID, workflowID string, workflowVersion int, triggerType string, triggerData []byte) (*Execution, error)
 	GetExecutionByID(ctx context.Context, tenantID, id string) (*Execution, error)
 	UpdateExecutionStatus(ctx context.Context, id string, status ExecutionStatus, outputData []byte, errorMessage *string) error
+	CancelExecution(ctx context.Context, tenantID, id, cancelledBy string) (*Execution, error)
 	GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*StepExecution, error)
+	CreateRetryExecution(ctx context.Context, original *Execution, workflowVersion int, fromNode string, seeds []*StepExecution) (*Execution, error)
+	ClaimIdempotencyKey(ctx context.Context, tenantID, key, requestHash string, ttl time.Duration) (*IdempotencyKey, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, tenantID, key, executionID string) error
+	ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error
 	ListExecutions(ctx context.Context, tenantID string, workflowID string, limit, offset int) ([]*Execution, error)
+	ListExecutionsPage(ctx context.Context, tenantID string, workflowID string, page pagination.Params) ([]*Execution, error)
 	ListExecutionsAdvanced(ctx context.Context, tenantID string, filter ExecutionFilter, cursor string, limit int) (*ExecutionListResult, error)
 	GetExecutionWithSteps(ctx context.Context, tenantID, executionID string) (*ExecutionWithSteps, error)
 	CountExecutions(ctx context.Context, tenantID string, filter ExecutionFilter) (int, error)
+	SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error)
 	CreateWorkflowVersion(ctx context.Context, workflowID string, version int, definition json.RawMessage, createdBy string) (*WorkflowVersion, error)
 	ListWorkflowVersions(ctx context.Context, workflowID string) ([]*WorkflowVersion, error)
 	GetWorkflowVersion(ctx context.Context, workflowID string, version int) (*WorkflowVersion, error)
 	RestoreWorkflowVersion(ctx context.Context, tenantID, workflowID string, version int) (*Workflow, error)
+	CreateDeadLetter(ctx context.Context, execution *Execution, failure ExecutionFailure) (*DeadLetter, error)
+	GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetter, error)
+	ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error)
+	RequeueDeadLetter(ctx context.Context, entry *DeadLetter, workflowVersion int) (*Execution, error)
 }
 
 // Service handles workflow business logic
 type Service struct {
-	repo           RepositoryInterface
-	executor       WorkflowExecutor
-	webhookService WebhookService
-	queuePublisher QueuePublisher
-	logger         *slog.Logger
+	repo              RepositoryInterface
+	executor          WorkflowExecutor
+	webhookService    WebhookService
+	queuePublisher    QueuePublisher
+	credentialChecker CredentialChecker
+	envChecker        EnvChecker
+	eventTypes        EventTypeRegistry
+	strictReferences  bool
+	// httpMaxResponseBytes is the ceiling for an HTTP node's max_response_bytes
+	httpMaxResponseBytes int64
+	logger               *slog.Logger
 }
 
 // NewService creates a new workflow service
@@ -72,6 +105,12 @@ func (s *Service) SetQueuePublisher(publisher QueuePublisher) {
 	s.queuePublisher = publisher
 }
 
+// SetHTTPMaxResponseBytes sets the response body limit HTTP nodes may not
+// exceed. Without one, max_response_bytes is not checked against a ceiling.
+func (s *Service) SetHTTPMaxResponseBytes(limit int64) {
+	s.httpMaxResponseBytes = limit
+}
+
 // Create creates a new workflow
 func (s *Service) Create(ctx context.Context, tenantID, userID string, input CreateWorkflowInput) (*Workflow, error) {
 	// Validate definition structure
@@ -79,11 +118,46 @@ func (s *Service) Create(ctx context.Context, tenantID, userID string, input Cre
 		return nil, err
 	}
 
+	if err := input.AutoPause.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxConcurrency(input.MaxConcurrency); err != nil {
+		return nil, err
+	}
+
+	if err := validateConcurrencyKey(input.ConcurrencyKey); err != nil {
+		return nil, err
+	}
+
+	if err := validateEventRetentionDays(input.EventRetentionDays); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxExecutionDuration(input.MaxExecutionDuration); err != nil {
+		return nil, err
+	}
+
+	if input.Status != "" && input.Status != WorkflowStatusDraft && input.Status != WorkflowStatusActive {
+		return nil, &ValidationError{Message: fmt.Sprintf("workflows cannot be created with status %q", input.Status)}
+	}
+
+	if err := s.validateOnFailureWorkflow(ctx, tenantID, "", input.OnFailureWorkflowID); err != nil {
+		return nil, err
+	}
+
+	referenceIssues, err := s.checkReferences(ctx, tenantID, "", input.Definition)
+	if err != nil {
+		return nil, err
+	}
+
 	workflow, err := s.repo.Create(ctx, tenantID, userID, input)
 	if err != nil {
 		s.logger.Error("failed to create workflow", "error", err, "tenant_id", tenantID)
 		return nil, err
 	}
+	workflow.ReferenceWarnings = referenceIssues
+	workflow.LintWarnings = lintDefinitionJSON(input.Definition)
 
 	// Sync webhooks if webhook service is available
 	if s.webhookService != nil {
@@ -114,11 +188,48 @@ func (s *Service) Update(ctx context.Context, tenantID, id string, input UpdateW
 		}
 	}
 
+	if err := input.AutoPause.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxConcurrency(input.MaxConcurrency); err != nil {
+		return nil, err
+	}
+
+	if err := validateConcurrencyKey(input.ConcurrencyKey); err != nil {
+		return nil, err
+	}
+
+	if err := validateEventRetentionDays(input.EventRetentionDays); err != nil {
+		return nil, err
+	}
+
+	if err := validateMaxExecutionDuration(input.MaxExecutionDuration); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateOnFailureWorkflow(ctx, tenantID, id, input.OnFailureWorkflowID); err != nil {
+		return nil, err
+	}
+
+	var referenceIssues []ReferenceIssue
+	if input.Definition != nil {
+		var err error
+		referenceIssues, err = s.checkReferences(ctx, tenantID, id, input.Definition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	workflow, err := s.repo.Update(ctx, tenantID, id, input)
 	if err != nil {
 		s.logger.Error("failed to update workflow", "error", err, "workflow_id", id)
 		return nil, err
 	}
+	workflow.ReferenceWarnings = referenceIssues
+	if input.Definition != nil {
+		workflow.LintWarnings = lintDefinitionJSON(input.Definition)
+	}
 
 	// Sync webhooks if definition was updated and webhook service is available
 	if input.Definition != nil && s.webhookService != nil {
@@ -201,6 +312,14 @@ func (s *Service) Execute(ctx context.Context, tenantID, workflowID, triggerType
 
 	s.logger.Info("execution created", "execution_id", execution.ID, "workflow_id", workflowID)
 
+	s.dispatchExecution(ctx, execution, workflow.Version, triggerData)
+
+	return execution, nil
+}
+
+// dispatchExecution publishes a created execution to the queue, or runs it in a
+// goroutine when no queue is configured or publishing fails
+func (s *Service) dispatchExecution(ctx context.Context, execution *Execution, workflowVersion int, triggerData []byte) {
 	// If queue publisher is configured, publish to queue
 	// Otherwise, execute in goroutine (backward compatibility)
 	if s.queuePublisher != nil {
@@ -214,10 +333,10 @@ func (s *Service) Execute(ctx context.Context, tenantID, workflowID, triggerType
 		// Create execution message for queue
 		execMsg := map[string]interface{}{
 			"execution_id":     execution.ID,
-			"tenant_id":        tenantID,
-			"workflow_id":      workflowID,
-			"workflow_version": workflow.Version,
-			"trigger_type":     triggerType,
+			"tenant_id":        execution.TenantID,
+			"workflow_id":      execution.WorkflowID,
+			"workflow_version": workflowVersion,
+			"trigger_type":     execution.TriggerType,
 		}
 		if triggerDataPtr != nil {
 			execMsg["trigger_data"] = *triggerDataPtr
@@ -228,19 +347,17 @@ func (s *Service) Execute(ctx context.Context, tenantID, workflowID, triggerType
 			s.logger.Error("failed to publish execution to queue",
 				"error", err,
 				"execution_id", execution.ID,
-				"workflow_id", workflowID,
+				"workflow_id", execution.WorkflowID,
 			)
 			// Don't fail the request, fall back to goroutine execution
-			s.executeInGoroutine(execution, workflowID)
+			s.executeInGoroutine(execution, execution.WorkflowID)
 		} else {
-			s.logger.Info("execution published to queue", "execution_id", execution.ID, "workflow_id", workflowID)
+			s.logger.Info("execution published to queue", "execution_id", execution.ID, "workflow_id", execution.WorkflowID)
 		}
 	} else {
 		// Backward compatibility: execute in goroutine
-		s.executeInGoroutine(execution, workflowID)
+		s.executeInGoroutine(execution, execution.WorkflowID)
 	}
-
-	return execution, nil
 }
 
 // executeInGoroutine executes workflow in a goroutine (backward compatibility)
@@ -332,8 +449,15 @@ func (s *Service) ListExecutions(ctx context.Context, tenantID, workflowID strin
 	return s.repo.ListExecutions(ctx, tenantID, workflowID, limit, offset)
 }
 
-// CancelExecution cancels a running execution
-func (s *Service) CancelExecution(ctx context.Context, tenantID, executionID string) (*Execution, error) {
+// ListExecutionsPage retrieves one cursor page of executions for a tenant
+func (s *Service) ListExecutionsPage(ctx context.Context, tenantID, workflowID string, page pagination.Params) ([]*Execution, error) {
+	return s.repo.ListExecutionsPage(ctx, tenantID, workflowID, page)
+}
+
+// CancelExecution cancels a pending or running execution and records the user
+// who cancelled it. An execution running in this process is interrupted
+// immediately; one running on another worker stops before its next node.
+func (s *Service) CancelExecution(ctx context.Context, tenantID, executionID, userID string) (*Execution, error) {
 	// Get the execution first to verify it exists and belongs to tenant
 	execution, err := s.repo.GetExecutionByID(ctx, tenantID, executionID)
 	if err != nil {
@@ -341,22 +465,28 @@ func (s *Service) CancelExecution(ctx context.Context, tenantID, executionID str
 	}
 
 	// Check if execution is in a cancellable state
-	if execution.Status == string(ExecutionStatusCompleted) || execution.Status == string(ExecutionStatusFailed) || execution.Status == string(ExecutionStatusCancelled) {
+	if execution.Status == string(ExecutionStatusCompleted) || execution.Status == string(ExecutionStatusFailed) || execution.Status == string(ExecutionStatusCancelled) ||
+		execution.Status == string(ExecutionStatusSkippedConcurrent) {
 		return nil, &ValidationError{Message: fmt.Sprintf("cannot cancel execution in %s state", execution.Status)}
 	}
 
-	// Update execution status to cancelled
-	cancelMsg := "execution cancelled by user"
-	err = s.repo.UpdateExecutionStatus(ctx, executionID, ExecutionStatusCancelled, nil, &cancelMsg)
+	cancelled, err := s.repo.CancelExecution(ctx, tenantID, executionID, userID)
 	if err != nil {
+		if errors.Is(err, ErrExecutionNotCancellable) {
+			return nil, &ValidationError{Message: "execution finished before it could be cancelled"}
+		}
 		s.logger.Error("failed to cancel execution", "error", err, "execution_id", executionID)
 		return nil, fmt.Errorf("failed to cancel execution: %w", err)
 	}
 
-	s.logger.Info("execution cancelled", "execution_id", executionID, "tenant_id", tenantID)
+	// Interrupt the execution if it is running in this process
+	if canceller, ok := s.executor.(ExecutionCanceller); ok {
+		canceller.Cancel(executionID)
+	}
+
+	s.logger.Info("execution cancelled", "execution_id", executionID, "tenant_id", tenantID, "cancelled_by", userID)
 
-	// Return updated execution
-	return s.repo.GetExecutionByID(ctx, tenantID, executionID)
+	return cancelled, nil
 }
 
 // ListExecutionsAdvanced retrieves executions with advanced filtering and cursor-based pagination
@@ -375,6 +505,22 @@ func (s *Service) ListExecutionsAdvanced(ctx context.Context, tenantID string, f
 	return s.repo.ListExecutionsAdvanced(ctx, tenantID, filter, cursor, limit)
 }
 
+// SearchExecutions searches executions by error message with offset pagination
+func (s *Service) SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, &ValidationError{Message: "invalid filter: " + err.Error()}
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	return s.repo.SearchExecutions(ctx, tenantID, filter)
+}
+
 // GetExecutionWithSteps retrieves an execution with all its step executions
 func (s *Service) GetExecutionWithSteps(ctx context.Context, tenantID, executionID string) (*ExecutionWithSteps, error) {
 	return s.repo.GetExecutionWithSteps(ctx, tenantID, executionID)
@@ -396,6 +542,7 @@ func (s *Service) GetExecutionStats(ctx context.Context, tenantID string, filter
 		ExecutionStatusCompleted,
 		ExecutionStatusFailed,
 		ExecutionStatusCancelled,
+		ExecutionStatusSkippedConcurrent,
 	}
 
 	for _, status := range statuses {
@@ -414,6 +561,82 @@ func (s *Service) GetExecutionStats(ctx context.Context, tenantID string, filter
 	return stats, nil
 }
 
+// maxWorkflowConcurrency is the largest accepted max_concurrency
+const maxWorkflowConcurrency = 1000
+
+// validateMaxConcurrency checks a requested per-workflow concurrency limit
+func validateMaxConcurrency(maxConcurrency *int) error {
+	if maxConcurrency == nil {
+		return nil
+	}
+	if *maxConcurrency < 0 || *maxConcurrency > maxWorkflowConcurrency {
+		return &ValidationError{Message: fmt.Sprintf("max_concurrency must be between 0 and %d", maxWorkflowConcurrency)}
+	}
+	return nil
+}
+
+// maxConcurrencyKeyLength is the longest accepted concurrency_key
+const maxConcurrencyKeyLength = 255
+
+// validateConcurrencyKey checks a requested per-workflow concurrency key
+func validateConcurrencyKey(key *string) error {
+	if key == nil {
+		return nil
+	}
+	if len(*key) > maxConcurrencyKeyLength || strings.TrimSpace(*key) != *key {
+		return &ValidationError{Message: fmt.Sprintf("concurrency_key must be at most %d characters without surrounding whitespace", maxConcurrencyKeyLength)}
+	}
+	return nil
+}
+
+// maxEventRetentionDays is the largest accepted event_retention_days
+const maxEventRetentionDays = 3650
+
+// validateEventRetentionDays checks a requested per-workflow webhook event retention override
+func validateEventRetentionDays(days *int) error {
+	if days == nil {
+		return nil
+	}
+	if *days < 0 || *days > maxEventRetentionDays {
+		return &ValidationError{Message: fmt.Sprintf("event_retention_days must be between 0 and %d", maxEventRetentionDays)}
+	}
+	return nil
+}
+
+// maxExecutionDurationSeconds is the largest accepted max_execution_duration (30 days)
+const maxExecutionDurationSeconds = 30 * 24 * 60 * 60
+
+// validateMaxExecutionDuration checks a requested per-workflow execution time limit
+func validateMaxExecutionDuration(seconds *int) error {
+	if seconds == nil {
+		return nil
+	}
+	if *seconds < 0 || *seconds > maxExecutionDurationSeconds {
+		return &ValidationError{Message: fmt.Sprintf("max_execution_duration must be between 0 and %d seconds", maxExecutionDurationSeconds)}
+	}
+	return nil
+}
+
+// Lint returns advice about anti-patterns in a workflow definition. The
+// definition only needs to be valid JSON; structural problems are reported
+// by validation.
+func (s *Service) Lint(definition json.RawMessage) ([]LintFinding, error) {
+	var def WorkflowDefinition
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return nil, &ValidationError{Message: "invalid definition JSON: " + err.Error()}
+	}
+	return LintDefinition(&def), nil
+}
+
+// lintDefinitionJSON lints a definition that has already been validated
+func lintDefinitionJSON(definition json.RawMessage) []LintFinding {
+	var def WorkflowDefinition
+	if err := json.Unmarshal(definition, &def); err != nil {
+		return nil
+	}
+	return LintDefinition(&def)
+}
+
 // validateDefinition validates a workflow definition
 func (s *Service) validateDefinition(definition json.RawMessage) error {
 	var def WorkflowDefinition
@@ -428,10 +651,8 @@ func (s *Service) validateDefinition(definition json.RawMessage) error {
 
 	// Validate at least one trigger
 	hasTrigger := false
-	nodeIDs := make(map[string]bool)
 	for _, node := range def.Nodes {
-		nodeIDs[node.ID] = true
-		if node.Type == string(NodeTypeTriggerWebhook) || node.Type == string(NodeTypeTriggerSchedule) {
+		if isTriggerType(node.Type) {
 			hasTrigger = true
 		}
 	}
@@ -440,16 +661,29 @@ func (s *Service) validateDefinition(definition json.RawMessage) error {
 		return &ValidationError{Message: "workflow must have at least one trigger"}
 	}
 
-	// Validate edges reference existing nodes
-	for _, edge := range def.Edges {
-		if !nodeIDs[edge.Source] {
-			return &ValidationError{Message: "edge references non-existent source node: " + edge.Source}
+	// Oversized scripts would fail every run, so refuse them up front
+	for _, node := range def.Nodes {
+		if node.Type != string(NodeTypeActionScript) {
+			continue
 		}
-		if !nodeIDs[edge.Target] {
-			return &ValidationError{Message: "edge references non-existent target node: " + edge.Target}
+		if errs := validateScriptConfig(node); len(errs) > 0 {
+			return &ValidationError{Message: fmt.Sprintf("node %s: %s", node.ID, errs[0].Message)}
 		}
 	}
 
+	// Caching a node that changes state would skip the change, so only
+	// read-only nodes may opt in
+	for _, node := range def.Nodes {
+		if errs := validateNodeCache(node); len(errs) > 0 {
+			return &ValidationError{Message: fmt.Sprintf("node %s: %s", node.ID, errs[0].Message)}
+		}
+	}
+
+	// Validate graph structure
+	if issues := (DefinitionValidator{}).Validate(&def); len(issues) > 0 {
+		return &DefinitionError{Issues: issues}
+	}
+
 	return nil
 }
 
@@ -463,21 +697,7 @@ func (s *Service) extractWebhookNodes(definition json.RawMessage) []WebhookNodeC
 	var webhookNodes []WebhookNodeConfig
 	for _, node := range def.Nodes {
 		if node.Type == string(NodeTypeTriggerWebhook) {
-			// Parse config to get auth type
-			var config WebhookTriggerConfig
-			authType := AuthTypeSignature // Default
-			if node.Config != nil {
-				if err := json.Unmarshal(node.Config, &config); err == nil {
-					if config.AuthType != "" {
-						authType = config.AuthType
-					}
-				}
-			}
-
-			webhookNodes = append(webhookNodes, WebhookNodeConfig{
-				NodeID:   node.ID,
-				AuthType: authType,
-			})
+			webhookNodes = append(webhookNodes, newWebhookNodeConfig(node))
 		}
 	}
 
@@ -637,7 +857,7 @@ func (s *Service) DryRun(ctx context.Context, tenantID, workflowID string, testD
 			continue
 		}
 
-		nodeErrors := s.validateNodeConfig(node, availableVars)
+		nodeErrors := s.validateNodeConfig(node, definition.Edges, availableVars)
 		if len(nodeErrors) > 0 {
 			result.Valid = false
 			result.Errors = append(result.Errors, nodeErrors...)
@@ -668,9 +888,54 @@ func (s *Service) DryRun(ctx context.Context, tenantID, workflowID string, testD
 		}
 	}
 
+	// Output schema mismatches do not block saving, so they are warnings here
+	outputCheck := DefinitionValidator{AllowCycles: true, CheckOutputs: true}
+	for _, issue := range outputCheck.Validate(&definition) {
+		if issue.Code == IssueUndeclaredOutput {
+			result.Warnings = append(result.Warnings, DryRunWarning{NodeID: issue.NodeID, Message: issue.Message})
+		}
+	}
+
 	return result, nil
 }
 
+// SimulateExecution runs a workflow against triggerData without persisting an
+// execution. Nodes named in mocks return the given output instead of running;
+// actions that are not mocked are stubbed, while triggers, transforms,
+// formulas and branching nodes are computed so the data flow can be checked.
+func (s *Service) SimulateExecution(ctx context.Context, tenantID, workflowID string, triggerData []byte, mocks map[string]interface{}) (*SimulationResult, error) {
+	workflow, err := s.repo.GetByID(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var definition WorkflowDefinition
+	if err := json.Unmarshal(workflow.Definition, &definition); err != nil {
+		return nil, &ValidationError{Message: "failed to parse workflow definition: " + err.Error()}
+	}
+
+	if len(triggerData) > 0 {
+		var trigger map[string]interface{}
+		if err := json.Unmarshal(triggerData, &trigger); err != nil {
+			return nil, &ValidationError{Message: "trigger data must be a JSON object"}
+		}
+	}
+
+	nodeMap := s.buildNodeMapForDryRun(definition.Nodes)
+	for nodeID := range mocks {
+		if _, ok := nodeMap[nodeID]; !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("mock references unknown node %s", nodeID)}
+		}
+	}
+
+	simulator, ok := s.executor.(Simulator)
+	if !ok {
+		return nil, &ValidationError{Message: "executor does not support simulation"}
+	}
+
+	return simulator.Simulate(ctx, workflow, triggerData, mocks)
+}
+
 func (s *Service) buildNodeMapForDryRun(nodes []Node) map[string]Node {
 	nodeMap := make(map[string]Node)
 	for _, node := range nodes {
@@ -680,8 +945,7 @@ func (s *Service) buildNodeMapForDryRun(nodes []Node) map[string]Node {
 }
 
 func (s *Service) isTriggerNodeType(nodeType string) bool {
-	return nodeType == string(NodeTypeTriggerWebhook) ||
-		nodeType == string(NodeTypeTriggerSchedule)
+	return isTriggerType(nodeType)
 }
 
 func (s *Service) validateTopologicalOrder(nodes []Node, edges []Edge) ([]string, error) {
@@ -726,7 +990,7 @@ func (s *Service) validateTopologicalOrder(nodes []Node, edges []Edge) ([]string
 	return result, nil
 }
 
-func (s *Service) validateNodeConfig(node Node, availableVars map[string]bool) []DryRunError {
+func (s *Service) validateNodeConfig(node Node, edges []Edge, availableVars map[string]bool) []DryRunError {
 	var errors []DryRunError
 
 	switch node.Type {
@@ -736,11 +1000,18 @@ func (s *Service) validateNodeConfig(node Node, availableVars map[string]bool) [
 		errors = append(errors, s.validateTransformConfig(node, availableVars)...)
 	case string(NodeTypeActionFormula):
 		errors = append(errors, s.validateFormulaConfig(node, availableVars)...)
+	case string(NodeTypeActionScript):
+		errors = append(errors, validateScriptConfig(node)...)
 	case string(NodeTypeControlIf):
 		errors = append(errors, s.validateConditionalConfig(node, availableVars)...)
+	case string(NodeTypeControlSwitch):
+		errors = append(errors, s.validateSwitchConfig(node, edges, availableVars)...)
+	case string(NodeTypeControlSplit):
+		errors = append(errors, validateSplitBranches(node, edges)...)
 	case string(NodeTypeControlLoop):
 		errors = append(errors, s.validateLoopConfig(node, availableVars)...)
 	}
+	errors = append(errors, validateNodeCache(node)...)
 
 	return errors
 }
@@ -783,6 +1054,30 @@ func (s *Service) validateHTTPConfig(node Node, availableVars map[string]bool) [
 		})
 	}
 
+	switch config.ContentType {
+	case "", "application/json", "application/x-www-form-urlencoded", "multipart/form-data":
+	default:
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "content_type",
+			Message: "content_type must be application/json, application/x-www-form-urlencoded or multipart/form-data",
+		})
+	}
+
+	if config.MaxResponseBytes < 0 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "max_response_bytes",
+			Message: "max_response_bytes must not be negative",
+		})
+	} else if s.httpMaxResponseBytes > 0 && config.MaxResponseBytes > s.httpMaxResponseBytes {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "max_response_bytes",
+			Message: fmt.Sprintf("max_response_bytes must not exceed the server limit of %d bytes", s.httpMaxResponseBytes),
+		})
+	}
+
 	configStr := string(node.Data.Config)
 	errors = append(errors, s.validateVariableReferences(node.ID, configStr, availableVars)...)
 
@@ -806,6 +1101,24 @@ func (s *Service) validateTransformConfig(node Node, availableVars map[string]bo
 		return errors
 	}
 
+	switch config.TransformType {
+	case "", "mapping":
+	case "expr":
+		if strings.TrimSpace(config.Expression) == "" {
+			errors = append(errors, DryRunError{
+				NodeID:  node.ID,
+				Field:   "expression",
+				Message: "expression is required when transform_type is expr",
+			})
+		}
+	default:
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "transform_type",
+			Message: "transform_type must be mapping or expr",
+		})
+	}
+
 	configStr := string(node.Data.Config)
 	errors = append(errors, s.validateVariableReferences(node.ID, configStr, availableVars)...)
 
@@ -848,6 +1161,33 @@ func (s *Service) validateFormulaConfig(node Node, availableVars map[string]bool
 	return errors
 }
 
+// validateScriptConfig checks that an action:script node has a script within
+// the size cap. Scripts read steps directly rather than through ${...}.
+func validateScriptConfig(node Node) []DryRunError {
+	var config ScriptNodeConfig
+	if len(node.Data.Config) > 0 {
+		if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+			return []DryRunError{{
+				NodeID:  node.ID,
+				Field:   "config",
+				Message: "invalid script configuration: " + err.Error(),
+			}}
+		}
+	}
+
+	switch {
+	case strings.TrimSpace(config.Script) == "":
+		return []DryRunError{{NodeID: node.ID, Field: "script", Message: "script is required"}}
+	case len(config.Script) > MaxScriptNodeLength:
+		return []DryRunError{{
+			NodeID:  node.ID,
+			Field:   "script",
+			Message: fmt.Sprintf("script is %d bytes, limit is %d", len(config.Script), MaxScriptNodeLength),
+		}}
+	}
+	return nil
+}
+
 func (s *Service) validateConditionalConfig(node Node, availableVars map[string]bool) []DryRunError {
 	var errors []DryRunError
 	var config ConditionalActionConfig
@@ -884,6 +1224,145 @@ func (s *Service) validateConditionalConfig(node Node, availableVars map[string]
 	return errors
 }
 
+func (s *Service) validateSwitchConfig(node Node, edges []Edge, availableVars map[string]bool) []DryRunError {
+	errors := validateSwitchBranches(node, edges)
+	if len(node.Data.Config) == 0 {
+		return errors
+	}
+
+	configStr := string(node.Data.Config)
+	errors = append(errors, s.validateVariableReferences(node.ID, configStr, availableVars)...)
+
+	return errors
+}
+
+// validateSwitchBranches checks a switch node's config and that each of its
+// outgoing edges is labeled with a declared case or the default branch
+func validateSwitchBranches(node Node, edges []Edge) []DryRunError {
+	var errors []DryRunError
+	var config SwitchActionConfig
+
+	if len(node.Data.Config) == 0 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "config",
+			Message: "switch action requires configuration",
+		})
+		return errors
+	}
+
+	if err := json.Unmarshal(node.Data.Config, &config); err != nil {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "config",
+			Message: "invalid switch configuration: " + err.Error(),
+		})
+		return errors
+	}
+
+	if config.Expression == "" {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "expression",
+			Message: "expression is required",
+		})
+	}
+
+	if len(config.Cases) == 0 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "cases",
+			Message: "at least one case is required",
+		})
+	}
+
+	labels := map[string]bool{SwitchDefaultBranch: true}
+	for i, c := range config.Cases {
+		label := c.BranchLabel()
+		if label == SwitchDefaultBranch {
+			errors = append(errors, DryRunError{
+				NodeID:  node.ID,
+				Field:   fmt.Sprintf("cases[%d].label", i),
+				Message: fmt.Sprintf("case label %q is reserved for the default branch", SwitchDefaultBranch),
+			})
+		}
+		labels[label] = true
+	}
+
+	for _, edge := range edges {
+		if edge.Source != node.ID || labels[edge.Label] {
+			continue
+		}
+		message := fmt.Sprintf("edge to %s must be labeled with a case or %q", edge.Target, SwitchDefaultBranch)
+		if edge.Label != "" {
+			message = fmt.Sprintf("edge to %s is labeled %q, which is not a declared case", edge.Target, edge.Label)
+		}
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "edges",
+			Message: message,
+		})
+	}
+
+	return errors
+}
+
+// validateSplitBranches checks that a split node's outgoing edges each have a
+// distinct label and a weight, and that the weights add up to 100
+func validateSplitBranches(node Node, edges []Edge) []DryRunError {
+	var errors []DryRunError
+	labels := make(map[string]bool)
+	total, outgoing := 0, 0
+
+	for _, edge := range edges {
+		if edge.Source != node.ID {
+			continue
+		}
+		outgoing++
+		total += edge.Weight
+
+		switch {
+		case edge.Label == "":
+			errors = append(errors, DryRunError{
+				NodeID:  node.ID,
+				Field:   "edges",
+				Message: fmt.Sprintf("edge to %s must be labeled", edge.Target),
+			})
+		case labels[edge.Label]:
+			errors = append(errors, DryRunError{
+				NodeID:  node.ID,
+				Field:   "edges",
+				Message: fmt.Sprintf("edge to %s reuses the label %q", edge.Target, edge.Label),
+			})
+		}
+		labels[edge.Label] = true
+
+		if edge.Weight < 1 || edge.Weight > 100 {
+			errors = append(errors, DryRunError{
+				NodeID:  node.ID,
+				Field:   "edges",
+				Message: fmt.Sprintf("edge to %s must have a weight from 1 to 100", edge.Target),
+			})
+		}
+	}
+
+	if outgoing == 0 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "edges",
+			Message: "split node requires at least one outgoing edge",
+		})
+	} else if total != 100 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "edges",
+			Message: fmt.Sprintf("edge weights must add up to 100, not %d", total),
+		})
+	}
+
+	return errors
+}
+
 func (s *Service) validateLoopConfig(node Node, availableVars map[string]bool) []DryRunError {
 	var errors []DryRunError
 	var config LoopActionConfig
@@ -922,6 +1401,20 @@ func (s *Service) validateLoopConfig(node Node, availableVars map[string]bool) [
 		})
 	}
 
+	if config.Concurrency < 0 || config.Concurrency > MaxLoopConcurrency {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "concurrency",
+			Message: fmt.Sprintf("concurrency must be between 1 and %d", MaxLoopConcurrency),
+		})
+	} else if config.Concurrency > 1 && len(config.BreakConditions) > 0 {
+		errors = append(errors, DryRunError{
+			NodeID:  node.ID,
+			Field:   "break_conditions",
+			Message: "break conditions require sequential iteration (concurrency 1)",
+		})
+	}
+
 	configStr := string(node.Data.Config)
 	errors = append(errors, s.validateVariableReferences(node.ID, configStr, availableVars)...)
 
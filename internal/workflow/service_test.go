@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gorax/gorax/internal/pagination"
 )
 
 // MockRepository is a mock implementation of RepositoryInterface for testing
@@ -77,6 +79,14 @@ func (m *MockRepository) UpdateExecutionStatus(ctx context.Context, id string, s
 	return args.Error(0)
 }
 
+func (m *MockRepository) CancelExecution(ctx context.Context, tenantID, id, cancelledBy string) (*Execution, error) {
+	args := m.Called(ctx, tenantID, id, cancelledBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
 func (m *MockRepository) GetStepExecutionsByExecutionID(ctx context.Context, executionID string) ([]*StepExecution, error) {
 	args := m.Called(ctx, executionID)
 	if args.Get(0) == nil {
@@ -85,6 +95,64 @@ func (m *MockRepository) GetStepExecutionsByExecutionID(ctx context.Context, exe
 	return args.Get(0).([]*StepExecution), args.Error(1)
 }
 
+func (m *MockRepository) CreateRetryExecution(ctx context.Context, original *Execution, workflowVersion int, fromNode string, seeds []*StepExecution) (*Execution, error) {
+	args := m.Called(ctx, original, workflowVersion, fromNode, seeds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
+func (m *MockRepository) CreateDeadLetter(ctx context.Context, execution *Execution, failure ExecutionFailure) (*DeadLetter, error) {
+	args := m.Called(ctx, execution, failure)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetter), args.Error(1)
+}
+
+func (m *MockRepository) GetDeadLetter(ctx context.Context, tenantID, id string) (*DeadLetter, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetter), args.Error(1)
+}
+
+func (m *MockRepository) ListDeadLetters(ctx context.Context, tenantID string, filter DeadLetterFilter) (*DeadLetterListResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeadLetterListResult), args.Error(1)
+}
+
+func (m *MockRepository) RequeueDeadLetter(ctx context.Context, entry *DeadLetter, workflowVersion int) (*Execution, error) {
+	args := m.Called(ctx, entry, workflowVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Execution), args.Error(1)
+}
+
+func (m *MockRepository) ClaimIdempotencyKey(ctx context.Context, tenantID, key, requestHash string, ttl time.Duration) (*IdempotencyKey, bool, error) {
+	args := m.Called(ctx, tenantID, key, requestHash, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*IdempotencyKey), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRepository) CompleteIdempotencyKey(ctx context.Context, tenantID, key, executionID string) error {
+	args := m.Called(ctx, tenantID, key, executionID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error {
+	args := m.Called(ctx, tenantID, key)
+	return args.Error(0)
+}
+
 func (m *MockRepository) ListExecutions(ctx context.Context, tenantID string, workflowID string, limit, offset int) ([]*Execution, error) {
 	args := m.Called(ctx, tenantID, workflowID, limit, offset)
 	if args.Get(0) == nil {
@@ -93,6 +161,14 @@ func (m *MockRepository) ListExecutions(ctx context.Context, tenantID string, wo
 	return args.Get(0).([]*Execution), args.Error(1)
 }
 
+func (m *MockRepository) ListExecutionsPage(ctx context.Context, tenantID string, workflowID string, page pagination.Params) ([]*Execution, error) {
+	args := m.Called(ctx, tenantID, workflowID, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Execution), args.Error(1)
+}
+
 func (m *MockRepository) ListExecutionsAdvanced(ctx context.Context, tenantID string, filter ExecutionFilter, cursor string, limit int) (*ExecutionListResult, error) {
 	args := m.Called(ctx, tenantID, filter, cursor, limit)
 	if args.Get(0) == nil {
@@ -114,6 +190,14 @@ func (m *MockRepository) CountExecutions(ctx context.Context, tenantID string, f
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockRepository) SearchExecutions(ctx context.Context, tenantID string, filter ExecutionSearchFilter) (*ExecutionSearchResult, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ExecutionSearchResult), args.Error(1)
+}
+
 func (m *MockRepository) CreateWorkflowVersion(ctx context.Context, workflowID string, version int, definition json.RawMessage, createdBy string) (*WorkflowVersion, error) {
 	args := m.Called(ctx, workflowID, version, definition, createdBy)
 	if args.Get(0) == nil {
@@ -158,6 +242,73 @@ func newTestService() (*Service, *MockRepository) {
 	return service, mockRepo
 }
 
+// cancellingExecutor records executions cancelled through ExecutionCanceller
+type cancellingExecutor struct {
+	cancelled []string
+}
+
+func (e *cancellingExecutor) Execute(ctx context.Context, execution *Execution) error {
+	return nil
+}
+
+func (e *cancellingExecutor) Cancel(executionID string) bool {
+	e.cancelled = append(e.cancelled, executionID)
+	return true
+}
+
+// TestCancelExecution_Success tests that cancelling records the user and interrupts the local executor
+func TestCancelExecution_Success(t *testing.T) {
+	service, mockRepo := newTestService()
+	executor := &cancellingExecutor{}
+	service.SetExecutor(executor)
+	ctx := context.Background()
+
+	cancelledBy := "user-1"
+	cancelled := &Execution{ID: "exec-1", Status: string(ExecutionStatusCancelled), CancelledBy: &cancelledBy}
+
+	mockRepo.On("GetExecutionByID", ctx, "tenant-123", "exec-1").Return(&Execution{ID: "exec-1", Status: string(ExecutionStatusRunning)}, nil)
+	mockRepo.On("CancelExecution", ctx, "tenant-123", "exec-1", "user-1").Return(cancelled, nil)
+
+	result, err := service.CancelExecution(ctx, "tenant-123", "exec-1", "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, cancelled, result)
+	assert.Equal(t, []string{"exec-1"}, executor.cancelled)
+	mockRepo.AssertExpectations(t)
+}
+
+// TestCancelExecution_NotCancellable tests cancelling executions that have already finished
+func TestCancelExecution_NotCancellable(t *testing.T) {
+	t.Run("finished before the request", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		ctx := context.Background()
+
+		mockRepo.On("GetExecutionByID", ctx, "tenant-123", "exec-1").Return(&Execution{ID: "exec-1", Status: string(ExecutionStatusCompleted)}, nil)
+
+		result, err := service.CancelExecution(ctx, "tenant-123", "exec-1", "user-1")
+
+		assert.Nil(t, result)
+		assert.IsType(t, &ValidationError{}, err)
+		mockRepo.AssertNotCalled(t, "CancelExecution")
+	})
+
+	t.Run("finished while cancelling", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		executor := &cancellingExecutor{}
+		service.SetExecutor(executor)
+		ctx := context.Background()
+
+		mockRepo.On("GetExecutionByID", ctx, "tenant-123", "exec-1").Return(&Execution{ID: "exec-1", Status: string(ExecutionStatusRunning)}, nil)
+		mockRepo.On("CancelExecution", ctx, "tenant-123", "exec-1", "user-1").Return(nil, ErrExecutionNotCancellable)
+
+		result, err := service.CancelExecution(ctx, "tenant-123", "exec-1", "user-1")
+
+		assert.Nil(t, result)
+		assert.IsType(t, &ValidationError{}, err)
+		assert.Empty(t, executor.cancelled)
+	})
+}
+
 // TestListExecutionsAdvanced_Success tests successful execution listing with filters
 func TestListExecutionsAdvanced_Success(t *testing.T) {
 	service, mockRepo := newTestService()
@@ -349,6 +500,66 @@ func TestListExecutionsAdvanced_RepositoryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "database connection error")
 }
 
+// TestSearchExecutions_Limits tests limit defaults and capping for execution search
+func TestSearchExecutions_Limits(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputLimit    int
+		expectedLimit int
+	}{
+		{name: "zero limit uses default", inputLimit: 0, expectedLimit: 20},
+		{name: "negative limit uses default", inputLimit: -5, expectedLimit: 20},
+		{name: "limit is capped", inputLimit: 500, expectedLimit: 100},
+		{name: "limit within range is kept", inputLimit: 50, expectedLimit: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo := newTestService()
+			ctx := context.Background()
+
+			expected := ExecutionSearchFilter{Query: "timeout", Limit: tt.expectedLimit, Offset: 40}
+			result := &ExecutionSearchResult{Data: []*Execution{{ID: "exec-1"}}, TotalCount: 41, Limit: tt.expectedLimit, Offset: 40}
+			mockRepo.On("SearchExecutions", ctx, "tenant-123", expected).Return(result, nil)
+
+			got, err := service.SearchExecutions(ctx, "tenant-123", ExecutionSearchFilter{Query: "timeout", Limit: tt.inputLimit, Offset: 40})
+
+			require.NoError(t, err)
+			assert.Equal(t, result, got)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSearchExecutions_FilterValidation tests that invalid search filters never reach the repository
+func TestSearchExecutions_FilterValidation(t *testing.T) {
+	service, mockRepo := newTestService()
+	ctx := context.Background()
+
+	startDate := time.Now()
+	endDate := startDate.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		filter ExecutionSearchFilter
+	}{
+		{name: "end before start", filter: ExecutionSearchFilter{StartDate: &startDate, EndDate: &endDate}},
+		{name: "negative offset", filter: ExecutionSearchFilter{Offset: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.SearchExecutions(ctx, "tenant-123", tt.filter)
+
+			require.Error(t, err)
+			assert.Nil(t, result)
+			assert.IsType(t, &ValidationError{}, err)
+		})
+	}
+
+	mockRepo.AssertNotCalled(t, "SearchExecutions")
+}
+
 // TestGetExecutionWithSteps_Success tests successful retrieval of execution with steps
 func TestGetExecutionWithSteps_Success(t *testing.T) {
 	service, mockRepo := newTestService()
@@ -453,20 +664,22 @@ func TestGetExecutionStats_Success(t *testing.T) {
 			name:   "all executions stats",
 			filter: ExecutionFilter{},
 			mockCounts: map[string]int{
-				"pending":   5,
-				"running":   3,
-				"completed": 20,
-				"failed":    2,
-				"cancelled": 1,
+				"pending":            5,
+				"running":            3,
+				"completed":          20,
+				"failed":             2,
+				"cancelled":          1,
+				"skipped_concurrent": 4,
 			},
 			expectedStats: ExecutionStats{
-				TotalCount: 31,
+				TotalCount: 35,
 				StatusCounts: map[string]int{
-					"pending":   5,
-					"running":   3,
-					"completed": 20,
-					"failed":    2,
-					"cancelled": 1,
+					"pending":            5,
+					"running":            3,
+					"completed":          20,
+					"failed":             2,
+					"cancelled":          1,
+					"skipped_concurrent": 4,
 				},
 			},
 		},
@@ -476,20 +689,22 @@ func TestGetExecutionStats_Success(t *testing.T) {
 				WorkflowID: "workflow-1",
 			},
 			mockCounts: map[string]int{
-				"pending":   2,
-				"running":   1,
-				"completed": 10,
-				"failed":    1,
-				"cancelled": 0,
+				"pending":            2,
+				"running":            1,
+				"completed":          10,
+				"failed":             1,
+				"cancelled":          0,
+				"skipped_concurrent": 0,
 			},
 			expectedStats: ExecutionStats{
 				TotalCount: 14,
 				StatusCounts: map[string]int{
-					"pending":   2,
-					"running":   1,
-					"completed": 10,
-					"failed":    1,
-					"cancelled": 0,
+					"pending":            2,
+					"running":            1,
+					"completed":          10,
+					"failed":             1,
+					"cancelled":          0,
+					"skipped_concurrent": 0,
 				},
 			},
 		},
@@ -568,7 +783,7 @@ func TestGetExecutionStats_ZeroCounts(t *testing.T) {
 	filter := ExecutionFilter{}
 
 	// Mock zero count for all statuses
-	statuses := []string{"pending", "running", "completed", "failed", "cancelled"}
+	statuses := []string{"pending", "running", "completed", "failed", "cancelled", "skipped_concurrent"}
 	for _, status := range statuses {
 		statusFilter := ExecutionFilter{Status: status}
 		mockRepo.On("CountExecutions", ctx, tenantID, statusFilter).
@@ -585,5 +800,96 @@ func TestGetExecutionStats_ZeroCounts(t *testing.T) {
 	assert.Equal(t, 0, result.StatusCounts["completed"])
 	assert.Equal(t, 0, result.StatusCounts["failed"])
 	assert.Equal(t, 0, result.StatusCounts["cancelled"])
+	assert.Equal(t, 0, result.StatusCounts["skipped_concurrent"])
 	mockRepo.AssertExpectations(t)
 }
+
+func TestValidateMaxConcurrency(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		value   *int
+		wantErr bool
+	}{
+		{name: "unset", value: nil},
+		{name: "zero removes the limit", value: intPtr(0)},
+		{name: "within range", value: intPtr(5)},
+		{name: "maximum", value: intPtr(maxWorkflowConcurrency)},
+		{name: "negative", value: intPtr(-1), wantErr: true},
+		{name: "too large", value: intPtr(maxWorkflowConcurrency + 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMaxConcurrency(tt.value)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateEventRetentionDays(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		value   *int
+		wantErr bool
+	}{
+		{name: "unset", value: nil},
+		{name: "zero removes the override", value: intPtr(0)},
+		{name: "within range", value: intPtr(365)},
+		{name: "maximum", value: intPtr(maxEventRetentionDays)},
+		{name: "negative", value: intPtr(-1), wantErr: true},
+		{name: "too large", value: intPtr(maxEventRetentionDays + 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEventRetentionDays(tt.value)
+			if tt.wantErr {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCreate_InitialStatus(t *testing.T) {
+	ctx := context.Background()
+	definition := json.RawMessage(`{
+		"nodes": [
+			{"id": "trigger", "type": "trigger:webhook", "data": {"config": {}}},
+			{"id": "call", "type": "action:http", "data": {"config": {"method": "GET", "url": "https://api.example.com"}}}
+		],
+		"edges": [{"id": "e1", "source": "trigger", "target": "call"}]
+	}`)
+
+	t.Run("active", func(t *testing.T) {
+		service, mockRepo := newTestService()
+		input := CreateWorkflowInput{Name: "Installed", Definition: definition, Status: WorkflowStatusActive}
+		mockRepo.On("Create", ctx, "tenant-123", "user-1", input).Return(&Workflow{ID: "wf-1", Status: "active"}, nil)
+
+		created, err := service.Create(ctx, "tenant-123", "user-1", input)
+
+		require.NoError(t, err)
+		assert.Equal(t, "active", created.Status)
+	})
+
+	t.Run("archived is rejected", func(t *testing.T) {
+		service, mockRepo := newTestService()
+
+		_, err := service.Create(ctx, "tenant-123", "user-1", CreateWorkflowInput{Name: "Installed", Definition: definition, Status: WorkflowStatusArchived})
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import "context"
+
+type triggerNodeKey struct{}
+
+// WithTriggerNode returns a context naming the trigger node that fired.
+// Executions created with it start from that node and skip its sibling
+// triggers.
+func WithTriggerNode(ctx context.Context, nodeID string) context.Context {
+	return context.WithValue(ctx, triggerNodeKey{}, nodeID)
+}
+
+// TriggerNodeFromContext returns the trigger node set by WithTriggerNode, or ""
+func TriggerNodeFromContext(ctx context.Context) string {
+	nodeID, _ := ctx.Value(triggerNodeKey{}).(string)
+	return nodeID
+}
+
+// triggerNodeTypes maps execution trigger types to the node type that fires them
+var triggerNodeTypes = map[string]NodeType{
+	"webhook":        NodeTypeTriggerWebhook,
+	"webhook_replay": NodeTypeTriggerWebhook,
+	"schedule":       NodeTypeTriggerSchedule,
+}
+
+// EntryTrigger returns the trigger node an execution starts from: the node
+// recorded on the execution, or else the only trigger node of the type that
+// fired. It returns "" when no single trigger can be identified, such as for
+// manual runs, in which case every trigger is an entry point.
+func EntryTrigger(def *WorkflowDefinition, execution *Execution) string {
+	if execution.TriggerNodeID != nil {
+		for _, node := range def.Nodes {
+			if node.ID == *execution.TriggerNodeID && isTriggerType(node.Type) {
+				return node.ID
+			}
+		}
+	}
+
+	nodeType, ok := triggerNodeTypes[execution.TriggerType]
+	if !ok {
+		return ""
+	}
+
+	entry := ""
+	for _, node := range def.Nodes {
+		if node.Type != string(nodeType) {
+			continue
+		}
+		if entry != "" {
+			return ""
+		}
+		entry = node.ID
+	}
+	return entry
+}
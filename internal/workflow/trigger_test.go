@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryTrigger(t *testing.T) {
+	def := &WorkflowDefinition{
+		Nodes: []Node{
+			{ID: "github", Type: string(NodeTypeTriggerWebhook)},
+			{ID: "stripe", Type: string(NodeTypeTriggerWebhook)},
+			{ID: "nightly", Type: string(NodeTypeTriggerSchedule)},
+			{ID: "action", Type: string(NodeTypeActionHTTP)},
+		},
+	}
+	nodeID := func(id string) *string { return &id }
+
+	tests := []struct {
+		name      string
+		execution *Execution
+		want      string
+	}{
+		{name: "recorded trigger node", execution: &Execution{TriggerType: "webhook", TriggerNodeID: nodeID("stripe")}, want: "stripe"},
+		{name: "only trigger of the type", execution: &Execution{TriggerType: "schedule"}, want: "nightly"},
+		{name: "several triggers of the type", execution: &Execution{TriggerType: "webhook"}},
+		{name: "recorded node is not a trigger", execution: &Execution{TriggerType: "webhook", TriggerNodeID: nodeID("action")}},
+		{name: "manual", execution: &Execution{TriggerType: "manual"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EntryTrigger(def, tt.execution))
+		})
+	}
+}
+
+func TestTriggerNodeFromContext(t *testing.T) {
+	assert.Empty(t, TriggerNodeFromContext(context.Background()))
+	assert.Equal(t, "github", TriggerNodeFromContext(WithTriggerNode(context.Background(), "github")))
+}
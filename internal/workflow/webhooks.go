@@ -1,6 +1,10 @@
 package workflow
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
 
 // WebhookService interface to avoid circular dependency
 type WebhookService interface {
@@ -11,8 +15,60 @@ type WebhookService interface {
 
 // WebhookNodeConfig represents a webhook trigger node configuration
 type WebhookNodeConfig struct {
-	NodeID   string
-	AuthType string
+	NodeID          string
+	AuthType        string
+	SignatureScheme string
+	// Secret is a signing secret set in the node config, such as one issued
+	// by Slack or Stripe. Empty when the webhook should use a generated secret.
+	Secret string
+	// IdempotencyHeader names the header holding the provider's delivery ID;
+	// repeated IDs within IdempotencyTTL do not trigger new executions
+	IdempotencyHeader string
+	IdempotencyTTL    string
+	// PayloadSchema is a JSON Schema that request bodies must match
+	PayloadSchema json.RawMessage
+	// EventType names a registered event type request bodies must match;
+	// EventTypeVersion 0 follows the latest version
+	EventType        string
+	EventTypeVersion int
+}
+
+// newWebhookNodeConfig reads the webhook settings of a trigger node, defaulting
+// to signature auth when the config is missing or invalid
+func newWebhookNodeConfig(node Node) WebhookNodeConfig {
+	nodeConfig := WebhookNodeConfig{
+		NodeID:   node.ID,
+		AuthType: AuthTypeSignature,
+	}
+
+	raw := node.Data.Config
+	if raw == nil {
+		raw = node.Config
+	}
+	if raw == nil {
+		return nodeConfig
+	}
+
+	var config WebhookTriggerConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nodeConfig
+	}
+
+	if config.AuthType != "" {
+		nodeConfig.AuthType = config.AuthType
+	}
+	nodeConfig.SignatureScheme = config.SignatureScheme
+	nodeConfig.IdempotencyHeader = config.IdempotencyHeader
+	nodeConfig.IdempotencyTTL = config.IdempotencyTTL
+	nodeConfig.PayloadSchema = config.PayloadSchema
+	nodeConfig.EventType = config.EventType
+	nodeConfig.EventTypeVersion = config.EventTypeVersion
+	// Expressions such as ${env.SECRET} are not resolved when webhooks are synced
+	if !strings.Contains(config.Secret, "${") {
+		nodeConfig.Secret = config.Secret
+	}
+
+	return nodeConfig
 }
 
 // WebhookInfo contains webhook information for responses
@@ -0,0 +1,8 @@
+-- Add per-outcome response configuration to webhooks
+-- Lets integrations (e.g. Slack) return a specific acknowledgment body whether
+-- the event passed filters, was dropped by a filter, or failed
+
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS response_config JSONB;
+
+COMMENT ON COLUMN webhooks.response_config IS 'Optional responses keyed by outcome (passed, filtered, error), each with statusCode, body and contentType';
@@ -0,0 +1,10 @@
+-- Add business metadata tags to executions
+-- Tags are set during a run by action:set_tags nodes (e.g. order_id, customer_id)
+-- and searched with JSONB containment
+
+ALTER TABLE executions
+ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_executions_tags ON executions USING GIN (tags);
+
+COMMENT ON COLUMN executions.tags IS 'String key/value tags set during execution, searchable with @> containment';
@@ -0,0 +1,17 @@
+-- Automatic pausing of workflows with sustained high failure rates
+-- auto_pause_config overrides the tenant default (tenants.settings->'workflow_auto_pause')
+-- auto_paused_at/auto_pause_reason record why a workflow was paused; they are
+-- cleared when the workflow is manually re-enabled
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS auto_pause_config JSONB,
+ADD COLUMN IF NOT EXISTS auto_paused_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS auto_pause_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_executions_workflow_completed
+    ON executions (workflow_id, completed_at DESC)
+    WHERE completed_at IS NOT NULL;
+
+COMMENT ON COLUMN workflows.auto_pause_config IS 'Per-workflow failure rate auto-pause thresholds; NULL uses the tenant default';
+COMMENT ON COLUMN workflows.auto_paused_at IS 'When the workflow was automatically paused for a high failure rate';
+COMMENT ON COLUMN workflows.auto_pause_reason IS 'Why the workflow was automatically paused';
@@ -0,0 +1,13 @@
+-- Interval-based schedules (e.g. every 30s or 5m) as an alternative to cron
+-- A schedule uses exactly one of cron_expression or run_interval
+
+ALTER TABLE schedules
+ALTER COLUMN cron_expression SET DEFAULT '';
+
+ALTER TABLE schedules
+ADD COLUMN IF NOT EXISTS run_interval VARCHAR(32) NOT NULL DEFAULT '';
+
+ALTER TABLE schedules
+ADD CONSTRAINT schedule_cron_xor_interval CHECK ((cron_expression = '') <> (run_interval = ''));
+
+COMMENT ON COLUMN schedules.run_interval IS 'Go duration (e.g. 30s, 5m) between runs; empty when cron_expression is used';
@@ -0,0 +1,15 @@
+-- Misfire (catch-up) policy for schedules whose runs were missed during downtime
+-- last_fired_at records the scheduled occurrence time of the most recent run so
+-- missed occurrences can be computed when the scheduler starts
+
+ALTER TABLE schedules
+ADD COLUMN IF NOT EXISTS misfire_policy VARCHAR(20) NOT NULL DEFAULT 'fire_once';
+
+ALTER TABLE schedules
+ADD CONSTRAINT valid_misfire_policy CHECK (misfire_policy IN ('skip', 'fire_once', 'fire_all'));
+
+ALTER TABLE schedules
+ADD COLUMN IF NOT EXISTS last_fired_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN schedules.misfire_policy IS 'What to do with runs missed while the scheduler was down: skip, fire_once, fire_all';
+COMMENT ON COLUMN schedules.last_fired_at IS 'Scheduled occurrence time of the most recent run';
@@ -0,0 +1,22 @@
+-- Pause/resume for schedules
+-- A paused schedule keeps its cron expression or interval but is not dispatched
+-- until resumed. paused_by/paused_at and resumed_by/resumed_at record who
+-- changed the status and when, for audit.
+
+ALTER TABLE schedules
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+ALTER TABLE schedules
+ADD CONSTRAINT valid_schedule_status CHECK (status IN ('active', 'paused'));
+
+ALTER TABLE schedules
+ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS paused_by UUID,
+ADD COLUMN IF NOT EXISTS resumed_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS resumed_by UUID;
+
+CREATE INDEX IF NOT EXISTS idx_schedules_status ON schedules(tenant_id, status);
+
+COMMENT ON COLUMN schedules.status IS 'Schedule status: active or paused. Paused schedules are not dispatched';
+COMMENT ON COLUMN schedules.paused_by IS 'User who last paused the schedule';
+COMMENT ON COLUMN schedules.resumed_by IS 'User who last resumed the schedule';
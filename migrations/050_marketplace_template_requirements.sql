@@ -0,0 +1,10 @@
+-- Prerequisite manifest for marketplace templates
+-- Credentials and environment variables referenced by a template's definition,
+-- extracted at publish time so installers can be told what to configure first
+
+ALTER TABLE marketplace_templates
+ADD COLUMN IF NOT EXISTS required_credentials TEXT[] NOT NULL DEFAULT '{}',
+ADD COLUMN IF NOT EXISTS required_env_vars TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN marketplace_templates.required_credentials IS 'Credential names referenced by the template definition';
+COMMENT ON COLUMN marketplace_templates.required_env_vars IS 'Environment variables referenced by the template definition';
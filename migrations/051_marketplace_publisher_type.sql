@@ -0,0 +1,20 @@
+-- Publisher trust level for marketplace templates
+-- system: built-in templates shipped with the platform
+-- verified: templates from publishers vetted by a platform admin
+-- community: everything else
+
+ALTER TABLE marketplace_templates
+ADD COLUMN IF NOT EXISTS publisher_type VARCHAR(20) NOT NULL DEFAULT 'community'
+    CHECK (publisher_type IN ('system', 'verified', 'community'));
+
+UPDATE marketplace_templates
+SET publisher_type = 'system', is_verified = TRUE
+WHERE author_id = 'system';
+
+UPDATE marketplace_templates
+SET publisher_type = 'verified'
+WHERE is_verified = TRUE AND publisher_type = 'community';
+
+CREATE INDEX IF NOT EXISTS idx_marketplace_templates_publisher_type ON marketplace_templates(publisher_type);
+
+COMMENT ON COLUMN marketplace_templates.publisher_type IS 'Publisher trust level: system, verified or community';
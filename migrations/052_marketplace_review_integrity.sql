@@ -0,0 +1,36 @@
+-- Review integrity for the marketplace
+-- Rating aggregates are now recalculated from the reviews in the same
+-- transaction as every review change, so the incremental trigger (which also
+-- counted hidden reviews) is no longer needed
+
+DROP TRIGGER IF EXISTS marketplace_reviews_rating_distribution ON marketplace_reviews;
+DROP FUNCTION IF EXISTS update_template_rating_distribution();
+
+-- Supports the per-user daily limit on new reviews
+CREATE INDEX IF NOT EXISTS idx_marketplace_reviews_user_created
+    ON marketplace_reviews(tenant_id, user_id, created_at);
+
+-- Bring existing aggregates in line with the visible reviews
+UPDATE marketplace_templates mt
+SET average_rating = COALESCE(stats.average_rating, 0),
+    total_ratings = COALESCE(stats.total_ratings, 0),
+    rating_1_count = COALESCE(stats.rating_1_count, 0),
+    rating_2_count = COALESCE(stats.rating_2_count, 0),
+    rating_3_count = COALESCE(stats.rating_3_count, 0),
+    rating_4_count = COALESCE(stats.rating_4_count, 0),
+    rating_5_count = COALESCE(stats.rating_5_count, 0)
+FROM marketplace_templates t
+LEFT JOIN (
+    SELECT template_id,
+           AVG(rating) AS average_rating,
+           COUNT(*) AS total_ratings,
+           COUNT(*) FILTER (WHERE rating = 1) AS rating_1_count,
+           COUNT(*) FILTER (WHERE rating = 2) AS rating_2_count,
+           COUNT(*) FILTER (WHERE rating = 3) AS rating_3_count,
+           COUNT(*) FILTER (WHERE rating = 4) AS rating_4_count,
+           COUNT(*) FILTER (WHERE rating = 5) AS rating_5_count
+    FROM marketplace_reviews
+    WHERE deleted_at IS NULL AND is_hidden = false
+    GROUP BY template_id
+) stats ON stats.template_id = t.id
+WHERE mt.id = t.id;
@@ -0,0 +1,11 @@
+-- Index for execution success-rate and latency statistics
+-- GetExecutionStats filters on (tenant_id, created_at) and aggregates by status.
+-- idx_executions_tenant_status (004) covers (tenant_id, status, created_at DESC)
+-- for status-filtered lookups; this covering index adds the columns the
+-- percentile aggregation reads so the stats queries can use index-only scans.
+-- On large tables, consider building it with CREATE INDEX CONCURRENTLY
+-- outside a transaction instead.
+
+CREATE INDEX IF NOT EXISTS idx_executions_tenant_status_created_stats
+    ON executions(tenant_id, status, created_at)
+    INCLUDE (workflow_id, started_at, completed_at);
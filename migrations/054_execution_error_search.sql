@@ -0,0 +1,15 @@
+-- Trigram index for searching executions by error message
+-- 010 creates this index but leaves enabling pg_trgm commented out, so it
+-- only applied where the extension had been installed by hand. Without it,
+-- ILIKE '%text%' searches fall back to a sequential scan.
+-- Note: creating the extension requires sufficient privileges
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_executions_error_message_gin
+ON executions
+USING gin (error_message gin_trgm_ops)
+WHERE error_message IS NOT NULL;
+
+-- Rollback instructions:
+-- DROP INDEX IF EXISTS idx_executions_error_message_gin;
@@ -0,0 +1,14 @@
+-- Execution cancellation
+-- Records who cancelled an execution and when. Executors check the execution
+-- status between nodes, so a cancelled status also stops executions running
+-- on other workers.
+
+ALTER TABLE executions
+ADD COLUMN IF NOT EXISTS cancelled_by VARCHAR(255),
+ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN executions.cancelled_by IS 'User who cancelled the execution';
+COMMENT ON COLUMN executions.cancelled_at IS 'When the execution was cancelled';
+
+-- Rollback instructions:
+-- ALTER TABLE executions DROP COLUMN IF EXISTS cancelled_by, DROP COLUMN IF EXISTS cancelled_at;
@@ -0,0 +1,19 @@
+-- Execution retry from a failed node
+-- A retried execution links back to the execution it retries and records the
+-- node it resumed at. Steps before that node are copied from the original
+-- execution, so the executor reuses their outputs instead of running them again.
+
+ALTER TABLE executions
+ADD COLUMN IF NOT EXISTS retry_of_execution_id UUID REFERENCES executions(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS resume_from_node_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_executions_retry_of
+ON executions(retry_of_execution_id)
+WHERE retry_of_execution_id IS NOT NULL;
+
+COMMENT ON COLUMN executions.retry_of_execution_id IS 'Execution this execution retries';
+COMMENT ON COLUMN executions.resume_from_node_id IS 'Node the retried execution resumed at';
+
+-- Rollback instructions:
+-- DROP INDEX IF EXISTS idx_executions_retry_of;
+-- ALTER TABLE executions DROP COLUMN IF EXISTS retry_of_execution_id, DROP COLUMN IF EXISTS resume_from_node_id;
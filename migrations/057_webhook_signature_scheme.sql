@@ -0,0 +1,17 @@
+-- Webhook signature schemes
+-- Signature-authenticated webhooks verify requests with a provider-specific
+-- scheme. Slack and Stripe sign a timestamp with the body, so requests outside
+-- the replay tolerance are rejected.
+
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS signature_scheme VARCHAR(20) NOT NULL DEFAULT 'generic';
+
+ALTER TABLE webhooks
+ADD CONSTRAINT webhooks_signature_scheme_check
+CHECK (signature_scheme IN ('generic', 'github', 'slack', 'stripe'));
+
+COMMENT ON COLUMN webhooks.signature_scheme IS 'Signature verifier: generic, github, slack or stripe';
+
+-- Rollback instructions:
+-- ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_signature_scheme_check;
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS signature_scheme;
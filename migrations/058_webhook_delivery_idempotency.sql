@@ -0,0 +1,28 @@
+-- Webhook delivery idempotency
+-- Providers retry deliveries, so a webhook can name a header carrying the
+-- provider's delivery ID (such as X-GitHub-Delivery). Seen IDs are kept until
+-- they expire; a repeated ID within that window does not trigger a new execution.
+
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS idempotency_header VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS idempotency_ttl_seconds INTEGER NOT NULL DEFAULT 86400;
+
+COMMENT ON COLUMN webhooks.idempotency_header IS 'Request header with the provider delivery ID; empty disables deduplication';
+COMMENT ON COLUMN webhooks.idempotency_ttl_seconds IS 'How long delivery IDs are remembered';
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (webhook_id, delivery_id)
+);
+
+-- Supports the cleanup sweep of expired delivery IDs
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_expires_at ON webhook_deliveries(expires_at);
+
+COMMENT ON TABLE webhook_deliveries IS 'Seen webhook delivery IDs used to drop provider retries';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS webhook_deliveries;
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS idempotency_header, DROP COLUMN IF EXISTS idempotency_ttl_seconds;
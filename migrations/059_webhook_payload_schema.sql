@@ -0,0 +1,11 @@
+-- Webhook payload schema validation
+-- An optional JSON Schema that incoming webhook bodies must match. Requests
+-- that do not match are rejected with 422 before any execution starts.
+
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS payload_schema JSONB;
+
+COMMENT ON COLUMN webhooks.payload_schema IS 'JSON Schema that request bodies must match; NULL accepts any body';
+
+-- Rollback instructions:
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS payload_schema;
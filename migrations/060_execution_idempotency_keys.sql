@@ -0,0 +1,24 @@
+-- Execution idempotency keys
+-- Clients send an Idempotency-Key header when executing a workflow so that a
+-- retried request returns the original execution instead of starting another.
+-- A key is bound to a hash of the workflow and request body until it expires.
+
+CREATE TABLE IF NOT EXISTS execution_idempotency_keys (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    execution_id UUID REFERENCES executions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, idempotency_key)
+);
+
+-- Supports purging expired keys
+CREATE INDEX IF NOT EXISTS idx_execution_idempotency_keys_expires_at ON execution_idempotency_keys(expires_at);
+
+COMMENT ON TABLE execution_idempotency_keys IS 'Idempotency-Key values mapped to the execution they created';
+COMMENT ON COLUMN execution_idempotency_keys.request_hash IS 'SHA-256 of the workflow ID and request body; a different hash for the same key is rejected';
+COMMENT ON COLUMN execution_idempotency_keys.execution_id IS 'NULL while the first request with the key is still creating its execution';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS execution_idempotency_keys;
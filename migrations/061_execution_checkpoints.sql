@@ -0,0 +1,11 @@
+-- Execution checkpoints
+-- A worker that cannot finish an execution within its drain timeout on
+-- shutdown puts the execution back to pending with resume_from_node_id set to
+-- the node it was running, so the next worker reuses the completed steps.
+
+ALTER TABLE executions ADD COLUMN IF NOT EXISTS checkpointed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN executions.checkpointed_at IS 'When a draining worker last returned the execution to pending; stale pending executions are measured from here instead of created_at';
+
+-- Rollback instructions:
+-- ALTER TABLE executions DROP COLUMN IF EXISTS checkpointed_at;
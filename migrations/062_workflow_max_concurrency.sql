@@ -0,0 +1,11 @@
+-- Per-workflow concurrency limit
+-- Workflows that call rate-limited APIs can cap how many of their executions
+-- run at once on each worker. Executions over the limit wait for a slot.
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS max_concurrency INTEGER CHECK (max_concurrency IS NULL OR max_concurrency > 0);
+
+COMMENT ON COLUMN workflows.max_concurrency IS 'Maximum executions of the workflow running at once per worker; NULL means only the worker and tenant limits apply';
+
+-- Rollback instructions:
+-- ALTER TABLE workflows DROP COLUMN IF EXISTS max_concurrency;
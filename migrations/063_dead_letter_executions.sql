@@ -0,0 +1,38 @@
+-- Dead-letter queue for failed executions
+-- Every top-level execution that ends in failed status is recorded with its
+-- failure reason and trigger payload so it can be inspected and requeued.
+-- A workflow can name an on-failure workflow that is started with the
+-- failure context.
+
+CREATE TABLE IF NOT EXISTS dead_letter_executions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    workflow_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    execution_id UUID NOT NULL UNIQUE REFERENCES executions(id) ON DELETE CASCADE,
+    workflow_version INTEGER NOT NULL,
+    trigger_type VARCHAR(50) NOT NULL,
+    payload JSONB,
+    failure_reason TEXT NOT NULL,
+    failed_node_id VARCHAR(255),
+    failed_node_type VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'requeued')),
+    requeued_execution_id UUID REFERENCES executions(id) ON DELETE SET NULL,
+    requeued_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letter_executions_tenant_created ON dead_letter_executions(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_dead_letter_executions_tenant_workflow ON dead_letter_executions(tenant_id, workflow_id, created_at DESC);
+
+COMMENT ON TABLE dead_letter_executions IS 'Failed executions awaiting inspection or requeue';
+COMMENT ON COLUMN dead_letter_executions.payload IS 'Trigger data of the failed execution, reused when it is requeued';
+COMMENT ON COLUMN dead_letter_executions.requeued_execution_id IS 'Execution started when the entry was requeued';
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS on_failure_workflow_id UUID REFERENCES workflows(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN workflows.on_failure_workflow_id IS 'Workflow started with the failure context when an execution of this workflow fails';
+
+-- Rollback instructions:
+-- ALTER TABLE workflows DROP COLUMN IF EXISTS on_failure_workflow_id;
+-- DROP TABLE IF EXISTS dead_letter_executions;
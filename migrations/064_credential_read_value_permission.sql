@@ -0,0 +1,21 @@
+-- Credential value permission
+-- Decrypting a credential's value through GET /api/v1/credentials/{id}/value
+-- needs credential:read_value. credential:read only covers metadata.
+
+INSERT INTO permissions (resource, action, description) VALUES
+    ('credential', 'read_value', 'Read decrypted credential values')
+ON CONFLICT (resource, action) DO NOTHING;
+
+-- Existing admin roles keep full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.name = 'admin'
+AND r.is_system = true
+AND p.resource = 'credential'
+AND p.action = 'read_value'
+ON CONFLICT DO NOTHING;
+
+-- Rollback instructions:
+-- DELETE FROM permissions WHERE resource = 'credential' AND action = 'read_value';
@@ -0,0 +1,34 @@
+-- Event type schema versions
+-- Producers register JSON Schemas for event types. Each version's schema can
+-- gain backward-compatible additions; breaking changes need a new version.
+-- event_types.schema and event_types.version keep the latest version.
+
+CREATE TABLE IF NOT EXISTS event_type_versions (
+    event_type_id UUID NOT NULL REFERENCES event_types(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    schema JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_type_id, version)
+);
+
+COMMENT ON TABLE event_type_versions IS 'JSON Schema of each registered version of an event type';
+
+-- Existing event types become their own first recorded version
+INSERT INTO event_type_versions (event_type_id, version, schema, created_at, updated_at)
+SELECT id, version, schema, created_at, updated_at
+FROM event_types
+ON CONFLICT (event_type_id, version) DO NOTHING;
+
+-- Webhooks can require payloads to match a registered event type
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS event_type VARCHAR(100),
+ADD COLUMN IF NOT EXISTS event_type_version INTEGER CHECK (event_type_version IS NULL OR event_type_version > 0);
+
+COMMENT ON COLUMN webhooks.event_type IS 'Registered event type that request bodies must match; NULL skips the check';
+COMMENT ON COLUMN webhooks.event_type_version IS 'Pinned event type version; NULL follows the latest version';
+
+-- Rollback instructions:
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS event_type_version;
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS event_type;
+-- DROP TABLE IF EXISTS event_type_versions;
@@ -0,0 +1,15 @@
+-- OAuth client credentials grant
+-- Providers record which grant issues their tokens. Client credentials
+-- connections are service-to-service: they belong to a tenant rather than a
+-- user and are stored with an empty user_id.
+
+ALTER TABLE oauth_providers
+ADD COLUMN IF NOT EXISTS grant_type VARCHAR(30) NOT NULL DEFAULT 'authorization_code'
+    CHECK (grant_type IN ('authorization_code', 'client_credentials'));
+
+ALTER TABLE oauth_connections
+ADD COLUMN IF NOT EXISTS grant_type VARCHAR(30) NOT NULL DEFAULT 'authorization_code'
+    CHECK (grant_type IN ('authorization_code', 'client_credentials'));
+
+COMMENT ON COLUMN oauth_providers.grant_type IS 'OAuth 2.0 grant used to obtain tokens: authorization_code (user flow) or client_credentials (service flow)';
+COMMENT ON COLUMN oauth_connections.grant_type IS 'Grant that issued the connection tokens; client_credentials connections have an empty user_id and re-request tokens instead of refreshing';
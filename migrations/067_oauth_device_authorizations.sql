@@ -0,0 +1,33 @@
+-- OAuth device authorization grant (RFC 8628)
+-- Device authorizations let CLI and headless clients connect without a browser
+-- redirect: the user approves the user_code on another device while the
+-- device_code is polled at the provider's interval until it is approved,
+-- denied, or expires.
+
+CREATE TABLE IF NOT EXISTS oauth_device_authorizations (
+    device_code VARCHAR(512) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    provider_key VARCHAR(50) NOT NULL,
+    user_code VARCHAR(64) NOT NULL,
+    verification_uri TEXT NOT NULL,
+    verification_uri_complete TEXT,
+    scopes TEXT[],
+    interval_seconds INTEGER NOT NULL DEFAULT 5,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    connection_id UUID REFERENCES oauth_connections(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    next_poll_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_polled_at TIMESTAMPTZ,
+
+    CONSTRAINT oauth_device_authorizations_status_check CHECK (status IN ('pending', 'approved', 'denied', 'expired'))
+);
+
+-- Index for the background poller
+CREATE INDEX IF NOT EXISTS idx_oauth_device_authorizations_due ON oauth_device_authorizations(next_poll_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_oauth_device_authorizations_user_tenant ON oauth_device_authorizations(user_id, tenant_id);
+
+COMMENT ON TABLE oauth_device_authorizations IS 'Pending and completed OAuth device authorization grants (RFC 8628)';
+COMMENT ON COLUMN oauth_device_authorizations.interval_seconds IS 'Minimum polling interval; increased by 5 seconds whenever the provider answers slow_down';
+COMMENT ON COLUMN oauth_device_authorizations.next_poll_at IS 'Earliest time the device code may be polled again';
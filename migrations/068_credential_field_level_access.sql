@@ -0,0 +1,13 @@
+-- Credential field-level value access
+-- GET /api/v1/credentials/{id}/value?keys=... returns only the requested keys
+-- of a credential value. credential:read_value still grants every key;
+-- credential:read_value:<key> permissions grant individual keys. The access
+-- log records which keys each field-level read asked for.
+
+ALTER TABLE credential_access_log
+ADD COLUMN IF NOT EXISTS keys TEXT[];
+
+COMMENT ON COLUMN credential_access_log.keys IS 'Value keys requested by a field-level read; NULL when the whole value was read';
+
+-- Rollback instructions:
+-- ALTER TABLE credential_access_log DROP COLUMN IF EXISTS keys;
@@ -0,0 +1,13 @@
+-- Per-workflow webhook event retention
+-- Audit-sensitive workflows can keep their webhook events longer than the
+-- global CLEANUP_RETENTION_DAYS retention, and noisy ones can purge them sooner.
+-- The cleanup sweep deletes each workflow's events with its own window and
+-- skips those workflows in the global sweep.
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS event_retention_days INTEGER CHECK (event_retention_days IS NULL OR event_retention_days > 0);
+
+COMMENT ON COLUMN workflows.event_retention_days IS 'Days to keep the workflow''s webhook events; NULL means the global retention applies';
+
+-- Rollback instructions:
+-- ALTER TABLE workflows DROP COLUMN IF EXISTS event_retention_days;
@@ -0,0 +1,13 @@
+-- Per-tenant KMS keys
+-- Tenants can have their own KMS key wrapping the data keys of their
+-- credentials and OAuth tokens. New secrets use the tenant's key; existing
+-- secrets keep the kms_key_id they were encrypted with until a rotation
+-- re-wraps their data keys.
+
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS kms_key_id VARCHAR(255);
+
+COMMENT ON COLUMN tenants.kms_key_id IS 'KMS key ID or ARN wrapping the tenant''s data keys; NULL means the platform default key';
+
+-- Rollback instructions:
+-- ALTER TABLE tenants DROP COLUMN IF EXISTS kms_key_id;
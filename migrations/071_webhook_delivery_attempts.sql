@@ -0,0 +1,29 @@
+-- Webhook delivery attempts
+-- Every delivery of a webhook event to its workflow is recorded, including
+-- the background retries of failed deliveries, so the attempt count, outcome
+-- and next retry time of each delivery can be inspected.
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES webhook_events(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    status_code INTEGER,
+    error TEXT,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    next_retry_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_webhook
+    ON webhook_delivery_attempts(webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_event
+    ON webhook_delivery_attempts(event_id, attempt);
+
+COMMENT ON TABLE webhook_delivery_attempts IS 'Delivery attempts of webhook events, including background retries';
+COMMENT ON COLUMN webhook_delivery_attempts.next_retry_at IS 'When the next retry is due after a failed attempt; NULL if no retry is scheduled';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS webhook_delivery_attempts;
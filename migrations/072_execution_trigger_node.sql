@@ -0,0 +1,12 @@
+-- Execution trigger node
+-- Workflows can have several trigger nodes, such as a schedule and a webhook.
+-- An execution records the trigger node that fired so it starts from that
+-- node and skips its sibling triggers.
+
+ALTER TABLE executions
+ADD COLUMN IF NOT EXISTS trigger_node_id VARCHAR(255);
+
+COMMENT ON COLUMN executions.trigger_node_id IS 'Trigger node that fired the execution; NULL when it is resolved from trigger_type';
+
+-- Rollback instructions:
+-- ALTER TABLE executions DROP COLUMN IF EXISTS trigger_node_id;
@@ -0,0 +1,12 @@
+-- OAuth logs without a connection
+-- Authorizations that fail at the provider, such as a user denying access,
+-- are logged before any connection exists.
+
+ALTER TABLE oauth_connection_logs
+ALTER COLUMN connection_id DROP NOT NULL;
+
+COMMENT ON COLUMN oauth_connection_logs.connection_id IS 'Connection the entry is about; NULL for authorizations that failed before a connection was created';
+
+-- Rollback instructions:
+-- DELETE FROM oauth_connection_logs WHERE connection_id IS NULL;
+-- ALTER TABLE oauth_connection_logs ALTER COLUMN connection_id SET NOT NULL;
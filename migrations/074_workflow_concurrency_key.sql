@@ -0,0 +1,23 @@
+-- Workflow concurrency key
+-- Workflows such as a nightly sync must not overlap. While an execution with
+-- a concurrency key is running, further executions with the same key in the
+-- tenant are skipped. The partial unique index makes the check atomic across
+-- workers.
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS concurrency_key VARCHAR(255);
+
+ALTER TABLE executions
+ADD COLUMN IF NOT EXISTS concurrency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_executions_running_concurrency_key
+ON executions (tenant_id, concurrency_key)
+WHERE status = 'running' AND concurrency_key IS NOT NULL;
+
+COMMENT ON COLUMN workflows.concurrency_key IS 'Executions with the same key never run at once in the tenant; NULL means executions may overlap';
+COMMENT ON COLUMN executions.concurrency_key IS 'Concurrency key held by the execution while it runs';
+
+-- Rollback instructions:
+-- DROP INDEX IF EXISTS idx_executions_running_concurrency_key;
+-- ALTER TABLE executions DROP COLUMN IF EXISTS concurrency_key;
+-- ALTER TABLE workflows DROP COLUMN IF EXISTS concurrency_key;
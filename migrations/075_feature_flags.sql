@@ -0,0 +1,38 @@
+-- Feature flags
+-- Flags gate endpoints and experimental node types. Each flag has a global
+-- default, and a tenant override replaces the default for that tenant only.
+-- The seeded flags cover features that already shipped, so they default to
+-- enabled and existing tenants keep their current behavior.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tenant_feature_flags (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    flag_key VARCHAR(100) NOT NULL REFERENCES feature_flags(key) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL,
+    updated_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, flag_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_feature_flags_flag_key ON tenant_feature_flags(flag_key);
+
+INSERT INTO feature_flags (key, description, enabled) VALUES
+    ('ai_builder', 'AI workflow builder endpoints', true),
+    ('switch_node', 'Experimental control:switch node type', true),
+    ('database_action', 'Experimental action:database node type', true)
+ON CONFLICT (key) DO NOTHING;
+
+COMMENT ON TABLE feature_flags IS 'Feature flags and their global defaults';
+COMMENT ON TABLE tenant_feature_flags IS 'Per-tenant feature flag overrides of the global default';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS tenant_feature_flags;
+-- DROP TABLE IF EXISTS feature_flags;
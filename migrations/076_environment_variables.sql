@@ -0,0 +1,29 @@
+-- Environment variables
+-- Non-secret values such as API base URLs that workflows read as ${env.KEY}.
+-- Tenant-wide variables have no workflow_id; a workflow's own variable
+-- overrides the tenant-wide one with the same key. Secrets belong in
+-- credentials, not here: values are stored and returned in plain text.
+
+CREATE TABLE IF NOT EXISTS environment_variables (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    workflow_id UUID REFERENCES workflows(id) ON DELETE CASCADE,
+    key VARCHAR(100) NOT NULL,
+    value TEXT NOT NULL,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_environment_variables_tenant_key
+ON environment_variables (tenant_id, key)
+WHERE workflow_id IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_environment_variables_workflow_key
+ON environment_variables (workflow_id, key)
+WHERE workflow_id IS NOT NULL;
+
+COMMENT ON TABLE environment_variables IS 'Non-secret ${env.*} values per tenant, optionally overridden per workflow';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS environment_variables;
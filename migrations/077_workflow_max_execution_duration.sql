@@ -0,0 +1,12 @@
+-- Workflow execution timeout
+-- A stuck node could keep an execution running forever. Executions that run
+-- longer than the workflow's max_execution_duration, or the global ceiling
+-- from WORKFLOW_MAX_EXECUTION_DURATION, are stopped and marked failed.
+
+ALTER TABLE workflows
+ADD COLUMN IF NOT EXISTS max_execution_duration INTEGER;
+
+COMMENT ON COLUMN workflows.max_execution_duration IS 'Seconds an execution may run before it fails with execution_timeout; NULL means only the global ceiling applies';
+
+-- Rollback instructions:
+-- ALTER TABLE workflows DROP COLUMN IF EXISTS max_execution_duration;
@@ -0,0 +1,29 @@
+-- Slack interactions
+-- A Slack message with buttons embeds a token that ties clicks, and slash
+-- commands quoting it, back to the execution that sent it. The execution's
+-- slack:wait_for_response node waits until the interaction is answered or
+-- expires.
+
+CREATE TABLE IF NOT EXISTS slack_interactions (
+    token VARCHAR(64) PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    execution_id UUID NOT NULL REFERENCES executions(id) ON DELETE CASCADE,
+    node_id VARCHAR(255) NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, responded, expired
+    value TEXT,
+    source VARCHAR(20), -- button, slash_command
+    responder_id VARCHAR(255),
+    responder_name VARCHAR(255),
+    responded_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_interactions_execution
+ON slack_interactions (execution_id);
+
+COMMENT ON TABLE slack_interactions IS 'Slack button and slash-command responses awaited by executions';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS slack_interactions;
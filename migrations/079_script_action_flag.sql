@@ -0,0 +1,10 @@
+-- Feature flag for the action:script node
+-- Sandboxed scripts are new, so the flag starts disabled; enable it globally
+-- or per tenant once the limits have been reviewed.
+
+INSERT INTO feature_flags (key, description, enabled) VALUES
+    ('script_action', 'Experimental action:script node type', false)
+ON CONFLICT (key) DO NOTHING;
+
+-- Rollback instructions:
+-- DELETE FROM feature_flags WHERE key = 'script_action';
@@ -0,0 +1,27 @@
+-- Soft-deleted credentials
+-- Deleting a credential hides it instead of removing the row, so it can be
+-- restored until the purge window from CREDENTIAL_DELETED_RETENTION passes and
+-- the worker removes it. Names only need to be unique among live credentials.
+
+ALTER TABLE credentials
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS deleted_by UUID;
+
+ALTER TABLE credentials
+DROP CONSTRAINT IF EXISTS unique_credential_name_per_tenant;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credentials_tenant_name_live
+    ON credentials(tenant_id, name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_credentials_deleted_at
+    ON credentials(deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN credentials.deleted_at IS 'When the credential was deleted; NULL for live credentials. Deleted credentials are hidden and purged after the retention window';
+COMMENT ON COLUMN credentials.deleted_by IS 'User who deleted the credential';
+
+-- Rollback instructions:
+-- DELETE FROM credentials WHERE deleted_at IS NOT NULL;
+-- DROP INDEX IF EXISTS idx_credentials_deleted_at;
+-- DROP INDEX IF EXISTS idx_credentials_tenant_name_live;
+-- ALTER TABLE credentials ADD CONSTRAINT unique_credential_name_per_tenant UNIQUE (tenant_id, name);
+-- ALTER TABLE credentials DROP COLUMN IF EXISTS deleted_by, DROP COLUMN IF EXISTS deleted_at;
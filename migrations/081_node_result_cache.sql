@@ -0,0 +1,27 @@
+-- Node result cache
+-- Nodes that opt in with a "cache" block reuse a prior result while it is
+-- fresh. Entries are keyed by a hash of the node's resolved config, scoped to
+-- the tenant, and removed by the worker once they expire.
+
+CREATE TABLE IF NOT EXISTS node_result_cache (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    cache_key CHAR(64) NOT NULL,
+    workflow_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    node_id VARCHAR(255) NOT NULL,
+    output JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, cache_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_result_cache_workflow
+ON node_result_cache (tenant_id, workflow_id, node_id);
+
+CREATE INDEX IF NOT EXISTS idx_node_result_cache_expires_at
+ON node_result_cache (expires_at);
+
+COMMENT ON TABLE node_result_cache IS 'Cached results of workflow nodes that opt in to caching';
+COMMENT ON COLUMN node_result_cache.cache_key IS 'SHA-256 of the workflow, node and resolved node config';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS node_result_cache;
@@ -0,0 +1,12 @@
+-- Tenant data regions
+-- Tenants pinned to a data region have their credentials encrypted with the
+-- region's KMS key and their executions stored in the region's database.
+-- NULL keeps the tenant on the platform defaults.
+
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS region VARCHAR(64);
+
+COMMENT ON COLUMN tenants.region IS 'Data region the tenant''s credentials and executions are stored in; NULL means the platform defaults';
+
+-- Rollback instructions:
+-- ALTER TABLE tenants DROP COLUMN IF EXISTS region;
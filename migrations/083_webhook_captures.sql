@@ -0,0 +1,35 @@
+-- Webhook request capture
+-- A webhook can opt in to storing the raw requests it receives (method,
+-- headers, query, body and remote address) so a misbehaving delivery can be
+-- inspected and replayed. Captures are taken before signature verification
+-- and kept until they expire; secret header values are redacted.
+
+ALTER TABLE webhooks
+ADD COLUMN IF NOT EXISTS capture_config JSONB;
+
+COMMENT ON COLUMN webhooks.capture_config IS 'Request capture settings: enabled, ttlSeconds and redactHeaders; NULL disables capture';
+
+CREATE TABLE IF NOT EXISTS webhook_captures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    method VARCHAR(16) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    headers JSONB NOT NULL DEFAULT '{}',
+    body BYTEA NOT NULL DEFAULT '',
+    remote_addr VARCHAR(255) NOT NULL DEFAULT '',
+    captured_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_captures_webhook
+    ON webhook_captures(webhook_id, captured_at DESC);
+
+-- Supports the cleanup sweep of expired captures
+CREATE INDEX IF NOT EXISTS idx_webhook_captures_expires_at ON webhook_captures(expires_at);
+
+COMMENT ON TABLE webhook_captures IS 'Raw requests received by webhooks with capture enabled, kept until expires_at';
+
+-- Rollback instructions:
+-- DROP TABLE IF EXISTS webhook_captures;
+-- ALTER TABLE webhooks DROP COLUMN IF EXISTS capture_config;
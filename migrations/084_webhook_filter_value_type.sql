@@ -0,0 +1,13 @@
+-- Webhook filter value types
+-- The gt, gte, lt, lte and between operators compare numbers, and dates when
+-- both operands are ISO 8601 timestamps. value_type forces a comparison as
+-- number, date or string when a field's values are ambiguous.
+
+ALTER TABLE webhook_filters
+ADD COLUMN IF NOT EXISTS value_type VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (value_type IN ('', 'number', 'date', 'string'));
+
+COMMENT ON COLUMN webhook_filters.value_type IS 'How ordering operators compare values: number, date or string; empty infers from the operands';
+
+-- Rollback instructions:
+-- ALTER TABLE webhook_filters DROP COLUMN IF EXISTS value_type;
@@ -156,10 +156,15 @@ func TestMarketplace_FullWorkflow(t *testing.T) {
 		resp := ts.MakeRequest(t, http.MethodGet, "/api/v1/marketplace/templates/"+templateID+"/reviews", nil, headers)
 		AssertStatusCode(t, resp, http.StatusOK)
 
-		var reviews []map[string]interface{}
-		ParseJSONResponse(t, resp, &reviews)
+		var page struct {
+			Data  []map[string]interface{} `json:"data"`
+			Total int                      `json:"total"`
+		}
+		ParseJSONResponse(t, resp, &page)
+		reviews := page.Data
 
 		assert.Len(t, reviews, 1)
+		assert.Equal(t, 1, page.Total)
 		assert.Equal(t, float64(5), reviews[0]["rating"])
 		t.Logf("✓ Retrieved %d reviews", len(reviews))
 	})
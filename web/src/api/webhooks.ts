@@ -278,12 +278,16 @@ export type FilterOperator =
   | 'matches_any'
   | 'matches_all'
 
+/** How gt, gte, lt, lte and between compare values; inferred when unset */
+export type FilterValueType = 'number' | 'date' | 'string'
+
 export interface WebhookFilter {
   id: string
   webhookId: string
   fieldPath: string
   operator: FilterOperator
   value: unknown
+  valueType?: FilterValueType
   logicGroup: number
   enabled: boolean
   createdAt: string
@@ -299,6 +303,7 @@ export interface WebhookFilterCreateInput {
   fieldPath: string
   operator: FilterOperator
   value: unknown
+  valueType?: FilterValueType
   logicGroup?: number
   enabled?: boolean
 }
@@ -307,6 +312,7 @@ export interface WebhookFilterUpdateInput {
   fieldPath?: string
   operator?: FilterOperator
   value?: unknown
+  valueType?: FilterValueType
   logicGroup?: number
   enabled?: boolean
 }
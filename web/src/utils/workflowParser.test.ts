@@ -225,16 +225,17 @@ describe('workflowParser', () => {
       expect(result.warnings.some(w => w.includes('no trigger node'))).toBe(true)
     })
 
-    it('should warn when workflow has multiple triggers', () => {
+    it('should warn about triggers without outgoing connections', () => {
       const nodes = [
         { id: 'node-1', type: 'trigger', position: { x: 0, y: 0 }, data: { label: 'Trigger 1' } },
         { id: 'node-2', type: 'trigger', position: { x: 100, y: 0 }, data: { label: 'Trigger 2' } },
+        { id: 'node-3', type: 'action', position: { x: 200, y: 0 }, data: { label: 'Action' } },
       ]
-      const edges: never[] = []
+      const edges = [{ id: 'edge-1', source: 'node-1', target: 'node-3' }]
 
       const result = validateWorkflowStructure(nodes, edges)
 
-      expect(result.warnings.some(w => w.includes('2 trigger nodes'))).toBe(true)
+      expect(result.warnings).toEqual(["Trigger 'Trigger 2' has no outgoing connections."])
     })
 
     it('should warn about disconnected nodes', () => {
@@ -541,8 +541,14 @@ export function validateWorkflowStructure(nodes: Node[], edges: Edge[]): {
   const triggerNodes = nodes.filter((n) => n.type === 'trigger')
   if (triggerNodes.length === 0) {
     warnings.push('Workflow has no trigger node. Add a trigger to start the workflow.')
-  } else if (triggerNodes.length > 1) {
-    warnings.push(`Workflow has ${triggerNodes.length} trigger nodes. Consider using a single trigger.`)
+  }
+
+  // Each trigger starts the runs it fires, so it needs an outgoing connection
+  const sources = new Set(edges.map((edge) => edge.source))
+  for (const trigger of triggerNodes) {
+    if (!sources.has(trigger.id)) {
+      warnings.push(`Trigger '${trigger.data?.label || trigger.id}' has no outgoing connections.`)
+    }
   }
 
   // Check for disconnected nodes
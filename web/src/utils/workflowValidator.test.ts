@@ -67,7 +67,7 @@ describe('validateWorkflow - structure validation', () => {
     expect(triggerError?.severity).toBe('error')
   })
 
-  it('should warn about multiple triggers', () => {
+  it('should allow multiple connected triggers', () => {
     const nodes: Node[] = [
       createTriggerNode('trigger-1', 'webhook'),
       createTriggerNode('trigger-2', 'schedule'),
@@ -80,10 +80,23 @@ describe('validateWorkflow - structure validation', () => {
 
     const result = validateWorkflow(nodes, edges)
 
-    const multiTriggerWarning = result.issues.find((i) =>
-      i.message.includes('trigger nodes') && i.severity === 'warning'
-    )
-    expect(multiTriggerWarning).toBeDefined()
+    const triggerIssues = result.issues.filter((i) => i.message.toLowerCase().includes('trigger'))
+    expect(triggerIssues).toEqual([])
+  })
+
+  it('should reject a trigger without outgoing connections', () => {
+    const nodes: Node[] = [
+      createTriggerNode('trigger-1', 'webhook'),
+      createTriggerNode('trigger-2', 'schedule'),
+      createActionNode('action-1'),
+    ]
+    const edges: Edge[] = [createEdge('trigger-1', 'action-1')]
+
+    const result = validateWorkflow(nodes, edges)
+
+    expect(result.valid).toBe(false)
+    const deadTrigger = result.issues.find((i) => i.nodeId === 'trigger-2')
+    expect(deadTrigger?.severity).toBe('error')
   })
 
   it('should pass for valid simple workflow', () => {
@@ -67,15 +67,6 @@ function validateStructure(nodes: Node[], _edges: Edge[]): ValidationIssue[] {
     }))
   }
 
-  // Warn about multiple triggers
-  if (triggerNodes.length > 1) {
-    issues.push(createIssue({
-      severity: 'warning',
-      message: `Workflow has ${triggerNodes.length} trigger nodes`,
-      suggestion: 'Consider using a single trigger for clarity, or use parallel execution',
-    }))
-  }
-
   return issues
 }
 
@@ -316,9 +307,10 @@ function validateConnectivity(nodes: Node[], edges: Edge[]): ValidationIssue[] {
           suggestion: 'Triggers start workflows - remove incoming connections',
         }))
       }
+      // A run starts from the trigger that fired, so every trigger needs a path into the workflow
       if (!hasOutgoing) {
         issues.push(createIssue({
-          severity: 'warning',
+          severity: 'error',
           nodeId: node.id,
           message: 'Trigger is not connected to any other node',
           suggestion: 'Connect this trigger to an action or control node',